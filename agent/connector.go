@@ -3,15 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
-	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/events"
-	"github.com/docker/docker/api/types/filters"
-	dockerclient "github.com/docker/docker/client"
 	"github.com/shellhub-io/shellhub/agent/pkg/agentd"
 	"github.com/shellhub-io/shellhub/agent/pkg/connector"
 	"github.com/shellhub-io/shellhub/pkg/api/client"
@@ -20,17 +15,17 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-var _ connector.Connector = new(DockerConnector)
+var _ connector.Connector = new(ContainerConnector)
 
-// DockerConnector is a struct that represents a connector that uses Docker as the container runtime.
-type DockerConnector struct {
+// ContainerConnector is a struct that represents a connector that manages the containers of a container runtime.
+type ContainerConnector struct {
 	mu sync.Mutex
 	// server is the ShellHub address of the server that the agent will connect to.
 	server string
 	// tenant is the tenant ID of the namespace that the agent belongs to.
 	tenant string
-	// cli is the Docker client.
-	cli *dockerclient.Client
+	// runtime is the container runtime.
+	runtime connector.Runtime
 	// privateKeys is the path to the directory that contains the private keys for the containers.
 	privateKeys string
 	// Label is the label used to identify the containers managed by the ShellHub agent.
@@ -63,6 +58,19 @@ type ConfigConnector struct {
 
 	// Label is the label used to identify the containers managed by the ShellHub agent.
 	Label string `env:"CONNECTOR_LABEL,default="`
+
+	// Runtime is the container runtime the connector manages the containers of: docker, podman, containerd or
+	// kubernetes.
+	Runtime string `env:"CONNECTOR_RUNTIME,default=docker" validate:"oneof=docker podman containerd kubernetes"`
+
+	// RuntimeEndpoint is the address of the container runtime's API. When empty, the runtime's default is used: the
+	// Docker CLI's environment for docker, Podman's socket for podman, containerd's socket for containerd, and the
+	// pod's service account for kubernetes, whose endpoint is otherwise the path to a kubeconfig file.
+	RuntimeEndpoint string `env:"CONNECTOR_RUNTIME_ENDPOINT,default="`
+
+	// Namespace restricts the kubernetes runtime to the pods of a namespace. When empty, the pods of every namespace
+	// are watched.
+	Namespace string `env:"CONNECTOR_NAMESPACE,default="`
 }
 
 func LoadConfigConnectorFromEnv() (*ConfigConnector, map[string]interface{}, error) {
@@ -81,76 +89,38 @@ func LoadConfigConnectorFromEnv() (*ConfigConnector, map[string]interface{}, err
 	return cfg, nil, nil
 }
 
-func NewDockerConnectorWithClient(cli *dockerclient.Client, config *ConfigConnector) connector.Connector {
-	return &DockerConnector{
-		cli:         cli,
-		server:      config.ServerAddress,
-		tenant:      config.TenantID,
-		privateKeys: config.PrivateKeys,
-		Label:       config.Label,
-		cancels:     make(map[string]context.CancelFunc),
-	}
-}
-
-// NewDockerConnector creates a new [Connector] that uses Docker as the container runtime.
-func NewDockerConnector(config *ConfigConnector) (connector.Connector, error) {
-	cli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
-	if err != nil {
-		return nil, err
-	}
-
-	return &DockerConnector{
-		cli:         cli,
+// NewConnectorWithRuntime creates a new [Connector] that manages the containers of the given runtime.
+func NewConnectorWithRuntime(runtime connector.Runtime, config *ConfigConnector) connector.Connector {
+	return &ContainerConnector{
+		runtime:     runtime,
 		server:      config.ServerAddress,
 		tenant:      config.TenantID,
 		privateKeys: config.PrivateKeys,
 		Label:       config.Label,
 		cancels:     make(map[string]context.CancelFunc),
-	}, nil
-}
-
-// events returns the docker events.
-func (d *DockerConnector) events(ctx context.Context) (<-chan events.Message, <-chan error) {
-	filters := filters.NewArgs()
-	if d.Label != "" {
-		filters.Add("label", d.Label)
 	}
-
-	return d.cli.Events(ctx, events.ListOptions{
-		Filters: filters,
-	})
 }
 
-func (d *DockerConnector) List(ctx context.Context) ([]connector.Container, error) {
-	filters := filters.NewArgs()
-	if d.Label != "" {
-		filters.Add("label", d.Label)
-	}
-
-	containers, err := d.cli.ContainerList(ctx, container.ListOptions{
-		Filters: filters,
+// NewConnector creates a new [Connector] that manages the containers of the configured runtime.
+func NewConnector(config *ConfigConnector) (connector.Connector, error) {
+	runtime, err := connector.NewRuntime(&connector.RuntimeConfig{
+		Name:      config.Runtime,
+		Endpoint:  config.RuntimeEndpoint,
+		Namespace: config.Namespace,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	list := make([]connector.Container, len(containers))
-	for i, container := range containers {
-		list[i].ID = container.ID
-
-		name, err := d.getContainerNameFromID(ctx, container.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		list[i].Name = name
-	}
+	return NewConnectorWithRuntime(runtime, config), nil
+}
 
-	return list, nil
+func (d *ContainerConnector) List(ctx context.Context) ([]connector.Container, error) {
+	return d.runtime.List(ctx, d.Label)
 }
 
 // Start starts the agent for the container with the given ID.
-func (d *DockerConnector) Start(ctx context.Context, id string, name string) {
+func (d *ContainerConnector) Start(ctx context.Context, id string, name string) {
 	id = id[:12]
 
 	d.mu.Lock()
@@ -158,7 +128,7 @@ func (d *DockerConnector) Start(ctx context.Context, id string, name string) {
 	d.mu.Unlock()
 
 	privateKey := fmt.Sprintf("%s/%s.key", d.privateKeys, id)
-	go initContainerAgent(ctx, d.cli, connector.Container{
+	go initContainerAgent(ctx, d.runtime, connector.Container{
 		ID:            id,
 		Name:          name,
 		ServerAddress: d.server,
@@ -169,7 +139,7 @@ func (d *DockerConnector) Start(ctx context.Context, id string, name string) {
 }
 
 // Stop stops the agent for the container with the given ID.
-func (d *DockerConnector) Stop(_ context.Context, id string) {
+func (d *ContainerConnector) Stop(_ context.Context, id string) {
 	id = id[:12]
 
 	d.mu.Lock()
@@ -182,29 +152,8 @@ func (d *DockerConnector) Stop(_ context.Context, id string) {
 	}
 }
 
-func (d *DockerConnector) getContainerNameFromID(ctx context.Context, id string) (string, error) {
-	container, err := d.cli.ContainerInspect(ctx, id)
-	if err != nil {
-		return "", err
-	}
-
-	// NOTE: It removes the first character on container's name that is a `/`.
-	name := container.Name[1:]
-
-	// NOTE: Normalize the container name to comply with ShellHub's device naming conventions.
-	// While Docker allows characters like dots and hyphens in its naming pattern `[a-zA-Z0-9][a-zA-Z0-9_.-]`,
-	// ShellHub restricts names to letters, numbers, underscores, and hyphens, with a maximum length of 64 characters
-	// `([a-zA-Z0-9_-]){1,64}$`. This normalization is essential for compatibility.
-	name = strings.ReplaceAll(name, ".", "_")
-	if len(name) > 64 {
-		name = name[:64]
-	}
-
-	return name, nil
-}
-
 // Listen listens for events and starts or stops the agent for the containers.
-func (d *DockerConnector) Listen(ctx context.Context) error {
+func (d *ContainerConnector) Listen(ctx context.Context) error {
 	containers, err := d.List(ctx)
 	if err != nil {
 		return err
@@ -214,34 +163,34 @@ func (d *DockerConnector) Listen(ctx context.Context) error {
 		d.Start(ctx, container.ID, container.Name)
 	}
 
-	events, errs := d.events(ctx)
+	events, errs := d.runtime.Events(ctx, d.Label)
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case err := <-errs:
 			return err
-		case container := <-events:
-			// NOTE: "start" and "die" Docker's events are call every time a new container start or stop,
-			// independently how the command was run. For example, if a container was started with `docker run -d`, the
-			// "start" event will be called, but if the same container was started with `docker start <container-id>`,
-			// the "start" event will be called too. The same happens with the "die" event.
-			switch container.Action {
-			case "start":
-				name, err := d.getContainerNameFromID(ctx, container.Actor.ID)
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			switch event.Action {
+			case connector.EventStart:
+				name, err := d.runtime.Inspect(ctx, event.ID)
 				if err != nil {
 					return err
 				}
 
-				d.Start(ctx, container.Actor.ID, name)
-			case "die":
-				d.Stop(ctx, container.Actor.ID)
+				d.Start(ctx, event.ID, name)
+			case connector.EventStop:
+				d.Stop(ctx, event.ID)
 			}
 		}
 	}
 }
 
-func (d *DockerConnector) CheckUpdate() (*semver.Version, error) {
+func (d *ContainerConnector) CheckUpdate() (*semver.Version, error) {
 	api, err := client.NewClient(d.server)
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
@@ -264,7 +213,7 @@ func (d *DockerConnector) CheckUpdate() (*semver.Version, error) {
 }
 
 // initContainerAgent initializes the agent for a container.
-func initContainerAgent(ctx context.Context, cli *dockerclient.Client, container connector.Container) {
+func initContainerAgent(ctx context.Context, runtime connector.Runtime, container connector.Container) {
 	// TODO: Let this configuration build next to the Agent [agent.LoadConfigConnectorFromEnv] function.
 	cfg := &agentd.Config{
 		ServerAddress:             container.ServerAddress,
@@ -289,7 +238,7 @@ func initContainerAgent(ctx context.Context, cli *dockerclient.Client, container
 		"version":        cfg.Version,
 	}).Info("Connector container started")
 
-	mode, err := agentd.NewConnectorMode(cli, container.ID)
+	mode, err := agentd.NewConnectorMode(runtime, container.ID)
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"id":             container.ID,
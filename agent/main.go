@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -26,6 +27,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// maintenanceWindowCheckInterval is how often the agent checks again for an update that waits for the maintenance
+// window to be applied.
+const maintenanceWindowCheckInterval = 15 * time.Minute
+
 func main() {
 	// Default command.
 	rootCmd := &cobra.Command{ // nolint: exhaustruct
@@ -129,11 +134,35 @@ func main() {
 				"preferred_hostname": cfg.PreferredHostname,
 			}).Info("Listening for connections")
 
+			// NOTE: An agent that was just updated is healthy once it is authorized and connected to the server.
+			go func() {
+				if err := updater.VerifyUpdate(ctx, func() bool {
+					health := ag.Health()
+
+					return health.Auth.OK && health.Tunnel == agentd.TunnelConnected
+				}); err != nil {
+					log.WithError(err).WithFields(log.Fields{
+						"version": AgentVersion,
+					}).Error("Failed to verify the update")
+				}
+			}()
+
 			// Disable check update in development mode
 			if AgentVersion != "latest" {
 				go func() {
 					for {
-						nextVersion, err := ag.CheckUpdate()
+						interval := time.Hour * 24
+
+						nextVersion, window, err := ag.CheckUpdate()
+						if errors.Is(err, agentd.ErrUpdateHeld) {
+							log.WithFields(log.Fields{
+								"version":   AgentVersion,
+								"tenant_id": cfg.TenantID,
+							}).Info("Update held until the server's rollout selects the device")
+
+							goto sleep
+						}
+
 						if err != nil {
 							log.WithError(err).WithFields(log.Fields{
 								"version":            AgentVersion,
@@ -147,6 +176,19 @@ func main() {
 						}
 
 						if nextVersion.GreaterThan(currentVersion) {
+							if window != nil {
+								if open, err := window.Contains(clock.Now()); err == nil && !open {
+									log.WithFields(log.Fields{
+										"version":      AgentVersion,
+										"next_version": nextVersion.String(),
+									}).Info("Waiting for the maintenance window to apply the update")
+
+									interval = maintenanceWindowCheckInterval
+
+									goto sleep
+								}
+							}
+
 							if err := updater.ApplyUpdate(nextVersion); err != nil {
 								log.WithError(err).WithFields(log.Fields{
 									"version":            AgentVersion,
@@ -155,6 +197,8 @@ func main() {
 									"server_address":     cfg.ServerAddress,
 									"preferred_hostname": cfg.PreferredHostname,
 								}).Error("Failed to apply update")
+
+								goto sleep
 							}
 
 							log.WithFields(log.Fields{
@@ -174,9 +218,10 @@ func main() {
 							"tenant_id":          cfg.TenantID,
 							"server_address":     cfg.ServerAddress,
 							"preferred_hostname": cfg.PreferredHostname,
-						}).Info("Sleeping for 24 hours")
+							"interval":           interval,
+						}).Info("Sleeping until the next update check")
 
-						time.Sleep(time.Hour * 24)
+						time.Sleep(interval)
 					}
 				}()
 			}
@@ -237,6 +282,7 @@ func main() {
 					"address":      cfg.ServerAddress,
 					"tenant_id":    cfg.TenantID,
 					"private_keys": cfg.PrivateKeys,
+					"runtime":      cfg.Runtime,
 					"version":      AgentVersion,
 				},
 			)
@@ -246,7 +292,7 @@ func main() {
 			logger.Info("Starting ShellHub Agent Connector")
 
 			connector.ConnectorVersion = AgentVersion
-			connector, err := NewDockerConnector(cfg)
+			connector, err := NewConnector(cfg)
 			if err != nil {
 				logger.Fatal("Failed to create ShellHub Agent Connector")
 			}
@@ -286,6 +332,16 @@ func main() {
 				}()
 			}
 
+			// NOTE: The connector has no connection of its own to the server, so an updated one is taken as healthy
+			// once it starts listening to the containers.
+			go func() {
+				if err := updater.VerifyUpdate(cmd.Context(), func() bool { return true }); err != nil {
+					log.WithError(err).WithFields(log.Fields{
+						"version": AgentVersion,
+					}).Error("Failed to verify the update")
+				}
+			}()
+
 			if err := connector.Listen(cmd.Context()); err != nil {
 				logger.Fatal("Failed to listen for connections")
 			}
@@ -337,6 +393,39 @@ func main() {
 		},
 	})
 
+	rootCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
+		Use:   "doctor",
+		Short: "Check the agent's connection to the server",
+		Long: `Checks what the agent needs to connect to the server, with the same environment
+variables it starts with: the private key, the server's address, its DNS resolution,
+the connection and TLS certificate, the server's /info endpoint and the clock skew
+against the server. Each check prints a hint when it warns or fails, and the command
+exits with 1 when any check fails.`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			loglevel.SetLogLevel()
+
+			cfg, err := envs.ParseWithPrefix[agentd.Config]("SHELLHUB_")
+			if err != nil {
+				log.WithError(err).Fatal("Failed to load the configuration from the environmental variables")
+			}
+
+			cfg.Version = AgentVersion
+			cfg.Platform = AgentPlatform
+
+			diagnoses := agentd.Doctor(cmd.Context(), cfg)
+			for _, diagnosis := range diagnoses {
+				cmd.Printf("[%s] %s: %s\n", diagnosis.Result, diagnosis.Check, diagnosis.Message)
+				if diagnosis.Hint != "" {
+					cmd.Printf("       %s\n", diagnosis.Hint)
+				}
+			}
+
+			if agentd.Failed(diagnoses) {
+				os.Exit(1)
+			}
+		},
+	})
+
 	rootCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
 		Use:   "login",
 		Short: "Accept this device from your browser",
@@ -427,7 +516,16 @@ waits until the device is accepted, rejected, or the code expires.`,
 		Long: `Starts the SFTP server. This command is used internally by the agent and should not be used directly.
 It is initialized by the agent when a new SFTP session is created.`,
 		Run: func(_ *cobra.Command, args []string) {
-			NewSFTPServer(command.SFTPServerMode(args[0]))
+			if mode := command.SFTPServerMode(args[0]); mode == command.SFTPServerModeConnector {
+				home := ""
+				if len(args) > 1 {
+					home = args[1]
+				}
+
+				NewConnectorSFTPServer(home)
+			} else {
+				NewSFTPServer(mode)
+			}
 		},
 	})
 
@@ -67,6 +67,7 @@ import (
 	"github.com/shellhub-io/shellhub/agent/pkg/sysinfo"
 	"github.com/shellhub-io/shellhub/agent/pkg/tunnel"
 	"github.com/shellhub-io/shellhub/agent/server"
+	"github.com/shellhub-io/shellhub/agent/server/modes/host"
 	"github.com/shellhub-io/shellhub/pkg/api/client"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/envs"
@@ -153,6 +154,22 @@ type Config struct {
 	// embedding program (where /proc/self/exe is not the agent binary) must set this to point
 	// at a binary/subcommand that runs the SFTP server.
 	SFTPServerCommand func() *exec.Cmd
+
+	// Restrictions restricts what each login may do on the device in host mode: the commands it may run, a command
+	// forced on it, and whether it may have a pseudo-terminal, SFTP and port forwarding. It is a JSON object keyed by
+	// the logins; check [host.LoadRestrictions] for its format.
+	Restrictions string `env:"RESTRICTIONS"`
+
+	// RestrictionsFile is the path to a file with the restrictions, an alternative to [Config.Restrictions].
+	RestrictionsFile string `env:"RESTRICTIONS_FILE" validate:"excluded_with=Restrictions"`
+
+	// LoginRestrictions are the restrictions loaded from [Config.Restrictions] or [Config.RestrictionsFile]. Embedders
+	// may set them directly.
+	LoginRestrictions host.Restrictions
+
+	// HealthAddress is the address the agent serves its health on, as JSON on `/healthz` and as Prometheus' metrics on
+	// `/metrics`. It isn't served when empty. Check [Agent.Health] for what it reports.
+	HealthAddress string `env:"HEALTH_ADDRESS"`
 }
 
 func LoadConfigFromEnv() (*Config, map[string]interface{}, error) {
@@ -190,6 +207,12 @@ func LoadConfigFromEnv() (*Config, map[string]interface{}, error) {
 		return nil, fields, err
 	}
 
+	if cfg.LoginRestrictions, err = loadRestrictions(cfg); err != nil {
+		log.WithError(err).Error("failed to load the login restrictions")
+
+		return nil, nil, err
+	}
+
 	// Tenant resolution: environment > tenant persisted by a previous pairing.
 	if persisted, err := ReadPersistedTenant(TenantFilePath(cfg.PrivateKey)); err == nil && persisted != "" {
 		switch {
@@ -206,6 +229,25 @@ func LoadConfigFromEnv() (*Config, map[string]interface{}, error) {
 	return cfg, nil, nil
 }
 
+// loadRestrictions loads the login restrictions from the configuration's inline JSON or file, if any.
+func loadRestrictions(cfg *Config) (host.Restrictions, error) {
+	switch {
+	case cfg.Restrictions != "":
+		return host.LoadRestrictions(strings.NewReader(cfg.Restrictions))
+	case cfg.RestrictionsFile != "":
+		file, err := os.Open(cfg.RestrictionsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		defer file.Close()
+
+		return host.LoadRestrictions(file)
+	default:
+		return nil, nil
+	}
+}
+
 type Agent struct {
 	config     *Config
 	pubKey     *rsa.PublicKey
@@ -223,6 +265,8 @@ type Agent struct {
 	listener atomic.Pointer[net.Listener]
 	// logger is the agent's logger instance.
 	logger *log.Entry
+	// health tracks the agent's connection to the server.
+	health health
 }
 
 // NewAgent creates a new agent instance, requiring the ShellHub server's address to connect to, the namespace's tenant
@@ -509,6 +553,7 @@ func (a *Agent) authorize() error {
 	}
 
 	data, err := a.cli.AuthDevice(req)
+	a.health.authorized(data, err)
 	if err != nil {
 		return err
 	}
@@ -574,6 +619,7 @@ func (a *Agent) isClosed() bool {
 // Close closes the ShellHub Agent's listening, stoping it from receive new connection requests.
 func (a *Agent) Close() error {
 	a.closed.Store(true)
+	a.health.closed()
 
 	l := a.listener.Load()
 	if l == nil {
@@ -591,6 +637,10 @@ const (
 func (a *Agent) Listen(ctx context.Context) error {
 	a.mode.Serve(a)
 
+	if a.config.HealthAddress != "" {
+		go a.serveHealth(ctx, a.config.HealthAddress)
+	}
+
 	switch a.config.TransportVersion {
 	case TransportV1:
 		return a.listenV1(ctx)
@@ -634,7 +684,8 @@ func (a *Agent) listenV1(ctx context.Context) error {
 				ShellHubConnectV1Path,
 			)
 			if err != nil {
-				a.logger.Error("Failed to connect to server through reverse tunnel. Retry in 10 seconds")
+				a.health.failed(err)
+				a.logger.WithError(err).Error("Failed to connect to server through reverse tunnel. Retry in 10 seconds")
 
 				time.Sleep(time.Second * 10)
 
@@ -642,14 +693,18 @@ func (a *Agent) listenV1(ctx context.Context) error {
 			}
 			a.listener.Store(&listener)
 
+			a.health.connected()
 			a.logger.Info("Server connection established")
 
 			a.listening <- true
 
-			if err := tun.Listen(ctx, listener); err != nil {
+			err = tun.Listen(ctx, listener)
+			if err != nil {
 				a.logger.WithError(err).Error("Tunnel listener exited with error")
 			}
 
+			a.health.disconnected(err)
+
 			a.listening <- false
 		}
 	}()
@@ -693,7 +748,8 @@ func (a *Agent) listenV2(ctx context.Context) error {
 				client.NewReverseV2ConfigFromMap(a.authData.Config),
 			)
 			if err != nil {
-				a.logger.Error("Failed to connect to server through reverse tunnel. Retry in 10 seconds")
+				a.health.failed(err)
+				a.logger.WithError(err).Error("Failed to connect to server through reverse tunnel. Retry in 10 seconds")
 
 				time.Sleep(time.Second * 10)
 
@@ -701,14 +757,18 @@ func (a *Agent) listenV2(ctx context.Context) error {
 			}
 			a.listener.Store(&listener)
 
+			a.health.connected()
 			a.logger.Info("Server connection established")
 
 			a.listening <- true
 
-			if err := tun.Listen(ctx, listener); err != nil {
+			err = tun.Listen(ctx, listener)
+			if err != nil {
 				a.logger.WithError(err).Error("Tunnel listener exited with error")
 			}
 
+			a.health.disconnected(err)
+
 			a.listening <- false
 		}
 	}()
@@ -792,14 +852,36 @@ func (a *Agent) ping(ctx context.Context, interval time.Duration) error {
 	}
 }
 
-// CheckUpdate gets the ShellHub's server version.
-func (a *Agent) CheckUpdate() (*semver.Version, error) {
-	info, err := a.cli.GetInfo(a.config.Version)
+// ErrUpdateHeld is returned by [Agent.CheckUpdate] when the server's rollout doesn't select the device to update yet.
+var ErrUpdateHeld = errors.New("the server's rollout doesn't select the device to update yet")
+
+// CheckUpdate gets the version the ShellHub's server updates the agent to, and when the agent may apply it, nil for
+// any time. The version is the server's, unless the server has a rollout that restricts which devices update and
+// when; it returns [ErrUpdateHeld] when the rollout doesn't select the device yet.
+func (a *Agent) CheckUpdate() (*semver.Version, *models.PolicySchedule, error) {
+	uid := ""
+	if a.authData != nil {
+		uid = a.authData.UID
+	}
+
+	info, err := a.cli.GetInfoForDevice(a.config.Version, uid)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if info.Rollout == nil {
+		version, err := semver.NewVersion(info.Version)
+
+		return version, nil, err
+	}
+
+	if !info.Rollout.Selected {
+		return nil, nil, ErrUpdateHeld
 	}
 
-	return semver.NewVersion(info.Version)
+	version, err := semver.NewVersion(info.Rollout.Version)
+
+	return version, info.Rollout.Window, err
 }
 
 // GetInfo gets the ShellHub's server information like version and endpoints, and updates the Agent's server's info.
@@ -347,6 +347,74 @@ func TestAgent_GetInfo(t *testing.T) {
 	}
 }
 
+func TestAgent_CheckUpdate(t *testing.T) {
+	clientMocks := new(client_mocks.MockClient)
+
+	agent := &Agent{
+		cli:      clientMocks,
+		config:   &Config{Version: "v0.20.0"},
+		authData: &models.DeviceAuthResponse{UID: "uid"},
+	}
+
+	window := &models.PolicySchedule{Windows: []models.PolicyScheduleWindow{{Weekdays: []string{"sat"}, Start: "02:00", End: "05:00"}}}
+
+	type expected struct {
+		version string
+		window  *models.PolicySchedule
+		err     error
+	}
+
+	tests := []struct {
+		description   string
+		requiredMocks func()
+		expected      expected
+	}{
+		{
+			description: "updates to the server's version without a rollout",
+			requiredMocks: func() {
+				clientMocks.On("GetInfoForDevice", "v0.20.0", "uid").Return(&models.Info{Version: "v0.21.0"}, nil).Once()
+			},
+			expected: expected{version: "v0.21.0"},
+		},
+		{
+			description: "holds the update when the device isn't selected",
+			requiredMocks: func() {
+				clientMocks.On("GetInfoForDevice", "v0.20.0", "uid").Return(&models.Info{
+					Version: "v0.21.0",
+					Rollout: &models.AgentRollout{Version: "v0.21.0", Selected: false},
+				}, nil).Once()
+			},
+			expected: expected{err: ErrUpdateHeld},
+		},
+		{
+			description: "updates to the rollout's version inside its window",
+			requiredMocks: func() {
+				clientMocks.On("GetInfoForDevice", "v0.20.0", "uid").Return(&models.Info{
+					Version: "v0.22.0",
+					Rollout: &models.AgentRollout{Version: "v0.21.0", Selected: true, Window: window},
+				}, nil).Once()
+			},
+			expected: expected{version: "v0.21.0", window: window},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.requiredMocks()
+
+			version, window, err := agent.CheckUpdate()
+			assert.ErrorIs(t, err, test.expected.err)
+			assert.Equal(t, test.expected.window, window)
+
+			if test.expected.version != "" {
+				assert.Equal(t, test.expected.version, version.Original())
+			} else {
+				assert.Nil(t, version)
+			}
+		})
+	}
+}
+
 // TestAgent_generatePrivateKey_PathContainment verifies that the production
 // generatePrivateKey method rejects PrivateKey paths that contain raw ".."
 // traversal sequences.  The raw path is what an operator would supply via
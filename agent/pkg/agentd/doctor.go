@@ -0,0 +1,259 @@
+package agentd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shellhub-io/shellhub/agent/pkg/keygen"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Results of a [Diagnosis].
+const (
+	DiagnosisPassed  = "pass"
+	DiagnosisWarning = "warn"
+	DiagnosisFailed  = "fail"
+)
+
+// Thresholds the diagnosis warns or fails on.
+const (
+	// doctorTimeout is how long each network check waits for the server.
+	doctorTimeout = 10 * time.Second
+	// certificateExpiryWarning is how close to its expiry the server's certificate is warned about.
+	certificateExpiryWarning = 14 * 24 * time.Hour
+	// clockSkewWarning is how far off the server's clock the device's is warned about. The server's clock is only
+	// known to the second, so a smaller skew can't be told apart.
+	clockSkewWarning = 5 * time.Second
+	// clockSkewFailure is how far off the server's clock the device's fails the diagnosis, as the tokens the server
+	// issues the device may be taken as expired or not yet valid.
+	clockSkewFailure = time.Minute
+)
+
+// Diagnosis is the result of one of the checks [Doctor] runs.
+type Diagnosis struct {
+	// Check is what was checked.
+	Check string `json:"check"`
+	// Result is one of [DiagnosisPassed], [DiagnosisWarning] and [DiagnosisFailed].
+	Result string `json:"result"`
+	// Message describes what was found.
+	Message string `json:"message"`
+	// Hint tells what to do about a warning or a failure.
+	Hint string `json:"hint,omitempty"`
+}
+
+// Failed reports whether any of the diagnoses failed.
+func Failed(diagnoses []Diagnosis) bool {
+	for _, diagnosis := range diagnoses {
+		if diagnosis.Result == DiagnosisFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Doctor checks what the agent needs to connect to the ShellHub server: that the server's address resolves and is
+// reachable, that its TLS certificate is trusted, that it answers as a ShellHub server, that the device's clock agrees
+// with the server's and that the device's private key is valid.
+//
+// The checks that depend on a failed one aren't run.
+func Doctor(ctx context.Context, cfg *Config) []Diagnosis {
+	diagnoses := []Diagnosis{diagnosePrivateKey(cfg.PrivateKey)}
+
+	address, diagnosis := diagnoseAddress(cfg.ServerAddress)
+	if diagnoses = append(diagnoses, diagnosis); diagnosis.Result == DiagnosisFailed {
+		return diagnoses
+	}
+
+	diagnosis = diagnoseDNS(ctx, address.Hostname())
+	if diagnoses = append(diagnoses, diagnosis); diagnosis.Result == DiagnosisFailed {
+		return diagnoses
+	}
+
+	diagnosis = diagnoseConnection(ctx, address)
+	if diagnoses = append(diagnoses, diagnosis); diagnosis.Result == DiagnosisFailed {
+		return diagnoses
+	}
+
+	date, diagnosis := diagnoseInfo(ctx, address, cfg.Version)
+	if diagnoses = append(diagnoses, diagnosis); diagnosis.Result == DiagnosisFailed {
+		return diagnoses
+	}
+
+	return append(diagnoses, diagnoseClock(date))
+}
+
+func diagnosePrivateKey(path string) Diagnosis {
+	const check = "private key"
+
+	keyPath, err := cleanKeyPath(path)
+	if err != nil {
+		return Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s isn't a clean path", path), "Set SHELLHUB_PRIVATE_KEY to an absolute path without \"..\" or repeated slashes."}
+	}
+
+	if _, err := os.Stat(keyPath); os.IsNotExist(err) {
+		return Diagnosis{check, DiagnosisWarning, fmt.Sprintf("%s doesn't exist", keyPath), "The agent generates it when it starts, registering the device as a new one. Restore the previous key instead if the device was already registered."}
+	}
+
+	key, err := keygen.ReadPublicKey(keyPath)
+	if err != nil {
+		return Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s can't be read as an RSA private key: %s", keyPath, err), "Check the file's permissions, or remove it for the agent to generate a new one, registering the device as a new one."}
+	}
+
+	return Diagnosis{check, DiagnosisPassed, fmt.Sprintf("%s is a %d bits RSA key", keyPath, key.Size()*8), ""}
+}
+
+func diagnoseAddress(address string) (*url.URL, Diagnosis) {
+	const check = "address"
+
+	parsed, err := url.ParseRequestURI(address)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return nil, Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%q isn't an HTTP or HTTPS URL", address), "Set SHELLHUB_SERVER_ADDRESS to the server's URL, like https://cloud.shellhub.io."}
+	}
+
+	return parsed, Diagnosis{check, DiagnosisPassed, parsed.String(), ""}
+}
+
+func diagnoseDNS(ctx context.Context, host string) Diagnosis {
+	const check = "dns"
+
+	if net.ParseIP(host) != nil {
+		return Diagnosis{check, DiagnosisPassed, fmt.Sprintf("%s is an IP address", host), ""}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+
+	addresses, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s doesn't resolve: %s", host, err), "Check the device's DNS servers, in /etc/resolv.conf, and that the address is spelled right."}
+	}
+
+	return Diagnosis{check, DiagnosisPassed, fmt.Sprintf("%s resolves to %v", host, addresses), ""}
+}
+
+func diagnoseConnection(ctx context.Context, address *url.URL) Diagnosis {
+	const check = "connection"
+
+	host := hostPort(address)
+	dialer := &net.Dialer{Timeout: doctorTimeout}
+
+	if address.Scheme == "http" {
+		conn, err := dialer.DialContext(ctx, "tcp", host)
+		if err != nil {
+			return Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s is unreachable: %s", host, err), "Check that a firewall or proxy on the way allows outgoing connections to it."}
+		}
+
+		conn.Close()
+
+		return Diagnosis{check, DiagnosisWarning, fmt.Sprintf("%s is reachable, but without TLS", host), "Use an HTTPS address unless the server is on a trusted network."}
+	}
+
+	conn, err := (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: address.Hostname()}}).DialContext(ctx, "tcp", host) //nolint:gosec
+	if err != nil {
+		var unknown x509.UnknownAuthorityError
+		var invalid x509.CertificateInvalidError
+		var hostname x509.HostnameError
+
+		switch {
+		case errors.As(err, &unknown):
+			return Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s's certificate isn't trusted: %s", host, err), "Install the CA certificates, like the ca-certificates package, or add the server's CA to them."}
+		case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+			return Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s's certificate isn't valid now: %s", host, err), "Check the device's clock, then renew the server's certificate if it is right."}
+		case errors.As(err, &hostname):
+			return Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s's certificate isn't for this address: %s", host, err), "Use the address the server's certificate was issued for."}
+		default:
+			return Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s is unreachable over TLS: %s", host, err), "Check that a firewall or proxy on the way allows outgoing connections to it."}
+		}
+	}
+
+	defer conn.Close()
+
+	certificate := conn.(*tls.Conn).ConnectionState().PeerCertificates[0]
+	if left := certificate.NotAfter.Sub(clock.Now()); left < certificateExpiryWarning {
+		return Diagnosis{check, DiagnosisWarning, fmt.Sprintf("%s's certificate expires on %s", host, certificate.NotAfter.Format(time.RFC3339)), "Renew the server's certificate before it expires."}
+	}
+
+	return Diagnosis{check, DiagnosisPassed, fmt.Sprintf("%s is reachable over TLS, with a certificate valid until %s", host, certificate.NotAfter.Format(time.RFC3339)), ""}
+}
+
+// diagnoseInfo gets the server's information, returning when the server answered according to it.
+func diagnoseInfo(ctx context.Context, address *url.URL, version string) (time.Time, Diagnosis) {
+	const check = "info"
+
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+
+	endpoint := address.JoinPath("/info")
+	endpoint.RawQuery = url.Values{"agent_version": {version}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return time.Time{}, Diagnosis{check, DiagnosisFailed, err.Error(), ""}
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return time.Time{}, Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s is unreachable: %s", endpoint.Path, err), "Check that a proxy on the way allows requests to the server."}
+	}
+
+	defer res.Body.Close()
+
+	date, _ := http.ParseTime(res.Header.Get("Date"))
+
+	if res.StatusCode != http.StatusOK {
+		return date, Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s answered %s", endpoint.Path, res.Status), "Check that the address is the ShellHub server's, and not of a proxy in front of it."}
+	}
+
+	info := new(models.Info)
+	if err := json.NewDecoder(res.Body).Decode(info); err != nil || info.Endpoints.SSH == "" {
+		return date, Diagnosis{check, DiagnosisFailed, fmt.Sprintf("%s didn't answer as a ShellHub server", endpoint.Path), "Check that the address is the ShellHub server's, and not of a proxy in front of it."}
+	}
+
+	return date, Diagnosis{check, DiagnosisPassed, fmt.Sprintf("ShellHub %s, with the SSH endpoint at %s", info.Version, info.Endpoints.SSH), ""}
+}
+
+func diagnoseClock(date time.Time) Diagnosis {
+	const check = "clock"
+
+	if date.IsZero() {
+		return Diagnosis{check, DiagnosisWarning, "the server didn't tell its time", "Check the device's clock is synchronized, by NTP for example."}
+	}
+
+	skew := clock.Now().Sub(date).Truncate(time.Second)
+	if skew < 0 {
+		skew = -skew
+	}
+
+	switch {
+	case skew >= clockSkewFailure:
+		return Diagnosis{check, DiagnosisFailed, fmt.Sprintf("the device's clock is %s off the server's", skew), "Synchronize the device's clock, by NTP for example with `timedatectl set-ntp true`."}
+	case skew >= clockSkewWarning:
+		return Diagnosis{check, DiagnosisWarning, fmt.Sprintf("the device's clock is %s off the server's", skew), "Synchronize the device's clock, by NTP for example with `timedatectl set-ntp true`."}
+	default:
+		return Diagnosis{check, DiagnosisPassed, "the device's clock agrees with the server's", ""}
+	}
+}
+
+// hostPort returns the address' host and port, defaulting the port to the scheme's.
+func hostPort(address *url.URL) string {
+	if port := address.Port(); port != "" {
+		return address.Host
+	}
+
+	if address.Scheme == "https" {
+		return net.JoinHostPort(address.Hostname(), "443")
+	}
+
+	return net.JoinHostPort(address.Hostname(), "80")
+}
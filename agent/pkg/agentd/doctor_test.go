@@ -0,0 +1,108 @@
+package agentd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/agent/pkg/keygen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctor(t *testing.T) {
+	key := filepath.Join(t.TempDir(), "shellhub.key")
+	require.NoError(t, keygen.GeneratePrivateKey(key))
+
+	info := func(date time.Time) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/info" {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			w.Header().Set("Date", date.UTC().Format(http.TimeFormat))
+			w.Write([]byte(`{"version":"v0.20.0","endpoints":{"api":"localhost:80","ssh":"localhost:22"}}`)) //nolint:errcheck
+		}
+	}
+
+	results := func(diagnoses []Diagnosis) map[string]string {
+		results := map[string]string{}
+		for _, diagnosis := range diagnoses {
+			results[diagnosis.Check] = diagnosis.Result
+		}
+
+		return results
+	}
+
+	t.Run("passes against a reachable server", func(t *testing.T) {
+		server := httptest.NewServer(info(time.Now()))
+		defer server.Close()
+
+		diagnoses := Doctor(context.Background(), &Config{ServerAddress: server.URL, PrivateKey: key})
+
+		assert.Equal(t, map[string]string{
+			"private key": DiagnosisPassed,
+			"address":     DiagnosisPassed,
+			"dns":         DiagnosisPassed,
+			"connection":  DiagnosisWarning,
+			"info":        DiagnosisPassed,
+			"clock":       DiagnosisPassed,
+		}, results(diagnoses))
+		assert.False(t, Failed(diagnoses))
+	})
+
+	t.Run("fails on a skewed clock", func(t *testing.T) {
+		server := httptest.NewServer(info(time.Now().Add(-10 * time.Minute)))
+		defer server.Close()
+
+		diagnoses := Doctor(context.Background(), &Config{ServerAddress: server.URL, PrivateKey: key})
+
+		assert.Equal(t, DiagnosisFailed, results(diagnoses)["clock"])
+		assert.True(t, Failed(diagnoses))
+	})
+
+	t.Run("fails on an untrusted certificate", func(t *testing.T) {
+		server := httptest.NewTLSServer(info(time.Now()))
+		defer server.Close()
+
+		diagnoses := Doctor(context.Background(), &Config{ServerAddress: server.URL, PrivateKey: key})
+
+		last := diagnoses[len(diagnoses)-1]
+		assert.Equal(t, "connection", last.Check)
+		assert.Equal(t, DiagnosisFailed, last.Result)
+		assert.True(t, strings.Contains(last.Message, "isn't trusted"), last.Message)
+	})
+
+	t.Run("fails when the server isn't ShellHub", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		diagnoses := Doctor(context.Background(), &Config{ServerAddress: server.URL, PrivateKey: key})
+
+		last := diagnoses[len(diagnoses)-1]
+		assert.Equal(t, "info", last.Check)
+		assert.Equal(t, DiagnosisFailed, last.Result)
+	})
+
+	t.Run("fails on an invalid address", func(t *testing.T) {
+		diagnoses := Doctor(context.Background(), &Config{ServerAddress: "localhost", PrivateKey: key})
+
+		assert.Equal(t, map[string]string{"private key": DiagnosisPassed, "address": DiagnosisFailed}, results(diagnoses))
+	})
+
+	t.Run("checks the private key", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing.key")
+		assert.Equal(t, DiagnosisWarning, diagnosePrivateKey(missing).Result)
+
+		invalid := filepath.Join(t.TempDir(), "invalid.key")
+		require.NoError(t, os.WriteFile(invalid, []byte("key"), 0o600))
+		assert.Equal(t, DiagnosisFailed, diagnosePrivateKey(invalid).Result)
+	})
+}
@@ -9,7 +9,6 @@ import (
 	"net/netip"
 	"sync"
 
-	dockerclient "github.com/docker/docker/client"
 	"github.com/labstack/echo/v5"
 	"github.com/pkg/errors"
 	"github.com/shellhub-io/shellhub/agent/pkg/tunnel"
@@ -47,15 +46,8 @@ func httpProxyHandlerV2(agent *Agent) tunnel.HandlerFunc {
 			"port": port,
 		})
 
-		if _, ok := agent.mode.(*ConnectorMode); ok {
-			cli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
-			if err != nil {
-				log.WithError(err).Error("failed to create the Docker client")
-
-				return ctx.Error(errors.New("failed to connect to the Docker Engine"))
-			}
-
-			container, err := cli.ContainerInspect(context.Background(), agent.server.ContainerID)
+		if mode, ok := agent.mode.(*ConnectorMode); ok {
+			networks, err := mode.runtime.Networks(context.Background(), agent.server.ContainerID)
 			if err != nil {
 				log.WithError(err).Error("failed to inspect the container")
 
@@ -74,13 +66,13 @@ func httpProxyHandlerV2(agent *Agent) tunnel.HandlerFunc {
 			if addr.IsLoopback() {
 				log.Trace("host is a loopback address, using the container IP address")
 
-				for _, network := range container.NetworkSettings.Networks {
+				for _, network := range networks {
 					target = network.IPAddress
 
 					break
 				}
 			} else {
-				for _, network := range container.NetworkSettings.Networks {
+				for _, network := range networks {
 					subnet, err := netip.ParsePrefix(fmt.Sprintf("%s/%d", network.Gateway, network.IPPrefixLen))
 					if err != nil {
 						logger.WithError(err).Error("failed to parse the gateway on proxy")
@@ -253,13 +245,8 @@ func httpProxyHandlerV1(agent *Agent) func(c *echo.Context) error {
 			return errorResponse(err, "failed because address is invalid", http.StatusInternalServerError)
 		}
 
-		if _, ok := agent.mode.(*ConnectorMode); ok {
-			cli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
-			if err != nil {
-				return errorResponse(err, "failed to connect to the Docker Engine", http.StatusInternalServerError)
-			}
-
-			container, err := cli.ContainerInspect(context.Background(), agent.server.ContainerID)
+		if mode, ok := agent.mode.(*ConnectorMode); ok {
+			networks, err := mode.runtime.Networks(context.Background(), agent.server.ContainerID)
 			if err != nil {
 				return errorResponse(err, "failed to inspect the container", http.StatusInternalServerError)
 			}
@@ -272,13 +259,13 @@ func httpProxyHandlerV1(agent *Agent) func(c *echo.Context) error {
 			}
 
 			if addr.IsLoopback() {
-				for _, network := range container.NetworkSettings.Networks {
+				for _, network := range networks {
 					target = network.IPAddress
 
 					break
 				}
 			} else {
-				for _, network := range container.NetworkSettings.Networks {
+				for _, network := range networks {
 					subnet, err := netip.ParsePrefix(fmt.Sprintf("%s/%d", network.Gateway, network.IPPrefixLen))
 					if err != nil {
 						logger.WithError(err).Trace("Failed to parse the gateway on proxy")
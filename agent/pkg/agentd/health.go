@@ -0,0 +1,283 @@
+package agentd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// States of the agent's tunnel to the ShellHub server.
+const (
+	// TunnelConnecting is the state of a tunnel that was never established, or failed to be and is being retried.
+	TunnelConnecting = "connecting"
+	// TunnelConnected is the state of an established tunnel.
+	TunnelConnected = "connected"
+	// TunnelDisconnected is the state of a tunnel that was lost and is about to be established again.
+	TunnelDisconnected = "disconnected"
+	// TunnelClosed is the state of the tunnel after the agent was closed.
+	TunnelClosed = "closed"
+)
+
+// AuthHealth is the result of the last device authorization on the ShellHub server.
+type AuthHealth struct {
+	// OK reports whether the authorization succeeded.
+	OK bool `json:"ok"`
+	// Name is the device's name, as the server registered it.
+	Name string `json:"name,omitempty"`
+	// Namespace is the name of the namespace the device belongs to.
+	Namespace string `json:"namespace,omitempty"`
+	// Error is why the authorization failed.
+	Error string `json:"error,omitempty"`
+	// At is when the authorization was attempted.
+	At *time.Time `json:"at,omitempty"`
+}
+
+// Health is a snapshot of the agent's connection to the ShellHub server.
+type Health struct {
+	// Version is the agent's version.
+	Version string `json:"version"`
+	// TransportVersion is the version of the transport protocol the tunnel uses.
+	TransportVersion int `json:"transport_version"`
+	// Tunnel is the state of the tunnel, one of [TunnelConnecting], [TunnelConnected], [TunnelDisconnected] and
+	// [TunnelClosed].
+	Tunnel string `json:"tunnel"`
+	// ConnectedAt is when the tunnel was last established.
+	ConnectedAt *time.Time `json:"connected_at,omitempty"`
+	// Reconnects counts the times the tunnel was established again after being lost.
+	Reconnects uint64 `json:"reconnects"`
+	// LastError is the last error of the tunnel, either failing to be established or exiting.
+	LastError string `json:"last_error,omitempty"`
+	// LastErrorAt is when the last error happened.
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	// KeepAliveRTT is the round-trip time, in seconds, of the last keep alive the agent sent on an SSH session.
+	KeepAliveRTT float64 `json:"keepalive_rtt_seconds"`
+	// ActiveSessions counts the SSH connections the agent is serving.
+	ActiveSessions int64 `json:"active_sessions"`
+	// Auth is the result of the last device authorization.
+	Auth AuthHealth `json:"auth"`
+}
+
+// health tracks what the agent reports on its [Health].
+type health struct {
+	mu          sync.Mutex
+	tunnel      string
+	connectedAt *time.Time
+	connections uint64
+	lastError   string
+	lastErrorAt *time.Time
+	auth        AuthHealth
+}
+
+func (h *health) connected() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := clock.Now()
+
+	h.tunnel = TunnelConnected
+	h.connectedAt = &now
+	h.connections++
+}
+
+// disconnected records the tunnel as lost, with the error it exited with, if any.
+func (h *health) disconnected(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.tunnel != TunnelClosed {
+		h.tunnel = TunnelDisconnected
+	}
+
+	h.record(err)
+}
+
+// failed records the tunnel as failing to be established.
+func (h *health) failed(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.tunnel != TunnelClosed {
+		h.tunnel = TunnelConnecting
+	}
+
+	h.record(err)
+}
+
+func (h *health) closed() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.tunnel = TunnelClosed
+}
+
+func (h *health) record(err error) {
+	if err == nil {
+		return
+	}
+
+	now := clock.Now()
+
+	h.lastError = err.Error()
+	h.lastErrorAt = &now
+}
+
+func (h *health) authorized(data *models.DeviceAuthResponse, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := clock.Now()
+
+	h.auth = AuthHealth{At: &now}
+	if err != nil {
+		h.auth.Error = err.Error()
+
+		return
+	}
+
+	h.auth.OK = true
+	h.auth.Name = data.Name
+	h.auth.Namespace = data.Namespace
+}
+
+// Health returns a snapshot of the agent's connection to the ShellHub server.
+func (a *Agent) Health() *Health {
+	a.health.mu.Lock()
+	defer a.health.mu.Unlock()
+
+	report := &Health{
+		Version:          a.config.Version,
+		TransportVersion: a.config.TransportVersion,
+		Tunnel:           a.health.tunnel,
+		ConnectedAt:      a.health.connectedAt,
+		LastError:        a.health.lastError,
+		LastErrorAt:      a.health.lastErrorAt,
+		Auth:             a.health.auth,
+	}
+
+	if report.Tunnel == "" {
+		report.Tunnel = TunnelConnecting
+	}
+
+	if a.health.connections > 1 {
+		report.Reconnects = a.health.connections - 1
+	}
+
+	if a.server != nil {
+		report.KeepAliveRTT = a.server.KeepAliveRTT().Seconds()
+		report.ActiveSessions = a.server.ActiveSessions()
+	}
+
+	return report
+}
+
+// HealthHandler serves the agent's [Health]: as JSON on `/healthz`, answering with 503 when the tunnel isn't
+// connected, and in Prometheus' text format on `/metrics`.
+func (a *Agent) HealthHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		report := a.Health()
+
+		w.Header().Set("Content-Type", "application/json")
+		if report.Tunnel != TunnelConnected {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		json.NewEncoder(w).Encode(report) //nolint:errcheck
+	})
+
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		writeMetrics(w, a.Health())
+	})
+
+	return mux
+}
+
+// writeMetrics writes the health report in Prometheus' text exposition format.
+func writeMetrics(w io.Writer, report *Health) {
+	metric := func(name, kind, help string, value float64, labels ...string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+
+		if len(labels) > 0 {
+			pairs := make([]string, 0, len(labels)/2)
+			for i := 0; i+1 < len(labels); i += 2 {
+				pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[i], labelEscaper.Replace(labels[i+1])))
+			}
+
+			name += "{" + strings.Join(pairs, ",") + "}"
+		}
+
+		fmt.Fprintf(w, "%s %g\n", name, value)
+	}
+
+	boolean := func(value bool) float64 {
+		if value {
+			return 1
+		}
+
+		return 0
+	}
+
+	timestamp := func(at *time.Time) float64 {
+		if at == nil {
+			return 0
+		}
+
+		return float64(at.Unix())
+	}
+
+	metric("shellhub_agent_info", "gauge", "Version and transport version of the agent.", 1,
+		"version", report.Version, "transport_version", fmt.Sprint(report.TransportVersion))
+	metric("shellhub_agent_tunnel_connected", "gauge", "Whether the tunnel to the server is connected.",
+		boolean(report.Tunnel == TunnelConnected))
+	metric("shellhub_agent_tunnel_connected_timestamp_seconds", "gauge", "When the tunnel was last established.",
+		timestamp(report.ConnectedAt))
+	metric("shellhub_agent_tunnel_reconnects_total", "counter", "Times the tunnel was established again after being lost.",
+		float64(report.Reconnects))
+	metric("shellhub_agent_tunnel_last_error_timestamp_seconds", "gauge", "When the tunnel last failed.",
+		timestamp(report.LastErrorAt))
+	metric("shellhub_agent_keepalive_rtt_seconds", "gauge", "Round-trip time of the last keep alive sent on an SSH session.",
+		report.KeepAliveRTT)
+	metric("shellhub_agent_ssh_sessions", "gauge", "SSH connections the agent is serving.",
+		float64(report.ActiveSessions))
+	metric("shellhub_agent_auth_ok", "gauge", "Whether the last device authorization succeeded.",
+		boolean(report.Auth.OK))
+	metric("shellhub_agent_auth_timestamp_seconds", "gauge", "When the device was last authorized.",
+		timestamp(report.Auth.At))
+}
+
+// labelEscaper escapes what Prometheus' label values need escaped: backslashes, double quotes and line feeds.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// serveHealth serves the [Agent.HealthHandler] on the address until the context is done.
+func (a *Agent) serveHealth(ctx context.Context, address string) {
+	server := &http.Server{ //nolint:exhaustruct
+		Addr:              address,
+		Handler:           a.HealthHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		server.Close() //nolint:errcheck
+	}()
+
+	log.WithField("address", address).Info("Serving the agent's health")
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.WithError(err).WithField("address", address).Error("Failed to serve the agent's health")
+	}
+}
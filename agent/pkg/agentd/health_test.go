@@ -0,0 +1,107 @@
+package agentd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	agent := &Agent{config: &Config{Version: "v0.20.0", TransportVersion: TransportV2}}
+
+	t.Run("connecting before the first connection", func(t *testing.T) {
+		report := agent.Health()
+
+		assert.Equal(t, TunnelConnecting, report.Tunnel)
+		assert.Equal(t, uint64(0), report.Reconnects)
+		assert.False(t, report.Auth.OK)
+	})
+
+	agent.health.authorized(&models.DeviceAuthResponse{Name: "device", Namespace: "dev"}, nil)
+	agent.health.failed(errors.New("connection refused"))
+	agent.health.connected()
+
+	t.Run("connected after a failure", func(t *testing.T) {
+		report := agent.Health()
+
+		assert.Equal(t, TunnelConnected, report.Tunnel)
+		assert.Equal(t, uint64(0), report.Reconnects)
+		assert.Equal(t, "connection refused", report.LastError)
+		assert.NotNil(t, report.ConnectedAt)
+		assert.Equal(t, AuthHealth{OK: true, Name: "device", Namespace: "dev", At: report.Auth.At}, report.Auth)
+	})
+
+	agent.health.disconnected(errors.New("EOF"))
+
+	t.Run("disconnected", func(t *testing.T) {
+		report := agent.Health()
+
+		assert.Equal(t, TunnelDisconnected, report.Tunnel)
+		assert.Equal(t, "EOF", report.LastError)
+	})
+
+	agent.health.connected()
+	agent.health.authorized(nil, errors.New("unauthorized"))
+
+	t.Run("reconnected", func(t *testing.T) {
+		report := agent.Health()
+
+		assert.Equal(t, TunnelConnected, report.Tunnel)
+		assert.Equal(t, uint64(1), report.Reconnects)
+		assert.Equal(t, AuthHealth{Error: "unauthorized", At: report.Auth.At}, report.Auth)
+	})
+
+	agent.health.closed()
+	agent.health.disconnected(nil)
+
+	t.Run("closed", func(t *testing.T) {
+		assert.Equal(t, TunnelClosed, agent.Health().Tunnel)
+	})
+}
+
+func TestHealthHandler(t *testing.T) {
+	agent := &Agent{config: &Config{Version: `v0.20.0"`, TransportVersion: TransportV2}}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		agent.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return rec
+	}
+
+	t.Run("healthz is unavailable while not connected", func(t *testing.T) {
+		rec := serve("/healthz")
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	agent.health.connected()
+
+	t.Run("healthz reports the health", func(t *testing.T) {
+		rec := serve("/healthz")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		report := new(Health)
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(report))
+		assert.Equal(t, TunnelConnected, report.Tunnel)
+		assert.Equal(t, TransportV2, report.TransportVersion)
+	})
+
+	t.Run("metrics reports the health", func(t *testing.T) {
+		rec := serve("/metrics")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		body := rec.Body.String()
+		assert.Contains(t, body, "# TYPE shellhub_agent_tunnel_reconnects_total counter\nshellhub_agent_tunnel_reconnects_total 0\n")
+		assert.Contains(t, body, "shellhub_agent_tunnel_connected 1\n")
+		assert.Contains(t, body, `shellhub_agent_info{version="v0.20.0\"",transport_version="2"} 1`+"\n")
+		assert.Contains(t, body, "shellhub_agent_ssh_sessions 0\n")
+		assert.Contains(t, body, "shellhub_agent_auth_ok 0\n")
+	})
+}
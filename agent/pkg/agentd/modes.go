@@ -3,7 +3,7 @@ package agentd
 import (
 	"context"
 
-	dockerclient "github.com/docker/docker/client"
+	runtimes "github.com/shellhub-io/shellhub/agent/pkg/connector"
 	"github.com/shellhub-io/shellhub/agent/pkg/sysinfo"
 	"github.com/shellhub-io/shellhub/agent/server"
 	"github.com/shellhub-io/shellhub/agent/server/modes/connector"
@@ -41,12 +41,12 @@ func (m *HostMode) Serve(agent *Agent) {
 		agent.cli,
 		&host.Mode{
 			Authenticator: *host.NewAuthenticator(agent.cli, agent.authData, agent.config.SingleUserPassword, &agent.authData.Name),
-			Sessioner:     *host.NewSessioner(&agent.authData.Name, agent.config.SFTPServerCommand),
+			Sessioner:     *host.NewSessioner(&agent.authData.Name, agent.config.SFTPServerCommand, agent.config.LoginRestrictions),
 		},
 		&server.Config{
 			PrivateKey:        agent.config.PrivateKey,
 			KeepAliveInterval: agent.config.KeepAliveInterval,
-			Features:          server.LocalPortForwardFeature,
+			Features:          server.LocalPortForwardFeature | server.ReversePortForwardFeature,
 		},
 	)
 
@@ -71,13 +71,13 @@ func (m *HostMode) GetInfo() (*Info, error) {
 // responsible for the SSH server, but the authentication and authorization is made by either the conainer
 // internals, `passwd` or `shadow`, or by the ShellHub API.
 type ConnectorMode struct {
-	cli      *dockerclient.Client
+	runtime  runtimes.Runtime
 	identity string
 }
 
-func NewConnectorMode(cli *dockerclient.Client, identity string) (Mode, error) {
+func NewConnectorMode(runtime runtimes.Runtime, identity string) (Mode, error) {
 	return &ConnectorMode{
-		cli:      cli,
+		runtime:  runtime,
 		identity: identity,
 	}, nil
 }
@@ -92,8 +92,8 @@ func (m *ConnectorMode) Serve(agent *Agent) {
 	agent.server = server.NewServer(
 		agent.cli,
 		&connector.Mode{
-			Authenticator: *connector.NewAuthenticator(agent.cli, m.cli, agent.authData, &agent.Identity.MAC),
-			Sessioner:     *connector.NewSessioner(&agent.Identity.MAC, m.cli),
+			Authenticator: *connector.NewAuthenticator(agent.cli, m.runtime, agent.authData, &agent.Identity.MAC),
+			Sessioner:     *connector.NewSessioner(&agent.Identity.MAC, m.runtime),
 		},
 		&server.Config{
 			PrivateKey:        agent.config.PrivateKey,
@@ -107,13 +107,13 @@ func (m *ConnectorMode) Serve(agent *Agent) {
 }
 
 func (m *ConnectorMode) GetInfo() (*Info, error) {
-	info, err := m.cli.ContainerInspect(context.Background(), m.identity)
+	image, err := m.runtime.Image(context.Background(), m.identity)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Info{
-		ID:   "docker",
-		Name: info.Config.Image,
+		ID:   m.runtime.Name(),
+		Name: image,
 	}, nil
 }
@@ -0,0 +1,352 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+const (
+	// criEndpoint is the default CRI endpoint, containerd's socket.
+	criEndpoint = "unix:///run/containerd/containerd.sock"
+	// criPollInterval is how often containers are listed when the runtime doesn't stream container events.
+	criPollInterval = 5 * time.Second
+	// criExecSyncTimeout is the time, in seconds, a synchronous exec run by the connector itself may take.
+	criExecSyncTimeout = 10
+	// criPodNameLabel is the label Kubernetes sets on containers with the name of their pod.
+	criPodNameLabel = "io.kubernetes.pod.name"
+)
+
+var _ Runtime = (*CRIRuntime)(nil)
+
+// CRIRuntime is a [Runtime] that uses the Container Runtime Interface, the gRPC API containerd and CRI-O serve to
+// Kubernetes.
+//
+// The CRI has no way to copy files out of a container or to run a process as a user by name, so files are read by
+// running `cat` inside the container, and processes are run through `su` when the user isn't the one the container
+// runs as.
+type CRIRuntime struct {
+	conn   *grpc.ClientConn
+	client runtimeapi.RuntimeServiceClient
+}
+
+// NewCRIRuntime creates a [Runtime] connected to a CRI endpoint. When the endpoint is empty, containerd's socket is
+// used.
+func NewCRIRuntime(endpoint string) (*CRIRuntime, error) {
+	if endpoint == "" {
+		endpoint = criEndpoint
+	}
+
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CRIRuntime{
+		conn:   conn,
+		client: runtimeapi.NewRuntimeServiceClient(conn),
+	}, nil
+}
+
+func (c *CRIRuntime) Name() string {
+	return RuntimeContainerd
+}
+
+// hasLabel reports whether the labels have the label filter, "key" or "key=value". An empty filter matches any labels.
+func hasLabel(labels map[string]string, label string) bool {
+	if label == "" {
+		return true
+	}
+
+	key, value := splitLabel(label)
+	got, ok := labels[key]
+
+	return ok && (!strings.Contains(label, "=") || got == value)
+}
+
+// containerName names a container after its pod, when it belongs to one, as container names are only unique in it.
+func containerName(name string, labels map[string]string) string {
+	if pod, ok := labels[criPodNameLabel]; ok {
+		name = pod + "_" + name
+	}
+
+	return normalizeName(name)
+}
+
+func (c *CRIRuntime) List(ctx context.Context, label string) ([]Container, error) {
+	res, err := c.client.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+		Filter: &runtimeapi.ContainerFilter{
+			State: &runtimeapi.ContainerStateValue{State: runtimeapi.ContainerState_CONTAINER_RUNNING},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Container, 0, len(res.GetContainers()))
+	for _, container := range res.GetContainers() {
+		// NOTE: The CRI filters labels by their values only, so a label given by its key alone is filtered here.
+		if !hasLabel(container.GetLabels(), label) {
+			continue
+		}
+
+		list = append(list, Container{
+			ID:   container.GetId(),
+			Name: containerName(container.GetMetadata().GetName(), container.GetLabels()),
+		})
+	}
+
+	return list, nil
+}
+
+func (c *CRIRuntime) Events(ctx context.Context, label string) (<-chan Event, <-chan error) {
+	out := make(chan Event)
+	errs := make(chan error, 1)
+
+	send := func(event Event) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case out <- event:
+			return true
+		}
+	}
+
+	go func() {
+		defer close(out)
+
+		stream, err := c.client.GetContainerEvents(ctx, &runtimeapi.GetEventsRequest{})
+		if err == nil {
+			err = c.stream(ctx, stream, label, send)
+		}
+
+		if status.Code(err) == codes.Unimplemented {
+			err = c.poll(ctx, label, send)
+		}
+
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return out, errs
+}
+
+// stream sends the container events streamed by the runtime.
+func (c *CRIRuntime) stream(ctx context.Context, stream runtimeapi.RuntimeService_GetContainerEventsClient, label string, send func(Event) bool) error {
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		switch res.GetContainerEventType() {
+		case runtimeapi.ContainerEventType_CONTAINER_STARTED_EVENT:
+			container, err := c.status(ctx, res.GetContainerId())
+			if err != nil {
+				return err
+			}
+
+			if !hasLabel(container.GetLabels(), label) {
+				continue
+			}
+
+			event = Event{Action: EventStart, ID: res.GetContainerId()}
+		case runtimeapi.ContainerEventType_CONTAINER_STOPPED_EVENT, runtimeapi.ContainerEventType_CONTAINER_DELETED_EVENT:
+			// NOTE: Stopping a container the connector doesn't manage, or has already stopped, is a no-op, so its labels
+			// aren't checked, and a removal stops the container as well, in case its stop went unreported.
+			event = Event{Action: EventStop, ID: res.GetContainerId()}
+		default:
+			continue
+		}
+
+		if !send(event) {
+			return nil
+		}
+	}
+}
+
+// poll lists the running containers periodically, sending the start and stop of the containers between listings, for
+// runtimes that don't stream container events.
+func (c *CRIRuntime) poll(ctx context.Context, label string, send func(Event) bool) error {
+	running := make(map[string]bool)
+
+	containers, err := c.List(ctx, label)
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		running[container.ID] = true
+	}
+
+	ticker := time.NewTicker(criPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		containers, err := c.List(ctx, label)
+		if err != nil {
+			return err
+		}
+
+		listed := make(map[string]bool, len(containers))
+		for _, container := range containers {
+			listed[container.ID] = true
+
+			if !running[container.ID] && !send(Event{Action: EventStart, ID: container.ID}) {
+				return nil
+			}
+		}
+
+		for id := range running {
+			if !listed[id] && !send(Event{Action: EventStop, ID: id}) {
+				return nil
+			}
+		}
+
+		running = listed
+	}
+}
+
+func (c *CRIRuntime) status(ctx context.Context, id string) (*runtimeapi.ContainerStatus, error) {
+	res, err := c.client.ContainerStatus(ctx, &runtimeapi.ContainerStatusRequest{ContainerId: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetStatus(), nil
+}
+
+func (c *CRIRuntime) Inspect(ctx context.Context, id string) (string, error) {
+	container, err := c.status(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return containerName(container.GetMetadata().GetName(), container.GetLabels()), nil
+}
+
+func (c *CRIRuntime) Image(ctx context.Context, id string) (string, error) {
+	container, err := c.status(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	if image := container.GetImage().GetImage(); image != "" {
+		return image, nil
+	}
+
+	return container.GetImageRef(), nil
+}
+
+// Networks lists the addresses of the container's pod. The CRI doesn't report the pod network's gateway.
+func (c *CRIRuntime) Networks(ctx context.Context, id string) ([]Network, error) {
+	res, err := c.client.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+		Filter: &runtimeapi.ContainerFilter{Id: id},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.GetContainers()) == 0 {
+		return nil, status.Errorf(codes.NotFound, "container %s not found", id)
+	}
+
+	sandbox, err := c.client.PodSandboxStatus(ctx, &runtimeapi.PodSandboxStatusRequest{
+		PodSandboxId: res.GetContainers()[0].GetPodSandboxId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	network := sandbox.GetStatus().GetNetwork()
+	if network.GetIp() == "" {
+		return nil, nil
+	}
+
+	networks := []Network{{IPAddress: network.GetIp()}}
+	for _, ip := range network.GetAdditionalIps() {
+		networks = append(networks, Network{IPAddress: ip.GetIp()})
+	}
+
+	return networks, nil
+}
+
+// execSync runs a command inside the container and returns its stdout, failing when it exits with a non-zero code.
+func (c *CRIRuntime) execSync(ctx context.Context, id string, cmd ...string) ([]byte, error) {
+	res, err := c.client.ExecSync(ctx, &runtimeapi.ExecSyncRequest{
+		ContainerId: id,
+		Cmd:         cmd,
+		Timeout:     criExecSyncTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.GetExitCode() != 0 {
+		return nil, fmt.Errorf("%s exited with code %d: %s", cmd[0], res.GetExitCode(), bytes.TrimSpace(res.GetStderr()))
+	}
+
+	return res.GetStdout(), nil
+}
+
+func (c *CRIRuntime) ReadFile(ctx context.Context, id string, path string) (io.ReadCloser, error) {
+	data, err := c.execSync(ctx, id, "cat", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// command returns the command that runs the process as the configured user.
+func (c *CRIRuntime) command(ctx context.Context, id string, config *ExecConfig) []string {
+	out, _ := c.execSync(ctx, id, "id", "-u")
+
+	return asUser(config, parseUID(out))
+}
+
+func (c *CRIRuntime) Exec(ctx context.Context, id string, config *ExecConfig) (Process, error) {
+	res, err := c.client.Exec(ctx, &runtimeapi.ExecRequest{
+		ContainerId: id,
+		Cmd:         c.command(ctx, id, config),
+		Tty:         config.TTY,
+		Stdin:       true,
+		Stdout:      true,
+		Stderr:      !config.TTY,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	proc, err := dialStream(ctx, res.GetUrl(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.TTY {
+		if err := proc.Resize(config.Height, config.Width); err != nil {
+			proc.Close()
+
+			return nil, err
+		}
+	}
+
+	return proc, nil
+}
@@ -0,0 +1,277 @@
+package connector
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/moby/spdystream"
+)
+
+// The CRI streams an exec's stdin, output, exit status and terminal size over a SPDY connection, the protocol kubelet
+// speaks to it, as one stream each. The websocket protocol the runtimes also serve can't close stdin, which a process
+// reading it to the end, as a heredoc does, waits for.
+const (
+	// criStreamProtocol is the version of the streaming protocol, the first to report the exit status as JSON.
+	criStreamProtocol = "v4.channel.k8s.io"
+	// criStreamTimeout is how long the runtime may take to accept each stream.
+	criStreamTimeout = 30 * time.Second
+
+	criStreamType       = "streamType"
+	criStreamTypeError  = "error"
+	criStreamTypeStdin  = "stdin"
+	criStreamTypeStdout = "stdout"
+	criStreamTypeStderr = "stderr"
+	criStreamTypeResize = "resize"
+)
+
+// ErrStreamClosed is returned when a CRI exec's stream closes without reporting the process's exit status.
+var ErrStreamClosed = errors.New("exec stream closed without an exit status")
+
+// criStatus is the exit status the runtime writes on the error stream.
+type criStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	Details *struct {
+		Causes []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"causes"`
+	} `json:"details"`
+}
+
+// exitCode returns the process's exit code the status reports.
+func (s *criStatus) exitCode() (int, error) {
+	if s.Status == "Success" {
+		return 0, nil
+	}
+
+	if s.Reason == "NonZeroExitCode" && s.Details != nil {
+		for _, cause := range s.Details.Causes {
+			if cause.Reason == "ExitCode" {
+				return strconv.Atoi(cause.Message)
+			}
+		}
+	}
+
+	return -1, fmt.Errorf("exec failed: %s", s.Message)
+}
+
+// upgradeStream upgrades a connection to the exec's URL to SPDY.
+func upgradeStream(ctx context.Context, rawURL string) (net.Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var conn net.Conn
+	switch u.Scheme {
+	case "http":
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", u.Host)
+	case "https":
+		// NOTE: The runtime serves the streams with a self-signed certificate, and the URL, a single-use token, comes
+		// from the runtime itself over its socket.
+		dialer := tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}} //nolint:gosec
+		conn, err = dialer.DialContext(ctx, "tcp", u.Host)
+	default:
+		return nil, fmt.Errorf("unsupported exec stream scheme: %s", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "SPDY/3.1")
+	req.Header.Set("X-Stream-Protocol-Version", criStreamProtocol)
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		conn.Close()
+
+		return nil, fmt.Errorf("failed to upgrade the exec stream: %s", res.Status)
+	}
+
+	if res.Header.Get("X-Stream-Protocol-Version") != criStreamProtocol {
+		conn.Close()
+
+		return nil, fmt.Errorf("runtime doesn't support the %s streaming protocol", criStreamProtocol)
+	}
+
+	return conn, nil
+}
+
+// dialStream connects to the exec's streams and copies its output to the configured writers.
+func dialStream(ctx context.Context, rawURL string, config *ExecConfig) (*criProcess, error) {
+	conn, err := upgradeStream(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	spdy, err := spdystream.NewConnection(conn, false)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	go spdy.Serve(spdystream.NoOpStreamHandler)
+
+	p := &criProcess{
+		conn: spdy,
+		done: make(chan struct{}),
+	}
+
+	create := func(kind string) (*spdystream.Stream, error) {
+		stream, err := spdy.CreateStream(http.Header{criStreamType: {kind}}, nil, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := stream.WaitTimeout(criStreamTimeout); err != nil {
+			return nil, err
+		}
+
+		return stream, nil
+	}
+
+	kinds := []string{criStreamTypeError, criStreamTypeStdin, criStreamTypeStdout}
+	if config.TTY {
+		kinds = append(kinds, criStreamTypeResize)
+	} else {
+		kinds = append(kinds, criStreamTypeStderr)
+	}
+
+	streams := make(map[string]*spdystream.Stream, len(kinds))
+	for _, kind := range kinds {
+		if streams[kind], err = create(kind); err != nil {
+			spdy.Close()
+
+			return nil, err
+		}
+	}
+
+	p.stdin = streams[criStreamTypeStdin]
+	p.resize = streams[criStreamTypeResize]
+
+	var wg sync.WaitGroup
+	relay := func(w io.Writer, r io.Reader) {
+		defer wg.Done()
+
+		io.Copy(w, r) //nolint:errcheck
+	}
+
+	wg.Add(1)
+	go relay(config.Stdout, streams[criStreamTypeStdout])
+
+	if !config.TTY {
+		wg.Add(1)
+		go relay(config.Stderr, streams[criStreamTypeStderr])
+	}
+
+	go func() {
+		defer close(p.done)
+
+		status, err := io.ReadAll(streams[criStreamTypeError])
+		wg.Wait()
+
+		switch {
+		case err != nil:
+			p.code, p.err = -1, err
+		case len(status) == 0:
+			p.code, p.err = -1, ErrStreamClosed
+		default:
+			var s criStatus
+			if err := json.Unmarshal(status, &s); err != nil {
+				p.code, p.err = -1, err
+
+				return
+			}
+
+			p.code, p.err = s.exitCode()
+		}
+	}()
+
+	return p, nil
+}
+
+// criProcess is a [Process] started by a CRI exec.
+type criProcess struct {
+	conn   *spdystream.Connection
+	stdin  *spdystream.Stream
+	resize *spdystream.Stream
+	mu     sync.Mutex
+	// done is closed when the process has exited and its output is copied.
+	done chan struct{}
+	code int
+	err  error
+}
+
+func (p *criProcess) Write(b []byte) (int, error) {
+	return p.stdin.Write(b)
+}
+
+func (p *criProcess) CloseWrite() error {
+	return p.stdin.Close()
+}
+
+func (p *criProcess) Resize(height, width uint) error {
+	if p.resize == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return json.NewEncoder(p.resize).Encode(struct {
+		Width  uint16
+		Height uint16
+	}{
+		Width:  uint16(width),  //nolint:gosec
+		Height: uint16(height), //nolint:gosec
+	})
+}
+
+func (p *criProcess) Wait() (int, error) {
+	<-p.done
+
+	return p.code, p.err
+}
+
+func (p *criProcess) Close() error {
+	return p.conn.Close()
+}
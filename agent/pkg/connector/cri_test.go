@@ -0,0 +1,471 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/moby/spdystream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+const (
+	criWebID   = "4f2a9c1e5b7d8a6c3e1f0b9d2c4a6e8f1b3d5c7e9a0b2d4f6e8c0a1b3d5f7e9c"
+	criOtherID = "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1a0f9e8d"
+)
+
+// criExit is the status the runtime writes on the error stream when the process exits with code.
+func criExit(code int) string {
+	if code == 0 {
+		return `{"status": "Success"}`
+	}
+
+	return `{"status": "Failure", "reason": "NonZeroExitCode", "details": {"causes": [{"reason": "ExitCode", "message": "` + strconv.Itoa(code) + `"}]}}`
+}
+
+// fakeCRI is a CRI runtime service with the containers and container events it is given. Its execs run process on a
+// fake streaming server, which speaks the SPDY protocol the runtimes serve.
+type fakeCRI struct {
+	runtimeapi.UnimplementedRuntimeServiceServer
+
+	containers []*runtimeapi.Container
+	events     []*runtimeapi.ContainerEventResponse
+	// uid is what `id -u` prints inside the containers.
+	uid string
+	// process runs an exec against its streams, keyed by their type, and returns the status written on the error
+	// stream once its output is closed.
+	process func(req *runtimeapi.ExecRequest, streams map[string]*spdystream.Stream) string
+
+	streaming *httptest.Server
+
+	mu    sync.Mutex
+	execs []*runtimeapi.ExecRequest
+}
+
+// criRuntime serves the fake runtime on a unix socket, returning a [CRIRuntime] connected to it.
+func criRuntime(t *testing.T, fake *fakeCRI) *CRIRuntime {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /exec/{token}", fake.serveExec)
+
+	fake.streaming = httptest.NewServer(mux)
+	t.Cleanup(fake.streaming.Close)
+
+	socket := filepath.Join(t.TempDir(), "containerd.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	runtimeapi.RegisterRuntimeServiceServer(server, fake)
+
+	go server.Serve(listener) //nolint:errcheck
+
+	t.Cleanup(server.Stop)
+
+	runtime, err := NewCRIRuntime("unix://" + socket)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { runtime.conn.Close() })
+
+	return runtime
+}
+
+func (f *fakeCRI) container(id string) *runtimeapi.Container {
+	for _, container := range f.containers {
+		if container.GetId() == id {
+			return container
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeCRI) ListContainers(_ context.Context, req *runtimeapi.ListContainersRequest) (*runtimeapi.ListContainersResponse, error) {
+	filter := req.GetFilter()
+
+	var containers []*runtimeapi.Container
+	for _, container := range f.containers {
+		if filter.GetId() != "" && container.GetId() != filter.GetId() {
+			continue
+		}
+
+		if filter.GetState() != nil && container.GetState() != filter.GetState().GetState() {
+			continue
+		}
+
+		containers = append(containers, container)
+	}
+
+	return &runtimeapi.ListContainersResponse{Containers: containers}, nil
+}
+
+func (f *fakeCRI) ContainerStatus(_ context.Context, req *runtimeapi.ContainerStatusRequest) (*runtimeapi.ContainerStatusResponse, error) {
+	container := f.container(req.GetContainerId())
+	if container == nil {
+		return nil, status.Errorf(codes.NotFound, "container %s not found", req.GetContainerId())
+	}
+
+	return &runtimeapi.ContainerStatusResponse{
+		Status: &runtimeapi.ContainerStatus{
+			Id:       container.GetId(),
+			Metadata: container.GetMetadata(),
+			State:    container.GetState(),
+			Image:    container.GetImage(),
+			Labels:   container.GetLabels(),
+		},
+	}, nil
+}
+
+func (f *fakeCRI) GetContainerEvents(_ *runtimeapi.GetEventsRequest, stream grpc.ServerStreamingServer[runtimeapi.ContainerEventResponse]) error {
+	for _, event := range f.events {
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+
+	<-stream.Context().Done()
+
+	return nil
+}
+
+func (f *fakeCRI) ExecSync(_ context.Context, req *runtimeapi.ExecSyncRequest) (*runtimeapi.ExecSyncResponse, error) {
+	switch cmd := req.GetCmd(); cmd[0] {
+	case "id":
+		return &runtimeapi.ExecSyncResponse{Stdout: []byte(f.uid + "\n")}, nil
+	case "cat":
+		if cmd[1] == "/etc/passwd" {
+			return &runtimeapi.ExecSyncResponse{Stdout: []byte("root:x:0:0:root:/root:/bin/sh\n")}, nil
+		}
+
+		return &runtimeapi.ExecSyncResponse{ExitCode: 1, Stderr: []byte("cat: " + cmd[1] + ": No such file or directory\n")}, nil
+	default:
+		return &runtimeapi.ExecSyncResponse{ExitCode: 127}, nil
+	}
+}
+
+func (f *fakeCRI) Exec(_ context.Context, req *runtimeapi.ExecRequest) (*runtimeapi.ExecResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.execs = append(f.execs, req)
+
+	return &runtimeapi.ExecResponse{Url: f.streaming.URL + "/exec/" + strconv.Itoa(len(f.execs)-1)}, nil
+}
+
+// exec returns the request the exec with the token was created with.
+func (f *fakeCRI) exec(token string) *runtimeapi.ExecRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i >= len(f.execs) {
+		return nil
+	}
+
+	return f.execs[i]
+}
+
+// serveExec upgrades the connection to SPDY, as the runtimes' streaming server does, and runs the exec's process
+// once the client has opened its streams.
+func (f *fakeCRI) serveExec(w http.ResponseWriter, r *http.Request) {
+	req := f.exec(r.PathValue("token"))
+	if req == nil {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	if r.Header.Get("X-Stream-Protocol-Version") != criStreamProtocol {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return
+	}
+
+	defer conn.Close()
+
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" + //nolint:errcheck
+		"Connection: Upgrade\r\n" +
+		"Upgrade: SPDY/3.1\r\n" +
+		"X-Stream-Protocol-Version: " + criStreamProtocol + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		return
+	}
+
+	spdy, err := spdystream.NewConnection(conn, true)
+	if err != nil {
+		return
+	}
+
+	defer spdy.Close()
+
+	arrived := make(chan *spdystream.Stream, 4)
+	go spdy.Serve(func(stream *spdystream.Stream) {
+		stream.SendReply(http.Header{}, false) //nolint:errcheck
+		arrived <- stream
+	})
+
+	// The error, stdin and stdout streams, and the resize stream with a terminal or the stderr one without.
+	streams := make(map[string]*spdystream.Stream, 4)
+	for range 4 {
+		stream := <-arrived
+		streams[stream.Headers().Get(criStreamType)] = stream
+	}
+
+	status := f.process(req, streams)
+
+	streams[criStreamTypeStdout].Close()
+	if stderr, ok := streams[criStreamTypeStderr]; ok {
+		stderr.Close()
+	}
+
+	if status != "" {
+		streams[criStreamTypeError].Write([]byte(status)) //nolint:errcheck
+	}
+
+	streams[criStreamTypeError].Close()
+
+	<-spdy.CloseChan()
+}
+
+// syncBuffer is a [bytes.Buffer] safe to write while the test reads it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestCRIRuntime(t *testing.T) {
+	ctx := context.Background()
+
+	type size struct {
+		Width  uint16
+		Height uint16
+	}
+
+	var (
+		mu      sync.Mutex
+		resized []size
+	)
+
+	fake := &fakeCRI{
+		containers: []*runtimeapi.Container{
+			{
+				Id:       criWebID,
+				Metadata: &runtimeapi.ContainerMetadata{Name: "nginx"},
+				State:    runtimeapi.ContainerState_CONTAINER_RUNNING,
+				Image:    &runtimeapi.ImageSpec{Image: "docker.io/library/nginx:latest"},
+				Labels:   map[string]string{"shellhub": "true", criPodNameLabel: "web"},
+			},
+			{
+				Id:       criOtherID,
+				Metadata: &runtimeapi.ContainerMetadata{Name: "redis"},
+				State:    runtimeapi.ContainerState_CONTAINER_RUNNING,
+			},
+		},
+		events: []*runtimeapi.ContainerEventResponse{
+			{ContainerId: criOtherID, ContainerEventType: runtimeapi.ContainerEventType_CONTAINER_STARTED_EVENT},
+			{ContainerId: criWebID, ContainerEventType: runtimeapi.ContainerEventType_CONTAINER_CREATED_EVENT},
+			{ContainerId: criWebID, ContainerEventType: runtimeapi.ContainerEventType_CONTAINER_STARTED_EVENT},
+			{ContainerId: criWebID, ContainerEventType: runtimeapi.ContainerEventType_CONTAINER_STOPPED_EVENT},
+			{ContainerId: criWebID, ContainerEventType: runtimeapi.ContainerEventType_CONTAINER_DELETED_EVENT},
+		},
+		uid: "0",
+		process: func(req *runtimeapi.ExecRequest, streams map[string]*spdystream.Stream) string {
+			cmd := req.GetCmd()
+			if cmd[0] == "su" {
+				// The command su runs follows its own arguments.
+				cmd = cmd[6:]
+			}
+
+			switch cmd[0] {
+			case "/bin/sh":
+				// A shell on a terminal echoes what it's typed, until its input ends, and exits with the code it was
+				// last resized to the height of.
+				decoder := json.NewDecoder(streams[criStreamTypeResize])
+
+				var last size
+				for range 2 {
+					if err := decoder.Decode(&last); err != nil {
+						return ""
+					}
+
+					mu.Lock()
+					resized = append(resized, last)
+					mu.Unlock()
+				}
+
+				io.Copy(streams[criStreamTypeStdout], streams[criStreamTypeStdin]) //nolint:errcheck
+
+				return criExit(int(last.Height))
+			case "printf":
+				streams[criStreamTypeStdout].Write([]byte("out")) //nolint:errcheck
+				streams[criStreamTypeStderr].Write([]byte("err")) //nolint:errcheck
+
+				return criExit(0)
+			default:
+				// The runtime went away before the process exited.
+				return ""
+			}
+		},
+	}
+
+	runtime := criRuntime(t, fake)
+
+	t.Run("lists the labeled running containers", func(t *testing.T) {
+		containers, err := runtime.List(ctx, "shellhub")
+		require.NoError(t, err)
+		assert.Equal(t, []Container{{ID: criWebID, Name: "web_nginx"}}, containers)
+	})
+
+	t.Run("inspects the container", func(t *testing.T) {
+		name, err := runtime.Inspect(ctx, criWebID)
+		require.NoError(t, err)
+		assert.Equal(t, "web_nginx", name)
+
+		image, err := runtime.Image(ctx, criWebID)
+		require.NoError(t, err)
+		assert.Equal(t, "docker.io/library/nginx:latest", image)
+
+		_, err = runtime.Inspect(ctx, "missing")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("reads a file from the container", func(t *testing.T) {
+		file, err := runtime.ReadFile(ctx, criWebID, "/etc/passwd")
+		require.NoError(t, err)
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "root:x:0:0:root:/root:/bin/sh\n", string(data))
+
+		_, err = runtime.ReadFile(ctx, criWebID, "/etc/missing")
+		assert.EqualError(t, err, "cat exited with code 1: cat: /etc/missing: No such file or directory")
+	})
+
+	t.Run("runs a process on a terminal, resizing it, and reports its exit code", func(t *testing.T) {
+		var stdout syncBuffer
+
+		proc, err := runtime.Exec(ctx, criWebID, &ExecConfig{
+			User:   "root",
+			Shell:  "/bin/sh",
+			Cmd:    []string{"/bin/sh"},
+			TTY:    true,
+			Height: 24,
+			Width:  80,
+			Stdout: &stdout,
+		})
+		require.NoError(t, err)
+		defer proc.Close()
+
+		require.NoError(t, proc.Resize(42, 120))
+
+		_, err = proc.Write([]byte("echo hello\n"))
+		require.NoError(t, err)
+		require.NoError(t, proc.CloseWrite())
+
+		code, err := proc.Wait()
+		require.NoError(t, err)
+		assert.Equal(t, 42, code)
+		assert.Equal(t, "echo hello\n", stdout.String())
+
+		mu.Lock()
+		assert.Equal(t, []size{{Width: 80, Height: 24}, {Width: 120, Height: 42}}, resized)
+		mu.Unlock()
+
+		req := fake.exec("0")
+		assert.Equal(t, criWebID, req.GetContainerId())
+		assert.Equal(t, []string{"/bin/sh"}, req.GetCmd())
+		assert.True(t, req.GetTty())
+		assert.True(t, req.GetStdin())
+		assert.False(t, req.GetStderr())
+	})
+
+	t.Run("runs a process without a terminal as another user", func(t *testing.T) {
+		var stdout, stderr syncBuffer
+
+		proc, err := runtime.Exec(ctx, criWebID, &ExecConfig{
+			User:   "guest",
+			UID:    1000,
+			Shell:  "/bin/sh",
+			Cmd:    []string{"printf", "out"},
+			Stdout: &stdout,
+			Stderr: &stderr,
+		})
+		require.NoError(t, err)
+		defer proc.Close()
+
+		// Without a terminal there is nothing to resize.
+		require.NoError(t, proc.Resize(24, 80))
+
+		code, err := proc.Wait()
+		require.NoError(t, err)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "out", stdout.String())
+		assert.Equal(t, "err", stderr.String())
+
+		req := fake.exec("1")
+		assert.Equal(t, []string{"su", "-s", "/bin/sh", "guest", "-c", `exec "$0" "$@"`, "printf", "out"}, req.GetCmd())
+		assert.False(t, req.GetTty())
+		assert.True(t, req.GetStderr())
+	})
+
+	t.Run("fails when the streams close without an exit status", func(t *testing.T) {
+		proc, err := runtime.Exec(ctx, criWebID, &ExecConfig{
+			User:   "root",
+			Cmd:    []string{"sleep", "infinity"},
+			Stdout: io.Discard,
+			Stderr: io.Discard,
+		})
+		require.NoError(t, err)
+		defer proc.Close()
+
+		code, err := proc.Wait()
+		assert.ErrorIs(t, err, ErrStreamClosed)
+		assert.Equal(t, -1, code)
+	})
+
+	t.Run("streams the labeled containers' start, and their stop and removal", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		events, _ := runtime.Events(ctx, "shellhub")
+
+		assert.Equal(t, Event{Action: EventStart, ID: criWebID}, <-events)
+		assert.Equal(t, Event{Action: EventStop, ID: criWebID}, <-events)
+		assert.Equal(t, Event{Action: EventStop, ID: criWebID}, <-events)
+	})
+}
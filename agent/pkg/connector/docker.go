@@ -0,0 +1,281 @@
+package connector
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/process"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+var _ Runtime = (*DockerRuntime)(nil)
+
+// DockerRuntime is a [Runtime] that uses Docker's Engine API.
+type DockerRuntime struct {
+	cli dockerclient.APIClient
+}
+
+// NewDockerRuntime creates a [Runtime] connected to the Docker Engine. When the endpoint is empty, it is taken from the
+// environment, as the Docker CLI does.
+func NewDockerRuntime(endpoint string) (*DockerRuntime, error) {
+	opts := []dockerclient.Opt{dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation()}
+	if endpoint != "" {
+		opts = append(opts, dockerclient.WithHost(endpoint))
+	}
+
+	cli, err := dockerclient.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDockerRuntimeWithClient(cli), nil
+}
+
+// NewDockerRuntimeWithClient creates a [Runtime] that uses the given Docker client.
+func NewDockerRuntimeWithClient(cli dockerclient.APIClient) *DockerRuntime {
+	return &DockerRuntime{cli: cli}
+}
+
+func (d *DockerRuntime) Name() string {
+	return RuntimeDocker
+}
+
+func (d *DockerRuntime) filters(label string) filters.Args {
+	filters := filters.NewArgs()
+	if label != "" {
+		filters.Add("label", label)
+	}
+
+	return filters
+}
+
+func (d *DockerRuntime) List(ctx context.Context, label string) ([]Container, error) {
+	containers, err := d.cli.ContainerList(ctx, container.ListOptions{
+		Filters: d.filters(label),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Container, len(containers))
+	for i, container := range containers {
+		list[i].ID = container.ID
+
+		name, err := d.Inspect(ctx, container.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		list[i].Name = name
+	}
+
+	return list, nil
+}
+
+func (d *DockerRuntime) Events(ctx context.Context, label string) (<-chan Event, <-chan error) {
+	msgs, errs := d.cli.Events(ctx, events.ListOptions{
+		Filters: d.filters(label),
+	})
+
+	out := make(chan Event)
+	go func() {
+		defer close(out)
+
+		for {
+			var msg events.Message
+			select {
+			case <-ctx.Done():
+				return
+			case msg = <-msgs:
+			}
+
+			// NOTE: "start" and "die" Docker's events are call every time a new container start or stop,
+			// independently how the command was run. For example, if a container was started with `docker run -d`,
+			// the "start" event will be called, but if the same container was started with `docker start
+			// <container-id>`, the "start" event will be called too. The same happens with the "die" event.
+			var event Event
+			switch msg.Action {
+			case "start":
+				event = Event{Action: EventStart, ID: msg.Actor.ID}
+			case "die":
+				event = Event{Action: EventStop, ID: msg.Actor.ID}
+			default:
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- event:
+			}
+		}
+	}()
+
+	return out, errs
+}
+
+func (d *DockerRuntime) Inspect(ctx context.Context, id string) (string, error) {
+	container, err := d.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return normalizeName(container.Name), nil
+}
+
+func (d *DockerRuntime) Image(ctx context.Context, id string) (string, error) {
+	container, err := d.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return container.Config.Image, nil
+}
+
+func (d *DockerRuntime) Networks(ctx context.Context, id string) ([]Network, error) {
+	container, err := d.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := make([]Network, 0, len(container.NetworkSettings.Networks))
+	for _, network := range container.NetworkSettings.Networks {
+		networks = append(networks, Network{
+			IPAddress:   network.IPAddress,
+			Gateway:     network.Gateway,
+			IPPrefixLen: network.IPPrefixLen,
+		})
+	}
+
+	return networks, nil
+}
+
+func (d *DockerRuntime) ReadFile(ctx context.Context, id string, path string) (io.ReadCloser, error) {
+	archive, _, err := d.cli.CopyFromContainer(ctx, id, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return untar(archive)
+}
+
+func (d *DockerRuntime) Exec(ctx context.Context, id string, config *ExecConfig) (Process, error) {
+	size := &[2]uint{config.Height, config.Width}
+
+	exec, err := d.cli.ContainerExecCreate(ctx, id, container.ExecOptions{
+		User:         config.User,
+		Tty:          config.TTY,
+		ConsoleSize:  size,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          config.Cmd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.cli.ContainerExecAttach(ctx, exec.ID, container.ExecStartOptions{
+		Tty:         config.TTY,
+		ConsoleSize: size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dockerProcess{
+		cli:  d.cli,
+		id:   exec.ID,
+		resp: resp,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(p.done)
+
+		// NOTICE: According to the [Docker] documentation, we can "demultiplex" a command sent to container, but only
+		// when the exec started doesn't allocate a TTY. As a result, we check if the exec's is requesting it and do
+		// what was recommended by [Docker]'s to get the stdout and stderr separately.
+		//
+		// [Docker]: https://pkg.go.dev/github.com/docker/docker/client#Client.ContainerAttach
+		if config.TTY {
+			_, p.err = io.Copy(config.Stdout, resp.Reader)
+		} else {
+			_, p.err = stdcopy.StdCopy(config.Stdout, config.Stderr, resp.Reader)
+		}
+	}()
+
+	return p, nil
+}
+
+// dockerProcess is a [Process] started by Docker's exec.
+type dockerProcess struct {
+	cli  dockerclient.APIClient
+	id   string
+	resp types.HijackedResponse
+	// done is closed when the process's output is copied.
+	done chan struct{}
+	err  error
+}
+
+func (p *dockerProcess) Write(b []byte) (int, error) {
+	return p.resp.Conn.Write(b)
+}
+
+func (p *dockerProcess) CloseWrite() error {
+	return p.resp.CloseWrite()
+}
+
+func (p *dockerProcess) Resize(height, width uint) error {
+	return p.cli.ContainerExecResize(context.Background(), p.id, container.ResizeOptions{
+		Height: height,
+		Width:  width,
+	})
+}
+
+func (p *dockerProcess) Wait() (int, error) {
+	<-p.done
+
+	inspected, err := p.cli.ContainerExecInspect(context.Background(), p.id)
+	if err != nil {
+		return -1, err
+	}
+
+	if inspected.Running {
+		// NOTICE: when a process is running after the exec command, it is necessary to kill it.
+		return 0, process.Kill(inspected.Pid)
+	}
+
+	if p.err != nil && p.err != io.EOF {
+		return inspected.ExitCode, p.err
+	}
+
+	return inspected.ExitCode, nil
+}
+
+func (p *dockerProcess) Close() error {
+	p.resp.Close()
+
+	return nil
+}
+
+// untar returns a reader for the first file in the tar archive, closing the archive when it is closed.
+func untar(archive io.ReadCloser) (io.ReadCloser, error) {
+	reader := tar.NewReader(archive)
+	if _, err := reader.Next(); err != nil {
+		archive.Close()
+
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{reader, archive}, nil
+}
@@ -0,0 +1,550 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// ErrContainerNotFound is returned when the container isn't one of the pods' running containers the runtime knows.
+var ErrContainerNotFound = errors.New("container not found")
+
+// KubernetesTarget is a container in a pod.
+type KubernetesTarget struct {
+	Namespace string
+	Pod       string
+	Container string
+}
+
+// PodExecutor runs a command in a pod's container through the pods/exec subresource, streaming its stdin and output.
+type PodExecutor func(ctx context.Context, target KubernetesTarget, cmd []string, options remotecommand.StreamOptions) error
+
+var _ Runtime = (*KubernetesRuntime)(nil)
+
+// KubernetesRuntime is a [Runtime] that exposes the containers of the pods in a Kubernetes cluster, watched through
+// the Kubernetes API, and runs their sessions through the pods/exec subresource.
+//
+// A container's ID is derived from its pod's UID and its name, so it is stable while the pod lives, across restarts
+// of the container. Its name is the pod's namespace, the pod's name and its own name, joined by underscores, a
+// character Kubernetes names can't have.
+//
+// As with the CRI, the pods/exec subresource runs processes as the user the container runs as, so switching to
+// another one is done by `su`, and files are read by running `cat`.
+type KubernetesRuntime struct {
+	client    kubernetes.Interface
+	namespace string
+	exec      PodExecutor
+
+	mu sync.RWMutex
+	// targets maps the ID of every running container the runtime has seen to the container.
+	targets map[string]KubernetesTarget
+}
+
+// NewKubernetesRuntime creates a [Runtime] connected to the Kubernetes API. When the endpoint is empty, the agent must
+// run in the cluster, and connects with its pod's service account; otherwise, the endpoint is the path to a
+// kubeconfig file.
+func NewKubernetesRuntime(endpoint string, namespace string) (*KubernetesRuntime, error) {
+	var config *rest.Config
+	var err error
+	if endpoint == "" {
+		config, err = rest.InClusterConfig()
+	} else {
+		config, err = clientcmd.BuildConfigFromFlags("", endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKubernetesRuntimeWithClient(client, namespace, NewPodExecutor(config, client)), nil
+}
+
+// NewKubernetesRuntimeWithClient creates a [Runtime] that uses the given Kubernetes client and executor.
+func NewKubernetesRuntimeWithClient(client kubernetes.Interface, namespace string, exec PodExecutor) *KubernetesRuntime {
+	return &KubernetesRuntime{
+		client:    client,
+		namespace: namespace,
+		exec:      exec,
+		targets:   make(map[string]KubernetesTarget),
+	}
+}
+
+// NewPodExecutor creates a [PodExecutor] that streams over websockets, falling back to SPDY for API servers that
+// don't support them.
+func NewPodExecutor(config *rest.Config, client kubernetes.Interface) PodExecutor {
+	return func(ctx context.Context, target KubernetesTarget, cmd []string, options remotecommand.StreamOptions) error {
+		req := client.CoreV1().RESTClient().Post().
+			Resource("pods").
+			Namespace(target.Namespace).
+			Name(target.Pod).
+			SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Container: target.Container,
+				Command:   cmd,
+				Stdin:     options.Stdin != nil,
+				Stdout:    options.Stdout != nil,
+				Stderr:    options.Stderr != nil,
+				TTY:       options.Tty,
+			}, scheme.ParameterCodec)
+
+		websocket, err := remotecommand.NewWebSocketExecutor(config, "GET", req.URL().String())
+		if err != nil {
+			return err
+		}
+
+		spdy, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+		if err != nil {
+			return err
+		}
+
+		executor, err := remotecommand.NewFallbackExecutor(websocket, spdy, func(err error) bool {
+			return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+		})
+		if err != nil {
+			return err
+		}
+
+		return executor.StreamWithContext(ctx, options)
+	}
+}
+
+func (k *KubernetesRuntime) Name() string {
+	return RuntimeKubernetes
+}
+
+// podContainerID returns the ID of the pod's container.
+func podContainerID(uid types.UID, container string) string {
+	sum := sha256.Sum256([]byte(string(uid) + "/" + container))
+
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// running returns the pod's running containers by their IDs. A terminating pod has none, as its containers are
+// about to stop.
+func running(pod *corev1.Pod) map[string]KubernetesTarget {
+	targets := make(map[string]KubernetesTarget)
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return targets
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running == nil {
+			continue
+		}
+
+		targets[podContainerID(pod.UID, status.Name)] = KubernetesTarget{
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Container: status.Name,
+		}
+	}
+
+	return targets
+}
+
+// targetName names a container after its pod's namespace, its pod and itself.
+func targetName(target KubernetesTarget) string {
+	return normalizeName(strings.Join([]string{target.Namespace, target.Pod, target.Container}, "_"))
+}
+
+func (k *KubernetesRuntime) target(id string) (KubernetesTarget, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	target, ok := k.targets[id]
+	if !ok {
+		return KubernetesTarget{}, ErrContainerNotFound
+	}
+
+	return target, nil
+}
+
+func (k *KubernetesRuntime) pods(ctx context.Context, label string) (*corev1.PodList, error) {
+	return k.client.CoreV1().Pods(k.namespace).List(ctx, metav1.ListOptions{LabelSelector: label})
+}
+
+func (k *KubernetesRuntime) List(ctx context.Context, label string) ([]Container, error) {
+	pods, err := k.pods(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	list := make([]Container, 0)
+	for i := range pods.Items {
+		for id, target := range running(&pods.Items[i]) {
+			k.targets[id] = target
+
+			list = append(list, Container{ID: id, Name: targetName(target)})
+		}
+	}
+
+	return list, nil
+}
+
+// podTracker tracks the running containers of the watched pods, turning the changes of the pods into the start and
+// stop of their containers.
+type podTracker struct {
+	runtime *KubernetesRuntime
+	pods    map[types.UID]map[string]KubernetesTarget
+}
+
+// update sets the pod's running containers, returning the events of the containers that started and stopped since
+// the pod's last update.
+func (t *podTracker) update(uid types.UID, targets map[string]KubernetesTarget) []Event {
+	t.runtime.mu.Lock()
+	defer t.runtime.mu.Unlock()
+
+	events := make([]Event, 0)
+	for id, target := range targets {
+		if _, ok := t.pods[uid][id]; !ok {
+			t.runtime.targets[id] = target
+			events = append(events, Event{Action: EventStart, ID: id})
+		}
+	}
+
+	for id := range t.pods[uid] {
+		if _, ok := targets[id]; !ok {
+			delete(t.runtime.targets, id)
+			events = append(events, Event{Action: EventStop, ID: id})
+		}
+	}
+
+	if len(targets) == 0 {
+		delete(t.pods, uid)
+	} else {
+		t.pods[uid] = targets
+	}
+
+	return events
+}
+
+// sync updates every pod from a listing, returning the events of the containers that started and stopped since.
+func (t *podTracker) sync(pods *corev1.PodList) []Event {
+	listed := make(map[types.UID]bool, len(pods.Items))
+
+	events := make([]Event, 0)
+	for i := range pods.Items {
+		listed[pods.Items[i].UID] = true
+		events = append(events, t.update(pods.Items[i].UID, running(&pods.Items[i]))...)
+	}
+
+	for uid := range t.pods {
+		if !listed[uid] {
+			events = append(events, t.update(uid, nil)...)
+		}
+	}
+
+	return events
+}
+
+func (k *KubernetesRuntime) Events(ctx context.Context, label string) (<-chan Event, <-chan error) {
+	out := make(chan Event)
+	errs := make(chan error, 1)
+
+	send := func(events []Event) bool {
+		for _, event := range events {
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- event:
+			}
+		}
+
+		return true
+	}
+
+	go func() {
+		defer close(out)
+
+		tracker := &podTracker{runtime: k, pods: make(map[types.UID]map[string]KubernetesTarget)}
+
+		// NOTE: The containers running when the events start were listed already; only their changes are sent.
+		initial := true
+		for {
+			pods, err := k.pods(ctx, label)
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+
+				return
+			}
+
+			events := tracker.sync(pods)
+			if !initial && !send(events) {
+				return
+			}
+
+			initial = false
+
+			watcher, err := k.client.CoreV1().Pods(k.namespace).Watch(ctx, metav1.ListOptions{
+				LabelSelector:   label,
+				ResourceVersion: pods.ResourceVersion,
+			})
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+
+				return
+			}
+
+			if !k.watch(ctx, watcher, tracker, send) {
+				watcher.Stop()
+
+				return
+			}
+
+			// NOTE: The API server ends watches from time to time; the pods are listed again, so nothing that changed
+			// in between is missed, and watched from there.
+			watcher.Stop()
+		}
+	}()
+
+	return out, errs
+}
+
+// watch sends the changes of the watched pods' containers until the watch ends, returning false when the events must
+// stop.
+func (k *KubernetesRuntime) watch(ctx context.Context, watcher watch.Interface, tracker *podTracker, send func([]Event) bool) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return true
+			}
+
+			pod, ok := event.Object.(*corev1.Pod)
+			if !ok {
+				// NOTE: An error event, such as an expired resource version, ends the watch.
+				return true
+			}
+
+			targets := running(pod)
+			if event.Type == watch.Deleted {
+				targets = nil
+			}
+
+			if !send(tracker.update(pod.UID, targets)) {
+				return false
+			}
+		}
+	}
+}
+
+func (k *KubernetesRuntime) Inspect(_ context.Context, id string) (string, error) {
+	target, err := k.target(id)
+	if err != nil {
+		return "", err
+	}
+
+	return targetName(target), nil
+}
+
+func (k *KubernetesRuntime) pod(ctx context.Context, id string) (*corev1.Pod, KubernetesTarget, error) {
+	target, err := k.target(id)
+	if err != nil {
+		return nil, target, err
+	}
+
+	pod, err := k.client.CoreV1().Pods(target.Namespace).Get(ctx, target.Pod, metav1.GetOptions{})
+	if err != nil {
+		return nil, target, err
+	}
+
+	return pod, target, nil
+}
+
+func (k *KubernetesRuntime) Image(ctx context.Context, id string) (string, error) {
+	pod, target, err := k.pod(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name == target.Container {
+			return container.Image, nil
+		}
+	}
+
+	return "", ErrContainerNotFound
+}
+
+// Networks lists the pod's addresses. Kubernetes doesn't report the pod network's gateway.
+func (k *KubernetesRuntime) Networks(ctx context.Context, id string) ([]Network, error) {
+	pod, _, err := k.pod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := make([]Network, 0, len(pod.Status.PodIPs))
+	for _, ip := range pod.Status.PodIPs {
+		networks = append(networks, Network{IPAddress: ip.IP})
+	}
+
+	return networks, nil
+}
+
+// execSync runs a command in the container and returns its stdout, failing when it exits with a non-zero code.
+func (k *KubernetesRuntime) execSync(ctx context.Context, target KubernetesTarget, cmd ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	if err := k.exec(ctx, target, cmd, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", cmd[0], err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (k *KubernetesRuntime) ReadFile(ctx context.Context, id string, path string) (io.ReadCloser, error) {
+	target, err := k.target(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := k.execSync(ctx, target, "cat", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (k *KubernetesRuntime) Exec(ctx context.Context, id string, config *ExecConfig) (Process, error) {
+	target, err := k.target(id)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _ := k.execSync(ctx, target, "id", "-u")
+	cmd := asUser(config, parseUID(out))
+
+	stdin, writer := io.Pipe()
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &kubernetesProcess{
+		stdin:  writer,
+		sizes:  make(chan remotecommand.TerminalSize, 1),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	options := remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: config.Stdout,
+		Tty:    config.TTY,
+	}
+
+	if config.TTY {
+		p.sizes <- remotecommand.TerminalSize{Width: uint16(config.Width), Height: uint16(config.Height)} //nolint:gosec
+		options.TerminalSizeQueue = p
+	} else {
+		options.Stderr = config.Stderr
+	}
+
+	go func() {
+		defer close(p.done)
+
+		err := k.exec(ctx, target, cmd, options)
+
+		var exit exec.CodeExitError
+		switch {
+		case err == nil:
+			p.code = 0
+		case errors.As(err, &exit):
+			p.code = exit.Code
+		default:
+			p.code, p.err = -1, err
+		}
+	}()
+
+	return p, nil
+}
+
+// kubernetesProcess is a [Process] started through the pods/exec subresource.
+type kubernetesProcess struct {
+	stdin  *io.PipeWriter
+	sizes  chan remotecommand.TerminalSize
+	cancel context.CancelFunc
+	// done is closed when the process has exited and its output is copied.
+	done chan struct{}
+	code int
+	err  error
+}
+
+var _ remotecommand.TerminalSizeQueue = (*kubernetesProcess)(nil)
+
+// Next returns the terminal's next size, or nil when the process has exited.
+func (p *kubernetesProcess) Next() *remotecommand.TerminalSize {
+	select {
+	case <-p.done:
+		return nil
+	case size := <-p.sizes:
+		return &size
+	}
+}
+
+func (p *kubernetesProcess) Write(b []byte) (int, error) {
+	return p.stdin.Write(b)
+}
+
+func (p *kubernetesProcess) CloseWrite() error {
+	return p.stdin.Close()
+}
+
+func (p *kubernetesProcess) Resize(height, width uint) error {
+	size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)} //nolint:gosec
+
+	// NOTE: Only the latest size matters, so a size not yet sent is replaced.
+	for {
+		select {
+		case <-p.done:
+			return nil
+		case p.sizes <- size:
+			return nil
+		default:
+			select {
+			case <-p.sizes:
+			default:
+			}
+		}
+	}
+}
+
+func (p *kubernetesProcess) Wait() (int, error) {
+	<-p.done
+
+	return p.code, p.err
+}
+
+func (p *kubernetesProcess) Close() error {
+	p.cancel()
+
+	return p.stdin.CloseWithError(context.Canceled)
+}
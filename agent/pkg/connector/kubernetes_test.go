@@ -0,0 +1,166 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// pod returns a running pod with a running container.
+func pod(uid types.UID, name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uid,
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"shellhub": "true"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.27"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:  corev1.PodRunning,
+			PodIPs: []corev1.PodIP{{IP: "10.244.0.7"}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "nginx", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	}
+}
+
+func TestKubernetesRuntime(t *testing.T) {
+	ctx := context.Background()
+
+	id := podContainerID("6f1c2d3e", "nginx")
+
+	var commands [][]string
+	executor := func(_ context.Context, target KubernetesTarget, cmd []string, options remotecommand.StreamOptions) error {
+		assert.Equal(t, KubernetesTarget{Namespace: "default", Pod: "web", Container: "nginx"}, target)
+
+		commands = append(commands, cmd)
+
+		switch cmd[0] {
+		case "id":
+			options.Stdout.Write([]byte("0\n")) //nolint:errcheck
+		case "cat":
+			options.Stdout.Write([]byte("root:x:0:0:root:/root:/bin/sh\n")) //nolint:errcheck
+		default:
+			io.Copy(options.Stdout, options.Stdin) //nolint:errcheck
+
+			return exec.CodeExitError{Err: assert.AnError, Code: 3}
+		}
+
+		return nil
+	}
+
+	unlabeled := pod("9a8b7c6d", "db")
+	unlabeled.Labels = nil
+
+	client := fake.NewClientset(pod("6f1c2d3e", "web"), unlabeled)
+	runtime := NewKubernetesRuntimeWithClient(client, "", executor)
+
+	t.Run("lists the labeled pods' running containers", func(t *testing.T) {
+		containers, err := runtime.List(ctx, "shellhub")
+		require.NoError(t, err)
+		assert.Equal(t, []Container{{ID: id, Name: "default_web_nginx"}}, containers)
+	})
+
+	t.Run("inspects the container", func(t *testing.T) {
+		name, err := runtime.Inspect(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "default_web_nginx", name)
+
+		image, err := runtime.Image(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "nginx:1.27", image)
+
+		networks, err := runtime.Networks(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, []Network{{IPAddress: "10.244.0.7"}}, networks)
+
+		_, err = runtime.Inspect(ctx, "missing")
+		assert.ErrorIs(t, err, ErrContainerNotFound)
+	})
+
+	t.Run("reads a file from the container", func(t *testing.T) {
+		file, err := runtime.ReadFile(ctx, id, "/etc/passwd")
+		require.NoError(t, err)
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "root:x:0:0:root:/root:/bin/sh\n", string(data))
+	})
+
+	t.Run("runs a process as another user", func(t *testing.T) {
+		commands = nil
+
+		var stdout bytes.Buffer
+		proc, err := runtime.Exec(ctx, id, &ExecConfig{
+			User:   "nginx",
+			UID:    101,
+			Shell:  "/bin/sh",
+			Cmd:    []string{"/bin/sh"},
+			Stdout: &stdout,
+			Stderr: io.Discard,
+		})
+		require.NoError(t, err)
+
+		_, err = proc.Write([]byte("exit 3\n"))
+		require.NoError(t, err)
+		require.NoError(t, proc.CloseWrite())
+
+		code, err := proc.Wait()
+		require.NoError(t, err)
+		assert.Equal(t, 3, code)
+		assert.Equal(t, "exit 3\n", stdout.String())
+
+		assert.Equal(t, [][]string{
+			{"id", "-u"},
+			{"su", "-s", "/bin/sh", "nginx", "-c", `exec "$0" "$@"`, "/bin/sh"},
+		}, commands)
+	})
+}
+
+func TestKubernetesRuntimeEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := watch.NewFake()
+
+	client := fake.NewClientset()
+	client.PrependWatchReactor("pods", k8stesting.DefaultWatchReactor(watcher, nil))
+
+	runtime := NewKubernetesRuntimeWithClient(client, "default", nil)
+	id := podContainerID("6f1c2d3e", "nginx")
+
+	events, _ := runtime.Events(ctx, "shellhub")
+
+	web := pod("6f1c2d3e", "web")
+	watcher.Add(web)
+	assert.Equal(t, Event{Action: EventStart, ID: id}, <-events)
+
+	name, err := runtime.Inspect(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "default_web_nginx", name)
+
+	terminating := web.DeepCopy()
+	terminating.DeletionTimestamp = &metav1.Time{}
+	watcher.Modify(terminating)
+	assert.Equal(t, Event{Action: EventStop, ID: id}, <-events)
+
+	_, err = runtime.Inspect(ctx, id)
+	assert.ErrorIs(t, err, ErrContainerNotFound)
+}
@@ -0,0 +1,492 @@
+package connector
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/docker/docker/pkg/process"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// podmanAPI is the prefix of the libpod API's endpoints.
+const podmanAPI = "/v4.0.0/libpod"
+
+var _ Runtime = (*PodmanRuntime)(nil)
+
+// PodmanRuntime is a [Runtime] that uses Podman's libpod REST API, served on its unix socket by `podman system
+// service`.
+type PodmanRuntime struct {
+	socket string
+	http   *http.Client
+}
+
+// podmanSocket returns the default Podman socket: the system's one when running as root, and the user's one
+// otherwise.
+func podmanSocket() string {
+	if os.Geteuid() != 0 {
+		if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+			return filepath.Join(dir, "podman", "podman.sock")
+		}
+	}
+
+	return "/run/podman/podman.sock"
+}
+
+// NewPodmanRuntime creates a [Runtime] connected to Podman's socket. The endpoint is a `unix://` address; when it is
+// empty, the default socket is used.
+func NewPodmanRuntime(endpoint string) (*PodmanRuntime, error) {
+	socket := podmanSocket()
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		if u.Scheme != "unix" {
+			return nil, fmt.Errorf("podman endpoint must be a unix socket: %s", endpoint)
+		}
+
+		socket = u.Path
+	}
+
+	p := &PodmanRuntime{socket: socket}
+	p.http = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return p.dial(ctx)
+			},
+		},
+	}
+
+	return p, nil
+}
+
+func (p *PodmanRuntime) Name() string {
+	return RuntimePodman
+}
+
+func (p *PodmanRuntime) dial(ctx context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+
+	return dialer.DialContext(ctx, "unix", p.socket)
+}
+
+// podmanError is the body of the libpod API's error responses.
+type podmanError struct {
+	Cause   string `json:"cause"`
+	Message string `json:"message"`
+}
+
+// request builds a request to the libpod API. The body, when not nil, is encoded as JSON.
+func (p *PodmanRuntime) request(ctx context.Context, method string, path string, query url.Values, body any) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	u := url.URL{Scheme: "http", Host: "d", Path: podmanAPI + path, RawQuery: query.Encode()}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// check turns an error response from the libpod API into an error, closing its body.
+func (p *PodmanRuntime) check(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 || res.StatusCode == http.StatusSwitchingProtocols {
+		return nil
+	}
+
+	defer res.Body.Close()
+
+	var perr podmanError
+	if err := json.NewDecoder(res.Body).Decode(&perr); err != nil || perr.Message == "" {
+		return fmt.Errorf("podman: %s", res.Status)
+	}
+
+	return fmt.Errorf("podman: %s", perr.Message)
+}
+
+// do sends a request to the libpod API and decodes its JSON response into out, when it is not nil.
+func (p *PodmanRuntime) do(ctx context.Context, method string, path string, query url.Values, body any, out any) error {
+	req, err := p.request(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+
+	res, err := p.http.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if err := p.check(res); err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if out == nil {
+		_, err := io.Copy(io.Discard, res.Body)
+
+		return err
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// filters encodes the libpod API's filters query parameter.
+func (p *PodmanRuntime) filters(filters map[string][]string) url.Values {
+	data, _ := json.Marshal(filters)
+
+	return url.Values{"filters": {string(data)}}
+}
+
+func (p *PodmanRuntime) List(ctx context.Context, label string) ([]Container, error) {
+	filters := map[string][]string{}
+	if label != "" {
+		filters["label"] = []string{label}
+	}
+
+	var containers []struct {
+		ID    string   `json:"Id"`
+		Names []string `json:"Names"`
+	}
+
+	if err := p.do(ctx, http.MethodGet, "/containers/json", p.filters(filters), nil, &containers); err != nil {
+		return nil, err
+	}
+
+	list := make([]Container, len(containers))
+	for i, container := range containers {
+		list[i].ID = container.ID
+
+		if len(container.Names) > 0 {
+			list[i].Name = normalizeName(container.Names[0])
+		}
+	}
+
+	return list, nil
+}
+
+func (p *PodmanRuntime) Events(ctx context.Context, label string) (<-chan Event, <-chan error) {
+	out := make(chan Event)
+	errs := make(chan error, 1)
+
+	filters := map[string][]string{
+		"type":  {"container"},
+		"event": {"start", "died"},
+	}
+	if label != "" {
+		filters["label"] = []string{label}
+	}
+
+	query := p.filters(filters)
+	query.Set("stream", "true")
+
+	go func() {
+		defer close(out)
+
+		req, err := p.request(ctx, http.MethodGet, "/events", query, nil)
+		if err != nil {
+			errs <- err
+
+			return
+		}
+
+		res, err := p.http.Do(req)
+		if err != nil {
+			errs <- err
+
+			return
+		}
+
+		if err := p.check(res); err != nil {
+			errs <- err
+
+			return
+		}
+
+		defer res.Body.Close()
+
+		decoder := json.NewDecoder(res.Body)
+		for {
+			var msg struct {
+				Action string `json:"Action"`
+				Actor  struct {
+					ID string `json:"ID"`
+				} `json:"Actor"`
+			}
+
+			if err := decoder.Decode(&msg); err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+
+				return
+			}
+
+			// NOTE: Podman sends "died" when the container's process exits, whether it was stopped, killed or has
+			// exited on its own, as Docker does with "die".
+			var event Event
+			switch msg.Action {
+			case "start":
+				event = Event{Action: EventStart, ID: msg.Actor.ID}
+			case "died":
+				event = Event{Action: EventStop, ID: msg.Actor.ID}
+			default:
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- event:
+			}
+		}
+	}()
+
+	return out, errs
+}
+
+// podmanContainer is the part of libpod's container inspection the runtime uses.
+type podmanContainer struct {
+	Name      string `json:"Name"`
+	ImageName string `json:"ImageName"`
+
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress   string `json:"IPAddress"`
+			Gateway     string `json:"Gateway"`
+			IPPrefixLen int    `json:"IPPrefixLen"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+func (p *PodmanRuntime) inspect(ctx context.Context, id string) (*podmanContainer, error) {
+	container := new(podmanContainer)
+	if err := p.do(ctx, http.MethodGet, "/containers/"+url.PathEscape(id)+"/json", nil, nil, container); err != nil {
+		return nil, err
+	}
+
+	return container, nil
+}
+
+func (p *PodmanRuntime) Inspect(ctx context.Context, id string) (string, error) {
+	container, err := p.inspect(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return normalizeName(container.Name), nil
+}
+
+func (p *PodmanRuntime) Image(ctx context.Context, id string) (string, error) {
+	container, err := p.inspect(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return container.ImageName, nil
+}
+
+func (p *PodmanRuntime) Networks(ctx context.Context, id string) ([]Network, error) {
+	container, err := p.inspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := make([]Network, 0, len(container.NetworkSettings.Networks))
+	for _, network := range container.NetworkSettings.Networks {
+		networks = append(networks, Network{
+			IPAddress:   network.IPAddress,
+			Gateway:     network.Gateway,
+			IPPrefixLen: network.IPPrefixLen,
+		})
+	}
+
+	return networks, nil
+}
+
+func (p *PodmanRuntime) ReadFile(ctx context.Context, id string, path string) (io.ReadCloser, error) {
+	req, err := p.request(ctx, http.MethodGet, "/containers/"+url.PathEscape(id)+"/archive", url.Values{"path": {path}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.check(res); err != nil {
+		return nil, err
+	}
+
+	return untar(res.Body)
+}
+
+func (p *PodmanRuntime) Exec(ctx context.Context, id string, config *ExecConfig) (Process, error) {
+	var exec struct {
+		ID string `json:"Id"`
+	}
+
+	if err := p.do(ctx, http.MethodPost, "/containers/"+url.PathEscape(id)+"/exec", nil, map[string]any{
+		"AttachStdin":  true,
+		"AttachStdout": true,
+		"AttachStderr": true,
+		"Tty":          config.TTY,
+		"User":         config.User,
+		"Cmd":          config.Cmd,
+	}, &exec); err != nil {
+		return nil, err
+	}
+
+	req, err := p.request(ctx, http.MethodPost, "/exec/"+exec.ID+"/start", nil, map[string]any{
+		"Detach": false,
+		"Tty":    config.TTY,
+		"h":      config.Height,
+		"w":      config.Width,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: Starting an attached exec hijacks the connection, which carries the process's stdin and output from then
+	// on, so the request is written on a connection of our own instead of the client's pool.
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if err := p.check(res); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	proc := &podmanProcess{
+		runtime: p,
+		id:      exec.ID,
+		conn:    conn,
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(proc.done)
+
+		// NOTE: libpod multiplexes stdout and stderr on the stream as Docker does when no TTY is allocated.
+		if config.TTY {
+			_, proc.err = io.Copy(config.Stdout, reader)
+		} else {
+			_, proc.err = stdcopy.StdCopy(config.Stdout, config.Stderr, reader)
+		}
+	}()
+
+	return proc, nil
+}
+
+// podmanProcess is a [Process] started by libpod's exec.
+type podmanProcess struct {
+	runtime *PodmanRuntime
+	id      string
+	conn    net.Conn
+	once    sync.Once
+	// done is closed when the process's output is copied.
+	done chan struct{}
+	err  error
+}
+
+func (p *podmanProcess) Write(b []byte) (int, error) {
+	return p.conn.Write(b)
+}
+
+func (p *podmanProcess) CloseWrite() error {
+	if conn, ok := p.conn.(interface{ CloseWrite() error }); ok {
+		return conn.CloseWrite()
+	}
+
+	return nil
+}
+
+func (p *podmanProcess) Resize(height, width uint) error {
+	return p.runtime.do(context.Background(), http.MethodPost, "/exec/"+p.id+"/resize", url.Values{
+		"h": {strconv.FormatUint(uint64(height), 10)},
+		"w": {strconv.FormatUint(uint64(width), 10)},
+	}, nil, nil)
+}
+
+func (p *podmanProcess) Wait() (int, error) {
+	<-p.done
+
+	var inspected struct {
+		ExitCode int  `json:"ExitCode"`
+		Running  bool `json:"Running"`
+		Pid      int  `json:"Pid"`
+	}
+
+	if err := p.runtime.do(context.Background(), http.MethodGet, "/exec/"+p.id+"/json", nil, nil, &inspected); err != nil {
+		return -1, err
+	}
+
+	if inspected.Running {
+		// NOTICE: when a process is running after the exec command, it is necessary to kill it.
+		return 0, process.Kill(inspected.Pid)
+	}
+
+	if p.err != nil && !errors.Is(p.err, io.EOF) && !errors.Is(p.err, net.ErrClosed) {
+		return inspected.ExitCode, p.err
+	}
+
+	return inspected.ExitCode, nil
+}
+
+func (p *podmanProcess) Close() error {
+	var err error
+	p.once.Do(func() {
+		err = p.conn.Close()
+	})
+
+	return err
+}
@@ -0,0 +1,172 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Runtimes supported by the connector.
+const (
+	RuntimeDocker     = "docker"
+	RuntimePodman     = "podman"
+	RuntimeContainerd = "containerd"
+	RuntimeKubernetes = "kubernetes"
+)
+
+// ErrRuntimeUnsupported is returned when the connector is configured with a runtime it doesn't know.
+var ErrRuntimeUnsupported = errors.New("unsupported container runtime")
+
+// EventAction is what happened to a container in a runtime's event.
+type EventAction string
+
+const (
+	// EventStart is sent when a container starts running.
+	EventStart EventAction = "start"
+	// EventStop is sent when a container stops running.
+	EventStop EventAction = "stop"
+)
+
+// Event is a container lifecycle event sent by the runtime.
+type Event struct {
+	// Action is what happened to the container.
+	Action EventAction
+	// ID is the container ID.
+	ID string
+}
+
+// Network is a network a container is attached to.
+type Network struct {
+	// IPAddress is the container's address on the network.
+	IPAddress string
+	// Gateway is the network's gateway. It may be empty when the runtime doesn't report it.
+	Gateway string
+	// IPPrefixLen is the length of the network's prefix.
+	IPPrefixLen int
+}
+
+// ExecConfig describes a process to run inside a container.
+type ExecConfig struct {
+	// User is the user the process runs as.
+	User string
+	// UID is the user's ID, used by runtimes that can't run a process as a user by name.
+	UID uint32
+	// Shell is the user's shell, used by runtimes that need a shell to switch the user.
+	Shell string
+	// Cmd is the command to run.
+	Cmd []string
+	// TTY allocates a pseudo-terminal to the process.
+	TTY bool
+	// Height and Width are the pseudo-terminal's initial size.
+	Height, Width uint
+	// Stdout and Stderr receive the process's output. When TTY is set, Stderr is unused, as the terminal merges it
+	// into Stdout.
+	Stdout, Stderr io.Writer
+}
+
+// Process is a process running inside a container.
+type Process interface {
+	// Write writes to the process's stdin.
+	io.Writer
+	// CloseWrite closes the process's stdin.
+	CloseWrite() error
+	// Resize resizes the process's pseudo-terminal.
+	Resize(height, width uint) error
+	// Wait waits for the process's output to be copied and returns its exit code.
+	Wait() (int, error)
+	// Close releases the process's streams, killing it when it's still running.
+	Close() error
+}
+
+// Runtime is a container runtime the connector discovers containers on and runs their sessions in.
+type Runtime interface {
+	// Name returns the runtime's name.
+	Name() string
+	// List lists the running containers that have the label, or every running container when the label is empty.
+	List(ctx context.Context, label string) ([]Container, error)
+	// Events streams the start and stop of the containers that have the label.
+	Events(ctx context.Context, label string) (<-chan Event, <-chan error)
+	// Inspect returns the container's name.
+	Inspect(ctx context.Context, id string) (string, error)
+	// Image returns the image the container runs.
+	Image(ctx context.Context, id string) (string, error)
+	// Networks lists the networks the container is attached to.
+	Networks(ctx context.Context, id string) ([]Network, error)
+	// ReadFile reads a file from the container's filesystem.
+	ReadFile(ctx context.Context, id string, path string) (io.ReadCloser, error)
+	// Exec starts a process inside the container.
+	Exec(ctx context.Context, id string, config *ExecConfig) (Process, error)
+}
+
+// RuntimeConfig configures the runtime the connector manages the containers of.
+type RuntimeConfig struct {
+	// Name is the runtime's name.
+	Name string
+	// Endpoint is the address of the runtime's API. When empty, the runtime's default one is used.
+	Endpoint string
+	// Namespace restricts the Kubernetes runtime to the pods of a namespace. When empty, every namespace is watched.
+	Namespace string
+}
+
+// NewRuntime creates the configured runtime.
+func NewRuntime(config *RuntimeConfig) (Runtime, error) {
+	switch config.Name {
+	case "", RuntimeDocker:
+		return NewDockerRuntime(config.Endpoint)
+	case RuntimePodman:
+		return NewPodmanRuntime(config.Endpoint)
+	case RuntimeContainerd:
+		return NewCRIRuntime(config.Endpoint)
+	case RuntimeKubernetes:
+		return NewKubernetesRuntime(config.Endpoint, config.Namespace)
+	default:
+		return nil, ErrRuntimeUnsupported
+	}
+}
+
+// asUser returns the command that runs the process as the configured user, for the runtimes that can only run a
+// process as the user the container runs as, whose ID is uid. Switching to another user is done by `su`, which the
+// container must have.
+func asUser(config *ExecConfig, uid uint32) []string {
+	if uid == config.UID {
+		return config.Cmd
+	}
+
+	// NOTE: `su` passes the arguments after the command to the shell, which turns them back into the command.
+	return append([]string{"su", "-s", config.Shell, config.User, "-c", `exec "$0" "$@"`}, config.Cmd...)
+}
+
+// parseUID parses the output of `id -u`, falling back to root when it can't, as containers run as root by default.
+func parseUID(out []byte) uint32 {
+	uid, err := strconv.ParseUint(string(bytes.TrimSpace(out)), 10, 32)
+	if err != nil {
+		return 0
+	}
+
+	return uint32(uid)
+}
+
+// splitLabel splits a label filter, "key" or "key=value", into its key and value.
+func splitLabel(label string) (string, string) {
+	key, value, _ := strings.Cut(label, "=")
+
+	return key, value
+}
+
+// normalizeName normalizes a container name to comply with ShellHub's device naming conventions.
+//
+// While runtimes allow characters like dots and hyphens in their naming pattern `[a-zA-Z0-9][a-zA-Z0-9_.-]`, ShellHub
+// restricts names to letters, numbers, underscores, and hyphens, with a maximum length of 64 characters
+// `([a-zA-Z0-9_-]){1,64}$`. This normalization is essential for compatibility.
+func normalizeName(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	name = strings.ReplaceAll(name, ".", "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+
+	return name
+}
@@ -0,0 +1,156 @@
+package connector
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "web_1", normalizeName("/web.1"))
+	assert.Equal(t, strings.Repeat("a", 64), normalizeName(strings.Repeat("a", 70)))
+}
+
+func TestHasLabel(t *testing.T) {
+	labels := map[string]string{"shellhub": "true", "io.kubernetes.pod.name": "web"}
+
+	assert.True(t, hasLabel(labels, ""))
+	assert.True(t, hasLabel(labels, "shellhub"))
+	assert.True(t, hasLabel(labels, "shellhub=true"))
+	assert.False(t, hasLabel(labels, "shellhub=false"))
+	assert.False(t, hasLabel(labels, "other"))
+}
+
+func TestContainerName(t *testing.T) {
+	assert.Equal(t, "web_nginx", containerName("nginx", map[string]string{criPodNameLabel: "web"}))
+	assert.Equal(t, "nginx", containerName("nginx", nil))
+}
+
+func TestCRIStatusExitCode(t *testing.T) {
+	cases := []struct {
+		description string
+		status      string
+		code        int
+		fails       bool
+	}{
+		{
+			description: "succeeds with a zero exit code",
+			status:      `{"status": "Success"}`,
+			code:        0,
+		},
+		{
+			description: "reports a non-zero exit code",
+			status:      `{"status": "Failure", "reason": "NonZeroExitCode", "details": {"causes": [{"reason": "ExitCode", "message": "127"}]}}`,
+			code:        127,
+		},
+		{
+			description: "fails when the exec fails",
+			status:      `{"status": "Failure", "reason": "InternalError", "message": "container not running"}`,
+			code:        -1,
+			fails:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var status criStatus
+			require.NoError(t, json.Unmarshal([]byte(tc.status), &status))
+
+			code, err := status.exitCode()
+			assert.Equal(t, tc.code, code)
+			assert.Equal(t, tc.fails, err != nil)
+		})
+	}
+}
+
+// podman serves a fake libpod API on a unix socket, returning its address.
+func podman(t *testing.T, handler http.Handler) string {
+	t.Helper()
+
+	socket := filepath.Join(t.TempDir(), "podman.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: handler} //nolint:gosec
+	go server.Serve(listener)                //nolint:errcheck
+
+	t.Cleanup(func() { server.Close() })
+
+	return "unix://" + socket
+}
+
+func TestPodmanRuntime(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v4.0.0/libpod/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		assert.JSONEq(t, `{"label": ["shellhub"]}`, r.URL.Query().Get("filters"))
+
+		w.Write([]byte(`[{"Id": "4f2a9c1e5b7d", "Names": ["web.1"]}]`)) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /v4.0.0/libpod/containers/{id}/archive", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/etc/passwd", r.URL.Query().Get("path"))
+
+		var archive bytes.Buffer
+		writer := tar.NewWriter(&archive)
+		passwd := []byte("root:x:0:0:root:/root:/bin/sh\n")
+		writer.WriteHeader(&tar.Header{Name: "passwd", Mode: 0o644, Size: int64(len(passwd))}) //nolint:errcheck
+		writer.Write(passwd)                                                                   //nolint:errcheck
+		writer.Close()
+
+		w.Write(archive.Bytes()) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /v4.0.0/libpod/containers/{id}/json", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"cause": "no such container", "message": "no container with name or ID \"missing\" found"}`)) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /v4.0.0/libpod/events", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"Type": "container", "Action": "start", "Actor": {"ID": "4f2a9c1e5b7d"}}` + "\n")) //nolint:errcheck
+		w.Write([]byte(`{"Type": "container", "Action": "died", "Actor": {"ID": "4f2a9c1e5b7d"}}` + "\n"))  //nolint:errcheck
+	})
+
+	runtime, err := NewPodmanRuntime(podman(t, mux))
+	require.NoError(t, err)
+
+	t.Run("lists the labeled containers", func(t *testing.T) {
+		containers, err := runtime.List(ctx, "shellhub")
+		require.NoError(t, err)
+		assert.Equal(t, []Container{{ID: "4f2a9c1e5b7d", Name: "web_1"}}, containers)
+	})
+
+	t.Run("reads a file from the container", func(t *testing.T) {
+		file, err := runtime.ReadFile(ctx, "4f2a9c1e5b7d", "/etc/passwd")
+		require.NoError(t, err)
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "root:x:0:0:root:/root:/bin/sh\n", string(data))
+	})
+
+	t.Run("fails with the API's error message", func(t *testing.T) {
+		_, err := runtime.Inspect(ctx, "missing")
+		require.EqualError(t, err, `podman: no container with name or ID "missing" found`)
+	})
+
+	t.Run("streams the containers' start and stop", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		events, _ := runtime.Events(ctx, "")
+
+		assert.Equal(t, Event{Action: EventStart, ID: "4f2a9c1e5b7d"}, <-events)
+		assert.Equal(t, Event{Action: EventStop, ID: "4f2a9c1e5b7d"}, <-events)
+	})
+}
@@ -0,0 +1,403 @@
+package selfupdater
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultReleasesURL is where the native agent downloads its updates from, as
+// `<url>/<version>/shellhub-agent-<os>-<arch>.gz`, described by the manifest at the same URL ended by `.json` instead,
+// whose detached signature ends by `.json.sig`. It can be replaced by SHELLHUB_UPDATE_URL, for a mirror.
+const DefaultReleasesURL = "https://github.com/shellhub-io/shellhub/releases/download"
+
+// PublicKey is the base64 encoded Ed25519 public key the native agent verifies the signature of its updates against.
+// It is pinned at build time:
+//
+//	go build -ldflags "-X github.com/shellhub-io/shellhub/agent/pkg/selfupdater.PublicKey=..."
+//
+// The native agent doesn't update itself without it.
+var PublicKey string
+
+const (
+	// verifyTimeout is how long an updated agent has to become healthy before its update is rolled back.
+	verifyTimeout = 5 * time.Minute
+	// maxStarts is how many times an updated agent may start without becoming healthy before its update is rolled
+	// back, as the service manager keeps restarting an agent that crashes.
+	maxStarts = 3
+	// maxReleaseSize bounds the download of a release, a compressed binary of a few tens of megabytes.
+	maxReleaseSize = 128 << 20
+	// maxManifestSize bounds the download of a release's manifest, and of its signature.
+	maxManifestSize = 64 << 10
+)
+
+var (
+	ErrNoPublicKey      = errors.New("no public key is pinned to verify the updates")
+	ErrInvalidPublicKey = errors.New("the public key pinned to verify the updates is invalid")
+	ErrInvalidSignature = errors.New("the update's signature is invalid")
+	ErrInvalidManifest  = errors.New("the update's manifest is invalid")
+	ErrManifestMismatch = errors.New("the update's manifest describes another release")
+	ErrInvalidChecksum  = errors.New("the update's checksum doesn't match its manifest")
+)
+
+// manifest describes a release. It's what is signed, so the signature vouches for the release's version and platform
+// along with its content, and a release signed for one version or platform can't be served for another.
+type manifest struct {
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	// SHA256 is the hex encoded SHA-256 of the compressed release.
+	SHA256 string `json:"sha256"`
+}
+
+// pendingUpdate is an update the native agent applied, saved next to its binary until the updated agent is healthy.
+type pendingUpdate struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Starts counts the times the updated agent started.
+	Starts int `json:"starts"`
+}
+
+// nativeUpdater updates an agent running straight on the host, replacing its binary by a signed release and
+// restarting it in place.
+//
+// The binary it replaces is kept, with the `.old` suffix, until the updated agent is healthy. When it isn't in time,
+// or keeps failing to start, the binary is restored and the previous agent restarted.
+type nativeUpdater struct {
+	version string
+	// executable is the path to the agent's binary.
+	executable string
+	releases   string
+	publicKey  string
+	http       *http.Client
+	timeout    time.Duration
+	// restart replaces the running agent by the binary at the path.
+	restart func(path string) error
+}
+
+func newNativeUpdater(version string) *nativeUpdater {
+	releases := os.Getenv("SHELLHUB_UPDATE_URL")
+	if releases == "" {
+		releases = DefaultReleasesURL
+	}
+
+	executable, err := os.Executable()
+	if err == nil {
+		executable, err = filepath.EvalSymlinks(executable)
+	}
+
+	if err != nil {
+		log.WithError(err).Warn("failed to find the agent's binary, so it won't be updated")
+	}
+
+	return &nativeUpdater{
+		version:    version,
+		executable: executable,
+		releases:   strings.TrimSuffix(releases, "/"),
+		publicKey:  PublicKey,
+		http:       &http.Client{Timeout: 10 * time.Minute},
+		timeout:    verifyTimeout,
+		restart: func(path string) error {
+			return syscall.Exec(path, os.Args, os.Environ()) //nolint:gosec
+		},
+	}
+}
+
+func (n *nativeUpdater) CurrentVersion() (*semver.Version, error) {
+	return semver.NewVersion(n.version)
+}
+
+// ApplyUpdate downloads the version's release, verifies it against its signed manifest, replaces the agent's binary by
+// it and restarts the agent, so it only returns on failure.
+func (n *nativeUpdater) ApplyUpdate(v *semver.Version) error {
+	if n.publicKey == "" {
+		return ErrNoPublicKey
+	}
+
+	key, err := base64.StdEncoding.DecodeString(n.publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return ErrInvalidPublicKey
+	}
+
+	if n.executable == "" {
+		return errors.New("the agent's binary wasn't found")
+	}
+
+	asset := fmt.Sprintf("%s/%s/shellhub-agent-%s-%s", n.releases, v.Original(), runtime.GOOS, arch())
+
+	data, err := n.download(asset+".json", maxManifestSize)
+	if err != nil {
+		return err
+	}
+
+	signature, err := n.download(asset+".json.sig", maxManifestSize)
+	if err != nil {
+		return err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil || !ed25519.Verify(key, data, decoded) {
+		return ErrInvalidSignature
+	}
+
+	var described manifest
+	if err := json.Unmarshal(data, &described); err != nil {
+		return ErrInvalidManifest
+	}
+
+	version, err := semver.NewVersion(described.Version)
+	if err != nil || !version.Equal(v) || described.OS != runtime.GOOS || described.Arch != arch() {
+		return fmt.Errorf("%w: %s for %s/%s", ErrManifestMismatch, described.Version, described.OS, described.Arch)
+	}
+
+	release, err := n.download(asset+".gz", maxReleaseSize)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(release)
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), described.SHA256) {
+		return ErrInvalidChecksum
+	}
+
+	if err := n.stage(release); err != nil {
+		return err
+	}
+
+	// NOTE: The binary being replaced is copied, not moved, so there is always an agent at the executable's path.
+	if err := copyFile(n.executable, n.executable+".old"); err != nil {
+		return fmt.Errorf("failed to keep the current binary: %w", err)
+	}
+
+	if err := n.save(&pendingUpdate{From: n.version, To: v.Original()}); err != nil {
+		return err
+	}
+
+	if err := os.Rename(n.executable+".new", n.executable); err != nil {
+		return fmt.Errorf("failed to replace the binary: %w", err)
+	}
+
+	log.WithFields(log.Fields{
+		"version":      n.version,
+		"next_version": v.Original(),
+	}).Info("Restarting the agent to complete the update")
+
+	return n.restart(n.executable)
+}
+
+// CompleteUpdate counts the start of an agent that was just updated, rolling the update back when the agent already
+// started too many times without becoming healthy.
+func (n *nativeUpdater) CompleteUpdate() error {
+	pending, err := n.pending()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	pending.Starts++
+	if pending.Starts > maxStarts {
+		return n.rollback(pending, "the updated agent failed to start")
+	}
+
+	return n.save(pending)
+}
+
+func (n *nativeUpdater) VerifyUpdate(ctx context.Context, healthy func() bool) error {
+	pending, err := n.pending()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(n.timeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timeout.C:
+			return n.rollback(pending, "the updated agent didn't become healthy in time")
+		case <-ticker.C:
+			if !healthy() {
+				continue
+			}
+
+			os.Remove(n.executable + ".old")    //nolint:errcheck
+			os.Remove(n.executable + ".update") //nolint:errcheck
+
+			log.WithFields(log.Fields{
+				"version":          pending.To,
+				"previous_version": pending.From,
+			}).Info("Update verified")
+
+			return nil
+		}
+	}
+}
+
+// rollback restores the binary the update replaced and restarts the agent, so it only returns on failure.
+func (n *nativeUpdater) rollback(pending *pendingUpdate, reason string) error {
+	log.WithFields(log.Fields{
+		"version":          pending.To,
+		"previous_version": pending.From,
+	}).Warn(reason + ", rolling the update back")
+
+	if err := os.Rename(n.executable+".old", n.executable); err != nil {
+		return fmt.Errorf("failed to restore the previous binary: %w", err)
+	}
+
+	os.Remove(n.executable + ".update") //nolint:errcheck
+
+	return n.restart(n.executable)
+}
+
+// download reads the file at the URL, failing when it's larger than limit.
+func (n *nativeUpdater) download(url string, limit int64) ([]byte, error) {
+	res, err := n.http.Get(url) //nolint:noctx
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, res.Status)
+	}
+
+	if res.ContentLength > limit {
+		return nil, fmt.Errorf("failed to download %s: it's larger than %d bytes", url, limit)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("failed to download %s: it's larger than %d bytes", url, limit)
+	}
+
+	return data, nil
+}
+
+// stage decompresses the release next to the agent's binary, so it replaces the binary by a rename, on the same file
+// system.
+func (n *nativeUpdater) stage(release []byte) error {
+	reader, err := gzip.NewReader(bytes.NewReader(release))
+	if err != nil {
+		return fmt.Errorf("failed to decompress the release: %w", err)
+	}
+
+	file, err := os.OpenFile(n.executable+".new", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to stage the release: %w", err)
+	}
+
+	defer file.Close()
+
+	if _, err := io.Copy(file, reader); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to stage the release: %w", err)
+	}
+
+	return file.Sync()
+}
+
+func (n *nativeUpdater) pending() (*pendingUpdate, error) {
+	data, err := os.ReadFile(n.executable + ".update")
+	if err != nil {
+		return nil, err
+	}
+
+	pending := new(pendingUpdate)
+	if err := json.Unmarshal(data, pending); err != nil {
+		return nil, fmt.Errorf("failed to read the pending update: %w", err)
+	}
+
+	return pending, nil
+}
+
+func (n *nativeUpdater) save(pending *pendingUpdate) error {
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(n.executable+".update", data, 0o600); err != nil {
+		return fmt.Errorf("failed to save the pending update: %w", err)
+	}
+
+	return nil
+}
+
+// arch returns the architecture the releases are named after, telling the ARM versions apart.
+func arch() string {
+	if runtime.GOARCH != "arm" {
+		return runtime.GOARCH
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "GOARM" {
+				return "armv" + strings.SplitN(setting.Value, ",", 2)[0]
+			}
+		}
+	}
+
+	return "armv6"
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	stat, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, stat.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+
+	return out.Sync()
+}
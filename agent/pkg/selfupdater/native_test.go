@@ -0,0 +1,239 @@
+package selfupdater
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestUpdater returns a native updater for an agent binary in a temporary directory, serving releases of the
+// content described by a signed manifest for v0.21.0, which edit may change, and the paths it restarts.
+func newTestUpdater(t *testing.T, content []byte, edit ...func(*manifest)) (*nativeUpdater, *[]string) {
+	t.Helper()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	executable := filepath.Join(t.TempDir(), "agent")
+	require.NoError(t, os.WriteFile(executable, []byte("current"), 0o755)) //nolint:gosec
+
+	release := new(bytes.Buffer)
+	writer := gzip.NewWriter(release)
+	_, err = writer.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	sum := sha256.Sum256(release.Bytes())
+	described := &manifest{Version: "v0.21.0", OS: runtime.GOOS, Arch: arch(), SHA256: hex.EncodeToString(sum[:])}
+	for _, edit := range edit {
+		edit(described)
+	}
+
+	data, err := json.Marshal(described)
+	require.NoError(t, err)
+
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(private, data))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ".gz"):
+			w.Write(release.Bytes()) //nolint:errcheck
+		case strings.HasSuffix(r.URL.Path, ".json"):
+			w.Write(data) //nolint:errcheck
+		case strings.HasSuffix(r.URL.Path, ".json.sig"):
+			w.Write([]byte(signature + "\n")) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restarts := []string{}
+
+	return &nativeUpdater{
+		version:    "v0.20.0",
+		executable: executable,
+		releases:   server.URL,
+		publicKey:  base64.StdEncoding.EncodeToString(public),
+		http:       server.Client(),
+		timeout:    verifyTimeout,
+		restart: func(path string) error {
+			restarts = append(restarts, path)
+
+			return nil
+		},
+	}, &restarts
+}
+
+func TestNativeUpdaterApplyUpdate(t *testing.T) {
+	t.Run("fails without a public key", func(t *testing.T) {
+		updater, restarts := newTestUpdater(t, []byte("next"))
+		updater.publicKey = ""
+
+		assert.ErrorIs(t, updater.ApplyUpdate(semver.MustParse("v0.21.0")), ErrNoPublicKey)
+		assert.Empty(t, *restarts)
+	})
+
+	t.Run("fails when the signature doesn't match the public key", func(t *testing.T) {
+		updater, restarts := newTestUpdater(t, []byte("next"))
+
+		public, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		updater.publicKey = base64.StdEncoding.EncodeToString(public)
+
+		assert.ErrorIs(t, updater.ApplyUpdate(semver.MustParse("v0.21.0")), ErrInvalidSignature)
+		assert.Empty(t, *restarts)
+
+		binary, err := os.ReadFile(updater.executable)
+		require.NoError(t, err)
+		assert.Equal(t, "current", string(binary))
+	})
+
+	t.Run("fails when the manifest describes another release", func(t *testing.T) {
+		cases := map[string]func(*manifest){
+			"version":      func(m *manifest) { m.Version = "v0.20.1" },
+			"os":           func(m *manifest) { m.OS = "plan9" },
+			"architecture": func(m *manifest) { m.Arch = "mips" },
+		}
+
+		for description, edit := range cases {
+			t.Run(description, func(t *testing.T) {
+				updater, restarts := newTestUpdater(t, []byte("next"), edit)
+
+				assert.ErrorIs(t, updater.ApplyUpdate(semver.MustParse("v0.21.0")), ErrManifestMismatch)
+				assert.Empty(t, *restarts)
+			})
+		}
+	})
+
+	t.Run("fails when the release doesn't match the manifest's checksum", func(t *testing.T) {
+		updater, restarts := newTestUpdater(t, []byte("next"), func(m *manifest) {
+			sum := sha256.Sum256([]byte("other"))
+			m.SHA256 = hex.EncodeToString(sum[:])
+		})
+
+		assert.ErrorIs(t, updater.ApplyUpdate(semver.MustParse("v0.21.0")), ErrInvalidChecksum)
+		assert.Empty(t, *restarts)
+
+		binary, err := os.ReadFile(updater.executable)
+		require.NoError(t, err)
+		assert.Equal(t, "current", string(binary))
+	})
+
+	t.Run("replaces the binary and restarts the agent", func(t *testing.T) {
+		updater, restarts := newTestUpdater(t, []byte("next"))
+
+		require.NoError(t, updater.ApplyUpdate(semver.MustParse("v0.21.0")))
+		assert.Equal(t, []string{updater.executable}, *restarts)
+
+		binary, err := os.ReadFile(updater.executable)
+		require.NoError(t, err)
+		assert.Equal(t, "next", string(binary))
+
+		old, err := os.ReadFile(updater.executable + ".old")
+		require.NoError(t, err)
+		assert.Equal(t, "current", string(old))
+
+		pending, err := updater.pending()
+		require.NoError(t, err)
+		assert.Equal(t, &pendingUpdate{From: "v0.20.0", To: "v0.21.0"}, pending)
+	})
+}
+
+func TestNativeUpdaterDownload(t *testing.T) {
+	updater, _ := newTestUpdater(t, []byte("next"))
+
+	data, err := updater.download(updater.releases+"/v0.21.0/manifest.json", maxManifestSize)
+	require.NoError(t, err)
+	assert.NotEmpty(t, data)
+
+	_, err = updater.download(updater.releases+"/v0.21.0/manifest.json", int64(len(data)-1))
+	assert.ErrorContains(t, err, "larger than")
+
+	// A body whose length isn't told is cut at the limit all the same.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(bytes.Repeat([]byte("a"), 1024)) //nolint:errcheck
+		w.(http.Flusher).Flush()
+		w.Write(bytes.Repeat([]byte("a"), 1024)) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+
+	_, err = updater.download(server.URL, 1500)
+	assert.ErrorContains(t, err, "larger than")
+}
+
+func TestNativeUpdaterCompleteUpdate(t *testing.T) {
+	updater, restarts := newTestUpdater(t, []byte("next"))
+	require.NoError(t, updater.ApplyUpdate(semver.MustParse("v0.21.0")))
+	*restarts = []string{}
+
+	for i := 0; i < maxStarts; i++ {
+		require.NoError(t, updater.CompleteUpdate())
+	}
+
+	assert.Empty(t, *restarts)
+
+	require.NoError(t, updater.CompleteUpdate())
+	assert.Equal(t, []string{updater.executable}, *restarts)
+
+	binary, err := os.ReadFile(updater.executable)
+	require.NoError(t, err)
+	assert.Equal(t, "current", string(binary))
+
+	_, err = updater.pending()
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNativeUpdaterVerifyUpdate(t *testing.T) {
+	t.Run("returns at once without a pending update", func(t *testing.T) {
+		updater, _ := newTestUpdater(t, []byte("next"))
+
+		assert.NoError(t, updater.VerifyUpdate(context.Background(), func() bool { return false }))
+	})
+
+	t.Run("keeps the update when the agent is healthy", func(t *testing.T) {
+		updater, restarts := newTestUpdater(t, []byte("next"))
+		require.NoError(t, updater.ApplyUpdate(semver.MustParse("v0.21.0")))
+		*restarts = []string{}
+
+		require.NoError(t, updater.VerifyUpdate(context.Background(), func() bool { return true }))
+		assert.Empty(t, *restarts)
+
+		_, err := os.Stat(updater.executable + ".old")
+		assert.True(t, os.IsNotExist(err))
+		_, err = updater.pending()
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("rolls the update back when the agent isn't healthy in time", func(t *testing.T) {
+		updater, restarts := newTestUpdater(t, []byte("next"))
+		require.NoError(t, updater.ApplyUpdate(semver.MustParse("v0.21.0")))
+		*restarts = []string{}
+		updater.timeout = 100 * time.Millisecond
+
+		require.NoError(t, updater.VerifyUpdate(context.Background(), func() bool { return false }))
+		assert.Equal(t, []string{updater.executable}, *restarts)
+
+		binary, err := os.ReadFile(updater.executable)
+		require.NoError(t, err)
+		assert.Equal(t, "current", string(binary))
+	})
+}
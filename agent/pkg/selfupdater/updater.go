@@ -1,6 +1,8 @@
 package selfupdater
 
 import (
+	"context"
+
 	"github.com/Masterminds/semver/v3"
 )
 
@@ -8,20 +10,7 @@ type Updater interface {
 	CurrentVersion() (*semver.Version, error)
 	ApplyUpdate(v *semver.Version) error
 	CompleteUpdate() error
-}
-
-type nativeUpdater struct {
-	version string
-}
-
-func (n *nativeUpdater) CurrentVersion() (*semver.Version, error) {
-	return semver.NewVersion(n.version)
-}
-
-func (n *nativeUpdater) ApplyUpdate(_ *semver.Version) error {
-	return nil
-}
-
-func (n *nativeUpdater) CompleteUpdate() error {
-	return nil
+	// VerifyUpdate waits for an agent that was just updated to be healthy, rolling the update back when it isn't in
+	// time. It returns at once when the agent wasn't just updated.
+	VerifyUpdate(ctx context.Context, healthy func() bool) error
 }
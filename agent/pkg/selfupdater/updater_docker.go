@@ -145,6 +145,12 @@ func (d *dockerUpdater) CompleteUpdate() error {
 	return nil
 }
 
+// VerifyUpdate does nothing, as [dockerUpdater.CompleteUpdate] only replaces the parent container once the updated
+// one started.
+func (d *dockerUpdater) VerifyUpdate(_ context.Context, _ func() bool) error {
+	return nil
+}
+
 func (d *dockerUpdater) getContainer(id string) (*dockerContainer, error) {
 	ctx := context.Background()
 
@@ -235,7 +241,7 @@ func (d *dockerUpdater) updateContainer(container *dockerContainer, image, name
 func NewUpdater(version string) (Updater, error) {
 	// ensure we are running inside a docker container, otherwise returns a dummy updater implementation
 	if _, err := os.Stat("/.dockerenv"); os.IsNotExist(err) {
-		return &nativeUpdater{version}, nil
+		return newNativeUpdater(version), nil
 	}
 
 	api, err := client.NewClientWithOpts(client.FromEnv)
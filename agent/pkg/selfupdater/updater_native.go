@@ -4,5 +4,5 @@
 package selfupdater
 
 func NewUpdater(version string) (Updater, error) {
-	return &nativeUpdater{version}, nil
+	return newNativeUpdater(version), nil
 }
@@ -1,7 +1,6 @@
 package connector
 
 import (
-	"archive/tar"
 	"context"
 	"crypto"
 	"crypto/rsa"
@@ -10,8 +9,8 @@ import (
 	"encoding/json"
 	"io"
 
-	dockerclient "github.com/docker/docker/client"
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/agent/pkg/connector"
 	"github.com/shellhub-io/shellhub/agent/pkg/osauth"
 	"github.com/shellhub-io/shellhub/agent/server/modes"
 	"github.com/shellhub-io/shellhub/pkg/api/client"
@@ -33,53 +32,33 @@ type Authenticator struct {
 	//
 	// NOTICE: Uses a pointer for later assignment.
 	container *string
-	// docker is a client to communicate with the Docker's API.
-	docker dockerclient.APIClient
+	// runtime is the container runtime the container runs on.
+	runtime connector.Runtime
 }
 
 // NewAuthenticator creates a new instance of Authenticator for the connector mode.
-func NewAuthenticator(api client.Client, docker dockerclient.APIClient, authData *models.DeviceAuthResponse, container *string) *Authenticator {
+func NewAuthenticator(api client.Client, runtime connector.Runtime, authData *models.DeviceAuthResponse, container *string) *Authenticator {
 	return &Authenticator{
 		api:       api,
 		authData:  authData,
 		container: container,
-		docker:    docker,
+		runtime:   runtime,
 	}
 }
 
-// getPasswd return a [io.Reader] for the container's passwd file.
-func getPasswd(ctx context.Context, cli dockerclient.APIClient, container string) (io.Reader, error) {
-	passwdTar, _, err := cli.CopyFromContainer(ctx, container, "/etc/passwd")
-	if err != nil {
-		return nil, err
-	}
-
-	passwd := tar.NewReader(passwdTar)
-	if _, err := passwd.Next(); err != nil {
-		return nil, err
-	}
-
-	return passwd, nil
+// getPasswd return a [io.ReadCloser] for the container's passwd file.
+func getPasswd(ctx context.Context, runtime connector.Runtime, container string) (io.ReadCloser, error) {
+	return runtime.ReadFile(ctx, container, "/etc/passwd")
 }
 
-// getShadow return a [io.Reader] for the container's shadow file.
-func getShadow(ctx context.Context, cli dockerclient.APIClient, container string) (io.Reader, error) {
-	shadowTar, _, err := cli.CopyFromContainer(ctx, container, "/etc/shadow")
-	if err != nil {
-		return nil, err
-	}
-
-	shadow := tar.NewReader(shadowTar)
-	if _, err := shadow.Next(); err != nil {
-		return nil, err
-	}
-
-	return shadow, nil
+// getShadow return a [io.ReadCloser] for the container's shadow file.
+func getShadow(ctx context.Context, runtime connector.Runtime, container string) (io.ReadCloser, error) {
+	return runtime.ReadFile(ctx, container, "/etc/shadow")
 }
 
 // Password handles the server's SSH password authentication when server is running in connector mode.
 func (a *Authenticator) Password(ctx gliderssh.Context, username string, password string) bool {
-	passwd, err := getPasswd(ctx, a.docker, *a.container)
+	passwd, err := getPasswd(ctx, a.runtime, *a.container)
 	if err != nil {
 		log.WithFields(
 			log.Fields{
@@ -90,6 +69,7 @@ func (a *Authenticator) Password(ctx gliderssh.Context, username string, passwor
 
 		return false
 	}
+	defer passwd.Close()
 
 	user, err := osauth.LookupUserFromPasswd(username, passwd)
 	if err != nil {
@@ -115,7 +95,7 @@ func (a *Authenticator) Password(ctx gliderssh.Context, username string, passwor
 		return false
 	}
 
-	shadow, err := getShadow(ctx, a.docker, *a.container)
+	shadow, err := getShadow(ctx, a.runtime, *a.container)
 	if err != nil {
 		log.WithFields(
 			log.Fields{
@@ -126,6 +106,7 @@ func (a *Authenticator) Password(ctx gliderssh.Context, username string, passwor
 
 		return false
 	}
+	defer shadow.Close()
 
 	if !osauth.AuthUserFromShadow(username, password, shadow) {
 		log.WithFields(
@@ -153,7 +134,7 @@ func (a *Authenticator) Password(ctx gliderssh.Context, username string, passwor
 
 // PublicKey handles the server's SSH public key authentication when server is running in connector mode.
 func (a *Authenticator) PublicKey(ctx gliderssh.Context, username string, key gliderssh.PublicKey) bool {
-	passwd, err := getPasswd(ctx, a.docker, *a.container)
+	passwd, err := getPasswd(ctx, a.runtime, *a.container)
 	if err != nil {
 		log.WithFields(
 			log.Fields{
@@ -164,6 +145,7 @@ func (a *Authenticator) PublicKey(ctx gliderssh.Context, username string, key gl
 
 		return false
 	}
+	defer passwd.Close()
 
 	user, err := osauth.LookupUserFromPasswd(username, passwd)
 	if err != nil {
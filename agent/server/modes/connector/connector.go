@@ -1,94 +1,241 @@
 // Package connector defines methods for authentication and sessions handles to SSH when it is running in connector mode.
 //
-// Connector mode means that the SSH's server runs in the host machine, but redirect the IO to a specific docker
-// container, maning its authentication through the container's "/etc/passwd", "/etc/shadow" and etc.
+// Connector mode means that the SSH's server runs in the host machine, but redirect the IO to a specific container, on
+// any of the runtimes the connector supports, maning its authentication through the container's "/etc/passwd",
+// "/etc/shadow" and etc.
 package connector
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	goruntime "runtime"
+	"slices"
+	"strings"
 
-	"github.com/docker/docker/api/types"
-	dockercontainer "github.com/docker/docker/api/types/container"
-	dockerclient "github.com/docker/docker/client"
-	"github.com/docker/docker/pkg/process"
+	"github.com/shellhub-io/shellhub/agent/pkg/connector"
 	"github.com/shellhub-io/shellhub/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
 )
 
+// sftpServerDir is the container's directory the SFTP server is copied to.
+const sftpServerDir = "/tmp"
+
+// sftpPreflight checks, inside the container, that the SFTP server can be copied to the directory given as $0 and run
+// from there. It prints why it can't, or the container's machine, for its architecture to be checked.
+const sftpPreflight = `[ -d "$0" ] && [ -w "$0" ] || { echo read-only; exit 0; }
+best= opts=
+while read -r _ mnt _ o _; do
+	case "$0/" in "${mnt%/}/"*) [ ${#mnt} -ge ${#best} ] && best=$mnt opts=$o ;; esac
+done < /proc/mounts
+case ",$opts," in *,noexec,*) echo noexec; exit 0 ;; esac
+uname -m 2>/dev/null
+exit 0`
+
+var (
+	ErrSFTPNoShell  = errors.New("the container has no /bin/sh to set the SFTP server up with")
+	ErrSFTPReadOnly = errors.New("the SFTP server can't be copied to the container, as its " + sftpServerDir + " isn't writable, as on a read-only root filesystem")
+	ErrSFTPNoExec   = errors.New("the SFTP server can't run in the container, as its " + sftpServerDir + " is mounted noexec")
+	ErrSFTPArch     = errors.New("the SFTP server can't run in the container, as it's built for another architecture")
+)
+
+// machines are the names `uname -m` gives the architectures the agent is built for.
+var machines = map[string][]string{
+	"386":     {"i386", "i486", "i586", "i686"},
+	"amd64":   {"x86_64", "amd64"},
+	"arm":     {"armv6l", "armv7l", "armv8l"},
+	"arm64":   {"aarch64", "arm64"},
+	"ppc64le": {"ppc64le"},
+	"riscv64": {"riscv64"},
+	"s390x":   {"s390x"},
+}
+
+// agentArch is the architecture the agent, and so the SFTP server, is built for.
+var agentArch = goruntime.GOARCH
+
 type Mode struct {
 	Authenticator
 	Sessioner
 }
 
-func attachShellToContainer(ctx context.Context, cli dockerclient.APIClient, container string, user *osauth.User, size [2]int) (*types.HijackedResponse, string, error) {
-	return attachToContainer(ctx, cli, "shell", container, user, true, []string{}, size)
+func attachShellToContainer(ctx context.Context, runtime connector.Runtime, container string, user *osauth.User, config *connector.ExecConfig) (connector.Process, error) {
+	return attachToContainer(ctx, runtime, "shell", container, user, []string{}, config)
 }
 
-func attachExecToContainer(ctx context.Context, cli dockerclient.APIClient, container string, user *osauth.User, isPty bool, commands []string, size [2]int) (*types.HijackedResponse, string, error) {
-	return attachToContainer(ctx, cli, "exec", container, user, isPty, commands, size)
+func attachExecToContainer(ctx context.Context, runtime connector.Runtime, container string, user *osauth.User, commands []string, config *connector.ExecConfig) (connector.Process, error) {
+	return attachToContainer(ctx, runtime, "exec", container, user, commands, config)
 }
 
-func attachHereDocToContainer(ctx context.Context, cli dockerclient.APIClient, container string, user *osauth.User, size [2]int) (*types.HijackedResponse, string, error) {
-	return attachToContainer(ctx, cli, "heredoc", container, user, false, []string{}, size)
+func attachHereDocToContainer(ctx context.Context, runtime connector.Runtime, container string, user *osauth.User, config *connector.ExecConfig) (connector.Process, error) {
+	return attachToContainer(ctx, runtime, "heredoc", container, user, []string{}, config)
 }
 
-func attachToContainer(ctx context.Context, cli dockerclient.APIClient, requestType string, container string, user *osauth.User, isPty bool, commands []string, size [2]int) (*types.HijackedResponse, string, error) {
+func attachToContainer(ctx context.Context, runtime connector.Runtime, requestType string, container string, user *osauth.User, commands []string, config *connector.ExecConfig) (connector.Process, error) {
 	if user.Shell == "" {
 		user.Shell = "/bin/sh"
 	}
 
-	s := &[2]uint{
-		uint(size[0]), //nolint:gosec
-		uint(size[1]), //nolint:gosec
-	}
-
-	id, err := cli.ContainerExecCreate(ctx, container, dockercontainer.ExecOptions{
-		User:         user.Username,
-		Tty:          isPty,
-		ConsoleSize:  s,
-		AttachStdin:  true,
-		AttachStdout: true,
-		AttachStderr: true,
-		Cmd: func() []string {
-			switch requestType {
-			case "shell":
-				return []string{user.Shell}
-			case "exec":
-				// NOTE(r): when the exec session's has `-t` or `-tt` flag, the command must be executed into a tty/pty.
-				// the Shell's `-c` flag is used to do this.
-				if isPty {
-					return append([]string{user.Shell, "-c"}, commands...)
-				}
-
-				return commands
-			case "heredoc":
-				return []string{user.Shell}
-			default:
-				return []string{}
+	config.User = user.Username
+	config.UID = user.UID
+	config.Shell = user.Shell
+	config.Cmd = func() []string {
+		switch requestType {
+		case "shell":
+			return []string{user.Shell}
+		case "exec":
+			// NOTE(r): when the exec session's has `-t` or `-tt` flag, the command must be executed into a tty/pty.
+			// the Shell's `-c` flag is used to do this.
+			if config.TTY {
+				return append([]string{user.Shell, "-c"}, commands...)
 			}
-		}(),
-	})
+
+			return commands
+		case "heredoc":
+			return []string{user.Shell}
+		default:
+			return []string{}
+		}
+	}()
+
+	return runtime.Exec(ctx, container, config)
+}
+
+// attachSFTPToContainer starts an SFTP server inside the container, running as the user, so the user's permissions
+// apply to every file operation.
+//
+// Containers rarely have an SFTP server, so the agent copies its own executable, which is statically linked, into the
+// container and runs its "sftp" subcommand there, which removes the copy as soon as it starts. Both the copy and the
+// server run as the user, as only the copy's owner can remove it from the sticky temporary directory.
+func attachSFTPToContainer(ctx context.Context, runtime connector.Runtime, container string, user *osauth.User, executable string, config *connector.ExecConfig) (connector.Process, error) {
+	if user.Shell == "" {
+		user.Shell = "/bin/sh"
+	}
+
+	src, err := os.Open(executable)
+	if err != nil {
+		return nil, err
+	}
+
+	defer src.Close()
+
+	if err := checkSFTPContainer(ctx, runtime, container, user); err != nil {
+		return nil, err
+	}
+
+	server := path.Join(sftpServerDir, ".shellhub-sftp-"+uuid.Generate())
+
+	// NOTE: The path is passed as the script's $0, so it isn't interpreted by the shell.
+	if err := runInContainer(ctx, runtime, container, user, src, "/bin/sh", "-c", `cat > "$0" && chmod 700 "$0"`, server); err != nil {
+		return nil, fmt.Errorf("failed to copy the SFTP server to the container: %w", err)
+	}
+
+	config.User = user.Username
+	config.UID = user.UID
+	config.Shell = user.Shell
+	config.TTY = false
+	config.Cmd = []string{server, "sftp", "connector", user.HomeDir}
+
+	proc, err := runtime.Exec(ctx, container, config)
 	if err != nil {
-		return nil, "", err
+		// NOTE: The server removes its copy when it starts; as it didn't, the copy is removed here.
+		runInContainer(ctx, runtime, container, user, nil, "rm", "-f", server) //nolint:errcheck
+
+		return nil, err
 	}
 
-	res, err := cli.ContainerExecAttach(ctx, id.ID, dockercontainer.ExecStartOptions{
-		Tty:         isPty,
-		ConsoleSize: s,
+	return proc, nil
+}
+
+// checkSFTPContainer tells whether the SFTP server can be copied to the container and run there, so a container that
+// can't run it fails with why, rather than with whatever the copy or the exec fails with.
+func checkSFTPContainer(ctx context.Context, runtime connector.Runtime, container string, user *osauth.User) error {
+	var stdout bytes.Buffer
+
+	proc, err := runtime.Exec(ctx, container, &connector.ExecConfig{
+		User:   user.Username,
+		UID:    user.UID,
+		Shell:  user.Shell,
+		Cmd:    []string{"/bin/sh", "-c", sftpPreflight, sftpServerDir},
+		Stdout: &stdout,
+		Stderr: io.Discard,
 	})
+	if err != nil {
+		return err
+	}
+
+	defer proc.Close()
+
+	if err := proc.CloseWrite(); err != nil {
+		return err
+	}
+
+	// NOTE: Runtimes report a command they can't find either as an exec failure or as the shell's codes for it.
+	code, err := proc.Wait()
+	if err != nil || code == 126 || code == 127 {
+		return ErrSFTPNoShell
+	}
+
+	if code != 0 {
+		return fmt.Errorf("failed to check the container can run the SFTP server: /bin/sh exited with code %d", code)
+	}
+
+	switch result := strings.TrimSpace(stdout.String()); result {
+	case "read-only":
+		return ErrSFTPReadOnly
+	case "noexec":
+		return ErrSFTPNoExec
+	default:
+		// NOTE: A machine that isn't known, or wasn't told, is left for the exec to fail on.
+		names, ok := machines[agentArch]
+		if ok && result != "" && !slices.Contains(names, result) {
+			return fmt.Errorf("%w: the container's machine is %s, and the agent's architecture %s", ErrSFTPArch, result, agentArch)
+		}
+	}
 
-	return &res, id.ID, err
+	return nil
 }
 
-func exitCodeExecFromContainer(cli dockerclient.APIClient, id string) (int, error) {
-	inspected, err := cli.ContainerExecInspect(context.Background(), id)
+// runInContainer runs a command inside the container as the user, writing stdin, when not nil, to the process's stdin,
+// and fails when it doesn't exit successfully.
+func runInContainer(ctx context.Context, runtime connector.Runtime, container string, user *osauth.User, stdin io.Reader, cmd ...string) error {
+	var stderr bytes.Buffer
+
+	proc, err := runtime.Exec(ctx, container, &connector.ExecConfig{
+		User:   user.Username,
+		UID:    user.UID,
+		Shell:  user.Shell,
+		Cmd:    cmd,
+		Stdout: io.Discard,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer proc.Close()
+
+	if stdin != nil {
+		if _, err := io.Copy(proc, stdin); err != nil {
+			return err
+		}
+	}
+
+	if err := proc.CloseWrite(); err != nil {
+		return err
+	}
+
+	code, err := proc.Wait()
 	if err != nil {
-		return -1, err
+		return err
 	}
 
-	if inspected.Running {
-		// NOTICE: when a process is running after the exec command, it is necessary to kill it.
-		return 0, process.Kill(inspected.Pid)
+	if code != 0 {
+		return fmt.Errorf("%s exited with code %d: %s", cmd[0], code, bytes.TrimSpace(stderr.Bytes()))
 	}
 
-	return inspected.ExitCode, nil
+	return nil
 }
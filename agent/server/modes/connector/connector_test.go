@@ -0,0 +1,158 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/agent/pkg/connector"
+	"github.com/shellhub-io/shellhub/agent/pkg/osauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// process is a [connector.Process] that records its stdin.
+type process struct {
+	bytes.Buffer
+	code int
+}
+
+func (p *process) CloseWrite() error      { return nil }
+func (p *process) Resize(_, _ uint) error { return nil }
+func (p *process) Wait() (int, error)     { return p.code, nil }
+func (p *process) Close() error           { return nil }
+
+// runtime is a [connector.Runtime] that records the processes it runs, which exit with code. When failServer is set,
+// the SFTP server fails to start.
+//
+// The SFTP preflight prints preflight, or the agent's own machine when it isn't set, and exits with preflightCode.
+type runtime struct {
+	connector.Runtime
+	execs         []*connector.ExecConfig
+	processes     []*process
+	code          int
+	failServer    bool
+	preflight     string
+	preflightCode int
+}
+
+func (r *runtime) Exec(_ context.Context, _ string, config *connector.ExecConfig) (connector.Process, error) {
+	r.execs = append(r.execs, config)
+
+	if r.failServer && strings.HasPrefix(config.Cmd[0], sftpServerDir) {
+		return nil, errors.New("exec failed")
+	}
+
+	proc := &process{code: r.code}
+	if slices.Contains(config.Cmd, sftpPreflight) {
+		output := r.preflight
+		if names := machines[agentArch]; output == "" && len(names) > 0 {
+			output = names[0]
+		}
+
+		io.WriteString(config.Stdout, output+"\n") //nolint:errcheck
+
+		proc.code = r.preflightCode
+	}
+
+	r.processes = append(r.processes, proc)
+
+	return proc, nil
+}
+
+func TestAttachSFTPToContainer(t *testing.T) {
+	executable := filepath.Join(t.TempDir(), "agent")
+	require.NoError(t, os.WriteFile(executable, []byte("agent"), 0o600))
+
+	user := &osauth.User{Username: "nginx", UID: 101, HomeDir: "/home/nginx"}
+
+	t.Run("copies the server to the container and runs it as the user", func(t *testing.T) {
+		runtime := &runtime{}
+
+		_, err := attachSFTPToContainer(context.Background(), runtime, "web", user, executable, &connector.ExecConfig{})
+		require.NoError(t, err)
+
+		require.Len(t, runtime.execs, 3)
+
+		preflight, copied, server := runtime.execs[0], runtime.execs[1], runtime.execs[2]
+		assert.Equal(t, []string{"/bin/sh", "-c", sftpPreflight, sftpServerDir}, preflight.Cmd)
+		assert.Equal(t, "agent", runtime.processes[1].String())
+		assert.Equal(t, []string{"/bin/sh", "-c", `cat > "$0" && chmod 700 "$0"`}, copied.Cmd[:3])
+
+		path := copied.Cmd[3]
+		assert.True(t, strings.HasPrefix(path, sftpServerDir+"/.shellhub-sftp-"))
+		assert.Equal(t, []string{path, "sftp", "connector", "/home/nginx"}, server.Cmd)
+
+		for _, config := range runtime.execs {
+			assert.Equal(t, "nginx", config.User)
+			assert.Equal(t, uint32(101), config.UID)
+			assert.Equal(t, "/bin/sh", config.Shell)
+			assert.False(t, config.TTY)
+		}
+	})
+
+	t.Run("fails when the copy fails", func(t *testing.T) {
+		runtime := &runtime{code: 1}
+
+		_, err := attachSFTPToContainer(context.Background(), runtime, "web", user, executable, &connector.ExecConfig{})
+		require.ErrorContains(t, err, "failed to copy the SFTP server to the container: /bin/sh exited with code 1")
+	})
+
+	t.Run("removes the copy when the server doesn't start", func(t *testing.T) {
+		runtime := &runtime{failServer: true}
+
+		_, err := attachSFTPToContainer(context.Background(), runtime, "web", user, executable, &connector.ExecConfig{})
+		require.Error(t, err)
+
+		require.Len(t, runtime.execs, 4)
+		assert.Equal(t, []string{"rm", "-f", runtime.execs[1].Cmd[3]}, runtime.execs[3].Cmd)
+	})
+
+	t.Run("fails with why the container can't run the server before copying it", func(t *testing.T) {
+		original := agentArch
+		t.Cleanup(func() { agentArch = original })
+		agentArch = "amd64"
+
+		cases := []struct {
+			description string
+			runtime     *runtime
+			err         error
+		}{
+			{
+				description: "without /bin/sh",
+				runtime:     &runtime{preflightCode: 127},
+				err:         ErrSFTPNoShell,
+			},
+			{
+				description: "with a read-only root filesystem",
+				runtime:     &runtime{preflight: "read-only"},
+				err:         ErrSFTPReadOnly,
+			},
+			{
+				description: "with a noexec temporary directory",
+				runtime:     &runtime{preflight: "noexec"},
+				err:         ErrSFTPNoExec,
+			},
+			{
+				description: "on another architecture",
+				runtime:     &runtime{preflight: "aarch64"},
+				err:         ErrSFTPArch,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.description, func(t *testing.T) {
+				_, err := attachSFTPToContainer(context.Background(), tc.runtime, "web", user, executable, &connector.ExecConfig{})
+				require.ErrorIs(t, err, tc.err)
+
+				assert.Len(t, tc.runtime.execs, 1)
+			})
+		}
+	})
+}
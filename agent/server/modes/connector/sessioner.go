@@ -4,11 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"sync"
+	"os"
 
-	dockerclient "github.com/docker/docker/client"
-	"github.com/docker/docker/pkg/stdcopy"
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/agent/pkg/connector"
 	"github.com/shellhub-io/shellhub/agent/pkg/osauth"
 	"github.com/shellhub-io/shellhub/agent/server/modes"
 )
@@ -24,22 +23,57 @@ type Sessioner struct {
 	//
 	// NOTICE: It's a pointer because when the server is created, we don't know the device name yet, that is set later.
 	container *string
-	docker    dockerclient.APIClient
+	runtime   connector.Runtime
 }
 
 // NewSessioner creates a new instance of Sessioner for the connector mode.
 // The container is a pointer to a string because when the server is created, we don't know the device name yet, that
 // is set later.
-func NewSessioner(container *string, docker dockerclient.APIClient) *Sessioner {
+func NewSessioner(container *string, runtime connector.Runtime) *Sessioner {
 	return &Sessioner{
 		container: container,
-		docker:    docker,
+		runtime:   runtime,
 	}
 }
 
+// relay copies the session's stdin and terminal size to the process, until it exits, and sends its exit code back to
+// the session. When the session's stdin ends, done is called with the process.
+func relay(session gliderssh.Session, proc connector.Process, winCh <-chan gliderssh.Window, done func(connector.Process) error) {
+	defer proc.Close()
+
+	if winCh != nil {
+		go func() {
+			// NOTE: The channel is closed when the session ends, and must be drained for the session to keep on
+			// receiving its requests.
+			for win := range winCh {
+				if err := proc.Resize(uint(win.Height), uint(win.Width)); err != nil { //nolint:gosec
+					fmt.Println(err) //nolint:forbidigo
+				}
+			}
+		}()
+	}
+
+	go func() {
+		if _, err := io.Copy(proc, session); err != nil && err != io.EOF {
+			fmt.Println(err) //nolint:forbidigo
+		}
+
+		if err := done(proc); err != nil {
+			fmt.Println(err) //nolint:forbidigo
+		}
+	}()
+
+	code, err := proc.Wait()
+	if err != nil {
+		fmt.Println(err) //nolint:forbidigo
+	}
+
+	session.Exit(code) //nolint:errcheck
+}
+
 // Shell handles the server's SSH shell session when server is running in connector mode.
 func (s *Sessioner) Shell(session gliderssh.Session) error {
-	sspty, _, _ := session.Pty()
+	sspty, winCh, _ := session.Pty()
 
 	// NOTICE(r): To identify what the container the connector should connect to, we use the `deviceName` as the container name
 	container := *s.container
@@ -49,48 +83,26 @@ func (s *Sessioner) Shell(session gliderssh.Session) error {
 		return ErrUserNotFound
 	}
 
-	resp, id, err := attachShellToContainer(session.Context(), s.docker, container, user, [2]int{sspty.Window.Height, sspty.Window.Width})
+	proc, err := attachShellToContainer(session.Context(), s.runtime, container, user, &connector.ExecConfig{
+		TTY:    true,
+		Height: uint(sspty.Window.Height), //nolint:gosec
+		Width:  uint(sspty.Window.Width),  //nolint:gosec
+		Stdout: session,
+		Stderr: session.Stderr(),
+	})
 	if err != nil {
 		return err
 	}
-	defer resp.Close()
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer func() {
-			code, err := exitCodeExecFromContainer(s.docker, id)
-			if err != nil {
-				fmt.Println(err)
-			}
-
-			session.Exit(code) //nolint:errcheck
-		}()
-
-		if _, err := io.Copy(session, resp.Conn); err != nil && err != io.EOF {
-			fmt.Println(err)
-		}
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer resp.Close()
-
-		if _, err := io.Copy(resp.Conn, session); err != nil && err != io.EOF {
-			fmt.Println(err)
-		}
-	}()
-
-	wg.Wait()
+	// NOTE: The shell's stdin only ends when the client is gone, so the shell goes with it.
+	relay(session, proc, winCh, connector.Process.Close)
 
 	return nil
 }
 
 // Exec handles the SSH's server exec session when server is running in connector mode.
 func (s *Sessioner) Exec(session gliderssh.Session) error {
-	sspty, _, isPty := session.Pty()
+	sspty, winCh, isPty := session.Pty()
 
 	// NOTICE(r): To identify what the container the connector should connect to, we use the `deviceName` as the container name
 	container := *s.container
@@ -100,52 +112,18 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		return ErrUserNotFound
 	}
 
-	resp, id, err := attachExecToContainer(session.Context(), s.docker, container, user, isPty, session.Command(), [2]int{sspty.Window.Height, sspty.Window.Width})
+	proc, err := attachExecToContainer(session.Context(), s.runtime, container, user, session.Command(), &connector.ExecConfig{
+		TTY:    isPty,
+		Height: uint(sspty.Window.Height), //nolint:gosec
+		Width:  uint(sspty.Window.Width),  //nolint:gosec
+		Stdout: session,
+		Stderr: session.Stderr(),
+	})
 	if err != nil {
 		return err
 	}
-	defer resp.Close()
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer func() {
-			code, err := exitCodeExecFromContainer(s.docker, id)
-			if err != nil {
-				fmt.Println(err)
-			}
-
-			session.Exit(code) //nolint:errcheck
-		}()
-
-		// NOTICE: According to the [Docker] documentation, we can "demultiplex" a command sent to container, but only
-		// when the exec started doesn't allocate a TTY. As a result, we check if the exec's is requesting it and do
-		// what was recommended by [Docker]'s to get the stdout and stderr separately.
-		//
-		// [Docker]: https://pkg.go.dev/github.com/docker/docker/client#Client.ContainerAttach
-		if isPty {
-			if _, err := io.Copy(session, resp.Reader); err != nil && err != io.EOF {
-				fmt.Println(err)
-			}
-		} else {
-			if _, err := stdcopy.StdCopy(session, session.Stderr(), resp.Reader); err != nil && err != io.EOF {
-				fmt.Println(err)
-			}
-		}
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer resp.CloseWrite() //nolint:errcheck
-
-		if _, err := io.Copy(resp.Conn, session); err != nil && err != io.EOF {
-			fmt.Println(err)
-		}
-	}()
-
-	wg.Wait()
+	relay(session, proc, winCh, connector.Process.CloseWrite)
 
 	return nil
 }
@@ -165,48 +143,48 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 		return ErrUserNotFound
 	}
 
-	resp, id, err := attachHereDocToContainer(session.Context(), s.docker, container, user, [2]int{sspty.Window.Height, sspty.Window.Width})
+	proc, err := attachHereDocToContainer(session.Context(), s.runtime, container, user, &connector.ExecConfig{
+		Height: uint(sspty.Window.Height), //nolint:gosec
+		Width:  uint(sspty.Window.Width),  //nolint:gosec
+		Stdout: session,
+		Stderr: session.Stderr(),
+	})
 	if err != nil {
 		return err
 	}
-	defer resp.Close()
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer func() {
-			code, err := exitCodeExecFromContainer(s.docker, id)
-			if err != nil {
-				fmt.Println(err)
-			}
+	relay(session, proc, nil, connector.Process.CloseWrite)
 
-			session.Exit(code) //nolint:errcheck
-		}()
+	return nil
+}
 
-		if _, err := stdcopy.StdCopy(session, session.Stderr(), resp.Reader); err != nil && err != io.EOF {
-			fmt.Println(err)
-		}
-	}()
+// SFTP handles the SSH's server sftp session when server is running in connector mode.
+//
+// sftp is a subsystem of SSH that allows file operations over SSH. The SFTP server runs inside the container as the
+// user, so the user can only access the files their permissions allow.
+func (s *Sessioner) SFTP(session gliderssh.Session) error {
+	// NOTICE(r): To identify what the container the connector should connect to, we use the `deviceName` as the container name
+	container := *s.container
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer resp.CloseWrite() //nolint:errcheck
+	user, ok := session.Context().Value("user").(*osauth.User)
+	if !ok {
+		return ErrUserNotFound
+	}
 
-		if _, err := io.Copy(resp.Conn, session); err != nil && err != io.EOF {
-			fmt.Println(err)
-		}
-	}()
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
 
-	wg.Wait()
+	proc, err := attachSFTPToContainer(session.Context(), s.runtime, container, user, executable, &connector.ExecConfig{
+		Stdout: session,
+		Stderr: session.Stderr(),
+	})
+	if err != nil {
+		return err
+	}
 
-	return nil
-}
+	relay(session, proc, nil, connector.Process.CloseWrite)
 
-// SFTP handles the SSH's server sftp session when server is running in connector mode.
-//
-// sftp is a subsystem of SSH that allows file operations over SSH.
-func (s *Sessioner) SFTP(_ gliderssh.Session) error {
-	return errors.New("SFTP isn't supported to ShellHub Agent in connector mode")
+	return nil
 }
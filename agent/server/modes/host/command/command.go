@@ -5,4 +5,7 @@ type SFTPServerMode string
 const (
 	SFTPServerModeNative SFTPServerMode = "native"
 	SFTPServerModeDocker SFTPServerMode = "docker"
+	// SFTPServerModeConnector runs the SFTP server inside a container, where the connector copies the agent's
+	// executable to and runs it as the user.
+	SFTPServerModeConnector SFTPServerMode = "connector"
 )
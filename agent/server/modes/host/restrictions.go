@@ -0,0 +1,165 @@
+package host
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// DeniedRequest is the request the agent sends to ShellHub's SSH server when a login's restriction denies something,
+// so the denial is recorded as a session event. It is sent on the session's channel, or on the connection for what
+// isn't part of a session, such as a port forward.
+const DeniedRequest = "denied@shellhub.io"
+
+// AnyLogin is the login whose restriction applies to the logins without one of their own.
+const AnyLogin = "*"
+
+// commandArgument matches what a `*` in a permitted command stands for: any text without the characters the shell
+// would interpret to run something else, so a permitted command can't be chained to another one.
+const commandArgument = "[^;&|<>$`\\\\()\\n\\r]*"
+
+// Restriction restricts what a login may do on the device, in the spirit of OpenSSH's ForceCommand and the no-pty,
+// no-port-forwarding options of its authorized keys.
+type Restriction struct {
+	// Commands lists the commands the login may run, where `*` stands for any arguments. When set, the login can't
+	// start a shell, whose input could run anything, and can only exec a command that matches one of them.
+	Commands []string `json:"commands"`
+	// ForceCommand is run instead of the shell or command the login asks for, which is passed to it on the
+	// SSH_ORIGINAL_COMMAND environment variable. When set, Commands isn't checked and SFTP is denied.
+	ForceCommand string `json:"force_command"`
+	// NoPty denies the login a pseudo-terminal.
+	NoPty bool `json:"no_pty"`
+	// NoSFTP denies the login SFTP.
+	NoSFTP bool `json:"no_sftp"`
+	// NoPortForwarding denies the login local and remote port forwarding.
+	NoPortForwarding bool `json:"no_port_forwarding"`
+
+	commands []*regexp.Regexp
+}
+
+// Permits reports whether the login may run the command.
+func (r *Restriction) Permits(command string) bool {
+	if r == nil || len(r.Commands) == 0 {
+		return true
+	}
+
+	for _, pattern := range r.commands {
+		if pattern.MatchString(command) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Restrictions maps logins to their restriction. The restriction of [AnyLogin] applies to the logins without one.
+type Restrictions map[string]*Restriction
+
+// LoadRestrictions reads restrictions encoded as a JSON object, keyed by the logins.
+//
+//	{
+//	  "backup": {"force_command": "/usr/local/bin/backup"},
+//	  "operator": {"commands": ["systemctl status *", "journalctl -u *"], "no_port_forwarding": true},
+//	  "*": {"no_sftp": true}
+//	}
+func LoadRestrictions(reader io.Reader) (Restrictions, error) {
+	var restrictions Restrictions
+	if err := json.NewDecoder(reader).Decode(&restrictions); err != nil {
+		return nil, fmt.Errorf("failed to decode the restrictions: %w", err)
+	}
+
+	for login, restriction := range restrictions {
+		if restriction == nil {
+			return nil, fmt.Errorf("restriction of %q is empty", login)
+		}
+
+		for _, command := range restriction.Commands {
+			parts := strings.Split(command, "*")
+			for i, part := range parts {
+				parts[i] = regexp.QuoteMeta(part)
+			}
+
+			restriction.commands = append(restriction.commands, regexp.MustCompile("^"+strings.Join(parts, commandArgument)+"$"))
+		}
+	}
+
+	return restrictions, nil
+}
+
+// For returns the login's restriction, or nil when the login isn't restricted.
+func (r Restrictions) For(login string) *Restriction {
+	if restriction, ok := r[login]; ok {
+		return restriction
+	}
+
+	return r[AnyLogin]
+}
+
+// denial is a [DeniedRequest] sent about a login.
+func denial(login, action, command, reason string) []byte {
+	log.WithFields(log.Fields{
+		"user":    login,
+		"action":  action,
+		"command": command,
+	}).Warn(reason)
+
+	return gossh.Marshal(&models.SSHDenied{Action: action, Command: command, Reason: reason})
+}
+
+// deny refuses the session, reporting the denial to ShellHub's SSH server and to the client, and exits it.
+func deny(session gliderssh.Session, action, command, reason string) error {
+	if _, err := session.SendRequest(DeniedRequest, false, denial(session.User(), action, command, reason)); err != nil {
+		log.WithError(err).Warn("failed to report the denial")
+	}
+
+	fmt.Fprintln(session.Stderr(), reason) //nolint:errcheck
+	_ = session.Exit(1)
+
+	return fmt.Errorf("denied: %s", reason)
+}
+
+// AllowPty reports whether the login may have a pseudo-terminal, reporting the denial on the session when it may not.
+func (s *Sessioner) AllowPty(session gliderssh.Session) bool {
+	if !s.restrictions.For(session.User()).noPty() {
+		return true
+	}
+
+	reason := "pseudo-terminal allocation is disabled for this login"
+	if _, err := session.SendRequest(DeniedRequest, false, denial(session.User(), "pty-req", "", reason)); err != nil {
+		log.WithError(err).Warn("failed to report the denial")
+	}
+
+	return false
+}
+
+// AllowPortForwarding reports whether the login may forward a port, reporting the denial on the connection when it
+// may not. The kind is the SSH channel or request that asks for the forward.
+func (s *Sessioner) AllowPortForwarding(ctx gliderssh.Context, kind string) bool {
+	if !s.restrictions.For(ctx.User()).noPortForwarding() {
+		return true
+	}
+
+	reason := "port forwarding is disabled for this login"
+	if conn, ok := ctx.Value(gliderssh.ContextKeyConn).(gossh.Conn); ok {
+		if _, _, err := conn.SendRequest(DeniedRequest, false, denial(ctx.User(), kind, "", reason)); err != nil {
+			log.WithError(err).Warn("failed to report the denial")
+		}
+	}
+
+	return false
+}
+
+func (r *Restriction) noPty() bool {
+	return r != nil && r.NoPty
+}
+
+func (r *Restriction) noPortForwarding() bool {
+	return r != nil && r.NoPortForwarding
+}
@@ -0,0 +1,116 @@
+package host
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestLoadRestrictions(t *testing.T) {
+	restrictions, err := LoadRestrictions(strings.NewReader(`{
+		"operator": {"commands": ["uptime", "systemctl status *"], "no_pty": true},
+		"*": {"no_sftp": true}
+	}`))
+	require.NoError(t, err)
+
+	t.Run("falls back to the restriction of any login", func(t *testing.T) {
+		assert.True(t, restrictions.For("operator").NoPty)
+		assert.True(t, restrictions.For("root").NoSFTP)
+		assert.Nil(t, Restrictions(nil).For("root"))
+	})
+
+	t.Run("permits the listed commands only", func(t *testing.T) {
+		operator := restrictions.For("operator")
+
+		cases := []struct {
+			command string
+			permits bool
+		}{
+			{command: "uptime", permits: true},
+			{command: "systemctl status nginx", permits: true},
+			{command: "systemctl status nginx sshd", permits: true},
+			{command: "uptime -p", permits: false},
+			{command: "systemctl restart nginx", permits: false},
+			{command: "systemctl status nginx; rm -rf /", permits: false},
+			{command: "systemctl status $(reboot)", permits: false},
+			{command: "systemctl status nginx | sh", permits: false},
+			{command: "systemctl status nginx\nreboot", permits: false},
+		}
+
+		for _, tc := range cases {
+			assert.Equal(t, tc.permits, operator.Permits(tc.command), tc.command)
+		}
+
+		assert.True(t, restrictions.For("root").Permits("reboot"))
+		assert.True(t, (*Restriction)(nil).Permits("reboot"))
+	})
+
+	t.Run("fails on an empty restriction", func(t *testing.T) {
+		_, err := LoadRestrictions(strings.NewReader(`{"root": null}`))
+		assert.Error(t, err)
+	})
+}
+
+// denied decodes the denial reported on the session.
+func denied(t *testing.T, sess *fakeSession) *models.SSHDenied {
+	t.Helper()
+
+	require.Len(t, sess.requests, 1)
+	require.Equal(t, DeniedRequest, sess.requests[0].Type)
+
+	var denial models.SSHDenied
+	require.NoError(t, gossh.Unmarshal(sess.requests[0].Payload, &denial))
+
+	return &denial
+}
+
+func TestSessionerRestrictions(t *testing.T) {
+	origCheckCredentialSwitch := checkCredentialSwitchFn
+	t.Cleanup(func() { checkCredentialSwitchFn = origCheckCredentialSwitch })
+
+	checkCredentialSwitchFn = func() error { return nil }
+
+	restrictions, err := LoadRestrictions(strings.NewReader(`{"operator": {"commands": ["uptime"], "no_pty": true, "no_sftp": true}}`))
+	require.NoError(t, err)
+
+	deviceName := "test-device"
+	s := NewSessioner(&deviceName, nil, restrictions)
+
+	t.Run("denies a command that isn't permitted", func(t *testing.T) {
+		sess := newFakeSession("session-exec", "operator")
+		sess.command = []string{"reboot"}
+		sess.rawCommand = "reboot"
+
+		assert.Error(t, s.Exec(sess))
+		assert.Equal(t, int32(1), sess.exitCode)
+		assert.Equal(t, &models.SSHDenied{Action: "exec", Command: "reboot", Reason: "command is not permitted for this login"}, denied(t, sess))
+	})
+
+	t.Run("denies a shell to a login restricted to some commands", func(t *testing.T) {
+		sess := newFakeSession("session-shell", "operator")
+
+		assert.Error(t, s.Shell(sess))
+		assert.Equal(t, int32(1), sess.exitCode)
+		assert.Equal(t, "shell", denied(t, sess).Action)
+	})
+
+	t.Run("denies SFTP", func(t *testing.T) {
+		sess := newFakeSession("session-sftp", "operator")
+
+		assert.Error(t, s.SFTP(sess))
+		assert.Equal(t, &models.SSHDenied{Action: "subsystem", Command: "sftp", Reason: "SFTP is disabled for this login"}, denied(t, sess))
+	})
+
+	t.Run("denies a pseudo-terminal", func(t *testing.T) {
+		sess := newFakeSession("session-pty", "operator")
+
+		assert.False(t, s.AllowPty(sess))
+		assert.Equal(t, "pty-req", denied(t, sess).Action)
+
+		assert.True(t, s.AllowPty(newFakeSession("session-pty-root", "root")))
+	})
+}
@@ -106,6 +106,8 @@ type Sessioner struct {
 	// (/proc/self/exe) with the "sftp" subcommand. It can be overridden so the agent can run
 	// embedded in another binary, where /proc/self/exe is not the agent.
 	sftpServerCommand func() *exec.Cmd
+	// restrictions restricts what each login may do on the device.
+	restrictions Restrictions
 }
 
 // NewSessioner creates a new instance of Sessioner for the host mode.
@@ -115,19 +117,50 @@ type Sessioner struct {
 // sftpServerCommand builds the command used to start the SFTP server subprocess. When nil,
 // [command.SFTPServerCommand] is used (re-executing /proc/self/exe). It can be overridden so
 // the agent can run embedded in another binary, where /proc/self/exe is not the agent.
-func NewSessioner(deviceName *string, sftpServerCommand func() *exec.Cmd) *Sessioner {
+//
+// restrictions restricts what each login may do on the device. When nil, every login may do anything.
+func NewSessioner(deviceName *string, sftpServerCommand func() *exec.Cmd, restrictions Restrictions) *Sessioner {
 	return &Sessioner{
 		deviceName:        deviceName,
 		sftpServerCommand: sftpServerCommand,
+		restrictions:      restrictions,
 	}
 }
 
+// forced runs the login's forced command instead of what the session asked for, when it has one, reporting whether
+// it did.
+func (s *Sessioner) forced(session gliderssh.Session) (bool, error) {
+	restriction := s.restrictions.For(session.User())
+	if restriction == nil || restriction.ForceCommand == "" {
+		return false, nil
+	}
+
+	return true, s.exec(session, restriction.ForceCommand, "SSH_ORIGINAL_COMMAND="+session.RawCommand())
+}
+
+// refuseIfShellRestricted denies a shell, whose input could run anything, to a login restricted to some commands.
+func (s *Sessioner) refuseIfShellRestricted(session gliderssh.Session, action string) error {
+	if restriction := s.restrictions.For(session.User()); restriction != nil && len(restriction.Commands) > 0 {
+		return deny(session, action, "", "shell access is disabled for this login")
+	}
+
+	return nil
+}
+
 // Shell manages the SSH shell session of the server when operating in host mode.
 func (s *Sessioner) Shell(session gliderssh.Session) error {
 	if err := refuseIfCredentialSwitchDenied(session); err != nil {
 		return err
 	}
 
+	if ok, err := s.forced(session); ok {
+		return err
+	}
+
+	if err := s.refuseIfShellRestricted(session, "shell"); err != nil {
+		return err
+	}
+
 	sspty, _, isPty := session.Pty()
 
 	scmd := generateShellCmd(*s.deviceName, session, sspty.Term)
@@ -234,6 +267,14 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 		return err
 	}
 
+	if ok, err := s.forced(session); ok {
+		return err
+	}
+
+	if err := s.refuseIfShellRestricted(session, "shell"); err != nil {
+		return err
+	}
+
 	_, _, isPty := session.Pty()
 
 	cmd := generateShellCmd(*s.deviceName, session, "")
@@ -301,6 +342,10 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		return err
 	}
 
+	if ok, err := s.forced(session); ok {
+		return err
+	}
+
 	if len(session.Command()) == 0 {
 		log.WithFields(log.Fields{
 			"user":      session.User(),
@@ -313,6 +358,15 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		return nil
 	}
 
+	if !s.restrictions.For(session.User()).Permits(session.RawCommand()) {
+		return deny(session, "exec", session.RawCommand(), "command is not permitted for this login")
+	}
+
+	return s.exec(session, session.RawCommand())
+}
+
+// exec runs the raw command through the user's shell, with the session's environment and env.
+func (s *Sessioner) exec(session gliderssh.Session, raw string, env ...string) error {
 	user, err := osauth.LookupUser(session.User())
 	if err != nil {
 		return err
@@ -330,7 +384,7 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		term = "xterm"
 	}
 
-	cmd := command.NewCmd(user, shell, term, *s.deviceName, sessionEnv(session.Environ()), shell, "-c", session.RawCommand())
+	cmd := command.NewCmd(user, shell, term, *s.deviceName, append(sessionEnv(session.Environ()), env...), shell, "-c", raw)
 
 	wg := &sync.WaitGroup{}
 	if !sIsPty {
@@ -355,7 +409,7 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		"ispty":       sIsPty,
 		"remoteaddr":  session.RemoteAddr(),
 		"localaddr":   session.LocalAddr(),
-		"Raw command": session.RawCommand(),
+		"Raw command": raw,
 	}).Info("Command started")
 
 	// Pty.Start starts the command itself, so only the pipe branch reaches
@@ -398,7 +452,7 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		"ispty":       sIsPty,
 		"remoteaddr":  session.RemoteAddr(),
 		"localaddr":   session.LocalAddr(),
-		"Raw command": session.RawCommand(),
+		"Raw command": raw,
 	}).Info("Command ended")
 
 	code := 1
@@ -422,6 +476,15 @@ func (s *Sessioner) SFTP(session gliderssh.Session) error {
 	}).Info("SFTP session started")
 	defer session.Close()
 
+	if restriction := s.restrictions.For(session.User()); restriction != nil {
+		switch {
+		case restriction.NoSFTP:
+			return deny(session, "subsystem", "sftp", "SFTP is disabled for this login")
+		case restriction.ForceCommand != "":
+			return deny(session, "subsystem", "sftp", "SFTP is disabled for a login with a forced command")
+		}
+	}
+
 	newSFTPServerCommand := command.SFTPServerCommand
 	if s.sftpServerCommand != nil {
 		newSFTPServerCommand = s.sftpServerCommand
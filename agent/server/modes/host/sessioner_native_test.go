@@ -64,7 +64,7 @@ func TestShell_DeniedCredentialSwitch(t *testing.T) {
 	}
 
 	deviceName := "test-device"
-	s := NewSessioner(&deviceName, nil)
+	s := NewSessioner(&deviceName, nil, nil)
 
 	sess := newFakeSession("session-cred-switch", "root")
 
@@ -96,7 +96,7 @@ func TestExec_DeniedCredentialSwitch(t *testing.T) {
 	}
 
 	deviceName := "test-device"
-	s := NewSessioner(&deviceName, nil)
+	s := NewSessioner(&deviceName, nil, nil)
 
 	sess := newFakeSession("session-exec-cred-switch", "root")
 	sess.command = []string{"/bin/true"}
@@ -141,7 +141,7 @@ func TestHeredoc_StartFailure(t *testing.T) {
 	osauthMock.On("ListGroups", mock.AnythingOfType("string")).Return([]uint32{}, nil).Maybe()
 
 	deviceName := "test-device"
-	s := NewSessioner(&deviceName, nil)
+	s := NewSessioner(&deviceName, nil, nil)
 
 	sess := newFakeSession("session-heredoc-start-fail", "root")
 
@@ -179,7 +179,7 @@ func TestHeredoc_DeniedCredentialSwitch(t *testing.T) {
 	}
 
 	deviceName := "test-device"
-	s := NewSessioner(&deviceName, nil)
+	s := NewSessioner(&deviceName, nil, nil)
 
 	sess := newFakeSession("session-heredoc-cred-switch", "root")
 
@@ -221,7 +221,7 @@ func TestExec_NonPty_SucceedingCommand(t *testing.T) {
 	osauthMock.On("ListGroups", mock.AnythingOfType("string")).Return([]uint32{}, nil).Maybe()
 
 	deviceName := "test-device"
-	s := NewSessioner(&deviceName, nil)
+	s := NewSessioner(&deviceName, nil, nil)
 
 	sess := newFakeSession("session-exec-npty", "root")
 	sess.isPty = false
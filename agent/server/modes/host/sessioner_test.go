@@ -36,6 +36,8 @@ type fakeSession struct {
 	ctx        gliderssh.Context
 	command    []string
 	rawCommand string
+	// requests records the requests sent on the session.
+	requests []*gossh.Request
 
 	// exitCalled tracks whether Exit() was called and with what code.
 	exitCalled int32 // atomic: 0 = not called, 1 = called
@@ -53,6 +55,8 @@ func (f *fakeSession) Close() error { return nil }
 func (f *fakeSession) CloseWrite() error { return nil }
 
 func (f *fakeSession) SendRequest(name string, wantReply bool, payload []byte) (bool, error) {
+	f.requests = append(f.requests, &gossh.Request{Type: name, WantReply: wantReply, Payload: payload})
+
 	return false, nil
 }
 
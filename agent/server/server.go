@@ -1,8 +1,10 @@
 package server
 
 import (
+	"errors"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
@@ -34,6 +36,11 @@ type Server struct {
 	// Check the [modes] package for more information.
 	mode     modes.Mode
 	Sessions sync.Map
+
+	// activeSessions counts the SSH connections being served.
+	activeSessions atomic.Int64
+	// keepAliveRTT is the round-trip time of the last keep alive request answered, in nanoseconds.
+	keepAliveRTT atomic.Int64
 }
 
 // SSH channels supported by the SSH server.
@@ -59,6 +66,22 @@ const (
 	ChannelDirectTcpip string = "direct-tcpip"
 )
 
+// SSH global requests supported by the SSH server.
+const (
+	// RequestTcpipForward asks the server to listen on a port and hand every connection it accepts back to the client
+	// through a "forwarded-tcpip" channel. It is how the gateway serves a remote port forward on the device.
+	//
+	// Check www.ietf.org/rfc/rfc4254.txt at section 7.1 for more information.
+	RequestTcpipForward string = "tcpip-forward"
+	// RequestCancelTcpipForward asks the server to stop listening for a forward set by [RequestTcpipForward].
+	//
+	// Check www.ietf.org/rfc/rfc4254.txt at section 7.1 for more information.
+	RequestCancelTcpipForward string = "cancel-tcpip-forward"
+)
+
+// ErrPtyDenied is returned when a login's restrictions deny it a pseudo-terminal.
+var ErrPtyDenied = errors.New("pseudo-terminal denied by the login's restrictions")
+
 type Feature uint
 
 const (
@@ -97,11 +120,18 @@ func NewServer(api client.Client, mode modes.Mode, cfg *Config) *Server {
 		SubsystemHandlers: map[string]gliderssh.SubsystemHandler{
 			SFTPSubsystemName: server.sftpSubsystemHandler,
 		},
-		LocalPortForwardingCallback: func(_ gliderssh.Context, _ string, _ uint32) bool {
-			return cfg.Features&LocalPortForwardFeature > 0
+		LocalPortForwardingCallback: func(ctx gliderssh.Context, _ string, _ uint32) bool {
+			return cfg.Features&LocalPortForwardFeature > 0 && server.allowPortForwarding(ctx, ChannelDirectTcpip)
 		},
-		ReversePortForwardingCallback: func(_ gliderssh.Context, _ string, _ uint32) bool {
-			return cfg.Features&ReversePortForwardFeature > 0
+		ReversePortForwardingCallback: func(ctx gliderssh.Context, _ string, port uint32) bool {
+			if cfg.Features&ReversePortForwardFeature == 0 || !server.allowPortForwarding(ctx, RequestTcpipForward) {
+				return false
+			}
+
+			// The listener is opened by the agent, which usually runs as root, so
+			// binding a privileged port is left to those who could do it anyway,
+			// as OpenSSH does.
+			return port == 0 || port >= 1024 || ctx.User() == "root"
 		},
 		ChannelHandlers: map[string]gliderssh.ChannelHandler{
 			ChannelSession:     gliderssh.DefaultSessionHandler,
@@ -109,6 +139,15 @@ func NewServer(api client.Client, mode modes.Mode, cfg *Config) *Server {
 		},
 	}
 
+	if cfg.Features&ReversePortForwardFeature > 0 {
+		forwards := new(gliderssh.ForwardedTCPHandler)
+
+		server.sshd.RequestHandlers = map[string]gliderssh.RequestHandler{
+			RequestTcpipForward:       forwards.HandleSSHRequest,
+			RequestCancelTcpipForward: forwards.HandleSSHRequest,
+		}
+	}
+
 	// Host mode only: in connector mode the pty lives inside the container, so
 	// allocating one here would leave an orphan and a second reader on the
 	// channel.
@@ -118,6 +157,10 @@ func NewServer(api client.Client, mode modes.Mode, cfg *Config) *Server {
 	// its terminal and nothing says why.
 	if _, ok := mode.(*host.Mode); ok {
 		server.sshd.PtyHandler = func(ctx gliderssh.Context, sess gliderssh.Session, pty gliderssh.Pty) (func() error, error) {
+			if !mode.(*host.Mode).AllowPty(sess) {
+				return nil, ErrPtyDenied
+			}
+
 			closer, err := gliderssh.AllocatePtyHandler(ctx, sess, pty)
 			if err != nil {
 				entry := log.WithError(err)
@@ -140,6 +183,15 @@ func NewServer(api client.Client, mode modes.Mode, cfg *Config) *Server {
 	return server
 }
 
+// allowPortForwarding reports whether the login may forward a port. Only the host mode restricts it.
+func (s *Server) allowPortForwarding(ctx gliderssh.Context, kind string) bool {
+	if mode, ok := s.mode.(*host.Mode); ok {
+		return mode.AllowPortForwarding(ctx, kind)
+	}
+
+	return true
+}
+
 // startKeepAlive sends a keep alive message to the server every in keepAliveInterval seconds.
 func (s *Server) startKeepAliveLoop(session gliderssh.Session) {
 	interval := time.Duration(s.keepAliveInterval) * time.Second
@@ -156,9 +208,13 @@ loop:
 		select {
 		case <-ticker.C:
 			if conn, ok := session.Context().Value(gliderssh.ContextKeyConn).(gossh.Conn); ok {
+				sent := time.Now()
+
 				ok, _, err := conn.SendRequest("keepalive", true, nil)
 				if err != nil {
 					log.Error(err)
+				} else {
+					s.keepAliveRTT.Store(int64(time.Since(sent)))
 				}
 
 				log.WithField("reply", ok).Info("keepalive sent with WantReply=true")
@@ -201,9 +257,23 @@ func (s *Server) sessionRequestCallback(session gliderssh.Session, requestType s
 }
 
 func (s *Server) HandleConn(conn net.Conn) {
+	s.activeSessions.Add(1)
+	defer s.activeSessions.Add(-1)
+
 	s.sshd.HandleConn(conn)
 }
 
+// ActiveSessions returns the number of SSH connections being served.
+func (s *Server) ActiveSessions() int64 {
+	return s.activeSessions.Load()
+}
+
+// KeepAliveRTT returns the round-trip time of the last keep alive request answered by ShellHub's SSH server, or zero
+// when none was.
+func (s *Server) KeepAliveRTT() time.Duration {
+	return time.Duration(s.keepAliveRTT.Load())
+}
+
 func (s *Server) SetDeviceName(name string) {
 	s.deviceName = name
 }
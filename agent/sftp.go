@@ -32,6 +32,34 @@ func (p *pipe) Close() error {
 	return nil
 }
 
+// NewConnectorSFTPServer creates a new SFTP server inside a container, where the connector copied the agent's
+// executable to and runs it as the user, so it only has to remove the copy and start at the user's home directory.
+func NewConnectorSFTPServer(home string) {
+	piped := &pipe{os.Stdin, os.Stdout, os.Stderr}
+
+	if executable, err := os.Executable(); err == nil {
+		os.Remove(executable) //nolint:errcheck
+	}
+
+	// NOTE: A user without a home directory starts at the container's working directory instead.
+	if home != "" {
+		syscall.Chdir(home) //nolint:errcheck
+	}
+
+	server, err := sftp.NewServer(piped, []sftp.ServerOption{}...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return
+	}
+
+	if err := server.Serve(); err != io.EOF {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	server.Close()
+}
+
 // NewSFTPServer creates a new SFTP server when a new session is created between the agent and the server.
 func NewSFTPServer(mode command.SFTPServerMode) {
 	piped := &pipe{os.Stdin, os.Stdout, os.Stderr}
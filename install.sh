@@ -147,8 +147,10 @@ podman_install() {
     DEFAULT_CONTAINER_NAME="shellhub-connector"
     ARGS="$ARGS -e SHELLHUB_PRIVATE_KEYS=${PRIVATE_KEYS:-/host/etc/shellhub/connector/keys}"
     ARGS="$ARGS -e SHELLHUB_CONNECTOR_LABEL=${CONNECTOR_LABEL}"
+    ARGS="$ARGS -e SHELLHUB_CONNECTOR_RUNTIME=podman"
+    ARGS="$ARGS -e SHELLHUB_CONNECTOR_RUNTIME_ENDPOINT=unix:///var/run/podman/podman.sock"
 
-    echo "🚀 Starting ShellHub container in Docker Connector mode..."
+    echo "🚀 Starting ShellHub container in Podman Connector mode..."
     shift 1
     ;;
   *)
@@ -13,6 +13,9 @@ type UserClaims struct {
 	MFA bool `json:"mfa"`
 	// Admin indicates whether the user has administrative privileges.
 	Admin bool `json:"admin"`
+	// AuthMethod is how the user signed in, like "local" or "oidc". It is carried over to the tokens
+	// the user switches namespaces with, for the namespaces that refuse some methods.
+	AuthMethod string `json:"auth_method,omitempty"`
 }
 
 // DeviceClaims represents the attributes needed to authenticate a device.
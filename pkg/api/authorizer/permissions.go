@@ -71,8 +71,124 @@ const (
 	// SSHIdentityManage allows viewing and revoking any member's SSH identities
 	// in the namespace (offboarding). Owner/admin only.
 	SSHIdentityManage
+	// SSHUserCAManage allows managing the user certificate authorities the
+	// namespace trusts, and the certificates they revoked. Owner/admin only.
+	SSHUserCAManage
+	// AccessRequestCreate allows requesting temporary access to devices, and
+	// following and withdrawing one's own requests. Every human role.
+	AccessRequestCreate
+	// AccessRequestDecide allows granting, rejecting and revoking any member's
+	// access requests. Owner/admin only.
+	AccessRequestDecide
+	// AuditList allows reading the namespace's audit log. Owner/admin only.
+	AuditList
+	// WebhookManage allows managing the namespace's webhook subscriptions and
+	// replaying their deliveries. Owner/admin only.
+	WebhookManage
+	// SessionShadow allows watching an active session from the console, and
+	// typing into it alongside its user. Owner/admin only.
+	SessionShadow
 )
 
+// permissionNames are the names a permission is spelled with outside the
+// process, where the iota value would shift as permissions are added: an API
+// key's permission list is stored and sent with them.
+var permissionNames = map[Permission]string{
+	DeviceAccept:            "device:accept",
+	DeviceReject:            "device:reject",
+	DeviceUpdate:            "device:update",
+	DeviceRemove:            "device:remove",
+	DeviceConnect:           "device:connect",
+	DeviceRename:            "device:rename",
+	DeviceDetails:           "device:details",
+	DeviceCustomFieldUpdate: "device:custom-field-update",
+
+	TagCreate: "tag:create",
+	TagUpdate: "tag:update",
+	TagDelete: "tag:delete",
+
+	SessionPlay:    "session:play",
+	SessionClose:   "session:close",
+	SessionRemove:  "session:remove",
+	SessionDetails: "session:details",
+	SessionApprove: "session:approve",
+
+	FirewallCreate: "firewall:create",
+	FirewallEdit:   "firewall:edit",
+	FirewallRemove: "firewall:remove",
+
+	PublicKeyCreate: "public-key:create",
+	PublicKeyEdit:   "public-key:edit",
+	PublicKeyRemove: "public-key:remove",
+
+	NamespaceUpdate:              "namespace:update",
+	NamespaceAddMember:           "namespace:add-member",
+	NamespaceRemoveMember:        "namespace:remove-member",
+	NamespaceEditMember:          "namespace:edit-member",
+	NamespaceEnableSessionRecord: "namespace:enable-session-record",
+	NamespaceDelete:              "namespace:delete",
+
+	BillingCreateCustomer:      "billing:create-customer",
+	BillingChooseDevices:       "billing:choose-devices",
+	BillingAddPaymentMethod:    "billing:add-payment-method",
+	BillingUpdatePaymentMethod: "billing:update-payment-method",
+	BillingRemovePaymentMethod: "billing:remove-payment-method",
+	BillingCancelSubscription:  "billing:cancel-subscription",
+	BillingCreateSubscription:  "billing:create-subscription",
+	BillingGetPaymentMethod:    "billing:get-payment-method",
+	BillingGetSubscription:     "billing:get-subscription",
+
+	APIKeyCreate: "api-key:create",
+	APIKeyUpdate: "api-key:update",
+	APIKeyDelete: "api-key:delete",
+
+	InstallKeyCreate: "install-key:create",
+	InstallKeyUpdate: "install-key:update",
+	InstallKeyReveal: "install-key:reveal",
+	InstallKeyList:   "install-key:list",
+
+	ConnectorDelete: "connector:delete",
+	ConnectorUpdate: "connector:update",
+	ConnectorSet:    "connector:set",
+
+	TunnelsCreate: "tunnel:create",
+	TunnelsDelete: "tunnel:delete",
+
+	AccessPolicyManage: "access-policy:manage",
+
+	SSHIdentityAdd:    "ssh-identity:add",
+	SSHIdentityManage: "ssh-identity:manage",
+	SSHUserCAManage:   "ssh-user-ca:manage",
+
+	AccessRequestCreate: "access-request:create",
+	AccessRequestDecide: "access-request:decide",
+
+	AuditList:     "audit:list",
+	WebhookManage: "webhook:manage",
+	SessionShadow: "session:shadow",
+}
+
+// PermissionFromString returns the permission named str. It reports false when
+// no permission has that name.
+func PermissionFromString(str string) (Permission, bool) {
+	for permission, name := range permissionNames {
+		if name == str {
+			return permission, true
+		}
+	}
+
+	return 0, false
+}
+
+// String returns the permission's name, or "N/A" when it has none.
+func (p Permission) String() string {
+	if name, ok := permissionNames[p]; ok {
+		return name
+	}
+
+	return "N/A"
+}
+
 // servicePermissions is intentionally empty: a service account has no management
 // permissions (see [RoleService]).
 var servicePermissions = []Permission{}
@@ -82,6 +198,8 @@ var observerPermissions = []Permission{
 	DeviceDetails,
 
 	SessionDetails,
+
+	AccessRequestCreate,
 }
 
 var operatorPermissions = []Permission{
@@ -101,6 +219,8 @@ var operatorPermissions = []Permission{
 	SessionApprove,
 
 	SSHIdentityAdd,
+
+	AccessRequestCreate,
 }
 
 var adminPermissions = []Permission{
@@ -157,6 +277,15 @@ var adminPermissions = []Permission{
 
 	SSHIdentityAdd,
 	SSHIdentityManage,
+	SSHUserCAManage,
+
+	AccessRequestCreate,
+	AccessRequestDecide,
+
+	AuditList,
+
+	WebhookManage,
+	SessionShadow,
 }
 
 var ownerPermissions = []Permission{
@@ -223,4 +352,13 @@ var ownerPermissions = []Permission{
 
 	SSHIdentityAdd,
 	SSHIdentityManage,
+	SSHUserCAManage,
+
+	AccessRequestCreate,
+	AccessRequestDecide,
+
+	AuditList,
+
+	WebhookManage,
+	SessionShadow,
 }
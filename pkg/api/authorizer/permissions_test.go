@@ -0,0 +1,38 @@
+package authorizer_test
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionNames(t *testing.T) {
+	seen := make(map[string]authorizer.Permission)
+
+	// SessionShadow is the last permission; one added after it extends the loop.
+	for permission := authorizer.DeviceAccept; permission <= authorizer.SessionShadow; permission++ {
+		name := permission.String()
+		require.NotEqual(t, "N/A", name, "permission %d has no name", permission)
+
+		other, duplicated := seen[name]
+		require.False(t, duplicated, "permissions %d and %d share the name %q", permission, other, name)
+		seen[name] = permission
+
+		parsed, ok := authorizer.PermissionFromString(name)
+		require.True(t, ok)
+		assert.Equal(t, permission, parsed)
+	}
+}
+
+func TestPermissionFromString(t *testing.T) {
+	permission, ok := authorizer.PermissionFromString("device:remove")
+	assert.True(t, ok)
+	assert.Equal(t, authorizer.DeviceRemove, permission)
+
+	_, ok = authorizer.PermissionFromString("device:destroy")
+	assert.False(t, ok)
+
+	assert.Equal(t, "N/A", authorizer.Permission(-1).String())
+}
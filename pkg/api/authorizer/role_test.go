@@ -113,6 +113,12 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.AccessPolicyManage,
 				authorizer.SSHIdentityAdd,
 				authorizer.SSHIdentityManage,
+				authorizer.SSHUserCAManage,
+				authorizer.AccessRequestCreate,
+				authorizer.AccessRequestDecide,
+				authorizer.AuditList,
+				authorizer.WebhookManage,
+				authorizer.SessionShadow,
 			},
 		},
 		{
@@ -161,6 +167,12 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.AccessPolicyManage,
 				authorizer.SSHIdentityAdd,
 				authorizer.SSHIdentityManage,
+				authorizer.SSHUserCAManage,
+				authorizer.AccessRequestCreate,
+				authorizer.AccessRequestDecide,
+				authorizer.AuditList,
+				authorizer.WebhookManage,
+				authorizer.SessionShadow,
 			},
 		},
 		{
@@ -180,6 +192,7 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.SessionDetails,
 				authorizer.SessionApprove,
 				authorizer.SSHIdentityAdd,
+				authorizer.AccessRequestCreate,
 			},
 		},
 		{
@@ -189,6 +202,7 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.DeviceConnect,
 				authorizer.DeviceDetails,
 				authorizer.SessionDetails,
+				authorizer.AccessRequestCreate,
 			},
 		},
 	}
@@ -25,6 +25,9 @@ type commonAPI interface {
 
 type publicAPI interface {
 	GetInfo(agentVersion string) (*models.Info, error)
+	// GetInfoForDevice gets the server's information as [GetInfo] does, identifying the device whose agent asks for
+	// the server to decide on updating it. Unauthenticated.
+	GetInfoForDevice(agentVersion string, uid string) (*models.Info, error)
 	Endpoints() (*models.Endpoints, error)
 	AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error)
 	AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error)
@@ -33,6 +33,27 @@ func (c *client) GetInfo(agentVersion string) (*models.Info, error) {
 	return info, nil
 }
 
+func (c *client) GetInfoForDevice(agentVersion string, uid string) (*models.Info, error) {
+	var info *models.Info
+
+	response, err := c.http.R().
+		SetQueryParams(map[string]string{
+			"agent_version": agentVersion,
+			"device_uid":    uid,
+		}).
+		SetResult(&info).
+		Get("/info")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ErrorFromResponse(response); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
 func (c *client) AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error) {
 	var res *models.DeviceAuthResponse
 
@@ -597,6 +597,74 @@ func (_c *MockClient_GetInfo_Call) RunAndReturn(run func(agentVersion string) (*
 	return _c
 }
 
+// GetInfoForDevice provides a mock function for the type MockClient
+func (_mock *MockClient) GetInfoForDevice(agentVersion string, uid string) (*models.Info, error) {
+	ret := _mock.Called(agentVersion, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInfoForDevice")
+	}
+
+	var r0 *models.Info
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) (*models.Info, error)); ok {
+		return returnFunc(agentVersion, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) *models.Info); ok {
+		r0 = returnFunc(agentVersion, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Info)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(agentVersion, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetInfoForDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInfoForDevice'
+type MockClient_GetInfoForDevice_Call struct {
+	*mock.Call
+}
+
+// GetInfoForDevice is a helper method to define mock.On call
+//   - agentVersion string
+//   - uid string
+func (_e *MockClient_Expecter) GetInfoForDevice(agentVersion any, uid any) *MockClient_GetInfoForDevice_Call {
+	return &MockClient_GetInfoForDevice_Call{Call: _e.mock.On("GetInfoForDevice", agentVersion, uid)}
+}
+
+func (_c *MockClient_GetInfoForDevice_Call) Run(run func(agentVersion string, uid string)) *MockClient_GetInfoForDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetInfoForDevice_Call) Return(info *models.Info, err error) *MockClient_GetInfoForDevice_Call {
+	_c.Call.Return(info, err)
+	return _c
+}
+
+func (_c *MockClient_GetInfoForDevice_Call) RunAndReturn(run func(agentVersion string, uid string) (*models.Info, error)) *MockClient_GetInfoForDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListDevices provides a mock function for the type MockClient
 func (_mock *MockClient) ListDevices() ([]models.Device, error) {
 	ret := _mock.Called()
@@ -1,5 +1,7 @@
 package requests
 
+import "time"
+
 // AccessPolicyFilter selects the devices an access policy applies to. It is
 // either a hostname regexp or a set of tags, never both, mirroring the
 // public-key filter shape.
@@ -10,10 +12,25 @@ type AccessPolicyFilter struct {
 
 // AccessPolicySubject identifies who an access policy grants access to.
 type AccessPolicySubject struct {
-	Type  string `json:"type" validate:"required,oneof=user role all-members"`
+	Type  string `json:"type" validate:"required,oneof=user role all-members api-key"`
 	Value string `json:"value"`
 }
 
+// AccessPolicySchedule restricts an access policy to recurring weekly time
+// windows, read in Timezone (UTC when empty).
+type AccessPolicySchedule struct {
+	Timezone string                       `json:"timezone" validate:"omitempty,timezone"`
+	Windows  []AccessPolicyScheduleWindow `json:"windows" validate:"required,min=1,dive"`
+}
+
+// AccessPolicyScheduleWindow is a daily "HH:MM" time range opening on the given
+// weekdays. An End at or before Start runs past midnight.
+type AccessPolicyScheduleWindow struct {
+	Weekdays []string `json:"weekdays" validate:"required,min=1,dive,oneof=mon tue wed thu fri sat sun"`
+	Start    string   `json:"start" validate:"required,schedule_time"`
+	End      string   `json:"end" validate:"required,schedule_time"`
+}
+
 // AccessPolicyIDParam represents an access policy id as a path param.
 type AccessPolicyIDParam struct {
 	ID string `param:"id" validate:"required"`
@@ -32,29 +49,35 @@ type AccessPolicyGet struct {
 
 // AccessPolicyCreate is the structure to represent the request data for the create access policy endpoint.
 type AccessPolicyCreate struct {
-	Name          string              `json:"name" validate:"required"`
-	Subject       AccessPolicySubject `json:"subject" validate:"required"`
-	Filter        AccessPolicyFilter  `json:"filter" validate:"required"`
-	Logins        []string            `json:"logins" validate:"required,min=1,dive,required"`
-	SourceIP      []string            `json:"source_ip" validate:"omitempty,dive,cidr|ip"`
-	Action        string              `json:"action" validate:"omitempty,oneof=allow deny"`
-	RequireReauth bool                `json:"require_reauth" validate:""`
-	ReauthPeriod  *int                `json:"reauth_period" validate:"omitempty,gte=0"`
-	TenantID      string              `json:"-"`
+	Name          string                `json:"name" validate:"required"`
+	Subject       AccessPolicySubject   `json:"subject" validate:"required"`
+	Filter        AccessPolicyFilter    `json:"filter" validate:"required"`
+	Logins        []string              `json:"logins" validate:"required,min=1,dive,required"`
+	SourceIP      []string              `json:"source_ip" validate:"omitempty,dive,cidr|ip"`
+	Action        string                `json:"action" validate:"omitempty,oneof=allow deny"`
+	RequireReauth bool                  `json:"require_reauth" validate:""`
+	ReauthPeriod  *int                  `json:"reauth_period" validate:"omitempty,gte=0"`
+	Schedule      *AccessPolicySchedule `json:"schedule" validate:"omitempty"`
+	NotBefore     *time.Time            `json:"not_before" validate:"omitempty"`
+	NotAfter      *time.Time            `json:"not_after" validate:"omitempty"`
+	TenantID      string                `json:"-"`
 }
 
 // AccessPolicyUpdate is the structure to represent the request data for the update access policy endpoint.
 type AccessPolicyUpdate struct {
 	AccessPolicyIDParam
-	Name          string              `json:"name" validate:"required"`
-	Subject       AccessPolicySubject `json:"subject" validate:"required"`
-	Filter        AccessPolicyFilter  `json:"filter" validate:"required"`
-	Logins        []string            `json:"logins" validate:"required,min=1,dive,required"`
-	SourceIP      []string            `json:"source_ip" validate:"omitempty,dive,cidr|ip"`
-	Action        string              `json:"action" validate:"omitempty,oneof=allow deny"`
-	RequireReauth bool                `json:"require_reauth" validate:""`
-	ReauthPeriod  *int                `json:"reauth_period" validate:"omitempty,gte=0"`
-	TenantID      string              `json:"-"`
+	Name          string                `json:"name" validate:"required"`
+	Subject       AccessPolicySubject   `json:"subject" validate:"required"`
+	Filter        AccessPolicyFilter    `json:"filter" validate:"required"`
+	Logins        []string              `json:"logins" validate:"required,min=1,dive,required"`
+	SourceIP      []string              `json:"source_ip" validate:"omitempty,dive,cidr|ip"`
+	Action        string                `json:"action" validate:"omitempty,oneof=allow deny"`
+	RequireReauth bool                  `json:"require_reauth" validate:""`
+	ReauthPeriod  *int                  `json:"reauth_period" validate:"omitempty,gte=0"`
+	Schedule      *AccessPolicySchedule `json:"schedule" validate:"omitempty"`
+	NotBefore     *time.Time            `json:"not_before" validate:"omitempty"`
+	NotAfter      *time.Time            `json:"not_after" validate:"omitempty"`
+	TenantID      string                `json:"-"`
 }
 
 // AccessPolicyDelete is the structure to represent the request data for the delete access policy endpoint.
@@ -62,3 +85,29 @@ type AccessPolicyDelete struct {
 	AccessPolicyIDParam
 	TenantID string `json:"-"`
 }
+
+// AccessPolicySimulate is the request data for evaluating the access policies
+// for a hypothetical login, without connecting. It targets either one device or
+// every accepted device a filter selects.
+type AccessPolicySimulate struct {
+	TenantID string `json:"-"`
+	// PrincipalID is the id of the member, human or service account, whose access
+	// is simulated.
+	PrincipalID string              `json:"principal_id" validate:"required"`
+	DeviceUID   string              `json:"device_uid" validate:"required_without=Filter"`
+	Filter      *AccessPolicyFilter `json:"filter" validate:"required_without=DeviceUID,excluded_with=DeviceUID"`
+	Login       string              `json:"login" validate:"required"`
+	// SourceIP is required as a login always comes from somewhere: without it, a
+	// policy limited to source CIDRs could not be evaluated, and a deny one would
+	// report a denial the login would not get.
+	SourceIP string `json:"source_ip" validate:"required,ip"`
+	// At is the time the policies are evaluated at; now when omitted.
+	At *time.Time `json:"at"`
+}
+
+// AccessPolicyReach is the request data for listing every principal/login pair
+// the access policies let reach a device.
+type AccessPolicyReach struct {
+	TenantID  string `json:"-"`
+	DeviceUID string `param:"uid" validate:"required"`
+}
@@ -0,0 +1,57 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/query"
+
+// AccessRequestIDParam represents an access request id as a path param.
+type AccessRequestIDParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// AccessRequestList is the request data for listing access requests. All lists
+// every member's requests and is set only for callers allowed to decide them;
+// anyone else only sees their own.
+type AccessRequestList struct {
+	TenantID string `json:"-"`
+	UserID   string `json:"-"`
+	All      bool   `json:"-"`
+	query.Paginator
+	query.Filters
+	query.Sorter
+}
+
+// AccessRequestGet is the request data for the get access request endpoint. All
+// has the same meaning as in [AccessRequestList].
+type AccessRequestGet struct {
+	AccessRequestIDParam
+	TenantID string `json:"-"`
+	UserID   string `json:"-"`
+	All      bool   `json:"-"`
+}
+
+// AccessRequestCreate is the request data for asking temporary access to the
+// devices a filter selects. Duration is in seconds, from one minute to a week.
+type AccessRequestCreate struct {
+	Filter        AccessPolicyFilter `json:"filter" validate:"required"`
+	Logins        []string           `json:"logins" validate:"required,min=1,dive,required"`
+	Duration      int                `json:"duration" validate:"required,min=60,max=604800"`
+	Justification string             `json:"justification" validate:"required,max=1024"`
+	TenantID      string             `json:"-"`
+	UserID        string             `json:"-"`
+}
+
+// AccessRequestDecide is the request data for granting, rejecting or revoking an
+// access request. UserID is the approver.
+type AccessRequestDecide struct {
+	AccessRequestIDParam
+	Note     string `json:"note" validate:"max=1024"`
+	TenantID string `json:"-"`
+	UserID   string `json:"-"`
+}
+
+// AccessRequestCancel is the request data for a requester withdrawing their own
+// pending access request.
+type AccessRequestCancel struct {
+	AccessRequestIDParam
+	TenantID string `json:"-"`
+	UserID   string `json:"-"`
+}
@@ -13,6 +13,12 @@ type CreateAPIKey struct {
 	ExpiresAt int             `json:"expires_at" validate:"required,api-key_expires-at"`
 	Key       string          `json:"key" validate:"omitempty,uuid"`
 	OptRole   authorizer.Role `json:"role" validate:"omitempty,member_role"`
+	// Permissions narrows the key to these permission names. Empty keeps every permission of the role.
+	Permissions []string `json:"permissions" validate:"omitempty,dive,permission"`
+	// Tags restricts the key to devices carrying any of these tags. Empty reaches every device.
+	Tags []string `json:"tags" validate:"omitempty,dive,min=3,max=255,alphanum,ascii"`
+	// SourceIPs lists the addresses and CIDR blocks the key may be used from. Empty allows any.
+	SourceIPs []string `json:"source_ips" validate:"omitempty,dive,cidr|ip"`
 }
 
 type ListAPIKey struct {
@@ -29,6 +35,10 @@ type UpdateAPIKey struct {
 	CurrentName string          `param:"name" validate:"required"`
 	Name        string          `json:"name" validate:"omitempty,api-key_name"`
 	Role        authorizer.Role `json:"role" validate:"omitempty,member_role"`
+	// Permissions, Tags and SourceIPs replace the key's scope when non-nil; an empty list clears it.
+	Permissions []string `json:"permissions" validate:"omitempty,dive,permission"`
+	Tags        []string `json:"tags" validate:"omitempty,dive,min=3,max=255,alphanum,ascii"`
+	SourceIPs   []string `json:"source_ips" validate:"omitempty,dive,cidr|ip"`
 }
 
 type DeleteAPIKey struct {
@@ -0,0 +1,11 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/query"
+
+// AuditEventList is the request data for listing a namespace's audit log.
+type AuditEventList struct {
+	TenantID string `json:"-"`
+	query.Paginator
+	query.Filters
+	query.Sorter
+}
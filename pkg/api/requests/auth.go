@@ -13,4 +13,6 @@ type AuthTokenSwap struct {
 type CreateUserToken struct {
 	UserID   string `param:"id" header:"X-ID" validate:"required"`
 	TenantID string `param:"tenant" validate:"omitempty,uuid"`
+	// AuthMethod is how the user signed in, which the new token carries over.
+	AuthMethod string `header:"X-Auth-Method"`
 }
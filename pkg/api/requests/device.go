@@ -8,6 +8,9 @@ import (
 type DeviceList struct {
 	TenantID     string              `header:"X-Tenant-ID"`
 	DeviceStatus models.DeviceStatus `query:"status"` //  TODO: validate
+	// ScopeTags restricts the list to devices carrying any of these tags. It is never bound from
+	// the request: it is the device scope of the API key the request authenticated with.
+	ScopeTags []string `json:"-"`
 	query.Paginator
 	query.Sorter
 	query.Filters
@@ -0,0 +1,21 @@
+package requests
+
+// EventStreamSubscribe is the request data for following a namespace's live
+// events.
+type EventStreamSubscribe struct {
+	TenantID string `json:"-"`
+	// LastEventID is the ID of the last event the client got, to resume right
+	// after it. Browsers send it in the Last-Event-ID header when they reconnect
+	// on their own; the query parameter serves a first connection.
+	LastEventID string `query:"last_event_id"`
+	// ScopeTags restricts the stream to the events of devices carrying any of these tags. It is
+	// never bound from the request: it is the device scope of the API key the request
+	// authenticated with.
+	ScopeTags []string `json:"-"`
+	// UserID is the account following the stream, empty for an API key. ReadsAccessPolicies and
+	// ReadsSSHApprovals are whether its role, or its API key, may read the access policies and
+	// every member's SSH approvals. None of them is ever bound from the request.
+	UserID              string `json:"-"`
+	ReadsAccessPolicies bool   `json:"-"`
+	ReadsSSHApprovals   bool   `json:"-"`
+}
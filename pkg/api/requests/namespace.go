@@ -62,6 +62,7 @@ type NamespaceEdit struct {
 	Settings struct {
 		SessionRecord          *bool   `json:"session_record" validate:"omitempty"`
 		ConnectionAnnouncement *string `json:"connection_announcement" validate:"omitempty,min=0,max=4096"`
+		LocalAuthDisabled      *bool   `json:"local_auth_disabled" validate:"omitempty"`
 	} `json:"settings"`
 }
 
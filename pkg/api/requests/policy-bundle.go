@@ -0,0 +1,19 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/models"
+
+// PolicyBundleExport is the request data for exporting the current namespace's
+// access configuration as a bundle.
+type PolicyBundleExport struct {
+	TenantID string `json:"-"`
+}
+
+// PolicyBundleApply is the request data for converging the current namespace to
+// a bundle. The body is the bundle itself.
+type PolicyBundleApply struct {
+	TenantID string `json:"-"`
+	UserID   string `json:"-"`
+	// DryRun computes the plan without applying it.
+	DryRun bool `json:"-"`
+	models.PolicyBundle
+}
@@ -14,6 +14,10 @@ type SessionIDParam struct {
 
 type ListSessions struct {
 	TenantID string `header:"X-Tenant-ID"`
+	// ScopeTags restricts the request to the sessions on devices carrying any of these tags. It is
+	// never bound from the request: it is the device scope of the API key the request
+	// authenticated with.
+	ScopeTags []string `json:"-"`
 	query.Paginator
 	query.Filters
 }
@@ -21,6 +25,10 @@ type ListSessions struct {
 // SessionGet is the structure to represent the request data for get session endpoint.
 type SessionGet struct {
 	SessionIDParam
+	// ScopeTags restricts the request to the sessions on devices carrying any of these tags. It is
+	// never bound from the request: it is the device scope of the API key the request
+	// authenticated with.
+	ScopeTags []string `json:"-"`
 }
 
 // SessionAuthenticatedSet is the structure to represent the request data for set authenticated session endpoint.
@@ -41,6 +49,14 @@ type SessionCreate struct {
 	// UserID is the ShellHub account that authorized the session via browser
 	// approval. Empty for password/public-key and web-terminal sessions.
 	UserID string `json:"user_id" validate:""`
+	// Fingerprint is the SSH identity the session logged in with, in the identity access mode.
+	Fingerprint string `json:"fingerprint" validate:""`
+	// CertificateCAID and CertificatePrincipal are the trusted CA and the principal a certificate
+	// login was accepted through.
+	CertificateCAID      string `json:"certificate_ca_id" validate:""`
+	CertificatePrincipal string `json:"certificate_principal" validate:""`
+	// APIKey is the name of the API key that ran the session through the API, if any.
+	APIKey string `json:"api_key" validate:""`
 }
 
 // SessionFinish is the structure to represent the request data for finish session endpoint.
@@ -71,3 +87,61 @@ type SessionSeat struct {
 	SessionIDParam
 	ID int `json:"id"`
 }
+
+// SessionRecordingExport is the structure to represent the request data for the export session
+// recording endpoint.
+type SessionRecordingExport struct {
+	SessionIDParam
+	// Seat is the session's seat whose recording is exported.
+	Seat int `query:"seat" validate:"min=0"`
+	// Format is either "asciicast" or "text", defaulting to "asciicast".
+	Format string `query:"format" validate:"omitempty,oneof=asciicast text"`
+	// Tail, when set, exports only the recording's last lines of output rather than all of it.
+	Tail int `query:"tail" validate:"omitempty,min=1,max=10000"`
+	// ScopeTags restricts the request to the sessions on devices carrying any of these tags. It is
+	// never bound from the request: it is the device scope of the API key the request
+	// authenticated with.
+	ScopeTags []string `json:"-"`
+}
+
+// SessionSearch is the structure to represent the request data for the search sessions endpoint.
+type SessionSearch struct {
+	// Query is the phrase searched for in the sessions' output and commands.
+	Query string `query:"q" validate:"required,max=256"`
+	// Since and Until, as RFC 3339 timestamps, bound when the matches happened.
+	Since time.Time `query:"since"`
+	Until time.Time `query:"until"`
+	// ScopeTags restricts the request to the sessions on devices carrying any of these tags. It is
+	// never bound from the request: it is the device scope of the API key the request
+	// authenticated with.
+	ScopeTags []string `json:"-"`
+	query.Paginator
+}
+
+// SessionCommandsList is the structure to represent the request data for the list session
+// commands endpoint.
+type SessionCommandsList struct {
+	// Username lists the commands of the sessions logged in as this device user.
+	Username string `query:"username" validate:"omitempty,max=255"`
+	// UserID lists the commands of the sessions bound to this ShellHub account.
+	UserID string `query:"user_id" validate:"omitempty,uuid"`
+	// Since and Until, as RFC 3339 timestamps, bound when the commands ran.
+	Since time.Time `query:"since"`
+	Until time.Time `query:"until"`
+	// ScopeTags restricts the request to the sessions on devices carrying any of these tags. It is
+	// never bound from the request: it is the device scope of the API key the request
+	// authenticated with.
+	ScopeTags []string `json:"-"`
+	query.Paginator
+}
+
+// SessionCommandsGet is the structure to represent the request data for the get session
+// commands endpoint.
+type SessionCommandsGet struct {
+	SessionIDParam
+	// ScopeTags restricts the request to the sessions on devices carrying any of these tags. It is
+	// never bound from the request: it is the device scope of the API key the request
+	// authenticated with.
+	ScopeTags []string `json:"-"`
+	query.Paginator
+}
@@ -0,0 +1,40 @@
+package requests
+
+// SSHUserCAList is the request data for listing the user certificate authorities
+// the current namespace trusts.
+type SSHUserCAList struct {
+	TenantID string `json:"-"`
+}
+
+// SSHUserCACreate is the request data for trusting a user certificate authority
+// in the current namespace.
+type SSHUserCACreate struct {
+	TenantID string `json:"-"`
+	Name     string `json:"name" validate:"required"`
+	// Data is the CA's OpenSSH public key, as found in sshd's TrustedUserCAKeys.
+	Data string `json:"data" validate:"required"`
+	// RevokedSerials are serials of certificates from this CA that must be refused.
+	RevokedSerials []uint64 `json:"revoked_serials"`
+}
+
+// SSHUserCAIDParam represents a user certificate authority id as a path param.
+type SSHUserCAIDParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// SSHUserCAUpdate is the request data for updating a trusted user certificate
+// authority. The key itself cannot change: trusting another key is a new CA.
+type SSHUserCAUpdate struct {
+	SSHUserCAIDParam
+	TenantID string `json:"-"`
+	Name     string `json:"name" validate:"required"`
+	// RevokedSerials replaces the CA's revoked serials as a whole.
+	RevokedSerials []uint64 `json:"revoked_serials"`
+}
+
+// SSHUserCADelete is the request data for no longer trusting a user certificate
+// authority.
+type SSHUserCADelete struct {
+	SSHUserCAIDParam
+	TenantID string `json:"-"`
+}
@@ -3,6 +3,8 @@ package requests
 type GetSystemInfo struct {
 	Host string `header:"X-Forwarded-Host"`
 	Port int    `header:"X-Forwarded-Port"`
+	// DeviceUID identifies the device whose agent asks, for the server to decide on updating it.
+	DeviceUID string `query:"device_uid"`
 }
 
 type SystemInstallScript struct {
@@ -34,3 +34,12 @@ type AuthLocalUser struct {
 	Identifier models.UserAuthIdentifier `json:"username" validate:"required"`
 	Password   string                    `json:"password" validate:"required"`
 }
+
+// AuthOIDCUser is the structure to represent the query the OpenID Connect provider sends the user back
+// with once they sign in.
+type AuthOIDCUser struct {
+	Code  string `query:"code" validate:"required"`
+	State string `query:"state" validate:"required"`
+	// Binding is the state bound to the browser when the sign-in started.
+	Binding string `json:"-"`
+}
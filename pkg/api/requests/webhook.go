@@ -0,0 +1,69 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/query"
+
+// WebhookIDParam represents a webhook id as a path param.
+type WebhookIDParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// WebhookList is the request data for listing a namespace's webhooks.
+type WebhookList struct {
+	TenantID string `json:"-"`
+	query.Paginator
+	query.Filters
+	query.Sorter
+}
+
+// WebhookGet is the request data for the get webhook endpoint.
+type WebhookGet struct {
+	WebhookIDParam
+	TenantID string `json:"-"`
+}
+
+// WebhookCreate is the request data for subscribing a URL to namespace events.
+// Events are checked against the known event types in the service.
+type WebhookCreate struct {
+	Name     string   `json:"name" validate:"required,max=64"`
+	URL      string   `json:"url" validate:"required,url"`
+	Secret   string   `json:"secret" validate:"required,min=16,max=256"`
+	Events   []string `json:"events" validate:"required,min=1,dive,required"`
+	Enabled  *bool    `json:"enabled"`
+	TenantID string   `json:"-"`
+}
+
+// WebhookUpdate is the request data for changing a webhook. A nil field is left
+// unchanged.
+type WebhookUpdate struct {
+	WebhookIDParam
+	Name     *string  `json:"name" validate:"omitempty,max=64"`
+	URL      *string  `json:"url" validate:"omitempty,url"`
+	Secret   *string  `json:"secret" validate:"omitempty,min=16,max=256"`
+	Events   []string `json:"events" validate:"omitempty,min=1,dive,required"`
+	Enabled  *bool    `json:"enabled"`
+	TenantID string   `json:"-"`
+}
+
+// WebhookDelete is the request data for deleting a webhook and its delivery
+// log.
+type WebhookDelete struct {
+	WebhookIDParam
+	TenantID string `json:"-"`
+}
+
+// WebhookDeliveryList is the request data for listing a webhook's delivery log.
+type WebhookDeliveryList struct {
+	WebhookIDParam
+	TenantID string `json:"-"`
+	query.Paginator
+	query.Filters
+	query.Sorter
+}
+
+// WebhookDeliveryReplay is the request data for sending a logged delivery's
+// event to its webhook again.
+type WebhookDeliveryReplay struct {
+	WebhookIDParam
+	DeliveryID string `param:"delivery" validate:"required"`
+	TenantID   string `json:"-"`
+}
@@ -16,17 +16,24 @@ type CreateAPIKey struct {
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 	ExpiresIn int64           `json:"expires_in"`
+	// Permissions, Tags and SourceIPs are the scope the key was created with.
+	Permissions []string `json:"permissions"`
+	Tags        []string `json:"tags"`
+	SourceIPs   []string `json:"source_ips"`
 }
 
 func CreateAPIKeyFromModel(m *models.APIKey) *CreateAPIKey {
 	return &CreateAPIKey{
-		ID:        m.ID,
-		Name:      m.Name,
-		UserID:    m.CreatedBy,
-		TenantID:  m.TenantID,
-		Role:      m.Role,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
-		ExpiresIn: m.ExpiresIn,
+		ID:          m.ID,
+		Name:        m.Name,
+		UserID:      m.CreatedBy,
+		TenantID:    m.TenantID,
+		Role:        m.Role,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		ExpiresIn:   m.ExpiresIn,
+		Permissions: m.Permissions,
+		Tags:        m.Tags,
+		SourceIPs:   m.SourceIPs,
 	}
 }
@@ -0,0 +1,41 @@
+// Package eventstream carries a namespace's live events, such as devices
+// connecting and sessions closing, to the clients watching the namespace, so
+// they learn about changes as they happen instead of polling for them.
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidEventID is returned when a subscriber asks to resume after an ID
+// the stream could never have produced.
+var ErrInvalidEventID = errors.New("invalid event id")
+
+// Event is one entry of a namespace's stream.
+type Event struct {
+	// ID orders the namespace's events. A subscriber that lost its connection
+	// hands back the last one it saw to resume right after it.
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Stream publishes the events of every namespace and hands them to the
+// namespace's subscribers, wherever they are connected.
+type Stream interface {
+	// Publish appends an event to the namespace's stream. data is encoded as
+	// JSON.
+	Publish(ctx context.Context, tenantID, eventType string, data any) error
+	// Subscribe returns the namespace's events from the one after lastID on, or
+	// from the first one published after the call when lastID is empty. Only a
+	// bounded, recent history is kept for resuming; older events are gone.
+	//
+	// The channel is closed once ctx is done, or earlier when the subscriber
+	// falls too far behind to be kept up to date; it can then subscribe again
+	// from the last event it got.
+	Subscribe(ctx context.Context, tenantID, lastID string) (<-chan Event, error)
+	// Close stops delivering events and releases the stream's connections.
+	Close() error
+}
@@ -0,0 +1,360 @@
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// redisStreamKeyPrefix prefixes the Redis stream holding a namespace's
+	// history, which is what a subscriber resumes from.
+	redisStreamKeyPrefix = "eventstream:"
+
+	// redisChannel is the Pub/Sub channel every event is also published on.
+	// Each process subscribes to it once and hands the events to its own
+	// subscribers, so a subscriber never holds a Redis connection of its own.
+	redisChannel = "eventstream"
+
+	// redisStreamMaxLen is how many of a namespace's latest events are kept for
+	// resuming. Trimming is approximate, so a few more may be.
+	redisStreamMaxLen = 1000
+
+	// redisStreamTTL drops the history of a namespace nothing happened in for
+	// this long.
+	redisStreamTTL = 24 * time.Hour
+
+	// subscriberBufferSize is how many events a subscriber may fall behind
+	// before it is dropped. Publishing never waits on a slow subscriber.
+	subscriberBufferSize = 256
+)
+
+type redisStream struct {
+	client *redis.Client
+	pubsub *redis.PubSub
+
+	mu          sync.Mutex
+	subscribers map[string]map[*subscriber]struct{}
+}
+
+var _ Stream = &redisStream{}
+
+// subscriber is a Subscribe call's end of the process-wide Pub/Sub
+// subscription.
+type subscriber struct {
+	events chan Event
+	// dropped is closed when the subscriber fell too far behind.
+	dropped chan struct{}
+}
+
+// message is what goes through the Pub/Sub channel.
+type message struct {
+	TenantID string `json:"tenant_id"`
+	Event
+}
+
+// NewRedisStream returns a stream kept in the Redis at uri.
+func NewRedisStream(ctx context.Context, uri string) (Stream, error) {
+	opt, err := redis.ParseURL(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(opt)
+
+	pubsub := client.Subscribe(ctx, redisChannel)
+	// Waiting on the confirmation is what guarantees no event published after
+	// this returns is missed.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		client.Close()
+
+		return nil, err
+	}
+
+	s := &redisStream{
+		client:      client,
+		pubsub:      pubsub,
+		subscribers: make(map[string]map[*subscriber]struct{}),
+	}
+
+	go s.run(pubsub.Channel())
+
+	return s, nil
+}
+
+func (s *redisStream) Publish(ctx context.Context, tenantID, eventType string, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	key := redisStreamKeyPrefix + tenantID
+
+	id, err := s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: redisStreamMaxLen,
+		Approx: true,
+		Values: map[string]any{"type": eventType, "data": string(body)},
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	msg, err := json.Marshal(message{
+		TenantID: tenantID,
+		Event:    Event{ID: id, Type: eventType, Data: body},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Expire(ctx, key, redisStreamTTL)
+		pipe.Publish(ctx, redisChannel, msg)
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *redisStream) Subscribe(ctx context.Context, tenantID, lastID string) (<-chan Event, error) {
+	if lastID != "" {
+		if _, _, ok := parseID(lastID); !ok {
+			return nil, ErrInvalidEventID
+		}
+	}
+
+	key := redisStreamKeyPrefix + tenantID
+
+	// Registering before reading the history leaves no window for an event to
+	// fall into: anything published meanwhile is both in the history and in the
+	// buffer, and the duplicate is skipped by its ID.
+	sub := s.register(tenantID)
+
+	var history []redis.XMessage
+	if lastID != "" {
+		var err error
+		if history, err = s.client.XRange(ctx, key, "("+lastID, "+").Result(); err != nil {
+			s.unregister(tenantID, sub)
+
+			return nil, err
+		}
+	} else {
+		// Starting from the tip of the history lets the live events be read back
+		// from it too, the same as when resuming.
+		tip, err := s.client.XRevRangeN(ctx, key, "+", "-", 1).Result()
+		if err != nil {
+			s.unregister(tenantID, sub)
+
+			return nil, err
+		}
+
+		lastID = "0-0"
+		if len(tip) > 0 {
+			lastID = tip[0].ID
+		}
+	}
+
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+		defer s.unregister(tenantID, sub)
+
+		send := func(event Event) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		last := lastID
+
+		for _, entry := range history {
+			event := eventFromXMessage(entry)
+			if !send(event) {
+				return
+			}
+
+			last = event.ID
+		}
+
+		for {
+			var latest string
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-sub.dropped:
+				return
+			case event := <-sub.events:
+				latest = event.ID
+			}
+
+			// The events buffered meanwhile are read back together.
+			for drained := false; !drained; {
+				select {
+				case event := <-sub.events:
+					if isAfter(event.ID, latest) {
+						latest = event.ID
+					}
+				default:
+					drained = true
+				}
+			}
+
+			if !isAfter(latest, last) {
+				continue
+			}
+
+			// Publishers racing each other may have their events announced out of
+			// the order the stream has them in, so an announced event only says how
+			// far the stream got: what is delivered is read back from it, gaps
+			// included, and the late announcements are then skipped by their IDs.
+			entries, err := s.client.XRange(ctx, key, "("+last, latest).Result()
+			if err != nil {
+				if ctx.Err() == nil {
+					log.WithError(err).WithField("tenant_id", tenantID).Warn("failed to read the stream's events back")
+				}
+
+				return
+			}
+
+			for _, entry := range entries {
+				event := eventFromXMessage(entry)
+				if !send(event) {
+					return
+				}
+
+				last = event.ID
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (s *redisStream) Close() error {
+	err := s.pubsub.Close()
+	if cerr := s.client.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+func (s *redisStream) run(messages <-chan *redis.Message) {
+	for msg := range messages {
+		var m message
+		if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+			log.WithError(err).Warn("dropping an undecodable stream event")
+
+			continue
+		}
+
+		s.dispatch(m.TenantID, m.Event)
+	}
+}
+
+func (s *redisStream) register(tenantID string) *subscriber {
+	sub := &subscriber{
+		events:  make(chan Event, subscriberBufferSize),
+		dropped: make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subscribers[tenantID] == nil {
+		s.subscribers[tenantID] = make(map[*subscriber]struct{})
+	}
+
+	s.subscribers[tenantID][sub] = struct{}{}
+
+	return sub
+}
+
+func (s *redisStream) unregister(tenantID string, sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(tenantID, sub)
+}
+
+// remove forgets a subscriber. The caller holds s.mu.
+func (s *redisStream) remove(tenantID string, sub *subscriber) {
+	delete(s.subscribers[tenantID], sub)
+	if len(s.subscribers[tenantID]) == 0 {
+		delete(s.subscribers, tenantID)
+	}
+}
+
+// dispatch hands an event to the namespace's subscribers. A subscriber whose
+// buffer is full is dropped rather than waited on.
+func (s *redisStream) dispatch(tenantID string, event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers[tenantID] {
+		select {
+		case sub.events <- event:
+		default:
+			s.remove(tenantID, sub)
+			close(sub.dropped)
+		}
+	}
+}
+
+func eventFromXMessage(entry redis.XMessage) Event {
+	event := Event{ID: entry.ID}
+
+	if v, ok := entry.Values["type"].(string); ok {
+		event.Type = v
+	}
+
+	if v, ok := entry.Values["data"].(string); ok {
+		event.Data = json.RawMessage(v)
+	}
+
+	return event
+}
+
+// parseID splits a Redis stream ID, "<milliseconds>-<sequence>", into its two
+// parts.
+func parseID(id string) (uint64, uint64, bool) {
+	ms, seq, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, 0, false
+	}
+
+	m, err := strconv.ParseUint(ms, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	s, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return m, s, true
+}
+
+// isAfter reports whether the event identified by id came after the one
+// identified by last.
+func isAfter(id, last string) bool {
+	im, is, _ := parseID(id)
+	lm, ls, _ := parseID(last)
+
+	return im > lm || (im == lm && is > ls)
+}
@@ -0,0 +1,159 @@
+package eventstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+func TestIsAfter(t *testing.T) {
+	cases := []struct {
+		id       string
+		last     string
+		expected bool
+	}{
+		{"1700000000000-1", "1700000000000-0", true},
+		{"1700000000001-0", "1700000000000-9", true},
+		{"1700000000000-0", "1700000000000-0", false},
+		{"1700000000000-2", "1700000000000-10", false},
+		{"999-0", "1000-0", false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, isAfter(tc.id, tc.last), "%s after %s", tc.id, tc.last)
+	}
+}
+
+func TestParseID(t *testing.T) {
+	for _, id := range []string{"", "1700000000000", "-1", "a-1", "1-b", "1700000000000-1-2"} {
+		_, _, ok := parseID(id)
+		assert.False(t, ok, id)
+	}
+
+	ms, seq, ok := parseID("1700000000000-3")
+	require.True(t, ok)
+	assert.Equal(t, uint64(1700000000000), ms)
+	assert.Equal(t, uint64(3), seq)
+}
+
+func TestDispatch(t *testing.T) {
+	s := &redisStream{subscribers: make(map[string]map[*subscriber]struct{})}
+
+	watching := s.register("tenant")
+	other := s.register("other")
+
+	s.dispatch("tenant", Event{ID: "1-0", Type: "device.online"})
+
+	assert.Equal(t, Event{ID: "1-0", Type: "device.online"}, <-watching.events)
+	assert.Empty(t, other.events)
+
+	// A subscriber that stops reading is dropped once its buffer is full, and the
+	// others are not held up by it.
+	for range subscriberBufferSize + 1 {
+		s.dispatch("tenant", Event{ID: "2-0"})
+	}
+
+	select {
+	case <-watching.dropped:
+	default:
+		t.Fatal("the subscriber that fell behind was not dropped")
+	}
+
+	assert.NotContains(t, s.subscribers, "tenant")
+	assert.Contains(t, s.subscribers, "other")
+
+	s.unregister("tenant", watching)
+	s.unregister("other", other)
+	assert.Empty(t, s.subscribers)
+}
+
+func TestRedisStream(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	redisContainer, err := redis.Run(ctx, "docker.io/valkey/valkey:9.1-alpine")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, redisContainer.Terminate(ctx))
+	})
+
+	uri, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	stream, err := NewRedisStream(ctx, uri)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	next := func(t *testing.T, events <-chan Event) Event {
+		t.Helper()
+
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "the stream was closed")
+
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event arrived")
+
+			return Event{}
+		}
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+
+	events, err := stream.Subscribe(subCtx, "tenant", "")
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Publish(ctx, "other", "device.online", map[string]string{"uid": "theirs"}))
+	require.NoError(t, stream.Publish(ctx, "tenant", "device.online", map[string]string{"uid": "first"}))
+	require.NoError(t, stream.Publish(ctx, "tenant", "device.offline", map[string]string{"uid": "second"}))
+
+	first := next(t, events)
+	assert.Equal(t, "device.online", first.Type)
+	assert.JSONEq(t, `{"uid":"first"}`, string(first.Data))
+
+	second := next(t, events)
+	assert.Equal(t, "device.offline", second.Type)
+	assert.True(t, isAfter(second.ID, first.ID))
+
+	cancel()
+
+	_, open := <-events
+	assert.False(t, open, "the stream was not closed with its context")
+
+	// Resuming after the first event replays the second from the history, and
+	// goes on with the live ones.
+	events, err = stream.Subscribe(ctx, "tenant", first.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, second, next(t, events))
+
+	require.NoError(t, stream.Publish(ctx, "tenant", "session.started", map[string]string{"uid": "third"}))
+	assert.Equal(t, "session.started", next(t, events).Type)
+
+	// An event whose announcement has not arrived yet, as when another publisher
+	// races this one, is read back from the stream rather than missed.
+	_, err = stream.(*redisStream).client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: redisStreamKeyPrefix + "tenant",
+		Values: map[string]any{"type": "session.closed", "data": `{"uid":"fourth"}`},
+	}).Result()
+	require.NoError(t, err)
+	require.NoError(t, stream.Publish(ctx, "tenant", "device.offline", map[string]string{"uid": "fifth"}))
+
+	fourth := next(t, events)
+	assert.Equal(t, "session.closed", fourth.Type)
+	assert.JSONEq(t, `{"uid":"fourth"}`, string(fourth.Data))
+
+	fifth := next(t, events)
+	assert.Equal(t, "device.offline", fifth.Type)
+	assert.True(t, isAfter(fifth.ID, fourth.ID))
+
+	_, err = stream.Subscribe(ctx, "tenant", "not-an-id")
+	assert.ErrorIs(t, err, ErrInvalidEventID)
+}
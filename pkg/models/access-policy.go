@@ -1,6 +1,13 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+)
 
 // PolicySubjectType enumerates who an Access Policy grants access to.
 type PolicySubjectType string
@@ -12,11 +19,14 @@ const (
 	PolicySubjectRole PolicySubjectType = "role"
 	// PolicySubjectAllMembers grants every member of the namespace; Value is empty.
 	PolicySubjectAllMembers PolicySubjectType = "all-members"
+	// PolicySubjectAPIKey grants a single API key, named in Value. Only the device
+	// commands and file transfers an API key runs over the API are matched by it.
+	PolicySubjectAPIKey PolicySubjectType = "api-key"
 )
 
 // PolicySubject identifies who an Access Policy grants access to.
 type PolicySubject struct {
-	Type  PolicySubjectType `json:"type"`
+	Type  PolicySubjectType `json:"type" validate:"required,oneof=user role all-members api-key"`
 	Value string            `json:"value"`
 }
 
@@ -66,11 +76,129 @@ type AccessPolicy struct {
 	// means "always", the only setting that is genuinely per login. Only
 	// meaningful when RequireReauth is set.
 	ReauthPeriod *int `json:"reauth_period"`
+	// Schedule restricts the policy to recurring weekly time windows. nil means
+	// the policy applies at any time.
+	Schedule *PolicySchedule `json:"schedule"`
+	// NotBefore and NotAfter bound when the policy is in effect at all: from
+	// NotBefore, inclusive, until NotAfter, exclusive. nil leaves that side open.
+	// Outside the bounds, as outside the schedule, the policy does not apply: an
+	// allow grants nothing and a deny blocks nothing.
+	NotBefore *time.Time `json:"not_before"`
+	NotAfter  *time.Time `json:"not_after"`
+	// AccessRequestID is the access request a grant materialized this policy
+	// for. Such a policy lives and dies with its request, so policy bundles
+	// leave it alone. Empty for every policy created directly.
+	AccessRequestID string `json:"access_request_id,omitempty"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// PolicySchedule restricts an Access Policy to recurring weekly time windows, e.g.
+// "Mon–Fri 08:00–18:00 Europe/Berlin". The policy applies while the current time,
+// read in Timezone, falls within any of its windows.
+type PolicySchedule struct {
+	// Timezone is the IANA time zone the windows are read in, e.g.
+	// "Europe/Berlin". Empty means UTC.
+	Timezone string                 `json:"timezone" validate:"omitempty,timezone"`
+	Windows  []PolicyScheduleWindow `json:"windows" validate:"required,min=1,dive"`
+}
+
+// PolicyScheduleWindow is a daily time range opening on a set of weekdays.
+type PolicyScheduleWindow struct {
+	// Weekdays are the days the window opens on, as "mon" to "sun".
+	Weekdays []string `json:"weekdays" validate:"required,min=1,dive,oneof=mon tue wed thu fri sat sun"`
+	// Start and End are wall-clock times as "HH:MM"; Start is inclusive and End
+	// exclusive, and End may be "24:00" for the end of the day. An End at or
+	// before Start runs past midnight, so "22:00"–"06:00" on "fri" covers Friday
+	// night until Saturday 06:00.
+	Start string `json:"start" validate:"required,schedule_time"`
+	End   string `json:"end" validate:"required,schedule_time"`
+}
+
+// PolicyWeekdays are the weekday names a schedule window accepts, indexed by
+// [time.Weekday].
+var PolicyWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Contains reports whether t falls within any of the schedule's windows. It
+// fails when the time zone or a window's times cannot be parsed, so a caller can
+// fail closed on a deny.
+func (s PolicySchedule) Contains(t time.Time) (bool, error) {
+	location := time.UTC
+	if s.Timezone != "" {
+		loaded, err := time.LoadLocation(s.Timezone)
+		if err != nil {
+			return false, fmt.Errorf("invalid schedule timezone %q: %w", s.Timezone, err)
+		}
+
+		location = loaded
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	today := PolicyWeekdays[local.Weekday()]
+	yesterday := PolicyWeekdays[(local.Weekday()+6)%7]
+
+	for _, window := range s.Windows {
+		start, err := ParsePolicyClock(window.Start)
+		if err != nil {
+			return false, err
+		}
+
+		end, err := ParsePolicyClock(window.End)
+		if err != nil {
+			return false, err
+		}
+
+		if start < end {
+			if slices.Contains(window.Weekdays, today) && minute >= start && minute < end {
+				return true, nil
+			}
+
+			continue
+		}
+
+		// The window runs past midnight: its evening belongs to the day it opens
+		// on, and its early morning to the day after.
+		if slices.Contains(window.Weekdays, today) && minute >= start {
+			return true, nil
+		}
+
+		if slices.Contains(window.Weekdays, yesterday) && minute < end {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ParsePolicyClock parses a schedule window time, "HH:MM" from "00:00" to
+// "24:00", into minutes since midnight.
+func ParsePolicyClock(clock string) (int, error) {
+	if len(clock) != 5 || clock[2] != ':' || !isDigits(clock[:2]) || !isDigits(clock[3:]) {
+		return 0, fmt.Errorf("invalid schedule time %q: expected HH:MM", clock)
+	}
+
+	hour, _ := strconv.Atoi(clock[:2])
+	minute, _ := strconv.Atoi(clock[3:])
+
+	if minute > 59 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid schedule time %q: out of range", clock)
+	}
+
+	return hour*60 + minute, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
 // NewOwnerAccessPolicy is the starter policy for the identity access mode: it
 // grants the namespace owner every login on every device. Seeded when a
 // namespace is born identity (creation) or switches to identity with no
@@ -101,3 +229,60 @@ type Decision struct {
 	ReauthPeriod *int   `json:"reauth_period"`
 	Reason       string `json:"reason"`
 }
+
+// PolicyVerdict is what a single Access Policy did in an evaluation.
+type PolicyVerdict string
+
+const (
+	// PolicyVerdictGranted is an allow that matched and is in effect.
+	PolicyVerdictGranted PolicyVerdict = "granted"
+	// PolicyVerdictDenied is a deny that matched and is in effect.
+	PolicyVerdictDenied PolicyVerdict = "denied"
+	// PolicyVerdictSkipped is a policy that did not apply; the evaluation's
+	// reason says which condition failed.
+	PolicyVerdictSkipped PolicyVerdict = "skipped"
+	// PolicyVerdictError is a policy that could not be evaluated, e.g. a broken
+	// hostname regexp. A deny with this verdict denies; an allow grants nothing.
+	PolicyVerdictError PolicyVerdict = "error"
+)
+
+// PolicyEvaluation records what one Access Policy did when evaluated for an
+// access, and why.
+type PolicyEvaluation struct {
+	PolicyID   string        `json:"policy_id"`
+	PolicyName string        `json:"policy_name"`
+	Action     PolicyAction  `json:"action"`
+	Verdict    PolicyVerdict `json:"verdict"`
+	Reason     string        `json:"reason,omitempty"`
+}
+
+// AccessSimulation is the outcome of evaluating the Access Policies for a
+// hypothetical login on a device, without connecting: the Decision the gateway
+// would reach, and what every policy did on the way to it.
+type AccessSimulation struct {
+	DeviceUID  string             `json:"device_uid"`
+	DeviceName string             `json:"device_name"`
+	Decision   Decision           `json:"decision"`
+	Policies   []PolicyEvaluation `json:"policies"`
+}
+
+// AccessGrant is a principal/login pair the Access Policies let reach a device
+// at the time of the query.
+type AccessGrant struct {
+	PrincipalID string `json:"principal_id"`
+	// PrincipalName is a human member's email, or a service account's name.
+	PrincipalName string          `json:"principal_name"`
+	PrincipalType UserType        `json:"principal_type"`
+	Role          authorizer.Role `json:"role"`
+	// Login is the unix login granted, or "*" for any login but those listed in
+	// ExceptLogins.
+	Login        string   `json:"login"`
+	ExceptLogins []string `json:"except_logins,omitempty"`
+	// SourceIP are the CIDRs the grant is limited to; empty means any address.
+	SourceIP []string `json:"source_ip"`
+	// DeniedFrom are CIDRs a deny carves out of the grant.
+	DeniedFrom    []string `json:"denied_from,omitempty"`
+	RequireReauth bool     `json:"require_reauth"`
+	// Policies are the names of the allows granting the pair.
+	Policies []string `json:"policies"`
+}
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyScheduleContains(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	workdays := PolicySchedule{
+		Timezone: "Europe/Berlin",
+		Windows:  []PolicyScheduleWindow{{Weekdays: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "08:00", End: "18:00"}},
+	}
+
+	overnight := PolicySchedule{
+		Windows: []PolicyScheduleWindow{{Weekdays: []string{"fri"}, Start: "22:00", End: "06:00"}},
+	}
+
+	cases := []struct {
+		description   string
+		schedule      PolicySchedule
+		at            time.Time
+		expectedMatch bool
+		expectedErr   bool
+	}{
+		{
+			description:   "matches inside a weekday window in its time zone",
+			schedule:      workdays,
+			at:            time.Date(2026, 10, 14, 9, 30, 0, 0, berlin), // Wednesday
+			expectedMatch: true,
+		},
+		{
+			description:   "reads the time in the schedule's time zone, not the caller's",
+			schedule:      workdays,
+			at:            time.Date(2026, 10, 14, 6, 30, 0, 0, time.UTC), // 08:30 in Berlin
+			expectedMatch: true,
+		},
+		{
+			description:   "the start is inclusive",
+			schedule:      workdays,
+			at:            time.Date(2026, 10, 14, 8, 0, 0, 0, berlin),
+			expectedMatch: true,
+		},
+		{
+			description:   "the end is exclusive",
+			schedule:      workdays,
+			at:            time.Date(2026, 10, 14, 18, 0, 0, 0, berlin),
+			expectedMatch: false,
+		},
+		{
+			description:   "does not match on a day the window does not open",
+			schedule:      workdays,
+			at:            time.Date(2026, 10, 17, 9, 30, 0, 0, berlin), // Saturday
+			expectedMatch: false,
+		},
+		{
+			description:   "an overnight window covers the evening of its day",
+			schedule:      overnight,
+			at:            time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC), // Friday
+			expectedMatch: true,
+		},
+		{
+			description:   "an overnight window covers the early morning after its day",
+			schedule:      overnight,
+			at:            time.Date(2026, 10, 17, 5, 59, 0, 0, time.UTC), // Saturday
+			expectedMatch: true,
+		},
+		{
+			description:   "an overnight window does not cover the early morning of its own day",
+			schedule:      overnight,
+			at:            time.Date(2026, 10, 16, 5, 0, 0, 0, time.UTC), // Friday
+			expectedMatch: false,
+		},
+		{
+			description: "24:00 closes a window at the end of the day",
+			schedule: PolicySchedule{
+				Windows: []PolicyScheduleWindow{{Weekdays: []string{"sat"}, Start: "12:00", End: "24:00"}},
+			},
+			at:            time.Date(2026, 10, 17, 23, 59, 0, 0, time.UTC),
+			expectedMatch: true,
+		},
+		{
+			description:   "a schedule without windows never matches",
+			schedule:      PolicySchedule{},
+			at:            time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
+			expectedMatch: false,
+		},
+		{
+			description:   "an unknown time zone returns an error",
+			schedule:      PolicySchedule{Timezone: "Mars/Olympus", Windows: workdays.Windows},
+			at:            time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
+			expectedErr:   true,
+			expectedMatch: false,
+		},
+		{
+			description: "a malformed window time returns an error",
+			schedule: PolicySchedule{
+				Windows: []PolicyScheduleWindow{{Weekdays: []string{"wed"}, Start: "8:00", End: "18:00"}},
+			},
+			at:          time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			matched, err := tc.schedule.Contains(tc.at)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedMatch, matched)
+		})
+	}
+}
+
+func TestParsePolicyClock(t *testing.T) {
+	cases := []struct {
+		clock       string
+		expected    int
+		expectedErr bool
+	}{
+		{clock: "00:00", expected: 0},
+		{clock: "08:30", expected: 510},
+		{clock: "24:00", expected: 1440},
+		{clock: "24:01", expectedErr: true},
+		{clock: "12:60", expectedErr: true},
+		{clock: "8:00", expectedErr: true},
+		{clock: "+8:00", expectedErr: true},
+		{clock: "08-00", expectedErr: true},
+		{clock: "", expectedErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.clock, func(t *testing.T) {
+			minutes, err := ParsePolicyClock(tc.clock)
+			if tc.expectedErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, minutes)
+		})
+	}
+}
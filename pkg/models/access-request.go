@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// AccessRequestStatus is where an access request is in its lifecycle. Only a
+// pending request can be decided, and only a granted one has an Access Policy.
+type AccessRequestStatus string
+
+const (
+	AccessRequestPending AccessRequestStatus = "pending"
+	// AccessRequestGranted is an approved request whose Access Policy is in
+	// effect until the request's ExpiresAt.
+	AccessRequestGranted  AccessRequestStatus = "granted"
+	AccessRequestRejected AccessRequestStatus = "rejected"
+	// AccessRequestCancelled is a pending request its requester withdrew.
+	AccessRequestCancelled AccessRequestStatus = "cancelled"
+	// AccessRequestRevoked is a granted request an approver ended before it
+	// lapsed.
+	AccessRequestRevoked AccessRequestStatus = "revoked"
+	// AccessRequestExpired is a granted request whose access lapsed, and whose
+	// Access Policy the worker removed.
+	AccessRequestExpired AccessRequestStatus = "expired"
+)
+
+// AccessRequest is a member asking for temporary SSH access they have no Access
+// Policy for: a set of logins on the devices a filter selects, for a duration,
+// with a justification an approver decides on. Granting it materializes an allow
+// Access Policy for the requester bounded to the granted window, which the
+// worker removes once the window lapses.
+type AccessRequest struct {
+	ID       string `json:"id"`
+	TenantID string `json:"tenant_id"`
+	// UserID is the member who asked for the access, and who the granted policy
+	// names as its subject.
+	UserID string          `json:"user_id"`
+	Filter PublicKeyFilter `json:"filter"`
+	Logins []string        `json:"logins"`
+	// Duration is how long the access lasts once granted, in seconds.
+	Duration      int                 `json:"duration"`
+	Justification string              `json:"justification"`
+	Status        AccessRequestStatus `json:"status"`
+	// DecidedBy is the approver who granted, rejected or revoked the request, and
+	// DecisionNote the reason they gave. Both are empty while it is pending.
+	DecidedBy    string     `json:"decided_by,omitempty"`
+	DecisionNote string     `json:"decision_note,omitempty"`
+	DecidedAt    *time.Time `json:"decided_at,omitempty"`
+	// PolicyID is the Access Policy granting the request, set while the request
+	// is granted.
+	PolicyID string `json:"policy_id,omitempty"`
+	// ExpiresAt is when the granted access lapses.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Events are the request's lifecycle, oldest first. They are only loaded when
+	// a single request is resolved.
+	Events []AccessRequestEvent `json:"events,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AccessRequestAction is one step of an access request's lifecycle.
+type AccessRequestAction string
+
+const (
+	AccessRequestActionRequested AccessRequestAction = "requested"
+	AccessRequestActionGranted   AccessRequestAction = "granted"
+	AccessRequestActionRejected  AccessRequestAction = "rejected"
+	AccessRequestActionCancelled AccessRequestAction = "cancelled"
+	AccessRequestActionRevoked   AccessRequestAction = "revoked"
+	AccessRequestActionExpired   AccessRequestAction = "expired"
+)
+
+// AccessRequestEvent is one row of an access request's append-only audit trail:
+// who moved the request, when, and why. Rows are never updated or deleted by the
+// application, so the trail outlives the policy a grant created.
+type AccessRequestEvent struct {
+	ID              string              `json:"id"`
+	AccessRequestID string              `json:"access_request_id"`
+	TenantID        string              `json:"tenant_id"`
+	Action          AccessRequestAction `json:"action"`
+	// ActorID is the member who took the action, empty when the worker expired
+	// the request.
+	ActorID   string    `json:"actor_id,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"net"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
@@ -31,6 +34,20 @@ type APIKey struct {
 	// ExpiresIn is the expiration date of the API key. An expired key cannot be used for
 	// authentication. When equals or less than 0 it means that are no expiration date.
 	ExpiresIn int64 `json:"expires_in"`
+	// Permissions narrows what the role grants to the named [authorizer.Permission]s. The key keeps
+	// only the permissions present in both its role and this list; when empty, it keeps every
+	// permission of its role.
+	Permissions []string `json:"permissions"`
+	// Tags restricts the key to the devices carrying at least one of these tags. When empty, the key
+	// reaches every device in the namespace.
+	Tags []string `json:"tags"`
+	// SourceIPs lists the addresses and CIDR blocks the key may be used from. When empty, the key may
+	// be used from anywhere.
+	SourceIPs []string `json:"source_ips"`
+	// LastUsedAt is when the key last authenticated a request, or nil if it never did.
+	LastUsedAt *time.Time `json:"last_used_at"`
+	// UsageCount is how many requests the key has authenticated.
+	UsageCount int64 `json:"usage_count"`
 }
 
 // IsValid reports whether an API key is valid or not.
@@ -45,6 +62,46 @@ func (a *APIKey) IsValid() bool {
 	return now.Before(expiresIn)
 }
 
+// GrantedPermissions returns the permissions the key is narrowed to, or nil when it keeps every
+// permission of its role. Names that are no longer known are dropped, so a key never gains a
+// permission by carrying a stale one.
+func (a *APIKey) GrantedPermissions() []authorizer.Permission {
+	if len(a.Permissions) == 0 {
+		return nil
+	}
+
+	permissions := make([]authorizer.Permission, 0, len(a.Permissions))
+	for _, name := range a.Permissions {
+		if permission, ok := authorizer.PermissionFromString(name); ok {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions
+}
+
+// AllowsSource reports whether the key may be used from the given IP address.
+func (a *APIKey) AllowsSource(ip string) bool {
+	if len(a.SourceIPs) == 0 {
+		return true
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(a.SourceIPs, func(source string) bool {
+		if strings.Contains(source, "/") {
+			_, network, err := net.ParseCIDR(source)
+
+			return err == nil && network.Contains(addr)
+		}
+
+		return addr.Equal(net.ParseIP(source))
+	})
+}
+
 // APIKeyConflicts holds API keys attributes that must be unique for each item (per tenant ID) and can be utilized in queries
 // to identify conflicts.
 type APIKeyConflicts struct {
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyAllowsSource(t *testing.T) {
+	cases := []struct {
+		description string
+		sources     []string
+		ip          string
+		expected    bool
+	}{
+		{
+			description: "an empty allowlist allows any address",
+			sources:     nil,
+			ip:          "203.0.113.7",
+			expected:    true,
+		},
+		{
+			description: "an exact address is allowed",
+			sources:     []string{"203.0.113.7"},
+			ip:          "203.0.113.7",
+			expected:    true,
+		},
+		{
+			description: "an address inside a CIDR block is allowed",
+			sources:     []string{"10.0.0.1", "198.51.100.0/24"},
+			ip:          "198.51.100.42",
+			expected:    true,
+		},
+		{
+			description: "an IPv6 address inside a CIDR block is allowed",
+			sources:     []string{"2001:db8::/32"},
+			ip:          "2001:db8::1",
+			expected:    true,
+		},
+		{
+			description: "an address outside the allowlist is refused",
+			sources:     []string{"203.0.113.7", "198.51.100.0/24"},
+			ip:          "192.0.2.1",
+			expected:    false,
+		},
+		{
+			description: "an unparseable address is refused",
+			sources:     []string{"203.0.113.0/24"},
+			ip:          "not-an-ip",
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			apiKey := &APIKey{SourceIPs: tc.sources}
+			assert.Equal(t, tc.expected, apiKey.AllowsSource(tc.ip))
+		})
+	}
+}
+
+func TestAPIKeyGrantedPermissions(t *testing.T) {
+	t.Run("an empty list keeps the role's permissions", func(t *testing.T) {
+		apiKey := &APIKey{}
+		assert.Nil(t, apiKey.GrantedPermissions())
+	})
+
+	t.Run("unknown names are dropped", func(t *testing.T) {
+		apiKey := &APIKey{Permissions: []string{"device:accept", "device:unknown"}}
+		assert.Equal(t, []authorizer.Permission{authorizer.DeviceAccept}, apiKey.GrantedPermissions())
+	})
+
+	t.Run("a list of only unknown names grants nothing", func(t *testing.T) {
+		apiKey := &APIKey{Permissions: []string{"device:unknown"}}
+		assert.Empty(t, apiKey.GrantedPermissions())
+		assert.NotNil(t, apiKey.GrantedPermissions())
+	})
+}
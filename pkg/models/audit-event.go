@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+// AuditActorType is the kind of principal an audit event names as its actor.
+type AuditActorType string
+
+const (
+	AuditActorUser AuditActorType = "user"
+	// AuditActorAPIKey is a request authenticated with an API key. The key is
+	// named by its name, which is unique in the namespace; its digest never
+	// leaves the store.
+	AuditActorAPIKey AuditActorType = "api_key"
+	// AuditActorServiceAccount is a service account acting as itself. Service
+	// accounts never sign in to the console, so only actions taken outside an
+	// API request can name one.
+	AuditActorServiceAccount AuditActorType = "service_account"
+)
+
+// AuditActor is who performed an audited action.
+type AuditActor struct {
+	Type AuditActorType `json:"type"`
+	// ID is the user's id for a user or service account, and the key's name for
+	// an API key.
+	ID string `json:"id"`
+	// Name is the actor's display name at the time of the action: the username
+	// for a user, the key's name for an API key.
+	Name string `json:"name"`
+}
+
+// AuditAction names an administrative action the audit log records, as
+// "<target type>.<verb>".
+type AuditAction string
+
+const (
+	AuditActionAccessPolicyCreate  AuditAction = "access_policy.create"
+	AuditActionAccessPolicyUpdate  AuditAction = "access_policy.update"
+	AuditActionAccessPolicyDelete  AuditAction = "access_policy.delete"
+	AuditActionAccessRequestGrant  AuditAction = "access_request.grant"
+	AuditActionAccessRequestReject AuditAction = "access_request.reject"
+	AuditActionAccessRequestRevoke AuditAction = "access_request.revoke"
+	AuditActionMemberAdd           AuditAction = "member.add"
+	AuditActionMemberInvite        AuditAction = "member.invite"
+	AuditActionMemberUpdate        AuditAction = "member.update"
+	AuditActionMemberRemove        AuditAction = "member.remove"
+	AuditActionInstallKeyCreate    AuditAction = "install_key.create"
+	AuditActionInstallKeyUpdate    AuditAction = "install_key.update"
+	AuditActionInstallKeyRevoke    AuditAction = "install_key.revoke"
+	AuditActionInstallKeyReveal    AuditAction = "install_key.reveal"
+	AuditActionSSHIdentityCreate   AuditAction = "ssh_identity.create"
+	AuditActionSSHIdentityUpdate   AuditAction = "ssh_identity.update"
+	AuditActionSSHIdentityRevoke   AuditAction = "ssh_identity.revoke"
+	AuditActionDeviceAccept        AuditAction = "device.accept"
+	AuditActionDeviceReject        AuditAction = "device.reject"
+	AuditActionDeviceRemove        AuditAction = "device.remove"
+	AuditActionAPIKeyCreate        AuditAction = "api_key.create"
+	AuditActionAPIKeyUpdate        AuditAction = "api_key.update"
+	AuditActionAPIKeyDelete        AuditAction = "api_key.delete"
+	AuditActionSSHUserCACreate     AuditAction = "ssh_user_ca.create"
+	AuditActionSSHUserCAUpdate     AuditAction = "ssh_user_ca.update"
+	AuditActionSSHUserCADelete     AuditAction = "ssh_user_ca.delete"
+	AuditActionWebhookCreate       AuditAction = "webhook.create"
+	AuditActionWebhookUpdate       AuditAction = "webhook.update"
+	AuditActionWebhookDelete       AuditAction = "webhook.delete"
+)
+
+// AuditTargetType is the kind of object an audited action was taken on.
+type AuditTargetType string
+
+const (
+	AuditTargetAccessPolicy  AuditTargetType = "access_policy"
+	AuditTargetAccessRequest AuditTargetType = "access_request"
+	AuditTargetMember        AuditTargetType = "member"
+	AuditTargetInstallKey    AuditTargetType = "install_key"
+	AuditTargetSSHIdentity   AuditTargetType = "ssh_identity"
+	AuditTargetSSHUserCA     AuditTargetType = "ssh_user_ca"
+	AuditTargetDevice        AuditTargetType = "device"
+	AuditTargetAPIKey        AuditTargetType = "api_key"
+	AuditTargetWebhook       AuditTargetType = "webhook"
+)
+
+// AuditEvent is one entry of a namespace's append-only audit log: an
+// administrative action, who took it, on what, and what it changed. The actor
+// and target are denormalized, so the entry survives either being removed.
+type AuditEvent struct {
+	ID         string          `json:"id"`
+	TenantID   string          `json:"tenant_id"`
+	Actor      AuditActor      `json:"actor"`
+	Action     AuditAction     `json:"action"`
+	TargetType AuditTargetType `json:"target_type"`
+	// TargetID identifies the object the action was taken on, in the form the
+	// API addresses it by.
+	TargetID string `json:"target_id"`
+	// Before and After hold the target's fields the action changed, as they
+	// were before and after it. Before is nil for a creation and After for a
+	// removal; both are nil for an action that changes nothing, like a reveal.
+	Before map[string]any `json:"before"`
+	After  map[string]any `json:"after"`
+	// SourceIP and RequestID trace the event back to the HTTP request that
+	// caused it. Both are empty for an action taken outside one.
+	SourceIP  string    `json:"source_ip"`
+	RequestID string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
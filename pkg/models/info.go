@@ -1,11 +1,81 @@
 package models
 
+import "hash/fnv"
+
 type Info struct {
 	Version   string    `json:"version"`
 	Endpoints Endpoints `json:"endpoints"`
+	// Rollout is the server's decision on updating the agent of the device that asked for the information. It is
+	// only set when the server has an [AgentRolloutPolicy] and the agent identified its device; otherwise, agents
+	// update to Version.
+	Rollout *AgentRollout `json:"rollout,omitempty"`
 }
 
 type Endpoints struct {
 	API string `json:"api"`
 	SSH string `json:"ssh"`
 }
+
+// AgentRollout is the server's decision on updating a device's agent.
+type AgentRollout struct {
+	// Version is the version the agents are updated to.
+	Version string `json:"version"`
+	// Selected reports whether the device's agent is updated now. The devices that aren't wait for a later stage of
+	// the rollout.
+	Selected bool `json:"selected"`
+	// Window is when the agent may apply the update, or nil for any time.
+	Window *PolicySchedule `json:"window,omitempty"`
+}
+
+// AgentRolloutPolicy restricts which devices the server updates to a version of the agent, and when. The devices are
+// selected by a percentage of them, which may be set per namespace and per tag; a device is always in the same
+// percentile, so raising a percentage only adds devices to the ones already selected.
+type AgentRolloutPolicy struct {
+	// Version is the version the agents are updated to.
+	Version string `json:"version" validate:"required"`
+	// Percentage is the percentage of the devices updated, from 0 to 100, when neither their namespace nor their tags
+	// set one.
+	Percentage int `json:"percentage" validate:"min=0,max=100"`
+	// Namespaces sets the percentage of the devices updated in a namespace, keyed by its tenant ID.
+	Namespaces map[string]int `json:"namespaces,omitempty" validate:"dive,min=0,max=100"`
+	// Tags sets the percentage of the devices updated with a tag, keyed by its name. It takes precedence over the
+	// namespace's, and a device with several of the tags uses the highest.
+	Tags map[string]int `json:"tags,omitempty" validate:"dive,min=0,max=100"`
+	// Window is when the agents may apply the update, or nil for any time.
+	Window *PolicySchedule `json:"window,omitempty" validate:"omitempty"`
+}
+
+// percentage returns the percentage of the devices like the device that the policy updates.
+func (p *AgentRolloutPolicy) percentage(device *Device) int {
+	percentage, tagged := 0, false
+	for _, tag := range device.Tags {
+		if value, ok := p.Tags[tag.Name]; ok && (!tagged || value > percentage) {
+			percentage, tagged = value, true
+		}
+	}
+
+	if tagged {
+		return percentage
+	}
+
+	if value, ok := p.Namespaces[device.TenantID]; ok {
+		return value
+	}
+
+	return p.Percentage
+}
+
+// Rollout returns the policy's decision on updating the device's agent. A nil device, one the server doesn't know,
+// isn't selected.
+func (p *AgentRolloutPolicy) Rollout(device *Device) *AgentRollout {
+	rollout := &AgentRollout{Version: p.Version, Window: p.Window}
+
+	if device != nil {
+		percentile := fnv.New32a()
+		percentile.Write([]byte(device.UID))
+
+		rollout.Selected = int(percentile.Sum32()%100) < p.percentage(device)
+	}
+
+	return rollout
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgentRolloutPolicyRollout(t *testing.T) {
+	devices := make([]*Device, 1000)
+	for i := range devices {
+		devices[i] = &Device{UID: fmt.Sprintf("device-%d", i), TenantID: "tenant"}
+	}
+
+	selected := func(policy *AgentRolloutPolicy, devices []*Device) int {
+		count := 0
+		for _, device := range devices {
+			if policy.Rollout(device).Selected {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	t.Run("selects about the percentage of the devices", func(t *testing.T) {
+		assert.Equal(t, 0, selected(&AgentRolloutPolicy{Version: "v0.21.0", Percentage: 0}, devices))
+		assert.InDelta(t, 250, selected(&AgentRolloutPolicy{Version: "v0.21.0", Percentage: 25}, devices), 50)
+		assert.Equal(t, len(devices), selected(&AgentRolloutPolicy{Version: "v0.21.0", Percentage: 100}, devices))
+	})
+
+	t.Run("keeps the selected devices when the percentage is raised", func(t *testing.T) {
+		for _, device := range devices {
+			if (&AgentRolloutPolicy{Version: "v0.21.0", Percentage: 10}).Rollout(device).Selected {
+				assert.True(t, (&AgentRolloutPolicy{Version: "v0.21.0", Percentage: 50}).Rollout(device).Selected)
+			}
+		}
+	})
+
+	t.Run("prefers the tag's percentage to the namespace's", func(t *testing.T) {
+		policy := &AgentRolloutPolicy{
+			Version:    "v0.21.0",
+			Percentage: 0,
+			Namespaces: map[string]int{"tenant": 0},
+			Tags:       map[string]int{"canary": 100, "stable": 0},
+		}
+
+		tagged := &Device{UID: "device", TenantID: "tenant", Taggable: Taggable{Tags: []Tag{{Name: "stable"}, {Name: "canary"}}}}
+		assert.True(t, policy.Rollout(tagged).Selected)
+
+		policy.Namespaces["tenant"] = 100
+		assert.True(t, policy.Rollout(&Device{UID: "device", TenantID: "tenant"}).Selected)
+		assert.False(t, policy.Rollout(&Device{UID: "device", TenantID: "other"}).Selected)
+	})
+
+	t.Run("doesn't select an unknown device", func(t *testing.T) {
+		window := &PolicySchedule{Windows: []PolicyScheduleWindow{{Weekdays: []string{"sat"}, Start: "02:00", End: "05:00"}}}
+
+		assert.Equal(t, &AgentRollout{Version: "v0.21.0", Window: window}, (&AgentRolloutPolicy{Version: "v0.21.0", Percentage: 100, Window: window}).Rollout(nil))
+	})
+}
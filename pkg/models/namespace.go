@@ -96,6 +96,11 @@ type NamespaceSettings struct {
 	// (grandfathered): only these may switch the SSH access mode back to legacy.
 	// Namespaces born identity have it false and can never leave identity mode.
 	SSHLegacyAllowed bool `json:"ssh_legacy_allowed"`
+	// LocalAuthDisabled refuses the namespace to the members who signed in with their email and
+	// password, leaving single sign-on as the only way in. Their other namespaces are left alone. It
+	// can only be set while the instance has an OpenID Connect provider, so the members are never
+	// locked out.
+	LocalAuthDisabled bool `json:"local_auth_disabled"`
 }
 
 // IsIdentityAccess reports whether the namespace uses the identity-based SSH
@@ -0,0 +1,117 @@
+package models
+
+import "time"
+
+// PolicyBundle is a namespace's SSH access configuration in declarative form:
+// its Access Policies, its SSH identities and its install keys. It is what the
+// export endpoint returns and what the apply endpoint converges the namespace
+// to, so a bundle kept in git can be reviewed and applied like any other file.
+//
+// A section left out of the bundle (null) is not managed, and applying the
+// bundle leaves it untouched; an empty section removes everything in it.
+type PolicyBundle struct {
+	AccessPolicies []PolicyBundleAccessPolicy `json:"access_policies" validate:"omitempty,dive"`
+	SSHIdentities  []PolicyBundleSSHIdentity  `json:"ssh_identities" validate:"omitempty,dive"`
+	InstallKeys    []PolicyBundleInstallKey   `json:"install_keys" validate:"omitempty,dive"`
+}
+
+// PolicyBundleAccessPolicy is an Access Policy in a bundle, identified by its
+// name, which must be unique within the bundle.
+type PolicyBundleAccessPolicy struct {
+	Name    string             `json:"name" validate:"required"`
+	Subject PolicySubject      `json:"subject"`
+	Filter  PolicyBundleFilter `json:"filter"`
+	Logins  []string           `json:"logins" validate:"required,min=1,dive,required"`
+	// SourceIP are the CIDRs the policy applies to; empty matches any address.
+	SourceIP      []string        `json:"source_ip" validate:"omitempty,dive,cidr|ip"`
+	Action        PolicyAction    `json:"action" validate:"omitempty,oneof=allow deny"`
+	RequireReauth bool            `json:"require_reauth"`
+	ReauthPeriod  *int            `json:"reauth_period" validate:"omitempty,gte=0"`
+	Schedule      *PolicySchedule `json:"schedule" validate:"omitempty"`
+	NotBefore     *time.Time      `json:"not_before"`
+	NotAfter      *time.Time      `json:"not_after"`
+}
+
+// PolicyBundleFilter selects the devices an Access Policy in a bundle covers,
+// by hostname pattern or by tag names. Tags are named rather than referenced by
+// id so a bundle reads the same in every namespace.
+type PolicyBundleFilter struct {
+	Hostname string   `json:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
+	Tags     []string `json:"tags,omitempty" validate:"required_without=Hostname"`
+}
+
+// PolicyBundleSSHIdentity is an SSH identity in a bundle, identified by the
+// fingerprint of its public key.
+type PolicyBundleSSHIdentity struct {
+	// Principal is the member the key is bound to: a human member's email, or a
+	// service account's name.
+	Principal string `json:"principal" validate:"required"`
+	Name      string `json:"name"`
+	// PublicKey is the OpenSSH public key, as in an authorized_keys line.
+	PublicKey string     `json:"public_key" validate:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	SingleUse bool       `json:"single_use"`
+}
+
+// PolicyBundleInstallKey is an install key in a bundle, identified by its name.
+// The key itself is generated when the bundle creates it and never leaves the
+// server through a bundle; it is revealed through the install key endpoints.
+// System keys are not part of a bundle.
+type PolicyBundleInstallKey struct {
+	Name       string         `json:"name" validate:"required,api-key_name"`
+	Mode       InstallKeyMode `json:"mode" validate:"omitempty,oneof=automatic manual webhook allowlist"`
+	WebhookURL string         `json:"webhook_url,omitempty" validate:"omitempty,url"`
+	// WebhookSecret is write-only: an export leaves it out, and applying a
+	// bundle without it keeps the key's current secret.
+	WebhookSecret      string     `json:"webhook_secret,omitempty"`
+	AllowedMACs        []string   `json:"allowed_macs,omitempty" validate:"omitempty,dive,required"`
+	WebhookTimeout     int        `json:"webhook_timeout,omitempty" validate:"omitempty,min=0,max=15"`
+	WebhookCallbackTTL int        `json:"webhook_callback_ttl,omitempty" validate:"omitempty,min=0,max=86400"`
+	UsageLimit         int        `json:"usage_limit" validate:"omitempty,min=0"`
+	Ephemeral          bool       `json:"ephemeral"`
+	EphemeralTimeout   int        `json:"ephemeral_timeout,omitempty" validate:"omitempty,min=1,max=10"`
+	Tags               []string   `json:"tags" validate:"omitempty,dive,required"`
+	Disabled           bool       `json:"disabled"`
+	ExpiresAt          *time.Time `json:"expires_at"`
+}
+
+// PolicyBundleKind names the kind of resource a bundle change applies to.
+type PolicyBundleKind string
+
+const (
+	PolicyBundleKindAccessPolicy PolicyBundleKind = "access_policy"
+	PolicyBundleKindSSHIdentity  PolicyBundleKind = "ssh_identity"
+	PolicyBundleKindInstallKey   PolicyBundleKind = "install_key"
+)
+
+// PolicyBundleAction is what applying a bundle does to one resource.
+type PolicyBundleAction string
+
+const (
+	PolicyBundleActionCreate PolicyBundleAction = "create"
+	PolicyBundleActionUpdate PolicyBundleAction = "update"
+	PolicyBundleActionDelete PolicyBundleAction = "delete"
+	// PolicyBundleActionRevoke is how an install key leaves the namespace:
+	// install keys are revoked rather than deleted, so the devices enrolled with
+	// them keep their history.
+	PolicyBundleActionRevoke PolicyBundleAction = "revoke"
+)
+
+// PolicyBundleChange is one step of a bundle plan.
+type PolicyBundleChange struct {
+	Kind PolicyBundleKind `json:"kind"`
+	// Name identifies the resource: the name of an Access Policy or install key,
+	// or the fingerprint of an SSH identity.
+	Name   string             `json:"name"`
+	Action PolicyBundleAction `json:"action"`
+	// Fields are the bundle fields an update changes.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// PolicyBundlePlan is the set of changes that converges a namespace to a
+// bundle. A dry run computes it without applying it; otherwise every change is
+// applied in one transaction, or none is.
+type PolicyBundlePlan struct {
+	DryRun  bool                 `json:"dry_run"`
+	Changes []PolicyBundleChange `json:"changes"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// SCIMGroup is a group pushed by the identity provider through the SCIM endpoint. Groups are
+// instance-wide and grant nothing by themselves: the SCIM group role mapping turns membership in
+// a group into a namespace membership and role.
+type SCIMGroup struct {
+	ID string `json:"id"`
+	// ExternalID is the group's identifier at the identity provider, if it sent one.
+	ExternalID  string `json:"external_id"`
+	DisplayName string `json:"display_name"`
+	// Members holds the IDs of the users in the group.
+	Members   []string  `json:"members"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
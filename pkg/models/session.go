@@ -17,7 +17,15 @@ type Session struct {
 	Username  string  `json:"username"`
 	// UserID is the ShellHub account that authorized this session via browser
 	// approval. Empty for password/public-key logins and web-terminal sessions.
-	UserID        string          `json:"user_id,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+	// Fingerprint is the SSH identity the session logged in with, in the identity access mode.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// CertificateCAID and CertificatePrincipal are the trusted CA and the principal a certificate
+	// login was accepted through; such a login has no SSH identity behind its fingerprint.
+	CertificateCAID      string `json:"certificate_ca_id,omitempty"`
+	CertificatePrincipal string `json:"certificate_principal,omitempty"`
+	// APIKey is the name of the API key that ran the session through the API, if any.
+	APIKey        string          `json:"api_key,omitempty"`
 	IPAddress     string          `json:"ip_address"`
 	StartedAt     time.Time       `json:"started_at"`
 	LastSeen      time.Time       `json:"last_seen"`
@@ -83,9 +91,24 @@ const (
 	SessionEventTypeSubsystem SessionEventType = "subsystem"
 
 	// Signal and forwarding requests
-	SessionEventTypeSignal       SessionEventType = "signal"
-	SessionEventTypeTcpipForward SessionEventType = "tcpip-forward"
-	SessionEventTypeAuthAgentReq SessionEventType = "auth-agent-req"
+	SessionEventTypeSignal             SessionEventType = "signal"
+	SessionEventTypeTcpipForward       SessionEventType = "tcpip-forward"
+	SessionEventTypeCancelTcpipForward SessionEventType = "cancel-tcpip-forward"
+	SessionEventTypeForwardedTcpip     SessionEventType = "forwarded-tcpip"
+	SessionEventTypeAuthAgentReq       SessionEventType = "auth-agent-req"
+
+	// SessionEventTypeCommand is a command run on a seat, as the gateway saw it: an exec
+	// request's command, or a line typed into a shell.
+	SessionEventTypeCommand SessionEventType = "command"
+	// SessionEventTypeShadow is a member of the namespace starting or stopping to watch a seat from
+	// the console.
+	SessionEventTypeShadow SessionEventType = "shadow"
+	// SessionEventTypeTerminate is the session being closed by ShellHub because what authorized it
+	// was revoked.
+	SessionEventTypeTerminate SessionEventType = "terminate"
+	// SessionEventTypeDenied is something the device's restrictions denied the login, as the agent
+	// reported it.
+	SessionEventTypeDenied SessionEventType = "denied"
 )
 
 // SessionEvent represents a session event.
@@ -115,3 +138,66 @@ type SessionSeat struct {
 	// ID is the identifier of session's seat.
 	ID int `json:"id"`
 }
+
+// SessionSearchHit is a recorded event matching a search of the sessions' output and commands.
+type SessionSearchHit struct {
+	// SessionUID and Seat name the recording the event belongs to.
+	SessionUID string `json:"session_uid"`
+	Seat       int    `json:"seat"`
+	// Type is the matching event's type: what the terminal printed, or the command an exec
+	// session ran.
+	Type      SessionEventType `json:"type"`
+	Timestamp time.Time        `json:"timestamp"`
+	// Offset is how far into the seat's recording the event is, in seconds: where playing the
+	// recording back reaches it.
+	Offset    float64 `json:"offset"`
+	DeviceUID UID     `json:"device_uid"`
+	Username  string  `json:"username"`
+	// Snippet is the matching text around the match, without the escape sequences that drew it.
+	Snippet string `json:"snippet"`
+}
+
+// SessionCommandLine is the data of a command event.
+type SessionCommandLine struct {
+	Command string `json:"command"`
+	// Interactive tells the command was typed into a shell, rather than requested by exec.
+	Interactive bool `json:"interactive"`
+	// Inexact tells the line was edited with keys whose effect the gateway cannot follow, such
+	// as tab completion or history recall, so the shell may have run something else.
+	Inexact bool `json:"inexact,omitempty"`
+	// TypedBy is the member whose read-write shadow typed into the line, if any.
+	TypedBy string `json:"typed_by,omitempty"`
+	// ExitStatus is how the command exited, when that could be told: the channel's exit status
+	// for an exec, or the one a shell with terminal integration reports after each command.
+	ExitStatus *uint32 `json:"exit_status"`
+	// FinishedAt is when the command was seen finishing, if it was.
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// SessionShadow is the data of a shadow event.
+type SessionShadow struct {
+	// UserID is the member watching the seat.
+	UserID string `json:"user_id"`
+	// Mode is "read-only", or "read-write" when the member could type into the seat as well.
+	Mode string `json:"mode"`
+	// Started is set when the member attached, and unset when they left.
+	Started bool `json:"started"`
+}
+
+// SessionTermination is the data of a terminate event.
+type SessionTermination struct {
+	// Reason is why the session is no longer authorized.
+	Reason string `json:"reason"`
+}
+
+// SessionCommand is a command run on a session, with who ran it where.
+type SessionCommand struct {
+	SessionUID string    `json:"session_uid"`
+	Seat       int       `json:"seat"`
+	Timestamp  time.Time `json:"timestamp"`
+	DeviceUID  UID       `json:"device_uid"`
+	Username   string    `json:"username"`
+	// UserID is the ShellHub account bound to the session, if any.
+	UserID string `json:"user_id,omitempty"`
+	SessionCommandLine
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// SSHUserCA is an OpenSSH certificate authority a namespace trusts to vouch for
+// its principals, the equivalent of sshd's TrustedUserCAKeys. In the identity SSH
+// access mode a user certificate signed by one of the namespace's CAs is
+// recognized without an enrolled [SSHIdentity]: the certificate's principals name
+// the account, and Access Policies decide what it may reach, as for any other
+// identity.
+//
+// A CA's public key maps to exactly one entry per namespace
+// (UNIQUE(namespace_id, fingerprint)); the same CA may be trusted by other
+// namespaces.
+type SSHUserCA struct {
+	ID       string `json:"id"`
+	TenantID string `json:"-"`
+	// Name is a label for the CA, e.g. "corp-vault".
+	Name string `json:"name"`
+	// Fingerprint is the CA public key's fingerprint in "SHA256:…" form, matched
+	// against the signature key of a presented certificate.
+	Fingerprint string `json:"fingerprint"`
+	// Data is the CA public key in OpenSSH authorized_keys form.
+	Data string `json:"data"`
+	// RevokedSerials are the serials of certificates issued by this CA that must
+	// no longer be accepted, however much of their validity is left. Serials are
+	// only unique per CA, so the list lives on it.
+	RevokedSerials []uint64  `json:"revoked_serials"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// IsRevoked reports whether the certificate with the given serial was revoked.
+func (c *SSHUserCA) IsRevoked(serial uint64) bool {
+	return slices.Contains(c.RevokedSerials, serial)
+}
+
+// SSHCertificateIdentity is what an accepted user certificate resolved to: the
+// account its principals name, and what the certificate constrains the session
+// to.
+type SSHCertificateIdentity struct {
+	// CAID is the id of the trusted CA that signed the certificate.
+	CAID string `json:"ca_id"`
+	// PrincipalID is the id of the account the certificate was accepted as, a
+	// human member or a service account.
+	PrincipalID string `json:"principal_id"`
+	// Principal is the certificate principal that named the account.
+	Principal string `json:"principal"`
+	// KeyID and Serial identify the certificate in the CA's own records.
+	KeyID  string `json:"key_id"`
+	Serial uint64 `json:"serial"`
+	// ForceCommand is the certificate's force-command critical option: when set,
+	// it runs in place of whatever shell, command or subsystem the client asks for.
+	ForceCommand string `json:"force_command,omitempty"`
+	// Permissions are the certificate's permit-* extensions.
+	Permissions SSHCertificatePermissions `json:"permissions"`
+}
+
+// SSHCertificatePermissions are the features a user certificate's permit-*
+// extensions grant the session. As with sshd, a certificate grants only what it
+// lists: one issued without permit-pty gets no terminal. permit-user-rc has no
+// counterpart, as the gateway never runs the user's rc file.
+type SSHCertificatePermissions struct {
+	PTY             bool `json:"pty"`
+	PortForwarding  bool `json:"port_forwarding"`
+	AgentForwarding bool `json:"agent_forwarding"`
+	X11Forwarding   bool `json:"x11_forwarding"`
+}
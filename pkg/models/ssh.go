@@ -40,3 +40,29 @@ type SSHPty struct {
 type SSHPtyOutput struct {
 	Output string `json:"output"`
 }
+
+// SSHTcpipForward is the body of a tcpip-forward or cancel-tcpip-forward global
+// request: the address and port the client asks the device to listen on.
+type SSHTcpipForward struct {
+	BindAddr string `json:"bind_addr"`
+	BindPort uint32 `json:"bind_port"`
+}
+
+// SSHForwardedTcpip is the body of a forwarded-tcpip channel: the forward a
+// connection arrived on, and the peer that made it.
+type SSHForwardedTcpip struct {
+	DestAddr   string `json:"dest_addr"`
+	DestPort   uint32 `json:"dest_port"`
+	OriginAddr string `json:"origin_addr"`
+	OriginPort uint32 `json:"origin_port"`
+}
+
+// SSHDenied is the body of the agent's denied@shellhub.io request: something a
+// login asked for that the device's restrictions denied, and why.
+type SSHDenied struct {
+	// Action is the SSH request or channel that was denied, such as "exec" or "pty-req".
+	Action string `json:"action"`
+	// Command is the command that was denied, when the action runs one.
+	Command string `json:"command,omitempty"`
+	Reason  string `json:"reason"`
+}
@@ -32,6 +32,12 @@ const (
 
 	// UserOriginSAML indicates that the user was created using a SAML method.
 	UserOriginSAML UserOrigin = "SAML"
+
+	// UserOriginOIDC indicates that the user was provisioned on their first OpenID Connect sign-in.
+	UserOriginOIDC UserOrigin = "oidc"
+
+	// UserOriginSCIM indicates that the user was created by the identity provider through the SCIM endpoint.
+	UserOriginSCIM UserOrigin = "scim"
 )
 
 func (o UserOrigin) String() string {
@@ -50,6 +56,10 @@ const (
 	// principal. This type is the human/service discriminator, not the membership role, so it
 	// stays valid if roles ever become groups.
 	UserTypeService UserType = "service"
+
+	// UserTypeAPIKey is an API key acting on a device, as Access Policies see it when the
+	// key runs a command or transfers a file. No user is ever stored with this type.
+	UserTypeAPIKey UserType = "api-key"
 )
 
 func (t UserType) String() string {
@@ -64,6 +74,9 @@ const (
 
 	// UserAuthMethodManual indicates that the user can authenticate using a third-party SAML application.
 	UserAuthMethodSAML UserAuthMethod = "saml"
+
+	// UserAuthMethodOIDC indicates that the user can authenticate through the instance's OpenID Connect provider.
+	UserAuthMethodOIDC UserAuthMethod = "oidc"
 )
 
 func (a UserAuthMethod) String() string {
@@ -102,6 +115,9 @@ type User struct {
 	// admin has not approved yet. While true the account is inert: only an admin can mint its
 	// activation link. It is set false when an admin creates the account directly or approves it.
 	AwaitingApproval bool `json:"awaiting_approval"`
+	// Disabled marks an account deactivated by the identity provider. A disabled user can't sign in,
+	// and tokens minted before the deactivation stop working on their next request.
+	Disabled bool `json:"disabled"`
 }
 
 type UserData struct {
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// WebhookEventType names a namespace event a webhook can subscribe to, as
+// "<object>.<what happened>".
+type WebhookEventType string
+
+const (
+	// WebhookEventDeviceOnline is a device reconnecting after it was seen
+	// disconnecting.
+	WebhookEventDeviceOnline  WebhookEventType = "device.online"
+	WebhookEventDeviceOffline WebhookEventType = "device.offline"
+	WebhookEventDeviceAccept  WebhookEventType = "device.accepted"
+	WebhookEventDeviceRemove  WebhookEventType = "device.removed"
+	WebhookEventSessionStart  WebhookEventType = "session.started"
+	WebhookEventSessionClose  WebhookEventType = "session.closed"
+	// WebhookEventSSHApprovalPending is an SSH login waiting on its user to
+	// approve it in the browser.
+	WebhookEventSSHApprovalPending WebhookEventType = "ssh_approval.pending"
+	// WebhookEventSSHApprovalConfirmed and WebhookEventSSHApprovalRejected are
+	// that login being let through or turned away.
+	WebhookEventSSHApprovalConfirmed WebhookEventType = "ssh_approval.confirmed"
+	WebhookEventSSHApprovalRejected  WebhookEventType = "ssh_approval.rejected"
+	// WebhookEventAccessPolicyChange is an Access Policy being created, updated
+	// or deleted.
+	WebhookEventAccessPolicyChange WebhookEventType = "access_policy.changed"
+)
+
+// WebhookEventTypes lists every event a webhook can subscribe to.
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventDeviceOnline,
+	WebhookEventDeviceOffline,
+	WebhookEventDeviceAccept,
+	WebhookEventDeviceRemove,
+	WebhookEventSessionStart,
+	WebhookEventSessionClose,
+	WebhookEventSSHApprovalPending,
+	WebhookEventSSHApprovalConfirmed,
+	WebhookEventSSHApprovalRejected,
+	WebhookEventAccessPolicyChange,
+}
+
+// Webhook is a namespace's subscription to some of its events: each one is
+// POSTed to URL, signed with Secret, until the subscription is disabled or
+// deleted.
+type Webhook struct {
+	ID       string `json:"id"`
+	TenantID string `json:"tenant_id"`
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	// Secret signs every delivery (HMAC-SHA256 of the body, hex-encoded in the
+	// X-ShellHub-Signature header) so the receiver can trust it. It is
+	// write-only.
+	Secret    string             `json:"-"`
+	Events    []WebhookEventType `json:"events"`
+	Enabled   bool               `json:"enabled"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// Subscribes reports whether the webhook wants the event.
+func (w *Webhook) Subscribes(event WebhookEventType) bool {
+	return slices.Contains(w.Events, event)
+}
+
+// WebhookEvent is the body of a delivery. ID identifies the event, not the
+// delivery: every attempt and replay of it carries the same one, so a receiver
+// can tell a retry from a new event.
+type WebhookEvent struct {
+	ID        string           `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	TenantID  string           `json:"tenant_id"`
+	CreatedAt time.Time        `json:"created_at"`
+	// Data is the event's subject, e.g. the device for a device event.
+	Data any `json:"data"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is a delivery still being attempted.
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed is a delivery that ran out of attempts.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent, or being sent, to one webhook: the entry
+// of the webhook's delivery log.
+type WebhookDelivery struct {
+	ID        string           `json:"id"`
+	WebhookID string           `json:"webhook_id"`
+	TenantID  string           `json:"tenant_id"`
+	EventID   string           `json:"event_id"`
+	EventType WebhookEventType `json:"event_type"`
+	// Payload is the exact body sent, and signed, on every attempt.
+	Payload json.RawMessage       `json:"payload"`
+	Status  WebhookDeliveryStatus `json:"status"`
+	// Attempts counts the requests made so far.
+	Attempts int `json:"attempts"`
+	// NextAttemptAt is when a pending delivery is tried next. It is nil once
+	// the delivery settled.
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	// ResponseStatus and LastError describe the latest attempt: the HTTP status
+	// the receiver answered with, or why no answer came. ResponseStatus is zero
+	// when there was none.
+	ResponseStatus int    `json:"response_status"`
+	LastError      string `json:"last_error"`
+	// ReplayOf is the delivery this one replays, if any.
+	ReplayOf    string     `json:"replay_of,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
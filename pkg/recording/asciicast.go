@@ -0,0 +1,140 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// asciicastVersion is the version of the asciicast format written.
+	asciicastVersion = 2
+
+	// Used when nothing in the recording tells the terminal's size.
+	defaultColumns = 80
+	defaultRows    = 24
+)
+
+// asciicastHeader is the first line of an asciicast v2 file.
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     uint32            `json:"width"`
+	Height    uint32            `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// asciicastEncoder writes an asciicast v2 file: a header line, then one line
+// per event with its offset in seconds from the first output.
+type asciicastEncoder struct {
+	w      *bufio.Writer
+	header Header
+	// started is set once the header is written.
+	started bool
+	// start is when the first output happened, which every offset counts from.
+	start time.Time
+	// last is the offset of the latest event written.
+	last time.Duration
+}
+
+var _ Encoder = &asciicastEncoder{}
+
+// NewAsciicastEncoder returns an encoder writing an asciinema v2 cast to w.
+//
+// The header goes out with the first output, so a resize coming before it, as
+// the one recorded when the client asks for its terminal does, sets the size
+// the cast starts at rather than resizing it at once.
+func NewAsciicastEncoder(w io.Writer, header Header) Encoder {
+	if header.Columns == 0 || header.Rows == 0 {
+		header.Columns, header.Rows = defaultColumns, defaultRows
+	}
+
+	return &asciicastEncoder{w: bufio.NewWriter(w), header: header}
+}
+
+func (e *asciicastEncoder) Output(at time.Time, data string) error {
+	if err := e.writeHeader(at); err != nil {
+		return err
+	}
+
+	return e.writeEvent(at, "o", data)
+}
+
+func (e *asciicastEncoder) Resize(at time.Time, columns, rows uint32) error {
+	if columns == 0 || rows == 0 {
+		return nil
+	}
+
+	if !e.started {
+		e.header.Columns, e.header.Rows = columns, rows
+
+		return nil
+	}
+
+	return e.writeEvent(at, "r", fmt.Sprintf("%dx%d", columns, rows))
+}
+
+func (e *asciicastEncoder) Close() error {
+	// A recording without output is still a cast, of nothing.
+	if err := e.writeHeader(time.Time{}); err != nil {
+		return err
+	}
+
+	return e.w.Flush()
+}
+
+func (e *asciicastEncoder) writeHeader(at time.Time) error {
+	if e.started {
+		return nil
+	}
+
+	header := asciicastHeader{
+		Version: asciicastVersion,
+		Width:   e.header.Columns,
+		Height:  e.header.Rows,
+		Title:   e.header.Title,
+	}
+
+	if !at.IsZero() {
+		header.Timestamp = at.Unix()
+	}
+
+	if e.header.Term != "" {
+		header.Env = map[string]string{"TERM": e.header.Term}
+	}
+
+	line, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	e.started = true
+	e.start = at
+
+	return e.writeLine(line)
+}
+
+func (e *asciicastEncoder) writeEvent(at time.Time, code, data string) error {
+	// An event stored out of order by a skewed clock is never placed before the
+	// ones already written, which players reject.
+	offset := max(at.Sub(e.start), e.last)
+	e.last = offset
+
+	line, err := json.Marshal([]any{json.Number(fmt.Sprintf("%.6f", offset.Seconds())), code, data})
+	if err != nil {
+		return err
+	}
+
+	return e.writeLine(line)
+}
+
+func (e *asciicastEncoder) writeLine(line []byte) error {
+	if _, err := e.w.Write(line); err != nil {
+		return err
+	}
+
+	return e.w.WriteByte('\n')
+}
@@ -0,0 +1,64 @@
+// Package recording renders a session's recorded terminal, as stored event by
+// event, in formats other tools read: an asciinema v2 cast to replay it, or a
+// plain-text transcript to read or search it.
+//
+// Encoders take the events one at a time, in order, and write as they go, so a
+// recording of any length is rendered without ever being held in memory.
+package recording
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// Format is a rendering of a recording.
+type Format string
+
+const (
+	// FormatAsciicast is an asciinema v2 cast, replayed at its original pace and
+	// terminal size.
+	FormatAsciicast Format = "asciicast"
+	// FormatText is the output alone, without the escape sequences that drew
+	// it, for reading or searching.
+	FormatText Format = "text"
+)
+
+// ErrUnknownFormat is returned for a format this package does not render.
+var ErrUnknownFormat = errors.New("unknown recording format")
+
+// Header describes the terminal a recording was made on.
+type Header struct {
+	// Columns and Rows are the terminal size the recording starts at. A resize
+	// arriving before the first output replaces them.
+	Columns uint32
+	Rows    uint32
+	// Term is the client's TERM, if known.
+	Term string
+	// Title names the recording, if anything does.
+	Title string
+}
+
+// Encoder renders a recording's events as they are handed to it, which must
+// be in the order they happened.
+type Encoder interface {
+	// Output renders what the terminal printed at the given time.
+	Output(at time.Time, data string) error
+	// Resize renders the terminal being resized at the given time.
+	Resize(at time.Time, columns, rows uint32) error
+	// Close renders whatever the recording still needs to be complete. It does
+	// not close the underlying writer.
+	Close() error
+}
+
+// NewEncoder returns an encoder rendering to w in the given format.
+func NewEncoder(format Format, w io.Writer, header Header) (Encoder, error) {
+	switch format {
+	case FormatAsciicast:
+		return NewAsciicastEncoder(w, header), nil
+	case FormatText:
+		return NewTextEncoder(w), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
@@ -0,0 +1,138 @@
+package recording
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsciicastEncoder(t *testing.T) {
+	start := time.Date(2026, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	t.Run("starts at the size the client asked for and resizes after", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		e := NewAsciicastEncoder(&buf, Header{Term: "xterm-256color"})
+		require.NoError(t, e.Resize(start, 120, 40))
+		require.NoError(t, e.Output(start.Add(100*time.Millisecond), "$ ls\r\n"))
+		require.NoError(t, e.Resize(start.Add(1500*time.Millisecond), 100, 30))
+		require.NoError(t, e.Output(start.Add(2*time.Second), "\x1b[1mREADME\x1b[0m"))
+		require.NoError(t, e.Close())
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 4)
+
+		assert.JSONEq(t, `{"version":2,"width":120,"height":40,"timestamp":1767366245,"env":{"TERM":"xterm-256color"}}`, lines[0])
+		assert.Equal(t, `[0.000000,"o","$ ls\r\n"]`, lines[1])
+		assert.Equal(t, `[1.400000,"r","100x30"]`, lines[2])
+		assert.Equal(t, `[1.900000,"o","\u001b[1mREADME\u001b[0m"]`, lines[3])
+	})
+
+	t.Run("falls back to a default size", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		e := NewAsciicastEncoder(&buf, Header{})
+		require.NoError(t, e.Output(start, "hi"))
+		require.NoError(t, e.Close())
+
+		assert.True(t, strings.HasPrefix(buf.String(), `{"version":2,"width":80,"height":24,`))
+	})
+
+	t.Run("never goes back in time", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		e := NewAsciicastEncoder(&buf, Header{})
+		require.NoError(t, e.Output(start, "a"))
+		require.NoError(t, e.Output(start.Add(time.Second), "b"))
+		require.NoError(t, e.Output(start.Add(500*time.Millisecond), "c"))
+		require.NoError(t, e.Close())
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 4)
+		assert.Equal(t, `[1.000000,"o","c"]`, lines[3])
+	})
+
+	t.Run("renders an empty recording as a header alone", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		e := NewAsciicastEncoder(&buf, Header{Columns: 100, Rows: 30})
+		require.NoError(t, e.Close())
+
+		assert.JSONEq(t, `{"version":2,"width":100,"height":30}`, strings.TrimSuffix(buf.String(), "\n"))
+	})
+}
+
+func TestTextEncoder(t *testing.T) {
+	cases := []struct {
+		description string
+		outputs     []string
+		expected    string
+	}{
+		{
+			description: "keeps lines and tabs",
+			outputs:     []string{"one\r\ntwo\tcolumns\r\n"},
+			expected:    "one\ntwo\tcolumns\n",
+		},
+		{
+			description: "strips colors and cursor movement",
+			outputs:     []string{"\x1b[01;34mdir\x1b[0m  \x1b[2J\x1b[Hfile\x1b[?25l\r\n"},
+			expected:    "dir  file\n",
+		},
+		{
+			description: "strips window titles ended by BEL or ST",
+			outputs:     []string{"\x1b]0;user@host: ~\x07$ \x1b]2;title\x1b\\ls\r\n"},
+			expected:    "$ ls\n",
+		},
+		{
+			description: "strips character set designations",
+			outputs:     []string{"\x1b(Bplain\x1b=\r\n"},
+			expected:    "plain\n",
+		},
+		{
+			description: "strips sequences cut across outputs",
+			outputs:     []string{"red:\x1b[3", "1mtext\x1b", "[0m\x1b]0;ti", "tle\x07done"},
+			expected:    "red:textdone",
+		},
+		{
+			description: "keeps multibyte characters",
+			outputs:     []string{"ação ✓\r\n"},
+			expected:    "ação ✓\n",
+		},
+		{
+			description: "drops other control characters",
+			outputs:     []string{"ab\bc\x07\x7f\r\n"},
+			expected:    "abc\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			e := NewTextEncoder(&buf)
+			for _, output := range tc.outputs {
+				require.NoError(t, e.Output(time.Time{}, output))
+			}
+
+			require.NoError(t, e.Resize(time.Time{}, 80, 24))
+			require.NoError(t, e.Close())
+
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestNewEncoder(t *testing.T) {
+	_, err := NewEncoder(Format("mp4"), &bytes.Buffer{}, Header{})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	for _, format := range []Format{FormatAsciicast, FormatText} {
+		e, err := NewEncoder(format, &bytes.Buffer{}, Header{})
+		require.NoError(t, err)
+		assert.NotNil(t, e)
+	}
+}
@@ -0,0 +1,127 @@
+package recording
+
+import (
+	"bufio"
+	"io"
+	"time"
+)
+
+// textState is where the text encoder is in the terminal's byte stream.
+type textState int
+
+const (
+	// textGround is printable output.
+	textGround textState = iota
+	// textEscape follows an ESC.
+	textEscape
+	// textEscapeIntermediate is inside an escape sequence with intermediate
+	// bytes, such as a character set designation.
+	textEscapeIntermediate
+	// textCSI is inside a control sequence, which moves the cursor, clears the
+	// screen or sets colors.
+	textCSI
+	// textString is inside a string sequence, such as the window title an OSC
+	// sets, which ends at BEL or ST.
+	textString
+	// textStringEscape follows an ESC inside a string sequence, which is the
+	// first half of its ST terminator.
+	textStringEscape
+)
+
+const (
+	bel = 0x07
+	esc = 0x1b
+	del = 0x7f
+)
+
+// textEncoder writes the printable output alone. It keeps its place in the
+// escape sequences across outputs, since a terminal does not care where the
+// writes that drew it were cut.
+type textEncoder struct {
+	w     *bufio.Writer
+	state textState
+}
+
+var _ Encoder = &textEncoder{}
+
+// NewTextEncoder returns an encoder writing a plain-text transcript to w: the
+// output without its escape sequences and control characters, lines ending in
+// a bare newline.
+//
+// It renders the stream rather than the screen, so what a full-screen program
+// redraws in place, or a user erases, is in the transcript as it was printed.
+func NewTextEncoder(w io.Writer) Encoder {
+	return &textEncoder{w: bufio.NewWriter(w)}
+}
+
+func (e *textEncoder) Output(_ time.Time, data string) error {
+	for i := 0; i < len(data); i++ {
+		if err := e.feed(data[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Resize does nothing: a transcript has no size.
+func (e *textEncoder) Resize(time.Time, uint32, uint32) error {
+	return nil
+}
+
+func (e *textEncoder) Close() error {
+	return e.w.Flush()
+}
+
+func (e *textEncoder) feed(b byte) error {
+	switch e.state {
+	case textGround:
+		switch {
+		case b == esc:
+			e.state = textEscape
+		case b == '\n', b == '\t':
+			return e.w.WriteByte(b)
+		case b < 0x20, b == del:
+			// Carriage returns, backspaces and bells position or alert, and print
+			// nothing.
+		default:
+			return e.w.WriteByte(b)
+		}
+	case textEscape:
+		switch {
+		case b == '[':
+			e.state = textCSI
+		case b == ']', b == 'P', b == 'X', b == '^', b == '_':
+			// OSC, DCS, SOS, PM and APC all carry a string up to ST.
+			e.state = textString
+		case b >= 0x20 && b <= 0x2f:
+			e.state = textEscapeIntermediate
+		default:
+			e.state = textGround
+		}
+	case textEscapeIntermediate:
+		if b < 0x20 || b > 0x2f {
+			e.state = textGround
+		}
+	case textCSI:
+		// Parameters and intermediates until the final byte.
+		if b >= 0x40 && b <= 0x7e {
+			e.state = textGround
+		}
+	case textString:
+		switch b {
+		case bel:
+			e.state = textGround
+		case esc:
+			e.state = textStringEscape
+		}
+	case textStringEscape:
+		if b == '\\' {
+			e.state = textGround
+		} else {
+			e.state = textString
+		}
+	}
+
+	return nil
+}
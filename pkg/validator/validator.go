@@ -47,6 +47,10 @@ const (
 	APIKeyExpiresAtTag = "api-key_expires-at" //nolint:gosec // G101: not a credential, this is a validator tag name
 	// MemberRoleTag contains the rule to validate a namespace member's role.
 	MemberRoleTag = "member_role"
+	// ScheduleTimeTag contains the rule to validate an access policy schedule's time of day.
+	ScheduleTimeTag = "schedule_time"
+	// PermissionTag contains the rule to validate a permission's name.
+	PermissionTag = "permission"
 )
 
 // Rules is a slice that contains all validation rules.
@@ -121,6 +125,25 @@ var Rules = []Rule{
 		},
 		Error: fmt.Errorf("role must be \"owner\", \"administrator\", \"operator\" or \"observer\""),
 	},
+	// schedule_time reports whether a given string is a "HH:MM" time of day, from
+	// "00:00" up to "24:00" for the end of the day.
+	{
+		Tag: ScheduleTimeTag,
+		Handler: func(field validator.FieldLevel) bool {
+			return regexp.MustCompile(`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`).MatchString(field.Field().String())
+		},
+		Error: fmt.Errorf("time must be in the HH:MM format, from 00:00 to 24:00"),
+	},
+	// permission reports whether a given string names a permission, like "device:connect".
+	{
+		Tag: PermissionTag,
+		Handler: func(field validator.FieldLevel) bool {
+			_, ok := authorizer.PermissionFromString(field.Field().String())
+
+			return ok
+		},
+		Error: fmt.Errorf("the permission is invalid"),
+	},
 	{
 		Tag: PrivateKeyPEMTag,
 		Handler: func(field validator.FieldLevel) bool {
@@ -577,6 +577,102 @@ func TestMemberRole(t *testing.T) {
 	}
 }
 
+func TestPermission(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+		want        bool
+	}{
+		{
+			description: "fails when permission is empty",
+			value:       "",
+			want:        false,
+		},
+		{
+			description: "fails when permission is unknown",
+			value:       "device:destroy",
+			want:        false,
+		},
+		{
+			description: "succeeds when permission is device:connect",
+			value:       "device:connect",
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			data := struct {
+				Permission string `validate:"permission"`
+			}{
+				Permission: tt.value,
+			}
+
+			ok, _ := New().Struct(data)
+
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestScheduleTime(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+		want        bool
+	}{
+		{
+			description: "fails when time is empty",
+			value:       "",
+			want:        false,
+		},
+		{
+			description: "fails when the hour has a single digit",
+			value:       "8:00",
+			want:        false,
+		},
+		{
+			description: "fails when the minute is out of range",
+			value:       "12:60",
+			want:        false,
+		},
+		{
+			description: "fails when time is past the end of the day",
+			value:       "24:30",
+			want:        false,
+		},
+		{
+			description: "succeeds when time is midnight",
+			value:       "00:00",
+			want:        true,
+		},
+		{
+			description: "succeeds when time is 18:30",
+			value:       "18:30",
+			want:        true,
+		},
+		{
+			description: "succeeds when time is the end of the day",
+			value:       "24:00",
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			data := struct {
+				Time string `validate:"schedule_time"`
+			}{
+				Time: tt.value,
+			}
+
+			ok, _ := New().Struct(data)
+
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
 func TestCertPEM(t *testing.T) {
 	tests := []struct {
 		description string
@@ -10,7 +10,10 @@ import (
 
 type ctxKey string
 
-const deviceLimitKey ctxKey = "namespace-device-limit"
+const (
+	deviceLimitKey ctxKey = "namespace-device-limit"
+	originKey      ctxKey = "origin"
+)
 
 // namespaceDeviceLimit pairs the limit with the namespace it was read for, so a request
 // targeting a different namespace cannot be answered from it.
@@ -41,3 +44,24 @@ func NamespaceDeviceLimit(ctx context.Context, tenantID string) (models.Namespac
 
 	return held.limit, true
 }
+
+// Origin is who made the request and where it came from, as authentication saw it. The audit
+// log attributes the actions a request takes to it.
+type Origin struct {
+	Actor     models.AuditActor
+	SourceIP  string
+	RequestID string
+}
+
+// WithOrigin returns ctx carrying the request's origin.
+func WithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, originKey, origin)
+}
+
+// OriginFrom returns the origin authentication recorded for the request. It reports false for a
+// context built outside an authenticated HTTP request, such as a worker's.
+func OriginFrom(ctx context.Context) (Origin, bool) {
+	origin, ok := ctx.Value(originKey).(Origin)
+
+	return origin, ok
+}
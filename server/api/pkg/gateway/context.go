@@ -75,6 +75,23 @@ func (c *Context) Role() authorizer.Role {
 	return authorizer.RoleFromString(c.Request().Header.Get("X-Role"))
 }
 
+// HasPermission reports whether the caller holds permission: its role must grant it and, for a
+// scoped API key, the key must keep it. See [Allows].
+func (c *Context) HasPermission(permission authorizer.Permission) bool {
+	return Allows(c.Request().Header, permission)
+}
+
+// DeviceTags returns the device tags a scoped API key is restricted to, or nil when the caller
+// reaches every device.
+func (c *Context) DeviceTags() []string {
+	return DeviceTags(c.Request().Header)
+}
+
+// ReachesDevice reports whether the caller may act on device. See [ReachesDevice].
+func (c *Context) ReachesDevice(device *models.Device) bool {
+	return ReachesDevice(c.Request().Header, device)
+}
+
 // Tenant returns the namespace's tenant got from JWT through gateway.
 func (c *Context) Tenant() *models.Tenant {
 	tenant := c.Request().Header.Get("X-Tenant-ID")
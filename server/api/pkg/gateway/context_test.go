@@ -359,3 +359,131 @@ func TestGetUsername(t *testing.T) {
 		})
 	}
 }
+
+func TestHasPermission(t *testing.T) {
+	cases := []struct {
+		description string
+		headers     map[string]string
+		permission  authorizer.Permission
+		expected    bool
+	}{
+		{
+			description: "the role grants the permission",
+			headers: map[string]string{
+				"X-Role": authorizer.RoleOperator.String(),
+			},
+			permission: authorizer.DeviceConnect,
+			expected:   true,
+		}, {
+			description: "the role does not grant the permission",
+			headers: map[string]string{
+				"X-Role": authorizer.RoleObserver.String(),
+			},
+			permission: authorizer.DeviceRemove,
+			expected:   false,
+		}, {
+			description: "a scoped API key keeps a listed permission",
+			headers: map[string]string{
+				"X-Role":                authorizer.RoleAdministrator.String(),
+				"X-API-Key-Permissions": "device:connect,device:rename",
+			},
+			permission: authorizer.DeviceRename,
+			expected:   true,
+		}, {
+			description: "a scoped API key loses a permission its role grants but it does not list",
+			headers: map[string]string{
+				"X-Role":                authorizer.RoleAdministrator.String(),
+				"X-API-Key-Permissions": "device:connect",
+			},
+			permission: authorizer.DeviceRemove,
+			expected:   false,
+		}, {
+			description: "a scoped API key never gains a permission its role lacks",
+			headers: map[string]string{
+				"X-Role":                authorizer.RoleObserver.String(),
+				"X-API-Key-Permissions": "device:remove",
+			},
+			permission: authorizer.DeviceRemove,
+			expected:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			ctxNew := Context{
+				nil,
+				ctx,
+			}
+
+			require.Equal(t, tc.expected, ctxNew.HasPermission(tc.permission))
+		})
+	}
+}
+
+func TestReachesDevice(t *testing.T) {
+	cases := []struct {
+		description string
+		headers     map[string]string
+		device      *models.Device
+		expected    bool
+	}{
+		{
+			description: "an unscoped caller reaches every device",
+			headers:     map[string]string{},
+			device:      &models.Device{},
+			expected:    true,
+		}, {
+			description: "a scoped API key reaches a device carrying one of its tags",
+			headers: map[string]string{
+				"X-API-Key-Tags": "production,staging",
+			},
+			device:   &models.Device{Taggable: models.Taggable{Tags: []models.Tag{{Name: "staging"}}}},
+			expected: true,
+		}, {
+			description: "a scoped API key does not reach a device without its tags",
+			headers: map[string]string{
+				"X-API-Key-Tags": "production",
+			},
+			device:   &models.Device{Taggable: models.Taggable{Tags: []models.Tag{{Name: "staging"}}}},
+			expected: false,
+		}, {
+			description: "a scoped API key does not reach an untagged device",
+			headers: map[string]string{
+				"X-API-Key-Tags": "production",
+			},
+			device:   &models.Device{},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			ctxNew := Context{
+				nil,
+				ctx,
+			}
+
+			require.Equal(t, tc.expected, ctxNew.ReachesDevice(tc.device))
+		})
+	}
+}
@@ -2,8 +2,11 @@ package gateway
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 // Identity is the caller's authenticated identity. It is produced by the
@@ -17,6 +20,13 @@ type Identity struct {
 	APIKey    string
 	Role      authorizer.Role
 	Admin     bool
+	// Permissions narrows Role for a scoped API key. Nil keeps every permission of the role.
+	Permissions []authorizer.Permission
+	// DeviceTags restricts a scoped API key to the devices carrying any of these tags. Nil reaches
+	// every device.
+	DeviceTags []string
+	// AuthMethod is how a user signed in, as their token records it.
+	AuthMethod string
 }
 
 // identityHeaders enumerates every header that carries part of the caller's
@@ -30,6 +40,9 @@ var identityHeaders = []string{
 	"X-API-Key",
 	"X-Role",
 	"X-Admin",
+	"X-API-Key-Permissions",
+	"X-API-Key-Tags",
+	"X-Auth-Method",
 }
 
 // WriteTo stamps the identity onto header, clearing every identity header
@@ -60,10 +73,64 @@ func (i *Identity) WriteTo(header http.Header) {
 	set("X-Device-UID", i.DeviceUID)
 	set("X-API-Key", i.APIKey)
 	set("X-Role", i.Role.String())
+	set("X-Auth-Method", i.AuthMethod)
 
 	if i.Admin {
 		header.Set("X-Admin", "true")
 	}
+
+	if i.Permissions != nil {
+		names := make([]string, len(i.Permissions))
+		for idx, permission := range i.Permissions {
+			names[idx] = permission.String()
+		}
+
+		set("X-API-Key-Permissions", strings.Join(names, ","))
+	}
+
+	set("X-API-Key-Tags", strings.Join(i.DeviceTags, ","))
+}
+
+// Allows reports whether the identity stamped on header holds permission: its role must grant it
+// and, for a scoped API key, the key must keep it.
+//
+// Every authorization decision on the caller's permissions goes through here rather than through
+// the role alone, or a scoped key would regain whatever its role grants.
+func Allows(header http.Header, permission authorizer.Permission) bool {
+	if !authorizer.RoleFromString(header.Get("X-Role")).HasPermission(permission) {
+		return false
+	}
+
+	scoped := header.Get("X-API-Key-Permissions")
+	if scoped == "" {
+		return true
+	}
+
+	return slices.Contains(strings.Split(scoped, ","), permission.String())
+}
+
+// DeviceTags returns the device tags the identity stamped on header is restricted to, or nil when
+// it reaches every device.
+func DeviceTags(header http.Header) []string {
+	tags := header.Get("X-API-Key-Tags")
+	if tags == "" {
+		return nil
+	}
+
+	return strings.Split(tags, ",")
+}
+
+// ReachesDevice reports whether the identity stamped on header may act on device: a scoped API key
+// reaches only the devices carrying one of its tags.
+func ReachesDevice(header http.Header, device *models.Device) bool {
+	scope := DeviceTags(header)
+	if scope == nil {
+		return true
+	}
+
+	return slices.ContainsFunc(device.Tags, func(tag models.Tag) bool {
+		return slices.Contains(scope, tag.Name)
+	})
 }
 
 // WithoutUserScope returns the identity stripped of the acting user's ID and
@@ -0,0 +1,85 @@
+// Package grouprole maps an identity provider's groups to namespace memberships and roles.
+package grouprole
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+)
+
+// GroupRole grants the members of a provider's group a role in a namespace.
+type GroupRole struct {
+	Group    string
+	TenantID string
+	Role     authorizer.Role
+}
+
+// Mapping is the list of grants a provider's groups are resolved against.
+type Mapping []GroupRole
+
+// Parse parses a group to role mapping written as semicolon separated "group=tenant:role"
+// entries. The group is everything before the last "=", so a distinguished name like
+// "cn=admins,ou=groups" can be mapped as is.
+//
+// Only the administrator, operator and observer roles can be granted: a namespace has a
+// single owner, and it is never one the provider decides.
+func Parse(raw string) (Mapping, error) {
+	mapping := make(Mapping, 0)
+
+	for entry := range strings.SplitSeq(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid group role mapping %q: want group=tenant:role", entry)
+		}
+
+		tenant, role, ok := strings.Cut(entry[i+1:], ":")
+		if !ok || tenant == "" {
+			return nil, fmt.Errorf("invalid group role mapping %q: want group=tenant:role", entry)
+		}
+
+		grant := GroupRole{
+			Group:    entry[:i],
+			TenantID: tenant,
+			Role:     authorizer.RoleFromString(role),
+		}
+
+		switch grant.Role {
+		case authorizer.RoleAdministrator, authorizer.RoleOperator, authorizer.RoleObserver:
+		default:
+			return nil, fmt.Errorf("invalid group role mapping %q: role %q cannot be granted", entry, role)
+		}
+
+		mapping = append(mapping, grant)
+	}
+
+	return mapping, nil
+}
+
+// Roles resolves the role a user in groups is granted in each namespace the mapping manages.
+// Every managed namespace is in the result; it is [authorizer.RoleInvalid] where none of the
+// user's groups grants a role, and the highest one where several do.
+func (m Mapping) Roles(groups []string) map[string]authorizer.Role {
+	roles := make(map[string]authorizer.Role)
+
+	for _, grant := range m {
+		current := roles[grant.TenantID]
+		if !slices.Contains(groups, grant.Group) {
+			roles[grant.TenantID] = current
+
+			continue
+		}
+
+		if current == authorizer.RoleInvalid || grant.Role.HasAuthority(current) {
+			roles[grant.TenantID] = grant.Role
+		}
+	}
+
+	return roles
+}
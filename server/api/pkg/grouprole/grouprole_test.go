@@ -0,0 +1,97 @@
+package grouprole_test
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/server/api/pkg/grouprole"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tenant = "00000000-0000-4000-0000-000000000000"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		description string
+		raw         string
+		expected    grouprole.Mapping
+		err         bool
+	}{
+		{
+			description: "an empty mapping maps nothing",
+			raw:         "",
+			expected:    grouprole.Mapping{},
+		},
+		{
+			description: "entries are read in order",
+			raw:         "admins=" + tenant + ":administrator; developers=" + tenant + ":operator",
+			expected: grouprole.Mapping{
+				{Group: "admins", TenantID: tenant, Role: authorizer.RoleAdministrator},
+				{Group: "developers", TenantID: tenant, Role: authorizer.RoleOperator},
+			},
+		},
+		{
+			description: "a distinguished name is kept whole",
+			raw:         "cn=auditors,ou=groups,dc=example=" + tenant + ":observer",
+			expected: grouprole.Mapping{
+				{Group: "cn=auditors,ou=groups,dc=example", TenantID: tenant, Role: authorizer.RoleObserver},
+			},
+		},
+		{
+			description: "an entry without a tenant fails",
+			raw:         "admins=administrator",
+			err:         true,
+		},
+		{
+			description: "an entry without a group fails",
+			raw:         "=" + tenant + ":administrator",
+			err:         true,
+		},
+		{
+			description: "the owner role cannot be granted",
+			raw:         "admins=" + tenant + ":owner",
+			err:         true,
+		},
+		{
+			description: "an unknown role fails",
+			raw:         "admins=" + tenant + ":root",
+			err:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			mapping, err := grouprole.Parse(tc.raw)
+			if tc.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, mapping)
+		})
+	}
+}
+
+func TestMappingRoles(t *testing.T) {
+	const other = "00000000-0000-4000-0000-000000000001"
+
+	mapping := grouprole.Mapping{
+		{Group: "developers", TenantID: tenant, Role: authorizer.RoleOperator},
+		{Group: "admins", TenantID: tenant, Role: authorizer.RoleAdministrator},
+		{Group: "auditors", TenantID: tenant, Role: authorizer.RoleObserver},
+		{Group: "operators", TenantID: other, Role: authorizer.RoleOperator},
+	}
+
+	assert.Equal(t, map[string]authorizer.Role{
+		tenant: authorizer.RoleAdministrator,
+		other:  authorizer.RoleInvalid,
+	}, mapping.Roles([]string{"auditors", "developers", "admins"}), "the highest role wins")
+
+	assert.Equal(t, map[string]authorizer.Role{
+		tenant: authorizer.RoleInvalid,
+		other:  authorizer.RoleInvalid,
+	}, mapping.Roles([]string{"guests"}), "every managed namespace is listed")
+}
@@ -0,0 +1,185 @@
+// Package oidc signs users in through an OpenID Connect provider, with the authorization code
+// flow protected by PKCE.
+package oidc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/server/api/pkg/grouprole"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrMissingIDToken = errors.New("the provider returned no ID token")
+	ErrNonceMismatch  = errors.New("the ID token nonce does not match the sign-in")
+	ErrMissingSubject = errors.New("the ID token carries no subject")
+)
+
+// DefaultGroupsClaim is the ID token claim the user's groups are read from when the
+// configuration names none.
+const DefaultGroupsClaim = "groups"
+
+type Config struct {
+	// Issuer is the provider's issuer URL, which its discovery document is served under.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is where the provider sends the user back to, and must be registered with it.
+	RedirectURL string
+	// Scopes are requested on top of "openid".
+	Scopes []string
+	// GroupsClaim names the ID token claim listing the user's groups. Empty means
+	// [DefaultGroupsClaim].
+	GroupsClaim string
+	// GroupRoles maps the provider's groups to namespace roles.
+	GroupRoles grouprole.Mapping
+}
+
+// Identity is the user the provider signed in.
+type Identity struct {
+	// Subject is the user's identifier at the provider, stable across sign-ins.
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// Username is the "preferred_username" claim, a hint the provider may not honor.
+	Username string
+	Groups   []string
+}
+
+type Provider struct {
+	config      oauth2.Config
+	verifier    *oidc.IDTokenVerifier
+	groupsClaim string
+	groupRoles  grouprole.Mapping
+}
+
+// NewProvider discovers the provider at cfg.Issuer. ctx is kept to fetch the provider's signing
+// keys as they rotate, and only its values are used: cancelling it does not stop the provider.
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	provider, err := oidc.NewProvider(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsClaim := cfg.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = DefaultGroupsClaim
+	}
+
+	scopes := []string{oidc.ScopeOpenID}
+	for _, scope := range cfg.Scopes {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &Provider{
+		config: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier:    provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+		groupsClaim: groupsClaim,
+		groupRoles:  cfg.GroupRoles,
+	}, nil
+}
+
+// NewVerifier returns a random PKCE code verifier, to be kept until the sign-in's code is exchanged.
+func NewVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+// AuthCodeURL returns the provider's URL the user signs in at. state comes back with the user to
+// tie the callback to this sign-in, nonce must be in the ID token it ends with, and only the S256
+// challenge of verifier leaves the server.
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	return p.config.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+}
+
+// Exchange redeems the authorization code the provider sent the user back with, proving the
+// sign-in with verifier, and returns the identity of the ID token after checking its signature,
+// audience, expiry and nonce.
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Identity, error) {
+	token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := token.Extra("id_token").(string)
+	if !ok || raw == "" {
+		return nil, ErrMissingIDToken
+	}
+
+	idToken, err := p.verifier.Verify(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	if idToken.Subject == "" {
+		return nil, ErrMissingSubject
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+		Username      string `json:"preferred_username"`
+	}
+
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+
+	var extra map[string]any
+	if err := idToken.Claims(&extra); err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		Subject:       idToken.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Username:      claims.Username,
+		Groups:        groups(extra[p.groupsClaim]),
+	}, nil
+}
+
+// groups reads a groups claim, which providers send either as a list or, with a single group,
+// as a plain string.
+func groups(claim any) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		groups := make([]string, 0, len(value))
+		for _, group := range value {
+			if group, ok := group.(string); ok {
+				groups = append(groups, group)
+			}
+		}
+
+		return groups
+	default:
+		return []string{}
+	}
+}
+
+// Roles resolves the role a user in groups is granted in each namespace the provider's group
+// mapping manages, as [grouprole.Mapping.Roles] does.
+func (p *Provider) Roles(groups []string) map[string]authorizer.Role {
+	return p.groupRoles.Roles(groups)
+}
@@ -0,0 +1,147 @@
+package oidc_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/server/api/pkg/grouprole"
+	"github.com/shellhub-io/shellhub/server/api/pkg/oidc"
+	"github.com/shellhub-io/shellhub/server/api/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tenant = "00000000-0000-4000-0000-000000000000"
+
+func newProvider(t *testing.T, idp *oidctest.Provider) *oidc.Provider {
+	t.Helper()
+
+	provider, err := oidc.NewProvider(t.Context(), oidc.Config{
+		Issuer:       idp.URL,
+		ClientID:     oidctest.ClientID,
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  "https://shellhub.example.com/api/user/oidc/callback",
+		Scopes:       []string{"openid", "email", "profile"},
+	})
+	require.NoError(t, err)
+
+	return provider
+}
+
+func TestProviderAuthCodeURL(t *testing.T) {
+	provider := newProvider(t, oidctest.NewProvider(t))
+
+	authURL, err := url.Parse(provider.AuthCodeURL("state", "nonce", oidc.NewVerifier()))
+	require.NoError(t, err)
+
+	query := authURL.Query()
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, "nonce", query.Get("nonce"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.NotEmpty(t, query.Get("code_challenge"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Empty(t, query.Get("code_verifier"))
+}
+
+func TestProviderExchange(t *testing.T) {
+	idp := oidctest.NewProvider(t)
+	provider := newProvider(t, idp)
+
+	t.Run("the signed in user's identity is returned", func(t *testing.T) {
+		idp.SignIn(map[string]any{
+			"sub":                "user-1",
+			"email":              "John.Doe@example.com",
+			"email_verified":     true,
+			"name":               "John Doe",
+			"preferred_username": "john",
+			"groups":             []string{"admins", "developers"},
+		})
+
+		verifier := oidc.NewVerifier()
+		code, state := idp.Authorize(t, provider.AuthCodeURL("state", "nonce", verifier))
+		assert.Equal(t, "state", state)
+
+		identity, err := provider.Exchange(t.Context(), code, verifier, "nonce")
+		require.NoError(t, err)
+		assert.Equal(t, &oidc.Identity{
+			Subject:       "user-1",
+			Email:         "john.doe@example.com",
+			EmailVerified: true,
+			Name:          "John Doe",
+			Username:      "john",
+			Groups:        []string{"admins", "developers"},
+		}, identity)
+	})
+
+	t.Run("a single group sent as a string is read", func(t *testing.T) {
+		idp.SignIn(map[string]any{"sub": "user-1", "groups": "admins"})
+
+		verifier := oidc.NewVerifier()
+		code, _ := idp.Authorize(t, provider.AuthCodeURL("state", "nonce", verifier))
+
+		identity, err := provider.Exchange(t.Context(), code, verifier, "nonce")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admins"}, identity.Groups)
+	})
+
+	t.Run("a code redeemed without its verifier is refused", func(t *testing.T) {
+		idp.SignIn(map[string]any{"sub": "user-1"})
+
+		code, _ := idp.Authorize(t, provider.AuthCodeURL("state", "nonce", oidc.NewVerifier()))
+
+		_, err := provider.Exchange(t.Context(), code, oidc.NewVerifier(), "nonce")
+		assert.Error(t, err)
+	})
+
+	t.Run("an ID token for another sign-in is refused", func(t *testing.T) {
+		idp.SignIn(map[string]any{"sub": "user-1"})
+
+		verifier := oidc.NewVerifier()
+		code, _ := idp.Authorize(t, provider.AuthCodeURL("state", "nonce", verifier))
+
+		_, err := provider.Exchange(t.Context(), code, verifier, "another-nonce")
+		assert.ErrorIs(t, err, oidc.ErrNonceMismatch)
+	})
+
+	t.Run("a code is redeemed only once", func(t *testing.T) {
+		idp.SignIn(map[string]any{"sub": "user-1"})
+
+		verifier := oidc.NewVerifier()
+		code, _ := idp.Authorize(t, provider.AuthCodeURL("state", "nonce", verifier))
+
+		_, err := provider.Exchange(t.Context(), code, verifier, "nonce")
+		require.NoError(t, err)
+
+		_, err = provider.Exchange(t.Context(), code, verifier, "nonce")
+		assert.Error(t, err)
+	})
+}
+
+func TestProviderRoles(t *testing.T) {
+	const other = "00000000-0000-4000-0000-000000000001"
+
+	idp := oidctest.NewProvider(t)
+
+	provider, err := oidc.NewProvider(t.Context(), oidc.Config{
+		Issuer:   idp.URL,
+		ClientID: oidctest.ClientID,
+		GroupRoles: grouprole.Mapping{
+			{Group: "developers", TenantID: tenant, Role: authorizer.RoleOperator},
+			{Group: "admins", TenantID: tenant, Role: authorizer.RoleAdministrator},
+			{Group: "auditors", TenantID: tenant, Role: authorizer.RoleObserver},
+			{Group: "operators", TenantID: other, Role: authorizer.RoleOperator},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]authorizer.Role{
+		tenant: authorizer.RoleAdministrator,
+		other:  authorizer.RoleInvalid,
+	}, provider.Roles([]string{"auditors", "developers", "admins"}), "the highest role wins")
+
+	assert.Equal(t, map[string]authorizer.Role{
+		tenant: authorizer.RoleInvalid,
+		other:  authorizer.RoleInvalid,
+	}, provider.Roles([]string{"guests"}), "every managed namespace is listed")
+}
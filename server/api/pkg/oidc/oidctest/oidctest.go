@@ -0,0 +1,230 @@
+// Package oidctest serves an OpenID Connect provider from memory, so the sign-in flow can be
+// exercised end to end in tests without a real identity provider.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/coreos/go-oidc/v3/oidc/oidctest"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+const (
+	ClientID     = "shellhub"
+	ClientSecret = "secret"
+
+	keyID = "oidctest"
+)
+
+type grant struct {
+	challenge   string
+	nonce       string
+	redirectURI string
+	claims      map[string]any
+}
+
+// Provider is a provider that signs in whoever [Provider.SignIn] names, without prompting. It
+// implements the authorization endpoint, at /auth, and the token endpoint, at /token, on top
+// of the discovery document and the signing keys.
+type Provider struct {
+	// URL is the provider's issuer URL.
+	URL string
+
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	claims map[string]any
+	grants map[string]grant
+}
+
+// NewProvider starts a provider that is closed when the test ends. It only accepts [ClientID]
+// and [ClientSecret] as the client's credentials.
+func NewProvider(t testing.TB) *Provider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &Provider{
+		key:    key,
+		claims: map[string]any{},
+		grants: map[string]grant{},
+	}
+
+	discovery := &oidctest.Server{
+		PublicKeys: []oidctest.PublicKey{{PublicKey: key.Public(), KeyID: keyID, Algorithm: oidc.RS256}},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/.well-known/openid-configuration", discovery)
+	mux.Handle("/keys", discovery)
+	mux.HandleFunc("/auth", p.authorize)
+	mux.HandleFunc("/token", p.token)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	discovery.SetIssuer(server.URL)
+	p.URL = server.URL
+
+	return p
+}
+
+// SignIn sets the claims of the user the next authorizations sign in, on top of the ones every
+// ID token carries.
+func (p *Provider) SignIn(claims map[string]any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.claims = maps.Clone(claims)
+}
+
+// Authorize visits authURL as the user's browser would and returns the code and state the
+// provider redirects back with.
+func (p *Provider) Authorize(t testing.TB, authURL string) (code, state string) {
+	t.Helper()
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	res, err := client.Get(authURL) //nolint:noctx
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	location, err := res.Location()
+	if err != nil {
+		t.Fatalf("the provider did not redirect back: %s", res.Status)
+	}
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("client_id") != ClientID || query.Get("response_type") != "code" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+
+		return
+	}
+
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "invalid_request: PKCE is required", http.StatusBadRequest)
+
+		return
+	}
+
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirect.String() == "" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+
+		return
+	}
+
+	code := uuid.Generate()
+
+	p.mu.Lock()
+	p.grants[code] = grant{
+		challenge:   query.Get("code_challenge"),
+		nonce:       query.Get("nonce"),
+		redirectURI: redirect.String(),
+		claims:      maps.Clone(p.claims),
+	}
+	p.mu.Unlock()
+
+	values := redirect.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirect.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request")
+
+		return
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+
+	if id != ClientID || secret != ClientSecret {
+		tokenError(w, "invalid_client")
+
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	p.mu.Lock()
+	grant, ok := p.grants[code]
+	// A code is redeemed once, whatever the outcome.
+	delete(p.grants, code)
+	p.mu.Unlock()
+
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("redirect_uri") != grant.redirectURI {
+		tokenError(w, "invalid_grant")
+
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge {
+		tokenError(w, "invalid_grant")
+
+		return
+	}
+
+	claims := maps.Clone(grant.claims)
+	claims["iss"] = p.URL
+	claims["aud"] = ClientID
+	claims["iat"] = time.Now().Unix()
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+	if grant.nonce != "" {
+		claims["nonce"] = grant.nonce
+	}
+
+	raw, err := json.Marshal(claims)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": uuid.Generate(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     oidctest.SignIDToken(p.key, keyID, oidc.RS256, string(raw)),
+	})
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
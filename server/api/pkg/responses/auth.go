@@ -0,0 +1,6 @@
+package responses
+
+// AuthURL is the identity provider's URL a user signs in at.
+type AuthURL struct {
+	URL string `json:"url"`
+}
@@ -1,14 +1,21 @@
 package responses
 
+import "github.com/shellhub-io/shellhub/pkg/models"
+
 type SystemInfo struct {
 	Version        string                    `json:"version"`
 	Endpoints      *SystemEndpointsInfo      `json:"endpoints"`
 	Setup          bool                      `json:"setup"`
 	Authentication *SystemAuthenticationInfo `json:"authentication"`
+	// Rollout is the decision on updating the agent of the device that asked, when the instance has an agent rollout
+	// policy.
+	Rollout *models.AgentRollout `json:"rollout,omitempty"`
 }
 
 type SystemAuthenticationInfo struct {
 	Local bool `json:"local"`
+	// OIDC reports whether users can sign in through the instance's OpenID Connect provider.
+	OIDC bool `json:"oidc"`
 }
 
 type SystemEndpointsInfo struct {
@@ -0,0 +1,60 @@
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Filter is an "attribute eq value" filter, the only expression identity providers send to look a
+// resource up before creating it.
+type Filter struct {
+	// Attribute is the attribute path as the client wrote it. SCIM attribute names are case
+	// insensitive, so compare it with [strings.EqualFold].
+	Attribute string
+	Value     string
+}
+
+// ParseFilter parses a filter. An empty one parses to nil, which matches every resource.
+func ParseFilter(raw string) (*Filter, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	attribute, rest, ok := cutAttribute(raw)
+	if !ok {
+		return nil, NewError(ErrorTypeInvalidFilter, "invalid filter %q", raw)
+	}
+
+	operator, value, ok := strings.Cut(strings.TrimSpace(rest), " ")
+	if !ok || !strings.EqualFold(operator, "eq") {
+		return nil, NewError(ErrorTypeInvalidFilter, "unsupported filter %q: only the eq operator is", raw)
+	}
+
+	filter := &Filter{Attribute: attribute}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(value)), &filter.Value); err != nil {
+		return nil, NewError(ErrorTypeInvalidFilter, "unsupported filter %q: the value must be a string", raw)
+	}
+
+	return filter, nil
+}
+
+// cutAttribute slices the attribute path off an expression. A path may hold a value filter like
+// emails[type eq "work"].value, whose spaces don't end it.
+func cutAttribute(raw string) (string, string, bool) {
+	depth := 0
+	for i, r := range raw {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ' ':
+			if depth == 0 {
+				return raw[:i], raw[i+1:], true
+			}
+		}
+	}
+
+	return raw, "", false
+}
@@ -0,0 +1,257 @@
+package scim
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PatchRequest is the body of a PATCH request.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation changes the resource at Path. Providers disagree on the details, so the operation
+// is matched case insensitively and, without a path, Value is an object keyed by paths.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+const (
+	opAdd     = "add"
+	opReplace = "replace"
+	opRemove  = "remove"
+)
+
+// forEach calls fn with every path and value the operation writes, expanding an operation without
+// a path into one call per key of its value.
+func (op *PatchOperation) forEach(fn func(path string, value json.RawMessage) error) error {
+	if op.Path != "" {
+		return fn(op.Path, op.Value)
+	}
+
+	if strings.EqualFold(op.Op, opRemove) {
+		return NewError(ErrorTypeNoTarget, "a remove operation needs a path")
+	}
+
+	values := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(op.Value, &values); err != nil {
+		return NewError(ErrorTypeInvalidValue, "an operation without a path needs an object value")
+	}
+
+	for path, value := range values {
+		if err := fn(path, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Patch applies the operations to the user in order. Attributes ShellHub does not keep, like a
+// user's title or the enterprise extension, are ignored rather than refused, since providers send
+// them whatever the server advertises.
+func (u *User) Patch(operations []PatchOperation) error {
+	for _, op := range operations {
+		remove := false
+		switch strings.ToLower(op.Op) {
+		case opAdd, opReplace:
+		case opRemove:
+			remove = true
+		default:
+			return NewError(ErrorTypeInvalidSyntax, "unsupported operation %q", op.Op)
+		}
+
+		if err := op.forEach(func(path string, value json.RawMessage) error {
+			if remove {
+				value = nil
+			}
+
+			return u.set(path, value)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *User) set(path string, value json.RawMessage) error {
+	if u.Name == nil {
+		u.Name = new(Name)
+	}
+
+	attribute := strings.ToLower(strings.TrimPrefix(path, SchemaUser+":"))
+	switch {
+	case attribute == "active":
+		active, err := decodeBool(path, value)
+		if err != nil {
+			return err
+		}
+
+		u.Active = &active
+	case attribute == "username":
+		return decodeString(path, value, &u.UserName)
+	case attribute == "externalid":
+		return decodeString(path, value, &u.ExternalID)
+	case attribute == "displayname":
+		return decodeString(path, value, &u.DisplayName)
+	case attribute == "name":
+		return decode(path, value, u.Name)
+	case attribute == "name.formatted":
+		return decodeString(path, value, &u.Name.Formatted)
+	case attribute == "name.givenname":
+		return decodeString(path, value, &u.Name.GivenName)
+	case attribute == "name.familyname":
+		return decodeString(path, value, &u.Name.FamilyName)
+	case attribute == "emails":
+		u.Emails = nil
+
+		return decode(path, value, &u.Emails)
+	case strings.HasPrefix(attribute, "emails") && strings.HasSuffix(attribute, ".value"):
+		// Whichever email the path selects, it is the one ShellHub keeps.
+		var email string
+		if err := decodeString(path, value, &email); err != nil {
+			return err
+		}
+
+		u.Emails = []Email{{Value: email, Type: "work", Primary: true}}
+	}
+
+	return nil
+}
+
+// Patch applies the operations to the group in order.
+func (g *Group) Patch(operations []PatchOperation) error {
+	for _, op := range operations {
+		var err error
+		switch strings.ToLower(op.Op) {
+		case opAdd:
+			err = op.forEach(g.add)
+		case opReplace:
+			err = op.forEach(g.replace)
+		case opRemove:
+			err = g.remove(op.Path, op.Value)
+		default:
+			err = NewError(ErrorTypeInvalidSyntax, "unsupported operation %q", op.Op)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Group) add(path string, value json.RawMessage) error {
+	if !strings.EqualFold(path, "members") {
+		return g.replace(path, value)
+	}
+
+	var members []Reference
+	if err := decode(path, value, &members); err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if !slices.ContainsFunc(g.Members, func(m Reference) bool { return m.Value == member.Value }) {
+			g.Members = append(g.Members, member)
+		}
+	}
+
+	return nil
+}
+
+func (g *Group) replace(path string, value json.RawMessage) error {
+	switch strings.ToLower(strings.TrimPrefix(path, SchemaGroup+":")) {
+	case "displayname":
+		return decodeString(path, value, &g.DisplayName)
+	case "externalid":
+		return decodeString(path, value, &g.ExternalID)
+	case "members":
+		g.Members = nil
+
+		return decode(path, value, &g.Members)
+	default:
+		return NewError(ErrorTypeInvalidPath, "unsupported path %q", path)
+	}
+}
+
+// remove drops what path selects: the members value lists, the members[value eq "id"] path
+// selects, or every member when it selects them all.
+func (g *Group) remove(path string, value json.RawMessage) error {
+	attribute, selector, _ := strings.Cut(path, "[")
+	switch {
+	case !strings.EqualFold(attribute, "members"):
+		return g.replace(path, nil)
+	case selector != "":
+		filter, err := ParseFilter(strings.TrimSuffix(selector, "]"))
+		if err != nil || filter == nil || !strings.EqualFold(filter.Attribute, "value") {
+			return NewError(ErrorTypeInvalidPath, "unsupported path %q", path)
+		}
+
+		g.removeMembers(filter.Value)
+	case len(value) > 0 && string(value) != "null":
+		var members []Reference
+		if err := decode(path, value, &members); err != nil {
+			return err
+		}
+
+		for _, member := range members {
+			g.removeMembers(member.Value)
+		}
+	default:
+		g.Members = nil
+	}
+
+	return nil
+}
+
+func (g *Group) removeMembers(id string) {
+	g.Members = slices.DeleteFunc(g.Members, func(m Reference) bool { return m.Value == id })
+}
+
+func decode(path string, value json.RawMessage, target any) error {
+	if len(value) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(value, target); err != nil {
+		return NewError(ErrorTypeInvalidValue, "invalid value for %q", path)
+	}
+
+	return nil
+}
+
+// decodeString decodes a string attribute. A removed attribute is emptied.
+func decodeString(path string, value json.RawMessage, target *string) error {
+	if len(value) == 0 {
+		*target = ""
+
+		return nil
+	}
+
+	return decode(path, value, target)
+}
+
+// decodeBool decodes a boolean attribute, which some providers send as a "True" or "False" string.
+func decodeBool(path string, value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+
+	return false, NewError(ErrorTypeInvalidValue, "invalid value for %q: want a boolean", path)
+}
@@ -0,0 +1,222 @@
+package scim_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/server/api/pkg/scim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	cases := []struct {
+		description string
+		raw         string
+		expected    *scim.Filter
+		scimType    string
+	}{
+		{
+			description: "an empty filter matches everything",
+			raw:         "",
+			expected:    nil,
+		},
+		{
+			description: "an eq filter is parsed",
+			raw:         `userName eq "john.doe@example.com"`,
+			expected:    &scim.Filter{Attribute: "userName", Value: "john.doe@example.com"},
+		},
+		{
+			description: "the operator is case insensitive and the value may hold spaces",
+			raw:         `displayName EQ "Platform Engineering"`,
+			expected:    &scim.Filter{Attribute: "displayName", Value: "Platform Engineering"},
+		},
+		{
+			description: "a value filter in the attribute path is kept whole",
+			raw:         `emails[type eq "work"].value eq "john@example.com"`,
+			expected:    &scim.Filter{Attribute: `emails[type eq "work"].value`, Value: "john@example.com"},
+		},
+		{
+			description: "another operator is refused",
+			raw:         `userName sw "john"`,
+			scimType:    scim.ErrorTypeInvalidFilter,
+		},
+		{
+			description: "an unquoted value is refused",
+			raw:         `userName eq john`,
+			scimType:    scim.ErrorTypeInvalidFilter,
+		},
+		{
+			description: "a filter without a value is refused",
+			raw:         `userName`,
+			scimType:    scim.ErrorTypeInvalidFilter,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			filter, err := scim.ParseFilter(tc.raw)
+			if tc.scimType != "" {
+				var scimErr *scim.Error
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, tc.scimType, scimErr.ScimType)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, filter)
+		})
+	}
+}
+
+func operations(t *testing.T, raw string) []scim.PatchOperation {
+	t.Helper()
+
+	req := new(scim.PatchRequest)
+	require.NoError(t, json.Unmarshal([]byte(raw), req))
+
+	return req.Operations
+}
+
+func TestUserPatch(t *testing.T) {
+	active := true
+
+	cases := []struct {
+		description string
+		operations  string
+		expected    func(*scim.User)
+		scimType    string
+	}{
+		{
+			description: "a path-less operation sets every key of its value",
+			operations:  `{"Operations":[{"op":"replace","value":{"active":false,"displayName":"Jane Doe"}}]}`,
+			expected: func(u *scim.User) {
+				assert.False(t, u.IsActive())
+				assert.Equal(t, "Jane Doe", u.DisplayName)
+			},
+		},
+		{
+			description: "active may be a capitalized string",
+			operations:  `{"Operations":[{"op":"Replace","path":"active","value":"False"}]}`,
+			expected: func(u *scim.User) {
+				assert.False(t, u.IsActive())
+			},
+		},
+		{
+			description: "the primary email is replaced through a value filter",
+			operations:  `{"Operations":[{"op":"Add","path":"emails[type eq \"work\"].value","value":"jane@example.com"}]}`,
+			expected: func(u *scim.User) {
+				assert.Equal(t, "jane@example.com", u.PrimaryEmail())
+			},
+		},
+		{
+			description: "a removed attribute is emptied",
+			operations:  `{"Operations":[{"op":"remove","path":"externalId"}]}`,
+			expected: func(u *scim.User) {
+				assert.Empty(t, u.ExternalID)
+			},
+		},
+		{
+			description: "an attribute ShellHub does not keep is ignored",
+			operations:  `{"Operations":[{"op":"add","path":"title","value":"Engineer"}]}`,
+			expected: func(u *scim.User) {
+				assert.Equal(t, "john", u.UserName)
+			},
+		},
+		{
+			description: "active must be a boolean",
+			operations:  `{"Operations":[{"op":"replace","path":"active","value":"maybe"}]}`,
+			scimType:    scim.ErrorTypeInvalidValue,
+		},
+		{
+			description: "an unknown operation is refused",
+			operations:  `{"Operations":[{"op":"move","path":"active","value":false}]}`,
+			scimType:    scim.ErrorTypeInvalidSyntax,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			user := &scim.User{
+				UserName:   "john",
+				ExternalID: "00u1",
+				Emails:     []scim.Email{{Value: "john@example.com", Primary: true}},
+				Active:     &active,
+			}
+
+			err := user.Patch(operations(t, tc.operations))
+			if tc.scimType != "" {
+				var scimErr *scim.Error
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, tc.scimType, scimErr.ScimType)
+
+				return
+			}
+
+			require.NoError(t, err)
+			tc.expected(user)
+		})
+	}
+}
+
+func TestGroupPatch(t *testing.T) {
+	cases := []struct {
+		description string
+		operations  string
+		expected    []string
+		scimType    string
+	}{
+		{
+			description: "added members are appended once",
+			operations:  `{"Operations":[{"op":"add","path":"members","value":[{"value":"b"},{"value":"c"}]}]}`,
+			expected:    []string{"a", "b", "c"},
+		},
+		{
+			description: "a member is removed through a value filter",
+			operations:  `{"Operations":[{"op":"remove","path":"members[value eq \"a\"]"}]}`,
+			expected:    []string{"b"},
+		},
+		{
+			description: "the members a remove lists are removed",
+			operations:  `{"Operations":[{"op":"Remove","path":"members","value":[{"value":"b"}]}]}`,
+			expected:    []string{"a"},
+		},
+		{
+			description: "a remove without a value removes every member",
+			operations:  `{"Operations":[{"op":"remove","path":"members"}]}`,
+			expected:    []string{},
+		},
+		{
+			description: "a replace sets the members",
+			operations:  `{"Operations":[{"op":"replace","path":"members","value":[{"value":"c"}]}]}`,
+			expected:    []string{"c"},
+		},
+		{
+			description: "an unknown path is refused",
+			operations:  `{"Operations":[{"op":"replace","path":"owners","value":[]}]}`,
+			scimType:    scim.ErrorTypeInvalidPath,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			group := &scim.Group{
+				DisplayName: "engineering",
+				Members:     []scim.Reference{{Value: "a"}, {Value: "b"}},
+			}
+
+			err := group.Patch(operations(t, tc.operations))
+			if tc.scimType != "" {
+				var scimErr *scim.Error
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, tc.scimType, scimErr.ScimType)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, group.MemberIDs())
+		})
+	}
+}
@@ -14,6 +14,8 @@ const (
 	CreateAccessPolicyURL = "/access-policies"
 	UpdateAccessPolicyURL = "/access-policies/:id"
 	DeleteAccessPolicyURL = "/access-policies/:id"
+	SimulateAccessURL     = "/access-policies/simulate"
+	ListDeviceAccessURL   = "/access-policies/reach/:uid"
 )
 
 func (h *Handler) ListAccessPolicies(c *gateway.Context) error {
@@ -118,3 +120,53 @@ func (h *Handler) DeleteAccessPolicy(c *gateway.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+// SimulateAccess evaluates the policies for a hypothetical login without
+// connecting, returning the decision and what every policy did.
+func (h *Handler) SimulateAccess(c *gateway.Context) error {
+	var req requests.AccessPolicySimulate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	simulations, err := h.service.SimulateAccess(c.Ctx(), &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, simulations)
+}
+
+// ListDeviceAccess lists every principal/login pair the policies let reach a
+// device.
+func (h *Handler) ListDeviceAccess(c *gateway.Context) error {
+	var req requests.AccessPolicyReach
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	grants, err := h.service.ListDeviceAccess(c.Ctx(), &req)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(len(grants)))
+
+	return c.JSON(http.StatusOK, grants)
+}
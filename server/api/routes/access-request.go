@@ -0,0 +1,206 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ListAccessRequestsURL  = "/access-requests"
+	CreateAccessRequestURL = "/access-requests"
+	GetAccessRequestURL    = "/access-requests/:id"
+	GrantAccessRequestURL  = "/access-requests/:id/grant"
+	RejectAccessRequestURL = "/access-requests/:id/reject"
+	RevokeAccessRequestURL = "/access-requests/:id/revoke"
+	CancelAccessRequestURL = "/access-requests/:id/cancel"
+)
+
+// ListAccessRequests returns the namespace's access requests to an approver, and
+// only the caller's own to anyone else.
+func (h *Handler) ListAccessRequests(c *gateway.Context) error {
+	req := new(requests.AccessRequestList)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := req.Unmarshal(); err != nil {
+		log.WithError(err).WithField("filter", req.Filters.Raw).Warn("failed to decode access requests list filter")
+
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err := query.ValidateFilters(&req.Filters, services.AccessRequestFilterFields); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	req.Paginator.Normalize()
+	req.Sorter.Normalize()
+
+	if err := query.ValidateSorter(&req.Sorter, services.AccessRequestSortFields); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	userID, ok := c.GetID()
+	if !ok {
+		return c.NoContent(http.StatusUnauthorized)
+	}
+
+	req.UserID = userID
+	req.All = c.HasPermission(authorizer.AccessRequestDecide)
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	list, count, err := h.service.ListAccessRequests(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetAccessRequest returns an access request with its audit trail. Like the
+// list, it only finds the caller's own requests unless they are an approver.
+func (h *Handler) GetAccessRequest(c *gateway.Context) error {
+	req := new(requests.AccessRequestGet)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userID, ok := c.GetID()
+	if !ok {
+		return c.NoContent(http.StatusUnauthorized)
+	}
+
+	req.UserID = userID
+	req.All = c.HasPermission(authorizer.AccessRequestDecide)
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	request, err := h.service.GetAccessRequest(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+// CreateAccessRequest asks for temporary access as the caller.
+func (h *Handler) CreateAccessRequest(c *gateway.Context) error {
+	req := new(requests.AccessRequestCreate)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userID, ok := c.GetID()
+	if !ok {
+		return c.NoContent(http.StatusUnauthorized)
+	}
+
+	req.UserID = userID
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	request, err := h.service.CreateAccessRequest(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+func (h *Handler) GrantAccessRequest(c *gateway.Context) error {
+	return h.decideAccessRequest(c, h.service.GrantAccessRequest)
+}
+
+func (h *Handler) RejectAccessRequest(c *gateway.Context) error {
+	return h.decideAccessRequest(c, h.service.RejectAccessRequest)
+}
+
+func (h *Handler) RevokeAccessRequest(c *gateway.Context) error {
+	return h.decideAccessRequest(c, h.service.RevokeAccessRequest)
+}
+
+// CancelAccessRequest withdraws one of the caller's own pending requests.
+func (h *Handler) CancelAccessRequest(c *gateway.Context) error {
+	req := new(requests.AccessRequestCancel)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userID, ok := c.GetID()
+	if !ok {
+		return c.NoContent(http.StatusUnauthorized)
+	}
+
+	req.UserID = userID
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	request, err := h.service.CancelAccessRequest(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+// decideAccessRequest binds an approver's decision and hands it to decide. The
+// approver is recorded on the request, so the caller must be a user.
+func (h *Handler) decideAccessRequest(c *gateway.Context, decide func(context.Context, *requests.AccessRequestDecide) (*models.AccessRequest, error)) error {
+	req := new(requests.AccessRequestDecide)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userID, ok := c.GetID()
+	if !ok {
+		return c.NoContent(http.StatusUnauthorized)
+	}
+
+	req.UserID = userID
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	request, err := decide(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
@@ -0,0 +1,119 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	servicemock "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListAccessRequests(t *testing.T) {
+	cases := []struct {
+		description    string
+		role           authorizer.Role
+		url            string
+		requiredMocks  func(svcMock *servicemock.MockService)
+		expectedStatus int
+	}{
+		{
+			description: "lists only the caller's own requests for a member",
+			role:        authorizer.RoleOperator,
+			url:         "/api/access-requests",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ListAccessRequests", mock.Anything, mock.MatchedBy(func(req *requests.AccessRequestList) bool {
+					return !req.All && req.UserID == "000000000000000000000000"
+				})).Return([]models.AccessRequest{}, 0, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description: "lists every request for an approver",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/access-requests",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ListAccessRequests", mock.Anything, mock.MatchedBy(func(req *requests.AccessRequestList) bool {
+					return req.All
+				})).Return([]models.AccessRequest{}, 0, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "fails when sorting by an unknown field",
+			role:           authorizer.RoleOwner,
+			url:            "/api/access-requests?sort_by=justification",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			tc.requiredMocks(svcMock)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+}
+
+func TestGrantAccessRequest(t *testing.T) {
+	cases := []struct {
+		description    string
+		role           authorizer.Role
+		requiredMocks  func(svcMock *servicemock.MockService)
+		expectedStatus int
+	}{
+		{
+			description:    "forbids a member who is not an approver",
+			role:           authorizer.RoleOperator,
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "grants as the approver",
+			role:        authorizer.RoleAdministrator,
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("GrantAccessRequest", mock.Anything, &requests.AccessRequestDecide{
+					AccessRequestIDParam: requests.AccessRequestIDParam{ID: "request-id"},
+					Note:                 "ok",
+					TenantID:             "00000000-0000-4000-0000-000000000000",
+					UserID:               "000000000000000000000000",
+				}).Return(&models.AccessRequest{ID: "request-id", Status: models.AccessRequestGranted}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			tc.requiredMocks(svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/access-requests/request-id/grant", strings.NewReader(`{"note":"ok"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+}
@@ -29,6 +29,24 @@ func registerAnonymousRoutes(authn *routesmiddleware.Authenticator) {
 	// Obtaining a credential cannot itself require one.
 	allow(http.MethodPost, AuthLocalUserURL)
 	allow(http.MethodPost, RegisterUserURL)
+	allow(http.MethodGet, AuthOIDCURL)
+	allow(http.MethodGet, AuthOIDCCallbackURL)
+
+	// The identity provider provisioning users authenticates with its SCIM token, which the
+	// routes check themselves.
+	allow(http.MethodGet, SCIMServiceProviderConfigURL)
+	allow(http.MethodGet, SCIMUsersURL)
+	allow(http.MethodPost, SCIMUsersURL)
+	allow(http.MethodGet, SCIMUserURL)
+	allow(http.MethodPut, SCIMUserURL)
+	allow(http.MethodPatch, SCIMUserURL)
+	allow(http.MethodDelete, SCIMUserURL)
+	allow(http.MethodGet, SCIMGroupsURL)
+	allow(http.MethodPost, SCIMGroupsURL)
+	allow(http.MethodGet, SCIMGroupURL)
+	allow(http.MethodPut, SCIMGroupURL)
+	allow(http.MethodPatch, SCIMGroupURL)
+	allow(http.MethodDelete, SCIMGroupURL)
 
 	// An agent authenticates with its install key or tenant, carried in the body.
 	allow(http.MethodPost, AuthDeviceURL)
@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	log "github.com/sirupsen/logrus"
+)
+
+const ListAuditEventsURL = "/audit"
+
+// ListAuditEvents returns a page of the namespace's audit log.
+func (h *Handler) ListAuditEvents(c *gateway.Context) error {
+	req := new(requests.AuditEventList)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := req.Unmarshal(); err != nil {
+		log.WithError(err).WithField("filter", req.Filters.Raw).Warn("failed to decode audit log filter")
+
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err := query.ValidateFilters(&req.Filters, services.AuditEventFilterFields); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	req.Paginator.Normalize()
+	req.Sorter.Normalize()
+
+	if err := query.ValidateSorter(&req.Sorter, services.AuditEventSortFields); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	events, count, err := h.service.ListAuditEvents(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, events)
+}
@@ -3,11 +3,13 @@ package routes
 import (
 	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/server/api/pkg/responses"
 	errs "github.com/shellhub-io/shellhub/server/api/routes/errors"
 	svc "github.com/shellhub-io/shellhub/server/api/services"
 )
@@ -21,8 +23,28 @@ const (
 	AuthUserTokenPublicURL   = "/auth/token/:tenant" //nolint:gosec
 	AuthPublicKeyURL         = "/auth/ssh"
 	AuthMFAURL               = "/auth/mfa"
+	AuthOIDCURL              = "/user/oidc/auth"
+	AuthOIDCCallbackURL      = "/user/oidc/callback"
 )
 
+// oidcStateCookie binds an OpenID Connect sign-in's state to the browser that started it. It is
+// only sent back to the callback.
+const oidcStateCookie = "oidc_state"
+
+func setOIDCStateCookie(c *gateway.Context, state string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/api" + AuthOIDCCallbackURL,
+		MaxAge:   maxAge,
+		Secure:   c.Scheme() == "https",
+		HttpOnly: true,
+		// Lax, not Strict: the provider sends the user back with a top-level navigation from its
+		// own site, which Strict would strip the cookie from.
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
 func (h *Handler) AuthDevice(c *gateway.Context) error {
 	var req requests.DeviceAuth
 	if err := c.Bind(&req); err != nil {
@@ -84,6 +106,46 @@ func (h *Handler) AuthLocalUser(c *gateway.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (h *Handler) GetOIDCAuthURL(c *gateway.Context) error {
+	authURL, state, err := h.service.GetOIDCAuthURL(c.Ctx())
+	if err != nil {
+		return err
+	}
+
+	setOIDCStateCookie(c, state, 0)
+
+	return c.JSON(http.StatusOK, &responses.AuthURL{URL: authURL})
+}
+
+// AuthOIDCUser is where the OpenID Connect provider sends the user back to. The user lands on the
+// console's login page with their token in the URL's fragment, which the browser never sends to a
+// server, so the token stays out of access logs and Referer headers.
+func (h *Handler) AuthOIDCUser(c *gateway.Context) error {
+	req := new(requests.AuthOIDCUser)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if cookie, err := c.Cookie(oidcStateCookie); err == nil {
+		req.Binding = cookie.Value
+	}
+
+	// A sign-in completes once, whatever the outcome, so its state goes with it.
+	setOIDCStateCookie(c, "", -1)
+
+	res, err := h.service.AuthOIDCUser(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Redirect(http.StatusFound, "/login#"+url.Values{"token": {res.Token}}.Encode())
+}
+
 func (h *Handler) CreateUserToken(c *gateway.Context) error {
 	req := new(requests.CreateUserToken)
 
@@ -530,3 +530,91 @@ func TestAuthPublicKey(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOIDCAuthURL(t *testing.T) {
+	mock := mocks.NewMockService(t)
+
+	mock.
+		On("GetOIDCAuthURL", gomock.Anything).
+		Return("https://idp.example.com/auth", "state", nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/oidc/auth", nil)
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "oidc_state", cookies[0].Name)
+	assert.Equal(t, "state", cookies[0].Value)
+	assert.Equal(t, "/api/user/oidc/callback", cookies[0].Path)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+}
+
+func TestAuthOIDCUser(t *testing.T) {
+	mock := mocks.NewMockService(t)
+
+	cases := []struct {
+		title            string
+		query            string
+		cookie           string
+		requiredMocks    func()
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			title:          "fails when the state is missing",
+			query:          "code=code",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the sign-in is refused",
+			query: "code=code&state=state",
+			requiredMocks: func() {
+				mock.
+					On("AuthOIDCUser", gomock.Anything, &requests.AuthOIDCUser{Code: "code", State: "state"}).
+					Return(nil, svc.ErrAuthUnathorized).
+					Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:  "succeeds redirecting to the console with the token",
+			query:  "code=code&state=state",
+			cookie: "state",
+			requiredMocks: func() {
+				mock.
+					On("AuthOIDCUser", gomock.Anything, &requests.AuthOIDCUser{Code: "code", State: "state", Binding: "state"}).
+					Return(&models.UserAuthResponse{Token: "a.b+c"}, nil).
+					Once()
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "/login#token=a.b%2Bc",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/user/oidc/callback?"+tc.query, nil)
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "oidc_state", Value: tc.cookie})
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+			assert.Equal(t, tc.expectedLocation, rec.Result().Header.Get("Location"))
+		})
+	}
+}
@@ -103,6 +103,8 @@ func (h *Handler) GetDeviceList(c *gateway.Context) error {
 		return err
 	}
 
+	req.ScopeTags = c.DeviceTags()
+
 	res, count, err := h.service.ListDevices(c.Ctx(), sc, req)
 	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
 
@@ -151,6 +153,12 @@ func (h *Handler) ResolveDevice(c *gateway.Context) error {
 		return err
 	}
 
+	// A device outside a scoped API key's reach is reported as missing, not forbidden, so the key
+	// cannot learn it exists.
+	if !c.ReachesDevice(device) {
+		return services.NewErrDeviceNotFound(models.UID(device.UID), nil)
+	}
+
 	return c.JSON(http.StatusOK, device)
 }
 
@@ -0,0 +1,87 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+)
+
+const StreamEventsURL = "/stream"
+
+// eventStreamKeepAlive is how often an idle stream gets a comment, so that
+// proxies in the way do not close it for inactivity.
+const eventStreamKeepAlive = 25 * time.Second
+
+// StreamEvents serves the namespace's live events as Server-Sent Events, each
+// one named after its type and carrying its ID, so an EventSource resumes where
+// it was when it reconnects.
+func (h *Handler) StreamEvents(c *gateway.Context) error {
+	req := new(requests.EventStreamSubscribe)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if id := c.Request().Header.Get("Last-Event-ID"); id != "" {
+		req.LastEventID = id
+	}
+
+	// The stream is a namespace's; an admin request outside of one has nothing
+	// to follow.
+	if c.Tenant() == nil {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	req.TenantID = c.Tenant().ID
+	req.ScopeTags = c.DeviceTags()
+	req.ReadsAccessPolicies = c.HasPermission(authorizer.AccessPolicyManage)
+	req.ReadsSSHApprovals = c.HasPermission(authorizer.SSHIdentityManage)
+	if id := c.ID(); id != nil {
+		req.UserID = id.ID
+	}
+
+	events, err := h.service.SubscribeEvents(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	res := c.Response()
+	rc := http.NewResponseController(res)
+
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	// Keeps nginx from buffering the stream.
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		return err
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if _, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data); err != nil {
+				return nil
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return nil
+		}
+	}
+}
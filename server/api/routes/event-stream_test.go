@@ -0,0 +1,142 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/eventstream"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	servicemock "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStreamEvents(t *testing.T) {
+	// stream hands out the given events and then ends, as a subscription does
+	// once the client goes away.
+	stream := func(events ...eventstream.Event) <-chan eventstream.Event {
+		ch := make(chan eventstream.Event, len(events))
+		for _, event := range events {
+			ch <- event
+		}
+
+		close(ch)
+
+		return ch
+	}
+
+	cases := []struct {
+		description    string
+		role           authorizer.Role
+		tenantID       string
+		url            string
+		lastEventID    string
+		requiredMocks  func(svcMock *servicemock.MockService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			description:    "forbids a request outside of a namespace",
+			url:            "/api/stream",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "fails when the stream is not available",
+			tenantID:    "00000000-0000-4000-0000-000000000000",
+			url:         "/api/stream",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("SubscribeEvents", mock.Anything, mock.Anything).Return(nil, services.ErrEventStreamNotAvailable).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description: "streams the namespace's events",
+			tenantID:    "00000000-0000-4000-0000-000000000000",
+			url:         "/api/stream",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("SubscribeEvents", mock.Anything, &requests.EventStreamSubscribe{TenantID: "00000000-0000-4000-0000-000000000000", UserID: "000000000000000000000000"}).
+					Return(stream(
+						eventstream.Event{ID: "1700000000000-0", Type: "device.online", Data: json.RawMessage(`{"uid":"a"}`)},
+						eventstream.Event{ID: "1700000000000-1", Type: "session.started", Data: json.RawMessage(`{"uid":"b"}`)},
+					), nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: "id: 1700000000000-0\nevent: device.online\ndata: {\"uid\":\"a\"}\n\n" +
+				"id: 1700000000000-1\nevent: session.started\ndata: {\"uid\":\"b\"}\n\n",
+		},
+		{
+			description: "tells the service which events an administrator may read",
+			role:        authorizer.RoleAdministrator,
+			tenantID:    "00000000-0000-4000-0000-000000000000",
+			url:         "/api/stream",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("SubscribeEvents", mock.Anything, &requests.EventStreamSubscribe{
+					TenantID:            "00000000-0000-4000-0000-000000000000",
+					UserID:              "000000000000000000000000",
+					ReadsAccessPolicies: true,
+					ReadsSSHApprovals:   true,
+				}).Return(stream(), nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description: "resumes from the query's last event",
+			tenantID:    "00000000-0000-4000-0000-000000000000",
+			url:         "/api/stream?last_event_id=1700000000000-0",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("SubscribeEvents", mock.Anything, &requests.EventStreamSubscribe{TenantID: "00000000-0000-4000-0000-000000000000", UserID: "000000000000000000000000", LastEventID: "1700000000000-0"}).
+					Return(stream(), nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description: "resumes from the header an EventSource sends when it reconnects",
+			tenantID:    "00000000-0000-4000-0000-000000000000",
+			url:         "/api/stream?last_event_id=1700000000000-0",
+			lastEventID: "1700000000000-5",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("SubscribeEvents", mock.Anything, &requests.EventStreamSubscribe{TenantID: "00000000-0000-4000-0000-000000000000", UserID: "000000000000000000000000", LastEventID: "1700000000000-5"}).
+					Return(stream(), nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			tc.requiredMocks(svcMock)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			role := tc.role
+			if role == "" {
+				role = authorizer.RoleObserver
+			}
+
+			req.Header.Set("X-Role", role.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			if tc.tenantID != "" {
+				req.Header.Set("X-Tenant-ID", tc.tenantID)
+			}
+
+			if tc.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "text/event-stream", rec.Result().Header.Get("Content-Type"))
+				assert.Equal(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
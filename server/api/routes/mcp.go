@@ -4,17 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v5"
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/server/api/pkg/echo/handlers"
 )
 
 type mcpContextKey string
@@ -22,19 +26,28 @@ type mcpContextKey string
 const (
 	mcpKeyTenantID mcpContextKey = "mcp_tenant_id"
 	mcpKeyHeaders  mcpContextKey = "mcp_headers"
+	mcpKeyRealIP   mcpContextKey = "mcp_real_ip"
 )
 
-// mcpAuthHeaders are the headers the API gateway injects to identify an API-key
-// caller. They are captured from the incoming /mcp request and replayed on the
-// in-process API calls the tools make, so the same authentication and
-// authorization the gateway performed for the MCP request flows through to the
-// REST middleware (BlockAPIKey, RequiresPermission, RequiresTenant). MCP auth
-// is API-key-only, so the user-identity headers (X-ID, X-Admin, X-Username)
-// never apply and are intentionally omitted.
+// mcpAuthHeaders are the headers that identify the MCP caller: the credential
+// it authenticated with -- an API key or a user's bearer token -- and the
+// identity the authenticator resolved from it. They are captured from the
+// incoming /mcp request and replayed on the in-process API calls the tools
+// make, so the same authentication and authorization the MCP request went
+// through flows through to the REST middleware (BlockAPIKey,
+// RequiresPermission, RequiresTenant). A tool is therefore exactly as capable
+// as its caller is on the REST API: an API key is refused the routes it is
+// blocked from, such as install keys and SSH approvals, which a user's token
+// reaches, and a scoped key keeps its permission list and device tags. X-Admin and X-Username never apply to the namespace-scoped tools
+// and are intentionally omitted.
 var mcpAuthHeaders = []string{
 	"X-Tenant-ID",
 	"X-Role",
+	"X-ID",
 	"X-Api-Key",
+	"X-Api-Key-Permissions",
+	"X-Api-Key-Tags",
+	"Authorization",
 }
 
 // SetupMCPRoutes mounts the MCP Streamable HTTP server at /mcp.
@@ -61,6 +74,10 @@ func SetupMCPRoutes(router *echo.Echo) {
 		}
 		ctx = context.WithValue(ctx, mcpKeyHeaders, headers)
 
+		// The MCP caller's address, so the API sees the same client the MCP
+		// request came from: Access Policies match device logins against it.
+		ctx = context.WithValue(ctx, mcpKeyRealIP, handlers.RealIPExtractor()(r))
+
 		return ctx
 	}
 
@@ -75,11 +92,19 @@ func SetupMCPRoutes(router *echo.Echo) {
 func buildMCPServer(router http.Handler) *mcpserver.MCPServer {
 	s := mcpserver.NewMCPServer("shellhub", "1.0.0",
 		mcpserver.WithToolCapabilities(true),
+		mcpserver.WithResourceCapabilities(false, false),
 	)
 
 	addDeviceTools(s, router)
 	addSessionTools(s, router)
 	addNamespaceTools(s, router)
+	addAccessPolicyTools(s, router)
+	addSSHIdentityTools(s, router)
+	addServiceAccountTools(s, router)
+	addSSHApprovalTools(s, router)
+	addInstallKeyTools(s, router)
+	addRemoteTools(s, router)
+	addNamespaceResources(s, router)
 
 	return s
 }
@@ -92,7 +117,7 @@ func buildMCPServer(router http.Handler) *mcpserver.MCPServer {
 // is an in-binary client of the API, not a shortcut past it -- no network hop,
 // but the same authorization path. body may be nil.
 func mcpAPICall(ctx context.Context, router http.Handler, method, target string, body io.Reader) *httptest.ResponseRecorder {
-	req := httptest.NewRequest(method, target, body)
+	req := httptest.NewRequestWithContext(ctx, method, target, body)
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -105,6 +130,13 @@ func mcpAPICall(ctx context.Context, router http.Handler, method, target string,
 		}
 	}
 
+	// Replayed from loopback, the API trusts the forwarded address as it would
+	// the gateway's.
+	if ip, ok := ctx.Value(mcpKeyRealIP).(string); ok && ip != "" {
+		req.RemoteAddr = "127.0.0.1:0"
+		req.Header.Set(echo.HeaderXRealIP, ip)
+	}
+
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
@@ -119,15 +151,25 @@ func mcpAPIResult(rec *httptest.ResponseRecorder) *mcp.CallToolResult {
 		return mcp.NewToolResultText(rec.Body.String())
 	}
 
-	switch rec.Code {
+	return mcp.NewToolResultError(mcpAPIError(rec.Code))
+}
+
+// mcpAPIError is the fixed message a failed in-process API call is reported
+// with, to tools and resources alike.
+func mcpAPIError(code int) string {
+	switch code {
 	case http.StatusUnauthorized:
-		return mcpUnauth()
+		return "unauthorized: missing or invalid token"
 	case http.StatusForbidden:
-		return mcpForbidden()
+		return "forbidden: insufficient permissions"
 	case http.StatusNotFound:
-		return mcp.NewToolResultError("not found")
+		return "not found"
+	case http.StatusBadRequest:
+		return "invalid arguments"
+	case http.StatusConflict:
+		return "conflict"
 	default:
-		return mcp.NewToolResultError("internal error")
+		return "internal error"
 	}
 }
 
@@ -159,12 +201,23 @@ func mcpAPIOK(rec *httptest.ResponseRecorder, msg string) *mcp.CallToolResult {
 	return mcpAPIResult(rec)
 }
 
-func mcpForbidden() *mcp.CallToolResult {
-	return mcp.NewToolResultError("forbidden: insufficient permissions")
-}
+// mcpRemoteResult is mcpAPIResult for the routes that reach a device, whose
+// refusals say what went wrong in words the caller can act on: the access was
+// denied, the file is too large, and so on. Those messages are fixed by the
+// routes, so they are forwarded; anything else takes the shared mapping.
+func mcpRemoteResult(rec *httptest.ResponseRecorder) *mcp.CallToolResult {
+	if rec.Code == http.StatusBadGateway {
+		return mcp.NewToolResultError("failed to connect to the device")
+	}
+
+	if rec.Code >= http.StatusBadRequest && rec.Code < http.StatusInternalServerError && rec.Code != http.StatusUnauthorized {
+		var body responses.Error
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err == nil && body.Message != "" {
+			return mcp.NewToolResultError(body.Message)
+		}
+	}
 
-func mcpUnauth() *mcp.CallToolResult {
-	return mcp.NewToolResultError("unauthorized: missing or invalid token")
+	return mcpAPIResult(rec)
 }
 
 func tenantFromCtx(ctx context.Context) string {
@@ -182,6 +235,23 @@ func intArg(args map[string]any, key string, def int) int {
 	return int(v)
 }
 
+// mcpBody marshals the named arguments the caller passed into a request body,
+// leaving out the ones it did not pass, so the route applies its own defaults
+// and a partial update leaves the rest untouched. An argument passed as null is
+// kept, for the routes that read null as "clear".
+func mcpBody(args map[string]any, keys ...string) io.Reader {
+	body := map[string]any{}
+	for _, key := range keys {
+		if value, ok := args[key]; ok {
+			body[key] = value
+		}
+	}
+
+	payload, _ := json.Marshal(body)
+
+	return bytes.NewReader(payload)
+}
+
 func toJSON(v any) string {
 	b, _ := json.MarshalIndent(v, "", "  ")
 
@@ -361,3 +431,549 @@ func addNamespaceTools(s *mcpserver.MCPServer, router http.Handler) {
 		},
 	)
 }
+
+// --- Access policy tools ---
+
+// accessPolicyFields are the arguments describing an access policy, shared by
+// the create and update tools. An update replaces the whole policy, so both
+// take the same ones.
+var accessPolicyFields = []string{
+	"name", "subject", "filter", "logins", "source_ip", "action",
+	"require_reauth", "reauth_period", "schedule", "not_before", "not_after",
+}
+
+func accessPolicyToolOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("name", mcp.Required(), mcp.Description("Policy name, unique in the namespace.")),
+		mcp.WithObject("subject", mcp.Required(), mcp.Description("Who the policy applies to, as {type, value}: type is user, role, all-members or api-key, and value the member ID, role name or API key name.")),
+		mcp.WithObject("filter", mcp.Required(), mcp.Description("Devices the policy covers, as {hostname} (a regular expression) or {tags} (tag names), never both.")),
+		mcp.WithArray("logins", mcp.Required(), mcp.WithStringItems(), mcp.Description("Device logins (OS users) the policy covers.")),
+		mcp.WithArray("source_ip", mcp.WithStringItems(), mcp.Description("CIDRs or addresses the policy is limited to. Default: any address.")),
+		mcp.WithString("action", mcp.Description("allow or deny. Default: allow.")),
+		mcp.WithBoolean("require_reauth", mcp.Description("Require the member to re-authenticate in the console before the login is released.")),
+		mcp.WithInteger("reauth_period", mcp.Description("Seconds a re-authentication stays valid. 0 asks for it on every login.")),
+		mcp.WithObject("schedule", mcp.Description("Weekly windows the policy applies in, as {timezone, windows: [{weekdays, start, end}]}, with weekdays like mon and times like 08:00.")),
+		mcp.WithString("not_before", mcp.Description("RFC 3339 time the policy starts applying.")),
+		mcp.WithString("not_after", mcp.Description("RFC 3339 time the policy stops applying.")),
+	}
+}
+
+func addAccessPolicyTools(s *mcpserver.MCPServer, router http.Handler) {
+	s.AddTool(
+		mcp.NewTool("shellhub_list_access_policies",
+			mcp.WithDescription("List the namespace's access policies, which decide who may log in to which devices as which logins."),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/access-policies", nil)
+
+			return mcpAPIListResult(rec, "access_policies"), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_get_access_policy",
+			mcp.WithDescription("Get an access policy by its ID."),
+			mcp.WithString("id", mcp.Required(), mcp.Description("Access policy ID.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			id, _ := req.GetArguments()["id"].(string)
+
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/access-policies/"+url.PathEscape(id), nil)
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_create_access_policy",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Create an access policy. Run shellhub_simulate_access afterwards to check it grants what was meant."),
+			}, accessPolicyToolOptions()...)...,
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			rec := mcpAPICall(ctx, router, http.MethodPost, "/api/access-policies", mcpBody(req.GetArguments(), accessPolicyFields...))
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_update_access_policy",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Replace an access policy. Every field is replaced, so pass the ones to keep as well; read them with shellhub_get_access_policy first."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("Access policy ID.")),
+			}, accessPolicyToolOptions()...)...,
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			id, _ := args["id"].(string)
+
+			rec := mcpAPICall(ctx, router, http.MethodPut, "/api/access-policies/"+url.PathEscape(id), mcpBody(args, accessPolicyFields...))
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_simulate_access",
+			mcp.WithDescription("Dry-run the namespace's access policies for a login without connecting. Returns, per device, the decision and what every policy did (granted, denied, skipped or error). Target one device by uid, or every accepted device matching a hostname pattern or tags."),
+			mcp.WithString("principal_id", mcp.Required(), mcp.Description("ID of the member (user or service account) attempting the login.")),
+			mcp.WithString("login", mcp.Required(), mcp.Description("Device login (OS user) being requested.")),
+			mcp.WithString("device_uid", mcp.Description("Device UID. Mutually exclusive with hostname and tags.")),
+			mcp.WithString("hostname", mcp.Description("Regular expression selecting devices by name.")),
+			mcp.WithArray("tags", mcp.WithStringItems(), mcp.Description("Tag names selecting devices.")),
+			mcp.WithString("source_ip", mcp.Required(), mcp.Description("Client IP address the login comes from.")),
+			mcp.WithString("at", mcp.Description("RFC 3339 time to evaluate the policies at. Default: now.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+
+			body := map[string]any{
+				"principal_id": args["principal_id"],
+				"login":        args["login"],
+				"source_ip":    args["source_ip"],
+			}
+
+			if uid, _ := args["device_uid"].(string); uid != "" {
+				body["device_uid"] = uid
+			}
+
+			filter := map[string]any{}
+			if hostname, _ := args["hostname"].(string); hostname != "" {
+				filter["hostname"] = hostname
+			}
+			if tags, _ := args["tags"].([]any); len(tags) > 0 {
+				filter["tags"] = tags
+			}
+			if len(filter) > 0 {
+				body["filter"] = filter
+			}
+
+			if at, _ := args["at"].(string); at != "" {
+				body["at"] = at
+			}
+
+			payload, _ := json.Marshal(body)
+			rec := mcpAPICall(ctx, router, http.MethodPost, "/api/access-policies/simulate", bytes.NewReader(payload))
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_list_device_access",
+			mcp.WithDescription("List every principal/login pair the access policies currently let reach a device, with the source CIDRs each grant is limited to or denied from."),
+			mcp.WithString("uid", mcp.Required(), mcp.Description("Device UID.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			uid, _ := req.GetArguments()["uid"].(string)
+
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/access-policies/reach/"+url.PathEscape(uid), nil)
+
+			return mcpAPIListResult(rec, "grants"), nil
+		},
+	)
+}
+
+// --- SSH identity tools ---
+
+func addSSHIdentityTools(s *mcpserver.MCPServer, router http.Handler) {
+	s.AddTool(
+		mcp.NewTool("shellhub_list_ssh_identities",
+			mcp.WithDescription("List SSH identities: the public keys enrolled to log in to devices as a member. Lists the caller's own, or every member's with all."),
+			mcp.WithBoolean("all", mcp.Description("List every member's identities instead of the caller's own. Needs the permission to manage identities.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target := "/api/ssh-identities"
+			if all, _ := req.GetArguments()["all"].(bool); all {
+				target += "?all=true"
+			}
+
+			rec := mcpAPICall(ctx, router, http.MethodGet, target, nil)
+
+			return mcpAPIListResult(rec, "ssh_identities"), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_create_ssh_identity",
+			mcp.WithDescription("Enroll an OpenSSH public key as an SSH identity of the calling user. Needs a user's token: an API key has no identities of its own."),
+			mcp.WithString("data", mcp.Required(), mcp.Description("OpenSSH public key, like \"ssh-ed25519 AAAA... comment\".")),
+			mcp.WithString("name", mcp.Description("Identity name. Default: derived from the key.")),
+			mcp.WithInteger("expires_in", mcp.Description("Days the key keeps working. Default: it never expires.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			rec := mcpAPICall(ctx, router, http.MethodPost, "/api/ssh-identities", mcpBody(req.GetArguments(), "data", "name", "expires_in"))
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_update_ssh_identity",
+			mcp.WithDescription("Rename one of the calling user's SSH identities."),
+			mcp.WithString("id", mcp.Required(), mcp.Description("SSH identity ID.")),
+			mcp.WithString("name", mcp.Required(), mcp.Description("New identity name.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			id, _ := args["id"].(string)
+
+			rec := mcpAPICall(ctx, router, http.MethodPatch, "/api/ssh-identities/"+url.PathEscape(id), mcpBody(args, "name"))
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+}
+
+// --- Service account tools ---
+
+func addServiceAccountTools(s *mcpserver.MCPServer, router http.Handler) {
+	s.AddTool(
+		mcp.NewTool("shellhub_list_service_accounts",
+			mcp.WithDescription("List the namespace's service accounts: members for automation, which log in to devices with their own SSH identities."),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/service-accounts", nil)
+
+			return mcpAPIListResult(rec, "service_accounts"), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_create_service_account",
+			mcp.WithDescription("Create a service account with an OpenSSH public key as its first SSH identity. Access policies naming it decide where it may log in."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Service account name.")),
+			mcp.WithString("data", mcp.Required(), mcp.Description("OpenSSH public key the account logs in with.")),
+			mcp.WithBoolean("single_use", mcp.Description("Burn the key after one SSH session.")),
+			mcp.WithInteger("expires_in", mcp.Description("Days the key keeps working. Default: it never expires.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			rec := mcpAPICall(ctx, router, http.MethodPost, "/api/service-accounts", mcpBody(req.GetArguments(), "name", "data", "single_use", "expires_in"))
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+}
+
+// --- SSH approval tools ---
+
+// addSSHApprovalTools decides the logins the gateway holds until a member
+// approves them, from the code printed in the terminal. Approving is a
+// person's act: confirming binds the key to whoever confirms, so the REST
+// routes refuse an API key and only a user's token gets through.
+func addSSHApprovalTools(s *mcpserver.MCPServer, router http.Handler) {
+	s.AddTool(
+		mcp.NewTool("shellhub_get_ssh_approval",
+			mcp.WithDescription("Get a pending SSH login approval by its code: the device, login, source IP and key fingerprint waiting to be approved."),
+			mcp.WithString("code", mcp.Required(), mcp.Description("Approval code shown in the terminal.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			code, _ := req.GetArguments()["code"].(string)
+
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/ssh-approvals/"+url.PathEscape(code), nil)
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_confirm_ssh_approval",
+			mcp.WithDescription("Approve a pending SSH login. The key is enrolled as an SSH identity of the calling user and the login proceeds. A login waiting for a re-authentication cannot be confirmed here."),
+			mcp.WithString("code", mcp.Required(), mcp.Description("Approval code shown in the terminal.")),
+			mcp.WithInteger("expires_in", mcp.Description("Days the enrolled key keeps working. Default: it never expires.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			code, _ := args["code"].(string)
+
+			rec := mcpAPICall(ctx, router, http.MethodPost, "/api/ssh-approvals/"+url.PathEscape(code)+"/confirm", mcpBody(args, "expires_in"))
+
+			return mcpAPIOK(rec, fmt.Sprintf("login %s approved", code)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_reject_ssh_approval",
+			mcp.WithDescription("Reject a pending SSH login. The login is refused."),
+			mcp.WithString("code", mcp.Required(), mcp.Description("Approval code shown in the terminal.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			code, _ := req.GetArguments()["code"].(string)
+
+			rec := mcpAPICall(ctx, router, http.MethodPost, "/api/ssh-approvals/"+url.PathEscape(code)+"/reject", nil)
+
+			return mcpAPIOK(rec, fmt.Sprintf("login %s rejected", code)), nil
+		},
+	)
+}
+
+// --- Install key tools ---
+
+// installKeyFields are the arguments shared by the create and update tools.
+var installKeyFields = []string{
+	"mode", "webhook_url", "webhook_secret", "allowed_macs", "webhook_timeout", "webhook_callback_ttl",
+	"expires_in", "usage_limit", "ephemeral", "ephemeral_timeout", "tags",
+}
+
+func installKeyToolOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("mode", mcp.Description("Enrollment policy: automatic, manual, webhook or allowlist.")),
+		mcp.WithString("webhook_url", mcp.Description("URL asked to decide each enrollment, in the webhook mode.")),
+		mcp.WithString("webhook_secret", mcp.Description("Secret the webhook requests are signed with.")),
+		mcp.WithArray("allowed_macs", mcp.WithStringItems(), mcp.Description("MAC addresses allowed to enroll, in the allowlist mode.")),
+		mcp.WithInteger("webhook_timeout", mcp.Description("Seconds the webhook has to answer (max 15).")),
+		mcp.WithInteger("webhook_callback_ttl", mcp.Description("Seconds a deferred webhook decision may take (max 86400).")),
+		mcp.WithInteger("expires_in", mcp.Description("Days from now the key expires.")),
+		mcp.WithInteger("usage_limit", mcp.Description("Devices that may enroll with the key: 1 is single-use, 0 unlimited.")),
+		mcp.WithBoolean("ephemeral", mcp.Description("Remove the devices enrolled with the key once they stay offline past ephemeral_timeout.")),
+		mcp.WithInteger("ephemeral_timeout", mcp.Description("Minutes an ephemeral device may stay offline (1-10).")),
+		mcp.WithArray("tags", mcp.WithStringItems(), mcp.Description("Tags applied to the devices enrolled with the key.")),
+	}
+}
+
+// addInstallKeyTools manages the keys devices enroll with. The REST routes
+// refuse an API key, which could otherwise mint credentials for new devices,
+// so only a user's token gets through.
+func addInstallKeyTools(s *mcpserver.MCPServer, router http.Handler) {
+	s.AddTool(
+		mcp.NewTool("shellhub_list_install_keys",
+			mcp.WithDescription("List the namespace's install keys, which devices enroll with."),
+			mcp.WithInteger("page", mcp.Description("Page number (1-based). Default: 1.")),
+			mcp.WithInteger("per_page", mcp.Description("Results per page (max 100). Default: 20.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+
+			q := url.Values{}
+			q.Set("page", strconv.Itoa(intArg(args, "page", 1)))
+			q.Set("per_page", strconv.Itoa(intArg(args, "per_page", 20)))
+
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/namespaces/install-key?"+q.Encode(), nil)
+
+			return mcpAPIListResult(rec, "install_keys"), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_create_install_key",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Create an install key. The result carries the plaintext key devices enroll with."),
+				mcp.WithString("name", mcp.Required(), mcp.Description("Install key name, unique in the namespace.")),
+			}, installKeyToolOptions()...)...,
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			rec := mcpAPICall(ctx, router, http.MethodPost, "/api/namespaces/install-key", mcpBody(req.GetArguments(), append([]string{"name"}, installKeyFields...)...))
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_update_install_key",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Update an install key. Only the arguments passed change; pass expires_in as null to make the key never expire."),
+				mcp.WithString("name", mcp.Required(), mcp.Description("Current install key name.")),
+				mcp.WithString("new_name", mcp.Description("New install key name.")),
+				mcp.WithBoolean("disabled", mcp.Description("Pause or resume the key.")),
+				mcp.WithBoolean("revoked", mcp.Description("Revoke the key for good. A revoked key cannot be restored.")),
+			}, installKeyToolOptions()...)...,
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			name, _ := args["name"].(string)
+
+			fields := map[string]any{}
+			for key, value := range args {
+				fields[key] = value
+			}
+
+			// The route takes the current name in the path and the new one in the
+			// body, under the same key.
+			delete(fields, "name")
+			if newName, ok := fields["new_name"]; ok {
+				fields["name"] = newName
+			}
+
+			rec := mcpAPICall(ctx, router, http.MethodPatch, "/api/namespaces/install-key/"+url.PathEscape(name),
+				mcpBody(fields, append([]string{"name", "disabled", "revoked"}, installKeyFields...)...))
+
+			return mcpAPIResult(rec), nil
+		},
+	)
+}
+
+// --- Remote tools ---
+
+// maxTailLines bounds how much of a session shellhub_tail_session returns.
+const maxTailLines = 500
+
+func addRemoteTools(s *mcpserver.MCPServer, router http.Handler) {
+	s.AddTool(
+		mcp.NewTool("shellhub_exec_command",
+			mcp.WithDescription("Run a non-interactive command on a device and return its exit code and output. The command runs as an SSH session opened for the calling API key, so the namespace must use the identity access mode and an access policy must grant the key the login. Output past max_output is dropped, and a command still running at the timeout is stopped."),
+			mcp.WithString("uid", mcp.Required(), mcp.Description("Device UID.")),
+			mcp.WithString("login", mcp.Required(), mcp.Description("Device login (OS user) to run the command as.")),
+			mcp.WithString("command", mcp.Required(), mcp.Description("Command line, run through the login's shell.")),
+			mcp.WithInteger("timeout", mcp.Description("Seconds the command may run (max 300). Default: 30.")),
+			mcp.WithInteger("max_output", mcp.Description("Bytes of stdout and of stderr to keep (max 1048576). Default: 65536.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			uid, _ := args["uid"].(string)
+
+			body, _ := json.Marshal(map[string]any{
+				"login":      args["login"],
+				"command":    args["command"],
+				"timeout":    intArg(args, "timeout", 0),
+				"max_output": intArg(args, "max_output", 0),
+			})
+			rec := mcpAPICall(ctx, router, http.MethodPost, "/api/devices/"+url.PathEscape(uid)+"/exec", bytes.NewReader(body))
+
+			return mcpRemoteResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_read_file",
+			mcp.WithDescription("Read a file of up to 1 MiB from a device over SFTP. Text comes back as is (encoding utf-8), anything else base64-encoded (encoding base64). Access works as for shellhub_exec_command."),
+			mcp.WithString("uid", mcp.Required(), mcp.Description("Device UID.")),
+			mcp.WithString("login", mcp.Required(), mcp.Description("Device login (OS user) to read the file as.")),
+			mcp.WithString("path", mcp.Required(), mcp.Description("Path of the file on the device.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			uid, _ := args["uid"].(string)
+			login, _ := args["login"].(string)
+			path, _ := args["path"].(string)
+
+			q := url.Values{}
+			q.Set("login", login)
+			q.Set("path", path)
+
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/devices/"+url.PathEscape(uid)+"/files?"+q.Encode(), nil)
+
+			return mcpRemoteResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_write_file",
+			mcp.WithDescription("Write a file of up to 1 MiB to a device over SFTP, replacing it if it exists. Access works as for shellhub_exec_command."),
+			mcp.WithString("uid", mcp.Required(), mcp.Description("Device UID.")),
+			mcp.WithString("login", mcp.Required(), mcp.Description("Device login (OS user) to write the file as.")),
+			mcp.WithString("path", mcp.Required(), mcp.Description("Path of the file on the device.")),
+			mcp.WithString("content", mcp.Required(), mcp.Description("File content.")),
+			mcp.WithString("encoding", mcp.Description("Encoding of content: utf-8 or base64. Default: utf-8.")),
+			mcp.WithString("mode", mcp.Description("Permission bits in octal, like 0644. Default: the device's.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			uid, _ := args["uid"].(string)
+
+			payload := map[string]any{
+				"login":   args["login"],
+				"path":    args["path"],
+				"content": args["content"],
+			}
+
+			if encoding, _ := args["encoding"].(string); encoding != "" {
+				payload["encoding"] = encoding
+			}
+			if mode, _ := args["mode"].(string); mode != "" {
+				payload["mode"] = mode
+			}
+
+			body, _ := json.Marshal(payload)
+			rec := mcpAPICall(ctx, router, http.MethodPut, "/api/devices/"+url.PathEscape(uid)+"/files", bytes.NewReader(body))
+
+			return mcpRemoteResult(rec), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("shellhub_tail_session",
+			mcp.WithDescription("Return the last lines of a session's recorded terminal output, as plain text. Works on a session still running, up to what it has recorded so far."),
+			mcp.WithString("uid", mcp.Required(), mcp.Description("Session UID.")),
+			mcp.WithInteger("seat", mcp.Description("Session seat. Default: 0, the first one.")),
+			mcp.WithInteger("lines", mcp.Description("Number of lines to return (max 500). Default: 50.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			uid, _ := args["uid"].(string)
+			lines := min(max(intArg(args, "lines", 50), 1), maxTailLines)
+
+			q := url.Values{}
+			q.Set("format", "text")
+			q.Set("seat", strconv.Itoa(intArg(args, "seat", 0)))
+			q.Set("tail", strconv.Itoa(lines))
+
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/sessions/"+url.PathEscape(uid)+"/recording?"+q.Encode(), nil)
+			if rec.Code < http.StatusOK || rec.Code >= http.StatusMultipleChoices {
+				return mcpAPIResult(rec), nil
+			}
+
+			output := strings.Split(strings.TrimRight(rec.Body.String(), "\n"), "\n")
+			if len(output) > lines {
+				output = output[len(output)-lines:]
+			}
+
+			return mcp.NewToolResultText(strings.Join(output, "\n")), nil
+		},
+	)
+}
+
+// --- Namespace resources ---
+
+// addNamespaceResources exposes the namespace's configuration as resources, for
+// a client to read into its context rather than call a tool for.
+func addNamespaceResources(s *mcpserver.MCPServer, router http.Handler) {
+	s.AddResource(
+		mcp.NewResource("shellhub://namespace", "Namespace",
+			mcp.WithResourceDescription("The caller's namespace: its members and settings, such as the SSH access mode."),
+			mcp.WithMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/namespaces/"+url.PathEscape(tenantFromCtx(ctx)), nil)
+
+			return mcpResourceResult(req.Params.URI, rec)
+		},
+	)
+
+	s.AddResource(
+		mcp.NewResource("shellhub://namespace/access-policies", "Access policies",
+			mcp.WithResourceDescription("The namespace's access policies."),
+			mcp.WithMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/access-policies", nil)
+
+			return mcpResourceResult(req.Params.URI, rec)
+		},
+	)
+
+	s.AddResource(
+		mcp.NewResource("shellhub://namespace/policy-bundle", "Policy bundle",
+			mcp.WithResourceDescription("The namespace's identity-mode configuration as one document: access policies, SSH identities and install keys. An API key reads it without the install keys."),
+			mcp.WithMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			rec := mcpAPICall(ctx, router, http.MethodGet, "/api/policy-bundle", nil)
+
+			return mcpResourceResult(req.Params.URI, rec)
+		},
+	)
+}
+
+// mcpResourceResult is mcpAPIResult for resources: a 2xx forwards the JSON body
+// as the resource's contents, anything else fails the read with the shared
+// fixed message.
+func mcpResourceResult(uri string, rec *httptest.ResponseRecorder) ([]mcp.ResourceContents, error) {
+	if rec.Code < http.StatusOK || rec.Code >= http.StatusMultipleChoices {
+		return nil, errors.New(mcpAPIError(rec.Code))
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: "application/json", Text: rec.Body.String()},
+	}, nil
+}
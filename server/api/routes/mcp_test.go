@@ -3,10 +3,12 @@ package routes
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/api/scope"
@@ -29,6 +31,14 @@ const mcpCallerTenant = "00000000-0000-4000-0000-000000000000"
 func mcpCall(t *testing.T, router http.Handler, tenant, role, body string) *httptest.ResponseRecorder {
 	t.Helper()
 
+	return mcpCallWith(t, router, tenant, role, http.Header{}, body)
+}
+
+// mcpCallWith is mcpCall with the caller's identity headers on top, such as
+// X-ID for a user or X-Api-Key for an API key.
+func mcpCallWith(t *testing.T, router http.Handler, tenant, role string, identity http.Header, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
 	req := httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
 	// The stateless session manager only validates the format of the session
@@ -41,6 +51,11 @@ func mcpCall(t *testing.T, router http.Handler, tenant, role, body string) *http
 	if role != "" {
 		req.Header.Set("X-Role", role)
 	}
+	for key, values := range identity {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
 
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
@@ -288,7 +303,7 @@ func TestMCPListSessions(t *testing.T) {
 func TestMCPGetSession(t *testing.T) {
 	mock := mocks.NewMockService(t)
 	mock.
-		On("GetSession", gomock.Anything, scope.MustBounded(mcpCallerTenant), models.UID("sess1")).
+		On("GetSession", gomock.Anything, scope.MustBounded(mcpCallerTenant), &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "sess1"}}).
 		Return(&models.Session{UID: "sess1"}, nil).
 		Once()
 
@@ -300,3 +315,259 @@ func TestMCPGetSession(t *testing.T) {
 	assert.Contains(t, text, "sess1")
 	mock.AssertExpectations(t)
 }
+
+// --- access policy tools ---
+
+// TestMCPSimulateAccess ensures the tool arguments become the simulate request
+// body, with hostname and tags folded into the device filter.
+func TestMCPSimulateAccess(t *testing.T) {
+	mock := mocks.NewMockService(t)
+	mock.
+		On("SimulateAccess", gomock.Anything, gomock.MatchedBy(func(r *requests.AccessPolicySimulate) bool {
+			return r.TenantID == mcpCallerTenant &&
+				r.PrincipalID == "user1" && r.Login == "root" && r.SourceIP == "10.0.0.1" &&
+				r.DeviceUID == "" && r.Filter != nil && r.Filter.Tags[0] == "prod"
+		})).
+		Return([]models.AccessSimulation{{DeviceUID: "uid1", Decision: models.Decision{Allowed: true}}}, nil).
+		Once()
+
+	rec := mcpCall(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleOwner.String(),
+		mcpToolCall("shellhub_simulate_access", `{"principal_id":"user1","login":"root","tags":["prod"],"source_ip":"10.0.0.1"}`))
+
+	text, isErr := mcpToolResult(t, rec)
+	assert.False(t, isErr)
+	assert.Contains(t, text, "uid1")
+	mock.AssertExpectations(t)
+}
+
+// TestMCPSimulateAccessWithoutSourceIP ensures a simulation without the source
+// IP is refused rather than evaluated: a policy limited to source CIDRs could
+// not say whether it applies, and a deny one would report a false denial.
+func TestMCPSimulateAccessWithoutSourceIP(t *testing.T) {
+	mock := mocks.NewMockService(t)
+
+	rec := mcpCall(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleOwner.String(),
+		mcpToolCall("shellhub_simulate_access", `{"principal_id":"user1","login":"root","device_uid":"uid1"}`))
+
+	text, isErr := mcpToolResult(t, rec)
+	assert.True(t, isErr)
+	assert.Contains(t, text, "invalid arguments")
+	mock.AssertNotCalled(t, "SimulateAccess", gomock.Anything, gomock.Anything)
+}
+
+// TestMCPSimulateAccessForbidden ensures a role without AccessPolicyManage is
+// stopped by the route before the service runs.
+func TestMCPSimulateAccessForbidden(t *testing.T) {
+	mock := mocks.NewMockService(t)
+
+	rec := mcpCall(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleObserver.String(),
+		mcpToolCall("shellhub_simulate_access", `{"principal_id":"user1","login":"root","device_uid":"uid1"}`))
+
+	text, isErr := mcpToolResult(t, rec)
+	assert.True(t, isErr)
+	assert.Contains(t, text, "forbidden")
+	mock.AssertNotCalled(t, "SimulateAccess", gomock.Anything, gomock.Anything)
+}
+
+// TestMCPListDeviceAccess ensures the uid arg becomes the path parameter and
+// the response is wrapped as {total, grants}.
+func TestMCPListDeviceAccess(t *testing.T) {
+	mock := mocks.NewMockService(t)
+	mock.
+		On("ListDeviceAccess", gomock.Anything, gomock.MatchedBy(func(r *requests.AccessPolicyReach) bool {
+			return r.TenantID == mcpCallerTenant && r.DeviceUID == "uid1"
+		})).
+		Return([]models.AccessGrant{{PrincipalID: "user1", Login: "*"}}, nil).
+		Once()
+
+	rec := mcpCall(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleOwner.String(),
+		mcpToolCall("shellhub_list_device_access", `{"uid":"uid1"}`))
+
+	text, isErr := mcpToolResult(t, rec)
+	assert.False(t, isErr)
+	assert.Contains(t, text, `"total": 1`)
+	assert.Contains(t, text, "user1")
+	mock.AssertExpectations(t)
+}
+
+// --- remote tools ---
+
+// TestMCPTailSession ensures the tool asks for the tail of the session's text
+// recording and keeps only its last lines.
+func TestMCPTailSession(t *testing.T) {
+	mock := mocks.NewMockService(t)
+	mock.
+		On("ExportSessionRecording", gomock.Anything, scope.MustBounded(mcpCallerTenant), gomock.MatchedBy(func(r *requests.SessionRecordingExport) bool {
+			return r.UID == "sess1" && r.Seat == 1 && r.Format == "text" && r.Tail == 2
+		}), gomock.Anything).
+		Run(func(args gomock.Arguments) {
+			args.Get(3).(io.Writer).Write([]byte("one\ntwo\nthree\n")) //nolint:errcheck
+		}).
+		Return(nil).
+		Once()
+
+	rec := mcpCall(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleOwner.String(),
+		mcpToolCall("shellhub_tail_session", `{"uid":"sess1","seat":1,"lines":2}`))
+
+	text, isErr := mcpToolResult(t, rec)
+	assert.False(t, isErr)
+	assert.Equal(t, "two\nthree", text)
+	mock.AssertExpectations(t)
+}
+
+// TestMCPRemoteResult ensures the refusals of the routes that reach a device
+// keep their message, while an unreachable device and other errors take fixed
+// ones.
+func TestMCPRemoteResult(t *testing.T) {
+	respond := func(code int, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(code)
+		rec.WriteString(body) //nolint:errcheck
+
+		return rec
+	}
+
+	cases := []struct {
+		description string
+		rec         *httptest.ResponseRecorder
+		expected    string
+		isErr       bool
+	}{
+		{"forwards a success", respond(http.StatusOK, `{"exit_code":0}`), `{"exit_code":0}`, false},
+		{"forwards a refusal's message", respond(http.StatusForbidden, `{"message":"access to the device has been denied"}`), "access to the device has been denied", true},
+		{"names an unreachable device", respond(http.StatusBadGateway, `{"message":"internal server error"}`), "failed to connect to the device", true},
+		{"keeps the fixed message for a missing credential", respond(http.StatusUnauthorized, ``), "unauthorized: missing or invalid token", true},
+		{"keeps the fixed message without a body", respond(http.StatusNotFound, ``), "not found", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			result := mcpRemoteResult(tc.rec)
+			require.NotEmpty(t, result.Content)
+
+			assert.Equal(t, tc.isErr, result.IsError)
+			assert.Equal(t, tc.expected, result.Content[0].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestMCPCreateAccessPolicy(t *testing.T) {
+	mock := mocks.NewMockService(t)
+	mock.
+		On("CreateAccessPolicy", gomock.Anything, gomock.MatchedBy(func(r *requests.AccessPolicyCreate) bool {
+			return r.TenantID == mcpCallerTenant &&
+				r.Name == "ops" &&
+				r.Subject.Type == "role" && r.Subject.Value == "operator" &&
+				r.Filter.Hostname == ".*" &&
+				len(r.Logins) == 1 && r.Logins[0] == "root" &&
+				r.SourceIP == nil
+		})).
+		Return(&models.AccessPolicy{ID: "pol1", Name: "ops"}, nil).
+		Once()
+
+	rec := mcpCall(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleOwner.String(),
+		mcpToolCall("shellhub_create_access_policy", `{"name":"ops","subject":{"type":"role","value":"operator"},"filter":{"hostname":".*"},"logins":["root"]}`))
+
+	text, isErr := mcpToolResult(t, rec)
+	assert.False(t, isErr)
+	assert.Contains(t, text, "pol1")
+	mock.AssertExpectations(t)
+}
+
+func TestMCPCreateAccessPolicyForbidden(t *testing.T) {
+	mock := mocks.NewMockService(t)
+
+	rec := mcpCall(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleObserver.String(),
+		mcpToolCall("shellhub_create_access_policy", `{"name":"ops","subject":{"type":"all-members"},"filter":{"hostname":".*"},"logins":["root"]}`))
+
+	text, isErr := mcpToolResult(t, rec)
+	assert.True(t, isErr)
+	assert.Contains(t, text, "forbidden")
+	mock.AssertNotCalled(t, "CreateAccessPolicy", gomock.Anything, gomock.Anything)
+}
+
+// TestMCPConfirmSSHApproval ensures approving a login goes through as the
+// calling user, and that an API key is refused as the REST route refuses it:
+// confirming binds the key to whoever confirms.
+func TestMCPConfirmSSHApproval(t *testing.T) {
+	t.Run("as a user", func(t *testing.T) {
+		mock := mocks.NewMockService(t)
+		mock.
+			On("ConfirmSSHApproval", gomock.Anything, "user1", gomock.MatchedBy(func(r *requests.SSHApprovalConfirm) bool {
+				return r.Code == "ABCD1234" && r.ExpiresIn != nil && *r.ExpiresIn == 30
+			})).
+			Return(nil).
+			Once()
+
+		rec := mcpCallWith(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleOwner.String(), http.Header{"X-Id": {"user1"}},
+			mcpToolCall("shellhub_confirm_ssh_approval", `{"code":"ABCD1234","expires_in":30}`))
+
+		text, isErr := mcpToolResult(t, rec)
+		assert.False(t, isErr)
+		assert.Equal(t, "login ABCD1234 approved", text)
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("as an API key", func(t *testing.T) {
+		mock := mocks.NewMockService(t)
+
+		rec := mcpCallWith(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleOwner.String(), http.Header{"X-Api-Key": {"key"}},
+			mcpToolCall("shellhub_confirm_ssh_approval", `{"code":"ABCD1234"}`))
+
+		text, isErr := mcpToolResult(t, rec)
+		assert.True(t, isErr)
+		assert.Contains(t, text, "forbidden")
+		mock.AssertNotCalled(t, "ConfirmSSHApproval", gomock.Anything, gomock.Anything, gomock.Anything)
+	})
+}
+
+// TestMCPUpdateInstallKey ensures the current name goes in the path and the
+// new one in the body, and that only the arguments passed are sent.
+func TestMCPUpdateInstallKey(t *testing.T) {
+	mock := mocks.NewMockService(t)
+	mock.
+		On("UpdateInstallKey", gomock.Anything, gomock.MatchedBy(func(r *requests.UpdateInstallKey) bool {
+			return r.CurrentName == "ci" &&
+				r.Name == "ci-runners" &&
+				r.Disabled != nil && *r.Disabled &&
+				r.Mode == nil &&
+				r.ExpiresIn.Present && r.ExpiresIn.Value == nil
+		})).
+		Return(nil).
+		Once()
+
+	rec := mcpCallWith(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleOwner.String(), http.Header{"X-Id": {"user1"}},
+		mcpToolCall("shellhub_update_install_key", `{"name":"ci","new_name":"ci-runners","disabled":true,"expires_in":null}`))
+
+	_, isErr := mcpToolResult(t, rec)
+	assert.False(t, isErr)
+	mock.AssertExpectations(t)
+}
+
+func TestMCPReadNamespaceResource(t *testing.T) {
+	mock := mocks.NewMockService(t)
+	mock.
+		On("GetNamespace", gomock.Anything, mcpCallerTenant).
+		Return(&models.Namespace{TenantID: mcpCallerTenant, Name: "ns"}, nil).
+		Once()
+
+	rec := mcpCall(t, NewRouter(mock), mcpCallerTenant, authorizer.RoleOwner.String(),
+		`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"shellhub://namespace"}}`)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var env struct {
+		Result struct {
+			Contents []struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"contents"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &env), rec.Body.String())
+	require.Len(t, env.Result.Contents, 1)
+
+	assert.Equal(t, "shellhub://namespace", env.Result.Contents[0].URI)
+	assert.Contains(t, env.Result.Contents[0].Text, mcpCallerTenant)
+	mock.AssertExpectations(t)
+}
@@ -19,6 +19,7 @@ import (
 // a credential into an identity.
 type AuthnService interface {
 	AuthAPIKey(ctx context.Context, key string) (*models.APIKey, error)
+	RecordAPIKeyUsage(ctx context.Context, apiKey *models.APIKey)
 	ResolveNamespaceRole(ctx context.Context, tenantID, userID string) (*models.Namespace, string, error)
 	GetUserAdmin(ctx context.Context, userID string) (bool, error)
 	PublicKey() *rsa.PublicKey
@@ -164,10 +165,40 @@ func (a *Authenticator) Resolve(c *echo.Context) (*gateway.Identity, error) {
 			return nil, nil //nolint:nilerr
 		}
 
+		// A key used from outside its allowlist is as unusable as an unknown one.
+		if !apiKey.AllowsSource(c.RealIP()) {
+			log.WithFields(log.Fields{"tenant_id": apiKey.TenantID, "name": apiKey.Name, "ip": c.RealIP()}).
+				Warn("refused an API key used from outside its source allowlist")
+
+			return nil, nil
+		}
+
+		// A key whose permission list names nothing this server knows would keep no permission at
+		// all; refusing it is clearer than letting it through to be forbidden everywhere, and
+		// never risks reading the empty list as "every permission of the role".
+		permissions := apiKey.GrantedPermissions()
+		if permissions != nil && len(permissions) == 0 {
+			log.WithFields(log.Fields{"tenant_id": apiKey.TenantID, "name": apiKey.Name}).
+				Warn("refused an API key that keeps no known permission")
+
+			return nil, nil
+		}
+
+		a.service.RecordAPIKeyUsage(c.Request().Context(), apiKey)
+
+		recordOrigin(c, models.AuditActor{Type: models.AuditActorAPIKey, ID: apiKey.Name, Name: apiKey.Name})
+
+		var tags []string
+		if len(apiKey.Tags) > 0 {
+			tags = apiKey.Tags
+		}
+
 		return &gateway.Identity{
-			TenantID: apiKey.TenantID,
-			Role:     apiKey.Role,
-			APIKey:   key,
+			TenantID:    apiKey.TenantID,
+			Role:        apiKey.Role,
+			APIKey:      key,
+			Permissions: permissions,
+			DeviceTags:  tags,
 		}, nil
 	}
 
@@ -230,14 +261,28 @@ func (a *Authenticator) Resolve(c *echo.Context) (*gateway.Identity, error) {
 			return nil, nil //nolint:nilerr
 		}
 
+		recordOrigin(c, models.AuditActor{Type: models.AuditActorUser, ID: claims.ID, Name: claims.Username})
+
 		return &gateway.Identity{
-			ID:       claims.ID,
-			Username: claims.Username,
-			TenantID: claims.TenantID,
-			Role:     claims.Role,
-			Admin:    admin,
+			ID:         claims.ID,
+			Username:   claims.Username,
+			TenantID:   claims.TenantID,
+			Role:       claims.Role,
+			Admin:      admin,
+			AuthMethod: claims.AuthMethod,
 		}, nil
 	}
 
 	return nil, nil
 }
+
+// recordOrigin parks the request's origin in its context, for the audit log to attribute the
+// actions the request takes. The request ID is the one the router's RequestID middleware
+// mirrored onto the request.
+func recordOrigin(c *echo.Context, actor models.AuditActor) {
+	c.SetRequest(c.Request().WithContext(authctx.WithOrigin(c.Request().Context(), authctx.Origin{
+		Actor:     actor,
+		SourceIP:  c.RealIP(),
+		RequestID: c.Request().Header.Get(echo.HeaderXRequestID),
+	})))
+}
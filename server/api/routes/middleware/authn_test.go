@@ -36,7 +36,7 @@ func userBearer(t *testing.T) (string, *rsa.PrivateKey) {
 	require.NoError(t, err)
 
 	bearer, err := jwttoken.EncodeUserClaims(
-		authorizer.UserClaims{ID: testUserID, TenantID: testTenant, Username: "john"},
+		authorizer.UserClaims{ID: testUserID, TenantID: testTenant, Username: "john", AuthMethod: "local"},
 		privateKey,
 	)
 	require.NoError(t, err)
@@ -70,11 +70,12 @@ func TestAuthenticatorResolveUserClaims(t *testing.T) {
 				service.On("GetUserAdmin", mock.Anything, testUserID).Return(true, nil).Once()
 			},
 			expected: &gateway.Identity{
-				ID:       testUserID,
-				Username: "john",
-				TenantID: testTenant,
-				Role:     authorizer.RoleOwner,
-				Admin:    true,
+				ID:         testUserID,
+				Username:   "john",
+				TenantID:   testTenant,
+				Role:       authorizer.RoleOwner,
+				Admin:      true,
+				AuthMethod: "local",
 			},
 		},
 		{
@@ -156,6 +157,108 @@ func TestAuthenticatorResolveForwardsNamespace(t *testing.T) {
 	service.AssertExpectations(t)
 }
 
+// The caller a request authenticates as is recorded in its context, for the audit log to
+// attribute what the request does.
+func TestAuthenticatorResolveRecordsOrigin(t *testing.T) {
+	bearer, privateKey := userBearer(t)
+
+	service := new(mocks.MockService)
+	service.On("PublicKey").Return(&privateKey.PublicKey).Once()
+	service.On("ResolveNamespaceRole", mock.Anything, testTenant, testUserID).
+		Return(&models.Namespace{TenantID: testTenant}, "owner", nil).Once()
+	service.On("GetUserAdmin", mock.Anything, testUserID).Return(false, nil).Once()
+
+	c, _ := authenticatedRequest(echo.New(), bearer)
+	c.Request().Header.Set(echo.HeaderXRequestID, "request-id")
+	c.Request().RemoteAddr = "192.0.2.10:4321"
+
+	_, err := NewAuthenticator(service).Resolve(c)
+	require.NoError(t, err)
+
+	origin, ok := authctx.OriginFrom(c.Request().Context())
+	require.True(t, ok)
+	assert.Equal(t, authctx.Origin{
+		Actor:     models.AuditActor{Type: models.AuditActorUser, ID: testUserID, Name: "john"},
+		SourceIP:  "192.0.2.10",
+		RequestID: "request-id",
+	}, origin)
+
+	service.AssertExpectations(t)
+}
+
+func TestAuthenticatorResolveAPIKey(t *testing.T) {
+	cases := []struct {
+		description string
+		apiKey      *models.APIKey
+		remoteAddr  string
+		recorded    bool
+		expected    *gateway.Identity
+	}{
+		{
+			description: "an unscoped key keeps its role",
+			apiKey:      &models.APIKey{Name: "ci", TenantID: testTenant, Role: authorizer.RoleOperator},
+			remoteAddr:  "192.0.2.10:4321",
+			recorded:    true,
+			expected:    &gateway.Identity{TenantID: testTenant, Role: authorizer.RoleOperator, APIKey: "key"},
+		},
+		{
+			description: "a scoped key carries its permissions and device tags",
+			apiKey: &models.APIKey{
+				Name:        "ci",
+				TenantID:    testTenant,
+				Role:        authorizer.RoleOperator,
+				Permissions: []string{"device:connect"},
+				Tags:        []string{"production"},
+				SourceIPs:   []string{"192.0.2.0/24"},
+			},
+			remoteAddr: "192.0.2.10:4321",
+			recorded:   true,
+			expected: &gateway.Identity{
+				TenantID:    testTenant,
+				Role:        authorizer.RoleOperator,
+				APIKey:      "key",
+				Permissions: []authorizer.Permission{authorizer.DeviceConnect},
+				DeviceTags:  []string{"production"},
+			},
+		},
+		{
+			description: "a key used from outside its allowlist yields no identity",
+			apiKey:      &models.APIKey{Name: "ci", TenantID: testTenant, Role: authorizer.RoleOperator, SourceIPs: []string{"192.0.2.0/24"}},
+			remoteAddr:  "198.51.100.7:4321",
+			recorded:    false,
+			expected:    nil,
+		},
+		{
+			description: "a key keeping no known permission yields no identity",
+			apiKey:      &models.APIKey{Name: "ci", TenantID: testTenant, Role: authorizer.RoleOperator, Permissions: []string{"device:unknown"}},
+			remoteAddr:  "192.0.2.10:4321",
+			recorded:    false,
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			service := new(mocks.MockService)
+			service.On("AuthAPIKey", mock.Anything, "key").Return(tc.apiKey, nil).Once()
+			if tc.recorded {
+				service.On("RecordAPIKeyUsage", mock.Anything, tc.apiKey).Return().Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices", nil)
+			req.Header.Set("X-API-Key", "key")
+			req.RemoteAddr = tc.remoteAddr
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			identity, err := NewAuthenticator(service).Resolve(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, identity)
+
+			service.AssertExpectations(t)
+		})
+	}
+}
+
 func TestAuthenticatorMiddlewareStaleToken(t *testing.T) {
 	bearer, privateKey := userBearer(t)
 
@@ -6,6 +6,8 @@ import (
 
 	"github.com/labstack/echo/v5"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
 )
 
@@ -43,11 +45,12 @@ func BlockAPIKey(next echo.HandlerFunc) echo.HandlerFunc {
 
 // RequiresPermission reports whether the client has the specified permission.
 // If not, it returns an [http.StatusForbidden] response. Otherwise, it executes
-// the next handler.
+// the next handler. A scoped API key must both hold the permission through its
+// role and keep it in its permission list.
 func RequiresPermission(permission authorizer.Permission) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) error {
-			if ctx, ok := gateway.From(c); !ok || !ctx.Role().HasPermission(permission) {
+			if ctx, ok := gateway.From(c); !ok || !ctx.HasPermission(permission) {
 				return c.NoContent(http.StatusForbidden)
 			}
 
@@ -56,6 +59,43 @@ func RequiresPermission(permission authorizer.Permission) echo.MiddlewareFunc {
 	}
 }
 
+// DeviceScopeService is the slice of the service layer [RequiresDeviceScope] needs to look up the
+// device a request targets.
+type DeviceScopeService interface {
+	GetDevice(ctx context.Context, sc scope.Scope, uid models.UID) (*models.Device, error)
+}
+
+// RequiresDeviceScope enforces that a scoped API key only reaches the devices carrying one of its
+// tags, for the device named by the given URL path parameter. A device out of reach answers
+// [http.StatusNotFound], as a missing one does, so the key cannot learn it exists. Callers without
+// a device scope pass through untouched.
+func RequiresDeviceScope(service DeviceScopeService, param string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			ctx, ok := gateway.From(c)
+			if !ok {
+				return c.NoContent(http.StatusForbidden)
+			}
+
+			if ctx.DeviceTags() == nil {
+				return next(c)
+			}
+
+			sc, err := ctx.Scope()
+			if err != nil {
+				return err
+			}
+
+			device, err := service.GetDevice(c.Request().Context(), sc, models.UID(c.Param(param)))
+			if err != nil || !ctx.ReachesDevice(device) {
+				return c.NoContent(http.StatusNotFound)
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // RequiresTenant enforces that the caller's tenant scope matches the tenant
 // provided in the given URL path parameter. It fails closed: if either the
 // caller's tenant or the path parameter is missing or they don't match, it
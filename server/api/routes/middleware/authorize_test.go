@@ -1,13 +1,19 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/labstack/echo/v5"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/server/api/services/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestRequiresTenant(t *testing.T) {
@@ -78,3 +84,126 @@ func TestRequiresTenant(t *testing.T) {
 		})
 	}
 }
+
+func TestRequiresPermission(t *testing.T) {
+	cases := []struct {
+		description string
+		headers     map[string]string
+		expected    int
+	}{
+		{
+			description: "allows a role granting the permission",
+			headers:     map[string]string{"X-Role": authorizer.RoleOperator.String()},
+			expected:    http.StatusOK,
+		},
+		{
+			description: "blocks a role without the permission",
+			headers:     map[string]string{"X-Role": authorizer.RoleObserver.String()},
+			expected:    http.StatusForbidden,
+		},
+		{
+			description: "allows a scoped API key listing the permission",
+			headers: map[string]string{
+				"X-Role":                authorizer.RoleAdministrator.String(),
+				"X-API-Key-Permissions": "device:connect,device:rename",
+			},
+			expected: http.StatusOK,
+		},
+		{
+			description: "blocks a scoped API key not listing the permission",
+			headers: map[string]string{
+				"X-Role":                authorizer.RoleAdministrator.String(),
+				"X-API-Key-Permissions": "device:connect",
+			},
+			expected: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			next := func(*echo.Context) error { return c.NoContent(http.StatusOK) }
+
+			_ = gateway.WithContext(nil)(RequiresPermission(authorizer.DeviceRename)(next))(c)
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+}
+
+func TestRequiresDeviceScope(t *testing.T) {
+	const (
+		tenant = "00000000-0000-4000-0000-000000000000"
+		uid    = "device"
+	)
+
+	tagged := &models.Device{UID: uid, TenantID: tenant, Taggable: models.Taggable{Tags: []models.Tag{{Name: "production"}}}}
+
+	cases := []struct {
+		description string
+		tags        string
+		setupMock   func(*mocks.MockService)
+		expected    int
+	}{
+		{
+			description: "passes an unscoped caller without a lookup",
+			expected:    http.StatusOK,
+		},
+		{
+			description: "allows a device carrying one of the key's tags",
+			tags:        "staging,production",
+			setupMock: func(m *mocks.MockService) {
+				m.On("GetDevice", mock.Anything, scope.MustBounded(tenant), models.UID(uid)).Return(tagged, nil).Once()
+			},
+			expected: http.StatusOK,
+		},
+		{
+			description: "hides a device without the key's tags",
+			tags:        "staging",
+			setupMock: func(m *mocks.MockService) {
+				m.On("GetDevice", mock.Anything, scope.MustBounded(tenant), models.UID(uid)).Return(tagged, nil).Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "hides a device that does not exist",
+			tags:        "production",
+			setupMock: func(m *mocks.MockService) {
+				m.On("GetDevice", mock.Anything, scope.MustBounded(tenant), models.UID(uid)).Return(nil, errors.New("not found")).Once()
+			},
+			expected: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			service := new(mocks.MockService)
+			if tc.setupMock != nil {
+				tc.setupMock(service)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Tenant-ID", tenant)
+			if tc.tags != "" {
+				req.Header.Set("X-API-Key-Tags", tc.tags)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "uid", Value: uid}})
+
+			next := func(*echo.Context) error { return c.NoContent(http.StatusOK) }
+
+			_ = gateway.WithContext(nil)(RequiresDeviceScope(service, "uid")(next))(c)
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+
+			service.AssertExpectations(t)
+		})
+	}
+}
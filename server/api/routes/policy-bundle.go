@@ -0,0 +1,184 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+	errs "github.com/shellhub-io/shellhub/server/api/routes/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ExportPolicyBundleURL = "/policy-bundle"
+	ApplyPolicyBundleURL  = "/policy-bundle/apply"
+)
+
+// yamlSexagesimal matches the base 60 numbers of YAML 1.1, such as 08:00 or 1:30:00.
+var yamlSexagesimal = regexp.MustCompile(`^[-+]?[0-9][0-9_]*(:[0-5]?[0-9])+(\.[0-9_]*)?$`)
+
+// MIMEApplicationYAML is the media type a bundle is exchanged in when kept as a file.
+const MIMEApplicationYAML = "application/yaml"
+
+// ExportPolicyBundle returns the namespace's bundle as JSON, or as YAML when the client accepts
+// it. Install keys cannot be managed with an API key, so an API key export leaves that section
+// out, and applying the export back with the same key leaves them untouched.
+func (h *Handler) ExportPolicyBundle(c *gateway.Context) error {
+	req := new(requests.PolicyBundleExport)
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	bundle, err := h.service.ExportPolicyBundle(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	if isAPIKeyRequest(c) {
+		bundle.InstallKeys = nil
+	}
+
+	if !acceptsYAML(c.Request()) {
+		return c.JSON(http.StatusOK, bundle)
+	}
+
+	body, err := jsonToYAML(bundle)
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, MIMEApplicationYAML, body)
+}
+
+// ApplyPolicyBundle converges the namespace to the bundle in the body, sent as JSON or YAML, and
+// returns the plan. With ?dry_run=true the plan is computed but not applied.
+func (h *Handler) ApplyPolicyBundle(c *gateway.Context) error {
+	if isYAML(c.Request().Header.Get(echo.HeaderContentType)) {
+		if err := yamlBodyToJSON(c.Request()); err != nil {
+			return errs.NewErrUnprocessableEntity(err)
+		}
+	}
+
+	req := new(requests.PolicyBundleApply)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if value := c.QueryParam("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			return errs.NewErrUnprocessableEntity(err)
+		}
+
+		req.DryRun = dryRun
+	}
+
+	if req.InstallKeys != nil && isAPIKeyRequest(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	if c.ID() != nil {
+		req.UserID = c.ID().ID
+	}
+
+	plan, err := h.service.ApplyPolicyBundle(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, plan)
+}
+
+func isAPIKeyRequest(c *gateway.Context) bool {
+	return c.Request().Header.Get("X-API-Key") != ""
+}
+
+func isYAML(mediaType string) bool {
+	for _, t := range []string{MIMEApplicationYAML, "application/x-yaml", "text/yaml"} {
+		if strings.HasPrefix(mediaType, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func acceptsYAML(req *http.Request) bool {
+	for _, accept := range strings.Split(req.Header.Get(echo.HeaderAccept), ",") {
+		if isYAML(strings.TrimSpace(accept)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// yamlBodyToJSON rewrites a YAML request body as JSON, so it binds and validates like any other.
+func yamlBodyToJSON(req *http.Request) error {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+
+	var value any
+	if err := yaml.Unmarshal(body, &value); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	return nil
+}
+
+// jsonToYAML renders v as YAML with the field names and order of its JSON encoding.
+func jsonToYAML(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+
+	// JSON is YAML in flow style; reset the style so the document renders in block style. Strings
+	// that YAML 1.1 parsers read as base 60 numbers, like a schedule's "08:00", stay quoted.
+	var reset func(*yaml.Node)
+	reset = func(n *yaml.Node) {
+		n.Style = 0
+		if n.Kind == yaml.ScalarNode && n.Tag == "!!str" && yamlSexagesimal.MatchString(n.Value) {
+			n.Style = yaml.DoubleQuotedStyle
+		}
+
+		for _, child := range n.Content {
+			reset(child)
+		}
+	}
+
+	reset(&node)
+
+	return yaml.Marshal(&node)
+}
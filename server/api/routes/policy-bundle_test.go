@@ -0,0 +1,194 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	servicemock "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportPolicyBundle(t *testing.T) {
+	bundle := func() *models.PolicyBundle {
+		return &models.PolicyBundle{
+			AccessPolicies: []models.PolicyBundleAccessPolicy{
+				{
+					Name:    "web",
+					Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+					Filter:  models.PolicyBundleFilter{Tags: []string{"web"}},
+					Logins:  []string{"deploy"},
+					Action:  models.PolicyActionAllow,
+					Schedule: &models.PolicySchedule{
+						Windows: []models.PolicyScheduleWindow{{Weekdays: []string{"mon"}, Start: "08:00", End: "18:00"}},
+					},
+				},
+			},
+			SSHIdentities: []models.PolicyBundleSSHIdentity{},
+			InstallKeys:   []models.PolicyBundleInstallKey{{Name: "fleet", Mode: models.InstallKeyModeAutomatic}},
+		}
+	}
+
+	cases := []struct {
+		description string
+		headers     map[string]string
+		contentType string
+		contains    []string
+		excludes    []string
+	}{
+		{
+			description: "renders JSON by default",
+			headers:     map[string]string{},
+			contentType: "application/json",
+			contains:    []string{`"access_policies":[{"name":"web"`, `"install_keys":[{"name":"fleet"`},
+		},
+		{
+			description: "renders YAML when the client accepts it",
+			headers:     map[string]string{"Accept": "application/yaml"},
+			contentType: "application/yaml",
+			contains:    []string{"access_policies:\n    - name: web\n", `start: "08:00"`, "install_keys:\n    - name: fleet\n"},
+		},
+		{
+			description: "leaves install keys out for an API key",
+			headers:     map[string]string{"Accept": "application/yaml", "X-API-Key": "key"},
+			contentType: "application/yaml",
+			contains:    []string{"install_keys: null\n"},
+			excludes:    []string{"fleet"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			svcMock.On("ExportPolicyBundle", mock.Anything, &requests.PolicyBundleExport{TenantID: "00000000-0000-4000-0000-000000000000"}).
+				Return(bundle(), nil).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/policy-bundle", nil)
+			req.Header.Set("X-Role", authorizer.RoleOwner.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Result().StatusCode)
+			assert.True(t, strings.HasPrefix(rec.Result().Header.Get("Content-Type"), tc.contentType))
+
+			for _, s := range tc.contains {
+				assert.Contains(t, rec.Body.String(), s)
+			}
+
+			for _, s := range tc.excludes {
+				assert.NotContains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestApplyPolicyBundle(t *testing.T) {
+	const yamlBundle = `access_policies:
+  - name: web
+    subject:
+      type: all-members
+    filter:
+      tags: [web]
+    logins: [deploy]
+    schedule:
+      windows:
+        - weekdays: [mon]
+          start: "08:00"
+          end: "18:00"
+install_keys:
+  - name: fleet
+`
+
+	cases := []struct {
+		description    string
+		url            string
+		headers        map[string]string
+		body           string
+		requiredMocks  func(svcMock *servicemock.MockService)
+		expectedStatus int
+	}{
+		{
+			description: "applies a YAML bundle as a dry run",
+			url:         "/api/policy-bundle/apply?dry_run=true",
+			headers:     map[string]string{"Content-Type": "application/yaml"},
+			body:        yamlBundle,
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ApplyPolicyBundle", mock.Anything, mock.MatchedBy(func(req *requests.PolicyBundleApply) bool {
+					return req.DryRun && req.UserID == "000000000000000000000000" &&
+						req.TenantID == "00000000-0000-4000-0000-000000000000" &&
+						len(req.AccessPolicies) == 1 && req.AccessPolicies[0].Schedule.Windows[0].Start == "08:00" &&
+						len(req.InstallKeys) == 1 && req.SSHIdentities == nil
+				})).Return(&models.PolicyBundlePlan{DryRun: true, Changes: []models.PolicyBundleChange{}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description: "applies a JSON bundle",
+			url:         "/api/policy-bundle/apply",
+			headers:     map[string]string{"Content-Type": "application/json"},
+			body:        `{"ssh_identities":[]}`,
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ApplyPolicyBundle", mock.Anything, mock.MatchedBy(func(req *requests.PolicyBundleApply) bool {
+					return !req.DryRun && req.SSHIdentities != nil && req.AccessPolicies == nil
+				})).Return(&models.PolicyBundlePlan{Changes: []models.PolicyBundleChange{}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "fails when the bundle does not validate",
+			url:            "/api/policy-bundle/apply",
+			headers:        map[string]string{"Content-Type": "application/yaml"},
+			body:           "access_policies:\n  - name: web\n    subject:\n      type: everyone\n    filter:\n      hostname: \".*\"\n    logins: [deploy]\n",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "fails when dry_run is not a boolean",
+			url:            "/api/policy-bundle/apply?dry_run=maybe",
+			headers:        map[string]string{"Content-Type": "application/json"},
+			body:           `{}`,
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			description:    "forbids managing install keys with an API key",
+			url:            "/api/policy-bundle/apply",
+			headers:        map[string]string{"Content-Type": "application/yaml", "X-API-Key": "key"},
+			body:           yamlBundle,
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			tc.requiredMocks(svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, tc.url, strings.NewReader(tc.body))
+			req.Header.Set("X-Role", authorizer.RoleOwner.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+}
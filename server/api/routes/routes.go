@@ -130,6 +130,24 @@ func NewRouter(service services.Service, opts ...Option) *echo.Echo {
 	publicAPI.POST(AuthLocalUserURL, gateway.Handler(handler.AuthLocalUser))
 	publicAPI.POST(AuthLocalUserURLV2, gateway.Handler(handler.AuthLocalUser))
 	publicAPI.POST(AuthPublicKeyURL, gateway.Handler(handler.AuthPublicKey))
+	publicAPI.GET(AuthOIDCURL, gateway.Handler(handler.GetOIDCAuthURL))
+	publicAPI.GET(AuthOIDCCallbackURL, gateway.Handler(handler.AuthOIDCUser))
+
+	// SCIM provisioning: the identity provider authenticates with its own token, which handler.SCIM
+	// checks, so the routes are anonymous to the authenticator.
+	publicAPI.GET(SCIMServiceProviderConfigURL, gateway.Handler(handler.SCIM(handler.GetSCIMServiceProviderConfig)))
+	publicAPI.GET(SCIMUsersURL, gateway.Handler(handler.SCIM(handler.ListSCIMUsers)))
+	publicAPI.POST(SCIMUsersURL, gateway.Handler(handler.SCIM(handler.CreateSCIMUser)))
+	publicAPI.GET(SCIMUserURL, gateway.Handler(handler.SCIM(handler.GetSCIMUser)))
+	publicAPI.PUT(SCIMUserURL, gateway.Handler(handler.SCIM(handler.ReplaceSCIMUser)))
+	publicAPI.PATCH(SCIMUserURL, gateway.Handler(handler.SCIM(handler.PatchSCIMUser)))
+	publicAPI.DELETE(SCIMUserURL, gateway.Handler(handler.SCIM(handler.DeleteSCIMUser)))
+	publicAPI.GET(SCIMGroupsURL, gateway.Handler(handler.SCIM(handler.ListSCIMGroups)))
+	publicAPI.POST(SCIMGroupsURL, gateway.Handler(handler.SCIM(handler.CreateSCIMGroup)))
+	publicAPI.GET(SCIMGroupURL, gateway.Handler(handler.SCIM(handler.GetSCIMGroup)))
+	publicAPI.PUT(SCIMGroupURL, gateway.Handler(handler.SCIM(handler.ReplaceSCIMGroup)))
+	publicAPI.PATCH(SCIMGroupURL, gateway.Handler(handler.SCIM(handler.PatchSCIMGroup)))
+	publicAPI.DELETE(SCIMGroupURL, gateway.Handler(handler.SCIM(handler.DeleteSCIMGroup)))
 
 	publicAPI.POST(CreateAPIKeyURL, gateway.Handler(handler.CreateAPIKey), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.APIKeyCreate))
 	publicAPI.GET(ListAPIKeysURL, gateway.Handler(handler.ListAPIKeys), routesmiddleware.BlockAPIKey)
@@ -157,12 +175,16 @@ func NewRouter(service services.Service, opts ...Option) *echo.Echo {
 	publicAPI.GET(URLNamespaceMembershipInvitationList, gateway.Handler(handler.GetNamespaceMembershipInvitationList), routesmiddleware.RequiresPermission(authorizer.NamespaceEditMember))
 	publicAPI.DELETE(URLCancelMembershipInvitation, gateway.Handler(handler.CancelMembershipInvitation), routesmiddleware.RequiresPermission(authorizer.NamespaceRemoveMember))
 
+	// A scoped API key reaches only the devices carrying one of its tags; every route acting on a
+	// single device checks it, after the permission so a key learns nothing it may not act on.
+	deviceScope := routesmiddleware.RequiresDeviceScope(service, ParamDeviceID)
+
 	publicAPI.GET(GetDeviceListURL, routesmiddleware.Authorize(gateway.Handler(handler.GetDeviceList)))
-	publicAPI.GET(GetDeviceURL, routesmiddleware.Authorize(gateway.Handler(handler.GetDevice)))
+	publicAPI.GET(GetDeviceURL, routesmiddleware.Authorize(gateway.Handler(handler.GetDevice)), deviceScope)
 	publicAPI.GET(ResolveDeviceURL, routesmiddleware.Authorize(gateway.Handler(handler.ResolveDevice)))
-	publicAPI.PUT(UpdateDevice, gateway.Handler(handler.UpdateDevice), routesmiddleware.RequiresPermission(authorizer.DeviceUpdate))
-	publicAPI.PATCH(RenameDeviceURL, gateway.Handler(handler.RenameDevice), routesmiddleware.RequiresPermission(authorizer.DeviceRename))
-	publicAPI.PATCH(UpdateDeviceStatusURL, gateway.Handler(handler.UpdateDeviceStatus), routesmiddleware.RequiresPermission(authorizer.DeviceAccept)) // TODO: DeviceWrite
+	publicAPI.PUT(UpdateDevice, gateway.Handler(handler.UpdateDevice), routesmiddleware.RequiresPermission(authorizer.DeviceUpdate), deviceScope)
+	publicAPI.PATCH(RenameDeviceURL, gateway.Handler(handler.RenameDevice), routesmiddleware.RequiresPermission(authorizer.DeviceRename), deviceScope)
+	publicAPI.PATCH(UpdateDeviceStatusURL, gateway.Handler(handler.UpdateDeviceStatus), routesmiddleware.RequiresPermission(authorizer.DeviceAccept), deviceScope) // TODO: DeviceWrite
 
 	// Device login flow: the device (authenticated with its own token) creates a
 	// short-lived code and polls its status; a user resolves the code into a
@@ -187,27 +209,33 @@ func NewRouter(service services.Service, opts ...Option) *echo.Echo {
 	publicAPI.GET(GetSSHApprovalURL, gateway.Handler(handler.GetSSHApproval), routesmiddleware.BlockAPIKey)
 	publicAPI.POST(ConfirmSSHApprovalURL, gateway.Handler(handler.ConfirmSSHApproval), routesmiddleware.BlockAPIKey)
 	publicAPI.POST(RejectSSHApprovalURL, gateway.Handler(handler.RejectSSHApproval), routesmiddleware.BlockAPIKey)
-	publicAPI.DELETE(DeleteDeviceURL, gateway.Handler(handler.DeleteDevice), routesmiddleware.RequiresPermission(authorizer.DeviceRemove))
-	publicAPI.PUT(SetDeviceCustomFieldURL, gateway.Handler(handler.SetDeviceCustomField), routesmiddleware.RequiresPermission(authorizer.DeviceCustomFieldUpdate))
-	publicAPI.DELETE(DeleteDeviceCustomFieldURL, gateway.Handler(handler.DeleteDeviceCustomField), routesmiddleware.RequiresPermission(authorizer.DeviceCustomFieldUpdate))
+	publicAPI.DELETE(DeleteDeviceURL, gateway.Handler(handler.DeleteDevice), routesmiddleware.RequiresPermission(authorizer.DeviceRemove), deviceScope)
+	publicAPI.PUT(SetDeviceCustomFieldURL, gateway.Handler(handler.SetDeviceCustomField), routesmiddleware.RequiresPermission(authorizer.DeviceCustomFieldUpdate), deviceScope)
+	publicAPI.DELETE(DeleteDeviceCustomFieldURL, gateway.Handler(handler.DeleteDeviceCustomField), routesmiddleware.RequiresPermission(authorizer.DeviceCustomFieldUpdate), deviceScope)
 
 	publicAPI.GET(URLGetTags, gateway.Handler(handler.GetTags))
 	publicAPI.POST(URLCreateTag, gateway.Handler(handler.CreateTag), routesmiddleware.RequiresPermission(authorizer.TagCreate))
 	publicAPI.PATCH(URLUpdateTag, gateway.Handler(handler.UpdateTag), routesmiddleware.RequiresPermission(authorizer.TagUpdate))
 	publicAPI.DELETE(URLDeleteTag, gateway.Handler(handler.DeleteTag), routesmiddleware.RequiresPermission(authorizer.TagDelete))
-	publicAPI.POST(URLPushTagToDevice, gateway.Handler(handler.PushTagToDevice), routesmiddleware.RequiresPermission(authorizer.TagCreate))
-	publicAPI.DELETE(URLPullTagFromDevice, gateway.Handler(handler.PullTagFromDevice), routesmiddleware.RequiresPermission(authorizer.TagDelete))
+	publicAPI.POST(URLPushTagToDevice, gateway.Handler(handler.PushTagToDevice), routesmiddleware.RequiresPermission(authorizer.TagCreate), deviceScope)
+	publicAPI.DELETE(URLPullTagFromDevice, gateway.Handler(handler.PullTagFromDevice), routesmiddleware.RequiresPermission(authorizer.TagDelete), deviceScope)
 
 	// NOTE: Legacy tag routes with tenant in path for backward compatibility.
 	publicAPI.GET(URLOldGetTags, gateway.Handler(handler.GetTags))
 	publicAPI.POST(URLOldCreateTag, gateway.Handler(handler.CreateTag), routesmiddleware.RequiresPermission(authorizer.TagCreate))
 	publicAPI.PATCH(URLOldUpdateTag, gateway.Handler(handler.UpdateTag), routesmiddleware.RequiresPermission(authorizer.TagUpdate))
 	publicAPI.DELETE(URLOldDeleteTag, gateway.Handler(handler.DeleteTag), routesmiddleware.RequiresPermission(authorizer.TagDelete))
-	publicAPI.POST(URLOldPushTagToDevice, gateway.Handler(handler.PushTagToDevice), routesmiddleware.RequiresPermission(authorizer.TagCreate))
-	publicAPI.DELETE(URLOldPullTagFromDevice, gateway.Handler(handler.PullTagFromDevice), routesmiddleware.RequiresPermission(authorizer.TagDelete))
+	publicAPI.POST(URLOldPushTagToDevice, gateway.Handler(handler.PushTagToDevice), routesmiddleware.RequiresPermission(authorizer.TagCreate), deviceScope)
+	publicAPI.DELETE(URLOldPullTagFromDevice, gateway.Handler(handler.PullTagFromDevice), routesmiddleware.RequiresPermission(authorizer.TagDelete), deviceScope)
 
 	publicAPI.GET(GetSessionsURL, routesmiddleware.Authorize(gateway.Handler(handler.GetSessionList)))
 	publicAPI.GET(GetSessionURL, routesmiddleware.Authorize(gateway.Handler(handler.GetSession)))
+	publicAPI.GET(ExportSessionRecordingURL, gateway.Handler(handler.ExportSessionRecording), routesmiddleware.RequiresPermission(authorizer.SessionPlay))
+	// Searching the output reads it as much as playing it back does.
+	publicAPI.GET(SearchSessionsURL, gateway.Handler(handler.SearchSessions), routesmiddleware.RequiresPermission(authorizer.SessionPlay))
+	// The commands typed are read off the session as much as its output is.
+	publicAPI.GET(ListSessionCommandsURL, gateway.Handler(handler.ListSessionCommands), routesmiddleware.RequiresPermission(authorizer.SessionPlay))
+	publicAPI.GET(GetSessionCommandsURL, gateway.Handler(handler.GetSessionCommands), routesmiddleware.RequiresPermission(authorizer.SessionPlay))
 
 	publicAPI.GET(GetStatsURL, routesmiddleware.Authorize(gateway.Handler(handler.GetStats)))
 	publicAPI.GET(GetSystemInfoURL, gateway.Handler(handler.GetSystemInfo))
@@ -244,6 +272,39 @@ func NewRouter(service services.Service, opts ...Option) *echo.Echo {
 	publicAPI.GET(GetAccessPolicyURL, gateway.Handler(handler.GetAccessPolicy), routesmiddleware.RequiresPermission(authorizer.AccessPolicyManage))
 	publicAPI.PUT(UpdateAccessPolicyURL, gateway.Handler(handler.UpdateAccessPolicy), routesmiddleware.RequiresPermission(authorizer.AccessPolicyManage))
 	publicAPI.DELETE(DeleteAccessPolicyURL, gateway.Handler(handler.DeleteAccessPolicy), routesmiddleware.RequiresPermission(authorizer.AccessPolicyManage))
+	publicAPI.POST(SimulateAccessURL, gateway.Handler(handler.SimulateAccess), routesmiddleware.RequiresPermission(authorizer.AccessPolicyManage))
+	publicAPI.GET(ListDeviceAccessURL, gateway.Handler(handler.ListDeviceAccess), routesmiddleware.RequiresPermission(authorizer.AccessPolicyManage), deviceScope)
+	publicAPI.GET(ExportPolicyBundleURL, gateway.Handler(handler.ExportPolicyBundle), routesmiddleware.RequiresPermission(authorizer.AccessPolicyManage), routesmiddleware.RequiresPermission(authorizer.SSHIdentityManage), routesmiddleware.RequiresPermission(authorizer.InstallKeyList))
+	publicAPI.POST(ApplyPolicyBundleURL, gateway.Handler(handler.ApplyPolicyBundle), routesmiddleware.RequiresPermission(authorizer.AccessPolicyManage), routesmiddleware.RequiresPermission(authorizer.SSHIdentityManage), routesmiddleware.RequiresPermission(authorizer.InstallKeyCreate), routesmiddleware.RequiresPermission(authorizer.InstallKeyUpdate))
+
+	// Just-in-time access requests. Any member can ask for access and follow
+	// their own requests; owner/admin see every request and decide them.
+	publicAPI.GET(ListAccessRequestsURL, gateway.Handler(handler.ListAccessRequests), routesmiddleware.RequiresPermission(authorizer.AccessRequestCreate))
+	publicAPI.POST(CreateAccessRequestURL, gateway.Handler(handler.CreateAccessRequest), routesmiddleware.RequiresPermission(authorizer.AccessRequestCreate))
+	publicAPI.GET(GetAccessRequestURL, gateway.Handler(handler.GetAccessRequest), routesmiddleware.RequiresPermission(authorizer.AccessRequestCreate))
+	publicAPI.POST(CancelAccessRequestURL, gateway.Handler(handler.CancelAccessRequest), routesmiddleware.RequiresPermission(authorizer.AccessRequestCreate))
+	publicAPI.POST(GrantAccessRequestURL, gateway.Handler(handler.GrantAccessRequest), routesmiddleware.RequiresPermission(authorizer.AccessRequestDecide))
+	publicAPI.POST(RejectAccessRequestURL, gateway.Handler(handler.RejectAccessRequest), routesmiddleware.RequiresPermission(authorizer.AccessRequestDecide))
+	publicAPI.POST(RevokeAccessRequestURL, gateway.Handler(handler.RevokeAccessRequest), routesmiddleware.RequiresPermission(authorizer.AccessRequestDecide))
+
+	// The namespace's audit log of administrative actions.
+	publicAPI.GET(ListAuditEventsURL, gateway.Handler(handler.ListAuditEvents), routesmiddleware.RequiresPermission(authorizer.AuditList))
+
+	// Outbound webhooks: the namespace's event subscriptions and their delivery
+	// logs.
+	publicAPI.GET(ListWebhooksURL, gateway.Handler(handler.ListWebhooks), routesmiddleware.RequiresPermission(authorizer.WebhookManage))
+	publicAPI.POST(CreateWebhookURL, gateway.Handler(handler.CreateWebhook), routesmiddleware.RequiresPermission(authorizer.WebhookManage))
+	publicAPI.GET(GetWebhookURL, gateway.Handler(handler.GetWebhook), routesmiddleware.RequiresPermission(authorizer.WebhookManage))
+	publicAPI.PATCH(UpdateWebhookURL, gateway.Handler(handler.UpdateWebhook), routesmiddleware.RequiresPermission(authorizer.WebhookManage))
+	publicAPI.DELETE(DeleteWebhookURL, gateway.Handler(handler.DeleteWebhook), routesmiddleware.RequiresPermission(authorizer.WebhookManage))
+	publicAPI.GET(ListWebhookDeliveriesURL, gateway.Handler(handler.ListWebhookDeliveries), routesmiddleware.RequiresPermission(authorizer.WebhookManage))
+	publicAPI.POST(ReplayWebhookDeliveryURL, gateway.Handler(handler.ReplayWebhookDelivery), routesmiddleware.RequiresPermission(authorizer.WebhookManage))
+
+	// The namespace's live events as Server-Sent Events, for what would
+	// otherwise poll the device and session lists. Every member may follow them,
+	// as every member may list both; the events a member could not read over
+	// the REST API are left out of its stream.
+	publicAPI.GET(StreamEventsURL, routesmiddleware.Authorize(gateway.Handler(handler.StreamEvents)))
 
 	// SSH Identities (enrolled keys) for the identity-based SSH access mode. A
 	// member manages their own; owner/admin can view/revoke every member's.
@@ -252,6 +313,13 @@ func NewRouter(service services.Service, opts ...Option) *echo.Echo {
 	publicAPI.PATCH(UpdateSSHIdentityURL, gateway.Handler(handler.UpdateSSHIdentity), routesmiddleware.RequiresPermission(authorizer.SSHIdentityAdd))
 	publicAPI.DELETE(DeleteSSHIdentityURL, gateway.Handler(handler.DeleteSSHIdentity))
 
+	// SSH user certificate authorities trusted by the identity-based SSH access
+	// mode. Managed by owner/admin.
+	publicAPI.GET(ListSSHUserCAsURL, gateway.Handler(handler.ListSSHUserCAs), routesmiddleware.RequiresPermission(authorizer.SSHUserCAManage))
+	publicAPI.POST(CreateSSHUserCAURL, gateway.Handler(handler.CreateSSHUserCA), routesmiddleware.RequiresPermission(authorizer.SSHUserCAManage))
+	publicAPI.PUT(UpdateSSHUserCAURL, gateway.Handler(handler.UpdateSSHUserCA), routesmiddleware.RequiresPermission(authorizer.SSHUserCAManage))
+	publicAPI.DELETE(DeleteSSHUserCAURL, gateway.Handler(handler.DeleteSSHUserCA), routesmiddleware.RequiresPermission(authorizer.SSHUserCAManage))
+
 	// Web terminal re-auth step-up: the browser submits its factor here when a
 	// policy's require_reauth window has lapsed. Any authenticated member.
 	publicAPI.POST(WebReauthURL, gateway.Handler(handler.WebReauthVerify))
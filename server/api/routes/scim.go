@@ -0,0 +1,243 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/server/api/pkg/echo/handlers/pkg/converter"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/server/api/pkg/scim"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	"github.com/shellhub-io/shellhub/server/api/store"
+)
+
+const (
+	ParamSCIMResourceID = "id"
+)
+
+const (
+	SCIMServiceProviderConfigURL = "/scim/v2/ServiceProviderConfig"
+	SCIMUsersURL                 = "/scim/v2/Users"
+	SCIMUserURL                  = "/scim/v2/Users/:" + ParamSCIMResourceID
+	SCIMGroupsURL                = "/scim/v2/Groups"
+	SCIMGroupURL                 = "/scim/v2/Groups/:" + ParamSCIMResourceID
+)
+
+// SCIM wraps a SCIM endpoint. The identity provider authenticates with the bearer token it was
+// configured with rather than with a user's credential, and errors are answered with a SCIM error
+// body, so the provider learns what it got wrong. An internal error is left to the API's error
+// handler, which doesn't expose it.
+func (h *Handler) SCIM(next func(c *gateway.Context) error) func(c *gateway.Context) error {
+	return func(c *gateway.Context) error {
+		token, _ := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+		if err := h.service.AuthSCIM(c.Ctx(), token); err != nil {
+			return scimError(c, err)
+		}
+
+		if err := next(c); err != nil {
+			return scimError(c, err)
+		}
+
+		return nil
+	}
+}
+
+func scimError(c *gateway.Context, err error) error {
+	var e errors.Error
+	if errors.Is(err, store.ErrInternal) || !errors.As(err, &e) || e.Layer != services.ErrLayer {
+		return err
+	}
+
+	status := converter.FromErrServiceToHTTPStatus(e.Code)
+	if status == http.StatusInternalServerError {
+		return err
+	}
+
+	body := scim.NewError("", "%s", e.Message)
+
+	var scimErr *scim.Error
+	if errors.As(err, &scimErr) {
+		body.ScimType, body.Detail = scimErr.ScimType, scimErr.Detail
+	}
+
+	if status == http.StatusConflict && body.ScimType == "" {
+		body.ScimType = scim.ErrorTypeUniqueness
+	}
+
+	body.Status = strconv.Itoa(status)
+
+	return scimJSON(c, status, body)
+}
+
+// scimJSON answers with the SCIM media type, which c.JSON would replace.
+func scimJSON(c *gateway.Context, status int, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(status, scim.ContentType, data)
+}
+
+// bindSCIM decodes a SCIM request body. Echo's binder only decodes application/json, while
+// providers send application/scim+json.
+func bindSCIM(c *gateway.Context, v any) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return services.NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidSyntax, "the request body is not valid JSON"))
+	}
+
+	return nil
+}
+
+func (h *Handler) GetSCIMServiceProviderConfig(c *gateway.Context) error {
+	return scimJSON(c, http.StatusOK, scim.NewServiceProviderConfig())
+}
+
+func (h *Handler) ListSCIMUsers(c *gateway.Context) error {
+	req := new(scim.ListRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.ListSCIMUsers(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusOK, res)
+}
+
+func (h *Handler) GetSCIMUser(c *gateway.Context) error {
+	user, err := h.service.GetSCIMUser(c.Ctx(), c.Param(ParamSCIMResourceID))
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusOK, user)
+}
+
+func (h *Handler) CreateSCIMUser(c *gateway.Context) error {
+	req := new(scim.User)
+	if err := bindSCIM(c, req); err != nil {
+		return err
+	}
+
+	user, err := h.service.CreateSCIMUser(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusCreated, user)
+}
+
+func (h *Handler) ReplaceSCIMUser(c *gateway.Context) error {
+	req := new(scim.User)
+	if err := bindSCIM(c, req); err != nil {
+		return err
+	}
+
+	user, err := h.service.ReplaceSCIMUser(c.Ctx(), c.Param(ParamSCIMResourceID), req)
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusOK, user)
+}
+
+func (h *Handler) PatchSCIMUser(c *gateway.Context) error {
+	req := new(scim.PatchRequest)
+	if err := bindSCIM(c, req); err != nil {
+		return err
+	}
+
+	user, err := h.service.PatchSCIMUser(c.Ctx(), c.Param(ParamSCIMResourceID), req)
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusOK, user)
+}
+
+func (h *Handler) DeleteSCIMUser(c *gateway.Context) error {
+	if err := h.service.DeleteSCIMUser(c.Ctx(), c.Param(ParamSCIMResourceID)); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handler) ListSCIMGroups(c *gateway.Context) error {
+	req := new(scim.ListRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.ListSCIMGroups(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusOK, res)
+}
+
+func (h *Handler) GetSCIMGroup(c *gateway.Context) error {
+	group, err := h.service.GetSCIMGroup(c.Ctx(), c.Param(ParamSCIMResourceID))
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusOK, group)
+}
+
+func (h *Handler) CreateSCIMGroup(c *gateway.Context) error {
+	req := new(scim.Group)
+	if err := bindSCIM(c, req); err != nil {
+		return err
+	}
+
+	group, err := h.service.CreateSCIMGroup(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusCreated, group)
+}
+
+func (h *Handler) ReplaceSCIMGroup(c *gateway.Context) error {
+	req := new(scim.Group)
+	if err := bindSCIM(c, req); err != nil {
+		return err
+	}
+
+	group, err := h.service.ReplaceSCIMGroup(c.Ctx(), c.Param(ParamSCIMResourceID), req)
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusOK, group)
+}
+
+func (h *Handler) PatchSCIMGroup(c *gateway.Context) error {
+	req := new(scim.PatchRequest)
+	if err := bindSCIM(c, req); err != nil {
+		return err
+	}
+
+	group, err := h.service.PatchSCIMGroup(c.Ctx(), c.Param(ParamSCIMResourceID), req)
+	if err != nil {
+		return err
+	}
+
+	return scimJSON(c, http.StatusOK, group)
+}
+
+func (h *Handler) DeleteSCIMGroup(c *gateway.Context) error {
+	if err := h.service.DeleteSCIMGroup(c.Ctx(), c.Param(ParamSCIMResourceID)); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,181 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/server/api/pkg/scim"
+	svc "github.com/shellhub-io/shellhub/server/api/services"
+	"github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCIM(t *testing.T) {
+	mock := mocks.NewMockService(t)
+
+	active := true
+
+	cases := []struct {
+		title          string
+		method         string
+		path           string
+		token          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+		expectedError  *scim.Error
+	}{
+		{
+			title:  "fails when the token is refused",
+			method: http.MethodGet,
+			path:   "/api/scim/v2/Users",
+			token:  "wrong",
+			requiredMocks: func() {
+				mock.On("AuthSCIM", gomock.Anything, "wrong").Return(svc.NewErrAuthUnathorized(nil)).Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  &scim.Error{Schemas: []string{scim.SchemaError}, Detail: "auth unauthorized", Status: "401"},
+		},
+		{
+			title:  "fails when SCIM is not configured",
+			method: http.MethodGet,
+			path:   "/api/scim/v2/ServiceProviderConfig",
+			token:  "token",
+			requiredMocks: func() {
+				mock.On("AuthSCIM", gomock.Anything, "token").Return(svc.NewErrAuthMethodNotAllowed("scim")).Once()
+			},
+			expectedStatus: http.StatusNotImplemented,
+			expectedError:  &scim.Error{Schemas: []string{scim.SchemaError}, Detail: "auth method not allowed", Status: "501"},
+		},
+		{
+			title:  "succeeds listing users with a filter",
+			method: http.MethodGet,
+			path:   "/api/scim/v2/Users?filter=userName+eq+%22jane%22&startIndex=1&count=10",
+			token:  "token",
+			requiredMocks: func() {
+				mock.On("AuthSCIM", gomock.Anything, "token").Return(nil).Once()
+				mock.
+					On("ListSCIMUsers", gomock.Anything, &scim.ListRequest{Filter: `userName eq "jane"`, StartIndex: 1, Count: 10}).
+					Return(scim.NewListResponse([]scim.User{}, 0, 1), nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:  "fails when the body is not JSON",
+			method: http.MethodPost,
+			path:   "/api/scim/v2/Users",
+			token:  "token",
+			body:   "{",
+			requiredMocks: func() {
+				mock.On("AuthSCIM", gomock.Anything, "token").Return(nil).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError: &scim.Error{
+				Schemas:  []string{scim.SchemaError},
+				ScimType: scim.ErrorTypeInvalidSyntax,
+				Detail:   "the request body is not valid JSON",
+				Status:   "400",
+			},
+		},
+		{
+			title:  "fails with uniqueness when the user exists",
+			method: http.MethodPost,
+			path:   "/api/scim/v2/Users",
+			token:  "token",
+			body:   `{"userName":"jane@example.com"}`,
+			requiredMocks: func() {
+				mock.On("AuthSCIM", gomock.Anything, "token").Return(nil).Once()
+				mock.
+					On("CreateSCIMUser", gomock.Anything, &scim.User{UserName: "jane@example.com"}).
+					Return(nil, svc.NewErrUserDuplicated([]string{"email"}, store.ErrDuplicate)).
+					Once()
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError: &scim.Error{
+				Schemas:  []string{scim.SchemaError},
+				ScimType: scim.ErrorTypeUniqueness,
+				Detail:   "user duplicated",
+				Status:   "409",
+			},
+		},
+		{
+			title:  "succeeds creating a user",
+			method: http.MethodPost,
+			path:   "/api/scim/v2/Users",
+			token:  "token",
+			body:   `{"userName":"jane@example.com"}`,
+			requiredMocks: func() {
+				mock.On("AuthSCIM", gomock.Anything, "token").Return(nil).Once()
+				mock.
+					On("CreateSCIMUser", gomock.Anything, &scim.User{UserName: "jane@example.com"}).
+					Return(&scim.User{Schemas: []string{scim.SchemaUser}, ID: "id", UserName: "jane", Active: &active}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			title:  "fails with the type of a refused patch",
+			method: http.MethodPatch,
+			path:   "/api/scim/v2/Groups/id",
+			token:  "token",
+			body:   `{"Operations":[{"op":"move"}]}`,
+			requiredMocks: func() {
+				mock.On("AuthSCIM", gomock.Anything, "token").Return(nil).Once()
+				mock.
+					On("PatchSCIMGroup", gomock.Anything, "id", &scim.PatchRequest{Operations: []scim.PatchOperation{{Op: "move"}}}).
+					Return(nil, svc.NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidSyntax, `unsupported operation "move"`))).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError: &scim.Error{
+				Schemas:  []string{scim.SchemaError},
+				ScimType: scim.ErrorTypeInvalidSyntax,
+				Detail:   `unsupported operation "move"`,
+				Status:   "400",
+			},
+		},
+		{
+			title:  "succeeds deleting a group",
+			method: http.MethodDelete,
+			path:   "/api/scim/v2/Groups/id",
+			token:  "token",
+			requiredMocks: func() {
+				mock.On("AuthSCIM", gomock.Anything, "token").Return(nil).Once()
+				mock.On("DeleteSCIMGroup", gomock.Anything, "id").Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", scim.ContentType)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+			if tc.expectedStatus != http.StatusNoContent {
+				assert.Equal(t, scim.ContentType, rec.Result().Header.Get("Content-Type"))
+			}
+
+			if tc.expectedError != nil {
+				body := new(scim.Error)
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(body))
+				assert.Equal(t, tc.expectedError, body)
+			}
+		})
+	}
+}
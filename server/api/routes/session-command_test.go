@@ -0,0 +1,102 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	servicemock "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSessionCommands(t *testing.T) {
+	cases := []struct {
+		description    string
+		role           authorizer.Role
+		url            string
+		requiredMocks  func(svcMock *servicemock.MockService)
+		expectedStatus int
+		expectedCount  string
+	}{
+		{
+			description:    "forbids a member who cannot play sessions to list the commands",
+			role:           authorizer.RoleObserver,
+			url:            "/api/sessions/commands",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "fails on a malformed account",
+			role:           authorizer.RoleAdministrator,
+			url:            "/api/sessions/commands?user_id=root",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description: "lists the commands of a device user",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/sessions/commands?username=root&page=2&per_page=5",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ListSessionCommands", mock.Anything, mock.Anything, &requests.SessionCommandsList{
+					Username:  "root",
+					Paginator: query.Paginator{Page: 2, PerPage: 5},
+				}).Return([]models.SessionCommand{{SessionUID: "uid"}}, 6, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  "6",
+		},
+		{
+			description:    "forbids a member who cannot play sessions to get a session's commands",
+			role:           authorizer.RoleObserver,
+			url:            "/api/sessions/uid/commands",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "fails when the session is not found",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/sessions/uid/commands",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("GetSessionCommands", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, 0, services.NewErrSessionNotFound(models.UID("uid"), nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description: "lists a session's commands",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/sessions/uid/commands",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("GetSessionCommands", mock.Anything, mock.Anything, mock.MatchedBy(func(req *requests.SessionCommandsGet) bool {
+					return req.UID == "uid"
+				})).Return([]models.SessionCommand{{SessionUID: "uid"}, {SessionUID: "uid"}}, 2, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  "2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			tc.requiredMocks(svcMock)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+			assert.Equal(t, tc.expectedCount, rec.Result().Header.Get("X-Total-Count"))
+		})
+	}
+}
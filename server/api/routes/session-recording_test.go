@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	servicemock "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportSessionRecording(t *testing.T) {
+	writes := func(body string) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			io.WriteString(args.Get(3).(io.Writer), body) //nolint:errcheck
+		}
+	}
+
+	cases := []struct {
+		description         string
+		role                authorizer.Role
+		url                 string
+		requiredMocks       func(svcMock *servicemock.MockService)
+		expectedStatus      int
+		expectedType        string
+		expectedDisposition string
+		expectedBody        string
+	}{
+		{
+			description:    "forbids a member who cannot play sessions",
+			role:           authorizer.RoleObserver,
+			url:            "/api/sessions/uid/recording",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "fails on an unknown format",
+			role:           authorizer.RoleAdministrator,
+			url:            "/api/sessions/uid/recording?format=mp4",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "fails on a tail of no lines",
+			role:           authorizer.RoleAdministrator,
+			url:            "/api/sessions/uid/recording?tail=-1",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the session is not found",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/sessions/uid/recording",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ExportSessionRecording", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(services.NewErrSessionNotFound(models.UID("uid"), nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description: "downloads the seat's cast",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/sessions/uid/recording?seat=1",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ExportSessionRecording", mock.Anything, mock.Anything, &requests.SessionRecordingExport{SessionIDParam: requests.SessionIDParam{UID: "uid"}, Seat: 1}, mock.Anything).
+					Run(writes("{\"version\":2}\n")).
+					Return(nil).Once()
+			},
+			expectedStatus:      http.StatusOK,
+			expectedType:        "application/x-asciicast",
+			expectedDisposition: `attachment; filename="uid-1.cast"`,
+			expectedBody:        "{\"version\":2}\n",
+		},
+		{
+			description: "downloads the transcript",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/sessions/uid/recording?format=text",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ExportSessionRecording", mock.Anything, mock.Anything, &requests.SessionRecordingExport{SessionIDParam: requests.SessionIDParam{UID: "uid"}, Format: "text"}, mock.Anything).
+					Run(writes("$ ls\n")).
+					Return(nil).Once()
+			},
+			expectedStatus:      http.StatusOK,
+			expectedType:        "text/plain; charset=utf-8",
+			expectedDisposition: `attachment; filename="uid-0.txt"`,
+			expectedBody:        "$ ls\n",
+		},
+		{
+			description: "downloads the transcript's tail",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/sessions/uid/recording?format=text&tail=20",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ExportSessionRecording", mock.Anything, mock.Anything, &requests.SessionRecordingExport{SessionIDParam: requests.SessionIDParam{UID: "uid"}, Format: "text", Tail: 20}, mock.Anything).
+					Run(writes("$ ls\n")).
+					Return(nil).Once()
+			},
+			expectedStatus:      http.StatusOK,
+			expectedType:        "text/plain; charset=utf-8",
+			expectedDisposition: `attachment; filename="uid-0.txt"`,
+			expectedBody:        "$ ls\n",
+		},
+		{
+			description: "answers an empty transcript",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/sessions/uid/recording?format=text",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ExportSessionRecording", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+			},
+			expectedStatus:      http.StatusOK,
+			expectedType:        "text/plain; charset=utf-8",
+			expectedDisposition: `attachment; filename="uid-0.txt"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			tc.requiredMocks(svcMock)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedType, rec.Result().Header.Get("Content-Type"))
+				assert.Equal(t, tc.expectedDisposition, rec.Result().Header.Get("Content-Disposition"))
+				assert.Equal(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
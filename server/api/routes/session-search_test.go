@@ -0,0 +1,82 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	servicemock "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchSessions(t *testing.T) {
+	cases := []struct {
+		description    string
+		role           authorizer.Role
+		url            string
+		requiredMocks  func(svcMock *servicemock.MockService)
+		expectedStatus int
+		expectedCount  string
+	}{
+		{
+			description:    "forbids a member who cannot play sessions",
+			role:           authorizer.RoleObserver,
+			url:            "/api/sessions/search?q=rm",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "fails without a phrase",
+			role:           authorizer.RoleAdministrator,
+			url:            "/api/sessions/search",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "fails on a malformed time",
+			role:           authorizer.RoleAdministrator,
+			url:            "/api/sessions/search?q=rm&since=yesterday",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "searches the namespace's sessions",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/sessions/search?q=rm+-rf+%2Fvar%2Flib%2Fpostgres&since=2026-09-01T00:00:00Z&until=2026-10-01T00:00:00Z&page=2&per_page=5",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("SearchSessions", mock.Anything, mock.Anything, &requests.SessionSearch{
+					Query:     "rm -rf /var/lib/postgres",
+					Since:     time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+					Until:     time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+					Paginator: query.Paginator{Page: 2, PerPage: 5},
+				}).Return([]models.SessionSearchHit{{SessionUID: "uid"}}, 6, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  "6",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			tc.requiredMocks(svcMock)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+			assert.Equal(t, tc.expectedCount, rec.Result().Header.Get("X-Total-Count"))
+		})
+	}
+}
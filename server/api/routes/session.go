@@ -1,12 +1,13 @@
 package routes
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
-	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/recording"
 	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/server/api/services"
 	log "github.com/sirupsen/logrus"
@@ -15,6 +16,11 @@ import (
 const (
 	GetSessionsURL = "/sessions"
 	GetSessionURL  = "/sessions/:uid"
+
+	ExportSessionRecordingURL = "/sessions/:uid/recording"
+	SearchSessionsURL         = "/sessions/search"
+	ListSessionCommandsURL    = "/sessions/commands"
+	GetSessionCommandsURL     = "/sessions/:uid/commands"
 )
 
 const (
@@ -50,6 +56,8 @@ func (h *Handler) GetSessionList(c *gateway.Context) error {
 		return err
 	}
 
+	req.ScopeTags = c.DeviceTags()
+
 	sessions, count, err := h.service.ListSessions(c.Ctx(), sc, req)
 	if err != nil {
 		return err
@@ -75,10 +83,177 @@ func (h *Handler) GetSession(c *gateway.Context) error {
 		return err
 	}
 
-	session, err := h.service.GetSession(c.Ctx(), sc, models.UID(req.UID))
+	req.ScopeTags = c.DeviceTags()
+
+	session, err := h.service.GetSession(c.Ctx(), sc, &req)
 	if err != nil {
 		return err
 	}
 
 	return c.JSON(http.StatusOK, session)
 }
+
+// SearchSessions finds the recorded output and exec commands matching a phrase.
+func (h *Handler) SearchSessions(c *gateway.Context) error {
+	req := new(requests.SessionSearch)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	sc, err := c.Scope()
+	if err != nil {
+		return err
+	}
+
+	req.ScopeTags = c.DeviceTags()
+
+	hits, count, err := h.service.SearchSessions(c.Ctx(), sc, req)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, hits)
+}
+
+// ListSessionCommands lists the commands run on the namespace's sessions, optionally only those
+// of a device user or a ShellHub account.
+func (h *Handler) ListSessionCommands(c *gateway.Context) error {
+	req := new(requests.SessionCommandsList)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	sc, err := c.Scope()
+	if err != nil {
+		return err
+	}
+
+	req.ScopeTags = c.DeviceTags()
+
+	commands, count, err := h.service.ListSessionCommands(c.Ctx(), sc, req)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, commands)
+}
+
+// GetSessionCommands lists a session's commands in the order they ran.
+func (h *Handler) GetSessionCommands(c *gateway.Context) error {
+	req := new(requests.SessionCommandsGet)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	sc, err := c.Scope()
+	if err != nil {
+		return err
+	}
+
+	req.ScopeTags = c.DeviceTags()
+
+	commands, count, err := h.service.GetSessionCommands(c.Ctx(), sc, req)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, commands)
+}
+
+// ExportSessionRecording streams a seat's recording as an asciinema v2 cast or a plain-text
+// transcript, as a download named after the session.
+func (h *Handler) ExportSessionRecording(c *gateway.Context) error {
+	req := new(requests.SessionRecordingExport)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	sc, err := c.Scope()
+	if err != nil {
+		return err
+	}
+
+	req.ScopeTags = c.DeviceTags()
+
+	contentType, extension := "application/x-asciicast", "cast"
+	if recording.Format(req.Format) == recording.FormatText {
+		contentType, extension = "text/plain; charset=utf-8", "txt"
+	}
+
+	res := &recordingResponse{
+		ResponseWriter: c.Response(),
+		contentType:    contentType,
+		filename:       fmt.Sprintf("%s-%d.%s", req.UID, req.Seat, extension),
+	}
+
+	if err := h.service.ExportSessionRecording(c.Ctx(), sc, req, res); err != nil {
+		// Past the headers the status is sent, and all that is left is to cut the download short.
+		if res.started {
+			log.WithError(err).WithField("session", req.UID).Warn("session recording export failed midway")
+
+			return nil
+		}
+
+		return err
+	}
+
+	// A recording with nothing to render writes nothing, and still answers.
+	res.start()
+
+	return nil
+}
+
+// recordingResponse sends the download's headers with its first write, so an export that fails
+// before writing anything is still answered as the error it is.
+type recordingResponse struct {
+	http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (r *recordingResponse) start() {
+	if r.started {
+		return
+	}
+
+	r.started = true
+
+	r.Header().Set("Content-Type", r.contentType)
+	r.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", r.filename))
+	r.WriteHeader(http.StatusOK)
+}
+
+func (r *recordingResponse) Write(b []byte) (int, error) {
+	r.start()
+
+	return r.ResponseWriter.Write(b)
+}
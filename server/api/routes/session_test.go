@@ -214,6 +214,7 @@ func TestGetSession(t *testing.T) {
 		uid           string
 		tenant        string
 		admin         bool
+		tags          string
 		requiredMocks func(session *models.Session)
 		expected      Expected
 	}{
@@ -243,7 +244,7 @@ func TestGetSession(t *testing.T) {
 			tenant: "00000000-0000-4000-0000-000000000000",
 			admin:  true,
 			requiredMocks: func(session *models.Session) {
-				mock.On("GetSession", gomock.Anything, scope.MustBounded("00000000-0000-4000-0000-000000000000"), models.UID("123")).Return(session, nil)
+				mock.On("GetSession", gomock.Anything, scope.MustBounded("00000000-0000-4000-0000-000000000000"), &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "123"}}).Return(session, nil)
 			},
 			expected: Expected{
 				expectedSession: &models.Session{UID: "123"},
@@ -255,7 +256,7 @@ func TestGetSession(t *testing.T) {
 			uid:    "1234",
 			tenant: "00000000-0000-4000-0000-000000000000",
 			requiredMocks: func(*models.Session) {
-				mock.On("GetSession", gomock.Anything, scope.MustBounded("00000000-0000-4000-0000-000000000000"), models.UID("1234")).Return(nil, svc.NewErrSessionNotFound(models.UID("1234"), store.ErrNoDocuments))
+				mock.On("GetSession", gomock.Anything, scope.MustBounded("00000000-0000-4000-0000-000000000000"), &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "1234"}}).Return(nil, svc.NewErrSessionNotFound(models.UID("1234"), store.ErrNoDocuments))
 			},
 			expected: Expected{
 				expectedSession: nil,
@@ -267,13 +268,26 @@ func TestGetSession(t *testing.T) {
 			uid:    "123",
 			tenant: "00000000-0000-4000-0000-000000000000",
 			requiredMocks: func(session *models.Session) {
-				mock.On("GetSession", gomock.Anything, scope.MustBounded("00000000-0000-4000-0000-000000000000"), models.UID("123")).Return(session, nil)
+				mock.On("GetSession", gomock.Anything, scope.MustBounded("00000000-0000-4000-0000-000000000000"), &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "123"}}).Return(session, nil)
 			},
 			expected: Expected{
 				expectedSession: &models.Session{UID: "123"},
 				expectedStatus:  http.StatusOK,
 			},
 		},
+		{
+			title:  "scopes the session to an API key's device tags",
+			uid:    "123",
+			tenant: "00000000-0000-4000-0000-000000000000",
+			tags:   "production,staging",
+			requiredMocks: func(*models.Session) {
+				mock.On("GetSession", gomock.Anything, scope.MustBounded("00000000-0000-4000-0000-000000000000"), &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "123"}, ScopeTags: []string{"production", "staging"}}).Return(nil, svc.NewErrSessionNotFound(models.UID("123"), store.ErrNoDocuments))
+			},
+			expected: Expected{
+				expectedSession: nil,
+				expectedStatus:  http.StatusNotFound,
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -289,6 +303,9 @@ func TestGetSession(t *testing.T) {
 			if tc.admin {
 				req.Header.Set("X-Admin", "true")
 			}
+			if tc.tags != "" {
+				req.Header.Set("X-API-Key-Tags", tc.tags)
+			}
 			rec := httptest.NewRecorder()
 
 			e := NewRouter(mock)
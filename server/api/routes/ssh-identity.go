@@ -37,7 +37,7 @@ func (h *Handler) ListSSHIdentities(c *gateway.Context) error {
 
 	// The namespace-wide view is restricted to owners/admins; a member without it
 	// only ever sees their own keys.
-	if req.All && !c.Role().HasPermission(authorizer.SSHIdentityManage) {
+	if req.All && !c.HasPermission(authorizer.SSHIdentityManage) {
 		req.All = false
 	}
 
@@ -126,8 +126,8 @@ func (h *Handler) DeleteSSHIdentity(c *gateway.Context) error {
 		return c.NoContent(http.StatusUnauthorized)
 	}
 
-	manage := c.Role().HasPermission(authorizer.SSHIdentityManage)
-	if !manage && !c.Role().HasPermission(authorizer.SSHIdentityAdd) {
+	manage := c.HasPermission(authorizer.SSHIdentityManage)
+	if !manage && !c.HasPermission(authorizer.SSHIdentityAdd) {
 		return c.NoContent(http.StatusForbidden)
 	}
 
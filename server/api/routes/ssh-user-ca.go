@@ -0,0 +1,102 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+)
+
+const (
+	ListSSHUserCAsURL  = "/ssh-user-cas"
+	CreateSSHUserCAURL = "/ssh-user-cas"
+	UpdateSSHUserCAURL = "/ssh-user-cas/:id"
+	DeleteSSHUserCAURL = "/ssh-user-cas/:id"
+)
+
+// ListSSHUserCAs returns the user certificate authorities the current namespace
+// trusts.
+func (h *Handler) ListSSHUserCAs(c *gateway.Context) error {
+	var req requests.SSHUserCAList
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	list, err := h.service.ListSSHUserCAs(c.Ctx(), &req)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(len(list)))
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// CreateSSHUserCA trusts a pasted OpenSSH CA public key in the current namespace.
+func (h *Handler) CreateSSHUserCA(c *gateway.Context) error {
+	var req requests.SSHUserCACreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	ca, err := h.service.CreateSSHUserCA(c.Ctx(), &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ca)
+}
+
+// UpdateSSHUserCA renames a trusted CA and replaces its revoked serials.
+func (h *Handler) UpdateSSHUserCA(c *gateway.Context) error {
+	var req requests.SSHUserCAUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	ca, err := h.service.UpdateSSHUserCA(c.Ctx(), &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ca)
+}
+
+// DeleteSSHUserCA stops trusting a CA in the current namespace.
+func (h *Handler) DeleteSSHUserCA(c *gateway.Context) error {
+	var req requests.SSHUserCADelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	if err := h.service.DeleteSSHUserCA(c.Ctx(), &req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,216 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ListWebhooksURL          = "/webhooks"
+	CreateWebhookURL         = "/webhooks"
+	GetWebhookURL            = "/webhooks/:id"
+	UpdateWebhookURL         = "/webhooks/:id"
+	DeleteWebhookURL         = "/webhooks/:id"
+	ListWebhookDeliveriesURL = "/webhooks/:id/deliveries"
+	ReplayWebhookDeliveryURL = "/webhooks/:id/deliveries/:delivery/replay"
+)
+
+func (h *Handler) ListWebhooks(c *gateway.Context) error {
+	req := new(requests.WebhookList)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := req.Unmarshal(); err != nil {
+		log.WithError(err).WithField("filter", req.Filters.Raw).Warn("failed to decode webhooks list filter")
+
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err := query.ValidateFilters(&req.Filters, services.WebhookFilterFields); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	req.Paginator.Normalize()
+	req.Sorter.Normalize()
+
+	if err := query.ValidateSorter(&req.Sorter, services.WebhookSortFields); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	webhooks, count, err := h.service.ListWebhooks(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+func (h *Handler) CreateWebhook(c *gateway.Context) error {
+	req := new(requests.WebhookCreate)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	webhook, err := h.service.CreateWebhook(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) GetWebhook(c *gateway.Context) error {
+	req := new(requests.WebhookGet)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	webhook, err := h.service.GetWebhook(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) UpdateWebhook(c *gateway.Context) error {
+	req := new(requests.WebhookUpdate)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	webhook, err := h.service.UpdateWebhook(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) DeleteWebhook(c *gateway.Context) error {
+	req := new(requests.WebhookDelete)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	if err := h.service.DeleteWebhook(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ListWebhookDeliveries returns a page of a webhook's delivery log.
+func (h *Handler) ListWebhookDeliveries(c *gateway.Context) error {
+	req := new(requests.WebhookDeliveryList)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := req.Unmarshal(); err != nil {
+		log.WithError(err).WithField("filter", req.Filters.Raw).Warn("failed to decode webhook deliveries list filter")
+
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if err := query.ValidateFilters(&req.Filters, services.WebhookDeliveryFilterFields); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	req.Paginator.Normalize()
+	req.Sorter.Normalize()
+
+	if err := query.ValidateSorter(&req.Sorter, services.WebhookDeliverySortFields); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	deliveries, count, err := h.service.ListWebhookDeliveries(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// ReplayWebhookDelivery sends a logged delivery's event to its webhook again,
+// returning the new delivery.
+func (h *Handler) ReplayWebhookDelivery(c *gateway.Context) error {
+	req := new(requests.WebhookDeliveryReplay)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	delivery, err := h.service.ReplayWebhookDelivery(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, delivery)
+}
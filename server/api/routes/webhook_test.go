@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	servicemock "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListWebhooks(t *testing.T) {
+	cases := []struct {
+		description    string
+		role           authorizer.Role
+		url            string
+		requiredMocks  func(svcMock *servicemock.MockService)
+		expectedStatus int
+		expectedCount  string
+	}{
+		{
+			description:    "forbids a member who is not an owner or administrator",
+			role:           authorizer.RoleOperator,
+			url:            "/api/webhooks",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "lists the namespace's webhooks",
+			role:        authorizer.RoleAdministrator,
+			url:         "/api/webhooks?page=1&per_page=10",
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ListWebhooks", mock.Anything, mock.MatchedBy(func(req *requests.WebhookList) bool {
+					return req.TenantID == "00000000-0000-4000-0000-000000000000" && req.Page == 1 && req.PerPage == 10
+				})).Return([]models.Webhook{{ID: "webhook-id"}}, 1, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  "1",
+		},
+		{
+			description:    "fails when sorting by an unknown field",
+			role:           authorizer.RoleOwner,
+			url:            "/api/webhooks?sort_by=secret",
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			tc.requiredMocks(svcMock)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+			assert.Equal(t, tc.expectedCount, rec.Result().Header.Get("X-Total-Count"))
+		})
+	}
+}
+
+func TestReplayWebhookDelivery(t *testing.T) {
+	cases := []struct {
+		description    string
+		role           authorizer.Role
+		requiredMocks  func(svcMock *servicemock.MockService)
+		expectedStatus int
+	}{
+		{
+			description:    "forbids a member who is not an owner or administrator",
+			role:           authorizer.RoleOperator,
+			requiredMocks:  func(_ *servicemock.MockService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "replays the delivery",
+			role:        authorizer.RoleOwner,
+			requiredMocks: func(svcMock *servicemock.MockService) {
+				svcMock.On("ReplayWebhookDelivery", mock.Anything, &requests.WebhookDeliveryReplay{
+					WebhookIDParam: requests.WebhookIDParam{ID: "webhook-id"},
+					DeliveryID:     "delivery-id",
+					TenantID:       "00000000-0000-4000-0000-000000000000",
+				}).Return(&models.WebhookDelivery{ID: "replay-id", ReplayOf: "delivery-id"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			svcMock := servicemock.NewMockService(t)
+			tc.requiredMocks(svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/webhooks/webhook-id/deliveries/delivery-id/replay", nil)
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+
+			rec := httptest.NewRecorder()
+			NewRouter(svcMock).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+}
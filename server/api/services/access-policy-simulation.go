@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+)
+
+func (s *service) SimulateAccess(ctx context.Context, req *requests.AccessPolicySimulate) ([]models.AccessSimulation, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := s.store.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, req.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	member, ok := namespace.FindMember(req.PrincipalID)
+	if !ok {
+		return nil, NewErrNamespaceMemberNotFound(req.PrincipalID, nil)
+	}
+
+	var devices []models.Device
+	if req.DeviceUID != "" {
+		dev, err := s.store.DeviceResolve(ctx, sc, store.DeviceUIDResolver, req.DeviceUID)
+		if err != nil {
+			return nil, NewErrDeviceNotFound(models.UID(req.DeviceUID), err)
+		}
+
+		devices = []models.Device{*dev}
+	} else {
+		filter, err := s.resolveAccessPolicyFilter(ctx, sc, *req.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if devices, err = s.filterAcceptedDevices(ctx, req.TenantID, filter); err != nil {
+			return nil, err
+		}
+	}
+
+	policies, _, err := s.store.AccessPolicyList(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	at := clock.Now()
+	if req.At != nil {
+		at = *req.At
+	}
+
+	simulations := make([]models.AccessSimulation, 0, len(devices))
+	for i := range devices {
+		decision, evaluations := evaluateAccessPolicies(policies, &devices[i], req.PrincipalID, member, req.Login, req.SourceIP, at)
+
+		simulations = append(simulations, models.AccessSimulation{
+			DeviceUID:  devices[i].UID,
+			DeviceName: devices[i].Name,
+			Decision:   *decision,
+			Policies:   evaluations,
+		})
+	}
+
+	return simulations, nil
+}
+
+// filterAcceptedDevices returns the namespace's accepted devices the filter
+// selects. The filter is the caller's input rather than a stored policy, so one
+// that cannot be evaluated is an error instead of a non-match.
+func (s *service) filterAcceptedDevices(ctx context.Context, tenantID string, filter models.PublicKeyFilter) ([]models.Device, error) {
+	sc, err := BoundTo(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, _, err := s.store.DeviceList(ctx, sc, store.DeviceAcceptableAsFalse, s.store.Options().WithDeviceStatus(models.DeviceStatusAccepted))
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]models.Device, 0, len(devices))
+	for _, dev := range devices {
+		matched, err := filter.Matches(&dev)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			selected = append(selected, dev)
+		}
+	}
+
+	return selected, nil
+}
+
+func (s *service) ListDeviceAccess(ctx context.Context, req *requests.AccessPolicyReach) ([]models.AccessGrant, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	dev, err := s.store.DeviceResolve(ctx, sc, store.DeviceUIDResolver, req.DeviceUID)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.DeviceUID), err)
+	}
+
+	namespace, err := s.store.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, req.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	policies, _, err := s.store.AccessPolicyList(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, _, err := s.store.ServiceAccountList(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountNames := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		accountNames[account.ID] = account.Name
+	}
+
+	now := clock.Now()
+
+	grants := make([]models.AccessGrant, 0)
+	for i := range namespace.Members {
+		member := &namespace.Members[i]
+
+		name := member.Email
+		if member.Type == models.UserTypeService {
+			name = accountNames[member.ID]
+		}
+
+		for _, grant := range memberDeviceGrants(policies, dev, member, now) {
+			grant.PrincipalName = name
+			grants = append(grants, grant)
+		}
+	}
+
+	return grants, nil
+}
+
+// memberDeviceGrants lists the logins the policies let the member use on dev at
+// now, reusing Authorize's matchers. The client address is unknown here, so
+// source CIDRs are reported on the grant instead of matched: an allow limited to
+// some addresses grants from those, and a deny limited to some addresses carves
+// them out. A deny that cannot be evaluated fails closed and leaves the member
+// with nothing, as it would at login.
+func memberDeviceGrants(policies []models.AccessPolicy, dev *models.Device, member *models.Member, now time.Time) []models.AccessGrant {
+	var allows, denies []models.AccessPolicy
+
+	for _, policy := range policies {
+		if !subjectMatches(policy.Subject, member.ID, member.Role, member.Type) {
+			continue
+		}
+
+		applies, err := policyAppliesToDeviceAt(policy, dev, now)
+		if err != nil && policy.Action == models.PolicyActionDeny {
+			return nil
+		}
+
+		if err != nil || !applies {
+			continue
+		}
+
+		if policy.Action == models.PolicyActionDeny {
+			denies = append(denies, policy)
+		} else {
+			allows = append(allows, policy)
+		}
+	}
+
+	// The logins worth asking about are the ones some policy names, plus the
+	// wildcard when an allow grants it; any other login is granted exactly as "*".
+	explicit := make([]string, 0)
+	wildcard := false
+
+	for _, policy := range slices.Concat(allows, denies) {
+		for _, login := range policy.Logins {
+			switch {
+			case login == "*":
+				wildcard = wildcard || policy.Action != models.PolicyActionDeny
+			case !slices.Contains(explicit, login):
+				explicit = append(explicit, login)
+			}
+		}
+	}
+
+	slices.Sort(explicit)
+
+	var anyLogin *models.AccessGrant
+	if wildcard {
+		anyLogin = loginGrant(allows, denies, member, "*")
+	}
+
+	grants := make([]models.AccessGrant, 0)
+	for _, login := range explicit {
+		grant := loginGrant(allows, denies, member, login)
+		if grant == nil {
+			if anyLogin != nil {
+				anyLogin.ExceptLogins = append(anyLogin.ExceptLogins, login)
+			}
+
+			continue
+		}
+
+		// A login the wildcard already grants on the same terms adds nothing.
+		if anyLogin != nil && sameGrantTerms(*anyLogin, *grant) {
+			continue
+		}
+
+		grants = append(grants, *grant)
+	}
+
+	if anyLogin != nil {
+		grants = append([]models.AccessGrant{*anyLogin}, grants...)
+	}
+
+	return grants
+}
+
+// policyAppliesToDeviceAt reports whether the policy's device filter selects dev
+// and its time conditions hold at now.
+func policyAppliesToDeviceAt(policy models.AccessPolicy, dev *models.Device, now time.Time) (bool, error) {
+	matched, err := policy.Filter.Matches(dev)
+	if err != nil || !matched {
+		return false, err
+	}
+
+	inactive, err := policyInactiveReason(policy, now)
+	if err != nil {
+		return false, err
+	}
+
+	return inactive == "", nil
+}
+
+// loginGrant builds the member's grant for login from the allows and denies
+// that apply to the device, or nil when no allow covers it or a deny blocks it
+// from every address.
+func loginGrant(allows, denies []models.AccessPolicy, member *models.Member, login string) *models.AccessGrant {
+	grant := &models.AccessGrant{
+		PrincipalID:   member.ID,
+		PrincipalType: member.Type,
+		Role:          member.Role,
+		Login:         login,
+		SourceIP:      []string{},
+		Policies:      []string{},
+	}
+
+	anySource := false
+
+	for _, policy := range allows {
+		if !loginMatches(policy.Logins, login) {
+			continue
+		}
+
+		grant.Policies = append(grant.Policies, policy.Name)
+		grant.RequireReauth = grant.RequireReauth || policy.RequireReauth
+
+		if len(policy.SourceIP) == 0 {
+			anySource = true
+		}
+
+		for _, cidr := range policy.SourceIP {
+			if !slices.Contains(grant.SourceIP, cidr) {
+				grant.SourceIP = append(grant.SourceIP, cidr)
+			}
+		}
+	}
+
+	if len(grant.Policies) == 0 {
+		return nil
+	}
+
+	if anySource {
+		grant.SourceIP = []string{}
+	}
+
+	for _, policy := range denies {
+		if !loginMatches(policy.Logins, login) {
+			continue
+		}
+
+		if len(policy.SourceIP) == 0 {
+			return nil
+		}
+
+		for _, cidr := range policy.SourceIP {
+			if !slices.Contains(grant.DeniedFrom, cidr) {
+				grant.DeniedFrom = append(grant.DeniedFrom, cidr)
+			}
+		}
+	}
+
+	// As in Authorize, re-auth never applies to a service account.
+	if member.Type == models.UserTypeService {
+		grant.RequireReauth = false
+	}
+
+	return grant
+}
+
+// sameGrantTerms reports whether two grants differ only in their login.
+func sameGrantTerms(a, b models.AccessGrant) bool {
+	return a.RequireReauth == b.RequireReauth &&
+		slices.Equal(a.SourceIP, b.SourceIP) &&
+		slices.Equal(a.DeniedFrom, b.DeniedFrom) &&
+		slices.Equal(a.Policies, b.Policies)
+}
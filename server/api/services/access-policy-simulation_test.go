@@ -0,0 +1,381 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateAccess(t *testing.T) {
+	ctx := context.TODO()
+
+	const (
+		tenantID = "00000000-0000-4000-0000-000000000000"
+		userID   = "user1"
+	)
+
+	web := models.Device{UID: "device1", Name: "web-01", TenantID: tenantID, Taggable: models.Taggable{TagIDs: []string{"tag-web"}}}
+	db := models.Device{UID: "device2", Name: "db-01", TenantID: tenantID}
+
+	namespace := &models.Namespace{
+		TenantID: tenantID,
+		Members:  []models.Member{{ID: userID, Role: authorizer.RoleOperator}},
+	}
+
+	// Wednesday 2026-10-14 09:30 in Berlin.
+	wednesdayMorning := time.Date(2026, 10, 14, 7, 30, 0, 0, time.UTC)
+	saturday := wednesdayMorning.Add(72 * time.Hour)
+
+	policies := []models.AccessPolicy{
+		{
+			ID:      "allow-web",
+			Name:    "web",
+			Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+			Filter:  models.PublicKeyFilter{Taggable: models.Taggable{TagIDs: []string{"tag-web"}}},
+			Logins:  []string{"deploy"},
+			Action:  models.PolicyActionAllow,
+			Schedule: &models.PolicySchedule{
+				Timezone: "Europe/Berlin",
+				Windows:  []models.PolicyScheduleWindow{{Weekdays: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "08:00", End: "18:00"}},
+			},
+		},
+		{
+			ID:      "deny-root",
+			Name:    "no-root",
+			Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+			Filter:  models.PublicKeyFilter{},
+			Logins:  []string{"root"},
+			Action:  models.PolicyActionDeny,
+		},
+	}
+
+	cases := []struct {
+		description  string
+		req          *requests.AccessPolicySimulate
+		requireMocks func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions)
+		expected     []models.AccessSimulation
+		expectedErr  bool
+	}{
+		{
+			description: "fails when the principal is not a member of the namespace",
+			req:         &requests.AccessPolicySimulate{TenantID: tenantID, PrincipalID: "stranger", DeviceUID: web.UID, Login: "deploy"},
+			requireMocks: func(storeMock *storemock.MockStore, _ *storemock.MockQueryOptions) {
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespace, nil).Once()
+			},
+			expectedErr: true,
+		},
+		{
+			description: "fails when the device cannot be resolved",
+			req:         &requests.AccessPolicySimulate{TenantID: tenantID, PrincipalID: userID, DeviceUID: "missing", Login: "deploy"},
+			requireMocks: func(storeMock *storemock.MockStore, _ *storemock.MockQueryOptions) {
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespace, nil).Once()
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, "missing").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expectedErr: true,
+		},
+		{
+			description: "records what every policy did on one device",
+			req:         &requests.AccessPolicySimulate{TenantID: tenantID, PrincipalID: userID, DeviceUID: web.UID, Login: "deploy"},
+			requireMocks: func(storeMock *storemock.MockStore, _ *storemock.MockQueryOptions) {
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespace, nil).Once()
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, web.UID).
+					Return(&web, nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return(policies, 2, nil).Once()
+			},
+			expected: []models.AccessSimulation{
+				{
+					DeviceUID:  web.UID,
+					DeviceName: web.Name,
+					Decision:   models.Decision{Allowed: true},
+					Policies: []models.PolicyEvaluation{
+						{PolicyID: "deny-root", PolicyName: "no-root", Action: models.PolicyActionDeny, Verdict: models.PolicyVerdictSkipped, Reason: `login "deploy" is not covered`},
+						{PolicyID: "allow-web", PolicyName: "web", Action: models.PolicyActionAllow, Verdict: models.PolicyVerdictGranted},
+					},
+				},
+			},
+		},
+		{
+			description: "evaluates the policies at the requested time",
+			req:         &requests.AccessPolicySimulate{TenantID: tenantID, PrincipalID: userID, DeviceUID: web.UID, Login: "deploy", At: &saturday},
+			requireMocks: func(storeMock *storemock.MockStore, _ *storemock.MockQueryOptions) {
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespace, nil).Once()
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, web.UID).
+					Return(&web, nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return(policies, 2, nil).Once()
+			},
+			expected: []models.AccessSimulation{
+				{
+					DeviceUID:  web.UID,
+					DeviceName: web.Name,
+					Decision: models.Decision{
+						Allowed: false,
+						Reason:  `no policy grants "deploy" on this device at this time: policy "web" is outside its schedule`,
+					},
+					Policies: []models.PolicyEvaluation{
+						{PolicyID: "deny-root", PolicyName: "no-root", Action: models.PolicyActionDeny, Verdict: models.PolicyVerdictSkipped, Reason: `login "deploy" is not covered`},
+						{PolicyID: "allow-web", PolicyName: "web", Action: models.PolicyActionAllow, Verdict: models.PolicyVerdictSkipped, Reason: `policy "web" is outside its schedule`},
+					},
+				},
+			},
+		},
+		{
+			description: "simulates every accepted device the filter selects",
+			req: &requests.AccessPolicySimulate{
+				TenantID:    tenantID,
+				PrincipalID: userID,
+				Filter:      &requests.AccessPolicyFilter{Hostname: "-01$"},
+				Login:       "root",
+			},
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespace, nil).Once()
+				queryOptionsMock.On("WithDeviceStatus", models.DeviceStatusAccepted).Return(nil).Once()
+				storeMock.On("DeviceList", ctx, mock.Anything, store.DeviceAcceptableAsFalse, mock.Anything).
+					Return([]models.Device{web, db, {UID: "device3", Name: "web-02"}}, 3, nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return(policies[1:], 1, nil).Once()
+			},
+			expected: []models.AccessSimulation{
+				{
+					DeviceUID:  web.UID,
+					DeviceName: web.Name,
+					Decision:   models.Decision{Allowed: false, Reason: `denied by policy "no-root"`},
+					Policies: []models.PolicyEvaluation{
+						{PolicyID: "deny-root", PolicyName: "no-root", Action: models.PolicyActionDeny, Verdict: models.PolicyVerdictDenied},
+					},
+				},
+				{
+					DeviceUID:  db.UID,
+					DeviceName: db.Name,
+					Decision:   models.Decision{Allowed: false, Reason: `denied by policy "no-root"`},
+					Policies: []models.PolicyEvaluation{
+						{PolicyID: "deny-root", PolicyName: "no-root", Action: models.PolicyActionDeny, Verdict: models.PolicyVerdictDenied},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := new(storemock.MockStore)
+			queryOptionsMock := new(storemock.MockQueryOptions)
+			storeMock.On("Options").Return(queryOptionsMock).Maybe()
+
+			tc.requireMocks(storeMock, queryOptionsMock)
+
+			clk := clockmock.NewMockClock(t)
+			clk.On("Now").Return(wednesdayMorning).Maybe()
+
+			prevClock := clock.DefaultBackend
+			clock.DefaultBackend = clk
+			t.Cleanup(func() { clock.DefaultBackend = prevClock })
+
+			service := NewService(storeMock, privateKey, publicKey, nil)
+
+			simulations, err := service.SimulateAccess(ctx, tc.req)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, simulations)
+			}
+
+			storeMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMemberDeviceGrants(t *testing.T) {
+	now := time.Date(2026, 10, 14, 7, 30, 0, 0, time.UTC)
+	device := &models.Device{UID: "device1", Name: "web-01"}
+	user := &models.Member{ID: "user1", Role: authorizer.RoleOperator, Type: models.UserTypeHuman}
+	bot := &models.Member{ID: "bot1", Role: authorizer.RoleOperator, Type: models.UserTypeService}
+
+	everyone := models.PolicySubject{Type: models.PolicySubjectAllMembers}
+
+	cases := []struct {
+		description string
+		member      *models.Member
+		policies    []models.AccessPolicy
+		expected    []models.AccessGrant
+	}{
+		{
+			description: "grants nothing without policies",
+			member:      user,
+			policies:    []models.AccessPolicy{},
+			expected:    []models.AccessGrant{},
+		},
+		{
+			description: "a wildcard allow lists the logins a deny carves out",
+			member:      user,
+			policies: []models.AccessPolicy{
+				{Name: "all", Subject: everyone, Logins: []string{"*"}, Action: models.PolicyActionAllow},
+				{Name: "no-root", Subject: everyone, Logins: []string{"root"}, Action: models.PolicyActionDeny},
+			},
+			expected: []models.AccessGrant{
+				{
+					PrincipalID:   "user1",
+					PrincipalType: models.UserTypeHuman,
+					Role:          authorizer.RoleOperator,
+					Login:         "*",
+					ExceptLogins:  []string{"root"},
+					SourceIP:      []string{},
+					Policies:      []string{"all"},
+				},
+			},
+		},
+		{
+			description: "an explicit login granted on stricter terms is listed apart from the wildcard",
+			member:      user,
+			policies: []models.AccessPolicy{
+				{Name: "all", Subject: everyone, Logins: []string{"*"}, Action: models.PolicyActionAllow},
+				{Name: "admin", Subject: everyone, Logins: []string{"admin"}, Action: models.PolicyActionAllow, RequireReauth: true},
+				{Name: "office-only", Subject: everyone, Logins: []string{"admin"}, Action: models.PolicyActionDeny, SourceIP: []string{"0.0.0.0/0"}},
+			},
+			expected: []models.AccessGrant{
+				{
+					PrincipalID:   "user1",
+					PrincipalType: models.UserTypeHuman,
+					Role:          authorizer.RoleOperator,
+					Login:         "*",
+					SourceIP:      []string{},
+					Policies:      []string{"all"},
+				},
+				{
+					PrincipalID:   "user1",
+					PrincipalType: models.UserTypeHuman,
+					Role:          authorizer.RoleOperator,
+					Login:         "admin",
+					SourceIP:      []string{},
+					DeniedFrom:    []string{"0.0.0.0/0"},
+					RequireReauth: true,
+					Policies:      []string{"all", "admin"},
+				},
+			},
+		},
+		{
+			description: "source CIDRs of the allows are reported on the grant",
+			member:      user,
+			policies: []models.AccessPolicy{
+				{Name: "vpn", Subject: everyone, Logins: []string{"deploy"}, Action: models.PolicyActionAllow, SourceIP: []string{"10.0.0.0/8"}},
+			},
+			expected: []models.AccessGrant{
+				{
+					PrincipalID:   "user1",
+					PrincipalType: models.UserTypeHuman,
+					Role:          authorizer.RoleOperator,
+					Login:         "deploy",
+					SourceIP:      []string{"10.0.0.0/8"},
+					Policies:      []string{"vpn"},
+				},
+			},
+		},
+		{
+			description: "skips policies for other subjects and devices",
+			member:      user,
+			policies: []models.AccessPolicy{
+				{Name: "bob", Subject: models.PolicySubject{Type: models.PolicySubjectUser, Value: "bob"}, Logins: []string{"*"}, Action: models.PolicyActionAllow},
+				{Name: "db", Subject: everyone, Filter: models.PublicKeyFilter{Hostname: "^db-"}, Logins: []string{"*"}, Action: models.PolicyActionAllow},
+			},
+			expected: []models.AccessGrant{},
+		},
+		{
+			description: "a deny that cannot be evaluated leaves the member with nothing",
+			member:      user,
+			policies: []models.AccessPolicy{
+				{Name: "all", Subject: everyone, Logins: []string{"*"}, Action: models.PolicyActionAllow},
+				{Name: "broken", Subject: everyone, Filter: models.PublicKeyFilter{Hostname: "("}, Logins: []string{"root"}, Action: models.PolicyActionDeny},
+			},
+			expected: nil,
+		},
+		{
+			description: "re-auth never applies to a service account, and all-members never reaches one",
+			member:      bot,
+			policies: []models.AccessPolicy{
+				{Name: "everyone", Subject: everyone, Logins: []string{"*"}, Action: models.PolicyActionAllow},
+				{Name: "ci", Subject: models.PolicySubject{Type: models.PolicySubjectUser, Value: "bot1"}, Logins: []string{"ci"}, Action: models.PolicyActionAllow, RequireReauth: true},
+			},
+			expected: []models.AccessGrant{
+				{
+					PrincipalID:   "bot1",
+					PrincipalType: models.UserTypeService,
+					Role:          authorizer.RoleOperator,
+					Login:         "ci",
+					SourceIP:      []string{},
+					Policies:      []string{"ci"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, memberDeviceGrants(tc.policies, device, tc.member, now))
+		})
+	}
+}
+
+func TestListDeviceAccess(t *testing.T) {
+	ctx := context.TODO()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	device := &models.Device{UID: "device1", Name: "web-01", TenantID: tenantID}
+
+	storeMock := new(storemock.MockStore)
+	storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, device.UID).
+		Return(device, nil).Once()
+	storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+		Return(&models.Namespace{
+			TenantID: tenantID,
+			Members: []models.Member{
+				{ID: "user1", Email: "alice@example.com", Role: authorizer.RoleOwner, Type: models.UserTypeHuman},
+				{ID: "bot1", Role: authorizer.RoleOperator, Type: models.UserTypeService},
+			},
+		}, nil).Once()
+	storeMock.On("AccessPolicyList", ctx, mock.Anything).
+		Return([]models.AccessPolicy{
+			{Name: "ops", Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers}, Logins: []string{"ops"}, Action: models.PolicyActionAllow},
+			{Name: "deploy", Subject: models.PolicySubject{Type: models.PolicySubjectUser, Value: "bot1"}, Logins: []string{"deploy"}, Action: models.PolicyActionAllow},
+		}, 1, nil).Once()
+	storeMock.On("ServiceAccountList", ctx, tenantID).
+		Return([]models.ServiceAccount{{ID: "bot1", Name: "deployer"}}, 1, nil).Once()
+
+	clk := clockmock.NewMockClock(t)
+	clk.On("Now").Return(time.Date(2026, 10, 14, 7, 30, 0, 0, time.UTC)).Maybe()
+
+	prevClock := clock.DefaultBackend
+	clock.DefaultBackend = clk
+	t.Cleanup(func() { clock.DefaultBackend = prevClock })
+
+	service := NewService(storeMock, privateKey, publicKey, nil)
+
+	grants, err := service.ListDeviceAccess(ctx, &requests.AccessPolicyReach{TenantID: tenantID, DeviceUID: device.UID})
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+	assert.Equal(t, "alice@example.com", grants[0].PrincipalName)
+	assert.Equal(t, "ops", grants[0].Login)
+	assert.Equal(t, "deployer", grants[1].PrincipalName)
+	assert.Equal(t, models.UserTypeService, grants[1].PrincipalType)
+
+	storeMock.AssertExpectations(t)
+}
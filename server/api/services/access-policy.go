@@ -2,13 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/netip"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/server/api/store"
 	log "github.com/sirupsen/logrus"
@@ -18,11 +22,18 @@ type AccessPolicyService interface {
 	// Authorize decides whether the user may reach the device as the given login,
 	// connecting from sourceIP, under the namespace's Access Policies. It is
 	// default-deny and fail-closed: access is granted iff at least one policy
-	// grants it, and any store failure denies. It is the authorization model for
-	// the identity-based SSH access mode; the gateway calls it at the
-	// ephemeral-key mint point.
+	// grants it, and any store failure denies. Policies are evaluated at the
+	// current time: one outside its schedule or its not-before/not-after bounds
+	// does not apply, and a denial caused only by that says so in the reason. It
+	// is the authorization model for the identity-based SSH access mode; the
+	// gateway calls it at the ephemeral-key mint point.
 	Authorize(ctx context.Context, tenantID, userID, deviceUID, login, sourceIP string) (*models.Decision, error)
 
+	// AuthorizeAPIKey is Authorize for the API key named name, which the API uses
+	// to run a command or transfer a file on the device. Only policies whose
+	// subject is that key grant it access; an expired or unknown key is denied.
+	AuthorizeAPIKey(ctx context.Context, tenantID, name, deviceUID, login, sourceIP string) (*models.Decision, error)
+
 	// ListAccessPolicies returns every access policy in the namespace.
 	ListAccessPolicies(ctx context.Context, tenantID string) ([]models.AccessPolicy, error)
 
@@ -43,6 +54,18 @@ type AccessPolicyService interface {
 
 	// DeleteAccessPolicy removes an access policy from the namespace.
 	DeleteAccessPolicy(ctx context.Context, req *requests.AccessPolicyDelete) error
+
+	// SimulateAccess evaluates the namespace's Access Policies for a login by a
+	// member on a device, or on every accepted device a filter selects, without
+	// connecting. Each result carries the Decision Authorize would return and
+	// what every policy did: granted, denied, skipped (and why) or failed to
+	// evaluate.
+	SimulateAccess(ctx context.Context, req *requests.AccessPolicySimulate) ([]models.AccessSimulation, error)
+
+	// ListDeviceAccess answers the reverse question: every member/login pair the
+	// Access Policies let reach the device right now, with the source addresses
+	// each pair is limited to.
+	ListDeviceAccess(ctx context.Context, req *requests.AccessPolicyReach) ([]models.AccessGrant, error)
 }
 
 func (s *service) Authorize(ctx context.Context, tenantID, userID, deviceUID, login, sourceIP string) (*models.Decision, error) {
@@ -66,30 +89,113 @@ func (s *service) Authorize(ctx context.Context, tenantID, userID, deviceUID, lo
 		return &models.Decision{Allowed: false, Reason: "user is not a member of the namespace"}, nil
 	}
 
+	// Deprovisioning cannot take an owner out of their namespace, so membership
+	// alone does not tell a deactivated account apart.
+	user, err := s.store.UserResolve(ctx, store.UserIDResolver, userID)
+	if err != nil {
+		return nil, NewErrUserNotFound(userID, err)
+	}
+
+	if user.Disabled {
+		return &models.Decision{Allowed: false, Reason: "the account is deactivated"}, nil
+	}
+
+	policies, _, err := s.store.AccessPolicyList(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	decision, _ := evaluateAccessPolicies(policies, dev, userID, member, login, sourceIP, clock.Now())
+
+	return decision, nil
+}
+
+func (s *service) AuthorizeAPIKey(ctx context.Context, tenantID, name, deviceUID, login, sourceIP string) (*models.Decision, error) {
+	sc, err := BoundTo(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	dev, err := s.store.DeviceResolve(ctx, sc, store.DeviceUIDResolver, deviceUID)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(deviceUID), err)
+	}
+
+	apiKey, err := s.store.APIKeyResolve(ctx, sc, store.APIKeyNameResolver, name)
+	if err != nil {
+		return nil, NewErrAPIKeyNotFound(name, err)
+	}
+
+	if !apiKey.IsValid() {
+		return &models.Decision{Allowed: false, Reason: "API key has expired"}, nil
+	}
+
+	// A key acts for whoever created it, and stops with them when they are
+	// deactivated. One whose creator is gone altogether stands on its own.
+	if apiKey.CreatedBy != "" {
+		creator, err := s.store.UserResolve(ctx, store.UserIDResolver, apiKey.CreatedBy)
+		switch {
+		case err == nil:
+			if creator.Disabled {
+				return &models.Decision{Allowed: false, Reason: "the API key's creator is deactivated"}, nil
+			}
+		case !errors.Is(err, store.ErrNoDocuments):
+			return nil, err
+		}
+	}
+
 	policies, _, err := s.store.AccessPolicyList(ctx, sc)
 	if err != nil {
 		return nil, err
 	}
 
+	principal := &models.Member{ID: apiKey.Name, Role: apiKey.Role, Type: models.UserTypeAPIKey}
+	decision, _ := evaluateAccessPolicies(policies, dev, apiKey.Name, principal, login, sourceIP, clock.Now())
+
+	return decision, nil
+}
+
+// evaluateAccessPolicies decides whether the member may reach dev as login from
+// sourceIP at now, and records what each policy did on the way. It is the whole
+// of Authorize's policy logic, shared with the simulation so the two can never
+// disagree.
+func evaluateAccessPolicies(policies []models.AccessPolicy, dev *models.Device, userID string, member *models.Member, login, sourceIP string, now time.Time) (*models.Decision, []models.PolicyEvaluation) {
+	evaluations := make([]models.PolicyEvaluation, 0, len(policies))
+
 	// Deny wins: a matching deny blocks access before any allow is considered,
 	// however specific the allow. It is fail-closed — a deny whose filter cannot
-	// be evaluated denies rather than silently opening access.
+	// be evaluated denies rather than silently opening access. Every deny is
+	// still evaluated, so the record covers all of them, but the first one to
+	// block decides the reason.
+	var denied *models.Decision
+
 	for _, policy := range policies {
 		if policy.Action != models.PolicyActionDeny {
 			continue
 		}
 
-		matched, err := policyApplies(policy, dev, userID, member.Role, member.Type, login, sourceIP)
-		if err != nil {
+		evaluation := models.PolicyEvaluation{PolicyID: policy.ID, PolicyName: policy.Name, Action: policy.Action}
+
+		reason, _, err := policyMismatch(policy, dev, userID, member.Role, member.Type, login, sourceIP, now)
+		switch {
+		case err != nil:
 			log.WithError(err).WithField("access_policy", policy.ID).
 				Warn("deny access policy failed to evaluate; denying")
 
-			return &models.Decision{Allowed: false, Reason: fmt.Sprintf("denied: policy %q could not be evaluated", policy.Name)}, nil
+			evaluation.Verdict, evaluation.Reason = models.PolicyVerdictError, err.Error()
+			if denied == nil {
+				denied = &models.Decision{Allowed: false, Reason: fmt.Sprintf("denied: policy %q could not be evaluated", policy.Name)}
+			}
+		case reason != "":
+			evaluation.Verdict, evaluation.Reason = models.PolicyVerdictSkipped, reason
+		default:
+			evaluation.Verdict = models.PolicyVerdictDenied
+			if denied == nil {
+				denied = &models.Decision{Allowed: false, Reason: fmt.Sprintf("denied by policy %q", policy.Name)}
+			}
 		}
 
-		if matched {
-			return &models.Decision{Allowed: false, Reason: fmt.Sprintf("denied by policy %q", policy.Name)}, nil
-		}
+		evaluations = append(evaluations, evaluation)
 	}
 
 	// Take the strictest re-auth across all matching allows — required if any allow
@@ -101,71 +207,136 @@ func (s *service) Authorize(ctx context.Context, tenantID, userID, deviceUID, lo
 
 	var reauthPeriod *int
 
+	// inactive is why the first allow that matches everything but the time did
+	// not grant, reported when nothing else does.
+	inactive := ""
+
 	for _, policy := range policies {
 		if policy.Action == models.PolicyActionDeny {
 			continue
 		}
 
-		matched, err := policyApplies(policy, dev, userID, member.Role, member.Type, login, sourceIP)
-		if err != nil {
+		evaluation := models.PolicyEvaluation{PolicyID: policy.ID, PolicyName: policy.Name, Action: policy.Action}
+
+		reason, timed, err := policyMismatch(policy, dev, userID, member.Role, member.Type, login, sourceIP, now)
+		switch {
+		case err != nil:
 			log.WithError(err).WithField("access_policy", policy.ID).
 				Warn("access policy failed to evaluate; treating as non-match")
 
-			continue
-		}
-
-		if !matched {
-			continue
+			evaluation.Verdict, evaluation.Reason = models.PolicyVerdictError, err.Error()
+		case reason != "":
+			evaluation.Verdict, evaluation.Reason = models.PolicyVerdictSkipped, reason
+			if timed && inactive == "" {
+				inactive = reason
+			}
+		default:
+			evaluation.Verdict = models.PolicyVerdictGranted
+			allowed = true
+
+			if policy.RequireReauth {
+				if requireReauth {
+					reauthPeriod = stricterReauthPeriod(reauthPeriod, policy.ReauthPeriod)
+				} else {
+					reauthPeriod = policy.ReauthPeriod
+				}
+
+				requireReauth = true
+			}
 		}
 
-		allowed = true
-
-		if policy.RequireReauth {
-			if requireReauth {
-				reauthPeriod = stricterReauthPeriod(reauthPeriod, policy.ReauthPeriod)
-			} else {
-				reauthPeriod = policy.ReauthPeriod
-			}
+		evaluations = append(evaluations, evaluation)
+	}
 
-			requireReauth = true
-		}
+	if denied != nil {
+		return denied, evaluations
 	}
 
 	if !allowed {
-		return &models.Decision{Allowed: false, Reason: fmt.Sprintf("no policy grants %q on this device", login)}, nil
+		if inactive != "" {
+			return &models.Decision{Allowed: false, Reason: fmt.Sprintf("no policy grants %q on this device at this time: %s", login, inactive)}, evaluations
+		}
+
+		return &models.Decision{Allowed: false, Reason: fmt.Sprintf("no policy grants %q on this device", login)}, evaluations
 	}
 
-	// Re-auth is an interactive step, so it cannot apply to a service account: there
-	// is no human to complete it, and demanding it would hang the connection. A
-	// service account's freshness comes from its key's lifecycle instead.
-	if member.Type == models.UserTypeService {
+	// Re-auth is an interactive step, so it cannot apply to a service account or an
+	// API key: there is no human to complete it, and demanding it would hang the
+	// connection. Their freshness comes from their key's lifecycle instead.
+	if member.Type == models.UserTypeService || member.Type == models.UserTypeAPIKey {
 		requireReauth = false
 		reauthPeriod = nil
 	}
 
-	return &models.Decision{Allowed: true, RequireReauth: requireReauth, ReauthPeriod: reauthPeriod}, nil
+	return &models.Decision{Allowed: true, RequireReauth: requireReauth, ReauthPeriod: reauthPeriod}, evaluations
 }
 
-// policyApplies reports whether the policy's subject, device filter, login, and
-// source IP all match the request. The bool is only meaningful when err is nil;
-// a non-nil error means a matcher could not be evaluated (a broken filter regexp,
-// or a malformed source CIDR / client IP), and the caller decides how to treat it
-// (deny fails closed, allow treats it as a non-match).
-func policyApplies(policy models.AccessPolicy, dev *models.Device, userID string, role authorizer.Role, userType models.UserType, login, sourceIP string) (bool, error) {
+// policyMismatch reports why the policy does not apply to the request, or ""
+// when its subject, device filter, login, source IP and time conditions all
+// match. timed is set when the time conditions are the only ones failing. A
+// non-nil error means a matcher could not be evaluated (a broken filter regexp,
+// a malformed source CIDR / client IP, or an unloadable schedule), and the
+// caller decides how to treat it (deny fails closed, allow treats it as a
+// non-match).
+func policyMismatch(policy models.AccessPolicy, dev *models.Device, userID string, role authorizer.Role, userType models.UserType, login, sourceIP string, now time.Time) (string, bool, error) {
 	if !subjectMatches(policy.Subject, userID, role, userType) {
-		return false, nil
+		return "subject does not match", false, nil
 	}
 
 	matched, err := policy.Filter.Matches(dev)
 	if err != nil {
-		return false, err
+		return "", false, err
+	}
+
+	if !matched {
+		return "device filter does not select the device", false, nil
+	}
+
+	if !loginMatches(policy.Logins, login) {
+		return fmt.Sprintf("login %q is not covered", login), false, nil
+	}
+
+	matched, err = sourceIPMatches(policy.SourceIP, sourceIP)
+	if err != nil {
+		return "", false, err
+	}
+
+	if !matched {
+		return fmt.Sprintf("source IP %q is outside the policy's source CIDRs", sourceIP), false, nil
+	}
+
+	reason, err := policyInactiveReason(policy, now)
+	if err != nil {
+		return "", false, err
+	}
+
+	return reason, reason != "", nil
+}
+
+// policyInactiveReason reports why the policy's time conditions keep it from
+// applying at now, or "" when it is in effect. A schedule that cannot be
+// evaluated returns an error, for the caller to treat as policyApplies does.
+func policyInactiveReason(policy models.AccessPolicy, now time.Time) (string, error) {
+	if policy.NotBefore != nil && now.Before(*policy.NotBefore) {
+		return fmt.Sprintf("policy %q is not in effect before %s", policy.Name, policy.NotBefore.UTC().Format(time.RFC3339)), nil
+	}
+
+	if policy.NotAfter != nil && !now.Before(*policy.NotAfter) {
+		return fmt.Sprintf("policy %q expired at %s", policy.Name, policy.NotAfter.UTC().Format(time.RFC3339)), nil
 	}
 
-	if !matched || !loginMatches(policy.Logins, login) {
-		return false, nil
+	if policy.Schedule != nil {
+		active, err := policy.Schedule.Contains(now)
+		if err != nil {
+			return "", err
+		}
+
+		if !active {
+			return fmt.Sprintf("policy %q is outside its schedule", policy.Name), nil
+		}
 	}
 
-	return sourceIPMatches(policy.SourceIP, sourceIP)
+	return "", nil
 }
 
 // normalizeSourceIPs canonicalizes source entries to CIDR form so a bare IP the
@@ -238,6 +409,12 @@ func (s *service) NamespaceHasAccessPolicies(ctx context.Context, tenantID strin
 
 // subjectMatches reports whether the policy subject applies to the given principal.
 func subjectMatches(subject models.PolicySubject, userID string, role authorizer.Role, userType models.UserType) bool {
+	// An API key is never a member, so only a policy naming it applies: its role
+	// bounds what it may do in the API, not which devices it may reach.
+	if userType == models.UserTypeAPIKey {
+		return subject.Type == models.PolicySubjectAPIKey && subject.Value == userID
+	}
+
 	switch subject.Type {
 	case models.PolicySubjectAllMembers:
 		// Only all-members needs the service carve-out: a role subject already excludes
@@ -287,6 +464,84 @@ func normalizeReauthPeriod(period *int) *int {
 	return period
 }
 
+// accessPolicySchedule translates the request's schedule into the stored one;
+// an omitted schedule stays nil, meaning any time.
+func accessPolicySchedule(req *requests.AccessPolicySchedule) *models.PolicySchedule {
+	if req == nil {
+		return nil
+	}
+
+	schedule := &models.PolicySchedule{
+		Timezone: req.Timezone,
+		Windows:  make([]models.PolicyScheduleWindow, 0, len(req.Windows)),
+	}
+
+	for _, window := range req.Windows {
+		schedule.Windows = append(schedule.Windows, models.PolicyScheduleWindow{
+			Weekdays: window.Weekdays,
+			Start:    window.Start,
+			End:      window.End,
+		})
+	}
+
+	return schedule
+}
+
+// checkPolicySchedule returns why each field of a schedule can't be read the way
+// [models.PolicySchedule.Contains] reads it, keyed by its path in the schedule. A
+// schedule that slips through would fail closed on a deny and never match on an
+// allow.
+func checkPolicySchedule(schedule *models.PolicySchedule) map[string]string {
+	invalid := make(map[string]string)
+	if schedule == nil {
+		return invalid
+	}
+
+	if schedule.Timezone != "" {
+		if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+			invalid["timezone"] = fmt.Sprintf("%q is not an IANA time zone", schedule.Timezone)
+		}
+	}
+
+	if len(schedule.Windows) == 0 {
+		invalid["windows"] = "must have at least one window"
+	}
+
+	for i, window := range schedule.Windows {
+		field := fmt.Sprintf("windows[%d]", i)
+
+		if len(window.Weekdays) == 0 {
+			invalid[field+".weekdays"] = "must have at least one weekday"
+		}
+
+		for j, weekday := range window.Weekdays {
+			if !slices.Contains(models.PolicyWeekdays, weekday) {
+				invalid[fmt.Sprintf("%s.weekdays[%d]", field, j)] = fmt.Sprintf("%q is not a weekday from mon to sun", weekday)
+			}
+		}
+
+		if _, err := models.ParsePolicyClock(window.Start); err != nil {
+			invalid[field+".start"] = "must be a time from 00:00 to 24:00"
+		}
+
+		if _, err := models.ParsePolicyClock(window.End); err != nil {
+			invalid[field+".end"] = "must be a time from 00:00 to 24:00"
+		}
+	}
+
+	return invalid
+}
+
+// checkAccessPolicyPeriod rejects a not-before/not-after pair that leaves the
+// policy never in effect.
+func checkAccessPolicyPeriod(notBefore, notAfter *time.Time) error {
+	if notBefore != nil && notAfter != nil && !notAfter.After(*notBefore) {
+		return NewErrAccessPolicyInvalidPeriod(*notBefore, *notAfter)
+	}
+
+	return nil
+}
+
 // stricterReauthPeriod returns the more demanding of two re-auth freshness windows.
 // A nil period means "every session" — the strictest — and always wins; between two
 // concrete windows the shorter one wins, since it forces re-auth more often.
@@ -344,6 +599,10 @@ func (s *service) CreateAccessPolicy(ctx context.Context, req *requests.AccessPo
 		return nil, NewErrNamespaceNotFound(req.TenantID, err)
 	}
 
+	if err := checkAccessPolicyPeriod(req.NotBefore, req.NotAfter); err != nil {
+		return nil, err
+	}
+
 	filter, err := s.resolveAccessPolicyFilter(ctx, sc, req.Filter)
 	if err != nil {
 		return nil, err
@@ -359,6 +618,9 @@ func (s *service) CreateAccessPolicy(ctx context.Context, req *requests.AccessPo
 		Action:        defaultAction(req.Action),
 		RequireReauth: req.RequireReauth,
 		ReauthPeriod:  normalizeReauthPeriod(req.ReauthPeriod),
+		Schedule:      accessPolicySchedule(req.Schedule),
+		NotBefore:     req.NotBefore,
+		NotAfter:      req.NotAfter,
 	}
 
 	id, err := s.store.AccessPolicyCreate(ctx, policy)
@@ -366,7 +628,19 @@ func (s *service) CreateAccessPolicy(ctx context.Context, req *requests.AccessPo
 		return nil, err
 	}
 
-	return s.store.AccessPolicyResolve(ctx, sc, store.AccessPolicyIDResolver, id)
+	created, err := s.store.AccessPolicyResolve(ctx, sc, store.AccessPolicyIDResolver, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.accessPolicyChanged(ctx, nil, created)
+
+	// Only a deny can take away access already granted.
+	if created.Action == models.PolicyActionDeny {
+		s.reevaluateSessions(ctx, req.TenantID, s.activeSessions(ctx, req.TenantID, ""))
+	}
+
+	return created, nil
 }
 
 func (s *service) UpdateAccessPolicy(ctx context.Context, req *requests.AccessPolicyUpdate) (*models.AccessPolicy, error) {
@@ -375,10 +649,15 @@ func (s *service) UpdateAccessPolicy(ctx context.Context, req *requests.AccessPo
 		return nil, err
 	}
 
-	if _, err := s.store.AccessPolicyResolve(ctx, sc, store.AccessPolicyIDResolver, req.ID); err != nil {
+	current, err := s.store.AccessPolicyResolve(ctx, sc, store.AccessPolicyIDResolver, req.ID)
+	if err != nil {
 		return nil, NewErrAccessPolicyNotFound(req.ID, err)
 	}
 
+	if err := checkAccessPolicyPeriod(req.NotBefore, req.NotAfter); err != nil {
+		return nil, err
+	}
+
 	filter, err := s.resolveAccessPolicyFilter(ctx, sc, req.Filter)
 	if err != nil {
 		return nil, err
@@ -395,13 +674,25 @@ func (s *service) UpdateAccessPolicy(ctx context.Context, req *requests.AccessPo
 		Action:        defaultAction(req.Action),
 		RequireReauth: req.RequireReauth,
 		ReauthPeriod:  normalizeReauthPeriod(req.ReauthPeriod),
+		Schedule:      accessPolicySchedule(req.Schedule),
+		NotBefore:     req.NotBefore,
+		NotAfter:      req.NotAfter,
 	}
 
 	if err := s.store.AccessPolicyUpdate(ctx, policy); err != nil {
 		return nil, err
 	}
 
-	return s.store.AccessPolicyResolve(ctx, sc, store.AccessPolicyIDResolver, req.ID)
+	updated, err := s.store.AccessPolicyResolve(ctx, sc, store.AccessPolicyIDResolver, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.accessPolicyChanged(ctx, current, updated)
+
+	s.reevaluateSessions(ctx, req.TenantID, s.activeSessions(ctx, req.TenantID, ""))
+
+	return updated, nil
 }
 
 func (s *service) DeleteAccessPolicy(ctx context.Context, req *requests.AccessPolicyDelete) error {
@@ -410,11 +701,38 @@ func (s *service) DeleteAccessPolicy(ctx context.Context, req *requests.AccessPo
 		return err
 	}
 
-	if _, err := s.store.AccessPolicyResolve(ctx, sc, store.AccessPolicyIDResolver, req.ID); err != nil {
+	current, err := s.store.AccessPolicyResolve(ctx, sc, store.AccessPolicyIDResolver, req.ID)
+	if err != nil {
 		return NewErrAccessPolicyNotFound(req.ID, err)
 	}
 
-	return s.store.AccessPolicyDelete(ctx, &models.AccessPolicy{ID: req.ID, TenantID: req.TenantID})
+	if err := s.store.AccessPolicyDelete(ctx, &models.AccessPolicy{ID: req.ID, TenantID: req.TenantID}); err != nil {
+		return err
+	}
+
+	s.accessPolicyChanged(ctx, current, nil)
+
+	s.reevaluateSessions(ctx, req.TenantID, s.activeSessions(ctx, req.TenantID, ""))
+
+	return nil
+}
+
+// accessPolicyChanged records a change to an access policy in its namespace's
+// audit log and announces it. before is nil for a creation, and after for a
+// deletion. Every path that writes an access policy calls it once the write is
+// committed.
+func (s *service) accessPolicyChanged(ctx context.Context, before, after *models.AccessPolicy) {
+	action, change, policy := models.AuditActionAccessPolicyUpdate, "updated", after
+	switch {
+	case before == nil:
+		action, change = models.AuditActionAccessPolicyCreate, "created"
+	case after == nil:
+		action, change, policy = models.AuditActionAccessPolicyDelete, "deleted", before
+	}
+
+	s.recordAuditChange(ctx, policy.TenantID, action, models.AuditTargetAccessPolicy, policy.ID, before, after)
+
+	s.emitEvent(ctx, policy.TenantID, models.WebhookEventAccessPolicyChange, eventAccessPolicyChange{Change: change, Policy: policy})
 }
 
 // resolveAccessPolicyFilter translates the request's device selector into a
@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/server/api/store"
@@ -31,13 +34,24 @@ func TestAuthorize(t *testing.T) {
 		}
 	}
 
+	// Wednesday 2026-10-14 09:30 in Berlin, unless a case says otherwise.
+	wednesdayMorning := time.Date(2026, 10, 14, 7, 30, 0, 0, time.UTC)
+	yesterday := wednesdayMorning.Add(-24 * time.Hour)
+	tomorrow := wednesdayMorning.Add(24 * time.Hour)
+	workdays := &models.PolicySchedule{
+		Timezone: "Europe/Berlin",
+		Windows:  []models.PolicyScheduleWindow{{Weekdays: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "08:00", End: "18:00"}},
+	}
+
 	cases := []struct {
 		description     string
 		login           string
 		sourceIP        string
+		at              time.Time
 		requireMocks    func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions)
 		expectedAllowed bool
 		expectedReauth  bool
+		expectedReason  string
 		expectedErr     bool
 	}{
 		{
@@ -62,6 +76,20 @@ func TestAuthorize(t *testing.T) {
 			expectedAllowed: false,
 			expectedErr:     false,
 		},
+		{
+			description: "denies a deactivated account that is still a member",
+			login:       "root",
+			requireMocks: func(storeMock *storemock.MockStore, _ *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("UserResolve", ctx, store.UserIDResolver, userID).
+					Return(&models.User{ID: userID, Disabled: true}, nil).Once()
+			},
+			expectedAllowed: false,
+			expectedReason:  "the account is deactivated",
+		},
 		{
 			description: "fails closed when the policy store errors",
 			login:       "root",
@@ -784,6 +812,264 @@ func TestAuthorize(t *testing.T) {
 			expectedAllowed: false,
 			expectedErr:     false,
 		},
+		{
+			description: "allow grants within its schedule",
+			login:       "root",
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:     "office hours",
+							Subject:  models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:   models.PublicKeyFilter{},
+							Logins:   []string{"*"},
+							Action:   models.PolicyActionAllow,
+							Schedule: workdays,
+						},
+					}, 1, nil).Once()
+			},
+			expectedAllowed: true,
+		},
+		{
+			description: "allow does not grant outside its schedule and says why",
+			login:       "root",
+			at:          time.Date(2026, 10, 14, 17, 0, 0, 0, time.UTC),
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:     "office hours",
+							Subject:  models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:   models.PublicKeyFilter{},
+							Logins:   []string{"*"},
+							Action:   models.PolicyActionAllow,
+							Schedule: workdays,
+						},
+					}, 1, nil).Once()
+			},
+			expectedAllowed: false,
+			expectedReason:  `no policy grants "root" on this device at this time: policy "office hours" is outside its schedule`,
+		},
+		{
+			description: "allow does not grant before its not-before",
+			login:       "root",
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:      "starts tomorrow",
+							Subject:   models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:    models.PublicKeyFilter{},
+							Logins:    []string{"*"},
+							Action:    models.PolicyActionAllow,
+							NotBefore: &tomorrow,
+						},
+					}, 1, nil).Once()
+			},
+			expectedAllowed: false,
+			expectedReason:  `no policy grants "root" on this device at this time: policy "starts tomorrow" is not in effect before 2026-10-15T07:30:00Z`,
+		},
+		{
+			description: "allow does not grant from its not-after on",
+			login:       "root",
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:     "expired",
+							Subject:  models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:   models.PublicKeyFilter{},
+							Logins:   []string{"*"},
+							Action:   models.PolicyActionAllow,
+							NotAfter: &yesterday,
+						},
+					}, 1, nil).Once()
+			},
+			expectedAllowed: false,
+			expectedReason:  `no policy grants "root" on this device at this time: policy "expired" expired at 2026-10-13T07:30:00Z`,
+		},
+		{
+			description: "allow grants between its not-before and not-after",
+			login:       "root",
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:      "current",
+							Subject:   models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:    models.PublicKeyFilter{},
+							Logins:    []string{"*"},
+							Action:    models.PolicyActionAllow,
+							NotBefore: &yesterday,
+							NotAfter:  &tomorrow,
+						},
+					}, 1, nil).Once()
+			},
+			expectedAllowed: true,
+		},
+		{
+			description: "a policy in effect grants even when another is out of its schedule",
+			login:       "root",
+			at:          time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:     "office hours",
+							Subject:  models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:   models.PublicKeyFilter{},
+							Logins:   []string{"*"},
+							Action:   models.PolicyActionAllow,
+							Schedule: workdays,
+						},
+						{
+							Name:    "on call",
+							Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:  models.PublicKeyFilter{},
+							Logins:  []string{"*"},
+							Action:  models.PolicyActionAllow,
+						},
+					}, 2, nil).Once()
+			},
+			expectedAllowed: true,
+		},
+		{
+			description: "deny blocks within its schedule",
+			login:       "root",
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:     "no office hours",
+							Subject:  models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:   models.PublicKeyFilter{},
+							Logins:   []string{"*"},
+							Action:   models.PolicyActionDeny,
+							Schedule: workdays,
+						},
+						{
+							Name:    "everyone",
+							Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:  models.PublicKeyFilter{},
+							Logins:  []string{"*"},
+							Action:  models.PolicyActionAllow,
+						},
+					}, 2, nil).Once()
+			},
+			expectedAllowed: false,
+			expectedReason:  `denied by policy "no office hours"`,
+		},
+		{
+			description: "deny blocks nothing outside its schedule",
+			login:       "root",
+			at:          time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:     "no office hours",
+							Subject:  models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:   models.PublicKeyFilter{},
+							Logins:   []string{"*"},
+							Action:   models.PolicyActionDeny,
+							Schedule: workdays,
+						},
+						{
+							Name:    "everyone",
+							Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:  models.PublicKeyFilter{},
+							Logins:  []string{"*"},
+							Action:  models.PolicyActionAllow,
+						},
+					}, 2, nil).Once()
+			},
+			expectedAllowed: true,
+		},
+		{
+			description: "deny with an unloadable schedule time zone fails closed",
+			login:       "root",
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:     "broken",
+							Subject:  models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:   models.PublicKeyFilter{},
+							Logins:   []string{"*"},
+							Action:   models.PolicyActionDeny,
+							Schedule: &models.PolicySchedule{Timezone: "Mars/Olympus", Windows: workdays.Windows},
+						},
+						{
+							Name:    "everyone",
+							Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:  models.PublicKeyFilter{},
+							Logins:  []string{"*"},
+							Action:  models.PolicyActionAllow,
+						},
+					}, 2, nil).Once()
+			},
+			expectedAllowed: false,
+			expectedReason:  `denied: policy "broken" could not be evaluated`,
+		},
+		{
+			description: "allow with an unloadable schedule time zone is skipped and stays default-deny",
+			login:       "root",
+			requireMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(namespaceWith(authorizer.RoleOwner), nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return([]models.AccessPolicy{
+						{
+							Name:     "broken",
+							Subject:  models.PolicySubject{Type: models.PolicySubjectAllMembers},
+							Filter:   models.PublicKeyFilter{},
+							Logins:   []string{"*"},
+							Action:   models.PolicyActionAllow,
+							Schedule: &models.PolicySchedule{Timezone: "Mars/Olympus", Windows: workdays.Windows},
+						},
+					}, 1, nil).Once()
+			},
+			expectedAllowed: false,
+			expectedReason:  `no policy grants "root" on this device`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -793,6 +1079,20 @@ func TestAuthorize(t *testing.T) {
 			storeMock.On("Options").Return(queryOptionsMock).Maybe()
 
 			tc.requireMocks(storeMock, queryOptionsMock)
+			// The member is an active account unless the case says otherwise.
+			storeMock.On("UserResolve", ctx, store.UserIDResolver, userID).Return(&models.User{ID: userID}, nil).Maybe()
+
+			at := tc.at
+			if at.IsZero() {
+				at = wednesdayMorning
+			}
+
+			clk := clockmock.NewMockClock(t)
+			clk.On("Now").Return(at).Maybe()
+
+			prevClock := clock.DefaultBackend
+			clock.DefaultBackend = clk
+			t.Cleanup(func() { clock.DefaultBackend = prevClock })
 
 			service := NewService(storeMock, privateKey, publicKey, nil)
 
@@ -803,6 +1103,134 @@ func TestAuthorize(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tc.expectedAllowed, decision.Allowed)
 				require.Equal(t, tc.expectedReauth, decision.RequireReauth)
+				if tc.expectedReason != "" {
+					require.Equal(t, tc.expectedReason, decision.Reason)
+				}
+			}
+
+			storeMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthorizeAPIKey(t *testing.T) {
+	ctx := context.TODO()
+
+	const (
+		tenantID = "00000000-0000-4000-0000-000000000000"
+		deviceID = "device1"
+	)
+
+	device := &models.Device{UID: deviceID, Name: "web-01", TenantID: tenantID}
+	policies := []models.AccessPolicy{
+		{
+			Subject:       models.PolicySubject{Type: models.PolicySubjectAPIKey, Value: "ci"},
+			Logins:        []string{"deploy"},
+			RequireReauth: true,
+		},
+		{
+			Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+			Logins:  []string{"*"},
+		},
+	}
+
+	cases := []struct {
+		description     string
+		login           string
+		requireMocks    func(storeMock *storemock.MockStore)
+		expectedAllowed bool
+		expectedReason  string
+		expectedErr     bool
+	}{
+		{
+			description: "fails when the key does not exist",
+			login:       "deploy",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("APIKeyResolve", ctx, mock.Anything, store.APIKeyNameResolver, "ci").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expectedErr: true,
+		},
+		{
+			description: "denies an expired key",
+			login:       "deploy",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("APIKeyResolve", ctx, mock.Anything, store.APIKeyNameResolver, "ci").
+					Return(&models.APIKey{Name: "ci", TenantID: tenantID, Role: authorizer.RoleAdministrator, ExpiresIn: 1}, nil).Once()
+			},
+			expectedAllowed: false,
+			expectedReason:  "API key has expired",
+		},
+		{
+			description: "denies a key whose creator is deactivated",
+			login:       "deploy",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("APIKeyResolve", ctx, mock.Anything, store.APIKeyNameResolver, "ci").
+					Return(&models.APIKey{Name: "ci", TenantID: tenantID, Role: authorizer.RoleAdministrator, CreatedBy: "owner"}, nil).Once()
+				storeMock.On("UserResolve", ctx, store.UserIDResolver, "owner").
+					Return(&models.User{ID: "owner", Disabled: true}, nil).Once()
+			},
+			expectedAllowed: false,
+			expectedReason:  "the API key's creator is deactivated",
+		},
+		{
+			description: "grants the login a policy naming the key grants, without re-auth",
+			login:       "deploy",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("APIKeyResolve", ctx, mock.Anything, store.APIKeyNameResolver, "ci").
+					Return(&models.APIKey{Name: "ci", TenantID: tenantID, Role: authorizer.RoleAdministrator}, nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return(policies, len(policies), nil).Once()
+			},
+			expectedAllowed: true,
+		},
+		{
+			description: "denies a login only an all-members policy grants",
+			login:       "root",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, deviceID).
+					Return(device, nil).Once()
+				storeMock.On("APIKeyResolve", ctx, mock.Anything, store.APIKeyNameResolver, "ci").
+					Return(&models.APIKey{Name: "ci", TenantID: tenantID, Role: authorizer.RoleAdministrator}, nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).
+					Return(policies, len(policies), nil).Once()
+			},
+			expectedAllowed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := new(storemock.MockStore)
+			tc.requireMocks(storeMock)
+
+			clk := clockmock.NewMockClock(t)
+			clk.On("Now").Return(time.Date(2026, 10, 14, 7, 30, 0, 0, time.UTC)).Maybe()
+
+			prevClock := clock.DefaultBackend
+			clock.DefaultBackend = clk
+			t.Cleanup(func() { clock.DefaultBackend = prevClock })
+
+			service := NewService(storeMock, privateKey, publicKey, nil)
+
+			decision, err := service.AuthorizeAPIKey(ctx, tenantID, "ci", deviceID, tc.login, "")
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedAllowed, decision.Allowed)
+				require.False(t, decision.RequireReauth)
+				if tc.expectedReason != "" {
+					require.Equal(t, tc.expectedReason, decision.Reason)
+				}
 			}
 
 			storeMock.AssertExpectations(t)
@@ -927,6 +1355,46 @@ func TestSubjectMatches(t *testing.T) {
 			userType:    "",
 			expected:    true,
 		},
+		{
+			description: "api-key matches the key it names",
+			subject:     models.PolicySubject{Type: models.PolicySubjectAPIKey, Value: "ci"},
+			userID:      "ci",
+			role:        authorizer.RoleAdministrator,
+			userType:    models.UserTypeAPIKey,
+			expected:    true,
+		},
+		{
+			description: "api-key does not match another key",
+			subject:     models.PolicySubject{Type: models.PolicySubjectAPIKey, Value: "ci"},
+			userID:      "deploy",
+			role:        authorizer.RoleAdministrator,
+			userType:    models.UserTypeAPIKey,
+			expected:    false,
+		},
+		{
+			description: "api-key does not match a user with the same id",
+			subject:     models.PolicySubject{Type: models.PolicySubjectAPIKey, Value: "ci"},
+			userID:      "ci",
+			role:        authorizer.RoleAdministrator,
+			userType:    models.UserTypeHuman,
+			expected:    false,
+		},
+		{
+			description: "all-members does not match an API key",
+			subject:     models.PolicySubject{Type: models.PolicySubjectAllMembers},
+			userID:      "ci",
+			role:        authorizer.RoleAdministrator,
+			userType:    models.UserTypeAPIKey,
+			expected:    false,
+		},
+		{
+			description: "role does not match an API key holding it",
+			subject:     models.PolicySubject{Type: models.PolicySubjectRole, Value: "administrator"},
+			userID:      "ci",
+			role:        authorizer.RoleAdministrator,
+			userType:    models.UserTypeAPIKey,
+			expected:    false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -958,3 +1426,32 @@ func TestStricterReauthPeriod(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckAccessPolicyPeriod(t *testing.T) {
+	earlier := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		description         string
+		notBefore, notAfter *time.Time
+		expectedErr         bool
+	}{
+		{"no bounds is valid", nil, nil, false},
+		{"only a not-before is valid", &earlier, nil, false},
+		{"only a not-after is valid", nil, &later, false},
+		{"a not-after past the not-before is valid", &earlier, &later, false},
+		{"a not-after before the not-before is rejected", &later, &earlier, true},
+		{"equal bounds leave no time in effect and are rejected", &earlier, &earlier, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := checkAccessPolicyPeriod(tc.notBefore, tc.notAfter)
+			if tc.expectedErr {
+				require.ErrorIs(t, err, ErrAccessPolicyInvalidPeriod)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
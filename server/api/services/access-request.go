@@ -0,0 +1,424 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/worker"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	log "github.com/sirupsen/logrus"
+)
+
+// AccessRequestFilterFields maps each filter field the access request list
+// endpoint accepts to the set of operators valid for it.
+var AccessRequestFilterFields = query.NewFieldConstraints(map[string][]string{
+	"status":  {"eq", "ne"},
+	"user_id": {"eq"},
+})
+
+// AccessRequestSortFields is the set of field names accepted in the sort_by
+// query parameter when listing access requests.
+var AccessRequestSortFields = query.NewFieldSet(
+	"created_at",
+	"status",
+	"expires_at",
+)
+
+type AccessRequestService interface {
+	// CreateAccessRequest asks for temporary access, as the caller, to the
+	// devices the filter selects. The request stays pending until an approver
+	// decides it or the caller cancels it.
+	CreateAccessRequest(ctx context.Context, req *requests.AccessRequestCreate) (*models.AccessRequest, error)
+
+	// ListAccessRequests returns the namespace's access requests, or only the
+	// caller's own unless the request lists all of them.
+	ListAccessRequests(ctx context.Context, req *requests.AccessRequestList) ([]models.AccessRequest, int, error)
+
+	// GetAccessRequest returns an access request with its audit trail. A request
+	// the caller may not see is reported as not found.
+	GetAccessRequest(ctx context.Context, req *requests.AccessRequestGet) (*models.AccessRequest, error)
+
+	// GrantAccessRequest approves a pending request: it creates an allow Access
+	// Policy for the requester, bounded to the requested duration from now, and
+	// records who granted it. The requester cannot grant their own request.
+	GrantAccessRequest(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error)
+
+	// RejectAccessRequest turns a pending request down.
+	RejectAccessRequest(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error)
+
+	// RevokeAccessRequest ends a granted request before it lapses, removing the
+	// Access Policy it created.
+	RevokeAccessRequest(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error)
+
+	// CancelAccessRequest withdraws one of the caller's own pending requests.
+	CancelAccessRequest(ctx context.Context, req *requests.AccessRequestCancel) (*models.AccessRequest, error)
+}
+
+func (s *service) CreateAccessRequest(ctx context.Context, req *requests.AccessRequestCreate) (*models.AccessRequest, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := s.resolveAccessPolicyFilter(ctx, sc, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &models.AccessRequest{
+		TenantID:      req.TenantID,
+		UserID:        req.UserID,
+		Filter:        filter,
+		Logins:        req.Logins,
+		Duration:      req.Duration,
+		Justification: req.Justification,
+		Status:        models.AccessRequestPending,
+	}
+
+	if err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.store.AccessRequestCreate(ctx, request); err != nil {
+			return err
+		}
+
+		return s.store.AccessRequestEventCreate(ctx, &models.AccessRequestEvent{
+			AccessRequestID: request.ID,
+			TenantID:        request.TenantID,
+			Action:          models.AccessRequestActionRequested,
+			ActorID:         req.UserID,
+			Note:            req.Justification,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.store.AccessRequestResolve(ctx, sc, request.ID)
+}
+
+func (s *service) ListAccessRequests(ctx context.Context, req *requests.AccessRequestList) ([]models.AccessRequest, int, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if req.Sorter.By == "" {
+		req.Sorter.By = "created_at"
+	}
+
+	if req.Sorter.Order == "" {
+		req.Sorter.Order = query.OrderDesc
+	}
+
+	req.Sorter.Tiebreak = "id"
+
+	opts := []store.QueryOption{
+		s.store.Options().Match(&req.Filters),
+		s.store.Options().Sort(&req.Sorter),
+		s.store.Options().Paginate(&req.Paginator),
+	}
+
+	if !req.All {
+		opts = append(opts, s.store.Options().WithUserID(req.UserID))
+	}
+
+	return s.store.AccessRequestList(ctx, sc, opts...)
+}
+
+func (s *service) GetAccessRequest(ctx context.Context, req *requests.AccessRequestGet) (*models.AccessRequest, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []store.QueryOption{}
+	if !req.All {
+		opts = append(opts, s.store.Options().WithUserID(req.UserID))
+	}
+
+	request, err := s.store.AccessRequestResolve(ctx, sc, req.ID, opts...)
+	if err != nil {
+		return nil, NewErrAccessRequestNotFound(req.ID, err)
+	}
+
+	return request, nil
+}
+
+func (s *service) GrantAccessRequest(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error) {
+	var granted *models.AccessPolicy
+
+	request, err := s.decideAccessRequest(ctx, req, models.AccessRequestPending, func(ctx context.Context, request *models.AccessRequest, now time.Time) error {
+		expiresAt := now.Add(time.Duration(request.Duration) * time.Second)
+
+		// The policy carries its own not_after, so the access ends on time even
+		// if the worker is late to remove it.
+		policy := &models.AccessPolicy{
+			TenantID:        request.TenantID,
+			Name:            fmt.Sprintf("access request %s", request.ID),
+			Subject:         models.PolicySubject{Type: models.PolicySubjectUser, Value: request.UserID},
+			Filter:          request.Filter,
+			Logins:          request.Logins,
+			Action:          models.PolicyActionAllow,
+			NotBefore:       &now,
+			NotAfter:        &expiresAt,
+			AccessRequestID: request.ID,
+		}
+
+		policyID, err := s.store.AccessPolicyCreate(ctx, policy)
+		if err != nil {
+			return err
+		}
+
+		policy.ID = policyID
+		granted = policy
+
+		request.Status = models.AccessRequestGranted
+		request.PolicyID = policyID
+		request.ExpiresAt = &expiresAt
+
+		return nil
+	}, models.AccessRequestActionGranted)
+	if err != nil {
+		return nil, err
+	}
+
+	s.accessPolicyChanged(ctx, nil, granted)
+
+	return request, nil
+}
+
+func (s *service) RejectAccessRequest(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error) {
+	return s.decideAccessRequest(ctx, req, models.AccessRequestPending, func(_ context.Context, request *models.AccessRequest, _ time.Time) error {
+		request.Status = models.AccessRequestRejected
+
+		return nil
+	}, models.AccessRequestActionRejected)
+}
+
+func (s *service) RevokeAccessRequest(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error) {
+	var removed *models.AccessPolicy
+
+	request, err := s.decideAccessRequest(ctx, req, models.AccessRequestGranted, func(ctx context.Context, request *models.AccessRequest, _ time.Time) error {
+		var err error
+		if removed, err = s.removeAccessRequestPolicy(ctx, request); err != nil {
+			return err
+		}
+
+		request.Status = models.AccessRequestRevoked
+
+		return nil
+	}, models.AccessRequestActionRevoked)
+	if err != nil {
+		return nil, err
+	}
+
+	if removed != nil {
+		s.accessPolicyChanged(ctx, removed, nil)
+	}
+
+	s.reevaluateSessions(ctx, req.TenantID, s.activeSessions(ctx, req.TenantID, request.UserID))
+
+	return request, nil
+}
+
+func (s *service) CancelAccessRequest(ctx context.Context, req *requests.AccessRequestCancel) (*models.AccessRequest, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		// Only the requester can withdraw a request, so anyone else's is not
+		// found rather than forbidden.
+		request, err := s.store.AccessRequestResolve(ctx, sc, req.ID, s.store.Options().WithUserID(req.UserID))
+		if err != nil {
+			return NewErrAccessRequestNotFound(req.ID, err)
+		}
+
+		if request.Status != models.AccessRequestPending {
+			return NewErrAccessRequestStatus(request.Status)
+		}
+
+		request.Status = models.AccessRequestCancelled
+
+		return s.transitionAccessRequest(ctx, request, models.AccessRequestPending, models.AccessRequestActionCancelled, req.UserID, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.AccessRequestResolve(ctx, sc, req.ID)
+}
+
+// AccessRequestExpiry moves granted access requests whose window lapsed to
+// expired and removes the Access Policy each one created. Each request is
+// expired in its own transaction, so one failure does not hold back the rest;
+// a failed one is found again on the next run.
+func (s *service) AccessRequestExpiry() worker.CronHandler {
+	return func(ctx context.Context) error {
+		lapsed, err := s.store.AccessRequestListLapsed(ctx, clock.Now())
+		if err != nil {
+			return err
+		}
+
+		expired := 0
+		for i := range lapsed {
+			request := &lapsed[i]
+
+			var removed *models.AccessPolicy
+			if err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				if removed, err = s.removeAccessRequestPolicy(ctx, request); err != nil {
+					return err
+				}
+
+				request.Status = models.AccessRequestExpired
+
+				return s.transitionAccessRequest(ctx, request, models.AccessRequestGranted, models.AccessRequestActionExpired, "", "")
+			}); err != nil {
+				log.WithError(err).WithField("access_request", request.ID).Warn("failed to expire access request")
+
+				continue
+			}
+
+			expired++
+
+			if removed != nil {
+				s.accessPolicyChanged(ctx, removed, nil)
+			}
+
+			s.reevaluateSessions(ctx, request.TenantID, s.activeSessions(ctx, request.TenantID, request.UserID))
+		}
+
+		if expired > 0 {
+			log.WithField("expired", expired).Info("expired lapsed access requests")
+		}
+
+		return nil
+	}
+}
+
+// accessRequestAuditActions maps an approver's decision to the action the audit
+// log records it as.
+var accessRequestAuditActions = map[models.AccessRequestAction]models.AuditAction{
+	models.AccessRequestActionGranted:  models.AuditActionAccessRequestGrant,
+	models.AccessRequestActionRejected: models.AuditActionAccessRequestReject,
+	models.AccessRequestActionRevoked:  models.AuditActionAccessRequestRevoke,
+}
+
+// decideAccessRequest runs an approver's decision on a request in the from
+// status: apply moves the request to its new status, along with whatever durable
+// effect the decision has, and the transition and its audit event are written
+// in the same transaction.
+func (s *service) decideAccessRequest(
+	ctx context.Context,
+	req *requests.AccessRequestDecide,
+	from models.AccessRequestStatus,
+	apply func(ctx context.Context, request *models.AccessRequest, now time.Time) error,
+	action models.AccessRequestAction,
+) (*models.AccessRequest, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var status models.AccessRequestStatus
+
+	err = s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		request, err := s.store.AccessRequestResolve(ctx, sc, req.ID)
+		if err != nil {
+			return NewErrAccessRequestNotFound(req.ID, err)
+		}
+
+		if request.UserID == req.UserID {
+			return NewErrAccessRequestSelfDecision()
+		}
+
+		if request.Status != from {
+			return NewErrAccessRequestStatus(request.Status)
+		}
+
+		now := clock.Now()
+		if err := apply(ctx, request, now); err != nil {
+			return err
+		}
+
+		request.DecidedBy = req.UserID
+		request.DecisionNote = req.Note
+		request.DecidedAt = &now
+		status = request.Status
+
+		return s.transitionAccessRequest(ctx, request, from, action, req.UserID, req.Note)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, &models.AuditEvent{
+		TenantID:   req.TenantID,
+		Action:     accessRequestAuditActions[action],
+		TargetType: models.AuditTargetAccessRequest,
+		TargetID:   req.ID,
+		Before:     map[string]any{"status": string(from)},
+		After:      map[string]any{"status": string(status), "decision_note": req.Note},
+	})
+
+	return s.store.AccessRequestResolve(ctx, sc, req.ID)
+}
+
+// transitionAccessRequest writes the request's new status and appends the
+// matching event. Losing the claim means someone else moved the request first;
+// the error rolls back whatever the caller already did in the transaction.
+func (s *service) transitionAccessRequest(ctx context.Context, request *models.AccessRequest, from models.AccessRequestStatus, action models.AccessRequestAction, actorID, note string) error {
+	moved, err := s.store.AccessRequestTransition(ctx, request, from)
+	if err != nil {
+		return err
+	}
+
+	if !moved {
+		return NewErrAccessRequestStatus("")
+	}
+
+	return s.store.AccessRequestEventCreate(ctx, &models.AccessRequestEvent{
+		AccessRequestID: request.ID,
+		TenantID:        request.TenantID,
+		Action:          action,
+		ActorID:         actorID,
+		Note:            note,
+	})
+}
+
+// removeAccessRequestPolicy deletes the Access Policy a grant created and clears
+// the request's reference to it, returning the policy it deleted. One already
+// removed, e.g. by hand, is not an error; the request still moves on, and nil is
+// returned.
+func (s *service) removeAccessRequestPolicy(ctx context.Context, request *models.AccessRequest) (*models.AccessPolicy, error) {
+	if request.PolicyID == "" {
+		return nil, nil
+	}
+
+	sc, err := BoundTo(request.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.store.AccessPolicyResolve(ctx, sc, store.AccessPolicyIDResolver, request.PolicyID)
+	switch {
+	case errors.Is(err, store.ErrNoDocuments):
+		policy = nil
+	case err != nil:
+		return nil, err
+	default:
+		if err := s.store.AccessPolicyDelete(ctx, &models.AccessPolicy{ID: request.PolicyID, TenantID: request.TenantID}); err != nil && !errors.Is(err, store.ErrNoDocuments) {
+			return nil, err
+		}
+	}
+
+	request.PolicyID = ""
+
+	return policy, nil
+}
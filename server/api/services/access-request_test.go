@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAccessRequest(t *testing.T) {
+	ctx := context.TODO()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	storeMock := new(storemock.MockStore)
+	storeMock.On("TagList", ctx, mock.Anything).
+		Return([]models.Tag{{ID: "tag-web", Name: "web"}}, 1, nil).Once()
+	storeMock.On("WithTransaction", ctx, mock.AnythingOfType("store.TransactionCb")).
+		Return(func(ctx context.Context, cb store.TransactionCb) error { return cb(ctx) }).Once()
+	storeMock.On("AccessRequestCreate", ctx, mock.MatchedBy(func(r *models.AccessRequest) bool {
+		return r.UserID == "alice" && r.Status == models.AccessRequestPending &&
+			assert.ObjectsAreEqual([]string{"tag-web"}, r.Filter.TagIDs)
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.AccessRequest).ID = "request-id"
+	}).Return("request-id", nil).Once()
+	storeMock.On("AccessRequestEventCreate", ctx, &models.AccessRequestEvent{
+		AccessRequestID: "request-id",
+		TenantID:        tenantID,
+		Action:          models.AccessRequestActionRequested,
+		ActorID:         "alice",
+		Note:            "incident 42",
+	}).Return(nil).Once()
+	storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").
+		Return(&models.AccessRequest{ID: "request-id", Status: models.AccessRequestPending}, nil).Once()
+
+	service := NewService(storeMock, privateKey, publicKey, nil)
+
+	request, err := service.CreateAccessRequest(ctx, &requests.AccessRequestCreate{
+		Filter:        requests.AccessPolicyFilter{Tags: []string{"web"}},
+		Logins:        []string{"root"},
+		Duration:      3600,
+		Justification: "incident 42",
+		TenantID:      tenantID,
+		UserID:        "alice",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "request-id", request.ID)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestListAccessRequests(t *testing.T) {
+	ctx := context.TODO()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	for _, all := range []bool{false, true} {
+		storeMock := new(storemock.MockStore)
+		queryOptionsMock := new(storemock.MockQueryOptions)
+		storeMock.On("Options").Return(queryOptionsMock).Maybe()
+		queryOptionsMock.On("Match", mock.Anything).Return(nil).Once()
+		queryOptionsMock.On("Sort", mock.Anything).Return(nil).Once()
+		queryOptionsMock.On("Paginate", mock.Anything).Return(nil).Once()
+		if !all {
+			queryOptionsMock.On("WithUserID", "alice").Return(nil).Once()
+		}
+
+		opts := []any{ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything}
+		if !all {
+			opts = append(opts, mock.Anything)
+		}
+
+		storeMock.On("AccessRequestList", opts...).Return([]models.AccessRequest{}, 0, nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		_, _, err := service.ListAccessRequests(ctx, &requests.AccessRequestList{TenantID: tenantID, UserID: "alice", All: all})
+		require.NoError(t, err)
+
+		// Only an approver's listing may skip the requester filter.
+		if all {
+			queryOptionsMock.AssertNotCalled(t, "WithUserID", mock.Anything)
+		}
+
+		queryOptionsMock.AssertExpectations(t)
+		storeMock.AssertExpectations(t)
+	}
+}
+
+func TestGrantAccessRequest(t *testing.T) {
+	ctx := context.TODO()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	clockMock.On("Now").Return(now)
+
+	pending := func() *models.AccessRequest {
+		return &models.AccessRequest{
+			ID:       "request-id",
+			TenantID: tenantID,
+			UserID:   "alice",
+			Filter:   models.PublicKeyFilter{Taggable: models.Taggable{TagIDs: []string{"tag-web"}}},
+			Logins:   []string{"root"},
+			Duration: 3600,
+			Status:   models.AccessRequestPending,
+		}
+	}
+
+	req := &requests.AccessRequestDecide{
+		AccessRequestIDParam: requests.AccessRequestIDParam{ID: "request-id"},
+		Note:                 "approved for the incident",
+		TenantID:             tenantID,
+		UserID:               "bob",
+	}
+
+	transaction := func(storeMock *storemock.MockStore) {
+		storeMock.On("WithTransaction", ctx, mock.AnythingOfType("store.TransactionCb")).
+			Return(func(ctx context.Context, cb store.TransactionCb) error { return cb(ctx) }).Once()
+	}
+
+	cases := []struct {
+		description  string
+		requireMocks func(storeMock *storemock.MockStore)
+		expectedErr  error
+	}{
+		{
+			description: "fails when the request is not found",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				transaction(storeMock)
+				storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expectedErr: ErrAccessRequestNotFound,
+		},
+		{
+			description: "refuses to let the requester grant their own request",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				transaction(storeMock)
+				request := pending()
+				request.UserID = "bob"
+				storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").Return(request, nil).Once()
+			},
+			expectedErr: ErrAccessRequestSelfDecision,
+		},
+		{
+			description: "refuses a request that is no longer pending",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				transaction(storeMock)
+				request := pending()
+				request.Status = models.AccessRequestRejected
+				storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").Return(request, nil).Once()
+			},
+			expectedErr: ErrAccessRequestStatus,
+		},
+		{
+			description: "fails when a concurrent decision claimed the request first",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				transaction(storeMock)
+				storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").Return(pending(), nil).Once()
+				storeMock.On("AccessPolicyCreate", ctx, mock.Anything).Return("policy-id", nil).Once()
+				storeMock.On("AccessRequestTransition", ctx, mock.Anything, models.AccessRequestPending).Return(false, nil).Once()
+			},
+			expectedErr: ErrAccessRequestStatus,
+		},
+		{
+			description: "grants a time-bounded policy to the requester",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				transaction(storeMock)
+				storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").Return(pending(), nil).Once()
+
+				expiresAt := now.Add(time.Hour)
+				storeMock.On("AccessPolicyCreate", ctx, &models.AccessPolicy{
+					TenantID:        tenantID,
+					Name:            "access request request-id",
+					Subject:         models.PolicySubject{Type: models.PolicySubjectUser, Value: "alice"},
+					Filter:          models.PublicKeyFilter{Taggable: models.Taggable{TagIDs: []string{"tag-web"}}},
+					Logins:          []string{"root"},
+					Action:          models.PolicyActionAllow,
+					NotBefore:       &now,
+					NotAfter:        &expiresAt,
+					AccessRequestID: "request-id",
+				}).Return("policy-id", nil).Once()
+				storeMock.On("AccessRequestTransition", ctx, mock.MatchedBy(func(r *models.AccessRequest) bool {
+					return r.Status == models.AccessRequestGranted && r.PolicyID == "policy-id" &&
+						r.DecidedBy == "bob" && r.DecisionNote == "approved for the incident" &&
+						r.ExpiresAt.Equal(expiresAt)
+				}), models.AccessRequestPending).Return(true, nil).Once()
+				storeMock.On("AccessRequestEventCreate", ctx, &models.AccessRequestEvent{
+					AccessRequestID: "request-id",
+					TenantID:        tenantID,
+					Action:          models.AccessRequestActionGranted,
+					ActorID:         "bob",
+					Note:            "approved for the incident",
+				}).Return(nil).Once()
+				storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").
+					Return(&models.AccessRequest{ID: "request-id", Status: models.AccessRequestGranted}, nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionAccessRequestGrant && event.TargetType == models.AuditTargetAccessRequest &&
+						event.TargetID == "request-id" && event.After["status"] == string(models.AccessRequestGranted)
+				})).Return(nil).Once()
+				// The granted policy is audited like one created by hand, once the grant is committed.
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.TenantID == tenantID && event.Action == models.AuditActionAccessPolicyCreate &&
+						event.TargetID == "policy-id" && event.After["access_request_id"] == "request-id"
+				})).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := new(storemock.MockStore)
+			tc.requireMocks(storeMock)
+
+			service := NewService(storeMock, privateKey, publicKey, nil)
+
+			request, err := service.GrantAccessRequest(ctx, req)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, models.AccessRequestGranted, request.Status)
+			}
+
+			storeMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRevokeAccessRequest(t *testing.T) {
+	ctx := context.TODO()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	clockMock.On("Now").Return(now)
+
+	policy := &models.AccessPolicy{ID: "policy-id", TenantID: tenantID, Name: "access request request-id", AccessRequestID: "request-id"}
+
+	for _, resolveErr := range []error{nil, store.ErrNoDocuments} {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("WithTransaction", ctx, mock.AnythingOfType("store.TransactionCb")).
+			Return(func(ctx context.Context, cb store.TransactionCb) error { return cb(ctx) }).Once()
+		storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").Return(&models.AccessRequest{
+			ID:       "request-id",
+			TenantID: tenantID,
+			UserID:   "alice",
+			Status:   models.AccessRequestGranted,
+			PolicyID: "policy-id",
+		}, nil).Once()
+		// A policy someone already removed by hand does not hold the revocation up.
+		if resolveErr == nil {
+			storeMock.On("AccessPolicyResolve", ctx, mock.Anything, store.AccessPolicyIDResolver, "policy-id").Return(policy, nil).Once()
+			storeMock.On("AccessPolicyDelete", ctx, &models.AccessPolicy{ID: "policy-id", TenantID: tenantID}).Return(nil).Once()
+			storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+				return event.Action == models.AuditActionAccessPolicyDelete && event.TargetID == "policy-id" && event.After == nil
+			})).Return(nil).Once()
+		} else {
+			storeMock.On("AccessPolicyResolve", ctx, mock.Anything, store.AccessPolicyIDResolver, "policy-id").Return(nil, resolveErr).Once()
+		}
+		storeMock.On("AccessRequestTransition", ctx, mock.MatchedBy(func(r *models.AccessRequest) bool {
+			return r.Status == models.AccessRequestRevoked && r.PolicyID == "" && r.DecidedBy == "bob"
+		}), models.AccessRequestGranted).Return(true, nil).Once()
+		storeMock.On("AccessRequestEventCreate", ctx, mock.MatchedBy(func(e *models.AccessRequestEvent) bool {
+			return e.Action == models.AccessRequestActionRevoked && e.ActorID == "bob"
+		})).Return(nil).Once()
+		storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").
+			Return(&models.AccessRequest{ID: "request-id", UserID: "alice", Status: models.AccessRequestRevoked}, nil).Once()
+		storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+			return event.Action == models.AuditActionAccessRequestRevoke && event.TargetID == "request-id"
+		})).Return(nil).Once()
+		// The requester's sessions are asked again; alice has none open.
+		storeMock.On("SessionListActive", ctx, mock.Anything, "alice").Return([]models.Session{}, nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		_, err := service.RevokeAccessRequest(ctx, &requests.AccessRequestDecide{
+			AccessRequestIDParam: requests.AccessRequestIDParam{ID: "request-id"},
+			TenantID:             tenantID,
+			UserID:               "bob",
+		})
+		require.NoError(t, err)
+
+		storeMock.AssertExpectations(t)
+	}
+}
+
+func TestCancelAccessRequest(t *testing.T) {
+	ctx := context.TODO()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	req := &requests.AccessRequestCancel{
+		AccessRequestIDParam: requests.AccessRequestIDParam{ID: "request-id"},
+		TenantID:             tenantID,
+		UserID:               "alice",
+	}
+
+	cases := []struct {
+		description  string
+		status       models.AccessRequestStatus
+		requireMocks func(storeMock *storemock.MockStore)
+		expectedErr  error
+	}{
+		{
+			description:  "refuses a request already decided",
+			status:       models.AccessRequestGranted,
+			requireMocks: func(*storemock.MockStore) {},
+			expectedErr:  ErrAccessRequestStatus,
+		},
+		{
+			description: "withdraws a pending request",
+			status:      models.AccessRequestPending,
+			requireMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("AccessRequestTransition", ctx, mock.MatchedBy(func(r *models.AccessRequest) bool {
+					return r.Status == models.AccessRequestCancelled && r.DecidedBy == ""
+				}), models.AccessRequestPending).Return(true, nil).Once()
+				storeMock.On("AccessRequestEventCreate", ctx, mock.MatchedBy(func(e *models.AccessRequestEvent) bool {
+					return e.Action == models.AccessRequestActionCancelled && e.ActorID == "alice"
+				})).Return(nil).Once()
+				storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id").
+					Return(&models.AccessRequest{ID: "request-id", Status: models.AccessRequestCancelled}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := new(storemock.MockStore)
+			queryOptionsMock := new(storemock.MockQueryOptions)
+			storeMock.On("Options").Return(queryOptionsMock).Maybe()
+			queryOptionsMock.On("WithUserID", "alice").Return(nil).Once()
+			storeMock.On("WithTransaction", ctx, mock.AnythingOfType("store.TransactionCb")).
+				Return(func(ctx context.Context, cb store.TransactionCb) error { return cb(ctx) }).Once()
+			storeMock.On("AccessRequestResolve", ctx, mock.Anything, "request-id", mock.Anything).
+				Return(&models.AccessRequest{ID: "request-id", TenantID: tenantID, UserID: "alice", Status: tc.status}, nil).Once()
+			tc.requireMocks(storeMock)
+
+			service := NewService(storeMock, privateKey, publicKey, nil)
+
+			_, err := service.CancelAccessRequest(ctx, req)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			storeMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_AccessRequestExpiry(t *testing.T) {
+	ctx := context.TODO()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	clockMock.On("Now").Return(now)
+
+	storeMock := new(storemock.MockStore)
+	storeMock.On("AccessRequestListLapsed", ctx, now).Return([]models.AccessRequest{
+		{ID: "broken", TenantID: tenantID, Status: models.AccessRequestGranted, PolicyID: "broken-policy", DecidedBy: "bob"},
+		{ID: "lapsed", TenantID: tenantID, UserID: "alice", Status: models.AccessRequestGranted, PolicyID: "lapsed-policy", DecidedBy: "bob"},
+	}, nil).Once()
+	storeMock.On("WithTransaction", ctx, mock.AnythingOfType("store.TransactionCb")).
+		Return(func(ctx context.Context, cb store.TransactionCb) error { return cb(ctx) }).Twice()
+
+	// A failure on one request is logged and left for the next run; the rest
+	// still expire.
+	storeMock.On("AccessPolicyResolve", ctx, mock.Anything, store.AccessPolicyIDResolver, "broken-policy").
+		Return(&models.AccessPolicy{ID: "broken-policy", TenantID: tenantID}, nil).Once()
+	storeMock.On("AccessPolicyDelete", ctx, &models.AccessPolicy{ID: "broken-policy", TenantID: tenantID}).
+		Return(errors.New("connection reset")).Once()
+
+	storeMock.On("AccessPolicyResolve", ctx, mock.Anything, store.AccessPolicyIDResolver, "lapsed-policy").
+		Return(&models.AccessPolicy{ID: "lapsed-policy", TenantID: tenantID}, nil).Once()
+	storeMock.On("AccessPolicyDelete", ctx, &models.AccessPolicy{ID: "lapsed-policy", TenantID: tenantID}).Return(nil).Once()
+	// Only the policy of a request that did expire is audited as deleted.
+	storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionAccessPolicyDelete && event.TargetID == "lapsed-policy"
+	})).Return(nil).Once()
+	storeMock.On("AccessRequestTransition", ctx, mock.MatchedBy(func(r *models.AccessRequest) bool {
+		return r.ID == "lapsed" && r.Status == models.AccessRequestExpired && r.PolicyID == "" && r.DecidedBy == "bob"
+	}), models.AccessRequestGranted).Return(true, nil).Once()
+	storeMock.On("AccessRequestEventCreate", ctx, &models.AccessRequestEvent{
+		AccessRequestID: "lapsed",
+		TenantID:        tenantID,
+		Action:          models.AccessRequestActionExpired,
+	}).Return(nil).Once()
+	// Only the sessions of a request that did expire are asked again.
+	storeMock.On("SessionListActive", ctx, mock.Anything, "alice").Return([]models.Session{}, nil).Once()
+
+	service := NewService(storeMock, privateKey, publicKey, nil)
+
+	require.NoError(t, service.AccessRequestExpiry()(ctx))
+
+	storeMock.AssertExpectations(t)
+}
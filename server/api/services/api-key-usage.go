@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// apiKeyUsageFlushInterval is how long the uses accumulate before being
+	// written, and so how stale a key's counter and last use may read.
+	apiKeyUsageFlushInterval = 10 * time.Second
+
+	// apiKeyUsageBatchSize flushes early once this many keys are pending,
+	// bounding the size of a single statement.
+	apiKeyUsageBatchSize = 1000
+
+	// apiKeyUsageQueueSize is the submission buffer, absorbing a burst of
+	// requests between two reads of the loop before Submit has to drop one.
+	apiKeyUsageQueueSize = 4096
+
+	// apiKeyUsageWriteTimeout bounds a single bulk write.
+	apiKeyUsageWriteTimeout = 30 * time.Second
+)
+
+type apiKeyUsageKey struct {
+	tenantID string
+	id       string
+}
+
+type apiKeyUse struct {
+	key apiKeyUsageKey
+	at  time.Time
+}
+
+// APIKeyUsageRecorder coalesces the requests the API keys authenticate into
+// bulk counter updates.
+//
+// Writing one statement per request would put a commit on the database for
+// every call an integration makes, on the path of the call itself. Batching
+// collapses a whole flush window into a single UPDATE, which keeps the write
+// rate proportional to time rather than to traffic.
+//
+// Submit never blocks. A full queue drops the use instead: the counters tell
+// how much a key is used, they are not an audit of each request.
+type APIKeyUsageRecorder struct {
+	store   store.Store
+	queue   chan apiKeyUse
+	done    chan struct{}
+	wg      sync.WaitGroup
+	dropped atomic.Uint64
+}
+
+// NewAPIKeyUsageRecorder returns a running recorder. Call Shutdown to flush
+// what is pending and stop it.
+func NewAPIKeyUsageRecorder(s store.Store) *APIKeyUsageRecorder {
+	r := &APIKeyUsageRecorder{
+		store: s,
+		queue: make(chan apiKeyUse, apiKeyUsageQueueSize),
+		done:  make(chan struct{}),
+	}
+
+	r.wg.Add(1)
+	go r.run()
+
+	return r
+}
+
+// Submit records a request the API key authenticated. It never blocks.
+func (r *APIKeyUsageRecorder) Submit(apiKey *models.APIKey) {
+	if apiKey == nil {
+		return
+	}
+
+	select {
+	case r.queue <- apiKeyUse{key: apiKeyUsageKey{tenantID: apiKey.TenantID, id: apiKey.ID}, at: clock.Now()}:
+	default:
+		if dropped := r.dropped.Add(1); dropped%1000 == 1 {
+			log.WithField("dropped", dropped).
+				Warn("API key usage queue is full; uses are being dropped")
+		}
+	}
+}
+
+// Shutdown stops accepting uses and writes what is already pending.
+func (r *APIKeyUsageRecorder) Shutdown(ctx context.Context) error {
+	close(r.done)
+
+	waited := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *APIKeyUsageRecorder) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(apiKeyUsageFlushInterval)
+	defer ticker.Stop()
+
+	batch := newAPIKeyUsageBatch()
+
+	for {
+		select {
+		case use := <-r.queue:
+			batch.add(use)
+			if batch.len() >= apiKeyUsageBatchSize {
+				r.flush(batch)
+			}
+		case <-ticker.C:
+			r.flush(batch)
+		case <-r.done:
+			r.drain(batch)
+			r.flush(batch)
+
+			return
+		}
+	}
+}
+
+// drain empties the queue without blocking, so a shutdown does not lose uses
+// that were submitted but not yet read by the loop.
+func (r *APIKeyUsageRecorder) drain(batch *apiKeyUsageBatch) {
+	for {
+		select {
+		case use := <-r.queue:
+			batch.add(use)
+		default:
+			return
+		}
+	}
+}
+
+func (r *APIKeyUsageRecorder) flush(batch *apiKeyUsageBatch) {
+	uses := batch.take()
+	if len(uses) == 0 {
+		return
+	}
+
+	// The recorder owns this context: it is not derived from any request, so
+	// one request ending early cannot cancel the uses of all the others.
+	ctx, cancel := context.WithTimeout(context.Background(), apiKeyUsageWriteTimeout)
+	defer cancel()
+
+	if err := r.store.APIKeyRecordUses(ctx, uses); err != nil {
+		log.WithError(err).
+			WithField("keys", len(uses)).
+			Error("failed to write the API key usage batch")
+
+		return
+	}
+
+	log.WithField("keys", len(uses)).Debug("wrote the API key usage batch")
+}
+
+// apiKeyUsageBatch accumulates the uses of each key seen in a flush window.
+type apiKeyUsageBatch struct {
+	uses map[apiKeyUsageKey]*store.APIKeyUse
+}
+
+func newAPIKeyUsageBatch() *apiKeyUsageBatch {
+	return &apiKeyUsageBatch{uses: make(map[apiKeyUsageKey]*store.APIKeyUse)}
+}
+
+func (b *apiKeyUsageBatch) add(use apiKeyUse) {
+	pending, ok := b.uses[use.key]
+	if !ok {
+		pending = &store.APIKeyUse{TenantID: use.key.tenantID, ID: use.key.id}
+		b.uses[use.key] = pending
+	}
+
+	pending.Count++
+	if use.at.After(pending.LastUsedAt) {
+		pending.LastUsedAt = use.at
+	}
+}
+
+func (b *apiKeyUsageBatch) len() int {
+	return len(b.uses)
+}
+
+func (b *apiKeyUsageBatch) take() []store.APIKeyUse {
+	if len(b.uses) == 0 {
+		return nil
+	}
+
+	uses := make([]store.APIKeyUse, 0, len(b.uses))
+	for _, use := range b.uses {
+		uses = append(uses, *use)
+	}
+
+	// Sorted so the UPDATE always touches rows in the same order, which keeps
+	// concurrent batches from deadlocking each other.
+	slices.SortFunc(uses, func(a, b store.APIKeyUse) int {
+		if c := strings.Compare(a.TenantID, b.TenantID); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	b.uses = make(map[apiKeyUsageKey]*store.APIKeyUse)
+
+	return uses
+}
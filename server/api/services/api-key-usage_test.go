@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyUsageRecorder_sumsTheUsesOfEachKey(t *testing.T) {
+	earliest := now
+	latest := now.Add(2 * time.Second)
+
+	fixedClock(t, earliest, earliest, latest)
+
+	storeMock := storemock.NewMockStore(t)
+	// key-a authenticated twice: a key is one row, so the batch must add both uses
+	// to it in one go, stamped with the latest of them.
+	storeMock.
+		On("APIKeyRecordUses", mock.Anything, []store.APIKeyUse{
+			{TenantID: "tenant", ID: "key-a", Count: 2, LastUsedAt: latest},
+			{TenantID: "tenant", ID: "key-b", Count: 1, LastUsedAt: earliest},
+		}).
+		Return(nil).
+		Once()
+
+	r := NewAPIKeyUsageRecorder(storeMock)
+
+	r.Submit(&models.APIKey{TenantID: "tenant", ID: "key-a"})
+	r.Submit(&models.APIKey{TenantID: "tenant", ID: "key-b"})
+	r.Submit(&models.APIKey{TenantID: "tenant", ID: "key-a"})
+
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAPIKeyUsageRecorder_keepsKeysOfDifferentNamespacesApart(t *testing.T) {
+	fixedClock(t, now)
+
+	storeMock := storemock.NewMockStore(t)
+	// The digest is only unique within a namespace, so the same one in two
+	// namespaces is two keys.
+	storeMock.
+		On("APIKeyRecordUses", mock.Anything, []store.APIKeyUse{
+			{TenantID: "tenant-a", ID: "key", Count: 1, LastUsedAt: now},
+			{TenantID: "tenant-b", ID: "key", Count: 1, LastUsedAt: now},
+		}).
+		Return(nil).
+		Once()
+
+	r := NewAPIKeyUsageRecorder(storeMock)
+
+	r.Submit(&models.APIKey{TenantID: "tenant-b", ID: "key"})
+	r.Submit(&models.APIKey{TenantID: "tenant-a", ID: "key"})
+
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAPIKeyUsageRecorder_survivesAStoreFailure(t *testing.T) {
+	fixedClock(t, now)
+
+	storeMock := storemock.NewMockStore(t)
+	storeMock.
+		On("APIKeyRecordUses", mock.Anything, []store.APIKeyUse{{TenantID: "tenant", ID: "key-a", Count: 1, LastUsedAt: now}}).
+		Return(errors.New("error")).
+		Once()
+
+	r := NewAPIKeyUsageRecorder(storeMock)
+
+	r.Submit(&models.APIKey{TenantID: "tenant", ID: "key-a"})
+
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAPIKeyUsageRecorder_submitDoesNotBlockWhenTheQueueIsFull(t *testing.T) {
+	fixedClock(t, now)
+
+	storeMock := storemock.NewMockStore(t)
+	storeMock.
+		On("APIKeyRecordUses", mock.Anything, mock.Anything).
+		Return(nil).
+		Maybe()
+
+	r := NewAPIKeyUsageRecorder(storeMock)
+
+	// Submit runs on the request's path: blocking it would delay the very request
+	// it is counting, so a full queue has to drop instead.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := 0; i < apiKeyUsageQueueSize*2; i++ {
+			r.Submit(&models.APIKey{TenantID: "tenant", ID: "key-a"})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Submit blocked when the queue was full")
+	}
+
+	require.NoError(t, r.Shutdown(context.Background()))
+}
@@ -29,6 +29,11 @@ type APIKeyService interface {
 
 	// DeleteAPIKey deletes an API key with the provided tenant ID and name. It returns an error, if any.
 	DeleteAPIKey(ctx context.Context, req *requests.DeleteAPIKey) (err error)
+
+	// RecordAPIKeyUsage counts a request authenticated by the API key and stamps its last use. The
+	// use is handed to the usage recorder, which writes it in a later batch, so it never delays nor
+	// refuses a request that authenticated.
+	RecordAPIKeyUsage(ctx context.Context, apiKey *models.APIKey)
 }
 
 func (s *service) CreateAPIKey(ctx context.Context, req *requests.CreateAPIKey) (*responses.CreateAPIKey, error) {
@@ -78,12 +83,15 @@ func (s *service) CreateAPIKey(ctx context.Context, req *requests.CreateAPIKey)
 	}
 
 	data := &models.APIKey{
-		ID:        hashedKey,
-		Name:      req.Name,
-		TenantID:  req.TenantID,
-		Role:      req.Role,
-		ExpiresIn: expiresIn,
-		CreatedBy: req.UserID,
+		ID:          hashedKey,
+		Name:        req.Name,
+		TenantID:    req.TenantID,
+		Role:        req.Role,
+		ExpiresIn:   expiresIn,
+		CreatedBy:   req.UserID,
+		Permissions: req.Permissions,
+		Tags:        req.Tags,
+		SourceIPs:   req.SourceIPs,
 	}
 
 	if _, err := s.store.APIKeyCreate(ctx, data); err != nil {
@@ -93,6 +101,16 @@ func (s *service) CreateAPIKey(ctx context.Context, req *requests.CreateAPIKey)
 	// As we need to return the plain key in the create service, we temporarily set
 	// the apiKey.ID to the plain key here.
 	apiKey, _ := s.store.APIKeyResolve(ctx, sc, store.APIKeyIDResolver, hashedKey)
+
+	_, after := auditDiff(nil, apiKey)
+	s.recordAudit(ctx, &models.AuditEvent{
+		TenantID:   req.TenantID,
+		Action:     models.AuditActionAPIKeyCreate,
+		TargetType: models.AuditTargetAPIKey,
+		TargetID:   req.Name,
+		After:      after,
+	})
+
 	apiKey.ID = req.Key
 
 	return responses.CreateAPIKeyFromModel(apiKey), nil
@@ -140,6 +158,8 @@ func (s *service) UpdateAPIKey(ctx context.Context, req *requests.UpdateAPIKey)
 		}
 	}
 
+	current := *apiKey
+
 	if apiKey.Name != req.Name {
 		if conflicts, has, _ := s.store.APIKeyConflicts(ctx, sc, &models.APIKeyConflicts{Name: req.Name}); has {
 			return NewErrAPIKeyDuplicated(conflicts)
@@ -152,11 +172,22 @@ func (s *service) UpdateAPIKey(ctx context.Context, req *requests.UpdateAPIKey)
 	if string(req.Role) != "" {
 		apiKey.Role = req.Role
 	}
+	if req.Permissions != nil {
+		apiKey.Permissions = req.Permissions
+	}
+	if req.Tags != nil {
+		apiKey.Tags = req.Tags
+	}
+	if req.SourceIPs != nil {
+		apiKey.SourceIPs = req.SourceIPs
+	}
 
-	if err := s.store.APIKeyUpdate(ctx, apiKey); err != nil { //nolint:revive
+	if err := s.store.APIKeyUpdate(ctx, apiKey); err != nil {
 		return err
 	}
 
+	s.recordAuditChange(ctx, req.TenantID, models.AuditActionAPIKeyUpdate, models.AuditTargetAPIKey, apiKey.Name, &current, apiKey)
+
 	return nil
 }
 
@@ -176,9 +207,19 @@ func (s *service) DeleteAPIKey(ctx context.Context, req *requests.DeleteAPIKey)
 		}
 	}
 
-	if err := s.store.APIKeyDelete(ctx, apiKey); err != nil { //nolint:revive
+	if err := s.store.APIKeyDelete(ctx, apiKey); err != nil {
 		return err
 	}
 
+	s.recordAuditChange(ctx, req.TenantID, models.AuditActionAPIKeyDelete, models.AuditTargetAPIKey, apiKey.Name, apiKey, nil)
+
 	return nil
 }
+
+func (s *service) RecordAPIKeyUsage(_ context.Context, apiKey *models.APIKey) {
+	if s.apiKeyUsage == nil {
+		return
+	}
+
+	s.apiKeyUsage.Submit(apiKey)
+}
@@ -281,6 +281,15 @@ func TestCreateAPIKey(t *testing.T) {
 						ExpiresIn: -1,
 					}, nil).
 					Once()
+				// The key's digest is hidden from the API, and so from the audit log.
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						_, hasID := event.After["id"]
+
+						return event.Action == models.AuditActionAPIKeyCreate && event.TargetID == "dev" && event.After["name"] == "dev" && !hasID
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: &responses.CreateAPIKey{
@@ -359,6 +368,15 @@ func TestCreateAPIKey(t *testing.T) {
 						ExpiresIn: -1,
 					}, nil).
 					Once()
+				// The key's digest is hidden from the API, and so from the audit log.
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						_, hasID := event.After["id"]
+
+						return event.Action == models.AuditActionAPIKeyCreate && event.TargetID == "dev" && event.After["name"] == "dev" && !hasID
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: &responses.CreateAPIKey{
@@ -696,6 +714,13 @@ func TestUpdateAPIKey(t *testing.T) {
 					On("APIKeyUpdate", ctx, updatedAPIKey).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionAPIKeyUpdate && event.TargetID == "newName" &&
+							event.Before["name"] == "dev" && event.After["name"] == "newName"
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -735,6 +760,12 @@ func TestUpdateAPIKey(t *testing.T) {
 					On("APIKeyUpdate", ctx, updatedAPIKey).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionAPIKeyUpdate
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -795,6 +826,66 @@ func TestUpdateAPIKey(t *testing.T) {
 					On("APIKeyUpdate", ctx, updatedAPIKey).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionAPIKeyUpdate
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds replacing the scope and clearing the tags",
+			req: &requests.UpdateAPIKey{
+				UserID:      "000000000000000000000000",
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				CurrentName: "dev",
+				Permissions: []string{"device:connect"},
+				Tags:        []string{},
+			},
+			requiredMocks: func(ctx context.Context) {
+				existingAPIKey := &models.APIKey{
+					ID:        "existing-id",
+					Name:      "dev",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					Role:      "operator",
+					Tags:      []string{"production"},
+					SourceIPs: []string{"198.51.100.0/24"},
+				}
+
+				updatedAPIKey := &models.APIKey{
+					ID:          "existing-id",
+					Name:        "dev",
+					TenantID:    "00000000-0000-4000-0000-000000000000",
+					Role:        "operator",
+					Permissions: []string{"device:connect"},
+					Tags:        []string{},
+					SourceIPs:   []string{"198.51.100.0/24"},
+				}
+
+				storeMock.
+					On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Members: []models.Member{{ID: "000000000000000000000000", Role: "owner"}}}, nil).
+					Once()
+				storeMock.
+					On("APIKeyResolve", ctx, mock.Anything, store.APIKeyNameResolver, "dev").
+					Return(existingAPIKey, nil).
+					Once()
+				storeMock.
+					On("APIKeyConflicts", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), &models.APIKeyConflicts{Name: ""}).
+					Return([]string{}, false, nil).
+					Once()
+				storeMock.
+					On("APIKeyUpdate", ctx, updatedAPIKey).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionAPIKeyUpdate
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -890,6 +981,12 @@ func TestDeleteAPIKey(t *testing.T) {
 					On("APIKeyDelete", ctx, existingAPIKey).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionAPIKeyDelete && event.TargetID == "dev" && event.After == nil
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -912,3 +1009,40 @@ func TestDeleteAPIKey(t *testing.T) {
 
 	storeMock.AssertExpectations(t)
 }
+
+func TestRecordAPIKeyUsage(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	apiKey := &models.APIKey{ID: "existing-id", Name: "dev", TenantID: "00000000-0000-4000-0000-000000000000"}
+
+	t.Run("hands the use to the recorder rather than writing it", func(t *testing.T) {
+		fixedClock(t, now)
+
+		storeMock := storemock.NewMockStore(t)
+		recorder := NewAPIKeyUsageRecorder(storeMock)
+
+		s := NewService(storeMock, privateKey, &privateKey.PublicKey, storecache.NewNullCache(), WithAPIKeyUsageRecorder(recorder))
+
+		s.RecordAPIKeyUsage(context.Background(), apiKey)
+
+		// Nothing is written on the request's path: the use only reaches the store
+		// when the recorder flushes.
+		storeMock.AssertNotCalled(t, "APIKeyRecordUses", mock.Anything, mock.Anything)
+
+		storeMock.
+			On("APIKeyRecordUses", mock.Anything, []store.APIKeyUse{{TenantID: apiKey.TenantID, ID: apiKey.ID, Count: 1, LastUsedAt: now}}).
+			Return(nil).
+			Once()
+
+		require.NoError(t, recorder.Shutdown(context.Background()))
+	})
+
+	t.Run("records nothing without a recorder", func(t *testing.T) {
+		storeMock := storemock.NewMockStore(t)
+
+		s := NewService(storeMock, privateKey, &privateKey.PublicKey, storecache.NewNullCache())
+
+		require.NotPanics(t, func() { s.RecordAPIKeyUsage(context.Background(), apiKey) })
+	})
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/worker"
+	"github.com/shellhub-io/shellhub/server/api/pkg/authctx"
+	log "github.com/sirupsen/logrus"
+)
+
+// AuditEventFilterFields maps each filter field the audit log endpoint accepts
+// to the set of operators valid for it.
+var AuditEventFilterFields = query.NewFieldConstraints(map[string][]string{
+	"action":      {"contains", "eq", "ne"},
+	"actor_type":  {"eq", "ne"},
+	"actor_id":    {"eq"},
+	"target_type": {"eq", "ne"},
+	"target_id":   {"eq"},
+})
+
+// AuditEventSortFields is the set of field names accepted in the sort_by query
+// parameter when listing the audit log.
+var AuditEventSortFields = query.NewFieldSet(
+	"created_at",
+	"action",
+)
+
+type AuditEventService interface {
+	// ListAuditEvents returns a page of the namespace's audit log, newest first
+	// unless the request sorts it otherwise.
+	ListAuditEvents(ctx context.Context, req *requests.AuditEventList) ([]models.AuditEvent, int, error)
+}
+
+func (s *service) ListAuditEvents(ctx context.Context, req *requests.AuditEventList) ([]models.AuditEvent, int, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if req.Sorter.By == "" {
+		req.Sorter.By = "created_at"
+	}
+
+	if req.Sorter.Order == "" {
+		req.Sorter.Order = query.OrderDesc
+	}
+
+	req.Sorter.Tiebreak = "id"
+
+	return s.store.AuditEventList(
+		ctx,
+		sc,
+		s.store.Options().Match(&req.Filters),
+		s.store.Options().Sort(&req.Sorter),
+		s.store.Options().Paginate(&req.Paginator),
+	)
+}
+
+// AuditCleanup enforces the instance's audit retention window: events recorded longer ago than
+// retention are deleted. Like [service.SessionCleanup], a retention that is not positive keeps
+// everything.
+func (s *service) AuditCleanup(retention time.Duration) worker.CronHandler {
+	return func(ctx context.Context) error {
+		if retention <= 0 {
+			return nil
+		}
+
+		deleted, err := s.store.AuditEventDeleteBefore(ctx, clock.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		if deleted > 0 {
+			log.WithField("deleted", deleted).Info("pruned audit events past the retention window")
+		}
+
+		return nil
+	}
+}
+
+// recordAudit appends event to its namespace's audit log, attributed to the origin authentication
+// recorded for the request in ctx. It is called once the action took effect and never fails it:
+// the change is already made, and an error would tell the caller it was not. A failure to record
+// is logged instead.
+func (s *service) recordAudit(ctx context.Context, event *models.AuditEvent) {
+	if origin, ok := authctx.OriginFrom(ctx); ok {
+		event.Actor = origin.Actor
+		event.SourceIP = origin.SourceIP
+		event.RequestID = origin.RequestID
+	}
+
+	if err := s.store.AuditEventCreate(ctx, event); err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{"tenant_id": event.TenantID, "action": event.Action, "target_id": event.TargetID}).
+			Error("failed to record the audit event")
+	}
+}
+
+// recordAuditChange records an action that changed target id from before to after. before is nil
+// for a creation and after for a removal.
+func (s *service) recordAuditChange(ctx context.Context, tenantID string, action models.AuditAction, target models.AuditTargetType, id string, before, after any) {
+	event := &models.AuditEvent{
+		TenantID:   tenantID,
+		Action:     action,
+		TargetType: target,
+		TargetID:   id,
+	}
+
+	event.Before, event.After = auditDiff(before, after)
+
+	s.recordAudit(ctx, event)
+}
+
+// auditDiff renders before and after as the JSON fields the API shows for them and keeps only the
+// ones that differ. A nil side stays nil, so a creation records the whole object as after and a
+// removal as before. Fields the API hides, like secrets and digests, never reach the log.
+func auditDiff(before, after any) (map[string]any, map[string]any) {
+	b, a := auditFields(before), auditFields(after)
+	if b == nil || a == nil {
+		return b, a
+	}
+
+	for field, value := range b {
+		if other, ok := a[field]; ok && reflect.DeepEqual(value, other) {
+			delete(b, field)
+			delete(a, field)
+		}
+	}
+
+	return b, a
+}
+
+func auditFields(v any) map[string]any {
+	if v == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	return fields
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/pkg/authctx"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	ctx := context.TODO()
+
+	storeMock := new(storemock.MockStore)
+	queryOptionsMock := new(storemock.MockQueryOptions)
+	storeMock.On("Options").Return(queryOptionsMock).Maybe()
+	queryOptionsMock.On("Match", mock.Anything).Return(nil).Once()
+	queryOptionsMock.On("Sort", &query.Sorter{By: "created_at", Order: query.OrderDesc, Tiebreak: "id"}).Return(nil).Once()
+	queryOptionsMock.On("Paginate", mock.Anything).Return(nil).Once()
+	storeMock.On("AuditEventList", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.AuditEvent{{ID: "event-id"}}, 1, nil).Once()
+
+	service := NewService(storeMock, privateKey, publicKey, nil)
+
+	events, count, err := service.ListAuditEvents(ctx, &requests.AuditEventList{TenantID: "00000000-0000-4000-0000-000000000000"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []models.AuditEvent{{ID: "event-id"}}, events)
+
+	queryOptionsMock.AssertExpectations(t)
+	storeMock.AssertExpectations(t)
+}
+
+// An audited action is attributed to the origin authentication recorded for the request.
+func TestDeleteAccessPolicyRecordsAudit(t *testing.T) {
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	ctx := authctx.WithOrigin(context.TODO(), authctx.Origin{
+		Actor:     models.AuditActor{Type: models.AuditActorUser, ID: "user-id", Name: "john"},
+		SourceIP:  "192.0.2.10",
+		RequestID: "request-id",
+	})
+
+	policy := &models.AccessPolicy{ID: "policy-id", TenantID: tenantID, Name: "ops", Action: models.PolicyActionAllow}
+
+	storeMock := new(storemock.MockStore)
+	storeMock.On("AccessPolicyResolve", ctx, mock.Anything, store.AccessPolicyIDResolver, "policy-id").Return(policy, nil).Once()
+	storeMock.On("AccessPolicyDelete", ctx, &models.AccessPolicy{ID: "policy-id", TenantID: tenantID}).Return(nil).Once()
+	storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.TenantID == tenantID &&
+			event.Actor == models.AuditActor{Type: models.AuditActorUser, ID: "user-id", Name: "john"} &&
+			event.Action == models.AuditActionAccessPolicyDelete &&
+			event.TargetType == models.AuditTargetAccessPolicy &&
+			event.TargetID == "policy-id" &&
+			event.Before["name"] == "ops" &&
+			event.After == nil &&
+			event.SourceIP == "192.0.2.10" &&
+			event.RequestID == "request-id"
+	})).Return(nil).Once()
+	storeMock.On("SessionListActive", ctx, mock.Anything, "").Return([]models.Session{}, nil).Once()
+
+	service := NewService(storeMock, privateKey, publicKey, nil)
+
+	require.NoError(t, service.DeleteAccessPolicy(ctx, &requests.AccessPolicyDelete{
+		AccessPolicyIDParam: requests.AccessPolicyIDParam{ID: "policy-id"},
+		TenantID:            tenantID,
+	}))
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestService_AuditCleanup(t *testing.T) {
+	ctx := context.TODO()
+
+	clockMock.On("Now").Return(now)
+
+	t.Run("keeps everything without a retention", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		require.NoError(t, service.AuditCleanup(0)(ctx))
+
+		storeMock.AssertNotCalled(t, "AuditEventDeleteBefore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("deletes events past the retention window", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("AuditEventDeleteBefore", ctx, now.Add(-30*24*time.Hour)).Return(int64(3), nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		require.NoError(t, service.AuditCleanup(30*24*time.Hour)(ctx))
+
+		storeMock.AssertExpectations(t)
+	})
+}
+
+func TestAuditDiff(t *testing.T) {
+	type target struct {
+		Name   string `json:"name"`
+		Role   string `json:"role"`
+		Secret string `json:"-"`
+	}
+
+	cases := []struct {
+		description    string
+		before         any
+		after          any
+		expectedBefore map[string]any
+		expectedAfter  map[string]any
+	}{
+		{
+			description:   "records the whole object on a creation",
+			after:         target{Name: "ops", Role: "observer", Secret: "s3cr3t"},
+			expectedAfter: map[string]any{"name": "ops", "role": "observer"},
+		},
+		{
+			description:    "records the whole object on a removal",
+			before:         target{Name: "ops", Role: "observer"},
+			expectedBefore: map[string]any{"name": "ops", "role": "observer"},
+		},
+		{
+			description:    "keeps only the changed fields on an update",
+			before:         target{Name: "ops", Role: "observer"},
+			after:          target{Name: "ops", Role: "operator"},
+			expectedBefore: map[string]any{"role": "observer"},
+			expectedAfter:  map[string]any{"role": "operator"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			before, after := auditDiff(tc.before, tc.after)
+			assert.Equal(t, tc.expectedBefore, before)
+			assert.Equal(t, tc.expectedAfter, after)
+		})
+	}
+}
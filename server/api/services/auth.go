@@ -44,7 +44,8 @@ type AuthService interface {
 	// It will try to use the user's preferred namespace or the first one to which the user was added. As the
 	// authentication key is a JWT, in these cases, the response does not contain the member role to avoid creating
 	// a stateful token. The role must be added in the auth middleware. The TenantID in the response will be empty if the user
-	// is not a member of any namespace or if the user's membership status is pending.
+	// is not a member of any namespace or if the user's membership status is pending, and when that namespace refuses the
+	// local login.
 	//
 	// It returns a timestamp when the block ends if the user is locked out, a token to be used with the OTP code if the MFA
 	// is enabled and an error, if any
@@ -55,6 +56,9 @@ type AuthService interface {
 	// preferred namespace or the first namespace to which the user was added; if the user's membership status is pending, it
 	// returns an NamespaceNotFound error.
 	//
+	// A namespace that refuses the local login is never issued to a user who signed in with their
+	// password: the preferred one is then left out of the token, and switching to it is forbidden.
+	//
 	// It returns the created token and an error if any.
 	CreateUserToken(ctx context.Context, req *requests.CreateUserToken) (res *models.UserAuthResponse, err error)
 	// ResolveNamespaceRole returns the namespace tenantID names, as [store.NamespaceStore.NamespaceResolve]
@@ -65,6 +69,7 @@ type AuthService interface {
 	ResolveNamespaceRole(ctx context.Context, tenantID, userID string) (ns *models.Namespace, role string, err error)
 	// GetUserAdmin checks whether the user currently has admin privileges.
 	// Unlike the JWT claim, this queries the store so changes take effect immediately.
+	// It fails with ErrUserDisabled for a deactivated user, so their tokens stop working.
 	GetUserAdmin(ctx context.Context, userID string) (admin bool, err error)
 	// AuthAPIKey authenticates the given key, returning its API key document. An API key can be used
 	// in place of a JWT token to authenticate requests. The key is only related to a namespace and not to a user,
@@ -339,6 +344,10 @@ func (s *service) authDevice(ctx context.Context, req requests.DeviceAuth, paire
 		// (the store was already updated through UpdateDeviceStatus by applyEnrollmentDecision).
 		device.Status = s.applyEnrollmentDecision(ctx, s.evaluateEnrollment(ctx, installKey, req, uid, hostname, paired), installKey, req, uid, hostname, false, true)
 	} else {
+		// A device the tunnel saw disconnecting is coming back; one that never
+		// dropped is only phoning home.
+		reconnected := device.DisconnectedAt != nil
+
 		device.LastSeen = clock.Now()
 		device.DisconnectedAt = nil
 
@@ -426,6 +435,10 @@ func (s *service) authDevice(ctx context.Context, req requests.DeviceAuth, paire
 
 			return nil, err
 		}
+
+		if reconnected {
+			s.emitEvent(ctx, device.TenantID, models.WebhookEventDeviceOnline, device)
+		}
 	}
 
 	// The agent hands us the session UIDs it believes it is serving. Bounding the resolve to the
@@ -549,6 +562,11 @@ func (s *service) AuthLocalUser(ctx context.Context, req *requests.AuthLocalUser
 			Warn("unable to reset authentication attempts")
 	}
 
+	// Checked once the password is, so a refusal never tells which accounts were deactivated.
+	if user.Disabled {
+		return nil, 0, "", NewErrUserDisabled(nil)
+	}
+
 	// Users with MFA enabled must authenticate to the cloud instead of community.
 	if user.MFA.Enabled {
 		mfaToken := uuid.Generate()
@@ -562,22 +580,31 @@ func (s *service) AuthLocalUser(ctx context.Context, req *requests.AuthLocalUser
 		return nil, 0, mfaToken, nil
 	}
 
+	preferredTenantID := ""
 	tenantID := ""
 	role := ""
 	if ns, _ := s.store.NamespaceGetPreferred(ctx, user.ID); ns != nil && ns.TenantID != "" {
 		if m, _ := ns.FindMember(user.ID); m != nil {
-			tenantID = ns.TenantID
-			role = m.Role.String()
+			preferredTenantID = ns.TenantID
+
+			// A namespace that refuses the local login is left out of the token rather than
+			// refusing the login, so the user can still switch to their other namespaces. It stays
+			// their preferred one for when they sign in through single sign-on.
+			if !s.localAuthDisabled(ns, models.UserAuthMethodLocal.String()) {
+				tenantID = ns.TenantID
+				role = m.Role.String()
+			}
 		}
 	}
 
 	claims := authorizer.UserClaims{
-		ID:       user.ID,
-		Origin:   user.Origin.String(),
-		TenantID: tenantID,
-		Username: user.Username,
-		MFA:      user.MFA.Enabled,
-		Admin:    user.Admin,
+		ID:         user.ID,
+		Origin:     user.Origin.String(),
+		TenantID:   tenantID,
+		Username:   user.Username,
+		MFA:        user.MFA.Enabled,
+		Admin:      user.Admin,
+		AuthMethod: models.UserAuthMethodLocal.String(),
 	}
 
 	token, err := jwttoken.EncodeUserClaims(claims, s.privKey)
@@ -599,7 +626,7 @@ func (s *service) AuthLocalUser(ctx context.Context, req *requests.AuthLocalUser
 	}
 
 	// preferred_namespace_id is skipupdate, so the UserUpdate above doesn't persist it.
-	if err := s.store.UserUpdatePreferredNamespace(ctx, user.ID, tenantID); err != nil {
+	if err := s.store.UserUpdatePreferredNamespace(ctx, user.ID, preferredTenantID); err != nil {
 		return nil, 0, "", NewErrUserUpdate(user, err)
 	}
 
@@ -634,6 +661,10 @@ func (s *service) CreateUserToken(ctx context.Context, req *requests.CreateUserT
 		return nil, NewErrUserNotFound(req.UserID, err)
 	}
 
+	if user.Disabled {
+		return nil, NewErrUserDisabled(nil)
+	}
+
 	tenantID := ""
 	role := ""
 
@@ -650,6 +681,11 @@ func (s *service) CreateUserToken(ctx context.Context, req *requests.CreateUserT
 			return nil, NewErrNamespaceMemberNotFound(user.ID, nil)
 		}
 
+		// As at the login, a preferred namespace that refuses how the user signed in is left out.
+		if s.localAuthDisabled(namespace, req.AuthMethod) {
+			break
+		}
+
 		tenantID = namespace.TenantID
 		role = member.Role.String()
 	default:
@@ -663,6 +699,12 @@ func (s *service) CreateUserToken(ctx context.Context, req *requests.CreateUserT
 			return nil, NewErrNamespaceMemberNotFound(user.ID, nil)
 		}
 
+		// Checked once the membership is, so a refusal never tells the settings of a namespace
+		// the user is not in.
+		if s.localAuthDisabled(namespace, req.AuthMethod) {
+			return nil, NewErrForbidden(ErrAuthLocalDisabled, nil)
+		}
+
 		tenantID = namespace.TenantID
 		role = member.Role.String()
 
@@ -676,12 +718,13 @@ func (s *service) CreateUserToken(ctx context.Context, req *requests.CreateUserT
 	}
 
 	claims := authorizer.UserClaims{
-		ID:       user.ID,
-		Origin:   user.Origin.String(),
-		TenantID: tenantID,
-		Username: user.Username,
-		MFA:      user.MFA.Enabled,
-		Admin:    user.Admin,
+		ID:         user.ID,
+		Origin:     user.Origin.String(),
+		TenantID:   tenantID,
+		Username:   user.Username,
+		MFA:        user.MFA.Enabled,
+		Admin:      user.Admin,
+		AuthMethod: req.AuthMethod,
 	}
 
 	token, err := jwttoken.EncodeUserClaims(claims, s.privKey)
@@ -801,6 +844,10 @@ func (s *service) GetUserAdmin(ctx context.Context, userID string) (bool, error)
 		return false, err
 	}
 
+	if user.Disabled {
+		return false, NewErrUserDisabled(nil)
+	}
+
 	return user.Admin, nil
 }
 
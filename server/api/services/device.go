@@ -128,6 +128,12 @@ func (s *service) ListDevices(ctx context.Context, sc scope.Scope, req *requests
 
 	opts = append(opts, s.store.Options().Match(&req.Filters), s.store.Options().Sort(&req.Sorter), s.store.Options().Paginate(&req.Paginator))
 
+	// The scope is matched as a group of its own, AND-ed with the caller's filters, so no filter the
+	// caller sends can widen the list past the devices the scope reaches.
+	if len(req.ScopeTags) > 0 {
+		opts = append(opts, s.store.Options().Match(deviceTagScope(req.ScopeTags)))
+	}
+
 	if req.DeviceStatus == models.DeviceStatusRemoved {
 		return s.store.DeviceList(ctx, sc, store.DeviceAcceptableFromRemoved, opts...)
 	}
@@ -148,6 +154,19 @@ func (s *service) ListDevices(ctx context.Context, sc scope.Scope, req *requests
 	return s.store.DeviceList(ctx, sc, acceptable, opts...)
 }
 
+// deviceTagScope matches the devices carrying any of tags.
+func deviceTagScope(tags []string) *query.Filters {
+	filters := &query.Filters{Data: make([]query.Filter, 0, len(tags))}
+	for _, tag := range tags {
+		filters.Data = append(filters.Data, query.Filter{
+			Type:   query.FilterTypeProperty,
+			Params: &query.FilterProperty{Name: "tags.name", Operator: "eq", Value: tag},
+		})
+	}
+
+	return filters
+}
+
 func (s *service) GetDevice(ctx context.Context, sc scope.Scope, uid models.UID) (*models.Device, error) {
 	device, err := s.store.DeviceResolve(ctx, sc, store.DeviceUIDResolver, string(uid))
 	if err != nil {
@@ -222,10 +241,20 @@ func (s *service) DeleteDevice(ctx context.Context, uid models.UID, tenant strin
 		}
 	}
 
-	if err := s.store.NamespaceIncrementDeviceCount(ctx, sc, device.Status, -1); err != nil { //nolint:revive
+	if err := s.store.NamespaceIncrementDeviceCount(ctx, sc, device.Status, -1); err != nil {
 		return err
 	}
 
+	s.recordAudit(ctx, &models.AuditEvent{
+		TenantID:   tenant,
+		Action:     models.AuditActionDeviceRemove,
+		TargetType: models.AuditTargetDevice,
+		TargetID:   device.UID,
+		Before:     map[string]any{"name": device.Name, "status": string(device.Status)},
+	})
+
+	s.emitEvent(ctx, tenant, models.WebhookEventDeviceRemove, device)
+
 	return nil
 }
 
@@ -280,14 +309,41 @@ func (s *service) OfflineDevice(ctx context.Context, uid models.UID) error {
 		return err
 	}
 
+	// Only the UID reaches here, so the device is read back for its namespace,
+	// and only when the event goes somewhere.
+	if s.emitsEvents() {
+		device, err := s.store.DeviceResolve(ctx, scope.NewUnbounded("the tunnel reports a disconnect by device UID alone"), store.DeviceUIDResolver, string(uid))
+		if err != nil {
+			log.WithError(err).WithField("device_uid", uid).Warn("failed to read back the disconnected device")
+
+			return nil
+		}
+
+		s.emitEvent(ctx, device.TenantID, models.WebhookEventDeviceOffline, device)
+	}
+
 	return nil
 }
 
+// AnnounceDevicesOnline emits the event of each device a heartbeat brought back
+// online. It is the [DeviceHeartbeater]'s reconnect handler.
+func (s *service) AnnounceDevicesOnline(ctx context.Context, devices []models.Device) {
+	for i := range devices {
+		s.emitEvent(ctx, devices[i].TenantID, models.WebhookEventDeviceOnline, &devices[i])
+	}
+}
+
 func (s *service) UpdateDeviceStatus(ctx context.Context, req *requests.DeviceUpdateStatus) error {
-	if err := s.store.WithTransaction(ctx, s.updateDeviceStatus(req)); err != nil {
+	var previous models.DeviceStatus
+	var updated models.Device
+	if err := s.store.WithTransaction(ctx, s.updateDeviceStatus(req, &previous, &updated)); err != nil {
 		return err
 	}
 
+	if updated.Status == models.DeviceStatusAccepted {
+		s.emitEvent(ctx, req.TenantID, models.WebhookEventDeviceAccept, &updated)
+	}
+
 	// Freeze the decision on the device's enrollment history event so the audit keeps it after the
 	// device is removed. Best-effort and outside the transaction: a stamp failure must not roll back the
 	// accept/reject that already committed. This is the single chokepoint every accept/reject reaches.
@@ -302,12 +358,28 @@ func (s *service) UpdateDeviceStatus(ctx context.Context, req *requests.DeviceUp
 			log.WithError(err).WithFields(log.Fields{"device_uid": req.UID, "status": req.Status}).
 				Warn("failed to stamp the enrollment decision on the history event")
 		}
+
+		action := models.AuditActionDeviceAccept
+		if status == models.DeviceStatusRejected {
+			action = models.AuditActionDeviceReject
+		}
+
+		s.recordAudit(ctx, &models.AuditEvent{
+			TenantID:   req.TenantID,
+			Action:     action,
+			TargetType: models.AuditTargetDevice,
+			TargetID:   req.UID,
+			Before:     map[string]any{"status": string(previous)},
+			After:      map[string]any{"status": string(status)},
+		})
 	}
 
 	return nil
 }
 
-func (s *service) updateDeviceStatus(req *requests.DeviceUpdateStatus) store.TransactionCb {
+// updateDeviceStatus moves the device to the requested status, storing the status it moved from
+// in previous and, when it did move, the device as it was left in updated.
+func (s *service) updateDeviceStatus(req *requests.DeviceUpdateStatus, previous *models.DeviceStatus, updated *models.Device) store.TransactionCb {
 	return func(ctx context.Context) error {
 		namespace, err := s.store.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, req.TenantID)
 		if err != nil {
@@ -330,6 +402,7 @@ func (s *service) updateDeviceStatus(req *requests.DeviceUpdateStatus) store.Tra
 
 		oldStatus := device.Status
 		newStatus := models.DeviceStatus(req.Status)
+		*previous = oldStatus
 
 		if newStatus == device.Status {
 			return nil
@@ -412,6 +485,8 @@ func (s *service) updateDeviceStatus(req *requests.DeviceUpdateStatus) store.Tra
 			}
 		}
 
+		*updated = *device
+
 		return nil
 	}
 }
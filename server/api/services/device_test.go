@@ -125,6 +125,56 @@ func TestListDevices(t *testing.T) {
 				err:     nil,
 			},
 		},
+		{
+			description: "succeeds to list devices in an API key's tag scope",
+			sc:          scope.MustBounded("00000000-0000-4000-0000-000000000000"),
+			req: &requests.DeviceList{
+				TenantID:     "00000000-0000-4000-0000-000000000000",
+				DeviceStatus: models.DeviceStatusAccepted,
+				ScopeTags:    []string{"production", "staging"},
+				Paginator:    query.Paginator{Page: 1, PerPage: 10},
+				Sorter:       query.Sorter{By: "created_at", Order: "asc"},
+				Filters:      query.Filters{},
+			},
+			requiredMocks: func(ctx context.Context) {
+				queryOptionsMock.
+					On("WithDeviceStatus", models.DeviceStatusAccepted).
+					Return(nil).
+					Once()
+				queryOptionsMock.
+					On("Match", &query.Filters{}).
+					Return(nil).
+					Once()
+				queryOptionsMock.
+					On("Sort", &query.Sorter{By: "created_at", Order: query.OrderAsc, Tiebreak: "id"}).
+					Return(nil).
+					Once()
+				queryOptionsMock.
+					On("Paginate", &query.Paginator{Page: 1, PerPage: 10}).
+					Return(nil).
+					Once()
+				queryOptionsMock.
+					On("Match", &query.Filters{Data: []query.Filter{
+						{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "tags.name", Operator: "eq", Value: "production"}},
+						{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "tags.name", Operator: "eq", Value: "staging"}},
+					}}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("NamespaceGetDeviceLimit", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(models.NamespaceDeviceLimit{}, nil).
+					Once()
+				storeMock.
+					On("DeviceList", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), store.DeviceAcceptableIfNotAccepted, mock.MatchedBy(func(opts []store.QueryOption) bool { return len(opts) == 5 })).
+					Return([]models.Device{}, 0, nil).
+					Once()
+			},
+			expected: Expected{
+				devices: []models.Device{},
+				count:   0,
+				err:     nil,
+			},
+		},
 		{
 			description: "succeeds for admin caller with unbounded scope",
 			sc:          scope.NewUnbounded("admin"),
@@ -923,6 +973,16 @@ func TestDeleteDevice(t *testing.T) {
 					On("NamespaceIncrementDeviceCount", ctx, scope.MustBounded("tenant"), models.DeviceStatusAccepted, int64(-1)).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, &models.AuditEvent{
+						TenantID:   "tenant",
+						Action:     models.AuditActionDeviceRemove,
+						TargetType: models.AuditTargetDevice,
+						TargetID:   "uid",
+						Before:     map[string]any{"name": "", "status": string(models.DeviceStatusAccepted)},
+					}).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -977,6 +1037,12 @@ func TestDeleteDevice(t *testing.T) {
 					On("NamespaceIncrementDeviceCount", ctx, scope.MustBounded("tenant"), models.DeviceStatusAccepted, int64(-1)).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionDeviceRemove && event.TargetID == "uid"
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -999,6 +1065,12 @@ func TestDeleteDevice(t *testing.T) {
 					On("NamespaceIncrementDeviceCount", ctx, scope.MustBounded("tenant"), models.DeviceStatusPending, int64(-1)).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionDeviceRemove && event.TargetID == "uid"
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -1030,6 +1102,12 @@ func TestDeleteDevice(t *testing.T) {
 					On("NamespaceIncrementDeviceCount", ctx, scope.MustBounded("tenant"), models.DeviceStatusAccepted, int64(-1)).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionDeviceRemove && event.TargetID == "uid"
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -2055,6 +2133,9 @@ func TestUpdateDeviceStatus(t *testing.T) {
 			// A successful accept/reject freezes the decision on the device's history event.
 			if st := models.DeviceStatus(tc.req.Status); tc.expectedError == nil && (st == models.DeviceStatusAccepted || st == models.DeviceStatusRejected) {
 				storeMock.On("InstallKeyEventStampDecision", ctx, scope.MustBounded(tc.req.TenantID), tc.req.UID, st, mock.Anything).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.TargetType == models.AuditTargetDevice && event.TargetID == tc.req.UID && event.After["status"] == tc.req.Status
+				})).Return(nil).Once()
 			}
 
 			err := service.UpdateDeviceStatus(ctx, tc.req)
@@ -2388,6 +2469,9 @@ func TestUpdateDeviceStatus_licenseEvaluator(t *testing.T) {
 			// A successful accept/reject freezes the decision on the device's history event.
 			if st := models.DeviceStatus(tc.req.Status); tc.expectedError == nil && (st == models.DeviceStatusAccepted || st == models.DeviceStatusRejected) {
 				storeMock.On("InstallKeyEventStampDecision", ctx, scope.MustBounded(tc.req.TenantID), tc.req.UID, st, mock.Anything).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.TargetType == models.AuditTargetDevice && event.TargetID == tc.req.UID && event.After["status"] == tc.req.Status
+				})).Return(nil).Once()
 			}
 
 			err := service.UpdateDeviceStatus(ctx, tc.req)
@@ -2,6 +2,7 @@ package services
 
 import (
 	stderrors "errors"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -85,6 +86,7 @@ var (
 	ErrUserPasswordNotMatch            = errors.New("user password does not match to the current password", ErrLayer, ErrCodeForbidden)
 	ErrUserNotConfirmed                = errors.New("user not confirmed", ErrLayer, ErrCodeForbidden)
 	ErrUserAwaitingApproval            = errors.New("user awaiting approval", ErrLayer, ErrCodeLocked)
+	ErrUserDisabled                    = errors.New("user disabled", ErrLayer, ErrCodeForbidden)
 	ErrUserUpdate                      = errors.New("user update", ErrLayer, ErrCodeStore)
 	ErrUserCreate                      = errors.New("user creation failed", ErrLayer, ErrCodeInvalid)
 	ErrUserGetToken                    = errors.New("user failed to get token", ErrLayer, ErrCodeNotFound)
@@ -99,6 +101,7 @@ var (
 	ErrNamespaceCreateStore            = errors.New("namespace create store", ErrLayer, ErrCodeStore)
 	ErrNamespaceInstanceProtected      = errors.New("namespace is bound to the instance and cannot be deleted", ErrLayer, ErrCodeConflict)
 	ErrNamespaceLegacyNotAllowed       = errors.New("legacy SSH access mode is not available for this namespace", ErrLayer, ErrCodeForbidden)
+	ErrNamespaceLocalAuthRequired      = errors.New("the local login cannot be disabled without single sign-on", ErrLayer, ErrCodeForbidden)
 	ErrNamespaceSingle                 = errors.New("instance does not support multi-tenancy", ErrLayer, ErrCodeConflict)
 	ErrMaxTagReached                   = errors.New("tag limit reached", ErrLayer, ErrCodeLimit)
 	ErrDuplicateTagName                = errors.New("tag duplicated", ErrLayer, ErrCodeDuplicated)
@@ -129,10 +132,23 @@ var (
 	ErrPublicKeyDataInvalid            = errors.New("public key data invalid", ErrLayer, ErrCodeInvalid)
 	ErrPublicKeyFilter                 = errors.New("public key cannot have more than one filter at same time", ErrLayer, ErrCodeInvalid)
 	ErrAccessPolicyNotFound            = errors.New("access policy not found", ErrLayer, ErrCodeNotFound)
+	ErrAccessPolicyInvalidPeriod       = errors.New("access policy not_after must be after not_before", ErrLayer, ErrCodeInvalid)
+	ErrAccessRequestNotFound           = errors.New("access request not found", ErrLayer, ErrCodeNotFound)
+	ErrAccessRequestStatus             = errors.New("access request cannot be changed in its current status", ErrLayer, ErrCodeConflict)
+	ErrAccessRequestSelfDecision       = errors.New("access request cannot be decided by its requester", ErrLayer, ErrCodeForbidden)
+	ErrWebhookNotFound                 = errors.New("webhook not found", ErrLayer, ErrCodeNotFound)
+	ErrWebhookInvalidField             = errors.New("webhook field is invalid", ErrLayer, ErrCodeInvalid)
+	ErrWebhookDeliveryNotFound         = errors.New("webhook delivery not found", ErrLayer, ErrCodeNotFound)
+	ErrEventStreamNotAvailable         = errors.New("event stream not available", ErrLayer, ErrCodeInvalid)
+	ErrEventStreamInvalidEventID       = errors.New("event stream event id is invalid", ErrLayer, ErrCodeInvalid)
 	ErrSSHIdentityNotFound             = errors.New("ssh identity not found", ErrLayer, ErrCodeNotFound)
 	ErrSSHIdentityDuplicated           = errors.New("ssh identity duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrSSHIdentityInvalid              = errors.New("ssh identity public key invalid", ErrLayer, ErrCodeInvalid)
 	ErrServiceAccountNotFound          = errors.New("service account not found", ErrLayer, ErrCodeNotFound)
+	ErrSSHUserCANotFound               = errors.New("ssh user ca not found", ErrLayer, ErrCodeNotFound)
+	ErrSSHUserCADuplicated             = errors.New("ssh user ca duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrSSHUserCAInvalid                = errors.New("ssh user ca public key invalid", ErrLayer, ErrCodeInvalid)
+	ErrSSHCertificateRejected          = errors.New("ssh certificate rejected", ErrLayer, ErrCodeForbidden)
 	ErrTokenSigned                     = errors.New("token signed", ErrLayer, ErrCodeInvalid)
 	ErrTypeAssertion                   = errors.New("type assertion failed", ErrLayer, ErrCodeInvalid)
 	ErrSessionNotFound                 = errors.New("session not found", ErrLayer, ErrCodeNotFound)
@@ -151,11 +167,17 @@ var (
 	ErrInstallKeyDuplicated            = errors.New("InstallKey duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrInstallKeyForbidden             = errors.New("the legacy install key cannot be modified", ErrLayer, ErrCodeForbidden)
 	ErrInstallKeyInvalidField          = errors.New("install key field is invalid", ErrLayer, ErrCodeInvalid)
+	ErrPolicyBundleInvalid             = errors.New("policy bundle is invalid", ErrLayer, ErrCodeInvalid)
 	ErrAuthForbidden                   = errors.New("user is authenticated but cannot access this resource", ErrLayer, ErrCodeForbidden)
 	ErrRoleForbidden                   = errors.New("role is forbidden", ErrLayer, ErrCodeForbidden)
 	ErrUserDelete                      = errors.New("user couldn't be deleted", ErrLayer, ErrCodeInvalid)
 	ErrSetupForbidden                  = errors.New("setup isn't allowed anymore", ErrLayer, ErrCodeForbidden)
 	ErrAuthMethodNotAllowed            = errors.New("auth method not allowed", ErrLayer, ErrCodeNotImplemented)
+	ErrAuthLocalDisabled               = errors.New("the namespace refuses the local login", ErrLayer, ErrCodeForbidden)
+	ErrSCIMRequestInvalid              = errors.New("SCIM request invalid", ErrLayer, ErrCodeInvalid)
+	ErrSCIMUserOwnsNamespace           = errors.New("the user owns a namespace and cannot be deleted", ErrLayer, ErrCodeConflict)
+	ErrSCIMGroupNotFound               = errors.New("SCIM group not found", ErrLayer, ErrCodeNotFound)
+	ErrSCIMGroupDuplicated             = errors.New("SCIM group duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrAuthDeviceNoIdentityAndHostname = errors.New("device doesn't have identity neither hostname defined", ErrLayer, ErrCodeInvalid)
 	ErruthDeviceNoIdentity             = errors.New("device doesn't have identity defined", ErrLayer, ErrCodeInvalid)
 )
@@ -272,6 +294,12 @@ func NewErrInstallKeyInvalidField(fields map[string]string) error {
 	return NewErrInvalidFields(ErrInstallKeyInvalidField, fields)
 }
 
+// NewErrPolicyBundleInvalid returns a bad-request error tagging the bundle field(s) that cannot
+// be applied to the namespace.
+func NewErrPolicyBundleInvalid(fields map[string]string) error {
+	return NewErrInvalidFields(ErrPolicyBundleInvalid, fields)
+}
+
 // NewErrTagInvalid returns an error when the tag is invalid.
 func NewErrTagInvalid(tag string, next error) error {
 	return NewErrInvalid(ErrTagInvalid, map[string]interface{}{"name": tag}, next)
@@ -355,6 +383,58 @@ func NewErrAccessPolicyNotFound(id string, next error) error {
 	return NewErrNotFound(ErrAccessPolicyNotFound, id, next)
 }
 
+// NewErrAccessPolicyInvalidPeriod returns an error when an access policy would
+// stop being in effect before it starts.
+func NewErrAccessPolicyInvalidPeriod(notBefore, notAfter time.Time) error {
+	return NewErrInvalid(ErrAccessPolicyInvalidPeriod, map[string]interface{}{"not_before": notBefore, "not_after": notAfter}, nil)
+}
+
+// NewErrAccessRequestNotFound returns an error when the access request is not
+// found, or is not visible to the caller.
+func NewErrAccessRequestNotFound(id string, next error) error {
+	return NewErrNotFound(ErrAccessRequestNotFound, id, next)
+}
+
+// NewErrAccessRequestStatus returns an error when an access request is asked to
+// move out of a status it cannot leave that way, e.g. granting one already
+// rejected. The status is left out when it is not known, as when a concurrent
+// decision moved the request first.
+func NewErrAccessRequestStatus(status models.AccessRequestStatus) error {
+	if status == "" {
+		return ErrAccessRequestStatus
+	}
+
+	return errors.WithData(ErrAccessRequestStatus, ErrDataInvalid{Data: map[string]interface{}{"status": status}})
+}
+
+// NewErrAccessRequestSelfDecision returns an error when an approver tries to
+// grant, reject or revoke their own access request.
+func NewErrAccessRequestSelfDecision() error {
+	return NewErrForbidden(ErrAccessRequestSelfDecision, nil)
+}
+
+// NewErrWebhookNotFound returns an error when the webhook is not found.
+func NewErrWebhookNotFound(id string, next error) error {
+	return NewErrNotFound(ErrWebhookNotFound, id, next)
+}
+
+// NewErrWebhookInvalidField returns a bad-request error tagging webhook field(s) with a reason.
+func NewErrWebhookInvalidField(fields map[string]string) error {
+	return NewErrInvalidFields(ErrWebhookInvalidField, fields)
+}
+
+// NewErrWebhookDeliveryNotFound returns an error when the delivery is not found
+// in the webhook's log.
+func NewErrWebhookDeliveryNotFound(id string, next error) error {
+	return NewErrNotFound(ErrWebhookDeliveryNotFound, id, next)
+}
+
+// NewErrEventStreamInvalidEventID returns a bad-request error when a subscriber
+// asks to resume after an ID the stream could not have produced.
+func NewErrEventStreamInvalidEventID() error {
+	return NewErrInvalidFields(ErrEventStreamInvalidEventID, map[string]string{"last_event_id": "must be the id of an event received from the stream"})
+}
+
 // NewErrSSHIdentityNotFound returns an error when the SSH identity is not found.
 func NewErrSSHIdentityNotFound(id string, next error) error {
 	return NewErrNotFound(ErrSSHIdentityNotFound, id, next)
@@ -378,6 +458,30 @@ func NewErrServiceAccountNotFound(id string, next error) error {
 	return NewErrNotFound(ErrServiceAccountNotFound, id, next)
 }
 
+// NewErrSSHUserCANotFound returns an error when the user certificate authority is
+// not trusted by the namespace.
+func NewErrSSHUserCANotFound(id string, next error) error {
+	return NewErrNotFound(ErrSSHUserCANotFound, id, next)
+}
+
+// NewErrSSHUserCADuplicated returns an error when the namespace already trusts
+// the certificate authority's key.
+func NewErrSSHUserCADuplicated(next error) error {
+	return NewErrDuplicated(ErrSSHUserCADuplicated, []string{"data"}, next)
+}
+
+// NewErrSSHUserCAInvalid returns an error when the provided certificate authority
+// key cannot be parsed, or is not a plain public key.
+func NewErrSSHUserCAInvalid(data string, next error) error {
+	return NewErrInvalid(ErrSSHUserCAInvalid, map[string]interface{}{"data": data}, next)
+}
+
+// NewErrSSHCertificateRejected returns an error when a presented user certificate
+// does not authenticate anyone in the namespace, wrapping why.
+func NewErrSSHCertificateRejected(reason error) error {
+	return NewErrForbidden(ErrSSHCertificateRejected, reason)
+}
+
 // NewErrPublicKeyInvalid returns an error when the public key is invalid.
 func NewErrPublicKeyInvalid(data map[string]interface{}, next error) error {
 	return NewErrInvalid(ErrPublicKeyInvalid, data, next)
@@ -552,6 +656,11 @@ func NewErrUserAwaitingApproval(err error) error {
 	return errors.Wrap(ErrUserAwaitingApproval, err)
 }
 
+// NewErrUserDisabled returns an error to be used when a deactivated user tries to authenticate.
+func NewErrUserDisabled(err error) error {
+	return NewErrForbidden(ErrUserDisabled, err)
+}
+
 // NewErrAuthInvalid returns a error to be used when the auth data is invalid.
 func NewErrAuthInvalid(data map[string]interface{}, err error) error {
 	return NewErrInvalid(ErrAuthInvalid, data, err)
@@ -635,3 +744,25 @@ func NewErrAuthDeviceNoIdentityAndHostname() error {
 func NewErrAuthDeviceNoIdentity() error {
 	return NewErrInvalid(ErruthDeviceNoIdentity, map[string]interface{}{"identity": true}, nil)
 }
+
+// NewErrSCIMRequestInvalid returns an error to be used when a SCIM request can't be applied. next
+// is usually a [scim.Error], which tells the client what was refused.
+func NewErrSCIMRequestInvalid(next error) error {
+	return NewErrRequest(ErrSCIMRequestInvalid, next)
+}
+
+// NewErrSCIMUserOwnsNamespace returns an error to be used when the identity provider deletes a user
+// who owns a namespace, which would be left without an owner.
+func NewErrSCIMUserOwnsNamespace(next error) error {
+	return errors.Wrap(ErrSCIMUserOwnsNamespace, next)
+}
+
+// NewErrSCIMGroupNotFound returns an error to be used when a SCIM group is not found.
+func NewErrSCIMGroupNotFound(id string, next error) error {
+	return NewErrNotFound(ErrSCIMGroupNotFound, id, next)
+}
+
+// NewErrSCIMGroupDuplicated returns an error to be used when a SCIM group's display name is taken.
+func NewErrSCIMGroupDuplicated(next error) error {
+	return NewErrDuplicated(ErrSCIMGroupDuplicated, []string{"displayName"}, next)
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/eventstream"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	log "github.com/sirupsen/logrus"
+)
+
+// eventStreamScopeTTL is how long a scoped subscription trusts what it read about whether a device
+// is in its scope before it reads it again, so a busy device costs no query per event while a
+// retagged one is followed, or dropped, soon after.
+const eventStreamScopeTTL = time.Minute
+
+type EventStreamService interface {
+	// SubscribeEvents follows the namespace's live events: devices coming online
+	// and going offline, sessions starting and closing and SSH approvals being
+	// asked for and decided, among the others webhooks can subscribe to. It
+	// resumes after the request's last event ID when there is one.
+	//
+	// A request with scope tags follows only the events about devices carrying
+	// any of them, and none of those about no device at all. Nor does a
+	// subscriber follow the events it could not read over the REST API: access
+	// policy changes without the permission to manage the policies, and the SSH
+	// approvals it did not decide without the one to manage every member's SSH
+	// identities.
+	//
+	// The channel is closed once ctx is done, or earlier when the subscriber
+	// falls too far behind; it can then subscribe again from the last event it
+	// got.
+	SubscribeEvents(ctx context.Context, req *requests.EventStreamSubscribe) (<-chan eventstream.Event, error)
+}
+
+func (s *service) SubscribeEvents(ctx context.Context, req *requests.EventStreamSubscribe) (<-chan eventstream.Event, error) {
+	if s.events == nil {
+		return nil, ErrEventStreamNotAvailable
+	}
+
+	events, err := s.events.Subscribe(ctx, req.TenantID, req.LastEventID)
+	if errors.Is(err, eventstream.ErrInvalidEventID) {
+		return nil, NewErrEventStreamInvalidEventID()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.ReadsAccessPolicies || !req.ReadsSSHApprovals {
+		events = readableStreamEvents(ctx, req, events)
+	}
+
+	if len(req.ScopeTags) == 0 {
+		return events, nil
+	}
+
+	sc, err := scope.NewBounded(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.scopeStreamEvents(ctx, sc, req.ScopeTags, events), nil
+}
+
+// readableStreamEvents passes on the events the subscriber may read, and drops the rest. See
+// [readsStreamEvent].
+func readableStreamEvents(ctx context.Context, req *requests.EventStreamSubscribe, events <-chan eventstream.Event) <-chan eventstream.Event {
+	readable := make(chan eventstream.Event)
+
+	go func() {
+		defer close(readable)
+
+		for event := range events {
+			if !readsStreamEvent(req, event) {
+				continue
+			}
+
+			select {
+			case readable <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return readable
+}
+
+// readsStreamEvent reports whether the subscriber may read the event, as it could over the REST
+// API. An access policy change carries the whole policy, which only who manages the policies
+// reads. An SSH approval carries someone's key and address, which only who manages every member's
+// SSH identities reads, beside the subscriber that decided it.
+func readsStreamEvent(req *requests.EventStreamSubscribe, event eventstream.Event) bool {
+	switch models.WebhookEventType(event.Type) {
+	case models.WebhookEventAccessPolicyChange:
+		return req.ReadsAccessPolicies
+	case models.WebhookEventSSHApprovalPending, models.WebhookEventSSHApprovalConfirmed, models.WebhookEventSSHApprovalRejected:
+		if req.ReadsSSHApprovals {
+			return true
+		}
+
+		var payload struct {
+			DecidedBy string `json:"decided_by"`
+		}
+
+		if req.UserID == "" || json.Unmarshal(event.Data, &payload) != nil {
+			return false
+		}
+
+		return payload.DecidedBy == req.UserID
+	default:
+		return true
+	}
+}
+
+// scopeStreamEvents passes on the events about the devices carrying any of tags, and drops the
+// rest. The IDs of the events passed on still order the whole stream, so a subscriber resumes
+// from one as from any other.
+func (s *service) scopeStreamEvents(ctx context.Context, sc scope.Scope, tags []string, events <-chan eventstream.Event) <-chan eventstream.Event {
+	type reach struct {
+		ok bool
+		at time.Time
+	}
+
+	scoped := make(chan eventstream.Event)
+
+	go func() {
+		defer close(scoped)
+
+		reached := make(map[string]reach)
+		for event := range events {
+			uid := streamEventDevice(event)
+			if uid == "" {
+				continue
+			}
+
+			r, ok := reached[uid]
+			if !ok || clock.Now().Sub(r.at) > eventStreamScopeTTL {
+				_, err := s.store.DeviceResolve(ctx, sc, store.DeviceUIDResolver, uid, s.store.Options().Match(deviceTagScope(tags)))
+				r = reach{ok: err == nil, at: clock.Now()}
+				reached[uid] = r
+			}
+
+			if !r.ok {
+				continue
+			}
+
+			select {
+			case scoped <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return scoped
+}
+
+// streamEventDevice returns the UID of the device a stream event is about, or an empty string when
+// it is about none, as an access policy change is.
+func streamEventDevice(event eventstream.Event) string {
+	var payload struct {
+		UID       string `json:"uid"`
+		DeviceUID string `json:"device_uid"`
+	}
+
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		return ""
+	}
+
+	switch models.WebhookEventType(event.Type) {
+	case models.WebhookEventDeviceOnline, models.WebhookEventDeviceOffline, models.WebhookEventDeviceAccept, models.WebhookEventDeviceRemove:
+		return payload.UID
+	case models.WebhookEventSessionStart, models.WebhookEventSessionClose,
+		models.WebhookEventSSHApprovalPending, models.WebhookEventSSHApprovalConfirmed, models.WebhookEventSSHApprovalRejected:
+		return payload.DeviceUID
+	default:
+		return ""
+	}
+}
+
+// publishStreamEvent appends an event to the namespace's live stream. Without a
+// stream it does nothing.
+func (s *service) publishStreamEvent(ctx context.Context, tenantID string, event models.WebhookEventType, data any) {
+	if s.events == nil || tenantID == "" {
+		return
+	}
+
+	if err := s.events.Publish(ctx, tenantID, string(event), data); err != nil {
+		log.WithError(err).WithFields(log.Fields{"tenant_id": tenantID, "event": event}).
+			Error("failed to publish the stream event")
+	}
+}
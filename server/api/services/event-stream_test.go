@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/eventstream"
+	eventstreammock "github.com/shellhub-io/shellhub/pkg/eventstream/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	ctx := context.TODO()
+
+	t.Run("fails without a stream", func(t *testing.T) {
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil)
+
+		_, err := service.SubscribeEvents(ctx, &requests.EventStreamSubscribe{TenantID: tenantID})
+		assert.ErrorIs(t, err, ErrEventStreamNotAvailable)
+	})
+
+	t.Run("rejects an event ID the stream could not have produced", func(t *testing.T) {
+		streamMock := eventstreammock.NewMockStream(t)
+		streamMock.On("Subscribe", ctx, tenantID, "not-an-id").Return(nil, eventstream.ErrInvalidEventID).Once()
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil, WithEventStream(streamMock))
+
+		_, err := service.SubscribeEvents(ctx, &requests.EventStreamSubscribe{TenantID: tenantID, LastEventID: "not-an-id"})
+		assert.ErrorIs(t, err, ErrEventStreamInvalidEventID)
+	})
+
+	t.Run("fails when the stream does", func(t *testing.T) {
+		streamMock := eventstreammock.NewMockStream(t)
+		streamMock.On("Subscribe", ctx, tenantID, "").Return(nil, errors.New("error")).Once()
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil, WithEventStream(streamMock))
+
+		_, err := service.SubscribeEvents(ctx, &requests.EventStreamSubscribe{TenantID: tenantID})
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("follows the namespace from the last event", func(t *testing.T) {
+		events := make(chan eventstream.Event)
+
+		streamMock := eventstreammock.NewMockStream(t)
+		streamMock.On("Subscribe", ctx, tenantID, "1700000000000-0").Return((<-chan eventstream.Event)(events), nil).Once()
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil, WithEventStream(streamMock))
+
+		got, err := service.SubscribeEvents(ctx, &requests.EventStreamSubscribe{
+			TenantID:            tenantID,
+			LastEventID:         "1700000000000-0",
+			ReadsAccessPolicies: true,
+			ReadsSSHApprovals:   true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, (<-chan eventstream.Event)(events), got)
+	})
+
+	t.Run("leaves out of an observer's stream the events it could not read", func(t *testing.T) {
+		events := make(chan eventstream.Event, 5)
+		events <- eventstream.Event{ID: "1-0", Type: string(models.WebhookEventDeviceOnline), Data: json.RawMessage(`{"uid":"device"}`)}
+		events <- eventstream.Event{ID: "2-0", Type: string(models.WebhookEventAccessPolicyChange), Data: json.RawMessage(`{"change":"created","policy":{"name":"no root"}}`)}
+		events <- eventstream.Event{ID: "3-0", Type: string(models.WebhookEventSSHApprovalPending), Data: json.RawMessage(`{"device_uid":"device","fingerprint":"SHA256:bob"}`)}
+		events <- eventstream.Event{ID: "4-0", Type: string(models.WebhookEventSSHApprovalConfirmed), Data: json.RawMessage(`{"device_uid":"device","decided_by":"bob"}`)}
+		events <- eventstream.Event{ID: "5-0", Type: string(models.WebhookEventSSHApprovalConfirmed), Data: json.RawMessage(`{"device_uid":"device","decided_by":"alice"}`)}
+		close(events)
+
+		streamMock := eventstreammock.NewMockStream(t)
+		streamMock.On("Subscribe", ctx, tenantID, "").Return((<-chan eventstream.Event)(events), nil).Once()
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil, WithEventStream(streamMock))
+
+		got, err := service.SubscribeEvents(ctx, &requests.EventStreamSubscribe{TenantID: tenantID, UserID: "alice"})
+		require.NoError(t, err)
+
+		ids := make([]string, 0)
+		for event := range got {
+			ids = append(ids, event.ID)
+		}
+
+		assert.Equal(t, []string{"1-0", "5-0"}, ids)
+	})
+
+	t.Run("follows only the events about the devices in an API key's tag scope", func(t *testing.T) {
+		events := make(chan eventstream.Event, 5)
+		events <- eventstream.Event{ID: "1-0", Type: string(models.WebhookEventDeviceOnline), Data: json.RawMessage(`{"uid":"in"}`)}
+		events <- eventstream.Event{ID: "2-0", Type: string(models.WebhookEventSessionStart), Data: json.RawMessage(`{"uid":"session","device_uid":"out"}`)}
+		events <- eventstream.Event{ID: "3-0", Type: string(models.WebhookEventAccessPolicyChange), Data: json.RawMessage(`{"change":"created"}`)}
+		events <- eventstream.Event{ID: "4-0", Type: string(models.WebhookEventSessionClose), Data: json.RawMessage(`{"uid":"session","device_uid":"in"}`)}
+		events <- eventstream.Event{ID: "5-0", Type: string(models.WebhookEventSSHApprovalPending), Data: json.RawMessage(`{"device_uid":"out"}`)}
+		close(events)
+
+		streamMock := eventstreammock.NewMockStream(t)
+		streamMock.On("Subscribe", ctx, tenantID, "").Return((<-chan eventstream.Event)(events), nil).Once()
+
+		clockMock.On("Now").Return(now)
+
+		sc := scope.MustBounded(tenantID)
+		scopeTags := &query.Filters{Data: []query.Filter{
+			{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "tags.name", Operator: "eq", Value: "production"}},
+		}}
+
+		queryOptionsMock := storemock.NewMockQueryOptions(t)
+		queryOptionsMock.On("Match", scopeTags).Return(nil)
+
+		// Whether a device is in the scope is read once, not once per event.
+		storeMock := storemock.NewMockStore(t)
+		storeMock.On("Options").Return(queryOptionsMock)
+		storeMock.On("DeviceResolve", ctx, sc, store.DeviceUIDResolver, "in", mock.Anything).Return(&models.Device{UID: "in"}, nil).Once()
+		storeMock.On("DeviceResolve", ctx, sc, store.DeviceUIDResolver, "out", mock.Anything).Return(nil, store.ErrNoDocuments).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil, WithEventStream(streamMock))
+
+		got, err := service.SubscribeEvents(ctx, &requests.EventStreamSubscribe{TenantID: tenantID, ScopeTags: []string{"production"}})
+		require.NoError(t, err)
+
+		ids := make([]string, 0)
+		for event := range got {
+			ids = append(ids, event.ID)
+		}
+
+		assert.Equal(t, []string{"1-0", "4-0"}, ids)
+	})
+}
+
+func TestEmitEvent(t *testing.T) {
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	ctx := context.TODO()
+
+	t.Run("publishes the event to the stream", func(t *testing.T) {
+		device := &models.Device{UID: "uid"}
+
+		streamMock := eventstreammock.NewMockStream(t)
+		streamMock.On("Publish", ctx, tenantID, string(models.WebhookEventDeviceOnline), device).Return(nil).Once()
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil, WithEventStream(streamMock))
+
+		assert.True(t, service.emitsEvents())
+		service.emitEvent(ctx, tenantID, models.WebhookEventDeviceOnline, device)
+	})
+
+	t.Run("a failed publish does not fail the caller", func(t *testing.T) {
+		streamMock := eventstreammock.NewMockStream(t)
+		streamMock.On("Publish", ctx, tenantID, string(models.WebhookEventDeviceOffline), &models.Device{}).Return(errors.New("error")).Once()
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil, WithEventStream(streamMock))
+
+		service.emitEvent(ctx, tenantID, models.WebhookEventDeviceOffline, &models.Device{})
+	})
+
+	t.Run("publishes nothing without a namespace", func(t *testing.T) {
+		// NewMockStream fails the test on any unexpected call.
+		streamMock := eventstreammock.NewMockStream(t)
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil, WithEventStream(streamMock))
+
+		service.publishStreamEvent(ctx, "", models.WebhookEventDeviceOnline, &models.Device{})
+	})
+
+	t.Run("goes nowhere without a worker or a stream", func(t *testing.T) {
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil)
+
+		assert.False(t, service.emitsEvents())
+		service.emitEvent(ctx, tenantID, models.WebhookEventDeviceOnline, &models.Device{})
+	})
+}
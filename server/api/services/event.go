@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// eventAccessPolicyChange is the data of an [models.WebhookEventAccessPolicyChange]
+// event: what happened to the policy, and the policy as it was left, or, for a
+// deletion, as it was.
+type eventAccessPolicyChange struct {
+	Change string               `json:"change"`
+	Policy *models.AccessPolicy `json:"policy"`
+}
+
+// eventSSHApproval is the data of an SSH approval event. The code is left out:
+// it is what the user confirms in the browser, and it is only meant to be seen
+// on the terminal that asked for it.
+type eventSSHApproval struct {
+	Kind        models.SSHApprovalKind  `json:"kind"`
+	State       models.SSHApprovalState `json:"state"`
+	SessionUID  string                  `json:"session_uid"`
+	DeviceUID   string                  `json:"device_uid"`
+	DeviceName  string                  `json:"device_name"`
+	Username    string                  `json:"username"`
+	IPAddress   string                  `json:"ip_address"`
+	Fingerprint string                  `json:"fingerprint"`
+	// DecidedBy is the account that confirmed or rejected the login.
+	DecidedBy   string    `json:"decided_by,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func newEventSSHApproval(approval *models.SSHApproval) eventSSHApproval {
+	return eventSSHApproval{
+		Kind:        approval.Kind,
+		State:       approval.State,
+		SessionUID:  approval.SessionUID,
+		DeviceUID:   approval.DeviceUID,
+		DeviceName:  approval.DeviceName,
+		Username:    approval.Username,
+		IPAddress:   approval.IPAddress,
+		Fingerprint: approval.Fingerprint,
+		DecidedBy:   approval.DecidedBy,
+		RequestedAt: approval.RequestedAt,
+		ExpiresAt:   approval.ExpiresAt,
+	}
+}
+
+// emitEvent announces something that happened in a namespace, once it has, to
+// the webhooks subscribed to it and to the clients following the namespace's
+// live stream. It never fails the caller.
+func (s *service) emitEvent(ctx context.Context, tenantID string, event models.WebhookEventType, data any) {
+	s.emitWebhookEvent(ctx, tenantID, event, data)
+	s.publishStreamEvent(ctx, tenantID, event, data)
+}
+
+// emitsEvents reports whether an event would go anywhere, so that a caller can
+// skip the reads that only serve to build one.
+func (s *service) emitsEvents() bool {
+	return s.worker != nil || s.events != nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/server/api/store"
 	log "github.com/sirupsen/logrus"
 )
@@ -49,22 +50,38 @@ type deviceHeartbeat struct {
 // queue drops the beat instead, which costs nothing — the next one arrives well
 // within the online threshold.
 type DeviceHeartbeater struct {
-	store   store.Store
-	queue   chan deviceHeartbeat
-	done    chan struct{}
-	wg      sync.WaitGroup
-	dropped atomic.Uint64
+	store       store.Store
+	onReconnect func(ctx context.Context, devices []models.Device)
+	queue       chan deviceHeartbeat
+	done        chan struct{}
+	wg          sync.WaitGroup
+	dropped     atomic.Uint64
+}
+
+type DeviceHeartbeaterOption func(h *DeviceHeartbeater)
+
+// WithReconnectHandler hands fn the devices each flush brings back online: the
+// ones marked disconnected while their tunnel kept beating. Telling them apart
+// costs a read per flush, so it is only done when there is a handler.
+func WithReconnectHandler(fn func(ctx context.Context, devices []models.Device)) DeviceHeartbeaterOption {
+	return func(h *DeviceHeartbeater) {
+		h.onReconnect = fn
+	}
 }
 
 // NewDeviceHeartbeater returns a running heartbeater. Call Shutdown to flush
 // what is pending and stop it.
-func NewDeviceHeartbeater(s store.Store) *DeviceHeartbeater {
+func NewDeviceHeartbeater(s store.Store, opts ...DeviceHeartbeaterOption) *DeviceHeartbeater {
 	h := &DeviceHeartbeater{
 		store: s,
 		queue: make(chan deviceHeartbeat, deviceHeartbeatQueueSize),
 		done:  make(chan struct{}),
 	}
 
+	for _, opt := range opts {
+		opt(h)
+	}
+
 	h.wg.Add(1)
 	go h.run()
 
@@ -156,6 +173,18 @@ func (h *DeviceHeartbeater) flush(batch *deviceHeartbeatBatch) {
 	ctx, cancel := context.WithTimeout(context.Background(), deviceHeartbeatWriteTimeout)
 	defer cancel()
 
+	// Read before the write, since the write is what clears disconnected_at. A
+	// failed read only costs the announcement, never the beats.
+	var reconnected []models.Device
+	if h.onReconnect != nil {
+		var err error
+		if reconnected, err = h.store.DeviceListDisconnected(ctx, uids); err != nil {
+			log.WithError(err).
+				WithField("devices", len(uids)).
+				Warn("failed to read the disconnected devices of the heartbeat batch")
+		}
+	}
+
 	modified, err := h.store.DeviceHeartbeat(ctx, uids, seenAt)
 	if err != nil {
 		log.WithError(err).
@@ -167,6 +196,16 @@ func (h *DeviceHeartbeater) flush(batch *deviceHeartbeatBatch) {
 
 	log.WithFields(log.Fields{"devices": len(uids), "modified": modified}).
 		Debug("wrote the device heartbeat batch")
+
+	if len(reconnected) > 0 {
+		for i := range reconnected {
+			reconnected[i].LastSeen = seenAt
+			reconnected[i].DisconnectedAt = nil
+			reconnected[i].Online = true
+		}
+
+		h.onReconnect(ctx, reconnected)
+	}
 }
 
 // deviceHeartbeatBatch accumulates the devices seen in a flush window.
@@ -8,6 +8,7 @@ import (
 
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -148,3 +149,58 @@ func TestDeviceHeartbeater_submitDoesNotBlockWhenTheQueueIsFull(t *testing.T) {
 
 	require.NoError(t, h.Shutdown(context.Background()))
 }
+
+func TestDeviceHeartbeater_announcesTheDevicesItBringsBack(t *testing.T) {
+	fixedClock(t, now)
+
+	disconnectedAt := now.Add(-time.Hour)
+
+	storeMock := storemock.NewMockStore(t)
+	storeMock.
+		On("DeviceListDisconnected", mock.Anything, []string{"device-a", "device-b"}).
+		Return([]models.Device{{UID: "device-b", TenantID: "tenant", DisconnectedAt: &disconnectedAt}}, nil).
+		Once()
+	storeMock.
+		On("DeviceHeartbeat", mock.Anything, []string{"device-a", "device-b"}, now).
+		Return(int64(2), nil).
+		Once()
+
+	var announced []models.Device
+	h := NewDeviceHeartbeater(storeMock, WithReconnectHandler(func(_ context.Context, devices []models.Device) {
+		announced = devices
+	}))
+
+	h.Submit("device-a")
+	h.Submit("device-b")
+
+	require.NoError(t, h.Shutdown(context.Background()))
+
+	// Only device-b was disconnected, and it is announced as the beat left it.
+	assert.Equal(t, []models.Device{{UID: "device-b", TenantID: "tenant", LastSeen: now, Online: true}}, announced)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeviceHeartbeater_announcesNothingWhenTheWriteFails(t *testing.T) {
+	fixedClock(t, now)
+
+	storeMock := storemock.NewMockStore(t)
+	storeMock.
+		On("DeviceListDisconnected", mock.Anything, []string{"device-a"}).
+		Return([]models.Device{{UID: "device-a", TenantID: "tenant"}}, nil).
+		Once()
+	storeMock.
+		On("DeviceHeartbeat", mock.Anything, []string{"device-a"}, now).
+		Return(int64(0), errors.New("error")).
+		Once()
+
+	h := NewDeviceHeartbeater(storeMock, WithReconnectHandler(func(context.Context, []models.Device) {
+		assert.Fail(t, "a device the write did not bring back was announced")
+	}))
+
+	h.Submit("device-a")
+
+	require.NoError(t, h.Shutdown(context.Background()))
+
+	storeMock.AssertExpectations(t)
+}
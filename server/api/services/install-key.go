@@ -154,6 +154,25 @@ func (s *service) decryptInstallKey(encoded string) (string, error) {
 	return string(plaintext), nil
 }
 
+// generateInstallKey draws a new plaintext key for installKey and returns it. The plaintext's SHA256
+// digest becomes the key's ID, so an enrolling agent's presented key can be matched by hashing it the
+// same way. The plaintext is also kept encrypted at rest so an admin can reveal it later, alongside a
+// short non-secret hint for the masked list display.
+func (s *service) generateInstallKey(installKey *models.InstallKey) (string, error) {
+	key := uuid.Generate()
+
+	encryptedKey, err := s.encryptInstallKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	installKey.ID = hashInstallKey(key)
+	installKey.KeyEncrypted = encryptedKey
+	installKey.KeyHint = installKeyHint(key)
+
+	return key, nil
+}
+
 type InstallKeyService interface {
 	// CreateInstallKey creates a new install key for the specified namespace. It generates a random key,
 	// stores its SHA256 hash plus an encrypted-at-rest copy, and returns the plaintext key once. It
@@ -215,29 +234,7 @@ func (s *service) CreateInstallKey(ctx context.Context, req *requests.CreateInst
 		}
 	}
 
-	key := uuid.Generate()
-
-	// The plaintext's SHA256 digest is the primary key, so an enrolling agent's presented key can be
-	// matched by hashing it the same way. The plaintext is also kept encrypted at rest so an admin can
-	// reveal it later, alongside a short non-secret hint for the masked list display.
-	hashedKey := hashInstallKey(key)
-
-	encryptedKey, err := s.encryptInstallKey(key)
-	if err != nil {
-		return nil, err
-	}
-
-	conflicts, has, err := s.store.InstallKeyConflicts(ctx, sc, &models.InstallKeyConflicts{ID: hashedKey, Name: req.Name})
-	if err != nil {
-		return nil, err
-	}
-
-	if has {
-		return nil, NewErrInstallKeyDuplicated(conflicts)
-	}
-
 	data := &models.InstallKey{
-		ID:                 hashedKey,
 		Name:               req.Name,
 		TenantID:           req.TenantID,
 		Mode:               mode,
@@ -253,8 +250,20 @@ func (s *service) CreateInstallKey(ctx context.Context, req *requests.CreateInst
 		Tags:               req.Tags,
 		ExpiresAt:          installKeyExpiry(req.ExpiresIn),
 		CreatedBy:          req.UserID,
-		KeyEncrypted:       encryptedKey,
-		KeyHint:            installKeyHint(key),
+	}
+
+	key, err := s.generateInstallKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, has, err := s.store.InstallKeyConflicts(ctx, sc, &models.InstallKeyConflicts{ID: data.ID, Name: req.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	if has {
+		return nil, NewErrInstallKeyDuplicated(conflicts)
 	}
 
 	if _, err := s.store.InstallKeyCreate(ctx, data); err != nil {
@@ -263,7 +272,7 @@ func (s *service) CreateInstallKey(ctx context.Context, req *requests.CreateInst
 
 	// Bounded: the digest is only unique per namespace, so an unbounded read-back here could return
 	// another namespace's key that happens to share the digest.
-	installKey, err := s.store.InstallKeyResolve(ctx, sc, store.InstallKeyIDResolver, hashedKey)
+	installKey, err := s.store.InstallKeyResolve(ctx, sc, store.InstallKeyIDResolver, data.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -467,7 +476,19 @@ func (s *service) RevealInstallKey(ctx context.Context, req *requests.RevealInst
 		return "", NewErrInstallKeyNotFound(req.Name, nil)
 	}
 
-	return s.decryptInstallKey(installKey.KeyEncrypted)
+	key, err := s.decryptInstallKey(installKey.KeyEncrypted)
+	if err != nil {
+		return "", err
+	}
+
+	s.recordAudit(ctx, &models.AuditEvent{
+		TenantID:   req.TenantID,
+		Action:     models.AuditActionInstallKeyReveal,
+		TargetType: models.AuditTargetInstallKey,
+		TargetID:   installKey.ID,
+	})
+
+	return key, nil
 }
 
 func (s *service) ListInstallKeyEvents(ctx context.Context, req *requests.ListInstallKeyEvents) ([]models.InstallKeyEvent, int, error) {
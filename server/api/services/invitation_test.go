@@ -334,6 +334,9 @@ func TestService_GenerateInvitationLink(t *testing.T) {
 					Return(nil, store.ErrNoDocuments).Once()
 				storeMock.On("MembershipInvitationCreate", ctx, mock.AnythingOfType("*models.MembershipInvitation")).
 					Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionMemberInvite
+				})).Return(nil).Once()
 			},
 			expected: Expected{true, nil},
 		},
@@ -352,6 +355,9 @@ func TestService_GenerateInvitationLink(t *testing.T) {
 					Return(nil, store.ErrNoDocuments).Once()
 				storeMock.On("MembershipInvitationCreate", ctx, mock.AnythingOfType("*models.MembershipInvitation")).
 					Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionMemberInvite
+				})).Return(nil).Once()
 			},
 			expected: Expected{true, nil},
 		},
@@ -374,6 +380,9 @@ func TestService_GenerateInvitationLink(t *testing.T) {
 					}, nil).Once()
 				storeMock.On("MembershipInvitationUpdate", ctx, mock.AnythingOfType("*models.MembershipInvitation")).
 					Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionMemberInvite
+				})).Return(nil).Once()
 			},
 			expected: Expected{true, nil},
 		},
@@ -390,6 +399,9 @@ func TestService_GenerateInvitationLink(t *testing.T) {
 				storeMock.On("NamespaceCreateMembership", ctx, scope.MustBounded("tenant"), &models.Member{
 					ID: "invitee", AddedAt: now, Role: authorizer.RoleOperator,
 				}).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionMemberAdd
+				})).Return(nil).Once()
 			},
 			expected: Expected{false, nil},
 		},
@@ -86,9 +86,9 @@ func (s *service) resolveActingMember(ctx context.Context, tenantID, actorID str
 // admitMember places a user into a namespace as a member and, when an invitation is supplied,
 // consumes it — the single "add-and-consume" write shared by every intake path.
 //
-// It opens no transaction of its own: the store's WithTransaction is not reentrant, so a caller that
-// needs the create and the delete to be atomic must wrap the call in its own transaction. admitMember
-// then joins that ambient transaction through the context.
+// It opens no transaction of its own, so a caller that needs the create and the delete to be atomic
+// must wrap the call in its own transaction. admitMember then joins that ambient transaction through
+// the context.
 func (s *service) admitMember(ctx context.Context, sc scope.Scope, member *models.Member, invitation *models.MembershipInvitation) error {
 	if err := s.store.NamespaceCreateMembership(ctx, sc, member); err != nil {
 		return err
@@ -130,6 +130,7 @@ func (s *service) intakeMembership(ctx context.Context, namespace *models.Namesp
 
 	var (
 		invitation    *models.MembershipInvitation
+		admitted      *models.Member
 		recipientName string
 	)
 
@@ -155,9 +156,9 @@ func (s *service) intakeMembership(ctx context.Context, namespace *models.Namesp
 		}
 
 		if userExists && directMembershipAllowed() {
-			member := &models.Member{ID: passiveUser.ID, AddedAt: clock.Now(), Role: role}
+			admitted = &models.Member{ID: passiveUser.ID, AddedAt: clock.Now(), Role: role}
 
-			return s.admitMember(ctx, scope.MustBounded(namespace.TenantID), member, nil)
+			return s.admitMember(ctx, scope.MustBounded(namespace.TenantID), admitted, nil)
 		}
 
 		existing, err := s.store.MembershipInvitationResolve(ctx, scope.MustBounded(namespace.TenantID), passiveUser.ID)
@@ -185,7 +186,19 @@ func (s *service) intakeMembership(ctx context.Context, namespace *models.Namesp
 		return nil, err
 	}
 
+	if admitted != nil {
+		added := *admitted
+		added.Email = email
+
+		s.recordAuditChange(ctx, namespace.TenantID, models.AuditActionMemberAdd, models.AuditTargetMember, added.ID, nil, &added)
+	}
+
 	if invitation != nil {
+		s.recordAuditChange(ctx, namespace.TenantID, models.AuditActionMemberInvite, models.AuditTargetMember, invitation.UserID, nil, map[string]any{
+			"email": email,
+			"role":  invitation.Role,
+		})
+
 		notification := &models.MembershipInvitationNotification{
 			Signature:      invitation.Sig,
 			ExpiresAt:      *invitation.ExpiresAt,
@@ -296,6 +309,8 @@ func (s *service) UpdateNamespaceMember(ctx context.Context, req *requests.Names
 		return NewErrRoleForbidden()
 	}
 
+	current := *member
+
 	if req.MemberRole != authorizer.RoleInvalid {
 		if !active.Role.HasAuthority(req.MemberRole) {
 			return NewErrRoleForbidden()
@@ -308,6 +323,16 @@ func (s *service) UpdateNamespaceMember(ctx context.Context, req *requests.Names
 		return err
 	}
 
+	before, after := auditDiff(current, member)
+	s.recordAudit(ctx, &models.AuditEvent{
+		TenantID:   namespace.TenantID,
+		Action:     models.AuditActionMemberUpdate,
+		TargetType: models.AuditTargetMember,
+		TargetID:   member.ID,
+		Before:     before,
+		After:      after,
+	})
+
 	s.AuthUncacheToken(ctx, namespace.TenantID, req.MemberID) // nolint: errcheck
 
 	return nil
@@ -420,6 +445,73 @@ func (s *service) removeMember(ctx context.Context, ns *models.Namespace, member
 			Error("failed to revoke the removed member's API keys")
 	}
 
+	s.recordAuditChange(ctx, ns.TenantID, models.AuditActionMemberRemove, models.AuditTargetMember, member.ID, member, nil)
+
+	// Before the account can go with its last membership, which unbinds its sessions.
+	s.reevaluateSessions(ctx, ns.TenantID, s.activeSessions(ctx, ns.TenantID, member.ID))
+
+	return nil
+}
+
+// syncMemberships applies the roles an identity provider's group mapping resolved to the user's
+// memberships: the user joins the namespaces a role is granted in, takes that role where they are
+// already a member, and leaves the managed namespaces granting them [authorizer.RoleInvalid].
+// Namespaces the mapping does not manage, and the namespaces the user owns, are left as they are.
+func (s *service) syncMemberships(ctx context.Context, user *models.User, roles map[string]authorizer.Role) error {
+	for tenantID, role := range roles {
+		namespace, err := s.store.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, tenantID)
+		if err != nil {
+			log.WithError(err).
+				WithField("tenant_id", tenantID).
+				Warn("the group role mapping names a namespace that cannot be resolved")
+
+			continue
+		}
+
+		member, ok := namespace.FindMember(user.ID)
+		switch {
+		case ok && member.Role == authorizer.RoleOwner:
+			continue
+		case !ok && role != authorizer.RoleInvalid:
+			member := &models.Member{ID: user.ID, AddedAt: clock.Now(), Role: role}
+			if err := s.admitMember(ctx, scope.MustBounded(tenantID), member, nil); err != nil {
+				return err
+			}
+		case ok && role == authorizer.RoleInvalid:
+			if err := s.removeMember(ctx, namespace, member); err != nil {
+				return err
+			}
+
+			if user.Preferences.PreferredNamespace == tenantID {
+				// preferred_namespace_id is skipupdate, so a full-model UserUpdate can't clear it.
+				if err := s.store.UserUpdatePreferredNamespace(ctx, user.ID, ""); err != nil {
+					return NewErrUserUpdate(user, err)
+				}
+			}
+
+			s.AuthUncacheToken(ctx, tenantID, user.ID) // nolint: errcheck
+		case ok && member.Role != role:
+			current := *member
+			member.Role = role
+
+			if err := s.store.NamespaceUpdateMembership(ctx, scope.MustBounded(tenantID), member); err != nil {
+				return err
+			}
+
+			before, after := auditDiff(current, member)
+			s.recordAudit(ctx, &models.AuditEvent{
+				TenantID:   tenantID,
+				Action:     models.AuditActionMemberUpdate,
+				TargetType: models.AuditTargetMember,
+				TargetID:   member.ID,
+				Before:     before,
+				After:      after,
+			})
+
+			s.AuthUncacheToken(ctx, tenantID, user.ID) // nolint: errcheck
+		}
+	}
+
 	return nil
 }
 
@@ -185,6 +185,12 @@ func TestService_AddNamespaceMember(t *testing.T) {
 					On("MembershipInvitationCreate", ctx, mock.AnythingOfType("*models.MembershipInvitation")).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionMemberInvite
+					})).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				namespace: &models.Namespace{
@@ -426,6 +432,17 @@ func TestService_UpdateNamespaceMember(t *testing.T) {
 					On("NamespaceUpdateMembership", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), &models.Member{ID: "000000000000000000000001", Role: authorizer.RoleAdministrator}).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, &models.AuditEvent{
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						Action:     models.AuditActionMemberUpdate,
+						TargetType: models.AuditTargetMember,
+						TargetID:   "000000000000000000000001",
+						Before:     map[string]any{},
+						After:      map[string]any{},
+					}).
+					Return(nil).
+					Once()
 				cacheMock.
 					On("Delete", ctx, "token_00000000-0000-4000-0000-000000000000000000000000000000000001").
 					Return(nil).
@@ -507,6 +524,17 @@ func TestService_UpdateNamespaceMember(t *testing.T) {
 					On("NamespaceUpdateMembership", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), &models.Member{ID: "000000000000000000000001", Role: authorizer.RoleObserver}).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, &models.AuditEvent{
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						Action:     models.AuditActionMemberUpdate,
+						TargetType: models.AuditTargetMember,
+						TargetID:   "000000000000000000000001",
+						Before:     map[string]any{"role": "administrator"},
+						After:      map[string]any{"role": "observer"},
+					}).
+					Return(nil).
+					Once()
 				cacheMock.
 					On("Delete", ctx, "token_00000000-0000-4000-0000-000000000000000000000000000000000001").
 					Return(nil).
@@ -618,6 +646,12 @@ func TestService_UpdateNamespaceMember(t *testing.T) {
 					On("NamespaceUpdateMembership", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), &models.Member{ID: "000000000000000000000001", Role: authorizer.RoleObserver}).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.TargetID == "000000000000000000000001" && event.After["role"] == "observer"
+					})).
+					Return(nil).
+					Once()
 				cacheMock.
 					On("Delete", ctx, "token_00000000-0000-4000-0000-000000000000000000000000000000000001").
 					Return(nil).
@@ -763,6 +797,16 @@ func TestService_RemoveNamespaceMember(t *testing.T) {
 					On("APIKeyDeleteAllByCreator", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000001").
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionMemberRemove && event.TenantID == "00000000-0000-4000-0000-000000000000" && event.TargetID == "000000000000000000000001"
+					})).
+					Return(nil).
+					Once()
+				storeMock.
+					On("SessionListActive", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), "000000000000000000000001").
+					Return([]models.Session{}, nil).
+					Once()
 				// Instance bound to this namespace: single-tenant Community.
 				storeMock.
 					On("SystemGet", ctx).
@@ -832,6 +876,16 @@ func TestService_RemoveNamespaceMember(t *testing.T) {
 					On("APIKeyDeleteAllByCreator", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000001").
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionMemberRemove && event.TenantID == "00000000-0000-4000-0000-000000000000" && event.TargetID == "000000000000000000000001"
+					})).
+					Return(nil).
+					Once()
+				storeMock.
+					On("SessionListActive", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), "000000000000000000000001").
+					Return([]models.Session{}, nil).
+					Once()
 				storeMock.
 					On("SystemGet", ctx).
 					Return(&models.System{InstanceTenantID: "00000000-0000-4000-0000-000000000000"}, nil).
@@ -1072,6 +1126,16 @@ func TestService_RemoveNamespaceMember(t *testing.T) {
 					On("APIKeyDeleteAllByCreator", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000001").
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionMemberRemove && event.TenantID == "00000000-0000-4000-0000-000000000000" && event.TargetID == "000000000000000000000001"
+					})).
+					Return(nil).
+					Once()
+				storeMock.
+					On("SessionListActive", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), "000000000000000000000001").
+					Return([]models.Session{}, nil).
+					Once()
 				storeMock.
 					On("SystemGet", ctx).
 					Return(&models.System{}, nil).
@@ -1187,6 +1251,16 @@ func TestService_RemoveNamespaceMember(t *testing.T) {
 					On("APIKeyDeleteAllByCreator", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000001").
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionMemberRemove && event.TenantID == "00000000-0000-4000-0000-000000000000" && event.TargetID == "000000000000000000000001"
+					})).
+					Return(nil).
+					Once()
+				storeMock.
+					On("SessionListActive", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), "000000000000000000000001").
+					Return([]models.Session{}, nil).
+					Once()
 				storeMock.
 					On("SystemGet", ctx).
 					Return(&models.System{}, nil).
@@ -1383,6 +1457,16 @@ func TestService_LeaveNamespace(t *testing.T) {
 					On("APIKeyDeleteAllByCreator", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000000").
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionMemberRemove && event.TenantID == "00000000-0000-4000-0000-000000000000" && event.TargetID == "000000000000000000000000"
+					})).
+					Return(nil).
+					Once()
+				storeMock.
+					On("SessionListActive", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), "000000000000000000000000").
+					Return([]models.Session{}, nil).
+					Once()
 			},
 			expected: Expected{
 				res: nil,
@@ -1477,6 +1561,16 @@ func TestService_LeaveNamespace(t *testing.T) {
 					On("APIKeyDeleteAllByCreator", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000000").
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionMemberRemove && event.TenantID == "00000000-0000-4000-0000-000000000000" && event.TargetID == "000000000000000000000000"
+					})).
+					Return(nil).
+					Once()
+				storeMock.
+					On("SessionListActive", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), "000000000000000000000000").
+					Return([]models.Session{}, nil).
+					Once()
 				storeMock.
 					On("UserResolve", ctx, store.UserIDResolver, "000000000000000000000000").
 					Return(user, nil).
@@ -1567,6 +1661,9 @@ func TestService_AddNamespaceMember_LowercasesEmail(t *testing.T) {
 	storeMock.On("UserInvitationsUpsert", ctx, "jane@test.com").Return("placeholder", nil).Once()
 	storeMock.On("MembershipInvitationResolve", ctx, scope.MustBounded(ns.TenantID), "placeholder").Return(nil, store.ErrNoDocuments).Once()
 	storeMock.On("MembershipInvitationCreate", ctx, mock.AnythingOfType("*models.MembershipInvitation")).Return(nil).Once()
+	storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionMemberInvite && event.TargetID == "placeholder"
+	})).Return(nil).Once()
 
 	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache())
 	_, err := s.AddNamespaceMember(ctx, &requests.NamespaceAddMember{
@@ -1611,6 +1708,9 @@ func TestService_AddNamespaceMember_FiresNotification(t *testing.T) {
 	storeMock.On("MembershipInvitationCreate", ctx, mock.AnythingOfType("*models.MembershipInvitation")).
 		Run(func(args mock.Arguments) { created = args.Get(1).(*models.MembershipInvitation) }).
 		Return(nil).Once()
+	storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionMemberInvite && event.TargetID == "invitee"
+	})).Return(nil).Once()
 
 	var got *models.MembershipInvitationNotification
 	OnMembershipInvited(func(_ context.Context, n *models.MembershipInvitationNotification) error {
@@ -1671,6 +1771,9 @@ func TestService_AddNamespaceMember_DirectMembershipFiresNoHook(t *testing.T) {
 	storeMock.On("NamespaceCreateMembership", ctx, scope.MustBounded(ns.TenantID), &models.Member{
 		ID: "invitee", AddedAt: now, Role: authorizer.RoleObserver,
 	}).Return(nil).Once()
+	storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionMemberAdd && event.TargetID == "invitee"
+	})).Return(nil).Once()
 
 	hookCalled := false
 	OnMembershipInvited(func(context.Context, *models.MembershipInvitationNotification) error {
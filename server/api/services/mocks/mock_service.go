@@ -7,15 +7,19 @@ package mocks
 import (
 	"context"
 	"crypto/rsa"
+	"io"
 
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/api/responses"
 	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/eventstream"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	responses0 "github.com/shellhub-io/shellhub/server/api/pkg/responses"
+	"github.com/shellhub-io/shellhub/server/api/pkg/scim"
 	"github.com/shellhub-io/shellhub/server/api/services"
 	"github.com/shellhub-io/shellhub/server/api/store"
 	mock "github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/ssh"
 )
 
 // NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -244,6 +248,74 @@ func (_c *MockService_AddNamespaceMember_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// ApplyPolicyBundle provides a mock function for the type MockService
+func (_mock *MockService) ApplyPolicyBundle(ctx context.Context, req *requests.PolicyBundleApply) (*models.PolicyBundlePlan, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyPolicyBundle")
+	}
+
+	var r0 *models.PolicyBundlePlan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.PolicyBundleApply) (*models.PolicyBundlePlan, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.PolicyBundleApply) *models.PolicyBundlePlan); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PolicyBundlePlan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.PolicyBundleApply) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ApplyPolicyBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyPolicyBundle'
+type MockService_ApplyPolicyBundle_Call struct {
+	*mock.Call
+}
+
+// ApplyPolicyBundle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.PolicyBundleApply
+func (_e *MockService_Expecter) ApplyPolicyBundle(ctx any, req any) *MockService_ApplyPolicyBundle_Call {
+	return &MockService_ApplyPolicyBundle_Call{Call: _e.mock.On("ApplyPolicyBundle", ctx, req)}
+}
+
+func (_c *MockService_ApplyPolicyBundle_Call) Run(run func(ctx context.Context, req *requests.PolicyBundleApply)) *MockService_ApplyPolicyBundle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.PolicyBundleApply
+		if args[1] != nil {
+			arg1 = args[1].(*requests.PolicyBundleApply)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ApplyPolicyBundle_Call) Return(policyBundlePlan *models.PolicyBundlePlan, err error) *MockService_ApplyPolicyBundle_Call {
+	_c.Call.Return(policyBundlePlan, err)
+	return _c
+}
+
+func (_c *MockService_ApplyPolicyBundle_Call) RunAndReturn(run func(ctx context.Context, req *requests.PolicyBundleApply) (*models.PolicyBundlePlan, error)) *MockService_ApplyPolicyBundle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AuthAPIKey provides a mock function for the type MockService
 func (_mock *MockService) AuthAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
 	ret := _mock.Called(ctx, key)
@@ -607,6 +679,74 @@ func (_c *MockService_AuthLocalUser_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// AuthOIDCUser provides a mock function for the type MockService
+func (_mock *MockService) AuthOIDCUser(ctx context.Context, req *requests.AuthOIDCUser) (*models.UserAuthResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthOIDCUser")
+	}
+
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AuthOIDCUser) (*models.UserAuthResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AuthOIDCUser) *models.UserAuthResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AuthOIDCUser) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_AuthOIDCUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthOIDCUser'
+type MockService_AuthOIDCUser_Call struct {
+	*mock.Call
+}
+
+// AuthOIDCUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.AuthOIDCUser
+func (_e *MockService_Expecter) AuthOIDCUser(ctx any, req any) *MockService_AuthOIDCUser_Call {
+	return &MockService_AuthOIDCUser_Call{Call: _e.mock.On("AuthOIDCUser", ctx, req)}
+}
+
+func (_c *MockService_AuthOIDCUser_Call) Run(run func(ctx context.Context, req *requests.AuthOIDCUser)) *MockService_AuthOIDCUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.AuthOIDCUser
+		if args[1] != nil {
+			arg1 = args[1].(*requests.AuthOIDCUser)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_AuthOIDCUser_Call) Return(res *models.UserAuthResponse, err error) *MockService_AuthOIDCUser_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *MockService_AuthOIDCUser_Call) RunAndReturn(run func(ctx context.Context, req *requests.AuthOIDCUser) (*models.UserAuthResponse, error)) *MockService_AuthOIDCUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AuthPublicKey provides a mock function for the type MockService
 func (_mock *MockService) AuthPublicKey(ctx context.Context, req requests.PublicKeyAuth) (*models.PublicKeyAuthResponse, error) {
 	ret := _mock.Called(ctx, req)
@@ -675,6 +815,63 @@ func (_c *MockService_AuthPublicKey_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// AuthSCIM provides a mock function for the type MockService
+func (_mock *MockService) AuthSCIM(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthSCIM")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_AuthSCIM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthSCIM'
+type MockService_AuthSCIM_Call struct {
+	*mock.Call
+}
+
+// AuthSCIM is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockService_Expecter) AuthSCIM(ctx any, token any) *MockService_AuthSCIM_Call {
+	return &MockService_AuthSCIM_Call{Call: _e.mock.On("AuthSCIM", ctx, token)}
+}
+
+func (_c *MockService_AuthSCIM_Call) Run(run func(ctx context.Context, token string)) *MockService_AuthSCIM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_AuthSCIM_Call) Return(err error) *MockService_AuthSCIM_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_AuthSCIM_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockService_AuthSCIM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AuthUncacheToken provides a mock function for the type MockService
 func (_mock *MockService) AuthUncacheToken(ctx context.Context, tenant string, id string) error {
 	ret := _mock.Called(ctx, tenant, id)
@@ -830,138 +1027,298 @@ func (_c *MockService_Authorize_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
-// CancelMembershipInvitation provides a mock function for the type MockService
-func (_mock *MockService) CancelMembershipInvitation(ctx context.Context, req *requests.CancelMembershipInvitation) error {
-	ret := _mock.Called(ctx, req)
+// AuthorizeAPIKey provides a mock function for the type MockService
+func (_mock *MockService) AuthorizeAPIKey(ctx context.Context, tenantID string, name string, deviceUID string, login string, sourceIP string) (*models.Decision, error) {
+	ret := _mock.Called(ctx, tenantID, name, deviceUID, login, sourceIP)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CancelMembershipInvitation")
+		panic("no return value specified for AuthorizeAPIKey")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.CancelMembershipInvitation) error); ok {
-		r0 = returnFunc(ctx, req)
+	var r0 *models.Decision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) (*models.Decision, error)); ok {
+		return returnFunc(ctx, tenantID, name, deviceUID, login, sourceIP)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) *models.Decision); ok {
+		r0 = returnFunc(ctx, tenantID, name, deviceUID, login, sourceIP)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Decision)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, tenantID, name, deviceUID, login, sourceIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_CancelMembershipInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelMembershipInvitation'
-type MockService_CancelMembershipInvitation_Call struct {
+// MockService_AuthorizeAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthorizeAPIKey'
+type MockService_AuthorizeAPIKey_Call struct {
 	*mock.Call
 }
 
-// CancelMembershipInvitation is a helper method to define mock.On call
+// AuthorizeAPIKey is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.CancelMembershipInvitation
-func (_e *MockService_Expecter) CancelMembershipInvitation(ctx any, req any) *MockService_CancelMembershipInvitation_Call {
-	return &MockService_CancelMembershipInvitation_Call{Call: _e.mock.On("CancelMembershipInvitation", ctx, req)}
+//   - tenantID string
+//   - name string
+//   - deviceUID string
+//   - login string
+//   - sourceIP string
+func (_e *MockService_Expecter) AuthorizeAPIKey(ctx any, tenantID any, name any, deviceUID any, login any, sourceIP any) *MockService_AuthorizeAPIKey_Call {
+	return &MockService_AuthorizeAPIKey_Call{Call: _e.mock.On("AuthorizeAPIKey", ctx, tenantID, name, deviceUID, login, sourceIP)}
 }
 
-func (_c *MockService_CancelMembershipInvitation_Call) Run(run func(ctx context.Context, req *requests.CancelMembershipInvitation)) *MockService_CancelMembershipInvitation_Call {
+func (_c *MockService_AuthorizeAPIKey_Call) Run(run func(ctx context.Context, tenantID string, name string, deviceUID string, login string, sourceIP string)) *MockService_AuthorizeAPIKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.CancelMembershipInvitation
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*requests.CancelMembershipInvitation)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_CancelMembershipInvitation_Call) Return(err error) *MockService_CancelMembershipInvitation_Call {
-	_c.Call.Return(err)
+func (_c *MockService_AuthorizeAPIKey_Call) Return(decision *models.Decision, err error) *MockService_AuthorizeAPIKey_Call {
+	_c.Call.Return(decision, err)
 	return _c
 }
 
-func (_c *MockService_CancelMembershipInvitation_Call) RunAndReturn(run func(ctx context.Context, req *requests.CancelMembershipInvitation) error) *MockService_CancelMembershipInvitation_Call {
+func (_c *MockService_AuthorizeAPIKey_Call) RunAndReturn(run func(ctx context.Context, tenantID string, name string, deviceUID string, login string, sourceIP string) (*models.Decision, error)) *MockService_AuthorizeAPIKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ConfirmSSHApproval provides a mock function for the type MockService
-func (_mock *MockService) ConfirmSSHApproval(ctx context.Context, userID string, req *requests.SSHApprovalConfirm) error {
-	ret := _mock.Called(ctx, userID, req)
+// CancelAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) CancelAccessRequest(ctx context.Context, req *requests.AccessRequestCancel) (*models.AccessRequest, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ConfirmSSHApproval")
+		panic("no return value specified for CancelAccessRequest")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *requests.SSHApprovalConfirm) error); ok {
-		r0 = returnFunc(ctx, userID, req)
+	var r0 *models.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestCancel) (*models.AccessRequest, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestCancel) *models.AccessRequest); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessRequestCancel) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_ConfirmSSHApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmSSHApproval'
-type MockService_ConfirmSSHApproval_Call struct {
+// MockService_CancelAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelAccessRequest'
+type MockService_CancelAccessRequest_Call struct {
 	*mock.Call
 }
 
-// ConfirmSSHApproval is a helper method to define mock.On call
+// CancelAccessRequest is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - req *requests.SSHApprovalConfirm
-func (_e *MockService_Expecter) ConfirmSSHApproval(ctx any, userID any, req any) *MockService_ConfirmSSHApproval_Call {
-	return &MockService_ConfirmSSHApproval_Call{Call: _e.mock.On("ConfirmSSHApproval", ctx, userID, req)}
+//   - req *requests.AccessRequestCancel
+func (_e *MockService_Expecter) CancelAccessRequest(ctx any, req any) *MockService_CancelAccessRequest_Call {
+	return &MockService_CancelAccessRequest_Call{Call: _e.mock.On("CancelAccessRequest", ctx, req)}
 }
 
-func (_c *MockService_ConfirmSSHApproval_Call) Run(run func(ctx context.Context, userID string, req *requests.SSHApprovalConfirm)) *MockService_ConfirmSSHApproval_Call {
+func (_c *MockService_CancelAccessRequest_Call) Run(run func(ctx context.Context, req *requests.AccessRequestCancel)) *MockService_CancelAccessRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *requests.AccessRequestCancel
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *requests.SSHApprovalConfirm
-		if args[2] != nil {
-			arg2 = args[2].(*requests.SSHApprovalConfirm)
+			arg1 = args[1].(*requests.AccessRequestCancel)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_ConfirmSSHApproval_Call) Return(err error) *MockService_ConfirmSSHApproval_Call {
-	_c.Call.Return(err)
+func (_c *MockService_CancelAccessRequest_Call) Return(accessRequest *models.AccessRequest, err error) *MockService_CancelAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
 	return _c
 }
 
-func (_c *MockService_ConfirmSSHApproval_Call) RunAndReturn(run func(ctx context.Context, userID string, req *requests.SSHApprovalConfirm) error) *MockService_ConfirmSSHApproval_Call {
+func (_c *MockService_CancelAccessRequest_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessRequestCancel) (*models.AccessRequest, error)) *MockService_CancelAccessRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ConsumeSSHIdentity provides a mock function for the type MockService
-func (_mock *MockService) ConsumeSSHIdentity(ctx context.Context, tenantID string, fingerprint string) (bool, error) {
-	ret := _mock.Called(ctx, tenantID, fingerprint)
+// CancelMembershipInvitation provides a mock function for the type MockService
+func (_mock *MockService) CancelMembershipInvitation(ctx context.Context, req *requests.CancelMembershipInvitation) error {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ConsumeSSHIdentity")
+		panic("no return value specified for CancelMembershipInvitation")
 	}
 
-	var r0 bool
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return returnFunc(ctx, tenantID, fingerprint)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.CancelMembershipInvitation) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_CancelMembershipInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelMembershipInvitation'
+type MockService_CancelMembershipInvitation_Call struct {
+	*mock.Call
+}
+
+// CancelMembershipInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.CancelMembershipInvitation
+func (_e *MockService_Expecter) CancelMembershipInvitation(ctx any, req any) *MockService_CancelMembershipInvitation_Call {
+	return &MockService_CancelMembershipInvitation_Call{Call: _e.mock.On("CancelMembershipInvitation", ctx, req)}
+}
+
+func (_c *MockService_CancelMembershipInvitation_Call) Run(run func(ctx context.Context, req *requests.CancelMembershipInvitation)) *MockService_CancelMembershipInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.CancelMembershipInvitation
+		if args[1] != nil {
+			arg1 = args[1].(*requests.CancelMembershipInvitation)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CancelMembershipInvitation_Call) Return(err error) *MockService_CancelMembershipInvitation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_CancelMembershipInvitation_Call) RunAndReturn(run func(ctx context.Context, req *requests.CancelMembershipInvitation) error) *MockService_CancelMembershipInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmSSHApproval provides a mock function for the type MockService
+func (_mock *MockService) ConfirmSSHApproval(ctx context.Context, userID string, req *requests.SSHApprovalConfirm) error {
+	ret := _mock.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmSSHApproval")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *requests.SSHApprovalConfirm) error); ok {
+		r0 = returnFunc(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_ConfirmSSHApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmSSHApproval'
+type MockService_ConfirmSSHApproval_Call struct {
+	*mock.Call
+}
+
+// ConfirmSSHApproval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - req *requests.SSHApprovalConfirm
+func (_e *MockService_Expecter) ConfirmSSHApproval(ctx any, userID any, req any) *MockService_ConfirmSSHApproval_Call {
+	return &MockService_ConfirmSSHApproval_Call{Call: _e.mock.On("ConfirmSSHApproval", ctx, userID, req)}
+}
+
+func (_c *MockService_ConfirmSSHApproval_Call) Run(run func(ctx context.Context, userID string, req *requests.SSHApprovalConfirm)) *MockService_ConfirmSSHApproval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *requests.SSHApprovalConfirm
+		if args[2] != nil {
+			arg2 = args[2].(*requests.SSHApprovalConfirm)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ConfirmSSHApproval_Call) Return(err error) *MockService_ConfirmSSHApproval_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ConfirmSSHApproval_Call) RunAndReturn(run func(ctx context.Context, userID string, req *requests.SSHApprovalConfirm) error) *MockService_ConfirmSSHApproval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumeSSHIdentity provides a mock function for the type MockService
+func (_mock *MockService) ConsumeSSHIdentity(ctx context.Context, tenantID string, fingerprint string) (bool, error) {
+	ret := _mock.Called(ctx, tenantID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeSSHIdentity")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, tenantID, fingerprint)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
 		r0 = returnFunc(ctx, tenantID, fingerprint)
@@ -1158,6 +1515,74 @@ func (_c *MockService_CreateAccessPolicy_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// CreateAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) CreateAccessRequest(ctx context.Context, req *requests.AccessRequestCreate) (*models.AccessRequest, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAccessRequest")
+	}
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestCreate) (*models.AccessRequest, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestCreate) *models.AccessRequest); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessRequestCreate) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CreateAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccessRequest'
+type MockService_CreateAccessRequest_Call struct {
+	*mock.Call
+}
+
+// CreateAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.AccessRequestCreate
+func (_e *MockService_Expecter) CreateAccessRequest(ctx any, req any) *MockService_CreateAccessRequest_Call {
+	return &MockService_CreateAccessRequest_Call{Call: _e.mock.On("CreateAccessRequest", ctx, req)}
+}
+
+func (_c *MockService_CreateAccessRequest_Call) Run(run func(ctx context.Context, req *requests.AccessRequestCreate)) *MockService_CreateAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.AccessRequestCreate
+		if args[1] != nil {
+			arg1 = args[1].(*requests.AccessRequestCreate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateAccessRequest_Call) Return(accessRequest *models.AccessRequest, err error) *MockService_CreateAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockService_CreateAccessRequest_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessRequestCreate) (*models.AccessRequest, error)) *MockService_CreateAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateDeviceLoginCode provides a mock function for the type MockService
 func (_mock *MockService) CreateDeviceLoginCode(ctx context.Context, uid string, tenantID string) (*models.DeviceLoginCode, error) {
 	ret := _mock.Called(ctx, uid, tenantID)
@@ -1572,55 +1997,55 @@ func (_c *MockService_CreatePublicKey_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
-// CreateSSHApproval provides a mock function for the type MockService
-func (_mock *MockService) CreateSSHApproval(ctx context.Context, req *requests.SSHApprovalCreate) (*models.SSHApprovalCreated, error) {
-	ret := _mock.Called(ctx, req)
+// CreateSCIMGroup provides a mock function for the type MockService
+func (_mock *MockService) CreateSCIMGroup(ctx context.Context, group *scim.Group) (*scim.Group, error) {
+	ret := _mock.Called(ctx, group)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateSSHApproval")
+		panic("no return value specified for CreateSCIMGroup")
 	}
 
-	var r0 *models.SSHApprovalCreated
+	var r0 *scim.Group
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHApprovalCreate) (*models.SSHApprovalCreated, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *scim.Group) (*scim.Group, error)); ok {
+		return returnFunc(ctx, group)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHApprovalCreate) *models.SSHApprovalCreated); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *scim.Group) *scim.Group); ok {
+		r0 = returnFunc(ctx, group)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.SSHApprovalCreated)
+			r0 = ret.Get(0).(*scim.Group)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHApprovalCreate) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *scim.Group) error); ok {
+		r1 = returnFunc(ctx, group)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_CreateSSHApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSSHApproval'
-type MockService_CreateSSHApproval_Call struct {
+// MockService_CreateSCIMGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSCIMGroup'
+type MockService_CreateSCIMGroup_Call struct {
 	*mock.Call
 }
 
-// CreateSSHApproval is a helper method to define mock.On call
+// CreateSCIMGroup is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.SSHApprovalCreate
-func (_e *MockService_Expecter) CreateSSHApproval(ctx any, req any) *MockService_CreateSSHApproval_Call {
-	return &MockService_CreateSSHApproval_Call{Call: _e.mock.On("CreateSSHApproval", ctx, req)}
+//   - group *scim.Group
+func (_e *MockService_Expecter) CreateSCIMGroup(ctx any, group any) *MockService_CreateSCIMGroup_Call {
+	return &MockService_CreateSCIMGroup_Call{Call: _e.mock.On("CreateSCIMGroup", ctx, group)}
 }
 
-func (_c *MockService_CreateSSHApproval_Call) Run(run func(ctx context.Context, req *requests.SSHApprovalCreate)) *MockService_CreateSSHApproval_Call {
+func (_c *MockService_CreateSCIMGroup_Call) Run(run func(ctx context.Context, group *scim.Group)) *MockService_CreateSCIMGroup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.SSHApprovalCreate
+		var arg1 *scim.Group
 		if args[1] != nil {
-			arg1 = args[1].(*requests.SSHApprovalCreate)
+			arg1 = args[1].(*scim.Group)
 		}
 		run(
 			arg0,
@@ -1630,65 +2055,65 @@ func (_c *MockService_CreateSSHApproval_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockService_CreateSSHApproval_Call) Return(sSHApprovalCreated *models.SSHApprovalCreated, err error) *MockService_CreateSSHApproval_Call {
-	_c.Call.Return(sSHApprovalCreated, err)
+func (_c *MockService_CreateSCIMGroup_Call) Return(group1 *scim.Group, err error) *MockService_CreateSCIMGroup_Call {
+	_c.Call.Return(group1, err)
 	return _c
 }
 
-func (_c *MockService_CreateSSHApproval_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHApprovalCreate) (*models.SSHApprovalCreated, error)) *MockService_CreateSSHApproval_Call {
+func (_c *MockService_CreateSCIMGroup_Call) RunAndReturn(run func(ctx context.Context, group *scim.Group) (*scim.Group, error)) *MockService_CreateSCIMGroup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateSSHIdentity provides a mock function for the type MockService
-func (_mock *MockService) CreateSSHIdentity(ctx context.Context, req *requests.SSHIdentityCreate) (*models.SSHIdentity, error) {
-	ret := _mock.Called(ctx, req)
+// CreateSCIMUser provides a mock function for the type MockService
+func (_mock *MockService) CreateSCIMUser(ctx context.Context, user *scim.User) (*scim.User, error) {
+	ret := _mock.Called(ctx, user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateSSHIdentity")
+		panic("no return value specified for CreateSCIMUser")
 	}
 
-	var r0 *models.SSHIdentity
+	var r0 *scim.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityCreate) (*models.SSHIdentity, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *scim.User) (*scim.User, error)); ok {
+		return returnFunc(ctx, user)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityCreate) *models.SSHIdentity); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *scim.User) *scim.User); ok {
+		r0 = returnFunc(ctx, user)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.SSHIdentity)
+			r0 = ret.Get(0).(*scim.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHIdentityCreate) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *scim.User) error); ok {
+		r1 = returnFunc(ctx, user)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_CreateSSHIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSSHIdentity'
-type MockService_CreateSSHIdentity_Call struct {
+// MockService_CreateSCIMUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSCIMUser'
+type MockService_CreateSCIMUser_Call struct {
 	*mock.Call
 }
 
-// CreateSSHIdentity is a helper method to define mock.On call
+// CreateSCIMUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.SSHIdentityCreate
-func (_e *MockService_Expecter) CreateSSHIdentity(ctx any, req any) *MockService_CreateSSHIdentity_Call {
-	return &MockService_CreateSSHIdentity_Call{Call: _e.mock.On("CreateSSHIdentity", ctx, req)}
+//   - user *scim.User
+func (_e *MockService_Expecter) CreateSCIMUser(ctx any, user any) *MockService_CreateSCIMUser_Call {
+	return &MockService_CreateSCIMUser_Call{Call: _e.mock.On("CreateSCIMUser", ctx, user)}
 }
 
-func (_c *MockService_CreateSSHIdentity_Call) Run(run func(ctx context.Context, req *requests.SSHIdentityCreate)) *MockService_CreateSSHIdentity_Call {
+func (_c *MockService_CreateSCIMUser_Call) Run(run func(ctx context.Context, user *scim.User)) *MockService_CreateSCIMUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.SSHIdentityCreate
+		var arg1 *scim.User
 		if args[1] != nil {
-			arg1 = args[1].(*requests.SSHIdentityCreate)
+			arg1 = args[1].(*scim.User)
 		}
 		run(
 			arg0,
@@ -1698,37 +2123,37 @@ func (_c *MockService_CreateSSHIdentity_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockService_CreateSSHIdentity_Call) Return(sSHIdentity *models.SSHIdentity, err error) *MockService_CreateSSHIdentity_Call {
-	_c.Call.Return(sSHIdentity, err)
+func (_c *MockService_CreateSCIMUser_Call) Return(user1 *scim.User, err error) *MockService_CreateSCIMUser_Call {
+	_c.Call.Return(user1, err)
 	return _c
 }
 
-func (_c *MockService_CreateSSHIdentity_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHIdentityCreate) (*models.SSHIdentity, error)) *MockService_CreateSSHIdentity_Call {
+func (_c *MockService_CreateSCIMUser_Call) RunAndReturn(run func(ctx context.Context, user *scim.User) (*scim.User, error)) *MockService_CreateSCIMUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateServiceAccount provides a mock function for the type MockService
-func (_mock *MockService) CreateServiceAccount(ctx context.Context, req *requests.ServiceAccountCreate) (*models.ServiceAccount, error) {
+// CreateSSHApproval provides a mock function for the type MockService
+func (_mock *MockService) CreateSSHApproval(ctx context.Context, req *requests.SSHApprovalCreate) (*models.SSHApprovalCreated, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateServiceAccount")
+		panic("no return value specified for CreateSSHApproval")
 	}
 
-	var r0 *models.ServiceAccount
+	var r0 *models.SSHApprovalCreated
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ServiceAccountCreate) (*models.ServiceAccount, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHApprovalCreate) (*models.SSHApprovalCreated, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ServiceAccountCreate) *models.ServiceAccount); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHApprovalCreate) *models.SSHApprovalCreated); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ServiceAccount)
+			r0 = ret.Get(0).(*models.SSHApprovalCreated)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ServiceAccountCreate) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHApprovalCreate) error); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -1736,27 +2161,27 @@ func (_mock *MockService) CreateServiceAccount(ctx context.Context, req *request
 	return r0, r1
 }
 
-// MockService_CreateServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServiceAccount'
-type MockService_CreateServiceAccount_Call struct {
+// MockService_CreateSSHApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSSHApproval'
+type MockService_CreateSSHApproval_Call struct {
 	*mock.Call
 }
 
-// CreateServiceAccount is a helper method to define mock.On call
+// CreateSSHApproval is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.ServiceAccountCreate
-func (_e *MockService_Expecter) CreateServiceAccount(ctx any, req any) *MockService_CreateServiceAccount_Call {
-	return &MockService_CreateServiceAccount_Call{Call: _e.mock.On("CreateServiceAccount", ctx, req)}
+//   - req *requests.SSHApprovalCreate
+func (_e *MockService_Expecter) CreateSSHApproval(ctx any, req any) *MockService_CreateSSHApproval_Call {
+	return &MockService_CreateSSHApproval_Call{Call: _e.mock.On("CreateSSHApproval", ctx, req)}
 }
 
-func (_c *MockService_CreateServiceAccount_Call) Run(run func(ctx context.Context, req *requests.ServiceAccountCreate)) *MockService_CreateServiceAccount_Call {
+func (_c *MockService_CreateSSHApproval_Call) Run(run func(ctx context.Context, req *requests.SSHApprovalCreate)) *MockService_CreateSSHApproval_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.ServiceAccountCreate
+		var arg1 *requests.SSHApprovalCreate
 		if args[1] != nil {
-			arg1 = args[1].(*requests.ServiceAccountCreate)
+			arg1 = args[1].(*requests.SSHApprovalCreate)
 		}
 		run(
 			arg0,
@@ -1766,8 +2191,212 @@ func (_c *MockService_CreateServiceAccount_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockService_CreateServiceAccount_Call) Return(serviceAccount *models.ServiceAccount, err error) *MockService_CreateServiceAccount_Call {
-	_c.Call.Return(serviceAccount, err)
+func (_c *MockService_CreateSSHApproval_Call) Return(sSHApprovalCreated *models.SSHApprovalCreated, err error) *MockService_CreateSSHApproval_Call {
+	_c.Call.Return(sSHApprovalCreated, err)
+	return _c
+}
+
+func (_c *MockService_CreateSSHApproval_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHApprovalCreate) (*models.SSHApprovalCreated, error)) *MockService_CreateSSHApproval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSSHIdentity provides a mock function for the type MockService
+func (_mock *MockService) CreateSSHIdentity(ctx context.Context, req *requests.SSHIdentityCreate) (*models.SSHIdentity, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSSHIdentity")
+	}
+
+	var r0 *models.SSHIdentity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityCreate) (*models.SSHIdentity, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityCreate) *models.SSHIdentity); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SSHIdentity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHIdentityCreate) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CreateSSHIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSSHIdentity'
+type MockService_CreateSSHIdentity_Call struct {
+	*mock.Call
+}
+
+// CreateSSHIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.SSHIdentityCreate
+func (_e *MockService_Expecter) CreateSSHIdentity(ctx any, req any) *MockService_CreateSSHIdentity_Call {
+	return &MockService_CreateSSHIdentity_Call{Call: _e.mock.On("CreateSSHIdentity", ctx, req)}
+}
+
+func (_c *MockService_CreateSSHIdentity_Call) Run(run func(ctx context.Context, req *requests.SSHIdentityCreate)) *MockService_CreateSSHIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.SSHIdentityCreate
+		if args[1] != nil {
+			arg1 = args[1].(*requests.SSHIdentityCreate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateSSHIdentity_Call) Return(sSHIdentity *models.SSHIdentity, err error) *MockService_CreateSSHIdentity_Call {
+	_c.Call.Return(sSHIdentity, err)
+	return _c
+}
+
+func (_c *MockService_CreateSSHIdentity_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHIdentityCreate) (*models.SSHIdentity, error)) *MockService_CreateSSHIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSSHUserCA provides a mock function for the type MockService
+func (_mock *MockService) CreateSSHUserCA(ctx context.Context, req *requests.SSHUserCACreate) (*models.SSHUserCA, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSSHUserCA")
+	}
+
+	var r0 *models.SSHUserCA
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHUserCACreate) (*models.SSHUserCA, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHUserCACreate) *models.SSHUserCA); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SSHUserCA)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHUserCACreate) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CreateSSHUserCA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSSHUserCA'
+type MockService_CreateSSHUserCA_Call struct {
+	*mock.Call
+}
+
+// CreateSSHUserCA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.SSHUserCACreate
+func (_e *MockService_Expecter) CreateSSHUserCA(ctx any, req any) *MockService_CreateSSHUserCA_Call {
+	return &MockService_CreateSSHUserCA_Call{Call: _e.mock.On("CreateSSHUserCA", ctx, req)}
+}
+
+func (_c *MockService_CreateSSHUserCA_Call) Run(run func(ctx context.Context, req *requests.SSHUserCACreate)) *MockService_CreateSSHUserCA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.SSHUserCACreate
+		if args[1] != nil {
+			arg1 = args[1].(*requests.SSHUserCACreate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateSSHUserCA_Call) Return(sSHUserCA *models.SSHUserCA, err error) *MockService_CreateSSHUserCA_Call {
+	_c.Call.Return(sSHUserCA, err)
+	return _c
+}
+
+func (_c *MockService_CreateSSHUserCA_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHUserCACreate) (*models.SSHUserCA, error)) *MockService_CreateSSHUserCA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateServiceAccount provides a mock function for the type MockService
+func (_mock *MockService) CreateServiceAccount(ctx context.Context, req *requests.ServiceAccountCreate) (*models.ServiceAccount, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateServiceAccount")
+	}
+
+	var r0 *models.ServiceAccount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ServiceAccountCreate) (*models.ServiceAccount, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ServiceAccountCreate) *models.ServiceAccount); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ServiceAccount)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ServiceAccountCreate) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CreateServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServiceAccount'
+type MockService_CreateServiceAccount_Call struct {
+	*mock.Call
+}
+
+// CreateServiceAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.ServiceAccountCreate
+func (_e *MockService_Expecter) CreateServiceAccount(ctx any, req any) *MockService_CreateServiceAccount_Call {
+	return &MockService_CreateServiceAccount_Call{Call: _e.mock.On("CreateServiceAccount", ctx, req)}
+}
+
+func (_c *MockService_CreateServiceAccount_Call) Run(run func(ctx context.Context, req *requests.ServiceAccountCreate)) *MockService_CreateServiceAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.ServiceAccountCreate
+		if args[1] != nil {
+			arg1 = args[1].(*requests.ServiceAccountCreate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateServiceAccount_Call) Return(serviceAccount *models.ServiceAccount, err error) *MockService_CreateServiceAccount_Call {
+	_c.Call.Return(serviceAccount, err)
 	return _c
 }
 
@@ -1978,6 +2607,74 @@ func (_c *MockService_CreateUserToken_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// CreateWebhook provides a mock function for the type MockService
+func (_mock *MockService) CreateWebhook(ctx context.Context, req *requests.WebhookCreate) (*models.Webhook, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookCreate) (*models.Webhook, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookCreate) *models.Webhook); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.WebhookCreate) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type MockService_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.WebhookCreate
+func (_e *MockService_Expecter) CreateWebhook(ctx any, req any) *MockService_CreateWebhook_Call {
+	return &MockService_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, req)}
+}
+
+func (_c *MockService_CreateWebhook_Call) Run(run func(ctx context.Context, req *requests.WebhookCreate)) *MockService_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.WebhookCreate
+		if args[1] != nil {
+			arg1 = args[1].(*requests.WebhookCreate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateWebhook_Call) Return(webhook *models.Webhook, err error) *MockService_CreateWebhook_Call {
+	_c.Call.Return(webhook, err)
+	return _c
+}
+
+func (_c *MockService_CreateWebhook_Call) RunAndReturn(run func(ctx context.Context, req *requests.WebhookCreate) (*models.Webhook, error)) *MockService_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeactivateSession provides a mock function for the type MockService
 func (_mock *MockService) DeactivateSession(ctx context.Context, uid models.UID) error {
 	ret := _mock.Called(ctx, uid)
@@ -2389,44 +3086,44 @@ func (_c *MockService_DeletePublicKey_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
-// DeleteSSHIdentity provides a mock function for the type MockService
-func (_mock *MockService) DeleteSSHIdentity(ctx context.Context, req *requests.SSHIdentityDelete) error {
-	ret := _mock.Called(ctx, req)
+// DeleteSCIMGroup provides a mock function for the type MockService
+func (_mock *MockService) DeleteSCIMGroup(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteSSHIdentity")
+		panic("no return value specified for DeleteSCIMGroup")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityDelete) error); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_DeleteSSHIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSSHIdentity'
-type MockService_DeleteSSHIdentity_Call struct {
+// MockService_DeleteSCIMGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSCIMGroup'
+type MockService_DeleteSCIMGroup_Call struct {
 	*mock.Call
 }
 
-// DeleteSSHIdentity is a helper method to define mock.On call
+// DeleteSCIMGroup is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.SSHIdentityDelete
-func (_e *MockService_Expecter) DeleteSSHIdentity(ctx any, req any) *MockService_DeleteSSHIdentity_Call {
-	return &MockService_DeleteSSHIdentity_Call{Call: _e.mock.On("DeleteSSHIdentity", ctx, req)}
+//   - id string
+func (_e *MockService_Expecter) DeleteSCIMGroup(ctx any, id any) *MockService_DeleteSCIMGroup_Call {
+	return &MockService_DeleteSCIMGroup_Call{Call: _e.mock.On("DeleteSCIMGroup", ctx, id)}
 }
 
-func (_c *MockService_DeleteSSHIdentity_Call) Run(run func(ctx context.Context, req *requests.SSHIdentityDelete)) *MockService_DeleteSSHIdentity_Call {
+func (_c *MockService_DeleteSCIMGroup_Call) Run(run func(ctx context.Context, id string)) *MockService_DeleteSCIMGroup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.SSHIdentityDelete
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*requests.SSHIdentityDelete)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -2436,17 +3133,188 @@ func (_c *MockService_DeleteSSHIdentity_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockService_DeleteSSHIdentity_Call) Return(err error) *MockService_DeleteSSHIdentity_Call {
+func (_c *MockService_DeleteSCIMGroup_Call) Return(err error) *MockService_DeleteSCIMGroup_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_DeleteSSHIdentity_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHIdentityDelete) error) *MockService_DeleteSSHIdentity_Call {
+func (_c *MockService_DeleteSCIMGroup_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockService_DeleteSCIMGroup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteServiceAccount provides a mock function for the type MockService
+// DeleteSCIMUser provides a mock function for the type MockService
+func (_mock *MockService) DeleteSCIMUser(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSCIMUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteSCIMUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSCIMUser'
+type MockService_DeleteSCIMUser_Call struct {
+	*mock.Call
+}
+
+// DeleteSCIMUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockService_Expecter) DeleteSCIMUser(ctx any, id any) *MockService_DeleteSCIMUser_Call {
+	return &MockService_DeleteSCIMUser_Call{Call: _e.mock.On("DeleteSCIMUser", ctx, id)}
+}
+
+func (_c *MockService_DeleteSCIMUser_Call) Run(run func(ctx context.Context, id string)) *MockService_DeleteSCIMUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteSCIMUser_Call) Return(err error) *MockService_DeleteSCIMUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteSCIMUser_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockService_DeleteSCIMUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSSHIdentity provides a mock function for the type MockService
+func (_mock *MockService) DeleteSSHIdentity(ctx context.Context, req *requests.SSHIdentityDelete) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSSHIdentity")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityDelete) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteSSHIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSSHIdentity'
+type MockService_DeleteSSHIdentity_Call struct {
+	*mock.Call
+}
+
+// DeleteSSHIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.SSHIdentityDelete
+func (_e *MockService_Expecter) DeleteSSHIdentity(ctx any, req any) *MockService_DeleteSSHIdentity_Call {
+	return &MockService_DeleteSSHIdentity_Call{Call: _e.mock.On("DeleteSSHIdentity", ctx, req)}
+}
+
+func (_c *MockService_DeleteSSHIdentity_Call) Run(run func(ctx context.Context, req *requests.SSHIdentityDelete)) *MockService_DeleteSSHIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.SSHIdentityDelete
+		if args[1] != nil {
+			arg1 = args[1].(*requests.SSHIdentityDelete)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteSSHIdentity_Call) Return(err error) *MockService_DeleteSSHIdentity_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteSSHIdentity_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHIdentityDelete) error) *MockService_DeleteSSHIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSSHUserCA provides a mock function for the type MockService
+func (_mock *MockService) DeleteSSHUserCA(ctx context.Context, req *requests.SSHUserCADelete) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSSHUserCA")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHUserCADelete) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteSSHUserCA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSSHUserCA'
+type MockService_DeleteSSHUserCA_Call struct {
+	*mock.Call
+}
+
+// DeleteSSHUserCA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.SSHUserCADelete
+func (_e *MockService_Expecter) DeleteSSHUserCA(ctx any, req any) *MockService_DeleteSSHUserCA_Call {
+	return &MockService_DeleteSSHUserCA_Call{Call: _e.mock.On("DeleteSSHUserCA", ctx, req)}
+}
+
+func (_c *MockService_DeleteSSHUserCA_Call) Run(run func(ctx context.Context, req *requests.SSHUserCADelete)) *MockService_DeleteSSHUserCA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.SSHUserCADelete
+		if args[1] != nil {
+			arg1 = args[1].(*requests.SSHUserCADelete)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteSSHUserCA_Call) Return(err error) *MockService_DeleteSSHUserCA_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteSSHUserCA_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHUserCADelete) error) *MockService_DeleteSSHUserCA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteServiceAccount provides a mock function for the type MockService
 func (_mock *MockService) DeleteServiceAccount(ctx context.Context, req *requests.ServiceAccountDelete) error {
 	ret := _mock.Called(ctx, req)
 
@@ -2560,6 +3428,63 @@ func (_c *MockService_DeleteTag_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// DeleteWebhook provides a mock function for the type MockService
+func (_mock *MockService) DeleteWebhook(ctx context.Context, req *requests.WebhookDelete) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookDelete) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockService_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.WebhookDelete
+func (_e *MockService_Expecter) DeleteWebhook(ctx any, req any) *MockService_DeleteWebhook_Call {
+	return &MockService_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, req)}
+}
+
+func (_c *MockService_DeleteWebhook_Call) Run(run func(ctx context.Context, req *requests.WebhookDelete)) *MockService_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.WebhookDelete
+		if args[1] != nil {
+			arg1 = args[1].(*requests.WebhookDelete)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteWebhook_Call) Return(err error) *MockService_DeleteWebhook_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteWebhook_Call) RunAndReturn(run func(ctx context.Context, req *requests.WebhookDelete) error) *MockService_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EditNamespace provides a mock function for the type MockService
 func (_mock *MockService) EditNamespace(ctx context.Context, req *requests.NamespaceEdit) (*models.Namespace, error) {
 	ret := _mock.Called(ctx, req)
@@ -3120,25 +4045,27 @@ func (_c *MockService_EventSession_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
-// GenerateInvitationLink provides a mock function for the type MockService
-func (_mock *MockService) GenerateInvitationLink(ctx context.Context, req *requests.GenerateInvitationLink) (string, error) {
+// ExportPolicyBundle provides a mock function for the type MockService
+func (_mock *MockService) ExportPolicyBundle(ctx context.Context, req *requests.PolicyBundleExport) (*models.PolicyBundle, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GenerateInvitationLink")
+		panic("no return value specified for ExportPolicyBundle")
 	}
 
-	var r0 string
+	var r0 *models.PolicyBundle
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.GenerateInvitationLink) (string, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.PolicyBundleExport) (*models.PolicyBundle, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.GenerateInvitationLink) string); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.PolicyBundleExport) *models.PolicyBundle); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PolicyBundle)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.GenerateInvitationLink) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.PolicyBundleExport) error); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -3146,27 +4073,27 @@ func (_mock *MockService) GenerateInvitationLink(ctx context.Context, req *reque
 	return r0, r1
 }
 
-// MockService_GenerateInvitationLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateInvitationLink'
-type MockService_GenerateInvitationLink_Call struct {
+// MockService_ExportPolicyBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportPolicyBundle'
+type MockService_ExportPolicyBundle_Call struct {
 	*mock.Call
 }
 
-// GenerateInvitationLink is a helper method to define mock.On call
+// ExportPolicyBundle is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.GenerateInvitationLink
-func (_e *MockService_Expecter) GenerateInvitationLink(ctx any, req any) *MockService_GenerateInvitationLink_Call {
-	return &MockService_GenerateInvitationLink_Call{Call: _e.mock.On("GenerateInvitationLink", ctx, req)}
+//   - req *requests.PolicyBundleExport
+func (_e *MockService_Expecter) ExportPolicyBundle(ctx any, req any) *MockService_ExportPolicyBundle_Call {
+	return &MockService_ExportPolicyBundle_Call{Call: _e.mock.On("ExportPolicyBundle", ctx, req)}
 }
 
-func (_c *MockService_GenerateInvitationLink_Call) Run(run func(ctx context.Context, req *requests.GenerateInvitationLink)) *MockService_GenerateInvitationLink_Call {
+func (_c *MockService_ExportPolicyBundle_Call) Run(run func(ctx context.Context, req *requests.PolicyBundleExport)) *MockService_ExportPolicyBundle_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.GenerateInvitationLink
+		var arg1 *requests.PolicyBundleExport
 		if args[1] != nil {
-			arg1 = args[1].(*requests.GenerateInvitationLink)
+			arg1 = args[1].(*requests.PolicyBundleExport)
 		}
 		run(
 			arg0,
@@ -3176,87 +4103,290 @@ func (_c *MockService_GenerateInvitationLink_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockService_GenerateInvitationLink_Call) Return(s string, err error) *MockService_GenerateInvitationLink_Call {
-	_c.Call.Return(s, err)
+func (_c *MockService_ExportPolicyBundle_Call) Return(policyBundle *models.PolicyBundle, err error) *MockService_ExportPolicyBundle_Call {
+	_c.Call.Return(policyBundle, err)
 	return _c
 }
 
-func (_c *MockService_GenerateInvitationLink_Call) RunAndReturn(run func(ctx context.Context, req *requests.GenerateInvitationLink) (string, error)) *MockService_GenerateInvitationLink_Call {
+func (_c *MockService_ExportPolicyBundle_Call) RunAndReturn(run func(ctx context.Context, req *requests.PolicyBundleExport) (*models.PolicyBundle, error)) *MockService_ExportPolicyBundle_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAccessPolicy provides a mock function for the type MockService
-func (_mock *MockService) GetAccessPolicy(ctx context.Context, req *requests.AccessPolicyGet) (*models.AccessPolicy, error) {
-	ret := _mock.Called(ctx, req)
+// ExportSessionRecording provides a mock function for the type MockService
+func (_mock *MockService) ExportSessionRecording(ctx context.Context, sc scope.Scope, req *requests.SessionRecordingExport, w io.Writer) error {
+	ret := _mock.Called(ctx, sc, req, w)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAccessPolicy")
+		panic("no return value specified for ExportSessionRecording")
 	}
 
-	var r0 *models.AccessPolicy
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessPolicyGet) (*models.AccessPolicy, error)); ok {
-		return returnFunc(ctx, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessPolicyGet) *models.AccessPolicy); ok {
-		r0 = returnFunc(ctx, req)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.AccessPolicy)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessPolicyGet) error); ok {
-		r1 = returnFunc(ctx, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.SessionRecordingExport, io.Writer) error); ok {
+		r0 = returnFunc(ctx, sc, req, w)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockService_GetAccessPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessPolicy'
-type MockService_GetAccessPolicy_Call struct {
+// MockService_ExportSessionRecording_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportSessionRecording'
+type MockService_ExportSessionRecording_Call struct {
 	*mock.Call
 }
 
-// GetAccessPolicy is a helper method to define mock.On call
+// ExportSessionRecording is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.AccessPolicyGet
-func (_e *MockService_Expecter) GetAccessPolicy(ctx any, req any) *MockService_GetAccessPolicy_Call {
-	return &MockService_GetAccessPolicy_Call{Call: _e.mock.On("GetAccessPolicy", ctx, req)}
+//   - sc scope.Scope
+//   - req *requests.SessionRecordingExport
+//   - w io.Writer
+func (_e *MockService_Expecter) ExportSessionRecording(ctx any, sc any, req any, w any) *MockService_ExportSessionRecording_Call {
+	return &MockService_ExportSessionRecording_Call{Call: _e.mock.On("ExportSessionRecording", ctx, sc, req, w)}
 }
 
-func (_c *MockService_GetAccessPolicy_Call) Run(run func(ctx context.Context, req *requests.AccessPolicyGet)) *MockService_GetAccessPolicy_Call {
+func (_c *MockService_ExportSessionRecording_Call) Run(run func(ctx context.Context, sc scope.Scope, req *requests.SessionRecordingExport, w io.Writer)) *MockService_ExportSessionRecording_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.AccessPolicyGet
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(*requests.AccessPolicyGet)
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 *requests.SessionRecordingExport
+		if args[2] != nil {
+			arg2 = args[2].(*requests.SessionRecordingExport)
+		}
+		var arg3 io.Writer
+		if args[3] != nil {
+			arg3 = args[3].(io.Writer)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_GetAccessPolicy_Call) Return(accessPolicy *models.AccessPolicy, err error) *MockService_GetAccessPolicy_Call {
-	_c.Call.Return(accessPolicy, err)
+func (_c *MockService_ExportSessionRecording_Call) Return(err error) *MockService_ExportSessionRecording_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_GetAccessPolicy_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessPolicyGet) (*models.AccessPolicy, error)) *MockService_GetAccessPolicy_Call {
+func (_c *MockService_ExportSessionRecording_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, req *requests.SessionRecordingExport, w io.Writer) error) *MockService_ExportSessionRecording_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDevice provides a mock function for the type MockService
-func (_mock *MockService) GetDevice(ctx context.Context, sc scope.Scope, uid models.UID) (*models.Device, error) {
-	ret := _mock.Called(ctx, sc, uid)
+// GenerateInvitationLink provides a mock function for the type MockService
+func (_mock *MockService) GenerateInvitationLink(ctx context.Context, req *requests.GenerateInvitationLink) (string, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateInvitationLink")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.GenerateInvitationLink) (string, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.GenerateInvitationLink) string); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.GenerateInvitationLink) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GenerateInvitationLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateInvitationLink'
+type MockService_GenerateInvitationLink_Call struct {
+	*mock.Call
+}
+
+// GenerateInvitationLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.GenerateInvitationLink
+func (_e *MockService_Expecter) GenerateInvitationLink(ctx any, req any) *MockService_GenerateInvitationLink_Call {
+	return &MockService_GenerateInvitationLink_Call{Call: _e.mock.On("GenerateInvitationLink", ctx, req)}
+}
+
+func (_c *MockService_GenerateInvitationLink_Call) Run(run func(ctx context.Context, req *requests.GenerateInvitationLink)) *MockService_GenerateInvitationLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.GenerateInvitationLink
+		if args[1] != nil {
+			arg1 = args[1].(*requests.GenerateInvitationLink)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GenerateInvitationLink_Call) Return(s string, err error) *MockService_GenerateInvitationLink_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockService_GenerateInvitationLink_Call) RunAndReturn(run func(ctx context.Context, req *requests.GenerateInvitationLink) (string, error)) *MockService_GenerateInvitationLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccessPolicy provides a mock function for the type MockService
+func (_mock *MockService) GetAccessPolicy(ctx context.Context, req *requests.AccessPolicyGet) (*models.AccessPolicy, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessPolicy")
+	}
+
+	var r0 *models.AccessPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessPolicyGet) (*models.AccessPolicy, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessPolicyGet) *models.AccessPolicy); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessPolicyGet) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetAccessPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessPolicy'
+type MockService_GetAccessPolicy_Call struct {
+	*mock.Call
+}
+
+// GetAccessPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.AccessPolicyGet
+func (_e *MockService_Expecter) GetAccessPolicy(ctx any, req any) *MockService_GetAccessPolicy_Call {
+	return &MockService_GetAccessPolicy_Call{Call: _e.mock.On("GetAccessPolicy", ctx, req)}
+}
+
+func (_c *MockService_GetAccessPolicy_Call) Run(run func(ctx context.Context, req *requests.AccessPolicyGet)) *MockService_GetAccessPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.AccessPolicyGet
+		if args[1] != nil {
+			arg1 = args[1].(*requests.AccessPolicyGet)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetAccessPolicy_Call) Return(accessPolicy *models.AccessPolicy, err error) *MockService_GetAccessPolicy_Call {
+	_c.Call.Return(accessPolicy, err)
+	return _c
+}
+
+func (_c *MockService_GetAccessPolicy_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessPolicyGet) (*models.AccessPolicy, error)) *MockService_GetAccessPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) GetAccessRequest(ctx context.Context, req *requests.AccessRequestGet) (*models.AccessRequest, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessRequest")
+	}
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestGet) (*models.AccessRequest, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestGet) *models.AccessRequest); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessRequestGet) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessRequest'
+type MockService_GetAccessRequest_Call struct {
+	*mock.Call
+}
+
+// GetAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.AccessRequestGet
+func (_e *MockService_Expecter) GetAccessRequest(ctx any, req any) *MockService_GetAccessRequest_Call {
+	return &MockService_GetAccessRequest_Call{Call: _e.mock.On("GetAccessRequest", ctx, req)}
+}
+
+func (_c *MockService_GetAccessRequest_Call) Run(run func(ctx context.Context, req *requests.AccessRequestGet)) *MockService_GetAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.AccessRequestGet
+		if args[1] != nil {
+			arg1 = args[1].(*requests.AccessRequestGet)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetAccessRequest_Call) Return(accessRequest *models.AccessRequest, err error) *MockService_GetAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockService_GetAccessRequest_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessRequestGet) (*models.AccessRequest, error)) *MockService_GetAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDevice provides a mock function for the type MockService
+func (_mock *MockService) GetDevice(ctx context.Context, sc scope.Scope, uid models.UID) (*models.Device, error) {
+	ret := _mock.Called(ctx, sc, uid)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDevice")
@@ -3464,6 +4594,72 @@ func (_c *MockService_GetNamespace_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// GetOIDCAuthURL provides a mock function for the type MockService
+func (_mock *MockService) GetOIDCAuthURL(ctx context.Context) (string, string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOIDCAuthURL")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (string, string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) string); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = returnFunc(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockService_GetOIDCAuthURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOIDCAuthURL'
+type MockService_GetOIDCAuthURL_Call struct {
+	*mock.Call
+}
+
+// GetOIDCAuthURL is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) GetOIDCAuthURL(ctx any) *MockService_GetOIDCAuthURL_Call {
+	return &MockService_GetOIDCAuthURL_Call{Call: _e.mock.On("GetOIDCAuthURL", ctx)}
+}
+
+func (_c *MockService_GetOIDCAuthURL_Call) Run(run func(ctx context.Context)) *MockService_GetOIDCAuthURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetOIDCAuthURL_Call) Return(url string, state string, err error) *MockService_GetOIDCAuthURL_Call {
+	_c.Call.Return(url, state, err)
+	return _c
+}
+
+func (_c *MockService_GetOIDCAuthURL_Call) RunAndReturn(run func(ctx context.Context) (string, string, error)) *MockService_GetOIDCAuthURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPublicKey provides a mock function for the type MockService
 func (_mock *MockService) GetPublicKey(ctx context.Context, fingerprint string, tenant string) (*models.PublicKey, error) {
 	ret := _mock.Called(ctx, fingerprint, tenant)
@@ -3538,48 +4734,184 @@ func (_c *MockService_GetPublicKey_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
-// GetSSHApproval provides a mock function for the type MockService
-func (_mock *MockService) GetSSHApproval(ctx context.Context, userID string, code string) (*models.SSHApprovalRequest, error) {
-	ret := _mock.Called(ctx, userID, code)
+// GetSCIMGroup provides a mock function for the type MockService
+func (_mock *MockService) GetSCIMGroup(ctx context.Context, id string) (*scim.Group, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSSHApproval")
+		panic("no return value specified for GetSCIMGroup")
 	}
 
-	var r0 *models.SSHApprovalRequest
+	var r0 *scim.Group
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.SSHApprovalRequest, error)); ok {
-		return returnFunc(ctx, userID, code)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*scim.Group, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.SSHApprovalRequest); ok {
-		r0 = returnFunc(ctx, userID, code)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *scim.Group); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.SSHApprovalRequest)
+			r0 = ret.Get(0).(*scim.Group)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, userID, code)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_GetSSHApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSSHApproval'
-type MockService_GetSSHApproval_Call struct {
+// MockService_GetSCIMGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSCIMGroup'
+type MockService_GetSCIMGroup_Call struct {
 	*mock.Call
 }
 
-// GetSSHApproval is a helper method to define mock.On call
+// GetSCIMGroup is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - code string
-func (_e *MockService_Expecter) GetSSHApproval(ctx any, userID any, code any) *MockService_GetSSHApproval_Call {
-	return &MockService_GetSSHApproval_Call{Call: _e.mock.On("GetSSHApproval", ctx, userID, code)}
+//   - id string
+func (_e *MockService_Expecter) GetSCIMGroup(ctx any, id any) *MockService_GetSCIMGroup_Call {
+	return &MockService_GetSCIMGroup_Call{Call: _e.mock.On("GetSCIMGroup", ctx, id)}
 }
 
-func (_c *MockService_GetSSHApproval_Call) Run(run func(ctx context.Context, userID string, code string)) *MockService_GetSSHApproval_Call {
+func (_c *MockService_GetSCIMGroup_Call) Run(run func(ctx context.Context, id string)) *MockService_GetSCIMGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetSCIMGroup_Call) Return(group *scim.Group, err error) *MockService_GetSCIMGroup_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *MockService_GetSCIMGroup_Call) RunAndReturn(run func(ctx context.Context, id string) (*scim.Group, error)) *MockService_GetSCIMGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSCIMUser provides a mock function for the type MockService
+func (_mock *MockService) GetSCIMUser(ctx context.Context, id string) (*scim.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSCIMUser")
+	}
+
+	var r0 *scim.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*scim.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *scim.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scim.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetSCIMUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSCIMUser'
+type MockService_GetSCIMUser_Call struct {
+	*mock.Call
+}
+
+// GetSCIMUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockService_Expecter) GetSCIMUser(ctx any, id any) *MockService_GetSCIMUser_Call {
+	return &MockService_GetSCIMUser_Call{Call: _e.mock.On("GetSCIMUser", ctx, id)}
+}
+
+func (_c *MockService_GetSCIMUser_Call) Run(run func(ctx context.Context, id string)) *MockService_GetSCIMUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetSCIMUser_Call) Return(user *scim.User, err error) *MockService_GetSCIMUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockService_GetSCIMUser_Call) RunAndReturn(run func(ctx context.Context, id string) (*scim.User, error)) *MockService_GetSCIMUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSSHApproval provides a mock function for the type MockService
+func (_mock *MockService) GetSSHApproval(ctx context.Context, userID string, code string) (*models.SSHApprovalRequest, error) {
+	ret := _mock.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSSHApproval")
+	}
+
+	var r0 *models.SSHApprovalRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.SSHApprovalRequest, error)); ok {
+		return returnFunc(ctx, userID, code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.SSHApprovalRequest); ok {
+		r0 = returnFunc(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SSHApprovalRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetSSHApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSSHApproval'
+type MockService_GetSSHApproval_Call struct {
+	*mock.Call
+}
+
+// GetSSHApproval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - code string
+func (_e *MockService_Expecter) GetSSHApproval(ctx any, userID any, code any) *MockService_GetSSHApproval_Call {
+	return &MockService_GetSSHApproval_Call{Call: _e.mock.On("GetSSHApproval", ctx, userID, code)}
+}
+
+func (_c *MockService_GetSSHApproval_Call) Run(run func(ctx context.Context, userID string, code string)) *MockService_GetSSHApproval_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3681,8 +5013,8 @@ func (_c *MockService_GetSSHApprovalStatus_Call) RunAndReturn(run func(ctx conte
 }
 
 // GetSession provides a mock function for the type MockService
-func (_mock *MockService) GetSession(ctx context.Context, sc scope.Scope, uid models.UID) (*models.Session, error) {
-	ret := _mock.Called(ctx, sc, uid)
+func (_mock *MockService) GetSession(ctx context.Context, sc scope.Scope, req *requests.SessionGet) (*models.Session, error) {
+	ret := _mock.Called(ctx, sc, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSession")
@@ -3690,18 +5022,18 @@ func (_mock *MockService) GetSession(ctx context.Context, sc scope.Scope, uid mo
 
 	var r0 *models.Session
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, models.UID) (*models.Session, error)); ok {
-		return returnFunc(ctx, sc, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.SessionGet) (*models.Session, error)); ok {
+		return returnFunc(ctx, sc, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, models.UID) *models.Session); ok {
-		r0 = returnFunc(ctx, sc, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.SessionGet) *models.Session); ok {
+		r0 = returnFunc(ctx, sc, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Session)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, models.UID) error); ok {
-		r1 = returnFunc(ctx, sc, uid)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *requests.SessionGet) error); ok {
+		r1 = returnFunc(ctx, sc, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3716,12 +5048,12 @@ type MockService_GetSession_Call struct {
 // GetSession is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - uid models.UID
-func (_e *MockService_Expecter) GetSession(ctx any, sc any, uid any) *MockService_GetSession_Call {
-	return &MockService_GetSession_Call{Call: _e.mock.On("GetSession", ctx, sc, uid)}
+//   - req *requests.SessionGet
+func (_e *MockService_Expecter) GetSession(ctx any, sc any, req any) *MockService_GetSession_Call {
+	return &MockService_GetSession_Call{Call: _e.mock.On("GetSession", ctx, sc, req)}
 }
 
-func (_c *MockService_GetSession_Call) Run(run func(ctx context.Context, sc scope.Scope, uid models.UID)) *MockService_GetSession_Call {
+func (_c *MockService_GetSession_Call) Run(run func(ctx context.Context, sc scope.Scope, req *requests.SessionGet)) *MockService_GetSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3731,9 +5063,9 @@ func (_c *MockService_GetSession_Call) Run(run func(ctx context.Context, sc scop
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 models.UID
+		var arg2 *requests.SessionGet
 		if args[2] != nil {
-			arg2 = args[2].(models.UID)
+			arg2 = args[2].(*requests.SessionGet)
 		}
 		run(
 			arg0,
@@ -3749,7 +5081,87 @@ func (_c *MockService_GetSession_Call) Return(session *models.Session, err error
 	return _c
 }
 
-func (_c *MockService_GetSession_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, uid models.UID) (*models.Session, error)) *MockService_GetSession_Call {
+func (_c *MockService_GetSession_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, req *requests.SessionGet) (*models.Session, error)) *MockService_GetSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSessionCommands provides a mock function for the type MockService
+func (_mock *MockService) GetSessionCommands(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsGet) ([]models.SessionCommand, int, error) {
+	ret := _mock.Called(ctx, sc, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionCommands")
+	}
+
+	var r0 []models.SessionCommand
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.SessionCommandsGet) ([]models.SessionCommand, int, error)); ok {
+		return returnFunc(ctx, sc, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.SessionCommandsGet) []models.SessionCommand); ok {
+		r0 = returnFunc(ctx, sc, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionCommand)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *requests.SessionCommandsGet) int); ok {
+		r1 = returnFunc(ctx, sc, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *requests.SessionCommandsGet) error); ok {
+		r2 = returnFunc(ctx, sc, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockService_GetSessionCommands_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionCommands'
+type MockService_GetSessionCommands_Call struct {
+	*mock.Call
+}
+
+// GetSessionCommands is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - req *requests.SessionCommandsGet
+func (_e *MockService_Expecter) GetSessionCommands(ctx any, sc any, req any) *MockService_GetSessionCommands_Call {
+	return &MockService_GetSessionCommands_Call{Call: _e.mock.On("GetSessionCommands", ctx, sc, req)}
+}
+
+func (_c *MockService_GetSessionCommands_Call) Run(run func(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsGet)) *MockService_GetSessionCommands_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 *requests.SessionCommandsGet
+		if args[2] != nil {
+			arg2 = args[2].(*requests.SessionCommandsGet)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetSessionCommands_Call) Return(sessionCommands []models.SessionCommand, n int, err error) *MockService_GetSessionCommands_Call {
+	_c.Call.Return(sessionCommands, n, err)
+	return _c
+}
+
+func (_c *MockService_GetSessionCommands_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsGet) ([]models.SessionCommand, int, error)) *MockService_GetSessionCommands_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3956,44 +5368,55 @@ func (_c *MockService_GetUserAdmin_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
-// KeepAliveSession provides a mock function for the type MockService
-func (_mock *MockService) KeepAliveSession(ctx context.Context, uid models.UID) error {
-	ret := _mock.Called(ctx, uid)
+// GetWebhook provides a mock function for the type MockService
+func (_mock *MockService) GetWebhook(ctx context.Context, req *requests.WebhookGet) (*models.Webhook, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for KeepAliveSession")
+		panic("no return value specified for GetWebhook")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = returnFunc(ctx, uid)
+	var r0 *models.Webhook
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookGet) (*models.Webhook, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookGet) *models.Webhook); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.WebhookGet) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_KeepAliveSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'KeepAliveSession'
-type MockService_KeepAliveSession_Call struct {
+// MockService_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type MockService_GetWebhook_Call struct {
 	*mock.Call
 }
 
-// KeepAliveSession is a helper method to define mock.On call
+// GetWebhook is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid models.UID
-func (_e *MockService_Expecter) KeepAliveSession(ctx any, uid any) *MockService_KeepAliveSession_Call {
-	return &MockService_KeepAliveSession_Call{Call: _e.mock.On("KeepAliveSession", ctx, uid)}
+//   - req *requests.WebhookGet
+func (_e *MockService_Expecter) GetWebhook(ctx any, req any) *MockService_GetWebhook_Call {
+	return &MockService_GetWebhook_Call{Call: _e.mock.On("GetWebhook", ctx, req)}
 }
 
-func (_c *MockService_KeepAliveSession_Call) Run(run func(ctx context.Context, uid models.UID)) *MockService_KeepAliveSession_Call {
+func (_c *MockService_GetWebhook_Call) Run(run func(ctx context.Context, req *requests.WebhookGet)) *MockService_GetWebhook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.UID
+		var arg1 *requests.WebhookGet
 		if args[1] != nil {
-			arg1 = args[1].(models.UID)
+			arg1 = args[1].(*requests.WebhookGet)
 		}
 		run(
 			arg0,
@@ -4003,37 +5426,37 @@ func (_c *MockService_KeepAliveSession_Call) Run(run func(ctx context.Context, u
 	return _c
 }
 
-func (_c *MockService_KeepAliveSession_Call) Return(err error) *MockService_KeepAliveSession_Call {
-	_c.Call.Return(err)
+func (_c *MockService_GetWebhook_Call) Return(webhook *models.Webhook, err error) *MockService_GetWebhook_Call {
+	_c.Call.Return(webhook, err)
 	return _c
 }
 
-func (_c *MockService_KeepAliveSession_Call) RunAndReturn(run func(ctx context.Context, uid models.UID) error) *MockService_KeepAliveSession_Call {
+func (_c *MockService_GetWebhook_Call) RunAndReturn(run func(ctx context.Context, req *requests.WebhookGet) (*models.Webhook, error)) *MockService_GetWebhook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LeaveNamespace provides a mock function for the type MockService
-func (_mock *MockService) LeaveNamespace(ctx context.Context, req *requests.LeaveNamespace) (*models.UserAuthResponse, error) {
+// GrantAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) GrantAccessRequest(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LeaveNamespace")
+		panic("no return value specified for GrantAccessRequest")
 	}
 
-	var r0 *models.UserAuthResponse
+	var r0 *models.AccessRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.LeaveNamespace) (*models.UserAuthResponse, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestDecide) (*models.AccessRequest, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.LeaveNamespace) *models.UserAuthResponse); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestDecide) *models.AccessRequest); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.UserAuthResponse)
+			r0 = ret.Get(0).(*models.AccessRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.LeaveNamespace) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessRequestDecide) error); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -4041,27 +5464,27 @@ func (_mock *MockService) LeaveNamespace(ctx context.Context, req *requests.Leav
 	return r0, r1
 }
 
-// MockService_LeaveNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaveNamespace'
-type MockService_LeaveNamespace_Call struct {
+// MockService_GrantAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GrantAccessRequest'
+type MockService_GrantAccessRequest_Call struct {
 	*mock.Call
 }
 
-// LeaveNamespace is a helper method to define mock.On call
+// GrantAccessRequest is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.LeaveNamespace
-func (_e *MockService_Expecter) LeaveNamespace(ctx any, req any) *MockService_LeaveNamespace_Call {
-	return &MockService_LeaveNamespace_Call{Call: _e.mock.On("LeaveNamespace", ctx, req)}
+//   - req *requests.AccessRequestDecide
+func (_e *MockService_Expecter) GrantAccessRequest(ctx any, req any) *MockService_GrantAccessRequest_Call {
+	return &MockService_GrantAccessRequest_Call{Call: _e.mock.On("GrantAccessRequest", ctx, req)}
 }
 
-func (_c *MockService_LeaveNamespace_Call) Run(run func(ctx context.Context, req *requests.LeaveNamespace)) *MockService_LeaveNamespace_Call {
+func (_c *MockService_GrantAccessRequest_Call) Run(run func(ctx context.Context, req *requests.AccessRequestDecide)) *MockService_GrantAccessRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.LeaveNamespace
+		var arg1 *requests.AccessRequestDecide
 		if args[1] != nil {
-			arg1 = args[1].(*requests.LeaveNamespace)
+			arg1 = args[1].(*requests.AccessRequestDecide)
 		}
 		run(
 			arg0,
@@ -4071,29 +5494,154 @@ func (_c *MockService_LeaveNamespace_Call) Run(run func(ctx context.Context, req
 	return _c
 }
 
-func (_c *MockService_LeaveNamespace_Call) Return(userAuthResponse *models.UserAuthResponse, err error) *MockService_LeaveNamespace_Call {
-	_c.Call.Return(userAuthResponse, err)
+func (_c *MockService_GrantAccessRequest_Call) Return(accessRequest *models.AccessRequest, err error) *MockService_GrantAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
 	return _c
 }
 
-func (_c *MockService_LeaveNamespace_Call) RunAndReturn(run func(ctx context.Context, req *requests.LeaveNamespace) (*models.UserAuthResponse, error)) *MockService_LeaveNamespace_Call {
+func (_c *MockService_GrantAccessRequest_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error)) *MockService_GrantAccessRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAPIKeys provides a mock function for the type MockService
-func (_mock *MockService) ListAPIKeys(ctx context.Context, req *requests.ListAPIKey) ([]models.APIKey, int, error) {
-	ret := _mock.Called(ctx, req)
+// KeepAliveSession provides a mock function for the type MockService
+func (_mock *MockService) KeepAliveSession(ctx context.Context, uid models.UID) error {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAPIKeys")
+		panic("no return value specified for KeepAliveSession")
 	}
 
-	var r0 []models.APIKey
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListAPIKey) ([]models.APIKey, int, error)); ok {
-		return returnFunc(ctx, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_KeepAliveSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'KeepAliveSession'
+type MockService_KeepAliveSession_Call struct {
+	*mock.Call
+}
+
+// KeepAliveSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid models.UID
+func (_e *MockService_Expecter) KeepAliveSession(ctx any, uid any) *MockService_KeepAliveSession_Call {
+	return &MockService_KeepAliveSession_Call{Call: _e.mock.On("KeepAliveSession", ctx, uid)}
+}
+
+func (_c *MockService_KeepAliveSession_Call) Run(run func(ctx context.Context, uid models.UID)) *MockService_KeepAliveSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.UID
+		if args[1] != nil {
+			arg1 = args[1].(models.UID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_KeepAliveSession_Call) Return(err error) *MockService_KeepAliveSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_KeepAliveSession_Call) RunAndReturn(run func(ctx context.Context, uid models.UID) error) *MockService_KeepAliveSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LeaveNamespace provides a mock function for the type MockService
+func (_mock *MockService) LeaveNamespace(ctx context.Context, req *requests.LeaveNamespace) (*models.UserAuthResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeaveNamespace")
+	}
+
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.LeaveNamespace) (*models.UserAuthResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.LeaveNamespace) *models.UserAuthResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.LeaveNamespace) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_LeaveNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaveNamespace'
+type MockService_LeaveNamespace_Call struct {
+	*mock.Call
+}
+
+// LeaveNamespace is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.LeaveNamespace
+func (_e *MockService_Expecter) LeaveNamespace(ctx any, req any) *MockService_LeaveNamespace_Call {
+	return &MockService_LeaveNamespace_Call{Call: _e.mock.On("LeaveNamespace", ctx, req)}
+}
+
+func (_c *MockService_LeaveNamespace_Call) Run(run func(ctx context.Context, req *requests.LeaveNamespace)) *MockService_LeaveNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.LeaveNamespace
+		if args[1] != nil {
+			arg1 = args[1].(*requests.LeaveNamespace)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_LeaveNamespace_Call) Return(userAuthResponse *models.UserAuthResponse, err error) *MockService_LeaveNamespace_Call {
+	_c.Call.Return(userAuthResponse, err)
+	return _c
+}
+
+func (_c *MockService_LeaveNamespace_Call) RunAndReturn(run func(ctx context.Context, req *requests.LeaveNamespace) (*models.UserAuthResponse, error)) *MockService_LeaveNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPIKeys provides a mock function for the type MockService
+func (_mock *MockService) ListAPIKeys(ctx context.Context, req *requests.ListAPIKey) ([]models.APIKey, int, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPIKeys")
+	}
+
+	var r0 []models.APIKey
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListAPIKey) ([]models.APIKey, int, error)); ok {
+		return returnFunc(ctx, req)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListAPIKey) []models.APIKey); ok {
 		r0 = returnFunc(ctx, req)
@@ -4223,113 +5771,107 @@ func (_c *MockService_ListAccessPolicies_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
-// ListDevices provides a mock function for the type MockService
-func (_mock *MockService) ListDevices(ctx context.Context, sc scope.Scope, req *requests.DeviceList) ([]models.Device, int, error) {
-	ret := _mock.Called(ctx, sc, req)
+// ListAccessRequests provides a mock function for the type MockService
+func (_mock *MockService) ListAccessRequests(ctx context.Context, req *requests.AccessRequestList) ([]models.AccessRequest, int, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListDevices")
+		panic("no return value specified for ListAccessRequests")
 	}
 
-	var r0 []models.Device
+	var r0 []models.AccessRequest
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.DeviceList) ([]models.Device, int, error)); ok {
-		return returnFunc(ctx, sc, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestList) ([]models.AccessRequest, int, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.DeviceList) []models.Device); ok {
-		r0 = returnFunc(ctx, sc, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestList) []models.AccessRequest); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Device)
+			r0 = ret.Get(0).([]models.AccessRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *requests.DeviceList) int); ok {
-		r1 = returnFunc(ctx, sc, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessRequestList) int); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *requests.DeviceList) error); ok {
-		r2 = returnFunc(ctx, sc, req)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.AccessRequestList) error); ok {
+		r2 = returnFunc(ctx, req)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockService_ListDevices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDevices'
-type MockService_ListDevices_Call struct {
+// MockService_ListAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccessRequests'
+type MockService_ListAccessRequests_Call struct {
 	*mock.Call
 }
 
-// ListDevices is a helper method to define mock.On call
+// ListAccessRequests is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - req *requests.DeviceList
-func (_e *MockService_Expecter) ListDevices(ctx any, sc any, req any) *MockService_ListDevices_Call {
-	return &MockService_ListDevices_Call{Call: _e.mock.On("ListDevices", ctx, sc, req)}
+//   - req *requests.AccessRequestList
+func (_e *MockService_Expecter) ListAccessRequests(ctx any, req any) *MockService_ListAccessRequests_Call {
+	return &MockService_ListAccessRequests_Call{Call: _e.mock.On("ListAccessRequests", ctx, req)}
 }
 
-func (_c *MockService_ListDevices_Call) Run(run func(ctx context.Context, sc scope.Scope, req *requests.DeviceList)) *MockService_ListDevices_Call {
+func (_c *MockService_ListAccessRequests_Call) Run(run func(ctx context.Context, req *requests.AccessRequestList)) *MockService_ListAccessRequests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 *requests.AccessRequestList
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
-		}
-		var arg2 *requests.DeviceList
-		if args[2] != nil {
-			arg2 = args[2].(*requests.DeviceList)
+			arg1 = args[1].(*requests.AccessRequestList)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_ListDevices_Call) Return(devices []models.Device, n int, err error) *MockService_ListDevices_Call {
-	_c.Call.Return(devices, n, err)
+func (_c *MockService_ListAccessRequests_Call) Return(accessRequests []models.AccessRequest, n int, err error) *MockService_ListAccessRequests_Call {
+	_c.Call.Return(accessRequests, n, err)
 	return _c
 }
 
-func (_c *MockService_ListDevices_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, req *requests.DeviceList) ([]models.Device, int, error)) *MockService_ListDevices_Call {
+func (_c *MockService_ListAccessRequests_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessRequestList) ([]models.AccessRequest, int, error)) *MockService_ListAccessRequests_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListInstallKeyEvents provides a mock function for the type MockService
-func (_mock *MockService) ListInstallKeyEvents(ctx context.Context, req *requests.ListInstallKeyEvents) ([]models.InstallKeyEvent, int, error) {
+// ListAuditEvents provides a mock function for the type MockService
+func (_mock *MockService) ListAuditEvents(ctx context.Context, req *requests.AuditEventList) ([]models.AuditEvent, int, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListInstallKeyEvents")
+		panic("no return value specified for ListAuditEvents")
 	}
 
-	var r0 []models.InstallKeyEvent
+	var r0 []models.AuditEvent
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListInstallKeyEvents) ([]models.InstallKeyEvent, int, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AuditEventList) ([]models.AuditEvent, int, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListInstallKeyEvents) []models.InstallKeyEvent); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AuditEventList) []models.AuditEvent); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.InstallKeyEvent)
+			r0 = ret.Get(0).([]models.AuditEvent)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ListInstallKeyEvents) int); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AuditEventList) int); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.ListInstallKeyEvents) error); ok {
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.AuditEventList) error); ok {
 		r2 = returnFunc(ctx, req)
 	} else {
 		r2 = ret.Error(2)
@@ -4337,27 +5879,27 @@ func (_mock *MockService) ListInstallKeyEvents(ctx context.Context, req *request
 	return r0, r1, r2
 }
 
-// MockService_ListInstallKeyEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInstallKeyEvents'
-type MockService_ListInstallKeyEvents_Call struct {
+// MockService_ListAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEvents'
+type MockService_ListAuditEvents_Call struct {
 	*mock.Call
 }
 
-// ListInstallKeyEvents is a helper method to define mock.On call
+// ListAuditEvents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.ListInstallKeyEvents
-func (_e *MockService_Expecter) ListInstallKeyEvents(ctx any, req any) *MockService_ListInstallKeyEvents_Call {
-	return &MockService_ListInstallKeyEvents_Call{Call: _e.mock.On("ListInstallKeyEvents", ctx, req)}
+//   - req *requests.AuditEventList
+func (_e *MockService_Expecter) ListAuditEvents(ctx any, req any) *MockService_ListAuditEvents_Call {
+	return &MockService_ListAuditEvents_Call{Call: _e.mock.On("ListAuditEvents", ctx, req)}
 }
 
-func (_c *MockService_ListInstallKeyEvents_Call) Run(run func(ctx context.Context, req *requests.ListInstallKeyEvents)) *MockService_ListInstallKeyEvents_Call {
+func (_c *MockService_ListAuditEvents_Call) Run(run func(ctx context.Context, req *requests.AuditEventList)) *MockService_ListAuditEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.ListInstallKeyEvents
+		var arg1 *requests.AuditEventList
 		if args[1] != nil {
-			arg1 = args[1].(*requests.ListInstallKeyEvents)
+			arg1 = args[1].(*requests.AuditEventList)
 		}
 		run(
 			arg0,
@@ -4367,71 +5909,65 @@ func (_c *MockService_ListInstallKeyEvents_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockService_ListInstallKeyEvents_Call) Return(events []models.InstallKeyEvent, count int, err error) *MockService_ListInstallKeyEvents_Call {
-	_c.Call.Return(events, count, err)
+func (_c *MockService_ListAuditEvents_Call) Return(auditEvents []models.AuditEvent, n int, err error) *MockService_ListAuditEvents_Call {
+	_c.Call.Return(auditEvents, n, err)
 	return _c
 }
 
-func (_c *MockService_ListInstallKeyEvents_Call) RunAndReturn(run func(ctx context.Context, req *requests.ListInstallKeyEvents) ([]models.InstallKeyEvent, int, error)) *MockService_ListInstallKeyEvents_Call {
+func (_c *MockService_ListAuditEvents_Call) RunAndReturn(run func(ctx context.Context, req *requests.AuditEventList) ([]models.AuditEvent, int, error)) *MockService_ListAuditEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListInstallKeys provides a mock function for the type MockService
-func (_mock *MockService) ListInstallKeys(ctx context.Context, req *requests.ListInstallKey) ([]models.InstallKey, int, error) {
+// ListDeviceAccess provides a mock function for the type MockService
+func (_mock *MockService) ListDeviceAccess(ctx context.Context, req *requests.AccessPolicyReach) ([]models.AccessGrant, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListInstallKeys")
+		panic("no return value specified for ListDeviceAccess")
 	}
 
-	var r0 []models.InstallKey
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListInstallKey) ([]models.InstallKey, int, error)); ok {
+	var r0 []models.AccessGrant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessPolicyReach) ([]models.AccessGrant, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListInstallKey) []models.InstallKey); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessPolicyReach) []models.AccessGrant); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.InstallKey)
+			r0 = ret.Get(0).([]models.AccessGrant)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ListInstallKey) int); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessPolicyReach) error); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.ListInstallKey) error); ok {
-		r2 = returnFunc(ctx, req)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockService_ListInstallKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInstallKeys'
-type MockService_ListInstallKeys_Call struct {
+// MockService_ListDeviceAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeviceAccess'
+type MockService_ListDeviceAccess_Call struct {
 	*mock.Call
 }
 
-// ListInstallKeys is a helper method to define mock.On call
+// ListDeviceAccess is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.ListInstallKey
-func (_e *MockService_Expecter) ListInstallKeys(ctx any, req any) *MockService_ListInstallKeys_Call {
-	return &MockService_ListInstallKeys_Call{Call: _e.mock.On("ListInstallKeys", ctx, req)}
+//   - req *requests.AccessPolicyReach
+func (_e *MockService_Expecter) ListDeviceAccess(ctx any, req any) *MockService_ListDeviceAccess_Call {
+	return &MockService_ListDeviceAccess_Call{Call: _e.mock.On("ListDeviceAccess", ctx, req)}
 }
 
-func (_c *MockService_ListInstallKeys_Call) Run(run func(ctx context.Context, req *requests.ListInstallKey)) *MockService_ListInstallKeys_Call {
+func (_c *MockService_ListDeviceAccess_Call) Run(run func(ctx context.Context, req *requests.AccessPolicyReach)) *MockService_ListDeviceAccess_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.ListInstallKey
+		var arg1 *requests.AccessPolicyReach
 		if args[1] != nil {
-			arg1 = args[1].(*requests.ListInstallKey)
+			arg1 = args[1].(*requests.AccessPolicyReach)
 		}
 		run(
 			arg0,
@@ -4441,117 +5977,123 @@ func (_c *MockService_ListInstallKeys_Call) Run(run func(ctx context.Context, re
 	return _c
 }
 
-func (_c *MockService_ListInstallKeys_Call) Return(installKeys []models.InstallKey, count int, err error) *MockService_ListInstallKeys_Call {
-	_c.Call.Return(installKeys, count, err)
+func (_c *MockService_ListDeviceAccess_Call) Return(accessGrants []models.AccessGrant, err error) *MockService_ListDeviceAccess_Call {
+	_c.Call.Return(accessGrants, err)
 	return _c
 }
 
-func (_c *MockService_ListInstallKeys_Call) RunAndReturn(run func(ctx context.Context, req *requests.ListInstallKey) ([]models.InstallKey, int, error)) *MockService_ListInstallKeys_Call {
+func (_c *MockService_ListDeviceAccess_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessPolicyReach) ([]models.AccessGrant, error)) *MockService_ListDeviceAccess_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListNamespaceMembers provides a mock function for the type MockService
-func (_mock *MockService) ListNamespaceMembers(ctx context.Context, req *requests.MemberList) ([]models.MemberView, int, error) {
-	ret := _mock.Called(ctx, req)
+// ListDevices provides a mock function for the type MockService
+func (_mock *MockService) ListDevices(ctx context.Context, sc scope.Scope, req *requests.DeviceList) ([]models.Device, int, error) {
+	ret := _mock.Called(ctx, sc, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListNamespaceMembers")
+		panic("no return value specified for ListDevices")
 	}
 
-	var r0 []models.MemberView
+	var r0 []models.Device
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.MemberList) ([]models.MemberView, int, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.DeviceList) ([]models.Device, int, error)); ok {
+		return returnFunc(ctx, sc, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.MemberList) []models.MemberView); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.DeviceList) []models.Device); ok {
+		r0 = returnFunc(ctx, sc, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.MemberView)
+			r0 = ret.Get(0).([]models.Device)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.MemberList) int); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *requests.DeviceList) int); ok {
+		r1 = returnFunc(ctx, sc, req)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.MemberList) error); ok {
-		r2 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *requests.DeviceList) error); ok {
+		r2 = returnFunc(ctx, sc, req)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockService_ListNamespaceMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNamespaceMembers'
-type MockService_ListNamespaceMembers_Call struct {
+// MockService_ListDevices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDevices'
+type MockService_ListDevices_Call struct {
 	*mock.Call
 }
 
-// ListNamespaceMembers is a helper method to define mock.On call
+// ListDevices is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.MemberList
-func (_e *MockService_Expecter) ListNamespaceMembers(ctx any, req any) *MockService_ListNamespaceMembers_Call {
-	return &MockService_ListNamespaceMembers_Call{Call: _e.mock.On("ListNamespaceMembers", ctx, req)}
+//   - sc scope.Scope
+//   - req *requests.DeviceList
+func (_e *MockService_Expecter) ListDevices(ctx any, sc any, req any) *MockService_ListDevices_Call {
+	return &MockService_ListDevices_Call{Call: _e.mock.On("ListDevices", ctx, sc, req)}
 }
 
-func (_c *MockService_ListNamespaceMembers_Call) Run(run func(ctx context.Context, req *requests.MemberList)) *MockService_ListNamespaceMembers_Call {
+func (_c *MockService_ListDevices_Call) Run(run func(ctx context.Context, sc scope.Scope, req *requests.DeviceList)) *MockService_ListDevices_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.MemberList
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(*requests.MemberList)
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 *requests.DeviceList
+		if args[2] != nil {
+			arg2 = args[2].(*requests.DeviceList)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_ListNamespaceMembers_Call) Return(memberViews []models.MemberView, n int, err error) *MockService_ListNamespaceMembers_Call {
-	_c.Call.Return(memberViews, n, err)
+func (_c *MockService_ListDevices_Call) Return(devices []models.Device, n int, err error) *MockService_ListDevices_Call {
+	_c.Call.Return(devices, n, err)
 	return _c
 }
 
-func (_c *MockService_ListNamespaceMembers_Call) RunAndReturn(run func(ctx context.Context, req *requests.MemberList) ([]models.MemberView, int, error)) *MockService_ListNamespaceMembers_Call {
+func (_c *MockService_ListDevices_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, req *requests.DeviceList) ([]models.Device, int, error)) *MockService_ListDevices_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListNamespaces provides a mock function for the type MockService
-func (_mock *MockService) ListNamespaces(ctx context.Context, req *requests.NamespaceList) ([]models.Namespace, int, error) {
+// ListInstallKeyEvents provides a mock function for the type MockService
+func (_mock *MockService) ListInstallKeyEvents(ctx context.Context, req *requests.ListInstallKeyEvents) ([]models.InstallKeyEvent, int, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListNamespaces")
+		panic("no return value specified for ListInstallKeyEvents")
 	}
 
-	var r0 []models.Namespace
+	var r0 []models.InstallKeyEvent
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.NamespaceList) ([]models.Namespace, int, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListInstallKeyEvents) ([]models.InstallKeyEvent, int, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.NamespaceList) []models.Namespace); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListInstallKeyEvents) []models.InstallKeyEvent); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Namespace)
+			r0 = ret.Get(0).([]models.InstallKeyEvent)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.NamespaceList) int); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ListInstallKeyEvents) int); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.NamespaceList) error); ok {
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.ListInstallKeyEvents) error); ok {
 		r2 = returnFunc(ctx, req)
 	} else {
 		r2 = ret.Error(2)
@@ -4559,27 +6101,27 @@ func (_mock *MockService) ListNamespaces(ctx context.Context, req *requests.Name
 	return r0, r1, r2
 }
 
-// MockService_ListNamespaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNamespaces'
-type MockService_ListNamespaces_Call struct {
+// MockService_ListInstallKeyEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInstallKeyEvents'
+type MockService_ListInstallKeyEvents_Call struct {
 	*mock.Call
 }
 
-// ListNamespaces is a helper method to define mock.On call
+// ListInstallKeyEvents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.NamespaceList
-func (_e *MockService_Expecter) ListNamespaces(ctx any, req any) *MockService_ListNamespaces_Call {
-	return &MockService_ListNamespaces_Call{Call: _e.mock.On("ListNamespaces", ctx, req)}
+//   - req *requests.ListInstallKeyEvents
+func (_e *MockService_Expecter) ListInstallKeyEvents(ctx any, req any) *MockService_ListInstallKeyEvents_Call {
+	return &MockService_ListInstallKeyEvents_Call{Call: _e.mock.On("ListInstallKeyEvents", ctx, req)}
 }
 
-func (_c *MockService_ListNamespaces_Call) Run(run func(ctx context.Context, req *requests.NamespaceList)) *MockService_ListNamespaces_Call {
+func (_c *MockService_ListInstallKeyEvents_Call) Run(run func(ctx context.Context, req *requests.ListInstallKeyEvents)) *MockService_ListInstallKeyEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.NamespaceList
+		var arg1 *requests.ListInstallKeyEvents
 		if args[1] != nil {
-			arg1 = args[1].(*requests.NamespaceList)
+			arg1 = args[1].(*requests.ListInstallKeyEvents)
 		}
 		run(
 			arg0,
@@ -4589,43 +6131,43 @@ func (_c *MockService_ListNamespaces_Call) Run(run func(ctx context.Context, req
 	return _c
 }
 
-func (_c *MockService_ListNamespaces_Call) Return(namespaces []models.Namespace, n int, err error) *MockService_ListNamespaces_Call {
-	_c.Call.Return(namespaces, n, err)
+func (_c *MockService_ListInstallKeyEvents_Call) Return(events []models.InstallKeyEvent, count int, err error) *MockService_ListInstallKeyEvents_Call {
+	_c.Call.Return(events, count, err)
 	return _c
 }
 
-func (_c *MockService_ListNamespaces_Call) RunAndReturn(run func(ctx context.Context, req *requests.NamespaceList) ([]models.Namespace, int, error)) *MockService_ListNamespaces_Call {
+func (_c *MockService_ListInstallKeyEvents_Call) RunAndReturn(run func(ctx context.Context, req *requests.ListInstallKeyEvents) ([]models.InstallKeyEvent, int, error)) *MockService_ListInstallKeyEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPublicKeys provides a mock function for the type MockService
-func (_mock *MockService) ListPublicKeys(ctx context.Context, req *requests.ListPublicKeys) ([]models.PublicKey, int, error) {
+// ListInstallKeys provides a mock function for the type MockService
+func (_mock *MockService) ListInstallKeys(ctx context.Context, req *requests.ListInstallKey) ([]models.InstallKey, int, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPublicKeys")
+		panic("no return value specified for ListInstallKeys")
 	}
 
-	var r0 []models.PublicKey
+	var r0 []models.InstallKey
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListPublicKeys) ([]models.PublicKey, int, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListInstallKey) ([]models.InstallKey, int, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListPublicKeys) []models.PublicKey); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListInstallKey) []models.InstallKey); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PublicKey)
+			r0 = ret.Get(0).([]models.InstallKey)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ListPublicKeys) int); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ListInstallKey) int); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.ListPublicKeys) error); ok {
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.ListInstallKey) error); ok {
 		r2 = returnFunc(ctx, req)
 	} else {
 		r2 = ret.Error(2)
@@ -4633,27 +6175,27 @@ func (_mock *MockService) ListPublicKeys(ctx context.Context, req *requests.List
 	return r0, r1, r2
 }
 
-// MockService_ListPublicKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicKeys'
-type MockService_ListPublicKeys_Call struct {
+// MockService_ListInstallKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInstallKeys'
+type MockService_ListInstallKeys_Call struct {
 	*mock.Call
 }
 
-// ListPublicKeys is a helper method to define mock.On call
+// ListInstallKeys is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.ListPublicKeys
-func (_e *MockService_Expecter) ListPublicKeys(ctx any, req any) *MockService_ListPublicKeys_Call {
-	return &MockService_ListPublicKeys_Call{Call: _e.mock.On("ListPublicKeys", ctx, req)}
+//   - req *requests.ListInstallKey
+func (_e *MockService_Expecter) ListInstallKeys(ctx any, req any) *MockService_ListInstallKeys_Call {
+	return &MockService_ListInstallKeys_Call{Call: _e.mock.On("ListInstallKeys", ctx, req)}
 }
 
-func (_c *MockService_ListPublicKeys_Call) Run(run func(ctx context.Context, req *requests.ListPublicKeys)) *MockService_ListPublicKeys_Call {
+func (_c *MockService_ListInstallKeys_Call) Run(run func(ctx context.Context, req *requests.ListInstallKey)) *MockService_ListInstallKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.ListPublicKeys
+		var arg1 *requests.ListInstallKey
 		if args[1] != nil {
-			arg1 = args[1].(*requests.ListPublicKeys)
+			arg1 = args[1].(*requests.ListInstallKey)
 		}
 		run(
 			arg0,
@@ -4663,65 +6205,71 @@ func (_c *MockService_ListPublicKeys_Call) Run(run func(ctx context.Context, req
 	return _c
 }
 
-func (_c *MockService_ListPublicKeys_Call) Return(publicKeys []models.PublicKey, n int, err error) *MockService_ListPublicKeys_Call {
-	_c.Call.Return(publicKeys, n, err)
+func (_c *MockService_ListInstallKeys_Call) Return(installKeys []models.InstallKey, count int, err error) *MockService_ListInstallKeys_Call {
+	_c.Call.Return(installKeys, count, err)
 	return _c
 }
 
-func (_c *MockService_ListPublicKeys_Call) RunAndReturn(run func(ctx context.Context, req *requests.ListPublicKeys) ([]models.PublicKey, int, error)) *MockService_ListPublicKeys_Call {
+func (_c *MockService_ListInstallKeys_Call) RunAndReturn(run func(ctx context.Context, req *requests.ListInstallKey) ([]models.InstallKey, int, error)) *MockService_ListInstallKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListSSHIdentities provides a mock function for the type MockService
-func (_mock *MockService) ListSSHIdentities(ctx context.Context, req *requests.SSHIdentityList) ([]models.SSHIdentity, error) {
+// ListNamespaceMembers provides a mock function for the type MockService
+func (_mock *MockService) ListNamespaceMembers(ctx context.Context, req *requests.MemberList) ([]models.MemberView, int, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListSSHIdentities")
+		panic("no return value specified for ListNamespaceMembers")
 	}
 
-	var r0 []models.SSHIdentity
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityList) ([]models.SSHIdentity, error)); ok {
+	var r0 []models.MemberView
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.MemberList) ([]models.MemberView, int, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityList) []models.SSHIdentity); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.MemberList) []models.MemberView); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.SSHIdentity)
+			r0 = ret.Get(0).([]models.MemberView)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHIdentityList) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.MemberList) int); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.MemberList) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockService_ListSSHIdentities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSSHIdentities'
-type MockService_ListSSHIdentities_Call struct {
+// MockService_ListNamespaceMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNamespaceMembers'
+type MockService_ListNamespaceMembers_Call struct {
 	*mock.Call
 }
 
-// ListSSHIdentities is a helper method to define mock.On call
+// ListNamespaceMembers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.SSHIdentityList
-func (_e *MockService_Expecter) ListSSHIdentities(ctx any, req any) *MockService_ListSSHIdentities_Call {
-	return &MockService_ListSSHIdentities_Call{Call: _e.mock.On("ListSSHIdentities", ctx, req)}
+//   - req *requests.MemberList
+func (_e *MockService_Expecter) ListNamespaceMembers(ctx any, req any) *MockService_ListNamespaceMembers_Call {
+	return &MockService_ListNamespaceMembers_Call{Call: _e.mock.On("ListNamespaceMembers", ctx, req)}
 }
 
-func (_c *MockService_ListSSHIdentities_Call) Run(run func(ctx context.Context, req *requests.SSHIdentityList)) *MockService_ListSSHIdentities_Call {
+func (_c *MockService_ListNamespaceMembers_Call) Run(run func(ctx context.Context, req *requests.MemberList)) *MockService_ListNamespaceMembers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.SSHIdentityList
+		var arg1 *requests.MemberList
 		if args[1] != nil {
-			arg1 = args[1].(*requests.SSHIdentityList)
+			arg1 = args[1].(*requests.MemberList)
 		}
 		run(
 			arg0,
@@ -4731,65 +6279,71 @@ func (_c *MockService_ListSSHIdentities_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockService_ListSSHIdentities_Call) Return(sSHIdentitys []models.SSHIdentity, err error) *MockService_ListSSHIdentities_Call {
-	_c.Call.Return(sSHIdentitys, err)
+func (_c *MockService_ListNamespaceMembers_Call) Return(memberViews []models.MemberView, n int, err error) *MockService_ListNamespaceMembers_Call {
+	_c.Call.Return(memberViews, n, err)
 	return _c
 }
 
-func (_c *MockService_ListSSHIdentities_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHIdentityList) ([]models.SSHIdentity, error)) *MockService_ListSSHIdentities_Call {
+func (_c *MockService_ListNamespaceMembers_Call) RunAndReturn(run func(ctx context.Context, req *requests.MemberList) ([]models.MemberView, int, error)) *MockService_ListNamespaceMembers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListServiceAccounts provides a mock function for the type MockService
-func (_mock *MockService) ListServiceAccounts(ctx context.Context, req *requests.ServiceAccountList) ([]models.ServiceAccount, error) {
+// ListNamespaces provides a mock function for the type MockService
+func (_mock *MockService) ListNamespaces(ctx context.Context, req *requests.NamespaceList) ([]models.Namespace, int, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListServiceAccounts")
+		panic("no return value specified for ListNamespaces")
 	}
 
-	var r0 []models.ServiceAccount
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ServiceAccountList) ([]models.ServiceAccount, error)); ok {
+	var r0 []models.Namespace
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.NamespaceList) ([]models.Namespace, int, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ServiceAccountList) []models.ServiceAccount); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.NamespaceList) []models.Namespace); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.ServiceAccount)
+			r0 = ret.Get(0).([]models.Namespace)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ServiceAccountList) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.NamespaceList) int); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.NamespaceList) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockService_ListServiceAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServiceAccounts'
-type MockService_ListServiceAccounts_Call struct {
+// MockService_ListNamespaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNamespaces'
+type MockService_ListNamespaces_Call struct {
 	*mock.Call
 }
 
-// ListServiceAccounts is a helper method to define mock.On call
+// ListNamespaces is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.ServiceAccountList
-func (_e *MockService_Expecter) ListServiceAccounts(ctx any, req any) *MockService_ListServiceAccounts_Call {
-	return &MockService_ListServiceAccounts_Call{Call: _e.mock.On("ListServiceAccounts", ctx, req)}
+//   - req *requests.NamespaceList
+func (_e *MockService_Expecter) ListNamespaces(ctx any, req any) *MockService_ListNamespaces_Call {
+	return &MockService_ListNamespaces_Call{Call: _e.mock.On("ListNamespaces", ctx, req)}
 }
 
-func (_c *MockService_ListServiceAccounts_Call) Run(run func(ctx context.Context, req *requests.ServiceAccountList)) *MockService_ListServiceAccounts_Call {
+func (_c *MockService_ListNamespaces_Call) Run(run func(ctx context.Context, req *requests.NamespaceList)) *MockService_ListNamespaces_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.ServiceAccountList
+		var arg1 *requests.NamespaceList
 		if args[1] != nil {
-			arg1 = args[1].(*requests.ServiceAccountList)
+			arg1 = args[1].(*requests.NamespaceList)
 		}
 		run(
 			arg0,
@@ -4799,151 +6353,139 @@ func (_c *MockService_ListServiceAccounts_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockService_ListServiceAccounts_Call) Return(serviceAccounts []models.ServiceAccount, err error) *MockService_ListServiceAccounts_Call {
-	_c.Call.Return(serviceAccounts, err)
+func (_c *MockService_ListNamespaces_Call) Return(namespaces []models.Namespace, n int, err error) *MockService_ListNamespaces_Call {
+	_c.Call.Return(namespaces, n, err)
 	return _c
 }
 
-func (_c *MockService_ListServiceAccounts_Call) RunAndReturn(run func(ctx context.Context, req *requests.ServiceAccountList) ([]models.ServiceAccount, error)) *MockService_ListServiceAccounts_Call {
+func (_c *MockService_ListNamespaces_Call) RunAndReturn(run func(ctx context.Context, req *requests.NamespaceList) ([]models.Namespace, int, error)) *MockService_ListNamespaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListSessions provides a mock function for the type MockService
-func (_mock *MockService) ListSessions(ctx context.Context, sc scope.Scope, req *requests.ListSessions) ([]models.Session, int, error) {
-	ret := _mock.Called(ctx, sc, req)
+// ListPublicKeys provides a mock function for the type MockService
+func (_mock *MockService) ListPublicKeys(ctx context.Context, req *requests.ListPublicKeys) ([]models.PublicKey, int, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListSessions")
+		panic("no return value specified for ListPublicKeys")
 	}
 
-	var r0 []models.Session
+	var r0 []models.PublicKey
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.ListSessions) ([]models.Session, int, error)); ok {
-		return returnFunc(ctx, sc, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListPublicKeys) ([]models.PublicKey, int, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.ListSessions) []models.Session); ok {
-		r0 = returnFunc(ctx, sc, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListPublicKeys) []models.PublicKey); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Session)
+			r0 = ret.Get(0).([]models.PublicKey)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *requests.ListSessions) int); ok {
-		r1 = returnFunc(ctx, sc, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ListPublicKeys) int); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *requests.ListSessions) error); ok {
-		r2 = returnFunc(ctx, sc, req)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.ListPublicKeys) error); ok {
+		r2 = returnFunc(ctx, req)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockService_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
-type MockService_ListSessions_Call struct {
+// MockService_ListPublicKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicKeys'
+type MockService_ListPublicKeys_Call struct {
 	*mock.Call
 }
 
-// ListSessions is a helper method to define mock.On call
+// ListPublicKeys is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - req *requests.ListSessions
-func (_e *MockService_Expecter) ListSessions(ctx any, sc any, req any) *MockService_ListSessions_Call {
-	return &MockService_ListSessions_Call{Call: _e.mock.On("ListSessions", ctx, sc, req)}
+//   - req *requests.ListPublicKeys
+func (_e *MockService_Expecter) ListPublicKeys(ctx any, req any) *MockService_ListPublicKeys_Call {
+	return &MockService_ListPublicKeys_Call{Call: _e.mock.On("ListPublicKeys", ctx, req)}
 }
 
-func (_c *MockService_ListSessions_Call) Run(run func(ctx context.Context, sc scope.Scope, req *requests.ListSessions)) *MockService_ListSessions_Call {
+func (_c *MockService_ListPublicKeys_Call) Run(run func(ctx context.Context, req *requests.ListPublicKeys)) *MockService_ListPublicKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 *requests.ListPublicKeys
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
-		}
-		var arg2 *requests.ListSessions
-		if args[2] != nil {
-			arg2 = args[2].(*requests.ListSessions)
+			arg1 = args[1].(*requests.ListPublicKeys)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_ListSessions_Call) Return(sessions []models.Session, n int, err error) *MockService_ListSessions_Call {
-	_c.Call.Return(sessions, n, err)
+func (_c *MockService_ListPublicKeys_Call) Return(publicKeys []models.PublicKey, n int, err error) *MockService_ListPublicKeys_Call {
+	_c.Call.Return(publicKeys, n, err)
 	return _c
 }
 
-func (_c *MockService_ListSessions_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, req *requests.ListSessions) ([]models.Session, int, error)) *MockService_ListSessions_Call {
+func (_c *MockService_ListPublicKeys_Call) RunAndReturn(run func(ctx context.Context, req *requests.ListPublicKeys) ([]models.PublicKey, int, error)) *MockService_ListPublicKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListTags provides a mock function for the type MockService
-func (_mock *MockService) ListTags(ctx context.Context, req *requests.ListTags) ([]models.Tag, int, error) {
+// ListSCIMGroups provides a mock function for the type MockService
+func (_mock *MockService) ListSCIMGroups(ctx context.Context, req *scim.ListRequest) (*scim.ListResponse, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListTags")
+		panic("no return value specified for ListSCIMGroups")
 	}
 
-	var r0 []models.Tag
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListTags) ([]models.Tag, int, error)); ok {
+	var r0 *scim.ListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *scim.ListRequest) (*scim.ListResponse, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListTags) []models.Tag); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *scim.ListRequest) *scim.ListResponse); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Tag)
+			r0 = ret.Get(0).(*scim.ListResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ListTags) int); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *scim.ListRequest) error); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.ListTags) error); ok {
-		r2 = returnFunc(ctx, req)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockService_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
-type MockService_ListTags_Call struct {
+// MockService_ListSCIMGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSCIMGroups'
+type MockService_ListSCIMGroups_Call struct {
 	*mock.Call
 }
 
-// ListTags is a helper method to define mock.On call
+// ListSCIMGroups is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.ListTags
-func (_e *MockService_Expecter) ListTags(ctx any, req any) *MockService_ListTags_Call {
-	return &MockService_ListTags_Call{Call: _e.mock.On("ListTags", ctx, req)}
+//   - req *scim.ListRequest
+func (_e *MockService_Expecter) ListSCIMGroups(ctx any, req any) *MockService_ListSCIMGroups_Call {
+	return &MockService_ListSCIMGroups_Call{Call: _e.mock.On("ListSCIMGroups", ctx, req)}
 }
 
-func (_c *MockService_ListTags_Call) Run(run func(ctx context.Context, req *requests.ListTags)) *MockService_ListTags_Call {
+func (_c *MockService_ListSCIMGroups_Call) Run(run func(ctx context.Context, req *scim.ListRequest)) *MockService_ListSCIMGroups_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.ListTags
+		var arg1 *scim.ListRequest
 		if args[1] != nil {
-			arg1 = args[1].(*requests.ListTags)
+			arg1 = args[1].(*scim.ListRequest)
 		}
 		run(
 			arg0,
@@ -4953,137 +6495,133 @@ func (_c *MockService_ListTags_Call) Run(run func(ctx context.Context, req *requ
 	return _c
 }
 
-func (_c *MockService_ListTags_Call) Return(tags []models.Tag, totalCount int, err error) *MockService_ListTags_Call {
-	_c.Call.Return(tags, totalCount, err)
+func (_c *MockService_ListSCIMGroups_Call) Return(listResponse *scim.ListResponse, err error) *MockService_ListSCIMGroups_Call {
+	_c.Call.Return(listResponse, err)
 	return _c
 }
 
-func (_c *MockService_ListTags_Call) RunAndReturn(run func(ctx context.Context, req *requests.ListTags) ([]models.Tag, int, error)) *MockService_ListTags_Call {
+func (_c *MockService_ListSCIMGroups_Call) RunAndReturn(run func(ctx context.Context, req *scim.ListRequest) (*scim.ListResponse, error)) *MockService_ListSCIMGroups_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LookupDevice provides a mock function for the type MockService
-func (_mock *MockService) LookupDevice(ctx context.Context, namespace string, name string) (*models.Device, error) {
-	ret := _mock.Called(ctx, namespace, name)
+// ListSCIMUsers provides a mock function for the type MockService
+func (_mock *MockService) ListSCIMUsers(ctx context.Context, req *scim.ListRequest) (*scim.ListResponse, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LookupDevice")
+		panic("no return value specified for ListSCIMUsers")
 	}
 
-	var r0 *models.Device
+	var r0 *scim.ListResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.Device, error)); ok {
-		return returnFunc(ctx, namespace, name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *scim.ListRequest) (*scim.ListResponse, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
-		r0 = returnFunc(ctx, namespace, name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *scim.ListRequest) *scim.ListResponse); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).(*scim.ListResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, namespace, name)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *scim.ListRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_LookupDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupDevice'
-type MockService_LookupDevice_Call struct {
+// MockService_ListSCIMUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSCIMUsers'
+type MockService_ListSCIMUsers_Call struct {
 	*mock.Call
 }
 
-// LookupDevice is a helper method to define mock.On call
+// ListSCIMUsers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - namespace string
-//   - name string
-func (_e *MockService_Expecter) LookupDevice(ctx any, namespace any, name any) *MockService_LookupDevice_Call {
-	return &MockService_LookupDevice_Call{Call: _e.mock.On("LookupDevice", ctx, namespace, name)}
+//   - req *scim.ListRequest
+func (_e *MockService_Expecter) ListSCIMUsers(ctx any, req any) *MockService_ListSCIMUsers_Call {
+	return &MockService_ListSCIMUsers_Call{Call: _e.mock.On("ListSCIMUsers", ctx, req)}
 }
 
-func (_c *MockService_LookupDevice_Call) Run(run func(ctx context.Context, namespace string, name string)) *MockService_LookupDevice_Call {
+func (_c *MockService_ListSCIMUsers_Call) Run(run func(ctx context.Context, req *scim.ListRequest)) *MockService_ListSCIMUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *scim.ListRequest
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*scim.ListRequest)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_LookupDevice_Call) Return(device *models.Device, err error) *MockService_LookupDevice_Call {
-	_c.Call.Return(device, err)
+func (_c *MockService_ListSCIMUsers_Call) Return(listResponse *scim.ListResponse, err error) *MockService_ListSCIMUsers_Call {
+	_c.Call.Return(listResponse, err)
 	return _c
 }
 
-func (_c *MockService_LookupDevice_Call) RunAndReturn(run func(ctx context.Context, namespace string, name string) (*models.Device, error)) *MockService_LookupDevice_Call {
+func (_c *MockService_ListSCIMUsers_Call) RunAndReturn(run func(ctx context.Context, req *scim.ListRequest) (*scim.ListResponse, error)) *MockService_ListSCIMUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceHasAccessPolicies provides a mock function for the type MockService
-func (_mock *MockService) NamespaceHasAccessPolicies(ctx context.Context, tenantID string) (bool, error) {
-	ret := _mock.Called(ctx, tenantID)
+// ListSSHIdentities provides a mock function for the type MockService
+func (_mock *MockService) ListSSHIdentities(ctx context.Context, req *requests.SSHIdentityList) ([]models.SSHIdentity, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceHasAccessPolicies")
+		panic("no return value specified for ListSSHIdentities")
 	}
 
-	var r0 bool
+	var r0 []models.SSHIdentity
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-		return returnFunc(ctx, tenantID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityList) ([]models.SSHIdentity, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = returnFunc(ctx, tenantID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityList) []models.SSHIdentity); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SSHIdentity)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, tenantID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHIdentityList) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_NamespaceHasAccessPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceHasAccessPolicies'
-type MockService_NamespaceHasAccessPolicies_Call struct {
+// MockService_ListSSHIdentities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSSHIdentities'
+type MockService_ListSSHIdentities_Call struct {
 	*mock.Call
 }
 
-// NamespaceHasAccessPolicies is a helper method to define mock.On call
+// ListSSHIdentities is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantID string
-func (_e *MockService_Expecter) NamespaceHasAccessPolicies(ctx any, tenantID any) *MockService_NamespaceHasAccessPolicies_Call {
-	return &MockService_NamespaceHasAccessPolicies_Call{Call: _e.mock.On("NamespaceHasAccessPolicies", ctx, tenantID)}
+//   - req *requests.SSHIdentityList
+func (_e *MockService_Expecter) ListSSHIdentities(ctx any, req any) *MockService_ListSSHIdentities_Call {
+	return &MockService_ListSSHIdentities_Call{Call: _e.mock.On("ListSSHIdentities", ctx, req)}
 }
 
-func (_c *MockService_NamespaceHasAccessPolicies_Call) Run(run func(ctx context.Context, tenantID string)) *MockService_NamespaceHasAccessPolicies_Call {
+func (_c *MockService_ListSSHIdentities_Call) Run(run func(ctx context.Context, req *requests.SSHIdentityList)) *MockService_ListSSHIdentities_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *requests.SSHIdentityList
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*requests.SSHIdentityList)
 		}
 		run(
 			arg0,
@@ -5093,71 +6631,65 @@ func (_c *MockService_NamespaceHasAccessPolicies_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *MockService_NamespaceHasAccessPolicies_Call) Return(b bool, err error) *MockService_NamespaceHasAccessPolicies_Call {
-	_c.Call.Return(b, err)
+func (_c *MockService_ListSSHIdentities_Call) Return(sSHIdentitys []models.SSHIdentity, err error) *MockService_ListSSHIdentities_Call {
+	_c.Call.Return(sSHIdentitys, err)
 	return _c
 }
 
-func (_c *MockService_NamespaceHasAccessPolicies_Call) RunAndReturn(run func(ctx context.Context, tenantID string) (bool, error)) *MockService_NamespaceHasAccessPolicies_Call {
+func (_c *MockService_ListSSHIdentities_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHIdentityList) ([]models.SSHIdentity, error)) *MockService_ListSSHIdentities_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceMembershipInvitationList provides a mock function for the type MockService
-func (_mock *MockService) NamespaceMembershipInvitationList(ctx context.Context, req *requests.NamespaceMembershipInvitationList) ([]responses0.MembershipInvitation, int64, error) {
+// ListSSHUserCAs provides a mock function for the type MockService
+func (_mock *MockService) ListSSHUserCAs(ctx context.Context, req *requests.SSHUserCAList) ([]models.SSHUserCA, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceMembershipInvitationList")
+		panic("no return value specified for ListSSHUserCAs")
 	}
 
-	var r0 []responses0.MembershipInvitation
-	var r1 int64
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.NamespaceMembershipInvitationList) ([]responses0.MembershipInvitation, int64, error)); ok {
+	var r0 []models.SSHUserCA
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHUserCAList) ([]models.SSHUserCA, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.NamespaceMembershipInvitationList) []responses0.MembershipInvitation); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHUserCAList) []models.SSHUserCA); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]responses0.MembershipInvitation)
+			r0 = ret.Get(0).([]models.SSHUserCA)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.NamespaceMembershipInvitationList) int64); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHUserCAList) error); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
-		r1 = ret.Get(1).(int64)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.NamespaceMembershipInvitationList) error); ok {
-		r2 = returnFunc(ctx, req)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockService_NamespaceMembershipInvitationList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceMembershipInvitationList'
-type MockService_NamespaceMembershipInvitationList_Call struct {
+// MockService_ListSSHUserCAs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSSHUserCAs'
+type MockService_ListSSHUserCAs_Call struct {
 	*mock.Call
 }
 
-// NamespaceMembershipInvitationList is a helper method to define mock.On call
+// ListSSHUserCAs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.NamespaceMembershipInvitationList
-func (_e *MockService_Expecter) NamespaceMembershipInvitationList(ctx any, req any) *MockService_NamespaceMembershipInvitationList_Call {
-	return &MockService_NamespaceMembershipInvitationList_Call{Call: _e.mock.On("NamespaceMembershipInvitationList", ctx, req)}
+//   - req *requests.SSHUserCAList
+func (_e *MockService_Expecter) ListSSHUserCAs(ctx any, req any) *MockService_ListSSHUserCAs_Call {
+	return &MockService_ListSSHUserCAs_Call{Call: _e.mock.On("ListSSHUserCAs", ctx, req)}
 }
 
-func (_c *MockService_NamespaceMembershipInvitationList_Call) Run(run func(ctx context.Context, req *requests.NamespaceMembershipInvitationList)) *MockService_NamespaceMembershipInvitationList_Call {
+func (_c *MockService_ListSSHUserCAs_Call) Run(run func(ctx context.Context, req *requests.SSHUserCAList)) *MockService_ListSSHUserCAs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.NamespaceMembershipInvitationList
+		var arg1 *requests.SSHUserCAList
 		if args[1] != nil {
-			arg1 = args[1].(*requests.NamespaceMembershipInvitationList)
+			arg1 = args[1].(*requests.SSHUserCAList)
 		}
 		run(
 			arg0,
@@ -5167,54 +6699,65 @@ func (_c *MockService_NamespaceMembershipInvitationList_Call) Run(run func(ctx c
 	return _c
 }
 
-func (_c *MockService_NamespaceMembershipInvitationList_Call) Return(membershipInvitations []responses0.MembershipInvitation, n int64, err error) *MockService_NamespaceMembershipInvitationList_Call {
-	_c.Call.Return(membershipInvitations, n, err)
+func (_c *MockService_ListSSHUserCAs_Call) Return(sSHUserCAs []models.SSHUserCA, err error) *MockService_ListSSHUserCAs_Call {
+	_c.Call.Return(sSHUserCAs, err)
 	return _c
 }
 
-func (_c *MockService_NamespaceMembershipInvitationList_Call) RunAndReturn(run func(ctx context.Context, req *requests.NamespaceMembershipInvitationList) ([]responses0.MembershipInvitation, int64, error)) *MockService_NamespaceMembershipInvitationList_Call {
+func (_c *MockService_ListSSHUserCAs_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHUserCAList) ([]models.SSHUserCA, error)) *MockService_ListSSHUserCAs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// OfflineDevice provides a mock function for the type MockService
-func (_mock *MockService) OfflineDevice(ctx context.Context, uid models.UID) error {
-	ret := _mock.Called(ctx, uid)
+// ListServiceAccounts provides a mock function for the type MockService
+func (_mock *MockService) ListServiceAccounts(ctx context.Context, req *requests.ServiceAccountList) ([]models.ServiceAccount, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for OfflineDevice")
+		panic("no return value specified for ListServiceAccounts")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = returnFunc(ctx, uid)
+	var r0 []models.ServiceAccount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ServiceAccountList) ([]models.ServiceAccount, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ServiceAccountList) []models.ServiceAccount); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ServiceAccount)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ServiceAccountList) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_OfflineDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OfflineDevice'
-type MockService_OfflineDevice_Call struct {
+// MockService_ListServiceAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServiceAccounts'
+type MockService_ListServiceAccounts_Call struct {
 	*mock.Call
 }
 
-// OfflineDevice is a helper method to define mock.On call
+// ListServiceAccounts is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid models.UID
-func (_e *MockService_Expecter) OfflineDevice(ctx any, uid any) *MockService_OfflineDevice_Call {
-	return &MockService_OfflineDevice_Call{Call: _e.mock.On("OfflineDevice", ctx, uid)}
+//   - req *requests.ServiceAccountList
+func (_e *MockService_Expecter) ListServiceAccounts(ctx any, req any) *MockService_ListServiceAccounts_Call {
+	return &MockService_ListServiceAccounts_Call{Call: _e.mock.On("ListServiceAccounts", ctx, req)}
 }
 
-func (_c *MockService_OfflineDevice_Call) Run(run func(ctx context.Context, uid models.UID)) *MockService_OfflineDevice_Call {
+func (_c *MockService_ListServiceAccounts_Call) Run(run func(ctx context.Context, req *requests.ServiceAccountList)) *MockService_ListServiceAccounts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.UID
+		var arg1 *requests.ServiceAccountList
 		if args[1] != nil {
-			arg1 = args[1].(models.UID)
+			arg1 = args[1].(*requests.ServiceAccountList)
 		}
 		run(
 			arg0,
@@ -5224,70 +6767,76 @@ func (_c *MockService_OfflineDevice_Call) Run(run func(ctx context.Context, uid
 	return _c
 }
 
-func (_c *MockService_OfflineDevice_Call) Return(err error) *MockService_OfflineDevice_Call {
-	_c.Call.Return(err)
+func (_c *MockService_ListServiceAccounts_Call) Return(serviceAccounts []models.ServiceAccount, err error) *MockService_ListServiceAccounts_Call {
+	_c.Call.Return(serviceAccounts, err)
 	return _c
 }
 
-func (_c *MockService_OfflineDevice_Call) RunAndReturn(run func(ctx context.Context, uid models.UID) error) *MockService_OfflineDevice_Call {
+func (_c *MockService_ListServiceAccounts_Call) RunAndReturn(run func(ctx context.Context, req *requests.ServiceAccountList) ([]models.ServiceAccount, error)) *MockService_ListServiceAccounts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PrepareDevicePairing provides a mock function for the type MockService
-func (_mock *MockService) PrepareDevicePairing(ctx context.Context, userID string, tenantID string) (*models.DevicePairing, error) {
-	ret := _mock.Called(ctx, userID, tenantID)
+// ListSessionCommands provides a mock function for the type MockService
+func (_mock *MockService) ListSessionCommands(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsList) ([]models.SessionCommand, int, error) {
+	ret := _mock.Called(ctx, sc, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PrepareDevicePairing")
+		panic("no return value specified for ListSessionCommands")
 	}
 
-	var r0 *models.DevicePairing
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.DevicePairing, error)); ok {
-		return returnFunc(ctx, userID, tenantID)
+	var r0 []models.SessionCommand
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.SessionCommandsList) ([]models.SessionCommand, int, error)); ok {
+		return returnFunc(ctx, sc, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.DevicePairing); ok {
-		r0 = returnFunc(ctx, userID, tenantID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.SessionCommandsList) []models.SessionCommand); ok {
+		r0 = returnFunc(ctx, sc, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.DevicePairing)
+			r0 = ret.Get(0).([]models.SessionCommand)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, userID, tenantID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *requests.SessionCommandsList) int); ok {
+		r1 = returnFunc(ctx, sc, req)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *requests.SessionCommandsList) error); ok {
+		r2 = returnFunc(ctx, sc, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockService_PrepareDevicePairing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrepareDevicePairing'
-type MockService_PrepareDevicePairing_Call struct {
+// MockService_ListSessionCommands_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessionCommands'
+type MockService_ListSessionCommands_Call struct {
 	*mock.Call
 }
 
-// PrepareDevicePairing is a helper method to define mock.On call
+// ListSessionCommands is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - tenantID string
-func (_e *MockService_Expecter) PrepareDevicePairing(ctx any, userID any, tenantID any) *MockService_PrepareDevicePairing_Call {
-	return &MockService_PrepareDevicePairing_Call{Call: _e.mock.On("PrepareDevicePairing", ctx, userID, tenantID)}
+//   - sc scope.Scope
+//   - req *requests.SessionCommandsList
+func (_e *MockService_Expecter) ListSessionCommands(ctx any, sc any, req any) *MockService_ListSessionCommands_Call {
+	return &MockService_ListSessionCommands_Call{Call: _e.mock.On("ListSessionCommands", ctx, sc, req)}
 }
 
-func (_c *MockService_PrepareDevicePairing_Call) Run(run func(ctx context.Context, userID string, tenantID string)) *MockService_PrepareDevicePairing_Call {
+func (_c *MockService_ListSessionCommands_Call) Run(run func(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsList)) *MockService_ListSessionCommands_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 string
+		var arg2 *requests.SessionCommandsList
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*requests.SessionCommandsList)
 		}
 		run(
 			arg0,
@@ -5298,19 +6847,814 @@ func (_c *MockService_PrepareDevicePairing_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockService_PrepareDevicePairing_Call) Return(devicePairing *models.DevicePairing, err error) *MockService_PrepareDevicePairing_Call {
-	_c.Call.Return(devicePairing, err)
+func (_c *MockService_ListSessionCommands_Call) Return(sessionCommands []models.SessionCommand, n int, err error) *MockService_ListSessionCommands_Call {
+	_c.Call.Return(sessionCommands, n, err)
 	return _c
 }
 
-func (_c *MockService_PrepareDevicePairing_Call) RunAndReturn(run func(ctx context.Context, userID string, tenantID string) (*models.DevicePairing, error)) *MockService_PrepareDevicePairing_Call {
+func (_c *MockService_ListSessionCommands_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsList) ([]models.SessionCommand, int, error)) *MockService_ListSessionCommands_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PublicKey provides a mock function for the type MockService
-func (_mock *MockService) PublicKey() *rsa.PublicKey {
-	ret := _mock.Called()
+// ListSessions provides a mock function for the type MockService
+func (_mock *MockService) ListSessions(ctx context.Context, sc scope.Scope, req *requests.ListSessions) ([]models.Session, int, error) {
+	ret := _mock.Called(ctx, sc, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []models.Session
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.ListSessions) ([]models.Session, int, error)); ok {
+		return returnFunc(ctx, sc, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.ListSessions) []models.Session); ok {
+		r0 = returnFunc(ctx, sc, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *requests.ListSessions) int); ok {
+		r1 = returnFunc(ctx, sc, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *requests.ListSessions) error); ok {
+		r2 = returnFunc(ctx, sc, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockService_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type MockService_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - req *requests.ListSessions
+func (_e *MockService_Expecter) ListSessions(ctx any, sc any, req any) *MockService_ListSessions_Call {
+	return &MockService_ListSessions_Call{Call: _e.mock.On("ListSessions", ctx, sc, req)}
+}
+
+func (_c *MockService_ListSessions_Call) Run(run func(ctx context.Context, sc scope.Scope, req *requests.ListSessions)) *MockService_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 *requests.ListSessions
+		if args[2] != nil {
+			arg2 = args[2].(*requests.ListSessions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListSessions_Call) Return(sessions []models.Session, n int, err error) *MockService_ListSessions_Call {
+	_c.Call.Return(sessions, n, err)
+	return _c
+}
+
+func (_c *MockService_ListSessions_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, req *requests.ListSessions) ([]models.Session, int, error)) *MockService_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function for the type MockService
+func (_mock *MockService) ListTags(ctx context.Context, req *requests.ListTags) ([]models.Tag, int, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 []models.Tag
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListTags) ([]models.Tag, int, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.ListTags) []models.Tag); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.ListTags) int); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.ListTags) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockService_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type MockService_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.ListTags
+func (_e *MockService_Expecter) ListTags(ctx any, req any) *MockService_ListTags_Call {
+	return &MockService_ListTags_Call{Call: _e.mock.On("ListTags", ctx, req)}
+}
+
+func (_c *MockService_ListTags_Call) Run(run func(ctx context.Context, req *requests.ListTags)) *MockService_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.ListTags
+		if args[1] != nil {
+			arg1 = args[1].(*requests.ListTags)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListTags_Call) Return(tags []models.Tag, totalCount int, err error) *MockService_ListTags_Call {
+	_c.Call.Return(tags, totalCount, err)
+	return _c
+}
+
+func (_c *MockService_ListTags_Call) RunAndReturn(run func(ctx context.Context, req *requests.ListTags) ([]models.Tag, int, error)) *MockService_ListTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function for the type MockService
+func (_mock *MockService) ListWebhookDeliveries(ctx context.Context, req *requests.WebhookDeliveryList) ([]models.WebhookDelivery, int, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []models.WebhookDelivery
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookDeliveryList) ([]models.WebhookDelivery, int, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookDeliveryList) []models.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.WebhookDeliveryList) int); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.WebhookDeliveryList) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockService_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockService_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.WebhookDeliveryList
+func (_e *MockService_Expecter) ListWebhookDeliveries(ctx any, req any) *MockService_ListWebhookDeliveries_Call {
+	return &MockService_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, req)}
+}
+
+func (_c *MockService_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, req *requests.WebhookDeliveryList)) *MockService_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.WebhookDeliveryList
+		if args[1] != nil {
+			arg1 = args[1].(*requests.WebhookDeliveryList)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListWebhookDeliveries_Call) Return(webhookDeliverys []models.WebhookDelivery, n int, err error) *MockService_ListWebhookDeliveries_Call {
+	_c.Call.Return(webhookDeliverys, n, err)
+	return _c
+}
+
+func (_c *MockService_ListWebhookDeliveries_Call) RunAndReturn(run func(ctx context.Context, req *requests.WebhookDeliveryList) ([]models.WebhookDelivery, int, error)) *MockService_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhooks provides a mock function for the type MockService
+func (_mock *MockService) ListWebhooks(ctx context.Context, req *requests.WebhookList) ([]models.Webhook, int, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 []models.Webhook
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookList) ([]models.Webhook, int, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookList) []models.Webhook); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.WebhookList) int); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.WebhookList) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockService_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type MockService_ListWebhooks_Call struct {
+	*mock.Call
+}
+
+// ListWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.WebhookList
+func (_e *MockService_Expecter) ListWebhooks(ctx any, req any) *MockService_ListWebhooks_Call {
+	return &MockService_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", ctx, req)}
+}
+
+func (_c *MockService_ListWebhooks_Call) Run(run func(ctx context.Context, req *requests.WebhookList)) *MockService_ListWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.WebhookList
+		if args[1] != nil {
+			arg1 = args[1].(*requests.WebhookList)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListWebhooks_Call) Return(webhooks []models.Webhook, n int, err error) *MockService_ListWebhooks_Call {
+	_c.Call.Return(webhooks, n, err)
+	return _c
+}
+
+func (_c *MockService_ListWebhooks_Call) RunAndReturn(run func(ctx context.Context, req *requests.WebhookList) ([]models.Webhook, int, error)) *MockService_ListWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupDevice provides a mock function for the type MockService
+func (_mock *MockService) LookupDevice(ctx context.Context, namespace string, name string) (*models.Device, error) {
+	ret := _mock.Called(ctx, namespace, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupDevice")
+	}
+
+	var r0 *models.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.Device, error)); ok {
+		return returnFunc(ctx, namespace, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
+		r0 = returnFunc(ctx, namespace, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, namespace, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_LookupDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupDevice'
+type MockService_LookupDevice_Call struct {
+	*mock.Call
+}
+
+// LookupDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - name string
+func (_e *MockService_Expecter) LookupDevice(ctx any, namespace any, name any) *MockService_LookupDevice_Call {
+	return &MockService_LookupDevice_Call{Call: _e.mock.On("LookupDevice", ctx, namespace, name)}
+}
+
+func (_c *MockService_LookupDevice_Call) Run(run func(ctx context.Context, namespace string, name string)) *MockService_LookupDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_LookupDevice_Call) Return(device *models.Device, err error) *MockService_LookupDevice_Call {
+	_c.Call.Return(device, err)
+	return _c
+}
+
+func (_c *MockService_LookupDevice_Call) RunAndReturn(run func(ctx context.Context, namespace string, name string) (*models.Device, error)) *MockService_LookupDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NamespaceHasAccessPolicies provides a mock function for the type MockService
+func (_mock *MockService) NamespaceHasAccessPolicies(ctx context.Context, tenantID string) (bool, error) {
+	ret := _mock.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NamespaceHasAccessPolicies")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, tenantID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, tenantID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_NamespaceHasAccessPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceHasAccessPolicies'
+type MockService_NamespaceHasAccessPolicies_Call struct {
+	*mock.Call
+}
+
+// NamespaceHasAccessPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockService_Expecter) NamespaceHasAccessPolicies(ctx any, tenantID any) *MockService_NamespaceHasAccessPolicies_Call {
+	return &MockService_NamespaceHasAccessPolicies_Call{Call: _e.mock.On("NamespaceHasAccessPolicies", ctx, tenantID)}
+}
+
+func (_c *MockService_NamespaceHasAccessPolicies_Call) Run(run func(ctx context.Context, tenantID string)) *MockService_NamespaceHasAccessPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_NamespaceHasAccessPolicies_Call) Return(b bool, err error) *MockService_NamespaceHasAccessPolicies_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockService_NamespaceHasAccessPolicies_Call) RunAndReturn(run func(ctx context.Context, tenantID string) (bool, error)) *MockService_NamespaceHasAccessPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NamespaceMembershipInvitationList provides a mock function for the type MockService
+func (_mock *MockService) NamespaceMembershipInvitationList(ctx context.Context, req *requests.NamespaceMembershipInvitationList) ([]responses0.MembershipInvitation, int64, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NamespaceMembershipInvitationList")
+	}
+
+	var r0 []responses0.MembershipInvitation
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.NamespaceMembershipInvitationList) ([]responses0.MembershipInvitation, int64, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.NamespaceMembershipInvitationList) []responses0.MembershipInvitation); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]responses0.MembershipInvitation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.NamespaceMembershipInvitationList) int64); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *requests.NamespaceMembershipInvitationList) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockService_NamespaceMembershipInvitationList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceMembershipInvitationList'
+type MockService_NamespaceMembershipInvitationList_Call struct {
+	*mock.Call
+}
+
+// NamespaceMembershipInvitationList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.NamespaceMembershipInvitationList
+func (_e *MockService_Expecter) NamespaceMembershipInvitationList(ctx any, req any) *MockService_NamespaceMembershipInvitationList_Call {
+	return &MockService_NamespaceMembershipInvitationList_Call{Call: _e.mock.On("NamespaceMembershipInvitationList", ctx, req)}
+}
+
+func (_c *MockService_NamespaceMembershipInvitationList_Call) Run(run func(ctx context.Context, req *requests.NamespaceMembershipInvitationList)) *MockService_NamespaceMembershipInvitationList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.NamespaceMembershipInvitationList
+		if args[1] != nil {
+			arg1 = args[1].(*requests.NamespaceMembershipInvitationList)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_NamespaceMembershipInvitationList_Call) Return(membershipInvitations []responses0.MembershipInvitation, n int64, err error) *MockService_NamespaceMembershipInvitationList_Call {
+	_c.Call.Return(membershipInvitations, n, err)
+	return _c
+}
+
+func (_c *MockService_NamespaceMembershipInvitationList_Call) RunAndReturn(run func(ctx context.Context, req *requests.NamespaceMembershipInvitationList) ([]responses0.MembershipInvitation, int64, error)) *MockService_NamespaceMembershipInvitationList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OfflineDevice provides a mock function for the type MockService
+func (_mock *MockService) OfflineDevice(ctx context.Context, uid models.UID) error {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OfflineDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_OfflineDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OfflineDevice'
+type MockService_OfflineDevice_Call struct {
+	*mock.Call
+}
+
+// OfflineDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid models.UID
+func (_e *MockService_Expecter) OfflineDevice(ctx any, uid any) *MockService_OfflineDevice_Call {
+	return &MockService_OfflineDevice_Call{Call: _e.mock.On("OfflineDevice", ctx, uid)}
+}
+
+func (_c *MockService_OfflineDevice_Call) Run(run func(ctx context.Context, uid models.UID)) *MockService_OfflineDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.UID
+		if args[1] != nil {
+			arg1 = args[1].(models.UID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_OfflineDevice_Call) Return(err error) *MockService_OfflineDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_OfflineDevice_Call) RunAndReturn(run func(ctx context.Context, uid models.UID) error) *MockService_OfflineDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchSCIMGroup provides a mock function for the type MockService
+func (_mock *MockService) PatchSCIMGroup(ctx context.Context, id string, req *scim.PatchRequest) (*scim.Group, error) {
+	ret := _mock.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchSCIMGroup")
+	}
+
+	var r0 *scim.Group
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *scim.PatchRequest) (*scim.Group, error)); ok {
+		return returnFunc(ctx, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *scim.PatchRequest) *scim.Group); ok {
+		r0 = returnFunc(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scim.Group)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *scim.PatchRequest) error); ok {
+		r1 = returnFunc(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_PatchSCIMGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchSCIMGroup'
+type MockService_PatchSCIMGroup_Call struct {
+	*mock.Call
+}
+
+// PatchSCIMGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - req *scim.PatchRequest
+func (_e *MockService_Expecter) PatchSCIMGroup(ctx any, id any, req any) *MockService_PatchSCIMGroup_Call {
+	return &MockService_PatchSCIMGroup_Call{Call: _e.mock.On("PatchSCIMGroup", ctx, id, req)}
+}
+
+func (_c *MockService_PatchSCIMGroup_Call) Run(run func(ctx context.Context, id string, req *scim.PatchRequest)) *MockService_PatchSCIMGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *scim.PatchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*scim.PatchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_PatchSCIMGroup_Call) Return(group *scim.Group, err error) *MockService_PatchSCIMGroup_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *MockService_PatchSCIMGroup_Call) RunAndReturn(run func(ctx context.Context, id string, req *scim.PatchRequest) (*scim.Group, error)) *MockService_PatchSCIMGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchSCIMUser provides a mock function for the type MockService
+func (_mock *MockService) PatchSCIMUser(ctx context.Context, id string, req *scim.PatchRequest) (*scim.User, error) {
+	ret := _mock.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchSCIMUser")
+	}
+
+	var r0 *scim.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *scim.PatchRequest) (*scim.User, error)); ok {
+		return returnFunc(ctx, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *scim.PatchRequest) *scim.User); ok {
+		r0 = returnFunc(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scim.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *scim.PatchRequest) error); ok {
+		r1 = returnFunc(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_PatchSCIMUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchSCIMUser'
+type MockService_PatchSCIMUser_Call struct {
+	*mock.Call
+}
+
+// PatchSCIMUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - req *scim.PatchRequest
+func (_e *MockService_Expecter) PatchSCIMUser(ctx any, id any, req any) *MockService_PatchSCIMUser_Call {
+	return &MockService_PatchSCIMUser_Call{Call: _e.mock.On("PatchSCIMUser", ctx, id, req)}
+}
+
+func (_c *MockService_PatchSCIMUser_Call) Run(run func(ctx context.Context, id string, req *scim.PatchRequest)) *MockService_PatchSCIMUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *scim.PatchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*scim.PatchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_PatchSCIMUser_Call) Return(user *scim.User, err error) *MockService_PatchSCIMUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockService_PatchSCIMUser_Call) RunAndReturn(run func(ctx context.Context, id string, req *scim.PatchRequest) (*scim.User, error)) *MockService_PatchSCIMUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrepareDevicePairing provides a mock function for the type MockService
+func (_mock *MockService) PrepareDevicePairing(ctx context.Context, userID string, tenantID string) (*models.DevicePairing, error) {
+	ret := _mock.Called(ctx, userID, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrepareDevicePairing")
+	}
+
+	var r0 *models.DevicePairing
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.DevicePairing, error)); ok {
+		return returnFunc(ctx, userID, tenantID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.DevicePairing); ok {
+		r0 = returnFunc(ctx, userID, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DevicePairing)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_PrepareDevicePairing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrepareDevicePairing'
+type MockService_PrepareDevicePairing_Call struct {
+	*mock.Call
+}
+
+// PrepareDevicePairing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tenantID string
+func (_e *MockService_Expecter) PrepareDevicePairing(ctx any, userID any, tenantID any) *MockService_PrepareDevicePairing_Call {
+	return &MockService_PrepareDevicePairing_Call{Call: _e.mock.On("PrepareDevicePairing", ctx, userID, tenantID)}
+}
+
+func (_c *MockService_PrepareDevicePairing_Call) Run(run func(ctx context.Context, userID string, tenantID string)) *MockService_PrepareDevicePairing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_PrepareDevicePairing_Call) Return(devicePairing *models.DevicePairing, err error) *MockService_PrepareDevicePairing_Call {
+	_c.Call.Return(devicePairing, err)
+	return _c
+}
+
+func (_c *MockService_PrepareDevicePairing_Call) RunAndReturn(run func(ctx context.Context, userID string, tenantID string) (*models.DevicePairing, error)) *MockService_PrepareDevicePairing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublicKey provides a mock function for the type MockService
+func (_mock *MockService) PublicKey() *rsa.PublicKey {
+	ret := _mock.Called()
 
 	if len(ret) == 0 {
 		panic("no return value specified for PublicKey")
@@ -5434,49 +7778,95 @@ func (_mock *MockService) PushTagTo(ctx context.Context, target store.TagTarget,
 	return r0
 }
 
-// MockService_PushTagTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushTagTo'
-type MockService_PushTagTo_Call struct {
+// MockService_PushTagTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushTagTo'
+type MockService_PushTagTo_Call struct {
+	*mock.Call
+}
+
+// PushTagTo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target store.TagTarget
+//   - req *requests.PushTag
+func (_e *MockService_Expecter) PushTagTo(ctx any, target any, req any) *MockService_PushTagTo_Call {
+	return &MockService_PushTagTo_Call{Call: _e.mock.On("PushTagTo", ctx, target, req)}
+}
+
+func (_c *MockService_PushTagTo_Call) Run(run func(ctx context.Context, target store.TagTarget, req *requests.PushTag)) *MockService_PushTagTo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 store.TagTarget
+		if args[1] != nil {
+			arg1 = args[1].(store.TagTarget)
+		}
+		var arg2 *requests.PushTag
+		if args[2] != nil {
+			arg2 = args[2].(*requests.PushTag)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_PushTagTo_Call) Return(err error) *MockService_PushTagTo_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_PushTagTo_Call) RunAndReturn(run func(ctx context.Context, target store.TagTarget, req *requests.PushTag) error) *MockService_PushTagTo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordAPIKeyUsage provides a mock function for the type MockService
+func (_mock *MockService) RecordAPIKeyUsage(ctx context.Context, apiKey *models.APIKey) {
+	_mock.Called(ctx, apiKey)
+	return
+}
+
+// MockService_RecordAPIKeyUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAPIKeyUsage'
+type MockService_RecordAPIKeyUsage_Call struct {
 	*mock.Call
 }
 
-// PushTagTo is a helper method to define mock.On call
+// RecordAPIKeyUsage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - target store.TagTarget
-//   - req *requests.PushTag
-func (_e *MockService_Expecter) PushTagTo(ctx any, target any, req any) *MockService_PushTagTo_Call {
-	return &MockService_PushTagTo_Call{Call: _e.mock.On("PushTagTo", ctx, target, req)}
+//   - apiKey *models.APIKey
+func (_e *MockService_Expecter) RecordAPIKeyUsage(ctx any, apiKey any) *MockService_RecordAPIKeyUsage_Call {
+	return &MockService_RecordAPIKeyUsage_Call{Call: _e.mock.On("RecordAPIKeyUsage", ctx, apiKey)}
 }
 
-func (_c *MockService_PushTagTo_Call) Run(run func(ctx context.Context, target store.TagTarget, req *requests.PushTag)) *MockService_PushTagTo_Call {
+func (_c *MockService_RecordAPIKeyUsage_Call) Run(run func(ctx context.Context, apiKey *models.APIKey)) *MockService_RecordAPIKeyUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 store.TagTarget
+		var arg1 *models.APIKey
 		if args[1] != nil {
-			arg1 = args[1].(store.TagTarget)
-		}
-		var arg2 *requests.PushTag
-		if args[2] != nil {
-			arg2 = args[2].(*requests.PushTag)
+			arg1 = args[1].(*models.APIKey)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_PushTagTo_Call) Return(err error) *MockService_PushTagTo_Call {
-	_c.Call.Return(err)
+func (_c *MockService_RecordAPIKeyUsage_Call) Return() *MockService_RecordAPIKeyUsage_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockService_PushTagTo_Call) RunAndReturn(run func(ctx context.Context, target store.TagTarget, req *requests.PushTag) error) *MockService_PushTagTo_Call {
-	_c.Call.Return(run)
+func (_c *MockService_RecordAPIKeyUsage_Call) RunAndReturn(run func(ctx context.Context, apiKey *models.APIKey)) *MockService_RecordAPIKeyUsage_Call {
+	_c.Run(run)
 	return _c
 }
 
@@ -5560,6 +7950,74 @@ func (_c *MockService_RegisterUser_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// RejectAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) RejectAccessRequest(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectAccessRequest")
+	}
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestDecide) (*models.AccessRequest, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestDecide) *models.AccessRequest); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessRequestDecide) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_RejectAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectAccessRequest'
+type MockService_RejectAccessRequest_Call struct {
+	*mock.Call
+}
+
+// RejectAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.AccessRequestDecide
+func (_e *MockService_Expecter) RejectAccessRequest(ctx any, req any) *MockService_RejectAccessRequest_Call {
+	return &MockService_RejectAccessRequest_Call{Call: _e.mock.On("RejectAccessRequest", ctx, req)}
+}
+
+func (_c *MockService_RejectAccessRequest_Call) Run(run func(ctx context.Context, req *requests.AccessRequestDecide)) *MockService_RejectAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.AccessRequestDecide
+		if args[1] != nil {
+			arg1 = args[1].(*requests.AccessRequestDecide)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RejectAccessRequest_Call) Return(accessRequest *models.AccessRequest, err error) *MockService_RejectAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockService_RejectAccessRequest_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error)) *MockService_RejectAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RejectSSHApproval provides a mock function for the type MockService
 func (_mock *MockService) RejectSSHApproval(ctx context.Context, userID string, req *requests.SSHApprovalReject) error {
 	ret := _mock.Called(ctx, userID, req)
@@ -5669,118 +8127,334 @@ func (_c *MockService_RemoveNamespaceMember_Call) Run(run func(ctx context.Conte
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.NamespaceRemoveMember
+		var arg1 *requests.NamespaceRemoveMember
+		if args[1] != nil {
+			arg1 = args[1].(*requests.NamespaceRemoveMember)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RemoveNamespaceMember_Call) Return(namespace *models.Namespace, err error) *MockService_RemoveNamespaceMember_Call {
+	_c.Call.Return(namespace, err)
+	return _c
+}
+
+func (_c *MockService_RemoveNamespaceMember_Call) RunAndReturn(run func(ctx context.Context, req *requests.NamespaceRemoveMember) (*models.Namespace, error)) *MockService_RemoveNamespaceMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameDevice provides a mock function for the type MockService
+func (_mock *MockService) RenameDevice(ctx context.Context, uid models.UID, name string, tenant string) error {
+	ret := _mock.Called(ctx, uid, name, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, name, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_RenameDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameDevice'
+type MockService_RenameDevice_Call struct {
+	*mock.Call
+}
+
+// RenameDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid models.UID
+//   - name string
+//   - tenant string
+func (_e *MockService_Expecter) RenameDevice(ctx any, uid any, name any, tenant any) *MockService_RenameDevice_Call {
+	return &MockService_RenameDevice_Call{Call: _e.mock.On("RenameDevice", ctx, uid, name, tenant)}
+}
+
+func (_c *MockService_RenameDevice_Call) Run(run func(ctx context.Context, uid models.UID, name string, tenant string)) *MockService_RenameDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.UID
+		if args[1] != nil {
+			arg1 = args[1].(models.UID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RenameDevice_Call) Return(err error) *MockService_RenameDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_RenameDevice_Call) RunAndReturn(run func(ctx context.Context, uid models.UID, name string, tenant string) error) *MockService_RenameDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameSSHIdentity provides a mock function for the type MockService
+func (_mock *MockService) RenameSSHIdentity(ctx context.Context, req *requests.SSHIdentityUpdate) (*models.SSHIdentity, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameSSHIdentity")
+	}
+
+	var r0 *models.SSHIdentity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityUpdate) (*models.SSHIdentity, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityUpdate) *models.SSHIdentity); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SSHIdentity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHIdentityUpdate) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_RenameSSHIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameSSHIdentity'
+type MockService_RenameSSHIdentity_Call struct {
+	*mock.Call
+}
+
+// RenameSSHIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.SSHIdentityUpdate
+func (_e *MockService_Expecter) RenameSSHIdentity(ctx any, req any) *MockService_RenameSSHIdentity_Call {
+	return &MockService_RenameSSHIdentity_Call{Call: _e.mock.On("RenameSSHIdentity", ctx, req)}
+}
+
+func (_c *MockService_RenameSSHIdentity_Call) Run(run func(ctx context.Context, req *requests.SSHIdentityUpdate)) *MockService_RenameSSHIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.SSHIdentityUpdate
+		if args[1] != nil {
+			arg1 = args[1].(*requests.SSHIdentityUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RenameSSHIdentity_Call) Return(sSHIdentity *models.SSHIdentity, err error) *MockService_RenameSSHIdentity_Call {
+	_c.Call.Return(sSHIdentity, err)
+	return _c
+}
+
+func (_c *MockService_RenameSSHIdentity_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHIdentityUpdate) (*models.SSHIdentity, error)) *MockService_RenameSSHIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceSCIMGroup provides a mock function for the type MockService
+func (_mock *MockService) ReplaceSCIMGroup(ctx context.Context, id string, group *scim.Group) (*scim.Group, error) {
+	ret := _mock.Called(ctx, id, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceSCIMGroup")
+	}
+
+	var r0 *scim.Group
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *scim.Group) (*scim.Group, error)); ok {
+		return returnFunc(ctx, id, group)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *scim.Group) *scim.Group); ok {
+		r0 = returnFunc(ctx, id, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scim.Group)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *scim.Group) error); ok {
+		r1 = returnFunc(ctx, id, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ReplaceSCIMGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSCIMGroup'
+type MockService_ReplaceSCIMGroup_Call struct {
+	*mock.Call
+}
+
+// ReplaceSCIMGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - group *scim.Group
+func (_e *MockService_Expecter) ReplaceSCIMGroup(ctx any, id any, group any) *MockService_ReplaceSCIMGroup_Call {
+	return &MockService_ReplaceSCIMGroup_Call{Call: _e.mock.On("ReplaceSCIMGroup", ctx, id, group)}
+}
+
+func (_c *MockService_ReplaceSCIMGroup_Call) Run(run func(ctx context.Context, id string, group *scim.Group)) *MockService_ReplaceSCIMGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*requests.NamespaceRemoveMember)
+			arg1 = args[1].(string)
+		}
+		var arg2 *scim.Group
+		if args[2] != nil {
+			arg2 = args[2].(*scim.Group)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_RemoveNamespaceMember_Call) Return(namespace *models.Namespace, err error) *MockService_RemoveNamespaceMember_Call {
-	_c.Call.Return(namespace, err)
+func (_c *MockService_ReplaceSCIMGroup_Call) Return(group1 *scim.Group, err error) *MockService_ReplaceSCIMGroup_Call {
+	_c.Call.Return(group1, err)
 	return _c
 }
 
-func (_c *MockService_RemoveNamespaceMember_Call) RunAndReturn(run func(ctx context.Context, req *requests.NamespaceRemoveMember) (*models.Namespace, error)) *MockService_RemoveNamespaceMember_Call {
+func (_c *MockService_ReplaceSCIMGroup_Call) RunAndReturn(run func(ctx context.Context, id string, group *scim.Group) (*scim.Group, error)) *MockService_ReplaceSCIMGroup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RenameDevice provides a mock function for the type MockService
-func (_mock *MockService) RenameDevice(ctx context.Context, uid models.UID, name string, tenant string) error {
-	ret := _mock.Called(ctx, uid, name, tenant)
+// ReplaceSCIMUser provides a mock function for the type MockService
+func (_mock *MockService) ReplaceSCIMUser(ctx context.Context, id string, user *scim.User) (*scim.User, error) {
+	ret := _mock.Called(ctx, id, user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RenameDevice")
+		panic("no return value specified for ReplaceSCIMUser")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, name, tenant)
+	var r0 *scim.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *scim.User) (*scim.User, error)); ok {
+		return returnFunc(ctx, id, user)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *scim.User) *scim.User); ok {
+		r0 = returnFunc(ctx, id, user)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scim.User)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *scim.User) error); ok {
+		r1 = returnFunc(ctx, id, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_RenameDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameDevice'
-type MockService_RenameDevice_Call struct {
+// MockService_ReplaceSCIMUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSCIMUser'
+type MockService_ReplaceSCIMUser_Call struct {
 	*mock.Call
 }
 
-// RenameDevice is a helper method to define mock.On call
+// ReplaceSCIMUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid models.UID
-//   - name string
-//   - tenant string
-func (_e *MockService_Expecter) RenameDevice(ctx any, uid any, name any, tenant any) *MockService_RenameDevice_Call {
-	return &MockService_RenameDevice_Call{Call: _e.mock.On("RenameDevice", ctx, uid, name, tenant)}
+//   - id string
+//   - user *scim.User
+func (_e *MockService_Expecter) ReplaceSCIMUser(ctx any, id any, user any) *MockService_ReplaceSCIMUser_Call {
+	return &MockService_ReplaceSCIMUser_Call{Call: _e.mock.On("ReplaceSCIMUser", ctx, id, user)}
 }
 
-func (_c *MockService_RenameDevice_Call) Run(run func(ctx context.Context, uid models.UID, name string, tenant string)) *MockService_RenameDevice_Call {
+func (_c *MockService_ReplaceSCIMUser_Call) Run(run func(ctx context.Context, id string, user *scim.User)) *MockService_ReplaceSCIMUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.UID
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(models.UID)
+			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *scim.User
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].(*scim.User)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_RenameDevice_Call) Return(err error) *MockService_RenameDevice_Call {
-	_c.Call.Return(err)
+func (_c *MockService_ReplaceSCIMUser_Call) Return(user1 *scim.User, err error) *MockService_ReplaceSCIMUser_Call {
+	_c.Call.Return(user1, err)
 	return _c
 }
 
-func (_c *MockService_RenameDevice_Call) RunAndReturn(run func(ctx context.Context, uid models.UID, name string, tenant string) error) *MockService_RenameDevice_Call {
+func (_c *MockService_ReplaceSCIMUser_Call) RunAndReturn(run func(ctx context.Context, id string, user *scim.User) (*scim.User, error)) *MockService_ReplaceSCIMUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RenameSSHIdentity provides a mock function for the type MockService
-func (_mock *MockService) RenameSSHIdentity(ctx context.Context, req *requests.SSHIdentityUpdate) (*models.SSHIdentity, error) {
+// ReplayWebhookDelivery provides a mock function for the type MockService
+func (_mock *MockService) ReplayWebhookDelivery(ctx context.Context, req *requests.WebhookDeliveryReplay) (*models.WebhookDelivery, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RenameSSHIdentity")
+		panic("no return value specified for ReplayWebhookDelivery")
 	}
 
-	var r0 *models.SSHIdentity
+	var r0 *models.WebhookDelivery
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityUpdate) (*models.SSHIdentity, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookDeliveryReplay) (*models.WebhookDelivery, error)); ok {
 		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHIdentityUpdate) *models.SSHIdentity); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookDeliveryReplay) *models.WebhookDelivery); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.SSHIdentity)
+			r0 = ret.Get(0).(*models.WebhookDelivery)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHIdentityUpdate) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.WebhookDeliveryReplay) error); ok {
 		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -5788,27 +8462,27 @@ func (_mock *MockService) RenameSSHIdentity(ctx context.Context, req *requests.S
 	return r0, r1
 }
 
-// MockService_RenameSSHIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameSSHIdentity'
-type MockService_RenameSSHIdentity_Call struct {
+// MockService_ReplayWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayWebhookDelivery'
+type MockService_ReplayWebhookDelivery_Call struct {
 	*mock.Call
 }
 
-// RenameSSHIdentity is a helper method to define mock.On call
+// ReplayWebhookDelivery is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.SSHIdentityUpdate
-func (_e *MockService_Expecter) RenameSSHIdentity(ctx any, req any) *MockService_RenameSSHIdentity_Call {
-	return &MockService_RenameSSHIdentity_Call{Call: _e.mock.On("RenameSSHIdentity", ctx, req)}
+//   - req *requests.WebhookDeliveryReplay
+func (_e *MockService_Expecter) ReplayWebhookDelivery(ctx any, req any) *MockService_ReplayWebhookDelivery_Call {
+	return &MockService_ReplayWebhookDelivery_Call{Call: _e.mock.On("ReplayWebhookDelivery", ctx, req)}
 }
 
-func (_c *MockService_RenameSSHIdentity_Call) Run(run func(ctx context.Context, req *requests.SSHIdentityUpdate)) *MockService_RenameSSHIdentity_Call {
+func (_c *MockService_ReplayWebhookDelivery_Call) Run(run func(ctx context.Context, req *requests.WebhookDeliveryReplay)) *MockService_ReplayWebhookDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.SSHIdentityUpdate
+		var arg1 *requests.WebhookDeliveryReplay
 		if args[1] != nil {
-			arg1 = args[1].(*requests.SSHIdentityUpdate)
+			arg1 = args[1].(*requests.WebhookDeliveryReplay)
 		}
 		run(
 			arg0,
@@ -5818,12 +8492,12 @@ func (_c *MockService_RenameSSHIdentity_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockService_RenameSSHIdentity_Call) Return(sSHIdentity *models.SSHIdentity, err error) *MockService_RenameSSHIdentity_Call {
-	_c.Call.Return(sSHIdentity, err)
+func (_c *MockService_ReplayWebhookDelivery_Call) Return(webhookDelivery *models.WebhookDelivery, err error) *MockService_ReplayWebhookDelivery_Call {
+	_c.Call.Return(webhookDelivery, err)
 	return _c
 }
 
-func (_c *MockService_RenameSSHIdentity_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHIdentityUpdate) (*models.SSHIdentity, error)) *MockService_RenameSSHIdentity_Call {
+func (_c *MockService_ReplayWebhookDelivery_Call) RunAndReturn(run func(ctx context.Context, req *requests.WebhookDeliveryReplay) (*models.WebhookDelivery, error)) *MockService_ReplayWebhookDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6238,6 +8912,86 @@ func (_c *MockService_ResolveNamespaceRole_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// ResolveSSHCertificate provides a mock function for the type MockService
+func (_mock *MockService) ResolveSSHCertificate(ctx context.Context, tenantID string, cert *ssh.Certificate, sourceIP string) (*models.SSHCertificateIdentity, error) {
+	ret := _mock.Called(ctx, tenantID, cert, sourceIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveSSHCertificate")
+	}
+
+	var r0 *models.SSHCertificateIdentity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *ssh.Certificate, string) (*models.SSHCertificateIdentity, error)); ok {
+		return returnFunc(ctx, tenantID, cert, sourceIP)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *ssh.Certificate, string) *models.SSHCertificateIdentity); ok {
+		r0 = returnFunc(ctx, tenantID, cert, sourceIP)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SSHCertificateIdentity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *ssh.Certificate, string) error); ok {
+		r1 = returnFunc(ctx, tenantID, cert, sourceIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ResolveSSHCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveSSHCertificate'
+type MockService_ResolveSSHCertificate_Call struct {
+	*mock.Call
+}
+
+// ResolveSSHCertificate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - cert *ssh.Certificate
+//   - sourceIP string
+func (_e *MockService_Expecter) ResolveSSHCertificate(ctx any, tenantID any, cert any, sourceIP any) *MockService_ResolveSSHCertificate_Call {
+	return &MockService_ResolveSSHCertificate_Call{Call: _e.mock.On("ResolveSSHCertificate", ctx, tenantID, cert, sourceIP)}
+}
+
+func (_c *MockService_ResolveSSHCertificate_Call) Run(run func(ctx context.Context, tenantID string, cert *ssh.Certificate, sourceIP string)) *MockService_ResolveSSHCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *ssh.Certificate
+		if args[2] != nil {
+			arg2 = args[2].(*ssh.Certificate)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ResolveSSHCertificate_Call) Return(sSHCertificateIdentity *models.SSHCertificateIdentity, err error) *MockService_ResolveSSHCertificate_Call {
+	_c.Call.Return(sSHCertificateIdentity, err)
+	return _c
+}
+
+func (_c *MockService_ResolveSSHCertificate_Call) RunAndReturn(run func(ctx context.Context, tenantID string, cert *ssh.Certificate, sourceIP string) (*models.SSHCertificateIdentity, error)) *MockService_ResolveSSHCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResolveSSHIdentity provides a mock function for the type MockService
 func (_mock *MockService) ResolveSSHIdentity(ctx context.Context, tenantID string, fingerprint string) (*models.SSHIdentity, bool, error) {
 	ret := _mock.Called(ctx, tenantID, fingerprint)
@@ -6277,109 +9031,257 @@ type MockService_ResolveSSHIdentity_Call struct {
 	*mock.Call
 }
 
-// ResolveSSHIdentity is a helper method to define mock.On call
+// ResolveSSHIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - fingerprint string
+func (_e *MockService_Expecter) ResolveSSHIdentity(ctx any, tenantID any, fingerprint any) *MockService_ResolveSSHIdentity_Call {
+	return &MockService_ResolveSSHIdentity_Call{Call: _e.mock.On("ResolveSSHIdentity", ctx, tenantID, fingerprint)}
+}
+
+func (_c *MockService_ResolveSSHIdentity_Call) Run(run func(ctx context.Context, tenantID string, fingerprint string)) *MockService_ResolveSSHIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ResolveSSHIdentity_Call) Return(identity *models.SSHIdentity, found bool, err error) *MockService_ResolveSSHIdentity_Call {
+	_c.Call.Return(identity, found, err)
+	return _c
+}
+
+func (_c *MockService_ResolveSSHIdentity_Call) RunAndReturn(run func(ctx context.Context, tenantID string, fingerprint string) (*models.SSHIdentity, bool, error)) *MockService_ResolveSSHIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevealInstallKey provides a mock function for the type MockService
+func (_mock *MockService) RevealInstallKey(ctx context.Context, req *requests.RevealInstallKey) (string, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevealInstallKey")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.RevealInstallKey) (string, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.RevealInstallKey) string); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.RevealInstallKey) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_RevealInstallKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevealInstallKey'
+type MockService_RevealInstallKey_Call struct {
+	*mock.Call
+}
+
+// RevealInstallKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.RevealInstallKey
+func (_e *MockService_Expecter) RevealInstallKey(ctx any, req any) *MockService_RevealInstallKey_Call {
+	return &MockService_RevealInstallKey_Call{Call: _e.mock.On("RevealInstallKey", ctx, req)}
+}
+
+func (_c *MockService_RevealInstallKey_Call) Run(run func(ctx context.Context, req *requests.RevealInstallKey)) *MockService_RevealInstallKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.RevealInstallKey
+		if args[1] != nil {
+			arg1 = args[1].(*requests.RevealInstallKey)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RevealInstallKey_Call) Return(key string, err error) *MockService_RevealInstallKey_Call {
+	_c.Call.Return(key, err)
+	return _c
+}
+
+func (_c *MockService_RevealInstallKey_Call) RunAndReturn(run func(ctx context.Context, req *requests.RevealInstallKey) (string, error)) *MockService_RevealInstallKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) RevokeAccessRequest(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAccessRequest")
+	}
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestDecide) (*models.AccessRequest, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessRequestDecide) *models.AccessRequest); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessRequestDecide) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_RevokeAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAccessRequest'
+type MockService_RevokeAccessRequest_Call struct {
+	*mock.Call
+}
+
+// RevokeAccessRequest is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantID string
-//   - fingerprint string
-func (_e *MockService_Expecter) ResolveSSHIdentity(ctx any, tenantID any, fingerprint any) *MockService_ResolveSSHIdentity_Call {
-	return &MockService_ResolveSSHIdentity_Call{Call: _e.mock.On("ResolveSSHIdentity", ctx, tenantID, fingerprint)}
+//   - req *requests.AccessRequestDecide
+func (_e *MockService_Expecter) RevokeAccessRequest(ctx any, req any) *MockService_RevokeAccessRequest_Call {
+	return &MockService_RevokeAccessRequest_Call{Call: _e.mock.On("RevokeAccessRequest", ctx, req)}
 }
 
-func (_c *MockService_ResolveSSHIdentity_Call) Run(run func(ctx context.Context, tenantID string, fingerprint string)) *MockService_ResolveSSHIdentity_Call {
+func (_c *MockService_RevokeAccessRequest_Call) Run(run func(ctx context.Context, req *requests.AccessRequestDecide)) *MockService_RevokeAccessRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *requests.AccessRequestDecide
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*requests.AccessRequestDecide)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_ResolveSSHIdentity_Call) Return(identity *models.SSHIdentity, found bool, err error) *MockService_ResolveSSHIdentity_Call {
-	_c.Call.Return(identity, found, err)
+func (_c *MockService_RevokeAccessRequest_Call) Return(accessRequest *models.AccessRequest, err error) *MockService_RevokeAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
 	return _c
 }
 
-func (_c *MockService_ResolveSSHIdentity_Call) RunAndReturn(run func(ctx context.Context, tenantID string, fingerprint string) (*models.SSHIdentity, bool, error)) *MockService_ResolveSSHIdentity_Call {
+func (_c *MockService_RevokeAccessRequest_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessRequestDecide) (*models.AccessRequest, error)) *MockService_RevokeAccessRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RevealInstallKey provides a mock function for the type MockService
-func (_mock *MockService) RevealInstallKey(ctx context.Context, req *requests.RevealInstallKey) (string, error) {
-	ret := _mock.Called(ctx, req)
+// SearchSessions provides a mock function for the type MockService
+func (_mock *MockService) SearchSessions(ctx context.Context, sc scope.Scope, req *requests.SessionSearch) ([]models.SessionSearchHit, int, error) {
+	ret := _mock.Called(ctx, sc, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RevealInstallKey")
+		panic("no return value specified for SearchSessions")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.RevealInstallKey) (string, error)); ok {
-		return returnFunc(ctx, req)
+	var r0 []models.SessionSearchHit
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.SessionSearch) ([]models.SessionSearchHit, int, error)); ok {
+		return returnFunc(ctx, sc, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.RevealInstallKey) string); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *requests.SessionSearch) []models.SessionSearchHit); ok {
+		r0 = returnFunc(ctx, sc, req)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionSearchHit)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.RevealInstallKey) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *requests.SessionSearch) int); ok {
+		r1 = returnFunc(ctx, sc, req)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *requests.SessionSearch) error); ok {
+		r2 = returnFunc(ctx, sc, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockService_RevealInstallKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevealInstallKey'
-type MockService_RevealInstallKey_Call struct {
+// MockService_SearchSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSessions'
+type MockService_SearchSessions_Call struct {
 	*mock.Call
 }
 
-// RevealInstallKey is a helper method to define mock.On call
+// SearchSessions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *requests.RevealInstallKey
-func (_e *MockService_Expecter) RevealInstallKey(ctx any, req any) *MockService_RevealInstallKey_Call {
-	return &MockService_RevealInstallKey_Call{Call: _e.mock.On("RevealInstallKey", ctx, req)}
+//   - sc scope.Scope
+//   - req *requests.SessionSearch
+func (_e *MockService_Expecter) SearchSessions(ctx any, sc any, req any) *MockService_SearchSessions_Call {
+	return &MockService_SearchSessions_Call{Call: _e.mock.On("SearchSessions", ctx, sc, req)}
 }
 
-func (_c *MockService_RevealInstallKey_Call) Run(run func(ctx context.Context, req *requests.RevealInstallKey)) *MockService_RevealInstallKey_Call {
+func (_c *MockService_SearchSessions_Call) Run(run func(ctx context.Context, sc scope.Scope, req *requests.SessionSearch)) *MockService_SearchSessions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *requests.RevealInstallKey
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(*requests.RevealInstallKey)
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 *requests.SessionSearch
+		if args[2] != nil {
+			arg2 = args[2].(*requests.SessionSearch)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_RevealInstallKey_Call) Return(key string, err error) *MockService_RevealInstallKey_Call {
-	_c.Call.Return(key, err)
+func (_c *MockService_SearchSessions_Call) Return(sessionSearchHits []models.SessionSearchHit, n int, err error) *MockService_SearchSessions_Call {
+	_c.Call.Return(sessionSearchHits, n, err)
 	return _c
 }
 
-func (_c *MockService_RevealInstallKey_Call) RunAndReturn(run func(ctx context.Context, req *requests.RevealInstallKey) (string, error)) *MockService_RevealInstallKey_Call {
+func (_c *MockService_SearchSessions_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, req *requests.SessionSearch) ([]models.SessionSearchHit, int, error)) *MockService_SearchSessions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6509,6 +9411,74 @@ func (_c *MockService_Setup_Call) RunAndReturn(run func(ctx context.Context, req
 	return _c
 }
 
+// SimulateAccess provides a mock function for the type MockService
+func (_mock *MockService) SimulateAccess(ctx context.Context, req *requests.AccessPolicySimulate) ([]models.AccessSimulation, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateAccess")
+	}
+
+	var r0 []models.AccessSimulation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessPolicySimulate) ([]models.AccessSimulation, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.AccessPolicySimulate) []models.AccessSimulation); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessSimulation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.AccessPolicySimulate) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_SimulateAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SimulateAccess'
+type MockService_SimulateAccess_Call struct {
+	*mock.Call
+}
+
+// SimulateAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.AccessPolicySimulate
+func (_e *MockService_Expecter) SimulateAccess(ctx any, req any) *MockService_SimulateAccess_Call {
+	return &MockService_SimulateAccess_Call{Call: _e.mock.On("SimulateAccess", ctx, req)}
+}
+
+func (_c *MockService_SimulateAccess_Call) Run(run func(ctx context.Context, req *requests.AccessPolicySimulate)) *MockService_SimulateAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.AccessPolicySimulate
+		if args[1] != nil {
+			arg1 = args[1].(*requests.AccessPolicySimulate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_SimulateAccess_Call) Return(accessSimulations []models.AccessSimulation, err error) *MockService_SimulateAccess_Call {
+	_c.Call.Return(accessSimulations, err)
+	return _c
+}
+
+func (_c *MockService_SimulateAccess_Call) RunAndReturn(run func(ctx context.Context, req *requests.AccessPolicySimulate) ([]models.AccessSimulation, error)) *MockService_SimulateAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Store provides a mock function for the type MockService
 func (_mock *MockService) Store() store.Store {
 	ret := _mock.Called()
@@ -6555,6 +9525,74 @@ func (_c *MockService_Store_Call) RunAndReturn(run func() store.Store) *MockServ
 	return _c
 }
 
+// SubscribeEvents provides a mock function for the type MockService
+func (_mock *MockService) SubscribeEvents(ctx context.Context, req *requests.EventStreamSubscribe) (<-chan eventstream.Event, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeEvents")
+	}
+
+	var r0 <-chan eventstream.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.EventStreamSubscribe) (<-chan eventstream.Event, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.EventStreamSubscribe) <-chan eventstream.Event); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan eventstream.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.EventStreamSubscribe) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_SubscribeEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeEvents'
+type MockService_SubscribeEvents_Call struct {
+	*mock.Call
+}
+
+// SubscribeEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.EventStreamSubscribe
+func (_e *MockService_Expecter) SubscribeEvents(ctx any, req any) *MockService_SubscribeEvents_Call {
+	return &MockService_SubscribeEvents_Call{Call: _e.mock.On("SubscribeEvents", ctx, req)}
+}
+
+func (_c *MockService_SubscribeEvents_Call) Run(run func(ctx context.Context, req *requests.EventStreamSubscribe)) *MockService_SubscribeEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.EventStreamSubscribe
+		if args[1] != nil {
+			arg1 = args[1].(*requests.EventStreamSubscribe)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_SubscribeEvents_Call) Return(v <-chan eventstream.Event, err error) *MockService_SubscribeEvents_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockService_SubscribeEvents_Call) RunAndReturn(run func(ctx context.Context, req *requests.EventStreamSubscribe) (<-chan eventstream.Event, error)) *MockService_SubscribeEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SystemDownloadInstallScript provides a mock function for the type MockService
 func (_mock *MockService) SystemDownloadInstallScript(ctx context.Context, req *requests.SystemInstallScript) (string, error) {
 	ret := _mock.Called(ctx, req)
@@ -7123,6 +10161,74 @@ func (_c *MockService_UpdatePublicKey_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// UpdateSSHUserCA provides a mock function for the type MockService
+func (_mock *MockService) UpdateSSHUserCA(ctx context.Context, req *requests.SSHUserCAUpdate) (*models.SSHUserCA, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSSHUserCA")
+	}
+
+	var r0 *models.SSHUserCA
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHUserCAUpdate) (*models.SSHUserCA, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.SSHUserCAUpdate) *models.SSHUserCA); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SSHUserCA)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.SSHUserCAUpdate) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_UpdateSSHUserCA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSSHUserCA'
+type MockService_UpdateSSHUserCA_Call struct {
+	*mock.Call
+}
+
+// UpdateSSHUserCA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.SSHUserCAUpdate
+func (_e *MockService_Expecter) UpdateSSHUserCA(ctx any, req any) *MockService_UpdateSSHUserCA_Call {
+	return &MockService_UpdateSSHUserCA_Call{Call: _e.mock.On("UpdateSSHUserCA", ctx, req)}
+}
+
+func (_c *MockService_UpdateSSHUserCA_Call) Run(run func(ctx context.Context, req *requests.SSHUserCAUpdate)) *MockService_UpdateSSHUserCA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.SSHUserCAUpdate
+		if args[1] != nil {
+			arg1 = args[1].(*requests.SSHUserCAUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateSSHUserCA_Call) Return(sSHUserCA *models.SSHUserCA, err error) *MockService_UpdateSSHUserCA_Call {
+	_c.Call.Return(sSHUserCA, err)
+	return _c
+}
+
+func (_c *MockService_UpdateSSHUserCA_Call) RunAndReturn(run func(ctx context.Context, req *requests.SSHUserCAUpdate) (*models.SSHUserCA, error)) *MockService_UpdateSSHUserCA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateSession provides a mock function for the type MockService
 func (_mock *MockService) UpdateSession(ctx context.Context, uid models.UID, model models.SessionUpdate) error {
 	ret := _mock.Called(ctx, uid, model)
@@ -7300,6 +10406,74 @@ func (_c *MockService_UpdateUser_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// UpdateWebhook provides a mock function for the type MockService
+func (_mock *MockService) UpdateWebhook(ctx context.Context, req *requests.WebhookUpdate) (*models.Webhook, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhook")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookUpdate) (*models.Webhook, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *requests.WebhookUpdate) *models.Webhook); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *requests.WebhookUpdate) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_UpdateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhook'
+type MockService_UpdateWebhook_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *requests.WebhookUpdate
+func (_e *MockService_Expecter) UpdateWebhook(ctx any, req any) *MockService_UpdateWebhook_Call {
+	return &MockService_UpdateWebhook_Call{Call: _e.mock.On("UpdateWebhook", ctx, req)}
+}
+
+func (_c *MockService_UpdateWebhook_Call) Run(run func(ctx context.Context, req *requests.WebhookUpdate)) *MockService_UpdateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *requests.WebhookUpdate
+		if args[1] != nil {
+			arg1 = args[1].(*requests.WebhookUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateWebhook_Call) Return(webhook *models.Webhook, err error) *MockService_UpdateWebhook_Call {
+	_c.Call.Return(webhook, err)
+	return _c
+}
+
+func (_c *MockService_UpdateWebhook_Call) RunAndReturn(run func(ctx context.Context, req *requests.WebhookUpdate) (*models.Webhook, error)) *MockService_UpdateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserMembershipInvitationList provides a mock function for the type MockService
 func (_mock *MockService) UserMembershipInvitationList(ctx context.Context, req *requests.UserMembershipInvitationList) ([]responses0.MembershipInvitation, int64, error) {
 	ret := _mock.Called(ctx, req)
@@ -268,6 +268,15 @@ func (s *service) EditNamespace(ctx context.Context, req *requests.NamespaceEdit
 		namespace.Settings.ConnectionAnnouncement = *req.Settings.ConnectionAnnouncement
 	}
 
+	if req.Settings.LocalAuthDisabled != nil {
+		// Only single sign-on is left once the local login is gone.
+		if *req.Settings.LocalAuthDisabled && s.oidc == nil {
+			return nil, NewErrForbidden(ErrNamespaceLocalAuthRequired, nil)
+		}
+
+		namespace.Settings.LocalAuthDisabled = *req.Settings.LocalAuthDisabled
+	}
+
 	// NamespaceUpdate returns store.ErrDuplicate when the new name collides with an
 	// existing namespace. Map it to ErrNamespaceDuplicated so callers get a
 	// consistent duplicate signal regardless of timing.
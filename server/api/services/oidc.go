@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/shellhub-io/shellhub/server/api/pkg/oidc"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	log "github.com/sirupsen/logrus"
+)
+
+// oidcSignInTTL bounds how long the user has to sign in at the provider.
+const oidcSignInTTL = 10 * time.Minute
+
+// OIDCProvider is the OpenID Connect provider users sign in through, as [oidc.Provider]
+// implements it.
+type OIDCProvider interface {
+	// AuthCodeURL returns the provider's URL the user signs in at.
+	AuthCodeURL(state, nonce, verifier string) string
+	// Exchange redeems the code the provider sent the user back with and returns who signed in.
+	Exchange(ctx context.Context, code, verifier, nonce string) (*oidc.Identity, error)
+	// Roles resolves the role a user in groups is granted in each namespace the provider's
+	// group mapping manages, [authorizer.RoleInvalid] where none is.
+	Roles(groups []string) map[string]authorizer.Role
+}
+
+// WithOIDCProvider sets the OpenID Connect provider users sign in through. Without one, single
+// sign-on is off and no namespace can disable the local login.
+func WithOIDCProvider(provider OIDCProvider) Option {
+	return func(service *APIService) {
+		service.oidc = provider
+	}
+}
+
+type OIDCService interface {
+	// GetOIDCAuthURL starts a sign-in through the OpenID Connect provider, returning the URL the user
+	// signs in at and the sign-in's state. The sign-in must be completed with
+	// [OIDCService.AuthOIDCUser] within 10 minutes, from the browser the caller binds the state to.
+	GetOIDCAuthURL(ctx context.Context) (url string, state string, err error)
+	// AuthOIDCUser completes a sign-in started with [OIDCService.GetOIDCAuthURL], once the provider
+	// sends the user back with an authorization code. The state the provider sends back must match
+	// the one bound to the browser, so a sign-in cannot be completed in somebody else's browser.
+	//
+	// The user is found by their subject at the provider or, with a verified email, by that email,
+	// in which case the provider is added to their authentication methods; when none matches, an
+	// account is provisioned. The provider's group mapping is then applied to the user's memberships
+	// before a token is created as [AuthService.CreateUserToken] does.
+	AuthOIDCUser(ctx context.Context, req *requests.AuthOIDCUser) (res *models.UserAuthResponse, err error)
+}
+
+// oidcSignIn is what a sign-in keeps while the user is at the provider.
+type oidcSignIn struct {
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+func oidcSignInKey(state string) string {
+	return "oidc-sign-in={" + state + "}"
+}
+
+func (s *service) GetOIDCAuthURL(ctx context.Context) (string, string, error) {
+	if s.oidc == nil {
+		return "", "", NewErrAuthMethodNotAllowed(models.UserAuthMethodOIDC.String())
+	}
+
+	state := uuid.Generate()
+	signIn := &oidcSignIn{Nonce: uuid.Generate(), Verifier: oidc.NewVerifier()}
+
+	if err := s.cache.Set(ctx, oidcSignInKey(state), signIn, oidcSignInTTL); err != nil {
+		return "", "", err
+	}
+
+	return s.oidc.AuthCodeURL(state, signIn.Nonce, signIn.Verifier), state, nil
+}
+
+func (s *service) AuthOIDCUser(ctx context.Context, req *requests.AuthOIDCUser) (*models.UserAuthResponse, error) {
+	if s.oidc == nil {
+		return nil, NewErrAuthMethodNotAllowed(models.UserAuthMethodOIDC.String())
+	}
+
+	// Without this, anybody could send a victim to the callback with their own code and state,
+	// signing the victim in to the attacker's account.
+	if subtle.ConstantTimeCompare([]byte(req.Binding), []byte(req.State)) != 1 {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	signIn, err := cache.Get[oidcSignIn](ctx, s.cache, oidcSignInKey(req.State))
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	// A sign-in completes once, whatever the outcome.
+	if err := s.cache.Delete(ctx, oidcSignInKey(req.State)); err != nil {
+		log.WithError(err).Warn("unable to delete the OIDC sign-in")
+	}
+
+	identity, err := s.oidc.Exchange(ctx, req.Code, signIn.Verifier, signIn.Nonce)
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	user, err := s.resolveOIDCUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status == models.UserStatusNotConfirmed {
+		return nil, NewErrUserNotConfirmed(nil)
+	}
+
+	if user.AwaitingApproval {
+		return nil, NewErrUserAwaitingApproval(nil)
+	}
+
+	if user.Disabled {
+		return nil, NewErrUserDisabled(nil)
+	}
+
+	if err := s.syncMemberships(ctx, user, s.oidc.Roles(identity.Groups)); err != nil {
+		return nil, err
+	}
+
+	user.LastLogin = clock.Now()
+	if err := s.store.UserUpdate(ctx, user); err != nil {
+		return nil, NewErrUserUpdate(user, err)
+	}
+
+	return s.CreateUserToken(ctx, &requests.CreateUserToken{UserID: user.ID, AuthMethod: models.UserAuthMethodOIDC.String()})
+}
+
+// resolveOIDCUser returns the account identity signs in to, linking or provisioning it when needed.
+// The changes to an existing account are left to the caller to save.
+func (s *service) resolveOIDCUser(ctx context.Context, identity *oidc.Identity) (*models.User, error) {
+	user, err := s.store.UserResolve(ctx, store.UserExternalIDResolver, identity.Subject)
+	switch {
+	case err == nil && user.Origin == models.UserOriginOIDC:
+		return user, nil
+	case err != nil && !errors.Is(err, store.ErrNoDocuments):
+		return nil, err
+	}
+
+	// An email the provider does not vouch for could claim anybody's account.
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	user, err = s.store.UserResolve(ctx, store.UserEmailResolver, identity.Email)
+	switch {
+	case errors.Is(err, store.ErrNoDocuments):
+		return s.provisionOIDCUser(ctx, identity)
+	case err != nil:
+		return nil, err
+	}
+
+	// A service account never signs in, and an account provisioned for another subject is not
+	// taken over by one that now has its email.
+	if user.Type == models.UserTypeService || user.Origin == models.UserOriginOIDC {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	if !slices.Contains(user.Preferences.AuthMethods, models.UserAuthMethodOIDC) {
+		user.Preferences.AuthMethods = append(user.Preferences.AuthMethods, models.UserAuthMethodOIDC)
+	}
+
+	return user, nil
+}
+
+// provisionOIDCUser creates the account of a user signing in for the first time. The account has no
+// password and owns no namespace: what it reaches is what the provider's group mapping grants.
+func (s *service) provisionOIDCUser(ctx context.Context, identity *oidc.Identity) (*models.User, error) {
+	username := ""
+	for _, candidate := range []string{identity.Username, strings.Split(identity.Email, "@")[0]} {
+		candidate = strings.ToLower(candidate)
+		if ok, err := s.validator.Var(candidate, validator.UserNameTag); ok && err == nil {
+			username = candidate
+
+			break
+		}
+	}
+
+	if username == "" {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	name := []rune(identity.Name)
+	if len(name) == 0 {
+		name = []rune(username)
+	}
+
+	user := &models.User{
+		Type:          models.UserTypeHuman,
+		Origin:        models.UserOriginOIDC,
+		ExternalID:    identity.Subject,
+		Status:        models.UserStatusConfirmed,
+		MaxNamespaces: 0,
+		CreatedAt:     clock.Now(),
+		UserData: models.UserData{
+			Name:     string(name[:min(len(name), 64)]),
+			Username: username,
+			Email:    identity.Email,
+		},
+		// The password digest is a locked sentinel, and the local login is not among the
+		// account's methods anyway.
+		Password: models.UserPassword{Hash: "!"},
+		Preferences: models.UserPreferences{
+			AuthMethods: []models.UserAuthMethod{models.UserAuthMethodOIDC},
+		},
+	}
+
+	var err error
+	if user.ID, err = s.store.UserCreate(ctx, user); err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			if field, ok := store.DuplicatedField(err); ok {
+				return nil, NewErrUserDuplicated([]string{field}, err)
+			}
+
+			return nil, NewErrUserDuplicated([]string{}, err)
+		}
+
+		return nil, NewErrUserCreate(err)
+	}
+
+	return user, nil
+}
+
+// localAuthDisabled reports whether namespace refuses a token to a user who signed in with method,
+// which it does to the local login when its settings say so. It is only enforced while the instance
+// has an OpenID Connect provider, so removing the provider from the configuration lets everybody
+// back in with their password.
+func (s *service) localAuthDisabled(namespace *models.Namespace, method string) bool {
+	if s.oidc == nil || method != models.UserAuthMethodLocal.String() {
+		return false
+	}
+
+	return namespace.Settings != nil && namespace.Settings.LocalAuthDisabled
+}
@@ -0,0 +1,794 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/jwttoken"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	mockcache "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/shellhub-io/shellhub/server/api/pkg/oidc"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockOIDCProvider stands in for OIDCProvider. It lives here rather than in the generated mocks
+// package for the same reason as mockLicenseEvaluator: services/mocks imports services, so an
+// internal test importing it would form a cycle.
+type mockOIDCProvider struct {
+	testifymock.Mock
+}
+
+func (m *mockOIDCProvider) AuthCodeURL(state, nonce, verifier string) string {
+	return m.Called(state, nonce, verifier).String(0)
+}
+
+func (m *mockOIDCProvider) Exchange(ctx context.Context, code, verifier, nonce string) (*oidc.Identity, error) {
+	args := m.Called(ctx, code, verifier, nonce)
+
+	identity, _ := args.Get(0).(*oidc.Identity)
+
+	return identity, args.Error(1)
+}
+
+func (m *mockOIDCProvider) Roles(groups []string) map[string]authorizer.Role {
+	roles, _ := m.Called(groups).Get(0).(map[string]authorizer.Role)
+
+	return roles
+}
+
+func TestGetOIDCAuthURL(t *testing.T) {
+	ctx := context.TODO()
+
+	prevUUID := uuid.DefaultBackend
+	t.Cleanup(func() { uuid.DefaultBackend = prevUUID })
+
+	t.Run("fails when single sign-on is off", func(t *testing.T) {
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, mockcache.NewMockCache(t))
+
+		_, _, err := service.GetOIDCAuthURL(ctx)
+		assert.Equal(t, NewErrAuthMethodNotAllowed(models.UserAuthMethodOIDC.String()), err)
+	})
+
+	t.Run("fails when the sign-in cannot be kept", func(t *testing.T) {
+		cacheMock := mockcache.NewMockCache(t)
+		provider := &mockOIDCProvider{}
+
+		uuidMock := uuidmock.NewMockUUID(t)
+		uuid.DefaultBackend = uuidMock
+		uuidMock.On("Generate").Return("state").Once()
+		uuidMock.On("Generate").Return("nonce").Once()
+
+		cacheMock.
+			On("Set", ctx, "oidc-sign-in={state}", testifymock.AnythingOfType("*services.oidcSignIn"), oidcSignInTTL).
+			Return(errors.New("error", "", 0)).
+			Once()
+
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, cacheMock, WithOIDCProvider(provider))
+
+		_, _, err := service.GetOIDCAuthURL(ctx)
+		assert.Equal(t, errors.New("error", "", 0), err)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("succeeds keeping the sign-in under its state", func(t *testing.T) {
+		cacheMock := mockcache.NewMockCache(t)
+		provider := &mockOIDCProvider{}
+
+		uuidMock := uuidmock.NewMockUUID(t)
+		uuid.DefaultBackend = uuidMock
+		uuidMock.On("Generate").Return("state").Once()
+		uuidMock.On("Generate").Return("nonce").Once()
+
+		var signIn *oidcSignIn
+
+		cacheMock.
+			On("Set", ctx, "oidc-sign-in={state}", testifymock.AnythingOfType("*services.oidcSignIn"), oidcSignInTTL).
+			Run(func(args testifymock.Arguments) {
+				signIn = args.Get(2).(*oidcSignIn)
+			}).
+			Return(nil).
+			Once()
+		provider.
+			On("AuthCodeURL", "state", "nonce", testifymock.AnythingOfType("string")).
+			Return("https://idp.example.com/auth").
+			Once()
+
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, cacheMock, WithOIDCProvider(provider))
+
+		url, state, err := service.GetOIDCAuthURL(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "https://idp.example.com/auth", url)
+		assert.Equal(t, "state", state)
+
+		require.NotNil(t, signIn)
+		assert.Equal(t, "nonce", signIn.Nonce)
+		assert.NotEmpty(t, signIn.Verifier)
+		assert.Equal(t, signIn.Verifier, provider.Calls[0].Arguments.String(2))
+		provider.AssertExpectations(t)
+	})
+}
+
+func TestAuthOIDCUser(t *testing.T) {
+	storeMock := mocks.NewMockStore(t)
+	cacheMock := mockcache.NewMockCache(t)
+	provider := &mockOIDCProvider{}
+
+	ctx := context.TODO()
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	prevClock := clock.DefaultBackend
+	prevUUID := uuid.DefaultBackend
+	t.Cleanup(func() {
+		clock.DefaultBackend = prevClock
+		uuid.DefaultBackend = prevUUID
+	})
+	clockMock := clockmock.NewMockClock(t)
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+	uuidMock := uuidmock.NewMockUUID(t)
+	uuid.DefaultBackend = uuidMock
+	uuidMock.On("Generate").Return("00000000-0000-0000-0000-000000000000").Maybe()
+
+	const (
+		state    = "00000000-0000-4000-0000-000000000000"
+		tenantID = "00000000-0000-4000-0000-000000000001"
+		otherID  = "00000000-0000-4000-0000-000000000002"
+		userID   = "65fdd16b5f62f93184ec8a39"
+	)
+
+	req := &requests.AuthOIDCUser{Code: "code", State: state, Binding: state}
+
+	signInMock := func() {
+		cacheMock.
+			On("Get", ctx, oidcSignInKey(state), testifymock.Anything).
+			Run(func(args testifymock.Arguments) {
+				*args.Get(2).(**oidcSignIn) = &oidcSignIn{Nonce: "nonce", Verifier: "verifier"}
+			}).
+			Return(nil).
+			Once()
+		cacheMock.
+			On("Delete", ctx, oidcSignInKey(state)).
+			Return(nil).
+			Once()
+	}
+
+	tokenMocks := func(user *models.User) {
+		storeMock.
+			On("UserResolve", ctx, store.UserIDResolver, user.ID).
+			Return(user, nil).
+			Once()
+		storeMock.
+			On("NamespaceGetPreferred", ctx, user.ID).
+			Return(nil, store.ErrNoDocuments).
+			Once()
+		cacheMock.
+			On("Set", ctx, "token_"+user.ID, testifymock.Anything, time.Hour*72).
+			Return(nil).
+			Once()
+	}
+
+	type Expected struct {
+		res *models.UserAuthResponse
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.AuthOIDCUser
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the state is not bound to the browser",
+			req:           &requests.AuthOIDCUser{Code: "code", State: state},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrAuthUnathorized(nil)},
+		},
+		{
+			description:   "fails when the browser is bound to another sign-in",
+			req:           &requests.AuthOIDCUser{Code: "code", State: state, Binding: otherID},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "fails when the sign-in is unknown or expired",
+			requiredMocks: func() {
+				cacheMock.
+					On("Get", ctx, oidcSignInKey(state), testifymock.Anything).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(cache.ErrGetNotFound)},
+		},
+		{
+			description: "fails when the code cannot be exchanged",
+			requiredMocks: func() {
+				signInMock()
+				provider.
+					On("Exchange", ctx, "code", "verifier", "nonce").
+					Return(nil, oidc.ErrNonceMismatch).
+					Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(oidc.ErrNonceMismatch)},
+		},
+		{
+			description: "fails when an unknown subject has no verified email",
+			requiredMocks: func() {
+				signInMock()
+				provider.
+					On("Exchange", ctx, "code", "verifier", "nonce").
+					Return(&oidc.Identity{Subject: "subject", Email: "john.doe@test.com", EmailVerified: false}, nil).
+					Once()
+				storeMock.
+					On("UserResolve", ctx, store.UserExternalIDResolver, "subject").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "fails when the email belongs to an account provisioned for another subject",
+			requiredMocks: func() {
+				signInMock()
+				provider.
+					On("Exchange", ctx, "code", "verifier", "nonce").
+					Return(&oidc.Identity{Subject: "subject", Email: "john.doe@test.com", EmailVerified: true}, nil).
+					Once()
+				storeMock.
+					On("UserResolve", ctx, store.UserExternalIDResolver, "subject").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("UserResolve", ctx, store.UserEmailResolver, "john.doe@test.com").
+					Return(&models.User{ID: userID, Origin: models.UserOriginOIDC, ExternalID: "another"}, nil).
+					Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "succeeds provisioning a user signing in for the first time",
+			requiredMocks: func() {
+				signInMock()
+				provider.
+					On("Exchange", ctx, "code", "verifier", "nonce").
+					Return(&oidc.Identity{
+						Subject:       "subject",
+						Email:         "john.doe@test.com",
+						EmailVerified: true,
+						Name:          "John Doe",
+						Username:      "John_Doe",
+						Groups:        []string{"developers"},
+					}, nil).
+					Once()
+				storeMock.
+					On("UserResolve", ctx, store.UserExternalIDResolver, "subject").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("UserResolve", ctx, store.UserEmailResolver, "john.doe@test.com").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+
+				user := &models.User{
+					Type:       models.UserTypeHuman,
+					Origin:     models.UserOriginOIDC,
+					ExternalID: "subject",
+					Status:     models.UserStatusConfirmed,
+					CreatedAt:  now,
+					UserData: models.UserData{
+						Name:     "John Doe",
+						Username: "john_doe",
+						Email:    "john.doe@test.com",
+					},
+					Password: models.UserPassword{Hash: "!"},
+					Preferences: models.UserPreferences{
+						AuthMethods: []models.UserAuthMethod{models.UserAuthMethodOIDC},
+					},
+				}
+
+				storeMock.
+					On("UserCreate", ctx, user).
+					Return(userID, nil).
+					Once()
+				provider.
+					On("Roles", []string{"developers"}).
+					Return(map[string]authorizer.Role{tenantID: authorizer.RoleOperator}).
+					Once()
+				storeMock.
+					On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(&models.Namespace{
+						TenantID: tenantID,
+						Members:  []models.Member{{ID: "owner", Role: authorizer.RoleOwner}},
+					}, nil).
+					Once()
+				storeMock.
+					On("NamespaceCreateMembership", ctx, scope.MustBounded(tenantID), &models.Member{ID: userID, AddedAt: now, Role: authorizer.RoleOperator}).
+					Return(nil).
+					Once()
+
+				created := *user
+				created.ID = userID
+				created.LastLogin = now
+
+				storeMock.
+					On("UserUpdate", ctx, &created).
+					Return(nil).
+					Once()
+				tokenMocks(&created)
+			},
+			expected: Expected{
+				res: &models.UserAuthResponse{
+					ID:          userID,
+					Origin:      models.UserOriginOIDC.String(),
+					AuthMethods: []models.UserAuthMethod{models.UserAuthMethodOIDC},
+					User:        "john_doe",
+					Name:        "John Doe",
+					Email:       "john.doe@test.com",
+					Token:       "must ignore",
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "succeeds linking the account that has the verified email",
+			requiredMocks: func() {
+				signInMock()
+				provider.
+					On("Exchange", ctx, "code", "verifier", "nonce").
+					Return(&oidc.Identity{Subject: "subject", Email: "john.doe@test.com", EmailVerified: true}, nil).
+					Once()
+				storeMock.
+					On("UserResolve", ctx, store.UserExternalIDResolver, "subject").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+
+				user := &models.User{
+					ID:     userID,
+					Origin: models.UserOriginLocal,
+					Status: models.UserStatusConfirmed,
+					UserData: models.UserData{
+						Name:     "john doe",
+						Username: "john_doe",
+						Email:    "john.doe@test.com",
+					},
+					Preferences: models.UserPreferences{
+						AuthMethods: []models.UserAuthMethod{models.UserAuthMethodLocal},
+					},
+				}
+
+				storeMock.
+					On("UserResolve", ctx, store.UserEmailResolver, "john.doe@test.com").
+					Return(user, nil).
+					Once()
+				provider.
+					On("Roles", []string(nil)).
+					Return(map[string]authorizer.Role{}).
+					Once()
+
+				linked := *user
+				linked.LastLogin = now
+				linked.Preferences.AuthMethods = []models.UserAuthMethod{models.UserAuthMethodLocal, models.UserAuthMethodOIDC}
+
+				storeMock.
+					On("UserUpdate", ctx, &linked).
+					Return(nil).
+					Once()
+				tokenMocks(&linked)
+			},
+			expected: Expected{
+				res: &models.UserAuthResponse{
+					ID:          userID,
+					Origin:      models.UserOriginLocal.String(),
+					AuthMethods: []models.UserAuthMethod{models.UserAuthMethodLocal, models.UserAuthMethodOIDC},
+					User:        "john_doe",
+					Name:        "john doe",
+					Email:       "john.doe@test.com",
+					Token:       "must ignore",
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "succeeds syncing the memberships the groups no longer grant",
+			requiredMocks: func() {
+				signInMock()
+				provider.
+					On("Exchange", ctx, "code", "verifier", "nonce").
+					Return(&oidc.Identity{Subject: "subject", Groups: []string{"admins"}}, nil).
+					Once()
+
+				user := &models.User{
+					ID:         userID,
+					Origin:     models.UserOriginOIDC,
+					ExternalID: "subject",
+					Status:     models.UserStatusConfirmed,
+					UserData:   models.UserData{Name: "john doe", Username: "john_doe", Email: "john.doe@test.com"},
+					Preferences: models.UserPreferences{
+						PreferredNamespace: otherID,
+						AuthMethods:        []models.UserAuthMethod{models.UserAuthMethodOIDC},
+					},
+				}
+
+				storeMock.
+					On("UserResolve", ctx, store.UserExternalIDResolver, "subject").
+					Return(user, nil).
+					Once()
+				provider.
+					On("Roles", []string{"admins"}).
+					Return(map[string]authorizer.Role{tenantID: authorizer.RoleAdministrator, otherID: authorizer.RoleInvalid}).
+					Once()
+
+				// The role granted in tenantID changes.
+				storeMock.
+					On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(&models.Namespace{
+						TenantID: tenantID,
+						Members:  []models.Member{{ID: userID, Role: authorizer.RoleOperator}},
+					}, nil).
+					Once()
+				storeMock.
+					On("NamespaceUpdateMembership", ctx, scope.MustBounded(tenantID), &models.Member{ID: userID, Role: authorizer.RoleAdministrator}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, testifymock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionMemberUpdate && event.TenantID == tenantID && event.TargetID == userID
+					})).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Delete", ctx, "token_"+tenantID+userID).
+					Return(nil).
+					Once()
+
+				// None is granted in otherID anymore.
+				storeMock.
+					On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, otherID).
+					Return(&models.Namespace{
+						TenantID: otherID,
+						Members:  []models.Member{{ID: userID, Role: authorizer.RoleObserver}},
+					}, nil).
+					Once()
+				storeMock.
+					On("NamespaceDeleteMembership", ctx, scope.MustBounded(otherID), &models.Member{ID: userID, Role: authorizer.RoleObserver}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("APIKeyDeleteAllByCreator", ctx, otherID, userID).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEventCreate", ctx, testifymock.MatchedBy(func(event *models.AuditEvent) bool {
+						return event.Action == models.AuditActionMemberRemove && event.TenantID == otherID && event.TargetID == userID
+					})).
+					Return(nil).
+					Once()
+				storeMock.
+					On("SessionListActive", ctx, scope.MustBounded(otherID), userID).
+					Return([]models.Session{}, nil).
+					Once()
+				storeMock.
+					On("UserUpdatePreferredNamespace", ctx, userID, "").
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Delete", ctx, "token_"+otherID+userID).
+					Return(nil).
+					Once()
+
+				updated := *user
+				updated.LastLogin = now
+
+				storeMock.
+					On("UserUpdate", ctx, &updated).
+					Return(nil).
+					Once()
+
+				// What CreateUserToken resolves has the preferred namespace cleared.
+				resolved := updated
+				resolved.Preferences.PreferredNamespace = ""
+				tokenMocks(&resolved)
+			},
+			expected: Expected{
+				res: &models.UserAuthResponse{
+					ID:          userID,
+					Origin:      models.UserOriginOIDC.String(),
+					AuthMethods: []models.UserAuthMethod{models.UserAuthMethodOIDC},
+					User:        "john_doe",
+					Name:        "john doe",
+					Email:       "john.doe@test.com",
+					Token:       "must ignore",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, WithOIDCProvider(provider))
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			r := req
+			if tc.req != nil {
+				r = tc.req
+			}
+
+			res, err := service.AuthOIDCUser(ctx, r)
+			// The token is signed at the current time, so it is not compared.
+			if res != nil {
+				res.Token = "must ignore"
+			}
+
+			assert.Equal(t, tc.expected, Expected{res, err})
+		})
+	}
+
+	provider.AssertExpectations(t)
+}
+
+func TestLocalAuthDisabled(t *testing.T) {
+	disabled := &models.Namespace{TenantID: "disabled", Settings: &models.NamespaceSettings{LocalAuthDisabled: true}}
+	enabled := &models.Namespace{TenantID: "enabled", Settings: &models.NamespaceSettings{}}
+
+	t.Run("the local login is allowed without single sign-on", func(t *testing.T) {
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, mockcache.NewMockCache(t))
+
+		assert.False(t, service.localAuthDisabled(disabled, models.UserAuthMethodLocal.String()))
+	})
+
+	t.Run("the local login is refused by a namespace that disables it", func(t *testing.T) {
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, mockcache.NewMockCache(t), WithOIDCProvider(&mockOIDCProvider{}))
+
+		assert.True(t, service.localAuthDisabled(disabled, models.UserAuthMethodLocal.String()))
+	})
+
+	t.Run("the local login is allowed by a namespace that does not disable it", func(t *testing.T) {
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, mockcache.NewMockCache(t), WithOIDCProvider(&mockOIDCProvider{}))
+
+		assert.False(t, service.localAuthDisabled(enabled, models.UserAuthMethodLocal.String()))
+	})
+
+	t.Run("single sign-on is allowed by a namespace that disables the local login", func(t *testing.T) {
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, mockcache.NewMockCache(t), WithOIDCProvider(&mockOIDCProvider{}))
+
+		assert.False(t, service.localAuthDisabled(disabled, models.UserAuthMethodOIDC.String()))
+	})
+}
+
+func TestEditNamespaceLocalAuthDisabled(t *testing.T) {
+	ctx := context.TODO()
+
+	disabled := true
+	req := &requests.NamespaceEdit{TenantParam: requests.TenantParam{Tenant: "xxxxx"}}
+	req.Settings.LocalAuthDisabled = &disabled
+
+	namespaceMock := func(storeMock *mocks.MockStore) {
+		storeMock.
+			On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, "xxxxx").
+			Return(&models.Namespace{TenantID: "xxxxx", Name: "namespace", Settings: &models.NamespaceSettings{}}, nil).
+			Once()
+	}
+
+	t.Run("fails to disable the local login without single sign-on", func(t *testing.T) {
+		storeMock := mocks.NewMockStore(t)
+		namespaceMock(storeMock)
+
+		service := NewService(storeMock, privateKey, publicKey, mockcache.NewMockCache(t))
+
+		_, err := service.EditNamespace(ctx, req)
+		assert.Equal(t, NewErrForbidden(ErrNamespaceLocalAuthRequired, nil), err)
+	})
+
+	t.Run("succeeds disabling the local login with single sign-on", func(t *testing.T) {
+		storeMock := mocks.NewMockStore(t)
+		namespaceMock(storeMock)
+
+		expected := &models.Namespace{TenantID: "xxxxx", Name: "namespace", Settings: &models.NamespaceSettings{LocalAuthDisabled: true}}
+		storeMock.
+			On("NamespaceUpdate", ctx, expected).
+			Return(nil).
+			Once()
+		storeMock.
+			On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, "xxxxx").
+			Return(expected, nil).
+			Once()
+
+		service := NewService(storeMock, privateKey, publicKey, mockcache.NewMockCache(t), WithOIDCProvider(&mockOIDCProvider{}))
+
+		namespace, err := service.EditNamespace(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, namespace.Settings.LocalAuthDisabled)
+	})
+}
+
+func TestAuthLocalUserLocalAuthDisabled(t *testing.T) {
+	ctx := context.TODO()
+
+	fixedClock(t, now)
+
+	// Signing a token generates its ID, which a mock left behind by another test would refuse.
+	prevUUID := uuid.DefaultBackend
+	t.Cleanup(func() { uuid.DefaultBackend = prevUUID })
+	uuid.DefaultBackend = realUUIDBackend
+
+	const (
+		tenantID = "00000000-0000-4000-0000-000000000000"
+		userID   = "65fdd16b5f62f93184ec8a39"
+	)
+
+	user := &models.User{
+		ID:       userID,
+		Origin:   models.UserOriginLocal,
+		Status:   models.UserStatusConfirmed,
+		UserData: models.UserData{Username: "john_doe", Email: "john.doe@test.com", Name: "john doe"},
+		Password: models.UserPassword{Hash: "$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YWQCIa2UYuFV4OJby7Yi"},
+		Preferences: models.UserPreferences{
+			PreferredNamespace: tenantID,
+			AuthMethods:        []models.UserAuthMethod{models.UserAuthMethodLocal},
+		},
+	}
+
+	storeMock := mocks.NewMockStore(t)
+	cacheMock := mockcache.NewMockCache(t)
+
+	storeMock.
+		On("SystemGet", ctx).
+		Return(&models.System{Authentication: &models.SystemAuthentication{Local: &models.SystemAuthenticationLocal{Enabled: true}}}, nil).
+		Once()
+	storeMock.
+		On("UserResolve", ctx, store.UserUsernameResolver, "john_doe").
+		Return(user, nil).
+		Once()
+	cacheMock.
+		On("HasAccountLockout", ctx, "127.0.0.1", userID).
+		Return(int64(0), 0, nil).
+		Once()
+	hashMock.
+		On("CompareWith", "secret", user.Password.Hash).
+		Return(true).
+		Once()
+	cacheMock.
+		On("ResetLoginAttempts", ctx, "127.0.0.1", userID).
+		Return(nil).
+		Once()
+	storeMock.
+		On("NamespaceGetPreferred", ctx, userID).
+		Return(&models.Namespace{
+			TenantID: tenantID,
+			Members:  []models.Member{{ID: userID, Role: authorizer.RoleOwner}},
+			Settings: &models.NamespaceSettings{LocalAuthDisabled: true},
+		}, nil).
+		Once()
+	storeMock.
+		On("UserUpdate", ctx, testifymock.AnythingOfType("*models.User")).
+		Return(nil).
+		Once()
+	// The namespace stays the preferred one, for when the user signs in through single sign-on.
+	storeMock.
+		On("UserUpdatePreferredNamespace", ctx, userID, tenantID).
+		Return(nil).
+		Once()
+	cacheMock.
+		On("Set", ctx, "token_"+userID, testifymock.Anything, time.Hour*72).
+		Return(nil).
+		Once()
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, WithOIDCProvider(&mockOIDCProvider{}))
+
+	// The namespace refuses the password, not the user: they sign in without it.
+	res, _, _, err := service.AuthLocalUser(ctx, &requests.AuthLocalUser{Identifier: "john_doe", Password: "secret"}, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Empty(t, res.Tenant)
+	assert.Empty(t, res.Role)
+
+	claims, err := jwttoken.ClaimsFromBearerToken(publicKey, res.Token)
+	require.NoError(t, err)
+	assert.Equal(t, models.UserAuthMethodLocal.String(), claims.(*authorizer.UserClaims).AuthMethod)
+}
+
+func TestCreateUserTokenLocalAuthDisabled(t *testing.T) {
+	ctx := context.TODO()
+
+	fixedClock(t, now)
+
+	// Signing a token generates its ID, which a mock left behind by another test would refuse.
+	prevUUID := uuid.DefaultBackend
+	t.Cleanup(func() { uuid.DefaultBackend = prevUUID })
+	uuid.DefaultBackend = realUUIDBackend
+
+	const (
+		tenantID = "00000000-0000-4000-0000-000000000000"
+		userID   = "65fdd16b5f62f93184ec8a39"
+	)
+
+	user := &models.User{ID: userID, Status: models.UserStatusConfirmed, UserData: models.UserData{Username: "john_doe"}}
+	namespace := &models.Namespace{
+		TenantID: tenantID,
+		Members:  []models.Member{{ID: userID, Role: authorizer.RoleOwner}},
+		Settings: &models.NamespaceSettings{LocalAuthDisabled: true},
+	}
+
+	type Expected struct {
+		tenant     string
+		authMethod string
+		err        error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.CreateUserToken
+		requiredMocks func(storeMock *mocks.MockStore, cacheMock *mockcache.MockCache)
+		expected      Expected
+	}{
+		{
+			description: "fails to switch to the namespace after a local login",
+			req:         &requests.CreateUserToken{UserID: userID, TenantID: tenantID, AuthMethod: models.UserAuthMethodLocal.String()},
+			requiredMocks: func(storeMock *mocks.MockStore, _ *mockcache.MockCache) {
+				storeMock.On("UserResolve", ctx, store.UserIDResolver, userID).Return(user, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).Return(namespace, nil).Once()
+			},
+			expected: Expected{err: NewErrForbidden(ErrAuthLocalDisabled, nil)},
+		},
+		{
+			description: "succeeds to switch to the namespace after a single sign-on",
+			req:         &requests.CreateUserToken{UserID: userID, TenantID: tenantID, AuthMethod: models.UserAuthMethodOIDC.String()},
+			requiredMocks: func(storeMock *mocks.MockStore, cacheMock *mockcache.MockCache) {
+				storeMock.On("UserResolve", ctx, store.UserIDResolver, userID).Return(user, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).Return(namespace, nil).Once()
+				storeMock.On("UserUpdatePreferredNamespace", ctx, userID, tenantID).Return(nil).Once()
+				cacheMock.On("Set", ctx, "token_"+tenantID+userID, testifymock.Anything, time.Hour*72).Return(nil).Once()
+			},
+			expected: Expected{tenant: tenantID, authMethod: models.UserAuthMethodOIDC.String()},
+		},
+		{
+			description: "leaves the preferred namespace out after a local login",
+			req:         &requests.CreateUserToken{UserID: userID, AuthMethod: models.UserAuthMethodLocal.String()},
+			requiredMocks: func(storeMock *mocks.MockStore, cacheMock *mockcache.MockCache) {
+				storeMock.On("UserResolve", ctx, store.UserIDResolver, userID).Return(user, nil).Once()
+				storeMock.On("NamespaceGetPreferred", ctx, userID).Return(namespace, nil).Once()
+				cacheMock.On("Set", ctx, "token_"+userID, testifymock.Anything, time.Hour*72).Return(nil).Once()
+			},
+			expected: Expected{tenant: "", authMethod: models.UserAuthMethodLocal.String()},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := mocks.NewMockStore(t)
+			cacheMock := mockcache.NewMockCache(t)
+			tc.requiredMocks(storeMock, cacheMock)
+
+			service := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, WithOIDCProvider(&mockOIDCProvider{}))
+
+			res, err := service.CreateUserToken(ctx, tc.req)
+			if tc.expected.err != nil {
+				assert.Equal(t, tc.expected.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.tenant, res.Tenant)
+
+			claims, err := jwttoken.ClaimsFromBearerToken(publicKey, res.Token)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.authMethod, claims.(*authorizer.UserClaims).AuthMethod)
+		})
+	}
+}
@@ -0,0 +1,883 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"golang.org/x/crypto/ssh"
+)
+
+type PolicyBundleService interface {
+	// ExportPolicyBundle returns the namespace's access policies, SSH identities and user install
+	// keys as a bundle that ApplyPolicyBundle accepts back unchanged.
+	ExportPolicyBundle(ctx context.Context, req *requests.PolicyBundleExport) (*models.PolicyBundle, error)
+
+	// ApplyPolicyBundle plans the creates, updates and deletes that converge the namespace to the
+	// bundle and, unless it is a dry run, applies them in a single transaction. A bundle that does
+	// not validate against the namespace fails as a whole, naming every offending field. It returns
+	// the plan.
+	ApplyPolicyBundle(ctx context.Context, req *requests.PolicyBundleApply) (*models.PolicyBundlePlan, error)
+}
+
+// policyBundleState is what a bundle is planned against: the namespace's current resources, and
+// the lookups that turn a bundle's names into ids.
+type policyBundleState struct {
+	policies   []models.AccessPolicy
+	identities []models.SSHIdentity
+	// installKeys are every install key of the namespace, system and revoked ones included, since
+	// their names stay taken.
+	installKeys []models.InstallKey
+	// tags maps a tag name to the tag.
+	tags map[string]models.Tag
+	// principals maps a human member's email, or a service account's name, to its id.
+	principals map[string]string
+}
+
+// policyBundlePlanner accumulates a plan: the changes reported to the caller, the steps that
+// apply them, what follows each applied change once committed, and the reasons the bundle is
+// invalid, keyed by field path.
+type policyBundlePlanner struct {
+	plan    *models.PolicyBundlePlan
+	steps   []func(ctx context.Context) error
+	done    []func(ctx context.Context)
+	invalid map[string]string
+}
+
+// add plans a change, applied by step. done, when not nil, runs after the transaction that
+// applied every step committed.
+func (p *policyBundlePlanner) add(change models.PolicyBundleChange, step func(ctx context.Context) error, done func(ctx context.Context)) {
+	p.plan.Changes = append(p.plan.Changes, change)
+	p.steps = append(p.steps, step)
+
+	if done != nil {
+		p.done = append(p.done, done)
+	}
+}
+
+func (p *policyBundlePlanner) reject(field, reason string) {
+	p.invalid[field] = reason
+}
+
+func (s *service) ExportPolicyBundle(ctx context.Context, req *requests.PolicyBundleExport) (*models.PolicyBundle, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := s.store.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, req.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	state, err := s.loadPolicyBundleState(ctx, sc, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &models.PolicyBundle{
+		AccessPolicies: make([]models.PolicyBundleAccessPolicy, 0, len(state.policies)),
+		SSHIdentities:  make([]models.PolicyBundleSSHIdentity, 0, len(state.identities)),
+		InstallKeys:    make([]models.PolicyBundleInstallKey, 0, len(state.installKeys)),
+	}
+
+	for _, policy := range state.policies {
+		bundle.AccessPolicies = append(bundle.AccessPolicies, policyBundleAccessPolicy(policy))
+	}
+
+	for _, identity := range state.identities {
+		bundle.SSHIdentities = append(bundle.SSHIdentities, policyBundleSSHIdentity(identity))
+	}
+
+	for _, installKey := range state.installKeys {
+		if installKey.IsSystem() || installKey.Revoked {
+			continue
+		}
+
+		bundle.InstallKeys = append(bundle.InstallKeys, policyBundleInstallKey(installKey))
+	}
+
+	// A bundle lives in version control, so keep its order stable across exports.
+	slices.SortStableFunc(bundle.AccessPolicies, func(a, b models.PolicyBundleAccessPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	slices.SortStableFunc(bundle.SSHIdentities, func(a, b models.PolicyBundleSSHIdentity) int {
+		if c := strings.Compare(a.Principal, b.Principal); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.PublicKey, b.PublicKey)
+	})
+
+	slices.SortStableFunc(bundle.InstallKeys, func(a, b models.PolicyBundleInstallKey) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return bundle, nil
+}
+
+func (s *service) ApplyPolicyBundle(ctx context.Context, req *requests.PolicyBundleApply) (*models.PolicyBundlePlan, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var planner *policyBundlePlanner
+
+	// The plan is built from the state read in the same transaction that applies it, so a change
+	// made meanwhile through another path isn't overwritten by a plan that didn't see it.
+	plan := func(ctx context.Context) error {
+		namespace, err := s.store.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, req.TenantID)
+		if err != nil {
+			return NewErrNamespaceNotFound(req.TenantID, err)
+		}
+
+		state, err := s.loadPolicyBundleState(ctx, sc, namespace)
+		if err != nil {
+			return err
+		}
+
+		planner = &policyBundlePlanner{
+			plan:    &models.PolicyBundlePlan{DryRun: req.DryRun, Changes: []models.PolicyBundleChange{}},
+			invalid: make(map[string]string),
+		}
+
+		if req.AccessPolicies != nil {
+			s.planAccessPolicies(planner, state, req.TenantID, req.AccessPolicies)
+		}
+
+		if req.SSHIdentities != nil {
+			s.planSSHIdentities(planner, state, req.TenantID, req.SSHIdentities)
+		}
+
+		if req.InstallKeys != nil {
+			s.planInstallKeys(planner, state, req.TenantID, req.UserID, req.InstallKeys)
+		}
+
+		if len(planner.invalid) > 0 {
+			return NewErrPolicyBundleInvalid(planner.invalid)
+		}
+
+		if req.DryRun {
+			return nil
+		}
+
+		for _, step := range planner.steps {
+			if err := step(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if req.DryRun {
+		if err := plan(ctx); err != nil {
+			return nil, err
+		}
+
+		return planner.plan, nil
+	}
+
+	if err := s.store.WithTransaction(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	// Each change is audited and announced only once the transaction committed it.
+	for _, done := range planner.done {
+		done(ctx)
+	}
+
+	// Install keys enroll devices; only the access policies and the identities authorize sessions.
+	if slices.ContainsFunc(planner.plan.Changes, func(change models.PolicyBundleChange) bool {
+		return change.Kind == models.PolicyBundleKindAccessPolicy || change.Kind == models.PolicyBundleKindSSHIdentity
+	}) {
+		s.reevaluateSessions(ctx, req.TenantID, s.activeSessions(ctx, req.TenantID, ""))
+	}
+
+	return planner.plan, nil
+}
+
+func (s *service) loadPolicyBundleState(ctx context.Context, sc scope.Scope, namespace *models.Namespace) (*policyBundleState, error) {
+	state := &policyBundleState{
+		tags:       make(map[string]models.Tag),
+		principals: make(map[string]string),
+	}
+
+	policies, _, err := s.store.AccessPolicyList(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Policies granted through an access request belong to the request's
+	// lifecycle, not to the bundle: they are neither exported nor pruned.
+	state.policies = make([]models.AccessPolicy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.AccessRequestID == "" {
+			state.policies = append(state.policies, policy)
+		}
+	}
+
+	if state.identities, _, err = s.store.SSHIdentityList(ctx, sc); err != nil {
+		return nil, err
+	}
+
+	if state.installKeys, _, err = s.store.InstallKeyList(ctx, sc); err != nil {
+		return nil, err
+	}
+
+	tags, _, err := s.store.TagList(ctx, sc)
+	if err != nil {
+		return nil, NewErrTagEmpty(namespace.TenantID, err)
+	}
+
+	for _, tag := range tags {
+		state.tags[tag.Name] = tag
+	}
+
+	accounts, _, err := s.store.ServiceAccountList(ctx, namespace.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		state.principals[account.Name] = account.ID
+	}
+
+	for _, member := range namespace.Members {
+		if member.Type != models.UserTypeService && member.Email != "" {
+			state.principals[member.Email] = member.ID
+		}
+	}
+
+	return state, nil
+}
+
+func (s *service) planAccessPolicies(planner *policyBundlePlanner, state *policyBundleState, tenantID string, entries []models.PolicyBundleAccessPolicy) {
+	existing := make(map[string][]models.AccessPolicy, len(state.policies))
+	for _, policy := range state.policies {
+		existing[policy.Name] = append(existing[policy.Name], policy)
+	}
+
+	seen := make(map[string]bool, len(entries))
+
+	for i, entry := range entries {
+		field := fmt.Sprintf("access_policies[%d]", i)
+
+		if seen[entry.Name] {
+			planner.reject(field+".name", "is duplicated in the bundle")
+
+			continue
+		}
+
+		seen[entry.Name] = true
+
+		matches := existing[entry.Name]
+		if len(matches) > 1 {
+			planner.reject(field+".name", "matches more than one access policy in the namespace")
+
+			continue
+		}
+
+		desired, ok := bundleAccessPolicyModel(planner, state, field, tenantID, entry)
+		if !ok {
+			continue
+		}
+
+		if len(matches) == 0 {
+			planner.add(
+				models.PolicyBundleChange{Kind: models.PolicyBundleKindAccessPolicy, Name: entry.Name, Action: models.PolicyBundleActionCreate},
+				func(ctx context.Context) error {
+					id, err := s.store.AccessPolicyCreate(ctx, desired)
+					desired.ID = id
+
+					return err
+				},
+				func(ctx context.Context) {
+					s.accessPolicyChanged(ctx, nil, desired)
+				},
+			)
+
+			continue
+		}
+
+		current := matches[0]
+		desired.ID = current.ID
+		desired.CreatedAt = current.CreatedAt
+
+		if fields := accessPolicyChangedFields(&current, desired); len(fields) > 0 {
+			planner.add(
+				models.PolicyBundleChange{Kind: models.PolicyBundleKindAccessPolicy, Name: entry.Name, Action: models.PolicyBundleActionUpdate, Fields: fields},
+				func(ctx context.Context) error {
+					return s.store.AccessPolicyUpdate(ctx, desired)
+				},
+				func(ctx context.Context) {
+					s.accessPolicyChanged(ctx, &current, desired)
+				},
+			)
+		}
+	}
+
+	for _, policy := range state.policies {
+		if seen[policy.Name] {
+			continue
+		}
+
+		planner.add(
+			models.PolicyBundleChange{Kind: models.PolicyBundleKindAccessPolicy, Name: policy.Name, Action: models.PolicyBundleActionDelete},
+			func(ctx context.Context) error {
+				return s.store.AccessPolicyDelete(ctx, &policy)
+			},
+			func(ctx context.Context) {
+				s.accessPolicyChanged(ctx, &policy, nil)
+			},
+		)
+	}
+}
+
+// bundleAccessPolicyModel builds the access policy an entry describes, normalized the way the
+// create endpoint normalizes it, rejecting what cannot be stored.
+func bundleAccessPolicyModel(planner *policyBundlePlanner, state *policyBundleState, field, tenantID string, entry models.PolicyBundleAccessPolicy) (*models.AccessPolicy, bool) {
+	ok := true
+
+	if err := checkAccessPolicyPeriod(entry.NotBefore, entry.NotAfter); err != nil {
+		planner.reject(field+".not_after", "must be after not_before")
+		ok = false
+	}
+
+	for name, reason := range checkPolicySchedule(entry.Schedule) {
+		planner.reject(field+".schedule."+name, reason)
+		ok = false
+	}
+
+	filter := models.PublicKeyFilter{Hostname: entry.Filter.Hostname}
+	for j, name := range entry.Filter.Tags {
+		tag, found := state.tags[name]
+		if !found {
+			planner.reject(fmt.Sprintf("%s.filter.tags[%d]", field, j), fmt.Sprintf("tag %q does not exist", name))
+			ok = false
+
+			continue
+		}
+
+		filter.TagIDs = append(filter.TagIDs, tag.ID)
+		filter.Tags = append(filter.Tags, tag)
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	return &models.AccessPolicy{
+		TenantID:      tenantID,
+		Name:          entry.Name,
+		Subject:       entry.Subject,
+		Filter:        filter,
+		Logins:        entry.Logins,
+		SourceIP:      normalizeSourceIPs(entry.SourceIP),
+		Action:        defaultAction(string(entry.Action)),
+		RequireReauth: entry.RequireReauth,
+		ReauthPeriod:  normalizeReauthPeriod(entry.ReauthPeriod),
+		Schedule:      entry.Schedule,
+		NotBefore:     entry.NotBefore,
+		NotAfter:      entry.NotAfter,
+	}, true
+}
+
+// accessPolicyChangedFields lists the bundle fields in which desired differs from current.
+func accessPolicyChangedFields(current, desired *models.AccessPolicy) []string {
+	fields := make([]string, 0)
+
+	if current.Subject != desired.Subject {
+		fields = append(fields, "subject")
+	}
+
+	if current.Filter.Hostname != desired.Filter.Hostname || !sameSet(current.Filter.TagIDs, desired.Filter.TagIDs) {
+		fields = append(fields, "filter")
+	}
+
+	if !slices.Equal(current.Logins, desired.Logins) {
+		fields = append(fields, "logins")
+	}
+
+	if !slices.Equal(current.SourceIP, desired.SourceIP) {
+		fields = append(fields, "source_ip")
+	}
+
+	if current.Action != desired.Action {
+		fields = append(fields, "action")
+	}
+
+	if current.RequireReauth != desired.RequireReauth {
+		fields = append(fields, "require_reauth")
+	}
+
+	if !sameIntPtr(current.ReauthPeriod, desired.ReauthPeriod) {
+		fields = append(fields, "reauth_period")
+	}
+
+	if !reflect.DeepEqual(current.Schedule, desired.Schedule) {
+		fields = append(fields, "schedule")
+	}
+
+	if !sameTimePtr(current.NotBefore, desired.NotBefore) {
+		fields = append(fields, "not_before")
+	}
+
+	if !sameTimePtr(current.NotAfter, desired.NotAfter) {
+		fields = append(fields, "not_after")
+	}
+
+	return fields
+}
+
+func (s *service) planSSHIdentities(planner *policyBundlePlanner, state *policyBundleState, tenantID string, entries []models.PolicyBundleSSHIdentity) {
+	existing := make(map[string]models.SSHIdentity, len(state.identities))
+	for _, identity := range state.identities {
+		existing[identity.Fingerprint] = identity
+	}
+
+	seen := make(map[string]bool, len(entries))
+
+	for i, entry := range entries {
+		field := fmt.Sprintf("ssh_identities[%d]", i)
+
+		pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(entry.PublicKey)) //nolint:dogsled
+		if err != nil {
+			planner.reject(field+".public_key", "is not a valid OpenSSH public key")
+
+			continue
+		}
+
+		fingerprint := ssh.FingerprintSHA256(pubKey)
+		if seen[fingerprint] {
+			planner.reject(field+".public_key", "is duplicated in the bundle")
+
+			continue
+		}
+
+		seen[fingerprint] = true
+
+		principalID, found := state.principals[entry.Principal]
+		if !found {
+			planner.reject(field+".principal", fmt.Sprintf("%q is not a member or service account of the namespace", entry.Principal))
+
+			continue
+		}
+
+		desired := &models.SSHIdentity{
+			TenantID:    tenantID,
+			PrincipalID: principalID,
+			Fingerprint: fingerprint,
+			Data:        ssh.MarshalAuthorizedKey(pubKey),
+			Name:        entry.Name,
+			Source:      models.SSHIdentitySourceManual,
+			ExpiresAt:   entry.ExpiresAt,
+			SingleUse:   entry.SingleUse,
+		}
+
+		current, found := existing[fingerprint]
+		if !found {
+			planner.add(
+				models.PolicyBundleChange{Kind: models.PolicyBundleKindSSHIdentity, Name: fingerprint, Action: models.PolicyBundleActionCreate},
+				func(ctx context.Context) error {
+					_, err := s.persistSSHIdentity(ctx, desired)
+
+					return err
+				},
+				func(ctx context.Context) {
+					s.recordAuditChange(ctx, tenantID, models.AuditActionSSHIdentityCreate, models.AuditTargetSSHIdentity, desired.ID, nil, desired)
+				},
+			)
+
+			continue
+		}
+
+		// A key moving to another principal is a new binding: the old one, and the re-auth and
+		// usage history that belong to its holder, go away.
+		if current.PrincipalID != principalID {
+			planner.add(
+				models.PolicyBundleChange{Kind: models.PolicyBundleKindSSHIdentity, Name: fingerprint, Action: models.PolicyBundleActionUpdate, Fields: []string{"principal"}},
+				func(ctx context.Context) error {
+					if err := s.store.SSHIdentityDelete(ctx, &current); err != nil {
+						return err
+					}
+
+					_, err := s.persistSSHIdentity(ctx, desired)
+
+					return err
+				},
+				func(ctx context.Context) {
+					s.recordAuditChange(ctx, tenantID, models.AuditActionSSHIdentityRevoke, models.AuditTargetSSHIdentity, current.ID, &current, nil)
+					s.recordAuditChange(ctx, tenantID, models.AuditActionSSHIdentityCreate, models.AuditTargetSSHIdentity, desired.ID, nil, desired)
+				},
+			)
+
+			continue
+		}
+
+		fields := make([]string, 0)
+
+		// A bundle entry without a name keeps the one the key has.
+		if desired.Name == "" {
+			desired.Name = current.Name
+		} else if desired.Name != current.Name {
+			fields = append(fields, "name")
+		}
+
+		if !sameTimePtr(current.ExpiresAt, desired.ExpiresAt) {
+			fields = append(fields, "expires_at")
+		}
+
+		if current.SingleUse != desired.SingleUse {
+			fields = append(fields, "single_use")
+		}
+
+		if len(fields) > 0 {
+			desired.ID = current.ID
+
+			planner.add(
+				models.PolicyBundleChange{Kind: models.PolicyBundleKindSSHIdentity, Name: fingerprint, Action: models.PolicyBundleActionUpdate, Fields: fields},
+				func(ctx context.Context) error {
+					return s.store.SSHIdentityUpdate(ctx, desired)
+				},
+				func(ctx context.Context) {
+					s.recordAuditChange(ctx, tenantID, models.AuditActionSSHIdentityUpdate, models.AuditTargetSSHIdentity, current.ID, &current, desired)
+				},
+			)
+		}
+	}
+
+	for _, identity := range state.identities {
+		if seen[identity.Fingerprint] {
+			continue
+		}
+
+		planner.add(
+			models.PolicyBundleChange{Kind: models.PolicyBundleKindSSHIdentity, Name: identity.Fingerprint, Action: models.PolicyBundleActionDelete},
+			func(ctx context.Context) error {
+				return s.store.SSHIdentityDelete(ctx, &identity)
+			},
+			func(ctx context.Context) {
+				s.recordAuditChange(ctx, tenantID, models.AuditActionSSHIdentityRevoke, models.AuditTargetSSHIdentity, identity.ID, &identity, nil)
+			},
+		)
+	}
+}
+
+func (s *service) planInstallKeys(planner *policyBundlePlanner, state *policyBundleState, tenantID, userID string, entries []models.PolicyBundleInstallKey) {
+	existing := make(map[string]models.InstallKey, len(state.installKeys))
+	for _, installKey := range state.installKeys {
+		existing[installKey.Name] = installKey
+	}
+
+	seen := make(map[string]bool, len(entries))
+
+	for i, entry := range entries {
+		field := fmt.Sprintf("install_keys[%d]", i)
+
+		if seen[entry.Name] {
+			planner.reject(field+".name", "is duplicated in the bundle")
+
+			continue
+		}
+
+		seen[entry.Name] = true
+
+		current, found := existing[entry.Name]
+		switch {
+		case found && current.IsSystem():
+			planner.reject(field+".name", "names a system install key, which a bundle does not manage")
+
+			continue
+		case found && current.Revoked:
+			planner.reject(field+".name", "names a revoked install key, which cannot be brought back")
+
+			continue
+		}
+
+		desired := bundleInstallKeyModel(entry)
+		desired.TenantID = tenantID
+
+		if found {
+			desired.ID = current.ID
+			desired.Type = current.Type
+			desired.UsedTimes = current.UsedTimes
+			desired.LastUsedAt = current.LastUsedAt
+			desired.KeyEncrypted = current.KeyEncrypted
+			desired.KeyHint = current.KeyHint
+			desired.CreatedBy = current.CreatedBy
+			desired.CreatedAt = current.CreatedAt
+
+			// The secret never leaves the server, so a bundle without one keeps it.
+			if desired.WebhookSecret == "" {
+				desired.WebhookSecret = current.WebhookSecret
+			}
+
+			if desired.UsageLimit != 0 && desired.UsageLimit < current.UsedTimes {
+				planner.reject(field+".usage_limit", "cannot be lower than the number of times the key was already used")
+
+				continue
+			}
+		}
+
+		if err := validateInstallKeyMode(desired.Mode, desired.WebhookURL, desired.WebhookSecret, desired.AllowedMACs); err != nil {
+			for name, reason := range invalidFieldsOf(err) {
+				planner.reject(field+"."+name, reason)
+			}
+
+			continue
+		}
+
+		if !found {
+			desired.CreatedBy = userID
+
+			planner.add(
+				models.PolicyBundleChange{Kind: models.PolicyBundleKindInstallKey, Name: entry.Name, Action: models.PolicyBundleActionCreate},
+				func(ctx context.Context) error {
+					if _, err := s.generateInstallKey(desired); err != nil {
+						return err
+					}
+
+					_, err := s.store.InstallKeyCreate(ctx, desired)
+
+					return err
+				},
+				func(ctx context.Context) {
+					s.recordAuditChange(ctx, tenantID, models.AuditActionInstallKeyCreate, models.AuditTargetInstallKey, desired.ID, nil, desired)
+				},
+			)
+
+			continue
+		}
+
+		if fields := installKeyChangedFields(&current, desired); len(fields) > 0 {
+			planner.add(
+				models.PolicyBundleChange{Kind: models.PolicyBundleKindInstallKey, Name: entry.Name, Action: models.PolicyBundleActionUpdate, Fields: fields},
+				func(ctx context.Context) error {
+					return s.store.InstallKeyUpdate(ctx, desired)
+				},
+				func(ctx context.Context) {
+					s.recordAuditChange(ctx, tenantID, models.AuditActionInstallKeyUpdate, models.AuditTargetInstallKey, current.ID, &current, desired)
+				},
+			)
+		}
+	}
+
+	for _, installKey := range state.installKeys {
+		if seen[installKey.Name] || installKey.IsSystem() || installKey.Revoked {
+			continue
+		}
+
+		planner.add(
+			models.PolicyBundleChange{Kind: models.PolicyBundleKindInstallKey, Name: installKey.Name, Action: models.PolicyBundleActionRevoke},
+			func(ctx context.Context) error {
+				revoked := installKey
+				revoked.Revoked = true
+
+				return s.store.InstallKeyUpdate(ctx, &revoked)
+			},
+			func(ctx context.Context) {
+				revoked := installKey
+				revoked.Revoked = true
+
+				s.recordAuditChange(ctx, tenantID, models.AuditActionInstallKeyRevoke, models.AuditTargetInstallKey, installKey.ID, &installKey, &revoked)
+			},
+		)
+	}
+}
+
+// bundleInstallKeyModel builds the install key settings an entry describes, defaulted and derived
+// the way the create endpoint does it.
+func bundleInstallKeyModel(entry models.PolicyBundleInstallKey) *models.InstallKey {
+	mode := entry.Mode
+	if mode == "" {
+		mode = models.InstallKeyModeAutomatic
+	}
+
+	ephemeralTimeout := 0
+	if entry.Ephemeral {
+		ephemeralTimeout = entry.EphemeralTimeout
+		if ephemeralTimeout <= 0 || ephemeralTimeout > installKeyMaxEphemeralTimeout {
+			ephemeralTimeout = installKeyMaxEphemeralTimeout
+		}
+	}
+
+	tags := entry.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return &models.InstallKey{
+		Name:               entry.Name,
+		Mode:               mode,
+		WebhookURL:         entry.WebhookURL,
+		WebhookSecret:      entry.WebhookSecret,
+		AllowedMACs:        normalizeMACs(entry.AllowedMACs),
+		WebhookTimeout:     entry.WebhookTimeout,
+		WebhookCallbackTTL: entry.WebhookCallbackTTL,
+		Reusable:           entry.UsageLimit != 1,
+		UsageLimit:         entry.UsageLimit,
+		Ephemeral:          entry.Ephemeral,
+		EphemeralTimeout:   ephemeralTimeout,
+		Tags:               tags,
+		Disabled:           entry.Disabled,
+		ExpiresAt:          entry.ExpiresAt,
+		Type:               models.InstallKeyTypeUser,
+	}
+}
+
+// installKeyChangedFields lists the bundle fields in which desired differs from current.
+func installKeyChangedFields(current, desired *models.InstallKey) []string {
+	fields := make([]string, 0)
+
+	if current.Mode != desired.Mode {
+		fields = append(fields, "mode")
+	}
+
+	if current.WebhookURL != desired.WebhookURL {
+		fields = append(fields, "webhook_url")
+	}
+
+	if current.WebhookSecret != desired.WebhookSecret {
+		fields = append(fields, "webhook_secret")
+	}
+
+	if !slices.Equal(current.AllowedMACs, desired.AllowedMACs) {
+		fields = append(fields, "allowed_macs")
+	}
+
+	if current.WebhookTimeout != desired.WebhookTimeout {
+		fields = append(fields, "webhook_timeout")
+	}
+
+	if current.WebhookCallbackTTL != desired.WebhookCallbackTTL {
+		fields = append(fields, "webhook_callback_ttl")
+	}
+
+	if current.UsageLimit != desired.UsageLimit {
+		fields = append(fields, "usage_limit")
+	}
+
+	if current.Ephemeral != desired.Ephemeral {
+		fields = append(fields, "ephemeral")
+	}
+
+	if current.EphemeralTimeout != desired.EphemeralTimeout {
+		fields = append(fields, "ephemeral_timeout")
+	}
+
+	if !slices.Equal(current.Tags, desired.Tags) {
+		fields = append(fields, "tags")
+	}
+
+	if current.Disabled != desired.Disabled {
+		fields = append(fields, "disabled")
+	}
+
+	if !sameTimePtr(current.ExpiresAt, desired.ExpiresAt) {
+		fields = append(fields, "expires_at")
+	}
+
+	return fields
+}
+
+func policyBundleAccessPolicy(policy models.AccessPolicy) models.PolicyBundleAccessPolicy {
+	filter := models.PolicyBundleFilter{Hostname: policy.Filter.Hostname}
+	for _, tag := range policy.Filter.Tags {
+		filter.Tags = append(filter.Tags, tag.Name)
+	}
+
+	return models.PolicyBundleAccessPolicy{
+		Name:          policy.Name,
+		Subject:       policy.Subject,
+		Filter:        filter,
+		Logins:        policy.Logins,
+		SourceIP:      policy.SourceIP,
+		Action:        policy.Action,
+		RequireReauth: policy.RequireReauth,
+		ReauthPeriod:  policy.ReauthPeriod,
+		Schedule:      policy.Schedule,
+		NotBefore:     policy.NotBefore,
+		NotAfter:      policy.NotAfter,
+	}
+}
+
+func policyBundleSSHIdentity(identity models.SSHIdentity) models.PolicyBundleSSHIdentity {
+	principal := identity.PrincipalEmail
+	if identity.PrincipalType == models.UserTypeService {
+		principal = identity.PrincipalName
+	}
+
+	return models.PolicyBundleSSHIdentity{
+		Principal: principal,
+		Name:      identity.Name,
+		PublicKey: strings.TrimSpace(string(identity.Data)),
+		ExpiresAt: identity.ExpiresAt,
+		SingleUse: identity.SingleUse,
+	}
+}
+
+func policyBundleInstallKey(installKey models.InstallKey) models.PolicyBundleInstallKey {
+	return models.PolicyBundleInstallKey{
+		Name:               installKey.Name,
+		Mode:               installKey.Mode,
+		WebhookURL:         installKey.WebhookURL,
+		AllowedMACs:        installKey.AllowedMACs,
+		WebhookTimeout:     installKey.WebhookTimeout,
+		WebhookCallbackTTL: installKey.WebhookCallbackTTL,
+		UsageLimit:         installKey.UsageLimit,
+		Ephemeral:          installKey.Ephemeral,
+		EphemeralTimeout:   installKey.EphemeralTimeout,
+		Tags:               installKey.Tags,
+		Disabled:           installKey.Disabled,
+		ExpiresAt:          installKey.ExpiresAt,
+	}
+}
+
+// invalidFieldsOf returns the per-field reasons an invalid-fields error carries.
+func invalidFieldsOf(err error) map[string]string {
+	var e errors.Error
+	if stderrors.As(err, &e) {
+		if data, ok := e.Data.(ErrDataInvalidFields); ok {
+			return data.Fields
+		}
+	}
+
+	return map[string]string{"": err.Error()}
+}
+
+// sameSet reports whether a and b hold the same strings, in any order.
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
+
+func sameIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func sameTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
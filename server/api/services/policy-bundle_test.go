@@ -0,0 +1,485 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// policyBundleFixture is the namespace state the bundle tests plan against.
+type policyBundleFixture struct {
+	namespace   *models.Namespace
+	policies    []models.AccessPolicy
+	identities  []models.SSHIdentity
+	installKeys []models.InstallKey
+	tags        []models.Tag
+	accounts    []models.ServiceAccount
+}
+
+func (f *policyBundleFixture) mock(ctx context.Context, storeMock *storemock.MockStore) {
+	storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, f.namespace.TenantID).
+		Return(f.namespace, nil).Once()
+	storeMock.On("AccessPolicyList", ctx, mock.Anything).
+		Return(f.policies, len(f.policies), nil).Once()
+	storeMock.On("SSHIdentityList", ctx, mock.Anything).
+		Return(f.identities, len(f.identities), nil).Once()
+	storeMock.On("InstallKeyList", ctx, mock.Anything).
+		Return(f.installKeys, len(f.installKeys), nil).Once()
+	storeMock.On("TagList", ctx, mock.Anything).
+		Return(f.tags, len(f.tags), nil).Once()
+	storeMock.On("ServiceAccountList", ctx, f.namespace.TenantID).
+		Return(f.accounts, len(f.accounts), nil).Once()
+}
+
+func newPolicyBundleFixture(t *testing.T) (*policyBundleFixture, map[string]string) {
+	t.Helper()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	aliceKey, aliceFingerprint := newTestPublicKey(t)
+	botKey, botFingerprint := newTestPublicKey(t)
+
+	expiresAt := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	fixture := &policyBundleFixture{
+		namespace: &models.Namespace{
+			TenantID: tenantID,
+			Members: []models.Member{
+				{ID: "alice", Email: "alice@example.com", Role: authorizer.RoleAdministrator, Type: models.UserTypeHuman},
+				{ID: "bot", Role: authorizer.RoleOperator, Type: models.UserTypeService},
+			},
+		},
+		policies: []models.AccessPolicy{
+			{
+				ID:      "policy-web",
+				Name:    "web",
+				Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+				Filter: models.PublicKeyFilter{Taggable: models.Taggable{
+					TagIDs: []string{"tag-web"},
+					Tags:   []models.Tag{{ID: "tag-web", Name: "web"}},
+				}},
+				Logins: []string{"deploy"},
+				Action: models.PolicyActionAllow,
+			},
+			{
+				ID:      "policy-db",
+				Name:    "db",
+				Subject: models.PolicySubject{Type: models.PolicySubjectRole, Value: "administrator"},
+				Filter:  models.PublicKeyFilter{Hostname: "^db-"},
+				Logins:  []string{"postgres"},
+				Action:  models.PolicyActionAllow,
+			},
+			// Granted through an access request: no bundle exports or prunes it.
+			{
+				ID:              "policy-granted",
+				Name:            "access request request-id",
+				Subject:         models.PolicySubject{Type: models.PolicySubjectUser, Value: "alice"},
+				Filter:          models.PublicKeyFilter{Hostname: ".*"},
+				Logins:          []string{"root"},
+				Action:          models.PolicyActionAllow,
+				NotAfter:        &expiresAt,
+				AccessRequestID: "request-id",
+			},
+		},
+		identities: []models.SSHIdentity{
+			{
+				ID:             "identity-alice",
+				TenantID:       tenantID,
+				PrincipalID:    "alice",
+				PrincipalEmail: "alice@example.com",
+				PrincipalType:  models.UserTypeHuman,
+				Fingerprint:    aliceFingerprint,
+				Data:           []byte(aliceKey),
+				Name:           "laptop",
+			},
+			{
+				ID:            "identity-bot",
+				TenantID:      tenantID,
+				PrincipalID:   "bot",
+				PrincipalName: "deployer",
+				PrincipalType: models.UserTypeService,
+				Fingerprint:   botFingerprint,
+				Data:          []byte(botKey),
+				Name:          "ci",
+				ExpiresAt:     &expiresAt,
+			},
+		},
+		installKeys: []models.InstallKey{
+			{ID: "key-fleet", Name: "fleet", TenantID: tenantID, Type: models.InstallKeyTypeUser, Mode: models.InstallKeyModeAutomatic, Reusable: true, Tags: []string{"web"}},
+			{ID: "key-legacy", Name: "legacy", TenantID: tenantID, Type: models.InstallKeyTypeLegacy, Mode: models.InstallKeyModeAutomatic},
+			{ID: "key-old", Name: "old", TenantID: tenantID, Type: models.InstallKeyTypeUser, Mode: models.InstallKeyModeAutomatic, Revoked: true},
+		},
+		tags: []models.Tag{
+			{ID: "tag-web", Name: "web"},
+			{ID: "tag-db", Name: "db"},
+		},
+		accounts: []models.ServiceAccount{
+			{ID: "bot", Name: "deployer"},
+		},
+	}
+
+	keys := map[string]string{
+		"alice":             strings.TrimSpace(aliceKey),
+		"alice-fingerprint": aliceFingerprint,
+		"bot":               strings.TrimSpace(botKey),
+		"bot-fingerprint":   botFingerprint,
+	}
+
+	return fixture, keys
+}
+
+func TestExportPolicyBundle(t *testing.T) {
+	ctx := context.TODO()
+
+	fixture, keys := newPolicyBundleFixture(t)
+
+	storeMock := new(storemock.MockStore)
+	queryOptionsMock := new(storemock.MockQueryOptions)
+	storeMock.On("Options").Return(queryOptionsMock).Maybe()
+
+	fixture.mock(ctx, storeMock)
+
+	s := NewService(storeMock, privateKey, publicKey, nil)
+
+	bundle, err := s.ExportPolicyBundle(ctx, &requests.PolicyBundleExport{TenantID: fixture.namespace.TenantID})
+	require.NoError(t, err)
+
+	assert.Equal(t, &models.PolicyBundle{
+		AccessPolicies: []models.PolicyBundleAccessPolicy{
+			{
+				Name:    "db",
+				Subject: models.PolicySubject{Type: models.PolicySubjectRole, Value: "administrator"},
+				Filter:  models.PolicyBundleFilter{Hostname: "^db-"},
+				Logins:  []string{"postgres"},
+				Action:  models.PolicyActionAllow,
+			},
+			{
+				Name:    "web",
+				Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+				Filter:  models.PolicyBundleFilter{Tags: []string{"web"}},
+				Logins:  []string{"deploy"},
+				Action:  models.PolicyActionAllow,
+			},
+		},
+		SSHIdentities: []models.PolicyBundleSSHIdentity{
+			{Principal: "alice@example.com", Name: "laptop", PublicKey: keys["alice"]},
+			{Principal: "deployer", Name: "ci", PublicKey: keys["bot"], ExpiresAt: fixture.identities[1].ExpiresAt},
+		},
+		InstallKeys: []models.PolicyBundleInstallKey{
+			{Name: "fleet", Mode: models.InstallKeyModeAutomatic, Tags: []string{"web"}},
+		},
+	}, bundle)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestApplyPolicyBundle(t *testing.T) {
+	ctx := context.TODO()
+
+	// A subtest swaps the global uuid backend for a mock; restore it so the mock does not leak
+	// into other tests that call uuid.Generate.
+	prevUUID := uuid.DefaultBackend
+	defer func() { uuid.DefaultBackend = prevUUID }()
+
+	fixture, keys := newPolicyBundleFixture(t)
+	tenantID := fixture.namespace.TenantID
+
+	// current is the bundle the fixture exports, which applies as a no-op.
+	current := func() models.PolicyBundle {
+		return models.PolicyBundle{
+			AccessPolicies: []models.PolicyBundleAccessPolicy{
+				{
+					Name:    "db",
+					Subject: models.PolicySubject{Type: models.PolicySubjectRole, Value: "administrator"},
+					Filter:  models.PolicyBundleFilter{Hostname: "^db-"},
+					Logins:  []string{"postgres"},
+				},
+				{
+					Name:    "web",
+					Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+					Filter:  models.PolicyBundleFilter{Tags: []string{"web"}},
+					Logins:  []string{"deploy"},
+				},
+			},
+			SSHIdentities: []models.PolicyBundleSSHIdentity{
+				{Principal: "alice@example.com", Name: "laptop", PublicKey: keys["alice"]},
+				{Principal: "deployer", Name: "ci", PublicKey: keys["bot"], ExpiresAt: fixture.identities[1].ExpiresAt},
+			},
+			InstallKeys: []models.PolicyBundleInstallKey{
+				{Name: "fleet", Tags: []string{"web"}},
+			},
+		}
+	}
+
+	withTransaction := func(storeMock *storemock.MockStore) {
+		storeMock.On("WithTransaction", ctx, mock.AnythingOfType("store.TransactionCb")).
+			Return(func(ctx context.Context, cb store.TransactionCb) error { return cb(ctx) }).Once()
+	}
+
+	cases := []struct {
+		description    string
+		bundle         func() models.PolicyBundle
+		dryRun         bool
+		requireMocks   func(t *testing.T, storeMock *storemock.MockStore)
+		expected       []models.PolicyBundleChange
+		expectedFields map[string]string
+		expectedErr    error
+	}{
+		{
+			description: "plans nothing when the namespace already matches the bundle",
+			bundle:      current,
+			requireMocks: func(_ *testing.T, storeMock *storemock.MockStore) {
+				withTransaction(storeMock)
+			},
+			expected: []models.PolicyBundleChange{},
+		},
+		{
+			description: "leaves the sections the bundle omits untouched",
+			bundle:      func() models.PolicyBundle { return models.PolicyBundle{} },
+			requireMocks: func(_ *testing.T, storeMock *storemock.MockStore) {
+				withTransaction(storeMock)
+			},
+			expected: []models.PolicyBundleChange{},
+		},
+		{
+			description: "plans without writing on a dry run",
+			bundle: func() models.PolicyBundle {
+				bundle := current()
+				bundle.AccessPolicies[1].Logins = []string{"deploy", "www-data"}
+				bundle.AccessPolicies = append(bundle.AccessPolicies[1:], models.PolicyBundleAccessPolicy{
+					Name:    "ops",
+					Subject: models.PolicySubject{Type: models.PolicySubjectRole, Value: "operator"},
+					Filter:  models.PolicyBundleFilter{Tags: []string{"db"}},
+					Logins:  []string{"ops"},
+				})
+
+				return bundle
+			},
+			dryRun:       true,
+			requireMocks: func(_ *testing.T, _ *storemock.MockStore) {},
+			expected: []models.PolicyBundleChange{
+				{Kind: models.PolicyBundleKindAccessPolicy, Name: "web", Action: models.PolicyBundleActionUpdate, Fields: []string{"logins"}},
+				{Kind: models.PolicyBundleKindAccessPolicy, Name: "ops", Action: models.PolicyBundleActionCreate},
+				{Kind: models.PolicyBundleKindAccessPolicy, Name: "db", Action: models.PolicyBundleActionDelete},
+			},
+		},
+		{
+			description: "applies access policy changes in one transaction",
+			bundle: func() models.PolicyBundle {
+				bundle := current()
+				bundle.AccessPolicies[1].Logins = []string{"deploy", "www-data"}
+				bundle.AccessPolicies = append(bundle.AccessPolicies[1:], models.PolicyBundleAccessPolicy{
+					Name:    "ops",
+					Subject: models.PolicySubject{Type: models.PolicySubjectRole, Value: "operator"},
+					Filter:  models.PolicyBundleFilter{Tags: []string{"db"}},
+					Logins:  []string{"ops"},
+				})
+
+				return bundle
+			},
+			requireMocks: func(_ *testing.T, storeMock *storemock.MockStore) {
+				withTransaction(storeMock)
+				storeMock.On("AccessPolicyUpdate", ctx, mock.MatchedBy(func(policy *models.AccessPolicy) bool {
+					return policy.ID == "policy-web" && len(policy.Logins) == 2
+				})).Return(nil).Once()
+				storeMock.On("AccessPolicyCreate", ctx, mock.MatchedBy(func(policy *models.AccessPolicy) bool {
+					return policy.Name == "ops" && policy.TenantID == tenantID &&
+						policy.Action == models.PolicyActionAllow && len(policy.Filter.TagIDs) == 1 && policy.Filter.TagIDs[0] == "tag-db"
+				})).Return("policy-ops", nil).Once()
+				storeMock.On("AccessPolicyDelete", ctx, mock.MatchedBy(func(policy *models.AccessPolicy) bool {
+					return policy.ID == "policy-db"
+				})).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionAccessPolicyUpdate && event.TargetID == "policy-web" &&
+						event.TenantID == tenantID && event.After["logins"] != nil && event.After["name"] == nil
+				})).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionAccessPolicyCreate && event.TargetID == "policy-ops" &&
+						event.Before == nil && event.After["name"] == "ops"
+				})).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionAccessPolicyDelete && event.TargetID == "policy-db" &&
+						event.Before["name"] == "db" && event.After == nil
+				})).Return(nil).Once()
+				storeMock.On("SessionListActive", ctx, mock.Anything, "").Return([]models.Session{}, nil).Once()
+			},
+			expected: []models.PolicyBundleChange{
+				{Kind: models.PolicyBundleKindAccessPolicy, Name: "web", Action: models.PolicyBundleActionUpdate, Fields: []string{"logins"}},
+				{Kind: models.PolicyBundleKindAccessPolicy, Name: "ops", Action: models.PolicyBundleActionCreate},
+				{Kind: models.PolicyBundleKindAccessPolicy, Name: "db", Action: models.PolicyBundleActionDelete},
+			},
+		},
+		{
+			description: "fails as a whole when a step fails",
+			bundle: func() models.PolicyBundle {
+				bundle := current()
+				bundle.AccessPolicies = bundle.AccessPolicies[1:]
+
+				return bundle
+			},
+			requireMocks: func(_ *testing.T, storeMock *storemock.MockStore) {
+				withTransaction(storeMock)
+				storeMock.On("AccessPolicyDelete", ctx, mock.Anything).Return(stderrors.New("error")).Once()
+			},
+			expectedErr: stderrors.New("error"),
+		},
+		{
+			description: "updates, rebinds and deletes SSH identities",
+			bundle: func() models.PolicyBundle {
+				bundle := current()
+				bundle.SSHIdentities = []models.PolicyBundleSSHIdentity{
+					{Principal: "alice@example.com", Name: "work laptop", PublicKey: keys["alice"], SingleUse: true},
+					{Principal: "alice@example.com", PublicKey: keys["bot"]},
+				}
+
+				return bundle
+			},
+			requireMocks: func(_ *testing.T, storeMock *storemock.MockStore) {
+				withTransaction(storeMock)
+				storeMock.On("SSHIdentityUpdate", ctx, mock.MatchedBy(func(identity *models.SSHIdentity) bool {
+					return identity.ID == "identity-alice" && identity.Name == "work laptop" && identity.SingleUse
+				})).Return(nil).Once()
+				storeMock.On("SSHIdentityDelete", ctx, mock.MatchedBy(func(identity *models.SSHIdentity) bool {
+					return identity.ID == "identity-bot"
+				})).Return(nil).Once()
+				storeMock.On("SSHIdentityCreate", ctx, mock.MatchedBy(func(identity *models.SSHIdentity) bool {
+					return identity.PrincipalID == "alice" && identity.Fingerprint == keys["bot-fingerprint"] && identity.Name != ""
+				})).Return("identity-rebound", nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionSSHIdentityUpdate && event.TargetID == "identity-alice"
+				})).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionSSHIdentityRevoke && event.TargetID == "identity-bot"
+				})).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionSSHIdentityCreate && event.TargetID == "identity-rebound"
+				})).Return(nil).Once()
+				storeMock.On("SessionListActive", ctx, mock.Anything, "").Return([]models.Session{}, nil).Once()
+			},
+			expected: []models.PolicyBundleChange{
+				{Kind: models.PolicyBundleKindSSHIdentity, Name: keys["alice-fingerprint"], Action: models.PolicyBundleActionUpdate, Fields: []string{"name", "single_use"}},
+				{Kind: models.PolicyBundleKindSSHIdentity, Name: keys["bot-fingerprint"], Action: models.PolicyBundleActionUpdate, Fields: []string{"principal"}},
+			},
+		},
+		{
+			description: "creates and revokes install keys",
+			bundle: func() models.PolicyBundle {
+				bundle := current()
+				bundle.InstallKeys = []models.PolicyBundleInstallKey{
+					{Name: "lab", UsageLimit: 1},
+				}
+
+				return bundle
+			},
+			requireMocks: func(t *testing.T, storeMock *storemock.MockStore) {
+				uuidMock := uuidmock.NewMockUUID(t)
+				uuid.DefaultBackend = uuidMock
+				uuidMock.On("Generate").Return("f4b3c2a1-0000-4000-8000-000000000000").Once()
+
+				withTransaction(storeMock)
+				storeMock.On("InstallKeyCreate", ctx, mock.MatchedBy(func(installKey *models.InstallKey) bool {
+					return installKey.Name == "lab" && installKey.ID != "" && installKey.KeyHint != "" &&
+						!installKey.Reusable && installKey.CreatedBy == "alice" && installKey.Type == models.InstallKeyTypeUser
+				})).Return("key-lab", nil).Once()
+				storeMock.On("InstallKeyUpdate", ctx, mock.MatchedBy(func(installKey *models.InstallKey) bool {
+					return installKey.ID == "key-fleet" && installKey.Revoked
+				})).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionInstallKeyCreate && event.After["name"] == "lab"
+				})).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionInstallKeyRevoke && event.TargetID == "key-fleet"
+				})).Return(nil).Once()
+			},
+			expected: []models.PolicyBundleChange{
+				{Kind: models.PolicyBundleKindInstallKey, Name: "lab", Action: models.PolicyBundleActionCreate},
+				{Kind: models.PolicyBundleKindInstallKey, Name: "fleet", Action: models.PolicyBundleActionRevoke},
+			},
+		},
+		{
+			description: "rejects a bundle that does not fit the namespace",
+			bundle: func() models.PolicyBundle {
+				bundle := current()
+				bundle.AccessPolicies[0].Filter = models.PolicyBundleFilter{Tags: []string{"missing"}}
+				bundle.AccessPolicies[0].Schedule = &models.PolicySchedule{
+					Timezone: "Mars/Olympus_Mons",
+					Windows:  []models.PolicyScheduleWindow{{Weekdays: []string{"mon", "someday"}, Start: "8:00", End: "24:30"}},
+				}
+				bundle.AccessPolicies[1].Name = "db"
+				bundle.SSHIdentities[0].Principal = "mallory@example.com"
+				bundle.SSHIdentities[1].PublicKey = "not a key"
+				bundle.InstallKeys = []models.PolicyBundleInstallKey{
+					{Name: "legacy"},
+					{Name: "hooks", Mode: models.InstallKeyModeWebhook, WebhookURL: "https://example.com"},
+				}
+
+				return bundle
+			},
+			requireMocks: func(_ *testing.T, storeMock *storemock.MockStore) {
+				withTransaction(storeMock)
+			},
+			expectedFields: map[string]string{
+				"access_policies[0].filter.tags[0]":                  `tag "missing" does not exist`,
+				"access_policies[0].schedule.timezone":               `"Mars/Olympus_Mons" is not an IANA time zone`,
+				"access_policies[0].schedule.windows[0].weekdays[1]": `"someday" is not a weekday from mon to sun`,
+				"access_policies[0].schedule.windows[0].start":       "must be a time from 00:00 to 24:00",
+				"access_policies[0].schedule.windows[0].end":         "must be a time from 00:00 to 24:00",
+				"access_policies[1].name":                            "is duplicated in the bundle",
+				"ssh_identities[0].principal":                        `"mallory@example.com" is not a member or service account of the namespace`,
+				"ssh_identities[1].public_key":                       "is not a valid OpenSSH public key",
+				"install_keys[0].name":                               "names a system install key, which a bundle does not manage",
+				"install_keys[1].webhook_secret":                     "is required for webhook mode",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := new(storemock.MockStore)
+			queryOptionsMock := new(storemock.MockQueryOptions)
+			storeMock.On("Options").Return(queryOptionsMock).Maybe()
+
+			fixture.mock(ctx, storeMock)
+			tc.requireMocks(t, storeMock)
+
+			s := NewService(storeMock, privateKey, publicKey, nil)
+
+			plan, err := s.ApplyPolicyBundle(ctx, &requests.PolicyBundleApply{
+				TenantID:     tenantID,
+				UserID:       "alice",
+				DryRun:       tc.dryRun,
+				PolicyBundle: tc.bundle(),
+			})
+
+			switch {
+			case tc.expectedFields != nil:
+				require.ErrorIs(t, err, ErrPolicyBundleInvalid)
+
+				var e errors.Error
+				require.True(t, stderrors.As(err, &e))
+				assert.Equal(t, ErrDataInvalidFields{Fields: tc.expectedFields}, e.Data)
+			case tc.expectedErr != nil:
+				assert.Equal(t, tc.expectedErr, err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, &models.PolicyBundlePlan{DryRun: tc.dryRun, Changes: tc.expected}, plan)
+			}
+
+			storeMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,757 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/shellhub-io/shellhub/server/api/pkg/grouprole"
+	"github.com/shellhub-io/shellhub/server/api/pkg/scim"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	log "github.com/sirupsen/logrus"
+)
+
+// WithSCIM turns on SCIM provisioning for the identity provider holding token, applying
+// groupRoles to the memberships of the users in its groups. Without it, the SCIM endpoint refuses
+// every request.
+func WithSCIM(token string, groupRoles grouprole.Mapping) Option {
+	return func(service *APIService) {
+		service.scimToken = token
+		service.scimGroupRoles = groupRoles
+	}
+}
+
+// SCIMService is the identity provider's side of user provisioning, through the SCIM 2.0 User and
+// Group resources.
+//
+// Users are the instance's human accounts; service accounts are not exposed. Deactivating a user
+// deprovisions them: in every namespace, their SSH identities are revoked, the API keys they created
+// are deleted and their sessions are closed, and they leave the namespaces they don't own. Groups
+// only grant roles through the group role mapping, by display name, which is reapplied to a member's
+// memberships whenever the groups they are in change.
+type SCIMService interface {
+	// AuthSCIM checks the bearer token the identity provider sent with a SCIM request.
+	AuthSCIM(ctx context.Context, token string) error
+
+	ListSCIMUsers(ctx context.Context, req *scim.ListRequest) (*scim.ListResponse, error)
+	GetSCIMUser(ctx context.Context, id string) (*scim.User, error)
+	// CreateSCIMUser provisions an account, which signs in through single sign-on only.
+	CreateSCIMUser(ctx context.Context, user *scim.User) (*scim.User, error)
+	ReplaceSCIMUser(ctx context.Context, id string, user *scim.User) (*scim.User, error)
+	PatchSCIMUser(ctx context.Context, id string, req *scim.PatchRequest) (*scim.User, error)
+	// DeleteSCIMUser deprovisions the user and deletes their account. A user who owns a namespace
+	// can't be deleted, only deactivated.
+	DeleteSCIMUser(ctx context.Context, id string) error
+
+	ListSCIMGroups(ctx context.Context, req *scim.ListRequest) (*scim.ListResponse, error)
+	GetSCIMGroup(ctx context.Context, id string) (*scim.Group, error)
+	CreateSCIMGroup(ctx context.Context, group *scim.Group) (*scim.Group, error)
+	ReplaceSCIMGroup(ctx context.Context, id string, group *scim.Group) (*scim.Group, error)
+	PatchSCIMGroup(ctx context.Context, id string, req *scim.PatchRequest) (*scim.Group, error)
+	DeleteSCIMGroup(ctx context.Context, id string) error
+}
+
+func (s *service) AuthSCIM(_ context.Context, token string) error {
+	if s.scimToken == "" {
+		return NewErrAuthMethodNotAllowed("scim")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.scimToken)) != 1 {
+		return NewErrAuthUnathorized(nil)
+	}
+
+	return nil
+}
+
+func (s *service) ListSCIMUsers(ctx context.Context, req *scim.ListRequest) (*scim.ListResponse, error) {
+	req.Normalize()
+
+	filter, err := scim.ParseFilter(req.Filter)
+	if err != nil {
+		return nil, NewErrSCIMRequestInvalid(err)
+	}
+
+	// A filter is how a provider looks a user up before provisioning them, so it matches at
+	// most one.
+	if filter != nil {
+		user, err := s.findSCIMUser(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		users, total := []scim.User{}, 0
+		if user != nil {
+			total = 1
+			if req.StartIndex == 1 {
+				users = append(users, *scimUser(user, nil))
+			}
+		}
+
+		return scim.NewListResponse(users, total, req.StartIndex), nil
+	}
+
+	paginator := &query.Paginator{Page: (req.StartIndex-1)/req.Count + 1, PerPage: req.Count}
+	users, count, err := s.store.UserList(
+		ctx,
+		s.store.Options().Match(&query.Filters{Data: []query.Filter{{
+			Type:   query.FilterTypeProperty,
+			Params: &query.FilterProperty{Name: "type", Operator: "eq", Value: string(models.UserTypeHuman)},
+		}}}),
+		s.store.Options().Sort(&query.Sorter{By: "created_at", Order: query.OrderAsc}),
+		s.store.Options().Paginate(paginator),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]scim.User, len(users))
+	for i := range users {
+		resources[i] = *scimUser(&users[i], nil)
+	}
+
+	return scim.NewListResponse(resources, count, (paginator.Page-1)*paginator.PerPage+1), nil
+}
+
+func (s *service) GetSCIMUser(ctx context.Context, id string) (*scim.User, error) {
+	user, err := s.scimUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := s.store.SCIMGroupListByMember(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scimUser(user, groups), nil
+}
+
+func (s *service) CreateSCIMUser(ctx context.Context, in *scim.User) (*scim.User, error) {
+	data, err := s.scimUserData(in)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Type:          models.UserTypeHuman,
+		Origin:        models.UserOriginSCIM,
+		ExternalID:    in.ExternalID,
+		Status:        models.UserStatusConfirmed,
+		Disabled:      !in.IsActive(),
+		MaxNamespaces: 0,
+		CreatedAt:     clock.Now(),
+		UserData:      data,
+		// As for the accounts single sign-on provisions, the password digest is a locked sentinel:
+		// the user signs in at the identity provider.
+		Password: models.UserPassword{Hash: "!"},
+		Preferences: models.UserPreferences{
+			AuthMethods: []models.UserAuthMethod{},
+		},
+	}
+
+	if user.ID, err = s.store.UserCreate(ctx, user); err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			if field, ok := store.DuplicatedField(err); ok {
+				return nil, NewErrUserDuplicated([]string{field}, err)
+			}
+
+			return nil, NewErrUserDuplicated([]string{}, err)
+		}
+
+		return nil, NewErrUserCreate(err)
+	}
+
+	return scimUser(user, []models.SCIMGroup{}), nil
+}
+
+func (s *service) ReplaceSCIMUser(ctx context.Context, id string, in *scim.User) (*scim.User, error) {
+	user, err := s.scimUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateSCIMUser(ctx, user, in)
+}
+
+func (s *service) PatchSCIMUser(ctx context.Context, id string, req *scim.PatchRequest) (*scim.User, error) {
+	user, err := s.scimUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	in := scimUser(user, nil)
+	if err := in.Patch(req.Operations); err != nil {
+		return nil, NewErrSCIMRequestInvalid(err)
+	}
+
+	return s.updateSCIMUser(ctx, user, in)
+}
+
+func (s *service) DeleteSCIMUser(ctx context.Context, id string) error {
+	user, err := s.scimUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	info, err := s.store.UserGetInfo(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(info.OwnedNamespaces) > 0 {
+		return NewErrSCIMUserOwnsNamespace(nil)
+	}
+
+	// The account is disabled first, so it can't be used again should deprovisioning or the
+	// deletion fail half-way.
+	if !user.Disabled {
+		user.Disabled = true
+		if err := s.store.UserUpdate(ctx, user); err != nil {
+			return NewErrUserUpdate(user, err)
+		}
+	}
+
+	if err := s.deprovisionUser(ctx, user); err != nil {
+		return err
+	}
+
+	if err := s.store.UserDelete(ctx, user); err != nil {
+		return NewErrUserDelete(err)
+	}
+
+	return nil
+}
+
+func (s *service) ListSCIMGroups(ctx context.Context, req *scim.ListRequest) (*scim.ListResponse, error) {
+	req.Normalize()
+
+	filter, err := scim.ParseFilter(req.Filter)
+	if err != nil {
+		return nil, NewErrSCIMRequestInvalid(err)
+	}
+
+	opts := []store.QueryOption{}
+	if filter != nil {
+		var column string
+		switch strings.ToLower(filter.Attribute) {
+		case "displayname":
+			column = "display_name"
+		case "externalid":
+			column = "external_id"
+		case "id":
+			column = "id"
+			if ok, _ := s.validator.Var(filter.Value, "uuid"); !ok {
+				return scim.NewListResponse([]scim.Group{}, 0, req.StartIndex), nil
+			}
+		default:
+			return nil, NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidFilter, "groups can't be filtered by %q", filter.Attribute))
+		}
+
+		opts = append(opts, s.store.Options().Match(&query.Filters{Data: []query.Filter{{
+			Type:   query.FilterTypeProperty,
+			Params: &query.FilterProperty{Name: column, Operator: "eq", Value: filter.Value},
+		}}}))
+	}
+
+	paginator := &query.Paginator{Page: (req.StartIndex-1)/req.Count + 1, PerPage: req.Count}
+	opts = append(
+		opts,
+		s.store.Options().Sort(&query.Sorter{By: "display_name", Order: query.OrderAsc}),
+		s.store.Options().Paginate(paginator),
+	)
+
+	groups, count, err := s.store.SCIMGroupList(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]scim.Group, len(groups))
+	for i := range groups {
+		resources[i] = *scimGroup(&groups[i])
+	}
+
+	return scim.NewListResponse(resources, count, (paginator.Page-1)*paginator.PerPage+1), nil
+}
+
+func (s *service) GetSCIMGroup(ctx context.Context, id string) (*scim.Group, error) {
+	group, err := s.scimGroupByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return scimGroup(group), nil
+}
+
+func (s *service) CreateSCIMGroup(ctx context.Context, in *scim.Group) (*scim.Group, error) {
+	group := &models.SCIMGroup{ExternalID: in.ExternalID}
+	if err := s.applySCIMGroup(ctx, group, in); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if group.ID, err = s.store.SCIMGroupCreate(ctx, group); err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			return nil, NewErrSCIMGroupDuplicated(err)
+		}
+
+		return nil, err
+	}
+
+	if err := s.syncSCIMMembers(ctx, group.Members); err != nil {
+		return nil, err
+	}
+
+	return scimGroup(group), nil
+}
+
+func (s *service) ReplaceSCIMGroup(ctx context.Context, id string, in *scim.Group) (*scim.Group, error) {
+	group, err := s.scimGroupByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateSCIMGroup(ctx, group, in)
+}
+
+func (s *service) PatchSCIMGroup(ctx context.Context, id string, req *scim.PatchRequest) (*scim.Group, error) {
+	group, err := s.scimGroupByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	in := scimGroup(group)
+	if err := in.Patch(req.Operations); err != nil {
+		return nil, NewErrSCIMRequestInvalid(err)
+	}
+
+	return s.updateSCIMGroup(ctx, group, in)
+}
+
+func (s *service) DeleteSCIMGroup(ctx context.Context, id string) error {
+	group, err := s.scimGroupByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.SCIMGroupDelete(ctx, group); err != nil {
+		return err
+	}
+
+	return s.syncSCIMMembers(ctx, group.Members)
+}
+
+// scimUserByID resolves a user the SCIM endpoint exposes.
+func (s *service) scimUserByID(ctx context.Context, id string) (*models.User, error) {
+	if ok, _ := s.validator.Var(id, "uuid"); !ok {
+		return nil, NewErrUserNotFound(id, nil)
+	}
+
+	user, err := s.store.UserResolve(ctx, store.UserIDResolver, id)
+	if err != nil {
+		return nil, NewErrUserNotFound(id, err)
+	}
+
+	if user.Type == models.UserTypeService {
+		return nil, NewErrUserNotFound(id, nil)
+	}
+
+	return user, nil
+}
+
+// scimUserLookup is one way of finding the user a list filter names.
+type scimUserLookup struct {
+	resolver store.UserResolver
+	value    string
+}
+
+// findSCIMUser looks up the user a list filter names, nil when none is.
+func (s *service) findSCIMUser(ctx context.Context, filter *scim.Filter) (*models.User, error) {
+	value := strings.ToLower(filter.Value)
+
+	var candidates []scimUserLookup
+
+	attribute := strings.ToLower(filter.Attribute)
+	switch {
+	case attribute == "id":
+		if ok, _ := s.validator.Var(filter.Value, "uuid"); !ok {
+			return nil, nil
+		}
+
+		candidates = append(candidates, scimUserLookup{store.UserIDResolver, filter.Value})
+	case attribute == "username":
+		// Providers usually send the user's email as their userName, which is how it was
+		// provisioned when it isn't a valid username.
+		candidates = append(candidates, scimUserLookup{store.UserUsernameResolver, value}, scimUserLookup{store.UserEmailResolver, value})
+	case attribute == "externalid":
+		candidates = append(candidates, scimUserLookup{store.UserExternalIDResolver, filter.Value})
+	case attribute == "emails" || (strings.HasPrefix(attribute, "emails") && strings.HasSuffix(attribute, ".value")):
+		candidates = append(candidates, scimUserLookup{store.UserEmailResolver, value})
+	default:
+		return nil, NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidFilter, "users can't be filtered by %q", filter.Attribute))
+	}
+
+	for _, candidate := range candidates {
+		user, err := s.store.UserResolve(ctx, candidate.resolver, candidate.value)
+		switch {
+		case errors.Is(err, store.ErrNoDocuments):
+			continue
+		case err != nil:
+			return nil, err
+		case user.Type == models.UserTypeService:
+			continue
+		// The external ID of the other accounts is their subject at the OpenID Connect
+		// provider, which the SCIM endpoint doesn't expose.
+		case candidate.resolver == store.UserExternalIDResolver && user.Origin != models.UserOriginSCIM:
+			continue
+		}
+
+		return user, nil
+	}
+
+	return nil, nil
+}
+
+// scimUserData maps what the provider sent for a user onto their account's data.
+func (s *service) scimUserData(in *scim.User) (models.UserData, error) {
+	userName := strings.ToLower(strings.TrimSpace(in.UserName))
+	email := strings.ToLower(strings.TrimSpace(in.PrimaryEmail()))
+
+	username := userName
+	if ok, _ := s.validator.Var(userName, validator.UserNameTag); !ok {
+		// A userName a username can't hold, like a long email, is cut down to the email's local part.
+		local, _, _ := strings.Cut(userName, "@")
+		if ok, _ := s.validator.Var(local, validator.UserNameTag); !ok {
+			return models.UserData{}, NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidValue, "userName %q can't be made a valid username", in.UserName))
+		}
+
+		username = local
+	}
+
+	// Providers usually send the user's email as their userName, and no email besides.
+	if email == "" && strings.Contains(userName, "@") {
+		email = userName
+	}
+
+	name := []rune(strings.TrimSpace(in.FullName()))
+	if len(name) == 0 {
+		name = []rune(username)
+	}
+
+	data := models.UserData{
+		Name:     string(name[:min(len(name), 64)]),
+		Username: username,
+		Email:    email,
+	}
+
+	if ok, err := s.validator.Struct(data); !ok || err != nil {
+		return models.UserData{}, NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidValue, "user %q needs a valid username and email", in.UserName))
+	}
+
+	return data, nil
+}
+
+// updateSCIMUser applies what the provider sent for an existing user, deprovisioning them when it
+// deactivates them and restoring the memberships their groups grant when it activates them again.
+func (s *service) updateSCIMUser(ctx context.Context, user *models.User, in *scim.User) (*scim.User, error) {
+	data, err := s.scimUserData(in)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Name = data.Name
+	user.Username = data.Username
+	user.Email = data.Email
+
+	if user.Origin == models.UserOriginSCIM {
+		user.ExternalID = in.ExternalID
+	}
+
+	wasDisabled := user.Disabled
+	user.Disabled = !in.IsActive()
+
+	if err := s.store.UserUpdate(ctx, user); err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			if field, ok := store.DuplicatedField(err); ok {
+				return nil, NewErrUserDuplicated([]string{field}, err)
+			}
+
+			return nil, NewErrUserDuplicated([]string{}, err)
+		}
+
+		return nil, NewErrUserUpdate(user, err)
+	}
+
+	switch {
+	case user.Disabled && !wasDisabled:
+		if err := s.deprovisionUser(ctx, user); err != nil {
+			return nil, err
+		}
+	case !user.Disabled && wasDisabled:
+		if err := s.syncSCIMMemberships(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	groups, err := s.store.SCIMGroupListByMember(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scimUser(user, groups), nil
+}
+
+// deprovisionUser takes away what a deactivated user reaches the instance with. In every namespace,
+// their SSH identities are revoked; they leave the namespaces they don't own, which deletes the API
+// keys they created there and closes their sessions, and in the namespaces they own, which can't lose
+// their owner, the keys are deleted and the sessions closed all the same.
+func (s *service) deprovisionUser(ctx context.Context, user *models.User) error {
+	info, err := s.store.UserGetInfo(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, namespace := range info.AssociatedNamespaces {
+		if err := s.revokeSSHIdentities(ctx, namespace.TenantID, user.ID); err != nil {
+			return err
+		}
+
+		member, ok := namespace.FindMember(user.ID)
+		if !ok {
+			continue
+		}
+
+		if err := s.removeMember(ctx, &namespace, member); err != nil {
+			return err
+		}
+
+		if user.Preferences.PreferredNamespace == namespace.TenantID {
+			// preferred_namespace_id is skipupdate, so a full-model UserUpdate can't clear it.
+			if err := s.store.UserUpdatePreferredNamespace(ctx, user.ID, ""); err != nil {
+				return NewErrUserUpdate(user, err)
+			}
+		}
+
+		s.AuthUncacheToken(ctx, namespace.TenantID, user.ID) // nolint: errcheck
+	}
+
+	for _, namespace := range info.OwnedNamespaces {
+		if err := s.revokeSSHIdentities(ctx, namespace.TenantID, user.ID); err != nil {
+			return err
+		}
+
+		if err := s.store.APIKeyDeleteAllByCreator(ctx, namespace.TenantID, user.ID); err != nil {
+			return err
+		}
+
+		for _, session := range s.activeSessions(ctx, namespace.TenantID, user.ID) {
+			s.terminateSession(ctx, namespace.TenantID, &session, sessionRevokedAccount)
+		}
+
+		s.AuthUncacheToken(ctx, namespace.TenantID, user.ID) // nolint: errcheck
+	}
+
+	return nil
+}
+
+// revokeSSHIdentities revokes the SSH identities the user holds in a namespace.
+func (s *service) revokeSSHIdentities(ctx context.Context, tenantID, userID string) error {
+	sc, err := BoundTo(tenantID)
+	if err != nil {
+		return err
+	}
+
+	identities, _, err := s.store.SSHIdentityList(ctx, sc, s.store.Options().WithUserID(userID))
+	if err != nil {
+		return err
+	}
+
+	for _, identity := range identities {
+		req := &requests.SSHIdentityDelete{
+			SSHIdentityIDParam: requests.SSHIdentityIDParam{ID: identity.ID},
+			TenantID:           tenantID,
+			UserID:             userID,
+			Manage:             true,
+		}
+
+		if err := s.DeleteSSHIdentity(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncSCIMMemberships applies the group role mapping to the memberships of a user, from the display
+// names of the groups they are in.
+func (s *service) syncSCIMMemberships(ctx context.Context, user *models.User) error {
+	groups, err := s.store.SCIMGroupListByMember(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(groups))
+	for i, group := range groups {
+		names[i] = group.DisplayName
+	}
+
+	return s.syncMemberships(ctx, user, s.scimGroupRoles.Roles(names))
+}
+
+// syncSCIMMembers applies the group role mapping to the memberships of each active user in ids,
+// after the groups they are in changed.
+func (s *service) syncSCIMMembers(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		user, err := s.store.UserResolve(ctx, store.UserIDResolver, id)
+		if err != nil {
+			log.WithError(err).WithField("user_id", id).Warn("failed to resolve a SCIM group member to sync")
+
+			continue
+		}
+
+		if user.Disabled {
+			continue
+		}
+
+		if err := s.syncSCIMMemberships(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scimGroupByID resolves a SCIM group.
+func (s *service) scimGroupByID(ctx context.Context, id string) (*models.SCIMGroup, error) {
+	if ok, _ := s.validator.Var(id, "uuid"); !ok {
+		return nil, NewErrSCIMGroupNotFound(id, nil)
+	}
+
+	group, err := s.store.SCIMGroupResolve(ctx, store.SCIMGroupIDResolver, id)
+	if err != nil {
+		return nil, NewErrSCIMGroupNotFound(id, err)
+	}
+
+	return group, nil
+}
+
+// applySCIMGroup maps what the provider sent for a group onto it. Its members must be users the
+// SCIM endpoint exposes.
+func (s *service) applySCIMGroup(ctx context.Context, group *models.SCIMGroup, in *scim.Group) error {
+	displayName := strings.TrimSpace(in.DisplayName)
+	if displayName == "" {
+		return NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidValue, "a group needs a displayName"))
+	}
+
+	members := make([]string, 0, len(in.Members))
+	for _, id := range in.MemberIDs() {
+		if slices.Contains(members, id) {
+			continue
+		}
+
+		if _, err := s.scimUserByID(ctx, id); err != nil {
+			return NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidValue, "group member %q is not a user", id))
+		}
+
+		members = append(members, id)
+	}
+
+	group.DisplayName = displayName
+	group.Members = members
+
+	return nil
+}
+
+// updateSCIMGroup applies what the provider sent for an existing group, then the group role mapping
+// to the memberships of the users who joined or left it.
+func (s *service) updateSCIMGroup(ctx context.Context, group *models.SCIMGroup, in *scim.Group) (*scim.Group, error) {
+	before := group.Members
+	renamed := group.DisplayName != strings.TrimSpace(in.DisplayName)
+
+	if err := s.applySCIMGroup(ctx, group, in); err != nil {
+		return nil, err
+	}
+
+	group.ExternalID = in.ExternalID
+
+	if err := s.store.SCIMGroupUpdate(ctx, group); err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			return nil, NewErrSCIMGroupDuplicated(err)
+		}
+
+		return nil, err
+	}
+
+	// A rename can change the roles of every member, not only of those who joined or left.
+	affected := make([]string, 0, len(before)+len(group.Members))
+	for _, id := range slices.Concat(before, group.Members) {
+		if slices.Contains(affected, id) {
+			continue
+		}
+
+		if renamed || slices.Contains(before, id) != slices.Contains(group.Members, id) {
+			affected = append(affected, id)
+		}
+	}
+
+	if err := s.syncSCIMMembers(ctx, affected); err != nil {
+		return nil, err
+	}
+
+	return scimGroup(group), nil
+}
+
+// scimUser is the SCIM resource of a user, in groups when they are not nil. The external ID is only
+// exposed for the accounts the provider provisioned.
+func scimUser(user *models.User, groups []models.SCIMGroup) *scim.User {
+	active := !user.Disabled
+
+	out := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          user.ID,
+		UserName:    user.Username,
+		DisplayName: user.Name,
+		Emails:      []scim.Email{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta:        &scim.Meta{ResourceType: "User", Created: user.CreatedAt},
+	}
+
+	if user.Origin == models.UserOriginSCIM {
+		out.ExternalID = user.ExternalID
+	}
+
+	if groups != nil {
+		out.Groups = make([]scim.Reference, len(groups))
+		for i, group := range groups {
+			out.Groups[i] = scim.Reference{Value: group.ID, Display: group.DisplayName}
+		}
+	}
+
+	return out
+}
+
+// scimGroup is the SCIM resource of a group.
+func scimGroup(group *models.SCIMGroup) *scim.Group {
+	updatedAt := group.UpdatedAt
+
+	out := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          group.ID,
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Members:     make([]scim.Reference, len(group.Members)),
+		Meta:        &scim.Meta{ResourceType: "Group", Created: group.CreatedAt, LastModified: &updatedAt},
+	}
+
+	for i, id := range group.Members {
+		out.Members[i] = scim.Reference{Value: id}
+	}
+
+	return out
+}
@@ -0,0 +1,561 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	mockcache "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/pkg/grouprole"
+	"github.com/shellhub-io/shellhub/server/api/pkg/scim"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAuthSCIM(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("fails when SCIM is not configured", func(t *testing.T) {
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, mockcache.NewMockCache(t))
+
+		assert.Equal(t, NewErrAuthMethodNotAllowed("scim"), service.AuthSCIM(ctx, "token"))
+	})
+
+	t.Run("fails when the token does not match", func(t *testing.T) {
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, mockcache.NewMockCache(t), WithSCIM("token", nil))
+
+		assert.Equal(t, NewErrAuthUnathorized(nil), service.AuthSCIM(ctx, "other"))
+		assert.Equal(t, NewErrAuthUnathorized(nil), service.AuthSCIM(ctx, ""))
+	})
+
+	t.Run("succeeds when the token matches", func(t *testing.T) {
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, mockcache.NewMockCache(t), WithSCIM("token", nil))
+
+		assert.NoError(t, service.AuthSCIM(ctx, "token"))
+	})
+}
+
+func TestListSCIMUsers(t *testing.T) {
+	ctx := context.TODO()
+
+	const userID = "00000000-0000-4000-0000-000000000001"
+
+	created := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	active := true
+
+	cases := []struct {
+		description   string
+		req           *scim.ListRequest
+		requiredMocks func(storeMock *mocks.MockStore, queryOptionsMock *mocks.MockQueryOptions)
+		expected      *scim.ListResponse
+		expectedErr   error
+	}{
+		{
+			description:   "fails when the filter names an unsupported attribute",
+			req:           &scim.ListRequest{Filter: `title eq "engineer"`},
+			requiredMocks: func(_ *mocks.MockStore, _ *mocks.MockQueryOptions) {},
+			expectedErr:   NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidFilter, `users can't be filtered by "title"`)),
+		},
+		{
+			description: "succeeds finding a user provisioned from their email",
+			req:         &scim.ListRequest{Filter: `userName eq "Jane@Example.com"`},
+			requiredMocks: func(storeMock *mocks.MockStore, _ *mocks.MockQueryOptions) {
+				storeMock.
+					On("UserResolve", ctx, store.UserUsernameResolver, "jane@example.com").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("UserResolve", ctx, store.UserEmailResolver, "jane@example.com").
+					Return(&models.User{
+						ID:        userID,
+						Type:      models.UserTypeHuman,
+						Origin:    models.UserOriginSCIM,
+						CreatedAt: created,
+						UserData:  models.UserData{Name: "Jane Doe", Username: "jane", Email: "jane@example.com"},
+					}, nil).
+					Once()
+			},
+			expected: scim.NewListResponse([]scim.User{{
+				Schemas:     []string{scim.SchemaUser},
+				ID:          userID,
+				UserName:    "jane",
+				DisplayName: "Jane Doe",
+				Emails:      []scim.Email{{Value: "jane@example.com", Type: "work", Primary: true}},
+				Active:      &active,
+				Meta:        &scim.Meta{ResourceType: "User", Created: created},
+			}}, 1, 1),
+		},
+		{
+			description: "succeeds finding no service account",
+			req:         &scim.ListRequest{Filter: `userName eq "robot"`},
+			requiredMocks: func(storeMock *mocks.MockStore, _ *mocks.MockQueryOptions) {
+				storeMock.
+					On("UserResolve", ctx, store.UserUsernameResolver, "robot").
+					Return(&models.User{ID: userID, Type: models.UserTypeService}, nil).
+					Once()
+				storeMock.
+					On("UserResolve", ctx, store.UserEmailResolver, "robot").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: scim.NewListResponse([]scim.User{}, 0, 1),
+		},
+		{
+			description: "succeeds listing a page of the human accounts",
+			req:         &scim.ListRequest{StartIndex: 11, Count: 10},
+			requiredMocks: func(storeMock *mocks.MockStore, queryOptionsMock *mocks.MockQueryOptions) {
+				queryOptionsMock.
+					On("Match", &query.Filters{Data: []query.Filter{{
+						Type:   query.FilterTypeProperty,
+						Params: &query.FilterProperty{Name: "type", Operator: "eq", Value: "human"},
+					}}}).
+					Return(nil).
+					Once()
+				queryOptionsMock.
+					On("Sort", &query.Sorter{By: "created_at", Order: query.OrderAsc}).
+					Return(nil).
+					Once()
+				queryOptionsMock.
+					On("Paginate", &query.Paginator{Page: 2, PerPage: 10}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("UserList", ctx, testifymock.Anything).
+					Return([]models.User{}, 10, nil).
+					Once()
+			},
+			expected: scim.NewListResponse([]scim.User{}, 10, 11),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := mocks.NewMockStore(t)
+			queryOptionsMock := mocks.NewMockQueryOptions(t)
+			storeMock.On("Options").Return(queryOptionsMock).Maybe()
+
+			tc.requiredMocks(storeMock, queryOptionsMock)
+
+			service := NewService(storeMock, privateKey, publicKey, mockcache.NewMockCache(t), WithSCIM("token", nil))
+
+			res, err := service.ListSCIMUsers(ctx, tc.req)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+func TestCreateSCIMUser(t *testing.T) {
+	ctx := context.TODO()
+
+	const userID = "00000000-0000-4000-0000-000000000001"
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	prevClock := clock.DefaultBackend
+	t.Cleanup(func() { clock.DefaultBackend = prevClock })
+	clockMock := clockmock.NewMockClock(t)
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now).Maybe()
+
+	inactive := false
+
+	cases := []struct {
+		description   string
+		user          *scim.User
+		requiredMocks func(storeMock *mocks.MockStore)
+		expected      *scim.User
+		expectedErr   error
+	}{
+		{
+			description:   "fails when the userName can't be made a username",
+			user:          &scim.User{UserName: "Jane Doe"},
+			requiredMocks: func(_ *mocks.MockStore) {},
+			expectedErr:   NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidValue, `userName "Jane Doe" can't be made a valid username`)),
+		},
+		{
+			description: "fails when the account exists",
+			user:        &scim.User{UserName: "jane@example.com"},
+			requiredMocks: func(storeMock *mocks.MockStore) {
+				storeMock.
+					On("UserCreate", ctx, testifymock.Anything).
+					Return("", store.ErrDuplicate).
+					Once()
+			},
+			expectedErr: NewErrUserDuplicated([]string{}, store.ErrDuplicate),
+		},
+		{
+			description: "succeeds provisioning an inactive account from an email",
+			user: &scim.User{
+				ExternalID: "external",
+				UserName:   "Jane@Example.com",
+				Name:       &scim.Name{GivenName: "Jane", FamilyName: "Doe"},
+				Active:     &inactive,
+			},
+			requiredMocks: func(storeMock *mocks.MockStore) {
+				storeMock.
+					On("UserCreate", ctx, &models.User{
+						Type:       models.UserTypeHuman,
+						Origin:     models.UserOriginSCIM,
+						ExternalID: "external",
+						Status:     models.UserStatusConfirmed,
+						Disabled:   true,
+						CreatedAt:  now,
+						UserData:   models.UserData{Name: "Jane Doe", Username: "jane@example.com", Email: "jane@example.com"},
+						Password:   models.UserPassword{Hash: "!"},
+						Preferences: models.UserPreferences{
+							AuthMethods: []models.UserAuthMethod{},
+						},
+					}).
+					Return(userID, nil).
+					Once()
+			},
+			expected: &scim.User{
+				Schemas:     []string{scim.SchemaUser},
+				ID:          userID,
+				ExternalID:  "external",
+				UserName:    "jane@example.com",
+				DisplayName: "Jane Doe",
+				Emails:      []scim.Email{{Value: "jane@example.com", Type: "work", Primary: true}},
+				Active:      &inactive,
+				Groups:      []scim.Reference{},
+				Meta:        &scim.Meta{ResourceType: "User", Created: now},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := mocks.NewMockStore(t)
+			tc.requiredMocks(storeMock)
+
+			service := NewService(storeMock, privateKey, publicKey, mockcache.NewMockCache(t), WithSCIM("token", nil))
+
+			user, err := service.CreateSCIMUser(ctx, tc.user)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, user)
+		})
+	}
+}
+
+func TestPatchSCIMUserDeactivation(t *testing.T) {
+	ctx := context.TODO()
+
+	const (
+		userID     = "00000000-0000-4000-0000-000000000001"
+		tenantID   = "00000000-0000-4000-0000-000000000002"
+		ownedID    = "00000000-0000-4000-0000-000000000003"
+		identityID = "00000000-0000-4000-0000-000000000004"
+	)
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	prevClock := clock.DefaultBackend
+	t.Cleanup(func() { clock.DefaultBackend = prevClock })
+	clockMock := clockmock.NewMockClock(t)
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now).Maybe()
+
+	storeMock := mocks.NewMockStore(t)
+	queryOptionsMock := mocks.NewMockQueryOptions(t)
+	cacheMock := mockcache.NewMockCache(t)
+	storeMock.On("Options").Return(queryOptionsMock).Maybe()
+
+	user := &models.User{
+		ID:          userID,
+		Type:        models.UserTypeHuman,
+		Origin:      models.UserOriginSCIM,
+		ExternalID:  "external",
+		CreatedAt:   now,
+		UserData:    models.UserData{Name: "Jane Doe", Username: "jane", Email: "jane@example.com"},
+		Preferences: models.UserPreferences{PreferredNamespace: tenantID},
+	}
+
+	storeMock.
+		On("UserResolve", ctx, store.UserIDResolver, userID).
+		Return(user, nil).
+		Once()
+
+	disabled := *user
+	disabled.Disabled = true
+
+	storeMock.
+		On("UserUpdate", ctx, &disabled).
+		Return(nil).
+		Once()
+	storeMock.
+		On("UserGetInfo", ctx, userID).
+		Return(&models.UserInfo{
+			OwnedNamespaces: []models.Namespace{{TenantID: ownedID, Owner: userID}},
+			AssociatedNamespaces: []models.Namespace{{
+				TenantID: tenantID,
+				Owner:    "owner",
+				Members: []models.Member{
+					{ID: "owner", Role: authorizer.RoleOwner},
+					{ID: userID, Role: authorizer.RoleOperator},
+				},
+			}},
+		}, nil).
+		Once()
+
+	// In the namespace the user is a member of, their SSH identity is revoked and they leave it.
+	queryOptionsMock.On("WithUserID", userID).Return(nil).Twice()
+	storeMock.
+		On("SSHIdentityList", ctx, scope.MustBounded(tenantID), testifymock.Anything).
+		Return([]models.SSHIdentity{{ID: identityID, TenantID: tenantID, PrincipalID: userID}}, 1, nil).
+		Once()
+	storeMock.
+		On("SSHIdentityResolve", ctx, scope.MustBounded(tenantID), store.SSHIdentityIDResolver, identityID).
+		Return(&models.SSHIdentity{ID: identityID, TenantID: tenantID, PrincipalID: userID}, nil).
+		Once()
+	storeMock.
+		On("SSHIdentityDelete", ctx, &models.SSHIdentity{ID: identityID, TenantID: tenantID}).
+		Return(nil).
+		Once()
+	storeMock.
+		On("AuditEventCreate", ctx, testifymock.MatchedBy(func(event *models.AuditEvent) bool {
+			return event.Action == models.AuditActionSSHIdentityRevoke
+		})).
+		Return(nil).
+		Once()
+	storeMock.
+		On("SessionListActive", ctx, scope.MustBounded(tenantID), userID).
+		Return([]models.Session{}, nil).
+		Twice()
+	storeMock.
+		On("NamespaceDeleteMembership", ctx, scope.MustBounded(tenantID), &models.Member{ID: userID, Role: authorizer.RoleOperator}).
+		Return(nil).
+		Once()
+	storeMock.
+		On("AuditEventCreate", ctx, testifymock.MatchedBy(func(event *models.AuditEvent) bool {
+			return event.Action == models.AuditActionMemberRemove && event.TargetID == userID && event.After == nil
+		})).
+		Return(nil).
+		Once()
+	storeMock.
+		On("APIKeyDeleteAllByCreator", ctx, tenantID, userID).
+		Return(nil).
+		Once()
+	storeMock.
+		On("UserUpdatePreferredNamespace", ctx, userID, "").
+		Return(nil).
+		Once()
+	cacheMock.
+		On("Delete", ctx, "token_"+tenantID+userID).
+		Return(nil).
+		Once()
+
+	// In the namespace the user owns, their keys are deleted and their sessions closed.
+	storeMock.
+		On("SSHIdentityList", ctx, scope.MustBounded(ownedID), testifymock.Anything).
+		Return([]models.SSHIdentity{}, 0, nil).
+		Once()
+	storeMock.
+		On("APIKeyDeleteAllByCreator", ctx, ownedID, userID).
+		Return(nil).
+		Once()
+	storeMock.
+		On("SessionListActive", ctx, scope.MustBounded(ownedID), userID).
+		Return([]models.Session{{UID: "session", DeviceUID: "device", UserID: userID}}, nil).
+		Once()
+	storeMock.
+		On("SessionEventsCreate", ctx, &models.SessionEvent{
+			Session:   "session",
+			Type:      models.SessionEventTypeTerminate,
+			Timestamp: now,
+			Data:      &models.SessionTermination{Reason: sessionRevokedAccount},
+		}).
+		Return(nil).
+		Once()
+	cacheMock.
+		On("Delete", ctx, "token_"+ownedID+userID).
+		Return(nil).
+		Once()
+
+	storeMock.
+		On("SCIMGroupListByMember", ctx, userID).
+		Return([]models.SCIMGroup{}, nil).
+		Once()
+
+	service := NewService(storeMock, privateKey, publicKey, cacheMock, WithSCIM("token", nil))
+
+	res, err := service.PatchSCIMUser(ctx, userID, &scim.PatchRequest{
+		Operations: []scim.PatchOperation{{Op: "Replace", Path: "active", Value: []byte("false")}},
+	})
+	require.NoError(t, err)
+	assert.False(t, *res.Active)
+	assert.Equal(t, []scim.Reference{}, res.Groups)
+}
+
+func TestPatchSCIMUserDeactivationCertificateLogin(t *testing.T) {
+	ctx := context.TODO()
+
+	const (
+		userID   = "00000000-0000-4000-0000-000000000001"
+		tenantID = "00000000-0000-4000-0000-000000000002"
+	)
+
+	clockMock.On("Now").Return(now)
+
+	storeMock := mocks.NewMockStore(t)
+	queryOptionsMock := mocks.NewMockQueryOptions(t)
+	cacheMock := mockcache.NewMockCache(t)
+	storeMock.On("Options").Return(queryOptionsMock).Maybe()
+
+	user := &models.User{
+		ID:         userID,
+		Type:       models.UserTypeHuman,
+		Origin:     models.UserOriginSCIM,
+		ExternalID: "external",
+		CreatedAt:  now,
+		UserData:   models.UserData{Name: "Jane Doe", Username: "jane", Email: "jane@example.com"},
+	}
+
+	// The namespace cannot be left without its owner, so deactivating her keeps her in it.
+	namespace := &models.Namespace{
+		TenantID: tenantID,
+		Owner:    userID,
+		Members:  []models.Member{{ID: userID, Role: authorizer.RoleOwner, Type: models.UserTypeHuman}},
+	}
+
+	disabled := *user
+	disabled.Disabled = true
+
+	storeMock.On("UserResolve", ctx, store.UserIDResolver, userID).Return(user, nil).Once()
+	storeMock.On("UserUpdate", ctx, &disabled).Return(nil).Once()
+	storeMock.On("UserGetInfo", ctx, userID).Return(&models.UserInfo{OwnedNamespaces: []models.Namespace{*namespace}}, nil).Once()
+	queryOptionsMock.On("WithUserID", userID).Return(nil).Once()
+	storeMock.On("SSHIdentityList", ctx, scope.MustBounded(tenantID), testifymock.Anything).Return([]models.SSHIdentity{}, 0, nil).Once()
+	storeMock.On("APIKeyDeleteAllByCreator", ctx, tenantID, userID).Return(nil).Once()
+	storeMock.On("SessionListActive", ctx, scope.MustBounded(tenantID), userID).Return([]models.Session{}, nil).Once()
+	cacheMock.On("Delete", ctx, "token_"+tenantID+userID).Return(nil).Once()
+	storeMock.On("SCIMGroupListByMember", ctx, userID).Return([]models.SCIMGroup{}, nil).Once()
+
+	service := NewService(storeMock, privateKey, publicKey, cacheMock, WithSCIM("token", nil))
+
+	_, err := service.PatchSCIMUser(ctx, userID, &scim.PatchRequest{
+		Operations: []scim.PatchOperation{{Op: "Replace", Path: "active", Value: []byte("false")}},
+	})
+	require.NoError(t, err)
+
+	// Her certificate still comes from a CA the namespace trusts, and names her.
+	ca := newTestCA(t)
+	storeMock.On("SSHUserCAResolve", ctx, testifymock.Anything, store.SSHUserCAFingerprintResolver, ssh.FingerprintSHA256(ca.PublicKey())).
+		Return(&models.SSHUserCA{ID: "ca1", TenantID: tenantID}, nil).Once()
+	storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).Return(namespace, nil).Once()
+	storeMock.On("ServiceAccountList", ctx, tenantID).Return([]models.ServiceAccount{}, 0, nil).Once()
+	storeMock.On("UserResolve", ctx, store.UserUsernameResolver, "jane").Return(&disabled, nil).Once()
+
+	cert := newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+		cert.ValidPrincipals = []string{"jane"}
+	})
+
+	_, err = service.ResolveSSHCertificate(ctx, tenantID, cert, "192.168.1.10")
+	require.ErrorIs(t, err, ErrSSHCertificateRejected)
+}
+
+func TestDeleteSCIMUser(t *testing.T) {
+	ctx := context.TODO()
+
+	const userID = "00000000-0000-4000-0000-000000000001"
+
+	t.Run("fails when the user owns a namespace", func(t *testing.T) {
+		storeMock := mocks.NewMockStore(t)
+		storeMock.
+			On("UserResolve", ctx, store.UserIDResolver, userID).
+			Return(&models.User{ID: userID, Type: models.UserTypeHuman}, nil).
+			Once()
+		storeMock.
+			On("UserGetInfo", ctx, userID).
+			Return(&models.UserInfo{OwnedNamespaces: []models.Namespace{{TenantID: "tenant", Owner: userID}}}, nil).
+			Once()
+
+		service := NewService(storeMock, privateKey, publicKey, mockcache.NewMockCache(t), WithSCIM("token", nil))
+
+		assert.Equal(t, NewErrSCIMUserOwnsNamespace(nil), service.DeleteSCIMUser(ctx, userID))
+	})
+
+	t.Run("fails when the ID is not a user's", func(t *testing.T) {
+		service := NewService(mocks.NewMockStore(t), privateKey, publicKey, mockcache.NewMockCache(t), WithSCIM("token", nil))
+
+		assert.Equal(t, NewErrUserNotFound("not-an-id", nil), service.DeleteSCIMUser(ctx, "not-an-id"))
+	})
+}
+
+func TestCreateSCIMGroup(t *testing.T) {
+	ctx := context.TODO()
+
+	const (
+		userID   = "00000000-0000-4000-0000-000000000001"
+		tenantID = "00000000-0000-4000-0000-000000000002"
+		groupID  = "00000000-0000-4000-0000-000000000003"
+	)
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	prevClock := clock.DefaultBackend
+	t.Cleanup(func() { clock.DefaultBackend = prevClock })
+	clockMock := clockmock.NewMockClock(t)
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now).Maybe()
+
+	groupRoles := grouprole.Mapping{{Group: "developers", TenantID: tenantID, Role: authorizer.RoleOperator}}
+
+	t.Run("fails when a member is not a user", func(t *testing.T) {
+		storeMock := mocks.NewMockStore(t)
+		storeMock.
+			On("UserResolve", ctx, store.UserIDResolver, userID).
+			Return(nil, store.ErrNoDocuments).
+			Once()
+
+		service := NewService(storeMock, privateKey, publicKey, mockcache.NewMockCache(t), WithSCIM("token", groupRoles))
+
+		group, err := service.CreateSCIMGroup(ctx, &scim.Group{DisplayName: "developers", Members: []scim.Reference{{Value: userID}}})
+		assert.Nil(t, group)
+		assert.Equal(t, NewErrSCIMRequestInvalid(scim.NewError(scim.ErrorTypeInvalidValue, `group member "%s" is not a user`, userID)), err)
+	})
+
+	t.Run("succeeds granting the members the group's roles", func(t *testing.T) {
+		storeMock := mocks.NewMockStore(t)
+
+		user := &models.User{ID: userID, Type: models.UserTypeHuman}
+		storeMock.
+			On("UserResolve", ctx, store.UserIDResolver, userID).
+			Return(user, nil).
+			Twice()
+		storeMock.
+			On("SCIMGroupCreate", ctx, &models.SCIMGroup{DisplayName: "developers", Members: []string{userID}}).
+			Return(groupID, nil).
+			Once()
+		storeMock.
+			On("SCIMGroupListByMember", ctx, userID).
+			Return([]models.SCIMGroup{{ID: groupID, DisplayName: "developers", Members: []string{userID}}}, nil).
+			Once()
+		storeMock.
+			On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+			Return(&models.Namespace{TenantID: tenantID, Members: []models.Member{{ID: "owner", Role: authorizer.RoleOwner}}}, nil).
+			Once()
+		storeMock.
+			On("NamespaceCreateMembership", ctx, scope.MustBounded(tenantID), &models.Member{ID: userID, AddedAt: now, Role: authorizer.RoleOperator}).
+			Return(nil).
+			Once()
+
+		service := NewService(storeMock, privateKey, publicKey, mockcache.NewMockCache(t), WithSCIM("token", groupRoles))
+
+		group, err := service.CreateSCIMGroup(ctx, &scim.Group{
+			DisplayName: " developers ",
+			Members:     []scim.Reference{{Value: userID}, {Value: userID}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, groupID, group.ID)
+		assert.Equal(t, "developers", group.DisplayName)
+		assert.Equal(t, []scim.Reference{{Value: userID}}, group.Members)
+	})
+}
@@ -161,6 +161,15 @@ func (s *service) DeleteServiceAccount(ctx context.Context, req *requests.Servic
 		return NewErrServiceAccountNotFound(req.ID, nil)
 	}
 
+	// Deleting the user unbinds its sessions from it, so they are listed first.
+	sessions := s.activeSessions(ctx, req.TenantID, req.ID)
+
 	// Deleting the user cascades to its membership and SSH identities (FK ON DELETE CASCADE).
-	return s.store.UserDelete(ctx, &models.User{ID: req.ID})
+	if err := s.store.UserDelete(ctx, &models.User{ID: req.ID}); err != nil {
+		return err
+	}
+
+	s.reevaluateSessions(ctx, req.TenantID, sessions)
+
+	return nil
 }
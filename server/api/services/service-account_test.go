@@ -6,9 +6,11 @@ import (
 
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/server/api/store"
 	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,10 +39,32 @@ func TestDeleteServiceAccount(t *testing.T) {
 					Return(&models.User{ID: saID, Type: models.UserTypeService}, nil).Once()
 				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
 					Return(&models.Namespace{TenantID: tenantID, Members: []models.Member{{ID: saID, Role: authorizer.RoleService}}}, nil).Once()
+				storeMock.On("SessionListActive", ctx, scope.MustBounded(tenantID), saID).Return([]models.Session{}, nil).Once()
 				storeMock.On("UserDelete", ctx, &models.User{ID: saID}).Return(nil).Once()
 			},
 			expectedErr: false,
 		},
+		{
+			description: "terminates the sessions the service account had open",
+			requireMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("UserResolve", ctx, store.UserIDResolver, saID).
+					Return(&models.User{ID: saID, Type: models.UserTypeService}, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(&models.Namespace{TenantID: tenantID, Members: []models.Member{{ID: saID, Role: authorizer.RoleService}}}, nil).Once()
+				// Listed before the delete, which unbinds them from the account.
+				storeMock.On("SessionListActive", ctx, scope.MustBounded(tenantID), saID).
+					Return([]models.Session{{UID: "session-uid", DeviceUID: "device-uid", UserID: saID}}, nil).Once()
+				storeMock.On("UserDelete", ctx, &models.User{ID: saID}).Return(nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).
+					Return(&models.Namespace{TenantID: tenantID}, nil).Once()
+				storeMock.On("SessionEventsCreate", ctx, mock.MatchedBy(func(event *models.SessionEvent) bool {
+					return event.Session == "session-uid" &&
+						event.Type == models.SessionEventTypeTerminate &&
+						event.Data.(*models.SessionTermination).Reason == sessionRevokedMember
+				})).Return(nil).Once()
+			},
+			expectedErr: false,
+		},
 		{
 			description: "fails when the user does not exist",
 			requireMocks: func(storeMock *storemock.MockStore) {
@@ -4,8 +4,11 @@ import (
 	"crypto/rsa"
 
 	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/eventstream"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
 	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/shellhub-io/shellhub/pkg/worker"
+	"github.com/shellhub-io/shellhub/server/api/pkg/grouprole"
 	"github.com/shellhub-io/shellhub/server/api/store"
 )
 
@@ -26,6 +29,12 @@ type service struct {
 	licenseEvaluator  LicenseEvaluator
 	firewallEvaluator FirewallEvaluator
 	recordingPruner   SessionRecordingPruner
+	worker            worker.Client
+	events            eventstream.Stream
+	apiKeyUsage       *APIKeyUsageRecorder
+	oidc              OIDCProvider
+	scimToken         string
+	scimGroupRoles    grouprole.Mapping
 }
 
 type Service interface {
@@ -35,7 +44,12 @@ type Service interface {
 	DevicePairingService
 	SSHApprovalService
 	AccessPolicyService
+	AccessRequestService
+	AuditEventService
+	WebhookService
+	EventStreamService
 	SSHIdentityService
+	SSHUserCAService
 	ServiceAccountService
 	WebReauthService
 	UserService
@@ -45,11 +59,14 @@ type Service interface {
 	MemberService
 	InvitationService
 	AuthService
+	OIDCService
+	SCIMService
 	StatsService
 	SetupService
 	SystemService
 	APIKeyService
 	InstallKeyService
+	PolicyBundleService
 	FirewallService
 	LicenseService
 	BillingService
@@ -101,6 +118,30 @@ func WithSessionRecordingPruner(rp SessionRecordingPruner) Option {
 	}
 }
 
+// WithWorkerClient sets the client the service submits background tasks with,
+// like webhook deliveries. Without one, no webhook is delivered.
+func WithWorkerClient(client worker.Client) Option {
+	return func(service *APIService) {
+		service.worker = client
+	}
+}
+
+// WithEventStream sets the stream the service publishes the namespaces' live
+// events on. Without one, nothing is published and nothing can be subscribed to.
+func WithEventStream(stream eventstream.Stream) Option {
+	return func(service *APIService) {
+		service.events = stream
+	}
+}
+
+// WithAPIKeyUsageRecorder sets the recorder the service counts the requests the
+// API keys authenticate with. Without one, the usage is not recorded.
+func WithAPIKeyUsageRecorder(recorder *APIKeyUsageRecorder) Option {
+	return func(service *APIService) {
+		service.apiKeyUsage = recorder
+	}
+}
+
 func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, options ...Option) *APIService {
 	if privKey == nil || pubKey == nil {
 		var err error
@@ -122,6 +163,8 @@ func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKe
 			licenseEvaluator:  nil, // injected via WithLicenseEvaluator option
 			firewallEvaluator: nil, // injected via WithFirewallEvaluator option
 			recordingPruner:   nil, // injected via WithSessionRecordingPruner option
+			worker:            nil, // injected via WithWorkerClient option
+			events:            nil, // injected via WithEventStream option
 		},
 	}
 
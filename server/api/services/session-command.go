@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+)
+
+func (s *service) ListSessionCommands(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsList) ([]models.SessionCommand, int, error) {
+	filter := store.SessionCommandsFilter{
+		Username: req.Username,
+		UserID:   req.UserID,
+		Since:    req.Since,
+		Until:    req.Until,
+	}
+
+	opts := append([]store.QueryOption{s.store.Options().Paginate(&req.Paginator)}, s.sessionDeviceScope(req.ScopeTags)...)
+
+	return s.store.SessionCommandsList(ctx, sc, filter, opts...)
+}
+
+func (s *service) GetSessionCommands(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsGet) ([]models.SessionCommand, int, error) {
+	session, err := s.store.SessionResolve(ctx, sc, store.SessionUIDResolver, req.UID, s.sessionDeviceScope(req.ScopeTags)...)
+	if err != nil {
+		return nil, 0, NewErrSessionNotFound(models.UID(req.UID), err)
+	}
+
+	filter := store.SessionCommandsFilter{SessionUID: models.UID(session.UID)}
+
+	return s.store.SessionCommandsList(ctx, sc, filter, s.store.Options().Paginate(&req.Paginator))
+}
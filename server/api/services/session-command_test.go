@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSessionCommands(t *testing.T) {
+	ctx := context.TODO()
+
+	sc := scope.MustBounded("00000000-0000-4000-0000-000000000000")
+
+	t.Run("fails when the store fails", func(t *testing.T) {
+		storeMock := storemock.NewMockStore(t)
+		queryOptionsMock := storemock.NewMockQueryOptions(t)
+		storeMock.On("Options").Return(queryOptionsMock)
+		queryOptionsMock.On("Paginate", mock.Anything).Return(nil).Once()
+		storeMock.On("SessionCommandsList", ctx, sc, mock.Anything, mock.Anything).Return(nil, 0, goerrors.New("error")).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		_, _, err := service.ListSessionCommands(ctx, sc, &requests.SessionCommandsList{})
+
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("lists the commands of a device user", func(t *testing.T) {
+		commands := []models.SessionCommand{
+			{SessionUID: "uid", Username: "root", SessionCommandLine: models.SessionCommandLine{Command: "reboot", Interactive: true}},
+		}
+
+		storeMock := storemock.NewMockStore(t)
+		queryOptionsMock := storemock.NewMockQueryOptions(t)
+		storeMock.On("Options").Return(queryOptionsMock)
+		queryOptionsMock.On("Paginate", &query.Paginator{Page: 2, PerPage: 10}).Return(nil).Once()
+		storeMock.On("SessionCommandsList", ctx, sc, store.SessionCommandsFilter{Username: "root"}, mock.Anything).Return(commands, 11, nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		listed, count, err := service.ListSessionCommands(ctx, sc, &requests.SessionCommandsList{
+			Username:  "root",
+			Paginator: query.Paginator{Page: 2, PerPage: 10},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 11, count)
+		assert.Equal(t, commands, listed)
+	})
+}
+
+func TestGetSessionCommands(t *testing.T) {
+	ctx := context.TODO()
+
+	sc := scope.MustBounded("00000000-0000-4000-0000-000000000000")
+
+	t.Run("fails when the session is not found", func(t *testing.T) {
+		storeMock := storemock.NewMockStore(t)
+		storeMock.On("SessionResolve", ctx, sc, store.SessionUIDResolver, "uid").Return(nil, goerrors.New("error")).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		_, _, err := service.GetSessionCommands(ctx, sc, &requests.SessionCommandsGet{SessionIDParam: requests.SessionIDParam{UID: "uid"}})
+
+		assert.Equal(t, NewErrSessionNotFound("uid", goerrors.New("error")), err)
+	})
+
+	t.Run("lists the session's commands", func(t *testing.T) {
+		commands := []models.SessionCommand{
+			{SessionUID: "uid", SessionCommandLine: models.SessionCommandLine{Command: "uptime"}},
+		}
+
+		storeMock := storemock.NewMockStore(t)
+		queryOptionsMock := storemock.NewMockQueryOptions(t)
+		storeMock.On("SessionResolve", ctx, sc, store.SessionUIDResolver, "uid").Return(&models.Session{UID: "uid"}, nil).Once()
+		storeMock.On("Options").Return(queryOptionsMock)
+		queryOptionsMock.On("Paginate", mock.Anything).Return(nil).Once()
+		storeMock.On("SessionCommandsList", ctx, sc, store.SessionCommandsFilter{SessionUID: "uid"}, mock.Anything).Return(commands, 1, nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		listed, count, err := service.GetSessionCommands(ctx, sc, &requests.SessionCommandsGet{SessionIDParam: requests.SessionIDParam{UID: "uid"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, count)
+		assert.Equal(t, commands, listed)
+	})
+}
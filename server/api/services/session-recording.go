@@ -1,12 +1,42 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"slices"
 
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
 	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/recording"
 	"github.com/shellhub-io/shellhub/server/api/store"
+	log "github.com/sirupsen/logrus"
 )
 
+// sessionRecordingPageSize is how many recorded events an export reads from the store at a time.
+const sessionRecordingPageSize = 1000
+
+// sessionRecordingTailPages bounds how many pages a tailed export reads back through, so a
+// recording of few, long lines costs no more than a bounded read.
+const sessionRecordingTailPages = 10
+
+// sessionRecordingEvents are the events a recording is rendered from: the terminal the client
+// asked for, its resizes and what it printed.
+var sessionRecordingEvents = []models.SessionEventType{
+	models.SessionEventTypePtyRequest,
+	models.SessionEventTypeWindowChange,
+	models.SessionEventTypePtyOutput,
+}
+
+// sessionRecordingResizes are the recording's events that set the terminal's size.
+var sessionRecordingResizes = []models.SessionEventType{
+	models.SessionEventTypePtyRequest,
+	models.SessionEventTypeWindowChange,
+}
+
 // SessionRecordingPrunerFactoryFunc constructs a SessionRecordingPruner from the core store and
 // cache. Enterprise packages register a factory via RegisterSessionRecordingPruner in their
 // init() functions; it runs during server setup.
@@ -93,3 +123,190 @@ func (s *service) pruneRecordings(ctx context.Context, sessions []store.ExpiredS
 
 	return deletable, nil
 }
+
+func (s *service) ExportSessionRecording(ctx context.Context, sc scope.Scope, req *requests.SessionRecordingExport, w io.Writer) error {
+	session, err := s.store.SessionResolve(ctx, sc, store.SessionUIDResolver, req.UID, s.sessionDeviceScope(req.ScopeTags)...)
+	if err != nil {
+		return NewErrSessionNotFound(models.UID(req.UID), err)
+	}
+
+	format := recording.Format(req.Format)
+	if format == "" {
+		format = recording.FormatAsciicast
+	}
+
+	encoder, err := recording.NewEncoder(format, w, recording.Header{Term: session.Term})
+	if err != nil {
+		return err
+	}
+
+	if req.Tail > 0 {
+		events, err := s.tailSessionRecording(ctx, models.UID(session.UID), req.Seat, req.Tail)
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			if err := renderSessionEvent(encoder, &events[i]); err != nil {
+				return err
+			}
+		}
+
+		return encoder.Close()
+	}
+
+	var cursor store.SessionEventsCursor
+	for {
+		events, next, err := s.store.SessionEventsScan(ctx, models.UID(session.UID), req.Seat, sessionRecordingEvents, cursor, sessionRecordingPageSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			if err := renderSessionEvent(encoder, &events[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(events) < sessionRecordingPageSize {
+			break
+		}
+
+		cursor = next
+	}
+
+	return encoder.Close()
+}
+
+// tailSessionRecording reads a seat's recording backwards until it holds more than lines lines
+// of output, or the read reaches its bound, and returns those events oldest first. The last
+// terminal size set before them leads the events, so the output renders at the size it was
+// printed at.
+func (s *service) tailSessionRecording(ctx context.Context, uid models.UID, seat int, lines int) ([]models.SessionEvent, error) {
+	var (
+		read      []models.SessionEvent
+		cursor    store.SessionEventsCursor
+		exhausted bool
+		// cut is how many of the events read, newest first, the tail keeps.
+		cut  = -1
+		seen int
+	)
+
+	for page := 0; page < sessionRecordingTailPages && cut < 0; page++ {
+		events, next, err := s.store.SessionEventsScanBackward(ctx, uid, seat, sessionRecordingEvents, cursor, sessionRecordingPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range events {
+			if events[i].Type != models.SessionEventTypePtyOutput {
+				continue
+			}
+
+			var output models.SSHPtyOutput
+			if !decodeSessionEventData(&events[i], &output) {
+				continue
+			}
+
+			if seen += bytes.Count([]byte(output.Output), []byte("\n")); seen > lines {
+				cut = len(read) + i + 1
+
+				break
+			}
+		}
+
+		read = append(read, events...)
+		cursor = next
+
+		if len(events) < sessionRecordingPageSize {
+			exhausted = true
+
+			break
+		}
+	}
+
+	if cut < 0 {
+		cut = len(read)
+	}
+
+	tail := read[:cut]
+
+	// NOTE: A resize already read, though left out of the tail, sets the size it starts at;
+	// otherwise it is the one just before what was read, unless the read reached the start.
+	var size *models.SessionEvent
+	for i := cut; i < len(read); i++ {
+		if read[i].Type != models.SessionEventTypePtyOutput {
+			size = &read[i]
+
+			break
+		}
+	}
+
+	if size == nil && !exhausted && len(read) > 0 {
+		resizes, _, err := s.store.SessionEventsScanBackward(ctx, uid, seat, sessionRecordingResizes, cursor, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(resizes) > 0 {
+			size = &resizes[0]
+		}
+	}
+
+	if size != nil && (len(tail) == 0 || tail[len(tail)-1].Type == models.SessionEventTypePtyOutput) {
+		tail = append(tail, *size)
+	}
+
+	slices.Reverse(tail)
+
+	return tail, nil
+}
+
+// renderSessionEvent hands a recorded event to the encoder. An event whose data does not decode
+// is skipped rather than failing the export, which would lose the rest of the recording to it.
+func renderSessionEvent(encoder recording.Encoder, event *models.SessionEvent) error {
+	switch event.Type {
+	case models.SessionEventTypePtyOutput:
+		var output models.SSHPtyOutput
+		if !decodeSessionEventData(event, &output) {
+			return nil
+		}
+
+		return encoder.Output(event.Timestamp, output.Output)
+	case models.SessionEventTypePtyRequest:
+		var pty models.SSHPty
+		if !decodeSessionEventData(event, &pty) {
+			return nil
+		}
+
+		return encoder.Resize(event.Timestamp, pty.Columns, pty.Rows)
+	case models.SessionEventTypeWindowChange:
+		var dimensions models.SSHWindowChange
+		if !decodeSessionEventData(event, &dimensions) {
+			return nil
+		}
+
+		return encoder.Resize(event.Timestamp, dimensions.Columns, dimensions.Rows)
+	default:
+		return nil
+	}
+}
+
+// decodeSessionEventData decodes an event's data into v. The store hands the data back as
+// generic JSON values, so it goes through JSON again to reach its type.
+func decodeSessionEventData(event *models.SessionEvent, v any) bool {
+	data, err := json.Marshal(event.Data)
+	if err == nil {
+		err = json.Unmarshal(data, v)
+	}
+
+	if err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{"session": event.Session, "type": event.Type}).
+			Warn("skipping a recorded event whose data does not decode")
+
+		return false
+	}
+
+	return true
+}
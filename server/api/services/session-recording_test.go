@@ -0,0 +1,172 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	goerrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSessionRecording(t *testing.T) {
+	ctx := context.TODO()
+
+	sc := scope.MustBounded("00000000-0000-4000-0000-000000000000")
+	start := time.Date(2026, time.January, 2, 15, 4, 5, 0, time.UTC)
+	session := &models.Session{UID: "uid", TenantID: "00000000-0000-4000-0000-000000000000", Term: "xterm"}
+
+	// The store hands event data back as generic JSON values.
+	output := func(at time.Duration, text string) models.SessionEvent {
+		return models.SessionEvent{
+			Session:   "uid",
+			Type:      models.SessionEventTypePtyOutput,
+			Timestamp: start.Add(at),
+			Data:      map[string]any{"output": text},
+		}
+	}
+
+	t.Run("fails when the session is not found", func(t *testing.T) {
+		storeMock := storemock.NewMockStore(t)
+		storeMock.On("SessionResolve", ctx, sc, store.SessionUIDResolver, "uid").Return(nil, goerrors.New("error")).Once()
+
+		var buf bytes.Buffer
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		err := service.ExportSessionRecording(ctx, sc, &requests.SessionRecordingExport{SessionIDParam: requests.SessionIDParam{UID: "uid"}}, &buf)
+
+		assert.Equal(t, NewErrSessionNotFound("uid", goerrors.New("error")), err)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("fails without writing when the events cannot be read", func(t *testing.T) {
+		storeMock := storemock.NewMockStore(t)
+		storeMock.On("SessionResolve", ctx, sc, store.SessionUIDResolver, "uid").Return(session, nil).Once()
+		storeMock.On("SessionEventsScan", ctx, models.UID("uid"), 0, sessionRecordingEvents, store.SessionEventsCursor{}, sessionRecordingPageSize).
+			Return(nil, store.SessionEventsCursor{}, goerrors.New("error")).Once()
+
+		var buf bytes.Buffer
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		err := service.ExportSessionRecording(ctx, sc, &requests.SessionRecordingExport{SessionIDParam: requests.SessionIDParam{UID: "uid"}}, &buf)
+
+		assert.EqualError(t, err, "error")
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("renders a cast sized by the pty request and resized by window changes", func(t *testing.T) {
+		storeMock := storemock.NewMockStore(t)
+		storeMock.On("SessionResolve", ctx, sc, store.SessionUIDResolver, "uid").Return(session, nil).Once()
+		storeMock.On("SessionEventsScan", ctx, models.UID("uid"), 1, sessionRecordingEvents, store.SessionEventsCursor{}, sessionRecordingPageSize).
+			Return([]models.SessionEvent{
+				{Session: "uid", Type: models.SessionEventTypePtyRequest, Timestamp: start, Data: map[string]any{"term": "xterm", "columns": float64(120), "rows": float64(40)}},
+				output(time.Second, "$ "),
+				{Session: "uid", Type: models.SessionEventTypeWindowChange, Timestamp: start.Add(2 * time.Second), Data: map[string]any{"columns": float64(100), "rows": float64(30)}},
+				// Undecodable data is skipped, not fatal.
+				{Session: "uid", Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(3 * time.Second), Data: "garbage"},
+				output(4*time.Second, "exit\r\n"),
+			}, store.SessionEventsCursor{Timestamp: start.Add(4 * time.Second), ID: "last"}, nil).Once()
+
+		var buf bytes.Buffer
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		err := service.ExportSessionRecording(ctx, sc, &requests.SessionRecordingExport{SessionIDParam: requests.SessionIDParam{UID: "uid"}, Seat: 1}, &buf)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 4)
+		assert.JSONEq(t, `{"version":2,"width":120,"height":40,"timestamp":1767366246,"env":{"TERM":"xterm"}}`, lines[0])
+		assert.Equal(t, `[0.000000,"o","$ "]`, lines[1])
+		assert.Equal(t, `[1.000000,"r","100x30"]`, lines[2])
+		assert.Equal(t, `[3.000000,"o","exit\r\n"]`, lines[3])
+	})
+
+	t.Run("reads the events page by page into a transcript", func(t *testing.T) {
+		first := make([]models.SessionEvent, sessionRecordingPageSize)
+		for i := range first {
+			first[i] = output(time.Duration(i)*time.Millisecond, "\x1b[32m.\x1b[0m")
+		}
+
+		cursor := store.SessionEventsCursor{Timestamp: start.Add(time.Second), ID: "cursor"}
+
+		storeMock := storemock.NewMockStore(t)
+		storeMock.On("SessionResolve", ctx, sc, store.SessionUIDResolver, "uid").Return(session, nil).Once()
+		storeMock.On("SessionEventsScan", ctx, models.UID("uid"), 0, sessionRecordingEvents, store.SessionEventsCursor{}, sessionRecordingPageSize).
+			Return(first, cursor, nil).Once()
+		storeMock.On("SessionEventsScan", ctx, models.UID("uid"), 0, sessionRecordingEvents, cursor, sessionRecordingPageSize).
+			Return([]models.SessionEvent{output(2*time.Second, "\r\ndone\r\n")}, cursor, nil).Once()
+
+		var buf bytes.Buffer
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		err := service.ExportSessionRecording(ctx, sc, &requests.SessionRecordingExport{SessionIDParam: requests.SessionIDParam{UID: "uid"}, Format: "text"}, &buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, strings.Repeat(".", sessionRecordingPageSize)+"\ndone\n", buf.String())
+	})
+
+	t.Run("tails the last lines from the size set before them", func(t *testing.T) {
+		storeMock := storemock.NewMockStore(t)
+		storeMock.On("SessionResolve", ctx, sc, store.SessionUIDResolver, "uid").Return(session, nil).Once()
+		storeMock.On("SessionEventsScanBackward", ctx, models.UID("uid"), 0, sessionRecordingEvents, store.SessionEventsCursor{}, sessionRecordingPageSize).
+			Return([]models.SessionEvent{
+				output(5*time.Second, "$ "),
+				output(4*time.Second, "three\r\n"),
+				output(3*time.Second, "two\r\n"),
+				{Session: "uid", Type: models.SessionEventTypeWindowChange, Timestamp: start.Add(2 * time.Second), Data: map[string]any{"columns": float64(100), "rows": float64(30)}},
+				output(time.Second, "one\r\n"),
+				{Session: "uid", Type: models.SessionEventTypePtyRequest, Timestamp: start, Data: map[string]any{"term": "xterm", "columns": float64(120), "rows": float64(40)}},
+			}, store.SessionEventsCursor{Timestamp: start, ID: "first"}, nil).Once()
+
+		var buf bytes.Buffer
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		err := service.ExportSessionRecording(ctx, sc, &requests.SessionRecordingExport{SessionIDParam: requests.SessionIDParam{UID: "uid"}, Tail: 1}, &buf)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 4)
+		assert.JSONEq(t, `{"version":2,"width":100,"height":30,"timestamp":1767366248,"env":{"TERM":"xterm"}}`, lines[0])
+		assert.Equal(t, `[0.000000,"o","two\r\n"]`, lines[1])
+		assert.Equal(t, `[1.000000,"o","three\r\n"]`, lines[2])
+		assert.Equal(t, `[2.000000,"o","$ "]`, lines[3])
+	})
+
+	t.Run("bounds how far a tail reads back", func(t *testing.T) {
+		page := make([]models.SessionEvent, sessionRecordingPageSize)
+		for i := range page {
+			page[i] = output(time.Second, ".")
+		}
+
+		cursor := store.SessionEventsCursor{Timestamp: start.Add(time.Second), ID: "cursor"}
+
+		storeMock := storemock.NewMockStore(t)
+		storeMock.On("SessionResolve", ctx, sc, store.SessionUIDResolver, "uid").Return(session, nil).Once()
+		storeMock.On("SessionEventsScanBackward", ctx, models.UID("uid"), 0, sessionRecordingEvents, store.SessionEventsCursor{}, sessionRecordingPageSize).
+			Return(page, cursor, nil).Once()
+		storeMock.On("SessionEventsScanBackward", ctx, models.UID("uid"), 0, sessionRecordingEvents, cursor, sessionRecordingPageSize).
+			Return(page, cursor, nil).Times(sessionRecordingTailPages - 1)
+		storeMock.On("SessionEventsScanBackward", ctx, models.UID("uid"), 0, sessionRecordingResizes, cursor, 1).
+			Return([]models.SessionEvent{
+				{Session: "uid", Type: models.SessionEventTypePtyRequest, Timestamp: start, Data: map[string]any{"term": "xterm", "columns": float64(120), "rows": float64(40)}},
+			}, store.SessionEventsCursor{Timestamp: start, ID: "first"}, nil).Once()
+
+		var buf bytes.Buffer
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		err := service.ExportSessionRecording(ctx, sc, &requests.SessionRecordingExport{SessionIDParam: requests.SessionIDParam{UID: "uid"}, Tail: 10}, &buf)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, sessionRecordingTailPages*sessionRecordingPageSize+1)
+		assert.JSONEq(t, `{"version":2,"width":120,"height":40,"timestamp":1767366246,"env":{"TERM":"xterm"}}`, lines[0])
+	})
+}
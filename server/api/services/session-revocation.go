@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/worker"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	log "github.com/sirupsen/logrus"
+)
+
+// TaskSessionTerminate closes an active session that is no longer authorized.
+const TaskSessionTerminate = worker.TaskPattern("sessions:terminate")
+
+const (
+	sessionRevokedMember               = "the member was removed from the namespace"
+	sessionRevokedIdentity             = "the SSH identity was revoked"
+	sessionRevokedCA                   = "the SSH certificate authority is no longer trusted"
+	sessionRevokedCertificatePrincipal = "the certificate's principal no longer names the account"
+	sessionRevokedAccount              = "the account was deactivated"
+)
+
+// SessionCloser closes a session on the device it runs on.
+type SessionCloser interface {
+	CloseSession(ctx context.Context, tenantID, deviceUID, sessionUID string) error
+}
+
+// sessionTermination is the payload of [TaskSessionTerminate].
+type sessionTermination struct {
+	TenantID   string `json:"tenant_id"`
+	DeviceUID  string `json:"device_uid"`
+	SessionUID string `json:"session_uid"`
+	Reason     string `json:"reason"`
+}
+
+// activeSessions lists the namespace's active sessions, only those bound to userID when it is not
+// empty. Revoking is done by then, and what it revoked must not fail over the sessions still
+// using it: a failure is logged, and nothing is listed.
+func (s *service) activeSessions(ctx context.Context, tenantID, userID string) []models.Session {
+	sc, err := BoundTo(tenantID)
+	if err != nil {
+		return nil
+	}
+
+	sessions, err := s.store.SessionListActive(ctx, sc, userID)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{"tenant_id": tenantID, "user_id": userID}).
+			Error("failed to list the active sessions to re-evaluate")
+
+		return nil
+	}
+
+	return sessions
+}
+
+// reevaluateSessions asks again whether each session may go on as it is now, after something
+// that authorized it was revoked, and terminates those that may not. The decision is the login's,
+// taken again: the account must still be a member, and in the identity access mode the key it
+// logged in with must still be its identity, or the certificate's CA still trusted, and the
+// access policies must still let it in.
+//
+// Sessions bound to no account are left alone: legacy logins are authorized by the device, not by
+// anything a revocation takes away.
+func (s *service) reevaluateSessions(ctx context.Context, tenantID string, sessions []models.Session) {
+	bound := make([]models.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.UserID != "" {
+			bound = append(bound, session)
+		}
+	}
+
+	if len(bound) == 0 {
+		return
+	}
+
+	sc, err := BoundTo(tenantID)
+	if err != nil {
+		return
+	}
+
+	logger := log.WithField("tenant_id", tenantID)
+
+	namespace, err := s.store.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, tenantID)
+	if err != nil {
+		logger.WithError(err).Error("failed to resolve the namespace to re-evaluate its sessions")
+
+		return
+	}
+
+	var policies []models.AccessPolicy
+	if namespace.Settings.IsIdentityAccess() {
+		if policies, _, err = s.store.AccessPolicyList(ctx, sc); err != nil {
+			logger.WithError(err).Error("failed to list the access policies to re-evaluate the sessions")
+
+			return
+		}
+	}
+
+	devices := make(map[models.UID]*models.Device)
+	for i := range bound {
+		session := &bound[i]
+
+		reason, err := s.sessionRevocation(ctx, sc, namespace, policies, devices, session)
+		if err != nil {
+			// Failing closed here would end sessions over a fault of ours; the session goes on,
+			// and the next revocation asks again.
+			logger.WithError(err).WithField("session", session.UID).Error("failed to re-evaluate the session")
+
+			continue
+		}
+
+		if reason != "" {
+			s.terminateSession(ctx, tenantID, session, reason)
+		}
+	}
+}
+
+// sessionRevocation returns why the session is no longer authorized, or an empty string when it
+// still is.
+func (s *service) sessionRevocation(ctx context.Context, sc scope.Scope, namespace *models.Namespace, policies []models.AccessPolicy, devices map[models.UID]*models.Device, session *models.Session) (string, error) {
+	member, ok := namespace.FindMember(session.UserID)
+	if !ok {
+		return sessionRevokedMember, nil
+	}
+
+	if !namespace.Settings.IsIdentityAccess() {
+		return "", nil
+	}
+
+	// A certificate login has no SSH identity behind its fingerprint: it holds for as long as the
+	// namespace still trusts the CA that signed it and its principal still names the account. Web
+	// terminal sessions present no key; the account's membership and the policies are all they are
+	// authorized by.
+	switch {
+	case session.CertificateCAID != "":
+		if _, err := s.store.SSHUserCAResolve(ctx, sc, store.SSHUserCAIDResolver, session.CertificateCAID); err != nil {
+			if errors.Is(err, store.ErrNoDocuments) {
+				return sessionRevokedCA, nil
+			}
+
+			return "", err
+		}
+
+		_, principalID, err := s.resolveCertificatePrincipal(ctx, namespace, []string{session.CertificatePrincipal})
+		switch {
+		case errors.Is(err, ErrSSHCertificateRejected):
+			return sessionRevokedCertificatePrincipal, nil
+		case err != nil:
+			return "", err
+		case principalID != session.UserID:
+			return sessionRevokedCertificatePrincipal, nil
+		}
+	case session.Fingerprint != "":
+		identity, err := s.store.SSHIdentityResolve(ctx, sc, store.SSHIdentityFingerprintResolver, session.Fingerprint)
+		switch {
+		case errors.Is(err, store.ErrNoDocuments):
+			return sessionRevokedIdentity, nil
+		case err != nil:
+			return "", err
+		case identity.PrincipalID != session.UserID:
+			return sessionRevokedIdentity, nil
+		}
+	}
+
+	device, ok := devices[session.DeviceUID]
+	if !ok {
+		var err error
+		if device, err = s.store.DeviceResolve(ctx, sc, store.DeviceUIDResolver, string(session.DeviceUID)); err != nil {
+			return "", err
+		}
+
+		devices[session.DeviceUID] = device
+	}
+
+	decision, _ := evaluateAccessPolicies(policies, device, session.UserID, member, session.Username, session.IPAddress, clock.Now())
+	if !decision.Allowed {
+		return decision.Reason, nil
+	}
+
+	return "", nil
+}
+
+// terminateSession writes why the session is ending into its events, and submits the task that
+// closes it on the device.
+func (s *service) terminateSession(ctx context.Context, tenantID string, session *models.Session, reason string) {
+	logger := log.WithFields(log.Fields{"tenant_id": tenantID, "session": session.UID, "reason": reason})
+
+	if err := s.store.SessionEventsCreate(ctx, &models.SessionEvent{
+		Session:   session.UID,
+		Type:      models.SessionEventTypeTerminate,
+		Timestamp: clock.Now(),
+		Data:      &models.SessionTermination{Reason: reason},
+	}); err != nil {
+		logger.WithError(err).Error("failed to record the session termination")
+	}
+
+	if s.worker == nil {
+		return
+	}
+
+	payload, err := json.Marshal(sessionTermination{
+		TenantID:   tenantID,
+		DeviceUID:  string(session.DeviceUID),
+		SessionUID: session.UID,
+		Reason:     reason,
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to encode the session termination")
+
+		return
+	}
+
+	if err := s.worker.Submit(ctx, TaskSessionTerminate, payload); err != nil {
+		logger.WithError(err).Error("failed to submit the session termination")
+
+		return
+	}
+
+	logger.Info("terminating a session no longer authorized")
+}
+
+// SessionTerminate handles [TaskSessionTerminate]: it asks the device's agent to close the
+// session. A failure to reach the device is returned so the worker retries the task; closing a
+// session that has already ended is a no-op on the agent.
+func (s *service) SessionTerminate(closer SessionCloser) worker.TaskHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var termination sessionTermination
+		if err := json.Unmarshal(payload, &termination); err != nil {
+			log.WithError(err).Error("dropping an undecodable session termination")
+
+			return nil
+		}
+
+		return closer.CloseSession(ctx, termination.TenantID, termination.DeviceUID, termination.SessionUID)
+	}
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	workermock "github.com/shellhub-io/shellhub/pkg/worker/mocks"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReevaluateSessions(t *testing.T) {
+	ctx := context.TODO()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	clockMock.On("Now").Return(now)
+
+	t.Run("leaves the sessions bound to no account alone", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		service.reevaluateSessions(ctx, tenantID, []models.Session{{UID: "legacy", DeviceUID: "device-uid"}})
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("terminates the sessions the policies no longer allow", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).Return(&models.Namespace{
+			TenantID: tenantID,
+			Members:  []models.Member{{ID: "alice", Role: authorizer.RoleOperator}},
+			Settings: &models.NamespaceSettings{SSHAccessMode: models.SSHAccessModeIdentity},
+		}, nil).Once()
+		storeMock.On("AccessPolicyList", ctx, mock.Anything).Return([]models.AccessPolicy{
+			{
+				Name:    "everyone",
+				Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+				Logins:  []string{"*"},
+				Action:  models.PolicyActionAllow,
+			},
+			{
+				Name:    "no root",
+				Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+				Logins:  []string{"root"},
+				Action:  models.PolicyActionDeny,
+			},
+		}, 2, nil).Once()
+		storeMock.On("SSHIdentityResolve", ctx, mock.Anything, store.SSHIdentityFingerprintResolver, "SHA256:alice").
+			Return(&models.SSHIdentity{PrincipalID: "alice", Fingerprint: "SHA256:alice"}, nil).Twice()
+		// Both sessions are on the same device, which is resolved once.
+		storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, "device-uid").
+			Return(&models.Device{UID: "device-uid", TenantID: tenantID}, nil).Once()
+		storeMock.On("SessionEventsCreate", ctx, &models.SessionEvent{
+			Session:   "root-session",
+			Type:      models.SessionEventTypeTerminate,
+			Timestamp: now,
+			Data:      &models.SessionTermination{Reason: `denied by policy "no root"`},
+		}).Return(nil).Once()
+
+		workerMock := workermock.NewMockClient(t)
+		workerMock.On("Submit", ctx, TaskSessionTerminate, mock.MatchedBy(func(payload []byte) bool {
+			return assert.JSONEq(t, `{
+				"tenant_id": "`+tenantID+`",
+				"device_uid": "device-uid",
+				"session_uid": "root-session",
+				"reason": "denied by policy \"no root\""
+			}`, string(payload))
+		})).Return(nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil, WithWorkerClient(workerMock))
+		service.reevaluateSessions(ctx, tenantID, []models.Session{
+			{UID: "root-session", DeviceUID: "device-uid", UserID: "alice", Fingerprint: "SHA256:alice", Username: "root"},
+			{UID: "deploy-session", DeviceUID: "device-uid", UserID: "alice", Fingerprint: "SHA256:alice", Username: "deploy"},
+		})
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("re-checks a certificate session against its CA, not the SSH identities", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).Return(&models.Namespace{
+			TenantID: tenantID,
+			Members:  []models.Member{{ID: "alice", Role: authorizer.RoleOperator, Type: models.UserTypeHuman}},
+			Settings: &models.NamespaceSettings{SSHAccessMode: models.SSHAccessModeIdentity},
+		}, nil).Once()
+		storeMock.On("AccessPolicyList", ctx, mock.Anything).Return([]models.AccessPolicy{
+			{
+				Name:    "everyone",
+				Subject: models.PolicySubject{Type: models.PolicySubjectAllMembers},
+				Logins:  []string{"*"},
+				Action:  models.PolicyActionAllow,
+			},
+		}, 1, nil).Once()
+		// No SSHIdentityResolve: a certificate's fingerprint is no identity's, and looking for one
+		// would revoke every certificate session.
+		storeMock.On("SSHUserCAResolve", ctx, mock.Anything, store.SSHUserCAIDResolver, "trusted-ca").
+			Return(&models.SSHUserCA{ID: "trusted-ca", TenantID: tenantID}, nil).Once()
+		storeMock.On("SSHUserCAResolve", ctx, mock.Anything, store.SSHUserCAIDResolver, "deleted-ca").
+			Return(nil, store.ErrNoDocuments).Once()
+		storeMock.On("ServiceAccountList", ctx, tenantID).Return([]models.ServiceAccount{}, 0, nil).Once()
+		storeMock.On("UserResolve", ctx, store.UserUsernameResolver, "alice").
+			Return(&models.User{ID: "alice"}, nil).Once()
+		storeMock.On("DeviceResolve", ctx, mock.Anything, store.DeviceUIDResolver, "device-uid").
+			Return(&models.Device{UID: "device-uid", TenantID: tenantID}, nil).Once()
+		storeMock.On("SessionEventsCreate", ctx, &models.SessionEvent{
+			Session:   "revoked-ca-session",
+			Type:      models.SessionEventTypeTerminate,
+			Timestamp: now,
+			Data:      &models.SessionTermination{Reason: sessionRevokedCA},
+		}).Return(nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		service.reevaluateSessions(ctx, tenantID, []models.Session{
+			{
+				UID: "certificate-session", DeviceUID: "device-uid", UserID: "alice", Username: "root",
+				Fingerprint: "SHA256:certificate", CertificateCAID: "trusted-ca", CertificatePrincipal: "alice",
+			},
+			{
+				UID: "revoked-ca-session", DeviceUID: "device-uid", UserID: "alice", Username: "root",
+				Fingerprint: "SHA256:certificate", CertificateCAID: "deleted-ca", CertificatePrincipal: "alice",
+			},
+		})
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("lets a session go on when it cannot be re-evaluated", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).Return(&models.Namespace{
+			TenantID: tenantID,
+			Members:  []models.Member{{ID: "alice", Role: authorizer.RoleOperator}},
+			Settings: &models.NamespaceSettings{SSHAccessMode: models.SSHAccessModeIdentity},
+		}, nil).Once()
+		storeMock.On("AccessPolicyList", ctx, mock.Anything).Return([]models.AccessPolicy{}, 0, nil).Once()
+		storeMock.On("SSHIdentityResolve", ctx, mock.Anything, store.SSHIdentityFingerprintResolver, "SHA256:alice").
+			Return(nil, errors.New("connection reset")).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		service.reevaluateSessions(ctx, tenantID, []models.Session{
+			{UID: "session-uid", DeviceUID: "device-uid", UserID: "alice", Fingerprint: "SHA256:alice", Username: "root"},
+		})
+
+		storeMock.AssertExpectations(t)
+	})
+}
+
+// closer records the sessions it is asked to close.
+type closer struct {
+	closed []string
+	err    error
+}
+
+func (c *closer) CloseSession(_ context.Context, tenantID, deviceUID, sessionUID string) error {
+	c.closed = append(c.closed, tenantID+"/"+deviceUID+"/"+sessionUID)
+
+	return c.err
+}
+
+func TestSessionTerminate(t *testing.T) {
+	ctx := context.TODO()
+
+	payload := []byte(`{"tenant_id": "tenant-id", "device_uid": "device-uid", "session_uid": "session-uid", "reason": "revoked"}`)
+
+	t.Run("closes the session on its device", func(t *testing.T) {
+		c := new(closer)
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil)
+		require.NoError(t, service.SessionTerminate(c)(ctx, payload))
+
+		assert.Equal(t, []string{"tenant-id/device-uid/session-uid"}, c.closed)
+	})
+
+	t.Run("fails for the worker to retry when the device cannot be reached", func(t *testing.T) {
+		c := &closer{err: errors.New("device offline")}
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil)
+		require.Error(t, service.SessionTerminate(c)(ctx, payload))
+	})
+
+	t.Run("drops an undecodable payload", func(t *testing.T) {
+		c := new(closer)
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil)
+		require.NoError(t, service.SessionTerminate(c)(ctx, []byte("{")))
+
+		assert.Empty(t, c.closed)
+	})
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/recording"
+	"github.com/shellhub-io/shellhub/server/api/store"
+)
+
+// sessionSearchSnippetRadius is how many characters a snippet keeps on each side of the match.
+const sessionSearchSnippetRadius = 80
+
+func (s *service) SearchSessions(ctx context.Context, sc scope.Scope, req *requests.SessionSearch) ([]models.SessionSearchHit, int, error) {
+	search := store.SessionSearch{
+		Phrase: req.Query,
+		Since:  req.Since,
+		Until:  req.Until,
+	}
+
+	opts := append([]store.QueryOption{s.store.Options().Paginate(&req.Paginator)}, s.sessionDeviceScope(req.ScopeTags)...)
+
+	hits, count, err := s.store.SessionEventsSearch(ctx, sc, search, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range hits {
+		hits[i].Snippet = sessionSearchSnippet(hits[i].Snippet, req.Query)
+	}
+
+	return hits, count, nil
+}
+
+// sessionSearchSnippet renders the text of a matching event as plain text, on a single line, and
+// trims it to the part around the phrase's first word. When the word is not found as typed, which
+// happens when the search matched it differently, the snippet is the text's start.
+func sessionSearchSnippet(text, phrase string) string {
+	var plain strings.Builder
+
+	// Writing to a strings.Builder never fails.
+	encoder := recording.NewTextEncoder(&plain)
+	encoder.Output(time.Time{}, text) //nolint:errcheck
+	encoder.Close()                   //nolint:errcheck
+
+	line := []rune(strings.Join(strings.Fields(plain.String()), " "))
+
+	at, length := 0, 0
+	if words := strings.Fields(phrase); len(words) > 0 {
+		// Lowering rune by rune keeps the rune count, so an index found in the lowered text is one
+		// in the original.
+		lowered := strings.Map(unicode.ToLower, string(line))
+		word := strings.Map(unicode.ToLower, words[0])
+
+		if i := strings.Index(lowered, word); i >= 0 {
+			at, length = utf8.RuneCountInString(lowered[:i]), utf8.RuneCountInString(word)
+		}
+	}
+
+	start := max(at-sessionSearchSnippetRadius, 0)
+	end := min(at+length+sessionSearchSnippetRadius, len(line))
+
+	snippet := string(line[start:end])
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+
+	if end < len(line) {
+		snippet += "…"
+	}
+
+	return snippet
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchSessions(t *testing.T) {
+	ctx := context.TODO()
+
+	sc := scope.MustBounded("00000000-0000-4000-0000-000000000000")
+	since := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("fails when the store fails", func(t *testing.T) {
+		storeMock := storemock.NewMockStore(t)
+		queryOptionsMock := storemock.NewMockQueryOptions(t)
+		storeMock.On("Options").Return(queryOptionsMock)
+		queryOptionsMock.On("Paginate", mock.Anything).Return(nil).Once()
+		storeMock.On("SessionEventsSearch", ctx, sc, mock.Anything, mock.Anything).Return(nil, 0, goerrors.New("error")).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		_, _, err := service.SearchSessions(ctx, sc, &requests.SessionSearch{Query: "rm"})
+
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("returns the hits with their snippets trimmed to the match", func(t *testing.T) {
+		storeMock := storemock.NewMockStore(t)
+		queryOptionsMock := storemock.NewMockQueryOptions(t)
+		storeMock.On("Options").Return(queryOptionsMock)
+		queryOptionsMock.On("Paginate", &query.Paginator{Page: 1, PerPage: 10}).Return(nil).Once()
+		storeMock.On("SessionEventsSearch", ctx, sc, store.SessionSearch{Phrase: "rm -rf /var/lib/postgres", Since: since}, mock.Anything).
+			Return([]models.SessionSearchHit{
+				{SessionUID: "uid", Type: models.SessionEventTypePtyOutput, Snippet: "\x1b[01;32mroot@db\x1b[0m:~# rm -rf /var/lib/postgres\r\n"},
+			}, 1, nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+		hits, count, err := service.SearchSessions(ctx, sc, &requests.SessionSearch{
+			Query:     "rm -rf /var/lib/postgres",
+			Since:     since,
+			Paginator: query.Paginator{Page: 1, PerPage: 10},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, count)
+		assert.Equal(t, []models.SessionSearchHit{
+			{SessionUID: "uid", Type: models.SessionEventTypePtyOutput, Snippet: "root@db:~# rm -rf /var/lib/postgres"},
+		}, hits)
+	})
+}
+
+func TestSessionSearchSnippet(t *testing.T) {
+	long := strings.Repeat("a ", 100) + "PostgreSQL " + strings.Repeat("b ", 100)
+
+	cases := []struct {
+		description string
+		text        string
+		phrase      string
+		expected    string
+	}{
+		{
+			description: "joins the lines of short output",
+			text:        "total 0\r\ndrwxr-xr-x  2 root root\r\n",
+			phrase:      "drwxr-xr-x",
+			expected:    "total 0 drwxr-xr-x 2 root root",
+		},
+		{
+			description: "trims long output around the match, whatever its case",
+			text:        long,
+			phrase:      "postgresql restart",
+			expected:    "…" + strings.Repeat("a ", 40) + "PostgreSQL" + strings.Repeat(" b", 40) + "…",
+		},
+		{
+			description: "starts at the beginning when the word is not found as typed",
+			text:        long,
+			phrase:      "-rf",
+			expected:    strings.Repeat("a ", 80)[:80] + "…",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sessionSearchSnippet(tc.text, tc.phrase))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"io"
 	"net"
 
 	"github.com/shellhub-io/shellhub/pkg/api/query"
@@ -39,12 +40,30 @@ type SessionService interface {
 	// GetSession fetches a session within the given namespace scope. The scope is an explicit
 	// parameter rather than something recovered from the request context, so a caller cannot
 	// receive a cross-namespace read by omission.
-	GetSession(ctx context.Context, sc scope.Scope, uid models.UID) (*models.Session, error)
+	GetSession(ctx context.Context, sc scope.Scope, req *requests.SessionGet) (*models.Session, error)
 	CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error)
 	DeactivateSession(ctx context.Context, uid models.UID) error
 	KeepAliveSession(ctx context.Context, uid models.UID) error
 	UpdateSession(ctx context.Context, uid models.UID, model models.SessionUpdate) error
 	EventSession(ctx context.Context, events []models.SessionEvent) error
+
+	// ExportSessionRecording renders a seat's recording to w, in the request's format, as it
+	// reads the recorded events from the store page by page, so a session hours long is never
+	// held in memory. The session is resolved within the given namespace scope first, so one the
+	// caller cannot read fails before anything is written.
+	ExportSessionRecording(ctx context.Context, sc scope.Scope, req *requests.SessionRecordingExport, w io.Writer) error
+
+	// SearchSessions finds the recorded output and exec commands matching the request's phrase
+	// within the given namespace scope, newest first, with the total count of matches. Each hit
+	// tells where in its seat's recording it is, and carries the text around the match.
+	SearchSessions(ctx context.Context, sc scope.Scope, req *requests.SessionSearch) ([]models.SessionSearchHit, int, error)
+
+	// ListSessionCommands lists the commands run on the sessions within the given namespace
+	// scope, newest first, optionally only those of a device user or a ShellHub account.
+	ListSessionCommands(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsList) ([]models.SessionCommand, int, error)
+	// GetSessionCommands lists a session's commands in the order they ran. The session is
+	// resolved within the given namespace scope first.
+	GetSessionCommands(ctx context.Context, sc scope.Scope, req *requests.SessionCommandsGet) ([]models.SessionCommand, int, error)
 }
 
 func (s *service) ListSessions(ctx context.Context, sc scope.Scope, req *requests.ListSessions) ([]models.Session, int, error) {
@@ -52,31 +71,47 @@ func (s *service) ListSessions(ctx context.Context, sc scope.Scope, req *request
 	opts = append(opts, s.store.Options().Match(&req.Filters))
 	opts = append(opts, s.store.Options().Sort(&query.Sorter{By: "started_at", Order: query.OrderDesc, Tiebreak: "id"}))
 	opts = append(opts, s.store.Options().Paginate(&req.Paginator))
+	opts = append(opts, s.sessionDeviceScope(req.ScopeTags)...)
 
 	return s.store.SessionList(ctx, sc, opts...)
 }
 
-func (s *service) GetSession(ctx context.Context, sc scope.Scope, uid models.UID) (*models.Session, error) {
-	session, err := s.store.SessionResolve(ctx, sc, store.SessionUIDResolver, string(uid))
+func (s *service) GetSession(ctx context.Context, sc scope.Scope, req *requests.SessionGet) (*models.Session, error) {
+	session, err := s.store.SessionResolve(ctx, sc, store.SessionUIDResolver, req.UID, s.sessionDeviceScope(req.ScopeTags)...)
 	if err != nil {
-		return nil, NewErrSessionNotFound(uid, err)
+		return nil, NewErrSessionNotFound(models.UID(req.UID), err)
 	}
 
 	return session, nil
 }
 
+// sessionDeviceScope narrows a session query to the sessions on the devices carrying any of tags,
+// the device scope of a scoped API key. A session outside of it reads as missing, so the key
+// cannot learn it exists.
+func (s *service) sessionDeviceScope(tags []string) []store.QueryOption {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return []store.QueryOption{s.store.Options().WithDeviceTags(tags)}
+}
+
 func (s *service) CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error) {
 	position, _ := s.locator.GetPosition(net.ParseIP(session.IPAddress))
 
 	uid, err := s.store.SessionCreate(ctx, models.Session{
-		UID:       session.UID,
-		DeviceUID: models.UID(session.DeviceUID),
-		Username:  session.Username,
-		UserID:    session.UserID,
-		IPAddress: session.IPAddress,
-		Type:      session.Type,
-		Term:      session.Term,
-		Web:       session.Web,
+		UID:                  session.UID,
+		DeviceUID:            models.UID(session.DeviceUID),
+		Username:             session.Username,
+		UserID:               session.UserID,
+		Fingerprint:          session.Fingerprint,
+		CertificateCAID:      session.CertificateCAID,
+		CertificatePrincipal: session.CertificatePrincipal,
+		APIKey:               session.APIKey,
+		IPAddress:            session.IPAddress,
+		Type:                 session.Type,
+		Term:                 session.Term,
+		Web:                  session.Web,
 		Position: models.SessionPosition{
 			Longitude: position.Longitude,
 			Latitude:  position.Latitude,
@@ -88,7 +123,14 @@ func (s *service) CreateSession(ctx context.Context, session requests.SessionCre
 
 	// Reading back the row just written under a UID this call generated; there is no namespace to
 	// bound by until the store has resolved it from the device.
-	return s.store.SessionResolve(ctx, scope.NewUnbounded("reading back the session this call just created, by its generated UID"), store.SessionUIDResolver, uid)
+	created, err := s.store.SessionResolve(ctx, scope.NewUnbounded("reading back the session this call just created, by its generated UID"), store.SessionUIDResolver, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	s.emitEvent(ctx, created.TenantID, models.WebhookEventSessionStart, created)
+
+	return created, nil
 }
 
 func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
@@ -97,7 +139,13 @@ func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
 		return NewErrSessionNotFound(uid, err)
 	}
 
-	return s.store.ActiveSessionDelete(ctx, models.UID(sess.UID))
+	if err := s.store.ActiveSessionDelete(ctx, models.UID(sess.UID)); err != nil {
+		return err
+	}
+
+	s.emitEvent(ctx, sess.TenantID, models.WebhookEventSessionClose, sess)
+
+	return nil
 }
 
 func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
@@ -275,6 +275,40 @@ func TestListSessions(t *testing.T) {
 				err:      nil,
 			},
 		},
+		{
+			description: "succeeds to list the sessions in an API key's tag scope",
+			sc:          scope.MustBounded("00000000-0000-4000-0000-000000000000"),
+			req: &requests.ListSessions{
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				ScopeTags: []string{"production"},
+				Paginator: query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func() {
+				queryOptionsMock.
+					On("Match", mock.AnythingOfType("*query.Filters")).
+					Return(nil).
+					Once()
+				queryOptionsMock.
+					On("Sort", &query.Sorter{By: "started_at", Order: query.OrderDesc, Tiebreak: "id"}).
+					Return(nil).
+					Once()
+				queryOptionsMock.
+					On("Paginate", &query.Paginator{Page: 1, PerPage: 10}).
+					Return(nil).
+					Once()
+				queryOptionsMock.
+					On("WithDeviceTags", []string{"production"}).
+					Return(nil).
+					Once()
+				storeMock.On("SessionList", ctx, scope.MustBounded("00000000-0000-4000-0000-000000000000"), mock.MatchedBy(func(opts []store.QueryOption) bool { return len(opts) == 4 })).
+					Return([]models.Session{{UID: "s1"}}, 1, nil).Once()
+			},
+			expected: Expected{
+				sessions: []models.Session{{UID: "s1"}},
+				count:    1,
+				err:      nil,
+			},
+		},
 	}
 
 	service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache())
@@ -304,15 +338,15 @@ func TestGetSession(t *testing.T) {
 	cases := []struct {
 		name          string
 		scope         scope.Scope
-		uid           models.UID
-		requiredMocks func(storeMock *storemock.MockStore)
+		req           *requests.SessionGet
+		requiredMocks func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions)
 		expected      Expected
 	}{
 		{
 			name:  "fails when session is not found",
 			scope: boundedScope,
-			uid:   models.UID("_uid"),
-			requiredMocks: func(storeMock *storemock.MockStore) {
+			req:   &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "_uid"}},
+			requiredMocks: func(storeMock *storemock.MockStore, _ *storemock.MockQueryOptions) {
 				storeMock.On("SessionResolve", ctx, boundedScope, store.SessionUIDResolver, "_uid").
 					Return(nil, goerrors.New("error")).Once()
 			},
@@ -324,8 +358,8 @@ func TestGetSession(t *testing.T) {
 		{
 			name:  "passes the caller's bounded scope straight to the store",
 			scope: boundedScope,
-			uid:   models.UID("uid"),
-			requiredMocks: func(storeMock *storemock.MockStore) {
+			req:   &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "uid"}},
+			requiredMocks: func(storeMock *storemock.MockStore, _ *storemock.MockQueryOptions) {
 				session := &models.Session{UID: "uid", TenantID: "00000000-0000-4000-0000-000000000000"}
 				storeMock.On("SessionResolve", ctx, boundedScope, store.SessionUIDResolver, "uid").
 					Return(session, nil).Once()
@@ -338,8 +372,8 @@ func TestGetSession(t *testing.T) {
 		{
 			name:  "returns not found for a session the bounded scope excludes",
 			scope: scope.MustBounded("11111111-1111-4111-0000-000000000000"),
-			uid:   models.UID("victim-uid"),
-			requiredMocks: func(storeMock *storemock.MockStore) {
+			req:   &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "victim-uid"}},
+			requiredMocks: func(storeMock *storemock.MockStore, _ *storemock.MockQueryOptions) {
 				storeMock.On("SessionResolve", ctx, scope.MustBounded("11111111-1111-4111-0000-000000000000"), store.SessionUIDResolver, "victim-uid").
 					Return(nil, goerrors.New("not found")).Once()
 			},
@@ -348,15 +382,31 @@ func TestGetSession(t *testing.T) {
 				err:     NewErrSessionNotFound(models.UID("victim-uid"), goerrors.New("not found")),
 			},
 		},
+		{
+			name:  "returns not found for a session outside an API key's tag scope",
+			scope: boundedScope,
+			req:   &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "uid"}, ScopeTags: []string{"production"}},
+			requiredMocks: func(storeMock *storemock.MockStore, queryOptionsMock *storemock.MockQueryOptions) {
+				queryOptionsMock.On("WithDeviceTags", []string{"production"}).Return(nil).Once()
+				storeMock.On("SessionResolve", ctx, boundedScope, store.SessionUIDResolver, "uid", mock.Anything).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{
+				session: nil,
+				err:     NewErrSessionNotFound(models.UID("uid"), store.ErrNoDocuments),
+			},
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			storeMock := storemock.NewMockStore(t)
-			tc.requiredMocks(storeMock)
+			queryOptionsMock := storemock.NewMockQueryOptions(t)
+			storeMock.On("Options").Return(queryOptionsMock).Maybe()
+			tc.requiredMocks(storeMock, queryOptionsMock)
 
 			service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache())
-			returnedSession, err := service.GetSession(ctx, tc.scope, tc.uid)
+			returnedSession, err := service.GetSession(ctx, tc.scope, tc.req)
 			assert.Equal(t, tc.expected, Expected{returnedSession, err})
 			storeMock.AssertExpectations(t)
 		})
@@ -52,7 +52,7 @@ func (s *service) CreateSSHApproval(ctx context.Context, req *requests.SSHApprov
 	}
 
 	now := clock.Now()
-	if err := s.store.SSHApprovalCreate(ctx, &models.SSHApproval{
+	approval := &models.SSHApproval{
 		Code:         code,
 		TenantID:     req.TenantID,
 		Kind:         req.Kind,
@@ -68,10 +68,14 @@ func (s *service) CreateSSHApproval(ctx context.Context, req *requests.SSHApprov
 		State:        models.SSHApprovalPending,
 		RequestedAt:  now,
 		ExpiresAt:    now.Add(sshApprovalTTL),
-	}); err != nil {
+	}
+
+	if err := s.store.SSHApprovalCreate(ctx, approval); err != nil {
 		return nil, err
 	}
 
+	s.emitEvent(ctx, req.TenantID, models.WebhookEventSSHApprovalPending, newEventSSHApproval(approval))
+
 	return &models.SSHApprovalCreated{
 		Code:      code,
 		ExpiresIn: int(sshApprovalTTL.Seconds()),
@@ -225,7 +229,7 @@ func (s *service) decideSSHApproval(ctx context.Context, userID, code string, de
 	// The claim and the effect share a transaction, so the gateway's poll never
 	// reads a confirmation whose effect has not landed, and a failed effect rolls
 	// the claim back — leaving the code decidable again instead of stuck.
-	return s.store.WithTransaction(ctx, func(ctx context.Context) error {
+	if err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
 		claimed, err := s.store.SSHApprovalDecide(ctx, code, decision, userID, now)
 		if err != nil {
 			return err
@@ -240,7 +244,21 @@ func (s *service) decideSSHApproval(ctx context.Context, userID, code string, de
 		}
 
 		return s.applySSHApproval(ctx, userID, approval, expiresIn)
-	})
+	}); err != nil {
+		return err
+	}
+
+	approval.State = decision
+	approval.DecidedBy = userID
+
+	event := models.WebhookEventSSHApprovalRejected
+	if decision == models.SSHApprovalConfirmed {
+		event = models.WebhookEventSSHApprovalConfirmed
+	}
+
+	s.emitEvent(ctx, approval.TenantID, event, newEventSSHApproval(approval))
+
+	return nil
 }
 
 // applySSHApproval performs a confirmation's durable effect: binding the key as
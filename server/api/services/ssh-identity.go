@@ -273,5 +273,20 @@ func (s *service) DeleteSSHIdentity(ctx context.Context, req *requests.SSHIdenti
 		return NewErrForbidden(ErrForbidden, nil)
 	}
 
-	return s.store.SSHIdentityDelete(ctx, &models.SSHIdentity{ID: req.ID, TenantID: req.TenantID})
+	if err := s.store.SSHIdentityDelete(ctx, &models.SSHIdentity{ID: req.ID, TenantID: req.TenantID}); err != nil {
+		return err
+	}
+
+	before, _ := auditDiff(identity, nil)
+	s.recordAudit(ctx, &models.AuditEvent{
+		TenantID:   req.TenantID,
+		Action:     models.AuditActionSSHIdentityRevoke,
+		TargetType: models.AuditTargetSSHIdentity,
+		TargetID:   req.ID,
+		Before:     before,
+	})
+
+	s.reevaluateSessions(ctx, req.TenantID, s.activeSessions(ctx, req.TenantID, identity.PrincipalID))
+
+	return nil
 }
@@ -454,6 +454,10 @@ func TestDeleteSSHIdentity(t *testing.T) {
 				storeMock.On("SSHIdentityResolve", ctx, mock.Anything, store.SSHIdentityIDResolver, idOwn).
 					Return(&models.SSHIdentity{ID: idOwn, PrincipalID: userID, TenantID: tenantID}, nil).Once()
 				storeMock.On("SSHIdentityDelete", ctx, mock.Anything).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionSSHIdentityRevoke && event.Before["id"] == event.TargetID && event.After == nil
+				})).Return(nil).Once()
+				storeMock.On("SessionListActive", ctx, mock.Anything, userID).Return([]models.Session{}, nil).Once()
 			},
 			expectedErr: nil,
 		},
@@ -473,6 +477,25 @@ func TestDeleteSSHIdentity(t *testing.T) {
 				storeMock.On("SSHIdentityResolve", ctx, mock.Anything, store.SSHIdentityIDResolver, idOther).
 					Return(&models.SSHIdentity{ID: idOther, PrincipalID: "someone-else", TenantID: tenantID}, nil).Once()
 				storeMock.On("SSHIdentityDelete", ctx, mock.Anything).Return(nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionSSHIdentityRevoke && event.Before["id"] == event.TargetID && event.After == nil
+				})).Return(nil).Once()
+				// The session logged in with the revoked key ends.
+				storeMock.On("SessionListActive", ctx, mock.Anything, "someone-else").
+					Return([]models.Session{{UID: "session-uid", DeviceUID: "device-uid", UserID: "someone-else", Fingerprint: "SHA256:revoked"}}, nil).Once()
+				storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).Return(&models.Namespace{
+					TenantID: tenantID,
+					Members:  []models.Member{{ID: "someone-else"}},
+					Settings: &models.NamespaceSettings{SSHAccessMode: models.SSHAccessModeIdentity},
+				}, nil).Once()
+				storeMock.On("AccessPolicyList", ctx, mock.Anything).Return([]models.AccessPolicy{}, 0, nil).Once()
+				storeMock.On("SSHIdentityResolve", ctx, mock.Anything, store.SSHIdentityFingerprintResolver, "SHA256:revoked").
+					Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("SessionEventsCreate", ctx, mock.MatchedBy(func(event *models.SessionEvent) bool {
+					return event.Session == "session-uid" &&
+						event.Type == models.SessionEventTypeTerminate &&
+						event.Data.(*models.SessionTermination).Reason == sessionRevokedIdentity
+				})).Return(nil).Once()
 			},
 			expectedErr: nil,
 		},
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// certOptionSourceAddress restricts a certificate to the listed client
+	// addresses, as a comma-separated list of addresses and CIDR ranges.
+	certOptionSourceAddress = "source-address"
+	// certOptionForceCommand replaces whatever the client asks to run with the
+	// given command.
+	certOptionForceCommand = "force-command"
+
+	certExtensionPermitPTY             = "permit-pty"
+	certExtensionPermitPortForwarding  = "permit-port-forwarding"
+	certExtensionPermitAgentForwarding = "permit-agent-forwarding"
+	certExtensionPermitX11Forwarding   = "permit-X11-forwarding"
+)
+
+type SSHUserCAService interface {
+	// ListSSHUserCAs returns the user certificate authorities the namespace trusts.
+	ListSSHUserCAs(ctx context.Context, req *requests.SSHUserCAList) ([]models.SSHUserCA, error)
+
+	// CreateSSHUserCA trusts a user certificate authority in the namespace.
+	CreateSSHUserCA(ctx context.Context, req *requests.SSHUserCACreate) (*models.SSHUserCA, error)
+
+	// UpdateSSHUserCA renames a trusted user certificate authority and replaces the
+	// serials it revoked.
+	UpdateSSHUserCA(ctx context.Context, req *requests.SSHUserCAUpdate) (*models.SSHUserCA, error)
+
+	// DeleteSSHUserCA stops trusting a user certificate authority. Certificates it
+	// issued stop working at the next login.
+	DeleteSSHUserCA(ctx context.Context, req *requests.SSHUserCADelete) error
+
+	// ResolveSSHCertificate decides whether a presented user certificate
+	// authenticates someone in the namespace, and who. It is signed by a CA the
+	// namespace trusts, within its validity window, not revoked, presented from an
+	// address its source-address allows, and names exactly one account. Any
+	// failure is an [ErrSSHCertificateRejected] wrapping why.
+	//
+	// It only reads, so it is safe to run for a certificate the client has merely
+	// offered.
+	ResolveSSHCertificate(ctx context.Context, tenantID string, cert *ssh.Certificate, sourceIP string) (*models.SSHCertificateIdentity, error)
+}
+
+func (s *service) ListSSHUserCAs(ctx context.Context, req *requests.SSHUserCAList) ([]models.SSHUserCA, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	cas, _, err := s.store.SSHUserCAList(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return cas, nil
+}
+
+func (s *service) CreateSSHUserCA(ctx context.Context, req *requests.SSHUserCACreate) (*models.SSHUserCA, error) {
+	if _, err := BoundTo(req.TenantID); err != nil {
+		return nil, err
+	}
+
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.Data)) //nolint:dogsled
+	if err != nil {
+		return nil, NewErrSSHUserCAInvalid(req.Data, err)
+	}
+
+	// A certificate cannot sign other certificates; trusting one would be a
+	// configuration mistake that never matches anything.
+	if _, ok := pubKey.(*ssh.Certificate); ok {
+		return nil, NewErrSSHUserCAInvalid(req.Data, errors.New("a certificate is not a certificate authority key"))
+	}
+
+	ca := &models.SSHUserCA{
+		TenantID:       req.TenantID,
+		Name:           req.Name,
+		Fingerprint:    ssh.FingerprintSHA256(pubKey),
+		Data:           strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pubKey))),
+		RevokedSerials: req.RevokedSerials,
+	}
+
+	id, err := s.store.SSHUserCACreate(ctx, ca)
+	if err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			return nil, NewErrSSHUserCADuplicated(err)
+		}
+
+		return nil, err
+	}
+
+	ca.ID = id
+
+	s.recordAuditChange(ctx, req.TenantID, models.AuditActionSSHUserCACreate, models.AuditTargetSSHUserCA, ca.ID, nil, ca)
+
+	return ca, nil
+}
+
+func (s *service) UpdateSSHUserCA(ctx context.Context, req *requests.SSHUserCAUpdate) (*models.SSHUserCA, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := s.store.SSHUserCAResolve(ctx, sc, store.SSHUserCAIDResolver, req.ID)
+	if err != nil {
+		return nil, NewErrSSHUserCANotFound(req.ID, err)
+	}
+
+	current := *ca
+
+	ca.Name = req.Name
+	ca.RevokedSerials = req.RevokedSerials
+
+	if err := s.store.SSHUserCAUpdate(ctx, ca); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return nil, NewErrSSHUserCANotFound(req.ID, err)
+		}
+
+		return nil, err
+	}
+
+	s.recordAuditChange(ctx, req.TenantID, models.AuditActionSSHUserCAUpdate, models.AuditTargetSSHUserCA, ca.ID, &current, ca)
+
+	return ca, nil
+}
+
+func (s *service) DeleteSSHUserCA(ctx context.Context, req *requests.SSHUserCADelete) error {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return err
+	}
+
+	ca, err := s.store.SSHUserCAResolve(ctx, sc, store.SSHUserCAIDResolver, req.ID)
+	if err != nil {
+		return NewErrSSHUserCANotFound(req.ID, err)
+	}
+
+	if err := s.store.SSHUserCADelete(ctx, ca); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrSSHUserCANotFound(req.ID, err)
+		}
+
+		return err
+	}
+
+	s.recordAuditChange(ctx, req.TenantID, models.AuditActionSSHUserCADelete, models.AuditTargetSSHUserCA, ca.ID, ca, nil)
+
+	return nil
+}
+
+func (s *service) ResolveSSHCertificate(ctx context.Context, tenantID string, cert *ssh.Certificate, sourceIP string) (*models.SSHCertificateIdentity, error) {
+	sc, err := BoundTo(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cert.CertType != ssh.UserCert {
+		return nil, NewErrSSHCertificateRejected(errors.New("not a user certificate"))
+	}
+
+	// Unlike a host, a user certificate without principals is refused outright,
+	// as sshd does: with nothing to name an account, it would vouch for everyone.
+	if len(cert.ValidPrincipals) == 0 {
+		return nil, NewErrSSHCertificateRejected(errors.New("certificate has no principals"))
+	}
+
+	ca, err := s.store.SSHUserCAResolve(ctx, sc, store.SSHUserCAFingerprintResolver, ssh.FingerprintSHA256(cert.SignatureKey))
+	if err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return nil, NewErrSSHCertificateRejected(errors.New("certificate authority is not trusted"))
+		}
+
+		return nil, err
+	}
+
+	checker := &ssh.CertChecker{
+		SupportedCriticalOptions: []string{certOptionForceCommand},
+		IsRevoked: func(cert *ssh.Certificate) bool {
+			return ca.IsRevoked(cert.Serial)
+		},
+		Clock: clock.Now,
+	}
+
+	// CheckCert verifies the CA signature, the validity window, the revocation
+	// and the critical options; the principal it is given only has to be one of
+	// the certificate's own, which the mapping below takes care of.
+	if err := checker.CheckCert(cert.ValidPrincipals[0], cert); err != nil {
+		return nil, NewErrSSHCertificateRejected(err)
+	}
+
+	if addresses, ok := cert.CriticalOptions[certOptionSourceAddress]; ok {
+		if err := checkCertSourceAddress(addresses, sourceIP); err != nil {
+			return nil, NewErrSSHCertificateRejected(err)
+		}
+	}
+
+	namespace, err := s.store.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, tenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	principal, principalID, err := s.resolveCertificatePrincipal(ctx, namespace, cert.ValidPrincipals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SSHCertificateIdentity{
+		CAID:         ca.ID,
+		PrincipalID:  principalID,
+		Principal:    principal,
+		KeyID:        cert.KeyId,
+		Serial:       cert.Serial,
+		ForceCommand: cert.CriticalOptions[certOptionForceCommand],
+		Permissions:  certPermissions(cert),
+	}, nil
+}
+
+// certPermissions reads the permit-* extensions of a certificate. An extension
+// is granted by being present; its value is always empty.
+func certPermissions(cert *ssh.Certificate) models.SSHCertificatePermissions {
+	permits := func(extension string) bool {
+		_, ok := cert.Extensions[extension]
+
+		return ok
+	}
+
+	return models.SSHCertificatePermissions{
+		PTY:             permits(certExtensionPermitPTY),
+		PortForwarding:  permits(certExtensionPermitPortForwarding),
+		AgentForwarding: permits(certExtensionPermitAgentForwarding),
+		X11Forwarding:   permits(certExtensionPermitX11Forwarding),
+	}
+}
+
+// resolveCertificatePrincipal maps a certificate's principals to the one account
+// they name in the namespace. A principal names a human member by username or
+// email, or, failing that, a service account by name. A certificate whose
+// principals name nobody is refused, and so is one naming more than one
+// account: the gateway cannot tell which of them is connecting, or a
+// deactivated one.
+func (s *service) resolveCertificatePrincipal(ctx context.Context, namespace *models.Namespace, principals []string) (string, string, error) {
+	accounts, _, err := s.store.ServiceAccountList(ctx, namespace.TenantID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Service account names are labels, not unique, so a name held twice names
+	// no one in particular and is left out.
+	byName := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		if _, taken := byName[account.Name]; taken {
+			byName[account.Name] = ""
+
+			continue
+		}
+
+		byName[account.Name] = account.ID
+	}
+
+	var matched, matchedID string
+	for _, principal := range principals {
+		id := ""
+
+		user, err := store.UserResolveByAuthIdentifier(ctx, s.store, models.UserAuthIdentifier(principal))
+		switch {
+		case err == nil:
+			if member, ok := namespace.FindMember(user.ID); ok && member.Type != models.UserTypeService {
+				// Deprovisioning cannot take an owner out of their namespace, so a
+				// deactivated account may still be a member here.
+				if user.Disabled {
+					return "", "", NewErrSSHCertificateRejected(fmt.Errorf("principal %q names a deactivated account", principal))
+				}
+
+				id = user.ID
+			}
+		case !errors.Is(err, store.ErrNoDocuments):
+			return "", "", err
+		}
+
+		if id == "" {
+			id = byName[principal]
+		}
+
+		if id == "" || id == matchedID {
+			continue
+		}
+
+		if matchedID != "" {
+			return "", "", NewErrSSHCertificateRejected(fmt.Errorf("principals %q and %q name different accounts", matched, principal))
+		}
+
+		matched, matchedID = principal, id
+	}
+
+	if matchedID == "" {
+		return "", "", NewErrSSHCertificateRejected(errors.New("no principal names an account in the namespace"))
+	}
+
+	return matched, matchedID, nil
+}
+
+// checkCertSourceAddress enforces a certificate's source-address critical option:
+// a comma-separated list of addresses and CIDR ranges the client must connect
+// from. A list that cannot be parsed refuses the certificate, as sshd does.
+func checkCertSourceAddress(addresses, sourceIP string) error {
+	ip := net.ParseIP(sourceIP)
+	if ip == nil {
+		return fmt.Errorf("source address %q cannot be checked against the certificate", sourceIP)
+	}
+
+	for _, address := range strings.Split(addresses, ",") {
+		address = strings.TrimSpace(address)
+
+		if allowed := net.ParseIP(address); allowed != nil {
+			if allowed.Equal(ip) {
+				return nil
+			}
+
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(address)
+		if err != nil {
+			return fmt.Errorf("invalid source-address %q in certificate: %w", address, err)
+		}
+
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("source address %s is not allowed by the certificate", sourceIP)
+}
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestCA returns a fresh ed25519 signer to issue test certificates with.
+func newTestCA(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	return signer
+}
+
+// newTestCertificate issues a user certificate from ca for a fresh key, after
+// letting edit shape it.
+func newTestCertificate(t *testing.T, ca ssh.Signer, edit func(*ssh.Certificate)) *ssh.Certificate {
+	t.Helper()
+
+	cert := &ssh.Certificate{
+		Key:             newTestCA(t).PublicKey(),
+		CertType:        ssh.UserCert,
+		KeyId:           "alice@corp",
+		Serial:          7,
+		ValidPrincipals: []string{"alice"},
+		ValidAfter:      uint64(now.Add(-time.Hour).Unix()),
+		ValidBefore:     uint64(now.Add(time.Hour).Unix()),
+	}
+
+	if edit != nil {
+		edit(cert)
+	}
+
+	require.NoError(t, cert.SignCert(rand.Reader, ca))
+
+	return cert
+}
+
+func TestResolveSSHCertificate(t *testing.T) {
+	ctx := context.TODO()
+
+	const (
+		tenantID = "00000000-0000-4000-0000-000000000000"
+		sourceIP = "192.168.1.10"
+	)
+
+	clockMock.On("Now").Return(now)
+
+	ca := newTestCA(t)
+	trusted := &models.SSHUserCA{ID: "ca1", TenantID: tenantID, Fingerprint: ssh.FingerprintSHA256(ca.PublicKey())}
+	namespace := &models.Namespace{
+		TenantID: tenantID,
+		Members: []models.Member{
+			{ID: "alice-id", Role: authorizer.RoleOperator, Type: models.UserTypeHuman},
+			{ID: "bob-id", Role: authorizer.RoleOperator, Type: models.UserTypeHuman},
+			{ID: "backup-id", Role: authorizer.RoleService, Type: models.UserTypeService},
+		},
+	}
+
+	// trust makes the CA a trusted one, with the given serials revoked.
+	trust := func(storeMock *storemock.MockStore, revoked ...uint64) {
+		ca := *trusted
+		ca.RevokedSerials = revoked
+
+		storeMock.On("SSHUserCAResolve", ctx, mock.Anything, store.SSHUserCAFingerprintResolver, trusted.Fingerprint).
+			Return(&ca, nil).Once()
+	}
+
+	// members answers the principal lookups: alice and bob are human members, and
+	// backup is a service account.
+	members := func(storeMock *storemock.MockStore) {
+		storeMock.On("NamespaceResolve", ctx, store.NamespaceTenantIDResolver, tenantID).Return(namespace, nil).Once()
+		storeMock.On("ServiceAccountList", ctx, tenantID).
+			Return([]models.ServiceAccount{{ID: "backup-id", Name: "backup"}}, 1, nil).Once()
+		storeMock.On("UserResolve", ctx, store.UserUsernameResolver, "alice").
+			Return(&models.User{ID: "alice-id"}, nil).Maybe()
+		storeMock.On("UserResolve", ctx, store.UserUsernameResolver, "bob").
+			Return(&models.User{ID: "bob-id"}, nil).Maybe()
+		storeMock.On("UserResolve", ctx, store.UserUsernameResolver, mock.Anything).
+			Return(nil, store.ErrNoDocuments).Maybe()
+	}
+
+	cases := []struct {
+		description   string
+		cert          *ssh.Certificate
+		requireMocks  func(storeMock *storemock.MockStore)
+		expected      *models.SSHCertificateIdentity
+		expectedError string
+	}{
+		{
+			description: "accepts a certificate as the member its principal names",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.CriticalOptions = map[string]string{"force-command": "uptime", "source-address": "10.0.0.0/8,192.168.1.0/24"}
+			}),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock)
+				members(storeMock)
+			},
+			expected: &models.SSHCertificateIdentity{
+				CAID:         "ca1",
+				PrincipalID:  "alice-id",
+				Principal:    "alice",
+				KeyID:        "alice@corp",
+				Serial:       7,
+				ForceCommand: "uptime",
+			},
+		},
+		{
+			description: "maps a principal to a service account by name",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.ValidPrincipals = []string{"nobody", "backup"}
+			}),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock)
+				members(storeMock)
+			},
+			expected: &models.SSHCertificateIdentity{
+				CAID:        "ca1",
+				PrincipalID: "backup-id",
+				Principal:   "backup",
+				KeyID:       "alice@corp",
+				Serial:      7,
+			},
+		},
+		{
+			description: "carries the permit extensions the certificate lists",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.Permissions.Extensions = map[string]string{"permit-pty": "", "permit-agent-forwarding": ""}
+			}),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock)
+				members(storeMock)
+			},
+			expected: &models.SSHCertificateIdentity{
+				CAID:        "ca1",
+				PrincipalID: "alice-id",
+				Principal:   "alice",
+				KeyID:       "alice@corp",
+				Serial:      7,
+				Permissions: models.SSHCertificatePermissions{PTY: true, AgentForwarding: true},
+			},
+		},
+		{
+			description: "refuses a certificate from an untrusted authority",
+			cert:        newTestCertificate(t, newTestCA(t), nil),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("SSHUserCAResolve", ctx, mock.Anything, store.SSHUserCAFingerprintResolver, mock.Anything).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expectedError: "certificate authority is not trusted",
+		},
+		{
+			description: "refuses a host certificate",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.CertType = ssh.HostCert
+			}),
+			requireMocks:  func(*storemock.MockStore) {},
+			expectedError: "not a user certificate",
+		},
+		{
+			description: "refuses a certificate without principals",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.ValidPrincipals = nil
+			}),
+			requireMocks:  func(*storemock.MockStore) {},
+			expectedError: "certificate has no principals",
+		},
+		{
+			description: "refuses a revoked certificate",
+			cert:        newTestCertificate(t, ca, nil),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock, 3, 7)
+			},
+			expectedError: "revoked",
+		},
+		{
+			description: "refuses an expired certificate",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.ValidBefore = uint64(now.Add(-time.Minute).Unix())
+			}),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock)
+			},
+			expectedError: "cert has expired",
+		},
+		{
+			description: "refuses a certificate signed by someone else in the trusted authority's name",
+			cert: func() *ssh.Certificate {
+				cert := newTestCertificate(t, newTestCA(t), nil)
+				cert.SignatureKey = ca.PublicKey()
+
+				return cert
+			}(),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock)
+			},
+			expectedError: "signature does not verify",
+		},
+		{
+			description: "refuses a critical option it does not know how to honor",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.CriticalOptions = map[string]string{"verify-required": ""}
+			}),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock)
+			},
+			expectedError: "unsupported critical option",
+		},
+		{
+			description: "refuses a connection from outside the source-address",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.CriticalOptions = map[string]string{"source-address": "10.0.0.0/8"}
+			}),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock)
+			},
+			expectedError: "not allowed by the certificate",
+		},
+		{
+			description: "refuses principals naming nobody in the namespace",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.ValidPrincipals = []string{"mallory"}
+			}),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock)
+				members(storeMock)
+			},
+			expectedError: "no principal names an account",
+		},
+		{
+			description: "refuses principals naming more than one account",
+			cert: newTestCertificate(t, ca, func(cert *ssh.Certificate) {
+				cert.ValidPrincipals = []string{"alice", "bob"}
+			}),
+			requireMocks: func(storeMock *storemock.MockStore) {
+				trust(storeMock)
+				members(storeMock)
+			},
+			expectedError: "name different accounts",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := new(storemock.MockStore)
+			tc.requireMocks(storeMock)
+
+			service := NewService(storeMock, privateKey, publicKey, nil)
+
+			identity, err := service.ResolveSSHCertificate(ctx, tenantID, tc.cert, sourceIP)
+			if tc.expectedError != "" {
+				require.ErrorIs(t, err, ErrSSHCertificateRejected)
+				require.ErrorContains(t, err, tc.expectedError)
+				assert.Nil(t, identity)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, identity)
+			}
+
+			storeMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateSSHUserCA(t *testing.T) {
+	ctx := context.TODO()
+
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	ca := newTestCA(t)
+	authorized := string(ssh.MarshalAuthorizedKey(ca.PublicKey()))
+	fingerprint := ssh.FingerprintSHA256(ca.PublicKey())
+
+	t.Run("refuses a request bound to no namespace", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		_, err := service.CreateSSHUserCA(ctx, &requests.SSHUserCACreate{Name: "corp", Data: authorized})
+		require.ErrorIs(t, err, ErrForbidden)
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("rejects an unparseable key", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		_, err := service.CreateSSHUserCA(ctx, &requests.SSHUserCACreate{TenantID: tenantID, Name: "corp", Data: "not-a-key"})
+		require.ErrorIs(t, err, ErrSSHUserCAInvalid)
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("rejects a certificate in place of the authority key", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		cert := string(ssh.MarshalAuthorizedKey(newTestCertificate(t, ca, nil)))
+
+		_, err := service.CreateSSHUserCA(ctx, &requests.SSHUserCACreate{TenantID: tenantID, Name: "corp", Data: cert})
+		require.ErrorIs(t, err, ErrSSHUserCAInvalid)
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("trusts the authority under its SHA256 fingerprint", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("SSHUserCACreate", ctx, mock.MatchedBy(func(ca *models.SSHUserCA) bool {
+			return ca.TenantID == tenantID && ca.Fingerprint == fingerprint && ca.Name == "corp"
+		})).Return("ca1", nil).Once()
+		storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+			return event.TenantID == tenantID && event.Action == models.AuditActionSSHUserCACreate &&
+				event.TargetType == models.AuditTargetSSHUserCA && event.TargetID == "ca1" && event.After["fingerprint"] == fingerprint
+		})).Return(nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		created, err := service.CreateSSHUserCA(ctx, &requests.SSHUserCACreate{TenantID: tenantID, Name: "corp", Data: authorized, RevokedSerials: []uint64{1}})
+		require.NoError(t, err)
+		assert.Equal(t, "ca1", created.ID)
+		assert.Equal(t, []uint64{1}, created.RevokedSerials)
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("reports an authority the namespace already trusts", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("SSHUserCACreate", ctx, mock.Anything).Return("", store.ErrDuplicate).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		_, err := service.CreateSSHUserCA(ctx, &requests.SSHUserCACreate{TenantID: tenantID, Name: "corp", Data: authorized})
+		require.ErrorIs(t, err, ErrSSHUserCADuplicated)
+
+		storeMock.AssertExpectations(t)
+	})
+}
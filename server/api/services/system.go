@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -9,10 +10,12 @@ import (
 	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/server/api/pkg/responses"
+	"github.com/shellhub-io/shellhub/server/api/store"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -82,6 +85,7 @@ func (s *service) GetSystemInfo(ctx context.Context, req *requests.GetSystemInfo
 		},
 		Authentication: &responses.SystemAuthenticationInfo{
 			Local: system.Authentication.Local.Enabled,
+			OIDC:  s.oidc != nil,
 		},
 	}
 
@@ -91,9 +95,51 @@ func (s *service) GetSystemInfo(ctx context.Context, req *requests.GetSystemInfo
 		resp.Endpoints.API = req.Host
 	}
 
+	if req.DeviceUID != "" {
+		resp.Rollout = s.agentRollout(ctx, req.DeviceUID)
+	}
+
 	return resp, nil
 }
 
+// agentRollout decides on updating the device's agent by the instance's agent rollout policy, set on
+// SHELLHUB_AGENT_ROLLOUT. It returns nil when there is no policy, leaving the agents to update to the instance's
+// version. A policy that cannot be used holds every agent instead: a mistake in it must not turn a staged rollout
+// into a fleet-wide one.
+func (s *service) agentRollout(ctx context.Context, uid string) *models.AgentRollout {
+	raw := envs.DefaultBackend.Get("SHELLHUB_AGENT_ROLLOUT")
+	if raw == "" {
+		return nil
+	}
+
+	policy := new(models.AgentRolloutPolicy)
+	if err := json.Unmarshal([]byte(raw), policy); err != nil {
+		log.WithError(err).Error("failed to decode the agent rollout policy")
+
+		return &models.AgentRollout{Selected: false}
+	}
+
+	if ok, err := s.validator.Struct(policy); !ok || err != nil {
+		log.WithError(err).Error("the agent rollout policy is invalid")
+
+		return &models.AgentRollout{Selected: false}
+	}
+
+	if invalid := checkPolicySchedule(policy.Window); len(invalid) > 0 {
+		log.WithField("fields", invalid).Error("the agent rollout policy's window is invalid")
+
+		return &models.AgentRollout{Selected: false}
+	}
+
+	device, err := s.store.DeviceResolve(ctx, scope.NewUnbounded("an agent asks for its update by device UID alone"), store.DeviceUIDResolver, uid)
+	if err != nil {
+		// NOTE: A device the instance doesn't know isn't selected, but still learns the rollout's version.
+		device = nil
+	}
+
+	return policy.Rollout(device)
+}
+
 func (s *service) SystemDownloadInstallScript(_ context.Context, req *requests.SystemInstallScript) (string, error) {
 	raw, err := os.ReadFile("/templates/install.sh")
 	if err != nil {
@@ -7,8 +7,11 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	cachemock "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
 	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -206,3 +209,73 @@ func TestSystemGet(t *testing.T) {
 		assert.Equal(t, setupSystem, system)
 	})
 }
+
+func TestAgentRollout(t *testing.T) {
+	cases := []struct {
+		description   string
+		policy        string
+		requiredMocks func(storeMock *storemock.MockStore)
+		expected      *models.AgentRollout
+	}{
+		{
+			description:   "returns nil without a rollout policy",
+			policy:        "",
+			requiredMocks: func(*storemock.MockStore) {},
+			expected:      nil,
+		},
+		{
+			description:   "holds every device when the rollout policy cannot be decoded",
+			policy:        `{"version":"v0.21.0","percentage":`,
+			requiredMocks: func(*storemock.MockStore) {},
+			expected:      &models.AgentRollout{Selected: false},
+		},
+		{
+			description:   "holds every device when the rollout policy is invalid",
+			policy:        `{"percentage":200}`,
+			requiredMocks: func(*storemock.MockStore) {},
+			expected:      &models.AgentRollout{Selected: false},
+		},
+		{
+			description:   "holds every device when the rollout window is invalid",
+			policy:        `{"version":"v0.21.0","percentage":100,"window":{"timezone":"Mars/Olympus_Mons","windows":[{"weekdays":["sat"],"start":"02:00","end":"04:00"}]}}`,
+			requiredMocks: func(*storemock.MockStore) {},
+			expected:      &models.AgentRollout{Selected: false},
+		},
+		{
+			description: "doesn't select a device the instance doesn't know",
+			policy:      `{"version":"v0.21.0","percentage":100}`,
+			requiredMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("DeviceResolve", mock.Anything, mock.Anything, store.DeviceUIDResolver, "uid").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: &models.AgentRollout{Version: "v0.21.0", Selected: false},
+		},
+		{
+			description: "selects the device by its tags",
+			policy:      `{"version":"v0.21.0","percentage":0,"tags":{"canary":100}}`,
+			requiredMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("DeviceResolve", mock.Anything, mock.Anything, store.DeviceUIDResolver, "uid").
+					Return(&models.Device{UID: "uid", Taggable: models.Taggable{Tags: []models.Tag{{Name: "canary"}}}}, nil).
+					Once()
+			},
+			expected: &models.AgentRollout{Version: "v0.21.0", Selected: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			prevEnv := envs.DefaultBackend
+			env := envmock.NewMockBackend(t)
+			env.On("Get", "SHELLHUB_AGENT_ROLLOUT").Return(tc.policy).Once()
+			envs.DefaultBackend = env
+			t.Cleanup(func() { envs.DefaultBackend = prevEnv })
+
+			storeMock := storemock.NewMockStore(t)
+			tc.requiredMocks(storeMock)
+
+			rollout := NewService(storeMock, privateKey, publicKey, cache.NewNullCache()).agentRollout(context.TODO(), "uid")
+			assert.Equal(t, tc.expected, rollout)
+		})
+	}
+}
@@ -21,7 +21,11 @@ const (
 	CronEphemeralCleanup          = worker.CronSpec("*/5 * * * *")
 	CronEnrollmentCallbackCleanup = worker.CronSpec("0 4 * * *")
 	CronSSHApprovalCleanup        = worker.CronSpec("*/10 * * * *")
+	CronAccessRequestExpiry       = worker.CronSpec("*/5 * * * *")
 	CronSessionCleanup            = worker.CronSpec("0 1 * * *")
+	CronAuditCleanup              = worker.CronSpec("30 1 * * *")
+	CronWebhookDeliveryRetry      = worker.CronSpec("* * * * *")
+	CronWebhookDeliveryCleanup    = worker.CronSpec("45 1 * * *")
 )
 
 const (
@@ -32,7 +32,26 @@ func (s *service) WebReauthVerify(ctx context.Context, req *requests.WebReauthVe
 		return NewErrUserPasswordNotMatch(nil)
 	}
 
-	return StampWebReauth(ctx, s.store, req)
+	// The release below only has the code, so the login it lets through is read
+	// first for the event announcing it. Expired or unknown codes are left for
+	// the release to refuse.
+	var approval *models.SSHApproval
+	if req.ApprovalCode != "" && s.emitsEvents() {
+		approval, _ = s.store.SSHApprovalGet(ctx, pairingcode.Normalize(req.ApprovalCode), clock.Now())
+	}
+
+	if err := StampWebReauth(ctx, s.store, req); err != nil {
+		return err
+	}
+
+	if approval != nil {
+		approval.State = models.SSHApprovalConfirmed
+		approval.DecidedBy = req.UserID
+
+		s.emitEvent(ctx, approval.TenantID, models.WebhookEventSSHApprovalConfirmed, newEventSSHApproval(approval))
+	}
+
+	return nil
 }
 
 // StampWebReauth records a successful step-up on the identity that presented the
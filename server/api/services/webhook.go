@@ -0,0 +1,621 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/shellhub-io/shellhub/pkg/worker"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// TaskWebhookEvent fans an event out to the namespace's subscribed webhooks.
+	TaskWebhookEvent = worker.TaskPattern("webhooks:event")
+	// TaskWebhookDelivery makes one attempt at a delivery.
+	TaskWebhookDelivery = worker.TaskPattern("webhooks:deliver")
+)
+
+const (
+	// webhookDeliveryTimeout bounds a single attempt, connection included.
+	webhookDeliveryTimeout = 10 * time.Second
+
+	// webhookDeliveryMaxAttempts is how many times a delivery is tried before it
+	// is marked failed. With the backoff below the last attempt comes about three
+	// hours after the first.
+	webhookDeliveryMaxAttempts = 10
+
+	// webhookDeliveryBaseBackoff is the wait after the first failed attempt; each
+	// further failure doubles it, up to webhookDeliveryMaxBackoff. Retries are
+	// picked up by a cron that runs every minute, so the first waits are rounded
+	// up to it.
+	webhookDeliveryBaseBackoff = 30 * time.Second
+	webhookDeliveryMaxBackoff  = time.Hour
+
+	// webhookDeliveryRetryBatchSize caps how many due deliveries one retry run
+	// submits. What is left is found by the next run.
+	webhookDeliveryRetryBatchSize = 500
+
+	// webhookDeliveryLogRetention is how long the delivery log keeps an entry.
+	webhookDeliveryLogRetention = 30 * 24 * time.Hour
+)
+
+// WebhookFilterFields maps each filter field the webhook list endpoint accepts
+// to the set of operators valid for it.
+var WebhookFilterFields = query.NewFieldConstraints(map[string][]string{
+	"name": {"contains", "eq"},
+})
+
+// WebhookSortFields is the set of field names accepted in the sort_by query
+// parameter when listing webhooks.
+var WebhookSortFields = query.NewFieldSet(
+	"created_at",
+	"name",
+)
+
+// WebhookDeliveryFilterFields maps each filter field the delivery log endpoint
+// accepts to the set of operators valid for it.
+var WebhookDeliveryFilterFields = query.NewFieldConstraints(map[string][]string{
+	"status":     {"eq", "ne"},
+	"event_type": {"eq", "ne"},
+	"event_id":   {"eq"},
+})
+
+// WebhookDeliverySortFields is the set of field names accepted in the sort_by
+// query parameter when listing a webhook's deliveries.
+var WebhookDeliverySortFields = query.NewFieldSet(
+	"created_at",
+	"status",
+)
+
+type WebhookService interface {
+	// CreateWebhook subscribes a URL to some of the namespace's events.
+	CreateWebhook(ctx context.Context, req *requests.WebhookCreate) (*models.Webhook, error)
+
+	// ListWebhooks returns the namespace's webhooks.
+	ListWebhooks(ctx context.Context, req *requests.WebhookList) ([]models.Webhook, int, error)
+
+	// GetWebhook returns one of the namespace's webhooks.
+	GetWebhook(ctx context.Context, req *requests.WebhookGet) (*models.Webhook, error)
+
+	// UpdateWebhook changes a webhook. Deliveries already made keep the payload
+	// they were made with, but their next attempt goes to the new URL, signed
+	// with the new secret.
+	UpdateWebhook(ctx context.Context, req *requests.WebhookUpdate) (*models.Webhook, error)
+
+	// DeleteWebhook removes a webhook and its delivery log.
+	DeleteWebhook(ctx context.Context, req *requests.WebhookDelete) error
+
+	// ListWebhookDeliveries returns a page of a webhook's delivery log, newest
+	// first unless the request sorts it otherwise.
+	ListWebhookDeliveries(ctx context.Context, req *requests.WebhookDeliveryList) ([]models.WebhookDelivery, int, error)
+
+	// ReplayWebhookDelivery sends a logged delivery's event to its webhook again,
+	// as a new delivery with its own attempts. The event keeps its id, so the
+	// receiver can tell it already saw it.
+	ReplayWebhookDelivery(ctx context.Context, req *requests.WebhookDeliveryReplay) (*models.WebhookDelivery, error)
+}
+
+// validateWebhook checks what the request validation cannot: the URL scheme and
+// the event names. It returns the events, deduplicated.
+func validateWebhook(url string, events []string) ([]models.WebhookEventType, error) {
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return nil, NewErrWebhookInvalidField(map[string]string{"url": "must be an http or https URL"})
+	}
+
+	subscribed := make([]models.WebhookEventType, 0, len(events))
+	for _, event := range events {
+		e := models.WebhookEventType(event)
+		if !slices.Contains(models.WebhookEventTypes, e) {
+			return nil, NewErrWebhookInvalidField(map[string]string{"events": fmt.Sprintf("%q is not a known event", event)})
+		}
+
+		if !slices.Contains(subscribed, e) {
+			subscribed = append(subscribed, e)
+		}
+	}
+
+	return subscribed, nil
+}
+
+func (s *service) CreateWebhook(ctx context.Context, req *requests.WebhookCreate) (*models.Webhook, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := validateWebhook(req.URL, req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &models.Webhook{
+		TenantID: req.TenantID,
+		Name:     req.Name,
+		URL:      req.URL,
+		Secret:   req.Secret,
+		Events:   events,
+		Enabled:  req.Enabled == nil || *req.Enabled,
+	}
+
+	id, err := s.store.WebhookCreate(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.store.WebhookResolve(ctx, sc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, after := auditDiff(nil, created)
+	s.recordAudit(ctx, &models.AuditEvent{
+		TenantID:   req.TenantID,
+		Action:     models.AuditActionWebhookCreate,
+		TargetType: models.AuditTargetWebhook,
+		TargetID:   id,
+		After:      after,
+	})
+
+	return created, nil
+}
+
+func (s *service) ListWebhooks(ctx context.Context, req *requests.WebhookList) ([]models.Webhook, int, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if req.Sorter.By == "" {
+		req.Sorter.By = "created_at"
+	}
+
+	if req.Sorter.Order == "" {
+		req.Sorter.Order = query.OrderDesc
+	}
+
+	req.Sorter.Tiebreak = "id"
+
+	return s.store.WebhookList(
+		ctx,
+		sc,
+		s.store.Options().Match(&req.Filters),
+		s.store.Options().Sort(&req.Sorter),
+		s.store.Options().Paginate(&req.Paginator),
+	)
+}
+
+func (s *service) GetWebhook(ctx context.Context, req *requests.WebhookGet) (*models.Webhook, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.store.WebhookResolve(ctx, sc, req.ID)
+	if err != nil {
+		return nil, NewErrWebhookNotFound(req.ID, err)
+	}
+
+	return webhook, nil
+}
+
+func (s *service) UpdateWebhook(ctx context.Context, req *requests.WebhookUpdate) (*models.Webhook, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.store.WebhookResolve(ctx, sc, req.ID)
+	if err != nil {
+		return nil, NewErrWebhookNotFound(req.ID, err)
+	}
+
+	webhook := *current
+
+	if req.Name != nil {
+		webhook.Name = *req.Name
+	}
+
+	if req.URL != nil {
+		webhook.URL = *req.URL
+	}
+
+	if req.Secret != nil {
+		webhook.Secret = *req.Secret
+	}
+
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+
+	events := make([]string, len(webhook.Events))
+	for i, event := range webhook.Events {
+		events[i] = string(event)
+	}
+
+	if req.Events != nil {
+		events = req.Events
+	}
+
+	if webhook.Events, err = validateWebhook(webhook.URL, events); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.WebhookUpdate(ctx, &webhook); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.WebhookResolve(ctx, sc, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The secret never shows in the diff, so rotating it alone is recorded as
+	// an update that changed nothing visible.
+	before, after := auditDiff(current, updated)
+	s.recordAudit(ctx, &models.AuditEvent{
+		TenantID:   req.TenantID,
+		Action:     models.AuditActionWebhookUpdate,
+		TargetType: models.AuditTargetWebhook,
+		TargetID:   req.ID,
+		Before:     before,
+		After:      after,
+	})
+
+	return updated, nil
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, req *requests.WebhookDelete) error {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.store.WebhookResolve(ctx, sc, req.ID)
+	if err != nil {
+		return NewErrWebhookNotFound(req.ID, err)
+	}
+
+	if err := s.store.WebhookDelete(ctx, current); err != nil {
+		return err
+	}
+
+	before, _ := auditDiff(current, nil)
+	s.recordAudit(ctx, &models.AuditEvent{
+		TenantID:   req.TenantID,
+		Action:     models.AuditActionWebhookDelete,
+		TargetType: models.AuditTargetWebhook,
+		TargetID:   req.ID,
+		Before:     before,
+	})
+
+	return nil
+}
+
+func (s *service) ListWebhookDeliveries(ctx context.Context, req *requests.WebhookDeliveryList) ([]models.WebhookDelivery, int, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := s.store.WebhookResolve(ctx, sc, req.ID); err != nil {
+		return nil, 0, NewErrWebhookNotFound(req.ID, err)
+	}
+
+	if req.Sorter.By == "" {
+		req.Sorter.By = "created_at"
+	}
+
+	if req.Sorter.Order == "" {
+		req.Sorter.Order = query.OrderDesc
+	}
+
+	req.Sorter.Tiebreak = "id"
+
+	return s.store.WebhookDeliveryList(
+		ctx,
+		sc,
+		req.ID,
+		s.store.Options().Match(&req.Filters),
+		s.store.Options().Sort(&req.Sorter),
+		s.store.Options().Paginate(&req.Paginator),
+	)
+}
+
+func (s *service) ReplayWebhookDelivery(ctx context.Context, req *requests.WebhookDeliveryReplay) (*models.WebhookDelivery, error) {
+	sc, err := BoundTo(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.WebhookResolve(ctx, sc, req.ID); err != nil {
+		return nil, NewErrWebhookNotFound(req.ID, err)
+	}
+
+	original, err := s.store.WebhookDeliveryResolve(ctx, sc, req.DeliveryID)
+	if err != nil {
+		return nil, NewErrWebhookDeliveryNotFound(req.DeliveryID, err)
+	}
+
+	if original.WebhookID != req.ID {
+		return nil, NewErrWebhookDeliveryNotFound(req.DeliveryID, nil)
+	}
+
+	now := clock.Now()
+	replay := &models.WebhookDelivery{
+		WebhookID:     original.WebhookID,
+		TenantID:      original.TenantID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		ReplayOf:      original.ID,
+	}
+
+	if _, err := s.store.WebhookDeliveryCreate(ctx, replay); err != nil {
+		return nil, err
+	}
+
+	s.submitWebhookDelivery(ctx, replay.ID)
+
+	return replay, nil
+}
+
+// emitWebhookEvent hands an event of the namespace to the worker, which delivers
+// it to every webhook subscribed to it. It is called once the event happened and
+// never fails the caller; without a worker client events are not delivered at
+// all.
+func (s *service) emitWebhookEvent(ctx context.Context, tenantID string, event models.WebhookEventType, data any) {
+	if s.worker == nil || tenantID == "" {
+		return
+	}
+
+	body, err := json.Marshal(models.WebhookEvent{
+		ID:        uuid.Generate(),
+		Type:      event,
+		TenantID:  tenantID,
+		CreatedAt: clock.Now(),
+		Data:      data,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{"tenant_id": tenantID, "event": event}).
+			Error("failed to encode the webhook event")
+
+		return
+	}
+
+	if err := s.worker.Submit(ctx, TaskWebhookEvent, body); err != nil {
+		log.WithError(err).WithFields(log.Fields{"tenant_id": tenantID, "event": event}).
+			Error("failed to submit the webhook event")
+	}
+}
+
+// WebhookEventFanout handles [TaskWebhookEvent]: it adds a delivery of the event
+// to the log of every webhook subscribed to it, and submits each one. A store
+// failure is returned so the worker retries the task; the deliveries it already
+// made are found again and skipped.
+func (s *service) WebhookEventFanout() worker.TaskHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var event models.WebhookEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			log.WithError(err).Error("dropping an undecodable webhook event")
+
+			return nil
+		}
+
+		sc, err := scope.NewBounded(event.TenantID)
+		if err != nil {
+			log.WithError(err).WithField("event_id", event.ID).Error("dropping a webhook event without a namespace")
+
+			return nil
+		}
+
+		webhooks, err := s.store.WebhookListSubscribed(ctx, sc, event.Type)
+		if err != nil {
+			return err
+		}
+
+		for _, webhook := range webhooks {
+			now := clock.Now()
+			delivery := &models.WebhookDelivery{
+				WebhookID:     webhook.ID,
+				TenantID:      event.TenantID,
+				EventID:       event.ID,
+				EventType:     event.Type,
+				Payload:       payload,
+				Status:        models.WebhookDeliveryPending,
+				NextAttemptAt: &now,
+			}
+
+			if _, err := s.store.WebhookDeliveryCreate(ctx, delivery); err != nil {
+				if errors.Is(err, store.ErrDuplicate) {
+					continue
+				}
+
+				return err
+			}
+
+			s.submitWebhookDelivery(ctx, delivery.ID)
+		}
+
+		return nil
+	}
+}
+
+// WebhookDeliveryAttempt handles [TaskWebhookDelivery]: the payload is the id of
+// the delivery to attempt.
+func (s *service) WebhookDeliveryAttempt() worker.TaskHandler {
+	return func(ctx context.Context, payload []byte) error {
+		return s.attemptWebhookDelivery(ctx, string(payload))
+	}
+}
+
+// WebhookDeliveryRetry submits the pending deliveries whose next attempt is due:
+// those a failed attempt scheduled, and any whose first submission was lost.
+func (s *service) WebhookDeliveryRetry() worker.CronHandler {
+	return func(ctx context.Context) error {
+		due, err := s.store.WebhookDeliveryListDue(ctx, clock.Now(), webhookDeliveryRetryBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range due {
+			s.submitWebhookDelivery(ctx, delivery.ID)
+		}
+
+		return nil
+	}
+}
+
+// WebhookDeliveryCleanup prunes delivery log entries older than the log's
+// retention window.
+func (s *service) WebhookDeliveryCleanup() worker.CronHandler {
+	return func(ctx context.Context) error {
+		deleted, err := s.store.WebhookDeliveryDeleteBefore(ctx, clock.Now().Add(-webhookDeliveryLogRetention))
+		if err != nil {
+			return err
+		}
+
+		if deleted > 0 {
+			log.WithField("deleted", deleted).Info("pruned webhook deliveries past the log retention")
+		}
+
+		return nil
+	}
+}
+
+// submitWebhookDelivery asks the worker to attempt a delivery. A failed
+// submission is only logged: the delivery is already due, so the retry cron
+// submits it again.
+func (s *service) submitWebhookDelivery(ctx context.Context, id string) {
+	if s.worker == nil {
+		return
+	}
+
+	if err := s.worker.Submit(ctx, TaskWebhookDelivery, []byte(id)); err != nil {
+		log.WithError(err).WithField("delivery_id", id).Warn("failed to submit the webhook delivery")
+	}
+}
+
+// attemptWebhookDelivery claims a due delivery, POSTs it to its webhook and
+// writes the outcome. A delivery that is not due, or that someone else claimed,
+// is left alone. The claim holds the delivery for longer than an attempt can
+// take, so a worker that dies mid-attempt only delays it.
+func (s *service) attemptWebhookDelivery(ctx context.Context, id string) error {
+	now := clock.Now()
+
+	claimed, err := s.store.WebhookDeliveryClaim(ctx, id, now, now.Add(2*webhookDeliveryTimeout))
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	// The task names the delivery by its id alone; the namespace is only known
+	// once it is read.
+	delivery, err := s.store.WebhookDeliveryResolve(ctx, scope.NewUnbounded("a webhook delivery task names the delivery by its id alone"), id)
+	if err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return nil
+		}
+
+		return err
+	}
+
+	webhook, err := s.store.WebhookResolve(ctx, scope.MustBounded(delivery.TenantID), delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !webhook.Enabled {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = "the webhook is disabled"
+
+		return s.store.WebhookDeliveryUpdate(ctx, delivery)
+	}
+
+	delivery.Attempts++
+
+	delivery.ResponseStatus, err = sendWebhookDelivery(ctx, webhook, delivery)
+	switch {
+	case err == nil:
+		delivered := clock.Now()
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+		delivery.DeliveredAt = &delivered
+	case delivery.Attempts >= webhookDeliveryMaxAttempts:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = err.Error()
+	default:
+		next := clock.Now().Add(webhookDeliveryBackoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+		delivery.LastError = err.Error()
+	}
+
+	return s.store.WebhookDeliveryUpdate(ctx, delivery)
+}
+
+// webhookDeliveryBackoff is how long to wait after the given number of failed
+// attempts.
+func webhookDeliveryBackoff(attempts int) time.Duration {
+	backoff := webhookDeliveryBaseBackoff
+	for i := 1; i < attempts && backoff < webhookDeliveryMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, webhookDeliveryMaxBackoff)
+}
+
+// sendWebhookDelivery POSTs the delivery's payload to the webhook and returns the
+// status the receiver answered with. Anything but a 2xx is an error. Deliveries
+// go through the same SSRF-guarded client, and are signed the same way, as the
+// enrollment webhook, so the operator's allowed CIDRs cover both.
+func sendWebhookDelivery(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, webhookDeliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-ShellHub-Event", string(delivery.EventType))
+	req.Header.Set("X-ShellHub-Delivery", delivery.ID)
+	req.Header.Set("X-ShellHub-Signature", signEnrollmentWebhook(webhook.Secret, delivery.Payload))
+
+	resp, err := enrollmentWebhookClient().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	workermock "github.com/shellhub-io/shellhub/pkg/worker/mocks"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	storemock "github.com/shellhub-io/shellhub/server/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		req           *requests.WebhookCreate
+		requiredMocks func(storeMock *storemock.MockStore)
+		expectedErr   error
+	}{
+		{
+			description:   "fails when the URL is not http or https",
+			req:           &requests.WebhookCreate{Name: "on-call", URL: "ftp://example.com", Secret: "0123456789abcdef", Events: []string{"device.online"}, TenantID: tenantID},
+			requiredMocks: func(_ *storemock.MockStore) {},
+			expectedErr:   NewErrWebhookInvalidField(map[string]string{"url": "must be an http or https URL"}),
+		},
+		{
+			description:   "fails when an event is unknown",
+			req:           &requests.WebhookCreate{Name: "on-call", URL: "https://example.com", Secret: "0123456789abcdef", Events: []string{"device.exploded"}, TenantID: tenantID},
+			requiredMocks: func(_ *storemock.MockStore) {},
+			expectedErr:   NewErrWebhookInvalidField(map[string]string{"events": `"device.exploded" is not a known event`}),
+		},
+		{
+			description: "creates an enabled webhook with its events deduplicated",
+			req: &requests.WebhookCreate{
+				Name:     "on-call",
+				URL:      "https://example.com",
+				Secret:   "0123456789abcdef",
+				Events:   []string{"device.online", "device.offline", "device.online"},
+				TenantID: tenantID,
+			},
+			requiredMocks: func(storeMock *storemock.MockStore) {
+				storeMock.On("WebhookCreate", ctx, &models.Webhook{
+					TenantID: tenantID,
+					Name:     "on-call",
+					URL:      "https://example.com",
+					Secret:   "0123456789abcdef",
+					Events:   []models.WebhookEventType{models.WebhookEventDeviceOnline, models.WebhookEventDeviceOffline},
+					Enabled:  true,
+				}).Return("webhook-id", nil).Once()
+				storeMock.On("WebhookResolve", ctx, mock.Anything, "webhook-id").
+					Return(&models.Webhook{ID: "webhook-id", TenantID: tenantID, Name: "on-call"}, nil).Once()
+				storeMock.On("AuditEventCreate", ctx, mock.MatchedBy(func(event *models.AuditEvent) bool {
+					return event.Action == models.AuditActionWebhookCreate && event.TargetID == "webhook-id"
+				})).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := new(storemock.MockStore)
+			tc.requiredMocks(storeMock)
+
+			service := NewService(storeMock, privateKey, publicKey, nil)
+
+			_, err := service.CreateWebhook(ctx, tc.req)
+			assert.Equal(t, tc.expectedErr, err)
+
+			storeMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReplayWebhookDelivery(t *testing.T) {
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	ctx := context.TODO()
+
+	clockMock.On("Now").Return(now)
+
+	original := &models.WebhookDelivery{
+		ID:        "delivery-id",
+		WebhookID: "webhook-id",
+		TenantID:  tenantID,
+		EventID:   "event-id",
+		EventType: models.WebhookEventDeviceOffline,
+		Payload:   []byte(`{"id":"event-id"}`),
+		Status:    models.WebhookDeliveryFailed,
+		Attempts:  10,
+	}
+
+	req := &requests.WebhookDeliveryReplay{
+		WebhookIDParam: requests.WebhookIDParam{ID: "webhook-id"},
+		DeliveryID:     "delivery-id",
+		TenantID:       tenantID,
+	}
+
+	t.Run("fails when the delivery belongs to another webhook", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("WebhookResolve", ctx, mock.Anything, "webhook-id").Return(&models.Webhook{ID: "webhook-id"}, nil).Once()
+		storeMock.On("WebhookDeliveryResolve", ctx, mock.Anything, "delivery-id").
+			Return(&models.WebhookDelivery{ID: "delivery-id", WebhookID: "other-id"}, nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		_, err := service.ReplayWebhookDelivery(ctx, req)
+		assert.Equal(t, NewErrWebhookDeliveryNotFound("delivery-id", nil), err)
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("sends the same event as a new delivery", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("WebhookResolve", ctx, mock.Anything, "webhook-id").Return(&models.Webhook{ID: "webhook-id"}, nil).Once()
+		storeMock.On("WebhookDeliveryResolve", ctx, mock.Anything, "delivery-id").Return(original, nil).Once()
+		storeMock.On("WebhookDeliveryCreate", ctx, &models.WebhookDelivery{
+			WebhookID:     "webhook-id",
+			TenantID:      tenantID,
+			EventID:       "event-id",
+			EventType:     models.WebhookEventDeviceOffline,
+			Payload:       original.Payload,
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+			ReplayOf:      "delivery-id",
+		}).Run(func(args mock.Arguments) {
+			args.Get(1).(*models.WebhookDelivery).ID = "replay-id"
+		}).Return("replay-id", nil).Once()
+
+		workerMock := workermock.NewMockClient(t)
+		workerMock.On("Submit", ctx, TaskWebhookDelivery, []byte("replay-id")).Return(nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil, WithWorkerClient(workerMock))
+
+		replay, err := service.ReplayWebhookDelivery(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "replay-id", replay.ID)
+		assert.Equal(t, "delivery-id", replay.ReplayOf)
+
+		storeMock.AssertExpectations(t)
+	})
+}
+
+func TestEmitWebhookEvent(t *testing.T) {
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	ctx := context.TODO()
+
+	clockMock.On("Now").Return(now)
+
+	t.Run("does nothing without a worker client", func(t *testing.T) {
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil)
+
+		service.emitWebhookEvent(ctx, tenantID, models.WebhookEventDeviceOnline, &models.Device{UID: "uid"})
+	})
+
+	t.Run("submits the event for fan-out", func(t *testing.T) {
+		prevUUID := uuid.DefaultBackend
+		t.Cleanup(func() { uuid.DefaultBackend = prevUUID })
+
+		uuidMock := uuidmock.NewMockUUID(t)
+		uuid.DefaultBackend = uuidMock
+		uuidMock.On("Generate").Return("6d8b9a0e-3f1c-4c2e-9a57-1f0e2d3c4b5a").Once()
+
+		workerMock := workermock.NewMockClient(t)
+		workerMock.On("Submit", ctx, TaskWebhookEvent, mock.MatchedBy(func(payload []byte) bool {
+			var event struct {
+				ID       string                  `json:"id"`
+				Type     models.WebhookEventType `json:"type"`
+				TenantID string                  `json:"tenant_id"`
+				Data     models.Device           `json:"data"`
+			}
+
+			return json.Unmarshal(payload, &event) == nil &&
+				event.ID == "6d8b9a0e-3f1c-4c2e-9a57-1f0e2d3c4b5a" &&
+				event.Type == models.WebhookEventDeviceOnline &&
+				event.TenantID == tenantID &&
+				event.Data.UID == "uid"
+		})).Return(nil).Once()
+
+		service := NewService(new(storemock.MockStore), privateKey, publicKey, nil, WithWorkerClient(workerMock))
+
+		service.emitWebhookEvent(ctx, tenantID, models.WebhookEventDeviceOnline, &models.Device{UID: "uid"})
+	})
+}
+
+func TestWebhookEventFanout(t *testing.T) {
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	ctx := context.TODO()
+
+	clockMock.On("Now").Return(now)
+
+	payload := []byte(`{"id":"event-id","type":"device.offline","tenant_id":"` + tenantID + `","data":{}}`)
+
+	storeMock := new(storemock.MockStore)
+	storeMock.On("WebhookListSubscribed", ctx, mock.Anything, models.WebhookEventDeviceOffline).
+		Return([]models.Webhook{{ID: "first-id"}, {ID: "second-id"}}, nil).Once()
+	storeMock.On("WebhookDeliveryCreate", ctx, mock.MatchedBy(func(delivery *models.WebhookDelivery) bool {
+		return delivery.WebhookID == "first-id"
+	})).Run(func(args mock.Arguments) {
+		delivery := args.Get(1).(*models.WebhookDelivery)
+		delivery.ID = "delivery-id"
+
+		assert.Equal(t, "event-id", delivery.EventID)
+		assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, payload, []byte(delivery.Payload))
+	}).Return("delivery-id", nil).Once()
+	// The second webhook already has the event, from a run that failed halfway.
+	storeMock.On("WebhookDeliveryCreate", ctx, mock.MatchedBy(func(delivery *models.WebhookDelivery) bool {
+		return delivery.WebhookID == "second-id"
+	})).Return("", store.ErrDuplicate).Once()
+
+	workerMock := workermock.NewMockClient(t)
+	workerMock.On("Submit", ctx, TaskWebhookDelivery, []byte("delivery-id")).Return(nil).Once()
+
+	service := NewService(storeMock, privateKey, publicKey, nil, WithWorkerClient(workerMock))
+
+	require.NoError(t, service.WebhookEventFanout()(ctx, payload))
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAttemptWebhookDelivery(t *testing.T) {
+	const tenantID = "00000000-0000-4000-0000-000000000000"
+
+	// The receiver runs on loopback in these tests; permit it through the SSRF guard's allowlist.
+	prevEnv := envs.DefaultBackend
+	env := envmock.NewMockBackend(t)
+	env.On("Get", enrollmentWebhookAllowedCIDRsEnv).Return("127.0.0.0/8,::1/128").Maybe()
+	env.On("Get", mock.Anything).Return("").Maybe()
+	envs.DefaultBackend = env
+	t.Cleanup(func() { envs.DefaultBackend = prevEnv })
+
+	clockMock.On("Now").Return(now)
+
+	ctx := context.TODO()
+
+	const secret = "0123456789abcdef"
+	payload := []byte(`{"id":"event-id"}`)
+
+	delivery := func(attempts int) *models.WebhookDelivery {
+		return &models.WebhookDelivery{
+			ID:        "delivery-id",
+			WebhookID: "webhook-id",
+			TenantID:  tenantID,
+			EventID:   "event-id",
+			EventType: models.WebhookEventDeviceOffline,
+			Payload:   payload,
+			Status:    models.WebhookDeliveryPending,
+			Attempts:  attempts,
+		}
+	}
+
+	receiver := func(t *testing.T, status int) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, payload, body)
+			assert.Equal(t, signEnrollmentWebhook(secret, body), r.Header.Get("X-ShellHub-Signature"))
+			assert.Equal(t, "device.offline", r.Header.Get("X-ShellHub-Event"))
+			assert.Equal(t, "delivery-id", r.Header.Get("X-ShellHub-Delivery"))
+
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	expect := func(storeMock *storemock.MockStore, attempts int, url string, enabled bool) {
+		storeMock.On("WebhookDeliveryClaim", ctx, "delivery-id", now, now.Add(2*webhookDeliveryTimeout)).Return(true, nil).Once()
+		storeMock.On("WebhookDeliveryResolve", ctx, mock.Anything, "delivery-id").Return(delivery(attempts), nil).Once()
+		storeMock.On("WebhookResolve", ctx, mock.Anything, "webhook-id").
+			Return(&models.Webhook{ID: "webhook-id", TenantID: tenantID, URL: url, Secret: secret, Enabled: enabled}, nil).Once()
+	}
+
+	t.Run("leaves a delivery someone else claimed alone", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		storeMock.On("WebhookDeliveryClaim", ctx, "delivery-id", now, now.Add(2*webhookDeliveryTimeout)).Return(false, nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		require.NoError(t, service.attemptWebhookDelivery(ctx, "delivery-id"))
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("marks a delivery the receiver accepted as succeeded", func(t *testing.T) {
+		srv := receiver(t, http.StatusNoContent)
+
+		storeMock := new(storemock.MockStore)
+		expect(storeMock, 0, srv.URL, true)
+		storeMock.On("WebhookDeliveryUpdate", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+			return d.Status == models.WebhookDeliverySucceeded &&
+				d.Attempts == 1 &&
+				d.ResponseStatus == http.StatusNoContent &&
+				d.NextAttemptAt == nil &&
+				d.DeliveredAt != nil
+		})).Return(nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		require.NoError(t, service.attemptWebhookDelivery(ctx, "delivery-id"))
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("schedules a retry when the receiver fails", func(t *testing.T) {
+		srv := receiver(t, http.StatusBadGateway)
+
+		storeMock := new(storemock.MockStore)
+		expect(storeMock, 2, srv.URL, true)
+		storeMock.On("WebhookDeliveryUpdate", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+			return d.Status == models.WebhookDeliveryPending &&
+				d.Attempts == 3 &&
+				d.ResponseStatus == http.StatusBadGateway &&
+				d.LastError == "webhook returned status 502" &&
+				d.NextAttemptAt != nil && d.NextAttemptAt.Equal(now.Add(2*time.Minute))
+		})).Return(nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		require.NoError(t, service.attemptWebhookDelivery(ctx, "delivery-id"))
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		srv := receiver(t, http.StatusInternalServerError)
+
+		storeMock := new(storemock.MockStore)
+		expect(storeMock, webhookDeliveryMaxAttempts-1, srv.URL, true)
+		storeMock.On("WebhookDeliveryUpdate", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+			return d.Status == models.WebhookDeliveryFailed &&
+				d.Attempts == webhookDeliveryMaxAttempts &&
+				d.NextAttemptAt == nil
+		})).Return(nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		require.NoError(t, service.attemptWebhookDelivery(ctx, "delivery-id"))
+
+		storeMock.AssertExpectations(t)
+	})
+
+	t.Run("fails the delivery of a disabled webhook without sending it", func(t *testing.T) {
+		storeMock := new(storemock.MockStore)
+		expect(storeMock, 0, "http://127.0.0.1:1", false)
+		storeMock.On("WebhookDeliveryUpdate", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+			return d.Status == models.WebhookDeliveryFailed &&
+				d.Attempts == 0 &&
+				d.LastError == "the webhook is disabled"
+		})).Return(nil).Once()
+
+		service := NewService(storeMock, privateKey, publicKey, nil)
+
+		require.NoError(t, service.attemptWebhookDelivery(ctx, "delivery-id"))
+
+		storeMock.AssertExpectations(t)
+	})
+}
+
+func TestWebhookDeliveryBackoff(t *testing.T) {
+	cases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{20, time.Hour},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, webhookDeliveryBackoff(tc.attempts), "after %d attempts", tc.attempts)
+	}
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AccessRequestStore interface {
+	// AccessRequestCreate creates a new access request and returns its id.
+	AccessRequestCreate(ctx context.Context, request *models.AccessRequest) (string, error)
+	// AccessRequestList retrieves the access requests of a namespace, newest first. Events are not
+	// loaded.
+	AccessRequestList(ctx context.Context, sc scope.Scope, opts ...QueryOption) ([]models.AccessRequest, int, error)
+	// AccessRequestResolve retrieves an access request by its id, scoped to a namespace, with its
+	// events oldest first.
+	AccessRequestResolve(ctx context.Context, sc scope.Scope, id string, opts ...QueryOption) (*models.AccessRequest, error)
+	// AccessRequestTransition writes the request's status and decision fields, but only while the
+	// stored request is still in the from status, and reports whether it moved. The transition is
+	// the claim on the request, so two approvers racing to decide it cannot both win. Call it
+	// inside a transaction with the transition's durable effect.
+	AccessRequestTransition(ctx context.Context, request *models.AccessRequest, from models.AccessRequestStatus) (bool, error)
+	// AccessRequestListLapsed retrieves the granted access requests of every namespace whose access
+	// expired at or before the given time.
+	AccessRequestListLapsed(ctx context.Context, before time.Time) ([]models.AccessRequest, error)
+	// AccessRequestEventCreate appends an event to an access request's audit trail.
+	AccessRequestEventCreate(ctx context.Context, event *models.AccessRequestEvent) error
+}
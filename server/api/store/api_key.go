@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/scope"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -14,6 +15,15 @@ const (
 	APIKeyNameResolver
 )
 
+// APIKeyUse is how many requests an API key authenticated over a while, and when the last one was.
+type APIKeyUse struct {
+	TenantID string
+	// ID is the key's digest.
+	ID         string
+	Count      int64
+	LastUsedAt time.Time
+}
+
 type APIKeyStore interface {
 	// APIKeyCreate creates an API key with the provided data. Returns the inserted ID and an error if any.
 	APIKeyCreate(ctx context.Context, APIKey *models.APIKey) (insertedID string, err error)
@@ -36,6 +46,10 @@ type APIKeyStore interface {
 	// APIKeyUpdate updates an API key. It returns an error if any.
 	APIKeyUpdate(ctx context.Context, apiKey *models.APIKey) (err error)
 
+	// APIKeyRecordUses adds each use's count to its API key's usage counter and stamps the key's last
+	// use, in a single write. A key that no longer exists is skipped. It returns an error if any.
+	APIKeyRecordUses(ctx context.Context, uses []APIKeyUse) (err error)
+
 	// APIKeyDelete deletes an API key. It returns an error if any.
 	APIKeyDelete(ctx context.Context, apiKey *models.APIKey) (err error)
 
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AuditEventStore interface {
+	// AuditEventCreate appends an event to a namespace's audit log.
+	AuditEventCreate(ctx context.Context, event *models.AuditEvent) error
+	// AuditEventList retrieves the audit events of a namespace.
+	AuditEventList(ctx context.Context, sc scope.Scope, opts ...QueryOption) ([]models.AuditEvent, int, error)
+	// AuditEventDeleteBefore deletes the audit events of every namespace recorded before the
+	// given time, and returns how many it deleted.
+	AuditEventDeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
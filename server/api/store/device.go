@@ -77,4 +77,8 @@ type DeviceStore interface {
 	// DeviceListExpiredEphemeral lists ephemeral devices that have stayed offline longer than their
 	// per-device ephemeral timeout (in minutes), so the cleanup can remove them.
 	DeviceListExpiredEphemeral(ctx context.Context) (devices []models.Device, err error)
+
+	// DeviceListDisconnected lists, across every namespace, the devices among uids currently marked
+	// disconnected, so a heartbeat about to bring them back online can tell which ones it does.
+	DeviceListDisconnected(ctx context.Context, uids []string) (devices []models.Device, err error)
 }
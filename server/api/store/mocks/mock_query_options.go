@@ -250,6 +250,59 @@ func (_c *MockQueryOptions_WithDeviceStatus_Call) RunAndReturn(run func(deviceSt
 	return _c
 }
 
+// WithDeviceTags provides a mock function for the type MockQueryOptions
+func (_mock *MockQueryOptions) WithDeviceTags(tags []string) store.QueryOption {
+	ret := _mock.Called(tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithDeviceTags")
+	}
+
+	var r0 store.QueryOption
+	if returnFunc, ok := ret.Get(0).(func([]string) store.QueryOption); ok {
+		r0 = returnFunc(tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.QueryOption)
+		}
+	}
+	return r0
+}
+
+// MockQueryOptions_WithDeviceTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithDeviceTags'
+type MockQueryOptions_WithDeviceTags_Call struct {
+	*mock.Call
+}
+
+// WithDeviceTags is a helper method to define mock.On call
+//   - tags []string
+func (_e *MockQueryOptions_Expecter) WithDeviceTags(tags any) *MockQueryOptions_WithDeviceTags_Call {
+	return &MockQueryOptions_WithDeviceTags_Call{Call: _e.mock.On("WithDeviceTags", tags)}
+}
+
+func (_c *MockQueryOptions_WithDeviceTags_Call) Run(run func(tags []string)) *MockQueryOptions_WithDeviceTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueryOptions_WithDeviceTags_Call) Return(queryOption store.QueryOption) *MockQueryOptions_WithDeviceTags_Call {
+	_c.Call.Return(queryOption)
+	return _c
+}
+
+func (_c *MockQueryOptions_WithDeviceTags_Call) RunAndReturn(run func(tags []string) store.QueryOption) *MockQueryOptions_WithDeviceTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WithMember provides a mock function for the type MockQueryOptions
 func (_mock *MockQueryOptions) WithMember(userID string) store.QueryOption {
 	ret := _mock.Called(userID)
@@ -396,6 +396,63 @@ func (_c *MockStore_APIKeyList_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// APIKeyRecordUses provides a mock function for the type MockStore
+func (_mock *MockStore) APIKeyRecordUses(ctx context.Context, uses []store.APIKeyUse) error {
+	ret := _mock.Called(ctx, uses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for APIKeyRecordUses")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []store.APIKeyUse) error); ok {
+		r0 = returnFunc(ctx, uses)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_APIKeyRecordUses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APIKeyRecordUses'
+type MockStore_APIKeyRecordUses_Call struct {
+	*mock.Call
+}
+
+// APIKeyRecordUses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uses []store.APIKeyUse
+func (_e *MockStore_Expecter) APIKeyRecordUses(ctx any, uses any) *MockStore_APIKeyRecordUses_Call {
+	return &MockStore_APIKeyRecordUses_Call{Call: _e.mock.On("APIKeyRecordUses", ctx, uses)}
+}
+
+func (_c *MockStore_APIKeyRecordUses_Call) Run(run func(ctx context.Context, uses []store.APIKeyUse)) *MockStore_APIKeyRecordUses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []store.APIKeyUse
+		if args[1] != nil {
+			arg1 = args[1].([]store.APIKeyUse)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_APIKeyRecordUses_Call) Return(err error) *MockStore_APIKeyRecordUses_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_APIKeyRecordUses_Call) RunAndReturn(run func(ctx context.Context, uses []store.APIKeyUse) error) *MockStore_APIKeyRecordUses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // APIKeyResolve provides a mock function for the type MockStore
 func (_mock *MockStore) APIKeyResolve(ctx context.Context, sc scope.Scope, resolver store.APIKeyResolver, value string, opts ...store.QueryOption) (*models.APIKey, error) {
 	var tmpRet mock.Arguments
@@ -912,44 +969,53 @@ func (_c *MockStore_AccessPolicyUpdate_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
-// ActiveSessionCreate provides a mock function for the type MockStore
-func (_mock *MockStore) ActiveSessionCreate(ctx context.Context, session *models.Session) error {
-	ret := _mock.Called(ctx, session)
+// AccessRequestCreate provides a mock function for the type MockStore
+func (_mock *MockStore) AccessRequestCreate(ctx context.Context, request *models.AccessRequest) (string, error) {
+	ret := _mock.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ActiveSessionCreate")
+		panic("no return value specified for AccessRequestCreate")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
-		r0 = returnFunc(ctx, session)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.AccessRequest) (string, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.AccessRequest) string); ok {
+		r0 = returnFunc(ctx, request)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.AccessRequest) error); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_ActiveSessionCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveSessionCreate'
-type MockStore_ActiveSessionCreate_Call struct {
+// MockStore_AccessRequestCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessRequestCreate'
+type MockStore_AccessRequestCreate_Call struct {
 	*mock.Call
 }
 
-// ActiveSessionCreate is a helper method to define mock.On call
+// AccessRequestCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - session *models.Session
-func (_e *MockStore_Expecter) ActiveSessionCreate(ctx any, session any) *MockStore_ActiveSessionCreate_Call {
-	return &MockStore_ActiveSessionCreate_Call{Call: _e.mock.On("ActiveSessionCreate", ctx, session)}
+//   - request *models.AccessRequest
+func (_e *MockStore_Expecter) AccessRequestCreate(ctx any, request any) *MockStore_AccessRequestCreate_Call {
+	return &MockStore_AccessRequestCreate_Call{Call: _e.mock.On("AccessRequestCreate", ctx, request)}
 }
 
-func (_c *MockStore_ActiveSessionCreate_Call) Run(run func(ctx context.Context, session *models.Session)) *MockStore_ActiveSessionCreate_Call {
+func (_c *MockStore_AccessRequestCreate_Call) Run(run func(ctx context.Context, request *models.AccessRequest)) *MockStore_AccessRequestCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Session
+		var arg1 *models.AccessRequest
 		if args[1] != nil {
-			arg1 = args[1].(*models.Session)
+			arg1 = args[1].(*models.AccessRequest)
 		}
 		run(
 			arg0,
@@ -959,54 +1025,54 @@ func (_c *MockStore_ActiveSessionCreate_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockStore_ActiveSessionCreate_Call) Return(err error) *MockStore_ActiveSessionCreate_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_AccessRequestCreate_Call) Return(s string, err error) *MockStore_AccessRequestCreate_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockStore_ActiveSessionCreate_Call) RunAndReturn(run func(ctx context.Context, session *models.Session) error) *MockStore_ActiveSessionCreate_Call {
+func (_c *MockStore_AccessRequestCreate_Call) RunAndReturn(run func(ctx context.Context, request *models.AccessRequest) (string, error)) *MockStore_AccessRequestCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ActiveSessionDelete provides a mock function for the type MockStore
-func (_mock *MockStore) ActiveSessionDelete(ctx context.Context, uid models.UID) error {
-	ret := _mock.Called(ctx, uid)
+// AccessRequestEventCreate provides a mock function for the type MockStore
+func (_mock *MockStore) AccessRequestEventCreate(ctx context.Context, event *models.AccessRequestEvent) error {
+	ret := _mock.Called(ctx, event)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ActiveSessionDelete")
+		panic("no return value specified for AccessRequestEventCreate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = returnFunc(ctx, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.AccessRequestEvent) error); ok {
+		r0 = returnFunc(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_ActiveSessionDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveSessionDelete'
-type MockStore_ActiveSessionDelete_Call struct {
+// MockStore_AccessRequestEventCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessRequestEventCreate'
+type MockStore_AccessRequestEventCreate_Call struct {
 	*mock.Call
 }
 
-// ActiveSessionDelete is a helper method to define mock.On call
+// AccessRequestEventCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid models.UID
-func (_e *MockStore_Expecter) ActiveSessionDelete(ctx any, uid any) *MockStore_ActiveSessionDelete_Call {
-	return &MockStore_ActiveSessionDelete_Call{Call: _e.mock.On("ActiveSessionDelete", ctx, uid)}
+//   - event *models.AccessRequestEvent
+func (_e *MockStore_Expecter) AccessRequestEventCreate(ctx any, event any) *MockStore_AccessRequestEventCreate_Call {
+	return &MockStore_AccessRequestEventCreate_Call{Call: _e.mock.On("AccessRequestEventCreate", ctx, event)}
 }
 
-func (_c *MockStore_ActiveSessionDelete_Call) Run(run func(ctx context.Context, uid models.UID)) *MockStore_ActiveSessionDelete_Call {
+func (_c *MockStore_AccessRequestEventCreate_Call) Run(run func(ctx context.Context, event *models.AccessRequestEvent)) *MockStore_AccessRequestEventCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.UID
+		var arg1 *models.AccessRequestEvent
 		if args[1] != nil {
-			arg1 = args[1].(models.UID)
+			arg1 = args[1].(*models.AccessRequestEvent)
 		}
 		run(
 			arg0,
@@ -1016,128 +1082,154 @@ func (_c *MockStore_ActiveSessionDelete_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockStore_ActiveSessionDelete_Call) Return(err error) *MockStore_ActiveSessionDelete_Call {
+func (_c *MockStore_AccessRequestEventCreate_Call) Return(err error) *MockStore_AccessRequestEventCreate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_ActiveSessionDelete_Call) RunAndReturn(run func(ctx context.Context, uid models.UID) error) *MockStore_ActiveSessionDelete_Call {
+func (_c *MockStore_AccessRequestEventCreate_Call) RunAndReturn(run func(ctx context.Context, event *models.AccessRequestEvent) error) *MockStore_AccessRequestEventCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ActiveSessionResolve provides a mock function for the type MockStore
-func (_mock *MockStore) ActiveSessionResolve(ctx context.Context, resolver store.SessionResolver, value string) (*models.ActiveSession, error) {
-	ret := _mock.Called(ctx, resolver, value)
+// AccessRequestList provides a mock function for the type MockStore
+func (_mock *MockStore) AccessRequestList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.AccessRequest, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for ActiveSessionResolve")
+		panic("no return value specified for AccessRequestList")
 	}
 
-	var r0 *models.ActiveSession
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, store.SessionResolver, string) (*models.ActiveSession, error)); ok {
-		return returnFunc(ctx, resolver, value)
+	var r0 []models.AccessRequest
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.AccessRequest, int, error)); ok {
+		return returnFunc(ctx, sc, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, store.SessionResolver, string) *models.ActiveSession); ok {
-		r0 = returnFunc(ctx, resolver, value)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.AccessRequest); ok {
+		r0 = returnFunc(ctx, sc, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ActiveSession)
+			r0 = ret.Get(0).([]models.AccessRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, store.SessionResolver, string) error); ok {
-		r1 = returnFunc(ctx, resolver, value)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, opts...)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_ActiveSessionResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveSessionResolve'
-type MockStore_ActiveSessionResolve_Call struct {
+// MockStore_AccessRequestList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessRequestList'
+type MockStore_AccessRequestList_Call struct {
 	*mock.Call
 }
 
-// ActiveSessionResolve is a helper method to define mock.On call
+// AccessRequestList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - resolver store.SessionResolver
-//   - value string
-func (_e *MockStore_Expecter) ActiveSessionResolve(ctx any, resolver any, value any) *MockStore_ActiveSessionResolve_Call {
-	return &MockStore_ActiveSessionResolve_Call{Call: _e.mock.On("ActiveSessionResolve", ctx, resolver, value)}
+//   - sc scope.Scope
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) AccessRequestList(ctx any, sc any, opts ...any) *MockStore_AccessRequestList_Call {
+	return &MockStore_AccessRequestList_Call{Call: _e.mock.On("AccessRequestList",
+		append([]any{ctx, sc}, opts...)...)}
 }
 
-func (_c *MockStore_ActiveSessionResolve_Call) Run(run func(ctx context.Context, resolver store.SessionResolver, value string)) *MockStore_ActiveSessionResolve_Call {
+func (_c *MockStore_AccessRequestList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_AccessRequestList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 store.SessionResolver
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(store.SessionResolver)
+			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+		var arg2 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
 		}
+		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2,
+			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_ActiveSessionResolve_Call) Return(activeSession *models.ActiveSession, err error) *MockStore_ActiveSessionResolve_Call {
-	_c.Call.Return(activeSession, err)
+func (_c *MockStore_AccessRequestList_Call) Return(accessRequests []models.AccessRequest, n int, err error) *MockStore_AccessRequestList_Call {
+	_c.Call.Return(accessRequests, n, err)
 	return _c
 }
 
-func (_c *MockStore_ActiveSessionResolve_Call) RunAndReturn(run func(ctx context.Context, resolver store.SessionResolver, value string) (*models.ActiveSession, error)) *MockStore_ActiveSessionResolve_Call {
+func (_c *MockStore_AccessRequestList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.AccessRequest, int, error)) *MockStore_AccessRequestList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ActiveSessionUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) ActiveSessionUpdate(ctx context.Context, activeSession *models.ActiveSession) error {
-	ret := _mock.Called(ctx, activeSession)
+// AccessRequestListLapsed provides a mock function for the type MockStore
+func (_mock *MockStore) AccessRequestListLapsed(ctx context.Context, before time.Time) ([]models.AccessRequest, error) {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ActiveSessionUpdate")
+		panic("no return value specified for AccessRequestListLapsed")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.ActiveSession) error); ok {
-		r0 = returnFunc(ctx, activeSession)
+	var r0 []models.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.AccessRequest, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []models.AccessRequest); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessRequest)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_ActiveSessionUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveSessionUpdate'
-type MockStore_ActiveSessionUpdate_Call struct {
+// MockStore_AccessRequestListLapsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessRequestListLapsed'
+type MockStore_AccessRequestListLapsed_Call struct {
 	*mock.Call
 }
 
-// ActiveSessionUpdate is a helper method to define mock.On call
+// AccessRequestListLapsed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - activeSession *models.ActiveSession
-func (_e *MockStore_Expecter) ActiveSessionUpdate(ctx any, activeSession any) *MockStore_ActiveSessionUpdate_Call {
-	return &MockStore_ActiveSessionUpdate_Call{Call: _e.mock.On("ActiveSessionUpdate", ctx, activeSession)}
+//   - before time.Time
+func (_e *MockStore_Expecter) AccessRequestListLapsed(ctx any, before any) *MockStore_AccessRequestListLapsed_Call {
+	return &MockStore_AccessRequestListLapsed_Call{Call: _e.mock.On("AccessRequestListLapsed", ctx, before)}
 }
 
-func (_c *MockStore_ActiveSessionUpdate_Call) Run(run func(ctx context.Context, activeSession *models.ActiveSession)) *MockStore_ActiveSessionUpdate_Call {
+func (_c *MockStore_AccessRequestListLapsed_Call) Run(run func(ctx context.Context, before time.Time)) *MockStore_AccessRequestListLapsed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.ActiveSession
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(*models.ActiveSession)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
@@ -1147,72 +1239,66 @@ func (_c *MockStore_ActiveSessionUpdate_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockStore_ActiveSessionUpdate_Call) Return(err error) *MockStore_ActiveSessionUpdate_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_AccessRequestListLapsed_Call) Return(accessRequests []models.AccessRequest, err error) *MockStore_AccessRequestListLapsed_Call {
+	_c.Call.Return(accessRequests, err)
 	return _c
 }
 
-func (_c *MockStore_ActiveSessionUpdate_Call) RunAndReturn(run func(ctx context.Context, activeSession *models.ActiveSession) error) *MockStore_ActiveSessionUpdate_Call {
+func (_c *MockStore_AccessRequestListLapsed_Call) RunAndReturn(run func(ctx context.Context, before time.Time) ([]models.AccessRequest, error)) *MockStore_AccessRequestListLapsed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceConflicts provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceConflicts(ctx context.Context, sc scope.Scope, target *models.DeviceConflicts, opts ...store.QueryOption) ([]string, bool, error) {
+// AccessRequestResolve provides a mock function for the type MockStore
+func (_mock *MockStore) AccessRequestResolve(ctx context.Context, sc scope.Scope, id string, opts ...store.QueryOption) (*models.AccessRequest, error) {
 	var tmpRet mock.Arguments
 	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, target, opts)
+		tmpRet = _mock.Called(ctx, sc, id, opts)
 	} else {
-		tmpRet = _mock.Called(ctx, sc, target)
+		tmpRet = _mock.Called(ctx, sc, id)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceConflicts")
+		panic("no return value specified for AccessRequestResolve")
 	}
 
-	var r0 []string
-	var r1 bool
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.DeviceConflicts, ...store.QueryOption) ([]string, bool, error)); ok {
-		return returnFunc(ctx, sc, target, opts...)
+	var r0 *models.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, ...store.QueryOption) (*models.AccessRequest, error)); ok {
+		return returnFunc(ctx, sc, id, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.DeviceConflicts, ...store.QueryOption) []string); ok {
-		r0 = returnFunc(ctx, sc, target, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, ...store.QueryOption) *models.AccessRequest); ok {
+		r0 = returnFunc(ctx, sc, id, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).(*models.AccessRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *models.DeviceConflicts, ...store.QueryOption) bool); ok {
-		r1 = returnFunc(ctx, sc, target, opts...)
-	} else {
-		r1 = ret.Get(1).(bool)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *models.DeviceConflicts, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, sc, target, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, string, ...store.QueryOption) error); ok {
+		r1 = returnFunc(ctx, sc, id, opts...)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_DeviceConflicts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceConflicts'
-type MockStore_DeviceConflicts_Call struct {
+// MockStore_AccessRequestResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessRequestResolve'
+type MockStore_AccessRequestResolve_Call struct {
 	*mock.Call
 }
 
-// DeviceConflicts is a helper method to define mock.On call
+// AccessRequestResolve is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - target *models.DeviceConflicts
+//   - id string
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) DeviceConflicts(ctx any, sc any, target any, opts ...any) *MockStore_DeviceConflicts_Call {
-	return &MockStore_DeviceConflicts_Call{Call: _e.mock.On("DeviceConflicts",
-		append([]any{ctx, sc, target}, opts...)...)}
+func (_e *MockStore_Expecter) AccessRequestResolve(ctx any, sc any, id any, opts ...any) *MockStore_AccessRequestResolve_Call {
+	return &MockStore_AccessRequestResolve_Call{Call: _e.mock.On("AccessRequestResolve",
+		append([]any{ctx, sc, id}, opts...)...)}
 }
 
-func (_c *MockStore_DeviceConflicts_Call) Run(run func(ctx context.Context, sc scope.Scope, target *models.DeviceConflicts, opts ...store.QueryOption)) *MockStore_DeviceConflicts_Call {
+func (_c *MockStore_AccessRequestResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, id string, opts ...store.QueryOption)) *MockStore_AccessRequestResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1222,9 +1308,9 @@ func (_c *MockStore_DeviceConflicts_Call) Run(run func(ctx context.Context, sc s
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 *models.DeviceConflicts
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*models.DeviceConflicts)
+			arg2 = args[2].(string)
 		}
 		var arg3 []store.QueryOption
 		var variadicArgs []store.QueryOption
@@ -1242,120 +1328,126 @@ func (_c *MockStore_DeviceConflicts_Call) Run(run func(ctx context.Context, sc s
 	return _c
 }
 
-func (_c *MockStore_DeviceConflicts_Call) Return(conflicts []string, has bool, err error) *MockStore_DeviceConflicts_Call {
-	_c.Call.Return(conflicts, has, err)
+func (_c *MockStore_AccessRequestResolve_Call) Return(accessRequest *models.AccessRequest, err error) *MockStore_AccessRequestResolve_Call {
+	_c.Call.Return(accessRequest, err)
 	return _c
 }
 
-func (_c *MockStore_DeviceConflicts_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, target *models.DeviceConflicts, opts ...store.QueryOption) ([]string, bool, error)) *MockStore_DeviceConflicts_Call {
+func (_c *MockStore_AccessRequestResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, id string, opts ...store.QueryOption) (*models.AccessRequest, error)) *MockStore_AccessRequestResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceCreate provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceCreate(ctx context.Context, device *models.Device) (string, error) {
-	ret := _mock.Called(ctx, device)
+// AccessRequestTransition provides a mock function for the type MockStore
+func (_mock *MockStore) AccessRequestTransition(ctx context.Context, request *models.AccessRequest, from models.AccessRequestStatus) (bool, error) {
+	ret := _mock.Called(ctx, request, from)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceCreate")
+		panic("no return value specified for AccessRequestTransition")
 	}
 
-	var r0 string
+	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Device) (string, error)); ok {
-		return returnFunc(ctx, device)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.AccessRequest, models.AccessRequestStatus) (bool, error)); ok {
+		return returnFunc(ctx, request, from)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Device) string); ok {
-		r0 = returnFunc(ctx, device)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.AccessRequest, models.AccessRequestStatus) bool); ok {
+		r0 = returnFunc(ctx, request, from)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.Device) error); ok {
-		r1 = returnFunc(ctx, device)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.AccessRequest, models.AccessRequestStatus) error); ok {
+		r1 = returnFunc(ctx, request, from)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_DeviceCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceCreate'
-type MockStore_DeviceCreate_Call struct {
+// MockStore_AccessRequestTransition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessRequestTransition'
+type MockStore_AccessRequestTransition_Call struct {
 	*mock.Call
 }
 
-// DeviceCreate is a helper method to define mock.On call
+// AccessRequestTransition is a helper method to define mock.On call
 //   - ctx context.Context
-//   - device *models.Device
-func (_e *MockStore_Expecter) DeviceCreate(ctx any, device any) *MockStore_DeviceCreate_Call {
-	return &MockStore_DeviceCreate_Call{Call: _e.mock.On("DeviceCreate", ctx, device)}
+//   - request *models.AccessRequest
+//   - from models.AccessRequestStatus
+func (_e *MockStore_Expecter) AccessRequestTransition(ctx any, request any, from any) *MockStore_AccessRequestTransition_Call {
+	return &MockStore_AccessRequestTransition_Call{Call: _e.mock.On("AccessRequestTransition", ctx, request, from)}
 }
 
-func (_c *MockStore_DeviceCreate_Call) Run(run func(ctx context.Context, device *models.Device)) *MockStore_DeviceCreate_Call {
+func (_c *MockStore_AccessRequestTransition_Call) Run(run func(ctx context.Context, request *models.AccessRequest, from models.AccessRequestStatus)) *MockStore_AccessRequestTransition_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Device
+		var arg1 *models.AccessRequest
 		if args[1] != nil {
-			arg1 = args[1].(*models.Device)
+			arg1 = args[1].(*models.AccessRequest)
+		}
+		var arg2 models.AccessRequestStatus
+		if args[2] != nil {
+			arg2 = args[2].(models.AccessRequestStatus)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_DeviceCreate_Call) Return(insertedUID string, err error) *MockStore_DeviceCreate_Call {
-	_c.Call.Return(insertedUID, err)
+func (_c *MockStore_AccessRequestTransition_Call) Return(b bool, err error) *MockStore_AccessRequestTransition_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockStore_DeviceCreate_Call) RunAndReturn(run func(ctx context.Context, device *models.Device) (string, error)) *MockStore_DeviceCreate_Call {
+func (_c *MockStore_AccessRequestTransition_Call) RunAndReturn(run func(ctx context.Context, request *models.AccessRequest, from models.AccessRequestStatus) (bool, error)) *MockStore_AccessRequestTransition_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceDelete provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceDelete(ctx context.Context, device *models.Device) error {
-	ret := _mock.Called(ctx, device)
+// ActiveSessionCreate provides a mock function for the type MockStore
+func (_mock *MockStore) ActiveSessionCreate(ctx context.Context, session *models.Session) error {
+	ret := _mock.Called(ctx, session)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceDelete")
+		panic("no return value specified for ActiveSessionCreate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Device) error); ok {
-		r0 = returnFunc(ctx, device)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
+		r0 = returnFunc(ctx, session)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_DeviceDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceDelete'
-type MockStore_DeviceDelete_Call struct {
+// MockStore_ActiveSessionCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveSessionCreate'
+type MockStore_ActiveSessionCreate_Call struct {
 	*mock.Call
 }
 
-// DeviceDelete is a helper method to define mock.On call
+// ActiveSessionCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - device *models.Device
-func (_e *MockStore_Expecter) DeviceDelete(ctx any, device any) *MockStore_DeviceDelete_Call {
-	return &MockStore_DeviceDelete_Call{Call: _e.mock.On("DeviceDelete", ctx, device)}
+//   - session *models.Session
+func (_e *MockStore_Expecter) ActiveSessionCreate(ctx any, session any) *MockStore_ActiveSessionCreate_Call {
+	return &MockStore_ActiveSessionCreate_Call{Call: _e.mock.On("ActiveSessionCreate", ctx, session)}
 }
 
-func (_c *MockStore_DeviceDelete_Call) Run(run func(ctx context.Context, device *models.Device)) *MockStore_DeviceDelete_Call {
+func (_c *MockStore_ActiveSessionCreate_Call) Run(run func(ctx context.Context, session *models.Session)) *MockStore_ActiveSessionCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Device
+		var arg1 *models.Session
 		if args[1] != nil {
-			arg1 = args[1].(*models.Device)
+			arg1 = args[1].(*models.Session)
 		}
 		run(
 			arg0,
@@ -1365,488 +1457,472 @@ func (_c *MockStore_DeviceDelete_Call) Run(run func(ctx context.Context, device
 	return _c
 }
 
-func (_c *MockStore_DeviceDelete_Call) Return(err error) *MockStore_DeviceDelete_Call {
+func (_c *MockStore_ActiveSessionCreate_Call) Return(err error) *MockStore_ActiveSessionCreate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_DeviceDelete_Call) RunAndReturn(run func(ctx context.Context, device *models.Device) error) *MockStore_DeviceDelete_Call {
+func (_c *MockStore_ActiveSessionCreate_Call) RunAndReturn(run func(ctx context.Context, session *models.Session) error) *MockStore_ActiveSessionCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceDeleteCustomField provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceDeleteCustomField(ctx context.Context, uid string, key string) error {
-	ret := _mock.Called(ctx, uid, key)
+// ActiveSessionDelete provides a mock function for the type MockStore
+func (_mock *MockStore) ActiveSessionDelete(ctx context.Context, uid models.UID) error {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceDeleteCustomField")
+		panic("no return value specified for ActiveSessionDelete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_DeviceDeleteCustomField_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceDeleteCustomField'
-type MockStore_DeviceDeleteCustomField_Call struct {
+// MockStore_ActiveSessionDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveSessionDelete'
+type MockStore_ActiveSessionDelete_Call struct {
 	*mock.Call
 }
 
-// DeviceDeleteCustomField is a helper method to define mock.On call
+// ActiveSessionDelete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - key string
-func (_e *MockStore_Expecter) DeviceDeleteCustomField(ctx any, uid any, key any) *MockStore_DeviceDeleteCustomField_Call {
-	return &MockStore_DeviceDeleteCustomField_Call{Call: _e.mock.On("DeviceDeleteCustomField", ctx, uid, key)}
+//   - uid models.UID
+func (_e *MockStore_Expecter) ActiveSessionDelete(ctx any, uid any) *MockStore_ActiveSessionDelete_Call {
+	return &MockStore_ActiveSessionDelete_Call{Call: _e.mock.On("ActiveSessionDelete", ctx, uid)}
 }
 
-func (_c *MockStore_DeviceDeleteCustomField_Call) Run(run func(ctx context.Context, uid string, key string)) *MockStore_DeviceDeleteCustomField_Call {
+func (_c *MockStore_ActiveSessionDelete_Call) Run(run func(ctx context.Context, uid models.UID)) *MockStore_ActiveSessionDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 models.UID
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(models.UID)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_DeviceDeleteCustomField_Call) Return(err error) *MockStore_DeviceDeleteCustomField_Call {
+func (_c *MockStore_ActiveSessionDelete_Call) Return(err error) *MockStore_ActiveSessionDelete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_DeviceDeleteCustomField_Call) RunAndReturn(run func(ctx context.Context, uid string, key string) error) *MockStore_DeviceDeleteCustomField_Call {
+func (_c *MockStore_ActiveSessionDelete_Call) RunAndReturn(run func(ctx context.Context, uid models.UID) error) *MockStore_ActiveSessionDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceDeleteMany provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceDeleteMany(ctx context.Context, uids []string) (int64, error) {
-	ret := _mock.Called(ctx, uids)
+// ActiveSessionResolve provides a mock function for the type MockStore
+func (_mock *MockStore) ActiveSessionResolve(ctx context.Context, resolver store.SessionResolver, value string) (*models.ActiveSession, error) {
+	ret := _mock.Called(ctx, resolver, value)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceDeleteMany")
+		panic("no return value specified for ActiveSessionResolve")
 	}
 
-	var r0 int64
+	var r0 *models.ActiveSession
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
-		return returnFunc(ctx, uids)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.SessionResolver, string) (*models.ActiveSession, error)); ok {
+		return returnFunc(ctx, resolver, value)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
-		r0 = returnFunc(ctx, uids)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.SessionResolver, string) *models.ActiveSession); ok {
+		r0 = returnFunc(ctx, resolver, value)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ActiveSession)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = returnFunc(ctx, uids)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, store.SessionResolver, string) error); ok {
+		r1 = returnFunc(ctx, resolver, value)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_DeviceDeleteMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceDeleteMany'
-type MockStore_DeviceDeleteMany_Call struct {
+// MockStore_ActiveSessionResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveSessionResolve'
+type MockStore_ActiveSessionResolve_Call struct {
 	*mock.Call
 }
 
-// DeviceDeleteMany is a helper method to define mock.On call
+// ActiveSessionResolve is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uids []string
-func (_e *MockStore_Expecter) DeviceDeleteMany(ctx any, uids any) *MockStore_DeviceDeleteMany_Call {
-	return &MockStore_DeviceDeleteMany_Call{Call: _e.mock.On("DeviceDeleteMany", ctx, uids)}
+//   - resolver store.SessionResolver
+//   - value string
+func (_e *MockStore_Expecter) ActiveSessionResolve(ctx any, resolver any, value any) *MockStore_ActiveSessionResolve_Call {
+	return &MockStore_ActiveSessionResolve_Call{Call: _e.mock.On("ActiveSessionResolve", ctx, resolver, value)}
 }
 
-func (_c *MockStore_DeviceDeleteMany_Call) Run(run func(ctx context.Context, uids []string)) *MockStore_DeviceDeleteMany_Call {
+func (_c *MockStore_ActiveSessionResolve_Call) Run(run func(ctx context.Context, resolver store.SessionResolver, value string)) *MockStore_ActiveSessionResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 store.SessionResolver
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(store.SessionResolver)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_DeviceDeleteMany_Call) Return(deletedCount int64, err error) *MockStore_DeviceDeleteMany_Call {
-	_c.Call.Return(deletedCount, err)
+func (_c *MockStore_ActiveSessionResolve_Call) Return(activeSession *models.ActiveSession, err error) *MockStore_ActiveSessionResolve_Call {
+	_c.Call.Return(activeSession, err)
 	return _c
 }
 
-func (_c *MockStore_DeviceDeleteMany_Call) RunAndReturn(run func(ctx context.Context, uids []string) (int64, error)) *MockStore_DeviceDeleteMany_Call {
+func (_c *MockStore_ActiveSessionResolve_Call) RunAndReturn(run func(ctx context.Context, resolver store.SessionResolver, value string) (*models.ActiveSession, error)) *MockStore_ActiveSessionResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceHeartbeat provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceHeartbeat(ctx context.Context, uids []string, lastSeen time.Time) (int64, error) {
-	ret := _mock.Called(ctx, uids, lastSeen)
+// ActiveSessionUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) ActiveSessionUpdate(ctx context.Context, activeSession *models.ActiveSession) error {
+	ret := _mock.Called(ctx, activeSession)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceHeartbeat")
+		panic("no return value specified for ActiveSessionUpdate")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Time) (int64, error)); ok {
-		return returnFunc(ctx, uids, lastSeen)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Time) int64); ok {
-		r0 = returnFunc(ctx, uids, lastSeen)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, time.Time) error); ok {
-		r1 = returnFunc(ctx, uids, lastSeen)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.ActiveSession) error); ok {
+		r0 = returnFunc(ctx, activeSession)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_DeviceHeartbeat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceHeartbeat'
-type MockStore_DeviceHeartbeat_Call struct {
+// MockStore_ActiveSessionUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveSessionUpdate'
+type MockStore_ActiveSessionUpdate_Call struct {
 	*mock.Call
 }
 
-// DeviceHeartbeat is a helper method to define mock.On call
+// ActiveSessionUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uids []string
-//   - lastSeen time.Time
-func (_e *MockStore_Expecter) DeviceHeartbeat(ctx any, uids any, lastSeen any) *MockStore_DeviceHeartbeat_Call {
-	return &MockStore_DeviceHeartbeat_Call{Call: _e.mock.On("DeviceHeartbeat", ctx, uids, lastSeen)}
+//   - activeSession *models.ActiveSession
+func (_e *MockStore_Expecter) ActiveSessionUpdate(ctx any, activeSession any) *MockStore_ActiveSessionUpdate_Call {
+	return &MockStore_ActiveSessionUpdate_Call{Call: _e.mock.On("ActiveSessionUpdate", ctx, activeSession)}
 }
 
-func (_c *MockStore_DeviceHeartbeat_Call) Run(run func(ctx context.Context, uids []string, lastSeen time.Time)) *MockStore_DeviceHeartbeat_Call {
+func (_c *MockStore_ActiveSessionUpdate_Call) Run(run func(ctx context.Context, activeSession *models.ActiveSession)) *MockStore_ActiveSessionUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 *models.ActiveSession
 		if args[1] != nil {
-			arg1 = args[1].([]string)
-		}
-		var arg2 time.Time
-		if args[2] != nil {
-			arg2 = args[2].(time.Time)
+			arg1 = args[1].(*models.ActiveSession)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_DeviceHeartbeat_Call) Return(modifiedCount int64, err error) *MockStore_DeviceHeartbeat_Call {
-	_c.Call.Return(modifiedCount, err)
+func (_c *MockStore_ActiveSessionUpdate_Call) Return(err error) *MockStore_ActiveSessionUpdate_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_DeviceHeartbeat_Call) RunAndReturn(run func(ctx context.Context, uids []string, lastSeen time.Time) (int64, error)) *MockStore_DeviceHeartbeat_Call {
+func (_c *MockStore_ActiveSessionUpdate_Call) RunAndReturn(run func(ctx context.Context, activeSession *models.ActiveSession) error) *MockStore_ActiveSessionUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceList provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceList(ctx context.Context, sc scope.Scope, acceptable store.DeviceAcceptable, opts ...store.QueryOption) ([]models.Device, int, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, acceptable, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, sc, acceptable)
-	}
-	ret := tmpRet
+// AuditEventCreate provides a mock function for the type MockStore
+func (_mock *MockStore) AuditEventCreate(ctx context.Context, event *models.AuditEvent) error {
+	ret := _mock.Called(ctx, event)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceList")
+		panic("no return value specified for AuditEventCreate")
 	}
 
-	var r0 []models.Device
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.DeviceAcceptable, ...store.QueryOption) ([]models.Device, int, error)); ok {
-		return returnFunc(ctx, sc, acceptable, opts...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.DeviceAcceptable, ...store.QueryOption) []models.Device); ok {
-		r0 = returnFunc(ctx, sc, acceptable, opts...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Device)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.DeviceAcceptable, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, sc, acceptable, opts...)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, store.DeviceAcceptable, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, sc, acceptable, opts...)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.AuditEvent) error); ok {
+		r0 = returnFunc(ctx, event)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
-	return r0, r1, r2
+	return r0
 }
 
-// MockStore_DeviceList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceList'
-type MockStore_DeviceList_Call struct {
+// MockStore_AuditEventCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditEventCreate'
+type MockStore_AuditEventCreate_Call struct {
 	*mock.Call
 }
 
-// DeviceList is a helper method to define mock.On call
+// AuditEventCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - acceptable store.DeviceAcceptable
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) DeviceList(ctx any, sc any, acceptable any, opts ...any) *MockStore_DeviceList_Call {
-	return &MockStore_DeviceList_Call{Call: _e.mock.On("DeviceList",
-		append([]any{ctx, sc, acceptable}, opts...)...)}
+//   - event *models.AuditEvent
+func (_e *MockStore_Expecter) AuditEventCreate(ctx any, event any) *MockStore_AuditEventCreate_Call {
+	return &MockStore_AuditEventCreate_Call{Call: _e.mock.On("AuditEventCreate", ctx, event)}
 }
 
-func (_c *MockStore_DeviceList_Call) Run(run func(ctx context.Context, sc scope.Scope, acceptable store.DeviceAcceptable, opts ...store.QueryOption)) *MockStore_DeviceList_Call {
+func (_c *MockStore_AuditEventCreate_Call) Run(run func(ctx context.Context, event *models.AuditEvent)) *MockStore_AuditEventCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 *models.AuditEvent
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
+			arg1 = args[1].(*models.AuditEvent)
 		}
-		var arg2 store.DeviceAcceptable
-		if args[2] != nil {
-			arg2 = args[2].(store.DeviceAcceptable)
-		}
-		var arg3 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 3 {
-			variadicArgs = args[3].([]store.QueryOption)
-		}
-		arg3 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_DeviceList_Call) Return(devices []models.Device, n int, err error) *MockStore_DeviceList_Call {
-	_c.Call.Return(devices, n, err)
+func (_c *MockStore_AuditEventCreate_Call) Return(err error) *MockStore_AuditEventCreate_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_DeviceList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, acceptable store.DeviceAcceptable, opts ...store.QueryOption) ([]models.Device, int, error)) *MockStore_DeviceList_Call {
+func (_c *MockStore_AuditEventCreate_Call) RunAndReturn(run func(ctx context.Context, event *models.AuditEvent) error) *MockStore_AuditEventCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceListExpiredEphemeral provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceListExpiredEphemeral(ctx context.Context) ([]models.Device, error) {
-	ret := _mock.Called(ctx)
+// AuditEventDeleteBefore provides a mock function for the type MockStore
+func (_mock *MockStore) AuditEventDeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceListExpiredEphemeral")
+		panic("no return value specified for AuditEventDeleteBefore")
 	}
 
-	var r0 []models.Device
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Device, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Device); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Device)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_DeviceListExpiredEphemeral_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceListExpiredEphemeral'
-type MockStore_DeviceListExpiredEphemeral_Call struct {
+// MockStore_AuditEventDeleteBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditEventDeleteBefore'
+type MockStore_AuditEventDeleteBefore_Call struct {
 	*mock.Call
 }
 
-// DeviceListExpiredEphemeral is a helper method to define mock.On call
+// AuditEventDeleteBefore is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) DeviceListExpiredEphemeral(ctx any) *MockStore_DeviceListExpiredEphemeral_Call {
-	return &MockStore_DeviceListExpiredEphemeral_Call{Call: _e.mock.On("DeviceListExpiredEphemeral", ctx)}
+//   - before time.Time
+func (_e *MockStore_Expecter) AuditEventDeleteBefore(ctx any, before any) *MockStore_AuditEventDeleteBefore_Call {
+	return &MockStore_AuditEventDeleteBefore_Call{Call: _e.mock.On("AuditEventDeleteBefore", ctx, before)}
 }
 
-func (_c *MockStore_DeviceListExpiredEphemeral_Call) Run(run func(ctx context.Context)) *MockStore_DeviceListExpiredEphemeral_Call {
+func (_c *MockStore_AuditEventDeleteBefore_Call) Run(run func(ctx context.Context, before time.Time)) *MockStore_AuditEventDeleteBefore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_DeviceListExpiredEphemeral_Call) Return(devices []models.Device, err error) *MockStore_DeviceListExpiredEphemeral_Call {
-	_c.Call.Return(devices, err)
+func (_c *MockStore_AuditEventDeleteBefore_Call) Return(n int64, err error) *MockStore_AuditEventDeleteBefore_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockStore_DeviceListExpiredEphemeral_Call) RunAndReturn(run func(ctx context.Context) ([]models.Device, error)) *MockStore_DeviceListExpiredEphemeral_Call {
+func (_c *MockStore_AuditEventDeleteBefore_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockStore_AuditEventDeleteBefore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceOffline provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceOffline(ctx context.Context, uid string, disconnectedAt time.Time) error {
-	ret := _mock.Called(ctx, uid, disconnectedAt)
+// AuditEventList provides a mock function for the type MockStore
+func (_mock *MockStore) AuditEventList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.AuditEvent, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceOffline")
+		panic("no return value specified for AuditEventList")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
-		r0 = returnFunc(ctx, uid, disconnectedAt)
+	var r0 []models.AuditEvent
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.AuditEvent, int, error)); ok {
+		return returnFunc(ctx, sc, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.AuditEvent); ok {
+		r0 = returnFunc(ctx, sc, opts...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditEvent)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, opts...)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_DeviceOffline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceOffline'
-type MockStore_DeviceOffline_Call struct {
+// MockStore_AuditEventList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditEventList'
+type MockStore_AuditEventList_Call struct {
 	*mock.Call
 }
 
-// DeviceOffline is a helper method to define mock.On call
+// AuditEventList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - disconnectedAt time.Time
-func (_e *MockStore_Expecter) DeviceOffline(ctx any, uid any, disconnectedAt any) *MockStore_DeviceOffline_Call {
-	return &MockStore_DeviceOffline_Call{Call: _e.mock.On("DeviceOffline", ctx, uid, disconnectedAt)}
+//   - sc scope.Scope
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) AuditEventList(ctx any, sc any, opts ...any) *MockStore_AuditEventList_Call {
+	return &MockStore_AuditEventList_Call{Call: _e.mock.On("AuditEventList",
+		append([]any{ctx, sc}, opts...)...)}
 }
 
-func (_c *MockStore_DeviceOffline_Call) Run(run func(ctx context.Context, uid string, disconnectedAt time.Time)) *MockStore_DeviceOffline_Call {
+func (_c *MockStore_AuditEventList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_AuditEventList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 time.Time
-		if args[2] != nil {
-			arg2 = args[2].(time.Time)
+		var arg2 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
 		}
+		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2,
+			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_DeviceOffline_Call) Return(err error) *MockStore_DeviceOffline_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_AuditEventList_Call) Return(auditEvents []models.AuditEvent, n int, err error) *MockStore_AuditEventList_Call {
+	_c.Call.Return(auditEvents, n, err)
 	return _c
 }
 
-func (_c *MockStore_DeviceOffline_Call) RunAndReturn(run func(ctx context.Context, uid string, disconnectedAt time.Time) error) *MockStore_DeviceOffline_Call {
+func (_c *MockStore_AuditEventList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.AuditEvent, int, error)) *MockStore_AuditEventList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceResolve provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceResolve(ctx context.Context, sc scope.Scope, resolver store.DeviceResolver, value string, opts ...store.QueryOption) (*models.Device, error) {
+// DeviceConflicts provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceConflicts(ctx context.Context, sc scope.Scope, target *models.DeviceConflicts, opts ...store.QueryOption) ([]string, bool, error) {
 	var tmpRet mock.Arguments
 	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
+		tmpRet = _mock.Called(ctx, sc, target, opts)
 	} else {
-		tmpRet = _mock.Called(ctx, sc, resolver, value)
+		tmpRet = _mock.Called(ctx, sc, target)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceResolve")
+		panic("no return value specified for DeviceConflicts")
 	}
 
-	var r0 *models.Device
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.DeviceResolver, string, ...store.QueryOption) (*models.Device, error)); ok {
-		return returnFunc(ctx, sc, resolver, value, opts...)
+	var r0 []string
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.DeviceConflicts, ...store.QueryOption) ([]string, bool, error)); ok {
+		return returnFunc(ctx, sc, target, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.DeviceResolver, string, ...store.QueryOption) *models.Device); ok {
-		r0 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.DeviceConflicts, ...store.QueryOption) []string); ok {
+		r0 = returnFunc(ctx, sc, target, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).([]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.DeviceResolver, string, ...store.QueryOption) error); ok {
-		r1 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *models.DeviceConflicts, ...store.QueryOption) bool); ok {
+		r1 = returnFunc(ctx, sc, target, opts...)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(bool)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *models.DeviceConflicts, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, target, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_DeviceResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceResolve'
-type MockStore_DeviceResolve_Call struct {
+// MockStore_DeviceConflicts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceConflicts'
+type MockStore_DeviceConflicts_Call struct {
 	*mock.Call
 }
 
-// DeviceResolve is a helper method to define mock.On call
+// DeviceConflicts is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - resolver store.DeviceResolver
-//   - value string
+//   - target *models.DeviceConflicts
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) DeviceResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_DeviceResolve_Call {
-	return &MockStore_DeviceResolve_Call{Call: _e.mock.On("DeviceResolve",
-		append([]any{ctx, sc, resolver, value}, opts...)...)}
+func (_e *MockStore_Expecter) DeviceConflicts(ctx any, sc any, target any, opts ...any) *MockStore_DeviceConflicts_Call {
+	return &MockStore_DeviceConflicts_Call{Call: _e.mock.On("DeviceConflicts",
+		append([]any{ctx, sc, target}, opts...)...)}
 }
 
-func (_c *MockStore_DeviceResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.DeviceResolver, value string, opts ...store.QueryOption)) *MockStore_DeviceResolve_Call {
+func (_c *MockStore_DeviceConflicts_Call) Run(run func(ctx context.Context, sc scope.Scope, target *models.DeviceConflicts, opts ...store.QueryOption)) *MockStore_DeviceConflicts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1856,116 +1932,108 @@ func (_c *MockStore_DeviceResolve_Call) Run(run func(ctx context.Context, sc sco
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 store.DeviceResolver
+		var arg2 *models.DeviceConflicts
 		if args[2] != nil {
-			arg2 = args[2].(store.DeviceResolver)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].(*models.DeviceConflicts)
 		}
-		var arg4 []store.QueryOption
+		var arg3 []store.QueryOption
 		var variadicArgs []store.QueryOption
-		if len(args) > 4 {
-			variadicArgs = args[4].([]store.QueryOption)
+		if len(args) > 3 {
+			variadicArgs = args[3].([]store.QueryOption)
 		}
-		arg4 = variadicArgs
+		arg3 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4...,
+			arg3...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_DeviceResolve_Call) Return(device *models.Device, err error) *MockStore_DeviceResolve_Call {
-	_c.Call.Return(device, err)
+func (_c *MockStore_DeviceConflicts_Call) Return(conflicts []string, has bool, err error) *MockStore_DeviceConflicts_Call {
+	_c.Call.Return(conflicts, has, err)
 	return _c
 }
 
-func (_c *MockStore_DeviceResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.DeviceResolver, value string, opts ...store.QueryOption) (*models.Device, error)) *MockStore_DeviceResolve_Call {
+func (_c *MockStore_DeviceConflicts_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, target *models.DeviceConflicts, opts ...store.QueryOption) ([]string, bool, error)) *MockStore_DeviceConflicts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceSetCustomField provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceSetCustomField(ctx context.Context, uid string, key string, value string) error {
-	ret := _mock.Called(ctx, uid, key, value)
+// DeviceCreate provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceCreate(ctx context.Context, device *models.Device) (string, error) {
+	ret := _mock.Called(ctx, device)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceSetCustomField")
+		panic("no return value specified for DeviceCreate")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, key, value)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Device) (string, error)); ok {
+		return returnFunc(ctx, device)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Device) string); ok {
+		r0 = returnFunc(ctx, device)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.Device) error); ok {
+		r1 = returnFunc(ctx, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_DeviceSetCustomField_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceSetCustomField'
-type MockStore_DeviceSetCustomField_Call struct {
+// MockStore_DeviceCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceCreate'
+type MockStore_DeviceCreate_Call struct {
 	*mock.Call
 }
 
-// DeviceSetCustomField is a helper method to define mock.On call
+// DeviceCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - key string
-//   - value string
-func (_e *MockStore_Expecter) DeviceSetCustomField(ctx any, uid any, key any, value any) *MockStore_DeviceSetCustomField_Call {
-	return &MockStore_DeviceSetCustomField_Call{Call: _e.mock.On("DeviceSetCustomField", ctx, uid, key, value)}
+//   - device *models.Device
+func (_e *MockStore_Expecter) DeviceCreate(ctx any, device any) *MockStore_DeviceCreate_Call {
+	return &MockStore_DeviceCreate_Call{Call: _e.mock.On("DeviceCreate", ctx, device)}
 }
 
-func (_c *MockStore_DeviceSetCustomField_Call) Run(run func(ctx context.Context, uid string, key string, value string)) *MockStore_DeviceSetCustomField_Call {
+func (_c *MockStore_DeviceCreate_Call) Run(run func(ctx context.Context, device *models.Device)) *MockStore_DeviceCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *models.Device
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg1 = args[1].(*models.Device)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_DeviceSetCustomField_Call) Return(err error) *MockStore_DeviceSetCustomField_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_DeviceCreate_Call) Return(insertedUID string, err error) *MockStore_DeviceCreate_Call {
+	_c.Call.Return(insertedUID, err)
 	return _c
 }
 
-func (_c *MockStore_DeviceSetCustomField_Call) RunAndReturn(run func(ctx context.Context, uid string, key string, value string) error) *MockStore_DeviceSetCustomField_Call {
+func (_c *MockStore_DeviceCreate_Call) RunAndReturn(run func(ctx context.Context, device *models.Device) (string, error)) *MockStore_DeviceCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeviceUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) DeviceUpdate(ctx context.Context, device *models.Device) error {
+// DeviceDelete provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceDelete(ctx context.Context, device *models.Device) error {
 	ret := _mock.Called(ctx, device)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceUpdate")
+		panic("no return value specified for DeviceDelete")
 	}
 
 	var r0 error
@@ -1977,19 +2045,19 @@ func (_mock *MockStore) DeviceUpdate(ctx context.Context, device *models.Device)
 	return r0
 }
 
-// MockStore_DeviceUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceUpdate'
-type MockStore_DeviceUpdate_Call struct {
+// MockStore_DeviceDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceDelete'
+type MockStore_DeviceDelete_Call struct {
 	*mock.Call
 }
 
-// DeviceUpdate is a helper method to define mock.On call
+// DeviceDelete is a helper method to define mock.On call
 //   - ctx context.Context
 //   - device *models.Device
-func (_e *MockStore_Expecter) DeviceUpdate(ctx any, device any) *MockStore_DeviceUpdate_Call {
-	return &MockStore_DeviceUpdate_Call{Call: _e.mock.On("DeviceUpdate", ctx, device)}
+func (_e *MockStore_Expecter) DeviceDelete(ctx any, device any) *MockStore_DeviceDelete_Call {
+	return &MockStore_DeviceDelete_Call{Call: _e.mock.On("DeviceDelete", ctx, device)}
 }
 
-func (_c *MockStore_DeviceUpdate_Call) Run(run func(ctx context.Context, device *models.Device)) *MockStore_DeviceUpdate_Call {
+func (_c *MockStore_DeviceDelete_Call) Run(run func(ctx context.Context, device *models.Device)) *MockStore_DeviceDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2007,270 +2075,273 @@ func (_c *MockStore_DeviceUpdate_Call) Run(run func(ctx context.Context, device
 	return _c
 }
 
-func (_c *MockStore_DeviceUpdate_Call) Return(err error) *MockStore_DeviceUpdate_Call {
+func (_c *MockStore_DeviceDelete_Call) Return(err error) *MockStore_DeviceDelete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_DeviceUpdate_Call) RunAndReturn(run func(ctx context.Context, device *models.Device) error) *MockStore_DeviceUpdate_Call {
+func (_c *MockStore_DeviceDelete_Call) RunAndReturn(run func(ctx context.Context, device *models.Device) error) *MockStore_DeviceDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EnrollmentCallbackCleanup provides a mock function for the type MockStore
-func (_mock *MockStore) EnrollmentCallbackCleanup(ctx context.Context, before time.Time) (int64, error) {
-	ret := _mock.Called(ctx, before)
+// DeviceDeleteCustomField provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceDeleteCustomField(ctx context.Context, uid string, key string) error {
+	ret := _mock.Called(ctx, uid, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnrollmentCallbackCleanup")
+		panic("no return value specified for DeviceDeleteCustomField")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
-		return returnFunc(ctx, before)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
-		r0 = returnFunc(ctx, before)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
-		r1 = returnFunc(ctx, before)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, key)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_EnrollmentCallbackCleanup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollmentCallbackCleanup'
-type MockStore_EnrollmentCallbackCleanup_Call struct {
+// MockStore_DeviceDeleteCustomField_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceDeleteCustomField'
+type MockStore_DeviceDeleteCustomField_Call struct {
 	*mock.Call
 }
 
-// EnrollmentCallbackCleanup is a helper method to define mock.On call
+// DeviceDeleteCustomField is a helper method to define mock.On call
 //   - ctx context.Context
-//   - before time.Time
-func (_e *MockStore_Expecter) EnrollmentCallbackCleanup(ctx any, before any) *MockStore_EnrollmentCallbackCleanup_Call {
-	return &MockStore_EnrollmentCallbackCleanup_Call{Call: _e.mock.On("EnrollmentCallbackCleanup", ctx, before)}
+//   - uid string
+//   - key string
+func (_e *MockStore_Expecter) DeviceDeleteCustomField(ctx any, uid any, key any) *MockStore_DeviceDeleteCustomField_Call {
+	return &MockStore_DeviceDeleteCustomField_Call{Call: _e.mock.On("DeviceDeleteCustomField", ctx, uid, key)}
 }
 
-func (_c *MockStore_EnrollmentCallbackCleanup_Call) Run(run func(ctx context.Context, before time.Time)) *MockStore_EnrollmentCallbackCleanup_Call {
+func (_c *MockStore_DeviceDeleteCustomField_Call) Run(run func(ctx context.Context, uid string, key string)) *MockStore_DeviceDeleteCustomField_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 time.Time
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(time.Time)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_EnrollmentCallbackCleanup_Call) Return(deleted int64, err error) *MockStore_EnrollmentCallbackCleanup_Call {
-	_c.Call.Return(deleted, err)
+func (_c *MockStore_DeviceDeleteCustomField_Call) Return(err error) *MockStore_DeviceDeleteCustomField_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_EnrollmentCallbackCleanup_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockStore_EnrollmentCallbackCleanup_Call {
+func (_c *MockStore_DeviceDeleteCustomField_Call) RunAndReturn(run func(ctx context.Context, uid string, key string) error) *MockStore_DeviceDeleteCustomField_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EnrollmentCallbackRedeem provides a mock function for the type MockStore
-func (_mock *MockStore) EnrollmentCallbackRedeem(ctx context.Context, jti string, at time.Time) (bool, error) {
-	ret := _mock.Called(ctx, jti, at)
+// DeviceDeleteMany provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceDeleteMany(ctx context.Context, uids []string) (int64, error) {
+	ret := _mock.Called(ctx, uids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnrollmentCallbackRedeem")
+		panic("no return value specified for DeviceDeleteMany")
 	}
 
-	var r0 bool
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
-		return returnFunc(ctx, jti, at)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
+		return returnFunc(ctx, uids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
-		r0 = returnFunc(ctx, jti, at)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
+		r0 = returnFunc(ctx, uids)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
-		r1 = returnFunc(ctx, jti, at)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, uids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_EnrollmentCallbackRedeem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollmentCallbackRedeem'
-type MockStore_EnrollmentCallbackRedeem_Call struct {
+// MockStore_DeviceDeleteMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceDeleteMany'
+type MockStore_DeviceDeleteMany_Call struct {
 	*mock.Call
 }
 
-// EnrollmentCallbackRedeem is a helper method to define mock.On call
+// DeviceDeleteMany is a helper method to define mock.On call
 //   - ctx context.Context
-//   - jti string
-//   - at time.Time
-func (_e *MockStore_Expecter) EnrollmentCallbackRedeem(ctx any, jti any, at any) *MockStore_EnrollmentCallbackRedeem_Call {
-	return &MockStore_EnrollmentCallbackRedeem_Call{Call: _e.mock.On("EnrollmentCallbackRedeem", ctx, jti, at)}
+//   - uids []string
+func (_e *MockStore_Expecter) DeviceDeleteMany(ctx any, uids any) *MockStore_DeviceDeleteMany_Call {
+	return &MockStore_DeviceDeleteMany_Call{Call: _e.mock.On("DeviceDeleteMany", ctx, uids)}
 }
 
-func (_c *MockStore_EnrollmentCallbackRedeem_Call) Run(run func(ctx context.Context, jti string, at time.Time)) *MockStore_EnrollmentCallbackRedeem_Call {
+func (_c *MockStore_DeviceDeleteMany_Call) Run(run func(ctx context.Context, uids []string)) *MockStore_DeviceDeleteMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 time.Time
-		if args[2] != nil {
-			arg2 = args[2].(time.Time)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_EnrollmentCallbackRedeem_Call) Return(redeemed bool, err error) *MockStore_EnrollmentCallbackRedeem_Call {
-	_c.Call.Return(redeemed, err)
+func (_c *MockStore_DeviceDeleteMany_Call) Return(deletedCount int64, err error) *MockStore_DeviceDeleteMany_Call {
+	_c.Call.Return(deletedCount, err)
 	return _c
 }
 
-func (_c *MockStore_EnrollmentCallbackRedeem_Call) RunAndReturn(run func(ctx context.Context, jti string, at time.Time) (bool, error)) *MockStore_EnrollmentCallbackRedeem_Call {
+func (_c *MockStore_DeviceDeleteMany_Call) RunAndReturn(run func(ctx context.Context, uids []string) (int64, error)) *MockStore_DeviceDeleteMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStats provides a mock function for the type MockStore
-func (_mock *MockStore) GetStats(ctx context.Context, sc scope.Scope) (*models.Stats, error) {
-	ret := _mock.Called(ctx, sc)
+// DeviceHeartbeat provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceHeartbeat(ctx context.Context, uids []string, lastSeen time.Time) (int64, error) {
+	ret := _mock.Called(ctx, uids, lastSeen)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStats")
+		panic("no return value specified for DeviceHeartbeat")
 	}
 
-	var r0 *models.Stats
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) (*models.Stats, error)); ok {
-		return returnFunc(ctx, sc)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, uids, lastSeen)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) *models.Stats); ok {
-		r0 = returnFunc(ctx, sc)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Time) int64); ok {
+		r0 = returnFunc(ctx, uids, lastSeen)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Stats)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope) error); ok {
-		r1 = returnFunc(ctx, sc)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, time.Time) error); ok {
+		r1 = returnFunc(ctx, uids, lastSeen)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
-type MockStore_GetStats_Call struct {
+// MockStore_DeviceHeartbeat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceHeartbeat'
+type MockStore_DeviceHeartbeat_Call struct {
 	*mock.Call
 }
 
-// GetStats is a helper method to define mock.On call
+// DeviceHeartbeat is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-func (_e *MockStore_Expecter) GetStats(ctx any, sc any) *MockStore_GetStats_Call {
-	return &MockStore_GetStats_Call{Call: _e.mock.On("GetStats", ctx, sc)}
+//   - uids []string
+//   - lastSeen time.Time
+func (_e *MockStore_Expecter) DeviceHeartbeat(ctx any, uids any, lastSeen any) *MockStore_DeviceHeartbeat_Call {
+	return &MockStore_DeviceHeartbeat_Call{Call: _e.mock.On("DeviceHeartbeat", ctx, uids, lastSeen)}
 }
 
-func (_c *MockStore_GetStats_Call) Run(run func(ctx context.Context, sc scope.Scope)) *MockStore_GetStats_Call {
+func (_c *MockStore_DeviceHeartbeat_Call) Run(run func(ctx context.Context, uids []string, lastSeen time.Time)) *MockStore_DeviceHeartbeat_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
+			arg1 = args[1].([]string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_GetStats_Call) Return(stats *models.Stats, err error) *MockStore_GetStats_Call {
-	_c.Call.Return(stats, err)
+func (_c *MockStore_DeviceHeartbeat_Call) Return(modifiedCount int64, err error) *MockStore_DeviceHeartbeat_Call {
+	_c.Call.Return(modifiedCount, err)
 	return _c
 }
 
-func (_c *MockStore_GetStats_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope) (*models.Stats, error)) *MockStore_GetStats_Call {
+func (_c *MockStore_DeviceHeartbeat_Call) RunAndReturn(run func(ctx context.Context, uids []string, lastSeen time.Time) (int64, error)) *MockStore_DeviceHeartbeat_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyConflicts provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyConflicts(ctx context.Context, sc scope.Scope, target *models.InstallKeyConflicts) ([]string, bool, error) {
-	ret := _mock.Called(ctx, sc, target)
+// DeviceList provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceList(ctx context.Context, sc scope.Scope, acceptable store.DeviceAcceptable, opts ...store.QueryOption) ([]models.Device, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, acceptable, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, acceptable)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyConflicts")
+		panic("no return value specified for DeviceList")
 	}
 
-	var r0 []string
-	var r1 bool
+	var r0 []models.Device
+	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.InstallKeyConflicts) ([]string, bool, error)); ok {
-		return returnFunc(ctx, sc, target)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.DeviceAcceptable, ...store.QueryOption) ([]models.Device, int, error)); ok {
+		return returnFunc(ctx, sc, acceptable, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.InstallKeyConflicts) []string); ok {
-		r0 = returnFunc(ctx, sc, target)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.DeviceAcceptable, ...store.QueryOption) []models.Device); ok {
+		r0 = returnFunc(ctx, sc, acceptable, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]models.Device)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *models.InstallKeyConflicts) bool); ok {
-		r1 = returnFunc(ctx, sc, target)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.DeviceAcceptable, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, acceptable, opts...)
 	} else {
-		r1 = ret.Get(1).(bool)
+		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *models.InstallKeyConflicts) error); ok {
-		r2 = returnFunc(ctx, sc, target)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, store.DeviceAcceptable, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, acceptable, opts...)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockStore_InstallKeyConflicts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyConflicts'
-type MockStore_InstallKeyConflicts_Call struct {
+// MockStore_DeviceList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceList'
+type MockStore_DeviceList_Call struct {
 	*mock.Call
 }
 
-// InstallKeyConflicts is a helper method to define mock.On call
+// DeviceList is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - target *models.InstallKeyConflicts
-func (_e *MockStore_Expecter) InstallKeyConflicts(ctx any, sc any, target any) *MockStore_InstallKeyConflicts_Call {
-	return &MockStore_InstallKeyConflicts_Call{Call: _e.mock.On("InstallKeyConflicts", ctx, sc, target)}
+//   - acceptable store.DeviceAcceptable
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) DeviceList(ctx any, sc any, acceptable any, opts ...any) *MockStore_DeviceList_Call {
+	return &MockStore_DeviceList_Call{Call: _e.mock.On("DeviceList",
+		append([]any{ctx, sc, acceptable}, opts...)...)}
 }
 
-func (_c *MockStore_InstallKeyConflicts_Call) Run(run func(ctx context.Context, sc scope.Scope, target *models.InstallKeyConflicts)) *MockStore_InstallKeyConflicts_Call {
+func (_c *MockStore_DeviceList_Call) Run(run func(ctx context.Context, sc scope.Scope, acceptable store.DeviceAcceptable, opts ...store.QueryOption)) *MockStore_DeviceList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2280,76 +2351,85 @@ func (_c *MockStore_InstallKeyConflicts_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 *models.InstallKeyConflicts
+		var arg2 store.DeviceAcceptable
 		if args[2] != nil {
-			arg2 = args[2].(*models.InstallKeyConflicts)
+			arg2 = args[2].(store.DeviceAcceptable)
+		}
+		var arg3 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 3 {
+			variadicArgs = args[3].([]store.QueryOption)
 		}
+		arg3 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_InstallKeyConflicts_Call) Return(conflicts []string, has bool, err error) *MockStore_InstallKeyConflicts_Call {
-	_c.Call.Return(conflicts, has, err)
+func (_c *MockStore_DeviceList_Call) Return(devices []models.Device, n int, err error) *MockStore_DeviceList_Call {
+	_c.Call.Return(devices, n, err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyConflicts_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, target *models.InstallKeyConflicts) ([]string, bool, error)) *MockStore_InstallKeyConflicts_Call {
+func (_c *MockStore_DeviceList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, acceptable store.DeviceAcceptable, opts ...store.QueryOption) ([]models.Device, int, error)) *MockStore_DeviceList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyCreate provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyCreate(ctx context.Context, installKey *models.InstallKey) (string, error) {
-	ret := _mock.Called(ctx, installKey)
+// DeviceListDisconnected provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceListDisconnected(ctx context.Context, uids []string) ([]models.Device, error) {
+	ret := _mock.Called(ctx, uids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyCreate")
+		panic("no return value specified for DeviceListDisconnected")
 	}
 
-	var r0 string
+	var r0 []models.Device
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) (string, error)); ok {
-		return returnFunc(ctx, installKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]models.Device, error)); ok {
+		return returnFunc(ctx, uids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) string); ok {
-		r0 = returnFunc(ctx, installKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []models.Device); ok {
+		r0 = returnFunc(ctx, uids)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.InstallKey) error); ok {
-		r1 = returnFunc(ctx, installKey)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, uids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_InstallKeyCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyCreate'
-type MockStore_InstallKeyCreate_Call struct {
+// MockStore_DeviceListDisconnected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceListDisconnected'
+type MockStore_DeviceListDisconnected_Call struct {
 	*mock.Call
 }
 
-// InstallKeyCreate is a helper method to define mock.On call
+// DeviceListDisconnected is a helper method to define mock.On call
 //   - ctx context.Context
-//   - installKey *models.InstallKey
-func (_e *MockStore_Expecter) InstallKeyCreate(ctx any, installKey any) *MockStore_InstallKeyCreate_Call {
-	return &MockStore_InstallKeyCreate_Call{Call: _e.mock.On("InstallKeyCreate", ctx, installKey)}
+//   - uids []string
+func (_e *MockStore_Expecter) DeviceListDisconnected(ctx any, uids any) *MockStore_DeviceListDisconnected_Call {
+	return &MockStore_DeviceListDisconnected_Call{Call: _e.mock.On("DeviceListDisconnected", ctx, uids)}
 }
 
-func (_c *MockStore_InstallKeyCreate_Call) Run(run func(ctx context.Context, installKey *models.InstallKey)) *MockStore_InstallKeyCreate_Call {
+func (_c *MockStore_DeviceListDisconnected_Call) Run(run func(ctx context.Context, uids []string)) *MockStore_DeviceListDisconnected_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.InstallKey
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(*models.InstallKey)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -2359,186 +2439,192 @@ func (_c *MockStore_InstallKeyCreate_Call) Run(run func(ctx context.Context, ins
 	return _c
 }
 
-func (_c *MockStore_InstallKeyCreate_Call) Return(insertedID string, err error) *MockStore_InstallKeyCreate_Call {
-	_c.Call.Return(insertedID, err)
+func (_c *MockStore_DeviceListDisconnected_Call) Return(devices []models.Device, err error) *MockStore_DeviceListDisconnected_Call {
+	_c.Call.Return(devices, err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyCreate_Call) RunAndReturn(run func(ctx context.Context, installKey *models.InstallKey) (string, error)) *MockStore_InstallKeyCreate_Call {
+func (_c *MockStore_DeviceListDisconnected_Call) RunAndReturn(run func(ctx context.Context, uids []string) ([]models.Device, error)) *MockStore_DeviceListDisconnected_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyDecrementUsage provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyDecrementUsage(ctx context.Context, installKey *models.InstallKey) error {
-	ret := _mock.Called(ctx, installKey)
+// DeviceListExpiredEphemeral provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceListExpiredEphemeral(ctx context.Context) ([]models.Device, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyDecrementUsage")
+		panic("no return value specified for DeviceListExpiredEphemeral")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) error); ok {
-		r0 = returnFunc(ctx, installKey)
+	var r0 []models.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Device, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Device); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_InstallKeyDecrementUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyDecrementUsage'
-type MockStore_InstallKeyDecrementUsage_Call struct {
+// MockStore_DeviceListExpiredEphemeral_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceListExpiredEphemeral'
+type MockStore_DeviceListExpiredEphemeral_Call struct {
 	*mock.Call
 }
 
-// InstallKeyDecrementUsage is a helper method to define mock.On call
+// DeviceListExpiredEphemeral is a helper method to define mock.On call
 //   - ctx context.Context
-//   - installKey *models.InstallKey
-func (_e *MockStore_Expecter) InstallKeyDecrementUsage(ctx any, installKey any) *MockStore_InstallKeyDecrementUsage_Call {
-	return &MockStore_InstallKeyDecrementUsage_Call{Call: _e.mock.On("InstallKeyDecrementUsage", ctx, installKey)}
+func (_e *MockStore_Expecter) DeviceListExpiredEphemeral(ctx any) *MockStore_DeviceListExpiredEphemeral_Call {
+	return &MockStore_DeviceListExpiredEphemeral_Call{Call: _e.mock.On("DeviceListExpiredEphemeral", ctx)}
 }
 
-func (_c *MockStore_InstallKeyDecrementUsage_Call) Run(run func(ctx context.Context, installKey *models.InstallKey)) *MockStore_InstallKeyDecrementUsage_Call {
+func (_c *MockStore_DeviceListExpiredEphemeral_Call) Run(run func(ctx context.Context)) *MockStore_DeviceListExpiredEphemeral_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.InstallKey
-		if args[1] != nil {
-			arg1 = args[1].(*models.InstallKey)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_InstallKeyDecrementUsage_Call) Return(err error) *MockStore_InstallKeyDecrementUsage_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_DeviceListExpiredEphemeral_Call) Return(devices []models.Device, err error) *MockStore_DeviceListExpiredEphemeral_Call {
+	_c.Call.Return(devices, err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyDecrementUsage_Call) RunAndReturn(run func(ctx context.Context, installKey *models.InstallKey) error) *MockStore_InstallKeyDecrementUsage_Call {
+func (_c *MockStore_DeviceListExpiredEphemeral_Call) RunAndReturn(run func(ctx context.Context) ([]models.Device, error)) *MockStore_DeviceListExpiredEphemeral_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyEventCreate provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyEventCreate(ctx context.Context, event *models.InstallKeyEvent) error {
-	ret := _mock.Called(ctx, event)
+// DeviceOffline provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceOffline(ctx context.Context, uid string, disconnectedAt time.Time) error {
+	ret := _mock.Called(ctx, uid, disconnectedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyEventCreate")
+		panic("no return value specified for DeviceOffline")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKeyEvent) error); ok {
-		r0 = returnFunc(ctx, event)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, uid, disconnectedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_InstallKeyEventCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyEventCreate'
-type MockStore_InstallKeyEventCreate_Call struct {
+// MockStore_DeviceOffline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceOffline'
+type MockStore_DeviceOffline_Call struct {
 	*mock.Call
 }
 
-// InstallKeyEventCreate is a helper method to define mock.On call
+// DeviceOffline is a helper method to define mock.On call
 //   - ctx context.Context
-//   - event *models.InstallKeyEvent
-func (_e *MockStore_Expecter) InstallKeyEventCreate(ctx any, event any) *MockStore_InstallKeyEventCreate_Call {
-	return &MockStore_InstallKeyEventCreate_Call{Call: _e.mock.On("InstallKeyEventCreate", ctx, event)}
+//   - uid string
+//   - disconnectedAt time.Time
+func (_e *MockStore_Expecter) DeviceOffline(ctx any, uid any, disconnectedAt any) *MockStore_DeviceOffline_Call {
+	return &MockStore_DeviceOffline_Call{Call: _e.mock.On("DeviceOffline", ctx, uid, disconnectedAt)}
 }
 
-func (_c *MockStore_InstallKeyEventCreate_Call) Run(run func(ctx context.Context, event *models.InstallKeyEvent)) *MockStore_InstallKeyEventCreate_Call {
+func (_c *MockStore_DeviceOffline_Call) Run(run func(ctx context.Context, uid string, disconnectedAt time.Time)) *MockStore_DeviceOffline_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.InstallKeyEvent
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*models.InstallKeyEvent)
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_InstallKeyEventCreate_Call) Return(err error) *MockStore_InstallKeyEventCreate_Call {
+func (_c *MockStore_DeviceOffline_Call) Return(err error) *MockStore_DeviceOffline_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyEventCreate_Call) RunAndReturn(run func(ctx context.Context, event *models.InstallKeyEvent) error) *MockStore_InstallKeyEventCreate_Call {
+func (_c *MockStore_DeviceOffline_Call) RunAndReturn(run func(ctx context.Context, uid string, disconnectedAt time.Time) error) *MockStore_DeviceOffline_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyEventList provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyEventList(ctx context.Context, sc scope.Scope, keyDigest string, opts ...store.QueryOption) ([]models.InstallKeyEvent, int, error) {
+// DeviceResolve provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceResolve(ctx context.Context, sc scope.Scope, resolver store.DeviceResolver, value string, opts ...store.QueryOption) (*models.Device, error) {
 	var tmpRet mock.Arguments
 	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, keyDigest, opts)
+		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
 	} else {
-		tmpRet = _mock.Called(ctx, sc, keyDigest)
+		tmpRet = _mock.Called(ctx, sc, resolver, value)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyEventList")
+		panic("no return value specified for DeviceResolve")
 	}
 
-	var r0 []models.InstallKeyEvent
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, ...store.QueryOption) ([]models.InstallKeyEvent, int, error)); ok {
-		return returnFunc(ctx, sc, keyDigest, opts...)
+	var r0 *models.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.DeviceResolver, string, ...store.QueryOption) (*models.Device, error)); ok {
+		return returnFunc(ctx, sc, resolver, value, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, ...store.QueryOption) []models.InstallKeyEvent); ok {
-		r0 = returnFunc(ctx, sc, keyDigest, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.DeviceResolver, string, ...store.QueryOption) *models.Device); ok {
+		r0 = returnFunc(ctx, sc, resolver, value, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.InstallKeyEvent)
+			r0 = ret.Get(0).(*models.Device)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, string, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, sc, keyDigest, opts...)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, string, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, sc, keyDigest, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.DeviceResolver, string, ...store.QueryOption) error); ok {
+		r1 = returnFunc(ctx, sc, resolver, value, opts...)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_InstallKeyEventList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyEventList'
-type MockStore_InstallKeyEventList_Call struct {
+// MockStore_DeviceResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceResolve'
+type MockStore_DeviceResolve_Call struct {
 	*mock.Call
 }
 
-// InstallKeyEventList is a helper method to define mock.On call
+// DeviceResolve is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - keyDigest string
+//   - resolver store.DeviceResolver
+//   - value string
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) InstallKeyEventList(ctx any, sc any, keyDigest any, opts ...any) *MockStore_InstallKeyEventList_Call {
-	return &MockStore_InstallKeyEventList_Call{Call: _e.mock.On("InstallKeyEventList",
-		append([]any{ctx, sc, keyDigest}, opts...)...)}
+func (_e *MockStore_Expecter) DeviceResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_DeviceResolve_Call {
+	return &MockStore_DeviceResolve_Call{Call: _e.mock.On("DeviceResolve",
+		append([]any{ctx, sc, resolver, value}, opts...)...)}
 }
 
-func (_c *MockStore_InstallKeyEventList_Call) Run(run func(ctx context.Context, sc scope.Scope, keyDigest string, opts ...store.QueryOption)) *MockStore_InstallKeyEventList_Call {
+func (_c *MockStore_DeviceResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.DeviceResolver, value string, opts ...store.QueryOption)) *MockStore_DeviceResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2548,149 +2634,148 @@ func (_c *MockStore_InstallKeyEventList_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 string
+		var arg2 store.DeviceResolver
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(store.DeviceResolver)
 		}
-		var arg3 []store.QueryOption
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []store.QueryOption
 		var variadicArgs []store.QueryOption
-		if len(args) > 3 {
-			variadicArgs = args[3].([]store.QueryOption)
+		if len(args) > 4 {
+			variadicArgs = args[4].([]store.QueryOption)
 		}
-		arg3 = variadicArgs
+		arg4 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3...,
+			arg3,
+			arg4...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_InstallKeyEventList_Call) Return(events []models.InstallKeyEvent, count int, err error) *MockStore_InstallKeyEventList_Call {
-	_c.Call.Return(events, count, err)
+func (_c *MockStore_DeviceResolve_Call) Return(device *models.Device, err error) *MockStore_DeviceResolve_Call {
+	_c.Call.Return(device, err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyEventList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, keyDigest string, opts ...store.QueryOption) ([]models.InstallKeyEvent, int, error)) *MockStore_InstallKeyEventList_Call {
+func (_c *MockStore_DeviceResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.DeviceResolver, value string, opts ...store.QueryOption) (*models.Device, error)) *MockStore_DeviceResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyEventStampDecision provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyEventStampDecision(ctx context.Context, sc scope.Scope, deviceUID string, status models.DeviceStatus, at time.Time) error {
-	ret := _mock.Called(ctx, sc, deviceUID, status, at)
+// DeviceSetCustomField provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceSetCustomField(ctx context.Context, uid string, key string, value string) error {
+	ret := _mock.Called(ctx, uid, key, value)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyEventStampDecision")
+		panic("no return value specified for DeviceSetCustomField")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, models.DeviceStatus, time.Time) error); ok {
-		r0 = returnFunc(ctx, sc, deviceUID, status, at)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, key, value)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_InstallKeyEventStampDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyEventStampDecision'
-type MockStore_InstallKeyEventStampDecision_Call struct {
+// MockStore_DeviceSetCustomField_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceSetCustomField'
+type MockStore_DeviceSetCustomField_Call struct {
 	*mock.Call
 }
 
-// InstallKeyEventStampDecision is a helper method to define mock.On call
+// DeviceSetCustomField is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - deviceUID string
-//   - status models.DeviceStatus
-//   - at time.Time
-func (_e *MockStore_Expecter) InstallKeyEventStampDecision(ctx any, sc any, deviceUID any, status any, at any) *MockStore_InstallKeyEventStampDecision_Call {
-	return &MockStore_InstallKeyEventStampDecision_Call{Call: _e.mock.On("InstallKeyEventStampDecision", ctx, sc, deviceUID, status, at)}
+//   - uid string
+//   - key string
+//   - value string
+func (_e *MockStore_Expecter) DeviceSetCustomField(ctx any, uid any, key any, value any) *MockStore_DeviceSetCustomField_Call {
+	return &MockStore_DeviceSetCustomField_Call{Call: _e.mock.On("DeviceSetCustomField", ctx, uid, key, value)}
 }
 
-func (_c *MockStore_InstallKeyEventStampDecision_Call) Run(run func(ctx context.Context, sc scope.Scope, deviceUID string, status models.DeviceStatus, at time.Time)) *MockStore_InstallKeyEventStampDecision_Call {
+func (_c *MockStore_DeviceSetCustomField_Call) Run(run func(ctx context.Context, uid string, key string, value string)) *MockStore_DeviceSetCustomField_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
+			arg1 = args[1].(string)
 		}
 		var arg2 string
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 models.DeviceStatus
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(models.DeviceStatus)
-		}
-		var arg4 time.Time
-		if args[4] != nil {
-			arg4 = args[4].(time.Time)
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_InstallKeyEventStampDecision_Call) Return(err error) *MockStore_InstallKeyEventStampDecision_Call {
+func (_c *MockStore_DeviceSetCustomField_Call) Return(err error) *MockStore_DeviceSetCustomField_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyEventStampDecision_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, deviceUID string, status models.DeviceStatus, at time.Time) error) *MockStore_InstallKeyEventStampDecision_Call {
+func (_c *MockStore_DeviceSetCustomField_Call) RunAndReturn(run func(ctx context.Context, uid string, key string, value string) error) *MockStore_DeviceSetCustomField_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyIncrementUsage provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyIncrementUsage(ctx context.Context, installKey *models.InstallKey) error {
-	ret := _mock.Called(ctx, installKey)
+// DeviceUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) DeviceUpdate(ctx context.Context, device *models.Device) error {
+	ret := _mock.Called(ctx, device)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyIncrementUsage")
+		panic("no return value specified for DeviceUpdate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) error); ok {
-		r0 = returnFunc(ctx, installKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Device) error); ok {
+		r0 = returnFunc(ctx, device)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_InstallKeyIncrementUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyIncrementUsage'
-type MockStore_InstallKeyIncrementUsage_Call struct {
+// MockStore_DeviceUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceUpdate'
+type MockStore_DeviceUpdate_Call struct {
 	*mock.Call
 }
 
-// InstallKeyIncrementUsage is a helper method to define mock.On call
+// DeviceUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - installKey *models.InstallKey
-func (_e *MockStore_Expecter) InstallKeyIncrementUsage(ctx any, installKey any) *MockStore_InstallKeyIncrementUsage_Call {
-	return &MockStore_InstallKeyIncrementUsage_Call{Call: _e.mock.On("InstallKeyIncrementUsage", ctx, installKey)}
+//   - device *models.Device
+func (_e *MockStore_Expecter) DeviceUpdate(ctx any, device any) *MockStore_DeviceUpdate_Call {
+	return &MockStore_DeviceUpdate_Call{Call: _e.mock.On("DeviceUpdate", ctx, device)}
 }
 
-func (_c *MockStore_InstallKeyIncrementUsage_Call) Run(run func(ctx context.Context, installKey *models.InstallKey)) *MockStore_InstallKeyIncrementUsage_Call {
+func (_c *MockStore_DeviceUpdate_Call) Run(run func(ctx context.Context, device *models.Device)) *MockStore_DeviceUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.InstallKey
+		var arg1 *models.Device
 		if args[1] != nil {
-			arg1 = args[1].(*models.InstallKey)
+			arg1 = args[1].(*models.Device)
 		}
 		run(
 			arg0,
@@ -2700,218 +2785,172 @@ func (_c *MockStore_InstallKeyIncrementUsage_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockStore_InstallKeyIncrementUsage_Call) Return(err error) *MockStore_InstallKeyIncrementUsage_Call {
+func (_c *MockStore_DeviceUpdate_Call) Return(err error) *MockStore_DeviceUpdate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyIncrementUsage_Call) RunAndReturn(run func(ctx context.Context, installKey *models.InstallKey) error) *MockStore_InstallKeyIncrementUsage_Call {
+func (_c *MockStore_DeviceUpdate_Call) RunAndReturn(run func(ctx context.Context, device *models.Device) error) *MockStore_DeviceUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyList provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.InstallKey, int, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, sc)
-	}
-	ret := tmpRet
+// EnrollmentCallbackCleanup provides a mock function for the type MockStore
+func (_mock *MockStore) EnrollmentCallbackCleanup(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyList")
+		panic("no return value specified for EnrollmentCallbackCleanup")
 	}
 
-	var r0 []models.InstallKey
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.InstallKey, int, error)); ok {
-		return returnFunc(ctx, sc, opts...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.InstallKey); ok {
-		r0 = returnFunc(ctx, sc, opts...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.InstallKey)
-		}
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
-		r1 = ret.Get(1).(int)
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_InstallKeyList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyList'
-type MockStore_InstallKeyList_Call struct {
+// MockStore_EnrollmentCallbackCleanup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollmentCallbackCleanup'
+type MockStore_EnrollmentCallbackCleanup_Call struct {
 	*mock.Call
 }
 
-// InstallKeyList is a helper method to define mock.On call
+// EnrollmentCallbackCleanup is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) InstallKeyList(ctx any, sc any, opts ...any) *MockStore_InstallKeyList_Call {
-	return &MockStore_InstallKeyList_Call{Call: _e.mock.On("InstallKeyList",
-		append([]any{ctx, sc}, opts...)...)}
+//   - before time.Time
+func (_e *MockStore_Expecter) EnrollmentCallbackCleanup(ctx any, before any) *MockStore_EnrollmentCallbackCleanup_Call {
+	return &MockStore_EnrollmentCallbackCleanup_Call{Call: _e.mock.On("EnrollmentCallbackCleanup", ctx, before)}
 }
 
-func (_c *MockStore_InstallKeyList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_InstallKeyList_Call {
+func (_c *MockStore_EnrollmentCallbackCleanup_Call) Run(run func(ctx context.Context, before time.Time)) *MockStore_EnrollmentCallbackCleanup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
-		}
-		var arg2 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 2 {
-			variadicArgs = args[2].([]store.QueryOption)
+			arg1 = args[1].(time.Time)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_InstallKeyList_Call) Return(installKeys []models.InstallKey, count int, err error) *MockStore_InstallKeyList_Call {
-	_c.Call.Return(installKeys, count, err)
+func (_c *MockStore_EnrollmentCallbackCleanup_Call) Return(deleted int64, err error) *MockStore_EnrollmentCallbackCleanup_Call {
+	_c.Call.Return(deleted, err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.InstallKey, int, error)) *MockStore_InstallKeyList_Call {
+func (_c *MockStore_EnrollmentCallbackCleanup_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockStore_EnrollmentCallbackCleanup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyResolve provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyResolve(ctx context.Context, sc scope.Scope, resolver store.InstallKeyResolver, value string, opts ...store.QueryOption) (*models.InstallKey, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, sc, resolver, value)
-	}
-	ret := tmpRet
+// EnrollmentCallbackRedeem provides a mock function for the type MockStore
+func (_mock *MockStore) EnrollmentCallbackRedeem(ctx context.Context, jti string, at time.Time) (bool, error) {
+	ret := _mock.Called(ctx, jti, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyResolve")
+		panic("no return value specified for EnrollmentCallbackRedeem")
 	}
 
-	var r0 *models.InstallKey
+	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.InstallKeyResolver, string, ...store.QueryOption) (*models.InstallKey, error)); ok {
-		return returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, jti, at)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.InstallKeyResolver, string, ...store.QueryOption) *models.InstallKey); ok {
-		r0 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = returnFunc(ctx, jti, at)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.InstallKey)
-		}
+		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.InstallKeyResolver, string, ...store.QueryOption) error); ok {
-		r1 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, jti, at)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_InstallKeyResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyResolve'
-type MockStore_InstallKeyResolve_Call struct {
+// MockStore_EnrollmentCallbackRedeem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollmentCallbackRedeem'
+type MockStore_EnrollmentCallbackRedeem_Call struct {
 	*mock.Call
 }
 
-// InstallKeyResolve is a helper method to define mock.On call
+// EnrollmentCallbackRedeem is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - resolver store.InstallKeyResolver
-//   - value string
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) InstallKeyResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_InstallKeyResolve_Call {
-	return &MockStore_InstallKeyResolve_Call{Call: _e.mock.On("InstallKeyResolve",
-		append([]any{ctx, sc, resolver, value}, opts...)...)}
+//   - jti string
+//   - at time.Time
+func (_e *MockStore_Expecter) EnrollmentCallbackRedeem(ctx any, jti any, at any) *MockStore_EnrollmentCallbackRedeem_Call {
+	return &MockStore_EnrollmentCallbackRedeem_Call{Call: _e.mock.On("EnrollmentCallbackRedeem", ctx, jti, at)}
 }
 
-func (_c *MockStore_InstallKeyResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.InstallKeyResolver, value string, opts ...store.QueryOption)) *MockStore_InstallKeyResolve_Call {
+func (_c *MockStore_EnrollmentCallbackRedeem_Call) Run(run func(ctx context.Context, jti string, at time.Time)) *MockStore_EnrollmentCallbackRedeem_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
+			arg1 = args[1].(string)
 		}
-		var arg2 store.InstallKeyResolver
+		var arg2 time.Time
 		if args[2] != nil {
-			arg2 = args[2].(store.InstallKeyResolver)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 4 {
-			variadicArgs = args[4].([]store.QueryOption)
+			arg2 = args[2].(time.Time)
 		}
-		arg4 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_InstallKeyResolve_Call) Return(installKey *models.InstallKey, err error) *MockStore_InstallKeyResolve_Call {
-	_c.Call.Return(installKey, err)
+func (_c *MockStore_EnrollmentCallbackRedeem_Call) Return(redeemed bool, err error) *MockStore_EnrollmentCallbackRedeem_Call {
+	_c.Call.Return(redeemed, err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.InstallKeyResolver, value string, opts ...store.QueryOption) (*models.InstallKey, error)) *MockStore_InstallKeyResolve_Call {
+func (_c *MockStore_EnrollmentCallbackRedeem_Call) RunAndReturn(run func(ctx context.Context, jti string, at time.Time) (bool, error)) *MockStore_EnrollmentCallbackRedeem_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyResolveSystem provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyResolveSystem(ctx context.Context, sc scope.Scope) (*models.InstallKey, error) {
+// GetStats provides a mock function for the type MockStore
+func (_mock *MockStore) GetStats(ctx context.Context, sc scope.Scope) (*models.Stats, error) {
 	ret := _mock.Called(ctx, sc)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyResolveSystem")
+		panic("no return value specified for GetStats")
 	}
 
-	var r0 *models.InstallKey
+	var r0 *models.Stats
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) (*models.InstallKey, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) (*models.Stats, error)); ok {
 		return returnFunc(ctx, sc)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) *models.InstallKey); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) *models.Stats); ok {
 		r0 = returnFunc(ctx, sc)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.InstallKey)
+			r0 = ret.Get(0).(*models.Stats)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope) error); ok {
@@ -2922,19 +2961,19 @@ func (_mock *MockStore) InstallKeyResolveSystem(ctx context.Context, sc scope.Sc
 	return r0, r1
 }
 
-// MockStore_InstallKeyResolveSystem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyResolveSystem'
-type MockStore_InstallKeyResolveSystem_Call struct {
+// MockStore_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
+type MockStore_GetStats_Call struct {
 	*mock.Call
 }
 
-// InstallKeyResolveSystem is a helper method to define mock.On call
+// GetStats is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-func (_e *MockStore_Expecter) InstallKeyResolveSystem(ctx any, sc any) *MockStore_InstallKeyResolveSystem_Call {
-	return &MockStore_InstallKeyResolveSystem_Call{Call: _e.mock.On("InstallKeyResolveSystem", ctx, sc)}
+func (_e *MockStore_Expecter) GetStats(ctx any, sc any) *MockStore_GetStats_Call {
+	return &MockStore_GetStats_Call{Call: _e.mock.On("GetStats", ctx, sc)}
 }
 
-func (_c *MockStore_InstallKeyResolveSystem_Call) Run(run func(ctx context.Context, sc scope.Scope)) *MockStore_InstallKeyResolveSystem_Call {
+func (_c *MockStore_GetStats_Call) Run(run func(ctx context.Context, sc scope.Scope)) *MockStore_GetStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2952,57 +2991,64 @@ func (_c *MockStore_InstallKeyResolveSystem_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockStore_InstallKeyResolveSystem_Call) Return(installKey *models.InstallKey, err error) *MockStore_InstallKeyResolveSystem_Call {
-	_c.Call.Return(installKey, err)
+func (_c *MockStore_GetStats_Call) Return(stats *models.Stats, err error) *MockStore_GetStats_Call {
+	_c.Call.Return(stats, err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyResolveSystem_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope) (*models.InstallKey, error)) *MockStore_InstallKeyResolveSystem_Call {
+func (_c *MockStore_GetStats_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope) (*models.Stats, error)) *MockStore_GetStats_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyResolveSystemPairing provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyResolveSystemPairing(ctx context.Context, sc scope.Scope) (*models.InstallKey, error) {
-	ret := _mock.Called(ctx, sc)
+// InstallKeyConflicts provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyConflicts(ctx context.Context, sc scope.Scope, target *models.InstallKeyConflicts) ([]string, bool, error) {
+	ret := _mock.Called(ctx, sc, target)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyResolveSystemPairing")
+		panic("no return value specified for InstallKeyConflicts")
 	}
 
-	var r0 *models.InstallKey
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) (*models.InstallKey, error)); ok {
-		return returnFunc(ctx, sc)
+	var r0 []string
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.InstallKeyConflicts) ([]string, bool, error)); ok {
+		return returnFunc(ctx, sc, target)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) *models.InstallKey); ok {
-		r0 = returnFunc(ctx, sc)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.InstallKeyConflicts) []string); ok {
+		r0 = returnFunc(ctx, sc, target)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.InstallKey)
+			r0 = ret.Get(0).([]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope) error); ok {
-		r1 = returnFunc(ctx, sc)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *models.InstallKeyConflicts) bool); ok {
+		r1 = returnFunc(ctx, sc, target)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(bool)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *models.InstallKeyConflicts) error); ok {
+		r2 = returnFunc(ctx, sc, target)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_InstallKeyResolveSystemPairing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyResolveSystemPairing'
-type MockStore_InstallKeyResolveSystemPairing_Call struct {
+// MockStore_InstallKeyConflicts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyConflicts'
+type MockStore_InstallKeyConflicts_Call struct {
 	*mock.Call
 }
 
-// InstallKeyResolveSystemPairing is a helper method to define mock.On call
+// InstallKeyConflicts is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-func (_e *MockStore_Expecter) InstallKeyResolveSystemPairing(ctx any, sc any) *MockStore_InstallKeyResolveSystemPairing_Call {
-	return &MockStore_InstallKeyResolveSystemPairing_Call{Call: _e.mock.On("InstallKeyResolveSystemPairing", ctx, sc)}
+//   - target *models.InstallKeyConflicts
+func (_e *MockStore_Expecter) InstallKeyConflicts(ctx any, sc any, target any) *MockStore_InstallKeyConflicts_Call {
+	return &MockStore_InstallKeyConflicts_Call{Call: _e.mock.On("InstallKeyConflicts", ctx, sc, target)}
 }
 
-func (_c *MockStore_InstallKeyResolveSystemPairing_Call) Run(run func(ctx context.Context, sc scope.Scope)) *MockStore_InstallKeyResolveSystemPairing_Call {
+func (_c *MockStore_InstallKeyConflicts_Call) Run(run func(ctx context.Context, sc scope.Scope, target *models.InstallKeyConflicts)) *MockStore_InstallKeyConflicts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3012,54 +3058,68 @@ func (_c *MockStore_InstallKeyResolveSystemPairing_Call) Run(run func(ctx contex
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
+		var arg2 *models.InstallKeyConflicts
+		if args[2] != nil {
+			arg2 = args[2].(*models.InstallKeyConflicts)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_InstallKeyResolveSystemPairing_Call) Return(installKey *models.InstallKey, err error) *MockStore_InstallKeyResolveSystemPairing_Call {
-	_c.Call.Return(installKey, err)
+func (_c *MockStore_InstallKeyConflicts_Call) Return(conflicts []string, has bool, err error) *MockStore_InstallKeyConflicts_Call {
+	_c.Call.Return(conflicts, has, err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyResolveSystemPairing_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope) (*models.InstallKey, error)) *MockStore_InstallKeyResolveSystemPairing_Call {
+func (_c *MockStore_InstallKeyConflicts_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, target *models.InstallKeyConflicts) ([]string, bool, error)) *MockStore_InstallKeyConflicts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallKeyUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) InstallKeyUpdate(ctx context.Context, installKey *models.InstallKey) error {
+// InstallKeyCreate provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyCreate(ctx context.Context, installKey *models.InstallKey) (string, error) {
 	ret := _mock.Called(ctx, installKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallKeyUpdate")
+		panic("no return value specified for InstallKeyCreate")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) error); ok {
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) (string, error)); ok {
+		return returnFunc(ctx, installKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) string); ok {
 		r0 = returnFunc(ctx, installKey)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.InstallKey) error); ok {
+		r1 = returnFunc(ctx, installKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_InstallKeyUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyUpdate'
-type MockStore_InstallKeyUpdate_Call struct {
+// MockStore_InstallKeyCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyCreate'
+type MockStore_InstallKeyCreate_Call struct {
 	*mock.Call
 }
 
-// InstallKeyUpdate is a helper method to define mock.On call
+// InstallKeyCreate is a helper method to define mock.On call
 //   - ctx context.Context
 //   - installKey *models.InstallKey
-func (_e *MockStore_Expecter) InstallKeyUpdate(ctx any, installKey any) *MockStore_InstallKeyUpdate_Call {
-	return &MockStore_InstallKeyUpdate_Call{Call: _e.mock.On("InstallKeyUpdate", ctx, installKey)}
+func (_e *MockStore_Expecter) InstallKeyCreate(ctx any, installKey any) *MockStore_InstallKeyCreate_Call {
+	return &MockStore_InstallKeyCreate_Call{Call: _e.mock.On("InstallKeyCreate", ctx, installKey)}
 }
 
-func (_c *MockStore_InstallKeyUpdate_Call) Run(run func(ctx context.Context, installKey *models.InstallKey)) *MockStore_InstallKeyUpdate_Call {
+func (_c *MockStore_InstallKeyCreate_Call) Run(run func(ctx context.Context, installKey *models.InstallKey)) *MockStore_InstallKeyCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3077,54 +3137,54 @@ func (_c *MockStore_InstallKeyUpdate_Call) Run(run func(ctx context.Context, ins
 	return _c
 }
 
-func (_c *MockStore_InstallKeyUpdate_Call) Return(err error) *MockStore_InstallKeyUpdate_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_InstallKeyCreate_Call) Return(insertedID string, err error) *MockStore_InstallKeyCreate_Call {
+	_c.Call.Return(insertedID, err)
 	return _c
 }
 
-func (_c *MockStore_InstallKeyUpdate_Call) RunAndReturn(run func(ctx context.Context, installKey *models.InstallKey) error) *MockStore_InstallKeyUpdate_Call {
+func (_c *MockStore_InstallKeyCreate_Call) RunAndReturn(run func(ctx context.Context, installKey *models.InstallKey) (string, error)) *MockStore_InstallKeyCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MembershipInvitationCreate provides a mock function for the type MockStore
-func (_mock *MockStore) MembershipInvitationCreate(ctx context.Context, invitation *models.MembershipInvitation) error {
-	ret := _mock.Called(ctx, invitation)
+// InstallKeyDecrementUsage provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyDecrementUsage(ctx context.Context, installKey *models.InstallKey) error {
+	ret := _mock.Called(ctx, installKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MembershipInvitationCreate")
+		panic("no return value specified for InstallKeyDecrementUsage")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MembershipInvitation) error); ok {
-		r0 = returnFunc(ctx, invitation)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) error); ok {
+		r0 = returnFunc(ctx, installKey)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_MembershipInvitationCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationCreate'
-type MockStore_MembershipInvitationCreate_Call struct {
+// MockStore_InstallKeyDecrementUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyDecrementUsage'
+type MockStore_InstallKeyDecrementUsage_Call struct {
 	*mock.Call
 }
 
-// MembershipInvitationCreate is a helper method to define mock.On call
+// InstallKeyDecrementUsage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - invitation *models.MembershipInvitation
-func (_e *MockStore_Expecter) MembershipInvitationCreate(ctx any, invitation any) *MockStore_MembershipInvitationCreate_Call {
-	return &MockStore_MembershipInvitationCreate_Call{Call: _e.mock.On("MembershipInvitationCreate", ctx, invitation)}
+//   - installKey *models.InstallKey
+func (_e *MockStore_Expecter) InstallKeyDecrementUsage(ctx any, installKey any) *MockStore_InstallKeyDecrementUsage_Call {
+	return &MockStore_InstallKeyDecrementUsage_Call{Call: _e.mock.On("InstallKeyDecrementUsage", ctx, installKey)}
 }
 
-func (_c *MockStore_MembershipInvitationCreate_Call) Run(run func(ctx context.Context, invitation *models.MembershipInvitation)) *MockStore_MembershipInvitationCreate_Call {
+func (_c *MockStore_InstallKeyDecrementUsage_Call) Run(run func(ctx context.Context, installKey *models.InstallKey)) *MockStore_InstallKeyDecrementUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.MembershipInvitation
+		var arg1 *models.InstallKey
 		if args[1] != nil {
-			arg1 = args[1].(*models.MembershipInvitation)
+			arg1 = args[1].(*models.InstallKey)
 		}
 		run(
 			arg0,
@@ -3134,54 +3194,54 @@ func (_c *MockStore_MembershipInvitationCreate_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockStore_MembershipInvitationCreate_Call) Return(err error) *MockStore_MembershipInvitationCreate_Call {
+func (_c *MockStore_InstallKeyDecrementUsage_Call) Return(err error) *MockStore_InstallKeyDecrementUsage_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_MembershipInvitationCreate_Call) RunAndReturn(run func(ctx context.Context, invitation *models.MembershipInvitation) error) *MockStore_MembershipInvitationCreate_Call {
+func (_c *MockStore_InstallKeyDecrementUsage_Call) RunAndReturn(run func(ctx context.Context, installKey *models.InstallKey) error) *MockStore_InstallKeyDecrementUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MembershipInvitationDelete provides a mock function for the type MockStore
-func (_mock *MockStore) MembershipInvitationDelete(ctx context.Context, invitation *models.MembershipInvitation) error {
-	ret := _mock.Called(ctx, invitation)
+// InstallKeyEventCreate provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyEventCreate(ctx context.Context, event *models.InstallKeyEvent) error {
+	ret := _mock.Called(ctx, event)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MembershipInvitationDelete")
+		panic("no return value specified for InstallKeyEventCreate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MembershipInvitation) error); ok {
-		r0 = returnFunc(ctx, invitation)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKeyEvent) error); ok {
+		r0 = returnFunc(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_MembershipInvitationDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationDelete'
-type MockStore_MembershipInvitationDelete_Call struct {
+// MockStore_InstallKeyEventCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyEventCreate'
+type MockStore_InstallKeyEventCreate_Call struct {
 	*mock.Call
 }
 
-// MembershipInvitationDelete is a helper method to define mock.On call
+// InstallKeyEventCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - invitation *models.MembershipInvitation
-func (_e *MockStore_Expecter) MembershipInvitationDelete(ctx any, invitation any) *MockStore_MembershipInvitationDelete_Call {
-	return &MockStore_MembershipInvitationDelete_Call{Call: _e.mock.On("MembershipInvitationDelete", ctx, invitation)}
+//   - event *models.InstallKeyEvent
+func (_e *MockStore_Expecter) InstallKeyEventCreate(ctx any, event any) *MockStore_InstallKeyEventCreate_Call {
+	return &MockStore_InstallKeyEventCreate_Call{Call: _e.mock.On("InstallKeyEventCreate", ctx, event)}
 }
 
-func (_c *MockStore_MembershipInvitationDelete_Call) Run(run func(ctx context.Context, invitation *models.MembershipInvitation)) *MockStore_MembershipInvitationDelete_Call {
+func (_c *MockStore_InstallKeyEventCreate_Call) Run(run func(ctx context.Context, event *models.InstallKeyEvent)) *MockStore_InstallKeyEventCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.MembershipInvitation
+		var arg1 *models.InstallKeyEvent
 		if args[1] != nil {
-			arg1 = args[1].(*models.MembershipInvitation)
+			arg1 = args[1].(*models.InstallKeyEvent)
 		}
 		run(
 			arg0,
@@ -3191,58 +3251,72 @@ func (_c *MockStore_MembershipInvitationDelete_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockStore_MembershipInvitationDelete_Call) Return(err error) *MockStore_MembershipInvitationDelete_Call {
+func (_c *MockStore_InstallKeyEventCreate_Call) Return(err error) *MockStore_InstallKeyEventCreate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_MembershipInvitationDelete_Call) RunAndReturn(run func(ctx context.Context, invitation *models.MembershipInvitation) error) *MockStore_MembershipInvitationDelete_Call {
+func (_c *MockStore_InstallKeyEventCreate_Call) RunAndReturn(run func(ctx context.Context, event *models.InstallKeyEvent) error) *MockStore_InstallKeyEventCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MembershipInvitationResolve provides a mock function for the type MockStore
-func (_mock *MockStore) MembershipInvitationResolve(ctx context.Context, sc scope.Scope, userID string) (*models.MembershipInvitation, error) {
-	ret := _mock.Called(ctx, sc, userID)
+// InstallKeyEventList provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyEventList(ctx context.Context, sc scope.Scope, keyDigest string, opts ...store.QueryOption) ([]models.InstallKeyEvent, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, keyDigest, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, keyDigest)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for MembershipInvitationResolve")
+		panic("no return value specified for InstallKeyEventList")
 	}
 
-	var r0 *models.MembershipInvitation
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) (*models.MembershipInvitation, error)); ok {
-		return returnFunc(ctx, sc, userID)
+	var r0 []models.InstallKeyEvent
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, ...store.QueryOption) ([]models.InstallKeyEvent, int, error)); ok {
+		return returnFunc(ctx, sc, keyDigest, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) *models.MembershipInvitation); ok {
-		r0 = returnFunc(ctx, sc, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, ...store.QueryOption) []models.InstallKeyEvent); ok {
+		r0 = returnFunc(ctx, sc, keyDigest, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.MembershipInvitation)
+			r0 = ret.Get(0).([]models.InstallKeyEvent)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, string) error); ok {
-		r1 = returnFunc(ctx, sc, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, string, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, keyDigest, opts...)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, string, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, keyDigest, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_MembershipInvitationResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationResolve'
-type MockStore_MembershipInvitationResolve_Call struct {
+// MockStore_InstallKeyEventList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyEventList'
+type MockStore_InstallKeyEventList_Call struct {
 	*mock.Call
 }
 
-// MembershipInvitationResolve is a helper method to define mock.On call
+// InstallKeyEventList is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - userID string
-func (_e *MockStore_Expecter) MembershipInvitationResolve(ctx any, sc any, userID any) *MockStore_MembershipInvitationResolve_Call {
-	return &MockStore_MembershipInvitationResolve_Call{Call: _e.mock.On("MembershipInvitationResolve", ctx, sc, userID)}
+//   - keyDigest string
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) InstallKeyEventList(ctx any, sc any, keyDigest any, opts ...any) *MockStore_InstallKeyEventList_Call {
+	return &MockStore_InstallKeyEventList_Call{Call: _e.mock.On("InstallKeyEventList",
+		append([]any{ctx, sc, keyDigest}, opts...)...)}
 }
 
-func (_c *MockStore_MembershipInvitationResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, userID string)) *MockStore_MembershipInvitationResolve_Call {
+func (_c *MockStore_InstallKeyEventList_Call) Run(run func(ctx context.Context, sc scope.Scope, keyDigest string, opts ...store.QueryOption)) *MockStore_InstallKeyEventList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3256,131 +3330,145 @@ func (_c *MockStore_MembershipInvitationResolve_Call) Run(run func(ctx context.C
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 3 {
+			variadicArgs = args[3].([]store.QueryOption)
+		}
+		arg3 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_MembershipInvitationResolve_Call) Return(membershipInvitation *models.MembershipInvitation, err error) *MockStore_MembershipInvitationResolve_Call {
-	_c.Call.Return(membershipInvitation, err)
+func (_c *MockStore_InstallKeyEventList_Call) Return(events []models.InstallKeyEvent, count int, err error) *MockStore_InstallKeyEventList_Call {
+	_c.Call.Return(events, count, err)
 	return _c
 }
 
-func (_c *MockStore_MembershipInvitationResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, userID string) (*models.MembershipInvitation, error)) *MockStore_MembershipInvitationResolve_Call {
+func (_c *MockStore_InstallKeyEventList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, keyDigest string, opts ...store.QueryOption) ([]models.InstallKeyEvent, int, error)) *MockStore_InstallKeyEventList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MembershipInvitationResolveBySig provides a mock function for the type MockStore
-func (_mock *MockStore) MembershipInvitationResolveBySig(ctx context.Context, sig string) (*models.MembershipInvitation, error) {
-	ret := _mock.Called(ctx, sig)
+// InstallKeyEventStampDecision provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyEventStampDecision(ctx context.Context, sc scope.Scope, deviceUID string, status models.DeviceStatus, at time.Time) error {
+	ret := _mock.Called(ctx, sc, deviceUID, status, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MembershipInvitationResolveBySig")
+		panic("no return value specified for InstallKeyEventStampDecision")
 	}
 
-	var r0 *models.MembershipInvitation
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.MembershipInvitation, error)); ok {
-		return returnFunc(ctx, sig)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.MembershipInvitation); ok {
-		r0 = returnFunc(ctx, sig)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.MembershipInvitation)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, sig)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, models.DeviceStatus, time.Time) error); ok {
+		r0 = returnFunc(ctx, sc, deviceUID, status, at)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_MembershipInvitationResolveBySig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationResolveBySig'
-type MockStore_MembershipInvitationResolveBySig_Call struct {
+// MockStore_InstallKeyEventStampDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyEventStampDecision'
+type MockStore_InstallKeyEventStampDecision_Call struct {
 	*mock.Call
 }
 
-// MembershipInvitationResolveBySig is a helper method to define mock.On call
+// InstallKeyEventStampDecision is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sig string
-func (_e *MockStore_Expecter) MembershipInvitationResolveBySig(ctx any, sig any) *MockStore_MembershipInvitationResolveBySig_Call {
-	return &MockStore_MembershipInvitationResolveBySig_Call{Call: _e.mock.On("MembershipInvitationResolveBySig", ctx, sig)}
+//   - sc scope.Scope
+//   - deviceUID string
+//   - status models.DeviceStatus
+//   - at time.Time
+func (_e *MockStore_Expecter) InstallKeyEventStampDecision(ctx any, sc any, deviceUID any, status any, at any) *MockStore_InstallKeyEventStampDecision_Call {
+	return &MockStore_InstallKeyEventStampDecision_Call{Call: _e.mock.On("InstallKeyEventStampDecision", ctx, sc, deviceUID, status, at)}
 }
 
-func (_c *MockStore_MembershipInvitationResolveBySig_Call) Run(run func(ctx context.Context, sig string)) *MockStore_MembershipInvitationResolveBySig_Call {
+func (_c *MockStore_InstallKeyEventStampDecision_Call) Run(run func(ctx context.Context, sc scope.Scope, deviceUID string, status models.DeviceStatus, at time.Time)) *MockStore_InstallKeyEventStampDecision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(scope.Scope)
 		}
-		run(
-			arg0,
-			arg1,
-		)
-	})
-	return _c
-}
-
-func (_c *MockStore_MembershipInvitationResolveBySig_Call) Return(membershipInvitation *models.MembershipInvitation, err error) *MockStore_MembershipInvitationResolveBySig_Call {
-	_c.Call.Return(membershipInvitation, err)
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 models.DeviceStatus
+		if args[3] != nil {
+			arg3 = args[3].(models.DeviceStatus)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
 	return _c
 }
 
-func (_c *MockStore_MembershipInvitationResolveBySig_Call) RunAndReturn(run func(ctx context.Context, sig string) (*models.MembershipInvitation, error)) *MockStore_MembershipInvitationResolveBySig_Call {
+func (_c *MockStore_InstallKeyEventStampDecision_Call) Return(err error) *MockStore_InstallKeyEventStampDecision_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_InstallKeyEventStampDecision_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, deviceUID string, status models.DeviceStatus, at time.Time) error) *MockStore_InstallKeyEventStampDecision_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MembershipInvitationUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) MembershipInvitationUpdate(ctx context.Context, invitation *models.MembershipInvitation) error {
-	ret := _mock.Called(ctx, invitation)
+// InstallKeyIncrementUsage provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyIncrementUsage(ctx context.Context, installKey *models.InstallKey) error {
+	ret := _mock.Called(ctx, installKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MembershipInvitationUpdate")
+		panic("no return value specified for InstallKeyIncrementUsage")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MembershipInvitation) error); ok {
-		r0 = returnFunc(ctx, invitation)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) error); ok {
+		r0 = returnFunc(ctx, installKey)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_MembershipInvitationUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationUpdate'
-type MockStore_MembershipInvitationUpdate_Call struct {
+// MockStore_InstallKeyIncrementUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyIncrementUsage'
+type MockStore_InstallKeyIncrementUsage_Call struct {
 	*mock.Call
 }
 
-// MembershipInvitationUpdate is a helper method to define mock.On call
+// InstallKeyIncrementUsage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - invitation *models.MembershipInvitation
-func (_e *MockStore_Expecter) MembershipInvitationUpdate(ctx any, invitation any) *MockStore_MembershipInvitationUpdate_Call {
-	return &MockStore_MembershipInvitationUpdate_Call{Call: _e.mock.On("MembershipInvitationUpdate", ctx, invitation)}
+//   - installKey *models.InstallKey
+func (_e *MockStore_Expecter) InstallKeyIncrementUsage(ctx any, installKey any) *MockStore_InstallKeyIncrementUsage_Call {
+	return &MockStore_InstallKeyIncrementUsage_Call{Call: _e.mock.On("InstallKeyIncrementUsage", ctx, installKey)}
 }
 
-func (_c *MockStore_MembershipInvitationUpdate_Call) Run(run func(ctx context.Context, invitation *models.MembershipInvitation)) *MockStore_MembershipInvitationUpdate_Call {
+func (_c *MockStore_InstallKeyIncrementUsage_Call) Run(run func(ctx context.Context, installKey *models.InstallKey)) *MockStore_InstallKeyIncrementUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.MembershipInvitation
+		var arg1 *models.InstallKey
 		if args[1] != nil {
-			arg1 = args[1].(*models.MembershipInvitation)
+			arg1 = args[1].(*models.InstallKey)
 		}
 		run(
 			arg0,
@@ -3390,187 +3478,241 @@ func (_c *MockStore_MembershipInvitationUpdate_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockStore_MembershipInvitationUpdate_Call) Return(err error) *MockStore_MembershipInvitationUpdate_Call {
+func (_c *MockStore_InstallKeyIncrementUsage_Call) Return(err error) *MockStore_InstallKeyIncrementUsage_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_MembershipInvitationUpdate_Call) RunAndReturn(run func(ctx context.Context, invitation *models.MembershipInvitation) error) *MockStore_MembershipInvitationUpdate_Call {
+func (_c *MockStore_InstallKeyIncrementUsage_Call) RunAndReturn(run func(ctx context.Context, installKey *models.InstallKey) error) *MockStore_InstallKeyIncrementUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceConflicts provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceConflicts(ctx context.Context, target *models.NamespaceConflicts) ([]string, bool, error) {
-	ret := _mock.Called(ctx, target)
+// InstallKeyList provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.InstallKey, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceConflicts")
+		panic("no return value specified for InstallKeyList")
 	}
 
-	var r0 []string
-	var r1 bool
+	var r0 []models.InstallKey
+	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.NamespaceConflicts) ([]string, bool, error)); ok {
-		return returnFunc(ctx, target)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.InstallKey, int, error)); ok {
+		return returnFunc(ctx, sc, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.NamespaceConflicts) []string); ok {
-		r0 = returnFunc(ctx, target)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.InstallKey); ok {
+		r0 = returnFunc(ctx, sc, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]models.InstallKey)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.NamespaceConflicts) bool); ok {
-		r1 = returnFunc(ctx, target)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, opts...)
 	} else {
-		r1 = ret.Get(1).(bool)
+		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *models.NamespaceConflicts) error); ok {
-		r2 = returnFunc(ctx, target)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, opts...)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockStore_NamespaceConflicts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceConflicts'
-type MockStore_NamespaceConflicts_Call struct {
+// MockStore_InstallKeyList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyList'
+type MockStore_InstallKeyList_Call struct {
 	*mock.Call
 }
 
-// NamespaceConflicts is a helper method to define mock.On call
+// InstallKeyList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - target *models.NamespaceConflicts
-func (_e *MockStore_Expecter) NamespaceConflicts(ctx any, target any) *MockStore_NamespaceConflicts_Call {
-	return &MockStore_NamespaceConflicts_Call{Call: _e.mock.On("NamespaceConflicts", ctx, target)}
+//   - sc scope.Scope
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) InstallKeyList(ctx any, sc any, opts ...any) *MockStore_InstallKeyList_Call {
+	return &MockStore_InstallKeyList_Call{Call: _e.mock.On("InstallKeyList",
+		append([]any{ctx, sc}, opts...)...)}
 }
 
-func (_c *MockStore_NamespaceConflicts_Call) Run(run func(ctx context.Context, target *models.NamespaceConflicts)) *MockStore_NamespaceConflicts_Call {
+func (_c *MockStore_InstallKeyList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_InstallKeyList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.NamespaceConflicts
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(*models.NamespaceConflicts)
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
 		}
+		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
+			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_NamespaceConflicts_Call) Return(conflicts []string, has bool, err error) *MockStore_NamespaceConflicts_Call {
-	_c.Call.Return(conflicts, has, err)
+func (_c *MockStore_InstallKeyList_Call) Return(installKeys []models.InstallKey, count int, err error) *MockStore_InstallKeyList_Call {
+	_c.Call.Return(installKeys, count, err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceConflicts_Call) RunAndReturn(run func(ctx context.Context, target *models.NamespaceConflicts) ([]string, bool, error)) *MockStore_NamespaceConflicts_Call {
+func (_c *MockStore_InstallKeyList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.InstallKey, int, error)) *MockStore_InstallKeyList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceCreate provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceCreate(ctx context.Context, namespace *models.Namespace) (string, error) {
-	ret := _mock.Called(ctx, namespace)
+// InstallKeyResolve provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyResolve(ctx context.Context, sc scope.Scope, resolver store.InstallKeyResolver, value string, opts ...store.QueryOption) (*models.InstallKey, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, resolver, value)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceCreate")
+		panic("no return value specified for InstallKeyResolve")
 	}
 
-	var r0 string
+	var r0 *models.InstallKey
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Namespace) (string, error)); ok {
-		return returnFunc(ctx, namespace)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.InstallKeyResolver, string, ...store.QueryOption) (*models.InstallKey, error)); ok {
+		return returnFunc(ctx, sc, resolver, value, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Namespace) string); ok {
-		r0 = returnFunc(ctx, namespace)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.InstallKeyResolver, string, ...store.QueryOption) *models.InstallKey); ok {
+		r0 = returnFunc(ctx, sc, resolver, value, opts...)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InstallKey)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.Namespace) error); ok {
-		r1 = returnFunc(ctx, namespace)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.InstallKeyResolver, string, ...store.QueryOption) error); ok {
+		r1 = returnFunc(ctx, sc, resolver, value, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_NamespaceCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceCreate'
-type MockStore_NamespaceCreate_Call struct {
+// MockStore_InstallKeyResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyResolve'
+type MockStore_InstallKeyResolve_Call struct {
 	*mock.Call
 }
 
-// NamespaceCreate is a helper method to define mock.On call
+// InstallKeyResolve is a helper method to define mock.On call
 //   - ctx context.Context
-//   - namespace *models.Namespace
-func (_e *MockStore_Expecter) NamespaceCreate(ctx any, namespace any) *MockStore_NamespaceCreate_Call {
-	return &MockStore_NamespaceCreate_Call{Call: _e.mock.On("NamespaceCreate", ctx, namespace)}
+//   - sc scope.Scope
+//   - resolver store.InstallKeyResolver
+//   - value string
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) InstallKeyResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_InstallKeyResolve_Call {
+	return &MockStore_InstallKeyResolve_Call{Call: _e.mock.On("InstallKeyResolve",
+		append([]any{ctx, sc, resolver, value}, opts...)...)}
 }
 
-func (_c *MockStore_NamespaceCreate_Call) Run(run func(ctx context.Context, namespace *models.Namespace)) *MockStore_NamespaceCreate_Call {
+func (_c *MockStore_InstallKeyResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.InstallKeyResolver, value string, opts ...store.QueryOption)) *MockStore_InstallKeyResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Namespace
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(*models.Namespace)
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 store.InstallKeyResolver
+		if args[2] != nil {
+			arg2 = args[2].(store.InstallKeyResolver)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 4 {
+			variadicArgs = args[4].([]store.QueryOption)
+		}
+		arg4 = variadicArgs
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_NamespaceCreate_Call) Return(s string, err error) *MockStore_NamespaceCreate_Call {
-	_c.Call.Return(s, err)
+func (_c *MockStore_InstallKeyResolve_Call) Return(installKey *models.InstallKey, err error) *MockStore_InstallKeyResolve_Call {
+	_c.Call.Return(installKey, err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceCreate_Call) RunAndReturn(run func(ctx context.Context, namespace *models.Namespace) (string, error)) *MockStore_NamespaceCreate_Call {
+func (_c *MockStore_InstallKeyResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.InstallKeyResolver, value string, opts ...store.QueryOption) (*models.InstallKey, error)) *MockStore_InstallKeyResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceCreateMembership provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceCreateMembership(ctx context.Context, sc scope.Scope, member *models.Member) error {
-	ret := _mock.Called(ctx, sc, member)
+// InstallKeyResolveSystem provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyResolveSystem(ctx context.Context, sc scope.Scope) (*models.InstallKey, error) {
+	ret := _mock.Called(ctx, sc)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceCreateMembership")
+		panic("no return value specified for InstallKeyResolveSystem")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.Member) error); ok {
-		r0 = returnFunc(ctx, sc, member)
+	var r0 *models.InstallKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) (*models.InstallKey, error)); ok {
+		return returnFunc(ctx, sc)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) *models.InstallKey); ok {
+		r0 = returnFunc(ctx, sc)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InstallKey)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope) error); ok {
+		r1 = returnFunc(ctx, sc)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_NamespaceCreateMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceCreateMembership'
-type MockStore_NamespaceCreateMembership_Call struct {
+// MockStore_InstallKeyResolveSystem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyResolveSystem'
+type MockStore_InstallKeyResolveSystem_Call struct {
 	*mock.Call
 }
 
-// NamespaceCreateMembership is a helper method to define mock.On call
+// InstallKeyResolveSystem is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - member *models.Member
-func (_e *MockStore_Expecter) NamespaceCreateMembership(ctx any, sc any, member any) *MockStore_NamespaceCreateMembership_Call {
-	return &MockStore_NamespaceCreateMembership_Call{Call: _e.mock.On("NamespaceCreateMembership", ctx, sc, member)}
+func (_e *MockStore_Expecter) InstallKeyResolveSystem(ctx any, sc any) *MockStore_InstallKeyResolveSystem_Call {
+	return &MockStore_InstallKeyResolveSystem_Call{Call: _e.mock.On("InstallKeyResolveSystem", ctx, sc)}
 }
 
-func (_c *MockStore_NamespaceCreateMembership_Call) Run(run func(ctx context.Context, sc scope.Scope, member *models.Member)) *MockStore_NamespaceCreateMembership_Call {
+func (_c *MockStore_InstallKeyResolveSystem_Call) Run(run func(ctx context.Context, sc scope.Scope)) *MockStore_InstallKeyResolveSystem_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3580,67 +3722,73 @@ func (_c *MockStore_NamespaceCreateMembership_Call) Run(run func(ctx context.Con
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 *models.Member
-		if args[2] != nil {
-			arg2 = args[2].(*models.Member)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_NamespaceCreateMembership_Call) Return(err error) *MockStore_NamespaceCreateMembership_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_InstallKeyResolveSystem_Call) Return(installKey *models.InstallKey, err error) *MockStore_InstallKeyResolveSystem_Call {
+	_c.Call.Return(installKey, err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceCreateMembership_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, member *models.Member) error) *MockStore_NamespaceCreateMembership_Call {
+func (_c *MockStore_InstallKeyResolveSystem_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope) (*models.InstallKey, error)) *MockStore_InstallKeyResolveSystem_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceDelete provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceDelete(ctx context.Context, namespace *models.Namespace) error {
-	ret := _mock.Called(ctx, namespace)
+// InstallKeyResolveSystemPairing provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyResolveSystemPairing(ctx context.Context, sc scope.Scope) (*models.InstallKey, error) {
+	ret := _mock.Called(ctx, sc)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceDelete")
+		panic("no return value specified for InstallKeyResolveSystemPairing")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Namespace) error); ok {
-		r0 = returnFunc(ctx, namespace)
+	var r0 *models.InstallKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) (*models.InstallKey, error)); ok {
+		return returnFunc(ctx, sc)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope) *models.InstallKey); ok {
+		r0 = returnFunc(ctx, sc)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InstallKey)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope) error); ok {
+		r1 = returnFunc(ctx, sc)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_NamespaceDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceDelete'
-type MockStore_NamespaceDelete_Call struct {
+// MockStore_InstallKeyResolveSystemPairing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyResolveSystemPairing'
+type MockStore_InstallKeyResolveSystemPairing_Call struct {
 	*mock.Call
 }
 
-// NamespaceDelete is a helper method to define mock.On call
+// InstallKeyResolveSystemPairing is a helper method to define mock.On call
 //   - ctx context.Context
-//   - namespace *models.Namespace
-func (_e *MockStore_Expecter) NamespaceDelete(ctx any, namespace any) *MockStore_NamespaceDelete_Call {
-	return &MockStore_NamespaceDelete_Call{Call: _e.mock.On("NamespaceDelete", ctx, namespace)}
+//   - sc scope.Scope
+func (_e *MockStore_Expecter) InstallKeyResolveSystemPairing(ctx any, sc any) *MockStore_InstallKeyResolveSystemPairing_Call {
+	return &MockStore_InstallKeyResolveSystemPairing_Call{Call: _e.mock.On("InstallKeyResolveSystemPairing", ctx, sc)}
 }
 
-func (_c *MockStore_NamespaceDelete_Call) Run(run func(ctx context.Context, namespace *models.Namespace)) *MockStore_NamespaceDelete_Call {
+func (_c *MockStore_InstallKeyResolveSystemPairing_Call) Run(run func(ctx context.Context, sc scope.Scope)) *MockStore_InstallKeyResolveSystemPairing_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Namespace
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(*models.Namespace)
+			arg1 = args[1].(scope.Scope)
 		}
 		run(
 			arg0,
@@ -3650,63 +3798,54 @@ func (_c *MockStore_NamespaceDelete_Call) Run(run func(ctx context.Context, name
 	return _c
 }
 
-func (_c *MockStore_NamespaceDelete_Call) Return(err error) *MockStore_NamespaceDelete_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_InstallKeyResolveSystemPairing_Call) Return(installKey *models.InstallKey, err error) *MockStore_InstallKeyResolveSystemPairing_Call {
+	_c.Call.Return(installKey, err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceDelete_Call) RunAndReturn(run func(ctx context.Context, namespace *models.Namespace) error) *MockStore_NamespaceDelete_Call {
+func (_c *MockStore_InstallKeyResolveSystemPairing_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope) (*models.InstallKey, error)) *MockStore_InstallKeyResolveSystemPairing_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceDeleteMany provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceDeleteMany(ctx context.Context, tenantIDs []string) (int64, error) {
-	ret := _mock.Called(ctx, tenantIDs)
+// InstallKeyUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) InstallKeyUpdate(ctx context.Context, installKey *models.InstallKey) error {
+	ret := _mock.Called(ctx, installKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceDeleteMany")
+		panic("no return value specified for InstallKeyUpdate")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
-		return returnFunc(ctx, tenantIDs)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
-		r0 = returnFunc(ctx, tenantIDs)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = returnFunc(ctx, tenantIDs)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.InstallKey) error); ok {
+		r0 = returnFunc(ctx, installKey)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_NamespaceDeleteMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceDeleteMany'
-type MockStore_NamespaceDeleteMany_Call struct {
+// MockStore_InstallKeyUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallKeyUpdate'
+type MockStore_InstallKeyUpdate_Call struct {
 	*mock.Call
 }
 
-// NamespaceDeleteMany is a helper method to define mock.On call
+// InstallKeyUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantIDs []string
-func (_e *MockStore_Expecter) NamespaceDeleteMany(ctx any, tenantIDs any) *MockStore_NamespaceDeleteMany_Call {
-	return &MockStore_NamespaceDeleteMany_Call{Call: _e.mock.On("NamespaceDeleteMany", ctx, tenantIDs)}
+//   - installKey *models.InstallKey
+func (_e *MockStore_Expecter) InstallKeyUpdate(ctx any, installKey any) *MockStore_InstallKeyUpdate_Call {
+	return &MockStore_InstallKeyUpdate_Call{Call: _e.mock.On("InstallKeyUpdate", ctx, installKey)}
 }
 
-func (_c *MockStore_NamespaceDeleteMany_Call) Run(run func(ctx context.Context, tenantIDs []string)) *MockStore_NamespaceDeleteMany_Call {
+func (_c *MockStore_InstallKeyUpdate_Call) Run(run func(ctx context.Context, installKey *models.InstallKey)) *MockStore_InstallKeyUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 *models.InstallKey
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(*models.InstallKey)
 		}
 		run(
 			arg0,
@@ -3716,126 +3855,111 @@ func (_c *MockStore_NamespaceDeleteMany_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockStore_NamespaceDeleteMany_Call) Return(n int64, err error) *MockStore_NamespaceDeleteMany_Call {
-	_c.Call.Return(n, err)
+func (_c *MockStore_InstallKeyUpdate_Call) Return(err error) *MockStore_InstallKeyUpdate_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceDeleteMany_Call) RunAndReturn(run func(ctx context.Context, tenantIDs []string) (int64, error)) *MockStore_NamespaceDeleteMany_Call {
+func (_c *MockStore_InstallKeyUpdate_Call) RunAndReturn(run func(ctx context.Context, installKey *models.InstallKey) error) *MockStore_InstallKeyUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceDeleteMembership provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceDeleteMembership(ctx context.Context, sc scope.Scope, member *models.Member) error {
-	ret := _mock.Called(ctx, sc, member)
+// MembershipInvitationCreate provides a mock function for the type MockStore
+func (_mock *MockStore) MembershipInvitationCreate(ctx context.Context, invitation *models.MembershipInvitation) error {
+	ret := _mock.Called(ctx, invitation)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceDeleteMembership")
+		panic("no return value specified for MembershipInvitationCreate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.Member) error); ok {
-		r0 = returnFunc(ctx, sc, member)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MembershipInvitation) error); ok {
+		r0 = returnFunc(ctx, invitation)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_NamespaceDeleteMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceDeleteMembership'
-type MockStore_NamespaceDeleteMembership_Call struct {
+// MockStore_MembershipInvitationCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationCreate'
+type MockStore_MembershipInvitationCreate_Call struct {
 	*mock.Call
 }
 
-// NamespaceDeleteMembership is a helper method to define mock.On call
+// MembershipInvitationCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - member *models.Member
-func (_e *MockStore_Expecter) NamespaceDeleteMembership(ctx any, sc any, member any) *MockStore_NamespaceDeleteMembership_Call {
-	return &MockStore_NamespaceDeleteMembership_Call{Call: _e.mock.On("NamespaceDeleteMembership", ctx, sc, member)}
+//   - invitation *models.MembershipInvitation
+func (_e *MockStore_Expecter) MembershipInvitationCreate(ctx any, invitation any) *MockStore_MembershipInvitationCreate_Call {
+	return &MockStore_MembershipInvitationCreate_Call{Call: _e.mock.On("MembershipInvitationCreate", ctx, invitation)}
 }
 
-func (_c *MockStore_NamespaceDeleteMembership_Call) Run(run func(ctx context.Context, sc scope.Scope, member *models.Member)) *MockStore_NamespaceDeleteMembership_Call {
+func (_c *MockStore_MembershipInvitationCreate_Call) Run(run func(ctx context.Context, invitation *models.MembershipInvitation)) *MockStore_MembershipInvitationCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 *models.MembershipInvitation
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
-		}
-		var arg2 *models.Member
-		if args[2] != nil {
-			arg2 = args[2].(*models.Member)
+			arg1 = args[1].(*models.MembershipInvitation)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_NamespaceDeleteMembership_Call) Return(err error) *MockStore_NamespaceDeleteMembership_Call {
+func (_c *MockStore_MembershipInvitationCreate_Call) Return(err error) *MockStore_MembershipInvitationCreate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceDeleteMembership_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, member *models.Member) error) *MockStore_NamespaceDeleteMembership_Call {
+func (_c *MockStore_MembershipInvitationCreate_Call) RunAndReturn(run func(ctx context.Context, invitation *models.MembershipInvitation) error) *MockStore_MembershipInvitationCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceGetDeviceLimit provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceGetDeviceLimit(ctx context.Context, tenantID string) (models.NamespaceDeviceLimit, error) {
-	ret := _mock.Called(ctx, tenantID)
+// MembershipInvitationDelete provides a mock function for the type MockStore
+func (_mock *MockStore) MembershipInvitationDelete(ctx context.Context, invitation *models.MembershipInvitation) error {
+	ret := _mock.Called(ctx, invitation)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceGetDeviceLimit")
+		panic("no return value specified for MembershipInvitationDelete")
 	}
 
-	var r0 models.NamespaceDeviceLimit
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.NamespaceDeviceLimit, error)); ok {
-		return returnFunc(ctx, tenantID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.NamespaceDeviceLimit); ok {
-		r0 = returnFunc(ctx, tenantID)
-	} else {
-		r0 = ret.Get(0).(models.NamespaceDeviceLimit)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, tenantID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MembershipInvitation) error); ok {
+		r0 = returnFunc(ctx, invitation)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_NamespaceGetDeviceLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceGetDeviceLimit'
-type MockStore_NamespaceGetDeviceLimit_Call struct {
+// MockStore_MembershipInvitationDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationDelete'
+type MockStore_MembershipInvitationDelete_Call struct {
 	*mock.Call
 }
 
-// NamespaceGetDeviceLimit is a helper method to define mock.On call
+// MembershipInvitationDelete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantID string
-func (_e *MockStore_Expecter) NamespaceGetDeviceLimit(ctx any, tenantID any) *MockStore_NamespaceGetDeviceLimit_Call {
-	return &MockStore_NamespaceGetDeviceLimit_Call{Call: _e.mock.On("NamespaceGetDeviceLimit", ctx, tenantID)}
+//   - invitation *models.MembershipInvitation
+func (_e *MockStore_Expecter) MembershipInvitationDelete(ctx any, invitation any) *MockStore_MembershipInvitationDelete_Call {
+	return &MockStore_MembershipInvitationDelete_Call{Call: _e.mock.On("MembershipInvitationDelete", ctx, invitation)}
 }
 
-func (_c *MockStore_NamespaceGetDeviceLimit_Call) Run(run func(ctx context.Context, tenantID string)) *MockStore_NamespaceGetDeviceLimit_Call {
+func (_c *MockStore_MembershipInvitationDelete_Call) Run(run func(ctx context.Context, invitation *models.MembershipInvitation)) *MockStore_MembershipInvitationDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *models.MembershipInvitation
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*models.MembershipInvitation)
 		}
 		run(
 			arg0,
@@ -3845,71 +3969,58 @@ func (_c *MockStore_NamespaceGetDeviceLimit_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockStore_NamespaceGetDeviceLimit_Call) Return(namespaceDeviceLimit models.NamespaceDeviceLimit, err error) *MockStore_NamespaceGetDeviceLimit_Call {
-	_c.Call.Return(namespaceDeviceLimit, err)
+func (_c *MockStore_MembershipInvitationDelete_Call) Return(err error) *MockStore_MembershipInvitationDelete_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceGetDeviceLimit_Call) RunAndReturn(run func(ctx context.Context, tenantID string) (models.NamespaceDeviceLimit, error)) *MockStore_NamespaceGetDeviceLimit_Call {
+func (_c *MockStore_MembershipInvitationDelete_Call) RunAndReturn(run func(ctx context.Context, invitation *models.MembershipInvitation) error) *MockStore_MembershipInvitationDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceGetMembers provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceGetMembers(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.MemberView, int, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, sc)
-	}
-	ret := tmpRet
+// MembershipInvitationResolve provides a mock function for the type MockStore
+func (_mock *MockStore) MembershipInvitationResolve(ctx context.Context, sc scope.Scope, userID string) (*models.MembershipInvitation, error) {
+	ret := _mock.Called(ctx, sc, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceGetMembers")
+		panic("no return value specified for MembershipInvitationResolve")
 	}
 
-	var r0 []models.MemberView
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.MemberView, int, error)); ok {
-		return returnFunc(ctx, sc, opts...)
+	var r0 *models.MembershipInvitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) (*models.MembershipInvitation, error)); ok {
+		return returnFunc(ctx, sc, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.MemberView); ok {
-		r0 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) *models.MembershipInvitation); ok {
+		r0 = returnFunc(ctx, sc, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.MemberView)
+			r0 = ret.Get(0).(*models.MembershipInvitation)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, sc, opts...)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, string) error); ok {
+		r1 = returnFunc(ctx, sc, userID)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_NamespaceGetMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceGetMembers'
-type MockStore_NamespaceGetMembers_Call struct {
+// MockStore_MembershipInvitationResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationResolve'
+type MockStore_MembershipInvitationResolve_Call struct {
 	*mock.Call
 }
 
-// NamespaceGetMembers is a helper method to define mock.On call
+// MembershipInvitationResolve is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) NamespaceGetMembers(ctx any, sc any, opts ...any) *MockStore_NamespaceGetMembers_Call {
-	return &MockStore_NamespaceGetMembers_Call{Call: _e.mock.On("NamespaceGetMembers",
-		append([]any{ctx, sc}, opts...)...)}
-}
+//   - userID string
+func (_e *MockStore_Expecter) MembershipInvitationResolve(ctx any, sc any, userID any) *MockStore_MembershipInvitationResolve_Call {
+	return &MockStore_MembershipInvitationResolve_Call{Call: _e.mock.On("MembershipInvitationResolve", ctx, sc, userID)}
+}
 
-func (_c *MockStore_NamespaceGetMembers_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_NamespaceGetMembers_Call {
+func (_c *MockStore_MembershipInvitationResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, userID string)) *MockStore_MembershipInvitationResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3919,72 +4030,70 @@ func (_c *MockStore_NamespaceGetMembers_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 2 {
-			variadicArgs = args[2].([]store.QueryOption)
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_NamespaceGetMembers_Call) Return(memberViews []models.MemberView, n int, err error) *MockStore_NamespaceGetMembers_Call {
-	_c.Call.Return(memberViews, n, err)
+func (_c *MockStore_MembershipInvitationResolve_Call) Return(membershipInvitation *models.MembershipInvitation, err error) *MockStore_MembershipInvitationResolve_Call {
+	_c.Call.Return(membershipInvitation, err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceGetMembers_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.MemberView, int, error)) *MockStore_NamespaceGetMembers_Call {
+func (_c *MockStore_MembershipInvitationResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, userID string) (*models.MembershipInvitation, error)) *MockStore_MembershipInvitationResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceGetPreferred provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceGetPreferred(ctx context.Context, userID string) (*models.Namespace, error) {
-	ret := _mock.Called(ctx, userID)
+// MembershipInvitationResolveBySig provides a mock function for the type MockStore
+func (_mock *MockStore) MembershipInvitationResolveBySig(ctx context.Context, sig string) (*models.MembershipInvitation, error) {
+	ret := _mock.Called(ctx, sig)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceGetPreferred")
+		panic("no return value specified for MembershipInvitationResolveBySig")
 	}
 
-	var r0 *models.Namespace
+	var r0 *models.MembershipInvitation
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.Namespace, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.MembershipInvitation, error)); ok {
+		return returnFunc(ctx, sig)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.Namespace); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.MembershipInvitation); ok {
+		r0 = returnFunc(ctx, sig)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Namespace)
+			r0 = ret.Get(0).(*models.MembershipInvitation)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+		r1 = returnFunc(ctx, sig)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_NamespaceGetPreferred_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceGetPreferred'
-type MockStore_NamespaceGetPreferred_Call struct {
+// MockStore_MembershipInvitationResolveBySig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationResolveBySig'
+type MockStore_MembershipInvitationResolveBySig_Call struct {
 	*mock.Call
 }
 
-// NamespaceGetPreferred is a helper method to define mock.On call
+// MembershipInvitationResolveBySig is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockStore_Expecter) NamespaceGetPreferred(ctx any, userID any) *MockStore_NamespaceGetPreferred_Call {
-	return &MockStore_NamespaceGetPreferred_Call{Call: _e.mock.On("NamespaceGetPreferred", ctx, userID)}
+//   - sig string
+func (_e *MockStore_Expecter) MembershipInvitationResolveBySig(ctx any, sig any) *MockStore_MembershipInvitationResolveBySig_Call {
+	return &MockStore_MembershipInvitationResolveBySig_Call{Call: _e.mock.On("MembershipInvitationResolveBySig", ctx, sig)}
 }
 
-func (_c *MockStore_NamespaceGetPreferred_Call) Run(run func(ctx context.Context, userID string)) *MockStore_NamespaceGetPreferred_Call {
+func (_c *MockStore_MembershipInvitationResolveBySig_Call) Run(run func(ctx context.Context, sig string)) *MockStore_MembershipInvitationResolveBySig_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4002,311 +4111,256 @@ func (_c *MockStore_NamespaceGetPreferred_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockStore_NamespaceGetPreferred_Call) Return(namespace *models.Namespace, err error) *MockStore_NamespaceGetPreferred_Call {
-	_c.Call.Return(namespace, err)
+func (_c *MockStore_MembershipInvitationResolveBySig_Call) Return(membershipInvitation *models.MembershipInvitation, err error) *MockStore_MembershipInvitationResolveBySig_Call {
+	_c.Call.Return(membershipInvitation, err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceGetPreferred_Call) RunAndReturn(run func(ctx context.Context, userID string) (*models.Namespace, error)) *MockStore_NamespaceGetPreferred_Call {
+func (_c *MockStore_MembershipInvitationResolveBySig_Call) RunAndReturn(run func(ctx context.Context, sig string) (*models.MembershipInvitation, error)) *MockStore_MembershipInvitationResolveBySig_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceIncrementDeviceCount provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceIncrementDeviceCount(ctx context.Context, sc scope.Scope, status models.DeviceStatus, count int64) error {
-	ret := _mock.Called(ctx, sc, status, count)
+// MembershipInvitationUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) MembershipInvitationUpdate(ctx context.Context, invitation *models.MembershipInvitation) error {
+	ret := _mock.Called(ctx, invitation)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceIncrementDeviceCount")
+		panic("no return value specified for MembershipInvitationUpdate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, models.DeviceStatus, int64) error); ok {
-		r0 = returnFunc(ctx, sc, status, count)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MembershipInvitation) error); ok {
+		r0 = returnFunc(ctx, invitation)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_NamespaceIncrementDeviceCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceIncrementDeviceCount'
-type MockStore_NamespaceIncrementDeviceCount_Call struct {
+// MockStore_MembershipInvitationUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MembershipInvitationUpdate'
+type MockStore_MembershipInvitationUpdate_Call struct {
 	*mock.Call
 }
 
-// NamespaceIncrementDeviceCount is a helper method to define mock.On call
+// MembershipInvitationUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - status models.DeviceStatus
-//   - count int64
-func (_e *MockStore_Expecter) NamespaceIncrementDeviceCount(ctx any, sc any, status any, count any) *MockStore_NamespaceIncrementDeviceCount_Call {
-	return &MockStore_NamespaceIncrementDeviceCount_Call{Call: _e.mock.On("NamespaceIncrementDeviceCount", ctx, sc, status, count)}
+//   - invitation *models.MembershipInvitation
+func (_e *MockStore_Expecter) MembershipInvitationUpdate(ctx any, invitation any) *MockStore_MembershipInvitationUpdate_Call {
+	return &MockStore_MembershipInvitationUpdate_Call{Call: _e.mock.On("MembershipInvitationUpdate", ctx, invitation)}
 }
 
-func (_c *MockStore_NamespaceIncrementDeviceCount_Call) Run(run func(ctx context.Context, sc scope.Scope, status models.DeviceStatus, count int64)) *MockStore_NamespaceIncrementDeviceCount_Call {
+func (_c *MockStore_MembershipInvitationUpdate_Call) Run(run func(ctx context.Context, invitation *models.MembershipInvitation)) *MockStore_MembershipInvitationUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 *models.MembershipInvitation
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
-		}
-		var arg2 models.DeviceStatus
-		if args[2] != nil {
-			arg2 = args[2].(models.DeviceStatus)
-		}
-		var arg3 int64
-		if args[3] != nil {
-			arg3 = args[3].(int64)
+			arg1 = args[1].(*models.MembershipInvitation)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_NamespaceIncrementDeviceCount_Call) Return(err error) *MockStore_NamespaceIncrementDeviceCount_Call {
+func (_c *MockStore_MembershipInvitationUpdate_Call) Return(err error) *MockStore_MembershipInvitationUpdate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceIncrementDeviceCount_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, status models.DeviceStatus, count int64) error) *MockStore_NamespaceIncrementDeviceCount_Call {
+func (_c *MockStore_MembershipInvitationUpdate_Call) RunAndReturn(run func(ctx context.Context, invitation *models.MembershipInvitation) error) *MockStore_MembershipInvitationUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceList provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceList(ctx context.Context, opts ...store.QueryOption) ([]models.Namespace, int, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, opts)
-	} else {
-		tmpRet = _mock.Called(ctx)
-	}
-	ret := tmpRet
+// NamespaceConflicts provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceConflicts(ctx context.Context, target *models.NamespaceConflicts) ([]string, bool, error) {
+	ret := _mock.Called(ctx, target)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceList")
+		panic("no return value specified for NamespaceConflicts")
 	}
 
-	var r0 []models.Namespace
-	var r1 int
+	var r0 []string
+	var r1 bool
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) ([]models.Namespace, int, error)); ok {
-		return returnFunc(ctx, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.NamespaceConflicts) ([]string, bool, error)); ok {
+		return returnFunc(ctx, target)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []models.Namespace); ok {
-		r0 = returnFunc(ctx, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.NamespaceConflicts) []string); ok {
+		r0 = returnFunc(ctx, target)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Namespace)
+			r0 = ret.Get(0).([]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.NamespaceConflicts) bool); ok {
+		r1 = returnFunc(ctx, target)
 	} else {
-		r1 = ret.Get(1).(int)
+		r1 = ret.Get(1).(bool)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, opts...)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *models.NamespaceConflicts) error); ok {
+		r2 = returnFunc(ctx, target)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockStore_NamespaceList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceList'
-type MockStore_NamespaceList_Call struct {
+// MockStore_NamespaceConflicts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceConflicts'
+type MockStore_NamespaceConflicts_Call struct {
 	*mock.Call
 }
 
-// NamespaceList is a helper method to define mock.On call
+// NamespaceConflicts is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) NamespaceList(ctx any, opts ...any) *MockStore_NamespaceList_Call {
-	return &MockStore_NamespaceList_Call{Call: _e.mock.On("NamespaceList",
-		append([]any{ctx}, opts...)...)}
+//   - target *models.NamespaceConflicts
+func (_e *MockStore_Expecter) NamespaceConflicts(ctx any, target any) *MockStore_NamespaceConflicts_Call {
+	return &MockStore_NamespaceConflicts_Call{Call: _e.mock.On("NamespaceConflicts", ctx, target)}
 }
 
-func (_c *MockStore_NamespaceList_Call) Run(run func(ctx context.Context, opts ...store.QueryOption)) *MockStore_NamespaceList_Call {
+func (_c *MockStore_NamespaceConflicts_Call) Run(run func(ctx context.Context, target *models.NamespaceConflicts)) *MockStore_NamespaceConflicts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 1 {
-			variadicArgs = args[1].([]store.QueryOption)
+		var arg1 *models.NamespaceConflicts
+		if args[1] != nil {
+			arg1 = args[1].(*models.NamespaceConflicts)
 		}
-		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_NamespaceList_Call) Return(namespaces []models.Namespace, n int, err error) *MockStore_NamespaceList_Call {
-	_c.Call.Return(namespaces, n, err)
+func (_c *MockStore_NamespaceConflicts_Call) Return(conflicts []string, has bool, err error) *MockStore_NamespaceConflicts_Call {
+	_c.Call.Return(conflicts, has, err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceList_Call) RunAndReturn(run func(ctx context.Context, opts ...store.QueryOption) ([]models.Namespace, int, error)) *MockStore_NamespaceList_Call {
+func (_c *MockStore_NamespaceConflicts_Call) RunAndReturn(run func(ctx context.Context, target *models.NamespaceConflicts) ([]string, bool, error)) *MockStore_NamespaceConflicts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceMembershipInvitationList provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceMembershipInvitationList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.MembershipInvitation, int64, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, sc)
-	}
-	ret := tmpRet
+// NamespaceCreate provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceCreate(ctx context.Context, namespace *models.Namespace) (string, error) {
+	ret := _mock.Called(ctx, namespace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceMembershipInvitationList")
+		panic("no return value specified for NamespaceCreate")
 	}
 
-	var r0 []models.MembershipInvitation
-	var r1 int64
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.MembershipInvitation, int64, error)); ok {
-		return returnFunc(ctx, sc, opts...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.MembershipInvitation); ok {
-		r0 = returnFunc(ctx, sc, opts...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.MembershipInvitation)
-		}
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Namespace) (string, error)); ok {
+		return returnFunc(ctx, namespace)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int64); ok {
-		r1 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Namespace) string); ok {
+		r0 = returnFunc(ctx, namespace)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.Namespace) error); ok {
+		r1 = returnFunc(ctx, namespace)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_NamespaceMembershipInvitationList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceMembershipInvitationList'
-type MockStore_NamespaceMembershipInvitationList_Call struct {
+// MockStore_NamespaceCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceCreate'
+type MockStore_NamespaceCreate_Call struct {
 	*mock.Call
 }
 
-// NamespaceMembershipInvitationList is a helper method to define mock.On call
+// NamespaceCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) NamespaceMembershipInvitationList(ctx any, sc any, opts ...any) *MockStore_NamespaceMembershipInvitationList_Call {
-	return &MockStore_NamespaceMembershipInvitationList_Call{Call: _e.mock.On("NamespaceMembershipInvitationList",
-		append([]any{ctx, sc}, opts...)...)}
+//   - namespace *models.Namespace
+func (_e *MockStore_Expecter) NamespaceCreate(ctx any, namespace any) *MockStore_NamespaceCreate_Call {
+	return &MockStore_NamespaceCreate_Call{Call: _e.mock.On("NamespaceCreate", ctx, namespace)}
 }
 
-func (_c *MockStore_NamespaceMembershipInvitationList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_NamespaceMembershipInvitationList_Call {
+func (_c *MockStore_NamespaceCreate_Call) Run(run func(ctx context.Context, namespace *models.Namespace)) *MockStore_NamespaceCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 *models.Namespace
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
-		}
-		var arg2 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 2 {
-			variadicArgs = args[2].([]store.QueryOption)
+			arg1 = args[1].(*models.Namespace)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_NamespaceMembershipInvitationList_Call) Return(membershipInvitations []models.MembershipInvitation, n int64, err error) *MockStore_NamespaceMembershipInvitationList_Call {
-	_c.Call.Return(membershipInvitations, n, err)
+func (_c *MockStore_NamespaceCreate_Call) Return(s string, err error) *MockStore_NamespaceCreate_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceMembershipInvitationList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.MembershipInvitation, int64, error)) *MockStore_NamespaceMembershipInvitationList_Call {
+func (_c *MockStore_NamespaceCreate_Call) RunAndReturn(run func(ctx context.Context, namespace *models.Namespace) (string, error)) *MockStore_NamespaceCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceResolve provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceResolve(ctx context.Context, resolver store.NamespaceResolver, value string) (*models.Namespace, error) {
-	ret := _mock.Called(ctx, resolver, value)
+// NamespaceCreateMembership provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceCreateMembership(ctx context.Context, sc scope.Scope, member *models.Member) error {
+	ret := _mock.Called(ctx, sc, member)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceResolve")
+		panic("no return value specified for NamespaceCreateMembership")
 	}
 
-	var r0 *models.Namespace
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, store.NamespaceResolver, string) (*models.Namespace, error)); ok {
-		return returnFunc(ctx, resolver, value)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, store.NamespaceResolver, string) *models.Namespace); ok {
-		r0 = returnFunc(ctx, resolver, value)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Namespace)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, store.NamespaceResolver, string) error); ok {
-		r1 = returnFunc(ctx, resolver, value)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.Member) error); ok {
+		r0 = returnFunc(ctx, sc, member)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_NamespaceResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceResolve'
-type MockStore_NamespaceResolve_Call struct {
+// MockStore_NamespaceCreateMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceCreateMembership'
+type MockStore_NamespaceCreateMembership_Call struct {
 	*mock.Call
 }
 
-// NamespaceResolve is a helper method to define mock.On call
+// NamespaceCreateMembership is a helper method to define mock.On call
 //   - ctx context.Context
-//   - resolver store.NamespaceResolver
-//   - value string
-func (_e *MockStore_Expecter) NamespaceResolve(ctx any, resolver any, value any) *MockStore_NamespaceResolve_Call {
-	return &MockStore_NamespaceResolve_Call{Call: _e.mock.On("NamespaceResolve", ctx, resolver, value)}
+//   - sc scope.Scope
+//   - member *models.Member
+func (_e *MockStore_Expecter) NamespaceCreateMembership(ctx any, sc any, member any) *MockStore_NamespaceCreateMembership_Call {
+	return &MockStore_NamespaceCreateMembership_Call{Call: _e.mock.On("NamespaceCreateMembership", ctx, sc, member)}
 }
 
-func (_c *MockStore_NamespaceResolve_Call) Run(run func(ctx context.Context, resolver store.NamespaceResolver, value string)) *MockStore_NamespaceResolve_Call {
+func (_c *MockStore_NamespaceCreateMembership_Call) Run(run func(ctx context.Context, sc scope.Scope, member *models.Member)) *MockStore_NamespaceCreateMembership_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 store.NamespaceResolver
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(store.NamespaceResolver)
+			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 string
+		var arg2 *models.Member
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*models.Member)
 		}
 		run(
 			arg0,
@@ -4317,105 +4371,120 @@ func (_c *MockStore_NamespaceResolve_Call) Run(run func(ctx context.Context, res
 	return _c
 }
 
-func (_c *MockStore_NamespaceResolve_Call) Return(namespace *models.Namespace, err error) *MockStore_NamespaceResolve_Call {
-	_c.Call.Return(namespace, err)
+func (_c *MockStore_NamespaceCreateMembership_Call) Return(err error) *MockStore_NamespaceCreateMembership_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceResolve_Call) RunAndReturn(run func(ctx context.Context, resolver store.NamespaceResolver, value string) (*models.Namespace, error)) *MockStore_NamespaceResolve_Call {
+func (_c *MockStore_NamespaceCreateMembership_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, member *models.Member) error) *MockStore_NamespaceCreateMembership_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceSyncDeviceCounts provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceSyncDeviceCounts(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// NamespaceDelete provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceDelete(ctx context.Context, namespace *models.Namespace) error {
+	ret := _mock.Called(ctx, namespace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceSyncDeviceCounts")
+		panic("no return value specified for NamespaceDelete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Namespace) error); ok {
+		r0 = returnFunc(ctx, namespace)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_NamespaceSyncDeviceCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceSyncDeviceCounts'
-type MockStore_NamespaceSyncDeviceCounts_Call struct {
+// MockStore_NamespaceDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceDelete'
+type MockStore_NamespaceDelete_Call struct {
 	*mock.Call
 }
 
-// NamespaceSyncDeviceCounts is a helper method to define mock.On call
+// NamespaceDelete is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) NamespaceSyncDeviceCounts(ctx any) *MockStore_NamespaceSyncDeviceCounts_Call {
-	return &MockStore_NamespaceSyncDeviceCounts_Call{Call: _e.mock.On("NamespaceSyncDeviceCounts", ctx)}
+//   - namespace *models.Namespace
+func (_e *MockStore_Expecter) NamespaceDelete(ctx any, namespace any) *MockStore_NamespaceDelete_Call {
+	return &MockStore_NamespaceDelete_Call{Call: _e.mock.On("NamespaceDelete", ctx, namespace)}
 }
 
-func (_c *MockStore_NamespaceSyncDeviceCounts_Call) Run(run func(ctx context.Context)) *MockStore_NamespaceSyncDeviceCounts_Call {
+func (_c *MockStore_NamespaceDelete_Call) Run(run func(ctx context.Context, namespace *models.Namespace)) *MockStore_NamespaceDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 *models.Namespace
+		if args[1] != nil {
+			arg1 = args[1].(*models.Namespace)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_NamespaceSyncDeviceCounts_Call) Return(err error) *MockStore_NamespaceSyncDeviceCounts_Call {
+func (_c *MockStore_NamespaceDelete_Call) Return(err error) *MockStore_NamespaceDelete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceSyncDeviceCounts_Call) RunAndReturn(run func(ctx context.Context) error) *MockStore_NamespaceSyncDeviceCounts_Call {
+func (_c *MockStore_NamespaceDelete_Call) RunAndReturn(run func(ctx context.Context, namespace *models.Namespace) error) *MockStore_NamespaceDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceUpdate(ctx context.Context, namespace *models.Namespace) error {
-	ret := _mock.Called(ctx, namespace)
+// NamespaceDeleteMany provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceDeleteMany(ctx context.Context, tenantIDs []string) (int64, error) {
+	ret := _mock.Called(ctx, tenantIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceUpdate")
+		panic("no return value specified for NamespaceDeleteMany")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Namespace) error); ok {
-		r0 = returnFunc(ctx, namespace)
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
+		return returnFunc(ctx, tenantIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
+		r0 = returnFunc(ctx, tenantIDs)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, tenantIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_NamespaceUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceUpdate'
-type MockStore_NamespaceUpdate_Call struct {
+// MockStore_NamespaceDeleteMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceDeleteMany'
+type MockStore_NamespaceDeleteMany_Call struct {
 	*mock.Call
 }
 
-// NamespaceUpdate is a helper method to define mock.On call
+// NamespaceDeleteMany is a helper method to define mock.On call
 //   - ctx context.Context
-//   - namespace *models.Namespace
-func (_e *MockStore_Expecter) NamespaceUpdate(ctx any, namespace any) *MockStore_NamespaceUpdate_Call {
-	return &MockStore_NamespaceUpdate_Call{Call: _e.mock.On("NamespaceUpdate", ctx, namespace)}
+//   - tenantIDs []string
+func (_e *MockStore_Expecter) NamespaceDeleteMany(ctx any, tenantIDs any) *MockStore_NamespaceDeleteMany_Call {
+	return &MockStore_NamespaceDeleteMany_Call{Call: _e.mock.On("NamespaceDeleteMany", ctx, tenantIDs)}
 }
 
-func (_c *MockStore_NamespaceUpdate_Call) Run(run func(ctx context.Context, namespace *models.Namespace)) *MockStore_NamespaceUpdate_Call {
+func (_c *MockStore_NamespaceDeleteMany_Call) Run(run func(ctx context.Context, tenantIDs []string)) *MockStore_NamespaceDeleteMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Namespace
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(*models.Namespace)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -4425,22 +4494,22 @@ func (_c *MockStore_NamespaceUpdate_Call) Run(run func(ctx context.Context, name
 	return _c
 }
 
-func (_c *MockStore_NamespaceUpdate_Call) Return(err error) *MockStore_NamespaceUpdate_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_NamespaceDeleteMany_Call) Return(n int64, err error) *MockStore_NamespaceDeleteMany_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceUpdate_Call) RunAndReturn(run func(ctx context.Context, namespace *models.Namespace) error) *MockStore_NamespaceUpdate_Call {
+func (_c *MockStore_NamespaceDeleteMany_Call) RunAndReturn(run func(ctx context.Context, tenantIDs []string) (int64, error)) *MockStore_NamespaceDeleteMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NamespaceUpdateMembership provides a mock function for the type MockStore
-func (_mock *MockStore) NamespaceUpdateMembership(ctx context.Context, sc scope.Scope, member *models.Member) error {
+// NamespaceDeleteMembership provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceDeleteMembership(ctx context.Context, sc scope.Scope, member *models.Member) error {
 	ret := _mock.Called(ctx, sc, member)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NamespaceUpdateMembership")
+		panic("no return value specified for NamespaceDeleteMembership")
 	}
 
 	var r0 error
@@ -4452,20 +4521,20 @@ func (_mock *MockStore) NamespaceUpdateMembership(ctx context.Context, sc scope.
 	return r0
 }
 
-// MockStore_NamespaceUpdateMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceUpdateMembership'
-type MockStore_NamespaceUpdateMembership_Call struct {
+// MockStore_NamespaceDeleteMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceDeleteMembership'
+type MockStore_NamespaceDeleteMembership_Call struct {
 	*mock.Call
 }
 
-// NamespaceUpdateMembership is a helper method to define mock.On call
+// NamespaceDeleteMembership is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
 //   - member *models.Member
-func (_e *MockStore_Expecter) NamespaceUpdateMembership(ctx any, sc any, member any) *MockStore_NamespaceUpdateMembership_Call {
-	return &MockStore_NamespaceUpdateMembership_Call{Call: _e.mock.On("NamespaceUpdateMembership", ctx, sc, member)}
+func (_e *MockStore_Expecter) NamespaceDeleteMembership(ctx any, sc any, member any) *MockStore_NamespaceDeleteMembership_Call {
+	return &MockStore_NamespaceDeleteMembership_Call{Call: _e.mock.On("NamespaceDeleteMembership", ctx, sc, member)}
 }
 
-func (_c *MockStore_NamespaceUpdateMembership_Call) Run(run func(ctx context.Context, sc scope.Scope, member *models.Member)) *MockStore_NamespaceUpdateMembership_Call {
+func (_c *MockStore_NamespaceDeleteMembership_Call) Run(run func(ctx context.Context, sc scope.Scope, member *models.Member)) *MockStore_NamespaceDeleteMembership_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4488,100 +4557,63 @@ func (_c *MockStore_NamespaceUpdateMembership_Call) Run(run func(ctx context.Con
 	return _c
 }
 
-func (_c *MockStore_NamespaceUpdateMembership_Call) Return(err error) *MockStore_NamespaceUpdateMembership_Call {
+func (_c *MockStore_NamespaceDeleteMembership_Call) Return(err error) *MockStore_NamespaceDeleteMembership_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_NamespaceUpdateMembership_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, member *models.Member) error) *MockStore_NamespaceUpdateMembership_Call {
+func (_c *MockStore_NamespaceDeleteMembership_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, member *models.Member) error) *MockStore_NamespaceDeleteMembership_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Options provides a mock function for the type MockStore
-func (_mock *MockStore) Options() store.QueryOptions {
-	ret := _mock.Called()
+// NamespaceGetDeviceLimit provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceGetDeviceLimit(ctx context.Context, tenantID string) (models.NamespaceDeviceLimit, error) {
+	ret := _mock.Called(ctx, tenantID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Options")
+		panic("no return value specified for NamespaceGetDeviceLimit")
 	}
 
-	var r0 store.QueryOptions
-	if returnFunc, ok := ret.Get(0).(func() store.QueryOptions); ok {
-		r0 = returnFunc()
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(store.QueryOptions)
-		}
+	var r0 models.NamespaceDeviceLimit
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.NamespaceDeviceLimit, error)); ok {
+		return returnFunc(ctx, tenantID)
 	}
-	return r0
-}
-
-// MockStore_Options_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Options'
-type MockStore_Options_Call struct {
-	*mock.Call
-}
-
-// Options is a helper method to define mock.On call
-func (_e *MockStore_Expecter) Options() *MockStore_Options_Call {
-	return &MockStore_Options_Call{Call: _e.mock.On("Options")}
-}
-
-func (_c *MockStore_Options_Call) Run(run func()) *MockStore_Options_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run()
-	})
-	return _c
-}
-
-func (_c *MockStore_Options_Call) Return(queryOptions store.QueryOptions) *MockStore_Options_Call {
-	_c.Call.Return(queryOptions)
-	return _c
-}
-
-func (_c *MockStore_Options_Call) RunAndReturn(run func() store.QueryOptions) *MockStore_Options_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// PrivateKeyCreate provides a mock function for the type MockStore
-func (_mock *MockStore) PrivateKeyCreate(ctx context.Context, key *models.PrivateKey) error {
-	ret := _mock.Called(ctx, key)
-
-	if len(ret) == 0 {
-		panic("no return value specified for PrivateKeyCreate")
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.NamespaceDeviceLimit); ok {
+		r0 = returnFunc(ctx, tenantID)
+	} else {
+		r0 = ret.Get(0).(models.NamespaceDeviceLimit)
 	}
-
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PrivateKey) error); ok {
-		r0 = returnFunc(ctx, key)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tenantID)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
-	return r0
+	return r0, r1
 }
 
-// MockStore_PrivateKeyCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrivateKeyCreate'
-type MockStore_PrivateKeyCreate_Call struct {
+// MockStore_NamespaceGetDeviceLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceGetDeviceLimit'
+type MockStore_NamespaceGetDeviceLimit_Call struct {
 	*mock.Call
 }
 
-// PrivateKeyCreate is a helper method to define mock.On call
+// NamespaceGetDeviceLimit is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key *models.PrivateKey
-func (_e *MockStore_Expecter) PrivateKeyCreate(ctx any, key any) *MockStore_PrivateKeyCreate_Call {
-	return &MockStore_PrivateKeyCreate_Call{Call: _e.mock.On("PrivateKeyCreate", ctx, key)}
+//   - tenantID string
+func (_e *MockStore_Expecter) NamespaceGetDeviceLimit(ctx any, tenantID any) *MockStore_NamespaceGetDeviceLimit_Call {
+	return &MockStore_NamespaceGetDeviceLimit_Call{Call: _e.mock.On("NamespaceGetDeviceLimit", ctx, tenantID)}
 }
 
-func (_c *MockStore_PrivateKeyCreate_Call) Run(run func(ctx context.Context, key *models.PrivateKey)) *MockStore_PrivateKeyCreate_Call {
+func (_c *MockStore_NamespaceGetDeviceLimit_Call) Run(run func(ctx context.Context, tenantID string)) *MockStore_NamespaceGetDeviceLimit_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.PrivateKey
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*models.PrivateKey)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -4591,131 +4623,154 @@ func (_c *MockStore_PrivateKeyCreate_Call) Run(run func(ctx context.Context, key
 	return _c
 }
 
-func (_c *MockStore_PrivateKeyCreate_Call) Return(err error) *MockStore_PrivateKeyCreate_Call {
-	_c.Call.Return(err)
-	return _c
+func (_c *MockStore_NamespaceGetDeviceLimit_Call) Return(namespaceDeviceLimit models.NamespaceDeviceLimit, err error) *MockStore_NamespaceGetDeviceLimit_Call {
+	_c.Call.Return(namespaceDeviceLimit, err)
+	return _c
 }
 
-func (_c *MockStore_PrivateKeyCreate_Call) RunAndReturn(run func(ctx context.Context, key *models.PrivateKey) error) *MockStore_PrivateKeyCreate_Call {
+func (_c *MockStore_NamespaceGetDeviceLimit_Call) RunAndReturn(run func(ctx context.Context, tenantID string) (models.NamespaceDeviceLimit, error)) *MockStore_NamespaceGetDeviceLimit_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PrivateKeyGet provides a mock function for the type MockStore
-func (_mock *MockStore) PrivateKeyGet(ctx context.Context, fingerprint string) (*models.PrivateKey, error) {
-	ret := _mock.Called(ctx, fingerprint)
+// NamespaceGetMembers provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceGetMembers(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.MemberView, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for PrivateKeyGet")
+		panic("no return value specified for NamespaceGetMembers")
 	}
 
-	var r0 *models.PrivateKey
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.PrivateKey, error)); ok {
-		return returnFunc(ctx, fingerprint)
+	var r0 []models.MemberView
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.MemberView, int, error)); ok {
+		return returnFunc(ctx, sc, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.PrivateKey); ok {
-		r0 = returnFunc(ctx, fingerprint)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.MemberView); ok {
+		r0 = returnFunc(ctx, sc, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.PrivateKey)
+			r0 = ret.Get(0).([]models.MemberView)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, fingerprint)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, opts...)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_PrivateKeyGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrivateKeyGet'
-type MockStore_PrivateKeyGet_Call struct {
+// MockStore_NamespaceGetMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceGetMembers'
+type MockStore_NamespaceGetMembers_Call struct {
 	*mock.Call
 }
 
-// PrivateKeyGet is a helper method to define mock.On call
+// NamespaceGetMembers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - fingerprint string
-func (_e *MockStore_Expecter) PrivateKeyGet(ctx any, fingerprint any) *MockStore_PrivateKeyGet_Call {
-	return &MockStore_PrivateKeyGet_Call{Call: _e.mock.On("PrivateKeyGet", ctx, fingerprint)}
+//   - sc scope.Scope
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) NamespaceGetMembers(ctx any, sc any, opts ...any) *MockStore_NamespaceGetMembers_Call {
+	return &MockStore_NamespaceGetMembers_Call{Call: _e.mock.On("NamespaceGetMembers",
+		append([]any{ctx, sc}, opts...)...)}
 }
 
-func (_c *MockStore_PrivateKeyGet_Call) Run(run func(ctx context.Context, fingerprint string)) *MockStore_PrivateKeyGet_Call {
+func (_c *MockStore_NamespaceGetMembers_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_NamespaceGetMembers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
 		}
+		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
+			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_PrivateKeyGet_Call) Return(privateKey *models.PrivateKey, err error) *MockStore_PrivateKeyGet_Call {
-	_c.Call.Return(privateKey, err)
+func (_c *MockStore_NamespaceGetMembers_Call) Return(memberViews []models.MemberView, n int, err error) *MockStore_NamespaceGetMembers_Call {
+	_c.Call.Return(memberViews, n, err)
 	return _c
 }
 
-func (_c *MockStore_PrivateKeyGet_Call) RunAndReturn(run func(ctx context.Context, fingerprint string) (*models.PrivateKey, error)) *MockStore_PrivateKeyGet_Call {
+func (_c *MockStore_NamespaceGetMembers_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.MemberView, int, error)) *MockStore_NamespaceGetMembers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PublicKeyCreate provides a mock function for the type MockStore
-func (_mock *MockStore) PublicKeyCreate(ctx context.Context, key *models.PublicKey) (string, error) {
-	ret := _mock.Called(ctx, key)
+// NamespaceGetPreferred provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceGetPreferred(ctx context.Context, userID string) (*models.Namespace, error) {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PublicKeyCreate")
+		panic("no return value specified for NamespaceGetPreferred")
 	}
 
-	var r0 string
+	var r0 *models.Namespace
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PublicKey) (string, error)); ok {
-		return returnFunc(ctx, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.Namespace, error)); ok {
+		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PublicKey) string); ok {
-		r0 = returnFunc(ctx, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.Namespace); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.PublicKey) error); ok {
-		r1 = returnFunc(ctx, key)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_PublicKeyCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyCreate'
-type MockStore_PublicKeyCreate_Call struct {
+// MockStore_NamespaceGetPreferred_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceGetPreferred'
+type MockStore_NamespaceGetPreferred_Call struct {
 	*mock.Call
 }
 
-// PublicKeyCreate is a helper method to define mock.On call
+// NamespaceGetPreferred is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key *models.PublicKey
-func (_e *MockStore_Expecter) PublicKeyCreate(ctx any, key any) *MockStore_PublicKeyCreate_Call {
-	return &MockStore_PublicKeyCreate_Call{Call: _e.mock.On("PublicKeyCreate", ctx, key)}
+//   - userID string
+func (_e *MockStore_Expecter) NamespaceGetPreferred(ctx any, userID any) *MockStore_NamespaceGetPreferred_Call {
+	return &MockStore_NamespaceGetPreferred_Call{Call: _e.mock.On("NamespaceGetPreferred", ctx, userID)}
 }
 
-func (_c *MockStore_PublicKeyCreate_Call) Run(run func(ctx context.Context, key *models.PublicKey)) *MockStore_PublicKeyCreate_Call {
+func (_c *MockStore_NamespaceGetPreferred_Call) Run(run func(ctx context.Context, userID string)) *MockStore_NamespaceGetPreferred_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.PublicKey
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*models.PublicKey)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -4725,213 +4780,223 @@ func (_c *MockStore_PublicKeyCreate_Call) Run(run func(ctx context.Context, key
 	return _c
 }
 
-func (_c *MockStore_PublicKeyCreate_Call) Return(s string, err error) *MockStore_PublicKeyCreate_Call {
-	_c.Call.Return(s, err)
+func (_c *MockStore_NamespaceGetPreferred_Call) Return(namespace *models.Namespace, err error) *MockStore_NamespaceGetPreferred_Call {
+	_c.Call.Return(namespace, err)
 	return _c
 }
 
-func (_c *MockStore_PublicKeyCreate_Call) RunAndReturn(run func(ctx context.Context, key *models.PublicKey) (string, error)) *MockStore_PublicKeyCreate_Call {
+func (_c *MockStore_NamespaceGetPreferred_Call) RunAndReturn(run func(ctx context.Context, userID string) (*models.Namespace, error)) *MockStore_NamespaceGetPreferred_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PublicKeyDelete provides a mock function for the type MockStore
-func (_mock *MockStore) PublicKeyDelete(ctx context.Context, publicKey *models.PublicKey) error {
-	ret := _mock.Called(ctx, publicKey)
+// NamespaceIncrementDeviceCount provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceIncrementDeviceCount(ctx context.Context, sc scope.Scope, status models.DeviceStatus, count int64) error {
+	ret := _mock.Called(ctx, sc, status, count)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PublicKeyDelete")
+		panic("no return value specified for NamespaceIncrementDeviceCount")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PublicKey) error); ok {
-		r0 = returnFunc(ctx, publicKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, models.DeviceStatus, int64) error); ok {
+		r0 = returnFunc(ctx, sc, status, count)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_PublicKeyDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyDelete'
-type MockStore_PublicKeyDelete_Call struct {
+// MockStore_NamespaceIncrementDeviceCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceIncrementDeviceCount'
+type MockStore_NamespaceIncrementDeviceCount_Call struct {
 	*mock.Call
 }
 
-// PublicKeyDelete is a helper method to define mock.On call
+// NamespaceIncrementDeviceCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - publicKey *models.PublicKey
-func (_e *MockStore_Expecter) PublicKeyDelete(ctx any, publicKey any) *MockStore_PublicKeyDelete_Call {
-	return &MockStore_PublicKeyDelete_Call{Call: _e.mock.On("PublicKeyDelete", ctx, publicKey)}
+//   - sc scope.Scope
+//   - status models.DeviceStatus
+//   - count int64
+func (_e *MockStore_Expecter) NamespaceIncrementDeviceCount(ctx any, sc any, status any, count any) *MockStore_NamespaceIncrementDeviceCount_Call {
+	return &MockStore_NamespaceIncrementDeviceCount_Call{Call: _e.mock.On("NamespaceIncrementDeviceCount", ctx, sc, status, count)}
 }
 
-func (_c *MockStore_PublicKeyDelete_Call) Run(run func(ctx context.Context, publicKey *models.PublicKey)) *MockStore_PublicKeyDelete_Call {
+func (_c *MockStore_NamespaceIncrementDeviceCount_Call) Run(run func(ctx context.Context, sc scope.Scope, status models.DeviceStatus, count int64)) *MockStore_NamespaceIncrementDeviceCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.PublicKey
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(*models.PublicKey)
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 models.DeviceStatus
+		if args[2] != nil {
+			arg2 = args[2].(models.DeviceStatus)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_PublicKeyDelete_Call) Return(err error) *MockStore_PublicKeyDelete_Call {
+func (_c *MockStore_NamespaceIncrementDeviceCount_Call) Return(err error) *MockStore_NamespaceIncrementDeviceCount_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_PublicKeyDelete_Call) RunAndReturn(run func(ctx context.Context, publicKey *models.PublicKey) error) *MockStore_PublicKeyDelete_Call {
+func (_c *MockStore_NamespaceIncrementDeviceCount_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, status models.DeviceStatus, count int64) error) *MockStore_NamespaceIncrementDeviceCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PublicKeyList provides a mock function for the type MockStore
-func (_mock *MockStore) PublicKeyList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.PublicKey, int, error) {
+// NamespaceList provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceList(ctx context.Context, opts ...store.QueryOption) ([]models.Namespace, int, error) {
 	var tmpRet mock.Arguments
 	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, opts)
+		tmpRet = _mock.Called(ctx, opts)
 	} else {
-		tmpRet = _mock.Called(ctx, sc)
+		tmpRet = _mock.Called(ctx)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for PublicKeyList")
+		panic("no return value specified for NamespaceList")
 	}
 
-	var r0 []models.PublicKey
+	var r0 []models.Namespace
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.PublicKey, int, error)); ok {
-		return returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) ([]models.Namespace, int, error)); ok {
+		return returnFunc(ctx, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.PublicKey); ok {
-		r0 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []models.Namespace); ok {
+		r0 = returnFunc(ctx, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PublicKey)
+			r0 = ret.Get(0).([]models.Namespace)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, opts...)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, opts...)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockStore_PublicKeyList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyList'
-type MockStore_PublicKeyList_Call struct {
+// MockStore_NamespaceList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceList'
+type MockStore_NamespaceList_Call struct {
 	*mock.Call
 }
 
-// PublicKeyList is a helper method to define mock.On call
+// NamespaceList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) PublicKeyList(ctx any, sc any, opts ...any) *MockStore_PublicKeyList_Call {
-	return &MockStore_PublicKeyList_Call{Call: _e.mock.On("PublicKeyList",
-		append([]any{ctx, sc}, opts...)...)}
+func (_e *MockStore_Expecter) NamespaceList(ctx any, opts ...any) *MockStore_NamespaceList_Call {
+	return &MockStore_NamespaceList_Call{Call: _e.mock.On("NamespaceList",
+		append([]any{ctx}, opts...)...)}
 }
 
-func (_c *MockStore_PublicKeyList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_PublicKeyList_Call {
+func (_c *MockStore_NamespaceList_Call) Run(run func(ctx context.Context, opts ...store.QueryOption)) *MockStore_NamespaceList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
-		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
-		}
-		var arg2 []store.QueryOption
+		var arg1 []store.QueryOption
 		var variadicArgs []store.QueryOption
-		if len(args) > 2 {
-			variadicArgs = args[2].([]store.QueryOption)
+		if len(args) > 1 {
+			variadicArgs = args[1].([]store.QueryOption)
 		}
-		arg2 = variadicArgs
+		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1,
-			arg2...,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_PublicKeyList_Call) Return(publicKeys []models.PublicKey, n int, err error) *MockStore_PublicKeyList_Call {
-	_c.Call.Return(publicKeys, n, err)
+func (_c *MockStore_NamespaceList_Call) Return(namespaces []models.Namespace, n int, err error) *MockStore_NamespaceList_Call {
+	_c.Call.Return(namespaces, n, err)
 	return _c
 }
 
-func (_c *MockStore_PublicKeyList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.PublicKey, int, error)) *MockStore_PublicKeyList_Call {
+func (_c *MockStore_NamespaceList_Call) RunAndReturn(run func(ctx context.Context, opts ...store.QueryOption) ([]models.Namespace, int, error)) *MockStore_NamespaceList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PublicKeyResolve provides a mock function for the type MockStore
-func (_mock *MockStore) PublicKeyResolve(ctx context.Context, sc scope.Scope, resolver store.PublicKeyResolver, value string, opts ...store.QueryOption) (*models.PublicKey, error) {
+// NamespaceMembershipInvitationList provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceMembershipInvitationList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.MembershipInvitation, int64, error) {
 	var tmpRet mock.Arguments
 	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
+		tmpRet = _mock.Called(ctx, sc, opts)
 	} else {
-		tmpRet = _mock.Called(ctx, sc, resolver, value)
+		tmpRet = _mock.Called(ctx, sc)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for PublicKeyResolve")
+		panic("no return value specified for NamespaceMembershipInvitationList")
 	}
 
-	var r0 *models.PublicKey
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.PublicKeyResolver, string, ...store.QueryOption) (*models.PublicKey, error)); ok {
-		return returnFunc(ctx, sc, resolver, value, opts...)
+	var r0 []models.MembershipInvitation
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.MembershipInvitation, int64, error)); ok {
+		return returnFunc(ctx, sc, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.PublicKeyResolver, string, ...store.QueryOption) *models.PublicKey); ok {
-		r0 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.MembershipInvitation); ok {
+		r0 = returnFunc(ctx, sc, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.PublicKey)
+			r0 = ret.Get(0).([]models.MembershipInvitation)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.PublicKeyResolver, string, ...store.QueryOption) error); ok {
-		r1 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int64); ok {
+		r1 = returnFunc(ctx, sc, opts...)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_PublicKeyResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyResolve'
-type MockStore_PublicKeyResolve_Call struct {
+// MockStore_NamespaceMembershipInvitationList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceMembershipInvitationList'
+type MockStore_NamespaceMembershipInvitationList_Call struct {
 	*mock.Call
 }
 
-// PublicKeyResolve is a helper method to define mock.On call
+// NamespaceMembershipInvitationList is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - resolver store.PublicKeyResolver
-//   - value string
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) PublicKeyResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_PublicKeyResolve_Call {
-	return &MockStore_PublicKeyResolve_Call{Call: _e.mock.On("PublicKeyResolve",
-		append([]any{ctx, sc, resolver, value}, opts...)...)}
+func (_e *MockStore_Expecter) NamespaceMembershipInvitationList(ctx any, sc any, opts ...any) *MockStore_NamespaceMembershipInvitationList_Call {
+	return &MockStore_NamespaceMembershipInvitationList_Call{Call: _e.mock.On("NamespaceMembershipInvitationList",
+		append([]any{ctx, sc}, opts...)...)}
 }
 
-func (_c *MockStore_PublicKeyResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.PublicKeyResolver, value string, opts ...store.QueryOption)) *MockStore_PublicKeyResolve_Call {
+func (_c *MockStore_NamespaceMembershipInvitationList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_NamespaceMembershipInvitationList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4941,202 +5006,194 @@ func (_c *MockStore_PublicKeyResolve_Call) Run(run func(ctx context.Context, sc
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 store.PublicKeyResolver
-		if args[2] != nil {
-			arg2 = args[2].(store.PublicKeyResolver)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 []store.QueryOption
+		var arg2 []store.QueryOption
 		var variadicArgs []store.QueryOption
-		if len(args) > 4 {
-			variadicArgs = args[4].([]store.QueryOption)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
 		}
-		arg4 = variadicArgs
+		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
-			arg4...,
+			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_PublicKeyResolve_Call) Return(publicKey *models.PublicKey, err error) *MockStore_PublicKeyResolve_Call {
-	_c.Call.Return(publicKey, err)
+func (_c *MockStore_NamespaceMembershipInvitationList_Call) Return(membershipInvitations []models.MembershipInvitation, n int64, err error) *MockStore_NamespaceMembershipInvitationList_Call {
+	_c.Call.Return(membershipInvitations, n, err)
 	return _c
 }
 
-func (_c *MockStore_PublicKeyResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.PublicKeyResolver, value string, opts ...store.QueryOption) (*models.PublicKey, error)) *MockStore_PublicKeyResolve_Call {
+func (_c *MockStore_NamespaceMembershipInvitationList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.MembershipInvitation, int64, error)) *MockStore_NamespaceMembershipInvitationList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PublicKeyUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) PublicKeyUpdate(ctx context.Context, publicKey *models.PublicKey) error {
-	ret := _mock.Called(ctx, publicKey)
+// NamespaceResolve provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceResolve(ctx context.Context, resolver store.NamespaceResolver, value string) (*models.Namespace, error) {
+	ret := _mock.Called(ctx, resolver, value)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PublicKeyUpdate")
+		panic("no return value specified for NamespaceResolve")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PublicKey) error); ok {
-		r0 = returnFunc(ctx, publicKey)
+	var r0 *models.Namespace
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.NamespaceResolver, string) (*models.Namespace, error)); ok {
+		return returnFunc(ctx, resolver, value)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.NamespaceResolver, string) *models.Namespace); ok {
+		r0 = returnFunc(ctx, resolver, value)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, store.NamespaceResolver, string) error); ok {
+		r1 = returnFunc(ctx, resolver, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_PublicKeyUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyUpdate'
-type MockStore_PublicKeyUpdate_Call struct {
+// MockStore_NamespaceResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceResolve'
+type MockStore_NamespaceResolve_Call struct {
 	*mock.Call
 }
 
-// PublicKeyUpdate is a helper method to define mock.On call
+// NamespaceResolve is a helper method to define mock.On call
 //   - ctx context.Context
-//   - publicKey *models.PublicKey
-func (_e *MockStore_Expecter) PublicKeyUpdate(ctx any, publicKey any) *MockStore_PublicKeyUpdate_Call {
-	return &MockStore_PublicKeyUpdate_Call{Call: _e.mock.On("PublicKeyUpdate", ctx, publicKey)}
+//   - resolver store.NamespaceResolver
+//   - value string
+func (_e *MockStore_Expecter) NamespaceResolve(ctx any, resolver any, value any) *MockStore_NamespaceResolve_Call {
+	return &MockStore_NamespaceResolve_Call{Call: _e.mock.On("NamespaceResolve", ctx, resolver, value)}
 }
 
-func (_c *MockStore_PublicKeyUpdate_Call) Run(run func(ctx context.Context, publicKey *models.PublicKey)) *MockStore_PublicKeyUpdate_Call {
+func (_c *MockStore_NamespaceResolve_Call) Run(run func(ctx context.Context, resolver store.NamespaceResolver, value string)) *MockStore_NamespaceResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.PublicKey
+		var arg1 store.NamespaceResolver
 		if args[1] != nil {
-			arg1 = args[1].(*models.PublicKey)
+			arg1 = args[1].(store.NamespaceResolver)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_PublicKeyUpdate_Call) Return(err error) *MockStore_PublicKeyUpdate_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_NamespaceResolve_Call) Return(namespace *models.Namespace, err error) *MockStore_NamespaceResolve_Call {
+	_c.Call.Return(namespace, err)
 	return _c
 }
 
-func (_c *MockStore_PublicKeyUpdate_Call) RunAndReturn(run func(ctx context.Context, publicKey *models.PublicKey) error) *MockStore_PublicKeyUpdate_Call {
+func (_c *MockStore_NamespaceResolve_Call) RunAndReturn(run func(ctx context.Context, resolver store.NamespaceResolver, value string) (*models.Namespace, error)) *MockStore_NamespaceResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHApprovalCleanup provides a mock function for the type MockStore
-func (_mock *MockStore) SSHApprovalCleanup(ctx context.Context, before time.Time) (int64, error) {
-	ret := _mock.Called(ctx, before)
+// NamespaceSyncDeviceCounts provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceSyncDeviceCounts(ctx context.Context) error {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHApprovalCleanup")
+		panic("no return value specified for NamespaceSyncDeviceCounts")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
-		return returnFunc(ctx, before)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
-		r0 = returnFunc(ctx, before)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
-		r1 = returnFunc(ctx, before)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_SSHApprovalCleanup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHApprovalCleanup'
-type MockStore_SSHApprovalCleanup_Call struct {
+// MockStore_NamespaceSyncDeviceCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceSyncDeviceCounts'
+type MockStore_NamespaceSyncDeviceCounts_Call struct {
 	*mock.Call
 }
 
-// SSHApprovalCleanup is a helper method to define mock.On call
+// NamespaceSyncDeviceCounts is a helper method to define mock.On call
 //   - ctx context.Context
-//   - before time.Time
-func (_e *MockStore_Expecter) SSHApprovalCleanup(ctx any, before any) *MockStore_SSHApprovalCleanup_Call {
-	return &MockStore_SSHApprovalCleanup_Call{Call: _e.mock.On("SSHApprovalCleanup", ctx, before)}
+func (_e *MockStore_Expecter) NamespaceSyncDeviceCounts(ctx any) *MockStore_NamespaceSyncDeviceCounts_Call {
+	return &MockStore_NamespaceSyncDeviceCounts_Call{Call: _e.mock.On("NamespaceSyncDeviceCounts", ctx)}
 }
 
-func (_c *MockStore_SSHApprovalCleanup_Call) Run(run func(ctx context.Context, before time.Time)) *MockStore_SSHApprovalCleanup_Call {
+func (_c *MockStore_NamespaceSyncDeviceCounts_Call) Run(run func(ctx context.Context)) *MockStore_NamespaceSyncDeviceCounts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 time.Time
-		if args[1] != nil {
-			arg1 = args[1].(time.Time)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SSHApprovalCleanup_Call) Return(n int64, err error) *MockStore_SSHApprovalCleanup_Call {
-	_c.Call.Return(n, err)
+func (_c *MockStore_NamespaceSyncDeviceCounts_Call) Return(err error) *MockStore_NamespaceSyncDeviceCounts_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SSHApprovalCleanup_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockStore_SSHApprovalCleanup_Call {
+func (_c *MockStore_NamespaceSyncDeviceCounts_Call) RunAndReturn(run func(ctx context.Context) error) *MockStore_NamespaceSyncDeviceCounts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHApprovalCreate provides a mock function for the type MockStore
-func (_mock *MockStore) SSHApprovalCreate(ctx context.Context, approval *models.SSHApproval) error {
-	ret := _mock.Called(ctx, approval)
+// NamespaceUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceUpdate(ctx context.Context, namespace *models.Namespace) error {
+	ret := _mock.Called(ctx, namespace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHApprovalCreate")
+		panic("no return value specified for NamespaceUpdate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHApproval) error); ok {
-		r0 = returnFunc(ctx, approval)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Namespace) error); ok {
+		r0 = returnFunc(ctx, namespace)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_SSHApprovalCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHApprovalCreate'
-type MockStore_SSHApprovalCreate_Call struct {
+// MockStore_NamespaceUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceUpdate'
+type MockStore_NamespaceUpdate_Call struct {
 	*mock.Call
 }
 
-// SSHApprovalCreate is a helper method to define mock.On call
+// NamespaceUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - approval *models.SSHApproval
-func (_e *MockStore_Expecter) SSHApprovalCreate(ctx any, approval any) *MockStore_SSHApprovalCreate_Call {
-	return &MockStore_SSHApprovalCreate_Call{Call: _e.mock.On("SSHApprovalCreate", ctx, approval)}
+//   - namespace *models.Namespace
+func (_e *MockStore_Expecter) NamespaceUpdate(ctx any, namespace any) *MockStore_NamespaceUpdate_Call {
+	return &MockStore_NamespaceUpdate_Call{Call: _e.mock.On("NamespaceUpdate", ctx, namespace)}
 }
 
-func (_c *MockStore_SSHApprovalCreate_Call) Run(run func(ctx context.Context, approval *models.SSHApproval)) *MockStore_SSHApprovalCreate_Call {
+func (_c *MockStore_NamespaceUpdate_Call) Run(run func(ctx context.Context, namespace *models.Namespace)) *MockStore_NamespaceUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.SSHApproval
+		var arg1 *models.Namespace
 		if args[1] != nil {
-			arg1 = args[1].(*models.SSHApproval)
+			arg1 = args[1].(*models.Namespace)
 		}
 		run(
 			arg0,
@@ -5146,214 +5203,223 @@ func (_c *MockStore_SSHApprovalCreate_Call) Run(run func(ctx context.Context, ap
 	return _c
 }
 
-func (_c *MockStore_SSHApprovalCreate_Call) Return(err error) *MockStore_SSHApprovalCreate_Call {
+func (_c *MockStore_NamespaceUpdate_Call) Return(err error) *MockStore_NamespaceUpdate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SSHApprovalCreate_Call) RunAndReturn(run func(ctx context.Context, approval *models.SSHApproval) error) *MockStore_SSHApprovalCreate_Call {
+func (_c *MockStore_NamespaceUpdate_Call) RunAndReturn(run func(ctx context.Context, namespace *models.Namespace) error) *MockStore_NamespaceUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHApprovalDecide provides a mock function for the type MockStore
-func (_mock *MockStore) SSHApprovalDecide(ctx context.Context, code string, state models.SSHApprovalState, userID string, now time.Time) (bool, error) {
-	ret := _mock.Called(ctx, code, state, userID, now)
+// NamespaceUpdateMembership provides a mock function for the type MockStore
+func (_mock *MockStore) NamespaceUpdateMembership(ctx context.Context, sc scope.Scope, member *models.Member) error {
+	ret := _mock.Called(ctx, sc, member)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHApprovalDecide")
+		panic("no return value specified for NamespaceUpdateMembership")
 	}
 
-	var r0 bool
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.SSHApprovalState, string, time.Time) (bool, error)); ok {
-		return returnFunc(ctx, code, state, userID, now)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.SSHApprovalState, string, time.Time) bool); ok {
-		r0 = returnFunc(ctx, code, state, userID, now)
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, models.SSHApprovalState, string, time.Time) error); ok {
-		r1 = returnFunc(ctx, code, state, userID, now)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.Member) error); ok {
+		r0 = returnFunc(ctx, sc, member)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_SSHApprovalDecide_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHApprovalDecide'
-type MockStore_SSHApprovalDecide_Call struct {
+// MockStore_NamespaceUpdateMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NamespaceUpdateMembership'
+type MockStore_NamespaceUpdateMembership_Call struct {
 	*mock.Call
 }
 
-// SSHApprovalDecide is a helper method to define mock.On call
+// NamespaceUpdateMembership is a helper method to define mock.On call
 //   - ctx context.Context
-//   - code string
-//   - state models.SSHApprovalState
-//   - userID string
-//   - now time.Time
-func (_e *MockStore_Expecter) SSHApprovalDecide(ctx any, code any, state any, userID any, now any) *MockStore_SSHApprovalDecide_Call {
-	return &MockStore_SSHApprovalDecide_Call{Call: _e.mock.On("SSHApprovalDecide", ctx, code, state, userID, now)}
+//   - sc scope.Scope
+//   - member *models.Member
+func (_e *MockStore_Expecter) NamespaceUpdateMembership(ctx any, sc any, member any) *MockStore_NamespaceUpdateMembership_Call {
+	return &MockStore_NamespaceUpdateMembership_Call{Call: _e.mock.On("NamespaceUpdateMembership", ctx, sc, member)}
 }
 
-func (_c *MockStore_SSHApprovalDecide_Call) Run(run func(ctx context.Context, code string, state models.SSHApprovalState, userID string, now time.Time)) *MockStore_SSHApprovalDecide_Call {
+func (_c *MockStore_NamespaceUpdateMembership_Call) Run(run func(ctx context.Context, sc scope.Scope, member *models.Member)) *MockStore_NamespaceUpdateMembership_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 models.SSHApprovalState
+		var arg2 *models.Member
 		if args[2] != nil {
-			arg2 = args[2].(models.SSHApprovalState)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 time.Time
-		if args[4] != nil {
-			arg4 = args[4].(time.Time)
+			arg2 = args[2].(*models.Member)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SSHApprovalDecide_Call) Return(b bool, err error) *MockStore_SSHApprovalDecide_Call {
-	_c.Call.Return(b, err)
+func (_c *MockStore_NamespaceUpdateMembership_Call) Return(err error) *MockStore_NamespaceUpdateMembership_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SSHApprovalDecide_Call) RunAndReturn(run func(ctx context.Context, code string, state models.SSHApprovalState, userID string, now time.Time) (bool, error)) *MockStore_SSHApprovalDecide_Call {
+func (_c *MockStore_NamespaceUpdateMembership_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, member *models.Member) error) *MockStore_NamespaceUpdateMembership_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHApprovalGet provides a mock function for the type MockStore
-func (_mock *MockStore) SSHApprovalGet(ctx context.Context, code string, now time.Time) (*models.SSHApproval, error) {
-	ret := _mock.Called(ctx, code, now)
+// Options provides a mock function for the type MockStore
+func (_mock *MockStore) Options() store.QueryOptions {
+	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHApprovalGet")
+		panic("no return value specified for Options")
 	}
 
-	var r0 *models.SSHApproval
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (*models.SSHApproval, error)); ok {
-		return returnFunc(ctx, code, now)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) *models.SSHApproval); ok {
-		r0 = returnFunc(ctx, code, now)
+	var r0 store.QueryOptions
+	if returnFunc, ok := ret.Get(0).(func() store.QueryOptions); ok {
+		r0 = returnFunc()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.SSHApproval)
+			r0 = ret.Get(0).(store.QueryOptions)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
-		r1 = returnFunc(ctx, code, now)
+	return r0
+}
+
+// MockStore_Options_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Options'
+type MockStore_Options_Call struct {
+	*mock.Call
+}
+
+// Options is a helper method to define mock.On call
+func (_e *MockStore_Expecter) Options() *MockStore_Options_Call {
+	return &MockStore_Options_Call{Call: _e.mock.On("Options")}
+}
+
+func (_c *MockStore_Options_Call) Run(run func()) *MockStore_Options_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStore_Options_Call) Return(queryOptions store.QueryOptions) *MockStore_Options_Call {
+	_c.Call.Return(queryOptions)
+	return _c
+}
+
+func (_c *MockStore_Options_Call) RunAndReturn(run func() store.QueryOptions) *MockStore_Options_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrivateKeyCreate provides a mock function for the type MockStore
+func (_mock *MockStore) PrivateKeyCreate(ctx context.Context, key *models.PrivateKey) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrivateKeyCreate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PrivateKey) error); ok {
+		r0 = returnFunc(ctx, key)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_SSHApprovalGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHApprovalGet'
-type MockStore_SSHApprovalGet_Call struct {
+// MockStore_PrivateKeyCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrivateKeyCreate'
+type MockStore_PrivateKeyCreate_Call struct {
 	*mock.Call
 }
 
-// SSHApprovalGet is a helper method to define mock.On call
+// PrivateKeyCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - code string
-//   - now time.Time
-func (_e *MockStore_Expecter) SSHApprovalGet(ctx any, code any, now any) *MockStore_SSHApprovalGet_Call {
-	return &MockStore_SSHApprovalGet_Call{Call: _e.mock.On("SSHApprovalGet", ctx, code, now)}
+//   - key *models.PrivateKey
+func (_e *MockStore_Expecter) PrivateKeyCreate(ctx any, key any) *MockStore_PrivateKeyCreate_Call {
+	return &MockStore_PrivateKeyCreate_Call{Call: _e.mock.On("PrivateKeyCreate", ctx, key)}
 }
 
-func (_c *MockStore_SSHApprovalGet_Call) Run(run func(ctx context.Context, code string, now time.Time)) *MockStore_SSHApprovalGet_Call {
+func (_c *MockStore_PrivateKeyCreate_Call) Run(run func(ctx context.Context, key *models.PrivateKey)) *MockStore_PrivateKeyCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *models.PrivateKey
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 time.Time
-		if args[2] != nil {
-			arg2 = args[2].(time.Time)
+			arg1 = args[1].(*models.PrivateKey)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SSHApprovalGet_Call) Return(sSHApproval *models.SSHApproval, err error) *MockStore_SSHApprovalGet_Call {
-	_c.Call.Return(sSHApproval, err)
+func (_c *MockStore_PrivateKeyCreate_Call) Return(err error) *MockStore_PrivateKeyCreate_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SSHApprovalGet_Call) RunAndReturn(run func(ctx context.Context, code string, now time.Time) (*models.SSHApproval, error)) *MockStore_SSHApprovalGet_Call {
+func (_c *MockStore_PrivateKeyCreate_Call) RunAndReturn(run func(ctx context.Context, key *models.PrivateKey) error) *MockStore_PrivateKeyCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHIdentityConsume provides a mock function for the type MockStore
-func (_mock *MockStore) SSHIdentityConsume(ctx context.Context, tenantID string, fingerprint string) (bool, error) {
-	ret := _mock.Called(ctx, tenantID, fingerprint)
+// PrivateKeyGet provides a mock function for the type MockStore
+func (_mock *MockStore) PrivateKeyGet(ctx context.Context, fingerprint string) (*models.PrivateKey, error) {
+	ret := _mock.Called(ctx, fingerprint)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHIdentityConsume")
+		panic("no return value specified for PrivateKeyGet")
 	}
 
-	var r0 bool
+	var r0 *models.PrivateKey
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return returnFunc(ctx, tenantID, fingerprint)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.PrivateKey, error)); ok {
+		return returnFunc(ctx, fingerprint)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-		r0 = returnFunc(ctx, tenantID, fingerprint)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.PrivateKey); ok {
+		r0 = returnFunc(ctx, fingerprint)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PrivateKey)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, tenantID, fingerprint)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, fingerprint)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_SSHIdentityConsume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityConsume'
-type MockStore_SSHIdentityConsume_Call struct {
+// MockStore_PrivateKeyGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrivateKeyGet'
+type MockStore_PrivateKeyGet_Call struct {
 	*mock.Call
 }
 
-// SSHIdentityConsume is a helper method to define mock.On call
+// PrivateKeyGet is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantID string
 //   - fingerprint string
-func (_e *MockStore_Expecter) SSHIdentityConsume(ctx any, tenantID any, fingerprint any) *MockStore_SSHIdentityConsume_Call {
-	return &MockStore_SSHIdentityConsume_Call{Call: _e.mock.On("SSHIdentityConsume", ctx, tenantID, fingerprint)}
+func (_e *MockStore_Expecter) PrivateKeyGet(ctx any, fingerprint any) *MockStore_PrivateKeyGet_Call {
+	return &MockStore_PrivateKeyGet_Call{Call: _e.mock.On("PrivateKeyGet", ctx, fingerprint)}
 }
 
-func (_c *MockStore_SSHIdentityConsume_Call) Run(run func(ctx context.Context, tenantID string, fingerprint string)) *MockStore_SSHIdentityConsume_Call {
+func (_c *MockStore_PrivateKeyGet_Call) Run(run func(ctx context.Context, fingerprint string)) *MockStore_PrivateKeyGet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5363,76 +5429,71 @@ func (_c *MockStore_SSHIdentityConsume_Call) Run(run func(ctx context.Context, t
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityConsume_Call) Return(b bool, err error) *MockStore_SSHIdentityConsume_Call {
-	_c.Call.Return(b, err)
+func (_c *MockStore_PrivateKeyGet_Call) Return(privateKey *models.PrivateKey, err error) *MockStore_PrivateKeyGet_Call {
+	_c.Call.Return(privateKey, err)
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityConsume_Call) RunAndReturn(run func(ctx context.Context, tenantID string, fingerprint string) (bool, error)) *MockStore_SSHIdentityConsume_Call {
+func (_c *MockStore_PrivateKeyGet_Call) RunAndReturn(run func(ctx context.Context, fingerprint string) (*models.PrivateKey, error)) *MockStore_PrivateKeyGet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHIdentityCreate provides a mock function for the type MockStore
-func (_mock *MockStore) SSHIdentityCreate(ctx context.Context, identity *models.SSHIdentity) (string, error) {
-	ret := _mock.Called(ctx, identity)
+// PublicKeyCreate provides a mock function for the type MockStore
+func (_mock *MockStore) PublicKeyCreate(ctx context.Context, key *models.PublicKey) (string, error) {
+	ret := _mock.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHIdentityCreate")
+		panic("no return value specified for PublicKeyCreate")
 	}
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHIdentity) (string, error)); ok {
-		return returnFunc(ctx, identity)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PublicKey) (string, error)); ok {
+		return returnFunc(ctx, key)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHIdentity) string); ok {
-		r0 = returnFunc(ctx, identity)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PublicKey) string); ok {
+		r0 = returnFunc(ctx, key)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.SSHIdentity) error); ok {
-		r1 = returnFunc(ctx, identity)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.PublicKey) error); ok {
+		r1 = returnFunc(ctx, key)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_SSHIdentityCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityCreate'
-type MockStore_SSHIdentityCreate_Call struct {
+// MockStore_PublicKeyCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyCreate'
+type MockStore_PublicKeyCreate_Call struct {
 	*mock.Call
 }
 
-// SSHIdentityCreate is a helper method to define mock.On call
+// PublicKeyCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - identity *models.SSHIdentity
-func (_e *MockStore_Expecter) SSHIdentityCreate(ctx any, identity any) *MockStore_SSHIdentityCreate_Call {
-	return &MockStore_SSHIdentityCreate_Call{Call: _e.mock.On("SSHIdentityCreate", ctx, identity)}
+//   - key *models.PublicKey
+func (_e *MockStore_Expecter) PublicKeyCreate(ctx any, key any) *MockStore_PublicKeyCreate_Call {
+	return &MockStore_PublicKeyCreate_Call{Call: _e.mock.On("PublicKeyCreate", ctx, key)}
 }
 
-func (_c *MockStore_SSHIdentityCreate_Call) Run(run func(ctx context.Context, identity *models.SSHIdentity)) *MockStore_SSHIdentityCreate_Call {
+func (_c *MockStore_PublicKeyCreate_Call) Run(run func(ctx context.Context, key *models.PublicKey)) *MockStore_PublicKeyCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.SSHIdentity
+		var arg1 *models.PublicKey
 		if args[1] != nil {
-			arg1 = args[1].(*models.SSHIdentity)
+			arg1 = args[1].(*models.PublicKey)
 		}
 		run(
 			arg0,
@@ -5442,54 +5503,54 @@ func (_c *MockStore_SSHIdentityCreate_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityCreate_Call) Return(s string, err error) *MockStore_SSHIdentityCreate_Call {
+func (_c *MockStore_PublicKeyCreate_Call) Return(s string, err error) *MockStore_PublicKeyCreate_Call {
 	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityCreate_Call) RunAndReturn(run func(ctx context.Context, identity *models.SSHIdentity) (string, error)) *MockStore_SSHIdentityCreate_Call {
+func (_c *MockStore_PublicKeyCreate_Call) RunAndReturn(run func(ctx context.Context, key *models.PublicKey) (string, error)) *MockStore_PublicKeyCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHIdentityDelete provides a mock function for the type MockStore
-func (_mock *MockStore) SSHIdentityDelete(ctx context.Context, identity *models.SSHIdentity) error {
-	ret := _mock.Called(ctx, identity)
+// PublicKeyDelete provides a mock function for the type MockStore
+func (_mock *MockStore) PublicKeyDelete(ctx context.Context, publicKey *models.PublicKey) error {
+	ret := _mock.Called(ctx, publicKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHIdentityDelete")
+		panic("no return value specified for PublicKeyDelete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHIdentity) error); ok {
-		r0 = returnFunc(ctx, identity)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PublicKey) error); ok {
+		r0 = returnFunc(ctx, publicKey)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_SSHIdentityDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityDelete'
-type MockStore_SSHIdentityDelete_Call struct {
+// MockStore_PublicKeyDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyDelete'
+type MockStore_PublicKeyDelete_Call struct {
 	*mock.Call
 }
 
-// SSHIdentityDelete is a helper method to define mock.On call
+// PublicKeyDelete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - identity *models.SSHIdentity
-func (_e *MockStore_Expecter) SSHIdentityDelete(ctx any, identity any) *MockStore_SSHIdentityDelete_Call {
-	return &MockStore_SSHIdentityDelete_Call{Call: _e.mock.On("SSHIdentityDelete", ctx, identity)}
+//   - publicKey *models.PublicKey
+func (_e *MockStore_Expecter) PublicKeyDelete(ctx any, publicKey any) *MockStore_PublicKeyDelete_Call {
+	return &MockStore_PublicKeyDelete_Call{Call: _e.mock.On("PublicKeyDelete", ctx, publicKey)}
 }
 
-func (_c *MockStore_SSHIdentityDelete_Call) Run(run func(ctx context.Context, identity *models.SSHIdentity)) *MockStore_SSHIdentityDelete_Call {
+func (_c *MockStore_PublicKeyDelete_Call) Run(run func(ctx context.Context, publicKey *models.PublicKey)) *MockStore_PublicKeyDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.SSHIdentity
+		var arg1 *models.PublicKey
 		if args[1] != nil {
-			arg1 = args[1].(*models.SSHIdentity)
+			arg1 = args[1].(*models.PublicKey)
 		}
 		run(
 			arg0,
@@ -5499,18 +5560,18 @@ func (_c *MockStore_SSHIdentityDelete_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityDelete_Call) Return(err error) *MockStore_SSHIdentityDelete_Call {
+func (_c *MockStore_PublicKeyDelete_Call) Return(err error) *MockStore_PublicKeyDelete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityDelete_Call) RunAndReturn(run func(ctx context.Context, identity *models.SSHIdentity) error) *MockStore_SSHIdentityDelete_Call {
+func (_c *MockStore_PublicKeyDelete_Call) RunAndReturn(run func(ctx context.Context, publicKey *models.PublicKey) error) *MockStore_PublicKeyDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHIdentityList provides a mock function for the type MockStore
-func (_mock *MockStore) SSHIdentityList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.SSHIdentity, int, error) {
+// PublicKeyList provides a mock function for the type MockStore
+func (_mock *MockStore) PublicKeyList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.PublicKey, int, error) {
 	var tmpRet mock.Arguments
 	if len(opts) > 0 {
 		tmpRet = _mock.Called(ctx, sc, opts)
@@ -5520,20 +5581,20 @@ func (_mock *MockStore) SSHIdentityList(ctx context.Context, sc scope.Scope, opt
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHIdentityList")
+		panic("no return value specified for PublicKeyList")
 	}
 
-	var r0 []models.SSHIdentity
+	var r0 []models.PublicKey
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.SSHIdentity, int, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.PublicKey, int, error)); ok {
 		return returnFunc(ctx, sc, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.SSHIdentity); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.PublicKey); ok {
 		r0 = returnFunc(ctx, sc, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.SSHIdentity)
+			r0 = ret.Get(0).([]models.PublicKey)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
@@ -5549,21 +5610,21 @@ func (_mock *MockStore) SSHIdentityList(ctx context.Context, sc scope.Scope, opt
 	return r0, r1, r2
 }
 
-// MockStore_SSHIdentityList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityList'
-type MockStore_SSHIdentityList_Call struct {
+// MockStore_PublicKeyList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyList'
+type MockStore_PublicKeyList_Call struct {
 	*mock.Call
 }
 
-// SSHIdentityList is a helper method to define mock.On call
+// PublicKeyList is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) SSHIdentityList(ctx any, sc any, opts ...any) *MockStore_SSHIdentityList_Call {
-	return &MockStore_SSHIdentityList_Call{Call: _e.mock.On("SSHIdentityList",
+func (_e *MockStore_Expecter) PublicKeyList(ctx any, sc any, opts ...any) *MockStore_PublicKeyList_Call {
+	return &MockStore_PublicKeyList_Call{Call: _e.mock.On("PublicKeyList",
 		append([]any{ctx, sc}, opts...)...)}
 }
 
-func (_c *MockStore_SSHIdentityList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_SSHIdentityList_Call {
+func (_c *MockStore_PublicKeyList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_PublicKeyList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5588,18 +5649,18 @@ func (_c *MockStore_SSHIdentityList_Call) Run(run func(ctx context.Context, sc s
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityList_Call) Return(sSHIdentitys []models.SSHIdentity, n int, err error) *MockStore_SSHIdentityList_Call {
-	_c.Call.Return(sSHIdentitys, n, err)
+func (_c *MockStore_PublicKeyList_Call) Return(publicKeys []models.PublicKey, n int, err error) *MockStore_PublicKeyList_Call {
+	_c.Call.Return(publicKeys, n, err)
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.SSHIdentity, int, error)) *MockStore_SSHIdentityList_Call {
+func (_c *MockStore_PublicKeyList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.PublicKey, int, error)) *MockStore_PublicKeyList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHIdentityResolve provides a mock function for the type MockStore
-func (_mock *MockStore) SSHIdentityResolve(ctx context.Context, sc scope.Scope, resolver store.SSHIdentityResolver, value string, opts ...store.QueryOption) (*models.SSHIdentity, error) {
+// PublicKeyResolve provides a mock function for the type MockStore
+func (_mock *MockStore) PublicKeyResolve(ctx context.Context, sc scope.Scope, resolver store.PublicKeyResolver, value string, opts ...store.QueryOption) (*models.PublicKey, error) {
 	var tmpRet mock.Arguments
 	if len(opts) > 0 {
 		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
@@ -5609,22 +5670,22 @@ func (_mock *MockStore) SSHIdentityResolve(ctx context.Context, sc scope.Scope,
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHIdentityResolve")
+		panic("no return value specified for PublicKeyResolve")
 	}
 
-	var r0 *models.SSHIdentity
+	var r0 *models.PublicKey
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SSHIdentityResolver, string, ...store.QueryOption) (*models.SSHIdentity, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.PublicKeyResolver, string, ...store.QueryOption) (*models.PublicKey, error)); ok {
 		return returnFunc(ctx, sc, resolver, value, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SSHIdentityResolver, string, ...store.QueryOption) *models.SSHIdentity); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.PublicKeyResolver, string, ...store.QueryOption) *models.PublicKey); ok {
 		r0 = returnFunc(ctx, sc, resolver, value, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.SSHIdentity)
+			r0 = ret.Get(0).(*models.PublicKey)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.SSHIdentityResolver, string, ...store.QueryOption) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.PublicKeyResolver, string, ...store.QueryOption) error); ok {
 		r1 = returnFunc(ctx, sc, resolver, value, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -5632,23 +5693,23 @@ func (_mock *MockStore) SSHIdentityResolve(ctx context.Context, sc scope.Scope,
 	return r0, r1
 }
 
-// MockStore_SSHIdentityResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityResolve'
-type MockStore_SSHIdentityResolve_Call struct {
+// MockStore_PublicKeyResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyResolve'
+type MockStore_PublicKeyResolve_Call struct {
 	*mock.Call
 }
 
-// SSHIdentityResolve is a helper method to define mock.On call
+// PublicKeyResolve is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
-//   - resolver store.SSHIdentityResolver
+//   - resolver store.PublicKeyResolver
 //   - value string
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) SSHIdentityResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_SSHIdentityResolve_Call {
-	return &MockStore_SSHIdentityResolve_Call{Call: _e.mock.On("SSHIdentityResolve",
+func (_e *MockStore_Expecter) PublicKeyResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_PublicKeyResolve_Call {
+	return &MockStore_PublicKeyResolve_Call{Call: _e.mock.On("PublicKeyResolve",
 		append([]any{ctx, sc, resolver, value}, opts...)...)}
 }
 
-func (_c *MockStore_SSHIdentityResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.SSHIdentityResolver, value string, opts ...store.QueryOption)) *MockStore_SSHIdentityResolve_Call {
+func (_c *MockStore_PublicKeyResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.PublicKeyResolver, value string, opts ...store.QueryOption)) *MockStore_PublicKeyResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5658,9 +5719,9 @@ func (_c *MockStore_SSHIdentityResolve_Call) Run(run func(ctx context.Context, s
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 store.SSHIdentityResolver
+		var arg2 store.PublicKeyResolver
 		if args[2] != nil {
-			arg2 = args[2].(store.SSHIdentityResolver)
+			arg2 = args[2].(store.PublicKeyResolver)
 		}
 		var arg3 string
 		if args[3] != nil {
@@ -5683,180 +5744,177 @@ func (_c *MockStore_SSHIdentityResolve_Call) Run(run func(ctx context.Context, s
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityResolve_Call) Return(sSHIdentity *models.SSHIdentity, err error) *MockStore_SSHIdentityResolve_Call {
-	_c.Call.Return(sSHIdentity, err)
+func (_c *MockStore_PublicKeyResolve_Call) Return(publicKey *models.PublicKey, err error) *MockStore_PublicKeyResolve_Call {
+	_c.Call.Return(publicKey, err)
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.SSHIdentityResolver, value string, opts ...store.QueryOption) (*models.SSHIdentity, error)) *MockStore_SSHIdentityResolve_Call {
+func (_c *MockStore_PublicKeyResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.PublicKeyResolver, value string, opts ...store.QueryOption) (*models.PublicKey, error)) *MockStore_PublicKeyResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHIdentityTouchLastUsed provides a mock function for the type MockStore
-func (_mock *MockStore) SSHIdentityTouchLastUsed(ctx context.Context, tenantID string, fingerprint string) error {
-	ret := _mock.Called(ctx, tenantID, fingerprint)
+// PublicKeyUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) PublicKeyUpdate(ctx context.Context, publicKey *models.PublicKey) error {
+	ret := _mock.Called(ctx, publicKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHIdentityTouchLastUsed")
+		panic("no return value specified for PublicKeyUpdate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, tenantID, fingerprint)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.PublicKey) error); ok {
+		r0 = returnFunc(ctx, publicKey)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_SSHIdentityTouchLastUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityTouchLastUsed'
-type MockStore_SSHIdentityTouchLastUsed_Call struct {
+// MockStore_PublicKeyUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeyUpdate'
+type MockStore_PublicKeyUpdate_Call struct {
 	*mock.Call
 }
 
-// SSHIdentityTouchLastUsed is a helper method to define mock.On call
+// PublicKeyUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantID string
-//   - fingerprint string
-func (_e *MockStore_Expecter) SSHIdentityTouchLastUsed(ctx any, tenantID any, fingerprint any) *MockStore_SSHIdentityTouchLastUsed_Call {
-	return &MockStore_SSHIdentityTouchLastUsed_Call{Call: _e.mock.On("SSHIdentityTouchLastUsed", ctx, tenantID, fingerprint)}
+//   - publicKey *models.PublicKey
+func (_e *MockStore_Expecter) PublicKeyUpdate(ctx any, publicKey any) *MockStore_PublicKeyUpdate_Call {
+	return &MockStore_PublicKeyUpdate_Call{Call: _e.mock.On("PublicKeyUpdate", ctx, publicKey)}
 }
 
-func (_c *MockStore_SSHIdentityTouchLastUsed_Call) Run(run func(ctx context.Context, tenantID string, fingerprint string)) *MockStore_SSHIdentityTouchLastUsed_Call {
+func (_c *MockStore_PublicKeyUpdate_Call) Run(run func(ctx context.Context, publicKey *models.PublicKey)) *MockStore_PublicKeyUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *models.PublicKey
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*models.PublicKey)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityTouchLastUsed_Call) Return(err error) *MockStore_SSHIdentityTouchLastUsed_Call {
+func (_c *MockStore_PublicKeyUpdate_Call) Return(err error) *MockStore_PublicKeyUpdate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityTouchLastUsed_Call) RunAndReturn(run func(ctx context.Context, tenantID string, fingerprint string) error) *MockStore_SSHIdentityTouchLastUsed_Call {
+func (_c *MockStore_PublicKeyUpdate_Call) RunAndReturn(run func(ctx context.Context, publicKey *models.PublicKey) error) *MockStore_PublicKeyUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHIdentityTouchReauth provides a mock function for the type MockStore
-func (_mock *MockStore) SSHIdentityTouchReauth(ctx context.Context, tenantID string, fingerprint string) error {
-	ret := _mock.Called(ctx, tenantID, fingerprint)
+// SCIMGroupCreate provides a mock function for the type MockStore
+func (_mock *MockStore) SCIMGroupCreate(ctx context.Context, group *models.SCIMGroup) (string, error) {
+	ret := _mock.Called(ctx, group)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHIdentityTouchReauth")
+		panic("no return value specified for SCIMGroupCreate")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, tenantID, fingerprint)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SCIMGroup) (string, error)); ok {
+		return returnFunc(ctx, group)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SCIMGroup) string); ok {
+		r0 = returnFunc(ctx, group)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.SCIMGroup) error); ok {
+		r1 = returnFunc(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_SSHIdentityTouchReauth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityTouchReauth'
-type MockStore_SSHIdentityTouchReauth_Call struct {
+// MockStore_SCIMGroupCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SCIMGroupCreate'
+type MockStore_SCIMGroupCreate_Call struct {
 	*mock.Call
 }
 
-// SSHIdentityTouchReauth is a helper method to define mock.On call
+// SCIMGroupCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantID string
-//   - fingerprint string
-func (_e *MockStore_Expecter) SSHIdentityTouchReauth(ctx any, tenantID any, fingerprint any) *MockStore_SSHIdentityTouchReauth_Call {
-	return &MockStore_SSHIdentityTouchReauth_Call{Call: _e.mock.On("SSHIdentityTouchReauth", ctx, tenantID, fingerprint)}
+//   - group *models.SCIMGroup
+func (_e *MockStore_Expecter) SCIMGroupCreate(ctx any, group any) *MockStore_SCIMGroupCreate_Call {
+	return &MockStore_SCIMGroupCreate_Call{Call: _e.mock.On("SCIMGroupCreate", ctx, group)}
 }
 
-func (_c *MockStore_SSHIdentityTouchReauth_Call) Run(run func(ctx context.Context, tenantID string, fingerprint string)) *MockStore_SSHIdentityTouchReauth_Call {
+func (_c *MockStore_SCIMGroupCreate_Call) Run(run func(ctx context.Context, group *models.SCIMGroup)) *MockStore_SCIMGroupCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *models.SCIMGroup
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*models.SCIMGroup)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityTouchReauth_Call) Return(err error) *MockStore_SSHIdentityTouchReauth_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_SCIMGroupCreate_Call) Return(s string, err error) *MockStore_SCIMGroupCreate_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityTouchReauth_Call) RunAndReturn(run func(ctx context.Context, tenantID string, fingerprint string) error) *MockStore_SSHIdentityTouchReauth_Call {
+func (_c *MockStore_SCIMGroupCreate_Call) RunAndReturn(run func(ctx context.Context, group *models.SCIMGroup) (string, error)) *MockStore_SCIMGroupCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SSHIdentityUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) SSHIdentityUpdate(ctx context.Context, identity *models.SSHIdentity) error {
-	ret := _mock.Called(ctx, identity)
+// SCIMGroupDelete provides a mock function for the type MockStore
+func (_mock *MockStore) SCIMGroupDelete(ctx context.Context, group *models.SCIMGroup) error {
+	ret := _mock.Called(ctx, group)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SSHIdentityUpdate")
+		panic("no return value specified for SCIMGroupDelete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHIdentity) error); ok {
-		r0 = returnFunc(ctx, identity)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SCIMGroup) error); ok {
+		r0 = returnFunc(ctx, group)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_SSHIdentityUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityUpdate'
-type MockStore_SSHIdentityUpdate_Call struct {
+// MockStore_SCIMGroupDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SCIMGroupDelete'
+type MockStore_SCIMGroupDelete_Call struct {
 	*mock.Call
 }
 
-// SSHIdentityUpdate is a helper method to define mock.On call
+// SCIMGroupDelete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - identity *models.SSHIdentity
-func (_e *MockStore_Expecter) SSHIdentityUpdate(ctx any, identity any) *MockStore_SSHIdentityUpdate_Call {
-	return &MockStore_SSHIdentityUpdate_Call{Call: _e.mock.On("SSHIdentityUpdate", ctx, identity)}
+//   - group *models.SCIMGroup
+func (_e *MockStore_Expecter) SCIMGroupDelete(ctx any, group any) *MockStore_SCIMGroupDelete_Call {
+	return &MockStore_SCIMGroupDelete_Call{Call: _e.mock.On("SCIMGroupDelete", ctx, group)}
 }
 
-func (_c *MockStore_SSHIdentityUpdate_Call) Run(run func(ctx context.Context, identity *models.SSHIdentity)) *MockStore_SSHIdentityUpdate_Call {
+func (_c *MockStore_SCIMGroupDelete_Call) Run(run func(ctx context.Context, group *models.SCIMGroup)) *MockStore_SCIMGroupDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.SSHIdentity
+		var arg1 *models.SCIMGroup
 		if args[1] != nil {
-			arg1 = args[1].(*models.SSHIdentity)
+			arg1 = args[1].(*models.SCIMGroup)
 		}
 		run(
 			arg0,
@@ -5866,137 +5924,148 @@ func (_c *MockStore_SSHIdentityUpdate_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityUpdate_Call) Return(err error) *MockStore_SSHIdentityUpdate_Call {
+func (_c *MockStore_SCIMGroupDelete_Call) Return(err error) *MockStore_SCIMGroupDelete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SSHIdentityUpdate_Call) RunAndReturn(run func(ctx context.Context, identity *models.SSHIdentity) error) *MockStore_SSHIdentityUpdate_Call {
+func (_c *MockStore_SCIMGroupDelete_Call) RunAndReturn(run func(ctx context.Context, group *models.SCIMGroup) error) *MockStore_SCIMGroupDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ServiceAccountList provides a mock function for the type MockStore
-func (_mock *MockStore) ServiceAccountList(ctx context.Context, tenantID string) ([]models.ServiceAccount, int, error) {
-	ret := _mock.Called(ctx, tenantID)
+// SCIMGroupList provides a mock function for the type MockStore
+func (_mock *MockStore) SCIMGroupList(ctx context.Context, opts ...store.QueryOption) ([]models.SCIMGroup, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, opts)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for ServiceAccountList")
+		panic("no return value specified for SCIMGroupList")
 	}
 
-	var r0 []models.ServiceAccount
+	var r0 []models.SCIMGroup
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.ServiceAccount, int, error)); ok {
-		return returnFunc(ctx, tenantID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) ([]models.SCIMGroup, int, error)); ok {
+		return returnFunc(ctx, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.ServiceAccount); ok {
-		r0 = returnFunc(ctx, tenantID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []models.SCIMGroup); ok {
+		r0 = returnFunc(ctx, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.ServiceAccount)
+			r0 = ret.Get(0).([]models.SCIMGroup)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) int); ok {
-		r1 = returnFunc(ctx, tenantID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, opts...)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = returnFunc(ctx, tenantID)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, opts...)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockStore_ServiceAccountList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServiceAccountList'
-type MockStore_ServiceAccountList_Call struct {
+// MockStore_SCIMGroupList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SCIMGroupList'
+type MockStore_SCIMGroupList_Call struct {
 	*mock.Call
 }
 
-// ServiceAccountList is a helper method to define mock.On call
+// SCIMGroupList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tenantID string
-func (_e *MockStore_Expecter) ServiceAccountList(ctx any, tenantID any) *MockStore_ServiceAccountList_Call {
-	return &MockStore_ServiceAccountList_Call{Call: _e.mock.On("ServiceAccountList", ctx, tenantID)}
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SCIMGroupList(ctx any, opts ...any) *MockStore_SCIMGroupList_Call {
+	return &MockStore_SCIMGroupList_Call{Call: _e.mock.On("SCIMGroupList",
+		append([]any{ctx}, opts...)...)}
 }
 
-func (_c *MockStore_ServiceAccountList_Call) Run(run func(ctx context.Context, tenantID string)) *MockStore_ServiceAccountList_Call {
+func (_c *MockStore_SCIMGroupList_Call) Run(run func(ctx context.Context, opts ...store.QueryOption)) *MockStore_SCIMGroupList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
+		var arg1 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]store.QueryOption)
 		}
+		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_ServiceAccountList_Call) Return(serviceAccounts []models.ServiceAccount, n int, err error) *MockStore_ServiceAccountList_Call {
-	_c.Call.Return(serviceAccounts, n, err)
+func (_c *MockStore_SCIMGroupList_Call) Return(groups []models.SCIMGroup, n int, err error) *MockStore_SCIMGroupList_Call {
+	_c.Call.Return(groups, n, err)
 	return _c
 }
 
-func (_c *MockStore_ServiceAccountList_Call) RunAndReturn(run func(ctx context.Context, tenantID string) ([]models.ServiceAccount, int, error)) *MockStore_ServiceAccountList_Call {
+func (_c *MockStore_SCIMGroupList_Call) RunAndReturn(run func(ctx context.Context, opts ...store.QueryOption) ([]models.SCIMGroup, int, error)) *MockStore_SCIMGroupList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionCreate provides a mock function for the type MockStore
-func (_mock *MockStore) SessionCreate(ctx context.Context, session models.Session) (string, error) {
-	ret := _mock.Called(ctx, session)
+// SCIMGroupListByMember provides a mock function for the type MockStore
+func (_mock *MockStore) SCIMGroupListByMember(ctx context.Context, userID string) ([]models.SCIMGroup, error) {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionCreate")
+		panic("no return value specified for SCIMGroupListByMember")
 	}
 
-	var r0 string
+	var r0 []models.SCIMGroup
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Session) (string, error)); ok {
-		return returnFunc(ctx, session)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.SCIMGroup, error)); ok {
+		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Session) string); ok {
-		r0 = returnFunc(ctx, session)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.SCIMGroup); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SCIMGroup)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Session) error); ok {
-		r1 = returnFunc(ctx, session)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_SessionCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionCreate'
-type MockStore_SessionCreate_Call struct {
+// MockStore_SCIMGroupListByMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SCIMGroupListByMember'
+type MockStore_SCIMGroupListByMember_Call struct {
 	*mock.Call
 }
 
-// SessionCreate is a helper method to define mock.On call
+// SCIMGroupListByMember is a helper method to define mock.On call
 //   - ctx context.Context
-//   - session models.Session
-func (_e *MockStore_Expecter) SessionCreate(ctx any, session any) *MockStore_SessionCreate_Call {
-	return &MockStore_SessionCreate_Call{Call: _e.mock.On("SessionCreate", ctx, session)}
+//   - userID string
+func (_e *MockStore_Expecter) SCIMGroupListByMember(ctx any, userID any) *MockStore_SCIMGroupListByMember_Call {
+	return &MockStore_SCIMGroupListByMember_Call{Call: _e.mock.On("SCIMGroupListByMember", ctx, userID)}
 }
 
-func (_c *MockStore_SessionCreate_Call) Run(run func(ctx context.Context, session models.Session)) *MockStore_SessionCreate_Call {
+func (_c *MockStore_SCIMGroupListByMember_Call) Run(run func(ctx context.Context, userID string)) *MockStore_SCIMGroupListByMember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.Session
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(models.Session)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -6006,120 +6075,128 @@ func (_c *MockStore_SessionCreate_Call) Run(run func(ctx context.Context, sessio
 	return _c
 }
 
-func (_c *MockStore_SessionCreate_Call) Return(s string, err error) *MockStore_SessionCreate_Call {
-	_c.Call.Return(s, err)
+func (_c *MockStore_SCIMGroupListByMember_Call) Return(sCIMGroups []models.SCIMGroup, err error) *MockStore_SCIMGroupListByMember_Call {
+	_c.Call.Return(sCIMGroups, err)
 	return _c
 }
 
-func (_c *MockStore_SessionCreate_Call) RunAndReturn(run func(ctx context.Context, session models.Session) (string, error)) *MockStore_SessionCreate_Call {
+func (_c *MockStore_SCIMGroupListByMember_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]models.SCIMGroup, error)) *MockStore_SCIMGroupListByMember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionDeleteMany provides a mock function for the type MockStore
-func (_mock *MockStore) SessionDeleteMany(ctx context.Context, uids []string) (int64, error) {
-	ret := _mock.Called(ctx, uids)
+// SCIMGroupResolve provides a mock function for the type MockStore
+func (_mock *MockStore) SCIMGroupResolve(ctx context.Context, resolver store.SCIMGroupResolver, value string) (*models.SCIMGroup, error) {
+	ret := _mock.Called(ctx, resolver, value)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionDeleteMany")
+		panic("no return value specified for SCIMGroupResolve")
 	}
 
-	var r0 int64
+	var r0 *models.SCIMGroup
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
-		return returnFunc(ctx, uids)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.SCIMGroupResolver, string) (*models.SCIMGroup, error)); ok {
+		return returnFunc(ctx, resolver, value)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
-		r0 = returnFunc(ctx, uids)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.SCIMGroupResolver, string) *models.SCIMGroup); ok {
+		r0 = returnFunc(ctx, resolver, value)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SCIMGroup)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = returnFunc(ctx, uids)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, store.SCIMGroupResolver, string) error); ok {
+		r1 = returnFunc(ctx, resolver, value)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_SessionDeleteMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionDeleteMany'
-type MockStore_SessionDeleteMany_Call struct {
+// MockStore_SCIMGroupResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SCIMGroupResolve'
+type MockStore_SCIMGroupResolve_Call struct {
 	*mock.Call
 }
 
-// SessionDeleteMany is a helper method to define mock.On call
+// SCIMGroupResolve is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uids []string
-func (_e *MockStore_Expecter) SessionDeleteMany(ctx any, uids any) *MockStore_SessionDeleteMany_Call {
-	return &MockStore_SessionDeleteMany_Call{Call: _e.mock.On("SessionDeleteMany", ctx, uids)}
+//   - resolver store.SCIMGroupResolver
+//   - value string
+func (_e *MockStore_Expecter) SCIMGroupResolve(ctx any, resolver any, value any) *MockStore_SCIMGroupResolve_Call {
+	return &MockStore_SCIMGroupResolve_Call{Call: _e.mock.On("SCIMGroupResolve", ctx, resolver, value)}
 }
 
-func (_c *MockStore_SessionDeleteMany_Call) Run(run func(ctx context.Context, uids []string)) *MockStore_SessionDeleteMany_Call {
+func (_c *MockStore_SCIMGroupResolve_Call) Run(run func(ctx context.Context, resolver store.SCIMGroupResolver, value string)) *MockStore_SCIMGroupResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 store.SCIMGroupResolver
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(store.SCIMGroupResolver)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SessionDeleteMany_Call) Return(n int64, err error) *MockStore_SessionDeleteMany_Call {
-	_c.Call.Return(n, err)
+func (_c *MockStore_SCIMGroupResolve_Call) Return(sCIMGroup *models.SCIMGroup, err error) *MockStore_SCIMGroupResolve_Call {
+	_c.Call.Return(sCIMGroup, err)
 	return _c
 }
 
-func (_c *MockStore_SessionDeleteMany_Call) RunAndReturn(run func(ctx context.Context, uids []string) (int64, error)) *MockStore_SessionDeleteMany_Call {
+func (_c *MockStore_SCIMGroupResolve_Call) RunAndReturn(run func(ctx context.Context, resolver store.SCIMGroupResolver, value string) (*models.SCIMGroup, error)) *MockStore_SCIMGroupResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionEventsCreate provides a mock function for the type MockStore
-func (_mock *MockStore) SessionEventsCreate(ctx context.Context, event *models.SessionEvent) error {
-	ret := _mock.Called(ctx, event)
+// SCIMGroupUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) SCIMGroupUpdate(ctx context.Context, group *models.SCIMGroup) error {
+	ret := _mock.Called(ctx, group)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionEventsCreate")
+		panic("no return value specified for SCIMGroupUpdate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SessionEvent) error); ok {
-		r0 = returnFunc(ctx, event)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SCIMGroup) error); ok {
+		r0 = returnFunc(ctx, group)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_SessionEventsCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsCreate'
-type MockStore_SessionEventsCreate_Call struct {
+// MockStore_SCIMGroupUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SCIMGroupUpdate'
+type MockStore_SCIMGroupUpdate_Call struct {
 	*mock.Call
 }
 
-// SessionEventsCreate is a helper method to define mock.On call
+// SCIMGroupUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - event *models.SessionEvent
-func (_e *MockStore_Expecter) SessionEventsCreate(ctx any, event any) *MockStore_SessionEventsCreate_Call {
-	return &MockStore_SessionEventsCreate_Call{Call: _e.mock.On("SessionEventsCreate", ctx, event)}
+//   - group *models.SCIMGroup
+func (_e *MockStore_Expecter) SCIMGroupUpdate(ctx any, group any) *MockStore_SCIMGroupUpdate_Call {
+	return &MockStore_SCIMGroupUpdate_Call{Call: _e.mock.On("SCIMGroupUpdate", ctx, group)}
 }
 
-func (_c *MockStore_SessionEventsCreate_Call) Run(run func(ctx context.Context, event *models.SessionEvent)) *MockStore_SessionEventsCreate_Call {
+func (_c *MockStore_SCIMGroupUpdate_Call) Run(run func(ctx context.Context, group *models.SCIMGroup)) *MockStore_SCIMGroupUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.SessionEvent
+		var arg1 *models.SCIMGroup
 		if args[1] != nil {
-			arg1 = args[1].(*models.SessionEvent)
+			arg1 = args[1].(*models.SCIMGroup)
 		}
 		run(
 			arg0,
@@ -6129,54 +6206,63 @@ func (_c *MockStore_SessionEventsCreate_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockStore_SessionEventsCreate_Call) Return(err error) *MockStore_SessionEventsCreate_Call {
+func (_c *MockStore_SCIMGroupUpdate_Call) Return(err error) *MockStore_SCIMGroupUpdate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SessionEventsCreate_Call) RunAndReturn(run func(ctx context.Context, event *models.SessionEvent) error) *MockStore_SessionEventsCreate_Call {
+func (_c *MockStore_SCIMGroupUpdate_Call) RunAndReturn(run func(ctx context.Context, group *models.SCIMGroup) error) *MockStore_SCIMGroupUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionEventsCreateMany provides a mock function for the type MockStore
-func (_mock *MockStore) SessionEventsCreateMany(ctx context.Context, events []models.SessionEvent) error {
-	ret := _mock.Called(ctx, events)
+// SSHApprovalCleanup provides a mock function for the type MockStore
+func (_mock *MockStore) SSHApprovalCleanup(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionEventsCreateMany")
+		panic("no return value specified for SSHApprovalCleanup")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.SessionEvent) error); ok {
-		r0 = returnFunc(ctx, events)
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_SessionEventsCreateMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsCreateMany'
-type MockStore_SessionEventsCreateMany_Call struct {
+// MockStore_SSHApprovalCleanup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHApprovalCleanup'
+type MockStore_SSHApprovalCleanup_Call struct {
 	*mock.Call
 }
 
-// SessionEventsCreateMany is a helper method to define mock.On call
+// SSHApprovalCleanup is a helper method to define mock.On call
 //   - ctx context.Context
-//   - events []models.SessionEvent
-func (_e *MockStore_Expecter) SessionEventsCreateMany(ctx any, events any) *MockStore_SessionEventsCreateMany_Call {
-	return &MockStore_SessionEventsCreateMany_Call{Call: _e.mock.On("SessionEventsCreateMany", ctx, events)}
+//   - before time.Time
+func (_e *MockStore_Expecter) SSHApprovalCleanup(ctx any, before any) *MockStore_SSHApprovalCleanup_Call {
+	return &MockStore_SSHApprovalCleanup_Call{Call: _e.mock.On("SSHApprovalCleanup", ctx, before)}
 }
 
-func (_c *MockStore_SessionEventsCreateMany_Call) Run(run func(ctx context.Context, events []models.SessionEvent)) *MockStore_SessionEventsCreateMany_Call {
+func (_c *MockStore_SSHApprovalCleanup_Call) Run(run func(ctx context.Context, before time.Time)) *MockStore_SSHApprovalCleanup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []models.SessionEvent
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].([]models.SessionEvent)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
@@ -6186,215 +6272,2836 @@ func (_c *MockStore_SessionEventsCreateMany_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockStore_SessionEventsCreateMany_Call) Return(err error) *MockStore_SessionEventsCreateMany_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_SSHApprovalCleanup_Call) Return(n int64, err error) *MockStore_SSHApprovalCleanup_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockStore_SessionEventsCreateMany_Call) RunAndReturn(run func(ctx context.Context, events []models.SessionEvent) error) *MockStore_SessionEventsCreateMany_Call {
+func (_c *MockStore_SSHApprovalCleanup_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockStore_SSHApprovalCleanup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionEventsDelete provides a mock function for the type MockStore
-func (_mock *MockStore) SessionEventsDelete(ctx context.Context, uid models.UID, seat int, event models.SessionEventType) error {
-	ret := _mock.Called(ctx, uid, seat, event)
+// SSHApprovalCreate provides a mock function for the type MockStore
+func (_mock *MockStore) SSHApprovalCreate(ctx context.Context, approval *models.SSHApproval) error {
+	ret := _mock.Called(ctx, approval)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionEventsDelete")
+		panic("no return value specified for SSHApprovalCreate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, models.SessionEventType) error); ok {
-		r0 = returnFunc(ctx, uid, seat, event)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHApproval) error); ok {
+		r0 = returnFunc(ctx, approval)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_SessionEventsDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsDelete'
-type MockStore_SessionEventsDelete_Call struct {
+// MockStore_SSHApprovalCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHApprovalCreate'
+type MockStore_SSHApprovalCreate_Call struct {
 	*mock.Call
 }
 
-// SessionEventsDelete is a helper method to define mock.On call
+// SSHApprovalCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid models.UID
-//   - seat int
-//   - event models.SessionEventType
-func (_e *MockStore_Expecter) SessionEventsDelete(ctx any, uid any, seat any, event any) *MockStore_SessionEventsDelete_Call {
-	return &MockStore_SessionEventsDelete_Call{Call: _e.mock.On("SessionEventsDelete", ctx, uid, seat, event)}
+//   - approval *models.SSHApproval
+func (_e *MockStore_Expecter) SSHApprovalCreate(ctx any, approval any) *MockStore_SSHApprovalCreate_Call {
+	return &MockStore_SSHApprovalCreate_Call{Call: _e.mock.On("SSHApprovalCreate", ctx, approval)}
 }
 
-func (_c *MockStore_SessionEventsDelete_Call) Run(run func(ctx context.Context, uid models.UID, seat int, event models.SessionEventType)) *MockStore_SessionEventsDelete_Call {
+func (_c *MockStore_SSHApprovalCreate_Call) Run(run func(ctx context.Context, approval *models.SSHApproval)) *MockStore_SSHApprovalCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.UID
+		var arg1 *models.SSHApproval
 		if args[1] != nil {
-			arg1 = args[1].(models.UID)
-		}
-		var arg2 int
-		if args[2] != nil {
-			arg2 = args[2].(int)
-		}
-		var arg3 models.SessionEventType
-		if args[3] != nil {
-			arg3 = args[3].(models.SessionEventType)
+			arg1 = args[1].(*models.SSHApproval)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SessionEventsDelete_Call) Return(err error) *MockStore_SessionEventsDelete_Call {
+func (_c *MockStore_SSHApprovalCreate_Call) Return(err error) *MockStore_SSHApprovalCreate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SessionEventsDelete_Call) RunAndReturn(run func(ctx context.Context, uid models.UID, seat int, event models.SessionEventType) error) *MockStore_SessionEventsDelete_Call {
+func (_c *MockStore_SSHApprovalCreate_Call) RunAndReturn(run func(ctx context.Context, approval *models.SSHApproval) error) *MockStore_SSHApprovalCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionEventsList provides a mock function for the type MockStore
-func (_mock *MockStore) SessionEventsList(ctx context.Context, uid models.UID, seat int, event models.SessionEventType, opts ...store.QueryOption) ([]models.SessionEvent, int, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, uid, seat, event, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, uid, seat, event)
-	}
-	ret := tmpRet
+// SSHApprovalDecide provides a mock function for the type MockStore
+func (_mock *MockStore) SSHApprovalDecide(ctx context.Context, code string, state models.SSHApprovalState, userID string, now time.Time) (bool, error) {
+	ret := _mock.Called(ctx, code, state, userID, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionEventsList")
+		panic("no return value specified for SSHApprovalDecide")
 	}
 
-	var r0 []models.SessionEvent
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, models.SessionEventType, ...store.QueryOption) ([]models.SessionEvent, int, error)); ok {
-		return returnFunc(ctx, uid, seat, event, opts...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, models.SessionEventType, ...store.QueryOption) []models.SessionEvent); ok {
-		r0 = returnFunc(ctx, uid, seat, event, opts...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.SessionEvent)
-		}
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.SSHApprovalState, string, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, code, state, userID, now)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.UID, int, models.SessionEventType, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, uid, seat, event, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.SSHApprovalState, string, time.Time) bool); ok {
+		r0 = returnFunc(ctx, code, state, userID, now)
 	} else {
-		r1 = ret.Get(1).(int)
+		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, models.UID, int, models.SessionEventType, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, uid, seat, event, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, models.SSHApprovalState, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, code, state, userID, now)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_SessionEventsList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsList'
-type MockStore_SessionEventsList_Call struct {
+// MockStore_SSHApprovalDecide_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHApprovalDecide'
+type MockStore_SSHApprovalDecide_Call struct {
 	*mock.Call
 }
 
-// SessionEventsList is a helper method to define mock.On call
+// SSHApprovalDecide is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid models.UID
-//   - seat int
-//   - event models.SessionEventType
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) SessionEventsList(ctx any, uid any, seat any, event any, opts ...any) *MockStore_SessionEventsList_Call {
-	return &MockStore_SessionEventsList_Call{Call: _e.mock.On("SessionEventsList",
-		append([]any{ctx, uid, seat, event}, opts...)...)}
+//   - code string
+//   - state models.SSHApprovalState
+//   - userID string
+//   - now time.Time
+func (_e *MockStore_Expecter) SSHApprovalDecide(ctx any, code any, state any, userID any, now any) *MockStore_SSHApprovalDecide_Call {
+	return &MockStore_SSHApprovalDecide_Call{Call: _e.mock.On("SSHApprovalDecide", ctx, code, state, userID, now)}
 }
 
-func (_c *MockStore_SessionEventsList_Call) Run(run func(ctx context.Context, uid models.UID, seat int, event models.SessionEventType, opts ...store.QueryOption)) *MockStore_SessionEventsList_Call {
+func (_c *MockStore_SSHApprovalDecide_Call) Run(run func(ctx context.Context, code string, state models.SSHApprovalState, userID string, now time.Time)) *MockStore_SSHApprovalDecide_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.UID
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(models.UID)
+			arg1 = args[1].(string)
 		}
-		var arg2 int
+		var arg2 models.SSHApprovalState
 		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg2 = args[2].(models.SSHApprovalState)
 		}
-		var arg3 models.SessionEventType
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(models.SessionEventType)
+			arg3 = args[3].(string)
 		}
-		var arg4 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 4 {
-			variadicArgs = args[4].([]store.QueryOption)
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
 		}
-		arg4 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4...,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SessionEventsList_Call) Return(sessionEvents []models.SessionEvent, n int, err error) *MockStore_SessionEventsList_Call {
-	_c.Call.Return(sessionEvents, n, err)
+func (_c *MockStore_SSHApprovalDecide_Call) Return(b bool, err error) *MockStore_SSHApprovalDecide_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockStore_SessionEventsList_Call) RunAndReturn(run func(ctx context.Context, uid models.UID, seat int, event models.SessionEventType, opts ...store.QueryOption) ([]models.SessionEvent, int, error)) *MockStore_SessionEventsList_Call {
+func (_c *MockStore_SSHApprovalDecide_Call) RunAndReturn(run func(ctx context.Context, code string, state models.SSHApprovalState, userID string, now time.Time) (bool, error)) *MockStore_SSHApprovalDecide_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionList provides a mock function for the type MockStore
-func (_mock *MockStore) SessionList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Session, int, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, sc)
-	}
-	ret := tmpRet
+// SSHApprovalGet provides a mock function for the type MockStore
+func (_mock *MockStore) SSHApprovalGet(ctx context.Context, code string, now time.Time) (*models.SSHApproval, error) {
+	ret := _mock.Called(ctx, code, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionList")
+		panic("no return value specified for SSHApprovalGet")
 	}
 
-	var r0 []models.Session
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.Session, int, error)); ok {
-		return returnFunc(ctx, sc, opts...)
+	var r0 *models.SSHApproval
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (*models.SSHApproval, error)); ok {
+		return returnFunc(ctx, code, now)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.Session); ok {
-		r0 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) *models.SSHApproval); ok {
+		r0 = returnFunc(ctx, code, now)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Session)
+			r0 = ret.Get(0).(*models.SSHApproval)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, code, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SSHApprovalGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHApprovalGet'
+type MockStore_SSHApprovalGet_Call struct {
+	*mock.Call
+}
+
+// SSHApprovalGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - now time.Time
+func (_e *MockStore_Expecter) SSHApprovalGet(ctx any, code any, now any) *MockStore_SSHApprovalGet_Call {
+	return &MockStore_SSHApprovalGet_Call{Call: _e.mock.On("SSHApprovalGet", ctx, code, now)}
+}
+
+func (_c *MockStore_SSHApprovalGet_Call) Run(run func(ctx context.Context, code string, now time.Time)) *MockStore_SSHApprovalGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHApprovalGet_Call) Return(sSHApproval *models.SSHApproval, err error) *MockStore_SSHApprovalGet_Call {
+	_c.Call.Return(sSHApproval, err)
+	return _c
+}
+
+func (_c *MockStore_SSHApprovalGet_Call) RunAndReturn(run func(ctx context.Context, code string, now time.Time) (*models.SSHApproval, error)) *MockStore_SSHApprovalGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHIdentityConsume provides a mock function for the type MockStore
+func (_mock *MockStore) SSHIdentityConsume(ctx context.Context, tenantID string, fingerprint string) (bool, error) {
+	ret := _mock.Called(ctx, tenantID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHIdentityConsume")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, tenantID, fingerprint)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, tenantID, fingerprint)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, tenantID, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SSHIdentityConsume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityConsume'
+type MockStore_SSHIdentityConsume_Call struct {
+	*mock.Call
+}
+
+// SSHIdentityConsume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - fingerprint string
+func (_e *MockStore_Expecter) SSHIdentityConsume(ctx any, tenantID any, fingerprint any) *MockStore_SSHIdentityConsume_Call {
+	return &MockStore_SSHIdentityConsume_Call{Call: _e.mock.On("SSHIdentityConsume", ctx, tenantID, fingerprint)}
+}
+
+func (_c *MockStore_SSHIdentityConsume_Call) Run(run func(ctx context.Context, tenantID string, fingerprint string)) *MockStore_SSHIdentityConsume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityConsume_Call) Return(b bool, err error) *MockStore_SSHIdentityConsume_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityConsume_Call) RunAndReturn(run func(ctx context.Context, tenantID string, fingerprint string) (bool, error)) *MockStore_SSHIdentityConsume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHIdentityCreate provides a mock function for the type MockStore
+func (_mock *MockStore) SSHIdentityCreate(ctx context.Context, identity *models.SSHIdentity) (string, error) {
+	ret := _mock.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHIdentityCreate")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHIdentity) (string, error)); ok {
+		return returnFunc(ctx, identity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHIdentity) string); ok {
+		r0 = returnFunc(ctx, identity)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.SSHIdentity) error); ok {
+		r1 = returnFunc(ctx, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SSHIdentityCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityCreate'
+type MockStore_SSHIdentityCreate_Call struct {
+	*mock.Call
+}
+
+// SSHIdentityCreate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identity *models.SSHIdentity
+func (_e *MockStore_Expecter) SSHIdentityCreate(ctx any, identity any) *MockStore_SSHIdentityCreate_Call {
+	return &MockStore_SSHIdentityCreate_Call{Call: _e.mock.On("SSHIdentityCreate", ctx, identity)}
+}
+
+func (_c *MockStore_SSHIdentityCreate_Call) Run(run func(ctx context.Context, identity *models.SSHIdentity)) *MockStore_SSHIdentityCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.SSHIdentity
+		if args[1] != nil {
+			arg1 = args[1].(*models.SSHIdentity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityCreate_Call) Return(s string, err error) *MockStore_SSHIdentityCreate_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityCreate_Call) RunAndReturn(run func(ctx context.Context, identity *models.SSHIdentity) (string, error)) *MockStore_SSHIdentityCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHIdentityDelete provides a mock function for the type MockStore
+func (_mock *MockStore) SSHIdentityDelete(ctx context.Context, identity *models.SSHIdentity) error {
+	ret := _mock.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHIdentityDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHIdentity) error); ok {
+		r0 = returnFunc(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SSHIdentityDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityDelete'
+type MockStore_SSHIdentityDelete_Call struct {
+	*mock.Call
+}
+
+// SSHIdentityDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identity *models.SSHIdentity
+func (_e *MockStore_Expecter) SSHIdentityDelete(ctx any, identity any) *MockStore_SSHIdentityDelete_Call {
+	return &MockStore_SSHIdentityDelete_Call{Call: _e.mock.On("SSHIdentityDelete", ctx, identity)}
+}
+
+func (_c *MockStore_SSHIdentityDelete_Call) Run(run func(ctx context.Context, identity *models.SSHIdentity)) *MockStore_SSHIdentityDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.SSHIdentity
+		if args[1] != nil {
+			arg1 = args[1].(*models.SSHIdentity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityDelete_Call) Return(err error) *MockStore_SSHIdentityDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityDelete_Call) RunAndReturn(run func(ctx context.Context, identity *models.SSHIdentity) error) *MockStore_SSHIdentityDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHIdentityList provides a mock function for the type MockStore
+func (_mock *MockStore) SSHIdentityList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.SSHIdentity, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHIdentityList")
+	}
+
+	var r0 []models.SSHIdentity
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.SSHIdentity, int, error)); ok {
+		return returnFunc(ctx, sc, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.SSHIdentity); ok {
+		r0 = returnFunc(ctx, sc, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SSHIdentity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, opts...)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_SSHIdentityList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityList'
+type MockStore_SSHIdentityList_Call struct {
+	*mock.Call
+}
+
+// SSHIdentityList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SSHIdentityList(ctx any, sc any, opts ...any) *MockStore_SSHIdentityList_Call {
+	return &MockStore_SSHIdentityList_Call{Call: _e.mock.On("SSHIdentityList",
+		append([]any{ctx, sc}, opts...)...)}
+}
+
+func (_c *MockStore_SSHIdentityList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_SSHIdentityList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityList_Call) Return(sSHIdentitys []models.SSHIdentity, n int, err error) *MockStore_SSHIdentityList_Call {
+	_c.Call.Return(sSHIdentitys, n, err)
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.SSHIdentity, int, error)) *MockStore_SSHIdentityList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHIdentityResolve provides a mock function for the type MockStore
+func (_mock *MockStore) SSHIdentityResolve(ctx context.Context, sc scope.Scope, resolver store.SSHIdentityResolver, value string, opts ...store.QueryOption) (*models.SSHIdentity, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, resolver, value)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHIdentityResolve")
+	}
+
+	var r0 *models.SSHIdentity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SSHIdentityResolver, string, ...store.QueryOption) (*models.SSHIdentity, error)); ok {
+		return returnFunc(ctx, sc, resolver, value, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SSHIdentityResolver, string, ...store.QueryOption) *models.SSHIdentity); ok {
+		r0 = returnFunc(ctx, sc, resolver, value, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SSHIdentity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.SSHIdentityResolver, string, ...store.QueryOption) error); ok {
+		r1 = returnFunc(ctx, sc, resolver, value, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SSHIdentityResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityResolve'
+type MockStore_SSHIdentityResolve_Call struct {
+	*mock.Call
+}
+
+// SSHIdentityResolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - resolver store.SSHIdentityResolver
+//   - value string
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SSHIdentityResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_SSHIdentityResolve_Call {
+	return &MockStore_SSHIdentityResolve_Call{Call: _e.mock.On("SSHIdentityResolve",
+		append([]any{ctx, sc, resolver, value}, opts...)...)}
+}
+
+func (_c *MockStore_SSHIdentityResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.SSHIdentityResolver, value string, opts ...store.QueryOption)) *MockStore_SSHIdentityResolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 store.SSHIdentityResolver
+		if args[2] != nil {
+			arg2 = args[2].(store.SSHIdentityResolver)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 4 {
+			variadicArgs = args[4].([]store.QueryOption)
+		}
+		arg4 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityResolve_Call) Return(sSHIdentity *models.SSHIdentity, err error) *MockStore_SSHIdentityResolve_Call {
+	_c.Call.Return(sSHIdentity, err)
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.SSHIdentityResolver, value string, opts ...store.QueryOption) (*models.SSHIdentity, error)) *MockStore_SSHIdentityResolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHIdentityTouchLastUsed provides a mock function for the type MockStore
+func (_mock *MockStore) SSHIdentityTouchLastUsed(ctx context.Context, tenantID string, fingerprint string) error {
+	ret := _mock.Called(ctx, tenantID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHIdentityTouchLastUsed")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, tenantID, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SSHIdentityTouchLastUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityTouchLastUsed'
+type MockStore_SSHIdentityTouchLastUsed_Call struct {
+	*mock.Call
+}
+
+// SSHIdentityTouchLastUsed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - fingerprint string
+func (_e *MockStore_Expecter) SSHIdentityTouchLastUsed(ctx any, tenantID any, fingerprint any) *MockStore_SSHIdentityTouchLastUsed_Call {
+	return &MockStore_SSHIdentityTouchLastUsed_Call{Call: _e.mock.On("SSHIdentityTouchLastUsed", ctx, tenantID, fingerprint)}
+}
+
+func (_c *MockStore_SSHIdentityTouchLastUsed_Call) Run(run func(ctx context.Context, tenantID string, fingerprint string)) *MockStore_SSHIdentityTouchLastUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityTouchLastUsed_Call) Return(err error) *MockStore_SSHIdentityTouchLastUsed_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityTouchLastUsed_Call) RunAndReturn(run func(ctx context.Context, tenantID string, fingerprint string) error) *MockStore_SSHIdentityTouchLastUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHIdentityTouchReauth provides a mock function for the type MockStore
+func (_mock *MockStore) SSHIdentityTouchReauth(ctx context.Context, tenantID string, fingerprint string) error {
+	ret := _mock.Called(ctx, tenantID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHIdentityTouchReauth")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, tenantID, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SSHIdentityTouchReauth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityTouchReauth'
+type MockStore_SSHIdentityTouchReauth_Call struct {
+	*mock.Call
+}
+
+// SSHIdentityTouchReauth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - fingerprint string
+func (_e *MockStore_Expecter) SSHIdentityTouchReauth(ctx any, tenantID any, fingerprint any) *MockStore_SSHIdentityTouchReauth_Call {
+	return &MockStore_SSHIdentityTouchReauth_Call{Call: _e.mock.On("SSHIdentityTouchReauth", ctx, tenantID, fingerprint)}
+}
+
+func (_c *MockStore_SSHIdentityTouchReauth_Call) Run(run func(ctx context.Context, tenantID string, fingerprint string)) *MockStore_SSHIdentityTouchReauth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityTouchReauth_Call) Return(err error) *MockStore_SSHIdentityTouchReauth_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityTouchReauth_Call) RunAndReturn(run func(ctx context.Context, tenantID string, fingerprint string) error) *MockStore_SSHIdentityTouchReauth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHIdentityUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) SSHIdentityUpdate(ctx context.Context, identity *models.SSHIdentity) error {
+	ret := _mock.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHIdentityUpdate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHIdentity) error); ok {
+		r0 = returnFunc(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SSHIdentityUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHIdentityUpdate'
+type MockStore_SSHIdentityUpdate_Call struct {
+	*mock.Call
+}
+
+// SSHIdentityUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identity *models.SSHIdentity
+func (_e *MockStore_Expecter) SSHIdentityUpdate(ctx any, identity any) *MockStore_SSHIdentityUpdate_Call {
+	return &MockStore_SSHIdentityUpdate_Call{Call: _e.mock.On("SSHIdentityUpdate", ctx, identity)}
+}
+
+func (_c *MockStore_SSHIdentityUpdate_Call) Run(run func(ctx context.Context, identity *models.SSHIdentity)) *MockStore_SSHIdentityUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.SSHIdentity
+		if args[1] != nil {
+			arg1 = args[1].(*models.SSHIdentity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityUpdate_Call) Return(err error) *MockStore_SSHIdentityUpdate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SSHIdentityUpdate_Call) RunAndReturn(run func(ctx context.Context, identity *models.SSHIdentity) error) *MockStore_SSHIdentityUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHUserCACreate provides a mock function for the type MockStore
+func (_mock *MockStore) SSHUserCACreate(ctx context.Context, ca *models.SSHUserCA) (string, error) {
+	ret := _mock.Called(ctx, ca)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHUserCACreate")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHUserCA) (string, error)); ok {
+		return returnFunc(ctx, ca)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHUserCA) string); ok {
+		r0 = returnFunc(ctx, ca)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.SSHUserCA) error); ok {
+		r1 = returnFunc(ctx, ca)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SSHUserCACreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHUserCACreate'
+type MockStore_SSHUserCACreate_Call struct {
+	*mock.Call
+}
+
+// SSHUserCACreate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ca *models.SSHUserCA
+func (_e *MockStore_Expecter) SSHUserCACreate(ctx any, ca any) *MockStore_SSHUserCACreate_Call {
+	return &MockStore_SSHUserCACreate_Call{Call: _e.mock.On("SSHUserCACreate", ctx, ca)}
+}
+
+func (_c *MockStore_SSHUserCACreate_Call) Run(run func(ctx context.Context, ca *models.SSHUserCA)) *MockStore_SSHUserCACreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.SSHUserCA
+		if args[1] != nil {
+			arg1 = args[1].(*models.SSHUserCA)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHUserCACreate_Call) Return(s string, err error) *MockStore_SSHUserCACreate_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockStore_SSHUserCACreate_Call) RunAndReturn(run func(ctx context.Context, ca *models.SSHUserCA) (string, error)) *MockStore_SSHUserCACreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHUserCADelete provides a mock function for the type MockStore
+func (_mock *MockStore) SSHUserCADelete(ctx context.Context, ca *models.SSHUserCA) error {
+	ret := _mock.Called(ctx, ca)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHUserCADelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHUserCA) error); ok {
+		r0 = returnFunc(ctx, ca)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SSHUserCADelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHUserCADelete'
+type MockStore_SSHUserCADelete_Call struct {
+	*mock.Call
+}
+
+// SSHUserCADelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ca *models.SSHUserCA
+func (_e *MockStore_Expecter) SSHUserCADelete(ctx any, ca any) *MockStore_SSHUserCADelete_Call {
+	return &MockStore_SSHUserCADelete_Call{Call: _e.mock.On("SSHUserCADelete", ctx, ca)}
+}
+
+func (_c *MockStore_SSHUserCADelete_Call) Run(run func(ctx context.Context, ca *models.SSHUserCA)) *MockStore_SSHUserCADelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.SSHUserCA
+		if args[1] != nil {
+			arg1 = args[1].(*models.SSHUserCA)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHUserCADelete_Call) Return(err error) *MockStore_SSHUserCADelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SSHUserCADelete_Call) RunAndReturn(run func(ctx context.Context, ca *models.SSHUserCA) error) *MockStore_SSHUserCADelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHUserCAList provides a mock function for the type MockStore
+func (_mock *MockStore) SSHUserCAList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.SSHUserCA, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHUserCAList")
+	}
+
+	var r0 []models.SSHUserCA
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.SSHUserCA, int, error)); ok {
+		return returnFunc(ctx, sc, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.SSHUserCA); ok {
+		r0 = returnFunc(ctx, sc, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SSHUserCA)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, opts...)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_SSHUserCAList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHUserCAList'
+type MockStore_SSHUserCAList_Call struct {
+	*mock.Call
+}
+
+// SSHUserCAList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SSHUserCAList(ctx any, sc any, opts ...any) *MockStore_SSHUserCAList_Call {
+	return &MockStore_SSHUserCAList_Call{Call: _e.mock.On("SSHUserCAList",
+		append([]any{ctx, sc}, opts...)...)}
+}
+
+func (_c *MockStore_SSHUserCAList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_SSHUserCAList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHUserCAList_Call) Return(sSHUserCAs []models.SSHUserCA, n int, err error) *MockStore_SSHUserCAList_Call {
+	_c.Call.Return(sSHUserCAs, n, err)
+	return _c
+}
+
+func (_c *MockStore_SSHUserCAList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.SSHUserCA, int, error)) *MockStore_SSHUserCAList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHUserCAResolve provides a mock function for the type MockStore
+func (_mock *MockStore) SSHUserCAResolve(ctx context.Context, sc scope.Scope, resolver store.SSHUserCAResolver, value string, opts ...store.QueryOption) (*models.SSHUserCA, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, resolver, value)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHUserCAResolve")
+	}
+
+	var r0 *models.SSHUserCA
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SSHUserCAResolver, string, ...store.QueryOption) (*models.SSHUserCA, error)); ok {
+		return returnFunc(ctx, sc, resolver, value, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SSHUserCAResolver, string, ...store.QueryOption) *models.SSHUserCA); ok {
+		r0 = returnFunc(ctx, sc, resolver, value, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SSHUserCA)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.SSHUserCAResolver, string, ...store.QueryOption) error); ok {
+		r1 = returnFunc(ctx, sc, resolver, value, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SSHUserCAResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHUserCAResolve'
+type MockStore_SSHUserCAResolve_Call struct {
+	*mock.Call
+}
+
+// SSHUserCAResolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - resolver store.SSHUserCAResolver
+//   - value string
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SSHUserCAResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_SSHUserCAResolve_Call {
+	return &MockStore_SSHUserCAResolve_Call{Call: _e.mock.On("SSHUserCAResolve",
+		append([]any{ctx, sc, resolver, value}, opts...)...)}
+}
+
+func (_c *MockStore_SSHUserCAResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.SSHUserCAResolver, value string, opts ...store.QueryOption)) *MockStore_SSHUserCAResolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 store.SSHUserCAResolver
+		if args[2] != nil {
+			arg2 = args[2].(store.SSHUserCAResolver)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 4 {
+			variadicArgs = args[4].([]store.QueryOption)
+		}
+		arg4 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHUserCAResolve_Call) Return(sSHUserCA *models.SSHUserCA, err error) *MockStore_SSHUserCAResolve_Call {
+	_c.Call.Return(sSHUserCA, err)
+	return _c
+}
+
+func (_c *MockStore_SSHUserCAResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.SSHUserCAResolver, value string, opts ...store.QueryOption) (*models.SSHUserCA, error)) *MockStore_SSHUserCAResolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSHUserCAUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) SSHUserCAUpdate(ctx context.Context, ca *models.SSHUserCA) error {
+	ret := _mock.Called(ctx, ca)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSHUserCAUpdate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SSHUserCA) error); ok {
+		r0 = returnFunc(ctx, ca)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SSHUserCAUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSHUserCAUpdate'
+type MockStore_SSHUserCAUpdate_Call struct {
+	*mock.Call
+}
+
+// SSHUserCAUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ca *models.SSHUserCA
+func (_e *MockStore_Expecter) SSHUserCAUpdate(ctx any, ca any) *MockStore_SSHUserCAUpdate_Call {
+	return &MockStore_SSHUserCAUpdate_Call{Call: _e.mock.On("SSHUserCAUpdate", ctx, ca)}
+}
+
+func (_c *MockStore_SSHUserCAUpdate_Call) Run(run func(ctx context.Context, ca *models.SSHUserCA)) *MockStore_SSHUserCAUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.SSHUserCA
+		if args[1] != nil {
+			arg1 = args[1].(*models.SSHUserCA)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SSHUserCAUpdate_Call) Return(err error) *MockStore_SSHUserCAUpdate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SSHUserCAUpdate_Call) RunAndReturn(run func(ctx context.Context, ca *models.SSHUserCA) error) *MockStore_SSHUserCAUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ServiceAccountList provides a mock function for the type MockStore
+func (_mock *MockStore) ServiceAccountList(ctx context.Context, tenantID string) ([]models.ServiceAccount, int, error) {
+	ret := _mock.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServiceAccountList")
+	}
+
+	var r0 []models.ServiceAccount
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.ServiceAccount, int, error)); ok {
+		return returnFunc(ctx, tenantID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.ServiceAccount); ok {
+		r0 = returnFunc(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ServiceAccount)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) int); ok {
+		r1 = returnFunc(ctx, tenantID)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, tenantID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_ServiceAccountList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServiceAccountList'
+type MockStore_ServiceAccountList_Call struct {
+	*mock.Call
+}
+
+// ServiceAccountList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *MockStore_Expecter) ServiceAccountList(ctx any, tenantID any) *MockStore_ServiceAccountList_Call {
+	return &MockStore_ServiceAccountList_Call{Call: _e.mock.On("ServiceAccountList", ctx, tenantID)}
+}
+
+func (_c *MockStore_ServiceAccountList_Call) Run(run func(ctx context.Context, tenantID string)) *MockStore_ServiceAccountList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_ServiceAccountList_Call) Return(serviceAccounts []models.ServiceAccount, n int, err error) *MockStore_ServiceAccountList_Call {
+	_c.Call.Return(serviceAccounts, n, err)
+	return _c
+}
+
+func (_c *MockStore_ServiceAccountList_Call) RunAndReturn(run func(ctx context.Context, tenantID string) ([]models.ServiceAccount, int, error)) *MockStore_ServiceAccountList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionCommandsList provides a mock function for the type MockStore
+func (_mock *MockStore) SessionCommandsList(ctx context.Context, sc scope.Scope, filter store.SessionCommandsFilter, opts ...store.QueryOption) ([]models.SessionCommand, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, filter, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, filter)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionCommandsList")
+	}
+
+	var r0 []models.SessionCommand
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SessionCommandsFilter, ...store.QueryOption) ([]models.SessionCommand, int, error)); ok {
+		return returnFunc(ctx, sc, filter, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SessionCommandsFilter, ...store.QueryOption) []models.SessionCommand); ok {
+		r0 = returnFunc(ctx, sc, filter, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionCommand)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.SessionCommandsFilter, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, filter, opts...)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, store.SessionCommandsFilter, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, filter, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_SessionCommandsList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionCommandsList'
+type MockStore_SessionCommandsList_Call struct {
+	*mock.Call
+}
+
+// SessionCommandsList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - filter store.SessionCommandsFilter
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SessionCommandsList(ctx any, sc any, filter any, opts ...any) *MockStore_SessionCommandsList_Call {
+	return &MockStore_SessionCommandsList_Call{Call: _e.mock.On("SessionCommandsList",
+		append([]any{ctx, sc, filter}, opts...)...)}
+}
+
+func (_c *MockStore_SessionCommandsList_Call) Run(run func(ctx context.Context, sc scope.Scope, filter store.SessionCommandsFilter, opts ...store.QueryOption)) *MockStore_SessionCommandsList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 store.SessionCommandsFilter
+		if args[2] != nil {
+			arg2 = args[2].(store.SessionCommandsFilter)
+		}
+		var arg3 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 3 {
+			variadicArgs = args[3].([]store.QueryOption)
+		}
+		arg3 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionCommandsList_Call) Return(sessionCommands []models.SessionCommand, n int, err error) *MockStore_SessionCommandsList_Call {
+	_c.Call.Return(sessionCommands, n, err)
+	return _c
+}
+
+func (_c *MockStore_SessionCommandsList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, filter store.SessionCommandsFilter, opts ...store.QueryOption) ([]models.SessionCommand, int, error)) *MockStore_SessionCommandsList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionCreate provides a mock function for the type MockStore
+func (_mock *MockStore) SessionCreate(ctx context.Context, session models.Session) (string, error) {
+	ret := _mock.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionCreate")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Session) (string, error)); ok {
+		return returnFunc(ctx, session)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Session) string); ok {
+		r0 = returnFunc(ctx, session)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Session) error); ok {
+		r1 = returnFunc(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SessionCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionCreate'
+type MockStore_SessionCreate_Call struct {
+	*mock.Call
+}
+
+// SessionCreate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session models.Session
+func (_e *MockStore_Expecter) SessionCreate(ctx any, session any) *MockStore_SessionCreate_Call {
+	return &MockStore_SessionCreate_Call{Call: _e.mock.On("SessionCreate", ctx, session)}
+}
+
+func (_c *MockStore_SessionCreate_Call) Run(run func(ctx context.Context, session models.Session)) *MockStore_SessionCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.Session
+		if args[1] != nil {
+			arg1 = args[1].(models.Session)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionCreate_Call) Return(s string, err error) *MockStore_SessionCreate_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockStore_SessionCreate_Call) RunAndReturn(run func(ctx context.Context, session models.Session) (string, error)) *MockStore_SessionCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionDeleteMany provides a mock function for the type MockStore
+func (_mock *MockStore) SessionDeleteMany(ctx context.Context, uids []string) (int64, error) {
+	ret := _mock.Called(ctx, uids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionDeleteMany")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
+		return returnFunc(ctx, uids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
+		r0 = returnFunc(ctx, uids)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, uids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SessionDeleteMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionDeleteMany'
+type MockStore_SessionDeleteMany_Call struct {
+	*mock.Call
+}
+
+// SessionDeleteMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uids []string
+func (_e *MockStore_Expecter) SessionDeleteMany(ctx any, uids any) *MockStore_SessionDeleteMany_Call {
+	return &MockStore_SessionDeleteMany_Call{Call: _e.mock.On("SessionDeleteMany", ctx, uids)}
+}
+
+func (_c *MockStore_SessionDeleteMany_Call) Run(run func(ctx context.Context, uids []string)) *MockStore_SessionDeleteMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionDeleteMany_Call) Return(n int64, err error) *MockStore_SessionDeleteMany_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStore_SessionDeleteMany_Call) RunAndReturn(run func(ctx context.Context, uids []string) (int64, error)) *MockStore_SessionDeleteMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionEventsCreate provides a mock function for the type MockStore
+func (_mock *MockStore) SessionEventsCreate(ctx context.Context, event *models.SessionEvent) error {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEventsCreate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.SessionEvent) error); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SessionEventsCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsCreate'
+type MockStore_SessionEventsCreate_Call struct {
+	*mock.Call
+}
+
+// SessionEventsCreate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *models.SessionEvent
+func (_e *MockStore_Expecter) SessionEventsCreate(ctx any, event any) *MockStore_SessionEventsCreate_Call {
+	return &MockStore_SessionEventsCreate_Call{Call: _e.mock.On("SessionEventsCreate", ctx, event)}
+}
+
+func (_c *MockStore_SessionEventsCreate_Call) Run(run func(ctx context.Context, event *models.SessionEvent)) *MockStore_SessionEventsCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.SessionEvent
+		if args[1] != nil {
+			arg1 = args[1].(*models.SessionEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionEventsCreate_Call) Return(err error) *MockStore_SessionEventsCreate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SessionEventsCreate_Call) RunAndReturn(run func(ctx context.Context, event *models.SessionEvent) error) *MockStore_SessionEventsCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionEventsCreateMany provides a mock function for the type MockStore
+func (_mock *MockStore) SessionEventsCreateMany(ctx context.Context, events []models.SessionEvent) error {
+	ret := _mock.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEventsCreateMany")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.SessionEvent) error); ok {
+		r0 = returnFunc(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SessionEventsCreateMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsCreateMany'
+type MockStore_SessionEventsCreateMany_Call struct {
+	*mock.Call
+}
+
+// SessionEventsCreateMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []models.SessionEvent
+func (_e *MockStore_Expecter) SessionEventsCreateMany(ctx any, events any) *MockStore_SessionEventsCreateMany_Call {
+	return &MockStore_SessionEventsCreateMany_Call{Call: _e.mock.On("SessionEventsCreateMany", ctx, events)}
+}
+
+func (_c *MockStore_SessionEventsCreateMany_Call) Run(run func(ctx context.Context, events []models.SessionEvent)) *MockStore_SessionEventsCreateMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.SessionEvent
+		if args[1] != nil {
+			arg1 = args[1].([]models.SessionEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionEventsCreateMany_Call) Return(err error) *MockStore_SessionEventsCreateMany_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SessionEventsCreateMany_Call) RunAndReturn(run func(ctx context.Context, events []models.SessionEvent) error) *MockStore_SessionEventsCreateMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionEventsDelete provides a mock function for the type MockStore
+func (_mock *MockStore) SessionEventsDelete(ctx context.Context, uid models.UID, seat int, event models.SessionEventType) error {
+	ret := _mock.Called(ctx, uid, seat, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEventsDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, models.SessionEventType) error); ok {
+		r0 = returnFunc(ctx, uid, seat, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SessionEventsDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsDelete'
+type MockStore_SessionEventsDelete_Call struct {
+	*mock.Call
+}
+
+// SessionEventsDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid models.UID
+//   - seat int
+//   - event models.SessionEventType
+func (_e *MockStore_Expecter) SessionEventsDelete(ctx any, uid any, seat any, event any) *MockStore_SessionEventsDelete_Call {
+	return &MockStore_SessionEventsDelete_Call{Call: _e.mock.On("SessionEventsDelete", ctx, uid, seat, event)}
+}
+
+func (_c *MockStore_SessionEventsDelete_Call) Run(run func(ctx context.Context, uid models.UID, seat int, event models.SessionEventType)) *MockStore_SessionEventsDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.UID
+		if args[1] != nil {
+			arg1 = args[1].(models.UID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 models.SessionEventType
+		if args[3] != nil {
+			arg3 = args[3].(models.SessionEventType)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionEventsDelete_Call) Return(err error) *MockStore_SessionEventsDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SessionEventsDelete_Call) RunAndReturn(run func(ctx context.Context, uid models.UID, seat int, event models.SessionEventType) error) *MockStore_SessionEventsDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionEventsList provides a mock function for the type MockStore
+func (_mock *MockStore) SessionEventsList(ctx context.Context, uid models.UID, seat int, event models.SessionEventType, opts ...store.QueryOption) ([]models.SessionEvent, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, uid, seat, event, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, uid, seat, event)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEventsList")
+	}
+
+	var r0 []models.SessionEvent
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, models.SessionEventType, ...store.QueryOption) ([]models.SessionEvent, int, error)); ok {
+		return returnFunc(ctx, uid, seat, event, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, models.SessionEventType, ...store.QueryOption) []models.SessionEvent); ok {
+		r0 = returnFunc(ctx, uid, seat, event, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.UID, int, models.SessionEventType, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, uid, seat, event, opts...)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.UID, int, models.SessionEventType, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, uid, seat, event, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_SessionEventsList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsList'
+type MockStore_SessionEventsList_Call struct {
+	*mock.Call
+}
+
+// SessionEventsList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid models.UID
+//   - seat int
+//   - event models.SessionEventType
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SessionEventsList(ctx any, uid any, seat any, event any, opts ...any) *MockStore_SessionEventsList_Call {
+	return &MockStore_SessionEventsList_Call{Call: _e.mock.On("SessionEventsList",
+		append([]any{ctx, uid, seat, event}, opts...)...)}
+}
+
+func (_c *MockStore_SessionEventsList_Call) Run(run func(ctx context.Context, uid models.UID, seat int, event models.SessionEventType, opts ...store.QueryOption)) *MockStore_SessionEventsList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.UID
+		if args[1] != nil {
+			arg1 = args[1].(models.UID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 models.SessionEventType
+		if args[3] != nil {
+			arg3 = args[3].(models.SessionEventType)
+		}
+		var arg4 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 4 {
+			variadicArgs = args[4].([]store.QueryOption)
+		}
+		arg4 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionEventsList_Call) Return(sessionEvents []models.SessionEvent, n int, err error) *MockStore_SessionEventsList_Call {
+	_c.Call.Return(sessionEvents, n, err)
+	return _c
+}
+
+func (_c *MockStore_SessionEventsList_Call) RunAndReturn(run func(ctx context.Context, uid models.UID, seat int, event models.SessionEventType, opts ...store.QueryOption) ([]models.SessionEvent, int, error)) *MockStore_SessionEventsList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionEventsScan provides a mock function for the type MockStore
+func (_mock *MockStore) SessionEventsScan(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, after store.SessionEventsCursor, limit int) ([]models.SessionEvent, store.SessionEventsCursor, error) {
+	ret := _mock.Called(ctx, uid, seat, types, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEventsScan")
+	}
+
+	var r0 []models.SessionEvent
+	var r1 store.SessionEventsCursor
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, []models.SessionEventType, store.SessionEventsCursor, int) ([]models.SessionEvent, store.SessionEventsCursor, error)); ok {
+		return returnFunc(ctx, uid, seat, types, after, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, []models.SessionEventType, store.SessionEventsCursor, int) []models.SessionEvent); ok {
+		r0 = returnFunc(ctx, uid, seat, types, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.UID, int, []models.SessionEventType, store.SessionEventsCursor, int) store.SessionEventsCursor); ok {
+		r1 = returnFunc(ctx, uid, seat, types, after, limit)
+	} else {
+		r1 = ret.Get(1).(store.SessionEventsCursor)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.UID, int, []models.SessionEventType, store.SessionEventsCursor, int) error); ok {
+		r2 = returnFunc(ctx, uid, seat, types, after, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_SessionEventsScan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsScan'
+type MockStore_SessionEventsScan_Call struct {
+	*mock.Call
+}
+
+// SessionEventsScan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid models.UID
+//   - seat int
+//   - types []models.SessionEventType
+//   - after store.SessionEventsCursor
+//   - limit int
+func (_e *MockStore_Expecter) SessionEventsScan(ctx any, uid any, seat any, types any, after any, limit any) *MockStore_SessionEventsScan_Call {
+	return &MockStore_SessionEventsScan_Call{Call: _e.mock.On("SessionEventsScan", ctx, uid, seat, types, after, limit)}
+}
+
+func (_c *MockStore_SessionEventsScan_Call) Run(run func(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, after store.SessionEventsCursor, limit int)) *MockStore_SessionEventsScan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.UID
+		if args[1] != nil {
+			arg1 = args[1].(models.UID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 []models.SessionEventType
+		if args[3] != nil {
+			arg3 = args[3].([]models.SessionEventType)
+		}
+		var arg4 store.SessionEventsCursor
+		if args[4] != nil {
+			arg4 = args[4].(store.SessionEventsCursor)
+		}
+		var arg5 int
+		if args[5] != nil {
+			arg5 = args[5].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionEventsScan_Call) Return(sessionEvents []models.SessionEvent, sessionEventsCursor store.SessionEventsCursor, err error) *MockStore_SessionEventsScan_Call {
+	_c.Call.Return(sessionEvents, sessionEventsCursor, err)
+	return _c
+}
+
+func (_c *MockStore_SessionEventsScan_Call) RunAndReturn(run func(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, after store.SessionEventsCursor, limit int) ([]models.SessionEvent, store.SessionEventsCursor, error)) *MockStore_SessionEventsScan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionEventsScanBackward provides a mock function for the type MockStore
+func (_mock *MockStore) SessionEventsScanBackward(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, before store.SessionEventsCursor, limit int) ([]models.SessionEvent, store.SessionEventsCursor, error) {
+	ret := _mock.Called(ctx, uid, seat, types, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEventsScanBackward")
+	}
+
+	var r0 []models.SessionEvent
+	var r1 store.SessionEventsCursor
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, []models.SessionEventType, store.SessionEventsCursor, int) ([]models.SessionEvent, store.SessionEventsCursor, error)); ok {
+		return returnFunc(ctx, uid, seat, types, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, int, []models.SessionEventType, store.SessionEventsCursor, int) []models.SessionEvent); ok {
+		r0 = returnFunc(ctx, uid, seat, types, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.UID, int, []models.SessionEventType, store.SessionEventsCursor, int) store.SessionEventsCursor); ok {
+		r1 = returnFunc(ctx, uid, seat, types, before, limit)
+	} else {
+		r1 = ret.Get(1).(store.SessionEventsCursor)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.UID, int, []models.SessionEventType, store.SessionEventsCursor, int) error); ok {
+		r2 = returnFunc(ctx, uid, seat, types, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_SessionEventsScanBackward_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsScanBackward'
+type MockStore_SessionEventsScanBackward_Call struct {
+	*mock.Call
+}
+
+// SessionEventsScanBackward is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid models.UID
+//   - seat int
+//   - types []models.SessionEventType
+//   - before store.SessionEventsCursor
+//   - limit int
+func (_e *MockStore_Expecter) SessionEventsScanBackward(ctx any, uid any, seat any, types any, before any, limit any) *MockStore_SessionEventsScanBackward_Call {
+	return &MockStore_SessionEventsScanBackward_Call{Call: _e.mock.On("SessionEventsScanBackward", ctx, uid, seat, types, before, limit)}
+}
+
+func (_c *MockStore_SessionEventsScanBackward_Call) Run(run func(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, before store.SessionEventsCursor, limit int)) *MockStore_SessionEventsScanBackward_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.UID
+		if args[1] != nil {
+			arg1 = args[1].(models.UID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 []models.SessionEventType
+		if args[3] != nil {
+			arg3 = args[3].([]models.SessionEventType)
+		}
+		var arg4 store.SessionEventsCursor
+		if args[4] != nil {
+			arg4 = args[4].(store.SessionEventsCursor)
+		}
+		var arg5 int
+		if args[5] != nil {
+			arg5 = args[5].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionEventsScanBackward_Call) Return(sessionEvents []models.SessionEvent, sessionEventsCursor store.SessionEventsCursor, err error) *MockStore_SessionEventsScanBackward_Call {
+	_c.Call.Return(sessionEvents, sessionEventsCursor, err)
+	return _c
+}
+
+func (_c *MockStore_SessionEventsScanBackward_Call) RunAndReturn(run func(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, before store.SessionEventsCursor, limit int) ([]models.SessionEvent, store.SessionEventsCursor, error)) *MockStore_SessionEventsScanBackward_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionEventsSearch provides a mock function for the type MockStore
+func (_mock *MockStore) SessionEventsSearch(ctx context.Context, sc scope.Scope, search store.SessionSearch, opts ...store.QueryOption) ([]models.SessionSearchHit, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, search, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, search)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEventsSearch")
+	}
+
+	var r0 []models.SessionSearchHit
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SessionSearch, ...store.QueryOption) ([]models.SessionSearchHit, int, error)); ok {
+		return returnFunc(ctx, sc, search, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SessionSearch, ...store.QueryOption) []models.SessionSearchHit); ok {
+		r0 = returnFunc(ctx, sc, search, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionSearchHit)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.SessionSearch, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, search, opts...)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, store.SessionSearch, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, search, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_SessionEventsSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionEventsSearch'
+type MockStore_SessionEventsSearch_Call struct {
+	*mock.Call
+}
+
+// SessionEventsSearch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - search store.SessionSearch
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SessionEventsSearch(ctx any, sc any, search any, opts ...any) *MockStore_SessionEventsSearch_Call {
+	return &MockStore_SessionEventsSearch_Call{Call: _e.mock.On("SessionEventsSearch",
+		append([]any{ctx, sc, search}, opts...)...)}
+}
+
+func (_c *MockStore_SessionEventsSearch_Call) Run(run func(ctx context.Context, sc scope.Scope, search store.SessionSearch, opts ...store.QueryOption)) *MockStore_SessionEventsSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 store.SessionSearch
+		if args[2] != nil {
+			arg2 = args[2].(store.SessionSearch)
+		}
+		var arg3 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 3 {
+			variadicArgs = args[3].([]store.QueryOption)
+		}
+		arg3 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionEventsSearch_Call) Return(sessionSearchHits []models.SessionSearchHit, n int, err error) *MockStore_SessionEventsSearch_Call {
+	_c.Call.Return(sessionSearchHits, n, err)
+	return _c
+}
+
+func (_c *MockStore_SessionEventsSearch_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, search store.SessionSearch, opts ...store.QueryOption) ([]models.SessionSearchHit, int, error)) *MockStore_SessionEventsSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionList provides a mock function for the type MockStore
+func (_mock *MockStore) SessionList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Session, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionList")
+	}
+
+	var r0 []models.Session
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.Session, int, error)); ok {
+		return returnFunc(ctx, sc, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.Session); ok {
+		r0 = returnFunc(ctx, sc, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, opts...)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_SessionList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionList'
+type MockStore_SessionList_Call struct {
+	*mock.Call
+}
+
+// SessionList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SessionList(ctx any, sc any, opts ...any) *MockStore_SessionList_Call {
+	return &MockStore_SessionList_Call{Call: _e.mock.On("SessionList",
+		append([]any{ctx, sc}, opts...)...)}
+}
+
+func (_c *MockStore_SessionList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_SessionList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionList_Call) Return(sessions []models.Session, n int, err error) *MockStore_SessionList_Call {
+	_c.Call.Return(sessions, n, err)
+	return _c
+}
+
+func (_c *MockStore_SessionList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Session, int, error)) *MockStore_SessionList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionListActive provides a mock function for the type MockStore
+func (_mock *MockStore) SessionListActive(ctx context.Context, sc scope.Scope, userID string) ([]models.Session, error) {
+	ret := _mock.Called(ctx, sc, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionListActive")
+	}
+
+	var r0 []models.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) ([]models.Session, error)); ok {
+		return returnFunc(ctx, sc, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) []models.Session); ok {
+		r0 = returnFunc(ctx, sc, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, string) error); ok {
+		r1 = returnFunc(ctx, sc, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SessionListActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionListActive'
+type MockStore_SessionListActive_Call struct {
+	*mock.Call
+}
+
+// SessionListActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - userID string
+func (_e *MockStore_Expecter) SessionListActive(ctx any, sc any, userID any) *MockStore_SessionListActive_Call {
+	return &MockStore_SessionListActive_Call{Call: _e.mock.On("SessionListActive", ctx, sc, userID)}
+}
+
+func (_c *MockStore_SessionListActive_Call) Run(run func(ctx context.Context, sc scope.Scope, userID string)) *MockStore_SessionListActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionListActive_Call) Return(sessions []models.Session, err error) *MockStore_SessionListActive_Call {
+	_c.Call.Return(sessions, err)
+	return _c
+}
+
+func (_c *MockStore_SessionListActive_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, userID string) ([]models.Session, error)) *MockStore_SessionListActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionListExpired provides a mock function for the type MockStore
+func (_mock *MockStore) SessionListExpired(ctx context.Context, before time.Time, limit int) ([]store.ExpiredSession, error) {
+	ret := _mock.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionListExpired")
+	}
+
+	var r0 []store.ExpiredSession
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]store.ExpiredSession, error)); ok {
+		return returnFunc(ctx, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []store.ExpiredSession); ok {
+		r0 = returnFunc(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]store.ExpiredSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SessionListExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionListExpired'
+type MockStore_SessionListExpired_Call struct {
+	*mock.Call
+}
+
+// SessionListExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *MockStore_Expecter) SessionListExpired(ctx any, before any, limit any) *MockStore_SessionListExpired_Call {
+	return &MockStore_SessionListExpired_Call{Call: _e.mock.On("SessionListExpired", ctx, before, limit)}
+}
+
+func (_c *MockStore_SessionListExpired_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockStore_SessionListExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionListExpired_Call) Return(expiredSessions []store.ExpiredSession, err error) *MockStore_SessionListExpired_Call {
+	_c.Call.Return(expiredSessions, err)
+	return _c
+}
+
+func (_c *MockStore_SessionListExpired_Call) RunAndReturn(run func(ctx context.Context, before time.Time, limit int) ([]store.ExpiredSession, error)) *MockStore_SessionListExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionResolve provides a mock function for the type MockStore
+func (_mock *MockStore) SessionResolve(ctx context.Context, sc scope.Scope, resolver store.SessionResolver, value string, opts ...store.QueryOption) (*models.Session, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, resolver, value)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionResolve")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SessionResolver, string, ...store.QueryOption) (*models.Session, error)); ok {
+		return returnFunc(ctx, sc, resolver, value, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SessionResolver, string, ...store.QueryOption) *models.Session); ok {
+		r0 = returnFunc(ctx, sc, resolver, value, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.SessionResolver, string, ...store.QueryOption) error); ok {
+		r1 = returnFunc(ctx, sc, resolver, value, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SessionResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionResolve'
+type MockStore_SessionResolve_Call struct {
+	*mock.Call
+}
+
+// SessionResolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - resolver store.SessionResolver
+//   - value string
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) SessionResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_SessionResolve_Call {
+	return &MockStore_SessionResolve_Call{Call: _e.mock.On("SessionResolve",
+		append([]any{ctx, sc, resolver, value}, opts...)...)}
+}
+
+func (_c *MockStore_SessionResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.SessionResolver, value string, opts ...store.QueryOption)) *MockStore_SessionResolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 store.SessionResolver
+		if args[2] != nil {
+			arg2 = args[2].(store.SessionResolver)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 4 {
+			variadicArgs = args[4].([]store.QueryOption)
+		}
+		arg4 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionResolve_Call) Return(session *models.Session, err error) *MockStore_SessionResolve_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockStore_SessionResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.SessionResolver, value string, opts ...store.QueryOption) (*models.Session, error)) *MockStore_SessionResolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) SessionUpdate(ctx context.Context, session *models.Session) error {
+	ret := _mock.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionUpdate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
+		r0 = returnFunc(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SessionUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionUpdate'
+type MockStore_SessionUpdate_Call struct {
+	*mock.Call
+}
+
+// SessionUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session *models.Session
+func (_e *MockStore_Expecter) SessionUpdate(ctx any, session any) *MockStore_SessionUpdate_Call {
+	return &MockStore_SessionUpdate_Call{Call: _e.mock.On("SessionUpdate", ctx, session)}
+}
+
+func (_c *MockStore_SessionUpdate_Call) Run(run func(ctx context.Context, session *models.Session)) *MockStore_SessionUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.Session
+		if args[1] != nil {
+			arg1 = args[1].(*models.Session)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionUpdate_Call) Return(err error) *MockStore_SessionUpdate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SessionUpdate_Call) RunAndReturn(run func(ctx context.Context, session *models.Session) error) *MockStore_SessionUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionUpdateDeviceUID provides a mock function for the type MockStore
+func (_mock *MockStore) SessionUpdateDeviceUID(ctx context.Context, oldUID models.UID, newUID models.UID) error {
+	ret := _mock.Called(ctx, oldUID, newUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionUpdateDeviceUID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, models.UID) error); ok {
+		r0 = returnFunc(ctx, oldUID, newUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SessionUpdateDeviceUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionUpdateDeviceUID'
+type MockStore_SessionUpdateDeviceUID_Call struct {
+	*mock.Call
+}
+
+// SessionUpdateDeviceUID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldUID models.UID
+//   - newUID models.UID
+func (_e *MockStore_Expecter) SessionUpdateDeviceUID(ctx any, oldUID any, newUID any) *MockStore_SessionUpdateDeviceUID_Call {
+	return &MockStore_SessionUpdateDeviceUID_Call{Call: _e.mock.On("SessionUpdateDeviceUID", ctx, oldUID, newUID)}
+}
+
+func (_c *MockStore_SessionUpdateDeviceUID_Call) Run(run func(ctx context.Context, oldUID models.UID, newUID models.UID)) *MockStore_SessionUpdateDeviceUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.UID
+		if args[1] != nil {
+			arg1 = args[1].(models.UID)
+		}
+		var arg2 models.UID
+		if args[2] != nil {
+			arg2 = args[2].(models.UID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SessionUpdateDeviceUID_Call) Return(err error) *MockStore_SessionUpdateDeviceUID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SessionUpdateDeviceUID_Call) RunAndReturn(run func(ctx context.Context, oldUID models.UID, newUID models.UID) error) *MockStore_SessionUpdateDeviceUID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SystemGet provides a mock function for the type MockStore
+func (_mock *MockStore) SystemGet(ctx context.Context) (*models.System, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SystemGet")
+	}
+
+	var r0 *models.System
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*models.System, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *models.System); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.System)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SystemGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SystemGet'
+type MockStore_SystemGet_Call struct {
+	*mock.Call
+}
+
+// SystemGet is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) SystemGet(ctx any) *MockStore_SystemGet_Call {
+	return &MockStore_SystemGet_Call{Call: _e.mock.On("SystemGet", ctx)}
+}
+
+func (_c *MockStore_SystemGet_Call) Run(run func(ctx context.Context)) *MockStore_SystemGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SystemGet_Call) Return(system *models.System, err error) *MockStore_SystemGet_Call {
+	_c.Call.Return(system, err)
+	return _c
+}
+
+func (_c *MockStore_SystemGet_Call) RunAndReturn(run func(ctx context.Context) (*models.System, error)) *MockStore_SystemGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SystemSet provides a mock function for the type MockStore
+func (_mock *MockStore) SystemSet(ctx context.Context, system *models.System) error {
+	ret := _mock.Called(ctx, system)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SystemSet")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.System) error); ok {
+		r0 = returnFunc(ctx, system)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_SystemSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SystemSet'
+type MockStore_SystemSet_Call struct {
+	*mock.Call
+}
+
+// SystemSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - system *models.System
+func (_e *MockStore_Expecter) SystemSet(ctx any, system any) *MockStore_SystemSet_Call {
+	return &MockStore_SystemSet_Call{Call: _e.mock.On("SystemSet", ctx, system)}
+}
+
+func (_c *MockStore_SystemSet_Call) Run(run func(ctx context.Context, system *models.System)) *MockStore_SystemSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.System
+		if args[1] != nil {
+			arg1 = args[1].(*models.System)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SystemSet_Call) Return(err error) *MockStore_SystemSet_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_SystemSet_Call) RunAndReturn(run func(ctx context.Context, system *models.System) error) *MockStore_SystemSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagConflicts provides a mock function for the type MockStore
+func (_mock *MockStore) TagConflicts(ctx context.Context, sc scope.Scope, target *models.TagConflicts) ([]string, bool, error) {
+	ret := _mock.Called(ctx, sc, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagConflicts")
+	}
+
+	var r0 []string
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.TagConflicts) ([]string, bool, error)); ok {
+		return returnFunc(ctx, sc, target)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.TagConflicts) []string); ok {
+		r0 = returnFunc(ctx, sc, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *models.TagConflicts) bool); ok {
+		r1 = returnFunc(ctx, sc, target)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *models.TagConflicts) error); ok {
+		r2 = returnFunc(ctx, sc, target)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_TagConflicts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagConflicts'
+type MockStore_TagConflicts_Call struct {
+	*mock.Call
+}
+
+// TagConflicts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - target *models.TagConflicts
+func (_e *MockStore_Expecter) TagConflicts(ctx any, sc any, target any) *MockStore_TagConflicts_Call {
+	return &MockStore_TagConflicts_Call{Call: _e.mock.On("TagConflicts", ctx, sc, target)}
+}
+
+func (_c *MockStore_TagConflicts_Call) Run(run func(ctx context.Context, sc scope.Scope, target *models.TagConflicts)) *MockStore_TagConflicts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 *models.TagConflicts
+		if args[2] != nil {
+			arg2 = args[2].(*models.TagConflicts)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_TagConflicts_Call) Return(conflicts []string, has bool, err error) *MockStore_TagConflicts_Call {
+	_c.Call.Return(conflicts, has, err)
+	return _c
+}
+
+func (_c *MockStore_TagConflicts_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, target *models.TagConflicts) ([]string, bool, error)) *MockStore_TagConflicts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagCreate provides a mock function for the type MockStore
+func (_mock *MockStore) TagCreate(ctx context.Context, tag *models.Tag) (string, error) {
+	ret := _mock.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagCreate")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Tag) (string, error)); ok {
+		return returnFunc(ctx, tag)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Tag) string); ok {
+		r0 = returnFunc(ctx, tag)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.Tag) error); ok {
+		r1 = returnFunc(ctx, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_TagCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagCreate'
+type MockStore_TagCreate_Call struct {
+	*mock.Call
+}
+
+// TagCreate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tag *models.Tag
+func (_e *MockStore_Expecter) TagCreate(ctx any, tag any) *MockStore_TagCreate_Call {
+	return &MockStore_TagCreate_Call{Call: _e.mock.On("TagCreate", ctx, tag)}
+}
+
+func (_c *MockStore_TagCreate_Call) Run(run func(ctx context.Context, tag *models.Tag)) *MockStore_TagCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.Tag
+		if args[1] != nil {
+			arg1 = args[1].(*models.Tag)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_TagCreate_Call) Return(insertedID string, err error) *MockStore_TagCreate_Call {
+	_c.Call.Return(insertedID, err)
+	return _c
+}
+
+func (_c *MockStore_TagCreate_Call) RunAndReturn(run func(ctx context.Context, tag *models.Tag) (string, error)) *MockStore_TagCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagDelete provides a mock function for the type MockStore
+func (_mock *MockStore) TagDelete(ctx context.Context, tag *models.Tag) error {
+	ret := _mock.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Tag) error); ok {
+		r0 = returnFunc(ctx, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_TagDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagDelete'
+type MockStore_TagDelete_Call struct {
+	*mock.Call
+}
+
+// TagDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tag *models.Tag
+func (_e *MockStore_Expecter) TagDelete(ctx any, tag any) *MockStore_TagDelete_Call {
+	return &MockStore_TagDelete_Call{Call: _e.mock.On("TagDelete", ctx, tag)}
+}
+
+func (_c *MockStore_TagDelete_Call) Run(run func(ctx context.Context, tag *models.Tag)) *MockStore_TagDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.Tag
+		if args[1] != nil {
+			arg1 = args[1].(*models.Tag)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_TagDelete_Call) Return(err error) *MockStore_TagDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_TagDelete_Call) RunAndReturn(run func(ctx context.Context, tag *models.Tag) error) *MockStore_TagDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagList provides a mock function for the type MockStore
+func (_mock *MockStore) TagList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Tag, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagList")
+	}
+
+	var r0 []models.Tag
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.Tag, int, error)); ok {
+		return returnFunc(ctx, sc, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.Tag); ok {
+		r0 = returnFunc(ctx, sc, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, opts...)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
@@ -6403,24 +9110,256 @@ func (_mock *MockStore) SessionList(ctx context.Context, sc scope.Scope, opts ..
 	} else {
 		r2 = ret.Error(2)
 	}
-	return r0, r1, r2
+	return r0, r1, r2
+}
+
+// MockStore_TagList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagList'
+type MockStore_TagList_Call struct {
+	*mock.Call
+}
+
+// TagList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sc scope.Scope
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) TagList(ctx any, sc any, opts ...any) *MockStore_TagList_Call {
+	return &MockStore_TagList_Call{Call: _e.mock.On("TagList",
+		append([]any{ctx, sc}, opts...)...)}
+}
+
+func (_c *MockStore_TagList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_TagList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 scope.Scope
+		if args[1] != nil {
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_TagList_Call) Return(tags []models.Tag, totalCount int, err error) *MockStore_TagList_Call {
+	_c.Call.Return(tags, totalCount, err)
+	return _c
+}
+
+func (_c *MockStore_TagList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Tag, int, error)) *MockStore_TagList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagPullFromTarget provides a mock function for the type MockStore
+func (_mock *MockStore) TagPullFromTarget(ctx context.Context, id string, target store.TagTarget, targetIDs ...string) error {
+	var tmpRet mock.Arguments
+	if len(targetIDs) > 0 {
+		tmpRet = _mock.Called(ctx, id, target, targetIDs)
+	} else {
+		tmpRet = _mock.Called(ctx, id, target)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagPullFromTarget")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, store.TagTarget, ...string) error); ok {
+		r0 = returnFunc(ctx, id, target, targetIDs...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_TagPullFromTarget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagPullFromTarget'
+type MockStore_TagPullFromTarget_Call struct {
+	*mock.Call
+}
+
+// TagPullFromTarget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - target store.TagTarget
+//   - targetIDs ...string
+func (_e *MockStore_Expecter) TagPullFromTarget(ctx any, id any, target any, targetIDs ...any) *MockStore_TagPullFromTarget_Call {
+	return &MockStore_TagPullFromTarget_Call{Call: _e.mock.On("TagPullFromTarget",
+		append([]any{ctx, id, target}, targetIDs...)...)}
+}
+
+func (_c *MockStore_TagPullFromTarget_Call) Run(run func(ctx context.Context, id string, target store.TagTarget, targetIDs ...string)) *MockStore_TagPullFromTarget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 store.TagTarget
+		if args[2] != nil {
+			arg2 = args[2].(store.TagTarget)
+		}
+		var arg3 []string
+		var variadicArgs []string
+		if len(args) > 3 {
+			variadicArgs = args[3].([]string)
+		}
+		arg3 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_TagPullFromTarget_Call) Return(err error) *MockStore_TagPullFromTarget_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_TagPullFromTarget_Call) RunAndReturn(run func(ctx context.Context, id string, target store.TagTarget, targetIDs ...string) error) *MockStore_TagPullFromTarget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagPushToTarget provides a mock function for the type MockStore
+func (_mock *MockStore) TagPushToTarget(ctx context.Context, id string, target store.TagTarget, targetID string) error {
+	ret := _mock.Called(ctx, id, target, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagPushToTarget")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, store.TagTarget, string) error); ok {
+		r0 = returnFunc(ctx, id, target, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_TagPushToTarget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagPushToTarget'
+type MockStore_TagPushToTarget_Call struct {
+	*mock.Call
+}
+
+// TagPushToTarget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - target store.TagTarget
+//   - targetID string
+func (_e *MockStore_Expecter) TagPushToTarget(ctx any, id any, target any, targetID any) *MockStore_TagPushToTarget_Call {
+	return &MockStore_TagPushToTarget_Call{Call: _e.mock.On("TagPushToTarget", ctx, id, target, targetID)}
+}
+
+func (_c *MockStore_TagPushToTarget_Call) Run(run func(ctx context.Context, id string, target store.TagTarget, targetID string)) *MockStore_TagPushToTarget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 store.TagTarget
+		if args[2] != nil {
+			arg2 = args[2].(store.TagTarget)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_TagPushToTarget_Call) Return(err error) *MockStore_TagPushToTarget_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_TagPushToTarget_Call) RunAndReturn(run func(ctx context.Context, id string, target store.TagTarget, targetID string) error) *MockStore_TagPushToTarget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagResolve provides a mock function for the type MockStore
+func (_mock *MockStore) TagResolve(ctx context.Context, sc scope.Scope, resolver store.TagResolver, value string, opts ...store.QueryOption) (*models.Tag, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, resolver, value)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagResolve")
+	}
+
+	var r0 *models.Tag
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.TagResolver, string, ...store.QueryOption) (*models.Tag, error)); ok {
+		return returnFunc(ctx, sc, resolver, value, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.TagResolver, string, ...store.QueryOption) *models.Tag); ok {
+		r0 = returnFunc(ctx, sc, resolver, value, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.TagResolver, string, ...store.QueryOption) error); ok {
+		r1 = returnFunc(ctx, sc, resolver, value, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_SessionList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionList'
-type MockStore_SessionList_Call struct {
+// MockStore_TagResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagResolve'
+type MockStore_TagResolve_Call struct {
 	*mock.Call
 }
 
-// SessionList is a helper method to define mock.On call
+// TagResolve is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sc scope.Scope
+//   - resolver store.TagResolver
+//   - value string
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) SessionList(ctx any, sc any, opts ...any) *MockStore_SessionList_Call {
-	return &MockStore_SessionList_Call{Call: _e.mock.On("SessionList",
-		append([]any{ctx, sc}, opts...)...)}
+func (_e *MockStore_Expecter) TagResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_TagResolve_Call {
+	return &MockStore_TagResolve_Call{Call: _e.mock.On("TagResolve",
+		append([]any{ctx, sc, resolver, value}, opts...)...)}
 }
 
-func (_c *MockStore_SessionList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_SessionList_Call {
+func (_c *MockStore_TagResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.TagResolver, value string, opts ...store.QueryOption)) *MockStore_TagResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6430,238 +9369,202 @@ func (_c *MockStore_SessionList_Call) Run(run func(ctx context.Context, sc scope
 		if args[1] != nil {
 			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 []store.QueryOption
+		var arg2 store.TagResolver
+		if args[2] != nil {
+			arg2 = args[2].(store.TagResolver)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []store.QueryOption
 		var variadicArgs []store.QueryOption
-		if len(args) > 2 {
-			variadicArgs = args[2].([]store.QueryOption)
+		if len(args) > 4 {
+			variadicArgs = args[4].([]store.QueryOption)
 		}
-		arg2 = variadicArgs
+		arg4 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
+			arg2,
+			arg3,
+			arg4...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SessionList_Call) Return(sessions []models.Session, n int, err error) *MockStore_SessionList_Call {
-	_c.Call.Return(sessions, n, err)
+func (_c *MockStore_TagResolve_Call) Return(tag *models.Tag, err error) *MockStore_TagResolve_Call {
+	_c.Call.Return(tag, err)
 	return _c
 }
 
-func (_c *MockStore_SessionList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Session, int, error)) *MockStore_SessionList_Call {
+func (_c *MockStore_TagResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.TagResolver, value string, opts ...store.QueryOption) (*models.Tag, error)) *MockStore_TagResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionListExpired provides a mock function for the type MockStore
-func (_mock *MockStore) SessionListExpired(ctx context.Context, before time.Time, limit int) ([]store.ExpiredSession, error) {
-	ret := _mock.Called(ctx, before, limit)
+// TagUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) TagUpdate(ctx context.Context, tag *models.Tag) error {
+	ret := _mock.Called(ctx, tag)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionListExpired")
+		panic("no return value specified for TagUpdate")
 	}
 
-	var r0 []store.ExpiredSession
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]store.ExpiredSession, error)); ok {
-		return returnFunc(ctx, before, limit)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []store.ExpiredSession); ok {
-		r0 = returnFunc(ctx, before, limit)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]store.ExpiredSession)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
-		r1 = returnFunc(ctx, before, limit)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Tag) error); ok {
+		r0 = returnFunc(ctx, tag)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStore_SessionListExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionListExpired'
-type MockStore_SessionListExpired_Call struct {
+// MockStore_TagUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagUpdate'
+type MockStore_TagUpdate_Call struct {
 	*mock.Call
 }
 
-// SessionListExpired is a helper method to define mock.On call
+// TagUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - before time.Time
-//   - limit int
-func (_e *MockStore_Expecter) SessionListExpired(ctx any, before any, limit any) *MockStore_SessionListExpired_Call {
-	return &MockStore_SessionListExpired_Call{Call: _e.mock.On("SessionListExpired", ctx, before, limit)}
+//   - tag *models.Tag
+func (_e *MockStore_Expecter) TagUpdate(ctx any, tag any) *MockStore_TagUpdate_Call {
+	return &MockStore_TagUpdate_Call{Call: _e.mock.On("TagUpdate", ctx, tag)}
 }
 
-func (_c *MockStore_SessionListExpired_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockStore_SessionListExpired_Call {
+func (_c *MockStore_TagUpdate_Call) Run(run func(ctx context.Context, tag *models.Tag)) *MockStore_TagUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 time.Time
+		var arg1 *models.Tag
 		if args[1] != nil {
-			arg1 = args[1].(time.Time)
-		}
-		var arg2 int
-		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg1 = args[1].(*models.Tag)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SessionListExpired_Call) Return(expiredSessions []store.ExpiredSession, err error) *MockStore_SessionListExpired_Call {
-	_c.Call.Return(expiredSessions, err)
+func (_c *MockStore_TagUpdate_Call) Return(err error) *MockStore_TagUpdate_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SessionListExpired_Call) RunAndReturn(run func(ctx context.Context, before time.Time, limit int) ([]store.ExpiredSession, error)) *MockStore_SessionListExpired_Call {
+func (_c *MockStore_TagUpdate_Call) RunAndReturn(run func(ctx context.Context, tag *models.Tag) error) *MockStore_TagUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionResolve provides a mock function for the type MockStore
-func (_mock *MockStore) SessionResolve(ctx context.Context, sc scope.Scope, resolver store.SessionResolver, value string, opts ...store.QueryOption) (*models.Session, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, sc, resolver, value)
-	}
-	ret := tmpRet
+// UserCreate provides a mock function for the type MockStore
+func (_mock *MockStore) UserCreate(ctx context.Context, user *models.User) (string, error) {
+	ret := _mock.Called(ctx, user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionResolve")
+		panic("no return value specified for UserCreate")
 	}
 
-	var r0 *models.Session
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SessionResolver, string, ...store.QueryOption) (*models.Session, error)); ok {
-		return returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) (string, error)); ok {
+		return returnFunc(ctx, user)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.SessionResolver, string, ...store.QueryOption) *models.Session); ok {
-		r0 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) string); ok {
+		r0 = returnFunc(ctx, user)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Session)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.SessionResolver, string, ...store.QueryOption) error); ok {
-		r1 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.User) error); ok {
+		r1 = returnFunc(ctx, user)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_SessionResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionResolve'
-type MockStore_SessionResolve_Call struct {
+// MockStore_UserCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserCreate'
+type MockStore_UserCreate_Call struct {
 	*mock.Call
 }
 
-// SessionResolve is a helper method to define mock.On call
+// UserCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - resolver store.SessionResolver
-//   - value string
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) SessionResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_SessionResolve_Call {
-	return &MockStore_SessionResolve_Call{Call: _e.mock.On("SessionResolve",
-		append([]any{ctx, sc, resolver, value}, opts...)...)}
+//   - user *models.User
+func (_e *MockStore_Expecter) UserCreate(ctx any, user any) *MockStore_UserCreate_Call {
+	return &MockStore_UserCreate_Call{Call: _e.mock.On("UserCreate", ctx, user)}
 }
 
-func (_c *MockStore_SessionResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.SessionResolver, value string, opts ...store.QueryOption)) *MockStore_SessionResolve_Call {
+func (_c *MockStore_UserCreate_Call) Run(run func(ctx context.Context, user *models.User)) *MockStore_UserCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 *models.User
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
-		}
-		var arg2 store.SessionResolver
-		if args[2] != nil {
-			arg2 = args[2].(store.SessionResolver)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 4 {
-			variadicArgs = args[4].([]store.QueryOption)
+			arg1 = args[1].(*models.User)
 		}
-		arg4 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
-			arg4...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SessionResolve_Call) Return(session *models.Session, err error) *MockStore_SessionResolve_Call {
-	_c.Call.Return(session, err)
+func (_c *MockStore_UserCreate_Call) Return(insertedID string, err error) *MockStore_UserCreate_Call {
+	_c.Call.Return(insertedID, err)
 	return _c
 }
 
-func (_c *MockStore_SessionResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.SessionResolver, value string, opts ...store.QueryOption) (*models.Session, error)) *MockStore_SessionResolve_Call {
+func (_c *MockStore_UserCreate_Call) RunAndReturn(run func(ctx context.Context, user *models.User) (string, error)) *MockStore_UserCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) SessionUpdate(ctx context.Context, session *models.Session) error {
-	ret := _mock.Called(ctx, session)
+// UserDelete provides a mock function for the type MockStore
+func (_mock *MockStore) UserDelete(ctx context.Context, user *models.User) error {
+	ret := _mock.Called(ctx, user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionUpdate")
+		panic("no return value specified for UserDelete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
-		r0 = returnFunc(ctx, session)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = returnFunc(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_SessionUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionUpdate'
-type MockStore_SessionUpdate_Call struct {
+// MockStore_UserDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserDelete'
+type MockStore_UserDelete_Call struct {
 	*mock.Call
 }
 
-// SessionUpdate is a helper method to define mock.On call
+// UserDelete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - session *models.Session
-func (_e *MockStore_Expecter) SessionUpdate(ctx any, session any) *MockStore_SessionUpdate_Call {
-	return &MockStore_SessionUpdate_Call{Call: _e.mock.On("SessionUpdate", ctx, session)}
+//   - user *models.User
+func (_e *MockStore_Expecter) UserDelete(ctx any, user any) *MockStore_UserDelete_Call {
+	return &MockStore_UserDelete_Call{Call: _e.mock.On("UserDelete", ctx, user)}
 }
 
-func (_c *MockStore_SessionUpdate_Call) Run(run func(ctx context.Context, session *models.Session)) *MockStore_SessionUpdate_Call {
+func (_c *MockStore_UserDelete_Call) Run(run func(ctx context.Context, user *models.User)) *MockStore_UserDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Session
+		var arg1 *models.User
 		if args[1] != nil {
-			arg1 = args[1].(*models.Session)
+			arg1 = args[1].(*models.User)
 		}
 		run(
 			arg0,
@@ -6671,179 +9574,196 @@ func (_c *MockStore_SessionUpdate_Call) Run(run func(ctx context.Context, sessio
 	return _c
 }
 
-func (_c *MockStore_SessionUpdate_Call) Return(err error) *MockStore_SessionUpdate_Call {
+func (_c *MockStore_UserDelete_Call) Return(err error) *MockStore_UserDelete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SessionUpdate_Call) RunAndReturn(run func(ctx context.Context, session *models.Session) error) *MockStore_SessionUpdate_Call {
+func (_c *MockStore_UserDelete_Call) RunAndReturn(run func(ctx context.Context, user *models.User) error) *MockStore_UserDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SessionUpdateDeviceUID provides a mock function for the type MockStore
-func (_mock *MockStore) SessionUpdateDeviceUID(ctx context.Context, oldUID models.UID, newUID models.UID) error {
-	ret := _mock.Called(ctx, oldUID, newUID)
+// UserGetInfo provides a mock function for the type MockStore
+func (_mock *MockStore) UserGetInfo(ctx context.Context, id string) (*models.UserInfo, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SessionUpdateDeviceUID")
+		panic("no return value specified for UserGetInfo")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UID, models.UID) error); ok {
-		r0 = returnFunc(ctx, oldUID, newUID)
+	var r0 *models.UserInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.UserInfo, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.UserInfo); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserInfo)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_SessionUpdateDeviceUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionUpdateDeviceUID'
-type MockStore_SessionUpdateDeviceUID_Call struct {
+// MockStore_UserGetInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserGetInfo'
+type MockStore_UserGetInfo_Call struct {
 	*mock.Call
 }
 
-// SessionUpdateDeviceUID is a helper method to define mock.On call
+// UserGetInfo is a helper method to define mock.On call
 //   - ctx context.Context
-//   - oldUID models.UID
-//   - newUID models.UID
-func (_e *MockStore_Expecter) SessionUpdateDeviceUID(ctx any, oldUID any, newUID any) *MockStore_SessionUpdateDeviceUID_Call {
-	return &MockStore_SessionUpdateDeviceUID_Call{Call: _e.mock.On("SessionUpdateDeviceUID", ctx, oldUID, newUID)}
+//   - id string
+func (_e *MockStore_Expecter) UserGetInfo(ctx any, id any) *MockStore_UserGetInfo_Call {
+	return &MockStore_UserGetInfo_Call{Call: _e.mock.On("UserGetInfo", ctx, id)}
 }
 
-func (_c *MockStore_SessionUpdateDeviceUID_Call) Run(run func(ctx context.Context, oldUID models.UID, newUID models.UID)) *MockStore_SessionUpdateDeviceUID_Call {
+func (_c *MockStore_UserGetInfo_Call) Run(run func(ctx context.Context, id string)) *MockStore_UserGetInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.UID
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(models.UID)
-		}
-		var arg2 models.UID
-		if args[2] != nil {
-			arg2 = args[2].(models.UID)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SessionUpdateDeviceUID_Call) Return(err error) *MockStore_SessionUpdateDeviceUID_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_UserGetInfo_Call) Return(userInfo *models.UserInfo, err error) *MockStore_UserGetInfo_Call {
+	_c.Call.Return(userInfo, err)
 	return _c
 }
 
-func (_c *MockStore_SessionUpdateDeviceUID_Call) RunAndReturn(run func(ctx context.Context, oldUID models.UID, newUID models.UID) error) *MockStore_SessionUpdateDeviceUID_Call {
+func (_c *MockStore_UserGetInfo_Call) RunAndReturn(run func(ctx context.Context, id string) (*models.UserInfo, error)) *MockStore_UserGetInfo_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SystemGet provides a mock function for the type MockStore
-func (_mock *MockStore) SystemGet(ctx context.Context) (*models.System, error) {
-	ret := _mock.Called(ctx)
+// UserInvitationGet provides a mock function for the type MockStore
+func (_mock *MockStore) UserInvitationGet(ctx context.Context, resolver store.UserInvitationResolver, value string) (*models.UserInvitation, error) {
+	ret := _mock.Called(ctx, resolver, value)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SystemGet")
+		panic("no return value specified for UserInvitationGet")
 	}
 
-	var r0 *models.System
+	var r0 *models.UserInvitation
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*models.System, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.UserInvitationResolver, string) (*models.UserInvitation, error)); ok {
+		return returnFunc(ctx, resolver, value)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *models.System); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.UserInvitationResolver, string) *models.UserInvitation); ok {
+		r0 = returnFunc(ctx, resolver, value)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.System)
+			r0 = ret.Get(0).(*models.UserInvitation)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, store.UserInvitationResolver, string) error); ok {
+		r1 = returnFunc(ctx, resolver, value)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_SystemGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SystemGet'
-type MockStore_SystemGet_Call struct {
+// MockStore_UserInvitationGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserInvitationGet'
+type MockStore_UserInvitationGet_Call struct {
 	*mock.Call
 }
 
-// SystemGet is a helper method to define mock.On call
+// UserInvitationGet is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) SystemGet(ctx any) *MockStore_SystemGet_Call {
-	return &MockStore_SystemGet_Call{Call: _e.mock.On("SystemGet", ctx)}
+//   - resolver store.UserInvitationResolver
+//   - value string
+func (_e *MockStore_Expecter) UserInvitationGet(ctx any, resolver any, value any) *MockStore_UserInvitationGet_Call {
+	return &MockStore_UserInvitationGet_Call{Call: _e.mock.On("UserInvitationGet", ctx, resolver, value)}
 }
 
-func (_c *MockStore_SystemGet_Call) Run(run func(ctx context.Context)) *MockStore_SystemGet_Call {
+func (_c *MockStore_UserInvitationGet_Call) Run(run func(ctx context.Context, resolver store.UserInvitationResolver, value string)) *MockStore_UserInvitationGet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 store.UserInvitationResolver
+		if args[1] != nil {
+			arg1 = args[1].(store.UserInvitationResolver)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_SystemGet_Call) Return(system *models.System, err error) *MockStore_SystemGet_Call {
-	_c.Call.Return(system, err)
+func (_c *MockStore_UserInvitationGet_Call) Return(userInvitation *models.UserInvitation, err error) *MockStore_UserInvitationGet_Call {
+	_c.Call.Return(userInvitation, err)
 	return _c
 }
 
-func (_c *MockStore_SystemGet_Call) RunAndReturn(run func(ctx context.Context) (*models.System, error)) *MockStore_SystemGet_Call {
+func (_c *MockStore_UserInvitationGet_Call) RunAndReturn(run func(ctx context.Context, resolver store.UserInvitationResolver, value string) (*models.UserInvitation, error)) *MockStore_UserInvitationGet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SystemSet provides a mock function for the type MockStore
-func (_mock *MockStore) SystemSet(ctx context.Context, system *models.System) error {
-	ret := _mock.Called(ctx, system)
+// UserInvitationUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) UserInvitationUpdate(ctx context.Context, invitation *models.UserInvitation) error {
+	ret := _mock.Called(ctx, invitation)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SystemSet")
+		panic("no return value specified for UserInvitationUpdate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.System) error); ok {
-		r0 = returnFunc(ctx, system)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.UserInvitation) error); ok {
+		r0 = returnFunc(ctx, invitation)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_SystemSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SystemSet'
-type MockStore_SystemSet_Call struct {
+// MockStore_UserInvitationUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserInvitationUpdate'
+type MockStore_UserInvitationUpdate_Call struct {
 	*mock.Call
 }
 
-// SystemSet is a helper method to define mock.On call
+// UserInvitationUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - system *models.System
-func (_e *MockStore_Expecter) SystemSet(ctx any, system any) *MockStore_SystemSet_Call {
-	return &MockStore_SystemSet_Call{Call: _e.mock.On("SystemSet", ctx, system)}
+//   - invitation *models.UserInvitation
+func (_e *MockStore_Expecter) UserInvitationUpdate(ctx any, invitation any) *MockStore_UserInvitationUpdate_Call {
+	return &MockStore_UserInvitationUpdate_Call{Call: _e.mock.On("UserInvitationUpdate", ctx, invitation)}
 }
 
-func (_c *MockStore_SystemSet_Call) Run(run func(ctx context.Context, system *models.System)) *MockStore_SystemSet_Call {
+func (_c *MockStore_UserInvitationUpdate_Call) Run(run func(ctx context.Context, invitation *models.UserInvitation)) *MockStore_UserInvitationUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.System
+		var arg1 *models.UserInvitation
 		if args[1] != nil {
-			arg1 = args[1].(*models.System)
+			arg1 = args[1].(*models.UserInvitation)
 		}
 		run(
 			arg0,
@@ -6853,418 +9773,431 @@ func (_c *MockStore_SystemSet_Call) Run(run func(ctx context.Context, system *mo
 	return _c
 }
 
-func (_c *MockStore_SystemSet_Call) Return(err error) *MockStore_SystemSet_Call {
+func (_c *MockStore_UserInvitationUpdate_Call) Return(err error) *MockStore_UserInvitationUpdate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_SystemSet_Call) RunAndReturn(run func(ctx context.Context, system *models.System) error) *MockStore_SystemSet_Call {
+func (_c *MockStore_UserInvitationUpdate_Call) RunAndReturn(run func(ctx context.Context, invitation *models.UserInvitation) error) *MockStore_UserInvitationUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TagConflicts provides a mock function for the type MockStore
-func (_mock *MockStore) TagConflicts(ctx context.Context, sc scope.Scope, target *models.TagConflicts) ([]string, bool, error) {
-	ret := _mock.Called(ctx, sc, target)
+// UserInvitationsUpsert provides a mock function for the type MockStore
+func (_mock *MockStore) UserInvitationsUpsert(ctx context.Context, email string) (string, error) {
+	ret := _mock.Called(ctx, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TagConflicts")
+		panic("no return value specified for UserInvitationsUpsert")
 	}
 
-	var r0 []string
-	var r1 bool
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.TagConflicts) ([]string, bool, error)); ok {
-		return returnFunc(ctx, sc, target)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, *models.TagConflicts) []string); ok {
-		r0 = returnFunc(ctx, sc, target)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, email)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, *models.TagConflicts) bool); ok {
-		r1 = returnFunc(ctx, sc, target)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, email)
 	} else {
-		r1 = ret.Get(1).(bool)
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, *models.TagConflicts) error); ok {
-		r2 = returnFunc(ctx, sc, target)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_TagConflicts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagConflicts'
-type MockStore_TagConflicts_Call struct {
+// MockStore_UserInvitationsUpsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserInvitationsUpsert'
+type MockStore_UserInvitationsUpsert_Call struct {
 	*mock.Call
 }
 
-// TagConflicts is a helper method to define mock.On call
+// UserInvitationsUpsert is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - target *models.TagConflicts
-func (_e *MockStore_Expecter) TagConflicts(ctx any, sc any, target any) *MockStore_TagConflicts_Call {
-	return &MockStore_TagConflicts_Call{Call: _e.mock.On("TagConflicts", ctx, sc, target)}
+//   - email string
+func (_e *MockStore_Expecter) UserInvitationsUpsert(ctx any, email any) *MockStore_UserInvitationsUpsert_Call {
+	return &MockStore_UserInvitationsUpsert_Call{Call: _e.mock.On("UserInvitationsUpsert", ctx, email)}
 }
 
-func (_c *MockStore_TagConflicts_Call) Run(run func(ctx context.Context, sc scope.Scope, target *models.TagConflicts)) *MockStore_TagConflicts_Call {
+func (_c *MockStore_UserInvitationsUpsert_Call) Run(run func(ctx context.Context, email string)) *MockStore_UserInvitationsUpsert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
-		}
-		var arg2 *models.TagConflicts
-		if args[2] != nil {
-			arg2 = args[2].(*models.TagConflicts)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_TagConflicts_Call) Return(conflicts []string, has bool, err error) *MockStore_TagConflicts_Call {
-	_c.Call.Return(conflicts, has, err)
+func (_c *MockStore_UserInvitationsUpsert_Call) Return(upsertedID string, err error) *MockStore_UserInvitationsUpsert_Call {
+	_c.Call.Return(upsertedID, err)
 	return _c
 }
 
-func (_c *MockStore_TagConflicts_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, target *models.TagConflicts) ([]string, bool, error)) *MockStore_TagConflicts_Call {
+func (_c *MockStore_UserInvitationsUpsert_Call) RunAndReturn(run func(ctx context.Context, email string) (string, error)) *MockStore_UserInvitationsUpsert_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TagCreate provides a mock function for the type MockStore
-func (_mock *MockStore) TagCreate(ctx context.Context, tag *models.Tag) (string, error) {
-	ret := _mock.Called(ctx, tag)
+// UserList provides a mock function for the type MockStore
+func (_mock *MockStore) UserList(ctx context.Context, opts ...store.QueryOption) ([]models.User, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, opts)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for TagCreate")
+		panic("no return value specified for UserList")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Tag) (string, error)); ok {
-		return returnFunc(ctx, tag)
+	var r0 []models.User
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) ([]models.User, int, error)); ok {
+		return returnFunc(ctx, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Tag) string); ok {
-		r0 = returnFunc(ctx, tag)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []models.User); ok {
+		r0 = returnFunc(ctx, opts...)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.User)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.Tag) error); ok {
-		r1 = returnFunc(ctx, tag)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, opts...)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_TagCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagCreate'
-type MockStore_TagCreate_Call struct {
+// MockStore_UserList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserList'
+type MockStore_UserList_Call struct {
 	*mock.Call
 }
 
-// TagCreate is a helper method to define mock.On call
+// UserList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tag *models.Tag
-func (_e *MockStore_Expecter) TagCreate(ctx any, tag any) *MockStore_TagCreate_Call {
-	return &MockStore_TagCreate_Call{Call: _e.mock.On("TagCreate", ctx, tag)}
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) UserList(ctx any, opts ...any) *MockStore_UserList_Call {
+	return &MockStore_UserList_Call{Call: _e.mock.On("UserList",
+		append([]any{ctx}, opts...)...)}
 }
 
-func (_c *MockStore_TagCreate_Call) Run(run func(ctx context.Context, tag *models.Tag)) *MockStore_TagCreate_Call {
+func (_c *MockStore_UserList_Call) Run(run func(ctx context.Context, opts ...store.QueryOption)) *MockStore_UserList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Tag
-		if args[1] != nil {
-			arg1 = args[1].(*models.Tag)
+		var arg1 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]store.QueryOption)
 		}
+		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_TagCreate_Call) Return(insertedID string, err error) *MockStore_TagCreate_Call {
-	_c.Call.Return(insertedID, err)
+func (_c *MockStore_UserList_Call) Return(users []models.User, n int, err error) *MockStore_UserList_Call {
+	_c.Call.Return(users, n, err)
 	return _c
 }
 
-func (_c *MockStore_TagCreate_Call) RunAndReturn(run func(ctx context.Context, tag *models.Tag) (string, error)) *MockStore_TagCreate_Call {
+func (_c *MockStore_UserList_Call) RunAndReturn(run func(ctx context.Context, opts ...store.QueryOption) ([]models.User, int, error)) *MockStore_UserList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TagDelete provides a mock function for the type MockStore
-func (_mock *MockStore) TagDelete(ctx context.Context, tag *models.Tag) error {
-	ret := _mock.Called(ctx, tag)
+// UserMembershipInvitationList provides a mock function for the type MockStore
+func (_mock *MockStore) UserMembershipInvitationList(ctx context.Context, userID string, opts ...store.QueryOption) ([]models.MembershipInvitation, int64, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, userID, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, userID)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for TagDelete")
+		panic("no return value specified for UserMembershipInvitationList")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Tag) error); ok {
-		r0 = returnFunc(ctx, tag)
+	var r0 []models.MembershipInvitation
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, ...store.QueryOption) ([]models.MembershipInvitation, int64, error)); ok {
+		return returnFunc(ctx, userID, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, ...store.QueryOption) []models.MembershipInvitation); ok {
+		r0 = returnFunc(ctx, userID, opts...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MembershipInvitation)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, ...store.QueryOption) int64); ok {
+		r1 = returnFunc(ctx, userID, opts...)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, userID, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_TagDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagDelete'
-type MockStore_TagDelete_Call struct {
+// MockStore_UserMembershipInvitationList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserMembershipInvitationList'
+type MockStore_UserMembershipInvitationList_Call struct {
 	*mock.Call
 }
 
-// TagDelete is a helper method to define mock.On call
+// UserMembershipInvitationList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tag *models.Tag
-func (_e *MockStore_Expecter) TagDelete(ctx any, tag any) *MockStore_TagDelete_Call {
-	return &MockStore_TagDelete_Call{Call: _e.mock.On("TagDelete", ctx, tag)}
+//   - userID string
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) UserMembershipInvitationList(ctx any, userID any, opts ...any) *MockStore_UserMembershipInvitationList_Call {
+	return &MockStore_UserMembershipInvitationList_Call{Call: _e.mock.On("UserMembershipInvitationList",
+		append([]any{ctx, userID}, opts...)...)}
 }
 
-func (_c *MockStore_TagDelete_Call) Run(run func(ctx context.Context, tag *models.Tag)) *MockStore_TagDelete_Call {
+func (_c *MockStore_UserMembershipInvitationList_Call) Run(run func(ctx context.Context, userID string, opts ...store.QueryOption)) *MockStore_UserMembershipInvitationList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Tag
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*models.Tag)
+			arg1 = args[1].(string)
+		}
+		var arg2 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]store.QueryOption)
 		}
+		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
+			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_TagDelete_Call) Return(err error) *MockStore_TagDelete_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_UserMembershipInvitationList_Call) Return(membershipInvitations []models.MembershipInvitation, n int64, err error) *MockStore_UserMembershipInvitationList_Call {
+	_c.Call.Return(membershipInvitations, n, err)
 	return _c
 }
 
-func (_c *MockStore_TagDelete_Call) RunAndReturn(run func(ctx context.Context, tag *models.Tag) error) *MockStore_TagDelete_Call {
+func (_c *MockStore_UserMembershipInvitationList_Call) RunAndReturn(run func(ctx context.Context, userID string, opts ...store.QueryOption) ([]models.MembershipInvitation, int64, error)) *MockStore_UserMembershipInvitationList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TagList provides a mock function for the type MockStore
-func (_mock *MockStore) TagList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Tag, int, error) {
+// UserResolve provides a mock function for the type MockStore
+func (_mock *MockStore) UserResolve(ctx context.Context, resolver store.UserResolver, value string, opts ...store.QueryOption) (*models.User, error) {
 	var tmpRet mock.Arguments
 	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, opts)
+		tmpRet = _mock.Called(ctx, resolver, value, opts)
 	} else {
-		tmpRet = _mock.Called(ctx, sc)
+		tmpRet = _mock.Called(ctx, resolver, value)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for TagList")
+		panic("no return value specified for UserResolve")
 	}
 
-	var r0 []models.Tag
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.Tag, int, error)); ok {
-		return returnFunc(ctx, sc, opts...)
+	var r0 *models.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.UserResolver, string, ...store.QueryOption) (*models.User, error)); ok {
+		return returnFunc(ctx, resolver, value, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.Tag); ok {
-		r0 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, store.UserResolver, string, ...store.QueryOption) *models.User); ok {
+		r0 = returnFunc(ctx, resolver, value, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Tag)
+			r0 = ret.Get(0).(*models.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, sc, opts...)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, sc, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, store.UserResolver, string, ...store.QueryOption) error); ok {
+		r1 = returnFunc(ctx, resolver, value, opts...)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_TagList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagList'
-type MockStore_TagList_Call struct {
+// MockStore_UserResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserResolve'
+type MockStore_UserResolve_Call struct {
 	*mock.Call
 }
 
-// TagList is a helper method to define mock.On call
+// UserResolve is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
+//   - resolver store.UserResolver
+//   - value string
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) TagList(ctx any, sc any, opts ...any) *MockStore_TagList_Call {
-	return &MockStore_TagList_Call{Call: _e.mock.On("TagList",
-		append([]any{ctx, sc}, opts...)...)}
+func (_e *MockStore_Expecter) UserResolve(ctx any, resolver any, value any, opts ...any) *MockStore_UserResolve_Call {
+	return &MockStore_UserResolve_Call{Call: _e.mock.On("UserResolve",
+		append([]any{ctx, resolver, value}, opts...)...)}
 }
 
-func (_c *MockStore_TagList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_TagList_Call {
+func (_c *MockStore_UserResolve_Call) Run(run func(ctx context.Context, resolver store.UserResolver, value string, opts ...store.QueryOption)) *MockStore_UserResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 store.UserResolver
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
+			arg1 = args[1].(store.UserResolver)
 		}
-		var arg2 []store.QueryOption
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []store.QueryOption
 		var variadicArgs []store.QueryOption
-		if len(args) > 2 {
-			variadicArgs = args[2].([]store.QueryOption)
+		if len(args) > 3 {
+			variadicArgs = args[3].([]store.QueryOption)
 		}
-		arg2 = variadicArgs
+		arg3 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
+			arg2,
+			arg3...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_TagList_Call) Return(tags []models.Tag, totalCount int, err error) *MockStore_TagList_Call {
-	_c.Call.Return(tags, totalCount, err)
+func (_c *MockStore_UserResolve_Call) Return(user *models.User, err error) *MockStore_UserResolve_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockStore_TagList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Tag, int, error)) *MockStore_TagList_Call {
+func (_c *MockStore_UserResolve_Call) RunAndReturn(run func(ctx context.Context, resolver store.UserResolver, value string, opts ...store.QueryOption) (*models.User, error)) *MockStore_UserResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TagPullFromTarget provides a mock function for the type MockStore
-func (_mock *MockStore) TagPullFromTarget(ctx context.Context, id string, target store.TagTarget, targetIDs ...string) error {
-	var tmpRet mock.Arguments
-	if len(targetIDs) > 0 {
-		tmpRet = _mock.Called(ctx, id, target, targetIDs)
-	} else {
-		tmpRet = _mock.Called(ctx, id, target)
-	}
-	ret := tmpRet
+// UserUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) UserUpdate(ctx context.Context, user *models.User) error {
+	ret := _mock.Called(ctx, user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TagPullFromTarget")
+		panic("no return value specified for UserUpdate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, store.TagTarget, ...string) error); ok {
-		r0 = returnFunc(ctx, id, target, targetIDs...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = returnFunc(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_TagPullFromTarget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagPullFromTarget'
-type MockStore_TagPullFromTarget_Call struct {
+// MockStore_UserUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserUpdate'
+type MockStore_UserUpdate_Call struct {
 	*mock.Call
 }
 
-// TagPullFromTarget is a helper method to define mock.On call
+// UserUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - target store.TagTarget
-//   - targetIDs ...string
-func (_e *MockStore_Expecter) TagPullFromTarget(ctx any, id any, target any, targetIDs ...any) *MockStore_TagPullFromTarget_Call {
-	return &MockStore_TagPullFromTarget_Call{Call: _e.mock.On("TagPullFromTarget",
-		append([]any{ctx, id, target}, targetIDs...)...)}
+//   - user *models.User
+func (_e *MockStore_Expecter) UserUpdate(ctx any, user any) *MockStore_UserUpdate_Call {
+	return &MockStore_UserUpdate_Call{Call: _e.mock.On("UserUpdate", ctx, user)}
 }
 
-func (_c *MockStore_TagPullFromTarget_Call) Run(run func(ctx context.Context, id string, target store.TagTarget, targetIDs ...string)) *MockStore_TagPullFromTarget_Call {
+func (_c *MockStore_UserUpdate_Call) Run(run func(ctx context.Context, user *models.User)) *MockStore_UserUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *models.User
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 store.TagTarget
-		if args[2] != nil {
-			arg2 = args[2].(store.TagTarget)
-		}
-		var arg3 []string
-		var variadicArgs []string
-		if len(args) > 3 {
-			variadicArgs = args[3].([]string)
+			arg1 = args[1].(*models.User)
 		}
-		arg3 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_TagPullFromTarget_Call) Return(err error) *MockStore_TagPullFromTarget_Call {
+func (_c *MockStore_UserUpdate_Call) Return(err error) *MockStore_UserUpdate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_TagPullFromTarget_Call) RunAndReturn(run func(ctx context.Context, id string, target store.TagTarget, targetIDs ...string) error) *MockStore_TagPullFromTarget_Call {
+func (_c *MockStore_UserUpdate_Call) RunAndReturn(run func(ctx context.Context, user *models.User) error) *MockStore_UserUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TagPushToTarget provides a mock function for the type MockStore
-func (_mock *MockStore) TagPushToTarget(ctx context.Context, id string, target store.TagTarget, targetID string) error {
-	ret := _mock.Called(ctx, id, target, targetID)
+// UserUpdatePreferredNamespace provides a mock function for the type MockStore
+func (_mock *MockStore) UserUpdatePreferredNamespace(ctx context.Context, userID string, tenantID string) error {
+	ret := _mock.Called(ctx, userID, tenantID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TagPushToTarget")
+		panic("no return value specified for UserUpdatePreferredNamespace")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, store.TagTarget, string) error); ok {
-		r0 = returnFunc(ctx, id, target, targetID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, tenantID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_TagPushToTarget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagPushToTarget'
-type MockStore_TagPushToTarget_Call struct {
+// MockStore_UserUpdatePreferredNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserUpdatePreferredNamespace'
+type MockStore_UserUpdatePreferredNamespace_Call struct {
 	*mock.Call
 }
 
-// TagPushToTarget is a helper method to define mock.On call
+// UserUpdatePreferredNamespace is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - target store.TagTarget
-//   - targetID string
-func (_e *MockStore_Expecter) TagPushToTarget(ctx any, id any, target any, targetID any) *MockStore_TagPushToTarget_Call {
-	return &MockStore_TagPushToTarget_Call{Call: _e.mock.On("TagPushToTarget", ctx, id, target, targetID)}
+//   - userID string
+//   - tenantID string
+func (_e *MockStore_Expecter) UserUpdatePreferredNamespace(ctx any, userID any, tenantID any) *MockStore_UserUpdatePreferredNamespace_Call {
+	return &MockStore_UserUpdatePreferredNamespace_Call{Call: _e.mock.On("UserUpdatePreferredNamespace", ctx, userID, tenantID)}
 }
 
-func (_c *MockStore_TagPushToTarget_Call) Run(run func(ctx context.Context, id string, target store.TagTarget, targetID string)) *MockStore_TagPushToTarget_Call {
+func (_c *MockStore_UserUpdatePreferredNamespace_Call) Run(run func(ctx context.Context, userID string, tenantID string)) *MockStore_UserUpdatePreferredNamespace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -7274,167 +10207,133 @@ func (_c *MockStore_TagPushToTarget_Call) Run(run func(ctx context.Context, id s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 store.TagTarget
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(store.TagTarget)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_TagPushToTarget_Call) Return(err error) *MockStore_TagPushToTarget_Call {
+func (_c *MockStore_UserUpdatePreferredNamespace_Call) Return(err error) *MockStore_UserUpdatePreferredNamespace_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_TagPushToTarget_Call) RunAndReturn(run func(ctx context.Context, id string, target store.TagTarget, targetID string) error) *MockStore_TagPushToTarget_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// TagResolve provides a mock function for the type MockStore
-func (_mock *MockStore) TagResolve(ctx context.Context, sc scope.Scope, resolver store.TagResolver, value string, opts ...store.QueryOption) (*models.Tag, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, sc, resolver, value, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, sc, resolver, value)
-	}
-	ret := tmpRet
-
+func (_c *MockStore_UserUpdatePreferredNamespace_Call) RunAndReturn(run func(ctx context.Context, userID string, tenantID string) error) *MockStore_UserUpdatePreferredNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WebhookCreate provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookCreate(ctx context.Context, webhook *models.Webhook) (string, error) {
+	ret := _mock.Called(ctx, webhook)
+
 	if len(ret) == 0 {
-		panic("no return value specified for TagResolve")
+		panic("no return value specified for WebhookCreate")
 	}
 
-	var r0 *models.Tag
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.TagResolver, string, ...store.QueryOption) (*models.Tag, error)); ok {
-		return returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Webhook) (string, error)); ok {
+		return returnFunc(ctx, webhook)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, store.TagResolver, string, ...store.QueryOption) *models.Tag); ok {
-		r0 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Webhook) string); ok {
+		r0 = returnFunc(ctx, webhook)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Tag)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, store.TagResolver, string, ...store.QueryOption) error); ok {
-		r1 = returnFunc(ctx, sc, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.Webhook) error); ok {
+		r1 = returnFunc(ctx, webhook)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_TagResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagResolve'
-type MockStore_TagResolve_Call struct {
+// MockStore_WebhookCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookCreate'
+type MockStore_WebhookCreate_Call struct {
 	*mock.Call
 }
 
-// TagResolve is a helper method to define mock.On call
+// WebhookCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sc scope.Scope
-//   - resolver store.TagResolver
-//   - value string
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) TagResolve(ctx any, sc any, resolver any, value any, opts ...any) *MockStore_TagResolve_Call {
-	return &MockStore_TagResolve_Call{Call: _e.mock.On("TagResolve",
-		append([]any{ctx, sc, resolver, value}, opts...)...)}
+//   - webhook *models.Webhook
+func (_e *MockStore_Expecter) WebhookCreate(ctx any, webhook any) *MockStore_WebhookCreate_Call {
+	return &MockStore_WebhookCreate_Call{Call: _e.mock.On("WebhookCreate", ctx, webhook)}
 }
 
-func (_c *MockStore_TagResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, resolver store.TagResolver, value string, opts ...store.QueryOption)) *MockStore_TagResolve_Call {
+func (_c *MockStore_WebhookCreate_Call) Run(run func(ctx context.Context, webhook *models.Webhook)) *MockStore_WebhookCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 scope.Scope
+		var arg1 *models.Webhook
 		if args[1] != nil {
-			arg1 = args[1].(scope.Scope)
+			arg1 = args[1].(*models.Webhook)
 		}
-		var arg2 store.TagResolver
-		if args[2] != nil {
-			arg2 = args[2].(store.TagResolver)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 4 {
-			variadicArgs = args[4].([]store.QueryOption)
-		}
-		arg4 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
-			arg4...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_TagResolve_Call) Return(tag *models.Tag, err error) *MockStore_TagResolve_Call {
-	_c.Call.Return(tag, err)
+func (_c *MockStore_WebhookCreate_Call) Return(s string, err error) *MockStore_WebhookCreate_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockStore_TagResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, resolver store.TagResolver, value string, opts ...store.QueryOption) (*models.Tag, error)) *MockStore_TagResolve_Call {
+func (_c *MockStore_WebhookCreate_Call) RunAndReturn(run func(ctx context.Context, webhook *models.Webhook) (string, error)) *MockStore_WebhookCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TagUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) TagUpdate(ctx context.Context, tag *models.Tag) error {
-	ret := _mock.Called(ctx, tag)
+// WebhookDelete provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookDelete(ctx context.Context, webhook *models.Webhook) error {
+	ret := _mock.Called(ctx, webhook)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TagUpdate")
+		panic("no return value specified for WebhookDelete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Tag) error); ok {
-		r0 = returnFunc(ctx, tag)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = returnFunc(ctx, webhook)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_TagUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagUpdate'
-type MockStore_TagUpdate_Call struct {
+// MockStore_WebhookDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookDelete'
+type MockStore_WebhookDelete_Call struct {
 	*mock.Call
 }
 
-// TagUpdate is a helper method to define mock.On call
+// WebhookDelete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tag *models.Tag
-func (_e *MockStore_Expecter) TagUpdate(ctx any, tag any) *MockStore_TagUpdate_Call {
-	return &MockStore_TagUpdate_Call{Call: _e.mock.On("TagUpdate", ctx, tag)}
+//   - webhook *models.Webhook
+func (_e *MockStore_Expecter) WebhookDelete(ctx any, webhook any) *MockStore_WebhookDelete_Call {
+	return &MockStore_WebhookDelete_Call{Call: _e.mock.On("WebhookDelete", ctx, webhook)}
 }
 
-func (_c *MockStore_TagUpdate_Call) Run(run func(ctx context.Context, tag *models.Tag)) *MockStore_TagUpdate_Call {
+func (_c *MockStore_WebhookDelete_Call) Run(run func(ctx context.Context, webhook *models.Webhook)) *MockStore_WebhookDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Tag
+		var arg1 *models.Webhook
 		if args[1] != nil {
-			arg1 = args[1].(*models.Tag)
+			arg1 = args[1].(*models.Webhook)
 		}
 		run(
 			arg0,
@@ -7444,120 +10343,141 @@ func (_c *MockStore_TagUpdate_Call) Run(run func(ctx context.Context, tag *model
 	return _c
 }
 
-func (_c *MockStore_TagUpdate_Call) Return(err error) *MockStore_TagUpdate_Call {
+func (_c *MockStore_WebhookDelete_Call) Return(err error) *MockStore_WebhookDelete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_TagUpdate_Call) RunAndReturn(run func(ctx context.Context, tag *models.Tag) error) *MockStore_TagUpdate_Call {
+func (_c *MockStore_WebhookDelete_Call) RunAndReturn(run func(ctx context.Context, webhook *models.Webhook) error) *MockStore_WebhookDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserCreate provides a mock function for the type MockStore
-func (_mock *MockStore) UserCreate(ctx context.Context, user *models.User) (string, error) {
-	ret := _mock.Called(ctx, user)
+// WebhookDeliveryClaim provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookDeliveryClaim(ctx context.Context, id string, now time.Time, until time.Time) (bool, error) {
+	ret := _mock.Called(ctx, id, now, until)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserCreate")
+		panic("no return value specified for WebhookDeliveryClaim")
 	}
 
-	var r0 string
+	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) (string, error)); ok {
-		return returnFunc(ctx, user)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, id, now, until)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) string); ok {
-		r0 = returnFunc(ctx, user)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) bool); ok {
+		r0 = returnFunc(ctx, id, now, until)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.User) error); ok {
-		r1 = returnFunc(ctx, user)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, now, until)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_UserCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserCreate'
-type MockStore_UserCreate_Call struct {
+// MockStore_WebhookDeliveryClaim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookDeliveryClaim'
+type MockStore_WebhookDeliveryClaim_Call struct {
 	*mock.Call
 }
 
-// UserCreate is a helper method to define mock.On call
+// WebhookDeliveryClaim is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *models.User
-func (_e *MockStore_Expecter) UserCreate(ctx any, user any) *MockStore_UserCreate_Call {
-	return &MockStore_UserCreate_Call{Call: _e.mock.On("UserCreate", ctx, user)}
+//   - id string
+//   - now time.Time
+//   - until time.Time
+func (_e *MockStore_Expecter) WebhookDeliveryClaim(ctx any, id any, now any, until any) *MockStore_WebhookDeliveryClaim_Call {
+	return &MockStore_WebhookDeliveryClaim_Call{Call: _e.mock.On("WebhookDeliveryClaim", ctx, id, now, until)}
 }
 
-func (_c *MockStore_UserCreate_Call) Run(run func(ctx context.Context, user *models.User)) *MockStore_UserCreate_Call {
+func (_c *MockStore_WebhookDeliveryClaim_Call) Run(run func(ctx context.Context, id string, now time.Time, until time.Time)) *MockStore_WebhookDeliveryClaim_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.User
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*models.User)
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_UserCreate_Call) Return(insertedID string, err error) *MockStore_UserCreate_Call {
-	_c.Call.Return(insertedID, err)
+func (_c *MockStore_WebhookDeliveryClaim_Call) Return(b bool, err error) *MockStore_WebhookDeliveryClaim_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockStore_UserCreate_Call) RunAndReturn(run func(ctx context.Context, user *models.User) (string, error)) *MockStore_UserCreate_Call {
+func (_c *MockStore_WebhookDeliveryClaim_Call) RunAndReturn(run func(ctx context.Context, id string, now time.Time, until time.Time) (bool, error)) *MockStore_WebhookDeliveryClaim_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserDelete provides a mock function for the type MockStore
-func (_mock *MockStore) UserDelete(ctx context.Context, user *models.User) error {
-	ret := _mock.Called(ctx, user)
+// WebhookDeliveryCreate provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) (string, error) {
+	ret := _mock.Called(ctx, delivery)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserDelete")
+		panic("no return value specified for WebhookDeliveryCreate")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
-		r0 = returnFunc(ctx, user)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) (string, error)); ok {
+		return returnFunc(ctx, delivery)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) string); ok {
+		r0 = returnFunc(ctx, delivery)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r1 = returnFunc(ctx, delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_UserDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserDelete'
-type MockStore_UserDelete_Call struct {
+// MockStore_WebhookDeliveryCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookDeliveryCreate'
+type MockStore_WebhookDeliveryCreate_Call struct {
 	*mock.Call
 }
 
-// UserDelete is a helper method to define mock.On call
+// WebhookDeliveryCreate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *models.User
-func (_e *MockStore_Expecter) UserDelete(ctx any, user any) *MockStore_UserDelete_Call {
-	return &MockStore_UserDelete_Call{Call: _e.mock.On("UserDelete", ctx, user)}
+//   - delivery *models.WebhookDelivery
+func (_e *MockStore_Expecter) WebhookDeliveryCreate(ctx any, delivery any) *MockStore_WebhookDeliveryCreate_Call {
+	return &MockStore_WebhookDeliveryCreate_Call{Call: _e.mock.On("WebhookDeliveryCreate", ctx, delivery)}
 }
 
-func (_c *MockStore_UserDelete_Call) Run(run func(ctx context.Context, user *models.User)) *MockStore_UserDelete_Call {
+func (_c *MockStore_WebhookDeliveryCreate_Call) Run(run func(ctx context.Context, delivery *models.WebhookDelivery)) *MockStore_WebhookDeliveryCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.User
+		var arg1 *models.WebhookDelivery
 		if args[1] != nil {
-			arg1 = args[1].(*models.User)
+			arg1 = args[1].(*models.WebhookDelivery)
 		}
 		run(
 			arg0,
@@ -7567,65 +10487,63 @@ func (_c *MockStore_UserDelete_Call) Run(run func(ctx context.Context, user *mod
 	return _c
 }
 
-func (_c *MockStore_UserDelete_Call) Return(err error) *MockStore_UserDelete_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_WebhookDeliveryCreate_Call) Return(s string, err error) *MockStore_WebhookDeliveryCreate_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockStore_UserDelete_Call) RunAndReturn(run func(ctx context.Context, user *models.User) error) *MockStore_UserDelete_Call {
+func (_c *MockStore_WebhookDeliveryCreate_Call) RunAndReturn(run func(ctx context.Context, delivery *models.WebhookDelivery) (string, error)) *MockStore_WebhookDeliveryCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserGetInfo provides a mock function for the type MockStore
-func (_mock *MockStore) UserGetInfo(ctx context.Context, id string) (*models.UserInfo, error) {
-	ret := _mock.Called(ctx, id)
+// WebhookDeliveryDeleteBefore provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookDeliveryDeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserGetInfo")
+		panic("no return value specified for WebhookDeliveryDeleteBefore")
 	}
 
-	var r0 *models.UserInfo
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.UserInfo, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.UserInfo); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.UserInfo)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_UserGetInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserGetInfo'
-type MockStore_UserGetInfo_Call struct {
+// MockStore_WebhookDeliveryDeleteBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookDeliveryDeleteBefore'
+type MockStore_WebhookDeliveryDeleteBefore_Call struct {
 	*mock.Call
 }
 
-// UserGetInfo is a helper method to define mock.On call
+// WebhookDeliveryDeleteBefore is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockStore_Expecter) UserGetInfo(ctx any, id any) *MockStore_UserGetInfo_Call {
-	return &MockStore_UserGetInfo_Call{Call: _e.mock.On("UserGetInfo", ctx, id)}
+//   - before time.Time
+func (_e *MockStore_Expecter) WebhookDeliveryDeleteBefore(ctx any, before any) *MockStore_WebhookDeliveryDeleteBefore_Call {
+	return &MockStore_WebhookDeliveryDeleteBefore_Call{Call: _e.mock.On("WebhookDeliveryDeleteBefore", ctx, before)}
 }
 
-func (_c *MockStore_UserGetInfo_Call) Run(run func(ctx context.Context, id string)) *MockStore_UserGetInfo_Call {
+func (_c *MockStore_WebhookDeliveryDeleteBefore_Call) Run(run func(ctx context.Context, before time.Time)) *MockStore_WebhookDeliveryDeleteBefore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
@@ -7635,359 +10553,379 @@ func (_c *MockStore_UserGetInfo_Call) Run(run func(ctx context.Context, id strin
 	return _c
 }
 
-func (_c *MockStore_UserGetInfo_Call) Return(userInfo *models.UserInfo, err error) *MockStore_UserGetInfo_Call {
-	_c.Call.Return(userInfo, err)
+func (_c *MockStore_WebhookDeliveryDeleteBefore_Call) Return(n int64, err error) *MockStore_WebhookDeliveryDeleteBefore_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockStore_UserGetInfo_Call) RunAndReturn(run func(ctx context.Context, id string) (*models.UserInfo, error)) *MockStore_UserGetInfo_Call {
+func (_c *MockStore_WebhookDeliveryDeleteBefore_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockStore_WebhookDeliveryDeleteBefore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserInvitationGet provides a mock function for the type MockStore
-func (_mock *MockStore) UserInvitationGet(ctx context.Context, resolver store.UserInvitationResolver, value string) (*models.UserInvitation, error) {
-	ret := _mock.Called(ctx, resolver, value)
+// WebhookDeliveryList provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookDeliveryList(ctx context.Context, sc scope.Scope, webhookID string, opts ...store.QueryOption) ([]models.WebhookDelivery, int, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, sc, webhookID, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, sc, webhookID)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserInvitationGet")
+		panic("no return value specified for WebhookDeliveryList")
 	}
 
-	var r0 *models.UserInvitation
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, store.UserInvitationResolver, string) (*models.UserInvitation, error)); ok {
-		return returnFunc(ctx, resolver, value)
+	var r0 []models.WebhookDelivery
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, ...store.QueryOption) ([]models.WebhookDelivery, int, error)); ok {
+		return returnFunc(ctx, sc, webhookID, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, store.UserInvitationResolver, string) *models.UserInvitation); ok {
-		r0 = returnFunc(ctx, resolver, value)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string, ...store.QueryOption) []models.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, sc, webhookID, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.UserInvitation)
+			r0 = ret.Get(0).([]models.WebhookDelivery)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, store.UserInvitationResolver, string) error); ok {
-		r1 = returnFunc(ctx, resolver, value)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, string, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, webhookID, opts...)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, string, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, webhookID, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockStore_UserInvitationGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserInvitationGet'
-type MockStore_UserInvitationGet_Call struct {
+// MockStore_WebhookDeliveryList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookDeliveryList'
+type MockStore_WebhookDeliveryList_Call struct {
 	*mock.Call
 }
 
-// UserInvitationGet is a helper method to define mock.On call
+// WebhookDeliveryList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - resolver store.UserInvitationResolver
-//   - value string
-func (_e *MockStore_Expecter) UserInvitationGet(ctx any, resolver any, value any) *MockStore_UserInvitationGet_Call {
-	return &MockStore_UserInvitationGet_Call{Call: _e.mock.On("UserInvitationGet", ctx, resolver, value)}
+//   - sc scope.Scope
+//   - webhookID string
+//   - opts ...store.QueryOption
+func (_e *MockStore_Expecter) WebhookDeliveryList(ctx any, sc any, webhookID any, opts ...any) *MockStore_WebhookDeliveryList_Call {
+	return &MockStore_WebhookDeliveryList_Call{Call: _e.mock.On("WebhookDeliveryList",
+		append([]any{ctx, sc, webhookID}, opts...)...)}
 }
 
-func (_c *MockStore_UserInvitationGet_Call) Run(run func(ctx context.Context, resolver store.UserInvitationResolver, value string)) *MockStore_UserInvitationGet_Call {
+func (_c *MockStore_WebhookDeliveryList_Call) Run(run func(ctx context.Context, sc scope.Scope, webhookID string, opts ...store.QueryOption)) *MockStore_WebhookDeliveryList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 store.UserInvitationResolver
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(store.UserInvitationResolver)
+			arg1 = args[1].(scope.Scope)
 		}
 		var arg2 string
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 []store.QueryOption
+		var variadicArgs []store.QueryOption
+		if len(args) > 3 {
+			variadicArgs = args[3].([]store.QueryOption)
+		}
+		arg3 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_UserInvitationGet_Call) Return(userInvitation *models.UserInvitation, err error) *MockStore_UserInvitationGet_Call {
-	_c.Call.Return(userInvitation, err)
+func (_c *MockStore_WebhookDeliveryList_Call) Return(webhookDeliverys []models.WebhookDelivery, n int, err error) *MockStore_WebhookDeliveryList_Call {
+	_c.Call.Return(webhookDeliverys, n, err)
 	return _c
 }
 
-func (_c *MockStore_UserInvitationGet_Call) RunAndReturn(run func(ctx context.Context, resolver store.UserInvitationResolver, value string) (*models.UserInvitation, error)) *MockStore_UserInvitationGet_Call {
+func (_c *MockStore_WebhookDeliveryList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, webhookID string, opts ...store.QueryOption) ([]models.WebhookDelivery, int, error)) *MockStore_WebhookDeliveryList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserInvitationUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) UserInvitationUpdate(ctx context.Context, invitation *models.UserInvitation) error {
-	ret := _mock.Called(ctx, invitation)
+// WebhookDeliveryListDue provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookDeliveryListDue(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, now, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserInvitationUpdate")
+		panic("no return value specified for WebhookDeliveryListDue")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.UserInvitation) error); ok {
-		r0 = returnFunc(ctx, invitation)
+	var r0 []models.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]models.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, now, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []models.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, now, limit)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_UserInvitationUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserInvitationUpdate'
-type MockStore_UserInvitationUpdate_Call struct {
+// MockStore_WebhookDeliveryListDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookDeliveryListDue'
+type MockStore_WebhookDeliveryListDue_Call struct {
 	*mock.Call
 }
 
-// UserInvitationUpdate is a helper method to define mock.On call
+// WebhookDeliveryListDue is a helper method to define mock.On call
 //   - ctx context.Context
-//   - invitation *models.UserInvitation
-func (_e *MockStore_Expecter) UserInvitationUpdate(ctx any, invitation any) *MockStore_UserInvitationUpdate_Call {
-	return &MockStore_UserInvitationUpdate_Call{Call: _e.mock.On("UserInvitationUpdate", ctx, invitation)}
+//   - now time.Time
+//   - limit int
+func (_e *MockStore_Expecter) WebhookDeliveryListDue(ctx any, now any, limit any) *MockStore_WebhookDeliveryListDue_Call {
+	return &MockStore_WebhookDeliveryListDue_Call{Call: _e.mock.On("WebhookDeliveryListDue", ctx, now, limit)}
 }
 
-func (_c *MockStore_UserInvitationUpdate_Call) Run(run func(ctx context.Context, invitation *models.UserInvitation)) *MockStore_UserInvitationUpdate_Call {
+func (_c *MockStore_WebhookDeliveryListDue_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *MockStore_WebhookDeliveryListDue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.UserInvitation
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(*models.UserInvitation)
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_UserInvitationUpdate_Call) Return(err error) *MockStore_UserInvitationUpdate_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_WebhookDeliveryListDue_Call) Return(webhookDeliverys []models.WebhookDelivery, err error) *MockStore_WebhookDeliveryListDue_Call {
+	_c.Call.Return(webhookDeliverys, err)
 	return _c
 }
 
-func (_c *MockStore_UserInvitationUpdate_Call) RunAndReturn(run func(ctx context.Context, invitation *models.UserInvitation) error) *MockStore_UserInvitationUpdate_Call {
+func (_c *MockStore_WebhookDeliveryListDue_Call) RunAndReturn(run func(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)) *MockStore_WebhookDeliveryListDue_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserInvitationsUpsert provides a mock function for the type MockStore
-func (_mock *MockStore) UserInvitationsUpsert(ctx context.Context, email string) (string, error) {
-	ret := _mock.Called(ctx, email)
+// WebhookDeliveryResolve provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookDeliveryResolve(ctx context.Context, sc scope.Scope, id string) (*models.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, sc, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserInvitationsUpsert")
+		panic("no return value specified for WebhookDeliveryResolve")
 	}
 
-	var r0 string
+	var r0 *models.WebhookDelivery
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return returnFunc(ctx, email)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) (*models.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, sc, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, email)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) *models.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, sc, id)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookDelivery)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, email)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, string) error); ok {
+		r1 = returnFunc(ctx, sc, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_UserInvitationsUpsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserInvitationsUpsert'
-type MockStore_UserInvitationsUpsert_Call struct {
+// MockStore_WebhookDeliveryResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookDeliveryResolve'
+type MockStore_WebhookDeliveryResolve_Call struct {
 	*mock.Call
 }
 
-// UserInvitationsUpsert is a helper method to define mock.On call
+// WebhookDeliveryResolve is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-func (_e *MockStore_Expecter) UserInvitationsUpsert(ctx any, email any) *MockStore_UserInvitationsUpsert_Call {
-	return &MockStore_UserInvitationsUpsert_Call{Call: _e.mock.On("UserInvitationsUpsert", ctx, email)}
+//   - sc scope.Scope
+//   - id string
+func (_e *MockStore_Expecter) WebhookDeliveryResolve(ctx any, sc any, id any) *MockStore_WebhookDeliveryResolve_Call {
+	return &MockStore_WebhookDeliveryResolve_Call{Call: _e.mock.On("WebhookDeliveryResolve", ctx, sc, id)}
 }
 
-func (_c *MockStore_UserInvitationsUpsert_Call) Run(run func(ctx context.Context, email string)) *MockStore_UserInvitationsUpsert_Call {
+func (_c *MockStore_WebhookDeliveryResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, id string)) *MockStore_WebhookDeliveryResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_UserInvitationsUpsert_Call) Return(upsertedID string, err error) *MockStore_UserInvitationsUpsert_Call {
-	_c.Call.Return(upsertedID, err)
+func (_c *MockStore_WebhookDeliveryResolve_Call) Return(webhookDelivery *models.WebhookDelivery, err error) *MockStore_WebhookDeliveryResolve_Call {
+	_c.Call.Return(webhookDelivery, err)
 	return _c
 }
 
-func (_c *MockStore_UserInvitationsUpsert_Call) RunAndReturn(run func(ctx context.Context, email string) (string, error)) *MockStore_UserInvitationsUpsert_Call {
+func (_c *MockStore_WebhookDeliveryResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, id string) (*models.WebhookDelivery, error)) *MockStore_WebhookDeliveryResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserList provides a mock function for the type MockStore
-func (_mock *MockStore) UserList(ctx context.Context, opts ...store.QueryOption) ([]models.User, int, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, opts)
-	} else {
-		tmpRet = _mock.Called(ctx)
-	}
-	ret := tmpRet
+// WebhookDeliveryUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookDeliveryUpdate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	ret := _mock.Called(ctx, delivery)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserList")
+		panic("no return value specified for WebhookDeliveryUpdate")
 	}
 
-	var r0 []models.User
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) ([]models.User, int, error)); ok {
-		return returnFunc(ctx, opts...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []models.User); ok {
-		r0 = returnFunc(ctx, opts...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.User)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) int); ok {
-		r1 = returnFunc(ctx, opts...)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, opts...)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = returnFunc(ctx, delivery)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
-	return r0, r1, r2
+	return r0
 }
 
-// MockStore_UserList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserList'
-type MockStore_UserList_Call struct {
+// MockStore_WebhookDeliveryUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookDeliveryUpdate'
+type MockStore_WebhookDeliveryUpdate_Call struct {
 	*mock.Call
 }
 
-// UserList is a helper method to define mock.On call
+// WebhookDeliveryUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) UserList(ctx any, opts ...any) *MockStore_UserList_Call {
-	return &MockStore_UserList_Call{Call: _e.mock.On("UserList",
-		append([]any{ctx}, opts...)...)}
+//   - delivery *models.WebhookDelivery
+func (_e *MockStore_Expecter) WebhookDeliveryUpdate(ctx any, delivery any) *MockStore_WebhookDeliveryUpdate_Call {
+	return &MockStore_WebhookDeliveryUpdate_Call{Call: _e.mock.On("WebhookDeliveryUpdate", ctx, delivery)}
 }
 
-func (_c *MockStore_UserList_Call) Run(run func(ctx context.Context, opts ...store.QueryOption)) *MockStore_UserList_Call {
+func (_c *MockStore_WebhookDeliveryUpdate_Call) Run(run func(ctx context.Context, delivery *models.WebhookDelivery)) *MockStore_WebhookDeliveryUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 1 {
-			variadicArgs = args[1].([]store.QueryOption)
+		var arg1 *models.WebhookDelivery
+		if args[1] != nil {
+			arg1 = args[1].(*models.WebhookDelivery)
 		}
-		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_UserList_Call) Return(users []models.User, n int, err error) *MockStore_UserList_Call {
-	_c.Call.Return(users, n, err)
+func (_c *MockStore_WebhookDeliveryUpdate_Call) Return(err error) *MockStore_WebhookDeliveryUpdate_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_UserList_Call) RunAndReturn(run func(ctx context.Context, opts ...store.QueryOption) ([]models.User, int, error)) *MockStore_UserList_Call {
+func (_c *MockStore_WebhookDeliveryUpdate_Call) RunAndReturn(run func(ctx context.Context, delivery *models.WebhookDelivery) error) *MockStore_WebhookDeliveryUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserMembershipInvitationList provides a mock function for the type MockStore
-func (_mock *MockStore) UserMembershipInvitationList(ctx context.Context, userID string, opts ...store.QueryOption) ([]models.MembershipInvitation, int64, error) {
+// WebhookList provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Webhook, int, error) {
 	var tmpRet mock.Arguments
 	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, userID, opts)
+		tmpRet = _mock.Called(ctx, sc, opts)
 	} else {
-		tmpRet = _mock.Called(ctx, userID)
+		tmpRet = _mock.Called(ctx, sc)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserMembershipInvitationList")
+		panic("no return value specified for WebhookList")
 	}
 
-	var r0 []models.MembershipInvitation
-	var r1 int64
+	var r0 []models.Webhook
+	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, ...store.QueryOption) ([]models.MembershipInvitation, int64, error)); ok {
-		return returnFunc(ctx, userID, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) ([]models.Webhook, int, error)); ok {
+		return returnFunc(ctx, sc, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, ...store.QueryOption) []models.MembershipInvitation); ok {
-		r0 = returnFunc(ctx, userID, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, ...store.QueryOption) []models.Webhook); ok {
+		r0 = returnFunc(ctx, sc, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.MembershipInvitation)
+			r0 = ret.Get(0).([]models.Webhook)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, ...store.QueryOption) int64); ok {
-		r1 = returnFunc(ctx, userID, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, ...store.QueryOption) int); ok {
+		r1 = returnFunc(ctx, sc, opts...)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, ...store.QueryOption) error); ok {
-		r2 = returnFunc(ctx, userID, opts...)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, scope.Scope, ...store.QueryOption) error); ok {
+		r2 = returnFunc(ctx, sc, opts...)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockStore_UserMembershipInvitationList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserMembershipInvitationList'
-type MockStore_UserMembershipInvitationList_Call struct {
+// MockStore_WebhookList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookList'
+type MockStore_WebhookList_Call struct {
 	*mock.Call
 }
 
-// UserMembershipInvitationList is a helper method to define mock.On call
+// WebhookList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
+//   - sc scope.Scope
 //   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) UserMembershipInvitationList(ctx any, userID any, opts ...any) *MockStore_UserMembershipInvitationList_Call {
-	return &MockStore_UserMembershipInvitationList_Call{Call: _e.mock.On("UserMembershipInvitationList",
-		append([]any{ctx, userID}, opts...)...)}
+func (_e *MockStore_Expecter) WebhookList(ctx any, sc any, opts ...any) *MockStore_WebhookList_Call {
+	return &MockStore_WebhookList_Call{Call: _e.mock.On("WebhookList",
+		append([]any{ctx, sc}, opts...)...)}
 }
 
-func (_c *MockStore_UserMembershipInvitationList_Call) Run(run func(ctx context.Context, userID string, opts ...store.QueryOption)) *MockStore_UserMembershipInvitationList_Call {
+func (_c *MockStore_WebhookList_Call) Run(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption)) *MockStore_WebhookList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(scope.Scope)
 		}
 		var arg2 []store.QueryOption
 		var variadicArgs []store.QueryOption
@@ -8004,221 +10942,217 @@ func (_c *MockStore_UserMembershipInvitationList_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *MockStore_UserMembershipInvitationList_Call) Return(membershipInvitations []models.MembershipInvitation, n int64, err error) *MockStore_UserMembershipInvitationList_Call {
-	_c.Call.Return(membershipInvitations, n, err)
+func (_c *MockStore_WebhookList_Call) Return(webhooks []models.Webhook, n int, err error) *MockStore_WebhookList_Call {
+	_c.Call.Return(webhooks, n, err)
 	return _c
 }
 
-func (_c *MockStore_UserMembershipInvitationList_Call) RunAndReturn(run func(ctx context.Context, userID string, opts ...store.QueryOption) ([]models.MembershipInvitation, int64, error)) *MockStore_UserMembershipInvitationList_Call {
+func (_c *MockStore_WebhookList_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Webhook, int, error)) *MockStore_WebhookList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserResolve provides a mock function for the type MockStore
-func (_mock *MockStore) UserResolve(ctx context.Context, resolver store.UserResolver, value string, opts ...store.QueryOption) (*models.User, error) {
-	var tmpRet mock.Arguments
-	if len(opts) > 0 {
-		tmpRet = _mock.Called(ctx, resolver, value, opts)
-	} else {
-		tmpRet = _mock.Called(ctx, resolver, value)
-	}
-	ret := tmpRet
+// WebhookListSubscribed provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookListSubscribed(ctx context.Context, sc scope.Scope, event models.WebhookEventType) ([]models.Webhook, error) {
+	ret := _mock.Called(ctx, sc, event)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserResolve")
+		panic("no return value specified for WebhookListSubscribed")
 	}
 
-	var r0 *models.User
+	var r0 []models.Webhook
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, store.UserResolver, string, ...store.QueryOption) (*models.User, error)); ok {
-		return returnFunc(ctx, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, models.WebhookEventType) ([]models.Webhook, error)); ok {
+		return returnFunc(ctx, sc, event)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, store.UserResolver, string, ...store.QueryOption) *models.User); ok {
-		r0 = returnFunc(ctx, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, models.WebhookEventType) []models.Webhook); ok {
+		r0 = returnFunc(ctx, sc, event)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.User)
+			r0 = ret.Get(0).([]models.Webhook)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, store.UserResolver, string, ...store.QueryOption) error); ok {
-		r1 = returnFunc(ctx, resolver, value, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, models.WebhookEventType) error); ok {
+		r1 = returnFunc(ctx, sc, event)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStore_UserResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserResolve'
-type MockStore_UserResolve_Call struct {
+// MockStore_WebhookListSubscribed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookListSubscribed'
+type MockStore_WebhookListSubscribed_Call struct {
 	*mock.Call
 }
 
-// UserResolve is a helper method to define mock.On call
+// WebhookListSubscribed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - resolver store.UserResolver
-//   - value string
-//   - opts ...store.QueryOption
-func (_e *MockStore_Expecter) UserResolve(ctx any, resolver any, value any, opts ...any) *MockStore_UserResolve_Call {
-	return &MockStore_UserResolve_Call{Call: _e.mock.On("UserResolve",
-		append([]any{ctx, resolver, value}, opts...)...)}
+//   - sc scope.Scope
+//   - event models.WebhookEventType
+func (_e *MockStore_Expecter) WebhookListSubscribed(ctx any, sc any, event any) *MockStore_WebhookListSubscribed_Call {
+	return &MockStore_WebhookListSubscribed_Call{Call: _e.mock.On("WebhookListSubscribed", ctx, sc, event)}
 }
 
-func (_c *MockStore_UserResolve_Call) Run(run func(ctx context.Context, resolver store.UserResolver, value string, opts ...store.QueryOption)) *MockStore_UserResolve_Call {
+func (_c *MockStore_WebhookListSubscribed_Call) Run(run func(ctx context.Context, sc scope.Scope, event models.WebhookEventType)) *MockStore_WebhookListSubscribed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 store.UserResolver
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(store.UserResolver)
+			arg1 = args[1].(scope.Scope)
 		}
-		var arg2 string
+		var arg2 models.WebhookEventType
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(models.WebhookEventType)
 		}
-		var arg3 []store.QueryOption
-		var variadicArgs []store.QueryOption
-		if len(args) > 3 {
-			variadicArgs = args[3].([]store.QueryOption)
-		}
-		arg3 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_UserResolve_Call) Return(user *models.User, err error) *MockStore_UserResolve_Call {
-	_c.Call.Return(user, err)
+func (_c *MockStore_WebhookListSubscribed_Call) Return(webhooks []models.Webhook, err error) *MockStore_WebhookListSubscribed_Call {
+	_c.Call.Return(webhooks, err)
 	return _c
 }
 
-func (_c *MockStore_UserResolve_Call) RunAndReturn(run func(ctx context.Context, resolver store.UserResolver, value string, opts ...store.QueryOption) (*models.User, error)) *MockStore_UserResolve_Call {
+func (_c *MockStore_WebhookListSubscribed_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, event models.WebhookEventType) ([]models.Webhook, error)) *MockStore_WebhookListSubscribed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserUpdate provides a mock function for the type MockStore
-func (_mock *MockStore) UserUpdate(ctx context.Context, user *models.User) error {
-	ret := _mock.Called(ctx, user)
+// WebhookResolve provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookResolve(ctx context.Context, sc scope.Scope, id string) (*models.Webhook, error) {
+	ret := _mock.Called(ctx, sc, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserUpdate")
+		panic("no return value specified for WebhookResolve")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
-		r0 = returnFunc(ctx, user)
+	var r0 *models.Webhook
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) (*models.Webhook, error)); ok {
+		return returnFunc(ctx, sc, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, scope.Scope, string) *models.Webhook); ok {
+		r0 = returnFunc(ctx, sc, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, scope.Scope, string) error); ok {
+		r1 = returnFunc(ctx, sc, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStore_UserUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserUpdate'
-type MockStore_UserUpdate_Call struct {
+// MockStore_WebhookResolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookResolve'
+type MockStore_WebhookResolve_Call struct {
 	*mock.Call
 }
 
-// UserUpdate is a helper method to define mock.On call
+// WebhookResolve is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *models.User
-func (_e *MockStore_Expecter) UserUpdate(ctx any, user any) *MockStore_UserUpdate_Call {
-	return &MockStore_UserUpdate_Call{Call: _e.mock.On("UserUpdate", ctx, user)}
+//   - sc scope.Scope
+//   - id string
+func (_e *MockStore_Expecter) WebhookResolve(ctx any, sc any, id any) *MockStore_WebhookResolve_Call {
+	return &MockStore_WebhookResolve_Call{Call: _e.mock.On("WebhookResolve", ctx, sc, id)}
 }
 
-func (_c *MockStore_UserUpdate_Call) Run(run func(ctx context.Context, user *models.User)) *MockStore_UserUpdate_Call {
+func (_c *MockStore_WebhookResolve_Call) Run(run func(ctx context.Context, sc scope.Scope, id string)) *MockStore_WebhookResolve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.User
+		var arg1 scope.Scope
 		if args[1] != nil {
-			arg1 = args[1].(*models.User)
+			arg1 = args[1].(scope.Scope)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_UserUpdate_Call) Return(err error) *MockStore_UserUpdate_Call {
-	_c.Call.Return(err)
+func (_c *MockStore_WebhookResolve_Call) Return(webhook *models.Webhook, err error) *MockStore_WebhookResolve_Call {
+	_c.Call.Return(webhook, err)
 	return _c
 }
 
-func (_c *MockStore_UserUpdate_Call) RunAndReturn(run func(ctx context.Context, user *models.User) error) *MockStore_UserUpdate_Call {
+func (_c *MockStore_WebhookResolve_Call) RunAndReturn(run func(ctx context.Context, sc scope.Scope, id string) (*models.Webhook, error)) *MockStore_WebhookResolve_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserUpdatePreferredNamespace provides a mock function for the type MockStore
-func (_mock *MockStore) UserUpdatePreferredNamespace(ctx context.Context, userID string, tenantID string) error {
-	ret := _mock.Called(ctx, userID, tenantID)
+// WebhookUpdate provides a mock function for the type MockStore
+func (_mock *MockStore) WebhookUpdate(ctx context.Context, webhook *models.Webhook) error {
+	ret := _mock.Called(ctx, webhook)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserUpdatePreferredNamespace")
+		panic("no return value specified for WebhookUpdate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, userID, tenantID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = returnFunc(ctx, webhook)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStore_UserUpdatePreferredNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserUpdatePreferredNamespace'
-type MockStore_UserUpdatePreferredNamespace_Call struct {
+// MockStore_WebhookUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookUpdate'
+type MockStore_WebhookUpdate_Call struct {
 	*mock.Call
 }
 
-// UserUpdatePreferredNamespace is a helper method to define mock.On call
+// WebhookUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - tenantID string
-func (_e *MockStore_Expecter) UserUpdatePreferredNamespace(ctx any, userID any, tenantID any) *MockStore_UserUpdatePreferredNamespace_Call {
-	return &MockStore_UserUpdatePreferredNamespace_Call{Call: _e.mock.On("UserUpdatePreferredNamespace", ctx, userID, tenantID)}
+//   - webhook *models.Webhook
+func (_e *MockStore_Expecter) WebhookUpdate(ctx any, webhook any) *MockStore_WebhookUpdate_Call {
+	return &MockStore_WebhookUpdate_Call{Call: _e.mock.On("WebhookUpdate", ctx, webhook)}
 }
 
-func (_c *MockStore_UserUpdatePreferredNamespace_Call) Run(run func(ctx context.Context, userID string, tenantID string)) *MockStore_UserUpdatePreferredNamespace_Call {
+func (_c *MockStore_WebhookUpdate_Call) Run(run func(ctx context.Context, webhook *models.Webhook)) *MockStore_WebhookUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *models.Webhook
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*models.Webhook)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStore_UserUpdatePreferredNamespace_Call) Return(err error) *MockStore_UserUpdatePreferredNamespace_Call {
+func (_c *MockStore_WebhookUpdate_Call) Return(err error) *MockStore_WebhookUpdate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStore_UserUpdatePreferredNamespace_Call) RunAndReturn(run func(ctx context.Context, userID string, tenantID string) error) *MockStore_UserUpdatePreferredNamespace_Call {
+func (_c *MockStore_WebhookUpdate_Call) RunAndReturn(run func(ctx context.Context, webhook *models.Webhook) error) *MockStore_WebhookUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
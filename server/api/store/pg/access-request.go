@@ -0,0 +1,169 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/shellhub-io/shellhub/server/api/store/pg/entity"
+	"github.com/uptrace/bun"
+)
+
+func (pg *Pg) AccessRequestCreate(ctx context.Context, request *models.AccessRequest) (string, error) {
+	err := pg.WithTransaction(ctx, func(ctx context.Context) error {
+		db := pg.GetConnection(ctx)
+
+		now := clock.Now()
+		request.CreatedAt = now
+		request.UpdatedAt = now
+
+		if request.ID == "" {
+			request.ID = uuid.Generate()
+		}
+
+		e := entity.AccessRequestFromModel(request)
+
+		if _, err := db.NewInsert().Model(e).Exec(ctx); err != nil {
+			return fromSQLError(err)
+		}
+
+		for _, tag := range e.Tags {
+			arTag := entity.NewAccessRequestTag(tag.ID, e.ID)
+			arTag.CreatedAt = now
+
+			if _, err := db.NewInsert().
+				Model(arTag).
+				On("CONFLICT (access_request_id, tag_id) DO NOTHING").
+				Exec(ctx); err != nil {
+				return fromSQLError(err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return request.ID, nil
+}
+
+func (pg *Pg) AccessRequestList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.AccessRequest, int, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.AccessRequest, 0)
+
+	query := db.NewSelect().Model(&entities).Relation("Tags")
+
+	query, err := applyScopedOptions(ctx, query, sc, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fromSQLError(err)
+	}
+
+	requests := make([]models.AccessRequest, len(entities))
+	for i, e := range entities {
+		requests[i] = *entity.AccessRequestToModel(&e)
+	}
+
+	return requests, count, nil
+}
+
+func (pg *Pg) AccessRequestResolve(ctx context.Context, sc scope.Scope, id string, opts ...store.QueryOption) (*models.AccessRequest, error) {
+	db := pg.GetConnection(ctx)
+
+	e := new(entity.AccessRequest)
+	query := db.NewSelect().Model(e).
+		Relation("Tags").
+		Relation("Events", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("created_at ASC")
+		}).
+		Where("access_request.id = ?", id)
+
+	query, err := applyScopedOptions(ctx, query, sc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	return entity.AccessRequestToModel(e), nil
+}
+
+func (pg *Pg) AccessRequestTransition(ctx context.Context, request *models.AccessRequest, from models.AccessRequestStatus) (bool, error) {
+	db := pg.GetConnection(ctx)
+
+	e := entity.AccessRequestFromModel(request)
+	e.UpdatedAt = clock.Now()
+
+	// Atomic claim: the status guard makes the affected-row count decide which
+	// caller moved the request.
+	res, err := db.NewUpdate().
+		Model(e).
+		Column("status", "decided_by", "decision_note", "decided_at", "policy_id", "expires_at", "updated_at").
+		Where("id = ?", request.ID).
+		Where("namespace_id = ?", request.TenantID).
+		Where("status = ?", string(from)).
+		Exec(ctx)
+	if err != nil {
+		return false, fromSQLError(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fromSQLError(err)
+	}
+
+	if affected == 1 {
+		request.UpdatedAt = e.UpdatedAt
+	}
+
+	return affected == 1, nil
+}
+
+func (pg *Pg) AccessRequestListLapsed(ctx context.Context, before time.Time) ([]models.AccessRequest, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.AccessRequest, 0)
+	if err := db.NewSelect().
+		Model(&entities).
+		Relation("Tags").
+		Where("status = ?", string(models.AccessRequestGranted)).
+		Where("expires_at <= ?", before).
+		Order("expires_at ASC").
+		Scan(ctx); err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	requests := make([]models.AccessRequest, len(entities))
+	for i, e := range entities {
+		requests[i] = *entity.AccessRequestToModel(&e)
+	}
+
+	return requests, nil
+}
+
+func (pg *Pg) AccessRequestEventCreate(ctx context.Context, event *models.AccessRequestEvent) error {
+	db := pg.GetConnection(ctx)
+
+	event.CreatedAt = clock.Now()
+	if event.ID == "" {
+		event.ID = uuid.Generate()
+	}
+
+	if _, err := db.NewInsert().Model(entity.AccessRequestEventFromModel(event)).Exec(ctx); err != nil {
+		return fromSQLError(err)
+	}
+
+	return nil
+}
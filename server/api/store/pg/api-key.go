@@ -2,6 +2,7 @@ package pg
 
 import (
 	"context"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/scope"
 	"github.com/shellhub-io/shellhub/pkg/clock"
@@ -9,6 +10,7 @@ import (
 	"github.com/shellhub-io/shellhub/server/api/store"
 	"github.com/shellhub-io/shellhub/server/api/store/pg/entity"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
 )
 
 func (pg *Pg) APIKeyCreate(ctx context.Context, apiKey *models.APIKey) (string, error) {
@@ -124,7 +126,11 @@ func (pg *Pg) APIKeyUpdate(ctx context.Context, apiKey *models.APIKey) error {
 
 	a := entity.APIKeyFromModel(apiKey)
 	a.UpdatedAt = clock.Now()
-	r, err := db.NewUpdate().Model(a).OmitZero().WherePK().Exec(ctx)
+
+	// A full-model update (not OmitZero) so an emptied scope list is written and clears the scope
+	// instead of leaving the old one in place. The usage counters (usage_count/last_used_at) carry
+	// `skipupdate` on the entity, so a concurrent request's increment is never clobbered.
+	r, err := db.NewUpdate().Model(a).WherePK().Exec(ctx)
 	if err != nil {
 		return fromSQLError(err)
 	}
@@ -136,6 +142,39 @@ func (pg *Pg) APIKeyUpdate(ctx context.Context, apiKey *models.APIKey) error {
 	return nil
 }
 
+func (pg *Pg) APIKeyRecordUses(ctx context.Context, uses []store.APIKeyUse) error {
+	if len(uses) == 0 {
+		return nil
+	}
+
+	db := pg.GetConnection(ctx)
+
+	ids := make([]string, len(uses))
+	tenants := make([]string, len(uses))
+	counts := make([]int64, len(uses))
+	lastUsedAt := make([]time.Time, len(uses))
+
+	for i, use := range uses {
+		ids[i], tenants[i], counts[i], lastUsedAt[i] = use.ID, use.TenantID, use.Count, use.LastUsedAt
+	}
+
+	// GREATEST keeps a batch written late from moving the last use back.
+	if _, err := db.NewUpdate().
+		Model((*entity.APIKey)(nil)).
+		Set("usage_count = api_key.usage_count + _data.count").
+		Set("last_used_at = GREATEST(api_key.last_used_at, _data.last_used_at)").
+		TableExpr(
+			"unnest(?::varchar[], ?::uuid[], ?::bigint[], ?::timestamptz[]) AS _data(key_digest, namespace_id, count, last_used_at)",
+			pgdialect.Array(ids), pgdialect.Array(tenants), pgdialect.Array(counts), pgdialect.Array(lastUsedAt),
+		).
+		Where("api_key.key_digest = _data.key_digest AND api_key.namespace_id = _data.namespace_id").
+		Exec(ctx); err != nil {
+		return fromSQLError(err)
+	}
+
+	return nil
+}
+
 func (pg *Pg) APIKeyDelete(ctx context.Context, apiKey *models.APIKey) error {
 	db := pg.GetConnection(ctx)
 
@@ -0,0 +1,65 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/shellhub-io/shellhub/server/api/store/pg/entity"
+)
+
+func (pg *Pg) AuditEventCreate(ctx context.Context, event *models.AuditEvent) error {
+	db := pg.GetConnection(ctx)
+
+	event.CreatedAt = clock.Now()
+	if event.ID == "" {
+		event.ID = uuid.Generate()
+	}
+
+	if _, err := db.NewInsert().Model(entity.AuditEventFromModel(event)).Exec(ctx); err != nil {
+		return fromSQLError(err)
+	}
+
+	return nil
+}
+
+func (pg *Pg) AuditEventList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.AuditEvent, int, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.AuditEvent, 0)
+
+	query, err := applyScopedOptions(ctx, db.NewSelect().Model(&entities), sc, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fromSQLError(err)
+	}
+
+	events := make([]models.AuditEvent, len(entities))
+	for i, e := range entities {
+		events[i] = *entity.AuditEventToModel(&e)
+	}
+
+	return events, count, nil
+}
+
+func (pg *Pg) AuditEventDeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := pg.GetConnection(ctx)
+
+	res, err := db.NewDelete().
+		Model((*entity.AuditEvent)(nil)).
+		Where("created_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, fromSQLError(err)
+	}
+
+	return res.RowsAffected()
+}
@@ -129,6 +129,27 @@ func (pg *Pg) DeviceListExpiredEphemeral(ctx context.Context) ([]models.Device,
 	return devices, nil
 }
 
+func (pg *Pg) DeviceListDisconnected(ctx context.Context, uids []string) ([]models.Device, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.Device, 0)
+	err := db.NewSelect().
+		Model(&entities).
+		Where("id IN (?)", bun.List(uids)).
+		Where("disconnected_at IS NOT NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	devices := make([]models.Device, len(entities))
+	for i, e := range entities {
+		devices[i] = *entity.DeviceToModel(&e)
+	}
+
+	return devices, nil
+}
+
 func (pg *Pg) DeviceResolve(ctx context.Context, sc scope.Scope, resolver store.DeviceResolver, val string, opts ...store.QueryOption) (*models.Device, error) {
 	db := pg.GetConnection(ctx)
 
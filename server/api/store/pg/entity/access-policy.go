@@ -23,10 +23,28 @@ type AccessPolicy struct {
 	RequireReauth  bool      `bun:"require_reauth"`
 	ReauthPeriod   *int      `bun:"reauth_period"`
 	Action         string    `bun:"action"`
+	// Schedule is stored as jsonb, NULL when the policy applies at any time.
+	Schedule  *AccessPolicySchedule `bun:"schedule,type:jsonb"`
+	NotBefore *time.Time            `bun:"not_before"`
+	NotAfter  *time.Time            `bun:"not_after"`
+	// AccessRequestID is NULL for every policy not created by granting an
+	// access request.
+	AccessRequestID *string `bun:"access_request_id,type:uuid"`
 
 	Tags []*Tag `bun:"m2m:access_policy_tags,join:AccessPolicy=Tag"`
 }
 
+type AccessPolicySchedule struct {
+	Timezone string                       `json:"timezone"`
+	Windows  []AccessPolicyScheduleWindow `json:"windows"`
+}
+
+type AccessPolicyScheduleWindow struct {
+	Weekdays []string `json:"weekdays"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+}
+
 type AccessPolicyTag struct {
 	bun.BaseModel  `bun:"table:access_policy_tags"`
 	AccessPolicyID string    `bun:"access_policy_id,pk"`
@@ -56,9 +74,16 @@ func AccessPolicyFromModel(model *models.AccessPolicy) *AccessPolicy {
 		RequireReauth:  model.RequireReauth,
 		ReauthPeriod:   model.ReauthPeriod,
 		Action:         string(model.Action),
+		Schedule:       accessPolicyScheduleFromModel(model.Schedule),
+		NotBefore:      model.NotBefore,
+		NotAfter:       model.NotAfter,
 		Tags:           []*Tag{},
 	}
 
+	if model.AccessRequestID != "" {
+		accessPolicy.AccessRequestID = &model.AccessRequestID
+	}
+
 	// Handle Tags if fully populated (e.g., from API requests)
 	if len(model.Filter.Tags) > 0 {
 		accessPolicy.Tags = make([]*Tag, len(model.Filter.Tags))
@@ -98,6 +123,13 @@ func AccessPolicyToModel(entity *AccessPolicy) *models.AccessPolicy {
 		RequireReauth: entity.RequireReauth,
 		ReauthPeriod:  entity.ReauthPeriod,
 		Action:        models.PolicyAction(entity.Action),
+		Schedule:      accessPolicyScheduleToModel(entity.Schedule),
+		NotBefore:     entity.NotBefore,
+		NotAfter:      entity.NotAfter,
+	}
+
+	if entity.AccessRequestID != nil {
+		accessPolicy.AccessRequestID = *entity.AccessRequestID
 	}
 
 	if len(entity.Tags) > 0 {
@@ -111,3 +143,37 @@ func AccessPolicyToModel(entity *AccessPolicy) *models.AccessPolicy {
 
 	return accessPolicy
 }
+
+func accessPolicyScheduleFromModel(model *models.PolicySchedule) *AccessPolicySchedule {
+	if model == nil {
+		return nil
+	}
+
+	schedule := &AccessPolicySchedule{
+		Timezone: model.Timezone,
+		Windows:  make([]AccessPolicyScheduleWindow, len(model.Windows)),
+	}
+
+	for i, w := range model.Windows {
+		schedule.Windows[i] = AccessPolicyScheduleWindow{Weekdays: w.Weekdays, Start: w.Start, End: w.End}
+	}
+
+	return schedule
+}
+
+func accessPolicyScheduleToModel(entity *AccessPolicySchedule) *models.PolicySchedule {
+	if entity == nil {
+		return nil
+	}
+
+	schedule := &models.PolicySchedule{
+		Timezone: entity.Timezone,
+		Windows:  make([]models.PolicyScheduleWindow, len(entity.Windows)),
+	}
+
+	for i, w := range entity.Windows {
+		schedule.Windows[i] = models.PolicyScheduleWindow{Weekdays: w.Weekdays, Start: w.Start, End: w.End}
+	}
+
+	return schedule
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessPolicyTimeConditionsRoundTrip(t *testing.T) {
+	notBefore := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		model *models.AccessPolicy
+	}{
+		{
+			name: "schedule and bounds",
+			model: &models.AccessPolicy{
+				ID:     "policy-1",
+				Logins: []string{"*"},
+				Action: models.PolicyActionAllow,
+				Schedule: &models.PolicySchedule{
+					Timezone: "Europe/Berlin",
+					Windows: []models.PolicyScheduleWindow{
+						{Weekdays: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "08:00", End: "18:00"},
+						{Weekdays: []string{"sat"}, Start: "22:00", End: "02:00"},
+					},
+				},
+				NotBefore: &notBefore,
+				NotAfter:  &notAfter,
+			},
+		},
+		{
+			name: "no time conditions",
+			model: &models.AccessPolicy{
+				ID:     "policy-2",
+				Logins: []string{"root"},
+				Action: models.PolicyActionDeny,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := AccessPolicyToModel(AccessPolicyFromModel(tt.model))
+
+			assert.Equal(t, tt.model.Schedule, result.Schedule)
+			assert.Equal(t, tt.model.NotBefore, result.NotBefore)
+			assert.Equal(t, tt.model.NotAfter, result.NotAfter)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+type AccessRequest struct {
+	bun.BaseModel `bun:"table:access_requests"`
+
+	ID             string     `bun:"id,pk,type:uuid"`
+	NamespaceID    string     `bun:"namespace_id"`
+	UserID         string     `bun:"user_id,type:uuid"`
+	FilterHostname string     `bun:"filter_hostname"`
+	Logins         []string   `bun:"logins,array"`
+	Duration       int        `bun:"duration"`
+	Justification  string     `bun:"justification"`
+	Status         string     `bun:"status"`
+	DecidedBy      *string    `bun:"decided_by,type:uuid"`
+	DecisionNote   string     `bun:"decision_note"`
+	DecidedAt      *time.Time `bun:"decided_at"`
+	PolicyID       *string    `bun:"policy_id,type:uuid"`
+	ExpiresAt      *time.Time `bun:"expires_at"`
+	CreatedAt      time.Time  `bun:"created_at"`
+	UpdatedAt      time.Time  `bun:"updated_at"`
+
+	Tags   []*Tag                `bun:"m2m:access_request_tags,join:AccessRequest=Tag"`
+	Events []*AccessRequestEvent `bun:"rel:has-many,join:id=access_request_id"`
+}
+
+type AccessRequestTag struct {
+	bun.BaseModel   `bun:"table:access_request_tags"`
+	AccessRequestID string    `bun:"access_request_id,pk"`
+	TagID           string    `bun:"tag_id,pk"`
+	CreatedAt       time.Time `bun:"created_at"`
+
+	AccessRequest *AccessRequest `bun:"rel:belongs-to,join:access_request_id=id"`
+	Tag           *Tag           `bun:"rel:belongs-to,join:tag_id=id"`
+}
+
+func NewAccessRequestTag(tagID, accessRequestID string) *AccessRequestTag {
+	return &AccessRequestTag{TagID: tagID, AccessRequestID: accessRequestID}
+}
+
+type AccessRequestEvent struct {
+	bun.BaseModel `bun:"table:access_request_events"`
+
+	ID              string    `bun:"id,pk,type:uuid"`
+	AccessRequestID string    `bun:"access_request_id,type:uuid"`
+	NamespaceID     string    `bun:"namespace_id"`
+	Action          string    `bun:"action"`
+	ActorID         *string   `bun:"actor_id,type:uuid"`
+	Note            string    `bun:"note"`
+	CreatedAt       time.Time `bun:"created_at"`
+}
+
+func AccessRequestFromModel(model *models.AccessRequest) *AccessRequest {
+	request := &AccessRequest{
+		ID:             model.ID,
+		NamespaceID:    model.TenantID,
+		UserID:         model.UserID,
+		FilterHostname: model.Filter.Hostname,
+		Logins:         model.Logins,
+		Duration:       model.Duration,
+		Justification:  model.Justification,
+		Status:         string(model.Status),
+		DecisionNote:   model.DecisionNote,
+		DecidedAt:      model.DecidedAt,
+		ExpiresAt:      model.ExpiresAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+		Tags:           make([]*Tag, len(model.Filter.TagIDs)),
+	}
+
+	for i, tagID := range model.Filter.TagIDs {
+		request.Tags[i] = &Tag{ID: tagID}
+	}
+
+	// decided_by is a users FK and policy_id a uuid, so an undecided request must
+	// write NULL rather than the empty string.
+	if model.DecidedBy != "" {
+		request.DecidedBy = &model.DecidedBy
+	}
+
+	if model.PolicyID != "" {
+		request.PolicyID = &model.PolicyID
+	}
+
+	return request
+}
+
+func AccessRequestToModel(e *AccessRequest) *models.AccessRequest {
+	request := &models.AccessRequest{
+		ID:       e.ID,
+		TenantID: e.NamespaceID,
+		UserID:   e.UserID,
+		Filter: models.PublicKeyFilter{
+			Hostname: e.FilterHostname,
+			Taggable: models.Taggable{Tags: []models.Tag{}},
+		},
+		Logins:        e.Logins,
+		Duration:      e.Duration,
+		Justification: e.Justification,
+		Status:        models.AccessRequestStatus(e.Status),
+		DecisionNote:  e.DecisionNote,
+		DecidedAt:     e.DecidedAt,
+		ExpiresAt:     e.ExpiresAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+
+	if e.DecidedBy != nil {
+		request.DecidedBy = *e.DecidedBy
+	}
+
+	if e.PolicyID != nil {
+		request.PolicyID = *e.PolicyID
+	}
+
+	if len(e.Tags) > 0 {
+		request.Filter.Tags = make([]models.Tag, len(e.Tags))
+		request.Filter.TagIDs = make([]string, len(e.Tags))
+		for i, t := range e.Tags {
+			request.Filter.Tags[i] = *TagToModel(t)
+			request.Filter.TagIDs[i] = t.ID
+		}
+	}
+
+	if len(e.Events) > 0 {
+		request.Events = make([]models.AccessRequestEvent, len(e.Events))
+		for i, event := range e.Events {
+			request.Events[i] = *AccessRequestEventToModel(event)
+		}
+	}
+
+	return request
+}
+
+func AccessRequestEventFromModel(model *models.AccessRequestEvent) *AccessRequestEvent {
+	event := &AccessRequestEvent{
+		ID:              model.ID,
+		AccessRequestID: model.AccessRequestID,
+		NamespaceID:     model.TenantID,
+		Action:          string(model.Action),
+		Note:            model.Note,
+		CreatedAt:       model.CreatedAt,
+	}
+
+	// actor_id is a users FK; the worker acts as no one and writes NULL.
+	if model.ActorID != "" {
+		event.ActorID = &model.ActorID
+	}
+
+	return event
+}
+
+func AccessRequestEventToModel(e *AccessRequestEvent) *models.AccessRequestEvent {
+	event := &models.AccessRequestEvent{
+		ID:              e.ID,
+		AccessRequestID: e.AccessRequestID,
+		TenantID:        e.NamespaceID,
+		Action:          models.AccessRequestAction(e.Action),
+		Note:            e.Note,
+		CreatedAt:       e.CreatedAt,
+	}
+
+	if e.ActorID != nil {
+		event.ActorID = *e.ActorID
+	}
+
+	return event
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessRequestRoundTrip(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		model *models.AccessRequest
+	}{
+		{
+			name: "pending",
+			model: &models.AccessRequest{
+				ID:            "request-1",
+				TenantID:      "tenant-id",
+				UserID:        "user-id",
+				Filter:        models.PublicKeyFilter{Hostname: ".*", Taggable: models.Taggable{Tags: []models.Tag{}}},
+				Logins:        []string{"root"},
+				Duration:      3600,
+				Justification: "incident",
+				Status:        models.AccessRequestPending,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			},
+		},
+		{
+			name: "granted",
+			model: &models.AccessRequest{
+				ID:            "request-2",
+				TenantID:      "tenant-id",
+				UserID:        "user-id",
+				Filter:        models.PublicKeyFilter{Hostname: ".*", Taggable: models.Taggable{Tags: []models.Tag{}}},
+				Logins:        []string{"root"},
+				Duration:      3600,
+				Justification: "incident",
+				Status:        models.AccessRequestGranted,
+				DecidedBy:     "approver-id",
+				DecisionNote:  "ok",
+				DecidedAt:     &now,
+				PolicyID:      "policy-id",
+				ExpiresAt:     &expiresAt,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := AccessRequestFromModel(tt.model)
+			// Undecided requests must write NULL into the FK columns.
+			assert.Equal(t, tt.model.DecidedBy == "", e.DecidedBy == nil)
+			assert.Equal(t, tt.model.PolicyID == "", e.PolicyID == nil)
+
+			assert.Equal(t, tt.model, AccessRequestToModel(e))
+		})
+	}
+}
+
+func TestAccessRequestEventRoundTrip(t *testing.T) {
+	event := &models.AccessRequestEvent{
+		ID:              "event-id",
+		AccessRequestID: "request-id",
+		TenantID:        "tenant-id",
+		Action:          models.AccessRequestActionExpired,
+		CreatedAt:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	e := AccessRequestEventFromModel(event)
+	assert.Nil(t, e.ActorID)
+	assert.Equal(t, event, AccessRequestEventToModel(e))
+}
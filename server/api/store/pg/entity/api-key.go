@@ -11,17 +11,32 @@ import (
 type APIKey struct {
 	bun.BaseModel `bun:"table:api_keys"`
 
-	KeyDigest   string    `bun:"key_digest,pk"`
-	NamespaceID string    `bun:"namespace_id,pk"`
-	Name        string    `bun:"name"`
-	Role        string    `bun:"role"`
-	UserID      string    `bun:"user_id"`
-	CreatedAt   time.Time `bun:"created_at"`
-	UpdatedAt   time.Time `bun:"updated_at"`
-	ExpiresIn   int64     `bun:"expires_in,nullzero"`
+	KeyDigest   string     `bun:"key_digest,pk"`
+	NamespaceID string     `bun:"namespace_id,pk"`
+	Name        string     `bun:"name"`
+	Role        string     `bun:"role"`
+	UserID      string     `bun:"user_id"`
+	CreatedAt   time.Time  `bun:"created_at"`
+	UpdatedAt   time.Time  `bun:"updated_at"`
+	ExpiresIn   int64      `bun:"expires_in,nullzero"`
+	Permissions []string   `bun:"permissions,array"`
+	Tags        []string   `bun:"tags,array"`
+	SourceIPs   []string   `bun:"source_ips,array"`
+	UsageCount  int64      `bun:"usage_count,skipupdate"`
+	LastUsedAt  *time.Time `bun:"last_used_at,nullzero,skipupdate"`
 }
 
 func APIKeyFromModel(model *models.APIKey) *APIKey {
+	// The scope columns are NOT NULL: a nil slice would be written as SQL NULL and violate the
+	// constraint, so coerce each to an empty array, the same shape the DEFAULT '{}' gives.
+	emptyIfNil := func(values []string) []string {
+		if values == nil {
+			return []string{}
+		}
+
+		return values
+	}
+
 	return &APIKey{
 		Name:        model.Name,
 		NamespaceID: model.TenantID,
@@ -31,18 +46,28 @@ func APIKeyFromModel(model *models.APIKey) *APIKey {
 		CreatedAt:   model.CreatedAt,
 		UpdatedAt:   model.UpdatedAt,
 		ExpiresIn:   model.ExpiresIn,
+		Permissions: emptyIfNil(model.Permissions),
+		Tags:        emptyIfNil(model.Tags),
+		SourceIPs:   emptyIfNil(model.SourceIPs),
+		UsageCount:  model.UsageCount,
+		LastUsedAt:  model.LastUsedAt,
 	}
 }
 
 func APIKeyToModel(entity *APIKey) *models.APIKey {
 	return &models.APIKey{
-		ID:        entity.KeyDigest,
-		Name:      entity.Name,
-		TenantID:  entity.NamespaceID,
-		Role:      authorizer.Role(entity.Role),
-		CreatedBy: entity.UserID,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
-		ExpiresIn: entity.ExpiresIn,
+		ID:          entity.KeyDigest,
+		Name:        entity.Name,
+		TenantID:    entity.NamespaceID,
+		Role:        authorizer.Role(entity.Role),
+		CreatedBy:   entity.UserID,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+		ExpiresIn:   entity.ExpiresIn,
+		Permissions: entity.Permissions,
+		Tags:        entity.Tags,
+		SourceIPs:   entity.SourceIPs,
+		UsageCount:  entity.UsageCount,
+		LastUsedAt:  entity.LastUsedAt,
 	}
 }
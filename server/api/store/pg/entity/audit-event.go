@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+type AuditEvent struct {
+	bun.BaseModel `bun:"table:audit_events"`
+
+	ID          string         `bun:"id,pk,type:uuid"`
+	NamespaceID string         `bun:"namespace_id"`
+	ActorType   string         `bun:"actor_type"`
+	ActorID     string         `bun:"actor_id"`
+	ActorName   string         `bun:"actor_name"`
+	Action      string         `bun:"action"`
+	TargetType  string         `bun:"target_type"`
+	TargetID    string         `bun:"target_id"`
+	Before      map[string]any `bun:"before,type:jsonb"`
+	After       map[string]any `bun:"after,type:jsonb"`
+	SourceIP    string         `bun:"source_ip"`
+	RequestID   string         `bun:"request_id"`
+	CreatedAt   time.Time      `bun:"created_at"`
+}
+
+func AuditEventFromModel(model *models.AuditEvent) *AuditEvent {
+	return &AuditEvent{
+		ID:          model.ID,
+		NamespaceID: model.TenantID,
+		ActorType:   string(model.Actor.Type),
+		ActorID:     model.Actor.ID,
+		ActorName:   model.Actor.Name,
+		Action:      string(model.Action),
+		TargetType:  string(model.TargetType),
+		TargetID:    model.TargetID,
+		Before:      model.Before,
+		After:       model.After,
+		SourceIP:    model.SourceIP,
+		RequestID:   model.RequestID,
+		CreatedAt:   model.CreatedAt,
+	}
+}
+
+func AuditEventToModel(e *AuditEvent) *models.AuditEvent {
+	return &models.AuditEvent{
+		ID:       e.ID,
+		TenantID: e.NamespaceID,
+		Actor: models.AuditActor{
+			Type: models.AuditActorType(e.ActorType),
+			ID:   e.ActorID,
+			Name: e.ActorName,
+		},
+		Action:     models.AuditAction(e.Action),
+		TargetType: models.AuditTargetType(e.TargetType),
+		TargetID:   e.TargetID,
+		Before:     e.Before,
+		After:      e.After,
+		SourceIP:   e.SourceIP,
+		RequestID:  e.RequestID,
+		CreatedAt:  e.CreatedAt,
+	}
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEventRoundTrip(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	event := &models.AuditEvent{
+		ID:         "event-id",
+		TenantID:   "tenant-id",
+		Actor:      models.AuditActor{Type: models.AuditActorAPIKey, ID: "ci", Name: "ci"},
+		Action:     models.AuditActionMemberUpdate,
+		TargetType: models.AuditTargetMember,
+		TargetID:   "member-id",
+		Before:     map[string]any{"role": "observer"},
+		After:      map[string]any{"role": "operator"},
+		SourceIP:   "192.0.2.10",
+		RequestID:  "request-id",
+		CreatedAt:  now,
+	}
+
+	assert.Equal(t, event, AuditEventToModel(AuditEventFromModel(event)))
+}
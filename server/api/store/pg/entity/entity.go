@@ -6,19 +6,27 @@ func Entities() []any {
 		(*DeviceTag)(nil),
 		(*PublicKeyTag)(nil),
 		(*AccessPolicyTag)(nil),
+		(*AccessRequestTag)(nil),
+		(*SCIMGroupMember)(nil),
 
 		(*AccessPolicy)(nil),
+		(*AccessRequest)(nil),
+		(*AccessRequestEvent)(nil),
 		(*APIKey)(nil),
+		(*AuditEvent)(nil),
 		(*Device)(nil),
 		(*Membership)(nil),
 		(*Namespace)(nil),
 		(*PrivateKey)(nil),
 		(*PublicKey)(nil),
+		(*SCIMGroup)(nil),
 		(*Session)(nil),
 		(*ActiveSession)(nil),
 		(*SessionEvent)(nil),
 		(*System)(nil),
 		(*Tag)(nil),
 		(*User)(nil),
+		(*Webhook)(nil),
+		(*WebhookDelivery)(nil),
 	}
 }
@@ -32,6 +32,7 @@ type NamespaceSettings struct {
 	ConnectionAnnouncement string `bun:"connection_announcement,type:text"`
 	SSHAccessMode          string `bun:"ssh_access_mode"`
 	SSHLegacyAllowed       bool   `bun:"ssh_legacy_allowed"`
+	LocalAuthDisabled      bool   `bun:"local_auth_disabled"`
 }
 
 func NamespaceFromModel(model *models.Namespace) *Namespace {
@@ -61,6 +62,7 @@ func NamespaceFromModel(model *models.Namespace) *Namespace {
 		namespace.Settings.ConnectionAnnouncement = model.Settings.ConnectionAnnouncement
 		namespace.Settings.SSHAccessMode = model.Settings.SSHAccessMode
 		namespace.Settings.SSHLegacyAllowed = model.Settings.SSHLegacyAllowed
+		namespace.Settings.LocalAuthDisabled = model.Settings.LocalAuthDisabled
 	}
 
 	namespace.Memberships = make([]Membership, len(model.Members))
@@ -93,6 +95,7 @@ func NamespaceToModel(entity *Namespace) *models.Namespace {
 			ConnectionAnnouncement: entity.Settings.ConnectionAnnouncement,
 			SSHAccessMode:          entity.Settings.SSHAccessMode,
 			SSHLegacyAllowed:       entity.Settings.SSHLegacyAllowed,
+			LocalAuthDisabled:      entity.Settings.LocalAuthDisabled,
 		},
 	}
 
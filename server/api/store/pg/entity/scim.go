@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+type SCIMGroup struct {
+	bun.BaseModel `bun:"table:scim_groups,alias:scim_group"`
+
+	ID          string    `bun:"id,pk,type:uuid"`
+	CreatedAt   time.Time `bun:"created_at"`
+	UpdatedAt   time.Time `bun:"updated_at"`
+	ExternalID  string    `bun:"external_id,nullzero"`
+	DisplayName string    `bun:"display_name"`
+	Members     []string  `bun:"members,array,scanonly"`
+}
+
+type SCIMGroupMember struct {
+	bun.BaseModel `bun:"table:scim_group_members"`
+
+	SCIMGroupID string    `bun:"scim_group_id,pk,type:uuid"`
+	UserID      string    `bun:"user_id,pk,type:uuid"`
+	CreatedAt   time.Time `bun:"created_at"`
+}
+
+func SCIMGroupFromModel(model *models.SCIMGroup) *SCIMGroup {
+	return &SCIMGroup{
+		ID:          model.ID,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+		ExternalID:  model.ExternalID,
+		DisplayName: model.DisplayName,
+		Members:     model.Members,
+	}
+}
+
+func SCIMGroupToModel(e *SCIMGroup) *models.SCIMGroup {
+	members := e.Members
+	if members == nil {
+		members = []string{}
+	}
+
+	return &models.SCIMGroup{
+		ID:          e.ID,
+		ExternalID:  e.ExternalID,
+		DisplayName: e.DisplayName,
+		Members:     members,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
@@ -14,24 +14,28 @@ import (
 type Session struct {
 	bun.BaseModel `bun:"table:sessions"`
 
-	ID            string    `bun:"id,pk"`
-	NamespaceID   string    `bun:"namespace_id"`
-	DeviceID      string    `bun:"device_id"`
-	Username      string    `bun:"username"`
-	UserID        string    `bun:"user_id,nullzero"`
-	IPAddress     string    `bun:"ip_address"`
-	StartedAt     time.Time `bun:"started_at"`
-	SeenAt        time.Time `bun:"seen_at"`
-	Closed        bool      `bun:"closed"`
-	Authenticated bool      `bun:"authenticated"`
-	Recorded      bool      `bun:"recorded"`
-	Type          string    `bun:"type"`
-	Term          string    `bun:"term"`
-	Web           bool      `bun:"web"`
-	Longitude     float64   `bun:"longitude"`
-	Latitude      float64   `bun:"latitude"`
-	CreatedAt     time.Time `bun:"created_at"`
-	UpdatedAt     time.Time `bun:"updated_at"`
+	ID                   string    `bun:"id,pk"`
+	NamespaceID          string    `bun:"namespace_id"`
+	DeviceID             string    `bun:"device_id"`
+	Username             string    `bun:"username"`
+	UserID               string    `bun:"user_id,nullzero"`
+	Fingerprint          string    `bun:"fingerprint,nullzero"`
+	CertificateCAID      string    `bun:"certificate_ca_id,nullzero"`
+	CertificatePrincipal string    `bun:"certificate_principal,nullzero"`
+	APIKey               string    `bun:"api_key,nullzero"`
+	IPAddress            string    `bun:"ip_address"`
+	StartedAt            time.Time `bun:"started_at"`
+	SeenAt               time.Time `bun:"seen_at"`
+	Closed               bool      `bun:"closed"`
+	Authenticated        bool      `bun:"authenticated"`
+	Recorded             bool      `bun:"recorded"`
+	Type                 string    `bun:"type"`
+	Term                 string    `bun:"term"`
+	Web                  bool      `bun:"web"`
+	Longitude            float64   `bun:"longitude"`
+	Latitude             float64   `bun:"latitude"`
+	CreatedAt            time.Time `bun:"created_at"`
+	UpdatedAt            time.Time `bun:"updated_at"`
 	// Active indicates if the session is currently active (computed from active_sessions table)
 	Active bool `bun:"active,scanonly"`
 	// EventTypes is a comma-separated list of unique event types
@@ -51,23 +55,27 @@ func SessionFromModel(model *models.Session) *Session {
 	}
 
 	session := &Session{
-		ID:            model.UID,
-		NamespaceID:   model.TenantID,
-		DeviceID:      string(model.DeviceUID),
-		Username:      model.Username,
-		UserID:        model.UserID,
-		IPAddress:     model.IPAddress,
-		StartedAt:     model.StartedAt,
-		SeenAt:        model.LastSeen,
-		Closed:        model.Closed,
-		Authenticated: model.Authenticated,
-		Recorded:      model.Recorded,
-		Type:          sessionType,
-		Term:          model.Term,
-		Web:           model.Web,
-		Longitude:     model.Position.Longitude,
-		Latitude:      model.Position.Latitude,
-		UpdatedAt:     clock.Now(),
+		ID:                   model.UID,
+		NamespaceID:          model.TenantID,
+		DeviceID:             string(model.DeviceUID),
+		Username:             model.Username,
+		UserID:               model.UserID,
+		Fingerprint:          model.Fingerprint,
+		CertificateCAID:      model.CertificateCAID,
+		CertificatePrincipal: model.CertificatePrincipal,
+		APIKey:               model.APIKey,
+		IPAddress:            model.IPAddress,
+		StartedAt:            model.StartedAt,
+		SeenAt:               model.LastSeen,
+		Closed:               model.Closed,
+		Authenticated:        model.Authenticated,
+		Recorded:             model.Recorded,
+		Type:                 sessionType,
+		Term:                 model.Term,
+		Web:                  model.Web,
+		Longitude:            model.Position.Longitude,
+		Latitude:             model.Position.Latitude,
+		UpdatedAt:            clock.Now(),
 	}
 
 	return session
@@ -75,21 +83,25 @@ func SessionFromModel(model *models.Session) *Session {
 
 func SessionToModel(entity *Session) *models.Session {
 	session := &models.Session{
-		UID:           strings.TrimSpace(entity.ID),
-		TenantID:      entity.NamespaceID,
-		DeviceUID:     models.UID(strings.TrimSpace(entity.DeviceID)),
-		Username:      entity.Username,
-		UserID:        entity.UserID,
-		IPAddress:     entity.IPAddress,
-		StartedAt:     entity.StartedAt,
-		LastSeen:      entity.SeenAt,
-		Active:        entity.Active,
-		Closed:        entity.Closed,
-		Authenticated: entity.Authenticated,
-		Recorded:      entity.Recorded,
-		Type:          entity.Type,
-		Term:          entity.Term,
-		Web:           entity.Web,
+		UID:                  strings.TrimSpace(entity.ID),
+		TenantID:             entity.NamespaceID,
+		DeviceUID:            models.UID(strings.TrimSpace(entity.DeviceID)),
+		Username:             entity.Username,
+		UserID:               entity.UserID,
+		Fingerprint:          entity.Fingerprint,
+		CertificateCAID:      entity.CertificateCAID,
+		CertificatePrincipal: entity.CertificatePrincipal,
+		APIKey:               entity.APIKey,
+		IPAddress:            entity.IPAddress,
+		StartedAt:            entity.StartedAt,
+		LastSeen:             entity.SeenAt,
+		Active:               entity.Active,
+		Closed:               entity.Closed,
+		Authenticated:        entity.Authenticated,
+		Recorded:             entity.Recorded,
+		Type:                 entity.Type,
+		Term:                 entity.Term,
+		Web:                  entity.Web,
 		Position: models.SessionPosition{
 			Longitude: entity.Longitude,
 			Latitude:  entity.Latitude,
@@ -188,6 +200,80 @@ func SessionEventToModel(entity *SessionEvent) *models.SessionEvent {
 	return event
 }
 
+// SessionSearchHit is a matching session event along with what a search result shows of its
+// session. Only the event's own columns are stored; the others come from the join.
+type SessionSearchHit struct {
+	bun.BaseModel `bun:"table:session_events"`
+
+	SessionID string    `bun:"session_id"`
+	Type      string    `bun:"type"`
+	Seat      int       `bun:"seat"`
+	Data      string    `bun:"data"`
+	CreatedAt time.Time `bun:"created_at"`
+
+	Username string `bun:"username,scanonly"`
+	DeviceID string `bun:"device_id,scanonly"`
+	// RecordingStartedAt is when the seat's first output was printed, which is where playing its
+	// recording starts. Null when the seat printed nothing.
+	RecordingStartedAt *time.Time `bun:"recording_started_at,scanonly"`
+}
+
+func SessionSearchHitToModel(entity *SessionSearchHit) *models.SessionSearchHit {
+	hit := &models.SessionSearchHit{
+		SessionUID: strings.TrimSpace(entity.SessionID),
+		Seat:       entity.Seat,
+		Type:       models.SessionEventType(entity.Type),
+		Timestamp:  entity.CreatedAt,
+		DeviceUID:  models.UID(strings.TrimSpace(entity.DeviceID)),
+		Username:   entity.Username,
+	}
+
+	if entity.RecordingStartedAt != nil {
+		hit.Offset = max(entity.CreatedAt.Sub(*entity.RecordingStartedAt), 0).Seconds()
+	}
+
+	var data struct {
+		Output  string `json:"output"`
+		Command string `json:"command"`
+	}
+
+	if err := json.Unmarshal([]byte(entity.Data), &data); err == nil {
+		hit.Snippet = data.Output + data.Command
+	}
+
+	return hit
+}
+
+// SessionCommand is a command event along with who ran it where, which come from the join.
+type SessionCommand struct {
+	bun.BaseModel `bun:"table:session_events"`
+
+	SessionID string    `bun:"session_id"`
+	Seat      int       `bun:"seat"`
+	Data      string    `bun:"data"`
+	CreatedAt time.Time `bun:"created_at"`
+
+	Username string `bun:"username,scanonly"`
+	UserID   string `bun:"user_id,scanonly,nullzero"`
+	DeviceID string `bun:"device_id,scanonly"`
+}
+
+func SessionCommandToModel(entity *SessionCommand) *models.SessionCommand {
+	command := &models.SessionCommand{
+		SessionUID: strings.TrimSpace(entity.SessionID),
+		Seat:       entity.Seat,
+		Timestamp:  entity.CreatedAt,
+		DeviceUID:  models.UID(strings.TrimSpace(entity.DeviceID)),
+		Username:   entity.Username,
+		UserID:     entity.UserID,
+	}
+
+	// A command the gateway could not describe is still listed, if only by when it ran.
+	_ = json.Unmarshal([]byte(entity.Data), &command.SessionCommandLine)
+
+	return command
+}
+
 // parseEventTypes converts a comma-separated string of event types into a slice of strings
 func parseEventTypes(eventTypes string) []string {
 	if eventTypes == "" {
@@ -395,6 +395,134 @@ func TestSessionEventToModel(t *testing.T) {
 	}
 }
 
+func TestSessionSearchHitToModel(t *testing.T) {
+	now := time.Now()
+	started := now.Add(-90 * time.Second)
+
+	tests := []struct {
+		name     string
+		entity   *SessionSearchHit
+		expected *models.SessionSearchHit
+	}{
+		{
+			name: "output placed in its recording",
+			entity: &SessionSearchHit{
+				SessionID:          "session-1",
+				Type:               "pty-output",
+				Seat:               1,
+				Data:               `{"output":"rm -rf /tmp\r\n"}`,
+				CreatedAt:          now,
+				Username:           "root",
+				DeviceID:           "device-1",
+				RecordingStartedAt: &started,
+			},
+			expected: &models.SessionSearchHit{
+				SessionUID: "session-1",
+				Seat:       1,
+				Type:       models.SessionEventTypePtyOutput,
+				Timestamp:  now,
+				Offset:     90,
+				DeviceUID:  "device-1",
+				Username:   "root",
+				Snippet:    "rm -rf /tmp\r\n",
+			},
+		},
+		{
+			name: "command of a seat that printed nothing",
+			entity: &SessionSearchHit{
+				SessionID: "session-2",
+				Type:      "exec",
+				Data:      `{"command":"systemctl restart nginx"}`,
+				CreatedAt: now,
+			},
+			expected: &models.SessionSearchHit{
+				SessionUID: "session-2",
+				Type:       models.SessionEventTypeExec,
+				Timestamp:  now,
+				Snippet:    "systemctl restart nginx",
+			},
+		},
+		{
+			name: "never placed before its recording starts",
+			entity: &SessionSearchHit{
+				SessionID:          "session-3",
+				Type:               "exec",
+				Data:               "not-json{",
+				CreatedAt:          started.Add(-time.Second),
+				RecordingStartedAt: &started,
+			},
+			expected: &models.SessionSearchHit{
+				SessionUID: "session-3",
+				Type:       models.SessionEventTypeExec,
+				Timestamp:  started.Add(-time.Second),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SessionSearchHitToModel(tt.entity))
+		})
+	}
+}
+
+func TestSessionCommandToModel(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	finished := now.Add(2 * time.Second)
+	status := uint32(1)
+
+	tests := []struct {
+		name     string
+		entity   *SessionCommand
+		expected *models.SessionCommand
+	}{
+		{
+			name: "typed command that finished",
+			entity: &SessionCommand{
+				SessionID: "session-1",
+				Seat:      1,
+				Data:      `{"command":"make test","interactive":true,"exit_status":1,"finished_at":"` + finished.Format(time.RFC3339) + `"}`,
+				CreatedAt: now,
+				Username:  "root",
+				UserID:    "user-1",
+				DeviceID:  "device-1",
+			},
+			expected: &models.SessionCommand{
+				SessionUID: "session-1",
+				Seat:       1,
+				Timestamp:  now,
+				DeviceUID:  "device-1",
+				Username:   "root",
+				UserID:     "user-1",
+				SessionCommandLine: models.SessionCommandLine{
+					Command:     "make test",
+					Interactive: true,
+					ExitStatus:  &status,
+					FinishedAt:  &finished,
+				},
+			},
+		},
+		{
+			name: "undecodable command",
+			entity: &SessionCommand{
+				SessionID: "session-2",
+				Data:      "not-json{",
+				CreatedAt: now,
+			},
+			expected: &models.SessionCommand{
+				SessionUID: "session-2",
+				Timestamp:  now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SessionCommandToModel(tt.entity))
+		})
+	}
+}
+
 func TestParseEventTypes(t *testing.T) {
 	tests := []struct {
 		name     string
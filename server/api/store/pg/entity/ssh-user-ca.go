@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+type SSHUserCA struct {
+	bun.BaseModel `bun:"table:ssh_user_cas"`
+
+	ID          string `bun:"id,pk,type:uuid"`
+	NamespaceID string `bun:"namespace_id"`
+	Name        string `bun:"name"`
+	Fingerprint string `bun:"fingerprint"`
+	Data        string `bun:"data"`
+	// RevokedSerials are kept as decimal strings: a certificate serial is an
+	// unsigned 64-bit integer, which no Postgres integer type holds.
+	RevokedSerials []string  `bun:"revoked_serials,array"`
+	CreatedAt      time.Time `bun:"created_at"`
+	UpdatedAt      time.Time `bun:"updated_at"`
+}
+
+func SSHUserCAFromModel(model *models.SSHUserCA) *SSHUserCA {
+	serials := make([]string, len(model.RevokedSerials))
+	for i, serial := range model.RevokedSerials {
+		serials[i] = strconv.FormatUint(serial, 10)
+	}
+
+	return &SSHUserCA{
+		ID:             model.ID,
+		NamespaceID:    model.TenantID,
+		Name:           model.Name,
+		Fingerprint:    model.Fingerprint,
+		Data:           model.Data,
+		RevokedSerials: serials,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+func SSHUserCAToModel(e *SSHUserCA) *models.SSHUserCA {
+	serials := make([]uint64, 0, len(e.RevokedSerials))
+	for _, s := range e.RevokedSerials {
+		// The column is only ever written from a uint64, so a value that does not
+		// parse back is not one this code stored; skip it rather than fail the read.
+		if serial, err := strconv.ParseUint(s, 10, 64); err == nil {
+			serials = append(serials, serial)
+		}
+	}
+
+	return &models.SSHUserCA{
+		ID:             e.ID,
+		TenantID:       e.NamespaceID,
+		Name:           e.Name,
+		Fingerprint:    e.Fingerprint,
+		Data:           e.Data,
+		RevokedSerials: serials,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSHUserCARoundTrip(t *testing.T) {
+	now := time.Now()
+
+	model := &models.SSHUserCA{
+		ID:             "ca-id",
+		TenantID:       "tenant-id",
+		Name:           "corp",
+		Fingerprint:    "SHA256:abc123",
+		Data:           "ssh-ed25519 AAAA corp",
+		RevokedSerials: []uint64{1, math.MaxUint64},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	e := SSHUserCAFromModel(model)
+	assert.Equal(t, "tenant-id", e.NamespaceID)
+	// The largest serial does not fit a signed column, which is why they are text.
+	assert.Equal(t, []string{"1", "18446744073709551615"}, e.RevokedSerials)
+
+	assert.Equal(t, model, SSHUserCAToModel(e))
+}
+
+func TestSSHUserCAToModelSkipsUnparseableSerials(t *testing.T) {
+	result := SSHUserCAToModel(&SSHUserCA{RevokedSerials: []string{"7", "not-a-serial", "-1"}})
+	assert.Equal(t, []uint64{7}, result.RevokedSerials)
+}
@@ -25,6 +25,7 @@ type User struct {
 	Preferences      UserPreferences `bun:"embed:"`
 	Admin            bool            `bun:"admin"`
 	AwaitingApproval bool            `bun:"awaiting_approval"`
+	Disabled         bool            `bun:"disabled"`
 	Namespaces       int             `bun:"namespaces,scanonly"`
 }
 
@@ -77,6 +78,7 @@ func UserFromModel(model *models.User) *User {
 		PasswordDigest:   model.Password.Hash,
 		Admin:            model.Admin,
 		AwaitingApproval: model.AwaitingApproval,
+		Disabled:         model.Disabled,
 		Preferences: UserPreferences{
 			PreferredNamespace: model.Preferences.PreferredNamespace,
 			AuthMethods:        authMethods,
@@ -105,6 +107,7 @@ func UserToModel(entity *User) *models.User {
 		EmailMarketing:   entity.Preferences.EmailMarketing,
 		Admin:            entity.Admin,
 		AwaitingApproval: entity.AwaitingApproval,
+		Disabled:         entity.Disabled,
 		UserData: models.UserData{
 			Name:          entity.Name,
 			Username:      entity.Username,
@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+type Webhook struct {
+	bun.BaseModel `bun:"table:webhooks"`
+
+	ID          string    `bun:"id,pk,type:uuid"`
+	NamespaceID string    `bun:"namespace_id"`
+	Name        string    `bun:"name"`
+	URL         string    `bun:"url"`
+	Secret      string    `bun:"secret"`
+	Events      []string  `bun:"events,array"`
+	Enabled     bool      `bun:"enabled"`
+	CreatedAt   time.Time `bun:"created_at"`
+	UpdatedAt   time.Time `bun:"updated_at"`
+}
+
+func WebhookFromModel(model *models.Webhook) *Webhook {
+	events := make([]string, len(model.Events))
+	for i, event := range model.Events {
+		events[i] = string(event)
+	}
+
+	return &Webhook{
+		ID:          model.ID,
+		NamespaceID: model.TenantID,
+		Name:        model.Name,
+		URL:         model.URL,
+		Secret:      model.Secret,
+		Events:      events,
+		Enabled:     model.Enabled,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
+
+func WebhookToModel(e *Webhook) *models.Webhook {
+	events := make([]models.WebhookEventType, len(e.Events))
+	for i, event := range e.Events {
+		events[i] = models.WebhookEventType(event)
+	}
+
+	return &models.Webhook{
+		ID:        e.ID,
+		TenantID:  e.NamespaceID,
+		Name:      e.Name,
+		URL:       e.URL,
+		Secret:    e.Secret,
+		Events:    events,
+		Enabled:   e.Enabled,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+type WebhookDelivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries"`
+
+	ID             string     `bun:"id,pk,type:uuid"`
+	WebhookID      string     `bun:"webhook_id,type:uuid"`
+	NamespaceID    string     `bun:"namespace_id"`
+	EventID        string     `bun:"event_id,type:uuid"`
+	EventType      string     `bun:"event_type"`
+	Payload        string     `bun:"payload"`
+	Status         string     `bun:"status"`
+	Attempts       int        `bun:"attempts"`
+	NextAttemptAt  *time.Time `bun:"next_attempt_at"`
+	ResponseStatus int        `bun:"response_status"`
+	LastError      string     `bun:"last_error"`
+	ReplayOf       string     `bun:"replay_of,type:uuid,nullzero"`
+	DeliveredAt    *time.Time `bun:"delivered_at"`
+	CreatedAt      time.Time  `bun:"created_at"`
+	UpdatedAt      time.Time  `bun:"updated_at"`
+}
+
+func WebhookDeliveryFromModel(model *models.WebhookDelivery) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:             model.ID,
+		WebhookID:      model.WebhookID,
+		NamespaceID:    model.TenantID,
+		EventID:        model.EventID,
+		EventType:      string(model.EventType),
+		Payload:        string(model.Payload),
+		Status:         string(model.Status),
+		Attempts:       model.Attempts,
+		NextAttemptAt:  model.NextAttemptAt,
+		ResponseStatus: model.ResponseStatus,
+		LastError:      model.LastError,
+		ReplayOf:       model.ReplayOf,
+		DeliveredAt:    model.DeliveredAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+func WebhookDeliveryToModel(e *WebhookDelivery) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:             e.ID,
+		WebhookID:      e.WebhookID,
+		TenantID:       e.NamespaceID,
+		EventID:        e.EventID,
+		EventType:      models.WebhookEventType(e.EventType),
+		Payload:        []byte(e.Payload),
+		Status:         models.WebhookDeliveryStatus(e.Status),
+		Attempts:       e.Attempts,
+		NextAttemptAt:  e.NextAttemptAt,
+		ResponseStatus: e.ResponseStatus,
+		LastError:      e.LastError,
+		ReplayOf:       e.ReplayOf,
+		DeliveredAt:    e.DeliveredAt,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRoundTrip(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	webhook := &models.Webhook{
+		ID:        "webhook-id",
+		TenantID:  "tenant-id",
+		Name:      "on-call",
+		URL:       "https://example.com/hook",
+		Secret:    "0123456789abcdef",
+		Events:    []models.WebhookEventType{models.WebhookEventDeviceOnline, models.WebhookEventDeviceOffline},
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	assert.Equal(t, webhook, WebhookToModel(WebhookFromModel(webhook)))
+}
+
+func TestWebhookDeliveryRoundTrip(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	delivery := &models.WebhookDelivery{
+		ID:             "delivery-id",
+		WebhookID:      "webhook-id",
+		TenantID:       "tenant-id",
+		EventID:        "event-id",
+		EventType:      models.WebhookEventDeviceOnline,
+		Payload:        []byte(`{"id":"event-id"}`),
+		Status:         models.WebhookDeliveryFailed,
+		Attempts:       3,
+		ResponseStatus: 502,
+		LastError:      "webhook returned status 502",
+		ReplayOf:       "original-id",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	assert.Equal(t, delivery, WebhookDeliveryToModel(WebhookDeliveryFromModel(delivery)))
+}
@@ -0,0 +1,29 @@
+-- Trusted user certificate authorities: the OpenSSH CAs a namespace accepts
+-- user certificates from in the identity SSH access mode, the equivalent of
+-- sshd's TrustedUserCAKeys. A certificate signed by one of them is recognized
+-- as the account its principals name, without that key ever being enrolled as
+-- an ssh_identities row, and is then authorized by Access Policies like any
+-- other identity.
+CREATE TABLE ssh_user_cas (
+    id uuid NOT NULL,
+    namespace_id uuid NOT NULL,
+    name character varying NOT NULL,
+    -- SHA256 fingerprint of the CA public key, matched against the signature
+    -- key of a presented certificate.
+    fingerprint character varying NOT NULL,
+    -- The CA public key, OpenSSH authorized_keys form.
+    data text NOT NULL,
+    -- Serials of certificates from this CA that must be refused before they
+    -- expire. Serials are unsigned 64-bit integers, which bigint cannot hold, so
+    -- they are stored as their decimal text.
+    revoked_serials text[] NOT NULL DEFAULT '{}'::text[],
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (namespace_id, fingerprint),
+    FOREIGN KEY (namespace_id) REFERENCES namespaces(id) ON DELETE CASCADE
+);
+
+--bun:split
+
+CREATE INDEX ssh_user_cas_namespace_id ON ssh_user_cas USING btree (namespace_id);
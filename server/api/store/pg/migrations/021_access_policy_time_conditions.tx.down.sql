@@ -0,0 +1,4 @@
+ALTER TABLE access_policies
+    DROP COLUMN IF EXISTS schedule,
+    DROP COLUMN IF EXISTS not_before,
+    DROP COLUMN IF EXISTS not_after;
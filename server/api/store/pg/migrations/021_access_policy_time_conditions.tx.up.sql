@@ -0,0 +1,9 @@
+-- Time conditions on access policies. schedule restricts a policy to recurring
+-- weekly windows read in an IANA time zone, as jsonb:
+-- {"timezone": "Europe/Berlin", "windows": [{"weekdays": ["mon"], "start": "08:00", "end": "18:00"}]}.
+-- not_before and not_after bound when the policy is in effect at all. NULL
+-- leaves each condition open, which is what every existing policy keeps.
+ALTER TABLE access_policies
+    ADD COLUMN IF NOT EXISTS schedule jsonb,
+    ADD COLUMN IF NOT EXISTS not_before timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS not_after timestamp with time zone;
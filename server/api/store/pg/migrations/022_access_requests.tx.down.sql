@@ -0,0 +1,13 @@
+ALTER TABLE access_policies DROP COLUMN IF EXISTS access_request_id;
+
+--bun:split
+
+DROP TABLE IF EXISTS access_request_events;
+
+--bun:split
+
+DROP TABLE IF EXISTS access_request_tags;
+
+--bun:split
+
+DROP TABLE IF EXISTS access_requests;
@@ -0,0 +1,81 @@
+-- Just-in-time access: a member without an Access Policy for some devices asks
+-- for temporary access to them, and an approver grants or rejects the request.
+-- Granting materializes an allow policy for the requester, bounded by not_after
+-- to the granted window and tied to the request by access_request_id; the
+-- worker removes it once the window lapses.
+--
+-- user_id is the requester. Removing the account removes its requests, and the
+-- cascade on access_request_id removes any access they still grant.
+CREATE TABLE access_requests (
+    id uuid NOT NULL,
+    namespace_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    filter_hostname character varying DEFAULT ''::character varying NOT NULL,
+    logins text[] NOT NULL DEFAULT '{}'::text[],
+    -- How long the access lasts once granted, in seconds.
+    duration bigint NOT NULL,
+    justification text NOT NULL DEFAULT '',
+    status character varying NOT NULL DEFAULT 'pending',
+    decided_by uuid,
+    decision_note text NOT NULL DEFAULT '',
+    decided_at timestamp with time zone,
+    -- The policy a grant created, and when the access it grants lapses. The
+    -- policy points back through access_policies.access_request_id, which is
+    -- the constrained side of the pair.
+    policy_id uuid,
+    expires_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (namespace_id) REFERENCES namespaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (decided_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+--bun:split
+
+CREATE INDEX access_requests_namespace_id ON access_requests USING btree (namespace_id);
+
+--bun:split
+
+-- The worker looks for granted requests past their expiry on every run.
+CREATE INDEX access_requests_granted_expires_at ON access_requests USING btree (expires_at) WHERE status = 'granted';
+
+--bun:split
+
+CREATE TABLE access_request_tags (
+    access_request_id uuid NOT NULL,
+    tag_id uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (access_request_id, tag_id),
+    FOREIGN KEY (access_request_id) REFERENCES access_requests(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+--bun:split
+
+-- The audit trail of a request's lifecycle, append-only. actor_id is NULL when
+-- the worker expired the request, and is cleared rather than the row removed
+-- when the acting account goes away.
+CREATE TABLE access_request_events (
+    id uuid NOT NULL,
+    access_request_id uuid NOT NULL,
+    namespace_id uuid NOT NULL,
+    action character varying NOT NULL,
+    actor_id uuid,
+    note text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (access_request_id) REFERENCES access_requests(id) ON DELETE CASCADE,
+    FOREIGN KEY (namespace_id) REFERENCES namespaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+--bun:split
+
+CREATE INDEX access_request_events_access_request_id ON access_request_events USING btree (access_request_id);
+
+--bun:split
+
+ALTER TABLE access_policies
+    ADD COLUMN access_request_id uuid REFERENCES access_requests(id) ON DELETE CASCADE;
@@ -0,0 +1,31 @@
+-- The namespace's audit log: one append-only row per administrative action.
+-- The actor and target are denormalized rather than referenced, so an entry
+-- outlives the account, key or object it names; only the namespace going away
+-- removes it. before and after hold the target's changed fields.
+CREATE TABLE audit_events (
+    id uuid NOT NULL,
+    namespace_id uuid NOT NULL,
+    actor_type character varying NOT NULL DEFAULT '',
+    actor_id character varying NOT NULL DEFAULT '',
+    actor_name character varying NOT NULL DEFAULT '',
+    action character varying NOT NULL,
+    target_type character varying NOT NULL,
+    target_id character varying NOT NULL DEFAULT '',
+    before jsonb,
+    after jsonb,
+    source_ip character varying NOT NULL DEFAULT '',
+    request_id character varying NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (namespace_id) REFERENCES namespaces(id) ON DELETE CASCADE
+);
+
+--bun:split
+
+-- The log is read newest first within a namespace.
+CREATE INDEX audit_events_namespace_id_created_at ON audit_events USING btree (namespace_id, created_at);
+
+--bun:split
+
+-- The retention worker prunes across every namespace by age.
+CREATE INDEX audit_events_created_at ON audit_events USING btree (created_at);
@@ -0,0 +1,66 @@
+-- Outbound webhooks: a namespace subscribes a URL to some of its events, and
+-- every event is delivered to each enabled subscription by the worker, signed
+-- with the subscription's secret.
+CREATE TABLE webhooks (
+    id uuid NOT NULL,
+    namespace_id uuid NOT NULL,
+    name character varying NOT NULL,
+    url character varying NOT NULL,
+    secret character varying NOT NULL,
+    events text[] NOT NULL DEFAULT '{}'::text[],
+    enabled boolean NOT NULL DEFAULT true,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (namespace_id) REFERENCES namespaces(id) ON DELETE CASCADE
+);
+
+--bun:split
+
+-- Every event looks up the enabled subscriptions of its namespace.
+CREATE INDEX webhooks_namespace_id ON webhooks USING btree (namespace_id) WHERE enabled;
+
+--bun:split
+
+-- The delivery log: one row per event sent, or being sent, to a webhook. The
+-- payload is kept as text, not jsonb, so every attempt sends, and signs, the
+-- bytes the first one did. Deleting the webhook drops its log. replay_of names
+-- the delivery a replay repeats; it is not a foreign key, so pruning the
+-- original leaves the replay as it was.
+CREATE TABLE webhook_deliveries (
+    id uuid NOT NULL,
+    webhook_id uuid NOT NULL,
+    namespace_id uuid NOT NULL,
+    event_id uuid NOT NULL,
+    event_type character varying NOT NULL,
+    payload text NOT NULL,
+    status character varying NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp with time zone,
+    response_status integer NOT NULL DEFAULT 0,
+    last_error text NOT NULL DEFAULT '',
+    replay_of uuid,
+    delivered_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+    FOREIGN KEY (namespace_id) REFERENCES namespaces(id) ON DELETE CASCADE
+);
+
+--bun:split
+
+-- A webhook's log is read newest first.
+CREATE INDEX webhook_deliveries_webhook_id_created_at ON webhook_deliveries USING btree (webhook_id, created_at);
+
+--bun:split
+
+-- An event reaches a webhook once: fanning it out again, after a failed run,
+-- finds the deliveries already made. Replays carry the event's id too, so they
+-- are left out.
+CREATE UNIQUE INDEX webhook_deliveries_webhook_id_event_id ON webhook_deliveries USING btree (webhook_id, event_id) WHERE replay_of IS NULL;
+
+--bun:split
+
+-- The worker looks for pending deliveries due for an attempt on every run.
+CREATE INDEX webhook_deliveries_pending_next_attempt_at ON webhook_deliveries USING btree (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,5 @@
+DROP INDEX CONCURRENTLY IF EXISTS session_events_search_idx;
+
+--bun:split
+
+DROP FUNCTION IF EXISTS session_event_search_document(text);
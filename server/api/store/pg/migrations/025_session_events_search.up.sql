@@ -0,0 +1,37 @@
+-- Full-text search over what recorded sessions printed and the commands exec sessions ran.
+--
+-- The searchable text is the "output" of a pty-output event or the "command" of an exec one,
+-- stored inside the JSON the data column holds, with the escape sequences that colored and
+-- positioned it replaced by spaces: left in, they glue themselves to the words next to them
+-- ("\x1b[01;34mdir" would index as "34mdir"). The 'simple' configuration indexes words as
+-- typed, without stemming or stop words, since a path or a flag is not English.
+--
+-- Data that is not a JSON object indexes as nothing rather than failing the cast, which would
+-- fail the insert and lose the event.
+CREATE OR REPLACE FUNCTION session_event_search_document(data text) RETURNS tsvector
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    RETURN to_tsvector('simple', CASE
+        WHEN data IS JSON OBJECT THEN regexp_replace(
+            coalesce(data::jsonb ->> 'output', data::jsonb ->> 'command', ''),
+            '\x1b(\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)?|[ -/]*[0-~])',
+            ' ',
+            'g'
+        )
+        ELSE ''
+    END);
+
+--bun:split
+
+-- A build interrupted by a failed boot leaves an invalid index behind, which IF NOT EXISTS would
+-- then keep forever; dropping it first makes the migration safe to run again.
+DROP INDEX CONCURRENTLY IF EXISTS session_events_search_idx;
+
+--bun:split
+
+-- Built concurrently: session_events is the largest table in the database and a plain build
+-- would block every session's recording for as long as it takes. Partial, because the other
+-- event types carry nothing worth searching and are most of the rows. The predicate must stay
+-- in step with the one the search query states, or the planner cannot use the index.
+CREATE INDEX CONCURRENTLY session_events_search_idx ON session_events
+    USING gin (session_event_search_document(data))
+    WHERE type IN ('pty-output', 'exec');
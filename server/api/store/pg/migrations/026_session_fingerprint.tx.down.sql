@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS sessions_user_id_idx;
+
+--bun:split
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS fingerprint;
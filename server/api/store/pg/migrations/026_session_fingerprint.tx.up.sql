@@ -0,0 +1,8 @@
+-- The fingerprint of the SSH identity a session logged in with, in the identity access mode, so
+-- revoking that identity can find the sessions it still holds open. NULL for every other login.
+ALTER TABLE sessions ADD COLUMN fingerprint text;
+
+--bun:split
+
+-- Revocations look up the active sessions of a principal.
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id) WHERE user_id IS NOT NULL;
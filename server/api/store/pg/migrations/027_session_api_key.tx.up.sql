@@ -0,0 +1,3 @@
+-- The name of the API key that ran a session through the API, so the session records which
+-- key executed it. NULL for every interactive login.
+ALTER TABLE sessions ADD COLUMN api_key text;
@@ -0,0 +1,6 @@
+ALTER TABLE api_keys
+    DROP COLUMN IF EXISTS permissions,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS source_ips,
+    DROP COLUMN IF EXISTS usage_count,
+    DROP COLUMN IF EXISTS last_used_at;
@@ -0,0 +1,9 @@
+-- An API key may narrow what its role grants: permissions lists the authorizer permissions it keeps
+-- (empty keeps every permission of the role), tags the device tags it reaches (empty reaches every
+-- device) and source_ips the addresses or CIDR blocks it may be used from (empty allows any).
+ALTER TABLE api_keys
+    ADD COLUMN permissions text[] DEFAULT '{}' NOT NULL,
+    ADD COLUMN tags text[] DEFAULT '{}' NOT NULL,
+    ADD COLUMN source_ips text[] DEFAULT '{}' NOT NULL,
+    ADD COLUMN usage_count bigint DEFAULT 0 NOT NULL,
+    ADD COLUMN last_used_at timestamp with time zone;
@@ -0,0 +1,44 @@
+ALTER TABLE namespaces DROP COLUMN IF EXISTS local_auth_disabled;
+
+--bun:split
+
+-- Postgres cannot drop a single enum value, so rebuild both types without
+-- 'oidc'. The casts fail on purpose if any row still holds it, which would mean
+-- down-migrating with live OIDC users.
+ALTER TYPE user_auth_method RENAME TO user_auth_method_old;
+
+--bun:split
+
+CREATE TYPE user_auth_method AS ENUM (
+    'local',
+    'saml'
+);
+
+--bun:split
+
+ALTER TABLE users
+    ALTER COLUMN auth_methods TYPE user_auth_method[] USING auth_methods::text[]::user_auth_method[];
+
+--bun:split
+
+DROP TYPE user_auth_method_old;
+
+--bun:split
+
+ALTER TYPE user_origin RENAME TO user_origin_old;
+
+--bun:split
+
+CREATE TYPE user_origin AS ENUM (
+    'local',
+    'saml'
+);
+
+--bun:split
+
+ALTER TABLE users
+    ALTER COLUMN origin TYPE user_origin USING origin::text::user_origin;
+
+--bun:split
+
+DROP TYPE user_origin_old;
@@ -0,0 +1,14 @@
+-- OpenID Connect single sign-on: users provisioned on their first OIDC sign-in
+-- carry the 'oidc' origin, and any user linked to the provider the 'oidc' auth
+-- method.
+ALTER TYPE user_origin ADD VALUE IF NOT EXISTS 'oidc';
+
+--bun:split
+
+ALTER TYPE user_auth_method ADD VALUE IF NOT EXISTS 'oidc';
+
+--bun:split
+
+-- A namespace may refuse the email and password login to its members, leaving
+-- single sign-on as the only way in.
+ALTER TABLE namespaces ADD COLUMN local_auth_disabled boolean NOT NULL DEFAULT false;
@@ -0,0 +1,33 @@
+DROP TABLE IF EXISTS scim_group_members;
+
+--bun:split
+
+DROP TABLE IF EXISTS scim_groups;
+
+--bun:split
+
+ALTER TABLE users DROP COLUMN IF EXISTS disabled;
+
+--bun:split
+
+-- Postgres cannot drop a single enum value, so rebuild the type without
+-- 'scim'. The cast fails on purpose if any row still holds it, which would mean
+-- down-migrating with live SCIM users.
+ALTER TYPE user_origin RENAME TO user_origin_old;
+
+--bun:split
+
+CREATE TYPE user_origin AS ENUM (
+    'local',
+    'saml',
+    'oidc'
+);
+
+--bun:split
+
+ALTER TABLE users
+    ALTER COLUMN origin TYPE user_origin USING origin::text::user_origin;
+
+--bun:split
+
+DROP TYPE user_origin_old;
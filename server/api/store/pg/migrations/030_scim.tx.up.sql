@@ -0,0 +1,42 @@
+-- SCIM 2.0 provisioning: users created by the identity provider carry the
+-- 'scim' origin.
+ALTER TYPE user_origin ADD VALUE IF NOT EXISTS 'scim';
+
+--bun:split
+
+-- A deactivated user keeps their account but can neither sign in nor use a
+-- token minted before the deactivation. Only SCIM sets it today.
+ALTER TABLE users ADD COLUMN disabled boolean NOT NULL DEFAULT false;
+
+--bun:split
+
+-- SCIM groups are instance-wide. They carry no permissions by themselves: the
+-- SHELLHUB_SCIM_GROUP_ROLES mapping turns membership in a group into a
+-- namespace membership and role.
+CREATE TABLE scim_groups (
+    id uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    external_id character varying,
+    display_name character varying NOT NULL,
+    PRIMARY KEY (id)
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX scim_groups_display_name ON scim_groups USING btree (display_name);
+
+--bun:split
+
+CREATE TABLE scim_group_members (
+    scim_group_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (scim_group_id, user_id),
+    FOREIGN KEY (scim_group_id) REFERENCES scim_groups(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+--bun:split
+
+CREATE INDEX scim_group_members_user_id ON scim_group_members USING btree (user_id);
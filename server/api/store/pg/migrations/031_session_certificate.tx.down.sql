@@ -0,0 +1,5 @@
+ALTER TABLE sessions DROP COLUMN IF EXISTS certificate_principal;
+
+--bun:split
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS certificate_ca_id;
@@ -0,0 +1,9 @@
+-- The trusted CA and the principal a certificate login was accepted through, so re-evaluating the
+-- session asks the CA again rather than looking for an SSH identity a certificate never has. NULL
+-- for every other login. No foreign key: a session outlives the CA that let it in, and a CA
+-- deleted since must read as one, not as a session that never presented a certificate.
+ALTER TABLE sessions ADD COLUMN certificate_ca_id uuid;
+
+--bun:split
+
+ALTER TABLE sessions ADD COLUMN certificate_principal text;
@@ -0,0 +1,7 @@
+DROP INDEX CONCURRENTLY IF EXISTS session_events_search_idx;
+
+--bun:split
+
+CREATE INDEX CONCURRENTLY session_events_search_idx ON session_events
+    USING gin (session_event_search_document(data))
+    WHERE type IN ('pty-output', 'exec');
@@ -0,0 +1,11 @@
+-- Command events, the lines typed into a shell as well as the exec requests, become searchable.
+-- Their text is the "command" of their data, which session_event_search_document already reads;
+-- only the predicate of the partial index has to take them in, and it must stay in step with the
+-- one the search query states, or the planner cannot use the index.
+DROP INDEX CONCURRENTLY IF EXISTS session_events_search_idx;
+
+--bun:split
+
+CREATE INDEX CONCURRENTLY session_events_search_idx ON session_events
+    USING gin (session_event_search_document(data))
+    WHERE type IN ('pty-output', 'exec', 'command');
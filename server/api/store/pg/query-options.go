@@ -153,6 +153,28 @@ func (*queryOptions) WithMember(userID string) store.QueryOption {
 	}
 }
 
+func (*queryOptions) WithDeviceTags(tags []string) store.QueryOption {
+	return func(ctx context.Context) error {
+		if len(tags) == 0 {
+			return nil
+		}
+
+		wrapper, ok := ctx.Value("query").(*queryWrapper)
+		if !ok {
+			return ErrQueryNotFound
+		}
+
+		col := "device_id"
+		if alias, ok := ctx.Value(CtxTableAlias).(string); ok && alias != "" {
+			col = alias + ".device_id"
+		}
+
+		wrapper.query = wrapper.query.Where(`EXISTS (SELECT 1 FROM "device_tags" JOIN "tags" ON "tags"."id" = "device_tags"."tag_id" WHERE "device_tags"."device_id" = ? AND "tags"."name" IN (?))`, bun.Ident(col), bun.List(tags))
+
+		return nil
+	}
+}
+
 func (*queryOptions) WithUserID(userID string) store.QueryOption {
 	return func(ctx context.Context) error {
 		wrapper, ok := ctx.Value("query").(*queryWrapper)
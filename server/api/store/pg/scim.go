@@ -0,0 +1,185 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/shellhub-io/shellhub/server/api/store/pg/entity"
+	"github.com/uptrace/bun"
+)
+
+func (pg *Pg) SCIMGroupCreate(ctx context.Context, group *models.SCIMGroup) (string, error) {
+	err := pg.WithTransaction(ctx, func(ctx context.Context) error {
+		db := pg.GetConnection(ctx)
+
+		now := clock.Now()
+		group.CreatedAt = now
+		group.UpdatedAt = now
+
+		if group.ID == "" {
+			group.ID = uuid.Generate()
+		}
+
+		if _, err := db.NewInsert().Model(entity.SCIMGroupFromModel(group)).Exec(ctx); err != nil {
+			return fromSQLError(err)
+		}
+
+		return pg.scimGroupInsertMembers(ctx, group.ID, group.Members, now)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return group.ID, nil
+}
+
+func (pg *Pg) SCIMGroupList(ctx context.Context, opts ...store.QueryOption) ([]models.SCIMGroup, int, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.SCIMGroup, 0)
+	query := scimGroupSelectQuery(db.NewSelect().Model(&entities))
+
+	var err error
+	query, err = applyOptions(ctx, query, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fromSQLError(err)
+	}
+
+	groups := make([]models.SCIMGroup, len(entities))
+	for i, e := range entities {
+		groups[i] = *entity.SCIMGroupToModel(&e)
+	}
+
+	return groups, count, nil
+}
+
+func (pg *Pg) SCIMGroupListByMember(ctx context.Context, userID string) ([]models.SCIMGroup, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.SCIMGroup, 0)
+	err := scimGroupSelectQuery(db.NewSelect().Model(&entities)).
+		Where("EXISTS (SELECT 1 FROM scim_group_members WHERE scim_group_members.scim_group_id = scim_group.id AND scim_group_members.user_id = ?)", userID).
+		Order("display_name").
+		Scan(ctx)
+	if err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	groups := make([]models.SCIMGroup, len(entities))
+	for i, e := range entities {
+		groups[i] = *entity.SCIMGroupToModel(&e)
+	}
+
+	return groups, nil
+}
+
+func (pg *Pg) SCIMGroupResolve(ctx context.Context, resolver store.SCIMGroupResolver, value string) (*models.SCIMGroup, error) {
+	db := pg.GetConnection(ctx)
+
+	column, err := SCIMGroupResolverToString(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	e := new(entity.SCIMGroup)
+	if err := scimGroupSelectQuery(db.NewSelect().Model(e)).
+		Where("? = ?", bun.Ident("scim_group."+column), value).
+		Scan(ctx); err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	return entity.SCIMGroupToModel(e), nil
+}
+
+func (pg *Pg) SCIMGroupUpdate(ctx context.Context, group *models.SCIMGroup) error {
+	return pg.WithTransaction(ctx, func(ctx context.Context) error {
+		db := pg.GetConnection(ctx)
+
+		e := entity.SCIMGroupFromModel(group)
+		e.UpdatedAt = clock.Now()
+
+		r, err := db.NewUpdate().
+			Model(e).
+			Column("external_id", "display_name", "updated_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fromSQLError(err)
+		}
+
+		if rowsAffected, err := r.RowsAffected(); err != nil || rowsAffected == 0 {
+			return store.ErrNoDocuments
+		}
+
+		// Sync the members the same way Access Policies sync their tags: drop the
+		// junction entries and re-insert the current set.
+		if _, err := db.NewDelete().
+			Model((*entity.SCIMGroupMember)(nil)).
+			Where("scim_group_id = ?", e.ID).
+			Exec(ctx); err != nil {
+			return fromSQLError(err)
+		}
+
+		return pg.scimGroupInsertMembers(ctx, e.ID, group.Members, e.UpdatedAt)
+	})
+}
+
+func (pg *Pg) SCIMGroupDelete(ctx context.Context, group *models.SCIMGroup) error {
+	db := pg.GetConnection(ctx)
+
+	r, err := db.NewDelete().
+		Model((*entity.SCIMGroup)(nil)).
+		Where("id = ?", group.ID).
+		Exec(ctx)
+	if err != nil {
+		return fromSQLError(err)
+	}
+
+	if rowsAffected, err := r.RowsAffected(); err != nil || rowsAffected == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (pg *Pg) scimGroupInsertMembers(ctx context.Context, groupID string, members []string, now time.Time) error {
+	db := pg.GetConnection(ctx)
+
+	for _, userID := range members {
+		if _, err := db.NewInsert().
+			Model(&entity.SCIMGroupMember{SCIMGroupID: groupID, UserID: userID, CreatedAt: now}).
+			On("CONFLICT (scim_group_id, user_id) DO NOTHING").
+			Exec(ctx); err != nil {
+			return fromSQLError(err)
+		}
+	}
+
+	return nil
+}
+
+// scimGroupSelectQuery selects every group column plus the IDs of its members.
+func scimGroupSelectQuery(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.
+		ColumnExpr("scim_group.*").
+		ColumnExpr("ARRAY(SELECT user_id::text FROM scim_group_members WHERE scim_group_members.scim_group_id = scim_group.id ORDER BY user_id) AS members")
+}
+
+func SCIMGroupResolverToString(resolver store.SCIMGroupResolver) (string, error) {
+	switch resolver {
+	case store.SCIMGroupIDResolver:
+		return "id", nil
+	case store.SCIMGroupDisplayNameResolver:
+		return "display_name", nil
+	default:
+		return "", store.ErrResolverNotFound
+	}
+}
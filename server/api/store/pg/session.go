@@ -58,6 +58,36 @@ func (pg *Pg) SessionList(ctx context.Context, sc scope.Scope, opts ...store.Que
 	return sessions, count, nil
 }
 
+func (pg *Pg) SessionListActive(ctx context.Context, sc scope.Scope, userID string) ([]models.Session, error) {
+	db := pg.GetConnection(ctx)
+
+	ctx = context.WithValue(ctx, CtxTableAlias, "session")
+
+	entities := make([]entity.Session, 0)
+	query := SessionSelectQuery(db.NewSelect().Model(&entities)).
+		Where("active_session.session_id IS NOT NULL")
+
+	if userID != "" {
+		query = query.Where("session.user_id = ?", userID)
+	}
+
+	query, err := applyScopedOptions(ctx, query, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Order("session.started_at ASC").Scan(ctx); err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	sessions := make([]models.Session, len(entities))
+	for i, e := range entities {
+		sessions[i] = *entity.SessionToModel(&e)
+	}
+
+	return sessions, nil
+}
+
 func (pg *Pg) SessionResolve(ctx context.Context, sc scope.Scope, resolver store.SessionResolver, value string, opts ...store.QueryOption) (*models.Session, error) {
 	db := pg.GetConnection(ctx)
 
@@ -308,6 +338,186 @@ func (pg *Pg) SessionEventsList(ctx context.Context, uid models.UID, seat int, e
 	return events, count, nil
 }
 
+func (pg *Pg) SessionEventsScan(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, after store.SessionEventsCursor, limit int) ([]models.SessionEvent, store.SessionEventsCursor, error) {
+	return pg.sessionEventsScan(ctx, uid, seat, types, after, limit, false)
+}
+
+func (pg *Pg) SessionEventsScanBackward(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, before store.SessionEventsCursor, limit int) ([]models.SessionEvent, store.SessionEventsCursor, error) {
+	return pg.sessionEventsScan(ctx, uid, seat, types, before, limit, true)
+}
+
+// sessionEventsScan pages through a seat's events from the cursor on, oldest first, or newest
+// first when backward.
+func (pg *Pg) sessionEventsScan(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, cursor store.SessionEventsCursor, limit int, backward bool) ([]models.SessionEvent, store.SessionEventsCursor, error) {
+	if limit <= 0 || len(types) == 0 {
+		return []models.SessionEvent{}, cursor, nil
+	}
+
+	db := pg.GetConnection(ctx)
+
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+
+	entities := make([]entity.SessionEvent, 0, limit)
+	query := db.NewSelect().
+		Model(&entities).
+		Where("session_id = ?", string(uid)).
+		Where("seat = ?", seat).
+		Where("type IN (?)", bun.List(names)).
+		Limit(limit)
+
+	// The ID breaks ties between events stored with the same timestamp, so no
+	// page boundary can fall between them and skip one.
+	if backward {
+		query = query.OrderExpr("created_at DESC, id DESC")
+	} else {
+		query = query.OrderExpr("created_at ASC, id ASC")
+	}
+
+	switch {
+	case cursor.ID == "":
+	case backward:
+		query = query.Where("(created_at, id) < (?, ?::uuid)", cursor.Timestamp, cursor.ID)
+	default:
+		query = query.Where("(created_at, id) > (?, ?::uuid)", cursor.Timestamp, cursor.ID)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, cursor, fromSQLError(err)
+	}
+
+	events := make([]models.SessionEvent, len(entities))
+	for i := range entities {
+		events[i] = *entity.SessionEventToModel(&entities[i])
+	}
+
+	if len(entities) > 0 {
+		last := entities[len(entities)-1]
+		cursor = store.SessionEventsCursor{Timestamp: last.CreatedAt, ID: last.ID}
+	}
+
+	return events, cursor, nil
+}
+
+// sessionSearchableEvents are the event types a search reaches. They must stay in step with the
+// predicate of the session_events_search_idx partial index, or the planner cannot use it.
+var sessionSearchableEvents = []string{
+	string(models.SessionEventTypePtyOutput),
+	string(models.SessionEventTypeExec),
+	string(models.SessionEventTypeCommand),
+}
+
+func (pg *Pg) SessionEventsSearch(ctx context.Context, sc scope.Scope, search store.SessionSearch, opts ...store.QueryOption) ([]models.SessionSearchHit, int, error) {
+	db := pg.GetConnection(ctx)
+
+	// The scope predicate is on the session, which the event is joined to.
+	ctx = context.WithValue(ctx, CtxTableAlias, "session")
+
+	entities := make([]entity.SessionSearchHit, 0)
+	query := db.NewSelect().
+		Model(&entities).
+		ModelTableExpr("session_events AS event").
+		ColumnExpr("event.session_id, event.type, event.seat, event.data, event.created_at").
+		ColumnExpr("session.username, session.device_id").
+		// Served by the (session_id, created_at) index, for the page of hits alone.
+		ColumnExpr(`(
+			SELECT min(first.created_at) FROM session_events AS first
+			WHERE first.session_id = event.session_id AND first.seat = event.seat AND first.type = ?
+		) AS recording_started_at`, string(models.SessionEventTypePtyOutput)).
+		Join("JOIN sessions AS session ON session.id = event.session_id").
+		Where("event.type IN (?)", bun.List(sessionSearchableEvents)).
+		// The expression is the index's, word for word. phraseto_tsquery splits the phrase the way
+		// to_tsvector split the output, so "rm -rf /var/lib" matches where it was printed as typed.
+		Where("session_event_search_document(event.data) @@ phraseto_tsquery('simple', ?)", search.Phrase).
+		OrderExpr("event.created_at DESC, event.id DESC")
+
+	if !search.Since.IsZero() {
+		query = query.Where("event.created_at >= ?", search.Since)
+	}
+
+	if !search.Until.IsZero() {
+		query = query.Where("event.created_at < ?", search.Until)
+	}
+
+	var err error
+	query, err = applyScopedOptions(ctx, query, sc, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fromSQLError(err)
+	}
+
+	hits := make([]models.SessionSearchHit, len(entities))
+	for i := range entities {
+		hits[i] = *entity.SessionSearchHitToModel(&entities[i])
+	}
+
+	return hits, count, nil
+}
+
+func (pg *Pg) SessionCommandsList(ctx context.Context, sc scope.Scope, filter store.SessionCommandsFilter, opts ...store.QueryOption) ([]models.SessionCommand, int, error) {
+	db := pg.GetConnection(ctx)
+
+	ctx = context.WithValue(ctx, CtxTableAlias, "session")
+
+	entities := make([]entity.SessionCommand, 0)
+	query := db.NewSelect().
+		Model(&entities).
+		ModelTableExpr("session_events AS event").
+		ColumnExpr("event.session_id, event.seat, event.data, event.created_at").
+		ColumnExpr("session.username, session.user_id, session.device_id").
+		Join("JOIN sessions AS session ON session.id = event.session_id").
+		Where("event.type = ?", string(models.SessionEventTypeCommand))
+
+	// A session's commands read as its transcript; anyone else's, as a log.
+	if filter.SessionUID != "" {
+		query = query.
+			Where("event.session_id = ?", string(filter.SessionUID)).
+			OrderExpr("event.created_at ASC, event.id ASC")
+	} else {
+		query = query.OrderExpr("event.created_at DESC, event.id DESC")
+	}
+
+	if filter.Username != "" {
+		query = query.Where("session.username = ?", filter.Username)
+	}
+
+	if filter.UserID != "" {
+		query = query.Where("session.user_id = ?", filter.UserID)
+	}
+
+	if !filter.Since.IsZero() {
+		query = query.Where("event.created_at >= ?", filter.Since)
+	}
+
+	if !filter.Until.IsZero() {
+		query = query.Where("event.created_at < ?", filter.Until)
+	}
+
+	var err error
+	query, err = applyScopedOptions(ctx, query, sc, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fromSQLError(err)
+	}
+
+	commands := make([]models.SessionCommand, len(entities))
+	for i := range entities {
+		commands[i] = *entity.SessionCommandToModel(&entities[i])
+	}
+
+	return commands, count, nil
+}
+
 func (pg *Pg) SessionEventsDelete(ctx context.Context, uid models.UID, seat int, event models.SessionEventType) error {
 	db := pg.GetConnection(ctx)
 
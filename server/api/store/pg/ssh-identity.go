@@ -87,6 +87,8 @@ func (pg *Pg) SSHIdentityUpdate(ctx context.Context, identity *models.SSHIdentit
 	r, err := db.NewUpdate().
 		Model((*entity.SSHIdentity)(nil)).
 		Set("name = ?", identity.Name).
+		Set("expires_at = ?", identity.ExpiresAt).
+		Set("single_use = ?", identity.SingleUse).
 		Where("id = ?", identity.ID).
 		Where("namespace_id = ?", identity.TenantID).
 		Exec(ctx)
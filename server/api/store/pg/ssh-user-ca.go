@@ -0,0 +1,137 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/shellhub-io/shellhub/server/api/store/pg/entity"
+	"github.com/uptrace/bun"
+)
+
+func (pg *Pg) SSHUserCACreate(ctx context.Context, ca *models.SSHUserCA) (string, error) {
+	db := pg.GetConnection(ctx)
+
+	now := clock.Now()
+	ca.CreatedAt = now
+	ca.UpdatedAt = now
+
+	if ca.ID == "" {
+		ca.ID = uuid.Generate()
+	}
+
+	e := entity.SSHUserCAFromModel(ca)
+
+	if _, err := db.NewInsert().Model(e).Exec(ctx); err != nil {
+		return "", fromSQLError(err)
+	}
+
+	return e.ID, nil
+}
+
+func (pg *Pg) SSHUserCAList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.SSHUserCA, int, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.SSHUserCA, 0)
+
+	query := db.NewSelect().Model(&entities).Order("created_at ASC")
+
+	query, err := applyScopedOptions(ctx, query, sc, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fromSQLError(err)
+	}
+
+	cas := make([]models.SSHUserCA, len(entities))
+	for i, e := range entities {
+		cas[i] = *entity.SSHUserCAToModel(&e)
+	}
+
+	return cas, count, nil
+}
+
+func (pg *Pg) SSHUserCAResolve(ctx context.Context, sc scope.Scope, resolver store.SSHUserCAResolver, value string, opts ...store.QueryOption) (*models.SSHUserCA, error) {
+	db := pg.GetConnection(ctx)
+
+	column, err := sshUserCAResolverToString(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	e := new(entity.SSHUserCA)
+	query := db.NewSelect().Model(e).
+		Where("? = ?", bun.Ident(column), value)
+
+	query, err = applyScopedOptions(ctx, query, sc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	return entity.SSHUserCAToModel(e), nil
+}
+
+func (pg *Pg) SSHUserCAUpdate(ctx context.Context, ca *models.SSHUserCA) error {
+	db := pg.GetConnection(ctx)
+
+	e := entity.SSHUserCAFromModel(ca)
+	e.UpdatedAt = clock.Now()
+
+	r, err := db.NewUpdate().
+		Model(e).
+		Column("name", "revoked_serials", "updated_at").
+		Where("id = ?", ca.ID).
+		Where("namespace_id = ?", ca.TenantID).
+		Exec(ctx)
+	if err != nil {
+		return fromSQLError(err)
+	}
+
+	if rowsAffected, err := r.RowsAffected(); err != nil || rowsAffected == 0 {
+		return store.ErrNoDocuments
+	}
+
+	ca.UpdatedAt = e.UpdatedAt
+
+	return nil
+}
+
+func (pg *Pg) SSHUserCADelete(ctx context.Context, ca *models.SSHUserCA) error {
+	db := pg.GetConnection(ctx)
+
+	r, err := db.NewDelete().
+		Model((*entity.SSHUserCA)(nil)).
+		Where("id = ?", ca.ID).
+		Where("namespace_id = ?", ca.TenantID).
+		Exec(ctx)
+	if err != nil {
+		return fromSQLError(err)
+	}
+
+	if rowsAffected, err := r.RowsAffected(); err != nil || rowsAffected == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func sshUserCAResolverToString(resolver store.SSHUserCAResolver) (string, error) {
+	switch resolver {
+	case store.SSHUserCAIDResolver:
+		return "id", nil
+	case store.SSHUserCAFingerprintResolver:
+		return "fingerprint", nil
+	default:
+		return "", store.ErrResolverNotFound
+	}
+}
@@ -53,6 +53,7 @@ func TestPgStore(t *testing.T) {
 		suite.TestDeviceUpdateDoesNotClobberCustomFields(t)
 		suite.TestDeviceUpdateDoesNotClobberHeartbeat(t)
 		suite.TestDeviceHeartbeat(t)
+		suite.TestDeviceListDisconnected(t)
 		suite.TestDeviceOffline(t)
 		suite.TestDeviceDelete(t)
 		suite.TestDeviceDeleteMany(t)
@@ -62,6 +63,7 @@ func TestPgStore(t *testing.T) {
 		suite.TestSessionList(t)
 		suite.TestSessionResolve(t)
 		suite.TestSessionCreate(t)
+		suite.TestSessionListActive(t)
 		suite.TestSessionUpdateDeviceUID(t)
 		suite.TestSessionUpdate(t)
 		suite.TestActiveSessionDelete(t)
@@ -70,6 +72,9 @@ func TestPgStore(t *testing.T) {
 		suite.TestActiveSessionUpdatePreservesCreatedAt(t)
 		suite.TestSessionEventsCreate(t)
 		suite.TestSessionEventsList(t)
+		suite.TestSessionEventsScan(t)
+		suite.TestSessionEventsSearch(t)
+		suite.TestSessionCommandsList(t)
 		suite.TestSessionEventsDelete(t)
 		suite.TestSessionCleanup(t)
 	})
@@ -91,6 +96,7 @@ func TestPgStore(t *testing.T) {
 		suite.TestAPIKeyResolve(t)
 		suite.TestAPIKeyList(t)
 		suite.TestAPIKeyUpdate(t)
+		suite.TestAPIKeyRecordUses(t)
 		suite.TestAPIKeyDelete(t)
 		suite.TestAPIKeyDeleteAllByCreator(t)
 	})
@@ -149,6 +155,27 @@ func TestPgStore(t *testing.T) {
 		suite.TestSystemSet(t)
 	})
 
+	runSubSuite(t, "AccessRequestStore", func(suite *storetest.Suite, t *testing.T) {
+		suite.TestAccessRequestTransition(t)
+	})
+
+	runSubSuite(t, "AuditEventStore", func(suite *storetest.Suite, t *testing.T) {
+		suite.TestAuditEventCreate(t)
+		suite.TestAuditEventDeleteBefore(t)
+	})
+
+	runSubSuite(t, "WebhookStore", func(suite *storetest.Suite, t *testing.T) {
+		suite.TestWebhookListSubscribed(t)
+		suite.TestWebhookDeliveryCreate(t)
+		suite.TestWebhookDeliveryClaim(t)
+	})
+
+	runSubSuite(t, "SCIMStore", func(suite *storetest.Suite, t *testing.T) {
+		suite.TestSCIMGroupCreate(t)
+		suite.TestSCIMGroupUpdate(t)
+		suite.TestSCIMGroupDelete(t)
+	})
+
 	runSubSuite(t, "TransactionStore", func(suite *storetest.Suite, t *testing.T) {
 		suite.TestWithTransaction(t)
 	})
@@ -186,6 +213,10 @@ func TestPgStore(t *testing.T) {
 		suite.TestScopeIsolationAccessPolicyResolve(t)
 		suite.TestScopeIsolationSSHIdentityList(t)
 		suite.TestScopeIsolationSSHIdentityResolve(t)
+		suite.TestScopeIsolationAccessRequestList(t)
+		suite.TestScopeIsolationAccessRequestResolve(t)
+		suite.TestScopeIsolationAuditEventList(t)
+		suite.TestScopeIsolationWebhookResolve(t)
 		suite.TestScopeRejectsUnconstructedScope(t)
 	})
 }
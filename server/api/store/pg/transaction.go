@@ -43,6 +43,14 @@ func (pg *Pg) GetConnection(ctx context.Context) bun.IDB {
 // This hides the dependency and makes it less explicit.
 // Consider refactoring to expose a typed TxStore in the future for better clarity.
 func (pg *Pg) WithTransaction(ctx context.Context, fn store.TransactionCb) (err error) {
+	// A call made inside another transaction joins it instead of opening a second
+	// one on another connection, so a store method that guards its own writes with
+	// a transaction (e.g. AccessPolicyUpdate) stays atomic with its caller's. The
+	// outermost call owns the commit and the rollback.
+	if _, ok := ctx.Value(txKey).(bun.Tx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := pg.driver.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -0,0 +1,286 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/shellhub-io/shellhub/server/api/store/pg/entity"
+)
+
+func (pg *Pg) WebhookCreate(ctx context.Context, webhook *models.Webhook) (string, error) {
+	db := pg.GetConnection(ctx)
+
+	now := clock.Now()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	if webhook.ID == "" {
+		webhook.ID = uuid.Generate()
+	}
+
+	if _, err := db.NewInsert().Model(entity.WebhookFromModel(webhook)).Exec(ctx); err != nil {
+		return "", fromSQLError(err)
+	}
+
+	return webhook.ID, nil
+}
+
+func (pg *Pg) WebhookList(ctx context.Context, sc scope.Scope, opts ...store.QueryOption) ([]models.Webhook, int, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.Webhook, 0)
+
+	query, err := applyScopedOptions(ctx, db.NewSelect().Model(&entities), sc, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fromSQLError(err)
+	}
+
+	webhooks := make([]models.Webhook, len(entities))
+	for i, e := range entities {
+		webhooks[i] = *entity.WebhookToModel(&e)
+	}
+
+	return webhooks, count, nil
+}
+
+func (pg *Pg) WebhookResolve(ctx context.Context, sc scope.Scope, id string) (*models.Webhook, error) {
+	db := pg.GetConnection(ctx)
+
+	e := new(entity.Webhook)
+
+	query, err := applyScopedOptions(ctx, db.NewSelect().Model(e).Where("id = ?", id), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	return entity.WebhookToModel(e), nil
+}
+
+func (pg *Pg) WebhookListSubscribed(ctx context.Context, sc scope.Scope, event models.WebhookEventType) ([]models.Webhook, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.Webhook, 0)
+
+	query := db.NewSelect().
+		Model(&entities).
+		Where("enabled").
+		Where("? = ANY(events)", string(event)).
+		Order("created_at ASC")
+
+	query, err := applyScopedOptions(ctx, query, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	webhooks := make([]models.Webhook, len(entities))
+	for i, e := range entities {
+		webhooks[i] = *entity.WebhookToModel(&e)
+	}
+
+	return webhooks, nil
+}
+
+func (pg *Pg) WebhookUpdate(ctx context.Context, webhook *models.Webhook) error {
+	db := pg.GetConnection(ctx)
+
+	e := entity.WebhookFromModel(webhook)
+	e.UpdatedAt = clock.Now()
+
+	r, err := db.NewUpdate().
+		Model(e).
+		Column("name", "url", "secret", "events", "enabled", "updated_at").
+		Where("id = ?", webhook.ID).
+		Where("namespace_id = ?", webhook.TenantID).
+		Exec(ctx)
+	if err != nil {
+		return fromSQLError(err)
+	}
+
+	if rowsAffected, err := r.RowsAffected(); err != nil || rowsAffected == 0 {
+		return store.ErrNoDocuments
+	}
+
+	webhook.UpdatedAt = e.UpdatedAt
+
+	return nil
+}
+
+func (pg *Pg) WebhookDelete(ctx context.Context, webhook *models.Webhook) error {
+	db := pg.GetConnection(ctx)
+
+	r, err := db.NewDelete().
+		Model((*entity.Webhook)(nil)).
+		Where("id = ?", webhook.ID).
+		Where("namespace_id = ?", webhook.TenantID).
+		Exec(ctx)
+	if err != nil {
+		return fromSQLError(err)
+	}
+
+	if rowsAffected, err := r.RowsAffected(); err != nil || rowsAffected == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (pg *Pg) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) (string, error) {
+	db := pg.GetConnection(ctx)
+
+	now := clock.Now()
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+
+	if delivery.ID == "" {
+		delivery.ID = uuid.Generate()
+	}
+
+	if _, err := db.NewInsert().Model(entity.WebhookDeliveryFromModel(delivery)).Exec(ctx); err != nil {
+		return "", fromSQLError(err)
+	}
+
+	return delivery.ID, nil
+}
+
+func (pg *Pg) WebhookDeliveryList(ctx context.Context, sc scope.Scope, webhookID string, opts ...store.QueryOption) ([]models.WebhookDelivery, int, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.WebhookDelivery, 0)
+
+	query, err := applyScopedOptions(ctx, db.NewSelect().Model(&entities).Where("webhook_id = ?", webhookID), sc, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fromSQLError(err)
+	}
+
+	deliveries := make([]models.WebhookDelivery, len(entities))
+	for i, e := range entities {
+		deliveries[i] = *entity.WebhookDeliveryToModel(&e)
+	}
+
+	return deliveries, count, nil
+}
+
+func (pg *Pg) WebhookDeliveryResolve(ctx context.Context, sc scope.Scope, id string) (*models.WebhookDelivery, error) {
+	db := pg.GetConnection(ctx)
+
+	e := new(entity.WebhookDelivery)
+
+	query, err := applyScopedOptions(ctx, db.NewSelect().Model(e).Where("id = ?", id), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	return entity.WebhookDeliveryToModel(e), nil
+}
+
+func (pg *Pg) WebhookDeliveryListDue(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	db := pg.GetConnection(ctx)
+
+	entities := make([]entity.WebhookDelivery, 0)
+	if err := db.NewSelect().
+		Model(&entities).
+		Where("status = ?", string(models.WebhookDeliveryPending)).
+		Where("next_attempt_at <= ?", now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, fromSQLError(err)
+	}
+
+	deliveries := make([]models.WebhookDelivery, len(entities))
+	for i, e := range entities {
+		deliveries[i] = *entity.WebhookDeliveryToModel(&e)
+	}
+
+	return deliveries, nil
+}
+
+func (pg *Pg) WebhookDeliveryClaim(ctx context.Context, id string, now, until time.Time) (bool, error) {
+	db := pg.GetConnection(ctx)
+
+	// Atomic claim: the due guard makes the affected-row count decide which
+	// caller makes the attempt.
+	res, err := db.NewUpdate().
+		Model((*entity.WebhookDelivery)(nil)).
+		Set("next_attempt_at = ?", until).
+		Set("updated_at = ?", clock.Now()).
+		Where("id = ?", id).
+		Where("status = ?", string(models.WebhookDeliveryPending)).
+		Where("next_attempt_at <= ?", now).
+		Exec(ctx)
+	if err != nil {
+		return false, fromSQLError(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fromSQLError(err)
+	}
+
+	return affected == 1, nil
+}
+
+func (pg *Pg) WebhookDeliveryUpdate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	db := pg.GetConnection(ctx)
+
+	e := entity.WebhookDeliveryFromModel(delivery)
+	e.UpdatedAt = clock.Now()
+
+	r, err := db.NewUpdate().
+		Model(e).
+		Column("status", "attempts", "next_attempt_at", "response_status", "last_error", "delivered_at", "updated_at").
+		Where("id = ?", delivery.ID).
+		Exec(ctx)
+	if err != nil {
+		return fromSQLError(err)
+	}
+
+	if rowsAffected, err := r.RowsAffected(); err != nil || rowsAffected == 0 {
+		return store.ErrNoDocuments
+	}
+
+	delivery.UpdatedAt = e.UpdatedAt
+
+	return nil
+}
+
+func (pg *Pg) WebhookDeliveryDeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := pg.GetConnection(ctx)
+
+	res, err := db.NewDelete().
+		Model((*entity.WebhookDelivery)(nil)).
+		Where("created_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, fromSQLError(err)
+	}
+
+	return res.RowsAffected()
+}
@@ -21,6 +21,10 @@ type QueryOptions interface {
 	// WithMember filters namespaces where the given user is a member.
 	WithMember(userID string) QueryOption
 
+	// WithDeviceTags matches records whose device_id column names a device carrying any of the
+	// given tags. No tags match every record.
+	WithDeviceTags(tags []string) QueryOption
+
 	// WithUserID matches records whose user_id column equals the given user.
 	WithUserID(userID string) QueryOption
 
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type SCIMGroupResolver int
+
+const (
+	SCIMGroupIDResolver SCIMGroupResolver = iota
+	SCIMGroupDisplayNameResolver
+)
+
+type SCIMStore interface {
+	// SCIMGroupCreate creates a SCIM group along with its members and returns its ID. It returns
+	// [ErrDuplicate] if a group with the same display name already exists.
+	SCIMGroupCreate(ctx context.Context, group *models.SCIMGroup) (string, error)
+	// SCIMGroupList retrieves the SCIM groups.
+	SCIMGroupList(ctx context.Context, opts ...QueryOption) ([]models.SCIMGroup, int, error)
+	// SCIMGroupListByMember retrieves the SCIM groups the user is a member of.
+	SCIMGroupListByMember(ctx context.Context, userID string) ([]models.SCIMGroup, error)
+	// SCIMGroupResolve retrieves a SCIM group by the given resolver type and value.
+	SCIMGroupResolve(ctx context.Context, resolver SCIMGroupResolver, value string) (*models.SCIMGroup, error)
+	// SCIMGroupUpdate updates a SCIM group, replacing its members with the given ones. It returns
+	// [ErrNoDocuments] if none was found.
+	SCIMGroupUpdate(ctx context.Context, group *models.SCIMGroup) error
+	// SCIMGroupDelete deletes a SCIM group. It returns [ErrNoDocuments] if none was found.
+	SCIMGroupDelete(ctx context.Context, group *models.SCIMGroup) error
+}
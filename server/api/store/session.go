@@ -23,10 +23,45 @@ type ExpiredSession struct {
 	Recorded bool
 }
 
+// SessionEventsCursor is where a page of [SessionStore.SessionEventsScan] ended. The zero value
+// is the start of the seat's events.
+type SessionEventsCursor struct {
+	Timestamp time.Time
+	ID        string
+}
+
+// SessionSearch is what [SessionStore.SessionEventsSearch] looks for.
+type SessionSearch struct {
+	// Phrase is matched as a phrase, word by word in order, against the output sessions printed
+	// and the commands exec sessions ran.
+	Phrase string
+	// Since and Until bound when the matching events happened. A zero value leaves that end
+	// open.
+	Since time.Time
+	Until time.Time
+}
+
+// SessionCommandsFilter narrows what [SessionStore.SessionCommandsList] lists. A zero field
+// does not narrow.
+type SessionCommandsFilter struct {
+	// SessionUID lists one session's commands, in the order they ran.
+	SessionUID models.UID
+	// Username and UserID list the commands of the sessions logged in as a device user, or
+	// bound to a ShellHub account.
+	Username string
+	UserID   string
+	// Since and Until bound when the commands ran.
+	Since time.Time
+	Until time.Time
+}
+
 type SessionStore interface {
 	// SessionList retrieves a list of sessions based on the provided filters and pagination settings.
 	// It returns the list of sessions, the total count of matching documents, and an error if any.
 	SessionList(ctx context.Context, sc scope.Scope, opts ...QueryOption) ([]models.Session, int, error)
+	// SessionListActive returns the active sessions within the given namespace scope, oldest
+	// first, only those bound to the ShellHub account userID when it is not empty.
+	SessionListActive(ctx context.Context, sc scope.Scope, userID string) ([]models.Session, error)
 	// SessionResolve fetches a session using a specific resolver within the given namespace scope.
 	// It returns the resolved session if found and an error, if any.
 	SessionResolve(ctx context.Context, sc scope.Scope, resolver SessionResolver, value string, opts ...QueryOption) (*models.Session, error)
@@ -53,6 +88,26 @@ type SessionStore interface {
 	SessionEventsCreateMany(ctx context.Context, events []models.SessionEvent) error
 	// SessionEventsList retrieves session events based on filters. It returns the list of events, total count, and an error if any.
 	SessionEventsList(ctx context.Context, uid models.UID, seat int, event models.SessionEventType, opts ...QueryOption) ([]models.SessionEvent, int, error)
+	// SessionEventsScan returns up to limit of a seat's events of the given types, oldest first,
+	// starting after the cursor, along with the cursor the next page starts after. A page shorter
+	// than limit is the last one.
+	//
+	// It pages by key rather than by offset, so reading through a recording hours long costs the
+	// same per page at its end as at its start.
+	SessionEventsScan(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, after SessionEventsCursor, limit int) ([]models.SessionEvent, SessionEventsCursor, error)
+	// SessionEventsScanBackward is [SessionStore.SessionEventsScan] read from the end: it returns
+	// up to limit of a seat's events of the given types, newest first, starting before the
+	// cursor, along with the cursor the next page starts before. The zero cursor is the end of
+	// the seat's events.
+	SessionEventsScanBackward(ctx context.Context, uid models.UID, seat int, types []models.SessionEventType, before SessionEventsCursor, limit int) ([]models.SessionEvent, SessionEventsCursor, error)
+	// SessionEventsSearch returns the recorded events matching the search, newest first, with the
+	// total count of matches. Only pty-output, exec and command events are searched, and each hit's
+	// snippet is the whole of the event's output or command, escape sequences included.
+	SessionEventsSearch(ctx context.Context, sc scope.Scope, search SessionSearch, opts ...QueryOption) ([]models.SessionSearchHit, int, error)
+	// SessionCommandsList returns the commands recorded on the sessions in scope that match the
+	// filter, with their total count. A session's commands are listed oldest first; otherwise the
+	// newest come first.
+	SessionCommandsList(ctx context.Context, sc scope.Scope, filter SessionCommandsFilter, opts ...QueryOption) ([]models.SessionCommand, int, error)
 	// SessionEventsDelete removes session events based on filters. It returns an error if any.
 	SessionEventsDelete(ctx context.Context, uid models.UID, seat int, event models.SessionEventType) error
 
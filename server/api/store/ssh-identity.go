@@ -24,7 +24,8 @@ type SSHIdentityStore interface {
 	SSHIdentityResolve(ctx context.Context, sc scope.Scope, resolver SSHIdentityResolver, value string, opts ...QueryOption) (*models.SSHIdentity, error)
 	// SSHIdentityCreate creates a new SSH identity and returns its id.
 	SSHIdentityCreate(ctx context.Context, identity *models.SSHIdentity) (string, error)
-	// SSHIdentityUpdate renames an existing SSH identity scoped to its namespace.
+	// SSHIdentityUpdate updates the name and lifecycle (expiry and single use) of an existing SSH
+	// identity scoped to its namespace.
 	SSHIdentityUpdate(ctx context.Context, identity *models.SSHIdentity) error
 	// SSHIdentityDelete removes an SSH identity scoped to its namespace.
 	SSHIdentityDelete(ctx context.Context, identity *models.SSHIdentity) error
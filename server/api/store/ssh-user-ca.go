@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type SSHUserCAResolver int
+
+const (
+	// SSHUserCAIDResolver resolves a user certificate authority by its id.
+	SSHUserCAIDResolver SSHUserCAResolver = iota
+	// SSHUserCAFingerprintResolver resolves a user certificate authority by the
+	// fingerprint of its public key. Fingerprints are unique only within a
+	// namespace, so the scope must be bounded.
+	SSHUserCAFingerprintResolver
+)
+
+type SSHUserCAStore interface {
+	// SSHUserCAList retrieves the user certificate authorities trusted by a namespace.
+	SSHUserCAList(ctx context.Context, sc scope.Scope, opts ...QueryOption) ([]models.SSHUserCA, int, error)
+	// SSHUserCAResolve retrieves a user certificate authority by the given resolver type and value, scoped to a namespace.
+	SSHUserCAResolve(ctx context.Context, sc scope.Scope, resolver SSHUserCAResolver, value string, opts ...QueryOption) (*models.SSHUserCA, error)
+	// SSHUserCACreate creates a new user certificate authority and returns its id.
+	SSHUserCACreate(ctx context.Context, ca *models.SSHUserCA) (string, error)
+	// SSHUserCAUpdate updates the name and revoked serials of a user certificate authority scoped to its namespace.
+	SSHUserCAUpdate(ctx context.Context, ca *models.SSHUserCA) error
+	// SSHUserCADelete removes a user certificate authority scoped to its namespace.
+	SSHUserCADelete(ctx context.Context, ca *models.SSHUserCA) error
+}
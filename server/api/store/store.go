@@ -9,7 +9,10 @@ type Store interface {
 	MemberStore
 	PublicKeyStore
 	AccessPolicyStore
+	AccessRequestStore
+	AuditEventStore
 	SSHIdentityStore
+	SSHUserCAStore
 	SSHApprovalStore
 	ServiceAccountStore
 	PrivateKeyStore
@@ -20,6 +23,8 @@ type Store interface {
 	SystemStore
 	MembershipInvitationStore
 	UserInvitationStore
+	WebhookStore
+	SCIMStore
 
 	Options() QueryOptions
 }
@@ -0,0 +1,59 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Suite) TestAccessRequestTransition(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	tenantID := s.CreateNamespace(t)
+	userID := s.CreateUser(t)
+	approverID := s.CreateUser(t)
+
+	request := &models.AccessRequest{
+		TenantID:      tenantID,
+		UserID:        userID,
+		Filter:        models.PublicKeyFilter{Hostname: ".*"},
+		Logins:        []string{"root"},
+		Duration:      3600,
+		Justification: "incident",
+		Status:        models.AccessRequestPending,
+	}
+	_, err := st.AccessRequestCreate(ctx, request)
+	require.NoError(t, err)
+
+	now := clock.Now().Truncate(time.Microsecond)
+	expiresAt := now.Add(-time.Minute)
+	request.Status = models.AccessRequestGranted
+	request.DecidedBy = approverID
+	request.DecidedAt = &now
+	request.ExpiresAt = &expiresAt
+
+	moved, err := st.AccessRequestTransition(ctx, request, models.AccessRequestPending)
+	require.NoError(t, err)
+	assert.True(t, moved)
+
+	// The request is no longer pending, so a second decision loses the claim.
+	moved, err = st.AccessRequestTransition(ctx, request, models.AccessRequestPending)
+	require.NoError(t, err)
+	assert.False(t, moved)
+
+	lapsed, err := st.AccessRequestListLapsed(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, lapsed, 1)
+	assert.Equal(t, approverID, lapsed[0].DecidedBy)
+
+	lapsed, err = st.AccessRequestListLapsed(ctx, expiresAt.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Empty(t, lapsed)
+}
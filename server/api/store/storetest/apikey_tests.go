@@ -3,6 +3,7 @@ package storetest
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/scope"
@@ -257,6 +258,94 @@ func (s *Suite) TestAPIKeyUpdate(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "updated-dev", updatedKey.Name)
 	})
+
+	t.Run("sets and clears the scope", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		tenantID := s.CreateNamespace(t)
+		keyID := s.CreateAPIKey(t, WithAPIKeyName("dev"), WithAPIKeyTenant(tenantID))
+
+		apiKey, err := st.APIKeyResolve(ctx, scope.MustBounded(tenantID), store.APIKeyIDResolver, keyID)
+		require.NoError(t, err)
+
+		apiKey.Permissions = []string{"device:connect"}
+		apiKey.Tags = []string{"production"}
+		apiKey.SourceIPs = []string{"198.51.100.0/24"}
+		require.NoError(t, st.APIKeyUpdate(ctx, apiKey))
+
+		scopedKey, err := st.APIKeyResolve(ctx, scope.MustBounded(tenantID), store.APIKeyIDResolver, keyID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"device:connect"}, scopedKey.Permissions)
+		assert.Equal(t, []string{"production"}, scopedKey.Tags)
+		assert.Equal(t, []string{"198.51.100.0/24"}, scopedKey.SourceIPs)
+
+		scopedKey.Permissions = []string{}
+		scopedKey.Tags = nil
+		require.NoError(t, st.APIKeyUpdate(ctx, scopedKey))
+
+		clearedKey, err := st.APIKeyResolve(ctx, scope.MustBounded(tenantID), store.APIKeyIDResolver, keyID)
+		require.NoError(t, err)
+		assert.Empty(t, clearedKey.Permissions)
+		assert.Empty(t, clearedKey.Tags)
+		assert.Equal(t, []string{"198.51.100.0/24"}, clearedKey.SourceIPs)
+	})
+}
+
+func (s *Suite) TestAPIKeyRecordUses(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+
+	t.Run("skips an API key that does not exist", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		tenantID := s.CreateNamespace(t)
+
+		err := st.APIKeyRecordUses(ctx, []store.APIKeyUse{{TenantID: tenantID, ID: "nonexistent", Count: 1, LastUsedAt: time.Now()}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("adds each batch's uses and stamps the last one", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		tenantID := s.CreateNamespace(t)
+		keyID := s.CreateAPIKey(t, WithAPIKeyName("dev"), WithAPIKeyTenant(tenantID))
+		otherID := s.CreateAPIKey(t, WithAPIKeyName("ci"), WithAPIKeyTenant(tenantID))
+
+		apiKey, err := st.APIKeyResolve(ctx, scope.MustBounded(tenantID), store.APIKeyIDResolver, keyID)
+		require.NoError(t, err)
+		assert.Zero(t, apiKey.UsageCount)
+		assert.Nil(t, apiKey.LastUsedAt)
+
+		lastUsedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		require.NoError(t, st.APIKeyRecordUses(ctx, []store.APIKeyUse{
+			{TenantID: tenantID, ID: keyID, Count: 2, LastUsedAt: lastUsedAt},
+			{TenantID: tenantID, ID: otherID, Count: 1, LastUsedAt: lastUsedAt},
+		}))
+		// A batch written late must not move the last use back in time.
+		require.NoError(t, st.APIKeyRecordUses(ctx, []store.APIKeyUse{
+			{TenantID: tenantID, ID: keyID, Count: 3, LastUsedAt: lastUsedAt.Add(-time.Hour)},
+		}))
+
+		usedKey, err := st.APIKeyResolve(ctx, scope.MustBounded(tenantID), store.APIKeyIDResolver, keyID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), usedKey.UsageCount)
+		require.NotNil(t, usedKey.LastUsedAt)
+		assert.True(t, lastUsedAt.Equal(*usedKey.LastUsedAt))
+
+		otherKey, err := st.APIKeyResolve(ctx, scope.MustBounded(tenantID), store.APIKeyIDResolver, otherID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), otherKey.UsageCount)
+
+		// An update carries the counters it resolved, which must not overwrite newer uses.
+		usedKey.Name = "renamed"
+		require.NoError(t, st.APIKeyRecordUses(ctx, []store.APIKeyUse{{TenantID: tenantID, ID: keyID, Count: 1, LastUsedAt: lastUsedAt}}))
+		require.NoError(t, st.APIKeyUpdate(ctx, usedKey))
+
+		renamedKey, err := st.APIKeyResolve(ctx, scope.MustBounded(tenantID), store.APIKeyIDResolver, keyID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), renamedKey.UsageCount)
+	})
 }
 
 func (s *Suite) TestAPIKeyDelete(t *testing.T) {
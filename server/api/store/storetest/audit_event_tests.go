@@ -0,0 +1,67 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Suite) TestAuditEventCreate(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	tenantID := s.CreateNamespace(t)
+
+	event := &models.AuditEvent{
+		TenantID:   tenantID,
+		Actor:      models.AuditActor{Type: models.AuditActorUser, ID: "user-id", Name: "john"},
+		Action:     models.AuditActionMemberUpdate,
+		TargetType: models.AuditTargetMember,
+		TargetID:   "member-id",
+		Before:     map[string]any{"role": "observer"},
+		After:      map[string]any{"role": "operator"},
+		SourceIP:   "192.0.2.10",
+		RequestID:  "request-id",
+	}
+	require.NoError(t, st.AuditEventCreate(ctx, event))
+	assert.NotEmpty(t, event.ID)
+
+	events, count, err := st.AuditEventList(ctx, scope.MustBounded(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, events, 1)
+	assert.Equal(t, event.Actor, events[0].Actor)
+	assert.Equal(t, event.Before, events[0].Before)
+	assert.Equal(t, event.After, events[0].After)
+	assert.Equal(t, "request-id", events[0].RequestID)
+}
+
+func (s *Suite) TestAuditEventDeleteBefore(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	tenantID := s.CreateNamespace(t)
+
+	require.NoError(t, st.AuditEventCreate(ctx, &models.AuditEvent{
+		TenantID:   tenantID,
+		Action:     models.AuditActionInstallKeyReveal,
+		TargetType: models.AuditTargetInstallKey,
+		TargetID:   "key-id",
+	}))
+
+	deleted, err := st.AuditEventDeleteBefore(ctx, clock.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	deleted, err = st.AuditEventDeleteBefore(ctx, clock.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
@@ -758,6 +758,45 @@ func (s *Suite) TestDeviceHeartbeat(t *testing.T) {
 	})
 }
 
+// TestDeviceListDisconnected tests reading which devices of a heartbeat batch
+// were disconnected
+func (s *Suite) TestDeviceListDisconnected(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+
+	t.Run("returns only the disconnected devices among the given ones", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		online := s.CreateDevice(t, WithDeviceName("online"))
+		disconnected := s.CreateDevice(t, WithDeviceName("disconnected"))
+		elsewhere := s.CreateDevice(t, WithDeviceName("elsewhere"))
+
+		require.NoError(t, st.DeviceOffline(ctx, string(disconnected), time.Now()))
+		require.NoError(t, st.DeviceOffline(ctx, string(elsewhere), time.Now()))
+
+		devices, err := st.DeviceListDisconnected(ctx, []string{string(online), string(disconnected)})
+		require.NoError(t, err)
+		require.Len(t, devices, 1)
+		assert.Equal(t, string(disconnected), devices[0].UID)
+		assert.NotEmpty(t, devices[0].TenantID)
+		assert.NotNil(t, devices[0].DisconnectedAt)
+	})
+
+	t.Run("a heartbeat brings the device back", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		uid := s.CreateDevice(t)
+		require.NoError(t, st.DeviceOffline(ctx, string(uid), time.Now()))
+
+		_, err := st.DeviceHeartbeat(ctx, []string{string(uid)}, time.Now())
+		require.NoError(t, err)
+
+		devices, err := st.DeviceListDisconnected(ctx, []string{string(uid)})
+		require.NoError(t, err)
+		assert.Empty(t, devices)
+	})
+}
+
 // TestDeviceDelete tests device deletion
 func (s *Suite) TestDeviceDelete(t *testing.T) {
 	ctx := context.Background()
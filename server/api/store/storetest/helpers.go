@@ -35,6 +35,14 @@ func WithEmail(email string) UserOption {
 	}
 }
 
+// WithExternalID sets the user's origin and identifier at an identity provider
+func WithExternalID(origin models.UserOrigin, externalID string) UserOption {
+	return func(u *models.User) {
+		u.Origin = origin
+		u.ExternalID = externalID
+	}
+}
+
 // WithUserStatus sets the user status
 func WithUserStatus(status models.UserStatus) UserOption {
 	return func(u *models.User) {
@@ -292,6 +300,13 @@ func WithSessionUser(username string) SessionOption {
 	}
 }
 
+// WithSessionUserID binds the session to a ShellHub account
+func WithSessionUserID(userID string) SessionOption {
+	return func(s *models.Session) {
+		s.UserID = userID
+	}
+}
+
 // WithSessionActive sets the active status
 func WithSessionActive(active bool) SessionOption {
 	return func(s *models.Session) {
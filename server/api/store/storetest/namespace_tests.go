@@ -533,6 +533,23 @@ func (s *Suite) TestNamespaceUpdate(t *testing.T) {
 		assert.Equal(t, 100, updated.MaxDevices)
 	})
 
+	t.Run("updates the local authentication setting", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		tenantID := s.CreateNamespace(t)
+
+		ns, err := st.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, tenantID)
+		require.NoError(t, err)
+		assert.False(t, ns.Settings.LocalAuthDisabled)
+
+		ns.Settings.LocalAuthDisabled = true
+		require.NoError(t, st.NamespaceUpdate(ctx, ns))
+
+		updated, err := st.NamespaceResolve(ctx, store.NamespaceTenantIDResolver, tenantID)
+		require.NoError(t, err)
+		assert.True(t, updated.Settings.LocalAuthDisabled)
+	})
+
 	t.Run("fails for non-existent namespace", func(t *testing.T) {
 		require.NoError(t, s.provider.CleanDatabase(t))
 
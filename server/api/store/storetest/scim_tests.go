@@ -0,0 +1,110 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Suite) TestSCIMGroupCreate(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	alice := s.CreateUser(t, WithUsername("alice"), WithEmail("alice@test.com"))
+	bob := s.CreateUser(t, WithUsername("bob"), WithEmail("bob@test.com"))
+
+	id, err := st.SCIMGroupCreate(ctx, &models.SCIMGroup{
+		ExternalID:  "00g1",
+		DisplayName: "engineering",
+		Members:     []string{alice, bob, alice},
+	})
+	require.NoError(t, err)
+
+	group, err := st.SCIMGroupResolve(ctx, store.SCIMGroupIDResolver, id)
+	require.NoError(t, err)
+	assert.Equal(t, "00g1", group.ExternalID)
+	assert.Equal(t, "engineering", group.DisplayName)
+	assert.ElementsMatch(t, []string{alice, bob}, group.Members)
+
+	// Display names are unique, which is what lets the role mapping key on them.
+	_, err = st.SCIMGroupCreate(ctx, &models.SCIMGroup{DisplayName: "engineering"})
+	assert.ErrorIs(t, err, store.ErrDuplicate)
+
+	byName, err := st.SCIMGroupResolve(ctx, store.SCIMGroupDisplayNameResolver, "engineering")
+	require.NoError(t, err)
+	assert.Equal(t, id, byName.ID)
+
+	groups, count, err := st.SCIMGroupList(ctx, st.Options().Match(&query.Filters{
+		Data: []query.Filter{{
+			Type:   query.FilterTypeProperty,
+			Params: &query.FilterProperty{Name: "display_name", Operator: "eq", Value: "engineering"},
+		}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, groups, 1)
+	assert.Equal(t, id, groups[0].ID)
+}
+
+func (s *Suite) TestSCIMGroupUpdate(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	alice := s.CreateUser(t, WithUsername("alice"), WithEmail("alice@test.com"))
+	bob := s.CreateUser(t, WithUsername("bob"), WithEmail("bob@test.com"))
+
+	group := &models.SCIMGroup{DisplayName: "engineering", Members: []string{alice}}
+	_, err := st.SCIMGroupCreate(ctx, group)
+	require.NoError(t, err)
+
+	group.DisplayName = "platform"
+	group.Members = []string{bob}
+	require.NoError(t, st.SCIMGroupUpdate(ctx, group))
+
+	updated, err := st.SCIMGroupResolve(ctx, store.SCIMGroupIDResolver, group.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "platform", updated.DisplayName)
+	assert.Equal(t, []string{bob}, updated.Members)
+
+	groups, err := st.SCIMGroupListByMember(ctx, alice)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	groups, err = st.SCIMGroupListByMember(ctx, bob)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, group.ID, groups[0].ID)
+
+	err = st.SCIMGroupUpdate(ctx, &models.SCIMGroup{ID: "00000000-0000-4000-0000-000000000000", DisplayName: "missing"})
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+}
+
+func (s *Suite) TestSCIMGroupDelete(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	alice := s.CreateUser(t, WithUsername("alice"), WithEmail("alice@test.com"))
+
+	group := &models.SCIMGroup{DisplayName: "engineering", Members: []string{alice}}
+	_, err := st.SCIMGroupCreate(ctx, group)
+	require.NoError(t, err)
+
+	require.NoError(t, st.SCIMGroupDelete(ctx, group))
+
+	_, err = st.SCIMGroupResolve(ctx, store.SCIMGroupIDResolver, group.ID)
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+
+	groups, err := st.SCIMGroupListByMember(ctx, alice)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	assert.ErrorIs(t, st.SCIMGroupDelete(ctx, group), store.ErrNoDocuments)
+}
@@ -811,3 +811,183 @@ func (s *Suite) TestScopeIsolationSSHIdentityResolve(t *testing.T) {
 	assert.ErrorIs(t, err, store.ErrNoDocuments)
 	assert.Nil(t, got)
 }
+
+func (s *Suite) TestScopeIsolationSSHUserCAList(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	owner := s.CreateNamespace(t)
+	other := s.CreateNamespace(t)
+
+	_, err := st.SSHUserCACreate(ctx, &models.SSHUserCA{
+		TenantID:       owner,
+		Name:           "corp",
+		Fingerprint:    "SHA256:cccccccccccccccccccccccccccccccccccccccccccc=",
+		Data:           "ssh-ed25519 CCCC fake-ca",
+		RevokedSerials: []uint64{42},
+	})
+	require.NoError(t, err)
+
+	cas, count, err := st.SSHUserCAList(ctx, scope.MustBounded(owner))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, cas, 1)
+	assert.Equal(t, []uint64{42}, cas[0].RevokedSerials)
+
+	cas, count, err = st.SSHUserCAList(ctx, scope.MustBounded(other))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, cas)
+}
+
+func (s *Suite) TestScopeIsolationSSHUserCAResolve(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	owner := s.CreateNamespace(t)
+	other := s.CreateNamespace(t)
+
+	fingerprint := "SHA256:dddddddddddddddddddddddddddddddddddddddddddd="
+	_, err := st.SSHUserCACreate(ctx, &models.SSHUserCA{
+		TenantID:    owner,
+		Name:        "corp",
+		Fingerprint: fingerprint,
+		Data:        "ssh-ed25519 DDDD fake-ca",
+	})
+	require.NoError(t, err)
+
+	got, err := st.SSHUserCAResolve(ctx, scope.MustBounded(owner), store.SSHUserCAFingerprintResolver, fingerprint)
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, got.Fingerprint)
+
+	got, err = st.SSHUserCAResolve(ctx, scope.MustBounded(other), store.SSHUserCAFingerprintResolver, fingerprint)
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+	assert.Nil(t, got)
+}
+
+func (s *Suite) TestScopeIsolationAccessRequestList(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	owner := s.CreateNamespace(t)
+	other := s.CreateNamespace(t)
+	userID := s.CreateUser(t)
+
+	_, err := st.AccessRequestCreate(ctx, &models.AccessRequest{
+		TenantID:      owner,
+		UserID:        userID,
+		Filter:        models.PublicKeyFilter{Hostname: ".*"},
+		Logins:        []string{"root"},
+		Duration:      3600,
+		Justification: "incident",
+		Status:        models.AccessRequestPending,
+	})
+	require.NoError(t, err)
+
+	requests, count, err := st.AccessRequestList(ctx, scope.MustBounded(owner))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, requests, 1)
+	assert.Equal(t, userID, requests[0].UserID)
+
+	requests, count, err = st.AccessRequestList(ctx, scope.MustBounded(other))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, requests)
+}
+
+func (s *Suite) TestScopeIsolationAccessRequestResolve(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	owner := s.CreateNamespace(t)
+	other := s.CreateNamespace(t)
+	userID := s.CreateUser(t)
+
+	id, err := st.AccessRequestCreate(ctx, &models.AccessRequest{
+		TenantID:      owner,
+		UserID:        userID,
+		Filter:        models.PublicKeyFilter{Hostname: ".*"},
+		Logins:        []string{"root"},
+		Duration:      3600,
+		Justification: "incident",
+		Status:        models.AccessRequestPending,
+	})
+	require.NoError(t, err)
+	require.NoError(t, st.AccessRequestEventCreate(ctx, &models.AccessRequestEvent{
+		AccessRequestID: id,
+		TenantID:        owner,
+		Action:          models.AccessRequestActionRequested,
+		ActorID:         userID,
+	}))
+
+	got, err := st.AccessRequestResolve(ctx, scope.MustBounded(owner), id)
+	require.NoError(t, err)
+	assert.Equal(t, id, got.ID)
+	require.Len(t, got.Events, 1)
+	assert.Equal(t, models.AccessRequestActionRequested, got.Events[0].Action)
+
+	got, err = st.AccessRequestResolve(ctx, scope.MustBounded(other), id)
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+	assert.Nil(t, got)
+}
+
+func (s *Suite) TestScopeIsolationAuditEventList(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	owner := s.CreateNamespace(t)
+	other := s.CreateNamespace(t)
+
+	require.NoError(t, st.AuditEventCreate(ctx, &models.AuditEvent{
+		TenantID:   owner,
+		Action:     models.AuditActionAccessPolicyDelete,
+		TargetType: models.AuditTargetAccessPolicy,
+		TargetID:   "policy-id",
+	}))
+
+	events, count, err := st.AuditEventList(ctx, scope.MustBounded(owner))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, events, 1)
+	assert.Equal(t, "policy-id", events[0].TargetID)
+
+	events, count, err = st.AuditEventList(ctx, scope.MustBounded(other))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, events)
+}
+
+func (s *Suite) TestScopeIsolationWebhookResolve(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	owner := s.CreateNamespace(t)
+	other := s.CreateNamespace(t)
+
+	id, err := st.WebhookCreate(ctx, &models.Webhook{
+		TenantID: owner,
+		Name:     "on-call",
+		URL:      "https://example.com",
+		Secret:   "0123456789abcdef",
+		Events:   []models.WebhookEventType{models.WebhookEventDeviceOnline},
+		Enabled:  true,
+	})
+	require.NoError(t, err)
+
+	_, err = st.WebhookResolve(ctx, scope.MustBounded(owner), id)
+	require.NoError(t, err)
+
+	_, err = st.WebhookResolve(ctx, scope.MustBounded(other), id)
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+
+	webhooks, err := st.WebhookListSubscribed(ctx, scope.MustBounded(other), models.WebhookEventDeviceOnline)
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+}
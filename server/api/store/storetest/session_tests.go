@@ -51,6 +51,35 @@ func (s *Suite) TestSessionList(t *testing.T) {
 		assert.Len(t, sessions, 4)
 	})
 
+	t.Run("succeeds when restricted to the devices carrying any of the tags", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		tenantID := s.CreateNamespace(t)
+
+		tagProd := s.CreateTag(t, WithTagName("production"), WithTagTenant(tenantID))
+		tagStaging := s.CreateTag(t, WithTagName("staging"), WithTagTenant(tenantID))
+
+		prod := s.CreateDevice(t, WithTenantID(tenantID))
+		require.NoError(t, st.TagPushToTarget(ctx, tagProd, store.TagTargetDevice, string(prod)))
+
+		staging := s.CreateDevice(t, WithTenantID(tenantID))
+		require.NoError(t, st.TagPushToTarget(ctx, tagStaging, store.TagTargetDevice, string(staging)))
+
+		untagged := s.CreateDevice(t, WithTenantID(tenantID))
+
+		s.CreateSession(t, WithSessionDevice(prod), WithSessionUser("user1"))
+		s.CreateSession(t, WithSessionDevice(staging), WithSessionUser("user2"))
+		s.CreateSession(t, WithSessionDevice(untagged), WithSessionUser("user3"))
+
+		sessions, count, err := st.SessionList(ctx, scope.MustBounded(tenantID), st.Options().WithDeviceTags([]string{"production", "staging"}))
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		require.Len(t, sessions, 2)
+
+		uids := []models.UID{sessions[0].DeviceUID, sessions[1].DeviceUID}
+		assert.ElementsMatch(t, []models.UID{prod, staging}, uids)
+	})
+
 	t.Run("returns all sessions across tenants without filter", func(t *testing.T) {
 		require.NoError(t, s.provider.CleanDatabase(t))
 
@@ -523,6 +552,47 @@ func (s *Suite) TestSessionUpdate(t *testing.T) {
 	})
 }
 
+// TestSessionListActive tests listing the active sessions of a namespace
+func (s *Suite) TestSessionListActive(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+
+	t.Run("lists only the active sessions in scope", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		tenant := s.CreateNamespace(t)
+		device := s.CreateDevice(t, WithTenantID(tenant))
+		other := s.CreateDevice(t, WithTenantID(s.CreateNamespace(t)))
+
+		active := s.CreateSession(t, WithSessionDevice(device), WithSessionActive(true))
+		s.CreateSession(t, WithSessionDevice(device), WithSessionActive(false))
+		s.CreateSession(t, WithSessionDevice(other), WithSessionActive(true))
+
+		sessions, err := st.SessionListActive(ctx, scope.MustBounded(tenant), "")
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, string(active), sessions[0].UID)
+		assert.True(t, sessions[0].Active)
+	})
+
+	t.Run("lists only the sessions of the account when one is given", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		tenant := s.CreateNamespace(t)
+		device := s.CreateDevice(t, WithTenantID(tenant))
+		user := s.CreateUser(t)
+
+		bound := s.CreateSession(t, WithSessionDevice(device), WithSessionUserID(user))
+		s.CreateSession(t, WithSessionDevice(device))
+
+		sessions, err := st.SessionListActive(ctx, scope.MustBounded(tenant), user)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, string(bound), sessions[0].UID)
+		assert.Equal(t, user, sessions[0].UserID)
+	})
+}
+
 // TestActiveSessionDelete tests active session deletion
 func (s *Suite) TestActiveSessionDelete(t *testing.T) {
 	ctx := context.Background()
@@ -764,6 +834,136 @@ func (s *Suite) TestSessionEventsList(t *testing.T) {
 	})
 }
 
+func (s *Suite) TestSessionEventsScan(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+
+	recording := []models.SessionEventType{models.SessionEventTypePtyRequest, models.SessionEventTypeWindowChange, models.SessionEventTypePtyOutput}
+
+	t.Run("succeeds when no events found", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		events, cursor, err := st.SessionEventsScan(ctx, "nonexistent", 0, recording, store.SessionEventsCursor{}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+		assert.Equal(t, store.SessionEventsCursor{}, cursor)
+	})
+
+	t.Run("pages through the seat's events of the given types in order", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		sessionUID := s.CreateSession(t, WithSessionUser("testuser"))
+
+		start := clock.Now().UTC().Truncate(time.Second)
+		events := []models.SessionEvent{
+			{Type: models.SessionEventTypePtyRequest, Timestamp: start, Seat: 0},
+			{Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(time.Second), Seat: 0, Data: map[string]any{"output": "a"}},
+			// Shares its timestamp with the one before: a page boundary between
+			// them must not skip either.
+			{Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(time.Second), Seat: 0, Data: map[string]any{"output": "b"}},
+			{Type: models.SessionEventTypeShell, Timestamp: start.Add(2 * time.Second), Seat: 0},
+			{Type: models.SessionEventTypeWindowChange, Timestamp: start.Add(3 * time.Second), Seat: 0},
+			{Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(4 * time.Second), Seat: 1, Data: map[string]any{"output": "other seat"}},
+			{Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(5 * time.Second), Seat: 0, Data: map[string]any{"output": "c"}},
+		}
+
+		for i := range events {
+			events[i].Session = string(sessionUID)
+		}
+
+		require.NoError(t, st.SessionEventsCreateMany(ctx, events))
+
+		var (
+			scanned []models.SessionEvent
+			cursor  store.SessionEventsCursor
+		)
+
+		for {
+			page, next, err := st.SessionEventsScan(ctx, sessionUID, 0, recording, cursor, 2)
+			require.NoError(t, err)
+
+			scanned = append(scanned, page...)
+			cursor = next
+
+			if len(page) < 2 {
+				break
+			}
+		}
+
+		require.Len(t, scanned, 5)
+		assert.Equal(t, models.SessionEventTypePtyRequest, scanned[0].Type)
+		assert.Equal(t, models.SessionEventTypeWindowChange, scanned[3].Type)
+		assert.Equal(t, map[string]any{"output": "c"}, scanned[4].Data)
+
+		outputs := []any{scanned[1].Data, scanned[2].Data}
+		assert.ElementsMatch(t, []any{map[string]any{"output": "a"}, map[string]any{"output": "b"}}, outputs)
+	})
+}
+
+func (s *Suite) TestSessionEventsScanBackward(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+
+	recording := []models.SessionEventType{models.SessionEventTypePtyRequest, models.SessionEventTypeWindowChange, models.SessionEventTypePtyOutput}
+
+	t.Run("succeeds when no events found", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		events, cursor, err := st.SessionEventsScanBackward(ctx, "nonexistent", 0, recording, store.SessionEventsCursor{}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+		assert.Equal(t, store.SessionEventsCursor{}, cursor)
+	})
+
+	t.Run("pages through the seat's events of the given types newest first", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		sessionUID := s.CreateSession(t, WithSessionUser("testuser"))
+
+		start := clock.Now().UTC().Truncate(time.Second)
+		events := []models.SessionEvent{
+			{Type: models.SessionEventTypePtyRequest, Timestamp: start, Seat: 0},
+			{Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(time.Second), Seat: 0, Data: map[string]any{"output": "a"}},
+			{Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(time.Second), Seat: 0, Data: map[string]any{"output": "b"}},
+			{Type: models.SessionEventTypeShell, Timestamp: start.Add(2 * time.Second), Seat: 0},
+			{Type: models.SessionEventTypeWindowChange, Timestamp: start.Add(3 * time.Second), Seat: 0},
+			{Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(4 * time.Second), Seat: 1, Data: map[string]any{"output": "other seat"}},
+			{Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(5 * time.Second), Seat: 0, Data: map[string]any{"output": "c"}},
+		}
+
+		for i := range events {
+			events[i].Session = string(sessionUID)
+		}
+
+		require.NoError(t, st.SessionEventsCreateMany(ctx, events))
+
+		var (
+			scanned []models.SessionEvent
+			cursor  store.SessionEventsCursor
+		)
+
+		for {
+			page, next, err := st.SessionEventsScanBackward(ctx, sessionUID, 0, recording, cursor, 2)
+			require.NoError(t, err)
+
+			scanned = append(scanned, page...)
+			cursor = next
+
+			if len(page) < 2 {
+				break
+			}
+		}
+
+		require.Len(t, scanned, 5)
+		assert.Equal(t, map[string]any{"output": "c"}, scanned[0].Data)
+		assert.Equal(t, models.SessionEventTypeWindowChange, scanned[1].Type)
+		assert.Equal(t, models.SessionEventTypePtyRequest, scanned[4].Type)
+
+		outputs := []any{scanned[2].Data, scanned[3].Data}
+		assert.ElementsMatch(t, []any{map[string]any{"output": "a"}, map[string]any{"output": "b"}}, outputs)
+	})
+}
+
 // TestSessionEventsDelete tests session events deletion
 func (s *Suite) TestSessionEventsDelete(t *testing.T) {
 	ctx := context.Background()
@@ -1080,3 +1280,155 @@ func (s *Suite) TestSessionCleanup(t *testing.T) {
 		assert.Equal(t, []string{string(uid)}, listUIDs(t))
 	})
 }
+
+func (s *Suite) TestSessionEventsSearch(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	sessionUID := s.CreateSession(t, WithSessionUser("root"))
+	otherUID := s.CreateSession(t)
+
+	session, err := st.SessionResolve(ctx, scope.NewUnbounded(reasonTestQueryMechanics), store.SessionUIDResolver, string(sessionUID))
+	require.NoError(t, err)
+
+	other, err := st.SessionResolve(ctx, scope.NewUnbounded(reasonTestQueryMechanics), store.SessionUIDResolver, string(otherUID))
+	require.NoError(t, err)
+	require.NotEqual(t, session.TenantID, other.TenantID)
+
+	start := clock.Now().UTC().Truncate(time.Second)
+
+	events := []models.SessionEvent{
+		{Session: string(sessionUID), Type: models.SessionEventTypePtyOutput, Timestamp: start, Data: map[string]any{"output": "\x1b[01;32mroot@db\x1b[0m:~# "}},
+		{Session: string(sessionUID), Type: models.SessionEventTypePtyOutput, Timestamp: start.Add(30 * time.Second), Data: map[string]any{"output": "rm -rf /var/lib/postgres\r\n"}},
+		{Session: string(sessionUID), Type: models.SessionEventTypeExec, Timestamp: start.Add(time.Hour), Seat: 1, Data: map[string]any{"command": "rm -rf /var/lib/postgres/data"}},
+		{Session: string(sessionUID), Type: models.SessionEventTypeCommand, Timestamp: start.Add(2 * time.Minute), Data: map[string]any{"command": "rm -rf /var/lib/postgres/wal", "interactive": true}},
+		// Not searched: only output and commands are.
+		{Session: string(sessionUID), Type: models.SessionEventTypeEnv, Timestamp: start, Data: map[string]any{"name": "rm -rf /var/lib/postgres"}},
+		{Session: string(otherUID), Type: models.SessionEventTypePtyOutput, Timestamp: start, Data: map[string]any{"output": "rm -rf /var/lib/postgres"}},
+	}
+
+	require.NoError(t, st.SessionEventsCreateMany(ctx, events))
+
+	t.Run("finds the output and commands of the namespace's sessions, newest first", func(t *testing.T) {
+		hits, count, err := st.SessionEventsSearch(ctx, scope.MustBounded(session.TenantID), store.SessionSearch{Phrase: "rm -rf /var/lib/postgres"})
+		require.NoError(t, err)
+
+		require.Equal(t, 3, count)
+		require.Len(t, hits, 3)
+
+		assert.Equal(t, string(sessionUID), hits[0].SessionUID)
+		assert.Equal(t, models.SessionEventTypeExec, hits[0].Type)
+		assert.Equal(t, 1, hits[0].Seat)
+		assert.Equal(t, "rm -rf /var/lib/postgres/data", hits[0].Snippet)
+
+		assert.Equal(t, models.SessionEventTypeCommand, hits[1].Type)
+		assert.Equal(t, "rm -rf /var/lib/postgres/wal", hits[1].Snippet)
+
+		assert.Equal(t, models.SessionEventTypePtyOutput, hits[2].Type)
+		assert.Equal(t, "root", hits[2].Username)
+		assert.Equal(t, session.DeviceUID, hits[2].DeviceUID)
+		assert.InDelta(t, 30, hits[2].Offset, 0.001)
+	})
+
+	t.Run("matches through escape sequences", func(t *testing.T) {
+		_, count, err := st.SessionEventsSearch(ctx, scope.MustBounded(session.TenantID), store.SessionSearch{Phrase: "root@db"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("matches the words in order only", func(t *testing.T) {
+		_, count, err := st.SessionEventsSearch(ctx, scope.MustBounded(session.TenantID), store.SessionSearch{Phrase: "/var/lib/postgres rm"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("bounds the matches in time", func(t *testing.T) {
+		hits, count, err := st.SessionEventsSearch(ctx, scope.MustBounded(session.TenantID), store.SessionSearch{
+			Phrase: "postgres",
+			Since:  start.Add(time.Second),
+			Until:  start.Add(time.Minute),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		assert.Equal(t, models.SessionEventTypePtyOutput, hits[0].Type)
+	})
+}
+
+func (s *Suite) TestSessionCommandsList(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	device := s.CreateDevice(t)
+	rootUID := s.CreateSession(t, WithSessionDevice(device), WithSessionUser("root"))
+	deployUID := s.CreateSession(t, WithSessionDevice(device), WithSessionUser("deploy"))
+	otherUID := s.CreateSession(t, WithSessionUser("root"))
+
+	session, err := st.SessionResolve(ctx, scope.NewUnbounded(reasonTestQueryMechanics), store.SessionUIDResolver, string(rootUID))
+	require.NoError(t, err)
+
+	start := clock.Now().UTC().Truncate(time.Second)
+	status := uint32(0)
+
+	command := func(uid models.UID, at time.Duration, line string) models.SessionEvent {
+		return models.SessionEvent{
+			Session:   string(uid),
+			Type:      models.SessionEventTypeCommand,
+			Timestamp: start.Add(at),
+			Data:      &models.SessionCommandLine{Command: line, Interactive: true, ExitStatus: &status},
+		}
+	}
+
+	require.NoError(t, st.SessionEventsCreateMany(ctx, []models.SessionEvent{
+		command(rootUID, 2*time.Second, "systemctl restart nginx"),
+		command(rootUID, time.Second, "vim /etc/nginx/nginx.conf"),
+		command(deployUID, 3*time.Second, "git pull"),
+		command(otherUID, 0, "reboot"),
+		// Not a command.
+		{Session: string(rootUID), Type: models.SessionEventTypeExec, Timestamp: start, Data: map[string]any{"command": "uptime"}},
+	}))
+
+	t.Run("lists a session's commands oldest first", func(t *testing.T) {
+		commands, count, err := st.SessionCommandsList(ctx, scope.MustBounded(session.TenantID), store.SessionCommandsFilter{SessionUID: rootUID})
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		assert.Equal(t, "vim /etc/nginx/nginx.conf", commands[0].Command)
+		assert.Equal(t, "systemctl restart nginx", commands[1].Command)
+		assert.Equal(t, "root", commands[1].Username)
+		assert.Equal(t, session.DeviceUID, commands[1].DeviceUID)
+		assert.True(t, commands[1].Interactive)
+		require.NotNil(t, commands[1].ExitStatus)
+		assert.Equal(t, uint32(0), *commands[1].ExitStatus)
+	})
+
+	t.Run("lists the namespace's commands newest first", func(t *testing.T) {
+		commands, count, err := st.SessionCommandsList(ctx, scope.MustBounded(session.TenantID), store.SessionCommandsFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		assert.Equal(t, "git pull", commands[0].Command)
+		assert.Equal(t, "vim /etc/nginx/nginx.conf", commands[2].Command)
+	})
+
+	t.Run("lists a device user's commands", func(t *testing.T) {
+		commands, count, err := st.SessionCommandsList(ctx, scope.MustBounded(session.TenantID), store.SessionCommandsFilter{
+			Username: "root",
+			Since:    start.Add(time.Second),
+			Until:    start.Add(2 * time.Second),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		assert.Equal(t, "vim /etc/nginx/nginx.conf", commands[0].Command)
+	})
+
+	t.Run("does not reach another namespace's session", func(t *testing.T) {
+		_, count, err := st.SessionCommandsList(ctx, scope.MustBounded(session.TenantID), store.SessionCommandsFilter{SessionUID: otherUID})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
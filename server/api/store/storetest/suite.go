@@ -38,10 +38,32 @@ func (s *Suite) Run(t *testing.T) {
 		s.TestDeviceConflicts(t)
 		s.TestDeviceUpdate(t)
 		s.TestDeviceHeartbeat(t)
+		s.TestDeviceListDisconnected(t)
 		s.TestDeviceDelete(t)
 		s.TestDeviceDeleteMany(t)
 	})
 
+	t.Run("AccessRequestStore", func(t *testing.T) {
+		s.TestAccessRequestTransition(t)
+	})
+
+	t.Run("AuditEventStore", func(t *testing.T) {
+		s.TestAuditEventCreate(t)
+		s.TestAuditEventDeleteBefore(t)
+	})
+
+	t.Run("WebhookStore", func(t *testing.T) {
+		s.TestWebhookListSubscribed(t)
+		s.TestWebhookDeliveryCreate(t)
+		s.TestWebhookDeliveryClaim(t)
+	})
+
+	t.Run("SCIMStore", func(t *testing.T) {
+		s.TestSCIMGroupCreate(t)
+		s.TestSCIMGroupUpdate(t)
+		s.TestSCIMGroupDelete(t)
+	})
+
 	t.Run("UserStore", func(t *testing.T) {
 		s.TestUserList(t)
 		s.TestUserResolve(t)
@@ -55,6 +77,7 @@ func (s *Suite) Run(t *testing.T) {
 		s.TestSessionList(t)
 		s.TestSessionResolve(t)
 		s.TestSessionCreate(t)
+		s.TestSessionListActive(t)
 		s.TestSessionUpdateDeviceUID(t)
 		s.TestSessionUpdate(t)
 		s.TestActiveSessionDelete(t)
@@ -62,6 +85,10 @@ func (s *Suite) Run(t *testing.T) {
 		s.TestActiveSessionUpdate(t)
 		s.TestSessionEventsCreate(t)
 		s.TestSessionEventsList(t)
+		s.TestSessionEventsScan(t)
+		s.TestSessionEventsScanBackward(t)
+		s.TestSessionEventsSearch(t)
+		s.TestSessionCommandsList(t)
 		s.TestSessionEventsDelete(t)
 		s.TestSessionCleanup(t)
 	})
@@ -175,6 +202,12 @@ func (s *Suite) Run(t *testing.T) {
 		s.TestScopeIsolationAccessPolicyResolve(t)
 		s.TestScopeIsolationSSHIdentityList(t)
 		s.TestScopeIsolationSSHIdentityResolve(t)
+		s.TestScopeIsolationSSHUserCAList(t)
+		s.TestScopeIsolationSSHUserCAResolve(t)
+		s.TestScopeIsolationAccessRequestList(t)
+		s.TestScopeIsolationAccessRequestResolve(t)
+		s.TestScopeIsolationAuditEventList(t)
+		s.TestScopeIsolationWebhookResolve(t)
 		s.TestScopeRejectsUnconstructedScope(t)
 	})
 }
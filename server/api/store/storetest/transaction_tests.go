@@ -84,4 +84,41 @@ func (s *Suite) TestWithTransaction(t *testing.T) {
 		assert.Equal(t, 1, count)
 		assert.Len(t, devices, 1)
 	})
+	t.Run("nested call joins the outer transaction", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		tenantID := s.CreateNamespace(t, WithNamespaceName("tx-nested"))
+
+		errIntentional := errors.New("intentional error")
+		err := st.WithTransaction(ctx, func(txCtx context.Context) error {
+			if err := st.WithTransaction(txCtx, func(innerCtx context.Context) error {
+				device := &models.Device{
+					UID:       fmt.Sprintf("%064x", time.Now().UnixNano()),
+					Name:      "tx-device",
+					TenantID:  tenantID,
+					Identity:  &models.DeviceIdentity{MAC: "aa:bb:cc:dd:ee:f3"},
+					Info:      &models.DeviceInfo{},
+					PublicKey: "-",
+					Status:    models.DeviceStatusAccepted,
+					CreatedAt: clock.Now(),
+					LastSeen:  clock.Now(),
+				}
+
+				_, err := st.DeviceCreate(innerCtx, device)
+
+				return err
+			}); err != nil {
+				return err
+			}
+
+			return errIntentional
+		})
+		assert.ErrorIs(t, err, errIntentional)
+
+		// The inner call's write is rolled back with the outer transaction.
+		devices, count, err := st.DeviceList(ctx, scope.NewUnbounded(reasonTestQueryMechanics), store.DeviceAcceptableIfNotAccepted)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+		assert.Empty(t, devices)
+	})
 }
@@ -118,6 +118,21 @@ func (s *Suite) TestUserResolve(t *testing.T) {
 		assert.Equal(t, userID, user.ID)
 		assert.Equal(t, models.UserStatusConfirmed, user.Status)
 	})
+
+	t.Run("succeeds resolving user by external ID", func(t *testing.T) {
+		require.NoError(t, s.provider.CleanDatabase(t))
+
+		s.CreateUser(t, WithUsername("local_user"))
+		userID := s.CreateUser(t, WithUsername("oidc_user"), WithExternalID(models.UserOriginOIDC, "subject-1"))
+
+		// Resolve by external ID
+		user, err := st.UserResolve(ctx, store.UserExternalIDResolver, "subject-1")
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.Equal(t, userID, user.ID)
+		assert.Equal(t, models.UserOriginOIDC, user.Origin)
+		assert.Equal(t, "subject-1", user.ExternalID)
+	})
 }
 
 func (s *Suite) TestUserCreate(t *testing.T) {
@@ -0,0 +1,137 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Suite) TestWebhookListSubscribed(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	tenantID := s.CreateNamespace(t)
+
+	for _, webhook := range []*models.Webhook{
+		{TenantID: tenantID, Name: "online", URL: "https://example.com/a", Secret: "0123456789abcdef", Events: []models.WebhookEventType{models.WebhookEventDeviceOnline}, Enabled: true},
+		{TenantID: tenantID, Name: "offline", URL: "https://example.com/b", Secret: "0123456789abcdef", Events: []models.WebhookEventType{models.WebhookEventDeviceOffline}, Enabled: true},
+		{TenantID: tenantID, Name: "disabled", URL: "https://example.com/c", Secret: "0123456789abcdef", Events: []models.WebhookEventType{models.WebhookEventDeviceOnline}, Enabled: false},
+	} {
+		_, err := st.WebhookCreate(ctx, webhook)
+		require.NoError(t, err)
+	}
+
+	webhooks, err := st.WebhookListSubscribed(ctx, scope.MustBounded(tenantID), models.WebhookEventDeviceOnline)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, "online", webhooks[0].Name)
+	assert.Equal(t, "0123456789abcdef", webhooks[0].Secret)
+}
+
+func (s *Suite) TestWebhookDeliveryCreate(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	tenantID := s.CreateNamespace(t)
+
+	webhookID, err := st.WebhookCreate(ctx, &models.Webhook{
+		TenantID: tenantID,
+		Name:     "on-call",
+		URL:      "https://example.com",
+		Secret:   "0123456789abcdef",
+		Events:   []models.WebhookEventType{models.WebhookEventDeviceOffline},
+		Enabled:  true,
+	})
+	require.NoError(t, err)
+
+	delivery := func() *models.WebhookDelivery {
+		return &models.WebhookDelivery{
+			WebhookID: webhookID,
+			TenantID:  tenantID,
+			EventID:   "6d8b9a0e-3f1c-4c2e-9a57-1f0e2d3c4b5a",
+			EventType: models.WebhookEventDeviceOffline,
+			Payload:   []byte(`{"id":"6d8b9a0e-3f1c-4c2e-9a57-1f0e2d3c4b5a"}`),
+			Status:    models.WebhookDeliveryPending,
+		}
+	}
+
+	original := delivery()
+	id, err := st.WebhookDeliveryCreate(ctx, original)
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	// The same event again is what a retried fan-out does.
+	_, err = st.WebhookDeliveryCreate(ctx, delivery())
+	assert.ErrorIs(t, err, store.ErrDuplicate)
+
+	// A replay carries the same event on purpose.
+	replay := delivery()
+	replay.ReplayOf = id
+	_, err = st.WebhookDeliveryCreate(ctx, replay)
+	require.NoError(t, err)
+
+	deliveries, count, err := st.WebhookDeliveryList(ctx, scope.MustBounded(tenantID), webhookID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, deliveries, 2)
+
+	resolved, err := st.WebhookDeliveryResolve(ctx, scope.MustBounded(tenantID), id)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original.Payload), string(resolved.Payload))
+}
+
+func (s *Suite) TestWebhookDeliveryClaim(t *testing.T) {
+	ctx := context.Background()
+	st := s.provider.Store()
+	require.NoError(t, s.provider.CleanDatabase(t))
+
+	tenantID := s.CreateNamespace(t)
+
+	webhookID, err := st.WebhookCreate(ctx, &models.Webhook{
+		TenantID: tenantID,
+		Name:     "on-call",
+		URL:      "https://example.com",
+		Secret:   "0123456789abcdef",
+		Events:   []models.WebhookEventType{models.WebhookEventDeviceOffline},
+		Enabled:  true,
+	})
+	require.NoError(t, err)
+
+	now := clock.Now().Truncate(time.Second)
+	id, err := st.WebhookDeliveryCreate(ctx, &models.WebhookDelivery{
+		WebhookID:     webhookID,
+		TenantID:      tenantID,
+		EventID:       "6d8b9a0e-3f1c-4c2e-9a57-1f0e2d3c4b5a",
+		EventType:     models.WebhookEventDeviceOffline,
+		Payload:       []byte(`{}`),
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	})
+	require.NoError(t, err)
+
+	due, err := st.WebhookDeliveryListDue(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	claimed, err := st.WebhookDeliveryClaim(ctx, id, now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// A second worker finding the same delivery due loses the race.
+	claimed, err = st.WebhookDeliveryClaim(ctx, id, now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	due, err = st.WebhookDeliveryListDue(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
@@ -15,6 +15,7 @@ type TransactionStore interface {
 	// WithTransaction executes a callback cb within a transaction, ensuring that a series of store
 	// operations are executed as a single unit, committing the changes when the callback returns nil.
 	// If any operation fails, the transaction is aborted, rolling back all operations and returning the
-	// error from the callback. It returns ErrTransactionFailed if the transaction cannot start.
+	// error from the callback. It returns ErrTransactionFailed if the transaction cannot start. A call
+	// nested in another transaction's callback joins that transaction instead of starting its own.
 	WithTransaction(ctx context.Context, cb TransactionCb) error
 }
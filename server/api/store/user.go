@@ -13,6 +13,9 @@ const (
 	UserIDResolver UserResolver = iota + 1
 	UserEmailResolver
 	UserUsernameResolver
+	// UserExternalIDResolver resolves a user by [models.User.ExternalID], their identifier at the
+	// identity provider they sign in through.
+	UserExternalIDResolver
 )
 
 type UserStore interface {
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type WebhookStore interface {
+	// WebhookCreate creates a new webhook and returns its id.
+	WebhookCreate(ctx context.Context, webhook *models.Webhook) (string, error)
+	// WebhookList retrieves the webhooks of a namespace.
+	WebhookList(ctx context.Context, sc scope.Scope, opts ...QueryOption) ([]models.Webhook, int, error)
+	// WebhookResolve retrieves a webhook by its id, scoped to a namespace.
+	WebhookResolve(ctx context.Context, sc scope.Scope, id string) (*models.Webhook, error)
+	// WebhookListSubscribed retrieves the enabled webhooks of a namespace subscribed to the event.
+	WebhookListSubscribed(ctx context.Context, sc scope.Scope, event models.WebhookEventType) ([]models.Webhook, error)
+	// WebhookUpdate updates a webhook. It returns [ErrNoDocuments] if none was found.
+	WebhookUpdate(ctx context.Context, webhook *models.Webhook) error
+	// WebhookDelete deletes a webhook along with its delivery log. It returns [ErrNoDocuments] if
+	// none was found.
+	WebhookDelete(ctx context.Context, webhook *models.Webhook) error
+
+	// WebhookDeliveryCreate adds a delivery to a webhook's log and returns its id. It returns
+	// [ErrDuplicate] if the event, other than as a replay, is already in the log.
+	WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) (string, error)
+	// WebhookDeliveryList retrieves a webhook's delivery log.
+	WebhookDeliveryList(ctx context.Context, sc scope.Scope, webhookID string, opts ...QueryOption) ([]models.WebhookDelivery, int, error)
+	// WebhookDeliveryResolve retrieves a delivery by its id, scoped to a namespace.
+	WebhookDeliveryResolve(ctx context.Context, sc scope.Scope, id string) (*models.WebhookDelivery, error)
+	// WebhookDeliveryListDue retrieves, across every namespace, up to limit pending deliveries whose
+	// next attempt is due at the given time, oldest first.
+	WebhookDeliveryListDue(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	// WebhookDeliveryClaim pushes a pending delivery's next attempt to until, but only while that
+	// attempt is due at now, and reports whether it did. The claim is what lets a single worker make
+	// an attempt: whoever else finds the delivery due sees it already moved.
+	WebhookDeliveryClaim(ctx context.Context, id string, now, until time.Time) (bool, error)
+	// WebhookDeliveryUpdate writes the outcome of an attempt: status, attempts, next attempt,
+	// response status, last error and when it was delivered.
+	WebhookDeliveryUpdate(ctx context.Context, delivery *models.WebhookDelivery) error
+	// WebhookDeliveryDeleteBefore deletes the deliveries of every namespace created before the
+	// given time, and returns how many it deleted.
+	WebhookDeliveryDeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -76,9 +76,11 @@ func TestOpenAPIValidationAllowsWebSocketUpgrades(t *testing.T) {
 	// The web terminal bridge upgrades through x/net/websocket, which panics rather than
 	// erroring when the writer cannot hijack. Registering it as production does keeps that
 	// path covered too.
-	e.GET(web.WebsocketSSHBridgeRoute, echo.WrapHandler(xwebsocket.Handler(func(conn *xwebsocket.Conn) {
-		conn.Close() //nolint:errcheck
-	})))
+	for _, path := range []string{web.WebsocketSSHBridgeRoute, web.WebsocketShadowRoute} {
+		e.GET(path, echo.WrapHandler(xwebsocket.Handler(func(conn *xwebsocket.Conn) {
+			conn.Close() //nolint:errcheck
+		})))
+	}
 
 	srv := httptest.NewServer(e)
 	t.Cleanup(srv.Close)
@@ -103,6 +105,7 @@ func TestOpenAPIValidationAllowsWebSocketUpgrades(t *testing.T) {
 		sshhttp.HandleConnectionV2Path,
 		sshhttp.HandleRevdialPath,
 		web.WebsocketSSHBridgeRoute,
+		web.WebsocketShadowRoute,
 	} {
 		t.Run("upgrade succeeds on "+path, func(t *testing.T) {
 			res, err := dial(t, path)
@@ -126,11 +129,16 @@ func TestOpenAPIValidationSkipper(t *testing.T) {
 		{sshhttp.HandleConnectionV2Path, true},
 		{sshhttp.HandleRevdialPath, true},
 		{web.WebsocketSSHBridgeRoute, true},
+		{web.WebsocketShadowRoute, true},
+		{"/api/stream", true},
+		{"/api/sessions/3f2a/recording", true},
 		{"/metrics", true},
 		{"/internal/auth", true},
 		// Sits under the bridge route but answers with a JSON body, so prefix matching
 		// would wrongly exempt it.
 		{web.WebSessionRoute, false},
+		{web.WebShadowRoute, false},
+		{"/api/sessions/3f2a", false},
 		{"/api/devices", false},
 		{"/api/namespaces", false},
 	}
@@ -17,8 +17,11 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/eventstream"
 	"github.com/shellhub-io/shellhub/pkg/worker"
 	"github.com/shellhub-io/shellhub/pkg/worker/asynq"
+	"github.com/shellhub-io/shellhub/server/api/pkg/grouprole"
+	"github.com/shellhub-io/shellhub/server/api/pkg/oidc"
 	"github.com/shellhub-io/shellhub/server/api/routes"
 	"github.com/shellhub-io/shellhub/server/api/routes/middleware"
 	"github.com/shellhub-io/shellhub/server/api/services"
@@ -27,7 +30,9 @@ import (
 	pgoptions "github.com/shellhub-io/shellhub/server/api/store/pg/options"
 	sshhttp "github.com/shellhub-io/shellhub/server/ssh/http"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/dialer"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/webhandoff"
+	"github.com/shellhub-io/shellhub/server/ssh/remote"
 	sshserver "github.com/shellhub-io/shellhub/server/ssh/server"
 	"github.com/shellhub-io/shellhub/server/ssh/web"
 	log "github.com/sirupsen/logrus"
@@ -79,6 +84,35 @@ type Env struct {
 	// commitment and the volume are both known, so it is the deployment that sets this rather
 	// than the binary assuming one. docker-compose.enterprise.yml does exactly that.
 	SessionRetentionDays int `env:"SHELLHUB_SESSION_RETENTION_DAYS,default=0"`
+
+	// AuditRetentionDays is how long an audit event is kept after it was recorded; 0, the
+	// default, keeps the audit log indefinitely, for the same reason as SessionRetentionDays.
+	AuditRetentionDays int `env:"SHELLHUB_AUDIT_RETENTION_DAYS,default=0"`
+
+	// OIDCIssuer is the issuer URL of the OpenID Connect provider users can sign in through.
+	// Leave empty to disable single sign-on.
+	OIDCIssuer       string `env:"SHELLHUB_OIDC_ISSUER,default="`
+	OIDCClientID     string `env:"SHELLHUB_OIDC_CLIENT_ID,default="`
+	OIDCClientSecret string `env:"SHELLHUB_OIDC_CLIENT_SECRET,default="`
+	// OIDCRedirectURL is the callback registered with the provider. Empty builds it from
+	// SHELLHUB_DOMAIN and SHELLHUB_AUTO_SSL.
+	OIDCRedirectURL string `env:"SHELLHUB_OIDC_REDIRECT_URL,default="`
+	// OIDCScopes are the comma separated scopes requested on top of "openid".
+	OIDCScopes string `env:"SHELLHUB_OIDC_SCOPES,default=email,profile"`
+	// OIDCGroupsClaim names the ID token claim listing the user's groups.
+	OIDCGroupsClaim string `env:"SHELLHUB_OIDC_GROUPS_CLAIM,default=groups"`
+	// OIDCGroupRoles maps the provider's groups to namespace roles, as semicolon separated
+	// "group=tenant:role" entries. The namespaces it names are kept in sync with the groups on
+	// every sign-in.
+	OIDCGroupRoles string `env:"SHELLHUB_OIDC_GROUP_ROLES,default="`
+
+	// SCIMToken is the bearer token the identity provider authenticates to the SCIM endpoint with.
+	// Leave empty to disable SCIM provisioning.
+	SCIMToken string `env:"SHELLHUB_SCIM_TOKEN,default="`
+	// SCIMGroupRoles maps the SCIM groups to namespace roles, in the same format as
+	// SHELLHUB_OIDC_GROUP_ROLES. The namespaces it names are kept in sync with the groups
+	// whenever the provider pushes a change.
+	SCIMGroupRoles string `env:"SHELLHUB_SCIM_GROUP_ROLES,default="`
 }
 
 // sshEnv is parsed with the SSH_ prefix, keeping the names the ssh service used.
@@ -105,8 +139,11 @@ type Server struct {
 	http        *http.Server
 	authn       *middleware.Authenticator
 	worker      worker.Server
+	tasks       worker.Client
+	events      eventstream.Stream
 	ssh         *sshserver.Server
 	heartbeater *services.DeviceHeartbeater
+	apiKeyUsage *services.APIKeyUsageRecorder
 }
 
 const (
@@ -115,6 +152,10 @@ const (
 	// heartbeaterDrainTimeout bounds how long shutdown waits for the pending
 	// device heartbeats to be written.
 	heartbeaterDrainTimeout = 10 * time.Second
+
+	// apiKeyUsageDrainTimeout bounds how long shutdown waits for the pending API
+	// key uses to be written.
+	apiKeyUsageDrainTimeout = 10 * time.Second
 )
 
 // Setup initializes all server components including database connections, cache, services, API routes, and background workers.
@@ -202,6 +243,30 @@ func (s *Server) Setup(ctx context.Context) error {
 
 	servicesOptions = append(servicesOptions, rpOpts...)
 
+	// The service submits background work, like webhook deliveries, through the
+	// same Redis the worker serves.
+	s.tasks, err = asynq.NewClient(s.env.RedisURI)
+	if err != nil {
+		return errors.Join(errors.New("failed to create the worker client"), err)
+	}
+
+	servicesOptions = append(servicesOptions, services.WithWorkerClient(s.tasks))
+
+	// Live events go through Redis too, so a client follows its namespace from
+	// whichever instance it is connected to.
+	s.events, err = eventstream.NewRedisStream(ctx, s.env.RedisURI)
+	if err != nil {
+		return errors.Join(errors.New("failed to create the event stream"), err)
+	}
+
+	servicesOptions = append(servicesOptions, services.WithEventStream(s.events))
+
+	// The requests the API keys authenticate are counted in batches, off the
+	// path of the requests themselves.
+	s.apiKeyUsage = services.NewAPIKeyUsageRecorder(store)
+
+	servicesOptions = append(servicesOptions, services.WithAPIKeyUsageRecorder(s.apiKeyUsage))
+
 	routerOptions, err := s.routerOptions()
 	if err != nil {
 		return err
@@ -226,6 +291,12 @@ func (s *Server) Setup(ctx context.Context) error {
 	s.worker.HandleCron(services.CronEphemeralCleanup, service.EphemeralCleanup(), asynq.Unique())
 	s.worker.HandleCron(services.CronEnrollmentCallbackCleanup, service.EnrollmentCallbackCleanup(), asynq.Unique())
 	s.worker.HandleCron(services.CronSSHApprovalCleanup, service.SSHApprovalCleanup(), asynq.Unique())
+	s.worker.HandleCron(services.CronAccessRequestExpiry, service.AccessRequestExpiry(), asynq.Unique())
+	s.worker.HandleCron(services.CronWebhookDeliveryRetry, service.WebhookDeliveryRetry(), asynq.Unique())
+	s.worker.HandleCron(services.CronWebhookDeliveryCleanup, service.WebhookDeliveryCleanup(), asynq.Unique())
+
+	s.worker.HandleTask(services.TaskWebhookEvent, service.WebhookEventFanout())
+	s.worker.HandleTask(services.TaskWebhookDelivery, service.WebhookDeliveryAttempt())
 
 	if retention := time.Duration(s.env.SessionRetentionDays) * 24 * time.Hour; retention > 0 {
 		s.worker.HandleCron(services.CronSessionCleanup, service.SessionCleanup(retention), asynq.Unique())
@@ -234,10 +305,15 @@ func (s *Server) Setup(ctx context.Context) error {
 		log.Warn("session retention disabled; sessions and their events are kept indefinitely")
 	}
 
+	if retention := time.Duration(s.env.AuditRetentionDays) * 24 * time.Hour; retention > 0 {
+		s.worker.HandleCron(services.CronAuditCleanup, service.AuditCleanup(retention), asynq.Unique())
+		log.WithField("days", s.env.AuditRetentionDays).Info("audit retention enabled")
+	}
+
 	// Apply any worker extensions registered by cloud/enterprise packages.
 	routes.ApplyWorkerExtensions(s.worker, store, cache)
 
-	s.heartbeater = services.NewDeviceHeartbeater(store)
+	s.heartbeater = services.NewDeviceHeartbeater(store, services.WithReconnectHandler(service.AnnounceDevicesOnline))
 
 	if err := s.setupSSH(service); err != nil {
 		return errors.Join(errors.New("failed to setup the ssh server"), err)
@@ -288,7 +364,7 @@ func reconcileInstanceBinding(ctx context.Context, st store.Store) error {
 // now live in the same process, so a second client would buy nothing. The
 // service is handed over for the same reason: the SSH side is migrating off the
 // loopback HTTP client and onto in-process calls.
-func (s *Server) setupSSH(service services.Service) error {
+func (s *Server) setupSSH(service *services.APIService) error {
 	env, err := envs.ParseWithPrefix[sshEnv]("SSH_")
 	if err != nil {
 		return err
@@ -296,6 +372,10 @@ func (s *Server) setupSSH(service services.Service) error {
 
 	d := dialer.NewDialer(service, s.heartbeater)
 
+	// Sessions whose authorization is revoked are closed through the agents' tunnels, which only
+	// the dialer holds.
+	s.worker.HandleTask(services.TaskSessionTerminate, service.SessionTerminate(d))
+
 	sshhttp.Register(s.router, s.authn, d, service, &sshhttp.Config{
 		RequireAcceptedTunnel: env.RequireAcceptedTunnel,
 	})
@@ -305,14 +385,22 @@ func (s *Server) setupSSH(service services.Service) error {
 	// the loopback dial claims it.
 	handoff := webhandoff.NewStore()
 
-	web.NewSSHServerBridge(s.router, s.authn, service, handoff)
+	// They share the seats open to shadows the same way: the SSH listener registers them, and
+	// the bridge attaches the members watching them.
+	shadows := shadow.NewRegistry()
+
+	web.NewSSHServerBridge(s.router, s.authn, service, handoff, shadows)
+
+	// Commands and file transfers an API key runs on a device dial the listener
+	// the same way, through the same handoff.
+	remote.Register(s.router, service, handoff)
 
 	if envs.IsDevelopment() {
 		runtime.SetBlockProfileRate(1)
 		pprof.Register(s.router)
 	}
 
-	s.ssh, err = sshserver.NewServer(d, service, handoff, &sshserver.Options{
+	s.ssh, err = sshserver.NewServer(d, service, handoff, shadows, &sshserver.Options{
 		ConnectTimeout:               env.ConnectTimeout,
 		AllowPublickeyAccessBelow060: env.AllowPublickeyAccessBelow060,
 		HostKeyFile:                  env.HostKeyFile,
@@ -362,10 +450,25 @@ func (s *Server) Shutdown() {
 
 	s.worker.Shutdown()
 
+	if s.tasks != nil {
+		s.tasks.Close() // nolint: errcheck
+	}
+
 	if s.http != nil {
 		s.http.Close() // nolint: errcheck
 	}
 
+	// Drained after the HTTP server closes, so no request can submit a use that
+	// the final batch would miss.
+	if s.apiKeyUsage != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), apiKeyUsageDrainTimeout)
+		defer cancel()
+
+		if err := s.apiKeyUsage.Shutdown(ctx); err != nil {
+			log.WithError(err).Warn("API key uses were still pending at shutdown")
+		}
+	}
+
 	s.ssh.Close() // nolint: errcheck
 
 	// Drained after the SSH listener closes, so no tunnel can submit a beat that
@@ -379,6 +482,11 @@ func (s *Server) Shutdown() {
 		}
 	}
 
+	// Closed last: the final heartbeats may still announce devices coming back.
+	if s.events != nil {
+		s.events.Close() // nolint: errcheck
+	}
+
 	log.Info("Server shutdown complete")
 }
 
@@ -402,9 +510,66 @@ func (s *Server) serviceOptions(ctx context.Context) ([]services.Option, error)
 		}
 	}
 
+	if s.env.OIDCIssuer != "" {
+		provider, err := s.oidcProvider(ctx)
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to initialize the OIDC provider"), err)
+		}
+
+		log.WithField("issuer", s.env.OIDCIssuer).Info("OIDC single sign-on enabled")
+
+		opts = append(opts, services.WithOIDCProvider(provider))
+	}
+
+	if s.env.SCIMToken != "" {
+		groupRoles, err := grouprole.Parse(s.env.SCIMGroupRoles)
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to parse the SCIM group role mapping"), err)
+		}
+
+		log.Info("SCIM provisioning enabled")
+
+		opts = append(opts, services.WithSCIM(s.env.SCIMToken, groupRoles))
+	}
+
 	return opts, nil
 }
 
+// oidcProvider discovers the OpenID Connect provider the environment configures.
+func (s *Server) oidcProvider(ctx context.Context) (*oidc.Provider, error) {
+	groupRoles, err := grouprole.Parse(s.env.OIDCGroupRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURL := s.env.OIDCRedirectURL
+	if redirectURL == "" {
+		scheme := "http"
+		if s.env.AutoSSL {
+			scheme = "https"
+		}
+
+		redirectURL = scheme + "://" + s.env.Domain + "/api" + routes.AuthOIDCCallbackURL
+	}
+
+	scopes := []string{}
+	for scope := range strings.SplitSeq(s.env.OIDCScopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return oidc.NewProvider(ctx, oidc.Config{
+		Issuer:       s.env.OIDCIssuer,
+		ClientID:     s.env.OIDCClientID,
+		ClientSecret: s.env.OIDCClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+		GroupsClaim:  s.env.OIDCGroupsClaim,
+		GroupRoles:   groupRoles,
+	})
+}
+
 // licenseEvaluatorOption initialises the license evaluator when a factory has been
 // registered by an enterprise/cloud package via services.RegisterLicenseEvaluator.
 // In Community Edition builds the factory is nil and an empty slice is returned.
@@ -489,8 +654,13 @@ func (s *Server) firewallEvaluatorOption(ctx context.Context, st store.Store, c
 // fails every agent tunnel and every web terminal - and with no tunnel there is no heartbeat,
 // so the whole fleet then ages out to offline.
 //
-// They match exactly rather than by prefix: WebSessionRoute sits under WebsocketSSHBridgeRoute
-// and does answer with a JSON body worth validating.
+// So is the event stream: it never ends while the client follows it, so capturing its response
+// would hold every event back in the validator's buffer. A recording export is no different in
+// kind, only finite.
+//
+// They match exactly rather than by prefix: WebSessionRoute sits under WebsocketSSHBridgeRoute,
+// as WebShadowRoute does under WebsocketShadowRoute, and both answer with a JSON body worth
+// validating.
 func openAPIValidationSkipper(ctx *echo.Context) bool {
 	path := ctx.Request().URL.Path
 
@@ -498,7 +668,15 @@ func openAPIValidationSkipper(ctx *echo.Context) bool {
 	case sshhttp.HandleConnectionV1Path,
 		sshhttp.HandleConnectionV2Path,
 		sshhttp.HandleRevdialPath,
-		web.WebsocketSSHBridgeRoute:
+		web.WebsocketSSHBridgeRoute,
+		web.WebsocketShadowRoute,
+		"/api" + routes.StreamEventsURL:
+		return true
+	}
+
+	// The recording export streams too, for as long as the recording is, and its path carries
+	// the session's UID.
+	if strings.HasPrefix(path, "/api/sessions/") && strings.HasSuffix(path, "/recording") {
 		return true
 	}
 
@@ -11,6 +11,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/scope"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/wsconnadapter"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/server/api/services"
 	"github.com/shellhub-io/shellhub/server/api/store"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/dialer"
@@ -116,10 +117,10 @@ func (h *Handlers) HandleSSHClose(c *echo.Context) error {
 	}
 
 	// The gateway only authenticates this route; closing a session is an
-	// administrative action, so enforce the permission here from the role it
+	// administrative action, so enforce the permission here from the identity it
 	// forwards. Without this, any observer, operator, API key or device token in
 	// the namespace could terminate any session.
-	if role := authorizer.RoleFromString(c.Request().Header.Get("X-Role")); !role.HasPermission(authorizer.SessionClose) {
+	if !gateway.Allows(c.Request().Header, authorizer.SessionClose) {
 		return c.NoContent(http.StatusForbidden)
 	}
 
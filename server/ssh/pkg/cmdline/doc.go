@@ -0,0 +1,14 @@
+// Package cmdline reconstructs the command lines typed into an interactive shell from what
+// crosses its terminal: the keystrokes the client sends and the output the shell prints back.
+//
+// The gateway sees keys, not the shell's line buffer, so the reconstruction models the
+// readline/emacs editing keys every common shell binds by default — cursor movement, the
+// kill and yank keys, bracketed paste — and flags a line as inexact when it used a key
+// whose effect depends on state the gateway cannot see, such as tab completion or history
+// recall.
+//
+// The output side tells the rest: whether a full-screen program owns the terminal, whether
+// the terminal echoed what was typed — a line typed without echo is most likely a password
+// and is never reported — and, when the shell marks its prompts the way terminal emulators'
+// shell integration does (OSC 133), how each command ended.
+package cmdline
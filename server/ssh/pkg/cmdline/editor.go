@@ -0,0 +1,391 @@
+package cmdline
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Line is a command line submitted with Enter.
+type Line struct {
+	Text string
+	// Inexact tells the line was edited with a key the editor cannot follow, such as tab
+	// completion or history recall, so what the shell ran may differ from Text.
+	Inexact bool
+	// Echoed tells the terminal printed something back while the line was typed. A line typed
+	// without any echo may still be echoed all at once after Enter, when it arrived in a
+	// single write, which only the output that follows can tell.
+	Echoed bool
+}
+
+type editorState int
+
+const (
+	editorGround editorState = iota
+	// editorEscape follows an ESC: the start of a sequence, or a meta (Alt) key.
+	editorEscape
+	// editorCSI is inside an ESC [ sequence, collecting its parameters.
+	editorCSI
+	// editorSS3 follows ESC O, which some terminals send for the cursor keys.
+	editorSS3
+)
+
+// Editor follows the keystrokes sent to a shell and replays their effect on a line buffer, the
+// way the shell's line editor would.
+//
+// It is not safe for concurrent use.
+type Editor struct {
+	line   []rune
+	cursor int
+	// killed is the text the last kill key removed, which yank puts back.
+	killed  []rune
+	inexact bool
+
+	// pendingEcho is set by every change to the line and cleared by output, which is then
+	// taken as its echo.
+	pendingEcho bool
+	echoed      bool
+
+	state  editorState
+	params []byte
+	// paste is set inside a bracketed paste, where keys are text.
+	paste bool
+	// partial holds a multibyte character cut across writes.
+	partial []byte
+}
+
+// Feed replays keystrokes, returning the lines they submitted. Blank lines are not returned.
+func (e *Editor) Feed(p []byte) []Line {
+	var lines []Line
+
+	data := p
+	if len(e.partial) > 0 {
+		data = append(e.partial, p...)
+		e.partial = nil
+	}
+
+	for len(data) > 0 {
+		b := data[0]
+
+		switch e.state {
+		case editorEscape:
+			data = data[1:]
+			e.escape(b)
+
+			continue
+		case editorCSI:
+			data = data[1:]
+			e.csi(b)
+
+			continue
+		case editorSS3:
+			data = data[1:]
+			e.state = editorGround
+			e.ss3(b)
+
+			continue
+		case editorGround:
+		}
+
+		if b >= utf8.RuneSelf {
+			if !utf8.FullRune(data) {
+				e.partial = append([]byte(nil), data...)
+
+				break
+			}
+
+			r, size := utf8.DecodeRune(data)
+			data = data[size:]
+
+			if r != utf8.RuneError {
+				e.insert(r)
+			}
+
+			continue
+		}
+
+		data = data[1:]
+
+		if line, ok := e.key(b); ok {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// Echo tells the editor the terminal printed something, which counts as the echo of the
+// changes made to the line since the last output.
+func (e *Editor) Echo() {
+	if e.pendingEcho {
+		e.echoed = true
+		e.pendingEcho = false
+	}
+}
+
+// Reset discards the line being typed and any sequence half-read.
+func (e *Editor) Reset() {
+	*e = Editor{killed: e.killed}
+}
+
+// key applies a single-byte key. It reports a line when the key submitted one.
+func (e *Editor) key(b byte) (Line, bool) {
+	if e.paste {
+		switch b {
+		case '\r', '\n':
+			e.insert('\n')
+		case '\t':
+			e.insert('\t')
+		case 0x1b:
+			// Only the end of the paste is expected here.
+			e.state = editorEscape
+		default:
+			if b >= 0x20 && b != 0x7f {
+				e.insert(rune(b))
+			}
+		}
+
+		return Line{}, false
+	}
+
+	switch {
+	case b == '\r' || b == '\n':
+		return e.submit()
+	case b == 0x1b:
+		e.state = editorEscape
+	case b == 0x7f || b == 0x08: // Backspace
+		e.delete(e.cursor-1, e.cursor)
+	case b == 0x01: // Ctrl-A
+		e.cursor = 0
+	case b == 0x05: // Ctrl-E
+		e.cursor = len(e.line)
+	case b == 0x02: // Ctrl-B
+		e.cursor = max(e.cursor-1, 0)
+	case b == 0x06: // Ctrl-F
+		e.cursor = min(e.cursor+1, len(e.line))
+	case b == 0x04: // Ctrl-D, which ends the shell instead on an empty line.
+		e.delete(e.cursor, e.cursor+1)
+	case b == 0x0b: // Ctrl-K
+		e.kill(e.cursor, len(e.line))
+	case b == 0x15: // Ctrl-U
+		e.kill(0, e.cursor)
+	case b == 0x17: // Ctrl-W, which stops at whitespace.
+		e.kill(e.wordBackward(unicode.IsSpace), e.cursor)
+	case b == 0x19: // Ctrl-Y
+		for _, r := range e.killed {
+			e.insert(r)
+		}
+	case b == 0x03: // Ctrl-C
+		e.clear()
+	case b == 0x07 || b == 0x0c || b == 0x00: // Ctrl-G, Ctrl-L and NUL leave the line as it is.
+	case b >= 0x20:
+		e.insert(rune(b))
+	default:
+		// Tab completion, history recall and search, transposition, undo: what they do
+		// depends on state only the shell has.
+		e.inexact = true
+	}
+
+	return Line{}, false
+}
+
+// escape handles the byte after an ESC.
+func (e *Editor) escape(b byte) {
+	e.state = editorGround
+
+	switch b {
+	case '[':
+		e.state = editorCSI
+		e.params = e.params[:0]
+	case 'O':
+		e.state = editorSS3
+	case 'b', 'B': // Alt-B
+		e.cursor = e.wordBackward(isWordSeparator)
+	case 'f', 'F': // Alt-F
+		e.cursor = e.wordForward()
+	case 'd', 'D': // Alt-D
+		e.kill(e.cursor, e.wordForward())
+	case 0x7f, 0x08: // Alt-Backspace
+		e.kill(e.wordBackward(isWordSeparator), e.cursor)
+	default:
+		e.inexact = true
+	}
+}
+
+// csi collects an ESC [ sequence and applies it once its final byte arrives.
+func (e *Editor) csi(b byte) {
+	switch {
+	case b >= 0x20 && b <= 0x3f:
+		// Parameters and intermediates; a runaway sequence is cut short rather than kept.
+		if len(e.params) < 16 {
+			e.params = append(e.params, b)
+		}
+
+		return
+	case b < 0x40 || b > 0x7e:
+		// Not a sequence after all.
+		e.state = editorGround
+
+		return
+	}
+
+	e.state = editorGround
+
+	params := string(e.params)
+	// A modifier, as in "1;5C" for Ctrl-Right, makes the arrows move by word.
+	_, modified := strings.CutPrefix(params, "1;")
+
+	if e.paste {
+		if b == '~' && params == "201" {
+			e.paste = false
+		}
+
+		return
+	}
+
+	switch b {
+	case 'C':
+		if modified {
+			e.cursor = e.wordForward()
+		} else {
+			e.cursor = min(e.cursor+1, len(e.line))
+		}
+	case 'D':
+		if modified {
+			e.cursor = e.wordBackward(isWordSeparator)
+		} else {
+			e.cursor = max(e.cursor-1, 0)
+		}
+	case 'H':
+		e.cursor = 0
+	case 'F':
+		e.cursor = len(e.line)
+	case 'I', 'O':
+		// Focus reports, when the terminal sends them.
+	case '~':
+		switch params {
+		case "1", "7":
+			e.cursor = 0
+		case "4", "8":
+			e.cursor = len(e.line)
+		case "3":
+			e.delete(e.cursor, e.cursor+1)
+		case "200":
+			e.paste = true
+		case "2", "201":
+		default:
+			e.inexact = true
+		}
+	default:
+		// Up and Down recall history, Shift-Tab completes.
+		e.inexact = true
+	}
+}
+
+// ss3 applies the cursor keys some terminals send as ESC O.
+func (e *Editor) ss3(b byte) {
+	switch b {
+	case 'C':
+		e.cursor = min(e.cursor+1, len(e.line))
+	case 'D':
+		e.cursor = max(e.cursor-1, 0)
+	case 'H':
+		e.cursor = 0
+	case 'F':
+		e.cursor = len(e.line)
+	case 'P', 'Q', 'R', 'S':
+		// F1 to F4, which a shell's line editor ignores.
+	default:
+		e.inexact = true
+	}
+}
+
+func (e *Editor) submit() (Line, bool) {
+	line := Line{
+		Text:    string(e.line),
+		Inexact: e.inexact,
+		Echoed:  e.echoed,
+	}
+
+	e.clear()
+
+	if strings.TrimSpace(line.Text) == "" {
+		return Line{}, false
+	}
+
+	return line, true
+}
+
+func (e *Editor) clear() {
+	e.line = e.line[:0]
+	e.cursor = 0
+	e.inexact = false
+	e.pendingEcho = false
+	e.echoed = false
+}
+
+func (e *Editor) insert(r rune) {
+	e.line = append(e.line, 0)
+	copy(e.line[e.cursor+1:], e.line[e.cursor:])
+	e.line[e.cursor] = r
+	e.cursor++
+	e.pendingEcho = true
+}
+
+// delete removes the runes in [from, to), clamped to the line.
+func (e *Editor) delete(from, to int) {
+	from, to = max(from, 0), min(to, len(e.line))
+	if from >= to {
+		return
+	}
+
+	e.line = append(e.line[:from], e.line[to:]...)
+	e.cursor = from
+	e.pendingEcho = true
+}
+
+// kill deletes like delete and keeps the text for yank.
+func (e *Editor) kill(from, to int) {
+	from, to = max(from, 0), min(to, len(e.line))
+	if from >= to {
+		return
+	}
+
+	e.killed = append(e.killed[:0], e.line[from:to]...)
+	e.delete(from, to)
+}
+
+// wordBackward returns where the word before the cursor starts, words being separated by
+// runes for which separator is true.
+func (e *Editor) wordBackward(separator func(rune) bool) int {
+	i := e.cursor
+	for i > 0 && separator(e.line[i-1]) {
+		i--
+	}
+
+	for i > 0 && !separator(e.line[i-1]) {
+		i--
+	}
+
+	return i
+}
+
+// wordForward returns where the word after the cursor ends.
+func (e *Editor) wordForward() int {
+	i := e.cursor
+	for i < len(e.line) && isWordSeparator(e.line[i]) {
+		i++
+	}
+
+	for i < len(e.line) && !isWordSeparator(e.line[i]) {
+		i++
+	}
+
+	return i
+}
+
+// isWordSeparator is what separates the words Alt-B, Alt-F and Alt-D move over: anything but
+// letters and digits.
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,147 @@
+package cmdline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditor(t *testing.T) {
+	cases := []struct {
+		description string
+		keys        []string
+		expected    []Line
+	}{
+		{
+			description: "submits the typed line",
+			keys:        []string{"l", "s", " ", "-", "l", "\r"},
+			expected:    []Line{{Text: "ls -l"}},
+		},
+		{
+			description: "skips blank lines",
+			keys:        []string{"\r", "  \r", "\r\n"},
+			expected:    nil,
+		},
+		{
+			description: "submits every line of a write",
+			keys:        []string{"cd /tmp\rpwd\r"},
+			expected:    []Line{{Text: "cd /tmp"}, {Text: "pwd"}},
+		},
+		{
+			description: "deletes with backspace",
+			keys:        []string{"lss", "\x7f", " /et", "\x08", "tc\r"},
+			expected:    []Line{{Text: "ls /etc"}},
+		},
+		{
+			description: "inserts at the cursor moved by the arrows",
+			keys:        []string{"echo wrld", "\x1b[D", "\x1b[D", "\x1b[D", "o", "\x1b[C", "\x1bOF", "!\r"},
+			expected:    []Line{{Text: "echo world!"}},
+		},
+		{
+			description: "moves to the start and end of the line",
+			keys:        []string{"ls", "\x01", "sudo ", "\x05", " -a", "\x1b[H", "#", "\x1b[4~", "\r"},
+			expected:    []Line{{Text: "#sudo ls -a"}},
+		},
+		{
+			description: "deletes under the cursor",
+			keys:        []string{"rmm", "\x02", "\x04", "\x1b[3~", " x\r"},
+			expected:    []Line{{Text: "rm x"}},
+		},
+		{
+			description: "kills and yanks",
+			keys:        []string{"echo", "\x17", "printf ", "\x19", "\r"},
+			expected:    []Line{{Text: "printf echo"}},
+		},
+		{
+			description: "kills to the end and to the start of the line",
+			keys:        []string{"echo a b", "\x02", "\x02", "\x0b", "\x15", "uname\r"},
+			expected:    []Line{{Text: "uname"}},
+		},
+		{
+			description: "moves and kills by word",
+			keys:        []string{"cp a.txt b.txt", "\x1bb", "\x1bb", "\x1bd", "c", "\x1bf", "\x1b\x7f", "md", "\x1b[1;5D", "\x1b[1;5D", "new_", "\r"},
+			expected:    []Line{{Text: "cp a.txt new_c.md"}},
+		},
+		{
+			description: "discards the line on Ctrl-C",
+			keys:        []string{"rm -rf /", "\x03", "ls\r"},
+			expected:    []Line{{Text: "ls"}},
+		},
+		{
+			description: "takes a bracketed paste as text",
+			keys:        []string{"\x1b[200~for i in 1 2; do\recho $i\tdone\x1b[201~", "\r"},
+			expected:    []Line{{Text: "for i in 1 2; do\necho $i\tdone"}},
+		},
+		{
+			description: "flags tab completion as inexact",
+			keys:        []string{"cat /etc/pass", "\t", "\r", "ls\r"},
+			expected:    []Line{{Text: "cat /etc/pass", Inexact: true}, {Text: "ls"}},
+		},
+		{
+			description: "flags history recall as inexact",
+			keys:        []string{"\x1b[A", "\r", "\x1bOA", " x\r", "\x12", "ssh\r"},
+			expected:    []Line{{Text: " x", Inexact: true}, {Text: "ssh", Inexact: true}},
+		},
+		{
+			description: "keeps multibyte characters cut across writes",
+			keys:        []string{"echo \xc3", "\xa7\xe2\x9c", "\x93\r"},
+			expected:    []Line{{Text: "echo ç✓"}},
+		},
+		{
+			description: "follows sequences cut across writes",
+			keys:        []string{"ab", "\x1b", "[", "D", "_\r"},
+			expected:    []Line{{Text: "a_b"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var (
+				editor Editor
+				lines  []Line
+			)
+
+			for _, keys := range tc.keys {
+				lines = append(lines, editor.Feed([]byte(keys))...)
+			}
+
+			assert.Equal(t, tc.expected, lines)
+		})
+	}
+}
+
+func TestEditorEcho(t *testing.T) {
+	t.Run("takes output after a change as its echo", func(t *testing.T) {
+		var editor Editor
+
+		assert.Empty(t, editor.Feed([]byte("l")))
+		editor.Echo()
+		assert.Equal(t, []Line{{Text: "ls", Echoed: true}}, editor.Feed([]byte("s\r")))
+	})
+
+	t.Run("does not take output before any change as an echo", func(t *testing.T) {
+		var editor Editor
+
+		editor.Echo()
+		assert.Equal(t, []Line{{Text: "hunter2"}}, editor.Feed([]byte("hunter2\r")))
+	})
+
+	t.Run("starts every line unechoed", func(t *testing.T) {
+		var editor Editor
+
+		editor.Feed([]byte("ls"))
+		editor.Echo()
+		editor.Feed([]byte("\r"))
+
+		assert.Equal(t, []Line{{Text: "hunter2"}}, editor.Feed([]byte("hunter2\r")))
+	})
+
+	t.Run("forgets the line on reset", func(t *testing.T) {
+		var editor Editor
+
+		editor.Feed([]byte(":wq\x1b["))
+		editor.Reset()
+
+		assert.Equal(t, []Line{{Text: "ls"}}, editor.Feed([]byte("ls\r")))
+	})
+}
@@ -0,0 +1,181 @@
+package cmdline
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// MarkKind is the kind of a shell integration mark, as the shell announces it with
+// OSC 133 ; <kind>.
+type MarkKind byte
+
+const (
+	MarkPromptStarted   MarkKind = 'A'
+	MarkCommandStarted  MarkKind = 'B'
+	MarkCommandExecuted MarkKind = 'C'
+	MarkCommandFinished MarkKind = 'D'
+)
+
+// Mark is a shell integration mark found in the output.
+type Mark struct {
+	Kind MarkKind
+	// ExitStatus is the status a finished command exited with, when the shell told it.
+	ExitStatus *uint32
+}
+
+type screenState int
+
+const (
+	screenGround screenState = iota
+	screenEscape
+	// screenIntermediate is inside an ESC sequence with intermediates, such as a character
+	// set designation.
+	screenIntermediate
+	screenCSI
+	screenOSC
+	// screenOSCEscape follows an ESC inside an OSC, which ends it when followed by a backslash.
+	screenOSCEscape
+)
+
+// maxOSC bounds how much of an OSC is kept. The marks are short; a clipboard or a hyperlink
+// sequence is not, and is of no interest.
+const maxOSC = 64
+
+// Screen follows the output of a terminal for what tells how the keystrokes sent to it are
+// read.
+//
+// It is not safe for concurrent use.
+type Screen struct {
+	state  screenState
+	params []byte
+	osc    []byte
+	// fullscreen is set while a program has switched to the alternate screen, as editors and
+	// pagers do, so keys are theirs rather than the shell's.
+	fullscreen bool
+}
+
+// Feed reads output, returning its printable text and the marks in it.
+func (s *Screen) Feed(p []byte) ([]byte, []Mark) {
+	var (
+		text  []byte
+		marks []Mark
+	)
+
+	for _, b := range p {
+		switch s.state {
+		case screenGround:
+			switch {
+			case b == 0x1b:
+				s.state = screenEscape
+			case b >= 0x20 && b != 0x7f:
+				text = append(text, b)
+			}
+		case screenEscape:
+			switch {
+			case b == '[':
+				s.state = screenCSI
+				s.params = s.params[:0]
+			case b == ']':
+				s.state = screenOSC
+				s.osc = s.osc[:0]
+			case b >= 0x20 && b <= 0x2f:
+				s.state = screenIntermediate
+			default:
+				s.state = screenGround
+			}
+		case screenIntermediate:
+			if b < 0x20 || b > 0x2f {
+				s.state = screenGround
+			}
+		case screenCSI:
+			switch {
+			case b >= 0x20 && b <= 0x3f:
+				if len(s.params) < 32 {
+					s.params = append(s.params, b)
+				}
+			case b >= 0x40 && b <= 0x7e:
+				s.state = screenGround
+				s.mode(b)
+			default:
+				s.state = screenGround
+			}
+		case screenOSC:
+			switch b {
+			case 0x07:
+				s.state = screenGround
+
+				if mark, ok := s.mark(); ok {
+					marks = append(marks, mark)
+				}
+			case 0x1b:
+				s.state = screenOSCEscape
+			default:
+				if len(s.osc) < maxOSC {
+					s.osc = append(s.osc, b)
+				}
+			}
+		case screenOSCEscape:
+			s.state = screenGround
+
+			if b == '\\' {
+				if mark, ok := s.mark(); ok {
+					marks = append(marks, mark)
+				}
+			}
+		}
+	}
+
+	return text, marks
+}
+
+// Fullscreen tells whether a program has the terminal's alternate screen.
+func (s *Screen) Fullscreen() bool {
+	return s.fullscreen
+}
+
+// mode follows the switches to and from the alternate screen.
+func (s *Screen) mode(final byte) {
+	if final != 'h' && final != 'l' {
+		return
+	}
+
+	params, ok := bytes.CutPrefix(s.params, []byte("?"))
+	if !ok {
+		return
+	}
+
+	for param := range bytes.SplitSeq(params, []byte(";")) {
+		switch string(param) {
+		case "1049", "1047", "47":
+			s.fullscreen = final == 'h'
+		}
+	}
+}
+
+// mark parses the OSC just read as a shell integration mark.
+func (s *Screen) mark() (Mark, bool) {
+	payload, ok := bytes.CutPrefix(s.osc, []byte("133;"))
+	if !ok || len(payload) == 0 {
+		return Mark{}, false
+	}
+
+	mark := Mark{Kind: MarkKind(payload[0])}
+
+	switch mark.Kind {
+	case MarkPromptStarted, MarkCommandStarted, MarkCommandExecuted:
+	case MarkCommandFinished:
+		// "D;<status>", possibly followed by options of its own.
+		if field, ok := bytes.CutPrefix(payload[1:], []byte(";")); ok {
+			field, _, _ = bytes.Cut(field, []byte(";"))
+
+			if status, err := strconv.ParseUint(string(field), 10, 32); err == nil {
+				value := uint32(status)
+				mark.ExitStatus = &value
+			}
+		}
+	default:
+		return Mark{}, false
+	}
+
+	return mark, true
+}
@@ -0,0 +1,89 @@
+package cmdline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreen(t *testing.T) {
+	status := func(v uint32) *uint32 { return &v }
+
+	cases := []struct {
+		description string
+		output      []string
+		text        string
+		marks       []Mark
+		fullscreen  bool
+	}{
+		{
+			description: "keeps the printable text",
+			output:      []string{"\x1b[01;32muser@host\x1b[0m:~$ \x1b(Bls\r\n"},
+			text:        "user@host:~$ ls",
+		},
+		{
+			description: "reads the marks ended by BEL or ST",
+			output:      []string{"\x1b]133;D;0\x07\x1b]133;A\x1b\\$ \x1b]133;B\x07ls\r\n\x1b]133;C\x07"},
+			text:        "$ ls",
+			marks: []Mark{
+				{Kind: MarkCommandFinished, ExitStatus: status(0)},
+				{Kind: MarkPromptStarted},
+				{Kind: MarkCommandStarted},
+				{Kind: MarkCommandExecuted},
+			},
+		},
+		{
+			description: "reads a finished mark without or with options after the status",
+			output:      []string{"\x1b]133;D\x07\x1b]133;D;130;aid=1\x07"},
+			marks: []Mark{
+				{Kind: MarkCommandFinished},
+				{Kind: MarkCommandFinished, ExitStatus: status(130)},
+			},
+		},
+		{
+			description: "reads marks cut across writes",
+			output:      []string{"\x1b]13", "3;D;", "2\x1b", "\\"},
+			marks:       []Mark{{Kind: MarkCommandFinished, ExitStatus: status(2)}},
+		},
+		{
+			description: "ignores other OSC sequences whatever their length",
+			output:      []string{"\x1b]0;title\x07\x1b]52;c;" + string(make([]byte, 256)) + "\x07ok"},
+			text:        "ok",
+		},
+		{
+			description: "follows the switch to the alternate screen",
+			output:      []string{"\x1b[?1049h\x1b[22;0;0t"},
+			fullscreen:  true,
+		},
+		{
+			description: "follows the switch back",
+			output:      []string{"\x1b[?1049h", "\x1b[?1l\x1b[?1049l"},
+			fullscreen:  false,
+		},
+		{
+			description: "follows the older alternate screen modes",
+			output:      []string{"\x1b[?25;47h"},
+			fullscreen:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var (
+				screen Screen
+				text   []byte
+				marks  []Mark
+			)
+
+			for _, output := range tc.output {
+				out, m := screen.Feed([]byte(output))
+				text = append(text, out...)
+				marks = append(marks, m...)
+			}
+
+			assert.Equal(t, tc.text, string(text))
+			assert.Equal(t, tc.marks, marks)
+			assert.Equal(t, tc.fullscreen, screen.Fullscreen())
+		})
+	}
+}
@@ -105,6 +105,17 @@ func (t *Dialer) DialTo(ctx context.Context, tenant string, uid string, target T
 	return handshake(ctx, conn, version, target)
 }
 
+// CloseSession asks the device's agent to close one of its SSH sessions, as a member closing it
+// from the console does. The agent acts on the request alone, so the stream is not kept open.
+func (t *Dialer) CloseSession(ctx context.Context, tenant, device, session string) error {
+	conn, err := t.DialTo(ctx, tenant, device, SSHCloseTarget{SessionID: session})
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
 // handshake runs the target's bootstrap under a deadline and hands back a
 // connection with that deadline cleared, ready for streaming. An agent that
 // accepts the stream but never answers fails here instead of parking the
@@ -0,0 +1,356 @@
+// Package shadow lets a member of the namespace watch a terminal someone else is using, and
+// drive it when the seat freezes or needs a hand.
+//
+// A seat with a pty registers here for as long as its data pipe runs. The web terminal bridge
+// attaches shadows to it: a shadow receives the seat's last screenful and everything printed
+// after it, and the keys of a read-write shadow reach the device as if the seat's own client
+// had typed them. The bridge and the gateway are the same process, so this lives in memory,
+// as the web handoff does.
+package shadow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Mode is how a shadow attaches to a seat.
+type Mode string
+
+const (
+	// ModeReadOnly only watches the seat.
+	ModeReadOnly Mode = "read-only"
+	// ModeReadWrite also types into it, alongside its own client.
+	ModeReadWrite Mode = "read-write"
+)
+
+// Valid tells whether the mode is one a shadow can attach with.
+func (m Mode) Valid() bool {
+	return m == ModeReadOnly || m == ModeReadWrite
+}
+
+var (
+	ErrSeatNotFound = errors.New("the session has no seat with a terminal to shadow")
+	ErrReadOnly     = errors.New("the shadow is read-only")
+	ErrDetached     = errors.New("the shadow is detached from the seat")
+)
+
+const (
+	// scrollback bounds the output a seat keeps for a shadow attaching mid-session, so it does
+	// not open on a blank screen. It is about a screenful of a busy terminal.
+	scrollback = 16 * 1024
+	// backlog bounds the frames waiting for a shadow to take them. A shadow that falls this far
+	// behind is detached rather than slowing the seat down: the seat's own client comes first.
+	backlog = 256
+)
+
+// Observer is the member behind a shadow.
+type Observer struct {
+	UserID string
+	// Name is how the seat's client is told who is watching.
+	Name string
+	Mode Mode
+}
+
+// Terminal is what a seat gives its shadows access to.
+type Terminal struct {
+	// Input is the device's side of the seat, where a read-write shadow's keys go.
+	Input io.Writer
+	// Typed is called with the keys a read-write shadow sent to Input, so they are followed
+	// as the seat's own client's are.
+	Typed func(observer Observer, p []byte)
+	// Notice is the seat's client, told when a shadow attaches or detaches.
+	Notice io.Writer
+	// Record is called when a shadow attaches, and when it detaches, so the session keeps
+	// track of who watched it.
+	Record func(observer Observer, started bool)
+	// Cols and Rows are the terminal's size when the seat registers.
+	Cols, Rows uint32
+}
+
+// Frame is what a shadow receives: output, or the seat's size when it changes.
+type Frame struct {
+	Output []byte
+	// Cols and Rows are set when the frame carries no output.
+	Cols, Rows uint32
+}
+
+// Seat is a terminal open to shadows.
+type Seat struct {
+	registry *Registry
+	key      key
+	terminal Terminal
+
+	mu      sync.Mutex
+	closed  bool
+	shadows map[*Shadow]struct{}
+	// screen is the tail of the output, which a new shadow starts from.
+	screen []byte
+}
+
+// Write gives the seat's output to its shadows. It never blocks, nor fails.
+func (s *Seat) Write(p []byte) (int, error) {
+	s.mu.Lock()
+
+	if s.closed {
+		s.mu.Unlock()
+
+		return len(p), nil
+	}
+
+	s.screen = append(s.screen, p...)
+	if len(s.screen) > scrollback {
+		// Cut at a line, not in the middle of a sequence.
+		tail := s.screen[len(s.screen)-scrollback:]
+		if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+			tail = tail[i+1:]
+		}
+
+		s.screen = append(s.screen[:0], tail...)
+	}
+
+	lagging := s.broadcast(Frame{Output: bytes.Clone(p)})
+
+	s.mu.Unlock()
+
+	for _, shadow := range lagging {
+		shadow.Detach()
+	}
+
+	return len(p), nil
+}
+
+// Resize tells the seat's shadows the terminal's new size.
+func (s *Seat) Resize(cols, rows uint32) {
+	s.mu.Lock()
+
+	if s.closed {
+		s.mu.Unlock()
+
+		return
+	}
+
+	s.terminal.Cols, s.terminal.Rows = cols, rows
+
+	lagging := s.broadcast(Frame{Cols: cols, Rows: rows})
+
+	s.mu.Unlock()
+
+	for _, shadow := range lagging {
+		shadow.Detach()
+	}
+}
+
+// broadcast queues a frame for every shadow, returning those too far behind to take it.
+func (s *Seat) broadcast(frame Frame) []*Shadow {
+	var lagging []*Shadow
+
+	for shadow := range s.shadows {
+		select {
+		case shadow.frames <- frame:
+		default:
+			lagging = append(lagging, shadow)
+		}
+	}
+
+	return lagging
+}
+
+// Close takes the seat off the registry and ends its shadows. The seat's client is gone by
+// then, so it is not told.
+func (s *Seat) Close() {
+	s.registry.remove(s.key, s)
+
+	s.mu.Lock()
+
+	if s.closed {
+		s.mu.Unlock()
+
+		return
+	}
+
+	s.closed = true
+
+	shadows := make([]*Shadow, 0, len(s.shadows))
+	for shadow := range s.shadows {
+		shadows = append(shadows, shadow)
+	}
+
+	s.mu.Unlock()
+
+	for _, shadow := range shadows {
+		shadow.detach(false)
+	}
+}
+
+// notice writes a line on the seat's client, on a line of its own. The observer's name is the
+// only text in it that the member chose, so it is stripped of what the terminal would interpret.
+func (s *Seat) notice(observer Observer, action string) {
+	if s.terminal.Notice == nil {
+		return
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+
+		return r
+	}, observer.Name)
+
+	fmt.Fprintf(s.terminal.Notice, "\r\n\x1b[7m[ShellHub] %s %s.\x1b[0m\r\n", name, action) //nolint:errcheck
+}
+
+// Shadow is a member attached to a seat.
+type Shadow struct {
+	seat     *Seat
+	observer Observer
+	frames   chan Frame
+	once     sync.Once
+}
+
+// Observer returns who the shadow is.
+func (s *Shadow) Observer() Observer {
+	return s.observer
+}
+
+// Frames returns the seat's output and size changes as they happen. It is closed once the
+// shadow is detached.
+func (s *Shadow) Frames() <-chan Frame {
+	return s.frames
+}
+
+// Write sends keys to the seat's device, when the shadow is read-write.
+func (s *Shadow) Write(p []byte) (int, error) {
+	if s.observer.Mode != ModeReadWrite {
+		return 0, ErrReadOnly
+	}
+
+	s.seat.mu.Lock()
+	_, attached := s.seat.shadows[s]
+	s.seat.mu.Unlock()
+
+	if !attached {
+		return 0, ErrDetached
+	}
+
+	n, err := s.seat.terminal.Input.Write(p)
+	if n > 0 && s.seat.terminal.Typed != nil {
+		s.seat.terminal.Typed(s.observer, p[:n])
+	}
+
+	return n, err
+}
+
+// Detach takes the shadow off its seat, telling the seat's client it is no longer watched. It
+// is safe to call more than once.
+func (s *Shadow) Detach() {
+	s.detach(true)
+}
+
+func (s *Shadow) detach(notify bool) {
+	s.once.Do(func() {
+		s.seat.mu.Lock()
+		delete(s.seat.shadows, s)
+		close(s.frames)
+		s.seat.mu.Unlock()
+
+		if notify {
+			s.seat.notice(s.observer, "stopped watching this session")
+		}
+
+		if s.seat.terminal.Record != nil {
+			s.seat.terminal.Record(s.observer, false)
+		}
+	})
+}
+
+type key struct {
+	session string
+	seat    int
+}
+
+// Registry holds the seats open to shadows. It is safe for concurrent use.
+type Registry struct {
+	mu    sync.Mutex
+	seats map[key]*Seat
+}
+
+func NewRegistry() *Registry {
+	return &Registry{seats: make(map[key]*Seat)}
+}
+
+// Register opens a session's seat to shadows, until the seat is closed.
+func (r *Registry) Register(session string, seat int, terminal Terminal) *Seat {
+	s := &Seat{
+		registry: r,
+		key:      key{session: session, seat: seat},
+		terminal: terminal,
+		shadows:  make(map[*Shadow]struct{}),
+	}
+
+	r.mu.Lock()
+	r.seats[s.key] = s
+	r.mu.Unlock()
+
+	return s
+}
+
+// Attach attaches an observer to a session's seat. The first frames the shadow receives are the
+// seat's size and its last screenful.
+func (r *Registry) Attach(session string, seat int, observer Observer) (*Shadow, error) {
+	r.mu.Lock()
+	s, ok := r.seats[key{session: session, seat: seat}]
+	r.mu.Unlock()
+
+	if !ok {
+		return nil, ErrSeatNotFound
+	}
+
+	shadow := &Shadow{
+		seat:     s,
+		observer: observer,
+		frames:   make(chan Frame, backlog),
+	}
+
+	s.mu.Lock()
+
+	if s.closed {
+		s.mu.Unlock()
+
+		return nil, ErrSeatNotFound
+	}
+
+	shadow.frames <- Frame{Cols: s.terminal.Cols, Rows: s.terminal.Rows}
+	if len(s.screen) > 0 {
+		shadow.frames <- Frame{Output: bytes.Clone(s.screen)}
+	}
+
+	// Recorded before the shadow can be detached, so its start never follows its end.
+	if s.terminal.Record != nil {
+		s.terminal.Record(observer, true)
+	}
+
+	s.shadows[shadow] = struct{}{}
+
+	s.mu.Unlock()
+
+	s.notice(observer, fmt.Sprintf("is watching this session (%s)", observer.Mode))
+
+	return shadow, nil
+}
+
+func (r *Registry) remove(k key, seat *Seat) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A seat number is not reused within a session, but a stale close must not take the place of
+	// a newer registration either way.
+	if r.seats[k] == seat {
+		delete(r.seats, k)
+	}
+}
@@ -0,0 +1,218 @@
+package shadow_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// record collects what a seat records about its shadows.
+type record struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *record) add(observer shadow.Observer, started bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if started {
+		r.events = append(r.events, "started "+observer.UserID+" "+string(observer.Mode))
+	} else {
+		r.events = append(r.events, "stopped "+observer.UserID)
+	}
+}
+
+func (r *record) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.events...)
+}
+
+// frames drains what the shadow received so far.
+func frames(s *shadow.Shadow) []shadow.Frame {
+	var received []shadow.Frame
+
+	for {
+		select {
+		case frame, ok := <-s.Frames():
+			if !ok {
+				return received
+			}
+
+			received = append(received, frame)
+		default:
+			return received
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	observer := shadow.Observer{UserID: "user-id", Name: "alice", Mode: shadow.ModeReadOnly}
+
+	t.Run("fails to attach to a seat not registered", func(t *testing.T) {
+		registry := shadow.NewRegistry()
+		registry.Register("session-uid", 0, shadow.Terminal{})
+
+		_, err := registry.Attach("session-uid", 1, observer)
+		assert.ErrorIs(t, err, shadow.ErrSeatNotFound)
+
+		_, err = registry.Attach("other-uid", 0, observer)
+		assert.ErrorIs(t, err, shadow.ErrSeatNotFound)
+	})
+
+	t.Run("fails to attach to a seat closed", func(t *testing.T) {
+		registry := shadow.NewRegistry()
+		registry.Register("session-uid", 0, shadow.Terminal{}).Close()
+
+		_, err := registry.Attach("session-uid", 0, observer)
+		assert.ErrorIs(t, err, shadow.ErrSeatNotFound)
+	})
+
+	t.Run("starts from the seat's size and last screenful", func(t *testing.T) {
+		registry := shadow.NewRegistry()
+		seat := registry.Register("session-uid", 0, shadow.Terminal{Cols: 80, Rows: 24})
+
+		seat.Write([]byte("$ ls\r\n")) //nolint:errcheck
+		seat.Resize(120, 40)
+
+		s, err := registry.Attach("session-uid", 0, observer)
+		require.NoError(t, err)
+
+		seat.Write([]byte("a.txt\r\n")) //nolint:errcheck
+
+		assert.Equal(t, []shadow.Frame{
+			{Cols: 120, Rows: 40},
+			{Output: []byte("$ ls\r\n")},
+			{Output: []byte("a.txt\r\n")},
+		}, frames(s))
+	})
+
+	t.Run("keeps only the tail of the output, cut at a line", func(t *testing.T) {
+		registry := shadow.NewRegistry()
+		seat := registry.Register("session-uid", 0, shadow.Terminal{})
+
+		seat.Write([]byte(strings.Repeat("x", 20*1024) + "\r\nlast line")) //nolint:errcheck
+
+		s, err := registry.Attach("session-uid", 0, observer)
+		require.NoError(t, err)
+
+		received := frames(s)
+		require.Len(t, received, 2)
+		assert.Equal(t, "last line", string(received[1].Output))
+	})
+
+	t.Run("tells the seat's client and records who watches it", func(t *testing.T) {
+		var (
+			notice bytes.Buffer
+			events record
+		)
+
+		registry := shadow.NewRegistry()
+		registry.Register("session-uid", 0, shadow.Terminal{Notice: &notice, Record: events.add})
+
+		s, err := registry.Attach("session-uid", 0, shadow.Observer{UserID: "user-id", Name: "al\x1b]0;x\x07ice", Mode: shadow.ModeReadWrite})
+		require.NoError(t, err)
+
+		s.Detach()
+		s.Detach()
+
+		assert.Equal(t, "\r\n\x1b[7m[ShellHub] al]0;xice is watching this session (read-write).\x1b[0m\r\n"+
+			"\r\n\x1b[7m[ShellHub] al]0;xice stopped watching this session.\x1b[0m\r\n", notice.String())
+		assert.Equal(t, []string{"started user-id read-write", "stopped user-id"}, events.list())
+
+		frames(s)
+		_, open := <-s.Frames()
+		assert.False(t, open)
+	})
+
+	t.Run("sends a read-write shadow's keys to the device", func(t *testing.T) {
+		var (
+			input bytes.Buffer
+			typed []string
+		)
+
+		registry := shadow.NewRegistry()
+		registry.Register("session-uid", 0, shadow.Terminal{
+			Input: &input,
+			Typed: func(observer shadow.Observer, p []byte) {
+				typed = append(typed, observer.UserID+" "+string(p))
+			},
+		})
+
+		s, err := registry.Attach("session-uid", 0, shadow.Observer{UserID: "user-id", Mode: shadow.ModeReadWrite})
+		require.NoError(t, err)
+
+		_, err = s.Write([]byte("\x03"))
+		require.NoError(t, err)
+		assert.Equal(t, "\x03", input.String())
+		assert.Equal(t, []string{"user-id \x03"}, typed)
+
+		s.Detach()
+
+		_, err = s.Write([]byte("ls\r"))
+		assert.ErrorIs(t, err, shadow.ErrDetached)
+		assert.Equal(t, "\x03", input.String())
+		assert.Len(t, typed, 1)
+	})
+
+	t.Run("refuses a read-only shadow's keys", func(t *testing.T) {
+		var input bytes.Buffer
+
+		registry := shadow.NewRegistry()
+		registry.Register("session-uid", 0, shadow.Terminal{Input: &input})
+
+		s, err := registry.Attach("session-uid", 0, observer)
+		require.NoError(t, err)
+
+		_, err = s.Write([]byte("ls\r"))
+		assert.ErrorIs(t, err, shadow.ErrReadOnly)
+		assert.Empty(t, input.String())
+	})
+
+	t.Run("detaches a shadow too far behind", func(t *testing.T) {
+		var events record
+
+		registry := shadow.NewRegistry()
+		seat := registry.Register("session-uid", 0, shadow.Terminal{Record: events.add})
+
+		s, err := registry.Attach("session-uid", 0, observer)
+		require.NoError(t, err)
+
+		for range 1024 {
+			seat.Write([]byte("y\r\n")) //nolint:errcheck
+		}
+
+		received := frames(s)
+		assert.Less(t, len(received), 1024)
+		assert.Equal(t, []string{"started user-id read-only", "stopped user-id"}, events.list())
+	})
+
+	t.Run("ends the shadows when the seat closes, without telling its client", func(t *testing.T) {
+		var (
+			notice bytes.Buffer
+			events record
+		)
+
+		registry := shadow.NewRegistry()
+		seat := registry.Register("session-uid", 0, shadow.Terminal{Notice: &notice, Record: events.add})
+
+		s, err := registry.Attach("session-uid", 0, observer)
+		require.NoError(t, err)
+
+		notice.Reset()
+		seat.Close()
+
+		frames(s)
+		_, open := <-s.Frames()
+		assert.False(t, open)
+		assert.Empty(t, notice.String())
+		assert.Equal(t, []string{"started user-id read-only", "stopped user-id"}, events.list())
+	})
+}
@@ -36,6 +36,13 @@ type Data struct {
 	// UserID is the logged-in account, set only in identity mode. Legacy web
 	// sessions authenticate with a device credential and leave it empty.
 	UserID string
+	// APIKey is the name of the API key running a command or a file transfer
+	// through the API, which dials the same way the web terminal does. Empty for
+	// web terminal sessions.
+	APIKey string
+	// TenantID is the namespace the API key belongs to, so the session refuses a
+	// device outside it. Set only with APIKey.
+	TenantID string
 }
 
 // Store holds the pending handoffs. It is safe for concurrent use.
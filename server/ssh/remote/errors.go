@@ -0,0 +1,22 @@
+package remote
+
+import "errors"
+
+var (
+	ErrAPIKeyRequired         = errors.New("only an API key may run commands or transfer files on a device")
+	ErrForbidden              = errors.New("connecting to devices is not allowed for this role")
+	ErrDeviceNotFound         = errors.New("failed to find the device")
+	ErrIdentityAccessRequired = errors.New("the namespace must use the identity access mode")
+	ErrAccessDenied           = errors.New("access to the device has been denied")
+	ErrConnect                = errors.New("failed to connect to device")
+	ErrSession                = errors.New("failed to open a session on the device")
+)
+
+var (
+	ErrFileNotFound   = errors.New("failed to find the file on the device")
+	ErrFilePermission = errors.New("the login is not allowed to access the file")
+	ErrFileDirectory  = errors.New("the path is a directory")
+	ErrFileTooLarge   = errors.New("the file is larger than 1 MiB")
+	ErrFileEncoding   = errors.New("the content does not match its encoding")
+	ErrFileMode       = errors.New("the mode must be octal permission bits, like 0644")
+)
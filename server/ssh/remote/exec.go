@@ -0,0 +1,170 @@
+package remote
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// defaultExecTimeout is how long a command runs when the request does not
+	// say, in seconds.
+	defaultExecTimeout = 30
+	// defaultExecOutput is how much of each output stream is kept when the
+	// request does not say, in bytes.
+	defaultExecOutput = 64 * 1024
+)
+
+// ExecRequest is the command an API key runs on a device.
+type ExecRequest struct {
+	UID string `param:"uid" validate:"required"`
+	// Login is the device user the command runs as.
+	Login string `json:"login" validate:"required"`
+	// Command runs without a terminal, through the login's shell.
+	Command string `json:"command" validate:"required,max=4096"`
+	// Timeout is how long the command may run, in seconds. It defaults to 30, up
+	// to 300.
+	Timeout int `json:"timeout" validate:"min=0,max=300"`
+	// MaxOutput is how many bytes of stdout and of stderr are kept. It defaults
+	// to 64 KiB, up to 1 MiB.
+	MaxOutput int `json:"max_output" validate:"min=0,max=1048576"`
+}
+
+// ExecResult is what the command did.
+type ExecResult struct {
+	// Session is the UID of the session the command ran in.
+	Session string `json:"session"`
+	// ExitCode is the command's exit status, or -1 when it did not report one.
+	ExitCode int    `json:"exit_code"`
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	// Truncated is set when an output stream went past MaxOutput.
+	Truncated bool `json:"truncated"`
+	// TimedOut is set when the command was stopped at its timeout.
+	TimedOut bool `json:"timed_out"`
+}
+
+// cappedBuffer keeps the first limit bytes written to it and drops the rest,
+// still accepting them so the command is never blocked on its output.
+type cappedBuffer struct {
+	mu        sync.Mutex
+	buffer    bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	kept := p
+	if room := b.limit - b.buffer.Len(); len(kept) > room {
+		kept = kept[:max(room, 0)]
+		b.truncated = true
+	}
+
+	b.buffer.Write(kept)
+
+	return len(p), nil
+}
+
+func (b *cappedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buffer.String()
+}
+
+func (b *cappedBuffer) Truncated() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.truncated
+}
+
+// HandleExec runs a command on a device and answers with its output once it
+// exits or its timeout stops it.
+func (h *Handlers) HandleExec(c *echo.Context) error {
+	req := new(ExecRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if req.Timeout == 0 {
+		req.Timeout = defaultExecTimeout
+	}
+
+	if req.MaxOutput == 0 {
+		req.MaxOutput = defaultExecOutput
+	}
+
+	t, err := h.resolve(c, req.UID)
+	if err != nil {
+		return err
+	}
+
+	conn, err := h.dial(c.Request().Context(), t, req.Login)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close() //nolint:errcheck
+
+	session, err := conn.NewSession()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, ErrSession.Error())
+	}
+
+	defer session.Close() //nolint:errcheck
+
+	stdout := &cappedBuffer{limit: req.MaxOutput}
+	stderr := &cappedBuffer{limit: req.MaxOutput}
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Run(req.Command)
+	}()
+
+	result := &ExecResult{Session: conn.session, ExitCode: -1}
+
+	timer := time.NewTimer(time.Duration(req.Timeout) * time.Second)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		var exit *gossh.ExitError
+		switch {
+		case err == nil:
+			result.ExitCode = 0
+		case errors.As(err, &exit):
+			result.ExitCode = exit.ExitStatus()
+		default:
+			log.WithError(err).WithField("session", conn.session).Debug("the command ended without an exit status")
+		}
+	case <-timer.C:
+		result.TimedOut = true
+
+		// Closing the connection ends the session on the device, and with it the
+		// command.
+		conn.Client.Close() //nolint:errcheck
+		<-done
+	}
+
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	result.Truncated = stdout.Truncated() || stderr.Truncated()
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,232 @@
+package remote
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pkg/sftp"
+)
+
+// maxFileSize is the largest file read or written through the API, in bytes.
+// Anything bigger belongs to an SFTP client.
+const maxFileSize = 1024 * 1024
+
+const (
+	// EncodingUTF8 carries the content as it is. A read uses it whenever the
+	// file is valid UTF-8.
+	EncodingUTF8 = "utf-8"
+	// EncodingBase64 carries binary content.
+	EncodingBase64 = "base64"
+)
+
+// FileReadRequest is the file an API key reads from a device.
+type FileReadRequest struct {
+	UID string `param:"uid" validate:"required"`
+	// Login is the device user the file is read as.
+	Login string `query:"login" validate:"required"`
+	Path  string `query:"path" validate:"required"`
+}
+
+// FileWriteRequest is the file an API key writes to a device. An existing
+// file is replaced.
+type FileWriteRequest struct {
+	UID string `param:"uid" validate:"required"`
+	// Login is the device user the file is written as.
+	Login    string `json:"login" validate:"required"`
+	Path     string `json:"path" validate:"required"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding" validate:"omitempty,oneof=utf-8 base64"`
+	// Mode is the file's permission bits in octal, like "0644". The device's
+	// default applies when it is empty.
+	Mode string `json:"mode"`
+}
+
+// File is a file on a device.
+type File struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	// Mode is the file's permission bits in octal.
+	Mode string `json:"mode"`
+	// Encoding and Content are set only when the file was read.
+	Encoding string `json:"encoding,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+// fileError turns an SFTP failure into the answer the caller can act on.
+func fileError(err error) error {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return echo.NewHTTPError(http.StatusNotFound, ErrFileNotFound.Error())
+	case errors.Is(err, fs.ErrPermission):
+		return echo.NewHTTPError(http.StatusForbidden, ErrFilePermission.Error())
+	default:
+		return echo.NewHTTPError(http.StatusBadGateway, ErrSession.Error())
+	}
+}
+
+// HandleReadFile reads a file from a device.
+func (h *Handlers) HandleReadFile(c *echo.Context) error {
+	req := new(FileReadRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	t, err := h.resolve(c, req.UID)
+	if err != nil {
+		return err
+	}
+
+	conn, err := h.dial(c.Request().Context(), t, req.Login)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close() //nolint:errcheck
+
+	client, err := sftp.NewClient(conn.Client)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, ErrSession.Error())
+	}
+
+	defer client.Close() //nolint:errcheck
+
+	file, err := client.Open(req.Path)
+	if err != nil {
+		return fileError(err)
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	info, err := file.Stat()
+	if err != nil {
+		return fileError(err)
+	}
+
+	if info.IsDir() {
+		return echo.NewHTTPError(http.StatusBadRequest, ErrFileDirectory.Error())
+	}
+
+	if info.Size() > maxFileSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, ErrFileTooLarge.Error())
+	}
+
+	// The size is only what the device reported when asked; a file still growing
+	// is cut at the limit rather than trusted.
+	content, err := io.ReadAll(io.LimitReader(file, maxFileSize+1))
+	if err != nil {
+		return fileError(err)
+	}
+
+	if len(content) > maxFileSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, ErrFileTooLarge.Error())
+	}
+
+	result := &File{
+		Path:     req.Path,
+		Size:     int64(len(content)),
+		Mode:     "0" + strconv.FormatUint(uint64(info.Mode().Perm()), 8),
+		Encoding: EncodingUTF8,
+		Content:  string(content),
+	}
+
+	if !utf8.Valid(content) {
+		result.Encoding = EncodingBase64
+		result.Content = base64.StdEncoding.EncodeToString(content)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// HandleWriteFile writes a file to a device.
+func (h *Handlers) HandleWriteFile(c *echo.Context) error {
+	req := new(FileWriteRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	content := []byte(req.Content)
+	if req.Encoding == EncodingBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(req.Content)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, ErrFileEncoding.Error())
+		}
+
+		content = decoded
+	}
+
+	if len(content) > maxFileSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, ErrFileTooLarge.Error())
+	}
+
+	var mode os.FileMode
+	if req.Mode != "" {
+		bits, err := strconv.ParseUint(req.Mode, 8, 32)
+		if err != nil || bits > uint64(fs.ModePerm) {
+			return echo.NewHTTPError(http.StatusBadRequest, ErrFileMode.Error())
+		}
+
+		mode = os.FileMode(bits)
+	}
+
+	t, err := h.resolve(c, req.UID)
+	if err != nil {
+		return err
+	}
+
+	conn, err := h.dial(c.Request().Context(), t, req.Login)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close() //nolint:errcheck
+
+	client, err := sftp.NewClient(conn.Client)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, ErrSession.Error())
+	}
+
+	defer client.Close() //nolint:errcheck
+
+	file, err := client.OpenFile(req.Path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return fileError(err)
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	if _, err := file.Write(content); err != nil {
+		return fileError(err)
+	}
+
+	if req.Mode != "" {
+		if err := file.Chmod(mode); err != nil {
+			return fileError(err)
+		}
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return fileError(err)
+	}
+
+	return c.JSON(http.StatusOK, &File{
+		Path: req.Path,
+		Size: info.Size(),
+		Mode: "0" + strconv.FormatUint(uint64(info.Mode().Perm()), 8),
+	})
+}
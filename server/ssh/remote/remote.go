@@ -0,0 +1,198 @@
+// Package remote runs commands and transfers files on devices for API keys.
+//
+// It is an SSH client living inside the SSH server, like the web terminal
+// bridge: every request dials the local listener, so it goes through the very
+// same handshake, Access Policies and session recording as a person logging in.
+// The API key the request authenticated with is parked in the handoff store
+// under the synthetic username the dial uses, and the session on the other side
+// of the loopback authorizes that key, records it as the session's principal,
+// and reaches the agent with the server-minted ephemeral key.
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/shellhub-io/shellhub/server/api/pkg/authctx"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/banner"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/magickey"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/webhandoff"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// ExecRoute runs a non-interactive command on a device.
+	ExecRoute = "/api/devices/:uid/exec"
+	// FilesRoute reads (GET) and writes (PUT) a file on a device over SFTP.
+	FilesRoute = "/api/devices/:uid/files"
+)
+
+// dialTimeout bounds the loopback handshake. It covers reaching the agent, but
+// not the command itself, which has its own timeout.
+const dialTimeout = 30 * time.Second
+
+// Handlers serves the routes. Addr is the SSH listener the requests dial.
+type Handlers struct {
+	Service services.Service
+	Handoff *webhandoff.Store
+	Addr    string
+}
+
+// Register adds the routes to the API's router. They are registered at the
+// root rather than under the API group, as closing a session is, and enforce
+// the DeviceConnect permission and the key's device scope themselves from the
+// identity the authenticator resolves.
+func Register(router *echo.Echo, service services.Service, handoff *webhandoff.Store) *Handlers {
+	handlers := &Handlers{
+		Service: service,
+		Handoff: handoff,
+		Addr:    "localhost:2222",
+	}
+
+	router.POST(ExecRoute, handlers.HandleExec)
+	router.GET(FilesRoute, handlers.HandleReadFile)
+	router.PUT(FilesRoute, handlers.HandleWriteFile)
+
+	return handlers
+}
+
+// target is the device a request acts on, and who it acts for.
+type target struct {
+	device *models.Device
+	apiKey string
+	ip     string
+}
+
+// resolve checks the request may act on the device and returns it. Only an API
+// key may, since an API key is what Access Policies name for these sessions; a
+// person opens a terminal instead.
+func (h *Handlers) resolve(c *echo.Context, uid string) (*target, error) {
+	ctx := c.Request().Context()
+
+	origin, ok := authctx.OriginFrom(ctx)
+	if !ok || origin.Actor.Type != models.AuditActorAPIKey {
+		return nil, echo.NewHTTPError(http.StatusForbidden, ErrAPIKeyRequired.Error())
+	}
+
+	if !gateway.Allows(c.Request().Header, authorizer.DeviceConnect) {
+		return nil, echo.NewHTTPError(http.StatusForbidden, ErrForbidden.Error())
+	}
+
+	tenant := c.Request().Header.Get("X-Tenant-ID")
+
+	sc, err := scope.NewBounded(tenant)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusForbidden, ErrForbidden.Error())
+	}
+
+	device, err := h.Service.GetDevice(ctx, sc, models.UID(uid))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, ErrDeviceNotFound.Error())
+	}
+
+	// A device outside the key's tag scope is as unknown to it as one outside the namespace.
+	if !gateway.ReachesDevice(c.Request().Header, device) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, ErrDeviceNotFound.Error())
+	}
+
+	namespace, err := h.Service.GetNamespace(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	if !namespace.Settings.IsIdentityAccess() {
+		return nil, echo.NewHTTPError(http.StatusForbidden, ErrIdentityAccessRequired.Error())
+	}
+
+	return &target{device: device, apiKey: origin.Actor.Name, ip: c.RealIP()}, nil
+}
+
+// connection is an SSH connection to a device, opened for an API key.
+type connection struct {
+	*gossh.Client
+	// session is the UID of the session the connection opened.
+	session string
+	stop    func() bool
+}
+
+func (c *connection) Close() error {
+	c.stop()
+
+	return c.Client.Close()
+}
+
+// dial opens an SSH connection to the device as login, for the target's API
+// key. It is closed when ctx is done, so an abandoned request releases the
+// device at once.
+func (h *Handlers) dial(ctx context.Context, t *target, login string) (*connection, error) {
+	signer, err := gossh.NewSignerFromKey(magickey.GetReference())
+	if err != nil {
+		return nil, err
+	}
+
+	// The handshake has nowhere to carry the device, the caller's address or the
+	// API key, so they are parked under the username about to be dialled and
+	// claimed by the session on the other side of the loopback.
+	user := fmt.Sprintf("%s@%s", login, uuid.Generate())
+	h.Handoff.Put(user, webhandoff.Data{
+		Device:   string(t.device.UID),
+		IP:       t.ip,
+		APIKey:   t.apiKey,
+		TenantID: t.device.TenantID,
+	})
+
+	var refused error
+	client, err := gossh.Dial("tcp", h.Addr, &gossh.ClientConfig{ //nolint:exhaustruct
+		User:            user,
+		Auth:            []gossh.AuthMethod{gossh.PublicKeys(signer)},
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(), //nolint:gosec
+		Timeout:         dialTimeout,
+		BannerCallback: func(message string) error {
+			if message == "" {
+				return nil
+			}
+
+			// Every banner the gateway sends a key that cannot reach the device is
+			// terminal; which one tells a denial from an unreachable device.
+			if kind, _ := banner.Classify(message); kind == banner.KindAccessDenied {
+				refused = ErrAccessDenied
+			} else {
+				refused = ErrConnect
+			}
+
+			return refused
+		},
+	})
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{"device": t.device.UID, "api_key": t.apiKey}).
+			Debug("failed to dial the device for an API key")
+
+		if errors.Is(refused, ErrAccessDenied) {
+			return nil, echo.NewHTTPError(http.StatusForbidden, ErrAccessDenied.Error())
+		}
+
+		return nil, echo.NewHTTPError(http.StatusBadGateway, ErrConnect.Error())
+	}
+
+	conn := &connection{Client: client}
+	if ok, reply, err := client.SendRequest("session-uid@shellhub.io", true, nil); err == nil && ok {
+		conn.session = string(reply)
+	}
+
+	conn.stop = context.AfterFunc(ctx, func() {
+		client.Close() //nolint:errcheck
+	})
+
+	return conn, nil
+}
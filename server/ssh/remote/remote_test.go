@@ -0,0 +1,203 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/api/pkg/authctx"
+	"github.com/shellhub-io/shellhub/server/api/pkg/echo/handlers"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	servicemocks "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/shellhub-io/shellhub/server/api/store"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/webhandoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTenant = "00000000-0000-4000-0000-000000000000"
+	testDevice = "0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+func newTestServer(t *testing.T, service services.Service) *echo.Echo {
+	t.Helper()
+
+	e := echo.New()
+	e.Binder = handlers.NewBinder()
+	e.Validator = handlers.NewValidator()
+	e.HTTPErrorHandler = handlers.NewErrors(nil)
+
+	Register(e, service, webhandoff.NewStore())
+
+	return e
+}
+
+// exec posts a command as the actor, with the role and API key scope the
+// authenticator would resolve for it.
+func exec(e *echo.Echo, actor *models.AuditActor, role string, scope map[string]string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/devices/"+testDevice+"/exec", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", testTenant)
+	req.Header.Set("X-Role", role)
+	for name, value := range scope {
+		req.Header.Set(name, value)
+	}
+
+	if actor != nil {
+		req = req.WithContext(authctx.WithOrigin(context.Background(), authctx.Origin{Actor: *actor}))
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestHandleExecRefusals(t *testing.T) {
+	apiKey := &models.AuditActor{Type: models.AuditActorAPIKey, ID: "ci", Name: "ci"}
+	command := `{"login":"root","command":"uptime"}`
+
+	tests := []struct {
+		description     string
+		actor           *models.AuditActor
+		role            string
+		scope           map[string]string
+		body            string
+		setupMock       func(*servicemocks.MockService)
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			description:    "refuses a request without a command",
+			actor:          apiKey,
+			role:           authorizer.RoleAdministrator.String(),
+			body:           `{"login":"root"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "refuses a timeout past the limit",
+			actor:          apiKey,
+			role:           authorizer.RoleAdministrator.String(),
+			body:           `{"login":"root","command":"uptime","timeout":301}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:     "refuses a person",
+			actor:           &models.AuditActor{Type: models.AuditActorUser, ID: "user1"},
+			role:            authorizer.RoleOwner.String(),
+			body:            command,
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: ErrAPIKeyRequired.Error(),
+		},
+		{
+			description:     "refuses a role that cannot connect to devices",
+			actor:           apiKey,
+			role:            "",
+			body:            command,
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: ErrForbidden.Error(),
+		},
+		{
+			description: "refuses a device outside the namespace",
+			actor:       apiKey,
+			role:        authorizer.RoleAdministrator.String(),
+			body:        command,
+			setupMock: func(m *servicemocks.MockService) {
+				m.EXPECT().
+					GetDevice(mock.Anything, mock.Anything, models.UID(testDevice)).
+					Return(nil, services.NewErrDeviceNotFound(models.UID(testDevice), store.ErrNoDocuments)).
+					Once()
+			},
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: ErrDeviceNotFound.Error(),
+		},
+		{
+			description: "refuses a device outside the key's tag scope",
+			actor:       apiKey,
+			role:        authorizer.RoleAdministrator.String(),
+			scope:       map[string]string{"X-API-Key-Tags": "production"},
+			body:        command,
+			setupMock: func(m *servicemocks.MockService) {
+				m.EXPECT().
+					GetDevice(mock.Anything, mock.Anything, models.UID(testDevice)).
+					Return(&models.Device{UID: testDevice, TenantID: testTenant}, nil). //nolint:exhaustruct
+					Once()
+			},
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: ErrDeviceNotFound.Error(),
+		},
+		{
+			description:     "refuses a key whose permission list leaves out connecting",
+			actor:           apiKey,
+			role:            authorizer.RoleAdministrator.String(),
+			scope:           map[string]string{"X-API-Key-Permissions": "device:details"},
+			body:            command,
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: ErrForbidden.Error(),
+		},
+		{
+			description: "refuses a namespace in the legacy access mode",
+			actor:       apiKey,
+			role:        authorizer.RoleAdministrator.String(),
+			body:        command,
+			setupMock: func(m *servicemocks.MockService) {
+				m.EXPECT().
+					GetDevice(mock.Anything, mock.Anything, models.UID(testDevice)).
+					Return(&models.Device{UID: testDevice, TenantID: testTenant}, nil). //nolint:exhaustruct
+					Once()
+				m.EXPECT().
+					GetNamespace(mock.Anything, testTenant).
+					Return(&models.Namespace{TenantID: testTenant, Settings: &models.NamespaceSettings{SSHAccessMode: models.SSHAccessModeLegacy}}, nil). //nolint:exhaustruct
+					Once()
+			},
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: ErrIdentityAccessRequired.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			service := servicemocks.NewMockService(t)
+			if tc.setupMock != nil {
+				tc.setupMock(service)
+			}
+
+			rec := exec(newTestServer(t, service), tc.actor, tc.role, tc.scope, tc.body)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code, rec.Body.String())
+			if tc.expectedMessage != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestCappedBuffer(t *testing.T) {
+	buffer := &cappedBuffer{limit: 5}
+
+	n, err := buffer.Write([]byte("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.False(t, buffer.Truncated())
+
+	// Whatever does not fit is dropped, yet reported as written so the command
+	// keeps running.
+	n, err = buffer.Write([]byte("defgh"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.True(t, buffer.Truncated())
+
+	n, err = buffer.Write(bytes.Repeat([]byte("x"), 10))
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+
+	assert.Equal(t, "abcde", buffer.String())
+}
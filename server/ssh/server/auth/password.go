@@ -33,7 +33,7 @@ func PasswordHandler(ctx gliderssh.Context, passwd string) bool {
 	// Identity mode has no password login at all: the identity is an SSH key, and
 	// the web terminal presents its own browser-held one through the public-key
 	// handler like any other client.
-	if sess.IsIdentityMode() {
+	if sess.IsIdentityMode() || sess.APIKey != "" {
 		logger.Info("password authentication is disabled in identity access mode")
 
 		return false
@@ -13,6 +13,12 @@ import (
 // identity mode resolves the key to an account, legacy mode uses the key as
 // presented, and neither writes anything.
 func resolveKeyAuth(ctx gliderssh.Context, sess *session.Session, publicKey gliderssh.PublicKey) (session.Auth, error) {
+	// A session the API opened for an API key is authorized as that key, in either
+	// mode, so it can never fall through to a device credential.
+	if sess.APIKey != "" {
+		return session.AuthAPIKey(ctx, publicKey), nil
+	}
+
 	if !sess.IsIdentityMode() {
 		return session.AuthPublicKey(publicKey), nil
 	}
@@ -12,5 +12,25 @@ const (
 	//
 	// Example of dynamic application-level port forwarding: `ssh -D 1080 user@sshid`.
 	DirectTCPIPChannel = "direct-tcpip"
-	SessionChannel     = "session"
+	// ForwardedTCPIPChannel is the channel type the server opens toward the client for every connection accepted on
+	// a remote port forward. It flows the other way around from [DirectTCPIPChannel]: the client never opens it.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-7.2
+	ForwardedTCPIPChannel = "forwarded-tcpip"
+	SessionChannel        = "session"
+)
+
+const (
+	// TCPIPForwardRequest is the global request a client sends to ask for remote port forwarding, which makes the
+	// device listen on a port and hand every connection back to the client.
+	//
+	// Example of remote port forwarding: `ssh -R 8080:localhost:80 user@sshid`.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-7.1
+	TCPIPForwardRequest = "tcpip-forward"
+	// CancelTCPIPForwardRequest is the global request that stops a remote port forward set up by
+	// [TCPIPForwardRequest].
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-7.1
+	CancelTCPIPForwardRequest = "cancel-tcpip-forward"
 )
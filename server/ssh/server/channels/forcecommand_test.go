@@ -0,0 +1,50 @@
+package channels
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestForceCommand(t *testing.T) {
+	tests := []struct {
+		description string
+		request     *gossh.Request
+	}{
+		{
+			description: "a shell runs the forced command instead",
+			request:     &gossh.Request{Type: ShellRequestType},
+		},
+		{
+			description: "an exec runs the forced command instead of its own",
+			request:     &gossh.Request{Type: ExecRequestType, Payload: gossh.Marshal(&models.SSHCommand{Command: "rm -rf /"})},
+		},
+		{
+			description: "a subsystem runs the forced command instead",
+			request:     &gossh.Request{Type: SubsystemRequestType, Payload: gossh.Marshal(&models.SSHSubsystem{Subsystem: "sftp"})},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			forceCommand(tc.request, "backup --run")
+
+			var command models.SSHCommand
+			assert.NoError(t, gossh.Unmarshal(tc.request.Payload, &command))
+			assert.Equal(t, ExecRequestType, tc.request.Type)
+			assert.Equal(t, "backup --run", command.Command)
+		})
+	}
+
+	t.Run("requests that start nothing are left alone", func(t *testing.T) {
+		payload := gossh.Marshal(&models.SSHWindowChange{Columns: 80, Rows: 24})
+		req := &gossh.Request{Type: WindowChangeRequestType, Payload: payload}
+
+		forceCommand(req, "backup --run")
+
+		assert.Equal(t, WindowChangeRequestType, req.Type)
+		assert.Equal(t, payload, req.Payload)
+	})
+}
@@ -0,0 +1,296 @@
+package channels
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// remoteForward is a listener the agent holds open on the device on behalf of a client.
+type remoteForward struct {
+	listener net.Listener
+	// seat is the seat the forward's events are recorded on. A forward is not a channel, so it gets a seat of its
+	// own rather than borrowing one from a shell that may close long before it does.
+	seat int
+}
+
+// ForwardedTCPIPHandler handles the [TCPIPForwardRequest] and [CancelTCPIPForwardRequest] global requests.
+//
+// The gateway never listens itself. It asks the agent to listen on the device, over the connection the session
+// already holds to it, and relays every connection the agent accepts there back to the client as a
+// [ForwardedTCPIPChannel].
+//
+// One handler serves every connection on the server, so the forwards are keyed by session as well as by address.
+type ForwardedTCPIPHandler struct {
+	mu       sync.Mutex
+	forwards map[string]*remoteForward
+}
+
+// NewForwardedTCPIPHandler creates a [ForwardedTCPIPHandler] with no forwards.
+func NewForwardedTCPIPHandler() *ForwardedTCPIPHandler {
+	return &ForwardedTCPIPHandler{
+		forwards: make(map[string]*remoteForward),
+	}
+}
+
+// forwardKey identifies a forward within the handler. The port is the one the device actually bound, which is what a
+// client cancels with after asking for port 0.
+func forwardKey(session, addr string, port uint32) string {
+	return session + "/" + net.JoinHostPort(addr, strconv.FormatUint(uint64(port), 10))
+}
+
+// forwardReply is the payload of a successful [TCPIPForwardRequest]. The RFC only carries the port when the client
+// asked the server to pick one, and a client that did not ask does not expect it.
+func forwardReply(requested, bound uint32) []byte {
+	if requested != 0 {
+		return nil
+	}
+
+	return gossh.Marshal(&struct{ Port uint32 }{Port: bound})
+}
+
+// HandleSSHRequest is the [gliderssh.RequestHandler] for both forward requests.
+func (h *ForwardedTCPIPHandler) HandleSSHRequest(ctx gliderssh.Context, srv *gliderssh.Server, req *gossh.Request) (bool, []byte) {
+	sess, state := session.ObtainSession(ctx)
+	if sess == nil || state < session.StateFinished {
+		// Global requests are only accepted once authentication has completed, so this is as unreachable as the
+		// same guard on the channel handlers.
+		log.WithFields(log.Fields{"session": ctx.SessionID(), "state": state}).
+			Error("port forward requested without an established session")
+
+		return false, nil
+	}
+
+	logger := log.WithFields(log.Fields{
+		"uid":      sess.UID,
+		"sshid":    sess.SSHID,
+		"device":   sess.Device.UID,
+		"username": sess.Target.Username,
+		"ip":       sess.IPAddress,
+	})
+
+	data := new(models.SSHTcpipForward)
+	if err := gossh.Unmarshal(req.Payload, data); err != nil {
+		logger.WithError(err).Error("failed to parse the port forward request")
+
+		return false, nil
+	}
+
+	logger = logger.WithFields(log.Fields{
+		"bind_addr": data.BindAddr,
+		"bind_port": data.BindPort,
+	})
+
+	switch req.Type {
+	case TCPIPForwardRequest:
+		return h.open(ctx, srv, sess, data, logger)
+	case CancelTCPIPForwardRequest:
+		return h.cancel(ctx, sess, data, logger)
+	default:
+		return false, nil
+	}
+}
+
+func (h *ForwardedTCPIPHandler) open(ctx gliderssh.Context, srv *gliderssh.Server, sess *session.Session, data *models.SSHTcpipForward, logger *log.Entry) (bool, []byte) {
+	if srv.ReversePortForwardingCallback == nil || !srv.ReversePortForwardingCallback(ctx, data.BindAddr, data.BindPort) {
+		logger.Info("remote port forwarding is not allowed")
+
+		return false, nil
+	}
+
+	// The agent replies with a refusal when it does not support remote forwarding, which is the case for every agent
+	// released before it did, and for devices in connector mode.
+	listener, err := sess.Agent.Client.Listen("tcp", net.JoinHostPort(data.BindAddr, strconv.FormatUint(uint64(data.BindPort), 10)))
+	if err != nil {
+		logger.WithError(err).Warn("the device refused to listen for the remote port forward")
+
+		return false, nil
+	}
+
+	bound := data.BindPort
+	if addr, ok := listener.Addr().(*net.TCPAddr); ok {
+		bound = uint32(addr.Port) //nolint:gosec // a TCP port always fits
+	}
+
+	key := forwardKey(ctx.SessionID(), data.BindAddr, bound)
+
+	// The duplicate is refused before a seat is taken, as seats are never given back.
+	h.mu.Lock()
+	if _, ok := h.forwards[key]; ok {
+		h.mu.Unlock()
+
+		logger.Warn("the remote port forward is already open")
+		listener.Close() //nolint:errcheck
+
+		return false, nil
+	}
+
+	seat, err := sess.NewSeat()
+	if err != nil {
+		h.mu.Unlock()
+
+		logger.WithError(err).Error("failed to create a new seat for the remote port forward")
+		listener.Close() //nolint:errcheck
+
+		return false, nil
+	}
+
+	h.forwards[key] = &remoteForward{listener: listener, seat: seat}
+	h.mu.Unlock()
+
+	sess.Seats.SetType(seat, TCPIPForwardRequest)
+
+	sess.Event(TCPIPForwardRequest, &models.SSHTcpipForward{BindAddr: data.BindAddr, BindPort: bound}, seat)
+
+	logger.WithField("bound_port", bound).Info("remote port forward opened")
+
+	// The agent connection closing ends the listener on its own, but the entry has to go with the session, or a
+	// long-lived server keeps one per forward it ever served.
+	go func() {
+		<-ctx.Done()
+
+		if forward := h.remove(key); forward != nil {
+			forward.listener.Close() //nolint:errcheck
+		}
+	}()
+
+	go h.serve(ctx, sess, listener, data.BindAddr, bound, seat, logger)
+
+	return true, forwardReply(data.BindPort, bound)
+}
+
+func (h *ForwardedTCPIPHandler) cancel(ctx gliderssh.Context, sess *session.Session, data *models.SSHTcpipForward, logger *log.Entry) (bool, []byte) {
+	forward := h.remove(forwardKey(ctx.SessionID(), data.BindAddr, data.BindPort))
+	if forward == nil {
+		logger.Warn("asked to cancel a remote port forward that is not open")
+
+		return false, nil
+	}
+
+	// Closing the listener is what asks the agent to stop listening.
+	if err := forward.listener.Close(); err != nil {
+		logger.WithError(err).Warn("failed to cancel the remote port forward on the device")
+	}
+
+	sess.Event(CancelTCPIPForwardRequest, data, forward.seat)
+
+	logger.Info("remote port forward canceled")
+
+	return true, nil
+}
+
+func (h *ForwardedTCPIPHandler) remove(key string) *remoteForward {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	forward, ok := h.forwards[key]
+	if !ok {
+		return nil
+	}
+
+	delete(h.forwards, key)
+
+	return forward
+}
+
+// serve accepts the connections the agent hands over on a forward until it is closed.
+func (h *ForwardedTCPIPHandler) serve(ctx gliderssh.Context, sess *session.Session, listener net.Listener, addr string, port uint32, seat int, logger *log.Entry) {
+	defer logger.Trace("remote port forward stopped accepting")
+
+	conn, ok := ctx.Value(gliderssh.ContextKeyConn).(gossh.Conn)
+	if !ok || conn == nil {
+		logger.Error("no client connection to relay the remote port forward to")
+
+		return
+	}
+
+	for {
+		agent, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go relayForwarded(sess, conn, agent, addr, port, seat, logger)
+	}
+}
+
+// relayForwarded opens a [ForwardedTCPIPChannel] on the client for a connection the agent accepted, and pipes data
+// between them until either side is done.
+func relayForwarded(sess *session.Session, conn gossh.Conn, agent net.Conn, addr string, port uint32, seat int, logger *log.Entry) {
+	defer agent.Close()
+
+	data := &models.SSHForwardedTcpip{
+		DestAddr: addr,
+		DestPort: port,
+	}
+
+	if origin, ok := agent.RemoteAddr().(*net.TCPAddr); ok {
+		data.OriginAddr = origin.IP.String()
+		data.OriginPort = uint32(origin.Port) //nolint:gosec // a TCP port always fits
+	}
+
+	logger = logger.WithFields(log.Fields{
+		"origin_addr": data.OriginAddr,
+		"origin_port": data.OriginPort,
+	})
+
+	client, reqs, err := conn.OpenChannel(ForwardedTCPIPChannel, gossh.Marshal(data))
+	if err != nil {
+		logger.WithError(err).Warn("the client refused the forwarded connection")
+
+		return
+	}
+
+	defer client.Close()
+
+	go gossh.DiscardRequests(reqs)
+
+	sess.Event(ForwardedTCPIPChannel, data, seat)
+
+	logger.Trace("piping data between agent and client on a remote port forward")
+
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		defer client.CloseWrite() //nolint:errcheck
+
+		if _, err := io.Copy(client, &deadReadGuard{r: agent}); err != nil && err != io.EOF {
+			logger.WithError(err).Error("failed to copy data from agent to client")
+
+			// Close both ends so the peer goroutine unblocks and wg.Wait can return.
+			_ = agent.Close()
+			_ = client.Close()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		if _, err := io.Copy(agent, &deadReadGuard{r: client}); err != nil && err != io.EOF {
+			logger.WithError(err).Error("failed to copy data from client to agent")
+
+			_ = agent.Close()
+			_ = client.Close()
+
+			return
+		}
+
+		// The agent side is a channel, so a half-close reaches the device and the forwarded peer sees EOF.
+		if closer, ok := agent.(interface{ CloseWrite() error }); ok {
+			closer.CloseWrite() //nolint:errcheck
+		}
+	}()
+
+	wg.Wait()
+
+	logger.Trace("remote port forward connection done")
+}
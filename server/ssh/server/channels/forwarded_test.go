@@ -0,0 +1,82 @@
+package channels
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// stubContext is the least of a gliderssh.Context the forward handler reads.
+type stubContext struct {
+	context.Context
+	sync.Mutex
+	values map[any]any
+}
+
+func (s *stubContext) User() string          { return "user@namespace.device" }
+func (s *stubContext) SessionID() string     { return "test-session-id" }
+func (s *stubContext) ClientVersion() string { return "" }
+func (s *stubContext) ServerVersion() string { return "" }
+func (s *stubContext) RemoteAddr() net.Addr  { return nil }
+func (s *stubContext) LocalAddr() net.Addr   { return nil }
+func (s *stubContext) Permissions() *gliderssh.Permissions {
+	return &gliderssh.Permissions{}
+}
+
+func (s *stubContext) SetValue(key, val any) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.values[key] = val
+}
+
+func (s *stubContext) Value(key any) any {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.values[key]
+}
+
+func TestForwardReply(t *testing.T) {
+	t.Run("carries the bound port when the client asked for any port", func(t *testing.T) {
+		var reply struct{ Port uint32 }
+
+		require.NoError(t, gossh.Unmarshal(forwardReply(0, 43022), &reply))
+		assert.Equal(t, uint32(43022), reply.Port)
+	})
+
+	t.Run("is empty when the client named the port", func(t *testing.T) {
+		assert.Nil(t, forwardReply(8080, 8080))
+	})
+}
+
+func TestForwardKey(t *testing.T) {
+	// Two sessions forwarding the same address on their own devices must not
+	// collide, since one handler serves the whole server.
+	assert.NotEqual(t, forwardKey("a", "localhost", 8080), forwardKey("b", "localhost", 8080))
+	assert.Equal(t, "a/[::1]:8080", forwardKey("a", "::1", 8080))
+}
+
+func TestForwardedTCPIPHandlerRefusesWithoutSession(t *testing.T) {
+	ctx := &stubContext{Context: context.Background(), values: make(map[any]any)} //nolint:exhaustruct
+
+	for _, kind := range []string{TCPIPForwardRequest, CancelTCPIPForwardRequest} {
+		t.Run(kind, func(t *testing.T) {
+			req := &gossh.Request{ //nolint:exhaustruct
+				Type:    kind,
+				Payload: gossh.Marshal(&models.SSHTcpipForward{BindAddr: "localhost", BindPort: 8080}),
+			}
+
+			ok, payload := NewForwardedTCPIPHandler().HandleSSHRequest(ctx, &gliderssh.Server{}, req) //nolint:exhaustruct
+			assert.False(t, ok)
+			assert.Nil(t, payload)
+		})
+	}
+}
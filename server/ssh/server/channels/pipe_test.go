@@ -10,6 +10,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/envs/envstest"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
 	"github.com/shellhub-io/shellhub/server/ssh/session"
 	log "github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
@@ -197,7 +198,7 @@ func runPipe(t *testing.T, sess *session.Session, client, agent gossh.Channel) c
 	go func() {
 		defer close(finished)
 
-		pipe(sess, client, agent, 0, done)
+		pipe(sess, client, agent, 0, nil, nil, done)
 	}()
 
 	return finished
@@ -293,6 +294,32 @@ func TestPipeKeepsStreamsApart(t *testing.T) {
 	assert.Equal(t, "to stderr", client.stderrOut.String())
 }
 
+func TestPipeGivesOutputToShadows(t *testing.T) {
+	sess := newPipeSession(t)
+	client, agent := newFakeChannel(), newFakeChannel()
+
+	registry := shadow.NewRegistry()
+	seat := registry.Register(sess.UID, 0, shadow.Terminal{Cols: 80, Rows: 24}) //nolint:exhaustruct
+
+	_, err := agent.dataIn.Write([]byte("$ ls"))
+	require.NoError(t, err)
+
+	agent.quiet()
+	client.quiet()
+
+	done := make(chan bool, 1)
+	pipe(sess, client, agent, 0, nil, seat, done)
+
+	assert.Equal(t, "$ ls", client.dataOut.String())
+
+	// A shadow attaching now opens on what the seat printed.
+	watching, err := registry.Attach(sess.UID, 0, shadow.Observer{UserID: "user-id", Mode: shadow.ModeReadOnly}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	assert.Equal(t, shadow.Frame{Cols: 80, Rows: 24}, <-watching.Frames())     //nolint:exhaustruct
+	assert.Equal(t, shadow.Frame{Output: []byte("$ ls")}, <-watching.Frames()) //nolint:exhaustruct
+}
+
 // TestPipeDrainsAgentStderrBeforeStdoutEOF covers the hang: extended data sits
 // in x/crypto's buffer and consumes the channel window, so a chatty stderr
 // stalls the whole session if nothing reads it until stdout is done.
@@ -5,6 +5,7 @@ import (
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
 	"github.com/shellhub-io/shellhub/server/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -70,6 +71,11 @@ const AuthRequestOpenSSHRequest = "auth-agent-req@openssh.com"
 // https://www.ietf.org/archive/id/draft-miller-ssh-agent-11.html#section-4.2
 const AuthRequestOpenSSHChannel = "auth-agent@openssh.com"
 
+// X11 forwarding may be requested for a session by sending this channel request.
+//
+// https://www.rfc-editor.org/rfc/rfc4254#section-6.3.1
+const X11RequestType = "x11-req"
+
 // startsDataPipe reports whether a channel request puts the session into
 // service, and so must start the data pipe between client and agent.
 //
@@ -86,13 +92,42 @@ func startsDataPipe(requestType string) bool {
 	}
 }
 
+// forceCommand rewrites a request that starts a program into an exec of the command a user certificate forces,
+// which is how sshd honours force-command: whatever shell, command or subsystem the client asked for, the command is
+// what runs.
+func forceCommand(req *gossh.Request, command string) {
+	switch req.Type {
+	case ShellRequestType, ExecRequestType, SubsystemRequestType:
+		req.Type = ExecRequestType
+		req.Payload = gossh.Marshal(&models.SSHCommand{Command: command})
+	}
+}
+
+// withheld reports whether a channel request asks for something the login's
+// certificate does not permit, which is refused as sshd does. A request the
+// permit-* extensions say nothing about is never withheld.
+func withheld(permissions models.SSHCertificatePermissions, requestType string) bool {
+	switch requestType {
+	case PtyRequestType:
+		return !permissions.PTY
+	case AuthRequestOpenSSHRequest:
+		return !permissions.AgentForwarding
+	case X11RequestType:
+		return !permissions.X11Forwarding
+	default:
+		return false
+	}
+}
+
 // DefaultSessionHandler is the default handler for session's channel.
 //
 // A session is a remote execution of a program. The program may be a shell, an application, a system command, or some
 // built-in subsystem. It may or may not have a TTY, and may or may not involve X11 forwarding.
 //
 // https://www.rfc-editor.org/rfc/rfc4254#section-6
-func DefaultSessionHandler() gliderssh.ChannelHandler {
+//
+// A seat that gets a pty is open to shadows through shadows, for as long as its data flows.
+func DefaultSessionHandler(shadows *shadow.Registry) gliderssh.ChannelHandler {
 	return func(_ *gliderssh.Server, _ *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
 		sess, state := session.ObtainSession(ctx)
 		if sess == nil || state < session.StateFinished {
@@ -159,6 +194,18 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 
 		defer client.Close()
 
+		commands := session.NewCommands(sess, seat)
+		defer commands.Close()
+
+		// shadowed is the seat as open to shadows, once the device allocated its pty. It is
+		// only touched by the goroutine reading the client's requests until they are done.
+		var shadowed *shadow.Seat
+		defer func() {
+			if shadowed != nil {
+				shadowed.Close()
+			}
+		}()
+
 		var wg sync.WaitGroup
 
 		// Buffered so pipe's completion signal never blocks the sender, even if
@@ -166,7 +213,7 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 		done := make(chan bool, 1)
 
 		oncePipe := sync.OnceFunc(func() {
-			go pipe(sess, client.Channel, agent.Channel, seat, done)
+			go pipe(sess, client.Channel, agent.Channel, seat, commands, shadowed, done)
 		})
 
 		wg.Add(2)
@@ -196,8 +243,23 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 					switch req.Type {
 					case ExitStatusRequest:
 						session.Event[models.SSHExitStatus](sess, req.Type, req.Payload, seat)
+
+						var exit models.SSHExitStatus
+						if err := gossh.Unmarshal(req.Payload, &exit); err == nil {
+							commands.Exit(exit.Status)
+						}
 					case ExitSignalRequest:
 						session.Event[models.SSHSignal](sess, req.Type, req.Payload, seat)
+					case session.DeniedRequestType:
+						// NOTE: The denial is ShellHub's own business, so it isn't forwarded to the client, which has
+						// already been told by the agent on its stderr.
+						session.Event[models.SSHDenied](sess, string(models.SessionEventTypeDenied), req.Payload, seat)
+
+						if req.WantReply {
+							denyRequest(logger, req)
+						}
+
+						continue
 					default:
 						sess.Event(req.Type, req.Payload, seat)
 					}
@@ -240,6 +302,17 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 						return
 					}
 
+					if sess.ForceCommand != "" {
+						forceCommand(req, sess.ForceCommand)
+					}
+
+					if withheld(sess.Permissions(), req.Type) {
+						logger.WithField("request", req.Type).Info("the certificate does not permit the request")
+						denyRequest(logger, req)
+
+						continue
+					}
+
 					switch req.Type {
 					case ShellRequestType:
 						if seat, ok := sess.Seats.Get(seat); ok && seat.HasPty {
@@ -249,9 +322,15 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 						}
 
 						sess.Event(req.Type, req.Payload, seat)
+						commands.Shell()
 					case ExecRequestType, SubsystemRequestType:
 						session.Event[models.SSHCommand](sess, req.Type, req.Payload, seat)
 
+						var exec models.SSHCommand
+						if err := gossh.Unmarshal(req.Payload, &exec); err == nil && req.Type == ExecRequestType {
+							commands.Exec(exec.Command)
+						}
+
 						sess.Seats.SetType(seat, ExecRequestType)
 					case PtyRequestType:
 						var pty models.SSHPty
@@ -280,6 +359,10 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 						}
 
 						sess.Event(req.Type, dimensions, seat) //nolint:errcheck
+
+						if shadowed != nil {
+							shadowed.Resize(dimensions.Columns, dimensions.Rows)
+						}
 					case AuthRequestOpenSSHRequest:
 						gliderssh.SetAgentRequested(ctx)
 
@@ -348,6 +431,27 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 						if ok || !req.WantReply {
 							sess.Seats.SetPty(seat, true)
 							sess.Event(PtyRequestType, *ptyRequested, seat) //nolint:errcheck
+
+							// The pty comes before the program it runs, so the seat is open
+							// to shadows before its data starts to flow.
+							if shadows != nil && shadowed == nil {
+								shadowed = shadows.Register(sess.UID, seat, shadow.Terminal{
+									Input:  agent.Channel,
+									Notice: client.Channel,
+									Typed: func(observer shadow.Observer, p []byte) {
+										commands.ShadowInput(observer.UserID, p)
+									},
+									Record: func(observer shadow.Observer, started bool) {
+										sess.Event(string(models.SessionEventTypeShadow), &models.SessionShadow{
+											UserID:  observer.UserID,
+											Mode:    string(observer.Mode),
+											Started: started,
+										}, seat)
+									},
+									Cols: ptyRequested.Columns,
+									Rows: ptyRequested.Rows,
+								})
+							}
 						} else {
 							logger.Warn("the device refused the pty; the session continues without one")
 						}
@@ -8,6 +8,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
 	"github.com/shellhub-io/shellhub/server/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -27,6 +28,25 @@ func NewRecorder(session *session.Session, seat int) (io.Writer, error) {
 	}, nil
 }
 
+// commandInput and commandOutput feed a seat's [session.Commands] with what crosses its
+// terminal, each in its direction.
+type (
+	commandInput  struct{ commands *session.Commands }
+	commandOutput struct{ commands *session.Commands }
+)
+
+func (w commandInput) Write(p []byte) (int, error) {
+	w.commands.Input(p)
+
+	return len(p), nil
+}
+
+func (w commandOutput) Write(p []byte) (int, error) {
+	w.commands.Output(p)
+
+	return len(p), nil
+}
+
 // PtyOutputEventType is the event's type for an output.
 const PtyOutputEventType = "pty-output"
 
@@ -69,8 +89,9 @@ func (c *Recorder) Write(output []byte) (int, error) {
 }
 
 // pipe function pipes data between client and agent, and vice versa, recording each frame when ShellHub instance are
-// Cloud or Enterprise.
-func pipe(sess *session.Session, client gossh.Channel, agent gossh.Channel, seat int, done chan bool) {
+// Cloud or Enterprise. The seat's commands are followed whenever its output is recorded. The output reaches the
+// seat's shadows too, when it is open to them.
+func pipe(sess *session.Session, client gossh.Channel, agent gossh.Channel, seat int, commands *session.Commands, shadowed *shadow.Seat, done chan bool) {
 	defer log.
 		WithFields(log.Fields{"session": sess.UID, "sshid": sess.SSHID}).
 		Trace("data pipe between client and agent has done")
@@ -91,6 +112,9 @@ func pipe(sess *session.Session, client gossh.Channel, agent gossh.Channel, seat
 		defer client.CloseWrite() //nolint:errcheck
 
 		writers := []io.Writer{client}
+		if shadowed != nil {
+			writers = append(writers, shadowed)
+		}
 		if envs.IsEnterpriseOrCloud() {
 			recorder, err := NewRecorder(sess, seat)
 			if err != nil {
@@ -115,6 +139,11 @@ func pipe(sess *session.Session, client gossh.Channel, agent gossh.Channel, seat
 
 			if recorder != nil {
 				writers = append(writers, recorder)
+
+				if commands != nil {
+					commands.Enable()
+					writers = append(writers, commandOutput{commands})
+				}
 			}
 		}
 
@@ -172,7 +201,13 @@ func pipe(sess *session.Session, client gossh.Channel, agent gossh.Channel, seat
 			}
 		}()
 
-		if _, err := io.Copy(agent, &deadReadGuard{r: client}); err != nil && err != io.EOF {
+		var input io.Reader = &deadReadGuard{r: client}
+		if commands != nil {
+			// The keystrokes are dropped until the output is known to be recorded.
+			input = io.TeeReader(input, commandInput{commands})
+		}
+
+		if _, err := io.Copy(agent, input); err != nil && err != io.EOF {
 			log.WithError(err).Error("failed on coping data from client to agent")
 
 			// Close both ends so the other copy goroutine unblocks and pipe can return.
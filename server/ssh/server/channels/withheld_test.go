@@ -0,0 +1,57 @@
+package channels
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithheld(t *testing.T) {
+	none := models.SSHCertificatePermissions{}
+	all := models.SSHCertificatePermissions{PTY: true, PortForwarding: true, AgentForwarding: true, X11Forwarding: true}
+
+	tests := []struct {
+		description string
+		permissions models.SSHCertificatePermissions
+		requestType string
+		expected    bool
+	}{
+		{
+			description: "a pty is refused without permit-pty",
+			permissions: none,
+			requestType: PtyRequestType,
+			expected:    true,
+		},
+		{
+			description: "agent forwarding is refused without permit-agent-forwarding",
+			permissions: none,
+			requestType: AuthRequestOpenSSHRequest,
+			expected:    true,
+		},
+		{
+			description: "X11 forwarding is refused without permit-X11-forwarding",
+			permissions: none,
+			requestType: X11RequestType,
+			expected:    true,
+		},
+		{
+			description: "a command runs whatever the certificate permits",
+			permissions: none,
+			requestType: ExecRequestType,
+			expected:    false,
+		},
+		{
+			description: "a permitted pty is let through",
+			permissions: all,
+			requestType: PtyRequestType,
+			expected:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, withheld(tc.permissions, tc.requestType))
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/shellhub-io/shellhub/server/api/services"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/banner"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/dialer"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/target"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/webhandoff"
 	"github.com/shellhub-io/shellhub/server/ssh/server/auth"
@@ -243,7 +244,7 @@ func newServerConfigCallback(ctx gliderssh.Context) *gossh.ServerConfig {
 	}
 }
 
-func NewServer(dialer *dialer.Dialer, service services.Service, handoff *webhandoff.Store, opts *Options) (*Server, error) {
+func NewServer(dialer *dialer.Dialer, service services.Service, handoff *webhandoff.Store, shadows *shadow.Registry, opts *Options) (*Server, error) {
 	session.Configure(session.Config{
 		AllowPublickeyAccessBelow060: opts.AllowPublickeyAccessBelow060,
 		Domain:                       opts.Domain,
@@ -256,6 +257,8 @@ func NewServer(dialer *dialer.Dialer, service services.Service, handoff *webhand
 		dialer: dialer,
 	}
 
+	forwards := channels.NewForwardedTCPIPHandler()
+
 	server.sshd = &gliderssh.Server{ // nolint: exhaustruct
 		Addr: ":2222",
 		// Only the handshake is bounded by a deadline. An established connection
@@ -284,21 +287,48 @@ func NewServer(dialer *dialer.Dialer, service services.Service, handoff *webhand
 		// and the server. SSH channels serve as the infrastructure for executing commands, establishing shell sessions,
 		// and securely forwarding network services.
 		ChannelHandlers: map[string]gliderssh.ChannelHandler{
-			channels.SessionChannel:     recoverChannel(channels.SessionChannel, channels.DefaultSessionHandler()),
+			channels.SessionChannel:     recoverChannel(channels.SessionChannel, channels.DefaultSessionHandler(shadows)),
 			channels.DirectTCPIPChannel: recoverChannel(channels.DirectTCPIPChannel, channels.DefaultDirectTCPIPHandler),
 		},
-		// Answers the web terminal bridge with this connection's session UID, so a
-		// client-side recording can be tied to its server session.
 		RequestHandlers: map[string]gliderssh.RequestHandler{
+			// Answers the web terminal bridge with this connection's session UID, so a
+			// client-side recording can be tied to its server session.
 			"session-uid@shellhub.io": func(ctx gliderssh.Context, _ *gliderssh.Server, _ *gossh.Request) (bool, []byte) {
 				return true, []byte(ctx.SessionID())
 			},
+			channels.TCPIPForwardRequest:       recoverRequest(channels.TCPIPForwardRequest, forwards.HandleSSHRequest),
+			channels.CancelTCPIPForwardRequest: recoverRequest(channels.CancelTCPIPForwardRequest, forwards.HandleSSHRequest),
 		},
-		LocalPortForwardingCallback: func(_ gliderssh.Context, _ string, _ uint32) bool {
-			return true
+		// A certificate login forwards ports only if its permit-port-forwarding
+		// extension says so; see [session.Session.Permissions].
+		LocalPortForwardingCallback: func(ctx gliderssh.Context, _ string, _ uint32) bool {
+			sess, _ := session.ObtainSession(ctx)
+
+			return sess != nil && sess.Permissions().PortForwarding
 		},
-		ReversePortForwardingCallback: func(_ gliderssh.Context, _ string, _ uint32) bool {
-			return false
+		// A remote forward is decided when it is asked for, not only at login:
+		// see [session.Session.AuthorizeForward].
+		ReversePortForwardingCallback: func(ctx gliderssh.Context, _ string, _ uint32) bool {
+			sess, state := session.ObtainSession(ctx)
+			if sess == nil || state < session.StateFinished {
+				return false
+			}
+
+			if !sess.Permissions().PortForwarding {
+				log.WithFields(log.Fields{"uid": sess.UID, "sshid": sess.SSHID}).
+					Info("remote port forward not permitted by the certificate")
+
+				return false
+			}
+
+			if err := sess.AuthorizeForward(ctx); err != nil {
+				log.WithError(err).WithFields(log.Fields{"uid": sess.UID, "sshid": sess.SSHID}).
+					Info("remote port forward denied by policy")
+
+				return false
+			}
+
+			return true
 		},
 	}
 
@@ -338,6 +368,27 @@ func recoverChannel(name string, next gliderssh.ChannelHandler) gliderssh.Channe
 	}
 }
 
+// recoverRequest is [recoverChannel] for global requests, which the upstream
+// server also runs with no recover of its own. A panic refuses the request.
+func recoverRequest(name string, next gliderssh.RequestHandler) gliderssh.RequestHandler {
+	return func(ctx gliderssh.Context, srv *gliderssh.Server, req *gossh.Request) (ok bool, payload []byte) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.WithFields(log.Fields{
+					"request": name,
+					"session": ctx.SessionID(),
+					"panic":   r,
+					"stack":   string(debug.Stack()),
+				}).Error("recovered from a panic on an ssh global request")
+
+				ok, payload = false, nil
+			}
+		}()
+
+		return next(ctx, srv, req)
+	}
+}
+
 // loopbackProxyPolicy honours a PROXY protocol header only when the real TCP
 // peer is loopback, and rejects the connection outright otherwise.
 //
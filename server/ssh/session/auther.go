@@ -14,6 +14,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/banner"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/magickey"
+	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
 )
 
@@ -231,7 +232,14 @@ func (s *Session) awaitApproval(gctx gliderssh.Context) (string, error) {
 // minted, so the agent is never contacted for a login the policies deny. It
 // returns the decision so the caller can honor a step-up requirement.
 func (s *Session) authorize(ctx context.Context) (*models.Decision, error) {
-	dec, err := s.service.Authorize(ctx, s.Namespace.TenantID, s.UserID, s.Device.UID, s.Target.Username, s.IPAddress)
+	var dec *models.Decision
+	var err error
+	if s.APIKey != "" {
+		dec, err = s.service.AuthorizeAPIKey(ctx, s.Namespace.TenantID, s.APIKey, s.Device.UID, s.Target.Username, s.IPAddress)
+	} else {
+		dec, err = s.service.Authorize(ctx, s.Namespace.TenantID, s.UserID, s.Device.UID, s.Target.Username, s.IPAddress)
+	}
+
 	if err != nil || dec == nil || !dec.Allowed {
 		return nil, ErrAccessDenied
 	}
@@ -239,6 +247,21 @@ func (s *Session) authorize(ctx context.Context) (*models.Decision, error) {
 	return dec, nil
 }
 
+// AuthorizeForward decides whether the session may open a remote port forward on
+// the device. In identity mode the policies are asked again instead of trusting
+// the login's decision: a forward exposes the device's network for as long as
+// it stays open, so a grant revoked since the login must stop new ones. Legacy
+// namespaces have no policies, and the login's authorization stands.
+func (s *Session) AuthorizeForward(ctx context.Context) error {
+	if !s.IsIdentityMode() {
+		return nil
+	}
+
+	_, err := s.authorize(ctx)
+
+	return err
+}
+
 // approvalAuth authenticates a session whose presented key is not an identity
 // yet (identity mode): it blocks until a member approves in the console — which
 // binds the key to the approving account — then authorizes the now-known
@@ -361,6 +384,56 @@ func (a *identityAuth) Evaluate(session *Session) error {
 	return nil
 }
 
+// apiKeyAuth authenticates a session the API opened to run a command or
+// transfer a file for an API key (identity mode). The API dials with the magic
+// key, so the key itself proves nothing about the caller: Access Policies naming
+// the API key decide, and the agent is reached with the server-minted ephemeral
+// key like any other identity login.
+type apiKeyAuth struct {
+	ctx gliderssh.Context
+	pk  gliderssh.PublicKey
+}
+
+func AuthAPIKey(ctx gliderssh.Context, pk gliderssh.PublicKey) Auth {
+	return &apiKeyAuth{ctx: ctx, pk: pk}
+}
+
+func (*apiKeyAuth) Auth() authFunc {
+	return mintEphemeralSigner
+}
+
+func (a *apiKeyAuth) Offer(session *Session) error {
+	// Only the API holds the magic key, and it only runs commands for API keys in
+	// namespaces where Access Policies govern every login.
+	if !session.IsIdentityMode() {
+		return ErrAccessDenied
+	}
+
+	magic, err := gossh.NewPublicKey(&magickey.GetReference().PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if gossh.FingerprintSHA256(magic) != gossh.FingerprintSHA256(a.pk) {
+		return ErrAccessDenied
+	}
+
+	return nil
+}
+
+func (a *apiKeyAuth) Evaluate(session *Session) error {
+	// The decision cannot ask for a re-auth: an API key has nobody to complete it.
+	if _, err := session.authorize(a.ctx); err != nil {
+		// The API answers the caller from the banner, as the web bridge does, so a
+		// denial is told apart from a device that cannot be reached.
+		sendBanner(a.ctx, banner.Message(banner.KindAccessDenied))
+
+		return err
+	}
+
+	return nil
+}
+
 // needsReauth reports whether a policy requiring re-authentication must challenge
 // this connection, given when the identity last re-authed and the policy's
 // freshness window in seconds. A nil or zero period means "always" (every
@@ -409,6 +482,14 @@ func sendBanner(ctx gliderssh.Context, msg string) {
 func (s *Session) ResolveKeyAuth(ctx gliderssh.Context, publicKey gliderssh.PublicKey) (Auth, error) {
 	s.Fingerprint = gossh.FingerprintSHA256(publicKey)
 	s.KeyData = gossh.MarshalAuthorizedKey(publicKey)
+	// A client may offer several keys, each resolved in turn, so nothing one
+	// of them imposed may carry over to the next.
+	s.ForceCommand = ""
+	s.Certificate = nil
+
+	if cert, ok := publicKey.(*gossh.Certificate); ok {
+		return s.resolveCertificateAuth(ctx, cert)
+	}
 
 	identity, found, err := s.service.ResolveSSHIdentity(ctx, s.Namespace.TenantID, s.Fingerprint)
 	if err != nil {
@@ -441,6 +522,34 @@ func (s *Session) ResolveKeyAuth(ctx gliderssh.Context, publicKey gliderssh.Publ
 	return AuthApproval(ctx), nil
 }
 
+// resolveCertificateAuth resolves an OpenSSH user certificate to the account its
+// principals name, through a CA the namespace trusts. A certificate is never an
+// enrolled identity, and never enters the browser approval either: one the
+// namespace does not accept is refused, and the client moves on to its next key.
+//
+// There is no identity row behind a certificate, so nothing remembers a
+// re-authentication for it: a policy requiring one asks on every login.
+func (s *Session) resolveCertificateAuth(ctx gliderssh.Context, cert *gossh.Certificate) (Auth, error) {
+	identity, err := s.service.ResolveSSHCertificate(ctx, s.Namespace.TenantID, cert, s.IPAddress)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"uid":    s.UID,
+			"key_id": cert.KeyId,
+			"serial": cert.Serial,
+		}).Warn("refused the ssh certificate")
+
+		return nil, ErrAccessDenied
+	}
+
+	s.UserID = identity.PrincipalID
+	s.LastReauthAt = nil
+	s.SingleUse = false
+	s.ForceCommand = identity.ForceCommand
+	s.Certificate = identity
+
+	return AuthIdentity(ctx), nil
+}
+
 var (
 	// ErrApprovalRejected is returned when the user rejects the login in the console.
 	ErrApprovalRejected = errors.New("ssh login denied")
@@ -0,0 +1,266 @@
+package session
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/cmdline"
+)
+
+// maxCommandEcho bounds how much output is searched for the late echo of a line, past the
+// length of the line itself.
+const maxCommandEcho = 4096
+
+// command is a command line waiting to be recorded.
+type command struct {
+	models.SessionCommandLine
+	at time.Time
+	// echo is the text printed since the line was submitted, while its echo is awaited.
+	echo []byte
+}
+
+// Commands records the commands run on a seat as command events: the command an exec
+// requested, or the lines typed into a shell.
+//
+// A command is recorded once it is known how it ended, or that it cannot be known: when its
+// exit status arrives, or when the next command starts, or when the seat closes.
+type Commands struct {
+	session *Session
+	seat    int
+
+	// writing keeps the commands in order while they are written, which is done without
+	// holding mu so the seat's data never waits on the events being sent.
+	writing sync.Mutex
+
+	mu sync.Mutex
+	// enabled lets the keystrokes be followed. They are the session's content, as much as
+	// its output is, so they are only followed when the output is recorded.
+	enabled     bool
+	interactive bool
+	closed      bool
+
+	editor cmdline.Editor
+	screen cmdline.Screen
+	// current is the command running, as far as the gateway can tell.
+	current *command
+	// candidate is a line submitted without echo. It becomes the current command if the echo
+	// follows, and is dropped otherwise: a line the terminal did not echo is most likely a
+	// password.
+	candidate *command
+	// typedBy is the member whose read-write shadow typed into the line being edited, if any.
+	typedBy string
+	// finished are the commands recorded while mu is held, waiting to be written.
+	finished []models.SessionEvent
+}
+
+func NewCommands(session *Session, seat int) *Commands {
+	return &Commands{
+		session: session,
+		seat:    seat,
+	}
+}
+
+// Enable starts following the keystrokes sent to the seat.
+func (c *Commands) Enable() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.enabled = true
+}
+
+// Exec records the command an exec request runs.
+func (c *Commands) Exec(line string) {
+	c.mu.Lock()
+	defer c.unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.finish(false, nil)
+	c.current = &command{
+		SessionCommandLine: models.SessionCommandLine{Command: line},
+		at:                 clock.Now(),
+	}
+}
+
+// Shell tells the seat runs a shell, whose keystrokes are command lines.
+func (c *Commands) Shell() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactive = true
+}
+
+// Input follows the keystrokes the client sends.
+func (c *Commands) Input(p []byte) {
+	c.input("", p)
+}
+
+// ShadowInput follows the keystrokes a member's read-write shadow sends. The lines they go
+// into are recorded as typed by the member.
+func (c *Commands) ShadowInput(userID string, p []byte) {
+	c.input(userID, p)
+}
+
+func (c *Commands) input(by string, p []byte) {
+	c.mu.Lock()
+	defer c.unlock()
+
+	if c.closed || !c.enabled || !c.interactive {
+		return
+	}
+
+	// The keys belong to the editor or pager running full screen.
+	if c.screen.Fullscreen() {
+		c.editor.Reset()
+		c.typedBy = ""
+
+		return
+	}
+
+	if by != "" {
+		c.typedBy = by
+	}
+
+	for _, line := range c.editor.Feed(p) {
+		next := &command{
+			SessionCommandLine: models.SessionCommandLine{
+				Command:     line.Text,
+				Interactive: true,
+				Inexact:     line.Inexact,
+				TypedBy:     c.typedBy,
+			},
+			at: clock.Now(),
+		}
+
+		// The next line is the client's own until a shadow types into it.
+		c.typedBy = ""
+
+		// A new line means the one awaiting its echo never got it.
+		c.candidate = nil
+
+		if line.Echoed {
+			c.start(next)
+		} else {
+			c.candidate = next
+		}
+	}
+}
+
+// Output follows what the device prints back.
+func (c *Commands) Output(p []byte) {
+	c.mu.Lock()
+	defer c.unlock()
+
+	if c.closed || !c.enabled || !c.interactive || len(p) == 0 {
+		return
+	}
+
+	c.editor.Echo()
+
+	text, marks := c.screen.Feed(p)
+
+	if c.candidate != nil {
+		c.candidate.echo = append(c.candidate.echo, text...)
+
+		switch {
+		case bytes.Contains(c.candidate.echo, []byte(c.candidate.Command)):
+			c.candidate.echo = nil
+			c.start(c.candidate)
+			c.candidate = nil
+		case len(c.candidate.echo) > len(c.candidate.Command)+maxCommandEcho:
+			c.candidate = nil
+		}
+	}
+
+	for _, mark := range marks {
+		if mark.Kind == cmdline.MarkCommandFinished {
+			c.finish(true, mark.ExitStatus)
+		}
+	}
+}
+
+// Exit records the seat's exit status as the status of its last command: the exec's, or the
+// one a shell exits with, which is its last command's.
+func (c *Commands) Exit(status uint32) {
+	c.mu.Lock()
+	defer c.unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.candidate = nil
+	c.finish(true, &status)
+}
+
+// Close records the command still running, if any, and stops following the seat.
+func (c *Commands) Close() {
+	c.mu.Lock()
+	defer c.unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.finish(false, nil)
+	c.candidate = nil
+	c.closed = true
+}
+
+// start records the current command, whose end went unseen, and makes next the current one.
+func (c *Commands) start(next *command) {
+	c.finish(false, nil)
+	c.current = next
+}
+
+// finish records the current command. It is written once mu is released.
+func (c *Commands) finish(finished bool, status *uint32) {
+	if c.current == nil {
+		return
+	}
+
+	if finished {
+		at := clock.Now()
+
+		c.current.FinishedAt = &at
+		c.current.ExitStatus = status
+	}
+
+	// Written with the time the command started, which is where it belongs in the recording.
+	c.finished = append(c.finished, models.SessionEvent{
+		Session:   c.session.UID,
+		Type:      models.SessionEventTypeCommand,
+		Timestamp: c.current.at,
+		Data:      &c.current.SessionCommandLine,
+		Seat:      c.seat,
+	})
+
+	c.current = nil
+}
+
+// unlock releases mu and writes the commands recorded while it was held. They are written in
+// the order they were recorded, as writing is taken before mu is released.
+func (c *Commands) unlock() {
+	finished := c.finished
+	c.finished = nil
+
+	if len(finished) == 0 {
+		c.mu.Unlock()
+
+		return
+	}
+
+	c.writing.Lock()
+	defer c.writing.Unlock()
+
+	c.mu.Unlock()
+
+	for _, event := range finished {
+		c.session.Events.Write(event)
+	}
+}
@@ -0,0 +1,212 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordCommands runs steps against a seat's commands and returns the lines recorded, in
+// order.
+func recordCommands(t *testing.T, steps func(c *Commands)) []models.SessionCommandLine {
+	t.Helper()
+
+	service, written := collectEvents(t, nil)
+
+	sess := &Session{UID: "session-uid", Events: NewEvents("session-uid", service)} //nolint:exhaustruct
+
+	commands := NewCommands(sess, 1)
+	steps(commands)
+	commands.Close()
+
+	require.NoError(t, sess.Events.Close())
+
+	lines := make([]models.SessionCommandLine, 0)
+	for _, event := range written() {
+		require.Equal(t, models.SessionEventTypeCommand, event.Type)
+		require.Equal(t, 1, event.Seat)
+
+		line := *event.Data.(*models.SessionCommandLine)
+		if line.FinishedAt != nil {
+			assert.False(t, line.FinishedAt.Before(event.Timestamp))
+			line.FinishedAt = nil
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// typed sends a line key by key, each echoed back.
+func typed(c *Commands, line string) {
+	for _, key := range []byte(line) {
+		c.Input([]byte{key})
+		c.Output([]byte{key})
+	}
+
+	c.Input([]byte("\r"))
+	c.Output([]byte("\r\n"))
+}
+
+func TestCommands(t *testing.T) {
+	status := func(v uint32) *uint32 { return &v }
+
+	t.Run("records an exec with its exit status", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Exec("uptime")
+			c.Exit(3)
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{{Command: "uptime", ExitStatus: status(3)}}, lines)
+	})
+
+	t.Run("records an exec whose end went unseen", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Exec("sleep infinity")
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{{Command: "sleep infinity"}}, lines)
+	})
+
+	t.Run("follows no keystrokes unless enabled", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Shell()
+			typed(c, "ls")
+		})
+
+		assert.Empty(t, lines)
+	})
+
+	t.Run("follows no keystrokes sent to an exec", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Enable()
+			c.Exec("cat")
+			typed(c, "hello")
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{{Command: "cat"}}, lines)
+	})
+
+	t.Run("records the lines typed into a shell, the last with the shell's exit status", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Enable()
+			c.Shell()
+			typed(c, "cd /tmp")
+			typed(c, "exit 1")
+			c.Exit(1)
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{
+			{Command: "cd /tmp", Interactive: true},
+			{Command: "exit 1", Interactive: true, ExitStatus: status(1)},
+		}, lines)
+	})
+
+	t.Run("takes exit statuses from the shell's integration marks", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Enable()
+			c.Shell()
+			c.Output([]byte("\x1b]133;D\x07\x1b]133;A\x07$ "))
+			typed(c, "false")
+			c.Output([]byte("\x1b]133;D;1\x07$ "))
+			typed(c, "true")
+			c.Output([]byte("\x1b]133;D;0\x07$ "))
+			c.Exit(0)
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{
+			{Command: "false", Interactive: true, ExitStatus: status(1)},
+			{Command: "true", Interactive: true, ExitStatus: status(0)},
+		}, lines)
+	})
+
+	t.Run("records a line echoed after it was sent whole", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Enable()
+			c.Shell()
+			c.Input([]byte("ls -l\r"))
+			c.Output([]byte("ls -"))
+			c.Output([]byte("l\r\ntotal 0\r\n"))
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{{Command: "ls -l", Interactive: true}}, lines)
+	})
+
+	t.Run("drops a line typed without echo", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Enable()
+			c.Shell()
+			typed(c, "sudo id")
+			c.Output([]byte("[sudo] password for root: "))
+			c.Input([]byte("hunter2\r"))
+			c.Output([]byte("\r\nuid=0(root)\r\n\x1b]133;D;0\x07"))
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{{Command: "sudo id", Interactive: true, ExitStatus: status(0)}}, lines)
+	})
+
+	t.Run("ignores the keys sent to a full-screen program", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Enable()
+			c.Shell()
+			typed(c, "vim notes")
+			c.Output([]byte("\x1b[?1049h"))
+			typed(c, "ihello")
+			typed(c, ":wq")
+			c.Output([]byte("\x1b[?1049l"))
+			typed(c, "ls")
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{
+			{Command: "vim notes", Interactive: true},
+			{Command: "ls", Interactive: true},
+		}, lines)
+	})
+
+	t.Run("flags a completed line as inexact", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Enable()
+			c.Shell()
+			c.Input([]byte("cat /etc/ho"))
+			c.Output([]byte("cat /etc/ho"))
+			c.Input([]byte("\t"))
+			c.Output([]byte("sts "))
+			c.Input([]byte("\r"))
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{{Command: "cat /etc/ho", Interactive: true, Inexact: true}}, lines)
+	})
+
+	t.Run("records the lines a read-write shadow typed into as theirs", func(t *testing.T) {
+		lines := recordCommands(t, func(c *Commands) {
+			c.Enable()
+			c.Shell()
+			c.Input([]byte("systemctl "))
+			c.ShadowInput("user-id", []byte("restart nginx\r"))
+			c.Output([]byte("systemctl restart nginx\r\n"))
+			typed(c, "uptime")
+		})
+
+		assert.Equal(t, []models.SessionCommandLine{
+			{Command: "systemctl restart nginx", Interactive: true, TypedBy: "user-id"},
+			{Command: "uptime", Interactive: true},
+		}, lines)
+	})
+
+	t.Run("records nothing after closing", func(t *testing.T) {
+		service, written := collectEvents(t, nil)
+
+		sess := &Session{UID: "session-uid", Events: NewEvents("session-uid", service)} //nolint:exhaustruct
+
+		commands := NewCommands(sess, 0)
+		commands.Close()
+		commands.Exec("ls")
+		commands.Exit(0)
+
+		require.NoError(t, sess.Events.Close())
+		assert.Empty(t, written())
+	})
+}
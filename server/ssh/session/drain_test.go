@@ -7,7 +7,9 @@ import (
 
 	"github.com/shellhub-io/shellhub/pkg/models"
 	servicemocks "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	gossh "golang.org/x/crypto/ssh"
 )
 
@@ -112,3 +114,42 @@ func TestDrainAgentRequestsEndsWithTheAgentConnection(t *testing.T) {
 		t.Fatal("the drain outlived the agent connection")
 	}
 }
+
+func TestDrainAgentRequestsRecordsDenials(t *testing.T) {
+	service, written := collectEvents(t, nil)
+
+	sess := newTestSession(service)
+	sess.Events = NewEvents("test-uid", service)
+
+	reqs := make(chan *gossh.Request)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		sess.drainAgentRequests(newStubContext(), reqs)
+	}()
+
+	reqs <- &gossh.Request{ //nolint:exhaustruct
+		Type:    DeniedRequestType,
+		Payload: gossh.Marshal(&models.SSHDenied{Action: "direct-tcpip", Reason: "port forwarding is disabled for this login"}),
+	}
+	close(reqs)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the drain did not return after the requests channel closed")
+	}
+
+	require.NoError(t, sess.Events.Close())
+
+	events := written()
+	require.Len(t, events, 1)
+	assert.Equal(t, models.SessionEventTypeDenied, events[0].Type)
+	assert.Equal(t, &models.SSHDenied{Action: "direct-tcpip", Reason: "port forwarding is disabled for this login"}, events[0].Data)
+
+	seat, ok := sess.Seats.Get(events[0].Seat)
+	require.True(t, ok)
+	assert.Equal(t, string(models.SessionEventTypeDenied), seat.Type)
+}
@@ -1,8 +1,10 @@
 package session
 
 import (
+	"context"
 	"crypto/ed25519"
 	"crypto/rand"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/server/api/services"
 	servicemocks "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/magickey"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/target"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -185,3 +188,170 @@ func TestResolveKeyAuth(t *testing.T) {
 		assert.Equal(t, "AB12CD34", sess.ApprovalCode)
 	})
 }
+
+func newTestSSHCertificate(t *testing.T) *gossh.Certificate {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ca, err := gossh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	cert := &gossh.Certificate{
+		Key:             newTestSSHKey(t),
+		CertType:        gossh.UserCert,
+		KeyId:           "alice@corp",
+		Serial:          7,
+		ValidPrincipals: []string{"alice"},
+		ValidBefore:     gossh.CertTimeInfinity,
+	}
+	require.NoError(t, cert.SignCert(rand.Reader, ca))
+
+	return cert
+}
+
+func TestResolveKeyAuthCertificate(t *testing.T) {
+	cert := newTestSSHCertificate(t)
+
+	t.Run("an accepted certificate yields the identity auth as its principal", func(t *testing.T) {
+		serviceMock := servicemocks.NewMockService(t)
+		serviceMock.EXPECT().
+			ResolveSSHCertificate(mock.Anything, "tenant-id", cert, "127.0.0.1").
+			Return(&models.SSHCertificateIdentity{ //nolint:exhaustruct
+				PrincipalID:  "user1",
+				ForceCommand: "uptime",
+				Permissions:  models.SSHCertificatePermissions{PTY: true}, //nolint:exhaustruct
+			}, nil).
+			Once()
+
+		sess := newIdentitySession(serviceMock, models.SSHAccessModeIdentity)
+		// Left over from a key the client offered before the certificate.
+		sess.SingleUse = true
+
+		auth, err := sess.ResolveKeyAuth(newStubContext(), cert)
+		require.NoError(t, err)
+		assert.IsType(t, &identityAuth{}, auth)
+		assert.Equal(t, "user1", sess.UserID)
+		assert.Equal(t, "uptime", sess.ForceCommand)
+		assert.False(t, sess.SingleUse)
+		assert.Equal(t, models.SSHCertificatePermissions{PTY: true}, sess.Permissions()) //nolint:exhaustruct
+	})
+
+	t.Run("a refused certificate is denied, never sent to enrollment", func(t *testing.T) {
+		serviceMock := servicemocks.NewMockService(t)
+		serviceMock.EXPECT().
+			ResolveSSHCertificate(mock.Anything, "tenant-id", cert, "127.0.0.1").
+			Return(nil, services.NewErrSSHCertificateRejected(errors.New("certificate authority is not trusted"))).
+			Once()
+
+		sess := newIdentitySession(serviceMock, models.SSHAccessModeIdentity)
+
+		auth, err := sess.ResolveKeyAuth(newStubContext(), cert)
+		require.ErrorIs(t, err, ErrAccessDenied)
+		assert.Nil(t, auth)
+		assert.Empty(t, sess.UserID)
+	})
+
+	t.Run("a plain key offered after a certificate forces and restricts nothing", func(t *testing.T) {
+		pubKey := newTestSSHKey(t)
+
+		serviceMock := servicemocks.NewMockService(t)
+		serviceMock.EXPECT().
+			ResolveSSHIdentity(mock.Anything, "tenant-id", gossh.FingerprintSHA256(pubKey)).
+			Return(&models.SSHIdentity{PrincipalID: "user1"}, true, nil). //nolint:exhaustruct
+			Once()
+
+		sess := newIdentitySession(serviceMock, models.SSHAccessModeIdentity)
+		sess.ForceCommand = "uptime"
+		sess.Certificate = &models.SSHCertificateIdentity{} //nolint:exhaustruct
+
+		_, err := sess.ResolveKeyAuth(newStubContext(), pubKey)
+		require.NoError(t, err)
+		assert.Empty(t, sess.ForceCommand)
+		assert.True(t, sess.Permissions().PTY)
+		assert.True(t, sess.Permissions().PortForwarding)
+	})
+}
+
+func TestAuthorizeForward(t *testing.T) {
+	t.Run("legacy namespaces keep the login's authorization", func(t *testing.T) {
+		// A nil service proves nothing is asked.
+		sess := newIdentitySession(nil, models.SSHAccessModeLegacy)
+
+		require.NoError(t, sess.AuthorizeForward(context.Background()))
+	})
+
+	t.Run("identity mode asks the policies again", func(t *testing.T) {
+		serviceMock := servicemocks.NewMockService(t)
+		serviceMock.EXPECT().
+			Authorize(mock.Anything, "tenant-id", "user1", "device-uid", "user", "127.0.0.1").
+			Return(&models.Decision{Allowed: true}, nil). //nolint:exhaustruct
+			Once()
+
+		sess := newIdentitySession(serviceMock, models.SSHAccessModeIdentity)
+		sess.UserID = "user1"
+
+		require.NoError(t, sess.AuthorizeForward(context.Background()))
+	})
+
+	t.Run("a grant revoked since the login refuses the forward", func(t *testing.T) {
+		serviceMock := servicemocks.NewMockService(t)
+		serviceMock.EXPECT().
+			Authorize(mock.Anything, "tenant-id", "user1", "device-uid", "user", "127.0.0.1").
+			Return(&models.Decision{Allowed: false, Reason: "denied"}, nil). //nolint:exhaustruct
+			Once()
+
+		sess := newIdentitySession(serviceMock, models.SSHAccessModeIdentity)
+		sess.UserID = "user1"
+
+		require.ErrorIs(t, sess.AuthorizeForward(context.Background()), ErrAccessDenied)
+	})
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	magic, err := gossh.NewPublicKey(&magickey.GetReference().PublicKey)
+	require.NoError(t, err)
+
+	t.Run("offer refuses a legacy namespace", func(t *testing.T) {
+		sess := newIdentitySession(nil, models.SSHAccessModeLegacy)
+		sess.APIKey = "ci"
+
+		require.ErrorIs(t, AuthAPIKey(newStubContext(), magic).Offer(sess), ErrAccessDenied)
+	})
+
+	t.Run("offer refuses any key but the magic one", func(t *testing.T) {
+		sess := newIdentitySession(nil, models.SSHAccessModeIdentity)
+		sess.APIKey = "ci"
+
+		require.ErrorIs(t, AuthAPIKey(newStubContext(), newTestSSHKey(t)).Offer(sess), ErrAccessDenied)
+	})
+
+	t.Run("evaluate asks the policies about the API key", func(t *testing.T) {
+		serviceMock := servicemocks.NewMockService(t)
+		serviceMock.EXPECT().
+			AuthorizeAPIKey(mock.Anything, "tenant-id", "ci", "device-uid", "user", "127.0.0.1").
+			Return(&models.Decision{Allowed: true}, nil). //nolint:exhaustruct
+			Once()
+
+		sess := newIdentitySession(serviceMock, models.SSHAccessModeIdentity)
+		sess.APIKey = "ci"
+
+		auth := AuthAPIKey(newStubContext(), magic)
+		require.NoError(t, auth.Offer(sess))
+		require.NoError(t, auth.Evaluate(sess))
+	})
+
+	t.Run("evaluate refuses what the policies deny", func(t *testing.T) {
+		serviceMock := servicemocks.NewMockService(t)
+		serviceMock.EXPECT().
+			AuthorizeAPIKey(mock.Anything, "tenant-id", "ci", "device-uid", "user", "127.0.0.1").
+			Return(&models.Decision{Allowed: false, Reason: "denied"}, nil). //nolint:exhaustruct
+			Once()
+
+		sess := newIdentitySession(serviceMock, models.SSHAccessModeIdentity)
+		sess.APIKey = "ci"
+
+		require.ErrorIs(t, AuthAPIKey(newStubContext(), magic).Evaluate(sess), ErrAccessDenied)
+	})
+}
@@ -42,6 +42,10 @@ type Data struct {
 	// presented key in identity mode, or set by the enrollment/step-up approval.
 	// Empty in legacy mode.
 	UserID string
+	// APIKey is the name of the API key the session runs for, when the API
+	// opened it to run a command or transfer a file. Access Policies authorize
+	// the key instead of a member. Empty for every other session.
+	APIKey string
 	// ApprovalCode is the JIT code minted in identity mode; the gateway polls
 	// its decision for enrollment or step-up. Empty otherwise. The enrollment URL
 	// derived from it is sent as a mid-handshake banner only once the presented
@@ -61,6 +65,14 @@ type Data struct {
 	// resolution. When set, the key is burned once this session establishes, so a
 	// second connection with it is rejected.
 	SingleUse bool
+	// ForceCommand is the command a user certificate's force-command option
+	// imposes, run in place of any shell, command or subsystem the client asks
+	// for. Empty when the login was not a certificate, or it forces nothing.
+	ForceCommand string
+	// Certificate is what the user certificate the login presented resolved to:
+	// the CA and principal it was accepted through, and its permit-* extensions
+	// (see [Session.Permissions]). Nil when the login was not a certificate.
+	Certificate *models.SSHCertificateIdentity
 }
 
 // AgentChannel represents a channel open between agent and server.
@@ -295,7 +307,7 @@ func NewSession(ctx gliderssh.Context, dialer *dialer.Dialer, service services.S
 	}
 
 	var namespaceName, deviceName string
-	var webUserID string
+	var webUserID, apiKey, apiKeyTenant string
 	web := false
 	if target.IsSSHID() {
 		namespaceName, deviceName, err = target.SplitSSHID()
@@ -328,6 +340,14 @@ func NewSession(ctx gliderssh.Context, dialer *dialer.Dialer, service services.S
 			// authenticated the request (identity mode). Absent for legacy web
 			// sessions, which authenticate with a device credential.
 			webUserID = data.UserID
+
+			// A command or file transfer the API runs for an API key comes the same
+			// way, but it is not a web terminal: nobody is there to see a banner.
+			if data.APIKey != "" {
+				web = false
+				apiKey = data.APIKey
+				apiKeyTenant = data.TenantID
+			}
 		}
 
 		device, err := service.GetDevice(ctx, scope.NewUnbounded(reasonSSHIDDeviceResolve), models.UID(target.Data))
@@ -335,6 +355,15 @@ func NewSession(ctx gliderssh.Context, dialer *dialer.Dialer, service services.S
 			return nil, err
 		}
 
+		// The API checked the device against the key's namespace before dialing;
+		// checking again keeps a key from ever reaching a device of another one.
+		if apiKey != "" && device.TenantID != apiKeyTenant {
+			log.WithFields(log.Fields{"sshid": sshid, "device": device.UID}).
+				Error("API key session handoff targets a device outside the key's namespace")
+
+			return nil, ErrWebData
+		}
+
 		namespaceName = device.Namespace
 		deviceName = device.Name
 	}
@@ -361,6 +390,7 @@ func NewSession(ctx gliderssh.Context, dialer *dialer.Dialer, service services.S
 			Namespace: namespace,
 			Web:       web,
 			UserID:    webUserID,
+			APIKey:    apiKey,
 			SSHID:     fmt.Sprintf("%s@%s.%s", target.Username, namespaceName, deviceName),
 		},
 		once:  new(sync.Once),
@@ -475,16 +505,25 @@ func (s *Session) checkBilling(ctx context.Context) error {
 
 // registerAPISession registers a new session on the API.
 func (s *Session) register(ctx context.Context) error {
-	_, err := s.service.CreateSession(ctx, requests.SessionCreate{
-		UID:       s.UID,
-		DeviceUID: s.Device.UID,
-		Username:  s.Target.Username,
-		UserID:    s.UserID,
-		IPAddress: s.IPAddress,
-		Type:      "none",
-		Term:      "none",
-		Web:       s.Web,
-	})
+	req := requests.SessionCreate{
+		UID:         s.UID,
+		DeviceUID:   s.Device.UID,
+		Username:    s.Target.Username,
+		UserID:      s.UserID,
+		Fingerprint: s.Fingerprint,
+		APIKey:      s.APIKey,
+		IPAddress:   s.IPAddress,
+		Type:        "none",
+		Term:        "none",
+		Web:         s.Web,
+	}
+
+	if s.Certificate != nil {
+		req.CertificateCAID = s.Certificate.CAID
+		req.CertificatePrincipal = s.Certificate.Principal
+	}
+
+	_, err := s.service.CreateSession(ctx, req)
 	if err != nil {
 		log.WithError(err).
 			WithFields(log.Fields{"session": s.UID, "sshid": s.SSHID}).
@@ -617,6 +656,12 @@ const keepAliveRequestPrefix = "keepalive"
 // KeepAliveRequestType is the keepalive the gateway forwards to the client.
 const KeepAliveRequestType = keepAliveRequestPrefix + "@shellhub.io"
 
+// DeniedRequestType is the request the agent sends when the device's
+// restrictions deny a login something. On a session channel it is recorded on
+// the channel's seat; on the connection, for what no channel carries, such as a
+// port forward, on a seat of its own.
+const DeniedRequestType = "denied@shellhub.io"
+
 // drainAgentRequests consumes the agent's global requests for as long as the
 // agent connection lives.
 //
@@ -635,6 +680,12 @@ func (s *Session) drainAgentRequests(ctx gliderssh.Context, reqs <-chan *gossh.R
 	defer logger.Trace("agent global requests drained")
 
 	for req := range reqs {
+		if req.Type == DeniedRequestType {
+			s.denied(req.Payload, logger)
+
+			continue
+		}
+
 		if !strings.HasPrefix(req.Type, keepAliveRequestPrefix) {
 			if req.WantReply {
 				if err := req.Reply(false, nil); err != nil {
@@ -667,6 +718,20 @@ func (s *Session) drainAgentRequests(ctx gliderssh.Context, reqs <-chan *gossh.R
 	}
 }
 
+// denied records a denial the agent reported on the connection on a seat of its own.
+func (s *Session) denied(payload []byte, logger *log.Entry) {
+	seat, err := s.NewSeat()
+	if err != nil {
+		logger.WithError(err).Warn("failed to create a seat for the denial")
+
+		return
+	}
+
+	s.Seats.SetType(seat, string(models.SessionEventTypeDenied))
+
+	Event[models.SSHDenied](s, string(models.SessionEventTypeDenied), payload, seat)
+}
+
 var ErrDialUnknown = errors.New("unknown protocol version")
 
 // Dial establishes the underlying transport to the target device. For V1
@@ -778,6 +843,17 @@ func (s *Session) IsIdentityMode() bool {
 	return s.Namespace.Settings.IsIdentityAccess()
 }
 
+// Permissions reports what the session may use beyond running a program. Only a
+// certificate login is restricted, to what its permit-* extensions grant; any
+// other login may use everything, leaving the decision to the Access Policies.
+func (s *Session) Permissions() models.SSHCertificatePermissions {
+	if s.Certificate == nil {
+		return models.SSHCertificatePermissions{PTY: true, PortForwarding: true, AgentForwarding: true, X11Forwarding: true}
+	}
+
+	return s.Certificate.Permissions
+}
+
 // consoleURL builds an absolute console URL from a path. Every path here avoids
 // /ssh/*, which the gateway routes to the ssh service instead of the console SPA.
 func consoleURL(domain string, autoSSL bool, path string) string {
@@ -51,3 +51,11 @@ var (
 	ErrAccessDenied = errors.New("access to the device has been denied")
 	ErrInvalidSSHID = errors.New("invalid sshid format")
 )
+
+var (
+	ErrShadowForbidden        = errors.New("shadowing sessions is not allowed for this role")
+	ErrShadowMode             = errors.New("the shadow mode must be read-only or read-write")
+	ErrShadowSessionNotFound  = errors.New("failed to find the session to shadow")
+	ErrShadowSessionNotActive = errors.New("the session to shadow is not active")
+	ErrShadowTokenNotFound    = errors.New("failed to find the shadow token")
+)
@@ -5,23 +5,23 @@ import (
 	"time"
 )
 
-// manager is used to store credentials for a time period.
-type manager struct {
+// manager is used to store what a token stands for, such as credentials, for a time period.
+type manager[T any] struct {
 	// ttl is the time that each credial live in the map.
 	ttl         time.Duration
 	credentials *sync.Map
 }
 
 // newManager creates a new [Manager] to store the credentials for a time period.
-func newManager(ttl time.Duration) *manager {
-	return &manager{
+func newManager[T any](ttl time.Duration) *manager[T] {
+	return &manager[T]{
 		ttl:         ttl,
 		credentials: new(sync.Map),
 	}
 }
 
 // save credentials for a time period. After this, the credentials are deleted.
-func (m *manager) save(id string, data *Credentials) {
+func (m *manager[T]) save(id string, data T) {
 	m.credentials.Store(id, data)
 
 	go time.AfterFunc(m.ttl, func() {
@@ -30,13 +30,29 @@ func (m *manager) save(id string, data *Credentials) {
 }
 
 // get gets the credentials if it time period have not ended.
-func (m *manager) get(id string) (*Credentials, bool) {
+func (m *manager[T]) get(id string) (T, bool) {
 	l, ok := m.credentials.Load(id)
 	if !ok {
-		return nil, false
+		var zero T
+
+		return zero, false
+	}
+
+	v, ok := l.(T)
+
+	return v, ok
+}
+
+// take is get for a token that can only be used once: the data is deleted as it is read.
+func (m *manager[T]) take(id string) (T, bool) {
+	l, ok := m.credentials.LoadAndDelete(id)
+	if !ok {
+		var zero T
+
+		return zero, false
 	}
 
-	v, ok := l.(*Credentials)
+	v, ok := l.(T)
 
 	return v, ok
 }
@@ -34,7 +34,7 @@ func TestManagerSave(t *testing.T) {
 		t.Run(test.description, func(t *testing.T) {
 			t.Parallel()
 
-			manager := newManager(test.waitFor)
+			manager := newManager[*Credentials](test.waitFor)
 			manager.save(test.id, nil)
 
 			assert.EventuallyWithT(t, func(tt *assert.CollectT) {
@@ -0,0 +1,279 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v5"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/server/api/pkg/gateway"
+	routesmiddleware "github.com/shellhub-io/shellhub/server/api/routes/middleware"
+	"github.com/shellhub-io/shellhub/server/api/services"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/magickey"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
+	"github.com/shellhub-io/shellhub/server/ssh/web/pkg/token"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// WebsocketShadowRoute is the WebSocket upgrade a member watches another user's seat through.
+	// Like the bridge's, it is gated by a single-use token rather than authenticated.
+	WebsocketShadowRoute = "/ws/ssh/shadow"
+	// WebShadowRoute is the authenticated POST where the member asks to shadow a session, and
+	// gets the token for the upgrade.
+	WebShadowRoute = "/ws/ssh/shadow/session"
+)
+
+// ShadowRequest is the seat a member asks to shadow, and how.
+type ShadowRequest struct {
+	// Session is the UID of an active session in the member's namespace.
+	Session string `json:"session"`
+	// Seat is the session's seat, the first one when omitted.
+	Seat int `json:"seat"`
+	// Mode is read-only when omitted.
+	Mode shadow.Mode `json:"mode"`
+}
+
+// shadowGrant is what a shadow token stands for.
+type shadowGrant struct {
+	session  string
+	seat     int
+	observer shadow.Observer
+}
+
+// newShadowBridge creates the routes through which a member shadows a seat of an active session.
+func newShadowBridge(router *echo.Echo, authn *routesmiddleware.Authenticator, service services.Service, shadows *shadow.Registry) {
+	grants := newManager[*shadowGrant](30 * time.Second)
+
+	if authn != nil {
+		authn.AllowAnonymous(http.MethodGet, WebsocketShadowRoute)
+	}
+
+	router.Add(http.MethodPost, WebShadowRoute, echo.WrapHandler(
+		http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			type Success struct {
+				Token string `json:"token"`
+			}
+
+			type Fail struct {
+				Error string `json:"error"`
+			}
+
+			response := func(res http.ResponseWriter, status int, data any) {
+				res.Header().Set("Content-Type", "application/json")
+				res.WriteHeader(status)
+
+				json.NewEncoder(res).Encode(data) //nolint: errcheck,errchkjson
+			}
+
+			// The gateway only authenticates this route, so the permission is enforced here from
+			// the identity it forwards, as closing a session is.
+			if !gateway.Allows(req.Header, authorizer.SessionShadow) {
+				response(res, http.StatusForbidden, Fail{Error: ErrShadowForbidden.Error()})
+
+				return
+			}
+
+			var request ShadowRequest
+			if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+				response(res, http.StatusBadRequest, Fail{Error: err.Error()})
+
+				return
+			}
+
+			if request.Mode == "" {
+				request.Mode = shadow.ModeReadOnly
+			}
+
+			if !request.Mode.Valid() {
+				response(res, http.StatusBadRequest, Fail{Error: ErrShadowMode.Error()})
+
+				return
+			}
+
+			sc, err := scope.NewBounded(req.Header.Get("X-Tenant-ID"))
+			if err != nil {
+				response(res, http.StatusForbidden, Fail{Error: ErrShadowForbidden.Error()})
+
+				return
+			}
+
+			sess, err := service.GetSession(req.Context(), sc, &requests.SessionGet{
+				SessionIDParam: requests.SessionIDParam{UID: request.Session},
+				ScopeTags:      gateway.DeviceTags(req.Header),
+			})
+			if err != nil {
+				response(res, http.StatusNotFound, Fail{Error: ErrShadowSessionNotFound.Error()})
+
+				return
+			}
+
+			if !sess.Active {
+				response(res, http.StatusConflict, Fail{Error: ErrShadowSessionNotActive.Error()})
+
+				return
+			}
+
+			token, err := token.NewToken(magickey.GetReference())
+			if err != nil {
+				response(res, http.StatusInternalServerError, Fail{Error: err.Error()})
+
+				return
+			}
+
+			// Who watches comes from the gateway-injected headers, never the body.
+			grants.save(token.ID, &shadowGrant{
+				session: sess.UID,
+				seat:    request.Seat,
+				observer: shadow.Observer{
+					UserID: req.Header.Get("X-ID"),
+					Name:   req.Header.Get("X-Username"),
+					Mode:   request.Mode,
+				},
+			})
+
+			response(res, http.StatusOK, Success{Token: token.ID})
+		})),
+	)
+
+	router.Add(http.MethodGet, WebsocketShadowRoute, echo.WrapHandler(websocket.Handler(func(wsconn *websocket.Conn) {
+		defer wsconn.Close() //nolint:errcheck
+
+		exit := func(wsconn *websocket.Conn, err error) {
+			log.WithError(err).Warn("web terminal shadow error")
+
+			buffer, marshalErr := json.Marshal(Message{
+				Kind: messageKindError,
+				Data: err.Error(),
+			})
+			if marshalErr != nil {
+				log.WithError(marshalErr).Error("failed to marshal error message")
+
+				return
+			}
+
+			wsconn.Write(buffer) //nolint:errcheck
+		}
+
+		id, err := getToken(wsconn.Request())
+		if err != nil {
+			exit(wsconn, ErrWebSocketGetToken)
+
+			return
+		}
+
+		// A token attaches once: it stands for control of someone else's terminal.
+		grant, ok := grants.take(id)
+		if !ok {
+			exit(wsconn, ErrShadowTokenNotFound)
+
+			return
+		}
+
+		watching, err := shadows.Attach(grant.session, grant.seat, grant.observer)
+		if err != nil {
+			exit(wsconn, err)
+
+			return
+		}
+
+		defer watching.Detach()
+
+		log.WithFields(log.Fields{
+			"session": grant.session,
+			"seat":    grant.seat,
+			"user":    grant.observer.UserID,
+			"mode":    grant.observer.Mode,
+		}).Info("session shadow started")
+
+		conn := NewConn(wsconn)
+		defer conn.Close() //nolint:errcheck
+
+		go conn.KeepAlive()
+
+		go func() {
+			// The shadow goes when its socket does, which ends the frames below.
+			defer watching.Detach()
+
+			for {
+				var message Message
+				if _, err := conn.ReadMessage(&message); err != nil {
+					return
+				}
+
+				// The seat's size is its client's to set: a shadow follows it, so only keys are taken,
+				// and only from a read-write shadow.
+				if message.Kind != messageKindInput {
+					continue
+				}
+
+				if _, err := watching.Write([]byte(message.Data.(string))); err != nil && !errors.Is(err, shadow.ErrReadOnly) {
+					return
+				}
+			}
+		}()
+
+		if err := redirShadowToWs(watching, conn); err != nil {
+			log.WithError(err).WithField("session", grant.session).Debug("session shadow write failed")
+		}
+
+		log.WithFields(log.Fields{"session": grant.session, "seat": grant.seat, "user": grant.observer.UserID}).
+			Info("session shadow done")
+	})))
+}
+
+// redirShadowToWs sends a shadow its seat's frames until it is detached: the output as binary
+// frames, and the seat's size as resize messages for the terminal to follow. A character cut
+// across outputs is held back until it is whole, as the bridge does for its own session.
+func redirShadowToWs(watching *shadow.Shadow, ws *Conn) error {
+	var pending []byte
+
+	for frame := range watching.Frames() {
+		if frame.Output == nil {
+			if _, err := ws.WriteMessage(&Message{
+				Kind: messageKindResize,
+				Data: Dimensions{Cols: frame.Cols, Rows: frame.Rows},
+			}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		var output []byte
+
+		output, pending = cutRunes(append(pending, frame.Output...))
+		if len(output) == 0 {
+			continue
+		}
+
+		if _, err := ws.WriteBinary(output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cutRunes splits p before a character cut at its end.
+func cutRunes(p []byte) ([]byte, []byte) {
+	for i := len(p) - 1; i >= 0 && i >= len(p)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(p[i]) {
+			continue
+		}
+
+		if !utf8.FullRune(p[i:]) {
+			return p[:i], append([]byte(nil), p[i:]...)
+		}
+
+		break
+	}
+
+	return p, nil
+}
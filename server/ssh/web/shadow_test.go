@@ -0,0 +1,240 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/scope"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	servicemocks "github.com/shellhub-io/shellhub/server/api/services/mocks"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// keys collects the keys a shadow sends to the device.
+type keys chan string
+
+func (k keys) Write(p []byte) (int, error) {
+	k <- string(p)
+
+	return len(p), nil
+}
+
+func TestShadowSession(t *testing.T) {
+	cases := []struct {
+		description string
+		role        string
+		body        string
+		setup       func(*servicemocks.MockService)
+		status      int
+		error       error
+	}{
+		{
+			description: "fails when the role cannot shadow sessions",
+			role:        "observer",
+			body:        `{"session": "session-uid"}`,
+			setup:       func(*servicemocks.MockService) {},
+			status:      http.StatusForbidden,
+			error:       ErrShadowForbidden,
+		},
+		{
+			description: "fails when the mode is unknown",
+			role:        "owner",
+			body:        `{"session": "session-uid", "mode": "exclusive"}`,
+			setup:       func(*servicemocks.MockService) {},
+			status:      http.StatusBadRequest,
+			error:       ErrShadowMode,
+		},
+		{
+			description: "fails when the session is not in the namespace",
+			role:        "administrator",
+			body:        `{"session": "session-uid"}`,
+			setup: func(service *servicemocks.MockService) {
+				service.
+					On("GetSession", mock.Anything, scope.MustBounded("tenant-id"), &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "session-uid"}}).
+					Return(nil, errors.New("not found")).
+					Once()
+			},
+			status: http.StatusNotFound,
+			error:  ErrShadowSessionNotFound,
+		},
+		{
+			description: "fails when the session is over",
+			role:        "owner",
+			body:        `{"session": "session-uid"}`,
+			setup: func(service *servicemocks.MockService) {
+				service.
+					On("GetSession", mock.Anything, scope.MustBounded("tenant-id"), &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "session-uid"}}).
+					Return(&models.Session{UID: "session-uid", Active: false}, nil).
+					Once()
+			},
+			status: http.StatusConflict,
+			error:  ErrShadowSessionNotActive,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			service := servicemocks.NewMockService(t)
+			tc.setup(service)
+
+			e := echo.New()
+			newShadowBridge(e, nil, service, shadow.NewRegistry())
+
+			req := httptest.NewRequest(http.MethodPost, WebShadowRoute, strings.NewReader(tc.body))
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+			assert.JSONEq(t, `{"error": "`+tc.error.Error()+`"}`, rec.Body.String())
+		})
+	}
+}
+
+func TestShadowWebsocket(t *testing.T) {
+	service := servicemocks.NewMockService(t)
+	service.
+		On("GetSession", mock.Anything, scope.MustBounded("tenant-id"), &requests.SessionGet{SessionIDParam: requests.SessionIDParam{UID: "session-uid"}}).
+		Return(&models.Session{UID: "session-uid", Active: true}, nil)
+
+	var events []bool
+
+	input := make(keys, 1)
+	registry := shadow.NewRegistry()
+	seat := registry.Register("session-uid", 0, shadow.Terminal{
+		Input:  input,
+		Record: func(_ shadow.Observer, started bool) { events = append(events, started) },
+		Cols:   80,
+		Rows:   24,
+	})
+
+	seat.Write([]byte("$ ")) //nolint:errcheck
+
+	e := echo.New()
+	newShadowBridge(e, nil, service, registry)
+
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	issue := func(t *testing.T) string {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodPost, WebShadowRoute, strings.NewReader(`{"session": "session-uid", "mode": "read-write"}`))
+		req.Header.Set("X-Role", "owner")
+		req.Header.Set("X-Tenant-ID", "tenant-id")
+		req.Header.Set("X-ID", "user-id")
+		req.Header.Set("X-Username", "alice")
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var success struct {
+			Token string `json:"token"`
+		}
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &success))
+
+		return success.Token
+	}
+
+	dial := func(t *testing.T, token string) *websocket.Conn {
+		t.Helper()
+
+		conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+WebsocketShadowRoute+"?token="+token, "", server.URL)
+		require.NoError(t, err)
+
+		return conn
+	}
+
+	token := issue(t)
+
+	t.Run("watches the seat and types into it", func(t *testing.T) {
+		conn := dial(t, token)
+
+		var resize []byte
+		require.NoError(t, websocket.Message.Receive(conn, &resize))
+		assert.JSONEq(t, `{"kind": 2, "data": {"cols": 80, "rows": 24}}`, string(resize))
+
+		var screen []byte
+		require.NoError(t, websocket.Message.Receive(conn, &screen))
+		assert.Equal(t, "$ ", string(screen))
+
+		require.NoError(t, websocket.Message.Send(conn, `{"kind": 1, "data": "ls\r"}`))
+
+		select {
+		case typed := <-input:
+			assert.Equal(t, "ls\r", typed)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the keys did not reach the device")
+		}
+
+		seat.Write([]byte("a.txt\r\n")) //nolint:errcheck
+
+		var output []byte
+		require.NoError(t, websocket.Message.Receive(conn, &output))
+		assert.Equal(t, "a.txt\r\n", string(output))
+
+		seat.Close()
+
+		// The socket ends with the seat.
+		assert.Error(t, websocket.Message.Receive(conn, &output))
+		assert.Equal(t, []bool{true, false}, events)
+	})
+
+	t.Run("fails to use the same token twice", func(t *testing.T) {
+		conn := dial(t, token)
+		defer conn.Close() //nolint:errcheck
+
+		var raw []byte
+		require.NoError(t, websocket.Message.Receive(conn, &raw))
+		assert.JSONEq(t, `{"kind": 4, "data": "`+ErrShadowTokenNotFound.Error()+`"}`, string(raw))
+	})
+}
+
+func TestCutRunes(t *testing.T) {
+	cases := []struct {
+		description string
+		input       []byte
+		head        string
+		tail        []byte
+	}{
+		{
+			description: "keeps whole characters",
+			input:       []byte("ç✓"),
+			head:        "ç✓",
+		},
+		{
+			description: "holds back a character cut at the end",
+			input:       []byte("a\xe2\x9c"),
+			head:        "a",
+			tail:        []byte("\xe2\x9c"),
+		},
+		{
+			description: "passes invalid bytes through",
+			input:       []byte{0x80, 0x81, 0x82},
+			head:        "\x80\x81\x82",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			head, tail := cutRunes(tc.input)
+			assert.Equal(t, tc.head, string(head))
+			assert.Equal(t, tc.tail, tail)
+		})
+	}
+}
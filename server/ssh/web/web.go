@@ -10,6 +10,7 @@ import (
 	routesmiddleware "github.com/shellhub-io/shellhub/server/api/routes/middleware"
 	"github.com/shellhub-io/shellhub/server/api/services"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/magickey"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/webhandoff"
 	"github.com/shellhub-io/shellhub/server/ssh/web/pkg/token"
 	log "github.com/sirupsen/logrus"
@@ -49,12 +50,15 @@ func exitLogLevel(err error) log.Level {
 	}
 }
 
-// NewSSHServerBridge creates routes into a [echo.Router] to connect a webscoket to SSH using Shell session.
+// NewSSHServerBridge creates routes into a [echo.Router] to connect a webscoket to SSH using Shell session, and to
+// shadow the seats registered in shadows.
 //
 // authn is the API's authenticator, used to declare the WebSocket upgrade as
 // reachable without a credential. It may be nil in tests.
-func NewSSHServerBridge(router *echo.Echo, authn *routesmiddleware.Authenticator, service services.Service, handoff *webhandoff.Store) {
-	manager := newManager(30 * time.Second)
+func NewSSHServerBridge(router *echo.Echo, authn *routesmiddleware.Authenticator, service services.Service, handoff *webhandoff.Store, shadows *shadow.Registry) {
+	manager := newManager[*Credentials](30 * time.Second)
+
+	newShadowBridge(router, authn, service, shadows)
 
 	// The upgrade is token-gated rather than authenticated: a browser cannot set
 	// headers on a WebSocket handshake. The token comes from WebSessionRoute
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/labstack/echo/v5"
+	"github.com/shellhub-io/shellhub/server/ssh/pkg/shadow"
 	"github.com/shellhub-io/shellhub/server/ssh/pkg/webhandoff"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -111,7 +112,7 @@ func TestNewSSHServerBridge_CredentialsNotFound(t *testing.T) {
 
 	// The token is never found, so the request fails before either dependency is
 	// reached.
-	NewSSHServerBridge(e, nil, nil, webhandoff.NewStore())
+	NewSSHServerBridge(e, nil, nil, webhandoff.NewStore(), shadow.NewRegistry())
 
 	server := httptest.NewServer(e)
 	defer server.Close()
@@ -83,7 +83,13 @@ export default function Login() {
     typeof rawState?.notice === "string" ? rawState.notice : undefined;
 
   const [searchParams] = useSearchParams();
-  const queryToken = searchParams.get("token");
+  // OpenID Connect hands the token over in the fragment, which is never sent to
+  // a server nor leaked through a Referer header. It is read once, so it can be
+  // dropped from the address bar and the history straight away.
+  const [fragmentToken] = useState(() =>
+    new URLSearchParams(location.hash.slice(1)).get("token"),
+  );
+  const queryToken = searchParams.get("token") ?? fragmentToken;
   const missingAssertions = searchParams.get("missing_assertions");
   const [tokenLoading, setTokenLoading] = useState(!!queryToken);
   const [authentication, setAuthentication] = useState<{
@@ -121,6 +127,14 @@ export default function Login() {
   useEffect(() => {
     if (!queryToken) return;
 
+    if (fragmentToken) {
+      window.history.replaceState(
+        window.history.state,
+        document.title,
+        window.location.pathname + window.location.search,
+      );
+    }
+
     const { logout, loginWithToken } = useAuthStore.getState();
     logout();
 
@@ -130,7 +144,7 @@ export default function Login() {
         setTokenLoading(false);
         setError("Failed to authenticate with the provided token.");
       });
-  }, [queryToken, navigate]);
+  }, [queryToken, fragmentToken, navigate]);
 
   const handleSsoLogin = async () => {
     setSsoLoading(true);